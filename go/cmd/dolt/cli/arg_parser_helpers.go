@@ -20,6 +20,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
@@ -55,6 +56,11 @@ const (
 	RemoveBackupShortId = "rm"
 )
 
+const (
+	CreateBundleId = "create"
+	VerifyBundleId = "verify"
+)
+
 var branchForceFlagDesc = "Reset {{.LessThan}}branchname{{.GreaterThan}} to {{.LessThan}}startpoint{{.GreaterThan}}, even if {{.LessThan}}branchname{{.GreaterThan}} exists already. Without {{.EmphasisLeft}}-f{{.EmphasisRight}}, {{.EmphasisLeft}}dolt branch{{.EmphasisRight}} refuses to change an existing branch. In combination with {{.EmphasisLeft}}-d{{.EmphasisRight}} (or {{.EmphasisLeft}}--delete{{.EmphasisRight}}), allow deleting the branch irrespective of its merged status. In combination with -m (or {{.EmphasisLeft}}--move{{.EmphasisRight}}), allow renaming the branch even if the new branch name already exists, the same applies for {{.EmphasisLeft}}-c{{.EmphasisRight}} (or {{.EmphasisLeft}}--copy{{.EmphasisRight}})."
 
 // CreateCommitArgParser creates the argparser shared dolt commit cli and DOLT_COMMIT.
@@ -80,6 +86,13 @@ func CreateConflictsResolveArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateConstraintViolationsResolveArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("constraint violations resolve")
+	ap.SupportsFlag(OursFlag, "", "For all violating rows, take the version from our branch and clear the violations")
+	ap.SupportsFlag(TheirsFlag, "", "For all violating rows, take the version from the commit that introduced the violation and clear the violations")
+	return ap
+}
+
 func CreateUpdateTagArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("update-tag", 3)
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "The name of the table"})
@@ -101,6 +114,7 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsString(StrategyParam, "s", "strategy", "Use the given merge {{.LessThan}}strategy{{.GreaterThan}} for rows changed differently on both sides of the merge. Valid values are: resolve-cell-wise (default), ours or theirs.")
 
 	return ap
 }
@@ -117,12 +131,15 @@ func CreateRebaseArgParser() *argparser.ArgParser {
 	return ap
 }
 
+const maxBandwidthHelp = "Limit the transfer of table files to and from the remote to {{.LessThan}}rate{{.GreaterThan}} bytes per second, such as {{.EmphasisLeft}}10MB{{.EmphasisRight}}."
+
 func CreatePushArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("push")
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SetUpstreamFlag, "u", "For every branch that is up to date or successfully pushed, add upstream (tracking) reference, used by argument-less {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} and other commands.")
 	ap.SupportsFlag(ForceFlag, "f", "Update the remote with local history, overwriting any conflicting history in the remote.")
 	ap.SupportsFlag(AllFlag, "", "Push all branches.")
+	ap.SupportsString(MaxBandwidthFlag, "", "rate", maxBandwidthHelp)
 	ap.SupportsFlag(SilentFlag, "", "Suppress progress information.")
 	return ap
 }
@@ -149,6 +166,14 @@ func CreateCloneArgParser() *argparser.ArgParser {
 	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use.")
 	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	ap.SupportsFlag(SingleBranchFlag, "", "Clone only the history leading to the tip of a single branch, either specified by --branch or the remote's HEAD (default).")
+	ap.SupportsFlag(NoTagsFlag, "", "Don't clone the tags of the remote database.")
+	ap.SupportsString(MaxBandwidthFlag, "", "rate", maxBandwidthHelp)
+	return ap
+}
+
+func CreateAttachRemoteArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("attach_remote", 2)
+	ap.SupportsString(UserFlag, "u", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
 	return ap
 }
 
@@ -161,6 +186,15 @@ func CreateResetArgParser() *argparser.ArgParser {
 
 func CreateRemoteArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("remote")
+	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "Cloud provider region associated with this remote.")
+	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "Credential type. Valid options are role, env, and file. See the help section for additional details.", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
+	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
+	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file")
+	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use")
+	ap.SupportsString(dbfactory.GRPCCredsParam, "", "key id", "Key id or public key of the stored credentials, as listed by `dolt creds ls`, used to authenticate with this remote instead of the default credentials.")
+	ap.SupportsString(dbfactory.GRPCTimeoutParam, "", "duration", "Time limit for each request made to this remote, such as 30s.")
+	ap.SupportsString(dbfactory.GRPCConcurrencyParam, "", "n", "Maximum number of concurrent downloads from this remote.")
 	return ap
 }
 
@@ -194,6 +228,7 @@ func CreateCherryPickArgParser() *argparser.ArgParser {
 func CreateFetchArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs("fetch")
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
+	ap.SupportsString(MaxBandwidthFlag, "", "rate", maxBandwidthHelp)
 	ap.SupportsFlag(PruneFlag, "p", "After fetching, remove any remote-tracking references that don't exist on the remote.")
 	ap.SupportsFlag(SilentFlag, "", "Suppress progress information.")
 	return ap
@@ -219,6 +254,7 @@ func CreatePullArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(NoCommitFlag, "", "Perform the merge and stop just before creating a merge commit. Note this will not prevent a fast-forward merge; use the --no-ff arg together with the --no-commit arg to prevent both fast-forwards and merge commits.")
 	ap.SupportsFlag(NoEditFlag, "", "Use an auto-generated commit message when creating a merge commit. The default for interactive CLI sessions is to open an editor.")
 	ap.SupportsString(UserFlag, "", "user", "User name to use when authenticating with the remote. Gets password from the environment variable {{.EmphasisLeft}}DOLT_REMOTE_PASSWORD{{.EmphasisRight}}.")
+	ap.SupportsString(MaxBandwidthFlag, "", "rate", maxBandwidthHelp)
 	ap.SupportsFlag(PruneFlag, "p", "After fetching, remove any remote-tracking references that don't exist on the remote.")
 	ap.SupportsFlag(SilentFlag, "", "Suppress progress information.")
 	return ap
@@ -256,6 +292,9 @@ func CreateTagArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(VerboseFlag, "v", "list tags along with their metadata.")
 	ap.SupportsFlag(DeleteFlag, "d", "Delete a tag.")
 	ap.SupportsString(AuthorParam, "", "author", "Specify an explicit author using the standard A U Thor {{.LessThan}}author@example.com{{.GreaterThan}} format.")
+	ap.SupportsFlag(AnnotateFlag, "a", "Make an annotated tag, which requires a message given with {{.EmphasisLeft}}-m{{.EmphasisRight}}.")
+	ap.SupportsOptionalString(SignFlag, "S", "key-id", "Sign the tag using GPG. If no key-id is provided the key-id is taken from 'user.signingkey' the in the configuration")
+	ap.SupportsString(RemoteParam, "", "name", "With {{.EmphasisLeft}}-d{{.EmphasisRight}}, delete the tags from the named remote instead of the local database.")
 	return ap
 }
 
@@ -273,6 +312,13 @@ func CreateBackupArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateBundleArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs("bundle")
+	ap.SupportsString(BranchParam, "b", "branch", "The branch created at the tagged commit, which is checked out when the bundle is cloned. Defaults to the init.defaultbranch config, or main.")
+	ap.SupportsFlag(RefsFlag, "", "Bundle the given branches and tags, or every branch and tag if none are given, rather than a release tag.")
+	return ap
+}
+
 func CreateVerifyConstraintsArgParser(name string) *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs(name)
 	ap.SupportsFlag(AllFlag, "a", "Verifies that all rows in the database do not violate constraints instead of just rows modified or inserted in the working set.")
@@ -290,12 +336,14 @@ func CreateLogArgParser(isTableFunction bool) *argparser.ArgParser {
 	ap.SupportsString(DecorateFlag, "", "decorate_fmt", "Shows refs next to commits. Valid options are short, full, no, and auto")
 	ap.SupportsStringList(NotFlag, "", "revision", "Excludes commits from revision.")
 	ap.SupportsFlag(ShowSignatureFlag, "", "Shows the signature of each commit.")
+	ap.SupportsFlag(ShowCommitterFlag, "", "Shows the committer of each commit, which is its author unless it was committed on their behalf.")
 	if isTableFunction {
 		ap.SupportsStringList(TablesFlag, "t", "table", "Restricts the log to commits that modified the specified tables.")
 	} else {
 		ap.SupportsFlag(OneLineFlag, "", "Shows logs in a compact format.")
 		ap.SupportsFlag(StatFlag, "", "Shows the diffstat for each commit.")
 		ap.SupportsFlag(GraphFlag, "", "Shows the commit graph.")
+		ap.SupportsString(FormatFlag, "", "format", "Exports the commit graph instead of the log. Valid options are dot and json. With --stat, each commit is annotated with the number of tables it changed.")
 	}
 	return ap
 }
@@ -305,6 +353,8 @@ func CreateGCArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(ShallowFlag, "s", "perform a fast, but incomplete garbage collection pass")
 	ap.SupportsFlag(FullFlag, "f", "perform a full garbage collection, including the old generation")
 	ap.SupportsInt(ArchiveLevelParam, "", "archive compression level", "Specify the archive compression level garbage collection results. Default is 0. Max is 2")
+	ap.SupportsFlag(DryRunFlag, "", "report how many chunks and bytes would be collected, and which table files would be rewritten, without collecting anything")
+	ap.SupportsFlag(VerboseFlag, "v", "log each phase of the garbage collection and how long it took")
 	return ap
 }
 
@@ -315,6 +365,29 @@ func CreateCountCommitsArgParser() *argparser.ArgParser {
 	return ap
 }
 
+func CreateCreateFunctionArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("dolt_create_function", 1)
+	ap.SupportsString("returns", "", "type", "the type the function returns: INT, BIGINT, FLOAT, DOUBLE or TEXT")
+	ap.SupportsString("params", "", "types", "a comma separated list of the function's parameter types")
+	ap.SupportsString("module", "", "base64", "the base64 encoded WebAssembly module implementing the function")
+	ap.SupportsString("export", "", "name", "the name of the module export to call. Defaults to the function name")
+	ap.SupportsString("language", "", "language", "the language the function is written in. Only WASM is supported")
+	ap.SupportsFlag("temporary", "", "create a function that is visible only to the current session and is not versioned")
+	return ap
+}
+
+func CreateDropFunctionArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("dolt_drop_function", 1)
+	ap.SupportsFlag("temporary", "", "drop a function created with --temporary")
+	return ap
+}
+
+func CreatePlanBaselineArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs("dolt_plan_baseline", 1)
+	ap.SupportsFlag("enforce", "", "fail queries whose plan doesn't match the baseline, rather than raising a warning")
+	return ap
+}
+
 func CreateReflogArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs("reflog", 1)
 	ap.SupportsFlag(AllFlag, "", "Show all refs, including hidden refs, such as DoltHub workspace refs")
@@ -411,6 +484,44 @@ func AddOSSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[s
 	return nil
 }
 
+// AddGRPCParams validates the remotesapi remote options of |apr|, and adds them to |params|. Options given an empty
+// value are added as empty, which callers updating a remote use to remove the option.
+func AddGRPCParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) error {
+	isGRPC := strings.HasPrefix(remoteUrl, dbfactory.HTTPScheme+"://") || strings.HasPrefix(remoteUrl, dbfactory.HTTPSScheme+"://")
+	for _, p := range dbfactory.GRPCParams {
+		val, ok := apr.GetValue(p)
+		if !ok {
+			continue
+		}
+		if !isGRPC {
+			return fmt.Errorf("%s param is only valid for http and https remotes", p)
+		}
+		if val != "" {
+			if err := validateGRPCParam(p, val); err != nil {
+				return err
+			}
+		}
+		params[p] = val
+	}
+	return nil
+}
+
+func validateGRPCParam(param, val string) error {
+	switch param {
+	case dbfactory.GRPCCredsParam:
+		if !creds.B32CredsByteSet.ContainsAll([]byte(val)) || (len(val) != creds.B32EncodedKeyIdLen && len(val) != creds.B32EncodedPubKeyLen) {
+			return fmt.Errorf("invalid %s '%s', expected the key id or public key of stored credentials", param, val)
+		}
+	case dbfactory.GRPCTimeoutParam:
+		_, err := dbfactory.ParseGRPCTimeout(val)
+		return err
+	case dbfactory.GRPCConcurrencyParam:
+		_, err := dbfactory.ParseGRPCConcurrency(val)
+		return err
+	}
+	return nil
+}
+
 func VerifyNoAwsParams(apr *argparser.ArgParseResults) error {
 	if awsParams := apr.GetValues(awsParams...); len(awsParams) > 0 {
 		awsParamKeys := make([]string, 0, len(awsParams))
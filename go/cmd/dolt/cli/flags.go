@@ -21,6 +21,7 @@ const (
 	AllFlag              = "all"
 	AllowEmptyFlag       = "allow-empty"
 	AmendFlag            = "amend"
+	AnnotateFlag         = "annotate"
 	AuthorParam          = "author"
 	ArchiveLevelParam    = "archive-level"
 	BranchParam          = "branch"
@@ -38,12 +39,14 @@ const (
 	DryRunFlag           = "dry-run"
 	EmptyParam           = "empty"
 	ForceFlag            = "force"
+	FormatFlag           = "format"
 	FullFlag             = "full"
 	GraphFlag            = "graph"
 	HardResetParam       = "hard"
 	HostFlag             = "host"
 	InteractiveFlag      = "interactive"
 	ListFlag             = "list"
+	MaxBandwidthFlag     = "max-bandwidth"
 	MergesFlag           = "merges"
 	MessageArg           = "message"
 	MinParentsFlag       = "min-parents"
@@ -52,6 +55,7 @@ const (
 	NoEditFlag           = "no-edit"
 	NoFFParam            = "no-ff"
 	NoPrettyFlag         = "no-pretty"
+	NoTagsFlag           = "no-tags"
 	NoTLSFlag            = "no-tls"
 	NoJsonMergeFlag      = "dont-merge-json"
 	NotFlag              = "not"
@@ -65,9 +69,11 @@ const (
 	PortFlag             = "port"
 	PruneFlag            = "prune"
 	QuietFlag            = "quiet"
+	RefsFlag             = "refs"
 	RemoteParam          = "remote"
 	SetUpstreamFlag      = "set-upstream"
 	ShallowFlag          = "shallow"
+	ShowCommitterFlag    = "show-committer"
 	ShowIgnoredFlag      = "ignored"
 	ShowSignatureFlag    = "show-signature"
 	SignFlag             = "gpg-sign"
@@ -78,6 +84,7 @@ const (
 	SquashParam          = "squash"
 	StagedFlag           = "staged"
 	StatFlag             = "stat"
+	StrategyParam        = "strategy"
 	SystemFlag           = "system"
 	TablesFlag           = "tables"
 	TheirsFlag           = "theirs"
@@ -24,5 +24,10 @@ var Commands = cli.NewHiddenSubCommandHandler("admin", "Commands for directly wo
 	ShowRootCmd{},
 	ZstdCmd{},
 	StorageCmd{},
+	VerifyCmd{},
+	RewriteManifestCmd{},
+	ForgetCmd{},
+	CalibrateCmd{},
+	MigrateJournalCmd{},
 	createchunk.Commands,
 })
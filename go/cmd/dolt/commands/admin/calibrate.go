@@ -0,0 +1,120 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/costmodel"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+const calibrateDryRunFlag = "dry-run"
+
+var calibrateDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Calibrates the optimizer's cost model for this machine.",
+	LongDesc: `Measures the latency of reading chunks from the storage of this database, and the costs of decoding rows and comparing keys on this machine, then stores the coefficients of the optimizer's cost model they imply in the global config, where every database on this machine uses them to choose query plans.
+
+The measurements are taken on the table of the working set with the most rows: a random sample of its leaf chunks is read from storage, and their rows are decoded and compared. Chunks read recently may be served by the caches of the operating system, so calibrate after a restart, or on a database larger than memory, to measure the latency of the storage itself.
+
+The coefficients are stored as {{.EmphasisLeft}}` + config.CostModelCPUKey + `{{.EmphasisRight}}, {{.EmphasisLeft}}` + config.CostModelSeqIOKey + `{{.EmphasisRight}}, {{.EmphasisLeft}}` + config.CostModelRandIOKey + `{{.EmphasisRight}} and {{.EmphasisLeft}}` + config.CostModelMemKey + `{{.EmphasisRight}}, relative to the cost of reading a row during a scan, and can be changed or removed with {{.EmphasisLeft}}dolt config{{.EmphasisRight}}. Without them, the default cost model is used. Running SQL servers use the new coefficients once restarted.
+
+With {{.EmphasisLeft}}--dry-run{{.EmphasisRight}}, the measurements and coefficients are printed and the config is not changed.`,
+	Synopsis: []string{
+		"[--dry-run]",
+	},
+}
+
+type CalibrateCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CalibrateCmd) Name() string {
+	return "calibrate"
+}
+
+// Description returns a description of the command
+func (cmd CalibrateCmd) Description() string {
+	return "Calibrates the optimizer's cost model for this machine."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd CalibrateCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd CalibrateCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(calibrateDocs, cmd.ArgParser())
+}
+
+func (cmd CalibrateCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsFlag(calibrateDryRunFlag, "", "Print the measurements and coefficients without storing them.")
+	return ap
+}
+
+func (cmd CalibrateCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd CalibrateCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, calibrateDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	root, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		verr := errhand.BuildDError("failed to get the working root").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	m, err := costmodel.Calibrate(ctx, dEnv.DoltDB(ctx), root)
+	if err != nil {
+		verr := errhand.BuildDError("failed to calibrate the cost model").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	c := m.Coefficients()
+
+	cli.Printf("Measured %d chunks of table %s:\n", m.Chunks, m.Table)
+	cli.Printf("  chunk read:     %v\n", m.ChunkRead)
+	cli.Printf("  rows per chunk: %.1f\n", m.RowsPerChunk)
+	cli.Printf("  row decode:     %v\n", m.Decode)
+	cli.Printf("  key comparison: %v\n", m.Compare)
+	cli.Println("Coefficients:")
+	cli.Printf("  %-20s %.4g\n", config.CostModelCPUKey, c.CPU)
+	cli.Printf("  %-20s %.4g\n", config.CostModelSeqIOKey, c.SeqIO)
+	cli.Printf("  %-20s %.4g\n", config.CostModelRandIOKey, c.RandIO)
+	cli.Printf("  %-20s %.4g\n", config.CostModelMemKey, c.Mem)
+	if apr.Contains(calibrateDryRunFlag) {
+		return 0
+	}
+
+	gcfg, ok := dEnv.Config.GetConfig(env.GlobalConfig)
+	if !ok {
+		verr := errhand.BuildDError("the global config was not found").Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if err = c.Save(gcfg); err != nil {
+		verr := errhand.BuildDError("failed to store the coefficients in the global config").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cli.Println("Stored the coefficients in the global config.")
+	return 0
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+const (
+	forgetDryRunFlag = "dry-run"
+	forgetNoGCFlag   = "no-gc"
+)
+
+var forgetDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Discards the history before a commit.",
+	LongDesc: `Rewrites the history of every branch, tag and workspace so that {{.LessThan}}commit{{.GreaterThan}} has no parents, then garbage collects the database to remove the discarded history from disk.
+
+Every branch, tag and workspace must contain {{.LessThan}}commit{{.GreaterThan}}. The commits after it are rewritten with the same data, messages, authors and dates, but get new commit hashes. Merge commits keep only the parents which descend from {{.LessThan}}commit{{.GreaterThan}}. Remote tracking branches are deleted, since the history they track is no longer in the database; the rewritten branches must be force pushed to their remotes, and fetching from a remote which still has the discarded history brings it back.
+
+Branches with a merge or rebase in progress, and stashes, reference commits in the history being discarded and must be resolved first.
+
+With {{.EmphasisLeft}}--dry-run{{.EmphasisRight}}, the refs which would be rewritten are printed and nothing is changed. With {{.EmphasisLeft}}--no-gc{{.EmphasisRight}}, the discarded history stays on disk until {{.EmphasisLeft}}dolt gc --full{{.EmphasisRight}} is run.`,
+	Synopsis: []string{
+		"[--dry-run] [--no-gc] {{.LessThan}}commit{{.GreaterThan}}",
+	},
+}
+
+type ForgetCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ForgetCmd) Name() string {
+	return "forget"
+}
+
+// Description returns a description of the command
+func (cmd ForgetCmd) Description() string {
+	return "Discards the history before a commit."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd ForgetCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd ForgetCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(forgetDocs, cmd.ArgParser())
+}
+
+func (cmd ForgetCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit which becomes the first commit of the history."})
+	ap.SupportsFlag(forgetDryRunFlag, "", "Print the refs which would be rewritten without changing anything.")
+	ap.SupportsFlag(forgetNoGCFlag, "", "Don't garbage collect the database once its history is rewritten.")
+	return ap
+}
+
+func (cmd ForgetCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd ForgetCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, forgetDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("a commit must be given").SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	base, verr := commands.MaybeGetCommitWithVErr(dEnv, apr.Arg(0))
+	if verr != nil {
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if base == nil {
+		verr = errhand.BuildDError("%s is not a valid commit", apr.Arg(0)).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	dryRun := apr.Contains(forgetDryRunFlag)
+	forgotten, err := rebase.ForgetHistory(ctx, dEnv.DoltDB(ctx), base, dryRun)
+	if err != nil {
+		verr = errhand.BuildDError("failed to discard history").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	for _, fr := range forgotten {
+		switch {
+		case fr.Deleted:
+			cli.Printf("%s  deleted\n", fr.Ref.String())
+		case dryRun:
+			cli.Printf("%s  %s\n", fr.Ref.String(), fr.Old.String())
+		default:
+			cli.Printf("%s  %s -> %s\n", fr.Ref.String(), fr.Old.String(), fr.New.String())
+		}
+	}
+	if dryRun || apr.Contains(forgetNoGCFlag) {
+		return 0
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+	_, err = commands.GetRowsForSql(queryist, sqlCtx, "CALL DOLT_GC('--full')")
+	if err != nil && err != chunks.ErrNothingToCollect {
+		verr = errhand.BuildDError("history was rewritten, but garbage collection failed; run dolt gc --full").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cli.Println("Removed the discarded history.")
+	return 0
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"os"
+	"runtime"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbcopy"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/verify"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+const (
+	migrateJournalPrepareArg    = "prepare"
+	migrateJournalVerifyArg     = "verify"
+	migrateJournalOutputFlag    = "output"
+	migrateJournalFilesOnlyFlag = "files-only"
+)
+
+var migrateJournalDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Prepares a database to be moved to another filesystem, and verifies the copy.",
+	LongDesc: `Copying the files of a database while a dolt process writes to it, or before the writes of its chunk journal are compacted, makes a copy which may be corrupt. This command prepares a database to be copied safely with rsync, scp or any other tool, and verifies the copy at its destination before it's used.
+
+{{.EmphasisLeft}}prepare{{.EmphasisRight}} takes exclusive access to the database, so that no other dolt process writes to it, and fails if a sql-server or another dolt process has it open. It then garbage collects the database, which flushes the chunk journal and compacts it into table files, and writes a manifest of the size and SHA-256 hash of every file of the .dolt directory to {{.EmphasisLeft}}.dolt/` + dbcopy.ManifestName + `{{.EmphasisRight}}. Statistics, lock files and temporary files aren't part of the copy. With {{.EmphasisLeft}}--output{{.EmphasisRight}}, a gzipped tar archive of the .dolt directory is written as well, which is extracted in an empty directory at the destination. Otherwise, copy the .dolt directory itself. Don't run dolt on the database until the copy is complete.
+
+{{.EmphasisLeft}}verify{{.EmphasisRight}} is run in the copy of the database. It checks every file of the .dolt directory against the manifest, reporting files which are missing, which changed, or which were added because the database was written to after it was prepared, then deeply verifies the integrity of the database like {{.EmphasisLeft}}dolt admin verify{{.EmphasisRight}}. With {{.EmphasisLeft}}--files-only{{.EmphasisRight}}, only the files are checked. The command exits with a non-zero status if any problem was found, in which case the database should be prepared and copied again.`,
+	Synopsis: []string{
+		"prepare [--output {{.LessThan}}file{{.GreaterThan}}]",
+		"verify [--files-only]",
+	},
+}
+
+type MigrateJournalCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd MigrateJournalCmd) Name() string {
+	return "migrate-journal"
+}
+
+// Description returns a description of the command
+func (cmd MigrateJournalCmd) Description() string {
+	return "Prepares a database to be moved to another filesystem, and verifies the copy."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd MigrateJournalCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd MigrateJournalCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(migrateJournalDocs, cmd.ArgParser())
+}
+
+func (cmd MigrateJournalCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"prepare|verify", "Prepare this database to be copied, or verify this copy of a database."})
+	ap.SupportsString(migrateJournalOutputFlag, "o", "file", "Write a gzipped tar archive of the prepared database to {{.LessThan}}file{{.GreaterThan}}.")
+	ap.SupportsFlag(migrateJournalFilesOnlyFlag, "", "Only check the files of the copy against the manifest, without verifying the integrity of the database.")
+	return ap
+}
+
+func (cmd MigrateJournalCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd MigrateJournalCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, migrateJournalDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		verr := errhand.BuildDError("one of %s or %s must be given", migrateJournalPrepareArg, migrateJournalVerifyArg).SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	dir, err := dEnv.FS.Abs(dbfactory.DoltDir)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	switch apr.Arg(0) {
+	case migrateJournalPrepareArg:
+		if apr.Contains(migrateJournalFilesOnlyFlag) {
+			verr := errhand.BuildDError("--%s is only valid with %s", migrateJournalFilesOnlyFlag, migrateJournalVerifyArg).SetPrintUsage().Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		return prepareMigration(ctx, dEnv, cliCtx, dir, apr, usage)
+	case migrateJournalVerifyArg:
+		if apr.Contains(migrateJournalOutputFlag) {
+			verr := errhand.BuildDError("--%s is only valid with %s", migrateJournalOutputFlag, migrateJournalPrepareArg).SetPrintUsage().Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		return verifyMigration(ctx, dEnv, dir, apr, usage)
+	default:
+		verr := errhand.BuildDError("unknown argument: %s", apr.Arg(0)).SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+}
+
+// prepareMigration compacts the journal of the database and writes the copy manifest of its .dolt directory |dir|,
+// and an archive of it if one was asked for. It holds exclusive access to the database until it returns.
+func prepareMigration(ctx context.Context, dEnv *env.DoltEnv, cliCtx cli.CliContext, dir string, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	if dEnv.IsAccessModeReadOnly(ctx) {
+		verr := errhand.BuildDError("the database is in use by another dolt process, such as a sql-server; stop it before preparing the database to be moved").Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+	_, err = commands.GetRowsForSql(queryist, sqlCtx, "CALL DOLT_GC()")
+	if err != nil && err != chunks.ErrNothingToCollect {
+		verr := errhand.BuildDError("failed to compact the chunk journal").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	m, err := dbcopy.WriteManifest(dir)
+	if err != nil {
+		verr := errhand.BuildDError("failed to write the copy manifest").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cli.Printf("Prepared %d files (%s) to be moved.\n", len(m.Files), humanize.Bytes(uint64(m.Size())))
+
+	output, ok := apr.GetValue(migrateJournalOutputFlag)
+	if !ok {
+		cli.Println("Copy the .dolt directory before running dolt on this database again, then run dolt admin migrate-journal verify in the copy.")
+		return 0
+	}
+	if err = writeMigrationArchive(output, dir, m); err != nil {
+		verr := errhand.BuildDError("failed to write %s", output).AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cli.Printf("Wrote %s. Extract it in an empty directory, then run dolt admin migrate-journal verify there.\n", output)
+	return 0
+}
+
+func writeMigrationArchive(output, dir string, m dbcopy.Manifest) (err error) {
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(output)
+		}
+	}()
+	if err = dbcopy.WriteArchive(f, dir, m); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// verifyMigration checks the copy of a database at the .dolt directory |dir| against its copy manifest, and then
+// verifies the integrity of the database unless only the files are to be checked.
+func verifyMigration(ctx context.Context, dEnv *env.DoltEnv, dir string, apr *argparser.ArgParseResults, usage cli.UsagePrinter) int {
+	m, problems, err := dbcopy.Verify(dir)
+	if err != nil {
+		verr := errhand.BuildDError("failed to verify the copy").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if len(problems) > 0 {
+		cli.Println(color.RedString("%d files don't match the copy manifest, prepare and copy the database again:", len(problems)))
+		for _, p := range problems {
+			cli.PrintErrln(p.Error())
+		}
+		return 1
+	}
+	cli.Printf("%d files (%s) match the copy manifest.\n", len(m.Files), humanize.Bytes(uint64(m.Size())))
+	if apr.Contains(migrateJournalFilesOnlyFlag) {
+		return 0
+	}
+
+	opts := verify.Options{Workers: runtime.NumCPU()}
+	report, err := verify.Database(ctx, dEnv.DoltDB(ctx), opts, nil)
+	if err != nil {
+		verr := errhand.BuildDError("failed to verify database").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	return printVerifyReport(report, opts)
+}
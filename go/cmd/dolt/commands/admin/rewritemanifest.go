@@ -0,0 +1,200 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/nbs"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	rewriteManifestRootFlag   = "root"
+	rewriteManifestScanFlag   = "scan-table-files"
+	rewriteManifestDryRunFlag = "dry-run"
+)
+
+var rewriteManifestDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Rebuilds the manifest of a database from the storage files on disk.",
+	LongDesc: `Recovers a database whose manifest is missing or corrupt by writing a new manifest which references every readable table file, archive and chunk journal in {{.EmphasisLeft}}.dolt/noms{{.EmphasisRight}}. The existing manifest is kept next to the new one, with a {{.EmphasisLeft}}.corrupt.{{.EmphasisRight}} suffix.
+
+The manifest also holds the root of the database, from which all of its branches, tags and working sets are reachable. If the database has a chunk journal, its root is the last root written to the journal. Otherwise, every chunk of the table files is read to find candidate roots, and if more than one is found, one must be chosen with {{.EmphasisLeft}}--root{{.EmphasisRight}}. {{.EmphasisLeft}}--scan-table-files{{.EmphasisRight}} lists the roots in the table files of a database with a chunk journal as well.
+
+The table files and candidate roots which were found are printed, followed by the refs of the recovered root once the manifest is written. With {{.EmphasisLeft}}--dry-run{{.EmphasisRight}}, nothing is written.
+
+Table files in {{.EmphasisLeft}}.dolt/noms/oldgen{{.EmphasisRight}} are referenced by their own manifest and are not affected.`,
+	Synopsis: []string{
+		"[--root {{.LessThan}}hash{{.GreaterThan}}] [--scan-table-files] [--dry-run]",
+	},
+}
+
+type RewriteManifestCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RewriteManifestCmd) Name() string {
+	return "rewrite-manifest"
+}
+
+// Description returns a description of the command
+func (cmd RewriteManifestCmd) Description() string {
+	return "Rebuilds the manifest of a database from the storage files on disk."
+}
+
+// RequiresRepo returns false, since a database with a corrupt manifest can't be loaded. The existence of the
+// database's storage directory is checked by the command itself.
+func (cmd RewriteManifestCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd RewriteManifestCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(rewriteManifestDocs, cmd.ArgParser())
+}
+
+func (cmd RewriteManifestCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsString(rewriteManifestRootFlag, "", "hash", "The root the new manifest points to, one of the candidate roots found in the table files.")
+	ap.SupportsFlag(rewriteManifestScanFlag, "", "Read every chunk of the table files to find candidate roots, even if the database has a chunk journal.")
+	ap.SupportsFlag(rewriteManifestDryRunFlag, "", "Print the table files and candidate roots which were found without writing a new manifest.")
+	return ap
+}
+
+func (cmd RewriteManifestCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd RewriteManifestCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, rewriteManifestDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if !dEnv.HasDoltDataDir() {
+		verr := errhand.BuildDError("The current directory is not a valid dolt repository.").Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	var root hash.Hash
+	if rootStr, ok := apr.GetValue(rewriteManifestRootFlag); ok {
+		root, ok = hash.MaybeParse(rootStr)
+		if !ok {
+			verr := errhand.BuildDError("invalid root hash: %s", rootStr).SetPrintUsage().Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+	}
+
+	dir, err := dEnv.FS.Abs(dbfactory.DoltDataDir)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if dEnv.DBLoadError == nil {
+		// A database whose manifest is missing can still be loaded, so release its lock on the storage directory
+		if ddb := dEnv.DoltDB(ctx); ddb != nil {
+			if err := ddb.Close(); err != nil {
+				return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+			}
+			_ = dbfactory.DeleteFromSingletonCache(filepath.ToSlash(dir))
+		}
+	}
+
+	recovery, err := nbs.RecoverManifest(ctx, dir, apr.Contains(rewriteManifestScanFlag))
+	if err != nil {
+		verr := errhand.BuildDError("failed to read the storage files of the database").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	printManifestRecovery(recovery)
+
+	if apr.Contains(rewriteManifestDryRunFlag) {
+		return 0
+	}
+
+	backup, err := recovery.RewriteManifest(ctx, types.Format_Default.VersionString(), root)
+	if err != nil {
+		verr := errhand.BuildDError("failed to rewrite manifest").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	if backup != "" {
+		cli.Printf("Moved the previous manifest to %s\n", backup)
+	}
+	cli.Println("Wrote a new manifest.")
+
+	return printRecoveredRefs(ctx, dEnv, usage)
+}
+
+func printManifestRecovery(recovery *nbs.ManifestRecovery) {
+	cli.Printf("Table files found: %d\n", len(recovery.TableFiles))
+	for _, tf := range recovery.TableFiles {
+		kind := ""
+		if tf.Journal {
+			kind = " (chunk journal)"
+		} else if tf.Archive {
+			kind = " (archive)"
+		}
+		cli.Printf("  %s  %d chunks%s\n", tf.Name.String(), tf.ChunkCount, kind)
+	}
+	for _, uf := range recovery.Unreadable {
+		cli.PrintErrln(color.YellowString("  skipping unreadable file %s: %s", uf.Name, uf.Err.Error()))
+	}
+
+	cli.Printf("Candidate roots found: %d\n", len(recovery.Roots))
+	for _, r := range recovery.Roots {
+		if r.Journal {
+			if r.Timestamp != nil {
+				cli.Printf("  %s  last root of the chunk journal, written %s\n", r.Root.String(), r.Timestamp.Format(time.RFC3339))
+			} else {
+				cli.Printf("  %s  last root of the chunk journal\n", r.Root.String())
+			}
+			continue
+		}
+		cli.Printf("  %s  found in table file %s\n", r.Root.String(), r.Source.String())
+	}
+}
+
+// printRecoveredRefs loads the database with its new manifest and prints the refs of its root.
+func printRecoveredRefs(ctx context.Context, dEnv *env.DoltEnv, usage cli.UsagePrinter) int {
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, dEnv.UrlStr(), dEnv.FS)
+	if err != nil {
+		verr := errhand.BuildDError("failed to load the database with its new manifest").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	dss, err := doltdb.HackDatasDatabaseFromDoltDB(ddb).Datasets(ctx)
+	if err != nil {
+		verr := errhand.BuildDError("failed to get database datasets").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	cli.Println("Recovered refs:")
+	err = dss.IterAll(ctx, func(key string, addr hash.Hash) error {
+		cli.Printf("  %-60s%s\n", key, addr.String())
+		return nil
+	})
+	if err != nil {
+		verr := errhand.BuildDError("failed to iterate all ref entries").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	return 0
+}
@@ -18,12 +18,19 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/dustin/go-humanize"
+
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/tablesize"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
+const storageAnalyzeArg = "analyze"
+
 type StorageCmd struct {
 }
 
@@ -35,7 +42,19 @@ func (s StorageCmd) Description() string {
 	return "print storage information for the current database"
 }
 
-func (s StorageCmd) Exec(ctx context.Context, _ string, _ []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+func (s StorageCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := s.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, storageDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 1 {
+		if apr.Arg(0) != storageAnalyzeArg {
+			verr := errhand.BuildDError("unknown argument: %s", apr.Arg(0)).SetPrintUsage().Build()
+			return commands.HandleVErrAndExitCode(verr, usage)
+		}
+		return analyzeStorage(ctx, dEnv, usage)
+	}
+
 	abs, err := dEnv.FS.Abs("")
 	if err != nil {
 		cli.Println(fmt.Sprintf("Couldn't get absolute path: %v", err))
@@ -50,20 +69,55 @@ func (s StorageCmd) Exec(ctx context.Context, _ string, _ []string, dEnv *env.Do
 	return 0
 }
 
+var storageDocs = cli.CommandDocumentationContent{
+	ShortDesc: "print storage information for the current database",
+	LongDesc: `Admin command to get some basic insights into the storage files in this database.
+
+With {{.EmphasisLeft}}analyze{{.EmphasisRight}}, the storage referenced by each table is broken down by index. Storage is attributed to the row data and secondary indexes of the working set of the current branch, to their history retained by the commits of every branch, tag and remote and by the working sets of other branches, and to their versions which are only found in the reflog, which {{.EmphasisLeft}}dolt gc{{.EmphasisRight}} can reclaim. Each chunk is attributed once, to the first table found to reference it, and sizes are of the uncompressed chunks. The whole history of the database is read. The same breakdown is available in SQL from the {{.EmphasisLeft}}dolt_table_sizes{{.EmphasisRight}} system table.`,
+	Synopsis: []string{
+		"",
+		"analyze",
+	},
+}
+
 func (s StorageCmd) Docs() *cli.CommandDocumentation {
-	return &cli.CommandDocumentation{
-		CommandStr: "storage",
-		ShortDesc:  "print storage information for the current database",
-		LongDesc:   `Admin command to get some basic insights into the storage files in this database`,
-		Synopsis: []string{
-			"storage",
-		},
-		ArgParser: s.ArgParser(),
-	}
+	return cli.NewCommandDocumentation(storageDocs, s.ArgParser())
 }
 
 func (s StorageCmd) ArgParser() *argparser.ArgParser {
-	return argparser.NewArgParserWithMaxArgs(s.Name(), 0)
+	return argparser.NewArgParserWithMaxArgs(s.Name(), 1)
+}
+
+// analyzeStorage prints the storage attributed to each index of each table of the working set of the current branch.
+func analyzeStorage(ctx context.Context, dEnv *env.DoltEnv, usage cli.UsagePrinter) int {
+	root, err := dEnv.WorkingRoot(ctx)
+	if err != nil {
+		verr := errhand.BuildDError("failed to get the working root").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+	sizes, err := tablesize.Analyze(ctx, dEnv.DoltDB(ctx), root)
+	if err != nil {
+		verr := errhand.BuildDError("failed to analyze storage").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	tableWidth, indexWidth := len("Table"), len("Index")
+	for _, sz := range sizes {
+		tableWidth = max(tableWidth, len(sz.Table))
+		indexWidth = max(indexWidth, len(sz.Index))
+	}
+	format := fmt.Sprintf("%%-%ds  %%-%ds  %%12s  %%12s  %%12s\n", tableWidth, indexWidth)
+	cli.Printf(format, "Table", "Index", "Current", "History", "Reclaimable")
+
+	var total tablesize.Size
+	for _, sz := range sizes {
+		cli.Printf(format, sz.Table, sz.Index, humanize.Bytes(sz.Current.Bytes), humanize.Bytes(sz.History.Bytes), humanize.Bytes(sz.Reclaimable.Bytes))
+		total.Current.Bytes += sz.Current.Bytes
+		total.History.Bytes += sz.History.Bytes
+		total.Reclaimable.Bytes += sz.Reclaimable.Bytes
+	}
+	cli.Printf(format, "Total", "", humanize.Bytes(total.Current.Bytes), humanize.Bytes(total.History.Bytes), humanize.Bytes(total.Reclaimable.Bytes))
+	return 0
 }
 
 var _ cli.Command = StorageCmd{}
@@ -0,0 +1,148 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admin
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/fatih/color"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/verify"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	verifyHistoryFlag    = "history"
+	verifyWorkersFlag    = "workers"
+	verifySkipChunksFlag = "skip-chunks"
+)
+
+var verifyDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Deeply verifies the integrity of the database.",
+	LongDesc: `Verifies the content hash of every chunk in the database, then walks every branch, tag, remote tracking branch and workspace, along with the working and staged roots of every branch, and checks every table they contain:
+
+  - the prolly trees of the table's rows and secondary indexes are correctly ordered and their row counts are correct
+  - every secondary index has exactly one entry for each row of the table
+  - every row satisfies the foreign keys of its table
+
+Tables shared between refs are only verified once. With {{.EmphasisLeft}}--history{{.EmphasisRight}}, the tables of every commit reachable from a ref are verified as well.
+
+A summary is printed when verification completes, and the command exits with a non-zero status if any problem was found.`,
+	Synopsis: []string{
+		"[--history] [--workers {{.LessThan}}n{{.GreaterThan}}] [--skip-chunks] [--quiet]",
+	},
+}
+
+type VerifyCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd VerifyCmd) Name() string {
+	return "verify"
+}
+
+// Description returns a description of the command
+func (cmd VerifyCmd) Description() string {
+	return "Deeply verifies the integrity of the database."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd VerifyCmd) RequiresRepo() bool {
+	return true
+}
+
+func (cmd VerifyCmd) Docs() *cli.CommandDocumentation {
+	return cli.NewCommandDocumentation(verifyDocs, cmd.ArgParser())
+}
+
+func (cmd VerifyCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap.SupportsFlag(verifyHistoryFlag, "", "Verify the tables of every commit reachable from a ref, not just the commits refs point to.")
+	ap.SupportsInt(verifyWorkersFlag, "", "n", "Number of tables verified at the same time. Defaults to the number of CPUs.")
+	ap.SupportsFlag(verifySkipChunksFlag, "", "Don't verify the content hash of every chunk.")
+	ap.SupportsFlag(cli.QuietFlag, "", "Don't show progress. Just print final report.")
+	return ap
+}
+
+func (cmd VerifyCmd) Hidden() bool {
+	return true
+}
+
+// Exec executes the command
+func (cmd VerifyCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, _ cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, verifyDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	opts := verify.Options{
+		Workers:    apr.GetIntOrDefault(verifyWorkersFlag, runtime.NumCPU()),
+		History:    apr.Contains(verifyHistoryFlag),
+		SkipChunks: apr.Contains(verifySkipChunksFlag),
+	}
+	if opts.Workers < 1 {
+		verr := errhand.BuildDError("--%s must be at least 1", verifyWorkersFlag).SetPrintUsage().Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	quiet := apr.Contains(cli.QuietFlag)
+	progress := make(chan string, 32)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for item := range progress {
+			if !quiet {
+				cli.Println(item)
+			}
+		}
+	}()
+
+	report, err := verify.Database(ctx, dEnv.DoltDB(ctx), opts, progress)
+	close(progress)
+	<-done
+	if err != nil {
+		verr := errhand.BuildDError("failed to verify database").AddCause(err).Build()
+		return commands.HandleVErrAndExitCode(verr, usage)
+	}
+
+	return printVerifyReport(report, opts)
+}
+
+func printVerifyReport(report *verify.Report, opts verify.Options) int {
+	if !opts.SkipChunks {
+		cli.Printf("Chunks verified:       %d\n", report.Chunks)
+	}
+	cli.Printf("Refs verified:         %d\n", report.Refs)
+	cli.Printf("Roots verified:        %d\n", report.Roots)
+	cli.Printf("Tables verified:       %d\n", report.Tables)
+	cli.Printf("Rows verified:         %d\n", report.Rows)
+	cli.Printf("Indexes verified:      %d\n", report.Indexes)
+	cli.Printf("Foreign keys verified: %d\n", report.ForeignKeys)
+
+	if len(report.Problems) == 0 {
+		cli.Println("No problems found.")
+		return 0
+	}
+
+	cli.Println(color.RedString("%d problems found:", len(report.Problems)))
+	for _, p := range report.Problems {
+		cli.PrintErrln(p.Error())
+	}
+	return 1
+}
@@ -131,9 +131,9 @@ func (cmd BranchCmd) Exec(ctx context.Context, commandStr string, args []string,
 	case apr.Contains(cli.CopyFlag):
 		return copyBranch(sqlCtx, queryEngine, apr, args, usage)
 	case apr.Contains(cli.DeleteFlag):
-		return deleteBranches(sqlCtx, queryEngine, apr, args, usage)
+		return deleteBranches(sqlCtx, queryEngine, dEnv, apr, args, usage)
 	case apr.Contains(cli.DeleteForceFlag):
-		return deleteBranches(sqlCtx, queryEngine, apr, args, usage)
+		return deleteBranches(sqlCtx, queryEngine, dEnv, apr, args, usage)
 	case apr.Contains(cli.ListFlag):
 		return printBranches(sqlCtx, queryEngine, apr, usage)
 	case apr.Contains(showCurrentFlag):
@@ -451,7 +451,7 @@ func copyBranch(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparser.Ar
 	return callStoredProcedure(sqlCtx, queryEngine, args)
 }
 
-func deleteBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparser.ArgParseResults, args []string, usage cli.UsagePrinter) int {
+func deleteBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, dEnv *env.DoltEnv, apr *argparser.ArgParseResults, args []string, usage cli.UsagePrinter) int {
 	if apr.NArg() == 0 {
 		usage()
 		return 1
@@ -467,9 +467,30 @@ func deleteBranches(sqlCtx *sql.Context, queryEngine cli.Queryist, apr *argparse
 		return 1
 	}
 
+	for _, branchName := range apr.Args {
+		if err := checkBranchNotInWorktree(dEnv, branchName); err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(fmt.Errorf("error: cannot delete branch: %w", err)), nil)
+		}
+	}
+
 	return callStoredProcedure(sqlCtx, queryEngine, args)
 }
 
+// checkBranchNotInWorktree returns an error if |branchName| is checked out in another worktree of the repository
+// backing |dEnv|. Worktrees are local to a directory, so there's nothing to check without a valid environment.
+func checkBranchNotInWorktree(dEnv *env.DoltEnv, branchName string) error {
+	if !dEnv.Valid() {
+		return nil
+	}
+	dir, ok, err := dEnv.WorktreeForBranch(branchName)
+	if err != nil {
+		return err
+	} else if ok {
+		return fmt.Errorf("%w: '%s' is checked out at '%s'", env.ErrWorktreeBranchCheckedOut, branchName, dir)
+	}
+	return nil
+}
+
 func generateForceDeleteMessage(args []string) string {
 	newArgs := ""
 	for _, arg := range args {
@@ -0,0 +1,270 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var bundleDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Create and verify release bundles",
+	LongDesc: `A bundle is a single file holding a set of branches and tags and their history, which can be copied anywhere and cloned or fetched from without access to the database it was created from, such as to move a database to a machine without network access.
+
+{{.EmphasisLeft}}create{{.EmphasisRight}}
+Creates a bundle of the tag {{.LessThan}}tag{{.GreaterThan}} in the file {{.LessThan}}file{{.GreaterThan}}, which defaults to {{.LessThan}}tag{{.GreaterThan}}.bundle. The bundle also has a branch at the tagged commit, which is checked out when the bundle is cloned. The SHA-256 hash of the bundle is printed, so that it can be published with the bundle.
+
+With {{.EmphasisLeft}}--refs{{.EmphasisRight}}, creates a bundle of the branches and tags named by {{.LessThan}}ref{{.GreaterThan}} in the file {{.LessThan}}file{{.GreaterThan}}, or of every branch and tag if no refs are given.
+
+{{.EmphasisLeft}}verify{{.EmphasisRight}}
+Verifies the bundle in {{.LessThan}}file{{.GreaterThan}}, checking every file it holds against the hashes of its manifest and that its refs point at the commits and tags it names, and prints its refs and SHA-256 hash.
+
+Bundles are read with urls of the form {{.EmphasisLeft}}bundle://path{{.EmphasisRight}}, as in {{.EmphasisLeft}}dolt clone bundle://./v1.0.bundle mydb{{.EmphasisRight}} or {{.EmphasisLeft}}dolt fetch bundle://./v1.0.bundle{{.EmphasisRight}}. They are verified before they are read.`,
+
+	Synopsis: []string{
+		"create [-b {{.LessThan}}branch{{.GreaterThan}}] {{.LessThan}}tag{{.GreaterThan}} [{{.LessThan}}file{{.GreaterThan}}]",
+		"create --refs {{.LessThan}}file{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}...]",
+		"verify {{.LessThan}}file{{.GreaterThan}}",
+	},
+}
+
+type BundleCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BundleCmd) Name() string {
+	return "bundle"
+}
+
+// Description returns a description of the command
+func (cmd BundleCmd) Description() string {
+	return "Create and verify single file bundles of branches and tags."
+}
+
+func (cmd BundleCmd) RequiresRepo() bool {
+	return false
+}
+
+func (cmd BundleCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(bundleDocs, ap)
+}
+
+func (cmd BundleCmd) ArgParser() *argparser.ArgParser {
+	return cli.CreateBundleArgParser()
+}
+
+// EventType returns the type of the event to log
+func (cmd BundleCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TAG
+}
+
+// Exec executes the command
+func (cmd BundleCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, bundleDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	var verr errhand.VerboseError
+	switch {
+	case apr.NArg() > 0 && apr.Arg(0) == cli.CreateBundleId:
+		if !cli.CheckEnvIsValid(dEnv) {
+			return 2
+		}
+		verr = createBundle(ctx, dEnv, apr)
+	case apr.NArg() > 0 && apr.Arg(0) == cli.VerifyBundleId:
+		verr = verifyBundle(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func createBundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	var path string
+	var create func(ctx context.Context, tmpDir string, w io.Writer, statsCh chan pull.Stats) (bundle.Manifest, error)
+	if apr.Contains(cli.RefsFlag) {
+		if apr.NArg() < 2 {
+			return errhand.BuildDError("").SetPrintUsage().Build()
+		}
+		path = apr.Arg(1)
+		refNames := apr.Args[2:]
+		create = func(ctx context.Context, tmpDir string, w io.Writer, statsCh chan pull.Stats) (bundle.Manifest, error) {
+			return actions.CreateRefsBundle(ctx, dEnv.DoltDB(ctx), tmpDir, refNames, w, statsCh)
+		}
+	} else {
+		if apr.NArg() < 2 || apr.NArg() > 3 {
+			return errhand.BuildDError("").SetPrintUsage().Build()
+		}
+		tagName := apr.Arg(1)
+		path = strings.ReplaceAll(tagName, "/", "-") + ".bundle"
+		if apr.NArg() == 3 {
+			path = apr.Arg(2)
+		}
+		branchName := apr.GetValueOrDefault(cli.BranchParam, env.GetDefaultInitBranch(dEnv.Config))
+		if !ref.IsValidBranchName(branchName) {
+			return errhand.BuildDError("error: '%s' is not a valid branch name.", branchName).Build()
+		}
+		create = func(ctx context.Context, tmpDir string, w io.Writer, statsCh chan pull.Stats) (bundle.Manifest, error) {
+			m, err := actions.CreateBundle(ctx, dEnv.DoltDB(ctx), tmpDir, tagName, branchName, w, statsCh)
+			if err == doltdb.ErrTagNotFound {
+				return m, fmt.Errorf("tag '%s' not found", tagName)
+			}
+			return m, err
+		}
+	}
+
+	tmpDir, err := dEnv.TempTableFilesDir()
+	if err != nil {
+		return errhand.BuildDError("error: ").AddCause(err).Build()
+	}
+	f, err := dEnv.FS.OpenForWrite(path, os.ModePerm)
+	if err != nil {
+		return errhand.BuildDError("error: unable to create bundle file '%s'.", path).AddCause(err).Build()
+	}
+	h := sha256.New()
+
+	newCtx, cancelFunc := context.WithCancel(ctx)
+	wg, statsCh := buildProgStarter(defaultLanguage)(newCtx)
+	m, err := create(ctx, tmpDir, io.MultiWriter(f, h), statsCh)
+	stopProgFuncs(cancelFunc, wg, statsCh)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = dEnv.FS.DeleteFile(path)
+		return errhand.BuildDError("error: unable to create bundle.").AddCause(err).Build()
+	}
+
+	if m.Tag != "" {
+		cli.Printf("Created bundle %s of tag %s at commit %s\n", path, m.Tag, m.Commit)
+	} else {
+		cli.Printf("Created bundle %s of %d refs\n", path, len(m.Refs))
+	}
+	cli.Printf("sha256: %s\n", hex.EncodeToString(h.Sum(nil)))
+	return nil
+}
+
+func verifyBundle(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+	path := apr.Arg(1)
+	f, err := dEnv.FS.OpenForRead(path)
+	if err != nil {
+		return errhand.BuildDError("error: unable to open bundle file '%s'.", path).AddCause(err).Build()
+	}
+	defer f.Close()
+
+	dir, err := os.MkdirTemp("", "dolt-bundle")
+	if err != nil {
+		return errhand.BuildDError("error: ").AddCause(err).Build()
+	}
+	defer os.RemoveAll(dir)
+
+	// The hash covers the whole file, including what follows the last entry of the bundle
+	h := sha256.New()
+	r := io.TeeReader(f, h)
+	m, err := bundle.Extract(r, dir)
+	if err == nil {
+		_, err = io.Copy(io.Discard, r)
+	}
+	if err != nil {
+		return errhand.BuildDError("error: bundle '%s' is invalid.", path).AddCause(err).Build()
+	}
+	if verr := verifyBundleRefs(ctx, dir, m); verr != nil {
+		return verr
+	}
+
+	cli.Printf("bundle %s is valid\n", path)
+	if m.Tag != "" {
+		cli.Printf("tag:     %s\n", m.Tag)
+		cli.Printf("commit:  %s\n", m.Commit)
+		cli.Printf("branch:  %s\n", m.Branch)
+	}
+	for _, r := range m.Refs {
+		cli.Printf("ref:     %s %s\n", r.Hash, r.Name)
+	}
+	if m.Tagger != "" {
+		cli.Printf("tagger:  %s\n", m.Tagger)
+	}
+	if m.Message != "" {
+		cli.Printf("message: %s\n", m.Message)
+	}
+	if m.Signature != "" {
+		cli.Println("signed:  yes")
+	}
+	cli.Printf("sha256:  %s\n", hex.EncodeToString(h.Sum(nil)))
+	return nil
+}
+
+// verifyBundleRefs checks that the refs of the store of a bundle extracted to |dir| are those of its manifest |m|, and
+// that the tag of a release bundle points at the commit of its manifest.
+func verifyBundleRefs(ctx context.Context, dir string, m bundle.Manifest) errhand.VerboseError {
+	ddb, err := doltdb.LoadDoltDB(ctx, types.Format_Default, dbfactory.FileScheme+"://"+filepath.ToSlash(dir), filesys.LocalFS)
+	if err != nil {
+		return errhand.BuildDError("error: unable to read bundle.").AddCause(err).Build()
+	}
+	defer dbfactory.DeleteFromSingletonCache(filepath.ToSlash(dir))
+	defer ddb.Close()
+
+	refs, err := ddb.GetRefsWithHashes(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: unable to read bundle.").AddCause(err).Build()
+	}
+	refHashes := make(map[string]string, len(refs))
+	for _, r := range refs {
+		refHashes[r.Ref.String()] = r.Hash.String()
+	}
+	if err = m.VerifyRefs(refHashes); err != nil {
+		return errhand.BuildDError("error: bundle is invalid.").AddCause(err).Build()
+	}
+	if m.Tag == "" {
+		return nil
+	}
+
+	tag, err := ddb.ResolveTag(ctx, ref.NewTagRef(m.Tag))
+	if err != nil {
+		return errhand.BuildDError("error: bundle tag '%s' is invalid.", m.Tag).AddCause(err).Build()
+	}
+	h, err := tag.Commit.HashOf()
+	if err != nil {
+		return errhand.BuildDError("error: bundle tag '%s' is invalid.", m.Tag).AddCause(err).Build()
+	}
+	if h.String() != m.Commit {
+		return errhand.BuildDError("error: bundle tag '%s' is at commit %s, not %s.", m.Tag, h.String(), m.Commit).Build()
+	}
+	return nil
+}
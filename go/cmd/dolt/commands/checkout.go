@@ -46,17 +46,17 @@ dolt checkout {{.LessThan}}branch{{.GreaterThan}}
    tree are kept, so that they can be committed to the {{.LessThan}}branch{{.GreaterThan}}.
 
 dolt checkout {{.LessThan}}commit{{.GreaterThan}} [--] {{.LessThan}}table{{.GreaterThan}}...
-	 Specifying table names after a commit reference (branch, commit hash, tag, etc.) updates the working set to match that commit for one or more tables, but keeps the current branch. Local modifications to the tables named will be overwritten by their versions in the commit named.
+	 Specifying table names after a commit reference (branch, commit hash, tag, etc.) updates the working set to match that commit for one or more tables, but keeps the current branch. Local modifications to the tables named will be overwritten by their versions in the commit named, and the tables named are staged. Changes to other tables are left unstaged.
 
 dolt checkout -b {{.LessThan}}new_branch{{.GreaterThan}} [{{.LessThan}}start_point{{.GreaterThan}}]
    Specifying -b causes a new branch to be created as if dolt branch were called and then checked out.
 
-dolt checkout {{.LessThan}}table{{.GreaterThan}}...
-  To update table(s) with their values in HEAD `,
+dolt checkout [--] {{.LessThan}}table{{.GreaterThan}}...
+  To update table(s) with their values in HEAD. Use -- to check out tables whose names are also the names of branches.`,
 	Synopsis: []string{
 		`{{.LessThan}}branch{{.GreaterThan}}`,
 		`{{.LessThan}}commit{{.GreaterThan}} [--] {{.LessThan}}table{{.GreaterThan}}...`,
-		`{{.LessThan}}table{{.GreaterThan}}...`,
+		`[--] {{.LessThan}}table{{.GreaterThan}}...`,
 		`-b {{.LessThan}}new-branch{{.GreaterThan}} [{{.LessThan}}start-point{{.GreaterThan}}]`,
 		`--track {{.LessThan}}remote{{.GreaterThan}}/{{.LessThan}}branch{{.GreaterThan}}`,
 	},
@@ -140,6 +140,12 @@ func (cmd CheckoutCmd) Exec(ctx context.Context, commandStr string, args []strin
 		branchName = apr.Arg(0)
 	}
 
+	if branchName != "" {
+		if err := checkBranchNotInWorktree(dEnv, branchName); err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+	}
+
 	sqlQuery, err := generateCheckoutSql(args)
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
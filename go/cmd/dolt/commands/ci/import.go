@@ -19,7 +19,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/fatih/color"
 
@@ -28,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/dolt_ci"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 )
 
@@ -135,9 +135,8 @@ func (cmd ImportCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	err = wm.StoreAndCommit(sqlCtx, db, workflowConfig)
 	if err != nil {
-		errorText := err.Error()
 		switch {
-		case strings.Contains("nothing to commit", errorText):
+		case dprocedures.HasErrorCode(err, dprocedures.ErrCodeNothingToCommit):
 			cli.Println(color.CyanString(fmt.Sprintf("Dolt CI Workflow '%s' up to date.", workflowConfig.Name.Value)))
 			return 0
 		default:
@@ -130,7 +130,7 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 
 	var r env.Remote
 	var srcDB *doltdb.DoltDB
-	r, srcDB, verr = createRemote(ctx, remoteName, remoteUrl, params, dEnv)
+	r, srcDB, verr = createRemote(ctx, remoteName, remoteUrl, params, apr.GetValueOrDefault(cli.MaxBandwidthFlag, ""), dEnv)
 	if verr != nil {
 		return verr
 	}
@@ -149,7 +149,7 @@ func clone(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.DoltEn
 	// Nil out the old Dolt env so we don't accidentally operate on the wrong database
 	dEnv = nil
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, depth, clonedEnv)
+	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, singleBranch, apr.Contains(cli.NoTagsFlag), depth, clonedEnv)
 	if err != nil {
 		// If we're cloning into a directory that already exists do not erase it. Otherwise
 		// make best effort to delete the directory we created.
@@ -207,10 +207,18 @@ func parseArgs(apr *argparser.ArgParseResults) (string, string, errhand.VerboseE
 	return dir, urlStr, nil
 }
 
-func createRemote(ctx context.Context, remoteName, remoteUrl string, params map[string]string, dEnv *env.DoltEnv) (env.Remote, *doltdb.DoltDB, errhand.VerboseError) {
+func createRemote(ctx context.Context, remoteName, remoteUrl string, params map[string]string, maxBandwidth string, dEnv *env.DoltEnv) (env.Remote, *doltdb.DoltDB, errhand.VerboseError) {
+	r := env.NewRemote(remoteName, remoteUrl, params)
+	if maxBandwidth != "" {
+		var err error
+		r, err = r.WithMaxBandwidth(maxBandwidth)
+		if err != nil {
+			return env.NoRemote, nil, errhand.VerboseErrorFromError(err)
+		}
+	}
+
 	cli.Printf("cloning %s\n", remoteUrl)
 
-	r := env.NewRemote(remoteName, remoteUrl, params)
 	ddb, err := r.GetRemoteDB(ctx, types.Format_Default, dEnv)
 	if err != nil {
 		bdr := errhand.BuildDError("error: failed to get remote db").AddCause(err)
@@ -16,6 +16,7 @@ package cnfcmds
 
 import (
 	"context"
+	"os"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -34,15 +35,21 @@ var resDocumentation = cli.CommandDocumentationContent{
 	When a merge finds conflicting changes, it documents them in the dolt_conflicts table. A conflict is between two versions: ours (the rows at the destination branch head) and theirs (the rows at the source branch head).
 
 	dolt conflicts resolve will automatically resolve the conflicts by taking either the ours or theirs versions for each row.
+
+	With {{.EmphasisLeft}}--script{{.EmphasisRight}}, the SQL statements of a script file are run in order to resolve conflicts. Statements resolve conflicts by updating the our_ columns of the dolt_conflicts_{{.LessThan}}table{{.GreaterThan}} tables, which updates the conflicting rows, and by deleting the conflicts they have resolved. Conflicts the script leaves unresolved in the given tables, or in all tables if none are given, are resolved with {{.EmphasisLeft}}--ours{{.EmphasisRight}} or {{.EmphasisLeft}}--theirs{{.EmphasisRight}} when either is given.
+
+	The number of rows each strategy resolved in each table is printed. With {{.EmphasisLeft}}--commit{{.EmphasisRight}}, the merge is committed once all conflicts are resolved, and the commit message records which strategy resolved how many rows, so that automated merges can be repeated and audited.
 `,
 	Synopsis: []string{
 		`--ours|--theirs {{.LessThan}}table{{.GreaterThan}}...`,
+		`--script {{.LessThan}}file{{.GreaterThan}} [--ours|--theirs] [--commit [-m {{.LessThan}}msg{{.GreaterThan}}]] [{{.LessThan}}table{{.GreaterThan}}...]`,
 	},
 }
 
 const (
 	oursFlag   = "ours"
 	theirsFlag = "theirs"
+	scriptArg  = "script"
 )
 
 var autoResolveStrategies = map[string]AutoResolveStrategy{
@@ -86,6 +93,9 @@ func (cmd ResolveCmd) ArgParser() *argparser.ArgParser {
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "List of tables to be resolved. '.' can be used to resolve all tables."})
 	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
 	ap.SupportsFlag("theirs", "", "For all conflicts, take the version from their branch and resolve the conflict")
+	ap.SupportsString(scriptArg, "", "file", "Resolve conflicts by running the SQL statements of the file in order")
+	ap.SupportsFlag(cli.CommitFlag, "", "With --script, commit the merge once all conflicts are resolved")
+	ap.SupportsString(cli.MessageArg, "m", "msg", "With --commit, use the given message for the merge commit")
 	return ap
 }
 
@@ -104,7 +114,11 @@ func (cmd ResolveCmd) Exec(ctx context.Context, commandStr string, args []string
 	}
 
 	var verr errhand.VerboseError
-	if apr.ContainsAny(autoResolverParams...) {
+	if apr.Contains(scriptArg) {
+		verr = scriptResolve(queryist, sqlCtx, apr)
+	} else if apr.ContainsAny(cli.CommitFlag, cli.MessageArg) {
+		verr = errhand.BuildDError("--commit and --message can only be used with --script").SetPrintUsage().Build()
+	} else if apr.ContainsAny(autoResolverParams...) {
 		verr = autoResolve(queryist, sqlCtx, apr)
 	} else {
 		verr = errhand.BuildDError("--ours or --theirs must be supplied").SetPrintUsage().Build()
@@ -135,3 +149,72 @@ func autoResolve(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgP
 	}
 	return nil
 }
+
+func scriptResolve(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) errhand.VerboseError {
+	funcFlags := apr.FlagsEqualTo(autoResolverParams, true)
+	if funcFlags.Size() > 1 {
+		ff := strings.Join(autoResolverParams, ", ")
+		return errhand.BuildDError("specify only one from [ %s ]", ff).SetPrintUsage().Build()
+	}
+	if apr.Contains(cli.MessageArg) && !apr.Contains(cli.CommitFlag) {
+		return errhand.BuildDError("--message can only be used with --commit").SetPrintUsage().Build()
+	}
+
+	scriptPath, _ := apr.GetValue(scriptArg)
+	script, err := os.ReadFile(scriptPath)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read resolution script %s", scriptPath).AddCause(err).Build()
+	}
+
+	summary := newResolutionSummary()
+	before, err := getConflictCounts(queryist, sqlCtx)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read conflicts").AddCause(err).Build()
+	}
+	if err = runResolutionScript(queryist, sqlCtx, string(script)); err != nil {
+		return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+	}
+	after, err := getConflictCounts(queryist, sqlCtx)
+	if err != nil {
+		return errhand.BuildDError("error: failed to read conflicts").AddCause(err).Build()
+	}
+	summary.record(scriptStrategy, before, after)
+
+	if funcFlags.Size() == 1 {
+		autoResolveFlag := funcFlags.AsSlice()[0]
+		tbls := apr.Args
+		if len(tbls) == 0 || (len(tbls) == 1 && tbls[0] == ".") {
+			tbls = after.sortedTables()
+		}
+		before = after
+		err = AutoResolveTables(queryist, sqlCtx, autoResolveStrategies[autoResolveFlag], tbls)
+		if err != nil {
+			return errhand.BuildDError("error: failed to resolve").AddCause(err).Build()
+		}
+		after, err = getConflictCounts(queryist, sqlCtx)
+		if err != nil {
+			return errhand.BuildDError("error: failed to read conflicts").AddCause(err).Build()
+		}
+		summary.record(autoResolveFlag, before, after)
+	}
+
+	cli.Print(summary.String())
+	if remaining := after.total(); remaining > 0 {
+		cli.Printf("%d conflicts remain unresolved\n", remaining)
+		if apr.Contains(cli.CommitFlag) {
+			return errhand.BuildDError("error: cannot commit the merge with unresolved conflicts").Build()
+		}
+		return nil
+	}
+
+	if apr.Contains(cli.CommitFlag) {
+		message, err := mergeCommitMessage(queryist, sqlCtx, apr.GetValueOrDefault(cli.MessageArg, ""), summary)
+		if err != nil {
+			return errhand.BuildDError("error: failed to commit the merge").AddCause(err).Build()
+		}
+		if _, err = commands.InterpolateAndRunQuery(queryist, sqlCtx, "CALL DOLT_COMMIT('-a', '-m', ?)", message); err != nil {
+			return errhand.BuildDError("error: failed to commit the merge").AddCause(err).Build()
+		}
+	}
+	return nil
+}
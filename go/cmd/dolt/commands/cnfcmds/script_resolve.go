@@ -0,0 +1,157 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cnfcmds
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+)
+
+const scriptStrategy = "script"
+
+// conflictCounts maps table names to their number of data conflicts.
+type conflictCounts map[string]uint64
+
+func (c conflictCounts) total() uint64 {
+	var total uint64
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// sortedTables returns the names of the tables of |c| with conflicts, in order.
+func (c conflictCounts) sortedTables() []string {
+	tbls := make([]string, 0, len(c))
+	for tbl, n := range c {
+		if n > 0 {
+			tbls = append(tbls, tbl)
+		}
+	}
+	sort.Strings(tbls)
+	return tbls
+}
+
+func getConflictCounts(queryist cli.Queryist, sqlCtx *sql.Context) (conflictCounts, error) {
+	rows, err := commands.GetRowsForSql(queryist, sqlCtx, "SELECT `table`, num_conflicts FROM dolt_conflicts")
+	if err != nil {
+		return nil, err
+	}
+	counts := make(conflictCounts, len(rows))
+	for _, row := range rows {
+		n, err := strconv.ParseUint(fmt.Sprint(row[1]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected number of conflicts %v for table %v", row[1], row[0])
+		}
+		counts[fmt.Sprint(row[0])] = n
+	}
+	return counts, nil
+}
+
+// resolutionSummary records how many conflicting rows of each table each strategy resolved.
+type resolutionSummary struct {
+	strategies []string
+	resolved   map[string]map[string]uint64
+}
+
+func newResolutionSummary() *resolutionSummary {
+	return &resolutionSummary{resolved: make(map[string]map[string]uint64)}
+}
+
+// record adds the conflicts resolved by |strategy|, which are the difference between the conflict counts |before|
+// and |after| it was applied.
+func (s *resolutionSummary) record(strategy string, before, after conflictCounts) {
+	byTable := make(map[string]uint64)
+	for tbl, n := range before {
+		if resolved := n - min(n, after[tbl]); resolved > 0 {
+			byTable[tbl] = resolved
+		}
+	}
+	s.strategies = append(s.strategies, strategy)
+	s.resolved[strategy] = byTable
+}
+
+// String returns a line for each table with resolved conflicts, such as "t: 3 rows by script, 2 rows by theirs".
+func (s *resolutionSummary) String() string {
+	tables := make(map[string]struct{})
+	for _, byTable := range s.resolved {
+		for tbl := range byTable {
+			tables[tbl] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(tables))
+	for tbl := range tables {
+		sorted = append(sorted, tbl)
+	}
+	sort.Strings(sorted)
+
+	var sb strings.Builder
+	for _, tbl := range sorted {
+		var parts []string
+		for _, strategy := range s.strategies {
+			if n := s.resolved[strategy][tbl]; n > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s by %s", n, pluralRows(n), strategy))
+			}
+		}
+		fmt.Fprintf(&sb, "%s: %s\n", tbl, strings.Join(parts, ", "))
+	}
+	return sb.String()
+}
+
+func pluralRows(n uint64) string {
+	if n == 1 {
+		return "row"
+	}
+	return "rows"
+}
+
+// runResolutionScript executes each statement of |script| in order. Statements resolve conflicts by updating the our_
+// columns of dolt_conflicts_<table> tables, and by deleting the conflicts they have resolved.
+func runResolutionScript(queryist cli.Queryist, sqlCtx *sql.Context, script string) error {
+	scanner := commands.NewStreamScanner(strings.NewReader(script))
+	for scanner.Scan() {
+		query := scanner.Text()
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		if _, err := commands.GetRowsForSql(queryist, sqlCtx, query); err != nil {
+			return fmt.Errorf("error running resolution script statement '%s': %w", strings.TrimSpace(query), err)
+		}
+	}
+	return scanner.Err()
+}
+
+// mergeCommitMessage returns the message of the commit which concludes a merge whose conflicts were resolved as
+// recorded by |summary|. |message| is used if given, otherwise a message is derived from the merge in progress.
+func mergeCommitMessage(queryist cli.Queryist, sqlCtx *sql.Context, message string, summary *resolutionSummary) (string, error) {
+	if message == "" {
+		rows, err := commands.GetRowsForSql(queryist, sqlCtx, "SELECT source, target FROM dolt_merge_status WHERE is_merging")
+		if err != nil {
+			return "", err
+		}
+		if len(rows) == 0 {
+			return "", fmt.Errorf("no merge in progress")
+		}
+		message = fmt.Sprintf("Merge %v into %s", rows[0][0], strings.TrimPrefix(fmt.Sprint(rows[0][1]), "refs/heads/"))
+	}
+	return fmt.Sprintf("%s\n\nResolved conflicts:\n%s", message, summary.String()), nil
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
@@ -309,7 +310,7 @@ func handleCommitErr(sqlCtx *sql.Context, queryist cli.Queryist, err error, usag
 		return HandleVErrAndExitCode(bdr.Build(), usage)
 	}
 
-	if err.Error() == "nothing to commit" {
+	if dprocedures.HasErrorCode(err, dprocedures.ErrCodeNothingToCommit) {
 		_, ri, _, err := queryist.Query(sqlCtx, "select table_name, status from dolt_status where staged = false")
 		if err != nil {
 			cli.Println(err)
@@ -0,0 +1,568 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	compareChunkSizeFlag = "chunk-size"
+	compareMaxDiffsFlag  = "max-diffs"
+
+	defaultCompareChunkSize = 1000
+	defaultCompareMaxDiffs  = 10
+)
+
+var compareToMySQLDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Verify that a MySQL database matches the current Dolt database",
+	LongDesc: `Connects to the MySQL server named by {{.LessThan}}dsn{{.GreaterThan}} and compares its tables with the tables of the current Dolt database. The dsn uses the Go MySQL driver format, e.g. {{.EmphasisLeft}}root:pass@tcp(127.0.0.1:3306)/mydb{{.EmphasisRight}}, and must name a database. If tables are given, only those tables are compared.
+
+Schemas are compared first: tables missing on either side, columns that differ in name, type or position, and differing primary keys are reported. Tables whose schemas match have their data compared by splitting them into ranges of {{.EmphasisLeft}}--chunk-size{{.EmphasisRight}} primary keys and checksumming each range on both servers. Only ranges whose row count or checksum differ are read in full, and the rows that are missing, extra or different are reported, up to {{.EmphasisLeft}}--max-diffs{{.EmphasisRight}} rows per table.
+
+Values are compared by their string representation, so columns whose types render differently on the two servers, such as floating point columns, may be reported as different even when they hold the same value.
+
+The command exits with a non-zero status if any divergence is found.
+`,
+	Synopsis: []string{
+		"[--chunk-size {{.LessThan}}n{{.GreaterThan}}] [--max-diffs {{.LessThan}}n{{.GreaterThan}}] {{.LessThan}}dsn{{.GreaterThan}} [{{.LessThan}}table{{.GreaterThan}}...]",
+	},
+}
+
+type CompareToMySQLCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd CompareToMySQLCmd) Name() string {
+	return "compare-to-mysql"
+}
+
+// Description returns a description of the command
+func (cmd CompareToMySQLCmd) Description() string {
+	return "Compare the schemas and data of a MySQL database with the current database."
+}
+
+func (cmd CompareToMySQLCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(compareToMySQLDocs, ap)
+}
+
+func (cmd CompareToMySQLCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"dsn", "The MySQL database to compare against, e.g. root:pass@tcp(127.0.0.1:3306)/mydb."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "A table to compare. Defaults to all tables."})
+	ap.SupportsInt(compareChunkSizeFlag, "", "rows", fmt.Sprintf("number of rows checksummed together. Defaults to %d.", defaultCompareChunkSize))
+	ap.SupportsInt(compareMaxDiffsFlag, "", "rows", fmt.Sprintf("maximum number of differing rows reported per table. Defaults to %d.", defaultCompareMaxDiffs))
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd CompareToMySQLCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_TYPE_UNSPECIFIED
+}
+
+// Exec executes the command
+func (cmd CompareToMySQLCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, compareToMySQLDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() == 0 {
+		verr := errhand.BuildDError("a MySQL dsn is required").SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	cfg, err := mysql.ParseDSN(apr.Arg(0))
+	if err != nil {
+		verr := errhand.BuildDError("invalid dsn '%s'", apr.Arg(0)).AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	if cfg.DBName == "" {
+		verr := errhand.BuildDError("dsn '%s' must name a database", apr.Arg(0)).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	chunkSize := apr.GetIntOrDefault(compareChunkSizeFlag, defaultCompareChunkSize)
+	maxDiffs := apr.GetIntOrDefault(compareMaxDiffsFlag, defaultCompareMaxDiffs)
+	if chunkSize <= 0 {
+		verr := errhand.BuildDError("--%s must be greater than 0", compareChunkSizeFlag).SetPrintUsage().Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if closeFunc != nil {
+		defer closeFunc()
+	}
+
+	db, err := gosql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	defer db.Close()
+	if err = db.PingContext(ctx); err != nil {
+		verr := errhand.BuildDError("unable to connect to MySQL server at %s", cfg.Addr).AddCause(err).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	c := &mysqlComparer{
+		ctx:       ctx,
+		db:        db,
+		mysqlDb:   cfg.DBName,
+		queryist:  queryist,
+		sqlCtx:    sqlCtx,
+		chunkSize: chunkSize,
+		maxDiffs:  maxDiffs,
+	}
+
+	diverged, err := c.compare(apr.Args[1:])
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+	if diverged {
+		cli.PrintErrln("databases differ")
+		return 1
+	}
+	cli.Println("databases match")
+	return 0
+}
+
+// mysqlComparer compares the current Dolt database with a database on a MySQL server. Every query it runs is sent
+// verbatim to both servers, so values are compared in whatever form both servers render them to.
+type mysqlComparer struct {
+	ctx       context.Context
+	db        *gosql.DB
+	mysqlDb   string
+	queryist  cli.Queryist
+	sqlCtx    *sql.Context
+	chunkSize int
+	maxDiffs  int
+}
+
+// compareColumn is the part of a column definition that must match between the two servers.
+type compareColumn struct {
+	name string
+	typ  string
+}
+
+// compare compares the named tables, or every table if none are named, and reports whether any divergence was found.
+func (c *mysqlComparer) compare(tables []string) (bool, error) {
+	mysqlTables, err := c.mysqlStrings("SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_type = 'BASE TABLE'", c.mysqlDb)
+	if err != nil {
+		return false, err
+	}
+	doltTables, err := c.doltStrings("SELECT table_name FROM information_schema.tables WHERE table_schema = database() AND table_type = 'BASE TABLE'")
+	if err != nil {
+		return false, err
+	}
+	inMySQL, inDolt := stringSet(mysqlTables), stringSet(doltTables)
+
+	diverged := false
+	if len(tables) == 0 {
+		for _, t := range mysqlTables {
+			if !inDolt[t] {
+				cli.Printf("%s: missing from dolt\n", t)
+				diverged = true
+			}
+		}
+		for _, t := range doltTables {
+			if !inMySQL[t] {
+				cli.Printf("%s: missing from mysql\n", t)
+				diverged = true
+			}
+		}
+		for _, t := range mysqlTables {
+			if inDolt[t] {
+				tables = append(tables, t)
+			}
+		}
+	} else {
+		var both []string
+		for _, t := range tables {
+			if !inMySQL[t] || !inDolt[t] {
+				if !inMySQL[t] {
+					cli.Printf("%s: missing from mysql\n", t)
+				}
+				if !inDolt[t] {
+					cli.Printf("%s: missing from dolt\n", t)
+				}
+				diverged = true
+				continue
+			}
+			both = append(both, t)
+		}
+		tables = both
+	}
+	sort.Strings(tables)
+
+	for _, t := range tables {
+		tableDiverged, err := c.compareTable(t)
+		if err != nil {
+			return false, fmt.Errorf("error comparing table %s: %w", t, err)
+		}
+		diverged = diverged || tableDiverged
+	}
+	return diverged, nil
+}
+
+func (c *mysqlComparer) compareTable(table string) (bool, error) {
+	cols, pks, diverged, err := c.compareSchemas(table)
+	if err != nil || diverged {
+		return diverged, err
+	}
+	if len(pks) == 0 {
+		cli.Printf("%s: skipped, table has no primary key\n", table)
+		return false, nil
+	}
+
+	keyExpr, rowExpr := checksumExprs(cols, pks)
+	ranges, err := c.keyRanges(table, pks)
+	if err != nil {
+		return false, err
+	}
+
+	var rows uint64
+	var diffs []string
+	for _, where := range ranges {
+		checksum := fmt.Sprintf("SELECT COUNT(*), COALESCE(BIT_XOR(CRC32(%s)), 0) FROM %s WHERE %s", rowExpr, quoteIdent(table), where)
+		mysqlSum, err := c.mysqlStrings(checksum)
+		if err != nil {
+			return false, err
+		}
+		doltSum, err := c.doltStrings(checksum)
+		if err != nil {
+			return false, err
+		}
+		if len(mysqlSum) == 2 && len(doltSum) == 2 && mysqlSum[0] == doltSum[0] && mysqlSum[1] == doltSum[1] {
+			var n uint64
+			fmt.Sscan(mysqlSum[0], &n)
+			rows += n
+			continue
+		}
+
+		diverged = true
+		if len(diffs) >= c.maxDiffs {
+			continue
+		}
+		rangeDiffs, err := c.drillDown(table, keyExpr, rowExpr, where)
+		if err != nil {
+			return false, err
+		}
+		diffs = append(diffs, rangeDiffs...)
+	}
+
+	if !diverged {
+		cli.Printf("%s: ok (%d rows)\n", table, rows)
+		return false, nil
+	}
+
+	cli.Printf("%s: data differs\n", table)
+	if len(diffs) > c.maxDiffs {
+		diffs = diffs[:c.maxDiffs]
+	}
+	for _, d := range diffs {
+		cli.Printf("  %s\n", d)
+	}
+	return true, nil
+}
+
+// compareSchemas reports any differences in the columns or primary key of |table| and returns its columns and
+// primary key columns.
+func (c *mysqlComparer) compareSchemas(table string) ([]compareColumn, []string, bool, error) {
+	const colQuery = "SELECT column_name, column_type FROM information_schema.columns WHERE table_schema = %s AND table_name = ? ORDER BY ordinal_position"
+	const pkQuery = "SELECT column_name FROM information_schema.key_column_usage WHERE table_schema = %s AND table_name = ? AND constraint_name = 'PRIMARY' ORDER BY ordinal_position"
+
+	mysqlCols, err := c.mysqlColumns(fmt.Sprintf(colQuery, "?"), c.mysqlDb, table)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	doltCols, err := c.doltColumns(fmt.Sprintf(colQuery, "database()"), table)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	mysqlPks, err := c.mysqlStrings(fmt.Sprintf(pkQuery, "?"), c.mysqlDb, table)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	doltPks, err := c.doltStrings(fmt.Sprintf(pkQuery, "database()"), table)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var problems []string
+	for i := 0; i < len(mysqlCols) || i < len(doltCols); i++ {
+		switch {
+		case i >= len(doltCols):
+			problems = append(problems, fmt.Sprintf("column %s %s missing from dolt", mysqlCols[i].name, mysqlCols[i].typ))
+		case i >= len(mysqlCols):
+			problems = append(problems, fmt.Sprintf("column %s %s missing from mysql", doltCols[i].name, doltCols[i].typ))
+		case !strings.EqualFold(mysqlCols[i].name, doltCols[i].name):
+			problems = append(problems, fmt.Sprintf("column %d is %s in mysql but %s in dolt", i+1, mysqlCols[i].name, doltCols[i].name))
+		case normalizeColumnType(mysqlCols[i].typ) != normalizeColumnType(doltCols[i].typ):
+			problems = append(problems, fmt.Sprintf("column %s is %s in mysql but %s in dolt", mysqlCols[i].name, mysqlCols[i].typ, doltCols[i].typ))
+		}
+	}
+	if !strings.EqualFold(strings.Join(mysqlPks, ","), strings.Join(doltPks, ",")) {
+		problems = append(problems, fmt.Sprintf("primary key is (%s) in mysql but (%s) in dolt", strings.Join(mysqlPks, ", "), strings.Join(doltPks, ", ")))
+	}
+
+	if len(problems) > 0 {
+		cli.Printf("%s: schema differs\n", table)
+		for _, p := range problems {
+			cli.Printf("  %s\n", p)
+		}
+		return nil, nil, true, nil
+	}
+	return mysqlCols, mysqlPks, false, nil
+}
+
+// keyRanges splits |table| into WHERE clauses covering |c.chunkSize| primary keys each. Range boundaries are read
+// from MySQL, the last range is unbounded so that rows only present in Dolt are still covered.
+func (c *mysqlComparer) keyRanges(table string, pks []string) ([]string, error) {
+	quoted := make([]string, len(pks))
+	for i, pk := range pks {
+		quoted[i] = quoteIdent(pk)
+	}
+	keyTuple := "(" + strings.Join(quoted, ", ") + ")"
+	orderBy := strings.Join(quoted, ", ")
+
+	var ranges []string
+	lower := ""
+	for {
+		q := fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), quoteIdent(table))
+		if lower != "" {
+			q += " WHERE " + keyTuple + " >= " + lower
+		}
+		q += fmt.Sprintf(" ORDER BY %s LIMIT 1 OFFSET %d", orderBy, c.chunkSize)
+		boundary, err := c.mysqlStrings(q)
+		if err != nil {
+			return nil, err
+		}
+		if len(boundary) == 0 {
+			break
+		}
+
+		upper, err := valueTuple(boundary)
+		if err != nil {
+			return nil, err
+		}
+		where := keyTuple + " < " + upper
+		if lower != "" {
+			where = keyTuple + " >= " + lower + " AND " + where
+		}
+		ranges = append(ranges, where)
+		lower = upper
+	}
+
+	if lower == "" {
+		ranges = append(ranges, "1 = 1")
+	} else {
+		ranges = append(ranges, keyTuple+" >= "+lower)
+	}
+	return ranges, nil
+}
+
+// drillDown reads every row in the range |where| from both servers and describes the rows that differ.
+func (c *mysqlComparer) drillDown(table, keyExpr, rowExpr, where string) ([]string, error) {
+	q := fmt.Sprintf("SELECT %s, MD5(%s) FROM %s WHERE %s", keyExpr, rowExpr, quoteIdent(table), where)
+
+	mysqlRows, err := c.db.QueryContext(c.ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer mysqlRows.Close()
+	inMySQL := make(map[string]string)
+	for mysqlRows.Next() {
+		var key, sum gosql.NullString
+		if err := mysqlRows.Scan(&key, &sum); err != nil {
+			return nil, err
+		}
+		inMySQL[key.String] = sum.String
+	}
+	if err := mysqlRows.Err(); err != nil {
+		return nil, err
+	}
+
+	doltRows, err := GetRowsForSql(c.queryist, c.sqlCtx, q)
+	if err != nil {
+		return nil, err
+	}
+	inDolt := make(map[string]string, len(doltRows))
+	for _, row := range doltRows {
+		inDolt[compareValueString(row[0])] = compareValueString(row[1])
+	}
+
+	var diffs []string
+	for key, sum := range inMySQL {
+		doltSum, ok := inDolt[key]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("missing from dolt: (%s)", key))
+		} else if doltSum != sum {
+			diffs = append(diffs, fmt.Sprintf("different: (%s)", key))
+		}
+	}
+	for key := range inDolt {
+		if _, ok := inMySQL[key]; !ok {
+			diffs = append(diffs, fmt.Sprintf("missing from mysql: (%s)", key))
+		}
+	}
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+// mysqlStrings runs |query| against MySQL and returns every value of every row as a string.
+func (c *mysqlComparer) mysqlStrings(query string, args ...interface{}) ([]string, error) {
+	rows, err := c.db.QueryContext(c.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	vals := make([]gosql.NullString, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for _, v := range vals {
+			res = append(res, v.String)
+		}
+	}
+	return res, rows.Err()
+}
+
+// doltStrings runs |query| against Dolt and returns every value of every row as a string.
+func (c *mysqlComparer) doltStrings(query string, args ...interface{}) ([]string, error) {
+	rows, err := InterpolateAndRunQuery(c.queryist, c.sqlCtx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, row := range rows {
+		for _, v := range row {
+			res = append(res, compareValueString(v))
+		}
+	}
+	return res, nil
+}
+
+func (c *mysqlComparer) mysqlColumns(query string, args ...interface{}) ([]compareColumn, error) {
+	vals, err := c.mysqlStrings(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return toCompareColumns(vals), nil
+}
+
+func (c *mysqlComparer) doltColumns(query string, args ...interface{}) ([]compareColumn, error) {
+	vals, err := c.doltStrings(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return toCompareColumns(vals), nil
+}
+
+func toCompareColumns(vals []string) []compareColumn {
+	cols := make([]compareColumn, 0, len(vals)/2)
+	for i := 0; i+1 < len(vals); i += 2 {
+		cols = append(cols, compareColumn{name: vals[i], typ: vals[i+1]})
+	}
+	return cols
+}
+
+// checksumExprs returns SQL expressions that render the primary key and the whole row of a table as strings. NULLs
+// are recorded in a separate suffix, since CONCAT_WS skips them.
+func checksumExprs(cols []compareColumn, pks []string) (keyExpr string, rowExpr string) {
+	keyParts := make([]string, len(pks))
+	for i, pk := range pks {
+		keyParts[i] = fmt.Sprintf("CAST(%s AS CHAR)", quoteIdent(pk))
+	}
+	rowParts := make([]string, len(cols))
+	nullParts := make([]string, len(cols))
+	for i, col := range cols {
+		rowParts[i] = fmt.Sprintf("CAST(%s AS CHAR)", quoteIdent(col.name))
+		nullParts[i] = fmt.Sprintf("ISNULL(%s)", quoteIdent(col.name))
+	}
+	keyExpr = fmt.Sprintf("CONCAT_WS(', ', %s)", strings.Join(keyParts, ", "))
+	rowExpr = fmt.Sprintf("CONCAT_WS('#', %s, CONCAT(%s))", strings.Join(rowParts, ", "), strings.Join(nullParts, ", "))
+	return keyExpr, rowExpr
+}
+
+var intDisplayWidthRegex = regexp.MustCompile(`^((?:tiny|small|medium|big)?int)\(\d+\)`)
+
+// normalizeColumnType strips the parts of a column type that MySQL and Dolt may render differently without the
+// type being different, such as integer display widths.
+func normalizeColumnType(typ string) string {
+	typ = strings.ToLower(strings.TrimSpace(typ))
+	return intDisplayWidthRegex.ReplaceAllString(typ, "$1")
+}
+
+// valueTuple renders |vals| as a SQL tuple of string literals.
+func valueTuple(vals []string) (string, error) {
+	placeholders := make([]string, len(vals))
+	params := make([]interface{}, len(vals))
+	for i, v := range vals {
+		placeholders[i] = "?"
+		params[i] = v
+	}
+	return dbr.InterpolateForDialect("("+strings.Join(placeholders, ", ")+")", params, dialect.MySQL)
+}
+
+func compareValueString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func quoteIdent(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+func stringSet(ss []string) map[string]bool {
+	set := make(map[string]bool, len(ss))
+	for _, s := range ss {
+		set[s] = true
+	}
+	return set
+}
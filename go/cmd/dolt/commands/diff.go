@@ -1311,6 +1311,8 @@ func diffDoltSchemasTable(
 			if err != nil {
 				return nil
 			}
+		case "function":
+			// user defined functions embed compiled WebAssembly modules, which can't be usefully diffed as text
 		default:
 			cli.PrintErrf("Unrecognized schema element type: %s", fragmentType)
 			continue
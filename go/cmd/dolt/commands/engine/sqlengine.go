@@ -17,8 +17,13 @@ package engine
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	// Embed the IANA time zone database so that named time zones resolve in CONVERT_TZ, @@time_zone and TIMESTAMP
+	// conversions even on hosts without a system zoneinfo directory. Zone names resolve through Go's time package, so
+	// the mysql.time_zone* tables are not provided.
+	_ "time/tzdata"
 
 	gms "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/eventscheduler"
@@ -41,10 +46,16 @@ import (
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	dblr "github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/costmodel"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/events"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/kvexec"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/mysql_file_handler"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/planbaseline"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/statspro"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
@@ -59,6 +70,8 @@ type SqlEngine struct {
 	dsessFactory   sessionFactory
 	engine         *gms.Engine
 	fs             filesys.Filesys
+
+	closeExternalFunctions func() error
 }
 
 type sessionFactory func(mysqlSess *sql.BaseSession, pro sql.DatabaseProvider) (*dsess.DoltSession, error)
@@ -85,6 +98,8 @@ type SqlEngineConfig struct {
 	AutoGCController           *dsqle.AutoGCController
 	BinlogReplicaController    binlogreplication.BinlogReplicaController
 	EventSchedulerStatus       eventscheduler.SchedulerStatus
+	ExternalFunctions          []dfunctions.ExternalFunctionSpec
+	ExternalTableCredentials   []dtables.FederatedCredential
 }
 
 // NewSqlEngine returns a SqlEngine
@@ -165,6 +180,18 @@ func NewSqlEngine(
 	}
 	pro = pro.WithRemoteDialer(mrEnv.RemoteDialProvider())
 
+	var closeExternalFunctions func() error
+	if len(config.ExternalFunctions) > 0 {
+		var externalFns []sql.Function
+		externalFns, closeExternalFunctions, err = dfunctions.DialExternalFunctions(config.ExternalFunctions)
+		if err != nil {
+			return nil, err
+		}
+		pro = pro.WithFunctions(append(append([]sql.Function{}, dfunctions.DoltFunctions...), externalFns...))
+	}
+
+	dtables.SetFederatedCredentials(config.ExternalTableCredentials)
+
 	config.ClusterController.RegisterStoredProcedures(pro)
 	if config.ClusterController != nil {
 		pro.InitDatabaseHooks = append(pro.InitDatabaseHooks, cluster.NewInitDatabaseHook(config.ClusterController, bThreads))
@@ -172,7 +199,7 @@ func NewSqlEngine(
 		config.ClusterController.SetDropDatabase(pro.DropDatabase)
 	}
 
-	sqlEngine := &SqlEngine{}
+	sqlEngine := &SqlEngine{closeExternalFunctions: closeExternalFunctions}
 
 	// Create the engine
 	engine := gms.New(analyzer.NewBuilder(pro).Build(), &gms.Config{
@@ -242,7 +269,20 @@ func NewSqlEngine(
 	engine.Analyzer.Catalog.StatsProvider = statsPro
 
 	engine.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
+	planbaseline.AddCheckRule(engine.Analyzer)
+	dsess.AddTransactionalDDLRule(engine.Analyzer)
+	index.AddDescendingIndexSortRule(engine.Analyzer)
+	index.AddSpatialIndexCostingRule(engine.Analyzer)
+	if closeExternalFunctions != nil {
+		dfunctions.AddExternalFunctionBatchingRule(engine.Analyzer)
+	}
+	if coefs, ok, err := costmodel.FromConfig(mrEnv.Config()); err != nil {
+		logrus.Warnf("using the default cost model: %s", err.Error())
+	} else if ok {
+		engine.Analyzer.Coster = costmodel.NewCoster(coefs)
+	}
 	sessFactory := doltSessionFactory(pro, statsPro, mrEnv.Config(), bcController, gcSafepointController, config.Autocommit)
+	pro.SetStatementRunner(engine)
 	sqlEngine.provider = pro
 	sqlEngine.dsessFactory = sessFactory
 	sqlEngine.ContextFactory = sqlContextFactory
@@ -250,6 +290,7 @@ func NewSqlEngine(
 	sqlEngine.fs = pro.FileSystem()
 
 	pro.InstallReplicationInitDatabaseHook(bThreads, sqlEngine.NewDefaultContext)
+	pro.AddCreateDatabaseHook(sqlEngine.applyDatabaseTemplate)
 	if err = config.ClusterController.RunCommitHooks(bThreads, sqlEngine.NewDefaultContext); err != nil {
 		return nil, err
 	}
@@ -428,6 +469,14 @@ func (se *SqlEngine) FileSystem() filesys.Filesys {
 }
 
 func (se *SqlEngine) Close() error {
+	if se.closeExternalFunctions != nil {
+		if err := se.closeExternalFunctions(); err != nil {
+			logrus.Warnf("error closing external functions: %v", err)
+		}
+	}
+	if err := dtables.CloseFederatedConns(); err != nil {
+		logrus.Warnf("error closing federated table connections: %v", err)
+	}
 	if se.engine != nil {
 		if se.engine.Analyzer.Catalog.BinlogReplicaController != nil {
 			dblr.DoltBinlogReplicaController.Close()
@@ -486,7 +535,19 @@ func configureEventScheduler(config *SqlEngineConfig, engine *gms.Engine, ctxFac
 		}
 	}
 
-	return engine.InitializeEventScheduler(getCtxFunc, config.EventSchedulerStatus, eventSchedulerPeriod)
+	// The last execution of each event is kept in the config directory, so that the events missed while the server
+	// was down can be caught up when it starts.
+	statePath := ""
+	if config.DoltCfgDirPath != "" {
+		statePath = filepath.Join(config.DoltCfgDirPath, events.StateFileName)
+	}
+	state, err := events.LoadState(pro.FileSystem(), statePath)
+	if err != nil {
+		return err
+	}
+	events.SetCurrent(events.NewHistory(events.GlobalHistorySize()))
+
+	return events.InitEventScheduler(engine, pro, getCtxFunc, config.EventSchedulerStatus, eventSchedulerPeriod, state)
 }
 
 // sqlContextFactory returns a contextFactory that creates a new sql.Context with the given session
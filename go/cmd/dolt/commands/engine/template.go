@@ -0,0 +1,69 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engine
+
+import (
+	"context"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbtemplate"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// ApplyTemplate applies |t| to the database |dbName| in a new session, whose statements run as |client|.
+func (se *SqlEngine) ApplyTemplate(ctx context.Context, client sql.Client, dbName string, t *dbtemplate.Template) error {
+	sqlCtx, err := se.NewDefaultContext(ctx)
+	if err != nil {
+		return err
+	}
+	sqlCtx.Session.SetClient(client)
+	sqlCtx.SetCurrentDatabase(dbName)
+	return t.Apply(sqlCtx, dbName, se.runTemplateQuery)
+}
+
+func (se *SqlEngine) runTemplateQuery(ctx *sql.Context, query string) error {
+	_, iter, _, err := se.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	_, err = sql.RowIterToRows(ctx, iter)
+	return err
+}
+
+// applyDatabaseTemplate is a CreateDatabaseHook which applies the template named by the dolt_database_template
+// system variable, if it's set, to databases created with CREATE DATABASE.
+func (se *SqlEngine) applyDatabaseTemplate(ctx *sql.Context, name string, newEnv *env.DoltEnv) error {
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.DatabaseTemplate)
+	if !ok {
+		return nil
+	}
+	source, _ := val.(string)
+	if source == "" {
+		return nil
+	}
+
+	t, err := dbtemplate.Load(ctx, source)
+	if err != nil {
+		return err
+	}
+	if len(t.Config) > 0 {
+		if err = newEnv.Config.WriteableConfig().SetStrings(t.Config); err != nil {
+			return err
+		}
+	}
+	return se.ApplyTemplate(ctx, ctx.Session.Client(), name, t)
+}
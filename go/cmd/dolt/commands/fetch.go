@@ -150,6 +150,11 @@ func constructInterpolatedDoltFetchQuery(apr *argparser.ArgParseResults) (string
 		args = append(args, "?")
 		params = append(params, user)
 	}
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		args = append(args, "'--max-bandwidth'")
+		args = append(args, "?")
+		params = append(params, maxBandwidth)
+	}
 	for _, arg := range apr.Args {
 		args = append(args, "?")
 		params = append(params, arg)
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
 	"strings"
 
 	sqle "github.com/dolthub/go-mysql-server"
@@ -43,6 +44,7 @@ const (
 	filterDbName    = "filterDB"
 	branchesFlag    = "branches"
 	uncommittedFlag = "apply-to-uncommitted"
+	parallelismFlag = "parallelism"
 )
 
 var filterBranchDocs = cli.CommandDocumentationContent{
@@ -54,10 +56,14 @@ If a {{.LessThan}}commit-spec{{.GreaterThan}} is provided, the traversal will st
 If the {{.EmphasisLeft}}--branches{{.EmphasisRight}} flag is supplied, filter-branch traverses and rewrites commits for all branches.
 
 If the {{.EmphasisLeft}}--all{{.EmphasisRight}} flag is supplied, filter-branch traverses and rewrites commits for all branches and tags.
+
+If the {{.EmphasisLeft}}--tables{{.EmphasisRight}} flag is supplied, only the given tables are rewritten. Changes the queries make to other tables are discarded, and the queries are only run on commits which changed one of the given tables; the other commits keep the rewritten tables of their parent. This is much faster when the tables being rewritten change in few commits.
+
+Commits are rewritten in parallel, by as many workers as there are CPUs unless {{.EmphasisLeft}}--parallelism{{.EmphasisRight}} is supplied. The queries are run on each commit independently, so they must not depend on how the commit's parents were rewritten.
 `,
 
 	Synopsis: []string{
-		"[--all] -q {{.LessThan}}queries{{.GreaterThan}} [{{.LessThan}}commit{{.GreaterThan}}]",
+		"[--all] [--tables {{.LessThan}}table{{.GreaterThan}}[,{{.LessThan}}table{{.GreaterThan}}...]] [--parallelism {{.LessThan}}n{{.GreaterThan}}] -q {{.LessThan}}queries{{.GreaterThan}} [{{.LessThan}}commit{{.GreaterThan}}]",
 	},
 }
 
@@ -86,6 +92,8 @@ func (cmd FilterBranchCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(cli.AllFlag, "a", "filter all branches and tags")
 	ap.SupportsFlag(continueFlag, "c", "log a warning and continue if any errors occur executing statements")
 	ap.SupportsString(QueryFlag, "q", "queries", "Queries to run, separated by semicolons. If not provided, queries are read from STDIN.")
+	ap.SupportsStringList(cli.TablesFlag, "t", "table", "Only rewrite the given tables, and only run the queries on commits which changed them.")
+	ap.SupportsInt(parallelismFlag, "", "n", "Number of commits to rewrite at once. Defaults to the number of CPUs.")
 	return ap
 }
 
@@ -138,14 +146,30 @@ func (cmd FilterBranchCmd) Exec(ctx context.Context, commandStr string, args []s
 		continueOnErr: continueOnErr,
 	}
 
+	opts := rebase.RewriteOptions{Parallelism: apr.GetIntOrDefault(parallelismFlag, runtime.GOMAXPROCS(0))}
+	if opts.Parallelism < 1 {
+		verr := errhand.BuildDError("--%s must be at least 1", parallelismFlag).Build()
+		return HandleVErrAndExitCode(verr, usage)
+	}
+	if tables, ok := apr.GetValueList(cli.TablesFlag); ok {
+		opts.Tables = doltdb.ToTableNames(tables, doltdb.DefaultSchemaName)
+	}
+	if !verbose {
+		p := cli.NewEphemeralPrinter()
+		opts.Progress = func(replayed, total int) {
+			p.Printf("Rewrote %d of %d commits.", replayed, total)
+			p.Display()
+		}
+	}
+
 	applyUncommitted := apr.Contains(uncommittedFlag)
 	switch {
 	case apr.Contains(branchesFlag):
-		err = rebase.AllBranches(ctx, dEnv, applyUncommitted, commitReplayer, rootReplayer, nerf)
+		err = rebase.AllBranches(ctx, dEnv, applyUncommitted, commitReplayer, rootReplayer, nerf, opts)
 	case apr.Contains(cli.AllFlag):
-		err = rebase.AllBranchesAndTags(ctx, dEnv, applyUncommitted, commitReplayer, rootReplayer, nerf)
+		err = rebase.AllBranchesAndTags(ctx, dEnv, applyUncommitted, commitReplayer, rootReplayer, nerf, opts)
 	default:
-		err = rebase.CurrentBranch(ctx, dEnv, applyUncommitted, commitReplayer, rootReplayer, nerf)
+		err = rebase.CurrentBranch(ctx, dEnv, applyUncommitted, commitReplayer, rootReplayer, nerf, opts)
 	}
 	if err != nil {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
@@ -210,7 +234,7 @@ type commitReplayer struct {
 var _ rebase.CommitReplayer = &commitReplayer{}
 
 // ReplayCommit implements the CommitReplayer interface
-func (c *commitReplayer) ReplayCommit(ctx context.Context, commit, _, _ *doltdb.Commit) (doltdb.RootValue, error) {
+func (c *commitReplayer) ReplayCommit(ctx context.Context, commit *doltdb.Commit) (doltdb.RootValue, error) {
 	root, err := commit.GetRootValue(ctx)
 	if err != nil {
 		return nil, err
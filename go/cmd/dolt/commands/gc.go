@@ -17,6 +17,7 @@ package commands
 import (
 	"context"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/gocraft/dbr/v2"
 	"github.com/gocraft/dbr/v2/dialect"
 
@@ -44,9 +45,14 @@ copied during a prior garbage collection.
 
 If the {{.EmphasisLeft}}--shallow{{.EmphasisRight}} flag is supplied, a faster but less thorough garbage collection will be performed.
 
-If the {{.EmphasisLeft}}--full{{.EmphasisRight}} flag is supplied, a more thorough garbage collection, fully collecting the old gen and new gen, will be performed.`,
+If the {{.EmphasisLeft}}--full{{.EmphasisRight}} flag is supplied, a more thorough garbage collection, fully collecting the old gen and new gen, will be performed.
+
+If the {{.EmphasisLeft}}--dry-run{{.EmphasisRight}} flag is supplied, nothing is collected. Instead, the table files which garbage collection would rewrite are listed, along with how many chunks they hold, how many of those are reachable, and an estimate of the bytes that would be freed. Table files which are due to be conjoined are listed as well.
+
+If the {{.EmphasisLeft}}--verbose{{.EmphasisRight}} flag is supplied, the time taken by each phase of garbage collection is printed once it completes.`,
 	Synopsis: []string{
-		"[--shallow|--full]",
+		"[--shallow|--full] [--verbose]",
+		"--dry-run [--full]",
 	},
 }
 
@@ -97,6 +103,9 @@ func (cmd GarbageCollectionCmd) Exec(ctx context.Context, commandStr string, arg
 	if apr.Contains(cli.ShallowFlag) && apr.Contains(cli.FullFlag) {
 		return HandleVErrAndExitCode(errhand.BuildDError("Invalid Argument: --shallow is not compatible with --full").SetPrintUsage().Build(), usage)
 	}
+	if apr.Contains(cli.ShallowFlag) && apr.Contains(cli.DryRunFlag) {
+		return HandleVErrAndExitCode(errhand.BuildDError("Invalid Argument: --shallow is not compatible with --dry-run").SetPrintUsage().Build(), usage)
+	}
 
 	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
 	if err != nil {
@@ -111,11 +120,22 @@ func (cmd GarbageCollectionCmd) Exec(ctx context.Context, commandStr string, arg
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
-	_, _, _, err = queryist.Query(sqlCtx, query)
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
 	if err != nil && err != chunks.ErrNothingToCollect {
 		return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
 	}
 
+	// Servers which predate --dry-run and --verbose return only a status column
+	if len(rows) == 1 && len(rows[0]) > 1 && rows[0][1] != nil {
+		message, ok, err := sql.Unwrap[string](sqlCtx, rows[0][1])
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		if ok {
+			cli.Println(message)
+		}
+	}
+
 	return HandleVErrAndExitCode(nil, usage)
 }
 
@@ -130,6 +150,17 @@ func (cmd GarbageCollectionCmd) constructDoltGCQuery(apr *argparser.ArgParseResu
 		extraFlag = "--full"
 	}
 
+	var flags []string
+	if extraFlag != "" {
+		flags = append(flags, extraFlag)
+	}
+	if apr.Contains(cli.DryRunFlag) {
+		flags = append(flags, "--dry-run")
+	}
+	if apr.Contains(cli.VerboseFlag) {
+		flags = append(flags, "--verbose")
+	}
+
 	archiveLevel := chunks.NoArchive
 	if apr.Contains(cli.ArchiveLevelParam) {
 		lvl, ok := apr.GetInt(cli.ArchiveLevelParam)
@@ -143,12 +174,11 @@ func (cmd GarbageCollectionCmd) constructDoltGCQuery(apr *argparser.ArgParseResu
 	params = append(params, archiveLevel)
 
 	query := "CALL DOLT_GC('--archive-level', ?"
-	if extraFlag != "" {
-		query += ", ?)"
-		params = append(params, extraFlag)
-	} else {
-		query += ")"
+	for _, flag := range flags {
+		query += ", ?"
+		params = append(params, flag)
 	}
+	query += ")"
 
 	return dbr.InterpolateForDialect(query, params, dialect.MySQL)
 }
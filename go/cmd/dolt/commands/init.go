@@ -19,10 +19,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbtemplate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
@@ -35,6 +38,7 @@ const (
 	emailParamName      = "email"
 	usernameParamName   = "name"
 	initBranchParamName = "initial-branch"
+	templateParamName   = "template"
 	newFormatFlag       = "new-format"
 	funHashFlag         = "fun"
 )
@@ -44,6 +48,20 @@ var initDocs = cli.CommandDocumentationContent{
 	LongDesc: `This command creates an empty Dolt data repository in the current directory.
 
 Running dolt init in an already initialized directory will fail.
+
+If {{.EmphasisLeft}}--template{{.EmphasisRight}} is given, or {{.EmphasisLeft}}init.template{{.EmphasisRight}} is set in the global config, the new repository is set up from a template. A template is a directory, or the http or https URL of a .tar.gz archive of one, which may contain:
+
+{{.EmphasisLeft}}config.json{{.EmphasisRight}}: config values to set in the repository config.
+
+{{.EmphasisLeft}}schema.sql{{.EmphasisRight}}: SQL statements creating the schema of the database.
+
+{{.EmphasisLeft}}dolt_ignore{{.EmphasisRight}}: table name patterns to add to dolt_ignore, one per line. Patterns starting with ! are explicitly not ignored.
+
+{{.EmphasisLeft}}queries/{{.EmphasisRight}}: .sql files saved to the query catalog, named after the file. A leading -- comment is the description of the query.
+
+{{.EmphasisLeft}}hooks/{{.EmphasisRight}}: .sql scripts run in name order after the rest of the template is applied.
+
+Everything but the config is committed on top of the initial commit. Templates are applied to databases created with CREATE DATABASE while the {{.EmphasisLeft}}dolt_database_template{{.EmphasisRight}} system variable is set.
 `,
 
 	Synopsis: []string{
@@ -80,6 +98,7 @@ func (cmd InitCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsString(emailParamName, "", "email", fmt.Sprintf("The email address used. If not provided will be taken from {{.EmphasisLeft}}%s{{.EmphasisRight}} in the global config.", config.UserEmailKey))
 	ap.SupportsString(cli.DateParam, "", "date", "Specify the date used in the initial commit. If not specified the current system time is used.")
 	ap.SupportsString(initBranchParamName, "b", "branch", fmt.Sprintf("The branch name used to initialize this database. If not provided will be taken from {{.EmphasisLeft}}%s{{.EmphasisRight}} in the global config. If unset, the default initialized branch will be named '%s'.", config.InitBranchName, env.DefaultInitBranch))
+	ap.SupportsString(templateParamName, "", "template", fmt.Sprintf("The directory or .tar.gz URL of a template used to set up this database. If not provided will be taken from {{.EmphasisLeft}}%s{{.EmphasisRight}} in the global config.", config.InitTemplate))
 	ap.SupportsFlag(newFormatFlag, "", fmt.Sprintf("Specify this flag to use the new storage format (%s).", types.Format_DOLT.VersionString()))
 	ap.SupportsFlag(funHashFlag, "", "") // This flag is an easter egg. We can't currently prevent it from being listed in the help, but the description is deliberately left blank.
 	return ap
@@ -153,6 +172,16 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		}
 	}
 
+	var tmpl *dbtemplate.Template
+	if source := dEnv.Config.IfEmptyUseConfig(apr.GetValueOrDefault(templateParamName, ""), config.InitTemplate); source != "" {
+		var err error
+		tmpl, err = dbtemplate.Load(ctx, source)
+		if err != nil {
+			cli.PrintErrln(color.RedString("Failed to load template. %s", err.Error()))
+			return 1
+		}
+	}
+
 	requiresFunHash := apr.Contains(funHashFlag)
 	commitMetaGenerator := datas.MakeCommitMetaGenerator(name, email, t)
 	if requiresFunHash {
@@ -172,6 +201,11 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	if apr.Contains(emailParamName) {
 		configuration[config.UserEmailKey] = email
 	}
+	if tmpl != nil {
+		for k, v := range tmpl.Config {
+			configuration[k] = v
+		}
+	}
 	if len(configuration) > 0 {
 		err = dEnv.Config.WriteableConfig().SetStrings(configuration)
 		if err != nil {
@@ -180,6 +214,23 @@ func (cmd InitCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		}
 	}
 
+	if tmpl != nil && !tmpl.IsEmpty() {
+		if err = applyInitTemplate(ctx, dEnv, tmpl); err != nil {
+			cli.PrintErrln(color.RedString("Failed to apply template. %s", err.Error()))
+			return 1
+		}
+	}
+
 	cli.Println(color.CyanString("Successfully initialized dolt data repository."))
 	return 0
 }
+
+// applyInitTemplate applies |tmpl| to the newly initialized database of |dEnv|.
+func applyInitTemplate(ctx context.Context, dEnv *env.DoltEnv, tmpl *dbtemplate.Template) error {
+	se, dbName, err := engine.NewSqlEngineForEnv(ctx, dEnv)
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+	return se.ApplyTemplate(ctx, sql.Client{User: "root", Address: "%", Capabilities: 0}, dbName, tmpl)
+}
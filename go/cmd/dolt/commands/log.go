@@ -57,7 +57,11 @@ The command takes options to control what is shown and how.
 	
 {{.EmphasisLeft}}dolt log <revisionB>...<revisionA>{{.EmphasisRight}}
 {{.EmphasisLeft}}dolt log <revisionA> <revisionB> --not $(dolt merge-base <revisionA> <revisionB>){{.EmphasisRight}}
-  Different ways to list three dot logs. These will list commit logs reachable by revisionA OR revisionB, while excluding commits reachable by BOTH revisionA AND revisionB.`,
+  Different ways to list three dot logs. These will list commit logs reachable by revisionA OR revisionB, while excluding commits reachable by BOTH revisionA AND revisionB.
+
+{{.EmphasisLeft}}dolt log --format dot [--stat] [<revisions>...]{{.EmphasisRight}}
+{{.EmphasisLeft}}dolt log --format json [--stat] [<revisions>...]{{.EmphasisRight}}
+  Exports the graph of the listed commits instead of the log, in the Graphviz DOT language or as JSON. Each commit has an edge to each of its parents that is also listed. With {{.EmphasisLeft}}--stat{{.EmphasisRight}}, each commit is annotated with the number of tables it changed relative to its first parent.`,
 	Synopsis: []string{
 		`[-n {{.LessThan}}num_commits{{.GreaterThan}}] [{{.LessThan}}revision-range{{.GreaterThan}}] [[--] {{.LessThan}}table{{.GreaterThan}}]`,
 	},
@@ -104,6 +108,9 @@ func (cmd LogCmd) logWithLoggerFunc(ctx context.Context, commandStr string, args
 	if terminate {
 		return status
 	}
+	if err := validateLogFormat(apr); err != nil {
+		return handleErrAndExit(err)
+	}
 
 	queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
 	if err != nil {
@@ -282,6 +289,7 @@ func getExistingTables(revisions []string, queryist cli.Queryist, sqlCtx *sql.Co
 func logCommits(apr *argparser.ArgParseResults, commitHashes []sql.Row, queryist cli.Queryist, sqlCtx *sql.Context) error {
 	opts := commitInfoOptions{
 		showSignature: apr.Contains(cli.ShowSignatureFlag),
+		showCommitter: apr.Contains(cli.ShowCommitterFlag),
 	}
 
 	var commitsInfo []CommitInfo
@@ -366,7 +374,9 @@ func logToStdOut(apr *argparser.ArgParseResults, commits []CommitInfo, sqlCtx *s
 	cli.ExecuteWithStdioRestored(func() {
 		pager := outputpager.Start()
 		defer pager.Stop()
-		if apr.Contains(cli.GraphFlag) {
+		if apr.Contains(cli.FormatFlag) {
+			err = logExportGraph(pager, apr, commits, sqlCtx, queryist)
+		} else if apr.Contains(cli.GraphFlag) {
 			logGraph(pager, apr, commits)
 		} else if apr.Contains(cli.OneLineFlag) {
 			err = logCompact(pager, apr, commits, sqlCtx, queryist)
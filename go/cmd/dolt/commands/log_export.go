@@ -0,0 +1,225 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/util/outputpager"
+)
+
+// The formats the commit graph can be exported in with dolt log --format.
+const (
+	logFormatDot  = "dot"
+	logFormatJson = "json"
+)
+
+// logExportShortHashLen is the length of the commit hashes used to label the nodes of a DOT graph.
+const logExportShortHashLen = 8
+
+// validateLogFormat returns an error if the --format given to dolt log is unknown or can't be combined with the
+// other options given.
+func validateLogFormat(apr *argparser.ArgParseResults) error {
+	format, ok := apr.GetValue(cli.FormatFlag)
+	if !ok {
+		return nil
+	}
+	if format != logFormatDot && format != logFormatJson {
+		return fmt.Errorf("error: invalid --%s '%s', valid options are %s and %s", cli.FormatFlag, format, logFormatDot, logFormatJson)
+	}
+	if apr.Contains(cli.GraphFlag) || apr.Contains(cli.OneLineFlag) {
+		return fmt.Errorf("error: --%s cannot be used with --%s or --%s", cli.FormatFlag, cli.GraphFlag, cli.OneLineFlag)
+	}
+	return nil
+}
+
+// logExportCommit is a node of an exported commit graph.
+type logExportCommit struct {
+	CommitHash string   `json:"commit_hash"`
+	Parents    []string `json:"parents"`
+	Refs       []string `json:"refs"`
+	Committer  string   `json:"committer"`
+	Email      string   `json:"email"`
+	Date       string   `json:"date"`
+	Message    string   `json:"message"`
+	// TablesChanged is the number of tables the commit changed relative to its first parent. It is only set with
+	// --stat, and never for commits without parents.
+	TablesChanged *int `json:"tables_changed,omitempty"`
+}
+
+// logExportEdge is an edge of an exported commit graph, from a commit to one of its parents. Edges are only
+// exported to parents that are part of the log.
+type logExportEdge struct {
+	From        string `json:"from"`
+	To          string `json:"to"`
+	ParentIndex int    `json:"parent_index"`
+}
+
+// logExport is the commit graph of a log, as exported by dolt log --format.
+type logExport struct {
+	Commits []logExportCommit `json:"commits"`
+	Edges   []logExportEdge   `json:"edges"`
+}
+
+// newLogExport returns the commit graph of |commits|. |tablesChanged| holds the number of tables changed by each
+// commit, and is nil when the graph isn't annotated.
+func newLogExport(commits []CommitInfo, tablesChanged map[string]int) logExport {
+	inLog := make(map[string]bool, len(commits))
+	for _, comm := range commits {
+		inLog[comm.commitHash] = true
+	}
+
+	export := logExport{Commits: []logExportCommit{}, Edges: []logExportEdge{}}
+	for _, comm := range commits {
+		node := logExportCommit{
+			CommitHash: comm.commitHash,
+			Parents:    comm.parentHashes,
+			Refs:       logExportRefs(comm),
+			Committer:  comm.commitMeta.Name,
+			Email:      comm.commitMeta.Email,
+			Date:       comm.commitMeta.Time().UTC().Format(time.RFC3339),
+			Message:    comm.commitMeta.Description,
+		}
+		if node.Parents == nil {
+			node.Parents = []string{}
+		}
+		if n, ok := tablesChanged[comm.commitHash]; ok {
+			node.TablesChanged = &n
+		}
+		export.Commits = append(export.Commits, node)
+
+		for i, parent := range comm.parentHashes {
+			if inLog[parent] {
+				export.Edges = append(export.Edges, logExportEdge{From: comm.commitHash, To: parent, ParentIndex: i})
+			}
+		}
+	}
+	return export
+}
+
+// logExportRefs returns the refs pointing at |comm| as they are decorated in dolt log.
+func logExportRefs(comm CommitInfo) []string {
+	refs := []string{}
+	if comm.isHead {
+		refs = append(refs, "HEAD")
+	}
+	refs = append(refs, comm.localBranchNames...)
+	refs = append(refs, comm.remoteBranchNames...)
+	for _, t := range comm.tagNames {
+		refs = append(refs, "tag: "+t)
+	}
+	return refs
+}
+
+// dot returns the graph in the Graphviz DOT language. Each commit points at its parents, and the edges to the
+// parents that were merged in are dashed.
+func (e logExport) dot() string {
+	var sb strings.Builder
+	sb.WriteString("digraph dolt_log {\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, c := range e.Commits {
+		shortHash := c.CommitHash
+		if len(shortHash) > logExportShortHashLen {
+			shortHash = shortHash[:logExportShortHashLen]
+		}
+		message, _, _ := strings.Cut(c.Message, "\n")
+		label := []string{shortHash, message}
+		if len(c.Refs) > 0 {
+			label = append(label, "("+strings.Join(c.Refs, ", ")+")")
+		}
+		if c.TablesChanged != nil {
+			label = append(label, fmt.Sprintf("%d tables changed", *c.TablesChanged))
+		}
+		for i := range label {
+			label[i] = dotEscape(label[i])
+		}
+		sb.WriteString(fmt.Sprintf("  \"%s\" [label=\"%s\"];\n", c.CommitHash, strings.Join(label, `\n`)))
+	}
+	for _, edge := range e.Edges {
+		if edge.ParentIndex > 0 {
+			sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\" [style=dashed];\n", edge.From, edge.To))
+		} else {
+			sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\";\n", edge.From, edge.To))
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotEscape escapes |s| for use in a quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`).Replace(s)
+}
+
+// logExportGraph writes the commit graph of |commits| to |pager| in the format given by --format.
+func logExportGraph(pager *outputpager.Pager, apr *argparser.ArgParseResults, commits []CommitInfo, sqlCtx *sql.Context, queryist cli.Queryist) error {
+	var tablesChanged map[string]int
+	if apr.Contains(cli.StatFlag) {
+		tablesChanged = make(map[string]int, len(commits))
+		for _, comm := range commits {
+			if len(comm.parentHashes) == 0 {
+				continue
+			}
+			n, err := countChangedTables(queryist, sqlCtx, comm.parentHashes[0], comm.commitHash)
+			if err != nil {
+				return err
+			}
+			tablesChanged[comm.commitHash] = n
+		}
+	}
+
+	export := newLogExport(commits, tablesChanged)
+	if apr.MustGetValue(cli.FormatFlag) == logFormatDot {
+		pager.Writer.Write([]byte(export.dot()))
+		return nil
+	}
+
+	out, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	pager.Writer.Write(append(out, '\n'))
+	return nil
+}
+
+// countChangedTables returns the number of tables added, dropped, modified or renamed between two refs, ignoring
+// the same tables dolt log --stat does.
+func countChangedTables(queryist cli.Queryist, sqlCtx *sql.Context, fromRef, toRef string) (int, error) {
+	summaries, err := getDiffSummariesBetweenRefs(queryist, sqlCtx, fromRef, toRef)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, summary := range summaries {
+		if doltdb.IsFullTextTable(summary.TableName.Name) || strings.HasPrefix(summary.TableName.Name, diff.DBPrefix) {
+			continue
+		}
+		switch summary.DiffType {
+		case "added", "dropped", "modified", "renamed":
+			changed++
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+func testLogExportCommits() []CommitInfo {
+	return []CommitInfo{
+		{
+			commitHash:       "mergehash0000000",
+			parentHashes:     []string{"mainhash00000000", "branchhash000000"},
+			isHead:           true,
+			localBranchNames: []string{"main"},
+			tagNames:         []string{"v1"},
+			commitMeta:       &datas.CommitMeta{Name: "Bob", Email: "bob@example.com", Description: "Merge branch 'b' into main\n\ndetails"},
+		},
+		{
+			commitHash:   "branchhash000000",
+			parentHashes: []string{"roothash00000000"},
+			commitMeta:   &datas.CommitMeta{Name: "Bob", Email: "bob@example.com", Description: `add "quoted" rows`},
+		},
+		{
+			commitHash:   "mainhash00000000",
+			parentHashes: []string{"roothash00000000"},
+			commitMeta:   &datas.CommitMeta{Name: "Bob", Email: "bob@example.com", Description: "main commit"},
+		},
+	}
+}
+
+func TestLogExportDot(t *testing.T) {
+	export := newLogExport(testLogExportCommits(), map[string]int{"mergehash0000000": 2})
+	expected := `digraph dolt_log {
+  node [shape=box];
+  "mergehash0000000" [label="mergehas\nMerge branch 'b' into main\n(HEAD, main, tag: v1)\n2 tables changed"];
+  "branchhash000000" [label="branchha\nadd \"quoted\" rows"];
+  "mainhash00000000" [label="mainhash\nmain commit"];
+  "mergehash0000000" -> "mainhash00000000";
+  "mergehash0000000" -> "branchhash000000" [style=dashed];
+}
+`
+	assert.Equal(t, expected, export.dot())
+}
+
+func TestLogExportJson(t *testing.T) {
+	export := newLogExport(testLogExportCommits(), nil)
+	out, err := json.Marshal(export)
+	require.NoError(t, err)
+
+	var decoded logExport
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	require.Len(t, decoded.Commits, 3)
+	assert.Equal(t, []string{"HEAD", "main", "tag: v1"}, decoded.Commits[0].Refs)
+	assert.Equal(t, []string{"roothash00000000"}, decoded.Commits[1].Parents)
+	assert.Nil(t, decoded.Commits[0].TablesChanged)
+	assert.NotContains(t, string(out), "tables_changed")
+
+	// edges to parents outside of the log are left out
+	assert.Equal(t, []logExportEdge{
+		{From: "mergehash0000000", To: "mainhash00000000", ParentIndex: 0},
+		{From: "mergehash0000000", To: "branchhash000000", ParentIndex: 1},
+	}, decoded.Edges)
+}
@@ -17,8 +17,10 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dustin/go-humanize"
 	"github.com/gocraft/dbr/v2"
 	"github.com/gocraft/dbr/v2/dialect"
 
@@ -32,7 +34,7 @@ import (
 
 var lsDocs = cli.CommandDocumentationContent{
 	ShortDesc: "List tables",
-	LongDesc: `With no arguments lists the tables in the current working set but if a commit is specified it will list the tables in that commit.  If the {{.EmphasisLeft}}--verbose{{.EmphasisRight}} flag is provided a row count of the table will also be displayed.
+	LongDesc: `With no arguments lists the tables in the current working set but if a commit is specified it will list the tables in that commit.  If the {{.EmphasisLeft}}--verbose{{.EmphasisRight}} flag is provided the row count and estimated size of each table will also be displayed.
 
 If the {{.EmphasisLeft}}--system{{.EmphasisRight}} flag is supplied this will show the dolt system tables which are queryable with SQL.
 
@@ -63,7 +65,7 @@ func (cmd LsCmd) Docs() *cli.CommandDocumentation {
 
 func (cmd LsCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
-	ap.SupportsFlag(cli.VerboseFlag, "v", "show the hash of the table and row count")
+	ap.SupportsFlag(cli.VerboseFlag, "v", "show the row count and estimated size of each table")
 	ap.SupportsFlag(cli.SystemFlag, "s", "show system tables")
 	ap.SupportsFlag(cli.AllFlag, "a", "show user and system tables")
 	return ap
@@ -180,10 +182,19 @@ func printUserTables(tableNames []string, apr *argparser.ArgParseResults, queryi
 		return nil
 	}
 
+	var sizes map[string]uint64
+	if apr.Contains(cli.VerboseFlag) {
+		var err error
+		sizes, err = getTableSizes(apr, label, queryist, sqlCtx)
+		if err != nil {
+			return err
+		}
+	}
+
 	cli.Printf("Tables in %s:\n", label)
 	for _, tbl := range tableNames {
 		if apr.Contains(cli.VerboseFlag) {
-			err := printTableVerbose(tbl, queryist, sqlCtx)
+			err := printTableVerbose(tbl, apr, sizes[tbl], queryist, sqlCtx)
 			if err != nil {
 				return err
 			}
@@ -195,21 +206,63 @@ func printUserTables(tableNames []string, apr *argparser.ArgParseResults, queryi
 	return nil
 }
 
-func printTableVerbose(table string, queryist cli.Queryist, sqlCtx *sql.Context) error {
+// getTableSizes returns the estimated data length of each table in the working set, or in the commit |commitHash|
+// if a commit was specified.
+func getTableSizes(apr *argparser.ArgParseResults, commitHash string, queryist cli.Queryist, sqlCtx *sql.Context) (map[string]uint64, error) {
+	query := "show table status"
+	if apr.NArg() == 1 {
+		rows, err := GetRowsForSql(queryist, sqlCtx, "select database()")
+		if err != nil {
+			return nil, err
+		}
+		dbName, ok := rows[0][0].(string)
+		if !ok {
+			return nil, fmt.Errorf("no database selected")
+		}
+		// tables at a commit are read from the revision database of the commit
+		dbName, _, _ = strings.Cut(dbName, "/")
+		query = fmt.Sprintf("show table status from `%s/%s`", dbName, commitHash)
+	}
+
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]uint64, len(rows))
+	for _, row := range rows {
+		name, ok := row[0].(string)
+		if !ok || row[6] == nil {
+			continue
+		}
+		size, err := getUint64ColAsUint64(row[6])
+		if err != nil {
+			return nil, err
+		}
+		sizes[name] = size
+	}
+	return sizes, nil
+}
+
+func printTableVerbose(table string, apr *argparser.ArgParseResults, size uint64, queryist cli.Queryist, sqlCtx *sql.Context) error {
 	query := fmt.Sprintf("select count(*) from `%s`", table)
+	if apr.NArg() == 1 {
+		var err error
+		query, err = dbr.InterpolateForDialect(query+" as of ?", []interface{}{apr.Arg(0)}, dialect.MySQL)
+		if err != nil {
+			return err
+		}
+	}
 	row, err := GetRowsForSql(queryist, sqlCtx, query)
 	if err != nil {
 		return err
 	}
 
-	if cnt, ok := row[0][0].(int64); ok {
-		cli.Println(fmt.Sprintf("\t%-20s     %d rows", table, cnt))
-	} else if cnt, ok := row[0][0].(string); ok {
-		// remote execution returns result as a string
-		cli.Println(fmt.Sprintf("\t%-20s     %s rows", table, cnt))
-	} else {
+	// remote execution returns the count as a string
+	cnt, err := getInt64ColAsInt64(row[0][0])
+	if err != nil {
 		return fmt.Errorf("unexpected type for count: %T", row[0][0])
 	}
+	cli.Println(fmt.Sprintf("\t%-20s     %d rows     %s", table, cnt, humanize.Bytes(size)))
 
 	return nil
 }
@@ -48,11 +48,13 @@ var mergeDocs = cli.CommandDocumentationContent{
 
 The second syntax ({{.LessThan}}dolt merge --abort{{.GreaterThan}}) can only be run after the merge has resulted in conflicts. dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will abort the merge process and try to reconstruct the pre-merge state. However, if there were uncommitted changes when the merge started (and especially if those changes were further modified after the merge was started), dolt merge {{.EmphasisLeft}}--abort{{.EmphasisRight}} will in some cases be unable to reconstruct the original (pre-merge) changes. Therefore: 
 
+Rows changed on both sides of the merge are merged cell by cell, and a row with a cell changed differently on both sides is reported as a conflict. The {{.EmphasisLeft}}--strategy{{.EmphasisRight}} option resolves such rows automatically instead: {{.EmphasisLeft}}ours{{.EmphasisRight}} keeps the version of each conflicting row on the current branch, and {{.EmphasisLeft}}theirs{{.EmphasisRight}} takes its version from the branch being merged. Schema conflicts and constraint violations are still reported for any strategy.
+
 {{.LessThan}}Warning{{.GreaterThan}}: Running dolt merge with non-trivial uncommitted changes is discouraged: while possible, it may leave you in a state that is hard to back out of in the case of a conflict.
 `,
 
 	Synopsis: []string{
-		"[--squash] {{.LessThan}}branch{{.GreaterThan}}",
+		"[--squash] [-s strategy] {{.LessThan}}branch{{.GreaterThan}}",
 		"--no-ff [-m message] {{.LessThan}}branch{{.GreaterThan}}",
 		"--abort",
 	},
@@ -284,6 +286,11 @@ func constructInterpolatedDoltMergeQuery(apr *argparser.ArgParseResults, cliCtx
 	if apr.Contains(cli.NoEditFlag) {
 		writeToBuffer("--no-edit", false)
 	}
+	if strategy, ok := apr.GetValue(cli.StrategyParam); ok {
+		writeToBuffer("--strategy", false)
+		writeToBuffer("?", true)
+		params = append(params, strategy)
+	}
 
 	writeToBuffer("--author", false)
 	var author string
@@ -40,10 +40,21 @@ var migrateDocs = cli.CommandDocumentationContent{
 	LongDesc: `Migrate is a multi-purpose command to update the data format of a Dolt database. Over time, development 
 on Dolt requires changes to the on-disk data format. These changes are necessary to improve Database performance and 
 correctness. Migrating to the latest format is therefore necessary for compatibility with the latest Dolt clients, and
-to take advantage of the newly released Dolt features.`,
+to take advantage of the newly released Dolt features.
+
+{{.EmphasisLeft}}dolt migrate up{{.EmphasisRight}} and {{.EmphasisLeft}}dolt migrate down{{.EmphasisRight}} run versioned schema migrations instead. Migrations are stored
+in the {{.EmphasisLeft}}dolt_migrations{{.EmphasisRight}} system table, and can be loaded from a directory of files named
+{{.EmphasisLeft}}V<version>__<description>.sql{{.EmphasisRight}}, with optional {{.EmphasisLeft}}U<version>__<description>.sql{{.EmphasisRight}} files to revert them. {{.EmphasisLeft}}up{{.EmphasisRight}} applies
+each pending migration in version order in its own commit, tagged {{.EmphasisLeft}}migration-<version>{{.EmphasisRight}}. {{.EmphasisLeft}}down{{.EmphasisRight}} reverts the
+latest applied migration, or every migration newer than {{.EmphasisLeft}}--target{{.EmphasisRight}}. Each script runs in a transaction with
+{{.EmphasisLeft}}dolt_transactional_ddl{{.EmphasisRight}} enabled, so a script that fails partway through changes nothing. Because applied migrations are
+recorded in a versioned table, merging a branch brings its applied migrations along with it. If a merge adds a pending
+migration older than the latest applied one, {{.EmphasisLeft}}up{{.EmphasisRight}} refuses to apply it unless {{.EmphasisLeft}}--out-of-order{{.EmphasisRight}} is given.`,
 
 	Synopsis: []string{
-		"[ --push ] [ --pull ]",
+		"[--drop-conflicts]",
+		"up [--dir {{.LessThan}}directory{{.GreaterThan}}] [--target {{.LessThan}}version{{.GreaterThan}}] [--out-of-order]",
+		"down [--dir {{.LessThan}}directory{{.GreaterThan}}] [--target {{.LessThan}}version{{.GreaterThan}}]",
 	},
 }
 
@@ -64,8 +75,11 @@ func (cmd MigrateCmd) Docs() *cli.CommandDocumentation {
 }
 
 func (cmd MigrateCmd) ArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
 	ap.SupportsFlag(migrateDropConflictsFlag, "", "Drop any conflicts visited during the migration")
+	ap.SupportsString(migrateDirParam, "", "directory", "Load migrations from the given directory into the dolt_migrations table before running them")
+	ap.SupportsString(migrateTargetParam, "", "version", "For up, the latest migration to apply. For down, the migration to revert to")
+	ap.SupportsFlag(migrateOutOfOrderFlag, "", "Apply pending migrations older than the latest applied migration")
 	return ap
 }
 
@@ -80,6 +94,20 @@ func (cmd MigrateCmd) Exec(ctx context.Context, commandStr string, args []string
 	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, migrateDocs, ap))
 	apr := cli.ParseArgsOrDie(ap, args, help)
 
+	if apr.NArg() > 0 {
+		queryist, sqlCtx, closeFunc, err := cliCtx.QueryEngine(ctx)
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+		}
+		if closeFunc != nil {
+			defer closeFunc()
+		}
+		if err = runSchemaMigrations(queryist, sqlCtx, apr); err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("migration failed").AddCause(err).Build(), usage)
+		}
+		return 0
+	}
+
 	dropConflicts := apr.Contains(migrateDropConflictsFlag)
 	if err := MigrateDatabase(ctx, dEnv, dropConflicts); err != nil {
 		verr := errhand.BuildDError("migration failed").AddCause(err).Build()
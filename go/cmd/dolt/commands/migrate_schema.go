@@ -0,0 +1,424 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+const (
+	migrateUpCmd   = "up"
+	migrateDownCmd = "down"
+
+	migrateDirParam       = "dir"
+	migrateTargetParam    = "target"
+	migrateOutOfOrderFlag = "out-of-order"
+
+	// migrationTagPrefix is the prefix of the tags created on the commits that apply migrations.
+	migrationTagPrefix = "migration-"
+)
+
+// migrationFileRegex matches the names of migration files in a migrations directory, following Flyway's naming
+// conventions: V<version>__<description>.sql applies a migration and U<version>__<description>.sql reverts it.
+var migrationFileRegex = regexp.MustCompile(`^([VvUu])(\d+)__(.+)\.sql$`)
+
+// schemaMigration is a single row of the dolt_migrations system table.
+type schemaMigration struct {
+	version     int64
+	description string
+	upScript    string
+	downScript  string
+	applied     bool
+}
+
+// migrationTagName returns the name of the tag created on the commit that applied migration |version|.
+func migrationTagName(version int64) string {
+	return migrationTagPrefix + strconv.FormatInt(version, 10)
+}
+
+// migrationCommitMessage returns the commit message used when applying or reverting |m|.
+func migrationCommitMessage(m schemaMigration, revert bool) string {
+	verb := "Apply"
+	if revert {
+		verb = "Revert"
+	}
+	if m.description == "" {
+		return fmt.Sprintf("%s migration %d", verb, m.version)
+	}
+	return fmt.Sprintf("%s migration %d: %s", verb, m.version, m.description)
+}
+
+// runSchemaMigrations runs `dolt migrate up` or `dolt migrate down`, applying or reverting the versioned migrations
+// stored in the dolt_migrations system table. Each migration is applied in its own commit, tagged with the migration
+// version.
+func runSchemaMigrations(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults) error {
+	subCmd := strings.ToLower(apr.Arg(0))
+	if subCmd != migrateUpCmd && subCmd != migrateDownCmd {
+		return fmt.Errorf("unknown migrate command '%s', expected '%s' or '%s'", apr.Arg(0), migrateUpCmd, migrateDownCmd)
+	}
+
+	target := int64(-1)
+	if targetStr, ok := apr.GetValue(migrateTargetParam); ok {
+		var err error
+		target, err = strconv.ParseInt(targetStr, 10, 64)
+		if err != nil || target < 0 {
+			return fmt.Errorf("invalid migration version '%s' for --%s", targetStr, migrateTargetParam)
+		}
+	}
+
+	if err := assertCleanWorkingSet(queryist, sqlCtx); err != nil {
+		return err
+	}
+	// DDL statements commit the transaction they run in unless this is enabled, see runMigrationScript
+	if _, err := GetRowsForSql(queryist, sqlCtx, fmt.Sprintf("SET @@SESSION.%s = 1", dsess.TransactionalDDL)); err != nil {
+		return err
+	}
+
+	if err := runMigrationsInCleanWorkingSet(queryist, sqlCtx, apr, subCmd, target); err != nil {
+		// Discard anything the failed migration left behind, such as a migration whose script was committed but whose
+		// Dolt commit failed. The working set was clean when we began, so this can't lose any of the user's changes.
+		if _, resetErr := GetRowsForSql(queryist, sqlCtx, "CALL DOLT_RESET('--hard')"); resetErr != nil {
+			return fmt.Errorf("%w; additionally, discarding the changes of the failed migration failed: %s", err, resetErr.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+// runMigrationsInCleanWorkingSet loads migrations from the migrations directory, if given, and runs |subCmd|.
+func runMigrationsInCleanWorkingSet(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgParseResults, subCmd string, target int64) error {
+	if dir, ok := apr.GetValue(migrateDirParam); ok {
+		migrations, err := readMigrationsDir(dir)
+		if err != nil {
+			return err
+		}
+		if err = loadMigrations(queryist, sqlCtx, migrations); err != nil {
+			return err
+		}
+	}
+
+	migrations, err := getSchemaMigrations(queryist, sqlCtx)
+	if err != nil {
+		return err
+	}
+	if subCmd == migrateUpCmd {
+		return migrateUp(queryist, sqlCtx, migrations, target, apr.Contains(migrateOutOfOrderFlag))
+	}
+	return migrateDown(queryist, sqlCtx, migrations, target)
+}
+
+// assertCleanWorkingSet returns an error if the current branch has uncommitted changes, since each migration is
+// committed along with every other change in the working set.
+func assertCleanWorkingSet(queryist cli.Queryist, sqlCtx *sql.Context) error {
+	rows, err := GetRowsForSql(queryist, sqlCtx, "SELECT table_name FROM dolt_status")
+	if err != nil {
+		return err
+	}
+	if len(rows) > 0 {
+		return fmt.Errorf("cannot run migrations with uncommitted changes, commit or discard them first")
+	}
+	return nil
+}
+
+// readMigrationsDir reads the migration files in |dir|, returning the migrations sorted by version.
+func readMigrationsDir(dir string) ([]schemaMigration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory '%s': %w", dir, err)
+	}
+
+	byVersion := make(map[int64]*schemaMigration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in file name '%s': %w", entry.Name(), err)
+		}
+		script, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &schemaMigration{version: version}
+			byVersion[version] = m
+		}
+		if strings.EqualFold(match[1], "V") {
+			if m.upScript != "" {
+				return nil, fmt.Errorf("found more than one migration with version %d in '%s'", version, dir)
+			}
+			m.upScript = string(script)
+			m.description = strings.ReplaceAll(match[3], "_", " ")
+		} else {
+			if m.downScript != "" {
+				return nil, fmt.Errorf("found more than one undo migration with version %d in '%s'", version, dir)
+			}
+			m.downScript = string(script)
+		}
+	}
+
+	migrations := make([]schemaMigration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.upScript == "" {
+			return nil, fmt.Errorf("undo migration %d in '%s' has no matching migration", m.version, dir)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+// loadMigrations adds the migrations read from a migrations directory to the dolt_migrations table, updating the
+// scripts of existing migrations. The script of a migration that has already been applied can't be changed.
+func loadMigrations(queryist cli.Queryist, sqlCtx *sql.Context, migrations []schemaMigration) error {
+	existing, err := getSchemaMigrations(queryist, sqlCtx)
+	if err != nil {
+		return err
+	}
+	applied := make(map[int64]schemaMigration)
+	for _, m := range existing {
+		if m.applied {
+			applied[m.version] = m
+		}
+	}
+
+	for _, m := range migrations {
+		if prev, ok := applied[m.version]; ok && prev.upScript != m.upScript {
+			return fmt.Errorf("migration %d has changed since it was applied, revert it with `dolt migrate down` before changing it", m.version)
+		}
+		_, err = InterpolateAndRunQuery(queryist, sqlCtx, fmt.Sprintf(
+			"INSERT INTO %s (%s, %s, %s, %s) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE %s = VALUES(%s), %s = VALUES(%s), %s = VALUES(%s)",
+			doltdb.MigrationsTableName,
+			doltdb.MigrationsVersionCol, doltdb.MigrationsDescriptionCol, doltdb.MigrationsUpScriptCol, doltdb.MigrationsDownScriptCol,
+			doltdb.MigrationsDescriptionCol, doltdb.MigrationsDescriptionCol,
+			doltdb.MigrationsUpScriptCol, doltdb.MigrationsUpScriptCol,
+			doltdb.MigrationsDownScriptCol, doltdb.MigrationsDownScriptCol),
+			m.version, m.description, m.upScript, m.downScript)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSchemaMigrations returns the rows of the dolt_migrations table, sorted by version.
+func getSchemaMigrations(queryist cli.Queryist, sqlCtx *sql.Context) ([]schemaMigration, error) {
+	rows, err := GetRowsForSql(queryist, sqlCtx, fmt.Sprintf("SELECT %s, %s, %s, %s, %s IS NOT NULL FROM %s ORDER BY %s",
+		doltdb.MigrationsVersionCol, doltdb.MigrationsDescriptionCol, doltdb.MigrationsUpScriptCol,
+		doltdb.MigrationsDownScriptCol, doltdb.MigrationsAppliedAtCol, doltdb.MigrationsTableName, doltdb.MigrationsVersionCol))
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]schemaMigration, len(rows))
+	for i, row := range rows {
+		m := &migrations[i]
+		if m.version, err = getInt64ColAsInt64(row[0]); err != nil {
+			return nil, err
+		}
+		if m.description, err = unwrapOptionalString(sqlCtx, row[1]); err != nil {
+			return nil, err
+		}
+		if m.upScript, err = unwrapOptionalString(sqlCtx, row[2]); err != nil {
+			return nil, err
+		}
+		if m.downScript, err = unwrapOptionalString(sqlCtx, row[3]); err != nil {
+			return nil, err
+		}
+		if m.applied, err = GetTinyIntColAsBool(row[4]); err != nil {
+			return nil, err
+		}
+	}
+	return migrations, nil
+}
+
+func unwrapOptionalString(sqlCtx *sql.Context, val interface{}) (string, error) {
+	if val == nil {
+		return "", nil
+	}
+	s, _, err := sql.Unwrap[string](sqlCtx, val)
+	return s, err
+}
+
+// migrateUp applies every migration that has not been applied yet, up to and including |target| if it is not
+// negative. Migrations are applied in version order. After a merge brings in migrations from another branch, a
+// pending migration may be older than the latest applied one. Applying it is an error unless |outOfOrder| is set.
+func migrateUp(queryist cli.Queryist, sqlCtx *sql.Context, migrations []schemaMigration, target int64, outOfOrder bool) error {
+	latestApplied := int64(-1)
+	for _, m := range migrations {
+		if m.applied && m.version > latestApplied {
+			latestApplied = m.version
+		}
+	}
+
+	var pending []schemaMigration
+	for _, m := range migrations {
+		if m.applied || (target >= 0 && m.version > target) {
+			continue
+		}
+		if m.version < latestApplied && !outOfOrder {
+			return fmt.Errorf("migration %d is older than the latest applied migration %d, "+
+				"use --%s to apply it anyway", m.version, latestApplied, migrateOutOfOrderFlag)
+		}
+		pending = append(pending, m)
+	}
+
+	if len(pending) == 0 {
+		cli.Println("No migrations to apply")
+		return nil
+	}
+
+	for _, m := range pending {
+		cli.Printf("Applying migration %d\n", m.version)
+		record := fmt.Sprintf("UPDATE %s SET %s = NOW() WHERE %s = %d",
+			doltdb.MigrationsTableName, doltdb.MigrationsAppliedAtCol, doltdb.MigrationsVersionCol, m.version)
+		if err := runMigrationScript(queryist, sqlCtx, m.upScript, record); err != nil {
+			return fmt.Errorf("migration %d failed: %w", m.version, err)
+		}
+		if err := commitMigration(queryist, sqlCtx, m, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateDown reverts applied migrations in descending version order. If |target| is negative, only the latest
+// applied migration is reverted, otherwise every applied migration newer than |target| is reverted.
+func migrateDown(queryist cli.Queryist, sqlCtx *sql.Context, migrations []schemaMigration, target int64) error {
+	var toRevert []schemaMigration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if !m.applied {
+			continue
+		}
+		if target < 0 {
+			toRevert = append(toRevert, m)
+			break
+		}
+		if m.version > target {
+			toRevert = append(toRevert, m)
+		}
+	}
+
+	if len(toRevert) == 0 {
+		cli.Println("No migrations to revert")
+		return nil
+	}
+
+	for _, m := range toRevert {
+		if strings.TrimSpace(m.downScript) == "" {
+			return fmt.Errorf("migration %d has no down script and cannot be reverted", m.version)
+		}
+		cli.Printf("Reverting migration %d\n", m.version)
+		record := fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s = %d",
+			doltdb.MigrationsTableName, doltdb.MigrationsAppliedAtCol, doltdb.MigrationsVersionCol, m.version)
+		if err := runMigrationScript(queryist, sqlCtx, m.downScript, record); err != nil {
+			return fmt.Errorf("reverting migration %d failed: %w", m.version, err)
+		}
+		if err := commitMigration(queryist, sqlCtx, m, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runMigrationScript executes each statement of |script|, followed by |record|, which records the migration in the
+// dolt_migrations table, in a single transaction. DDL statements join the transaction because dolt_transactional_ddl
+// is enabled, so if any statement fails, the transaction is rolled back and the script has no effect.
+func runMigrationScript(queryist cli.Queryist, sqlCtx *sql.Context, script string, record string) error {
+	if _, err := GetRowsForSql(queryist, sqlCtx, "START TRANSACTION"); err != nil {
+		return err
+	}
+	if err := runMigrationStatements(queryist, sqlCtx, script, record); err != nil {
+		if _, rollbackErr := GetRowsForSql(queryist, sqlCtx, "ROLLBACK"); rollbackErr != nil {
+			return fmt.Errorf("%w; additionally, rolling back the transaction failed: %s", err, rollbackErr.Error())
+		}
+		return err
+	}
+	_, err := GetRowsForSql(queryist, sqlCtx, "COMMIT")
+	return err
+}
+
+// runMigrationStatements executes each statement of |script|, then |record|.
+func runMigrationStatements(queryist cli.Queryist, sqlCtx *sql.Context, script string, record string) error {
+	scanner := NewStreamScanner(strings.NewReader(script))
+	for scanner.Scan() {
+		query := scanner.Text()
+		if strings.TrimSpace(query) == "" {
+			continue
+		}
+		if _, err := GetRowsForSql(queryist, sqlCtx, query); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	_, err := GetRowsForSql(queryist, sqlCtx, record)
+	return err
+}
+
+// commitMigration commits the working set after applying or reverting |m|. The commit that applies a migration is
+// tagged with its version, and the tag is removed when the migration is reverted.
+func commitMigration(queryist cli.Queryist, sqlCtx *sql.Context, m schemaMigration, revert bool) error {
+	_, err := InterpolateAndRunQuery(queryist, sqlCtx, "CALL DOLT_COMMIT('-A', '-m', ?)", migrationCommitMessage(m, revert))
+	if err != nil {
+		return err
+	}
+
+	tagName := migrationTagName(m.version)
+	rows, err := InterpolateAndRunQuery(queryist, sqlCtx, "SELECT tag_name FROM dolt_tags WHERE tag_name = ?", tagName)
+	if err != nil {
+		return err
+	}
+	tagExists := len(rows) > 0
+
+	if revert {
+		if tagExists {
+			_, err = InterpolateAndRunQuery(queryist, sqlCtx, "CALL DOLT_TAG('-d', ?)", tagName)
+		}
+		return err
+	}
+
+	if tagExists {
+		// The migration was applied on another branch that has since been merged, or the tag was created by hand.
+		cli.PrintErrf("Tag %s already exists, not tagging the commit that applied migration %d\n", tagName, m.version)
+		return nil
+	}
+	_, err = InterpolateAndRunQuery(queryist, sqlCtx, "CALL DOLT_TAG(?, 'HEAD')", tagName)
+	return err
+}
@@ -262,6 +262,11 @@ func constructInterpolatedDoltPullQuery(apr *argparser.ArgParseResults) (string,
 		args = append(args, "?")
 		params = append(params, user)
 	}
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		args = append(args, "'--max-bandwidth'")
+		args = append(args, "?")
+		params = append(params, maxBandwidth)
+	}
 
 	query := "call dolt_pull(" + strings.Join(args, ", ") + ")"
 
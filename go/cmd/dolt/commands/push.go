@@ -162,6 +162,11 @@ func constructInterpolatedDoltPushQuery(apr *argparser.ArgParseResults) (string,
 		args = append(args, "?")
 		params = append(params, user)
 	}
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		args = append(args, "'--max-bandwidth'")
+		args = append(args, "?")
+		params = append(params, maxBandwidth)
+	}
 
 	if setUpstream := apr.Contains(cli.SetUpstreamFlag); setUpstream {
 		args = append(args, "'--set-upstream'")
@@ -26,7 +26,6 @@ import (
 	"github.com/gocraft/dbr/v2/dialect"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
-	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
 	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
@@ -57,13 +56,28 @@ GCP remote urls should be of the form gs://gcs-bucket/database and will use the
 
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_scheme
 
+http and https remotes can be configured using the optional parameters {{.EmphasisLeft}}cred{{.EmphasisRight}}, {{.EmphasisLeft}}timeout{{.EmphasisRight}} and {{.EmphasisLeft}}concurrency{{.EmphasisRight}}. cred names the stored credentials, as listed by {{.EmphasisLeft}}dolt creds ls{{.EmphasisRight}}, which are used to authenticate with the remote instead of the default credentials. timeout limits how long each request to the remote can take, such as 30s. concurrency limits the number of concurrent downloads from the remote.
+
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
-Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
+Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.
+
+{{.EmphasisLeft}}rename{{.EmphasisRight}}
+Rename the remote named {{.LessThan}}old{{.GreaterThan}} to {{.LessThan}}new{{.GreaterThan}}. All remote-tracking branches of the remote are renamed.
+
+{{.EmphasisLeft}}set-url{{.EmphasisRight}}
+Change the url of the remote named {{.LessThan}}name{{.GreaterThan}}.
+
+{{.EmphasisLeft}}set{{.EmphasisRight}}
+Change the optional parameters of the remote named {{.LessThan}}name{{.GreaterThan}}. A parameter given an empty value is removed.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
 		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"add [--cred {{.LessThan}}key id{{.GreaterThan}}] [--timeout {{.LessThan}}duration{{.GreaterThan}}] [--concurrency {{.LessThan}}n{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
+		"rename {{.LessThan}}old{{.GreaterThan}} {{.LessThan}}new{{.GreaterThan}}",
+		"set-url {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"set [--cred {{.LessThan}}key id{{.GreaterThan}}] [--timeout {{.LessThan}}duration{{.GreaterThan}}] [--concurrency {{.LessThan}}n{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}}",
 	},
 }
 
@@ -71,6 +85,9 @@ const (
 	addRemoteId         = "add"
 	removeRemoteId      = "remove"
 	removeRemoteShortId = "rm"
+	renameRemoteId      = "rename"
+	setUrlRemoteId      = "set-url"
+	setRemoteId         = "set"
 )
 
 type RemoteCmd struct{}
@@ -93,14 +110,6 @@ func (cmd RemoteCmd) Docs() *cli.CommandDocumentation {
 func (cmd RemoteCmd) ArgParser() *argparser.ArgParser {
 	ap := cli.CreateRemoteArgParser()
 	ap.SupportsFlag(cli.VerboseFlag, "v", "When printing the list of remotes adds additional details.")
-
-	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "Cloud provider region associated with this remote.")
-	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "Credential type. Valid options are role, env, and file. See the help section for additional details.", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, dbfactory.AWSCredTypes))
-	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
-	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
-
-	ap.SupportsString(dbfactory.OSSCredsFileParam, "", "file", "OSS credentials file")
-	ap.SupportsString(dbfactory.OSSCredsProfile, "", "profile", "OSS profile to use")
 	return ap
 }
 
@@ -131,6 +140,12 @@ func (cmd RemoteCmd) Exec(ctx context.Context, commandStr string, args []string,
 		verr = addRemote(sqlCtx, queryist, dEnv, apr)
 	case apr.Arg(0) == removeRemoteId, apr.Arg(0) == removeRemoteShortId:
 		verr = removeRemote(sqlCtx, queryist, apr)
+	case apr.Arg(0) == renameRemoteId:
+		verr = renameRemote(sqlCtx, queryist, apr)
+	case apr.Arg(0) == setUrlRemoteId:
+		verr = setRemoteUrl(sqlCtx, queryist, apr)
+	case apr.Arg(0) == setRemoteId:
+		verr = setRemoteParams(sqlCtx, queryist, apr)
 	default:
 		verr = errhand.BuildDError("").SetPrintUsage().Build()
 	}
@@ -144,7 +159,7 @@ func removeRemote(sqlCtx *sql.Context, qureyist cli.Queryist, apr *argparser.Arg
 	}
 	toRemove := strings.TrimSpace(apr.Arg(1))
 
-	err := callSQLRemoteRemove(sqlCtx, qureyist, toRemove)
+	err := callSQLRemote(sqlCtx, qureyist, []string{removeRemoteId, toRemove})
 	if err != nil {
 		return errhand.BuildDError("error: Unable to remove remote.").AddCause(err).Build()
 	}
@@ -168,42 +183,79 @@ func addRemote(sqlCtx *sql.Context, queryist cli.Queryist, dEnv *env.DoltEnv, ap
 	if verr != nil {
 		return verr
 	}
+	if err = cli.AddGRPCParams(absRemoteUrl, apr, params); err != nil {
+		return errhand.VerboseErrorFromError(err)
+	}
 
-	if len(params) == 0 {
-		err := callSQLRemoteAdd(sqlCtx, queryist, remoteName, remoteUrl)
-		if err != nil {
-			return errhand.BuildDError("error: Unable to add remote.").AddCause(err).Build()
-		}
-	} else {
-		// We only support adding remotes with parameters in the local configuration
-		if _, ok := queryist.(*engine.SqlEngine); !ok {
-			return errhand.BuildDError("error: remote add failed. sql-server running while attempting to use advanced remote parameters. Stop server and re-run").Build()
+	err = callSQLRemote(sqlCtx, queryist, append([]string{addRemoteId, remoteName, remoteUrl}, remoteParamArgs(params)...))
+	if err != nil {
+		return errhand.BuildDError("error: Unable to add remote.").AddCause(err).Build()
+	}
+	return nil
+}
+
+func renameRemote(sqlCtx *sql.Context, queryist cli.Queryist, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	err := callSQLRemote(sqlCtx, queryist, []string{renameRemoteId, strings.TrimSpace(apr.Arg(1)), strings.TrimSpace(apr.Arg(2))})
+	if err != nil {
+		return errhand.BuildDError("error: Unable to rename remote.").AddCause(err).Build()
+	}
+	return nil
+}
+
+func setRemoteUrl(sqlCtx *sql.Context, queryist cli.Queryist, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	err := callSQLRemote(sqlCtx, queryist, []string{setUrlRemoteId, strings.TrimSpace(apr.Arg(1)), apr.Arg(2)})
+	if err != nil {
+		return errhand.BuildDError("error: Unable to set remote url.").AddCause(err).Build()
+	}
+	return nil
+}
+
+func setRemoteParams(sqlCtx *sql.Context, queryist cli.Queryist, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	args := []string{setRemoteId, strings.TrimSpace(apr.Arg(1))}
+	for _, p := range remoteParamNames {
+		if val, ok := apr.GetValue(p); ok {
+			args = append(args, "--"+p, val)
 		}
-		return addRemoteLocaly(remoteName, absRemoteUrl, params, dEnv)
+	}
+
+	err := callSQLRemote(sqlCtx, queryist, args)
+	if err != nil {
+		return errhand.BuildDError("error: Unable to set remote options.").AddCause(err).Build()
 	}
 	return nil
 }
 
-// addRemoteLocal adds a remote to the local configuration, which should only be used in the event that there
-// are AWS/GCP/OSS parameters. These are not supported in the SQL interface
-func addRemoteLocaly(remoteName, remoteUrl string, params map[string]string, dEnv *env.DoltEnv) errhand.VerboseError {
-	rmot := env.NewRemote(remoteName, remoteUrl, params)
-	err := dEnv.AddRemote(rmot)
-
-	switch err {
-	case nil:
-		return nil
-	case env.ErrRemoteAlreadyExists:
-		return errhand.BuildDError("error: a remote named '%s' already exists.", rmot.Name).AddDetails("remove it before running this command again").Build()
-	case env.ErrRemoteNotFound:
-		return errhand.BuildDError("error: unknown remote: '%s' ", rmot.Name).Build()
-	case env.ErrInvalidRemoteURL:
-		return errhand.BuildDError("error: '%s' is not valid.", rmot.Url).AddCause(err).Build()
-	case env.ErrInvalidRemoteName:
-		return errhand.BuildDError("error: invalid remote name: %s", rmot.Name).Build()
-	default:
-		return errhand.BuildDError("error: Unable to save changes.").AddCause(err).Build()
+// remoteParamNames are the names of the options which are persisted as the params of a remote.
+var remoteParamNames = append([]string{
+	dbfactory.AWSRegionParam,
+	dbfactory.AWSCredsTypeParam,
+	dbfactory.AWSCredsFileParam,
+	dbfactory.AWSCredsProfile,
+	dbfactory.OSSCredsFileParam,
+	dbfactory.OSSCredsProfile,
+}, dbfactory.GRPCParams...)
+
+// remoteParamArgs returns the dolt_remote arguments which set |params|.
+func remoteParamArgs(params map[string]string) []string {
+	var args []string
+	for _, p := range remoteParamNames {
+		if val, ok := params[p]; ok {
+			args = append(args, "--"+p, val)
+		}
 	}
+	return args
 }
 
 func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (map[string]string, errhand.VerboseError) {
@@ -225,25 +277,19 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 	return params, nil
 }
 
-// callSQLRemoteAdd calls the SQL function `call `dolt_remote('add', remoteName, remoteUrl)`
-func callSQLRemoteAdd(sqlCtx *sql.Context, queryist cli.Queryist, remoteName, remoteUrl string) error {
-	qry, err := dbr.InterpolateForDialect("call dolt_remote('add', ?, ?)", []interface{}{remoteName, remoteUrl}, dialect.MySQL)
-	if err != nil {
-		return err
+// callSQLRemote calls the SQL procedure `dolt_remote` with |args|
+func callSQLRemote(sqlCtx *sql.Context, queryist cli.Queryist, args []string) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	vals := make([]interface{}, len(args))
+	for i, arg := range args {
+		vals[i] = arg
 	}
-
-	_, err = GetRowsForSql(queryist, sqlCtx, qry)
-	return err
-}
-
-// callSQLRemoteRemove calls the SQL function `call `dolt_remote('remove', remoteName)`
-func callSQLRemoteRemove(sqlCtxe *sql.Context, queryist cli.Queryist, remoteName string) error {
-	qry, err := dbr.InterpolateForDialect("call dolt_remote('remove', ?)", []interface{}{remoteName}, dialect.MySQL)
+	qry, err := dbr.InterpolateForDialect("call dolt_remote("+placeholders+")", vals, dialect.MySQL)
 	if err != nil {
 		return err
 	}
 
-	_, err = GetRowsForSql(queryist, sqlCtxe, qry)
+	_, err = GetRowsForSql(queryist, sqlCtx, qry)
 	return err
 }
 
@@ -17,6 +17,8 @@ package schcmds
 import (
 	"context"
 
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -35,12 +37,16 @@ var tblSchemaDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Shows the schema of one or more tables.",
 	LongDesc: `{{.EmphasisLeft}}dolt schema show{{.EmphasisRight}} displays the schema of tables at a given commit.  If no commit is provided the working set will be used.
 
-A list of tables can optionally be provided.  If it is omitted all table schemas will be shown.`,
+A list of tables can optionally be provided.  If it is omitted all table schemas will be shown.
+
+Each schema is shown as a {{.EmphasisLeft}}CREATE TABLE{{.EmphasisRight}} statement including its indexes, foreign keys and check constraints. If {{.EmphasisLeft}}--ddl-only-changes{{.EmphasisRight}} is given, only tables whose schema differs from the parent of the commit (or from HEAD, for the working set) are shown.`,
 	Synopsis: []string{
-		"[{{.LessThan}}commit{{.GreaterThan}}] [{{.LessThan}}table{{.GreaterThan}}...]",
+		"[--ddl-only-changes] [{{.LessThan}}commit{{.GreaterThan}}] [{{.LessThan}}table{{.GreaterThan}}...]",
 	},
 }
 
+const ddlOnlyChangesFlag = "ddl-only-changes"
+
 var bold = color.New(color.Bold)
 
 type ShowCmd struct{}
@@ -64,6 +70,7 @@ func (cmd ShowCmd) ArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParserWithVariableArgs(cmd.Name())
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "table(s) whose schema is being displayed."})
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "commit at which point the schema will be displayed."})
+	ap.SupportsFlag(ddlOnlyChangesFlag, "", "Only show tables whose schema changed since the parent commit, or since HEAD for the working set.")
 	return ap
 }
 
@@ -140,6 +147,19 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 		opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
 		sqlCtx, engine, _ := dsqle.PrepareCreateTableStmt(ctx, dsqle.NewUserSpaceDatabase(root, opts))
 
+		var baseRoot doltdb.RootValue
+		var baseCtx *sql.Context
+		var baseEngine *sqle.Engine
+		if apr.Contains(ddlOnlyChangesFlag) {
+			baseRoot, verr = schemaShowBaseRoot(ctx, dEnv, cm)
+			if verr != nil {
+				return verr
+			}
+			if baseRoot != nil {
+				baseCtx, baseEngine, _ = dsqle.PrepareCreateTableStmt(ctx, dsqle.NewUserSpaceDatabase(baseRoot, opts))
+			}
+		}
+
 		var notFound []string
 		for _, tblName := range tables {
 			if doltdb.IsFullTextTable(tblName) {
@@ -153,11 +173,20 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 			if !ok {
 				notFound = append(notFound, tblName)
 			} else {
-				cli.Println(bold.Sprint(tblName), "@", cmStr)
 				stmt, err := dsqle.GetCreateTableStmt(sqlCtx, engine, tblName)
 				if err != nil {
 					return errhand.VerboseErrorFromError(err)
 				}
+				if baseRoot != nil {
+					unchanged, err := schemaUnchanged(baseCtx, baseEngine, baseRoot, tblName, stmt)
+					if err != nil {
+						return errhand.BuildDError("unable to get table '%s'", tblName).AddCause(err).Build()
+					}
+					if unchanged {
+						continue
+					}
+				}
+				cli.Println(bold.Sprint(tblName), "@", cmStr)
 				cli.Println(stmt)
 				cli.Println()
 			}
@@ -170,3 +199,45 @@ func printSchemas(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env
 
 	return verr
 }
+
+// schemaShowBaseRoot returns the root that schemas are compared against for --ddl-only-changes: the first parent of
+// |cm|, or HEAD if |cm| is nil. Returns a nil root if |cm| has no parents, in which case every table is new.
+func schemaShowBaseRoot(ctx context.Context, dEnv *env.DoltEnv, cm *doltdb.Commit) (doltdb.RootValue, errhand.VerboseError) {
+	if cm == nil {
+		root, err := dEnv.HeadRoot(ctx)
+		if err != nil {
+			return nil, errhand.BuildDError("unable to get HEAD root value").AddCause(err).Build()
+		}
+		return root, nil
+	}
+
+	if cm.NumParents() == 0 {
+		return nil, nil
+	}
+	optCmt, err := cm.GetParent(ctx, 0)
+	if err != nil {
+		return nil, errhand.BuildDError("unable to get parent commit").AddCause(err).Build()
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, errhand.BuildDError("parent commit %s is a ghost commit", optCmt.Addr.String()).Build()
+	}
+	root, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return nil, errhand.BuildDError("unable to get root value").AddCause(err).Build()
+	}
+	return root, nil
+}
+
+// schemaUnchanged returns whether table |tblName| exists in |baseRoot| with the CREATE TABLE statement |stmt|.
+func schemaUnchanged(ctx *sql.Context, engine *sqle.Engine, baseRoot doltdb.RootValue, tblName string, stmt string) (bool, error) {
+	ok, err := baseRoot.HasTable(ctx, doltdb.TableName{Name: tblName})
+	if err != nil || !ok {
+		return false, err
+	}
+	baseStmt, err := dsqle.GetCreateTableStmt(ctx, engine, tblName)
+	if err != nil {
+		return false, err
+	}
+	return baseStmt == stmt, nil
+}
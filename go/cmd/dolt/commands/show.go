@@ -21,6 +21,9 @@ import (
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/fatih/color"
+	"github.com/gocraft/dbr/v2"
+	"github.com/gocraft/dbr/v2/dialect"
 	"github.com/pkg/errors"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -37,6 +40,9 @@ import (
 
 var hashRegex = regexp.MustCompile(`^#?[0-9a-v]{32}$`)
 
+// defaultShowTableRows is the number of rows shown for a table given as <revision>:<table>, unless --limit is given.
+const defaultShowTableRows = 10
+
 type showOpts struct {
 	showParents bool
 	pretty      bool
@@ -48,9 +54,12 @@ type showOpts struct {
 
 var showDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show information about a specific commit`,
-	LongDesc:  `Show information about a specific commit`,
+	LongDesc: `Show information about a specific commit.
+
+If a table is given as {{.LessThan}}revision{{.GreaterThan}}:{{.LessThan}}table{{.GreaterThan}}, the schema, row count and first rows of the table at that revision are shown instead. The number of rows shown can be changed with {{.EmphasisLeft}}--limit{{.EmphasisRight}}.`,
 	Synopsis: []string{
 		`[{{.LessThan}}revision{{.GreaterThan}}]`,
+		`{{.LessThan}}revision{{.GreaterThan}}:{{.LessThan}}table{{.GreaterThan}}`,
 	},
 }
 
@@ -90,7 +99,7 @@ func (cmd ShowCmd) ArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(SummaryFlag, "", "Show summary of data and schema changes")
 	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json. Defaults to tabular.")
 	ap.SupportsString(whereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
-	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs.")
+	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs, or the first N rows of a table given as <revision>:<table>.")
 	ap.SupportsFlag(cli.CachedFlag, "c", "Show only the staged data changes.")
 	ap.SupportsFlag(SkinnyFlag, "sk", "Shows only primary key columns and any columns with data changes.")
 	ap.SupportsFlag(MergeBase, "", "Uses merge base of the first commit and second commit (or HEAD if not supplied) as the first commit")
@@ -129,7 +138,10 @@ func (cmd ShowCmd) Exec(ctx context.Context, commandStr string, args []string, d
 
 	resolvedRefs := make([]string, 0, len(opts.specRefs))
 	for _, specRef := range opts.specRefs {
-		if !hashRegex.MatchString(specRef) &&
+		if _, _, ok := parseTableSpec(specRef); ok {
+			// tables are resolved when they're shown
+			resolvedRefs = append(resolvedRefs, specRef)
+		} else if !hashRegex.MatchString(specRef) &&
 			!strings.EqualFold(specRef, "HEAD") &&
 			!strings.EqualFold(specRef, "WORKING") &&
 			!strings.EqualFold(specRef, "STAGED") {
@@ -178,6 +190,14 @@ func (cmd ShowCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	}
 
 	for _, specRef := range resolvedRefs {
+		if revision, table, ok := parseTableSpec(specRef); ok {
+			err := showTableAtRevision(queryist, sqlCtx, revision, table, opts.limit)
+			if err != nil {
+				return handleErrAndExit(err)
+			}
+			continue
+		}
+
 		// If --no-pretty was supplied, always display the raw contents of the referenced object.
 		if !opts.pretty {
 			err := printRawValue(sqlCtx, dEnv, specRef)
@@ -228,6 +248,81 @@ func (cmd ShowCmd) Exec(ctx context.Context, commandStr string, args []string, d
 	return 0
 }
 
+// parseTableSpec parses a spec of the form <revision>:<table>. Revisions can't contain colons.
+func parseTableSpec(spec string) (revision, table string, ok bool) {
+	revision, table, ok = strings.Cut(spec, ":")
+	if !ok || revision == "" || table == "" {
+		return "", "", false
+	}
+	return revision, table, true
+}
+
+// showTableAtRevision prints the schema, row count and first |limit| rows of |table| at |revision|. If |limit| is
+// zero, defaultShowTableRows rows are printed.
+func showTableAtRevision(queryist cli.Queryist, sqlCtx *sql.Context, revision, table string, limit int) error {
+	h, err := getHashOf(queryist, sqlCtx, revision)
+	if err != nil {
+		return fmt.Errorf("revision not found: %s", revision)
+	}
+	if limit <= 0 {
+		limit = defaultShowTableRows
+	}
+
+	query, err := dbr.InterpolateForDialect(fmt.Sprintf("show create table %s as of ?", sql.QuoteIdentifier(table)), []interface{}{revision}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	rows, err := GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 || len(rows[0]) < 2 {
+		return fmt.Errorf("table not found: %s", table)
+	}
+	createStmt, ok := rows[0][1].(string)
+	if !ok {
+		return fmt.Errorf("unexpected type for create statement: %T", rows[0][1])
+	}
+
+	query, err = dbr.InterpolateForDialect(fmt.Sprintf("select count(*) from %s as of ?", sql.QuoteIdentifier(table)), []interface{}{revision}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	rows, err = GetRowsForSql(queryist, sqlCtx, query)
+	if err != nil {
+		return err
+	}
+	cnt, err := getInt64ColAsInt64(rows[0][0])
+	if err != nil {
+		return err
+	}
+
+	cli.Println(color.YellowString("table %s at %s", table, h))
+	cli.Println(createStmt + ";")
+	cli.Println()
+	if cnt == 1 {
+		cli.Println("1 row")
+	} else {
+		cli.Printf("%d rows\n", cnt)
+	}
+	if cnt == 0 {
+		return nil
+	}
+	if cnt > int64(limit) {
+		cli.Printf("showing the first %d rows\n", limit)
+	}
+
+	query, err = dbr.InterpolateForDialect(fmt.Sprintf("select * from %s as of ? limit ?", sql.QuoteIdentifier(table)), []interface{}{revision, limit}, dialect.MySQL)
+	if err != nil {
+		return err
+	}
+	sch, rowIter, _, err := queryist.Query(sqlCtx, query)
+	if err != nil {
+		return err
+	}
+	return engine.PrettyPrintResults(sqlCtx, engine.FormatTabular, sch, rowIter, false)
+}
+
 // printRawValue prints the raw value of the object referenced by specRef. This function works directly on storage, and
 // requires a non-nil dEnv.
 func printRawValue(ctx context.Context, dEnv *env.DoltEnv, specRef string) error {
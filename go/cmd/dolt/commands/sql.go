@@ -1134,7 +1134,8 @@ func prepend(s string, ss []string) []string {
 // processQuery processes a single query. The Root of the sqlEngine will be updated if necessary.
 // Returns the schema and the row iterator for the results, which may be nil, and an error if one occurs.
 func processQuery(ctx *sql.Context, query string, qryist cli.Queryist) (sql.Schema, sql.RowIter, *sql.QueryFlags, error) {
-	sqlStatement, err := sqlparser.Parse(query)
+	sqlMode := sql.LoadSqlMode(ctx)
+	sqlStatement, err := sqlparser.ParseWithOptions(ctx, query, sqlMode.ParserOptions())
 	if err == sqlparser.ErrEmpty {
 		// silently skip empty statements
 		return nil, nil, nil, nil
@@ -0,0 +1,197 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/auditlog"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// newAuditLogger returns the audit logger configured by |config|.
+func newAuditLogger(config servercfg.AuditLogConfig) (*auditlog.Logger, error) {
+	return auditlog.NewLogger(auditlog.Options{
+		Target:     config.Target(),
+		Path:       config.Path(),
+		Queries:    config.Queries(),
+		MaxSize:    int64(config.MaxSizeMB()) * 1024 * 1024,
+		MaxFiles:   config.MaxFiles(),
+		MaxEntries: config.MaxEntries(),
+	})
+}
+
+// auditSessions tracks the session of each connection, so that the audit log can record the database and branch
+// each query ran against. A connection is recorded as connecting when its session is first built.
+type auditSessions struct {
+	*connSessions
+	log *auditlog.Logger
+}
+
+func newAuditSessions(log *auditlog.Logger) *auditSessions {
+	return &auditSessions{
+		connSessions: newConnSessions(func(conn *mysql.Conn, sess sql.Session) {
+			log.Log(newAuditEntry(auditlog.EventConnect, conn, sess))
+		}),
+		log: log,
+	}
+}
+
+// auditHandler is a mysql.Handler which records connection events and queries in an audit log.
+type auditHandler struct {
+	serverHandler
+	sessions *auditSessions
+}
+
+var _ serverHandler = auditHandler{}
+
+// newAuditHandler wraps |h|, which must be the go-mysql-server handler or a wrapper of it, to record to the audit log
+// of |sessions|.
+func newAuditHandler(h mysql.Handler, sessions *auditSessions) (mysql.Handler, error) {
+	sh, err := asServerHandler(h, "audit log")
+	if err != nil {
+		return nil, err
+	}
+	return auditHandler{serverHandler: sh, sessions: sessions}, nil
+}
+
+func (h auditHandler) ConnectionClosed(c *mysql.Conn) {
+	if sess := h.sessions.remove(c.ConnectionID); sess != nil {
+		h.sessions.log.Log(newAuditEntry(auditlog.EventDisconnect, c, sess))
+	}
+	h.serverHandler.ConnectionClosed(c)
+}
+
+func (h auditHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	start := time.Now()
+	err := h.serverHandler.ComQuery(ctx, c, query, callback)
+	h.logQuery(c, query, nil, start, err)
+	return err
+}
+
+func (h auditHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	start := time.Now()
+	remainder, err := h.serverHandler.ComMultiQuery(ctx, c, query, callback)
+	executed := query
+	if err == nil && len(remainder) <= len(query) {
+		executed = query[:len(query)-len(remainder)]
+	}
+	h.logQuery(c, executed, nil, start, err)
+	return remainder, err
+}
+
+func (h auditHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	start := time.Now()
+	err := h.serverHandler.ComParsedQuery(ctx, c, query, parsed, callback)
+	h.logQuery(c, query, parsed, start, err)
+	return err
+}
+
+func (h auditHandler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	start := time.Now()
+	err := h.serverHandler.ComStmtExecute(ctx, c, prepare, callback)
+	h.logQuery(c, prepare.PrepareStmt, nil, start, err)
+	return err
+}
+
+func (h auditHandler) ComExecuteBound(ctx context.Context, c *mysql.Conn, query string, boundQuery mysql.BoundQuery, callback mysql.ResultSpoolFn) error {
+	start := time.Now()
+	err := h.serverHandler.ComExecuteBound(ctx, c, query, boundQuery, callback)
+	h.logQuery(c, query, nil, start, err)
+	return err
+}
+
+// logQuery records |query| if its kind is one the audit log is configured to record. |parsed| is the parsed form of
+// |query|, or nil if it hasn't been parsed.
+func (h auditHandler) logQuery(c *mysql.Conn, query string, parsed sqlparser.Statement, start time.Time, err error) {
+	log := h.sessions.log
+	if !log.RecordsQueries(auditlog.KindDDL) {
+		return
+	}
+	query = strings.TrimSpace(query)
+	kind, tables := auditlog.KindOther, []string(nil)
+	if parsed == nil {
+		parsed, _ = sqlparser.Parse(query)
+	}
+	if parsed != nil {
+		kind, tables = auditlog.Classify(parsed)
+	}
+	if !log.RecordsQueries(kind) {
+		return
+	}
+
+	e := newAuditEntry(auditlog.EventQuery, c, h.sessions.get(c.ConnectionID))
+	e.Kind = kind
+	e.Query = query
+	e.Tables = tables
+	e.Duration = time.Since(start)
+	if err != nil {
+		e.Error = err.Error()
+	}
+	log.Log(e)
+}
+
+// newAuditEntry returns an entry for |event| on |c|, with the database and branch |sess| is using. |sess| may be
+// nil if the connection doesn't have a session yet.
+func newAuditEntry(event string, c *mysql.Conn, sess sql.Session) auditlog.Entry {
+	e := auditlog.Entry{
+		Event:        event,
+		ConnectionID: c.ConnectionID,
+		User:         c.User,
+	}
+	if addr := c.RemoteAddr(); addr != nil {
+		e.Client = addr.String()
+	}
+	if sess != nil {
+		e.Database, e.Branch = auditDatabaseAndBranch(sess)
+	}
+	return e
+}
+
+// auditDatabaseAndBranch returns the current database of |sess|, without any revision qualifier, and the branch of it
+// that |sess| is using, or "" if it isn't on a branch.
+func auditDatabaseAndBranch(sess sql.Session) (string, string) {
+	db := sess.GetCurrentDatabase()
+	if db == "" {
+		return "", ""
+	}
+	baseName, rev := dsess.SplitRevisionDbName(db)
+	if rev != "" {
+		return baseName, rev
+	}
+	ctx := sql.NewContext(context.Background(), sql.WithSession(sess))
+	val, err := sess.GetSessionVariable(ctx, dsess.HeadRefKey(baseName))
+	if err != nil {
+		return baseName, ""
+	}
+	headRef, ok := val.(string)
+	if !ok || headRef == "" {
+		return baseName, ""
+	}
+	r, err := ref.Parse(headRef)
+	if err != nil || r.GetType() != ref.BranchRefType {
+		return baseName, ""
+	}
+	return baseName, r.GetPath()
+}
@@ -342,6 +342,10 @@ func (cfg *commandLineServerConfig) RemotesapiReadOnly() *bool {
 	return cfg.remotesapiReadOnly
 }
 
+func (cfg *commandLineServerConfig) RemotesapiMaxBandwidth() *string {
+	return nil
+}
+
 func (cfg *commandLineServerConfig) ClusterConfig() servercfg.ClusterConfig {
 	return nil
 }
@@ -549,6 +553,31 @@ func (cfg *commandLineServerConfig) AutoGCBehavior() servercfg.AutoGCBehavior {
 	return stubAutoGCBehavior{}
 }
 
+// ExternalFunctions can only be configured in a config file.
+func (cfg *commandLineServerConfig) ExternalFunctions() []servercfg.ExternalFunctionConfig {
+	return nil
+}
+
+// ExternalTableCredentials can only be configured in a config file.
+func (cfg *commandLineServerConfig) ExternalTableCredentials() []servercfg.ExternalTableCredentialConfig {
+	return nil
+}
+
+// MetricsEmitters can only be configured in a config file.
+func (cfg *commandLineServerConfig) MetricsEmitters() []servercfg.MetricsEmitterConfig {
+	return nil
+}
+
+// AuditLog can only be configured in a config file.
+func (cfg *commandLineServerConfig) AuditLog() servercfg.AuditLogConfig {
+	return nil
+}
+
+// QueryScheduler can only be configured in a config file.
+func (cfg *commandLineServerConfig) QueryScheduler() servercfg.QuerySchedulerConfig {
+	return nil
+}
+
 // DoltServerConfigReader is the default implementation of ServerConfigReader suitable for parsing Dolt config files
 // and command line options.
 type DoltServerConfigReader struct{}
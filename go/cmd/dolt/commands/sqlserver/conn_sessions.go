@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+)
+
+// serverHandler is the go-mysql-server handler, or one of the wrappers of it in this package. The wrappers embed it,
+// rather than mysql.Handler, so that the extended and binlog interfaces the server handler implements stay visible
+// to the listener.
+type serverHandler interface {
+	mysql.Handler
+	mysql.ExtendedHandler
+	mysql.BinlogReplicaHandler
+}
+
+// asServerHandler returns |h| as a serverHandler, for a wrapper named |wrapper|.
+func asServerHandler(h mysql.Handler, wrapper string) (serverHandler, error) {
+	sh, ok := h.(serverHandler)
+	if !ok {
+		return nil, fmt.Errorf("%s cannot wrap handler of type %T", wrapper, h)
+	}
+	return sh, nil
+}
+
+// connSessions tracks the session of each connection, for handler wrappers which need the session a query runs in.
+// Sessions are created lazily by the handler, so a connection only has a session once its session is first built,
+// which is after it has authenticated.
+type connSessions struct {
+	mu       sync.Mutex
+	sessions map[uint32]sql.Session
+	// onConnect, if not nil, is called when the first session of a connection is built. It is not called when the
+	// session is rebuilt by a connection reset.
+	onConnect func(*mysql.Conn, sql.Session)
+}
+
+func newConnSessions(onConnect func(*mysql.Conn, sql.Session)) *connSessions {
+	return &connSessions{sessions: make(map[uint32]sql.Session), onConnect: onConnect}
+}
+
+// wrapSessionBuilder returns a SessionBuilder which tracks the sessions built by |builder|.
+func (s *connSessions) wrapSessionBuilder(builder server.SessionBuilder) server.SessionBuilder {
+	return func(ctx context.Context, conn *mysql.Conn, addr string) (sql.Session, error) {
+		sess, err := builder(ctx, conn, addr)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		_, reset := s.sessions[conn.ConnectionID]
+		s.sessions[conn.ConnectionID] = sess
+		s.mu.Unlock()
+		if !reset && s.onConnect != nil {
+			s.onConnect(conn, sess)
+		}
+		return sess, nil
+	}
+}
+
+func (s *connSessions) get(connID uint32) sql.Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessions[connID]
+}
+
+func (s *connSessions) remove(connID uint32) sql.Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess := s.sessions[connID]
+	delete(s.sessions, connID)
+	return sess
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	// maxExecutionTimeVar is MySQL's limit, in milliseconds, on how long a read-only SELECT may run.
+	maxExecutionTimeVar = "max_execution_time"
+
+	// erQueryTimeout is MySQL's ER_QUERY_TIMEOUT, returned when a statement exceeds max_execution_time.
+	erQueryTimeout = 3024
+	// erQueryInterrupted is MySQL's ER_QUERY_INTERRUPTED, returned when a statement is killed.
+	erQueryInterrupted   = 1317
+	sqlStateInterrupted  = "70100"
+	sqlStateGeneralError = "HY000"
+)
+
+// errResultRowsExceeded stops a statement from returning rows once it has returned dolt_max_result_rows of them.
+var errResultRowsExceeded = errors.New("result rows exceeded")
+
+// limitsHandler is a mysql.Handler which enforces the per-session query limits max_execution_time,
+// dolt_max_result_rows and dolt_remote_fetch_budget. Per-user limits are configured by setting these variables in the
+// user_session_vars of the server config.
+//
+// A SELECT which runs for longer than max_execution_time milliseconds has its context canceled, which kills the
+// statement as KILL QUERY would, and fails with ER_QUERY_TIMEOUT. As in MySQL, only read-only SELECT statements are
+// timed, so that a write is never interrupted part way through. A statement which returns more than
+// dolt_max_result_rows rows stops after returning that many, and fails with ER_QUERY_INTERRUPTED. A statement which
+// fetches more than dolt_remote_fetch_budget bytes of chunks from remote databases, such as those attached with
+// dolt_attach_remote, also fails with ER_QUERY_INTERRUPTED. None of these limits end the connection or the transaction
+// the statement ran in.
+type limitsHandler struct {
+	serverHandler
+	sessions *connSessions
+}
+
+var _ serverHandler = limitsHandler{}
+
+// newLimitsHandler wraps |h|, which must be the go-mysql-server handler or a wrapper of it, to enforce the limits of
+// the sessions tracked by |sessions|.
+func newLimitsHandler(h mysql.Handler, sessions *connSessions) (mysql.Handler, error) {
+	sh, err := asServerHandler(h, "query limits")
+	if err != nil {
+		return nil, err
+	}
+	return limitsHandler{serverHandler: sh, sessions: sessions}, nil
+}
+
+func (h limitsHandler) ConnectionClosed(c *mysql.Conn) {
+	h.sessions.remove(c.ConnectionID)
+	h.serverHandler.ConnectionClosed(c)
+}
+
+func (h limitsHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	l := h.limits(c)
+	return l.run(ctx, func() bool { return h.isSelect(c, query) }, func(ctx context.Context) error {
+		return h.serverHandler.ComQuery(ctx, c, query, func(res *sqltypes.Result, more bool) error {
+			if err := l.addRows(res); err != nil {
+				return err
+			}
+			return callback(res, more)
+		})
+	})
+}
+
+func (h limitsHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	l := h.limits(c)
+	var remainder string
+	err := l.run(ctx, func() bool { return h.isFirstSelect(ctx, query) }, func(ctx context.Context) error {
+		var err error
+		remainder, err = h.serverHandler.ComMultiQuery(ctx, c, query, func(res *sqltypes.Result, more bool) error {
+			if err := l.addRows(res); err != nil {
+				return err
+			}
+			return callback(res, more)
+		})
+		return err
+	})
+	return remainder, err
+}
+
+func (h limitsHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	l := h.limits(c)
+	return l.run(ctx, func() bool { return isSelectStatement(parsed) }, func(ctx context.Context) error {
+		return h.serverHandler.ComParsedQuery(ctx, c, query, parsed, func(res *sqltypes.Result, more bool) error {
+			if err := l.addRows(res); err != nil {
+				return err
+			}
+			return callback(res, more)
+		})
+	})
+}
+
+func (h limitsHandler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	l := h.limits(c)
+	return l.run(ctx, func() bool { return h.isSelect(c, prepare.PrepareStmt) }, func(ctx context.Context) error {
+		return h.serverHandler.ComStmtExecute(ctx, c, prepare, func(res *sqltypes.Result) error {
+			if err := l.addRows(res); err != nil {
+				return err
+			}
+			return callback(res)
+		})
+	})
+}
+
+func (h limitsHandler) ComExecuteBound(ctx context.Context, c *mysql.Conn, query string, boundQuery mysql.BoundQuery, callback mysql.ResultSpoolFn) error {
+	l := h.limits(c)
+	return l.run(ctx, func() bool { return h.isSelect(c, query) }, func(ctx context.Context) error {
+		return h.serverHandler.ComExecuteBound(ctx, c, query, boundQuery, func(res *sqltypes.Result, more bool) error {
+			if err := l.addRows(res); err != nil {
+				return err
+			}
+			return callback(res, more)
+		})
+	})
+}
+
+// limits returns the limits in effect for the next statement on |c|. The first statement on a connection usually
+// creates its session, so if |c| doesn't have a session yet, the global limits are in effect.
+func (h limitsHandler) limits(c *mysql.Conn) *queryLimits {
+	get := globalInt
+	if sess := h.sessions.get(c.ConnectionID); sess != nil {
+		ctx := sql.NewContext(context.Background(), sql.WithSession(sess))
+		get = func(name string) int64 {
+			val, err := sess.GetSessionVariable(ctx, name)
+			if err != nil {
+				return 0
+			}
+			i, _ := val.(int64)
+			return i
+		}
+	}
+
+	l := &queryLimits{}
+	if ms := get(maxExecutionTimeVar); ms > 0 {
+		l.timeout = time.Duration(ms) * time.Millisecond
+	}
+	if rows := get(dsess.MaxResultRows); rows > 0 {
+		l.maxRows = rows
+	}
+	if bytes := get(dsess.RemoteFetchBudget); bytes > 0 {
+		l.fetchBudget = remotestorage.NewFetchBudget(bytes)
+	}
+	return l
+}
+
+func globalInt(name string) int64 {
+	_, val, ok := sql.SystemVariables.GetGlobal(name)
+	if !ok {
+		return 0
+	}
+	i, _ := val.(int64)
+	return i
+}
+
+// isSelect returns whether |query| is a read-only SELECT statement.
+func (h limitsHandler) isSelect(c *mysql.Conn, query string) bool {
+	opts, err := h.ParserOptionsForConnection(c)
+	if err != nil {
+		return false
+	}
+	stmt, err := sqlparser.ParseWithOptions(context.Background(), query, opts)
+	if err != nil {
+		return false
+	}
+	return isSelectStatement(stmt)
+}
+
+// isFirstSelect returns whether the first statement of the multi-statement |query| is a read-only SELECT statement.
+func (h limitsHandler) isFirstSelect(ctx context.Context, query string) bool {
+	stmt, _, err := sqlparser.ParseOne(ctx, query)
+	if err != nil {
+		return false
+	}
+	return isSelectStatement(stmt)
+}
+
+func isSelectStatement(stmt sqlparser.Statement) bool {
+	s, ok := stmt.(sqlparser.SelectStatement)
+	// SELECT ... INTO writes to variables or files, and so isn't read-only
+	return ok && s.GetInto() == nil
+}
+
+// queryLimits are the limits on a single statement, and track the rows it has returned.
+type queryLimits struct {
+	timeout     time.Duration
+	maxRows     int64
+	rows        int64
+	fetchBudget *remotestorage.FetchBudget
+}
+
+// run runs a statement by calling |exec| with a context which is canceled if the statement exceeds the execution time
+// limit, and which limits its fetches from remote databases. |isSelect| reports whether the statement is a read-only
+// SELECT, and is only called if there is a time limit.
+func (l *queryLimits) run(ctx context.Context, isSelect func() bool, exec func(context.Context) error) error {
+	if l.fetchBudget != nil {
+		ctx = remotestorage.WithFetchBudget(ctx, l.fetchBudget)
+	}
+	if l.timeout == 0 || !isSelect() {
+		return l.toSQLError(exec(ctx), nil)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+	return l.toSQLError(exec(timeoutCtx), timeoutCtx)
+}
+
+// addRows counts the rows of |res|, and returns errResultRowsExceeded, without the rows being sent to the client, if
+// they take the statement over its row limit.
+func (l *queryLimits) addRows(res *sqltypes.Result) error {
+	if l.maxRows == 0 || res == nil {
+		return nil
+	}
+	l.rows += int64(len(res.Rows))
+	if l.rows > l.maxRows {
+		return errResultRowsExceeded
+	}
+	return nil
+}
+
+// toSQLError returns the error a statement which failed with |err| returns to the client. If the statement exceeded
+// one of its limits, that is reported rather than the error the engine returned on being interrupted. |timeoutCtx|
+// is the context the statement ran with if it was timed.
+func (l *queryLimits) toSQLError(err error, timeoutCtx context.Context) error {
+	if err == nil {
+		return nil
+	}
+	if timeoutCtx != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+		return mysql.NewSQLError(erQueryTimeout, sqlStateGeneralError, "Query execution was interrupted, maximum statement execution time exceeded")
+	}
+	if l.maxRows > 0 && l.rows > l.maxRows {
+		return mysql.NewSQLError(erQueryInterrupted, sqlStateInterrupted, "Query execution was interrupted, the statement returned more than %s = %d rows", dsess.MaxResultRows, l.maxRows)
+	}
+	if l.fetchBudget.Exceeded() {
+		return mysql.NewSQLError(erQueryInterrupted, sqlStateInterrupted, "Query execution was interrupted, the statement fetched more than %s = %d bytes from remote databases", dsess.RemoteFetchBudget, l.fetchBudget.Max())
+	}
+	return err
+}
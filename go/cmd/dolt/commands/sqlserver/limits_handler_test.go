@@ -0,0 +1,120 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
+)
+
+func TestIsSelectStatement(t *testing.T) {
+	tests := []struct {
+		query    string
+		isSelect bool
+	}{
+		{"select * from t", true},
+		{"select 1 union select 2", true},
+		{"with cte as (select 1) select * from cte", true},
+		{"select 1 into @x", false},
+		{"insert into t select * from u", false},
+		{"update t set c = 1", false},
+		{"call dolt_gc()", false},
+		{"show tables", false},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := sqlparser.Parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.isSelect, isSelectStatement(stmt))
+		})
+	}
+}
+
+func TestQueryLimitsMaxRows(t *testing.T) {
+	res := &sqltypes.Result{Rows: make([][]sqltypes.Value, 3)}
+
+	l := &queryLimits{}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.addRows(res))
+	}
+
+	l = &queryLimits{maxRows: 5}
+	require.NoError(t, l.addRows(res))
+	require.ErrorIs(t, l.addRows(res), errResultRowsExceeded)
+
+	err := l.toSQLError(errors.New("engine error"), nil)
+	var sqlErr *mysql.SQLError
+	require.True(t, errors.As(err, &sqlErr))
+	assert.Equal(t, erQueryInterrupted, sqlErr.Num)
+	assert.Equal(t, sqlStateInterrupted, sqlErr.State)
+}
+
+func TestQueryLimitsFetchBudget(t *testing.T) {
+	l := &queryLimits{fetchBudget: remotestorage.NewFetchBudget(10)}
+	engineErr := errors.New("engine error")
+	err := l.run(context.Background(), func() bool { return false }, func(ctx context.Context) error {
+		// every statement's fetches are limited, not just SELECTs
+		assert.Equal(t, l.fetchBudget, remotestorage.FetchBudgetFromContext(ctx))
+		return engineErr
+	})
+	// statements within their budget return their own errors
+	assert.Equal(t, engineErr, err)
+
+	l = &queryLimits{}
+	err = l.run(context.Background(), func() bool { return false }, func(ctx context.Context) error {
+		assert.Nil(t, remotestorage.FetchBudgetFromContext(ctx))
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestQueryLimitsTimeout(t *testing.T) {
+	waitForCancel := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	l := &queryLimits{timeout: 10 * time.Millisecond}
+	err := l.run(context.Background(), func() bool { return true }, waitForCancel)
+	var sqlErr *mysql.SQLError
+	require.True(t, errors.As(err, &sqlErr))
+	assert.Equal(t, erQueryTimeout, sqlErr.Num)
+
+	// Statements other than SELECTs aren't timed
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	err = l.run(ctx, func() bool { return false }, waitForCancel)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Statements which finish in time return their own errors
+	engineErr := errors.New("engine error")
+	err = l.run(context.Background(), func() bool { return true }, func(context.Context) error {
+		return engineErr
+	})
+	assert.Equal(t, engineErr, err)
+}
@@ -15,26 +15,39 @@
 package sqlserver
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/server"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
 
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/clusterdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/version"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
 )
 
 const (
 	clusterUpdateInterval = time.Second * 5
+	storageUpdateInterval = time.Second * 30
 
 	dbLabel     = "database"
 	roleLabel   = "role"
 	remoteLabel = "remote"
+	poolLabel   = "pool"
 )
 
+// dbSizesFunc returns the size in bytes of the storage of each database.
+type dbSizesFunc func(ctx context.Context) (map[string]uint64, error)
+
 var _ server.ServerEventListener = (*metricsListener)(nil)
 
 type metricsListener struct {
@@ -51,14 +64,30 @@ type metricsListener struct {
 	isReplicaGauges      *prometheus.GaugeVec
 	replicationLagGauges *prometheus.GaugeVec
 
+	// async push on write replication metrics
+	asyncReplCollectors []prometheus.Collector
+
+	// commit and node cache metrics
+	statsCollectors []prometheus.Collector
+
+	// storage metrics
+	storageBytesGauges *prometheus.GaugeVec
+
 	// used in updating cluster metrics
 	clusterStatus  clusterdb.ClusterStatusProvider
 	mu             *sync.Mutex
 	done           bool
 	clusterSeenDbs map[string]struct{}
+
+	// used in updating storage metrics
+	dbSizes           dbSizesFunc
+	lastStorageUpdate time.Time
+	storageSeenDbs    map[string]struct{}
 }
 
-func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStatus clusterdb.ClusterStatusProvider) (*metricsListener, error) {
+// newMetricsListener creates the metrics of the server and registers them with prometheus. If |dbSizes| is not nil,
+// it is used to report the storage used by each database.
+func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStatus clusterdb.ClusterStatusProvider, dbSizes dbSizesFunc) (*metricsListener, error) {
 	ml := &metricsListener{
 		labels: labels,
 		cntConnections: prometheus.NewCounter(prometheus.CounterOpts{
@@ -102,10 +131,57 @@ func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStat
 			Help:        "one if the server is currently in this role, zero otherwise",
 			ConstLabels: labels,
 		}, []string{dbLabel}),
+		asyncReplCollectors: []prometheus.Collector{
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "dss_async_replication_queue_length",
+				Help:        "Number of commits waiting in the async replication queue",
+				ConstLabels: labels,
+			}, func() float64 { return float64(sqle.GetAsyncReplicationStats().QueueLength) }),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "dss_async_replication_retrying",
+				Help:        "Number of refs whose async replication push is waiting to be retried",
+				ConstLabels: labels,
+			}, func() float64 { return float64(sqle.GetAsyncReplicationStats().Retrying) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_async_replication_dropped",
+				Help:        "Count of commits dropped from async replication because its queue was full",
+				ConstLabels: labels,
+			}, func() float64 { return float64(sqle.GetAsyncReplicationStats().Dropped) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_async_replication_pushes",
+				Help:        "Count of successful async replication pushes",
+				ConstLabels: labels,
+			}, func() float64 { return float64(sqle.GetAsyncReplicationStats().Pushed) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_async_replication_failures",
+				Help:        "Count of failed async replication pushes",
+				ConstLabels: labels,
+			}, func() float64 { return float64(sqle.GetAsyncReplicationStats().Failed) }),
+		},
+		statsCollectors: []prometheus.Collector{
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_transaction_commits",
+				Help:        "Count of transactions committed to a working set, including those which made dolt commits",
+				ConstLabels: labels,
+			}, func() float64 { return float64(dsess.GetCommitStats().Transactions) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_dolt_commits",
+				Help:        "Count of dolt commits made by transactions",
+				ConstLabels: labels,
+			}, func() float64 { return float64(dsess.GetCommitStats().DoltCommits) }),
+		},
+		storageBytesGauges: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_storage_bytes",
+			Help:        "The size of the storage of each database, in bytes",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
 		clusterStatus:  clusterStatus,
 		mu:             &sync.Mutex{},
 		clusterSeenDbs: make(map[string]struct{}),
+		dbSizes:        dbSizes,
+		storageSeenDbs: make(map[string]struct{}),
 	}
+	ml.statsCollectors = append(ml.statsCollectors, nodeCacheCollectors(labels)...)
 
 	u32Version, err := version.Encode(versionStr)
 	if err != nil {
@@ -126,9 +202,13 @@ func newMetricsListener(labels prometheus.Labels, versionStr string, clusterStat
 	prometheus.MustRegister(ml.histQueryDur)
 	prometheus.MustRegister(ml.replicationLagGauges)
 	prometheus.MustRegister(ml.isReplicaGauges)
+	prometheus.MustRegister(ml.asyncReplCollectors...)
+	prometheus.MustRegister(ml.statsCollectors...)
+	prometheus.MustRegister(ml.storageBytesGauges)
 
 	go func() {
 		for ml.updateReplMetrics() {
+			ml.updateStorageMetrics()
 			time.Sleep(clusterUpdateInterval)
 		}
 	}()
@@ -181,6 +261,116 @@ func (ml *metricsListener) updateReplMetrics() bool {
 	return true
 }
 
+// nodeCacheCollectors returns the metrics of each pool of the node cache, whose hit rate is the rate of hits over the
+// rate of hits and inserts, as nodes are inserted when they are read and not cached.
+func nodeCacheCollectors(labels prometheus.Labels) []prometheus.Collector {
+	poolStats := func(pool string) tree.CachePoolStats {
+		for _, s := range tree.CacheStats() {
+			if s.Pool == pool {
+				return s
+			}
+		}
+		return tree.CachePoolStats{}
+	}
+
+	var collectors []prometheus.Collector
+	for _, s := range tree.CacheStats() {
+		pool := s.Pool
+		poolLabels := prometheus.Labels{poolLabel: pool}
+		for k, v := range labels {
+			poolLabels[k] = v
+		}
+		collectors = append(collectors,
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_node_cache_hits",
+				Help:        "Count of reads of prolly tree nodes which were cached",
+				ConstLabels: poolLabels,
+			}, func() float64 { return float64(poolStats(pool).Hits) }),
+			prometheus.NewCounterFunc(prometheus.CounterOpts{
+				Name:        "dss_node_cache_inserts",
+				Help:        "Count of prolly tree nodes added to the cache after being read from storage",
+				ConstLabels: poolLabels,
+			}, func() float64 { return float64(poolStats(pool).Inserts) }),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "dss_node_cache_bytes",
+				Help:        "The size of the prolly tree nodes in the cache, in bytes",
+				ConstLabels: poolLabels,
+			}, func() float64 { return float64(poolStats(pool).Bytes) }),
+		)
+	}
+	return collectors
+}
+
+// engineDbSizes returns a dbSizesFunc for the databases of |se|. Databases whose storage can't be measured, such as
+// those in memory, are left out.
+func engineDbSizes(se *engine.SqlEngine) dbSizesFunc {
+	return func(ctx context.Context) (map[string]uint64, error) {
+		pro, ok := se.GetUnderlyingEngine().Analyzer.Catalog.DbProvider.(dsess.DoltDatabaseProvider)
+		if !ok {
+			return nil, nil
+		}
+		sizes := make(map[string]uint64)
+		for _, db := range pro.DoltDatabases() {
+			ddb := db.DbData().Ddb
+			if ddb == nil {
+				continue
+			}
+			sz, err := ddb.StoreSizes(ctx)
+			if err != nil {
+				logrus.Debugf("unable to measure storage of database %s: %v", db.Name(), err)
+				continue
+			}
+			sizes[db.Name()] = sz.TotalBytes
+		}
+		return sizes, nil
+	}
+}
+
+// newMetricsEmitter returns the events.MetricsEmitter configured by |c|.
+func newMetricsEmitter(c servercfg.MetricsEmitterConfig, versionStr string) (events.MetricsEmitter, error) {
+	switch c.Type() {
+	case servercfg.MetricsEmitterStatsD:
+		return events.NewStatsDEmitter(c.Endpoint(), c.Prefix())
+	case servercfg.MetricsEmitterOTLP:
+		return events.NewOTLPMetricsEmitter(c.Endpoint(), c.Headers(), map[string]string{
+			"service.name":    "dolt-sql-server",
+			"service.version": versionStr,
+		})
+	default:
+		return nil, fmt.Errorf("unknown metrics emitter type '%s'", c.Type())
+	}
+}
+
+// updateStorageMetrics updates the size of each database, if it hasn't been updated in the last
+// storageUpdateInterval.
+func (ml *metricsListener) updateStorageMetrics() {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+
+	if ml.done || ml.dbSizes == nil || time.Since(ml.lastStorageUpdate) < storageUpdateInterval {
+		return
+	}
+	ml.lastStorageUpdate = time.Now()
+
+	sizes, err := ml.dbSizes(context.Background())
+	if err != nil {
+		logrus.Warnf("error updating storage metrics: %v", err)
+		return
+	}
+
+	dbNames := make(map[string]struct{}, len(sizes))
+	for db, size := range sizes {
+		dbNames[db] = struct{}{}
+		ml.storageBytesGauges.WithLabelValues(db).Set(float64(size))
+	}
+	for db := range ml.storageSeenDbs {
+		if _, ok := dbNames[db]; !ok {
+			ml.storageBytesGauges.DeleteLabelValues(db)
+		}
+	}
+	ml.storageSeenDbs = dbNames
+}
+
 func (ml *metricsListener) ClientConnected() {
 	ml.gaugeConcurrentConn.Add(1.0)
 	ml.cntConnections.Add(1.0)
@@ -207,6 +397,12 @@ func (ml *metricsListener) Close() {
 	prometheus.Unregister(ml.gaugeConcurrentConn)
 	prometheus.Unregister(ml.gaugeConcurrentQueries)
 	prometheus.Unregister(ml.histQueryDur)
+	for _, c := range ml.asyncReplCollectors {
+		prometheus.Unregister(c)
+	}
+	for _, c := range ml.statsCollectors {
+		prometheus.Unregister(c)
+	}
 
 	ml.closeReplicationMetrics()
 }
@@ -217,6 +413,7 @@ func (ml *metricsListener) closeReplicationMetrics() {
 
 	prometheus.Unregister(ml.replicationLagGauges)
 	prometheus.Unregister(ml.isReplicaGauges)
+	prometheus.Unregister(ml.storageBytesGauges)
 
 	ml.done = true
 }
@@ -0,0 +1,179 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/querylog"
+)
+
+// queryLogHandler is a mysql.Handler which records statements, with their latency and the rows they examined, sent
+// and affected, in the query log while the dolt_query_log system variable is enabled.
+type queryLogHandler struct {
+	serverHandler
+	sessions *connSessions
+}
+
+var _ serverHandler = queryLogHandler{}
+
+// newQueryLogHandler wraps |h|, which must be the go-mysql-server handler or a wrapper of it, to record the statements
+// run in the sessions tracked by |sessions|.
+func newQueryLogHandler(h mysql.Handler, sessions *connSessions) (mysql.Handler, error) {
+	sh, err := asServerHandler(h, "query log")
+	if err != nil {
+		return nil, err
+	}
+	return queryLogHandler{serverHandler: sh, sessions: sessions}, nil
+}
+
+func (h queryLogHandler) ConnectionClosed(c *mysql.Conn) {
+	h.sessions.remove(c.ConnectionID)
+	h.serverHandler.ConnectionClosed(c)
+}
+
+func (h queryLogHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	r := h.begin(c)
+	err := h.serverHandler.ComQuery(ctx, c, query, func(res *sqltypes.Result, more bool) error {
+		r.addResult(res)
+		return callback(res, more)
+	})
+	h.finish(r, c, query, err)
+	return err
+}
+
+func (h queryLogHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	r := h.begin(c)
+	remainder, err := h.serverHandler.ComMultiQuery(ctx, c, query, func(res *sqltypes.Result, more bool) error {
+		r.addResult(res)
+		return callback(res, more)
+	})
+	executed := query
+	if err == nil && len(remainder) <= len(query) {
+		executed = query[:len(query)-len(remainder)]
+	}
+	h.finish(r, c, executed, err)
+	return remainder, err
+}
+
+func (h queryLogHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	r := h.begin(c)
+	err := h.serverHandler.ComParsedQuery(ctx, c, query, parsed, func(res *sqltypes.Result, more bool) error {
+		r.addResult(res)
+		return callback(res, more)
+	})
+	h.finish(r, c, query, err)
+	return err
+}
+
+func (h queryLogHandler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	r := h.begin(c)
+	err := h.serverHandler.ComStmtExecute(ctx, c, prepare, func(res *sqltypes.Result) error {
+		r.addResult(res)
+		return callback(res)
+	})
+	h.finish(r, c, prepare.PrepareStmt, err)
+	return err
+}
+
+func (h queryLogHandler) ComExecuteBound(ctx context.Context, c *mysql.Conn, query string, boundQuery mysql.BoundQuery, callback mysql.ResultSpoolFn) error {
+	r := h.begin(c)
+	err := h.serverHandler.ComExecuteBound(ctx, c, query, boundQuery, func(res *sqltypes.Result, more bool) error {
+		r.addResult(res)
+		return callback(res, more)
+	})
+	h.finish(r, c, query, err)
+	return err
+}
+
+// queryRecord tracks a statement being recorded to the query log.
+type queryRecord struct {
+	log   *querylog.Log
+	start time.Time
+	// hasSession is whether the connection had a session when the statement started, in which case database and
+	// rowsExamined are taken from it.
+	hasSession   bool
+	database     string
+	rowsExamined int64
+	rowsSent     int64
+	rowsAffected int64
+}
+
+// begin starts recording the next statement on |c|, or returns nil if the query log isn't enabled.
+func (h queryLogHandler) begin(c *mysql.Conn) *queryRecord {
+	log := querylog.Enabled()
+	if log == nil {
+		return nil
+	}
+	r := &queryRecord{log: log, start: time.Now()}
+	if sess := h.sessions.get(c.ConnectionID); sess != nil {
+		r.hasSession = true
+		r.database, _ = dsess.SplitRevisionDbName(sess.GetCurrentDatabase())
+		r.rowsExamined = sessionRowsExamined(sess)
+	}
+	return r
+}
+
+func (r *queryRecord) addResult(res *sqltypes.Result) {
+	if r == nil || res == nil {
+		return
+	}
+	r.rowsSent += int64(len(res.Rows))
+	r.rowsAffected += int64(res.RowsAffected)
+}
+
+// finish records |query|, which failed with |err| if it isn't nil, to the query log. The first statement on a
+// connection usually creates its session, in which case the database it ran against is the one the session was created
+// with.
+func (h queryLogHandler) finish(r *queryRecord, c *mysql.Conn, query string, err error) {
+	if r == nil {
+		return
+	}
+	s := querylog.Statement{
+		ConnectionID: c.ConnectionID,
+		User:         c.User,
+		Database:     r.database,
+		Query:        strings.TrimSpace(query),
+		Duration:     time.Since(r.start),
+		RowsSent:     r.rowsSent,
+		RowsAffected: r.rowsAffected,
+	}
+	if sess := h.sessions.get(c.ConnectionID); sess != nil {
+		s.RowsExamined = sessionRowsExamined(sess) - r.rowsExamined
+		if !r.hasSession {
+			s.Database, _ = dsess.SplitRevisionDbName(sess.GetCurrentDatabase())
+		}
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+	r.log.SetLimits(querylog.GlobalLimits())
+	r.log.Record(s)
+}
+
+func sessionRowsExamined(sess sql.Session) int64 {
+	if dSess, ok := sess.(*dsess.DoltSession); ok {
+		return dSess.RowsExamined()
+	}
+	return 0
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// erTooManyConcurrentTrxs is MySQL's ER_TOO_MANY_CONCURRENT_TRXS, returned when a statement times out waiting to be
+// scheduled.
+const erTooManyConcurrentTrxs = 1637
+
+// queryScheduler limits the number of statements which run at once, both in total and against each database, and
+// shares the statements which may run between the databases with statements waiting, in proportion to their
+// weights.
+//
+// Databases are scheduled by stride scheduling. Each database has a pass, which advances by the inverse of its weight
+// each time one of its statements starts, and when a statement finishes, the waiting statement of the database with
+// the lowest pass starts next. Statements against the same database start in the order they arrived. A database which
+// starts waiting has its pass moved up to the pass of the last database scheduled, so that it can't save up a share
+// while it is idle.
+type queryScheduler struct {
+	maxRunning   int
+	queueTimeout time.Duration
+	defaults     schedulerLimits
+	configured   map[string]schedulerLimits
+	metrics      *schedulerMetrics
+
+	mu      sync.Mutex
+	running int
+	pass    float64
+	dbs     map[string]*dbSchedule
+}
+
+// schedulerLimits are the weight and concurrency limit of a database.
+type schedulerLimits struct {
+	weight     int
+	maxRunning int
+}
+
+// dbSchedule is the scheduling state of a database with statements running or waiting. It is removed once the
+// database has neither.
+type dbSchedule struct {
+	name    string
+	limits  schedulerLimits
+	pass    float64
+	running int
+	waiting []*scheduledQuery
+}
+
+// scheduledQuery is a statement waiting to run.
+type scheduledQuery struct {
+	ready   chan struct{}
+	started bool
+}
+
+// newQueryScheduler returns a scheduler configured by |config|. |metrics| may be nil.
+func newQueryScheduler(config servercfg.QuerySchedulerConfig, metrics *schedulerMetrics) *queryScheduler {
+	s := &queryScheduler{
+		maxRunning:   config.MaxConcurrentQueries(),
+		queueTimeout: config.QueueTimeout(),
+		defaults:     schedulerLimits{weight: config.DefaultWeight(), maxRunning: config.DefaultMaxConcurrentQueries()},
+		configured:   make(map[string]schedulerLimits),
+		metrics:      metrics,
+		dbs:          make(map[string]*dbSchedule),
+	}
+	for _, db := range config.Databases() {
+		s.configured[strings.ToLower(db.Name())] = schedulerLimits{weight: db.Weight(), maxRunning: db.MaxConcurrentQueries()}
+	}
+	return s
+}
+
+// acquire waits until a statement against |db| may run, and returns the function which must be called when it
+// finishes. It fails if the statement waits for longer than the queue timeout or |ctx| is canceled.
+func (s *queryScheduler) acquire(ctx context.Context, db string) (func(), error) {
+	db = strings.ToLower(db)
+	start := time.Now()
+
+	s.mu.Lock()
+	d := s.schedule(db)
+	release := func() { s.release(d) }
+	if len(d.waiting) == 0 && s.canStart(d) {
+		s.start(d)
+		s.mu.Unlock()
+		s.metrics.observeWait(db, 0)
+		return release, nil
+	}
+	if len(d.waiting) == 0 && d.pass < s.pass {
+		d.pass = s.pass
+	}
+	q := &scheduledQuery{ready: make(chan struct{})}
+	d.waiting = append(d.waiting, q)
+	s.metrics.setQueued(db, len(d.waiting))
+	s.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if s.queueTimeout > 0 {
+		timer := time.NewTimer(s.queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+	select {
+	case <-q.ready:
+		s.metrics.observeWait(db, time.Since(start))
+		return release, nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-timeout:
+		s.metrics.incTimeouts(db)
+		err = mysql.NewSQLError(erTooManyConcurrentTrxs, sqlStateGeneralError, "Too many concurrent queries, the statement waited %s to run against database '%s'", s.queueTimeout, db)
+	}
+
+	s.mu.Lock()
+	if q.started {
+		// the statement was scheduled as it gave up waiting
+		s.mu.Unlock()
+		release()
+		return nil, err
+	}
+	for i, w := range d.waiting {
+		if w == q {
+			d.waiting = append(d.waiting[:i], d.waiting[i+1:]...)
+			break
+		}
+	}
+	s.metrics.setQueued(db, len(d.waiting))
+	s.removeIfIdle(d)
+	s.mu.Unlock()
+	return nil, err
+}
+
+// schedule returns the scheduling state of |db|, creating it if it has no statements running or waiting.
+func (s *queryScheduler) schedule(db string) *dbSchedule {
+	d, ok := s.dbs[db]
+	if !ok {
+		limits, ok := s.configured[db]
+		if !ok {
+			limits = s.defaults
+		}
+		d = &dbSchedule{name: db, limits: limits, pass: s.pass}
+		s.dbs[db] = d
+	}
+	return d
+}
+
+// canStart returns whether a statement against |d| may start without exceeding a limit.
+func (s *queryScheduler) canStart(d *dbSchedule) bool {
+	if s.maxRunning > 0 && s.running >= s.maxRunning {
+		return false
+	}
+	return d.limits.maxRunning == 0 || d.running < d.limits.maxRunning
+}
+
+func (s *queryScheduler) start(d *dbSchedule) {
+	s.running++
+	d.running++
+	d.pass += 1 / float64(d.limits.weight)
+	s.pass = d.pass
+	s.metrics.setRunning(d.name, d.running)
+}
+
+// release records that a statement against |d| finished, and starts the waiting statements which may now run.
+func (s *queryScheduler) release(d *dbSchedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running--
+	d.running--
+	s.metrics.setRunning(d.name, d.running)
+	s.dispatch()
+	s.removeIfIdle(d)
+}
+
+// dispatch starts waiting statements, from the database with the lowest pass first, until none may start.
+func (s *queryScheduler) dispatch() {
+	for {
+		var next *dbSchedule
+		for _, d := range s.dbs {
+			if len(d.waiting) == 0 || !s.canStart(d) {
+				continue
+			}
+			if next == nil || d.pass < next.pass || (d.pass == next.pass && d.name < next.name) {
+				next = d
+			}
+		}
+		if next == nil {
+			return
+		}
+		q := next.waiting[0]
+		next.waiting = next.waiting[1:]
+		q.started = true
+		s.start(next)
+		s.metrics.setQueued(next.name, len(next.waiting))
+		close(q.ready)
+	}
+}
+
+func (s *queryScheduler) removeIfIdle(d *dbSchedule) {
+	if d.running == 0 && len(d.waiting) == 0 && s.dbs[d.name] == d {
+		delete(s.dbs, d.name)
+	}
+}
+
+// schedulerMetrics are the per-database metrics of the query scheduler. Its methods do nothing on a nil
+// *schedulerMetrics.
+type schedulerMetrics struct {
+	queued   *prometheus.GaugeVec
+	running  *prometheus.GaugeVec
+	wait     *prometheus.HistogramVec
+	timeouts *prometheus.CounterVec
+}
+
+// newSchedulerMetrics creates the metrics of the query scheduler and registers them with prometheus.
+func newSchedulerMetrics(labels prometheus.Labels) *schedulerMetrics {
+	m := &schedulerMetrics{
+		queued: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_query_queue_length",
+			Help:        "Number of statements waiting to be scheduled against each database",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		running: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name:        "dss_scheduled_queries",
+			Help:        "Number of statements scheduled by the query scheduler running against each database",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+		wait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "dss_query_queue_wait",
+			Help:        "Histogram of the seconds statements waited to be scheduled against each database",
+			ConstLabels: labels,
+			Buckets:     []float64{0.001, 0.01, 0.1, 1.0, 10.0, 100.0},
+		}, []string{dbLabel}),
+		timeouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "dss_query_queue_timeouts",
+			Help:        "Count of statements which timed out waiting to be scheduled against each database",
+			ConstLabels: labels,
+		}, []string{dbLabel}),
+	}
+	prometheus.MustRegister(m.queued, m.running, m.wait, m.timeouts)
+	return m
+}
+
+func (m *schedulerMetrics) Close() {
+	if m == nil {
+		return
+	}
+	prometheus.Unregister(m.queued)
+	prometheus.Unregister(m.running)
+	prometheus.Unregister(m.wait)
+	prometheus.Unregister(m.timeouts)
+}
+
+func (m *schedulerMetrics) setQueued(db string, n int) {
+	if m != nil {
+		m.queued.WithLabelValues(db).Set(float64(n))
+	}
+}
+
+func (m *schedulerMetrics) setRunning(db string, n int) {
+	if m != nil {
+		m.running.WithLabelValues(db).Set(float64(n))
+	}
+}
+
+func (m *schedulerMetrics) observeWait(db string, d time.Duration) {
+	if m != nil {
+		m.wait.WithLabelValues(db).Observe(d.Seconds())
+	}
+}
+
+func (m *schedulerMetrics) incTimeouts(db string) {
+	if m != nil {
+		m.timeouts.WithLabelValues(db).Inc()
+	}
+}
+
+// schedulerHandler is a mysql.Handler which runs each statement once the query scheduler schedules it against the
+// current database of its session.
+type schedulerHandler struct {
+	serverHandler
+	sessions  *connSessions
+	scheduler *queryScheduler
+}
+
+var _ serverHandler = schedulerHandler{}
+
+// newSchedulerHandler wraps |h|, which must be the go-mysql-server handler or a wrapper of it, to schedule the
+// statements of the sessions tracked by |sessions| with |scheduler|.
+func newSchedulerHandler(h mysql.Handler, sessions *connSessions, scheduler *queryScheduler) (mysql.Handler, error) {
+	sh, err := asServerHandler(h, "query scheduler")
+	if err != nil {
+		return nil, err
+	}
+	return schedulerHandler{serverHandler: sh, sessions: sessions, scheduler: scheduler}, nil
+}
+
+func (h schedulerHandler) ConnectionClosed(c *mysql.Conn) {
+	h.sessions.remove(c.ConnectionID)
+	h.serverHandler.ConnectionClosed(c)
+}
+
+func (h schedulerHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	release, err := h.scheduler.acquire(ctx, h.database(c))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return h.serverHandler.ComQuery(ctx, c, query, callback)
+}
+
+func (h schedulerHandler) ComMultiQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) (string, error) {
+	release, err := h.scheduler.acquire(ctx, h.database(c))
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return h.serverHandler.ComMultiQuery(ctx, c, query, callback)
+}
+
+func (h schedulerHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	release, err := h.scheduler.acquire(ctx, h.database(c))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return h.serverHandler.ComParsedQuery(ctx, c, query, parsed, callback)
+}
+
+func (h schedulerHandler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	release, err := h.scheduler.acquire(ctx, h.database(c))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return h.serverHandler.ComStmtExecute(ctx, c, prepare, callback)
+}
+
+func (h schedulerHandler) ComExecuteBound(ctx context.Context, c *mysql.Conn, query string, boundQuery mysql.BoundQuery, callback mysql.ResultSpoolFn) error {
+	release, err := h.scheduler.acquire(ctx, h.database(c))
+	if err != nil {
+		return err
+	}
+	defer release()
+	return h.serverHandler.ComExecuteBound(ctx, c, query, boundQuery, callback)
+}
+
+// database returns the current database of the session of |c|, without any revision qualifier, which the next
+// statement on |c| is scheduled against. Statements run without a current database, or before |c| has a session,
+// are scheduled against the database "".
+func (h schedulerHandler) database(c *mysql.Conn) string {
+	sess := h.sessions.get(c.ConnectionID)
+	if sess == nil {
+		return ""
+	}
+	baseName, _ := dsess.SplitRevisionDbName(sess.GetCurrentDatabase())
+	return baseName
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+)
+
+func newTestScheduler(t *testing.T, yaml string) *queryScheduler {
+	cfg, err := servercfg.NewYamlConfig([]byte(yaml))
+	require.NoError(t, err)
+	require.NoError(t, servercfg.ValidateQuerySchedulerConfig(cfg.QueryScheduler()))
+	return newQueryScheduler(cfg.QueryScheduler(), nil)
+}
+
+// scheduledRun is a statement started by acquireInBackground, which runs until it is released.
+type scheduledRun struct {
+	db      string
+	release func()
+}
+
+// acquireInBackground acquires a slot for a statement against |db| in a goroutine, and waits until the statement is
+// either running or waiting. Running statements are sent to |started|.
+func acquireInBackground(t *testing.T, s *queryScheduler, db string, started chan scheduledRun) {
+	name := strings.ToLower(db)
+	waiting := func() int {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if d, ok := s.dbs[name]; ok {
+			return len(d.waiting)
+		}
+		return 0
+	}
+	before := waiting()
+	go func() {
+		release, err := s.acquire(context.Background(), db)
+		if assert.NoError(t, err) {
+			started <- scheduledRun{db: name, release: release}
+		}
+	}()
+	require.Eventually(t, func() bool {
+		return waiting() > before || len(started) > 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestQuerySchedulerWeights(t *testing.T) {
+	s := newTestScheduler(t, `
+query_scheduler:
+  max_concurrent_queries: 1
+  databases:
+    - name: heavy
+    - name: light
+      weight: 2
+`)
+	started := make(chan scheduledRun, 16)
+	acquireInBackground(t, s, "blocker", started)
+	blocker := <-started
+
+	for i := 0; i < 6; i++ {
+		acquireInBackground(t, s, "heavy", started)
+	}
+	for i := 0; i < 4; i++ {
+		acquireInBackground(t, s, "LIGHT", started)
+	}
+
+	var order []string
+	blocker.release()
+	for i := 0; i < 10; i++ {
+		r := <-started
+		order = append(order, r.db)
+		r.release()
+	}
+	// light has twice the weight of heavy, so gets two statements for each of heavy's while both are waiting
+	assert.Equal(t, []string{"heavy", "light", "light", "heavy", "light", "light", "heavy", "heavy", "heavy", "heavy"}, order)
+}
+
+func TestQuerySchedulerDatabaseLimit(t *testing.T) {
+	s := newTestScheduler(t, `
+query_scheduler:
+  default_max_concurrent_queries: 2
+  databases:
+    - name: limited
+      max_concurrent_queries: 1
+`)
+	started := make(chan scheduledRun, 16)
+	acquireInBackground(t, s, "limited", started)
+	first := <-started
+	acquireInBackground(t, s, "limited", started)
+	assert.Len(t, started, 0)
+
+	// other databases have their own limits
+	acquireInBackground(t, s, "other", started)
+	acquireInBackground(t, s, "other", started)
+	<-started
+	<-started
+	acquireInBackground(t, s, "other", started)
+	assert.Len(t, started, 0)
+
+	first.release()
+	second := <-started
+	assert.Equal(t, "limited", second.db)
+}
+
+func TestQuerySchedulerQueueTimeout(t *testing.T) {
+	s := newTestScheduler(t, `
+query_scheduler:
+  max_concurrent_queries: 1
+  queue_timeout_millis: 10
+`)
+	release, err := s.acquire(context.Background(), "db")
+	require.NoError(t, err)
+
+	_, err = s.acquire(context.Background(), "db")
+	var sqlErr *mysql.SQLError
+	require.True(t, errors.As(err, &sqlErr))
+	assert.Equal(t, erTooManyConcurrentTrxs, sqlErr.Number())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.acquire(ctx, "db")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	release()
+	release, err = s.acquire(context.Background(), "db")
+	require.NoError(t, err)
+	release()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	assert.Equal(t, 0, s.running)
+	assert.Empty(t, s.dbs)
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// retryBackoff is the longest wait before the first retry of a statement. The longest wait doubles with each retry,
+// and the wait is random up to it, so that statements which conflicted with each other don't conflict again.
+const retryBackoff = 10 * time.Millisecond
+
+// retryableProcedures are the dolt procedures whose effects are all rolled back with the transaction they run in, and
+// so can be retried like any other write.
+var retryableProcedures = map[string]struct{}{
+	"dolt_add":         {},
+	"dolt_cherry_pick": {},
+	"dolt_commit":      {},
+	"dolt_merge":       {},
+	"dolt_revert":      {},
+}
+
+// retryHandler is a mysql.Handler which retries autocommit writes whose transactions fail to commit because they
+// conflict with a transaction another client committed first. These fail with a serialization failure, which is
+// ER_LOCK_DEADLOCK with SQLSTATE 40001, and usually succeed when run again. Retries are off unless
+// dolt_autocommit_retries is set to the number of times a statement may be retried.
+//
+// A statement is only retried when it runs in its own transaction because @@autocommit is set, since a statement in
+// an explicit transaction can't be retried without the statements before it. It must also be an INSERT, REPLACE,
+// UPDATE or DELETE, or a call to one of the retryableProcedures, so that running it again after its transaction was
+// rolled back is the same as running it once. The results of a retried statement are held back until it succeeds, so
+// that the client only sees the results of its last attempt. Errors which retrying can't fix, such as unresolved
+// merge conflicts, are returned at once.
+type retryHandler struct {
+	serverHandler
+	sessions *connSessions
+}
+
+var _ serverHandler = retryHandler{}
+
+// newRetryHandler wraps |h|, which must be the go-mysql-server handler or a wrapper of it, to retry the statements of
+// the sessions tracked by |sessions|.
+func newRetryHandler(h mysql.Handler, sessions *connSessions) (mysql.Handler, error) {
+	sh, err := asServerHandler(h, "statement retries")
+	if err != nil {
+		return nil, err
+	}
+	return retryHandler{serverHandler: sh, sessions: sessions}, nil
+}
+
+func (h retryHandler) ConnectionClosed(c *mysql.Conn) {
+	h.sessions.remove(c.ConnectionID)
+	h.serverHandler.ConnectionClosed(c)
+}
+
+func (h retryHandler) ComQuery(ctx context.Context, c *mysql.Conn, query string, callback mysql.ResultSpoolFn) error {
+	retries := h.retries(c, func() sqlparser.Statement { return h.parse(c, query) })
+	return runWithRetries(ctx, retries, func(callback mysql.ResultSpoolFn) error {
+		return h.serverHandler.ComQuery(ctx, c, query, callback)
+	}, callback)
+}
+
+func (h retryHandler) ComParsedQuery(ctx context.Context, c *mysql.Conn, query string, parsed sqlparser.Statement, callback mysql.ResultSpoolFn) error {
+	retries := h.retries(c, func() sqlparser.Statement { return parsed })
+	return runWithRetries(ctx, retries, func(callback mysql.ResultSpoolFn) error {
+		return h.serverHandler.ComParsedQuery(ctx, c, query, parsed, callback)
+	}, callback)
+}
+
+func (h retryHandler) ComStmtExecute(ctx context.Context, c *mysql.Conn, prepare *mysql.PrepareData, callback func(*sqltypes.Result) error) error {
+	retries := h.retries(c, func() sqlparser.Statement { return h.parse(c, prepare.PrepareStmt) })
+	return runWithRetries(ctx, retries, func(callback mysql.ResultSpoolFn) error {
+		return h.serverHandler.ComStmtExecute(ctx, c, prepare, func(res *sqltypes.Result) error {
+			return callback(res, false)
+		})
+	}, func(res *sqltypes.Result, _ bool) error {
+		return callback(res)
+	})
+}
+
+func (h retryHandler) ComExecuteBound(ctx context.Context, c *mysql.Conn, query string, boundQuery mysql.BoundQuery, callback mysql.ResultSpoolFn) error {
+	retries := h.retries(c, func() sqlparser.Statement { return h.parse(c, query) })
+	return runWithRetries(ctx, retries, func(callback mysql.ResultSpoolFn) error {
+		return h.serverHandler.ComExecuteBound(ctx, c, query, boundQuery, callback)
+	}, callback)
+}
+
+// retries returns the number of times the next statement on |c| may be retried, which is zero unless its session set
+// dolt_autocommit_retries and the statement returned by |stmt| is a retryable write which will run in its own
+// autocommit transaction.
+func (h retryHandler) retries(c *mysql.Conn, stmt func() sqlparser.Statement) int {
+	sess := h.sessions.get(c.ConnectionID)
+	if sess == nil || sess.GetIgnoreAutoCommit() || sess.GetTransaction() != nil {
+		return 0
+	}
+	ctx := sql.NewContext(context.Background(), sql.WithSession(sess))
+	val, err := sess.GetSessionVariable(ctx, dsess.AutocommitRetries)
+	if err != nil {
+		return 0
+	}
+	retries, _ := val.(int64)
+	if retries <= 0 {
+		return 0
+	}
+	val, err = sess.GetSessionVariable(ctx, sql.AutoCommitSessionVar)
+	if err != nil {
+		return 0
+	}
+	if autocommit, err := sql.ConvertToBool(ctx, val); err != nil || !autocommit {
+		return 0
+	}
+	if !isRetryableStatement(stmt()) {
+		return 0
+	}
+	return int(retries)
+}
+
+func (h retryHandler) parse(c *mysql.Conn, query string) sqlparser.Statement {
+	opts, err := h.ParserOptionsForConnection(c)
+	if err != nil {
+		return nil
+	}
+	stmt, err := sqlparser.ParseWithOptions(context.Background(), query, opts)
+	if err != nil {
+		return nil
+	}
+	return stmt
+}
+
+// isRetryableStatement returns whether |stmt| is a write which can be run again after its transaction is rolled back.
+func isRetryableStatement(stmt sqlparser.Statement) bool {
+	switch s := stmt.(type) {
+	case *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete:
+		return true
+	case *sqlparser.Call:
+		_, ok := retryableProcedures[strings.ToLower(s.ProcName.Name.String())]
+		return ok
+	default:
+		return false
+	}
+}
+
+// isSerializationFailure returns whether |err| is the error of a transaction which conflicted with another.
+func isSerializationFailure(err error) bool {
+	var sqlErr *mysql.SQLError
+	if errors.As(err, &sqlErr) {
+		return sqlErr.Num == mysql.ERLockDeadlock
+	}
+	return sql.ErrLockDeadlock.Is(err)
+}
+
+type spooledResult struct {
+	res  *sqltypes.Result
+	more bool
+}
+
+// runWithRetries runs a statement with |exec| until it doesn't fail with a serialization failure, at most |retries|
+// times more than once, and then passes the results of its last attempt to |callback|. Without retries, |exec| passes
+// results to |callback| as they're returned.
+func runWithRetries(ctx context.Context, retries int, exec func(mysql.ResultSpoolFn) error, callback mysql.ResultSpoolFn) error {
+	if retries == 0 {
+		return exec(callback)
+	}
+
+	for attempt := 0; ; attempt++ {
+		var results []spooledResult
+		err := exec(func(res *sqltypes.Result, more bool) error {
+			// the handler may reuse the memory of results once they're spooled
+			results = append(results, spooledResult{res: copyResult(res), more: more})
+			return nil
+		})
+		if err == nil {
+			for _, r := range results {
+				if err := callback(r.res, r.more); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if !isSerializationFailure(err) {
+			return err
+		}
+		if attempt == retries {
+			sqlErr := sql.CastSQLError(err)
+			return mysql.NewSQLError(sqlErr.Num, sqlErr.State, "%s (retried %d times)", sqlErr.Message, retries)
+		}
+
+		wait := time.Duration(rand.Int63n(int64(retryBackoff << attempt)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func copyResult(res *sqltypes.Result) *sqltypes.Result {
+	cp := *res
+	cp.Rows = make([][]sqltypes.Value, len(res.Rows))
+	for i, row := range res.Rows {
+		cp.Rows[i] = make([]sqltypes.Value, len(row))
+		for j, v := range row {
+			cp.Rows[i][j] = sqltypes.MakeTrusted(v.Type(), append([]byte(nil), v.ToBytes()...))
+		}
+	}
+	return &cp
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryableStatement(t *testing.T) {
+	tests := []struct {
+		query     string
+		retryable bool
+	}{
+		{"insert into t values (1)", true},
+		{"replace into t values (1)", true},
+		{"update t set c = 1", true},
+		{"delete from t", true},
+		{"call dolt_merge('other')", true},
+		{"call DOLT_COMMIT('-am', 'message')", true},
+		{"call dolt_push('origin', 'main')", false},
+		{"call dolt_branch('other')", false},
+		{"select * from t", false},
+		{"create table u (pk int primary key)", false},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := sqlparser.Parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.retryable, isRetryableStatement(stmt))
+		})
+	}
+	assert.False(t, isRetryableStatement(nil))
+}
+
+func TestRunWithRetries(t *testing.T) {
+	ctx := context.Background()
+	serializationFailure := sql.CastSQLError(sql.ErrLockDeadlock.New("this transaction conflicts with a committed transaction from another client"))
+	result := &sqltypes.Result{RowsAffected: 1}
+
+	// failAttempts returns a statement which fails with |err| the first |n| times it's run
+	failAttempts := func(n int, err error) (func(mysql.ResultSpoolFn) error, *int) {
+		attempts := 0
+		return func(callback mysql.ResultSpoolFn) error {
+			attempts++
+			if err := callback(result, false); err != nil {
+				return err
+			}
+			if attempts <= n {
+				return err
+			}
+			return nil
+		}, &attempts
+	}
+	collect := func(results *[]*sqltypes.Result) mysql.ResultSpoolFn {
+		return func(res *sqltypes.Result, more bool) error {
+			*results = append(*results, res)
+			return nil
+		}
+	}
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		exec, attempts := failAttempts(2, serializationFailure)
+		var results []*sqltypes.Result
+		require.NoError(t, runWithRetries(ctx, 3, exec, collect(&results)))
+		assert.Equal(t, 3, *attempts)
+		// only the results of the last attempt are returned
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		exec, attempts := failAttempts(10, serializationFailure)
+		var results []*sqltypes.Result
+		err := runWithRetries(ctx, 2, exec, collect(&results))
+		assert.Equal(t, 3, *attempts)
+		assert.Empty(t, results)
+		var sqlErr *mysql.SQLError
+		require.True(t, errors.As(err, &sqlErr))
+		assert.Equal(t, mysql.ERLockDeadlock, sqlErr.Num)
+		assert.Equal(t, mysql.SSLockDeadlock, sqlErr.State)
+		assert.Contains(t, sqlErr.Message, "retried 2 times")
+	})
+
+	t.Run("other errors aren't retried", func(t *testing.T) {
+		engineErr := errors.New("engine error")
+		exec, attempts := failAttempts(1, engineErr)
+		var results []*sqltypes.Result
+		assert.Equal(t, engineErr, runWithRetries(ctx, 3, exec, collect(&results)))
+		assert.Equal(t, 1, *attempts)
+		assert.Empty(t, results)
+	})
+
+	t.Run("without retries", func(t *testing.T) {
+		exec, attempts := failAttempts(1, serializationFailure)
+		var results []*sqltypes.Result
+		assert.Equal(t, serializationFailure, runWithRetries(ctx, 0, exec, collect(&results)))
+		assert.Equal(t, 1, *attempts)
+		// results are returned as they're spooled
+		assert.Len(t, results, 1)
+	})
+}
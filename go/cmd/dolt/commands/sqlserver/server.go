@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/eventscheduler"
@@ -36,8 +37,10 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -51,15 +54,21 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/auditlog"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/binlogreplication"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/cluster"
-	_ "github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/profiler"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/querylog"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
 	"github.com/dolthub/dolt/go/libraries/events"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/libraries/utils/svcs"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
 	"github.com/dolthub/dolt/go/store/chunks"
 )
 
@@ -249,6 +258,8 @@ func ConfigureServices(
 				ClusterController:          clusterController,
 				BinlogReplicaController:    binlogreplication.DoltBinlogReplicaController,
 				SkipRootUserInitialization: cfg.SkipRootUserInit,
+				ExternalFunctions:          externalFunctionSpecs(cfg.ServerConfig.ExternalFunctions()),
+				ExternalTableCredentials:   federatedCredentials(cfg.ServerConfig.ExternalTableCredentials()),
 			}
 			return nil
 		},
@@ -505,7 +516,7 @@ func ConfigureServices(
 	InitMetricsListener := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
 			labels := cfg.ServerConfig.MetricsLabels()
-			metListener, err = newMetricsListener(labels, cfg.Version, clusterController)
+			metListener, err = newMetricsListener(labels, cfg.Version, clusterController, engineDbSizes(sqlEngine))
 			return err
 		},
 		StopF: func() error {
@@ -515,6 +526,108 @@ func ConfigureServices(
 	}
 	controller.Register(InitMetricsListener)
 
+	var metPushers []*events.MetricsPusher
+	RunMetricsEmitters := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			for _, emitterCfg := range cfg.ServerConfig.MetricsEmitters() {
+				emitter, err := newMetricsEmitter(emitterCfg, cfg.Version)
+				if err != nil {
+					return err
+				}
+				metPushers = append(metPushers, events.NewMetricsPusher(prometheus.DefaultGatherer, emitter, emitterCfg.Interval()))
+			}
+			return nil
+		},
+		RunF: func(ctx context.Context) {
+			var wg sync.WaitGroup
+			for _, pusher := range metPushers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					pusher.Run(ctx)
+				}()
+			}
+			wg.Wait()
+		},
+		StopF: func() error {
+			for _, pusher := range metPushers {
+				pusher.Stop()
+			}
+			return nil
+		},
+	}
+	controller.Register(RunMetricsEmitters)
+
+	var auditSess *auditSessions
+	InitAuditLog := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			auditCfg := cfg.ServerConfig.AuditLog()
+			if auditCfg == nil {
+				return nil
+			}
+			auditLog, err := newAuditLogger(auditCfg)
+			if err != nil {
+				return err
+			}
+			auditlog.SetCurrent(auditLog)
+			auditSess = newAuditSessions(auditLog)
+			return nil
+		},
+		StopF: func() error {
+			if auditSess == nil {
+				return nil
+			}
+			auditlog.SetCurrent(nil)
+			return auditSess.log.Close()
+		},
+	}
+	controller.Register(InitAuditLog)
+
+	var scheduler *queryScheduler
+	var schedMetrics *schedulerMetrics
+	InitQueryScheduler := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			schedCfg := cfg.ServerConfig.QueryScheduler()
+			if schedCfg == nil {
+				return nil
+			}
+			schedMetrics = newSchedulerMetrics(cfg.ServerConfig.MetricsLabels())
+			scheduler = newQueryScheduler(schedCfg, schedMetrics)
+			return nil
+		},
+		StopF: func() error {
+			schedMetrics.Close()
+			return nil
+		},
+	}
+	controller.Register(InitQueryScheduler)
+
+	// The query log is always available, but only records statements while dolt_query_log is enabled
+	InitQueryLog := &svcs.AnonService{
+		InitF: func(context.Context) error {
+			querylog.SetCurrent(querylog.NewLog(querylog.GlobalLimits()))
+			return nil
+		},
+		StopF: func() error {
+			querylog.SetCurrent(nil)
+			return nil
+		},
+	}
+	controller.Register(InitQueryLog)
+
+	// The profiler always runs, but only captures profiles while dolt_profile_interval is set
+	profiles := profiler.NewProfiler(profiler.GlobalOptions, "dolt-sql-server")
+	RunProfiler := &svcs.AnonService{
+		RunF: func(ctx context.Context) {
+			profiles.Run(ctx)
+		},
+		StopF: func() error {
+			profiles.Stop()
+			return nil
+		},
+	}
+	controller.Register(RunProfiler)
+
 	InitLockSuperUser := &svcs.AnonService{
 		InitF: func(context.Context) error {
 			mysqlDb := sqlEngine.GetUnderlyingEngine().Analyzer.Catalog.MySQLDb
@@ -603,6 +716,16 @@ func ConfigureServices(
 				apiReadOnly = *cfg.ServerConfig.RemotesapiReadOnly()
 			}
 
+			var maxBandwidth uint64
+			if cfg.ServerConfig.RemotesapiMaxBandwidth() != nil {
+				var err error
+				maxBandwidth, err = iohelp.ParseBandwidth(*cfg.ServerConfig.RemotesapiMaxBandwidth())
+				if err != nil {
+					lgr.Errorf("error in remotesapi max_bandwidth: %v", err)
+					return err
+				}
+			}
+
 			listenaddr := fmt.Sprintf(":%d", port)
 			sqlContextInterceptor := sqle.SqlContextServerInterceptor{
 				Factory: sqlEngine.NewDefaultContext,
@@ -613,6 +736,7 @@ func ConfigureServices(
 				HttpListenAddr:     listenaddr,
 				GrpcListenAddr:     listenaddr,
 				ConcurrencyControl: remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_ASSERT_WORKING_SET,
+				MaxBandwidth:       maxBandwidth,
 				Options:            sqlContextInterceptor.Options(),
 				HttpInterceptor:    sqlContextInterceptor.HTTP(nil),
 			}
@@ -723,27 +847,54 @@ func ConfigureServices(
 	var sqlServerClosed bool
 	InitSQLServer := &svcs.AnonService{
 		InitF: func(context.Context) (err error) {
+			sessionBuilder := newSessionBuilder(sqlEngine, cfg.ServerConfig)
+			// Retries are wrapped first, so that every other wrapper sees a retried statement once, with the results of
+			// its last attempt
+			retrySess := newConnSessions(nil)
+			sessionBuilder = retrySess.wrapSessionBuilder(sessionBuilder)
+			wrappers := []server.HandlerWrapper{func(h mysql.Handler) (mysql.Handler, error) {
+				return newRetryHandler(h, retrySess)
+			}}
+			// Query limits are wrapped next, so that the query log and audit log record the statements they interrupt
+			limitsSess := newConnSessions(nil)
+			sessionBuilder = limitsSess.wrapSessionBuilder(sessionBuilder)
+			wrappers = append(wrappers, func(h mysql.Handler) (mysql.Handler, error) {
+				return newLimitsHandler(h, limitsSess)
+			})
+			// Statements are scheduled outside of their limits, so that the time they wait to run doesn't count
+			// against max_execution_time
+			if scheduler != nil {
+				schedulerSess := newConnSessions(nil)
+				sessionBuilder = schedulerSess.wrapSessionBuilder(sessionBuilder)
+				wrappers = append(wrappers, func(h mysql.Handler) (mysql.Handler, error) {
+					return newSchedulerHandler(h, schedulerSess, scheduler)
+				})
+			}
+			queryLogSess := newConnSessions(nil)
+			sessionBuilder = queryLogSess.wrapSessionBuilder(sessionBuilder)
+			wrappers = append(wrappers, func(h mysql.Handler) (mysql.Handler, error) {
+				return newQueryLogHandler(h, queryLogSess)
+			})
+			if auditSess != nil {
+				sessionBuilder = auditSess.wrapSessionBuilder(sessionBuilder)
+				wrappers = append(wrappers, func(h mysql.Handler) (mysql.Handler, error) {
+					return newAuditHandler(h, auditSess)
+				})
+			}
 			v, ok := cfg.ServerConfig.(servercfg.ValidatingServerConfig)
 			if ok && v.GoldenMysqlConnectionString() != "" {
-				mySQLServer, err = server.NewServerWithHandler(
-					serverConf,
-					sqlEngine.GetUnderlyingEngine(),
-					sqlEngine.ContextFactory,
-					newSessionBuilder(sqlEngine, cfg.ServerConfig),
-					metListener,
-					func(h mysql.Handler) (mysql.Handler, error) {
-						return golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
-					},
-				)
-			} else {
-				mySQLServer, err = server.NewServer(
-					serverConf,
-					sqlEngine.GetUnderlyingEngine(),
-					sqlEngine.ContextFactory,
-					newSessionBuilder(sqlEngine, cfg.ServerConfig),
-					metListener,
-				)
+				wrappers = append(wrappers, func(h mysql.Handler) (mysql.Handler, error) {
+					return golden.NewValidatingHandler(h, v.GoldenMysqlConnectionString(), logrus.StandardLogger())
+				})
 			}
+			mySQLServer, err = server.NewServerWithHandler(
+				serverConf,
+				sqlEngine.GetUnderlyingEngine(),
+				sqlEngine.ContextFactory,
+				sessionBuilder,
+				metListener,
+				chainHandlerWrappers(wrappers),
+			)
 			if errors.Is(err, server.UnixSocketInUseError) {
 				lgr.Warn("unix socket set up failed: file already in use: ", serverConf.Socket)
 				err = nil
@@ -1035,6 +1186,21 @@ func portInUse(hostPort string) bool {
 	return false
 }
 
+// chainHandlerWrappers returns a HandlerWrapper which applies |wrappers| in order, so the first wraps the server's
+// handler and the last is the outermost.
+func chainHandlerWrappers(wrappers []server.HandlerWrapper) server.HandlerWrapper {
+	return func(h mysql.Handler) (mysql.Handler, error) {
+		var err error
+		for _, wrap := range wrappers {
+			h, err = wrap(h)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return h, nil
+	}
+}
+
 func newSessionBuilder(se *engine.SqlEngine, config servercfg.ServerConfig) server.SessionBuilder {
 	userToSessionVars := make(map[string]map[string]interface{})
 	userVars := config.UserVars()
@@ -1106,6 +1272,10 @@ func getConfigFromServerConfig(serverConfig servercfg.ServerConfig, plf server.P
 	serverConf.MaxLoggedQueryLen = serverConfig.MaxLoggedQueryLen()
 	serverConf.EncodeLoggedQuery = serverConfig.ShouldEncodeLoggedQuery()
 	serverConf.ProtocolListenerFactory = plf
+	if tracing.Enabled() {
+		// parents the spans of the storage layer under the span of their query
+		serverConf.Tracer = otel.Tracer("github.com/dolthub/dolt/go/cmd/dolt/commands/sqlserver")
+	}
 
 	return serverConf, nil
 }
@@ -1145,3 +1315,39 @@ func getEventSchedulerStatus(status string) (eventscheduler.SchedulerStatus, err
 		return eventscheduler.SchedulerDisabled, fmt.Errorf("Error while setting value '%s' to 'event_scheduler'.", status)
 	}
 }
+
+// externalFunctionSpecs converts the external functions in the server config to the specs used to dial them.
+func externalFunctionSpecs(fns []servercfg.ExternalFunctionConfig) []dfunctions.ExternalFunctionSpec {
+	if len(fns) == 0 {
+		return nil
+	}
+	specs := make([]dfunctions.ExternalFunctionSpec, len(fns))
+	for i, fn := range fns {
+		specs[i] = dfunctions.ExternalFunctionSpec{
+			Name:             fn.Name(),
+			Endpoint:         fn.Endpoint(),
+			Returns:          fn.Returns(),
+			Timeout:          fn.Timeout(),
+			BatchSize:        fn.BatchSize(),
+			FailureThreshold: fn.FailureThreshold(),
+			Cooldown:         fn.Cooldown(),
+		}
+	}
+	return specs
+}
+
+// federatedCredentials converts the external table credentials in the server config to the credentials federated
+// tables are checked against.
+func federatedCredentials(creds []servercfg.ExternalTableCredentialConfig) []dtables.FederatedCredential {
+	if len(creds) == 0 {
+		return nil
+	}
+	ret := make([]dtables.FederatedCredential, len(creds))
+	for i, cred := range creds {
+		ret[i] = dtables.FederatedCredential{
+			Name:  cred.Name(),
+			Hosts: cred.Hosts(),
+		}
+	}
+	return ret
+}
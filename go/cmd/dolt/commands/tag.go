@@ -47,11 +47,13 @@ var tagDocs = cli.CommandDocumentationContent{
 
 The command's second form creates a new tag named {{.LessThan}}tagname{{.GreaterThan}} which points to the current {{.EmphasisLeft}}HEAD{{.EmphasisRight}}, or {{.LessThan}}ref{{.GreaterThan}} if given. Optionally, a tag message can be passed using the {{.EmphasisLeft}}-m{{.EmphasisRight}} option. 
 
-With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted.`,
+With {{.EmphasisLeft}}-a{{.EmphasisRight}}, the tag is annotated and a message is required. With {{.EmphasisLeft}}-S{{.EmphasisRight}}, the tag is signed using GPG.
+
+With a {{.EmphasisLeft}}-d{{.EmphasisRight}}, {{.LessThan}}tagname{{.GreaterThan}} will be deleted. With {{.EmphasisLeft}}--remote{{.EmphasisRight}}, it is deleted from the named remote instead of the local database.`,
 	Synopsis: []string{
 		`[-v]`,
-		`[-m {{.LessThan}}message{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}]`,
-		`-d {{.LessThan}}tagname{{.GreaterThan}}`,
+		`[-a] [-m {{.LessThan}}message{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}} [{{.LessThan}}ref{{.GreaterThan}}] [-S[{{.LessThan}}key-id{{.GreaterThan}}]]`,
+		`-d [--remote {{.LessThan}}remote{{.GreaterThan}}] {{.LessThan}}tagname{{.GreaterThan}}`,
 	},
 }
 
@@ -117,6 +119,8 @@ func createTag(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgPar
 		return errors.New("verbose flag can only be used with tag listing")
 	} else if len(apr.Args) > 2 {
 		return errors.New("create tag takes at most two args")
+	} else if apr.Contains(cli.RemoteParam) {
+		return errors.New("--remote is only supported when deleting tags")
 	}
 
 	tagName := apr.Arg(0)
@@ -127,25 +131,28 @@ func createTag(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgPar
 	message, _ := apr.GetValue(cli.MessageArg)
 	author, _ := apr.GetValue(cli.AuthorParam)
 
-	var query string
-	var params []interface{}
-	if len(message) == 0 {
-		if len(author) == 0 {
-			query = "call dolt_tag(?, ?)"
-			params = []interface{}{tagName, startPoint}
-		} else {
-			query = "call dolt_tag(?, ?, '--author', ?)"
-			params = []interface{}{tagName, startPoint, author}
-		}
-	} else {
-		if len(author) == 0 {
-			query = "call dolt_tag('-m', ?, ?, ?)"
-			params = []interface{}{message, tagName, startPoint}
-		} else {
-			query = "call dolt_tag('-m', ?, ?, ?, '--author', ?)"
-			params = []interface{}{message, tagName, startPoint, author}
+	// the tag name and ref come first, since a key id given to --gpg-sign is the argument after it
+	query := "call dolt_tag(?, ?"
+	params := []interface{}{tagName, startPoint}
+	if len(message) != 0 {
+		query += ", '-m', ?"
+		params = append(params, message)
+	}
+	if len(author) != 0 {
+		query += ", '--author', ?"
+		params = append(params, author)
+	}
+	if apr.Contains(cli.AnnotateFlag) {
+		query += ", '--annotate'"
+	}
+	if apr.Contains(cli.SignFlag) {
+		query += ", '--gpg-sign'"
+		if gpgKey := apr.GetValueOrDefault(cli.SignFlag, ""); gpgKey != "" {
+			query += ", ?"
+			params = append(params, gpgKey)
 		}
 	}
+	query += ")"
 
 	_, err := InterpolateAndRunQuery(queryist, sqlCtx, query, params...)
 	if err != nil {
@@ -160,8 +167,14 @@ func deleteTags(queryist cli.Queryist, sqlCtx *sql.Context, apr *argparser.ArgPa
 	} else if apr.Contains(cli.VerboseFlag) {
 		return errors.New("delete and verbose options are incompatible")
 	} else {
+		remote, hasRemote := apr.GetValue(cli.RemoteParam)
 		for _, tagName := range apr.Args {
-			_, err := InterpolateAndRunQuery(queryist, sqlCtx, "call dolt_tag('-d', ?)", tagName)
+			var err error
+			if hasRemote {
+				_, err = InterpolateAndRunQuery(queryist, sqlCtx, "call dolt_tag('-d', '--remote', ?, ?)", remote, tagName)
+			} else {
+				_, err = InterpolateAndRunQuery(queryist, sqlCtx, "call dolt_tag('-d', ?)", tagName)
+			}
 			if err != nil {
 				return fmt.Errorf("error: failed to delete tag %s: %w", tagName, err)
 			}
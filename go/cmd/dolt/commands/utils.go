@@ -608,6 +608,9 @@ func PrintCommitInfo(pager *outputpager.Pager, minParents int, showParents, show
 	}
 
 	pager.Writer.Write([]byte(fmt.Sprintf("\nAuthor: %s <%s>", comm.commitMeta.Name, comm.commitMeta.Email)))
+	if comm.commitMeta.CommitterName != "" {
+		pager.Writer.Write([]byte(fmt.Sprintf("\nCommitter: %s <%s>", comm.commitMeta.CommitterName, comm.commitMeta.CommitterEmail)))
+	}
 
 	timeStr := comm.commitMeta.FormatTS()
 	pager.Writer.Write([]byte(fmt.Sprintf("\nDate:  %s", timeStr)))
@@ -667,6 +670,7 @@ func printRefs(pager *outputpager.Pager, comm *CommitInfo, decoration string) {
 
 type commitInfoOptions struct {
 	showSignature bool
+	showCommitter bool
 }
 
 // getCommitInfo returns the commit info for the given ref.
@@ -680,17 +684,16 @@ func getCommitInfoWithOptions(queryist cli.Queryist, sqlCtx *sql.Context, ref st
 		return nil, fmt.Errorf("error getting hash of HEAD: %v", err)
 	}
 
-	var q string
+	logArgs := "'--parents', '--decorate=full'"
 	if opts.showSignature {
-		q, err = dbr.InterpolateForDialect("select * from dolt_log(?, '--parents', '--decorate=full', '--show-signature')", []interface{}{ref}, dialect.MySQL)
-		if err != nil {
-			return nil, fmt.Errorf("error interpolating query: %v", err)
-		}
-	} else {
-		q, err = dbr.InterpolateForDialect("select * from dolt_log(?, '--parents', '--decorate=full')", []interface{}{ref}, dialect.MySQL)
-		if err != nil {
-			return nil, fmt.Errorf("error interpolating query: %v", err)
-		}
+		logArgs += ", '--show-signature'"
+	}
+	if opts.showCommitter {
+		logArgs += ", '--show-committer'"
+	}
+	q, err := dbr.InterpolateForDialect("select * from dolt_log(?, "+logArgs+")", []interface{}{ref}, dialect.MySQL)
+	if err != nil {
+		return nil, fmt.Errorf("error interpolating query: %v", err)
 	}
 
 	rows, err := GetRowsForSql(queryist, sqlCtx, q)
@@ -717,10 +720,16 @@ func getCommitInfoWithOptions(queryist cli.Queryist, sqlCtx *sql.Context, ref st
 	isHead := commitHash == hashOfHead
 
 	var signature string
-	if len(row) > 7 {
+	if opts.showSignature {
 		signature = row[7].(string)
 	}
 
+	var committerName, committerEmail string
+	if opts.showCommitter {
+		committerName = row[len(row)-2].(string)
+		committerEmail = row[len(row)-1].(string)
+	}
+
 	localBranchesForHash, err := getBranchesForHash(queryist, sqlCtx, commitHash, true)
 	if err != nil {
 		return nil, fmt.Errorf("error getting branches for hash '%s': %v", commitHash, err)
@@ -736,12 +745,14 @@ func getCommitInfoWithOptions(queryist cli.Queryist, sqlCtx *sql.Context, ref st
 
 	ci := &CommitInfo{
 		commitMeta: &datas.CommitMeta{
-			Name:          name,
-			Email:         email,
-			Timestamp:     timestamp,
-			Description:   message,
-			UserTimestamp: int64(timestamp),
-			Signature:     signature,
+			Name:           name,
+			Email:          email,
+			Timestamp:      timestamp,
+			Description:    message,
+			UserTimestamp:  int64(timestamp),
+			Signature:      signature,
+			CommitterName:  committerName,
+			CommitterEmail: committerEmail,
 		},
 		commitHash:        commitHash,
 		height:            height,
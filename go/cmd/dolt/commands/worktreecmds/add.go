@@ -0,0 +1,89 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktreecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var addDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Create a new working directory with a branch checked out.",
+	LongDesc: `Creates a linked working directory at {{.LessThan}}dir{{.GreaterThan}} with {{.LessThan}}branch{{.GreaterThan}} checked out.
+
+The new directory shares the storage of the current repository rather than copying it, so commits, branches and tags created in any worktree are visible in all of them. Each worktree has its own checked out branch and its own working set, which allows working on two branches at the same time without cloning.
+
+A branch can only be checked out in one worktree at a time.
+`,
+	Synopsis: []string{
+		"{{.LessThan}}dir{{.GreaterThan}} {{.LessThan}}branch{{.GreaterThan}}",
+	},
+}
+
+type AddCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd AddCmd) Name() string {
+	return "add"
+}
+
+// Description returns a description of the command
+func (cmd AddCmd) Description() string {
+	return addDocs.ShortDesc
+}
+
+func (cmd AddCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(addDocs, ap)
+}
+
+func (cmd AddCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 2)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"dir", "The directory to create the worktree in."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"branch", "The branch to check out in the new worktree."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd AddCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_WORKTREE_ADD
+}
+
+// Exec executes the command
+func (cmd AddCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, addDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 2 {
+		usage()
+		return 1
+	}
+
+	dir, branch := apr.Arg(0), apr.Arg(1)
+	err := dEnv.AddWorktree(ctx, dir, branch)
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("failed to add worktree").AddCause(err).Build(), usage)
+	}
+
+	cli.Printf("Created worktree '%s' with branch '%s' checked out\n", dir, branch)
+	return 0
+}
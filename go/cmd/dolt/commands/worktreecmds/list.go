@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktreecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var listDocs = cli.CommandDocumentationContent{
+	ShortDesc: "List the worktrees attached to this repository.",
+	LongDesc:  `Lists each linked worktree of the repository along with the branch it currently has checked out.`,
+	Synopsis: []string{
+		"",
+	},
+}
+
+type ListCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd ListCmd) Name() string {
+	return "list"
+}
+
+// Description returns a description of the command
+func (cmd ListCmd) Description() string {
+	return listDocs.ShortDesc
+}
+
+func (cmd ListCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(listDocs, ap)
+}
+
+func (cmd ListCmd) ArgParser() *argparser.ArgParser {
+	return argparser.NewArgParserWithMaxArgs(cmd.Name(), 0)
+}
+
+// EventType returns the type of the event to log
+func (cmd ListCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_WORKTREE_LIST
+}
+
+// Exec executes the command
+func (cmd ListCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, listDocs, ap))
+	cli.ParseArgsOrDie(ap, args, help)
+
+	worktrees, err := dEnv.Worktrees()
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.VerboseErrorFromError(err), usage)
+	}
+
+	for _, wt := range worktrees {
+		cli.Printf("%s\t[%s]\n", wt.Path, wt.Branch)
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worktreecmds
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+)
+
+var removeDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Remove a worktree.",
+	LongDesc: `Detaches the worktree at {{.LessThan}}dir{{.GreaterThan}} from the repository and deletes its {{.EmphasisLeft}}.dolt{{.EmphasisRight}} directory.
+
+Uncommitted changes in the worktree are not lost: they remain in the working set of the branch it had checked out, and are visible after checking that branch out in another worktree.
+`,
+	Synopsis: []string{
+		"{{.LessThan}}dir{{.GreaterThan}}",
+	},
+}
+
+type RemoveCmd struct{}
+
+// Name returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RemoveCmd) Name() string {
+	return "remove"
+}
+
+// Description returns a description of the command
+func (cmd RemoveCmd) Description() string {
+	return removeDocs.ShortDesc
+}
+
+func (cmd RemoveCmd) Docs() *cli.CommandDocumentation {
+	ap := cmd.ArgParser()
+	return cli.NewCommandDocumentation(removeDocs, ap)
+}
+
+func (cmd RemoveCmd) ArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParserWithMaxArgs(cmd.Name(), 1)
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"dir", "The directory of the worktree to remove."})
+	return ap
+}
+
+// EventType returns the type of the event to log
+func (cmd RemoveCmd) EventType() eventsapi.ClientEventType {
+	return eventsapi.ClientEventType_WORKTREE_REMOVE
+}
+
+// Exec executes the command
+func (cmd RemoveCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv, cliCtx cli.CliContext) int {
+	ap := cmd.ArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.CommandDocsForCommandString(commandStr, removeDocs, ap))
+	apr := cli.ParseArgsOrDie(ap, args, help)
+
+	if apr.NArg() != 1 {
+		usage()
+		return 1
+	}
+
+	err := dEnv.RemoveWorktree(apr.Arg(0))
+	if err != nil {
+		return commands.HandleVErrAndExitCode(errhand.BuildDError("failed to remove worktree").AddCause(err).Build(), usage)
+	}
+	return 0
+}
@@ -62,6 +62,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/tracing"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/util/tempfiles"
 )
@@ -72,6 +73,7 @@ var dumpZshCommand = &commands.GenZshCompCmd{}
 var commandsWithoutCliCtx = []cli.Command{
 	commands.CloneCmd{},
 	commands.BackupCmd{},
+	commands.BundleCmd{},
 	commands.LoginCmd{},
 	credcmds.Commands,
 	schcmds.Commands,
@@ -404,6 +406,16 @@ func runMain() int {
 
 	warnIfMaxFilesTooLow()
 
+	// Export spans with OTLP when the OTEL_EXPORTER_OTLP_* environment variables are set, unless --jaeger already
+	// installed a tracer provider
+	if !tracing.Enabled() {
+		if traceCfg, err := tracing.ConfigFromEnv("dolt"); err != nil {
+			cli.PrintErrln(color.YellowString("not exporting traces: %v", err))
+		} else if shutdown, ok := tracing.Init(traceCfg); ok {
+			defer shutdown(context.Background())
+		}
+	}
+
 	ctx := context.Background()
 	if ok, exit := interceptSendMetrics(ctx, args); ok {
 		return exit
@@ -28,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/sqlserver"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/stashcmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands/tblcmds"
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/worktreecmds"
 	"github.com/dolthub/dolt/go/cmd/dolt/doltversion"
 )
 
@@ -66,6 +67,7 @@ var doltSubCommands = []cli.Command{
 	schcmds.Commands,
 	tblcmds.Commands,
 	commands.TagCmd{},
+	commands.BundleCmd{},
 	commands.BlameCmd{},
 	cvcmds.Commands,
 	commands.SendMetricsCmd{},
@@ -84,9 +86,11 @@ var doltSubCommands = []cli.Command{
 	dumpZshCommand,
 	docscmds.Commands,
 	stashcmds.StashCommands,
+	worktreecmds.Commands,
 	&commands.Assist{},
 	commands.ProfileCmd{},
 	commands.QueryDiff{},
+	commands.CompareToMySQLCmd{},
 	commands.ReflogCmd{},
 	commands.RebaseCmd{},
 	commands.ArchiveCmd{},
@@ -0,0 +1,132 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api diffs and merges the data of a Dolt database from Go, without a SQL engine:
+//
+//	db, err := api.OpenDatabase(ctx, "/path/to/db")
+//	itr, err := db.Diff(ctx, "people", "main~1", "main")
+//	for {
+//		d, err := itr.Next(ctx)
+//		if err == io.EOF {
+//			break
+//		}
+//		...
+//	}
+//
+// Refs are branch names, tags, commit hashes and ancestor specs like main~2, resolved like the dolt CLI resolves
+// them, with HEAD being the current branch of the database. Diff also accepts WORKING and STAGED for the working set
+// of the current branch. Rows are returned as the Go values of their columns, as the SQL engine would return them.
+//
+// A Database must not be used while a dolt sql-server or another process writes to the same database. Only databases
+// of the current storage format are supported.
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/doltversion"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ErrNotADatabase is returned by OpenDatabase for directories which aren't Dolt databases.
+var ErrNotADatabase = errors.New("not a dolt database")
+
+// ErrUnsupportedFormat is returned for databases which aren't of the current storage format.
+var ErrUnsupportedFormat = errors.New("the storage format of this database isn't supported, run dolt migrate to upgrade it")
+
+// Database is a Dolt database opened for diffing and merging.
+type Database struct {
+	env *env.DoltEnv
+}
+
+// OpenDatabase opens the Dolt database in the directory |dir|, which holds its .dolt directory.
+func OpenDatabase(ctx context.Context, dir string) (*Database, error) {
+	fs, err := filesys.LocalFilesysWithWorkingDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, doltversion.Version)
+	if !dEnv.HasDoltDir() {
+		return nil, fmt.Errorf("%w: %s", ErrNotADatabase, dir)
+	}
+	if dEnv.DBLoadError != nil {
+		return nil, dEnv.DBLoadError
+	}
+	return NewDatabase(dEnv)
+}
+
+// NewDatabase returns a Database for the database of |dEnv|, which is closed along with the Database.
+func NewDatabase(dEnv *env.DoltEnv) (*Database, error) {
+	if dEnv.DBLoadError != nil {
+		return nil, dEnv.DBLoadError
+	}
+	if !types.IsFormat_DOLT(dEnv.DoltDB(context.Background()).Format()) {
+		return nil, ErrUnsupportedFormat
+	}
+	return &Database{env: dEnv}, nil
+}
+
+// Close closes the database.
+func (db *Database) Close() error {
+	return db.env.DoltDB(context.Background()).Close()
+}
+
+// resolveRoot returns the root value at |spec|, which is WORKING or STAGED for the working set of the current
+// branch, or a commit spec.
+func (db *Database) resolveRoot(ctx context.Context, spec string) (doltdb.RootValue, error) {
+	switch strings.ToUpper(spec) {
+	case doltdb.Working, doltdb.Staged:
+		roots, err := db.env.Roots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(spec, doltdb.Working) {
+			return roots.Working, nil
+		}
+		return roots.Staged, nil
+	}
+
+	cm, err := db.resolveCommit(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	return cm.GetRootValue(ctx)
+}
+
+// resolveCommit returns the commit at the commit spec |spec|.
+func (db *Database) resolveCommit(ctx context.Context, spec string) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	headRef, err := db.env.RepoStateReader().CWBHeadRef(ctx)
+	if err != nil {
+		return nil, err
+	}
+	optCmt, err := db.env.DoltDB(ctx).Resolve(ctx, cs, headRef)
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve %s: %w", spec, err)
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return nil, doltdb.ErrGhostCommitEncountered
+	}
+	return cm, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	cmd "github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/libraries/doltcore/api"
+	dtu "github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+type testCommand struct {
+	cmd  cli.Command
+	args []string
+}
+
+func setup(t *testing.T, ctx context.Context, cmds ...testCommand) *env.DoltEnv {
+	dEnv := dtu.CreateTestEnv()
+	for _, tc := range cmds {
+		cliCtx, err := cmd.NewArgFreeCliContext(ctx, dEnv, dEnv.FS)
+		require.NoError(t, err)
+		require.Equal(t, 0, tc.cmd.Exec(ctx, tc.cmd.Name(), tc.args, dEnv, cliCtx), "%s %v", tc.cmd.Name(), tc.args)
+	}
+	return dEnv
+}
+
+func readDiffs(t *testing.T, ctx context.Context, itr *api.DiffIter) []api.RowDiff {
+	var diffs []api.RowDiff
+	for {
+		d, err := itr.Next(ctx)
+		if err == io.EOF {
+			return diffs
+		}
+		require.NoError(t, err)
+		diffs = append(diffs, d)
+	}
+}
+
+func TestOpenDatabase(t *testing.T) {
+	_, err := api.OpenDatabase(context.Background(), t.TempDir())
+	assert.ErrorIs(t, err, api.ErrNotADatabase)
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+	dEnv := setup(t, ctx,
+		testCommand{cmd.SqlCmd{}, []string{"-q", "create table t (pk int primary key, c0 varchar(10));"}},
+		testCommand{cmd.SqlCmd{}, []string{"-q", "insert into t values (1, 'a'), (2, 'b'), (3, 'c');"}},
+		testCommand{cmd.CommitCmd{}, []string{"-Am", "created t"}},
+		testCommand{cmd.SqlCmd{}, []string{"-q", "update t set c0 = 'B' where pk = 2; delete from t where pk = 3; insert into t values (4, 'd');"}},
+		testCommand{cmd.CommitCmd{}, []string{"-am", "changed t"}},
+		testCommand{cmd.SqlCmd{}, []string{"-q", "insert into t values (5, 'e');"}},
+	)
+	db, err := api.NewDatabase(dEnv)
+	require.NoError(t, err)
+	defer db.Close()
+
+	itr, err := db.Diff(ctx, "t", "HEAD~1", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pk", "c0"}, itr.FromColumns())
+	assert.Equal(t, []string{"pk", "c0"}, itr.ToColumns())
+	assert.Equal(t, []api.RowDiff{
+		{Type: api.Modified, From: api.Row{int32(2), "b"}, To: api.Row{int32(2), "B"}},
+		{Type: api.Removed, From: api.Row{int32(3), "c"}},
+		{Type: api.Added, To: api.Row{int32(4), "d"}},
+	}, readDiffs(t, ctx, itr))
+
+	itr, err = db.Diff(ctx, "t", "HEAD", "WORKING")
+	require.NoError(t, err)
+	assert.Equal(t, []api.RowDiff{{Type: api.Added, To: api.Row{int32(5), "e"}}}, readDiffs(t, ctx, itr))
+
+	t.Run("added table", func(t *testing.T) {
+		itr, err := db.Diff(ctx, "t", "HEAD~2", "HEAD~1")
+		require.NoError(t, err)
+		assert.Nil(t, itr.FromColumns())
+		assert.Len(t, readDiffs(t, ctx, itr), 3)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := db.Diff(ctx, "nosuchtable", "HEAD~1", "HEAD")
+		assert.Error(t, err)
+		_, err = db.Diff(ctx, "t", "nosuchbranch", "HEAD")
+		assert.Error(t, err)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	ctx := context.Background()
+	dEnv := setup(t, ctx,
+		testCommand{cmd.SqlCmd{}, []string{"-q", "create table t (pk int primary key, c0 int);"}},
+		testCommand{cmd.SqlCmd{}, []string{"-q", "insert into t values (1, 1), (2, 2);"}},
+		testCommand{cmd.CommitCmd{}, []string{"-Am", "created t"}},
+		testCommand{cmd.BranchCmd{}, []string{"other"}},
+		testCommand{cmd.SqlCmd{}, []string{"-q", "update t set c0 = 10 where pk = 1; update t set c0 = 20 where pk = 2;"}},
+		testCommand{cmd.CommitCmd{}, []string{"-am", "changed rows on main"}},
+		testCommand{cmd.CheckoutCmd{}, []string{"other"}},
+		testCommand{cmd.SqlCmd{}, []string{"-q", "update t set c0 = 11 where pk = 1; update t set c0 = 21 where pk = 2; insert into t values (3, 3);"}},
+		testCommand{cmd.CommitCmd{}, []string{"-am", "changed rows on other"}},
+		testCommand{cmd.CheckoutCmd{}, []string{env.DefaultInitBranch}},
+	)
+	db, err := api.NewDatabase(dEnv)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var conflicts []api.Conflict
+	res, err := db.Merge(ctx, "", "main", "other", func(ctx context.Context, c api.Conflict) (api.Resolution, error) {
+		conflicts = append(conflicts, c)
+		if c.Ours[0] == int32(1) {
+			return api.UseTheirs, nil
+		}
+		return api.KeepConflict, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []api.Conflict{
+		{Table: "t", Columns: []string{"pk", "c0"}, Base: api.Row{int32(1), int32(1)}, Ours: api.Row{int32(1), int32(10)}, Theirs: api.Row{int32(1), int32(11)}},
+		{Table: "t", Columns: []string{"pk", "c0"}, Base: api.Row{int32(2), int32(2)}, Ours: api.Row{int32(2), int32(20)}, Theirs: api.Row{int32(2), int32(21)}},
+	}, conflicts)
+	require.Len(t, res.Tables, 1)
+	assert.Equal(t, "t", res.Tables[0].Table)
+	assert.Equal(t, 1, res.Tables[0].Adds)
+	assert.Equal(t, 1, res.Tables[0].Conflicts)
+	assert.Equal(t, 1, res.Tables[0].Resolved)
+	assert.True(t, res.HasConflicts())
+
+	// queries read the working set, so make the merged root the working root to inspect it
+	require.NoError(t, dEnv.UpdateWorkingRoot(ctx, res.Root))
+	rows, err := sqle.ExecuteSelect(ctx, dEnv, res.Root, "select * from t order by pk")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(11)}, {int32(2), int32(20)}, {int32(3), int32(3)}}, rows)
+
+	t.Run("resolve every conflict", func(t *testing.T) {
+		res, err := db.Merge(ctx, "", "main", "other", func(ctx context.Context, c api.Conflict) (api.Resolution, error) {
+			return api.UseOurs, nil
+		})
+		require.NoError(t, err)
+		assert.False(t, res.HasConflicts())
+		assert.Equal(t, 2, res.Tables[0].Resolved)
+		require.NoError(t, dEnv.UpdateWorkingRoot(ctx, res.Root))
+		rows, err := sqle.ExecuteSelect(ctx, dEnv, res.Root, "select count(*) from dolt_conflicts")
+		require.NoError(t, err)
+		assert.Equal(t, []sql.Row{{int64(0)}}, rows)
+	})
+
+	t.Run("keep conflicts", func(t *testing.T) {
+		res, err := db.Merge(ctx, "", "main", "other", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.Tables[0].Conflicts)
+		assert.Equal(t, 0, res.Tables[0].Resolved)
+	})
+
+	t.Run("explicit base", func(t *testing.T) {
+		res, err := db.Merge(ctx, "main", "main", "other", nil)
+		require.NoError(t, err)
+		assert.False(t, res.HasConflicts())
+	})
+}
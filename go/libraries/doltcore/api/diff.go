@@ -0,0 +1,256 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// Row is a row of a table, with a value for each of its columns.
+type Row []any
+
+// ChangeType is the type of change of a RowDiff.
+type ChangeType int
+
+const (
+	Added ChangeType = iota
+	Modified
+	Removed
+)
+
+func (ct ChangeType) String() string {
+	switch ct {
+	case Added:
+		return "added"
+	case Modified:
+		return "modified"
+	case Removed:
+		return "removed"
+	default:
+		return fmt.Sprintf("ChangeType(%d)", int(ct))
+	}
+}
+
+// RowDiff is a row which changed between two refs. From is nil for added rows, and To is nil for removed rows.
+type RowDiff struct {
+	Type     ChangeType
+	From, To Row
+}
+
+// DiffIter iterates over the rows of a table which changed between two refs, in primary key order.
+type DiffIter struct {
+	differ   tree.Differ[val.Tuple, val.TupleDesc]
+	from, to rowReader
+	// valDesc is set when both sides have the same value encoding, to skip modifications which only differ in it.
+	valDesc *val.TupleDesc
+}
+
+// Diff returns an iterator over the rows of table |tableName| which changed from the ref |fromRef| to the ref |toRef|.
+// If the table doesn't exist at one of them, every row is added or removed. Tables whose primary key changed can't be
+// diffed. When other columns changed, every row is returned as modified.
+func (db *Database) Diff(ctx context.Context, tableName, fromRef, toRef string) (*DiffIter, error) {
+	tblName := doltdb.TableName{Name: tableName}
+	fromTbl, err := db.tableAt(ctx, tblName, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	toTbl, err := db.tableAt(ctx, tblName, toRef)
+	if err != nil {
+		return nil, err
+	}
+	if fromTbl == nil && toTbl == nil {
+		return nil, fmt.Errorf("%w: %s", doltdb.ErrTableNotFound, tableName)
+	}
+
+	from, fromMap, err := newTableReader(ctx, fromTbl)
+	if err != nil {
+		return nil, err
+	}
+	to, toMap, err := newTableReader(ctx, toTbl)
+	if err != nil {
+		return nil, err
+	}
+	if fromTbl == nil {
+		fromMap, err = prolly.NewMapFromTuples(ctx, toMap.NodeStore(), toMap.KeyDesc(), toMap.ValDesc())
+	} else if toTbl == nil {
+		toMap, err = prolly.NewMapFromTuples(ctx, fromMap.NodeStore(), fromMap.KeyDesc(), fromMap.ValDesc())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !fromMap.KeyDesc().Equals(toMap.KeyDesc()) {
+		return nil, fmt.Errorf("the primary key of table %s changed from %s to %s, its rows can't be diffed", tableName, fromRef, toRef)
+	}
+	itr := &DiffIter{from: from, to: to}
+	sameValues := fromMap.ValDesc().Equals(toMap.ValDesc())
+	if sameValues {
+		vd := fromMap.ValDesc()
+		itr.valDesc = &vd
+	}
+	itr.differ, err = tree.DifferFromRoots[val.Tuple, val.TupleDesc](ctx, fromMap.NodeStore(), toMap.NodeStore(), fromMap.Node(), toMap.Node(), fromMap.KeyDesc(), !sameValues)
+	if err != nil {
+		return nil, err
+	}
+	return itr, nil
+}
+
+// FromColumns returns the names of the columns of the From rows, or nil if the table didn't exist at the from ref.
+func (itr *DiffIter) FromColumns() []string {
+	return itr.from.columnNames()
+}
+
+// ToColumns returns the names of the columns of the To rows, or nil if the table doesn't exist at the to ref.
+func (itr *DiffIter) ToColumns() []string {
+	return itr.to.columnNames()
+}
+
+// Next returns the next changed row, or io.EOF once every change was returned.
+func (itr *DiffIter) Next(ctx context.Context) (RowDiff, error) {
+	for {
+		d, err := itr.differ.Next(ctx)
+		if err != nil {
+			return RowDiff{}, err
+		}
+		// skip modifications of non-canonical tuples, whose trailing nulls were trimmed
+		if d.Type == tree.ModifiedDiff && itr.valDesc != nil && itr.valDesc.Compare(ctx, val.Tuple(d.From), val.Tuple(d.To)) == 0 {
+			continue
+		}
+
+		var rd RowDiff
+		switch d.Type {
+		case tree.AddedDiff:
+			rd.Type = Added
+		case tree.ModifiedDiff:
+			rd.Type = Modified
+		case tree.RemovedDiff:
+			rd.Type = Removed
+		}
+		if d.From != nil {
+			if rd.From, err = itr.from.read(ctx, val.Tuple(d.Key), val.Tuple(d.From)); err != nil {
+				return RowDiff{}, err
+			}
+		}
+		if d.To != nil {
+			if rd.To, err = itr.to.read(ctx, val.Tuple(d.Key), val.Tuple(d.To)); err != nil {
+				return RowDiff{}, err
+			}
+		}
+		return rd, nil
+	}
+}
+
+// tableAt returns table |tblName| at the ref |spec|, or nil if it doesn't exist there.
+func (db *Database) tableAt(ctx context.Context, tblName doltdb.TableName, spec string) (*doltdb.Table, error) {
+	root, err := db.resolveRoot(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return tbl, nil
+}
+
+// newTableReader returns a rowReader for the rows of |tbl| and its row data. |tbl| may be nil, in which case the
+// reader has no columns.
+func newTableReader(ctx context.Context, tbl *doltdb.Table) (rowReader, prolly.Map, error) {
+	if tbl == nil {
+		return rowReader{}, prolly.Map{}, nil
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return rowReader{}, prolly.Map{}, err
+	}
+	m, err := rowMap(ctx, tbl)
+	if err != nil {
+		return rowReader{}, prolly.Map{}, err
+	}
+	return newRowReader(sch, storedColumns(sch), m), m, nil
+}
+
+func rowMap(ctx context.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+// storedColumns returns the columns of |sch| which are stored in its rows, in schema order.
+func storedColumns(sch schema.Schema) []schema.Column {
+	var cols []schema.Column
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if !col.Virtual {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// rowReader decodes the key and value tuples of a table with schema |sch| into rows of the columns |cols|. Columns
+// which aren't in |sch| are read as nil.
+type rowReader struct {
+	sch    schema.Schema
+	cols   []schema.Column
+	kd, vd val.TupleDesc
+	ns     tree.NodeStore
+}
+
+func newRowReader(sch schema.Schema, cols []schema.Column, m prolly.Map) rowReader {
+	kd, vd := m.Descriptors()
+	return rowReader{sch: sch, cols: cols, kd: kd, vd: vd, ns: m.NodeStore()}
+}
+
+func (r rowReader) columnNames() []string {
+	if r.cols == nil {
+		return nil
+	}
+	names := make([]string, len(r.cols))
+	for i, col := range r.cols {
+		names[i] = col.Name
+	}
+	return names
+}
+
+func (r rowReader) read(ctx context.Context, k, v val.Tuple) (Row, error) {
+	keyless := schema.IsKeyless(r.sch)
+	row := make(Row, len(r.cols))
+	for i, col := range r.cols {
+		var err error
+		if j, ok := r.sch.GetPKCols().TagToIdx[col.Tag]; ok {
+			row[i], err = tree.GetField(ctx, r.kd, j, k, r.ns)
+		} else if j, ok := r.sch.GetNonPKCols().StoredIndexByTag(col.Tag); ok {
+			if keyless {
+				// the first field of keyless rows is their cardinality
+				j++
+			}
+			row[i], err = tree.GetField(ctx, r.vd, j, v, r.ns)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,399 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// Resolution is how a ConflictFunc resolves a conflict.
+type Resolution int
+
+const (
+	// KeepConflict leaves the conflict in the merged root, to be resolved later.
+	KeepConflict Resolution = iota
+	// UseOurs keeps our row.
+	UseOurs
+	// UseTheirs replaces our row with their row, or deletes it if they deleted it.
+	UseTheirs
+)
+
+// Conflict is a row which both sides of a merge changed differently. Its rows have the columns of the merged table,
+// and are nil if the row doesn't exist on that side.
+type Conflict struct {
+	Table              string
+	Columns            []string
+	Base, Ours, Theirs Row
+}
+
+// ConflictFunc decides how a Conflict is resolved. Returning an error aborts the merge.
+type ConflictFunc func(ctx context.Context, c Conflict) (Resolution, error)
+
+// TableMergeResult summarizes the merge of a table.
+type TableMergeResult struct {
+	Table         string
+	Adds          int
+	Deletes       int
+	Modifications int
+	// Conflicts is the number of conflicts left in the table after the ConflictFunc resolved the others.
+	Conflicts            int
+	Resolved             int
+	ConstraintViolations int
+}
+
+// MergeResult is the result of Merge.
+type MergeResult struct {
+	// Root is the merged root value. Conflicts which weren't resolved, and constraint violations, are stored in it.
+	Root doltdb.RootValue
+	// Tables are the tables the merge changed, ordered by name.
+	Tables []TableMergeResult
+}
+
+// HasConflicts returns whether any conflict is left in the merged root.
+func (r *MergeResult) HasConflicts() bool {
+	for _, t := range r.Tables {
+		if t.Conflicts > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge three-way merges the commits at the refs |ours| and |theirs|, with the commit at |base| as their common
+// ancestor, or with their merge base if |base| is empty. Each row conflict is passed to |onConflict|, which may be nil
+// to keep every conflict. Schema conflicts fail the merge. The merged root isn't written to any branch.
+func (db *Database) Merge(ctx context.Context, base, ours, theirs string, onConflict ConflictFunc) (*MergeResult, error) {
+	ourCm, err := db.resolveCommit(ctx, ours)
+	if err != nil {
+		return nil, err
+	}
+	theirCm, err := db.resolveCommit(ctx, theirs)
+	if err != nil {
+		return nil, err
+	}
+	var baseCm *doltdb.Commit
+	if base == "" {
+		optCmt, err := doltdb.GetCommitAncestor(ctx, ourCm, theirCm)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if baseCm, ok = optCmt.ToCommit(); !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+	} else if baseCm, err = db.resolveCommit(ctx, base); err != nil {
+		return nil, err
+	}
+
+	ourRoot, err := ourCm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	theirRoot, err := theirCm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseRoot, err := baseCm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := db.env.TempTableFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	sqlCtx := sql.NewContext(ctx)
+	opts := editor.Options{Deaf: db.env.BulkDbEaFactory(ctx), Tempdir: tmpDir}
+	res, err := merge.MergeRoots(sqlCtx, ourRoot, theirRoot, baseRoot, theirCm, baseCm, opts, merge.MergeOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MergeResult{Root: res.Root}
+	for tblName, stats := range res.Stats {
+		if stats.Operation == merge.TableUnmodified {
+			continue
+		}
+		tr := TableMergeResult{
+			Table:                tblName.Name,
+			Adds:                 stats.Adds,
+			Deletes:              stats.Deletes,
+			Modifications:        stats.Modifications,
+			Conflicts:            stats.DataConflicts,
+			ConstraintViolations: stats.ConstraintViolations,
+		}
+		if onConflict != nil && stats.DataConflicts > 0 {
+			result.Root, tr.Resolved, err = resolveConflicts(sqlCtx, result.Root, tblName, onConflict)
+			if err != nil {
+				return nil, err
+			}
+			tr.Conflicts -= tr.Resolved
+		}
+		result.Tables = append(result.Tables, tr)
+	}
+	sort.Slice(result.Tables, func(i, j int) bool {
+		return result.Tables[i].Table < result.Tables[j].Table
+	})
+	return result, nil
+}
+
+// resolveConflicts passes each conflict of table |tblName| in |root| to |onConflict| and applies its resolution.
+// It returns the updated root and the number of conflicts which were resolved.
+func resolveConflicts(ctx *sql.Context, root doltdb.RootValue, tblName doltdb.TableName, onConflict ConflictFunc) (doltdb.RootValue, int, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %s", doltdb.ErrTableNotFound, tblName.Name)
+	}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	baseSch, ourSch, theirSch, err := tbl.GetConflictSchemas(ctx, tblName)
+	if err != nil {
+		return nil, 0, err
+	}
+	if baseSch == nil || ourSch == nil || theirSch == nil {
+		baseSch, ourSch, theirSch = sch, sch, sch
+	}
+
+	ourMap, err := rowMap(ctx, tbl)
+	if err != nil {
+		return nil, 0, err
+	}
+	mutMap := ourMap.Mutate()
+	idxSet, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	mutIdxs, err := merge.GetMutableSecondaryIdxs(ctx, ourSch, sch, tblName.Name, idxSet)
+	if err != nil {
+		return nil, 0, err
+	}
+	artIdx, err := tbl.GetArtifacts(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	artMap := durable.ProllyMapFromArtifactIndex(artIdx)
+	artEditor := artMap.Editor()
+	itr, err := artMap.IterAllConflicts(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cols := storedColumns(sch)
+	ours := newRowReader(sch, cols, ourMap)
+	c := Conflict{Table: tblName.Name, Columns: ours.columnNames()}
+	bases := newSideRows(tblName, baseSch, cols)
+	theirs := newSideRows(tblName, theirSch, cols)
+	resolved, remaining := 0, 0
+	for {
+		cnf, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		var ourRow, theirRow val.Tuple
+		if err = ourMap.Get(ctx, cnf.Key, func(_, v val.Tuple) error {
+			ourRow = v
+			return nil
+		}); err != nil {
+			return nil, 0, err
+		}
+		if c.Base, _, err = bases.get(ctx, tbl, cnf.Metadata.BaseRootIsh, cnf.Key); err != nil {
+			return nil, 0, err
+		}
+		if c.Theirs, theirRow, err = theirs.get(ctx, tbl, cnf.TheirRootIsh, cnf.Key); err != nil {
+			return nil, 0, err
+		}
+		c.Ours = nil
+		if ourRow != nil {
+			if c.Ours, err = ours.read(ctx, cnf.Key, ourRow); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		res, err := onConflict(ctx, c)
+		if err != nil {
+			return nil, 0, err
+		}
+		switch res {
+		case KeepConflict:
+			remaining++
+			continue
+		case UseOurs:
+		case UseTheirs:
+			if !schema.ColCollsAreEqual(sch.GetAllCols(), theirSch.GetAllCols()) {
+				return nil, 0, fmt.Errorf("the schema of table %s changed on their side, its conflicts can't be resolved with their rows", tblName.Name)
+			}
+			if err = useTheirRow(ctx, mutMap, mutIdxs, cnf.Key, ourRow, theirRow); err != nil {
+				return nil, 0, err
+			}
+		default:
+			return nil, 0, fmt.Errorf("unknown conflict resolution %d", res)
+		}
+		artKey, err := artEditor.BuildArtifactKey(ctx, cnf.Key, cnf.TheirRootIsh, prolly.ArtifactTypeConflict)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err = artEditor.Delete(ctx, artKey); err != nil {
+			return nil, 0, err
+		}
+		resolved++
+	}
+	if resolved == 0 {
+		return root, 0, nil
+	}
+
+	newMap, err := mutMap.Map(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(newMap))
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, mutIdx := range mutIdxs {
+		m, err := mutIdx.Map(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		idxSet, err = idxSet.PutIndex(ctx, mutIdx.Name, durable.IndexFromProllyMap(m))
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if tbl, err = tbl.SetIndexSet(ctx, idxSet); err != nil {
+		return nil, 0, err
+	}
+	newArtMap, err := artEditor.Flush(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if tbl, err = tbl.SetArtifacts(ctx, durable.ArtifactIndexFromProllyMap(newArtMap)); err != nil {
+		return nil, 0, err
+	}
+	if remaining == 0 {
+		// clears the conflict schemas as well
+		if tbl, err = tbl.ClearConflicts(ctx); err != nil {
+			return nil, 0, err
+		}
+	}
+	root, err = root.PutTable(ctx, tblName, tbl)
+	if err != nil {
+		return nil, 0, err
+	}
+	return root, resolved, nil
+}
+
+// useTheirRow replaces |ourRow| with |theirRow| in the rows and secondary indexes of a table.
+func useTheirRow(ctx *sql.Context, mutMap *prolly.MutableMap, mutIdxs []merge.MutableSecondaryIdx, key, ourRow, theirRow val.Tuple) error {
+	var err error
+	if theirRow == nil {
+		err = mutMap.Delete(ctx, key)
+	} else {
+		err = mutMap.Put(ctx, key, theirRow)
+	}
+	if err != nil {
+		return err
+	}
+	for _, mutIdx := range mutIdxs {
+		if ourRow == nil {
+			err = mutIdx.InsertEntry(ctx, key, theirRow)
+		} else if theirRow == nil {
+			err = mutIdx.DeleteEntry(ctx, key, ourRow)
+		} else {
+			err = mutIdx.UpdateEntry(ctx, key, ourRow, theirRow)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sideRows reads the rows of a table on one side of a merge, from the root value a conflict artifact points to.
+type sideRows struct {
+	tblName doltdb.TableName
+	sch     schema.Schema
+	cols    []schema.Column
+	rootIsh hash.Hash
+	m       *prolly.Map
+	reader  rowReader
+}
+
+func newSideRows(tblName doltdb.TableName, sch schema.Schema, cols []schema.Column) *sideRows {
+	return &sideRows{tblName: tblName, sch: sch, cols: cols}
+}
+
+// get returns the row with key |key| of the table at the root value |rootIsh|, both decoded and as its value tuple,
+// or nils if the table or row doesn't exist there.
+func (s *sideRows) get(ctx context.Context, tbl *doltdb.Table, rootIsh hash.Hash, key val.Tuple) (Row, val.Tuple, error) {
+	if rootIsh != s.rootIsh {
+		root, err := doltdb.LoadRootValueFromRootIshAddr(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), rootIsh)
+		if err != nil {
+			return nil, nil, err
+		}
+		sideTbl, ok, err := root.GetTable(ctx, s.tblName)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.m = nil
+		if ok {
+			m, err := rowMap(ctx, sideTbl)
+			if err != nil {
+				return nil, nil, err
+			}
+			s.m = &m
+			s.reader = newRowReader(s.sch, s.cols, m)
+		}
+		s.rootIsh = rootIsh
+	}
+	if s.m == nil {
+		return nil, nil, nil
+	}
+
+	var tup val.Tuple
+	err := s.m.Get(ctx, key, func(_, v val.Tuple) error {
+		tup = v
+		return nil
+	})
+	if err != nil || tup == nil {
+		return nil, nil, err
+	}
+	row, err := s.reader.read(ctx, key, tup)
+	if err != nil {
+		return nil, nil, err
+	}
+	return row, tup, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bundle reads and writes bundles, single file archives of a database store holding a set of branches and
+// tags, such as a release tag. A bundle is a tar archive whose first entry is its Manifest, followed by the manifest
+// and table files of the store. The manifest lists the refs of the store, and the size and SHA-256 hash of every file
+// of the store, and bundles are verified against it when they're read.
+package bundle
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestName is the name of the first entry of a bundle, which holds its Manifest.
+const ManifestName = "bundle.json"
+
+// FormatVersion is the version of the bundle format written by Write.
+const FormatVersion = 1
+
+// storeDir is the directory of the entries of a bundle which hold the files of its store.
+const storeDir = "noms"
+
+// lockFileName is the name of the lock file of a store, which isn't bundled.
+const lockFileName = "LOCK"
+
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// Manifest describes a bundle: the refs and the files of its store. The bundle of a release tag also names the tag,
+// its commit, and the branch checked out when it's cloned.
+type Manifest struct {
+	FormatVersion int    `json:"format_version"`
+	Tag           string `json:"tag,omitempty"`
+	Branch        string `json:"branch,omitempty"`
+	Commit        string `json:"commit,omitempty"`
+	Tagger        string `json:"tagger,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Signature     string `json:"signature,omitempty"`
+	Refs          []Ref  `json:"refs"`
+	Files         []File `json:"files"`
+}
+
+// Ref is a branch or tag of the store of a bundle, such as refs/heads/main, and the hash it points to.
+type Ref struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+// VerifyRefs checks that the refs of a bundle's store, given by |refs| as a map from their names to their hashes, are
+// the refs of its manifest.
+func (m Manifest) VerifyRefs(refs map[string]string) error {
+	for _, r := range m.Refs {
+		h, ok := refs[r.Name]
+		if !ok {
+			return fmt.Errorf("%w: missing ref %s", ErrInvalidBundle, r.Name)
+		}
+		if h != r.Hash {
+			return fmt.Errorf("%w: ref %s is at %s, not %s", ErrInvalidBundle, r.Name, h, r.Hash)
+		}
+	}
+	return nil
+}
+
+// File is a file of the store of a bundle.
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Write writes a bundle of the store in |dir|, described by |m|, to |w|. It returns |m| with the files of the store.
+func Write(w io.Writer, m Manifest, dir string) (Manifest, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Manifest{}, err
+	}
+	m.FormatVersion = FormatVersion
+	m.Files = nil
+	for _, e := range entries {
+		if !e.Type().IsRegular() || e.Name() == lockFileName {
+			continue
+		}
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return Manifest{}, err
+		}
+		sum, size, err := hashOf(f)
+		f.Close()
+		if err != nil {
+			return Manifest{}, err
+		}
+		m.Files = append(m.Files, File{Name: e.Name(), Size: size, SHA256: sum})
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	tw := tar.NewWriter(w)
+	err = tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0644, Size: int64(len(data))})
+	if err != nil {
+		return Manifest{}, err
+	}
+	if _, err = tw.Write(data); err != nil {
+		return Manifest{}, err
+	}
+	for _, file := range m.Files {
+		err = tw.WriteHeader(&tar.Header{Name: path.Join(storeDir, file.Name), Mode: 0644, Size: file.Size})
+		if err != nil {
+			return Manifest{}, err
+		}
+		f, err := os.Open(filepath.Join(dir, file.Name))
+		if err != nil {
+			return Manifest{}, err
+		}
+		_, err = io.Copy(tw, f)
+		f.Close()
+		if err != nil {
+			return Manifest{}, err
+		}
+	}
+	return m, tw.Close()
+}
+
+// Extract verifies the bundle read from |r|, extracting its store into |dir| unless it's empty, and returns its
+// Manifest. Every file of the store must have the size and hash given by the manifest.
+func Extract(r io.Reader, dir string) (Manifest, error) {
+	tr := tar.NewReader(r)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != ManifestName {
+		return Manifest{}, fmt.Errorf("%w: missing %s", ErrInvalidBundle, ManifestName)
+	}
+	var m Manifest
+	if err = json.NewDecoder(tr).Decode(&m); err != nil {
+		return Manifest{}, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+	}
+	if m.FormatVersion != FormatVersion {
+		return Manifest{}, fmt.Errorf("%w: unsupported format version %d", ErrInvalidBundle, m.FormatVersion)
+	}
+
+	files := make(map[string]File, len(m.Files))
+	for _, f := range m.Files {
+		files[f.Name] = f
+	}
+	for {
+		hdr, err = tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return Manifest{}, fmt.Errorf("%w: %s", ErrInvalidBundle, err.Error())
+		}
+		name, ok := strings.CutPrefix(hdr.Name, storeDir+"/")
+		f, listed := files[name]
+		if !ok || !listed || !validFileName(name) {
+			return Manifest{}, fmt.Errorf("%w: unexpected entry %s", ErrInvalidBundle, hdr.Name)
+		}
+		delete(files, name)
+		if err = extractFile(tr, f, dir); err != nil {
+			return Manifest{}, err
+		}
+	}
+	for name := range files {
+		return Manifest{}, fmt.Errorf("%w: missing file %s", ErrInvalidBundle, name)
+	}
+	return m, nil
+}
+
+func extractFile(r io.Reader, f File, dir string) error {
+	dest := io.Discard
+	if dir != "" {
+		out, err := os.Create(filepath.Join(dir, f.Name))
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		dest = out
+	}
+	sum, size, err := hashOf(io.TeeReader(r, dest))
+	if err != nil {
+		return err
+	}
+	if size != f.Size || sum != f.SHA256 {
+		return fmt.Errorf("%w: file %s doesn't match its hash", ErrInvalidBundle, f.Name)
+	}
+	return nil
+}
+
+func validFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// Hash returns the hex encoded SHA-256 hash of the bundle read from |r|, which identifies it.
+func Hash(r io.Reader) (string, error) {
+	sum, _, err := hashOf(r)
+	return sum, err
+}
+
+func hashOf(r io.Reader) (string, int64, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
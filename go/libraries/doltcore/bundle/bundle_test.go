@@ -0,0 +1,78 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndExtract(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "manifest"), []byte("manifest contents"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "table1"), []byte("table file contents"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, lockFileName), nil, 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(src, "oldgen"), 0755))
+
+	var buf bytes.Buffer
+	refs := []Ref{{Name: "refs/heads/main", Hash: "abc"}, {Name: "refs/tags/v1", Hash: "def"}}
+	m, err := Write(&buf, Manifest{Tag: "v1", Branch: "main", Commit: "abc", Refs: refs}, src)
+	require.NoError(t, err)
+	assert.Equal(t, FormatVersion, m.FormatVersion)
+	require.Len(t, m.Files, 2)
+	assert.Equal(t, "manifest", m.Files[0].Name)
+	assert.Equal(t, int64(len("table file contents")), m.Files[1].Size)
+
+	// verifying doesn't extract anything
+	verified, err := Extract(bytes.NewReader(buf.Bytes()), "")
+	require.NoError(t, err)
+	assert.Equal(t, m, verified)
+
+	dest := t.TempDir()
+	extracted, err := Extract(bytes.NewReader(buf.Bytes()), dest)
+	require.NoError(t, err)
+	assert.Equal(t, m, extracted)
+	data, err := os.ReadFile(filepath.Join(dest, "table1"))
+	require.NoError(t, err)
+	assert.Equal(t, "table file contents", string(data))
+	_, err = os.Stat(filepath.Join(dest, lockFileName))
+	assert.True(t, os.IsNotExist(err))
+
+	// a corrupted file doesn't match its hash
+	corrupted := bytes.Replace(buf.Bytes(), []byte("table file contents"), []byte("table file CONTENTS"), 1)
+	_, err = Extract(bytes.NewReader(corrupted), "")
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+
+	_, err = Extract(bytes.NewReader([]byte("not a bundle")), "")
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+
+	h1, err := Hash(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	h2, err := Hash(bytes.NewReader(corrupted))
+	require.NoError(t, err)
+	assert.NotEqual(t, h1, h2)
+}
+
+func TestVerifyRefs(t *testing.T) {
+	m := Manifest{Refs: []Ref{{Name: "refs/heads/main", Hash: "abc"}, {Name: "refs/tags/v1", Hash: "def"}}}
+	assert.NoError(t, m.VerifyRefs(map[string]string{"refs/heads/main": "abc", "refs/tags/v1": "def", "refs/heads/other": "ghi"}))
+	assert.ErrorIs(t, m.VerifyRefs(map[string]string{"refs/heads/main": "abc"}), ErrInvalidBundle)
+	assert.ErrorIs(t, m.VerifyRefs(map[string]string{"refs/heads/main": "abc", "refs/tags/v1": "abc"}), ErrInvalidBundle)
+}
@@ -177,6 +177,10 @@ func CreateCommitStagedPropsFromCherryPickOptions(ctx *sql.Context, options Cher
 		return nil, fmt.Errorf("stop on empty commit is not currently supported")
 	}
 
+	if err := dsess.SetCommitIdentity(ctx, &commitProps, ""); err != nil {
+		return nil, err
+	}
+
 	return &commitProps, nil
 }
 
@@ -0,0 +1,251 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbcopy prepares the .dolt directory of a database to be copied to another filesystem, with rsync, scp or as
+// an archive, and verifies copies of it. WriteManifest lists the size and SHA-256 hash of every file of the directory
+// in a Manifest which is stored in the directory and copied with it, and Verify checks a copy against it, so that a
+// copy of a database which changed while it was being copied, or which was damaged on the way, is found before the
+// copy is used.
+package dbcopy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+)
+
+// ManifestName is the name of the file in the .dolt directory which holds its Manifest.
+const ManifestName = "copy_manifest.json"
+
+// FormatVersion is the version of the manifests written by WriteManifest.
+const FormatVersion = 1
+
+// ErrNoManifest is returned by Verify for directories which weren't prepared to be copied.
+var ErrNoManifest = errors.New("no copy manifest found, the database wasn't prepared to be copied")
+
+// Manifest lists the files of a .dolt directory which is prepared to be copied.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	Created       time.Time `json:"created"`
+	Files         []File    `json:"files"`
+}
+
+// File is a file of a .dolt directory. Its path is relative to the directory, with forward slashes.
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Size returns the total size of the files of |m|.
+func (m Manifest) Size() int64 {
+	var sz int64
+	for _, f := range m.Files {
+		sz += f.Size
+	}
+	return sz
+}
+
+// skipped returns whether the file or directory at |rel| isn't copied with the database: lock files, which are held
+// by the process which has a store open, the info file of a running sql-server, temporary table files, statistics,
+// which are rebuilt and may be written in the background, and the manifest itself.
+func skipped(rel string, isDir bool) bool {
+	if isDir {
+		return rel == "temptf" || rel == dbfactory.StatsDir
+	}
+	switch path.Base(rel) {
+	case "LOCK", "sql-server.info", ManifestName, ManifestName + ".tmp":
+		return true
+	}
+	return false
+}
+
+// WriteManifest lists the files of the .dolt directory |dir| in a Manifest, which it writes to the directory and
+// returns. The database must not be written to while, or after, it's listed, or copies of it won't verify.
+func WriteManifest(dir string) (Manifest, error) {
+	m := Manifest{FormatVersion: FormatVersion, Created: time.Now().UTC()}
+	err := walk(dir, func(rel, p string) error {
+		sum, size, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		m.Files = append(m.Files, File{Path: rel, Size: size, SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return Manifest{}, err
+	}
+	tmp := filepath.Join(dir, ManifestName+".tmp")
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return Manifest{}, err
+	}
+	if err = os.Rename(tmp, filepath.Join(dir, ManifestName)); err != nil {
+		return Manifest{}, err
+	}
+	return m, nil
+}
+
+// ReadManifest reads the Manifest of the .dolt directory |dir|.
+func ReadManifest(dir string) (Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Manifest{}, ErrNoManifest
+	} else if err != nil {
+		return Manifest{}, err
+	}
+	var m Manifest
+	if err = json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("invalid copy manifest: %w", err)
+	}
+	if m.FormatVersion != FormatVersion {
+		return Manifest{}, fmt.Errorf("invalid copy manifest: unsupported format version %d", m.FormatVersion)
+	}
+	return m, nil
+}
+
+// Verify checks the copy of a .dolt directory at |dir| against its Manifest, and returns the manifest and a problem
+// for each file which is missing, which doesn't have the size and hash of the manifest, or which isn't in it.
+func Verify(dir string) (Manifest, []error, error) {
+	m, err := ReadManifest(dir)
+	if err != nil {
+		return Manifest{}, nil, err
+	}
+
+	files := make(map[string]File, len(m.Files))
+	for _, f := range m.Files {
+		files[f.Path] = f
+	}
+	var problems []error
+	err = walk(dir, func(rel, p string) error {
+		f, ok := files[rel]
+		if !ok {
+			problems = append(problems, fmt.Errorf("%s is not in the copy manifest, the database changed after it was prepared", rel))
+			return nil
+		}
+		delete(files, rel)
+		sum, size, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		if size != f.Size || sum != f.SHA256 {
+			problems = append(problems, fmt.Errorf("%s doesn't match the copy manifest", rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return Manifest{}, nil, err
+	}
+	for _, f := range m.Files {
+		if _, ok := files[f.Path]; ok {
+			problems = append(problems, fmt.Errorf("%s is missing", f.Path))
+		}
+	}
+	return m, problems, nil
+}
+
+// WriteArchive writes a gzipped tar archive of the files of |m| in the .dolt directory |dir|, and the manifest, to
+// |w|. The entries are under .dolt/, so that extracting the archive in an empty directory makes it a copy of the
+// database.
+func WriteArchive(w io.Writer, dir string, m Manifest) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	if err := addFile(tw, dir, ManifestName); err != nil {
+		return err
+	}
+	for _, f := range m.Files {
+		if err := addFile(tw, dir, f.Path); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func addFile(tw *tar.Writer, dir, rel string) error {
+	f, err := os.Open(filepath.Join(dir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	err = tw.WriteHeader(&tar.Header{Name: path.Join(dbfactory.DoltDir, rel), Mode: 0644, Size: st.Size(), ModTime: st.ModTime()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// walk calls |cb| with the relative and full path of each file of the database in the .dolt directory |dir|.
+func walk(dir string, cb func(rel, p string) error) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == "." {
+			return nil
+		}
+		if skipped(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return cb(rel, p)
+	})
+}
+
+func hashFile(p string) (string, int64, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
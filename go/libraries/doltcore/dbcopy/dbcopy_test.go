@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbcopy
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(contents), 0644))
+	}
+}
+
+func TestManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"repo_state.json":            "{}",
+		"noms/manifest":              "manifest",
+		"noms/abcdef":                "table file",
+		"noms/LOCK":                  "",
+		"temptf/tmp":                 "temp",
+		"stats/.dolt/noms/manifest":  "stats",
+		"sql-server.info":            "1234:3306:secret",
+		"noms/oldgen/manifest":       "oldgen",
+		"noms/oldgen/0123456789abcd": "old table file",
+	})
+
+	m, err := WriteManifest(dir)
+	require.NoError(t, err)
+	var paths []string
+	for _, f := range m.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.ElementsMatch(t, []string{"repo_state.json", "noms/manifest", "noms/abcdef", "noms/oldgen/manifest", "noms/oldgen/0123456789abcd"}, paths)
+	assert.Equal(t, int64(len("{}manifesttable fileoldgenold table file")), m.Size())
+
+	read, err := ReadManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, m.Files, read.Files)
+
+	_, problems, err := Verify(dir)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// lock files and statistics may change without invalidating a copy
+	writeFiles(t, dir, map[string]string{"noms/LOCK": "locked", "stats/.dolt/noms/journal": "stats"})
+	_, problems, err = Verify(dir)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	writeFiles(t, dir, map[string]string{"noms/abcdef": "changed", "noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv": "journal"})
+	require.NoError(t, os.Remove(filepath.Join(dir, "noms", "oldgen", "manifest")))
+	_, problems, err = Verify(dir)
+	require.NoError(t, err)
+	require.Len(t, problems, 3)
+	assert.ErrorContains(t, problems[0], "noms/abcdef doesn't match the copy manifest")
+	assert.ErrorContains(t, problems[1], "noms/vvvvvvvvvvvvvvvvvvvvvvvvvvvvvvvv is not in the copy manifest")
+	assert.ErrorContains(t, problems[2], "noms/oldgen/manifest is missing")
+}
+
+func TestVerifyWithoutManifest(t *testing.T) {
+	_, _, err := Verify(t.TempDir())
+	assert.ErrorIs(t, err, ErrNoManifest)
+}
+
+func TestWriteArchive(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"repo_state.json": "{}",
+		"noms/manifest":   "manifest",
+		"noms/LOCK":       "",
+	})
+	m, err := WriteManifest(dir)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteArchive(&buf, dir, m))
+
+	// extracting the archive makes a copy which verifies
+	dest := t.TempDir()
+	gr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		writeFiles(t, dest, map[string]string{hdr.Name: string(data)})
+	}
+	assert.Equal(t, ".dolt/"+ManifestName, names[0])
+	assert.ElementsMatch(t, []string{".dolt/" + ManifestName, ".dolt/repo_state.json", ".dolt/noms/manifest"}, names)
+
+	_, problems, err := Verify(filepath.Join(dest, ".dolt"))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
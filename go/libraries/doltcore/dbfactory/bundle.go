@@ -0,0 +1,101 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbfactory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrBundleReadOnly = errors.New("bundles are read-only")
+
+// BundleFactory is a DBFactory implementation for reading the databases of bundle files, which are created by
+// `dolt bundle create`. A bundle is verified and extracted to a temporary directory the first time it's read, and its
+// database is read from there.
+type BundleFactory struct {
+}
+
+var bundleLock = new(sync.Mutex)
+
+// PrepareDB implements DBFactory. Bundles can't be written to.
+func (fact BundleFactory) PrepareDB(ctx context.Context, nbf *types.NomsBinFormat, u *url.URL, params map[string]interface{}) error {
+	return ErrBundleReadOnly
+}
+
+// CreateDB implements DBFactory, returning the database of the bundle at the path of |urlObj|.
+func (fact BundleFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]interface{}) (datas.Database, types.ValueReadWriter, tree.NodeStore, error) {
+	path, err := url.PathUnescape(urlObj.Path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	path = urlObj.Host + filepath.FromSlash(path)
+
+	dir, err := extractBundle(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return FileFactory{}.CreateDB(ctx, nbf, &url.URL{Scheme: FileScheme, Path: filepath.ToSlash(dir)}, params)
+}
+
+// extractBundle returns the directory the bundle at |path| is extracted to. Bundles are extracted to a directory
+// named by their hash, so a bundle is only extracted again if it changed.
+func extractBundle(path string) (string, error) {
+	bundleLock.Lock()
+	defer bundleLock.Unlock()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	sum, err := bundle.Hash(f)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(os.TempDir(), "dolt-bundles", sum)
+	if _, err = os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(dir), os.ModePerm); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(dir), sum+"-")
+	if err != nil {
+		return "", err
+	}
+	if _, err = bundle.Extract(f, tmp); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+	if err = os.Rename(tmp, dir); err != nil {
+		os.RemoveAll(tmp)
+		return "", err
+	}
+	return dir, nil
+}
@@ -53,6 +53,9 @@ const (
 
 	OSSScheme = "oss"
 
+	// BundleScheme is the scheme of bundle files created by dolt bundle create
+	BundleScheme = "bundle"
+
 	defaultScheme       = HTTPSScheme
 	defaultMemTableSize = 256 * 1024 * 1024
 )
@@ -76,6 +79,7 @@ var DBFactories = map[string]DBFactory{
 	FileScheme:    FileFactory{},
 	MemScheme:     MemFactory{},
 	LocalBSScheme: LocalBSFactory{},
+	BundleScheme:  BundleFactory{},
 	HTTPScheme:    NewDoltRemoteFactory(true),
 	HTTPSScheme:   NewDoltRemoteFactory(false),
 }
@@ -21,6 +21,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/sirupsen/logrus"
@@ -61,6 +62,27 @@ const (
 var DoltDataDir = filepath.Join(DoltDir, DataDir)
 var DoltStatsDir = filepath.Join(DoltDir, StatsDir)
 
+// DoltDataDirLink is a file holding the absolute path of a data directory shared with another repository. Linked
+// worktrees write it in place of a DoltDataDir symlink on filesystems which can't create symlinks.
+var DoltDataDirLink = filepath.Join(DoltDir, DataDir+".link")
+
+// LinkedDataDir returns the data directory named by the DoltDataDirLink file in |fs|, and false if there is no such
+// file or the directory it names no longer exists.
+func LinkedDataDir(fs filesys.ReadableFS) (string, bool, error) {
+	data, err := fs.ReadFile(DoltDataDirLink)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+
+	dataDir := strings.TrimSpace(string(data))
+	if exists, isDir := fs.Exists(dataDir); !exists || !isDir {
+		return "", false, nil
+	}
+	return dataDir, true, nil
+}
+
 // FileFactory is a DBFactory implementation for creating local filesys backed databases
 type FileFactory struct {
 }
@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/grpcendpoint"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotestorage"
 	"github.com/dolthub/dolt/go/libraries/events"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
@@ -35,6 +38,24 @@ import (
 var GRPCDialProviderParam = "__DOLT__grpc_dial_provider"
 var GRPCUsernameAuthParam = "__DOLT__grpc_username"
 
+// GRPCMaxBandwidthParam limits the table file uploads and downloads of a remotesapi database to a number of bytes per
+// second, such as "10MB". See iohelp.ParseBandwidth.
+var GRPCMaxBandwidthParam = "__DOLT__grpc_max_bandwidth"
+
+const (
+	// GRPCCredsParam is the key id of the stored dolt credentials, as created by `dolt creds new`, which a remotesapi
+	// remote authenticates with instead of the user's default credentials.
+	GRPCCredsParam = "cred"
+	// GRPCTimeoutParam limits how long each request to a remotesapi remote can take, such as "30s". See
+	// time.ParseDuration.
+	GRPCTimeoutParam = "timeout"
+	// GRPCConcurrencyParam limits the number of concurrent table file downloads from a remotesapi remote.
+	GRPCConcurrencyParam = "concurrency"
+)
+
+// GRPCParams are the params which can be persisted with a remotesapi remote.
+var GRPCParams = []string{GRPCCredsParam, GRPCTimeoutParam, GRPCConcurrencyParam}
+
 type GRPCRemoteConfig struct {
 	Endpoint    string
 	DialOptions []grpc.DialOption
@@ -107,18 +128,47 @@ func (fact DoltRemoteFactory) newChunkStore(ctx context.Context, nbf *types.Noms
 		user = userParam.(string)
 		wsValidate = true
 	}
+	var credsKeyId string
+	if credsParam, ok := params[GRPCCredsParam]; ok && credsParam != nil {
+		credsKeyId = credsParam.(string)
+	}
+	var timeout time.Duration
+	if timeoutParam, ok := params[GRPCTimeoutParam]; ok && timeoutParam != nil {
+		var err error
+		timeout, err = ParseGRPCTimeout(timeoutParam.(string))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var concurrency int
+	if concurrencyParam, ok := params[GRPCConcurrencyParam]; ok && concurrencyParam != nil {
+		var err error
+		concurrency, err = ParseGRPCConcurrency(concurrencyParam.(string))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cfg, err := dp.GetGRPCDialParams(grpcendpoint.Config{
 		Endpoint:           urlObj.Host,
 		Insecure:           fact.insecure,
 		UserIdForOsEnvAuth: user,
 		WithEnvCreds:       true,
+		CredsKeyId:         credsKeyId,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	opts := append(cfg.DialOptions, grpc.WithChainUnaryInterceptor(remotestorage.EventsUnaryClientInterceptor(events.GlobalCollector())))
+	// Chained first, so that the span of a call covers all of its attempts.
+	opts := append(cfg.DialOptions, grpc.WithChainUnaryInterceptor(remotestorage.TracingUnaryClientInterceptor))
+	opts = append(opts, grpc.WithChainStreamInterceptor(remotestorage.TracingStreamClientInterceptor))
+	opts = append(opts, grpc.WithChainUnaryInterceptor(remotestorage.EventsUnaryClientInterceptor(events.GlobalCollector())))
 	opts = append(opts, grpc.WithChainUnaryInterceptor(remotestorage.RetryingUnaryClientInterceptor))
+	if timeout > 0 {
+		// Chained after the retrying interceptor, so the timeout applies to each attempt.
+		opts = append(opts, grpc.WithChainUnaryInterceptor(remotestorage.TimeoutUnaryClientInterceptor(timeout)))
+	}
 
 	conn, err := grpc.Dial(cfg.Endpoint, opts...)
 	if err != nil {
@@ -138,5 +188,36 @@ func (fact DoltRemoteFactory) newChunkStore(ctx context.Context, nbf *types.Noms
 		cs = cs.WithNoopChunkCache()
 	}
 
+	if maxBandwidth, ok := params[GRPCMaxBandwidthParam]; ok && maxBandwidth != nil {
+		bytesPerSec, err := iohelp.ParseBandwidth(maxBandwidth.(string))
+		if err != nil {
+			cs.Close()
+			return nil, err
+		}
+		cs = cs.WithMaxBandwidth(bytesPerSec)
+	}
+
+	if concurrency > 0 {
+		cs = cs.WithMaxConcurrentDownloads(concurrency)
+	}
+
 	return cs, nil
 }
+
+// ParseGRPCTimeout parses the value of GRPCTimeoutParam.
+func ParseGRPCTimeout(s string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s', expected a positive duration such as 30s", GRPCTimeoutParam, s)
+	}
+	return timeout, nil
+}
+
+// ParseGRPCConcurrency parses the value of GRPCConcurrencyParam.
+func ParseGRPCConcurrency(s string) (int, error) {
+	concurrency, err := strconv.Atoi(s)
+	if err != nil || concurrency <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s', expected a positive integer", GRPCConcurrencyParam, s)
+	}
+	return concurrency, nil
+}
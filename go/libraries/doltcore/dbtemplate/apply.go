@@ -0,0 +1,139 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtemplate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+)
+
+// QueryFunc runs the single SQL statement |query| in the session of |ctx|.
+type QueryFunc func(ctx *sql.Context, query string) error
+
+// Apply applies the schema, ignore rules, saved queries and hooks of |t| to |dbName|, which must be the current
+// database of |ctx|, and commits them. If any of them fails, the changes of the template are discarded. The config
+// of |t| isn't applied, since it belongs to the environment of the database rather than its data.
+func (t *Template) Apply(ctx *sql.Context, dbName string, query QueryFunc) error {
+	if t.IsEmpty() {
+		return nil
+	}
+	if err := t.apply(ctx, dbName, query); err != nil {
+		if resetErr := query(ctx, "CALL DOLT_RESET('--hard')"); resetErr != nil {
+			return fmt.Errorf("%w; additionally, discarding the changes of the template failed: %s", err, resetErr.Error())
+		}
+		return fmt.Errorf("error applying template %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+func (t *Template) apply(ctx *sql.Context, dbName string, query QueryFunc) error {
+	if err := runScript(ctx, query, t.Schema); err != nil {
+		return fmt.Errorf("%s: %w", SchemaFile, err)
+	}
+
+	for _, rule := range t.Ignore {
+		ignored := 0
+		if rule.Ignored {
+			ignored = 1
+		}
+		err := query(ctx, fmt.Sprintf("REPLACE INTO %s VALUES (%s, %d)", doltdb.IgnoreTableName, quoteString(rule.Pattern), ignored))
+		if err != nil {
+			return fmt.Errorf("%s: %w", IgnoreFile, err)
+		}
+	}
+
+	if len(t.Queries) > 0 {
+		if err := saveQueries(ctx, dbName, query, t.Queries); err != nil {
+			return fmt.Errorf("%s: %w", QueriesDir, err)
+		}
+	}
+
+	for _, hook := range t.Hooks {
+		if err := runScript(ctx, query, hook.SQL); err != nil {
+			return fmt.Errorf("%s/%s.sql: %w", HooksDir, hook.Name, err)
+		}
+	}
+
+	msg := fmt.Sprintf("Apply template %s", t.Name)
+	return query(ctx, fmt.Sprintf("CALL DOLT_COMMIT('-A', '--skip-empty', '-m', %s)", quoteString(msg)))
+}
+
+// saveQueries adds |queries| to the query catalog of |dbName|. The query catalog can't be written with SQL, so the
+// queries are added to the working root of an explicit transaction.
+func saveQueries(ctx *sql.Context, dbName string, query QueryFunc, queries []SavedQuery) error {
+	if err := query(ctx, "START TRANSACTION"); err != nil {
+		return err
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, dbName)
+	if !ok {
+		return fmt.Errorf("unable to get roots for database %s", dbName)
+	}
+	root := roots.Working
+	for _, q := range queries {
+		var err error
+		_, root, err = dtables.NewQueryCatalogEntryWithNameAsID(ctx, root, q.Name, q.Query, q.Description)
+		if err != nil {
+			return err
+		}
+	}
+	if err := sess.SetWorkingRoot(ctx, dbName, root); err != nil {
+		return err
+	}
+	return query(ctx, "COMMIT")
+}
+
+// runScript runs each statement of |script|.
+func runScript(ctx *sql.Context, query QueryFunc, script string) error {
+	for _, stmt := range splitStatements(ctx, script) {
+		if err := query(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStatements splits |script| into its statements. If a statement doesn't parse, it's returned along with the
+// rest of |script| as the last statement, so that running it reports the error.
+func splitStatements(ctx *sql.Context, script string) []string {
+	var stmts []string
+	for rest := strings.TrimSpace(script); rest != ""; {
+		_, end, err := sqlparser.ParseOne(ctx, rest)
+		if err == sqlparser.ErrEmpty {
+			// only comments are left
+			break
+		}
+		if err != nil || end <= 0 || end >= len(rest) {
+			end = len(rest)
+		}
+		if stmt := sql.RemoveSpaceAndDelimiter(rest[:end], ';'); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return stmts
+}
+
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
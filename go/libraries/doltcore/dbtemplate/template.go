@@ -0,0 +1,310 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dbtemplate implements database templates, which standardize the setup of new databases. A template is applied
+// when a database is created with `dolt init --template` or, while the dolt_database_template system variable is set,
+// with CREATE DATABASE. It is a directory, or a .tar.gz archive of one served over HTTP, which may contain:
+//
+//	config.json  repository config values, as set by `dolt config --local`
+//	schema.sql   SQL statements creating the schema, such as tables, views, triggers and procedures
+//	dolt_ignore  patterns of tables to ignore, one per line. A pattern starting with ! is explicitly not ignored
+//	queries/     saved queries, one per .sql file, named after the file. A leading -- comment is the description
+//	hooks/       SQL scripts run in name order once the rest of the template is applied, such as to seed data
+//
+// Everything but the config is applied in a single commit on top of the database's initial commit.
+package dbtemplate
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+// The files and directories of a template.
+const (
+	ConfigFile = "config.json"
+	SchemaFile = "schema.sql"
+	IgnoreFile = "dolt_ignore"
+	QueriesDir = "queries"
+	HooksDir   = "hooks"
+)
+
+// maxArchiveSize is the largest template archive which will be downloaded.
+const maxArchiveSize = 64 * 1024 * 1024
+
+// IgnoreRule is a row of the dolt_ignore table.
+type IgnoreRule struct {
+	Pattern string
+	Ignored bool
+}
+
+// SavedQuery is a query saved in the dolt_query_catalog table.
+type SavedQuery struct {
+	Name        string
+	Query       string
+	Description string
+}
+
+// Script is a SQL script run when a template is applied.
+type Script struct {
+	Name string
+	SQL  string
+}
+
+// Template is a loaded database template.
+type Template struct {
+	// Name identifies the template in the message of the commit applying it.
+	Name    string
+	Config  map[string]string
+	Schema  string
+	Ignore  []IgnoreRule
+	Queries []SavedQuery
+	Hooks   []Script
+}
+
+// Load loads the template at |source|, which is either the path of a directory or an http or https URL of a .tar.gz
+// archive of one.
+func Load(ctx context.Context, source string) (*Template, error) {
+	if u, err := url.Parse(source); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		return loadArchive(ctx, u)
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("error loading template %s: %w", source, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("error loading template %s: not a directory", source)
+	}
+	return loadDir(source, filepath.Base(filepath.Clean(source)))
+}
+
+// IsEmpty returns whether |t| has nothing to apply to the data of a database, although it may have config.
+func (t *Template) IsEmpty() bool {
+	return strings.TrimSpace(t.Schema) == "" && len(t.Ignore) == 0 && len(t.Queries) == 0 && len(t.Hooks) == 0
+}
+
+func loadDir(dir, name string) (*Template, error) {
+	t := &Template{Name: name, Config: map[string]string{}}
+	found := false
+
+	data, err := readOptional(filepath.Join(dir, ConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		found = true
+		if err = json.Unmarshal(data, &t.Config); err != nil {
+			return nil, fmt.Errorf("error reading template %s: %w", ConfigFile, err)
+		}
+		for key := range t.Config {
+			if _, ok := config.ConfigOptions[key]; !ok && !strings.HasPrefix(key, env.SqlServerGlobalsPrefix) {
+				return nil, fmt.Errorf("error reading template %s: invalid config option %s", ConfigFile, key)
+			}
+		}
+	}
+
+	data, err = readOptional(filepath.Join(dir, SchemaFile))
+	if err != nil {
+		return nil, err
+	}
+	found = found || data != nil
+	t.Schema = string(data)
+
+	data, err = readOptional(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+	found = found || data != nil
+	t.Ignore = parseIgnoreRules(string(data))
+
+	queries, err := readScripts(filepath.Join(dir, QueriesDir))
+	if err != nil {
+		return nil, err
+	}
+	found = found || queries != nil
+	for _, q := range queries {
+		t.Queries = append(t.Queries, parseSavedQuery(q))
+	}
+
+	t.Hooks, err = readScripts(filepath.Join(dir, HooksDir))
+	if err != nil {
+		return nil, err
+	}
+	found = found || t.Hooks != nil
+
+	if !found {
+		return nil, fmt.Errorf("error loading template %s: it has none of %s, %s, %s, %s/ or %s/", name,
+			ConfigFile, SchemaFile, IgnoreFile, QueriesDir, HooksDir)
+	}
+	return t, nil
+}
+
+// readOptional returns the contents of the file at |path|, or nil if it doesn't exist.
+func readOptional(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading template file %s: %w", path, err)
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return data, nil
+}
+
+// readScripts returns the .sql files in |dir| in name order, or nil if |dir| doesn't exist.
+func readScripts(dir string) ([]Script, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading template directory %s: %w", dir, err)
+	}
+	scripts := []Script{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading template file %s: %w", e.Name(), err)
+		}
+		scripts = append(scripts, Script{Name: strings.TrimSuffix(e.Name(), ".sql"), SQL: string(data)})
+	}
+	sort.Slice(scripts, func(i, j int) bool {
+		return scripts[i].Name < scripts[j].Name
+	})
+	return scripts, nil
+}
+
+func parseIgnoreRules(data string) []IgnoreRule {
+	var rules []IgnoreRule
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "!") {
+			rules = append(rules, IgnoreRule{Pattern: strings.TrimSpace(line[1:]), Ignored: false})
+		} else {
+			rules = append(rules, IgnoreRule{Pattern: line, Ignored: true})
+		}
+	}
+	return rules
+}
+
+// parseSavedQuery returns the saved query in |s|. Leading -- comment lines are its description.
+func parseSavedQuery(s Script) SavedQuery {
+	var desc []string
+	query := strings.TrimSpace(s.SQL)
+	for strings.HasPrefix(query, "--") {
+		line, rest, _ := strings.Cut(query, "\n")
+		desc = append(desc, strings.TrimSpace(strings.TrimPrefix(line, "--")))
+		query = strings.TrimSpace(rest)
+	}
+	query = strings.TrimSpace(strings.TrimSuffix(query, ";"))
+	return SavedQuery{Name: s.Name, Query: query, Description: strings.Join(desc, " ")}
+}
+
+// loadArchive downloads the .tar.gz archive at |u| and loads the template it contains. The template may be at the
+// root of the archive, or in its only top level directory, as in the archives of source code hosts.
+func loadArchive(ctx context.Context, u *url.URL) (*Template, error) {
+	name := strings.TrimSuffix(strings.TrimSuffix(path.Base(u.Path), ".tgz"), ".tar.gz")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading template %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error downloading template %s: %s", name, resp.Status)
+	}
+
+	dir, err := os.MkdirTemp("", "dolt-template-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err = extractArchive(io.LimitReader(resp.Body, maxArchiveSize), dir); err != nil {
+		return nil, fmt.Errorf("error extracting template %s: %w", name, err)
+	}
+
+	root := dir
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) == 1 && entries[0].IsDir() {
+		root = filepath.Join(dir, entries[0].Name())
+	}
+	return loadDir(root, name)
+}
+
+// extractArchive extracts the regular files and directories of the .tar.gz archive |r| into |dir|.
+func extractArchive(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		name := filepath.FromSlash(path.Clean("/" + hdr.Name))
+		target := filepath.Join(dir, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
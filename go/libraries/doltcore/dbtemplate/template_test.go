@@ -0,0 +1,138 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbtemplate
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testTemplateFiles = map[string]string{
+	ConfigFile:                       `{"user.name": "Template User", "sqlserver.global.max_connections": "10"}`,
+	SchemaFile:                       "create table t (pk int primary key);\ncreate view v as select * from t;\n",
+	IgnoreFile:                       "# generated tables\ntmp_*\n!tmp_keep\n\n",
+	QueriesDir + "/count_t.sql":      "-- Counts the rows\n-- of t\nselect count(*) from t;\n",
+	QueriesDir + "/README.md":        "not a query",
+	HooksDir + "/02_seed_more.sql":   "insert into t values (2);",
+	HooksDir + "/01_seed.sql":        "insert into t values (1);",
+	HooksDir + "/nested/ignored.sql": "select 1;",
+}
+
+func writeTemplate(t *testing.T, dir string, files map[string]string) {
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, os.WriteFile(p, []byte(contents), 0644))
+	}
+}
+
+func assertTestTemplate(t *testing.T, tmpl *Template) {
+	assert.Equal(t, map[string]string{"user.name": "Template User", "sqlserver.global.max_connections": "10"}, tmpl.Config)
+	assert.Equal(t, testTemplateFiles[SchemaFile], tmpl.Schema)
+	assert.Equal(t, []IgnoreRule{{Pattern: "tmp_*", Ignored: true}, {Pattern: "tmp_keep", Ignored: false}}, tmpl.Ignore)
+	assert.Equal(t, []SavedQuery{{Name: "count_t", Query: "select count(*) from t", Description: "Counts the rows of t"}}, tmpl.Queries)
+	assert.Equal(t, []Script{{Name: "01_seed", SQL: "insert into t values (1);"}, {Name: "02_seed_more", SQL: "insert into t values (2);"}}, tmpl.Hooks)
+	assert.False(t, tmpl.IsEmpty())
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "standard")
+	writeTemplate(t, dir, testTemplateFiles)
+
+	tmpl, err := Load(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, "standard", tmpl.Name)
+	assertTestTemplate(t, tmpl)
+
+	t.Run("config only", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTemplate(t, dir, map[string]string{ConfigFile: `{"init.defaultbranch": "trunk"}`})
+		tmpl, err := Load(context.Background(), dir)
+		require.NoError(t, err)
+		assert.True(t, tmpl.IsEmpty())
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTemplate(t, dir, map[string]string{ConfigFile: `{"not.an.option": "x"}`})
+		_, err := Load(context.Background(), dir)
+		assert.ErrorContains(t, err, "invalid config option not.an.option")
+	})
+
+	t.Run("empty directory", func(t *testing.T) {
+		_, err := Load(context.Background(), t.TempDir())
+		assert.ErrorContains(t, err, "it has none of")
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := Load(context.Background(), filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, contents := range testTemplateFiles {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: "standard-main/" + name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	// entries escaping the archive are extracted inside it
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../../standard-main/escaped.txt", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/standard.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	// the template is in the only top level directory of the archive
+	tmpl, err := Load(context.Background(), srv.URL+"/standard.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, "standard", tmpl.Name)
+	assertTestTemplate(t, tmpl)
+
+	_, err = Load(context.Background(), srv.URL+"/missing.tar.gz")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestSplitStatements(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	assert.Equal(t, []string{"create table t (pk int primary key)", "insert into t values (1), (2)"},
+		splitStatements(ctx, "create table t (pk int primary key);\n\ninsert into t values (1), (2);\n-- done\n"))
+	assert.Equal(t, []string{"select ';'"}, splitStatements(ctx, "select ';'"))
+	assert.Empty(t, splitStatements(ctx, "  -- nothing\n"))
+	assert.Equal(t, []string{"select 1", "not sql; select 2"}, splitStatements(ctx, "select 1; not sql; select 2;"))
+}
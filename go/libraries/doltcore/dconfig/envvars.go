@@ -45,6 +45,13 @@ const (
 	EnvDbNameReplace                 = "DOLT_DBNAME_REPLACE"
 	EnvDoltRootHost                  = "DOLT_ROOT_HOST"
 	EnvDoltRootPassword              = "DOLT_ROOT_PASSWORD"
+	EnvCompressionThreads            = "DOLT_COMPRESSION_THREADS"
+	EnvScanPrefetchDepth             = "DOLT_SCAN_PREFETCH_DEPTH"
+	EnvTableFileReadMode             = "DOLT_TABLE_FILE_READ_MODE"
+	EnvPushDeltaChunks               = "DOLT_PUSH_DELTA_CHUNKS"
+
+	// Prefix of the environment variables holding the passwords of the credentials named by federated external tables.
+	EnvExternalTableCredPrefix = "DOLT_EXTERNAL_TABLE_CRED_"
 
 	// If set, must be "kill_connections" or "session_aware"
 	// Will go away after session_aware is made default-and-only.
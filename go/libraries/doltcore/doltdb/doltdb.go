@@ -61,6 +61,9 @@ const (
 
 	// 1GB.
 	defaultTargetFileSize = 1 << 30
+
+	// pushNegotiationBudget is the maximum number of chunk addresses negotiated before a push.
+	pushNegotiationBudget = 64 * 1024
 )
 
 var ErrMissingDoltDataDir = errors.New("missing dolt data directory")
@@ -125,16 +128,25 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 	}
 
 	if urlStr == LocalDirDoltDB {
+		var absPath string
 		exists, isDir := fs.Exists(dbfactory.DoltDataDir)
-		if !exists {
-			return nil, ErrMissingDoltDataDir
-		} else if !isDir {
+		if exists && !isDir {
 			return nil, errors.New("file exists where the dolt data directory should be")
-		}
-
-		absPath, err := fs.Abs(dbfactory.DoltDataDir)
-		if err != nil {
-			return nil, err
+		} else if exists {
+			var err error
+			absPath, err = fs.Abs(dbfactory.DoltDataDir)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// linked worktrees on filesystems without symlinks point at the shared data directory with a file instead
+			linked, ok, err := dbfactory.LinkedDataDir(fs)
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				return nil, ErrMissingDoltDataDir
+			}
+			absPath = linked
 		}
 
 		urlStr = earl.FileUrlFromPath(filepath.ToSlash(absPath), os.PathSeparator)
@@ -602,6 +614,24 @@ func (ddb *DoltDB) ResolveTag(ctx context.Context, tagRef ref.TagRef) (*Tag, err
 	return NewTag(ctx, tagRef.GetPath(), ds, ddb.vrw, ddb.ns)
 }
 
+// ResolveTagAtRoot takes a TagRef and returns the corresponding Tag object as it existed at the given root hash.
+func (ddb *DoltDB) ResolveTagAtRoot(ctx context.Context, tagRef ref.TagRef, nomsRoot hash.Hash) (*Tag, error) {
+	ds, err := ddb.db.GetDatasetByRootHash(ctx, tagRef.String(), nomsRoot)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	if !ds.HasHead() {
+		return nil, ErrTagNotFound
+	}
+
+	if !ds.IsTag() {
+		return nil, fmt.Errorf("tagRef head is not a tag")
+	}
+
+	return NewTag(ctx, tagRef.GetPath(), ds, ddb.vrw, ddb.ns)
+}
+
 // TagResolver is used to late load tag metadata resolution. There are situations where we need to list all the tags, but
 // don't necessarily need to load their metadata. See GetTagResolvers
 type TagResolver struct {
@@ -703,7 +733,11 @@ func (ddb *DoltDB) WriteRootValue(ctx context.Context, rv RootValue) (RootValue,
 }
 
 func (ddb *DoltDB) writeRootValue(ctx context.Context, rv RootValue) (RootValue, types.Ref, error) {
-	rv, err := rv.SetFeatureVersion(DoltFeatureVersion)
+	ver, err := writtenFeatureVersion(ctx, rv)
+	if err != nil {
+		return nil, types.Ref{}, err
+	}
+	rv, err = rv.SetFeatureVersion(ver)
 	if err != nil {
 		return nil, types.Ref{}, err
 	}
@@ -714,6 +748,23 @@ func (ddb *DoltDB) writeRootValue(ctx context.Context, rv RootValue) (RootValue,
 	return rv, ref, nil
 }
 
+// writtenFeatureVersion returns the feature version to write to |rv|, which is DoltFeatureVersion unless this client
+// is of DescendingIndexFeatureVersion and |rv| has no descending index columns. Only those change how the tables of a
+// root are read, so other roots are written with the previous version and remain usable by older clients.
+func writtenFeatureVersion(ctx context.Context, rv RootValue) (FeatureVersion, error) {
+	if DoltFeatureVersion != DescendingIndexFeatureVersion {
+		return DoltFeatureVersion, nil
+	}
+	desc, err := hasDescendingIndexColumns(ctx, rv)
+	if err != nil {
+		return 0, err
+	}
+	if desc {
+		return DescendingIndexFeatureVersion, nil
+	}
+	return DescendingIndexFeatureVersion - 1, nil
+}
+
 // Persists all relevant root values of the WorkingSet to the database and returns all hashes reachable
 // from the working set. This is used in GC, for example, where all dependencies of the in-memory working
 // set value need to be accounted for.
@@ -895,6 +946,42 @@ func (ddb *DoltDB) SetHead(ctx context.Context, ref ref.DoltRef, addr hash.Hash)
 	return err
 }
 
+// RefUpdate is a change made to a ref by UpdateRefs.
+type RefUpdate struct {
+	Ref ref.DoltRef
+	// Commit is the new head of a branch or workspace, or the commit of a new tag. A nil Commit deletes the ref.
+	Commit *Commit
+	// TagMeta is the metadata of a new tag.
+	TagMeta *datas.TagMeta
+}
+
+// UpdateRefs makes every change in |updates| in a single update of the database, so that either all of the refs are
+// changed or none of them are. Heads are force-set like SetHeadToCommit, and tags are replaced by new tags, since
+// tags can't otherwise be moved. Working sets aren't updated.
+func (ddb *DoltDB) UpdateRefs(ctx context.Context, updates []RefUpdate) error {
+	heads := make(map[string]hash.Hash, len(updates))
+	for _, u := range updates {
+		if u.Commit == nil {
+			heads[u.Ref.String()] = hash.Hash{}
+			continue
+		}
+		addr, err := u.Commit.HashOf()
+		if err != nil {
+			return err
+		}
+		if u.Ref.GetType() == ref.TagRefType {
+			if !IsValidTagRef(u.Ref) {
+				return fmt.Errorf("invalid tag name %s", u.Ref.String())
+			}
+			if addr, err = ddb.db.WriteTag(ctx, addr, datas.TagOptions{Meta: u.TagMeta}); err != nil {
+				return err
+			}
+		}
+		heads[u.Ref.String()] = addr
+	}
+	return ddb.db.SetHeads(ctx, heads)
+}
+
 // CommitWithParentSpecs commits the value hash given to the branch given, using the list of parent hashes given. Returns an
 // error if the value or any parents can't be resolved, or if anything goes wrong accessing the underlying storage.
 func (ddb *DoltDB) CommitWithParentSpecs(ctx context.Context, valHash hash.Hash, dref ref.DoltRef, parentCmSpecs []*CommitSpec, cm *datas.CommitMeta) (*Commit, error) {
@@ -1010,6 +1097,30 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 	return ddb.CommitDangling(ctx, val, commitOpts)
 }
 
+// CommitDanglingWithoutParents creates a new Commit for the root value |valHash| with no parents, which starts a new
+// history. Like CommitDanglingWithParentCommits, the commit isn't referenced by any DoltRef.
+func (ddb *DoltDB) CommitDanglingWithoutParents(ctx context.Context, valHash hash.Hash, cm *datas.CommitMeta) (*Commit, error) {
+	val, err := ddb.vrw.ReadValue(ctx, valHash)
+	if err != nil {
+		return nil, err
+	}
+	if !isRootValue(ddb.vrw.Format(), val) {
+		return nil, errors.New("can't commit a value that is not a valid root value")
+	}
+
+	cs := datas.ChunkStoreFromDatabase(ddb.db)
+	dcommit, err := datas.NewRootCommitForValue(ctx, cs, ddb.vrw, ddb.ns, val, datas.CommitOptions{Meta: cm})
+	if err != nil {
+		return nil, err
+	}
+	_, err = ddb.vrw.WriteValue(ctx, dcommit.NomsValue())
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCommit(ctx, ddb.vrw, ddb.ns, dcommit)
+}
+
 // CommitDangling creates a new Commit for |val| that is not referenced by any DoltRef.
 func (ddb *DoltDB) CommitDangling(ctx context.Context, val types.Value, opts datas.CommitOptions) (*Commit, error) {
 	cs := datas.ChunkStoreFromDatabase(ddb.db)
@@ -1211,6 +1322,27 @@ func (ddb *DoltDB) GetTagsWithHashes(ctx context.Context) ([]TagWithHash, error)
 	return refs, err
 }
 
+// GetTagsWithHashesByNomsRoot returns a list of objects containing Tags with their associated Commit's hashes, as they
+// existed at the given root hash.
+func (ddb *DoltDB) GetTagsWithHashesByNomsRoot(ctx context.Context, nomsRoot hash.Hash) ([]TagWithHash, error) {
+	var refs []TagWithHash
+	err := ddb.VisitRefsOfTypeByNomsRoot(ctx, tagsRefFilter, nomsRoot, func(r ref.DoltRef, _ hash.Hash) error {
+		if tr, ok := r.(ref.TagRef); ok {
+			tag, err := ddb.ResolveTagAtRoot(ctx, tr, nomsRoot)
+			if err != nil {
+				return err
+			}
+			h, err := tag.Commit.HashOf()
+			if err != nil {
+				return err
+			}
+			refs = append(refs, TagWithHash{tag, h})
+		}
+		return nil
+	})
+	return refs, err
+}
+
 // SetTuple sets a key ref value
 func (ddb *DoltDB) SetTuple(ctx context.Context, key string, value []byte) error {
 	ds, err := ddb.db.GetDataset(ctx, ref.NewTupleRef(key).String())
@@ -1786,13 +1918,39 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, cmp chunks.GCArchi
 		return err
 	}
 
-	datasets, err := ddb.db.Datasets(ctx)
+	oldGen, newGen, err := ddb.gcRefs(ctx)
 	if err != nil {
 		return err
 	}
 
-	newGen := make(hash.HashSet)
-	oldGen := make(hash.HashSet)
+	return collector.GC(ctx, mode, cmp, oldGen, newGen, safepointController)
+}
+
+// GCDryRun computes what GC would collect when run with |mode|, without modifying the database.
+func (ddb *DoltDB) GCDryRun(ctx context.Context, mode types.GCMode) (types.GCPlan, error) {
+	collector, ok := ddb.db.Database.(datas.GarbageCollector)
+	if !ok {
+		return types.GCPlan{}, fmt.Errorf("this database does not support garbage collection")
+	}
+
+	oldGen, newGen, err := ddb.gcRefs(ctx)
+	if err != nil {
+		return types.GCPlan{}, err
+	}
+
+	return collector.GCDryRun(ctx, mode, oldGen, newGen)
+}
+
+// gcRefs returns the heads of the datasets GC keeps, split into those whose chunks belong in the old generation and
+// those whose chunks belong in the new generation. Datasets which GC prunes, such as flushes, are not included.
+func (ddb *DoltDB) gcRefs(ctx context.Context) (oldGen, newGen hash.HashSet, err error) {
+	datasets, err := ddb.db.Datasets(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newGen = make(hash.HashSet)
+	oldGen = make(hash.HashSet)
 	err = datasets.IterAll(ctx, func(keyStr string, h hash.Hash) error {
 		var isOldGen bool
 		switch {
@@ -1804,6 +1962,8 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, cmp chunks.GCArchi
 
 			refType := parsed.GetType()
 			isOldGen = refType == ref.BranchRefType || refType == ref.RemoteRefType || refType == ref.InternalRefType
+		case !ref.IsWorkingSet(keyStr):
+			return nil
 		}
 
 		if isOldGen {
@@ -1816,10 +1976,10 @@ func (ddb *DoltDB) GC(ctx context.Context, mode types.GCMode, cmp chunks.GCArchi
 	})
 
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	return collector.GC(ctx, mode, cmp, oldGen, newGen, safepointController)
+	return oldGen, newGen, nil
 }
 
 func (ddb *DoltDB) ShallowGC(ctx context.Context) error {
@@ -1871,7 +2031,73 @@ func (ddb *DoltDB) PullChunks(
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
 ) error {
-	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes)
+	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, skipHashes, nil)
+}
+
+// PushChunks pushes all chunks reachable from the given targetHashes in the local source database given into this
+// database. Unlike PullChunks, the chunks this database already has are first negotiated from the heads of its
+// branches, so that fewer chunks have to be checked against this database, which is usually remote.
+func (ddb *DoltDB) PushChunks(
+	ctx context.Context,
+	tempDir string,
+	srcDB *DoltDB,
+	targetHashes []hash.Hash,
+	statsCh chan pull.Stats,
+) error {
+	haves, err := ddb.negotiateHaves(ctx, srcDB)
+	if err != nil {
+		return err
+	}
+	return pullHash(ctx, ddb.db, srcDB.db, targetHashes, tempDir, statsCh, nil, haves)
+}
+
+// negotiateHaves returns addresses of chunks in |srcDB| which this database is known to have, because they're
+// reachable from the heads of its branches.
+func (ddb *DoltDB) negotiateHaves(ctx context.Context, srcDB *DoltDB) (hash.HashSet, error) {
+	branches, err := ddb.GetBranchesWithHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(hash.HashSet, len(branches))
+	for _, b := range branches {
+		heads.Insert(b.Hash)
+	}
+
+	srcCS := datas.ChunkStoreFromDatabase(srcDB.db)
+	absent, err := srcCS.HasMany(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	var frontier []hash.Hash
+	boundary := make(hash.HashSet)
+	for h := range heads {
+		if absent.Has(h) {
+			// the source doesn't have the head, so it can't walk it
+			continue
+		}
+		cm, err := HashToCommit(ctx, srcDB.vrw, srcDB.ns, h)
+		if errors.Is(err, ErrGhostCommitEncountered) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		parents, err := cm.ParentHashes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// the history of the heads is checked by the puller, since this database may be a shallow clone
+		for _, p := range parents {
+			boundary.Insert(p)
+		}
+		frontier = append(frontier, h)
+	}
+	if len(frontier) == 0 {
+		return nil, nil
+	}
+
+	waf := types.WalkAddrsForNBF(srcDB.Format(), nil)
+	return pull.NegotiateHaves(ctx, srcCS, waf, frontier, boundary, pushNegotiationBudget)
 }
 
 func pullHash(
@@ -1881,13 +2107,14 @@ func pullHash(
 	tempDir string,
 	statsCh chan pull.Stats,
 	skipHashes hash.HashSet,
+	haves hash.HashSet,
 ) error {
 	srcCS := datas.ChunkStoreFromDatabase(srcDB)
 	destCS := datas.ChunkStoreFromDatabase(destDB)
 	waf := types.WalkAddrsForNBF(srcDB.Format(), skipHashes)
 
 	if datas.CanUsePuller(srcDB) && datas.CanUsePuller(destDB) {
-		puller, err := pull.NewPuller(ctx, tempDir, defaultTargetFileSize, srcCS, destCS, waf, targetHashes, statsCh)
+		puller, err := pull.NewPuller(ctx, tempDir, defaultTargetFileSize, srcCS, destCS, waf, targetHashes, haves, statsCh)
 		if err == pull.ErrDBUpToDate {
 			return nil
 		} else if err != nil {
@@ -1934,6 +2161,17 @@ func (ddb *DoltDB) ChunkJournal() *nbs.ChunkJournal {
 	}
 }
 
+// SetChunkRepairer sets the function used to fetch good copies of chunks found to be corrupt when reading from this
+// DoltDB. It is a no-op for DoltDBs which aren't backed by a local NomsBlockStore.
+func (ddb *DoltDB) SetChunkRepairer(r nbs.ChunkRepairer) {
+	switch cs := datas.ChunkStoreFromDatabase(ddb.db).(type) {
+	case *nbs.GenerationalNBS:
+		cs.SetChunkRepairer(r)
+	case *nbs.NomsBlockStore:
+		cs.SetChunkRepairer(r)
+	}
+}
+
 // An approximate representation of how large the on-disk storage is for a DoltDB.
 type StoreSizes struct {
 	// For ChunkJournal stores, this will be size of the journal file. A size
@@ -1950,6 +2188,9 @@ type StoreSizes struct {
 	// This is the approximate total on-disk storage overhead of the store.
 	// It includes Journal and NewGenBytes, if there are any.
 	TotalBytes uint64
+	// The size of the chunks which have been written but are still buffered in
+	// memory. They are not included in TotalBytes.
+	PendingBytes uint64
 }
 
 func (ddb *DoltDB) StoreSizes(ctx context.Context) (StoreSizes, error) {
@@ -1976,11 +2217,13 @@ func (ddb *DoltDB) StoreSizes(ctx context.Context) (StoreSizes, error) {
 				JournalBytes: uint64(journal.Size()),
 				NewGenBytes:  newgenSz,
 				TotalBytes:   totalSz,
+				PendingBytes: newGenNBS.PendingSize(),
 			}, nil
 		} else {
 			return StoreSizes{
-				NewGenBytes: newgenSz,
-				TotalBytes:  totalSz,
+				NewGenBytes:  newgenSz,
+				TotalBytes:   totalSz,
+				PendingBytes: newGenNBS.PendingSize(),
 			}, nil
 		}
 	} else {
@@ -1992,8 +2235,13 @@ func (ddb *DoltDB) StoreSizes(ctx context.Context) (StoreSizes, error) {
 		if err != nil {
 			return StoreSizes{}, err
 		}
+		var pendingSz uint64
+		if nbsStore, ok := cs.(*nbs.NomsBlockStore); ok {
+			pendingSz = nbsStore.PendingSize()
+		}
 		return StoreSizes{
-			TotalBytes: totalSz,
+			TotalBytes:   totalSz,
+			PendingBytes: pendingSz,
 		}, nil
 	}
 }
@@ -41,6 +41,8 @@ type fvTest struct {
 	name   string
 	setup  []fvCommand
 	expVer doltdb.FeatureVersion
+	// initVer is the feature version the test env is created with, oldVersion if unset
+	initVer doltdb.FeatureVersion
 
 	// for error path testing
 	errCmds []fvCommand
@@ -71,6 +73,9 @@ type fvUser struct {
 var NewClient = fvUser{vers: newVersion}
 var OldClient = fvUser{vers: oldVersion}
 
+var DescendingIndexClient = fvUser{vers: doltdb.DescendingIndexFeatureVersion}
+var PreDescendingIndexClient = fvUser{vers: doltdb.DescendingIndexFeatureVersion - 1}
+
 func TestFeatureVersion(t *testing.T) {
 
 	tests := []fvTest{
@@ -146,6 +151,45 @@ func TestFeatureVersion(t *testing.T) {
 			},
 			expVer: newVersion,
 		},
+		{
+			name: "descending index client writes the previous version without descending indexes",
+			setup: []fvCommand{
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "CREATE TABLE test (pk int PRIMARY KEY, c0 int);"}},
+				{DescendingIndexClient, commands.SqlCmd{}, args{"-q", "CREATE INDEX c0_asc ON test (c0);"}},
+				{DescendingIndexClient, commands.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (0, 0);"}},
+				// previous client can still read and write
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1, 1);"}},
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "SELECT * FROM test ORDER BY c0;"}},
+			},
+			expVer:  doltdb.DescendingIndexFeatureVersion - 1,
+			initVer: doltdb.DescendingIndexFeatureVersion - 1,
+		},
+		{
+			name: "descending index client creates a descending index, locking out previous client",
+			setup: []fvCommand{
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "CREATE TABLE test (pk int PRIMARY KEY, c0 int);"}},
+				{DescendingIndexClient, commands.SqlCmd{}, args{"-q", "CREATE INDEX c0_desc ON test (c0 DESC);"}},
+			},
+			errCmds: []fvCommand{
+				// previous client can't write
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (0, 0);"}},
+				// previous client can't read
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "SELECT * FROM test ORDER BY c0 DESC;"}},
+			},
+			expVer:  doltdb.DescendingIndexFeatureVersion,
+			initVer: doltdb.DescendingIndexFeatureVersion - 1,
+		},
+		{
+			name: "dropping the last descending index unlocks previous client",
+			setup: []fvCommand{
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "CREATE TABLE test (pk int PRIMARY KEY, c0 int);"}},
+				{DescendingIndexClient, commands.SqlCmd{}, args{"-q", "CREATE INDEX c0_desc ON test (c0 DESC);"}},
+				{DescendingIndexClient, commands.SqlCmd{}, args{"-q", "DROP INDEX c0_desc ON test;"}},
+				{PreDescendingIndexClient, commands.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (0, 0);"}},
+			},
+			expVer:  doltdb.DescendingIndexFeatureVersion - 1,
+			initVer: doltdb.DescendingIndexFeatureVersion - 1,
+		},
 	}
 
 	ctx := context.Background()
@@ -153,6 +197,9 @@ func TestFeatureVersion(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 
 			doltdb.DoltFeatureVersion = oldVersion
+			if test.initVer != 0 {
+				doltdb.DoltFeatureVersion = test.initVer
+			}
 			dEnv := dtestutils.CreateTestEnv()
 			defer dEnv.DoltDB(ctx).Close()
 			doltdb.DoltFeatureVersion = DoltFeatureVersionCopy
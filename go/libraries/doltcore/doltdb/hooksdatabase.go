@@ -165,6 +165,20 @@ func (db hooksDatabase) SetHead(ctx context.Context, ds datas.Dataset, newHeadAd
 	return ds, err
 }
 
+func (db hooksDatabase) SetHeads(ctx context.Context, heads map[string]hash.Hash) error {
+	err := db.Database.SetHeads(ctx, heads)
+	if err == nil {
+		for id := range heads {
+			ds, err := db.Database.GetDataset(ctx, id)
+			if err != nil {
+				return err
+			}
+			db.ExecuteCommitHooks(ctx, ds, false)
+		}
+	}
+	return err
+}
+
 func (db hooksDatabase) FastForward(ctx context.Context, ds datas.Dataset, newHeadAddr hash.Hash, workingSetPath string) (datas.Dataset, error) {
 	ds, err := db.Database.FastForward(ctx, ds, newHeadAddr, workingSetPath)
 	if err == nil {
@@ -52,7 +52,12 @@ type FeatureVersion int64
 
 // DoltFeatureVersion is described in feature_version.md.
 // only variable for testing.
-var DoltFeatureVersion FeatureVersion = 7 // last bumped when fixing bug related to GeomAddrs not getting pushed
+var DoltFeatureVersion FeatureVersion = 8 // last bumped when adding descending index columns, which older clients would read in ascending order
+
+// DescendingIndexFeatureVersion is the feature version which added descending index columns. A client of this version
+// only writes it to roots with a descending index column, and writes the previous version to the rest, so that older
+// clients can keep using databases which don't use them. See writtenFeatureVersion.
+const DescendingIndexFeatureVersion FeatureVersion = 8
 
 // RootValue is the value of the Database and is the committed value in every Dolt or Doltgres commit.
 type RootValue interface {
@@ -273,6 +278,23 @@ func (root *rootValue) NodeStore() tree.NodeStore {
 	return root.ns
 }
 
+// hasDescendingIndexColumns returns whether any index of any table in |root| has a descending column.
+func hasDescendingIndexColumns(ctx context.Context, root RootValue) (bool, error) {
+	found := false
+	err := root.IterTables(ctx, func(_ TableName, _ *Table, sch schema.Schema) (stop bool, err error) {
+		for _, idx := range sch.Indexes().AllIndexes() {
+			for _, desc := range idx.Descending() {
+				if desc {
+					found = true
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	return found, err
+}
+
 // GetFeatureVersion returns the feature version of this root, if one is written
 func (root *rootValue) GetFeatureVersion(ctx context.Context) (ver FeatureVersion, ok bool, err error) {
 	return root.st.GetFeatureVersion()
@@ -191,6 +191,10 @@ var getWriteableSystemTables = func() []string {
 		SchemasTableName,
 		ProceduresTableName,
 		IgnoreTableName,
+		MigrationsTableName,
+		ExternalTablesTableName,
+		SummaryTablesTableName,
+		PlanBaselinesTableName,
 		GetRebaseTableName(),
 
 		// TODO: find way to make these writable by the dolt process
@@ -221,6 +225,14 @@ var getGeneratedSystemTables = func() []string {
 		GetRemotesTableName(),
 		GetHelpTableName(),
 		GetBackupsTableName(),
+		GetOperationsTableName(),
+		GetCacheStatsTableName(),
+		GetStorageUsageTableName(),
+		GetTableSizesTableName(),
+		GetAuditLogTableName(),
+		GetQueryLogTableName(),
+		GetQuerySummaryTableName(),
+		GetEventsHistoryTableName(),
 	}
 }
 
@@ -393,6 +405,46 @@ var GetBackupsTableName = func() string {
 	return BackupsTableName
 }
 
+// GetOperationsTableName returns the operations table name
+var GetOperationsTableName = func() string {
+	return OperationsTableName
+}
+
+// GetCacheStatsTableName returns the cache stats table name
+var GetCacheStatsTableName = func() string {
+	return CacheStatsTableName
+}
+
+// GetStorageUsageTableName returns the storage usage table name
+var GetStorageUsageTableName = func() string {
+	return StorageUsageTableName
+}
+
+// GetTableSizesTableName returns the table sizes table name
+var GetTableSizesTableName = func() string {
+	return TableSizesTableName
+}
+
+// GetAuditLogTableName returns the audit log table name
+var GetAuditLogTableName = func() string {
+	return AuditLogTableName
+}
+
+// GetQueryLogTableName returns the query log table name
+var GetQueryLogTableName = func() string {
+	return QueryLogTableName
+}
+
+// GetQuerySummaryTableName returns the query summary table name
+var GetQuerySummaryTableName = func() string {
+	return QuerySummaryTableName
+}
+
+// GetEventsHistoryTableName returns the events history table name
+var GetEventsHistoryTableName = func() string {
+	return EventsHistoryTableName
+}
+
 const (
 	// LogTableName is the log system table name
 	LogTableName = "dolt_log"
@@ -439,6 +491,21 @@ const (
 	// IgnoreTableName is the ignore table name
 	IgnoreTableName = "dolt_ignore"
 
+	// MigrationsTableName is the system table name for versioned schema migrations run by `dolt migrate up`
+	MigrationsTableName = "dolt_migrations"
+
+	// ExternalTablesTableName is the system table name for the definitions of external tables, which are read from
+	// files or remote databases when they are queried
+	ExternalTablesTableName = "dolt_external_tables"
+
+	// SummaryTablesTableName is the system table name for the definitions of summary tables, which are maintained
+	// from the diff of their source tables on each commit
+	SummaryTablesTableName = "dolt_summary_tables"
+
+	// PlanBaselinesTableName is the system table name for the query plans pinned for query digests, which the plans
+	// of matching queries are checked against
+	PlanBaselinesTableName = "dolt_plan_baselines"
+
 	// RebaseTableName is the rebase system table name.
 	RebaseTableName = "dolt_rebase"
 
@@ -446,6 +513,69 @@ const (
 	StatisticsTableName = "dolt_statistics"
 )
 
+const (
+	// ExternalTablesNameCol is the name of the column storing the name of an external table.
+	ExternalTablesNameCol = "table_name"
+
+	// ExternalTablesFormatCol is the name of the column storing the format of an external table, such as csv or mysql.
+	ExternalTablesFormatCol = "format"
+
+	// ExternalTablesLocationCol is the name of the column storing the path or file:// URL of an external table's file, or
+	// the mysql:// or postgres:// URL of a federated table.
+	ExternalTablesLocationCol = "location"
+)
+
+const (
+	// SummaryTablesNameCol is the name of the column storing the name of a summary table.
+	SummaryTablesNameCol = "summary_table"
+
+	// SummaryTablesSourceCol is the name of the column storing the name of the table a summary table aggregates.
+	SummaryTablesSourceCol = "source_table"
+
+	// SummaryTablesGroupByCol is the name of the column storing the comma separated source columns a summary table
+	// groups by. They must be the primary key of the summary table.
+	SummaryTablesGroupByCol = "group_by"
+
+	// SummaryTablesAggregatesCol is the name of the column storing the comma separated aggregates of a summary table,
+	// such as "orders = count(*), revenue = sum(amount)".
+	SummaryTablesAggregatesCol = "aggregates"
+)
+
+const (
+	// MigrationsVersionCol is the name of the column storing the version of a migration. Migrations are applied in
+	// ascending version order.
+	MigrationsVersionCol = "version"
+
+	// MigrationsDescriptionCol is the name of the column storing the description of a migration.
+	MigrationsDescriptionCol = "description"
+
+	// MigrationsUpScriptCol is the name of the column storing the SQL script that applies a migration.
+	MigrationsUpScriptCol = "up_script"
+
+	// MigrationsDownScriptCol is the name of the column storing the SQL script that reverts a migration.
+	MigrationsDownScriptCol = "down_script"
+
+	// MigrationsAppliedAtCol is the name of the column storing when a migration was applied, or NULL if it has not
+	// been applied.
+	MigrationsAppliedAtCol = "applied_at"
+)
+
+const (
+	// PlanBaselinesDigestCol is the name of the column storing the digest of the queries a plan baseline applies to.
+	PlanBaselinesDigestCol = "digest"
+
+	// PlanBaselinesDigestTextCol is the name of the column storing the normalized text of the queries a plan baseline
+	// applies to.
+	PlanBaselinesDigestTextCol = "digest_text"
+
+	// PlanBaselinesPlanCol is the name of the column storing the shape of the pinned plan.
+	PlanBaselinesPlanCol = "plan"
+
+	// PlanBaselinesEnforcedCol is the name of the column storing whether queries whose plan doesn't match the baseline
+	// fail, rather than only raising a warning.
+	PlanBaselinesEnforcedCol = "enforced"
+)
+
 const (
 	// WorkflowsTableName is the dolt CI workflows system table name
 	WorkflowsTableName = "dolt_ci_workflows"
@@ -600,6 +730,14 @@ const (
 )
 
 const (
-	HelpTableName    = "dolt_help"
-	BackupsTableName = "dolt_backups"
+	HelpTableName          = "dolt_help"
+	BackupsTableName       = "dolt_backups"
+	OperationsTableName    = "dolt_operations"
+	CacheStatsTableName    = "dolt_cache_stats"
+	StorageUsageTableName  = "dolt_storage_usage"
+	TableSizesTableName    = "dolt_table_sizes"
+	AuditLogTableName      = "dolt_audit_log"
+	QueryLogTableName      = "dolt_query_log"
+	QuerySummaryTableName  = "dolt_query_summary"
+	EventsHistoryTableName = "dolt_events_history"
 )
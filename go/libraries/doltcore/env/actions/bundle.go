@@ -0,0 +1,146 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/bundle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas/pull"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ErrBundleRefNotFound is returned by CreateRefsBundle for refs which aren't branches or tags of the database.
+var ErrBundleRefNotFound = errors.New("no branch or tag named")
+
+// CreateBundle writes a bundle of the tag |tagName| of |srcDB| to |w|, and returns its manifest. The bundle holds the
+// tag and the history of its commit, and a branch named |branchName| at its commit, which is checked out when the
+// bundle is cloned.
+func CreateBundle(ctx context.Context, srcDB *doltdb.DoltDB, tempTableDir, tagName, branchName string, w io.Writer, statsCh chan pull.Stats) (bundle.Manifest, error) {
+	tagRef := ref.NewTagRef(tagName)
+	tag, err := srcDB.ResolveTag(ctx, tagRef)
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	tagAddr, err := tag.GetAddr()
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	cmHash, err := tag.Commit.HashOf()
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+
+	refs := []doltdb.RefWithHash{
+		{Ref: ref.NewBranchRef(branchName), Hash: cmHash},
+		{Ref: tagRef, Hash: tagAddr},
+	}
+	return writeBundle(ctx, srcDB, tempTableDir, refs, bundle.Manifest{
+		Tag:       tagName,
+		Branch:    branchName,
+		Commit:    cmHash.String(),
+		Tagger:    fmt.Sprintf("%s <%s>", tag.Meta.Name, tag.Meta.Email),
+		Message:   tag.Meta.Description,
+		Signature: tag.Meta.Signature,
+	}, w, statsCh)
+}
+
+// CreateRefsBundle writes a bundle of the branches and tags of |srcDB| named by |refNames| to |w|, and returns its
+// manifest. If |refNames| is empty, every branch and tag is bundled. A name is a branch or tag name, which names a
+// branch if there are both, or a full ref such as refs/tags/v1.
+func CreateRefsBundle(ctx context.Context, srcDB *doltdb.DoltDB, tempTableDir string, refNames []string, w io.Writer, statsCh chan pull.Stats) (bundle.Manifest, error) {
+	var all []doltdb.RefWithHash
+	err := srcDB.VisitRefsOfType(ctx, bundleRefTypes, func(r ref.DoltRef, addr hash.Hash) error {
+		all = append(all, doltdb.RefWithHash{Ref: r, Hash: addr})
+		return nil
+	})
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	if len(refNames) == 0 {
+		return writeBundle(ctx, srcDB, tempTableDir, all, bundle.Manifest{}, w, statsCh)
+	}
+
+	byName := make(map[string]doltdb.RefWithHash, len(all))
+	for _, r := range all {
+		byName[r.Ref.String()] = r
+	}
+	refs := make([]doltdb.RefWithHash, 0, len(refNames))
+	seen := make(map[string]struct{}, len(refNames))
+	for _, name := range refNames {
+		r, ok := byName[name]
+		if !ok {
+			r, ok = byName[ref.NewBranchRef(name).String()]
+		}
+		if !ok {
+			r, ok = byName[ref.NewTagRef(name).String()]
+		}
+		if !ok {
+			return bundle.Manifest{}, fmt.Errorf("%w '%s'", ErrBundleRefNotFound, name)
+		}
+		if _, ok = seen[r.Ref.String()]; !ok {
+			seen[r.Ref.String()] = struct{}{}
+			refs = append(refs, r)
+		}
+	}
+	return writeBundle(ctx, srcDB, tempTableDir, refs, bundle.Manifest{}, w, statsCh)
+}
+
+var bundleRefTypes = map[ref.RefType]struct{}{ref.BranchRefType: {}, ref.TagRefType: {}}
+
+// writeBundle writes a bundle of |refs| of |srcDB|, described by |m|, to |w|. The refs and the chunks they reference
+// are copied to a new store in |tempTableDir|, which is bundled and deleted.
+func writeBundle(ctx context.Context, srcDB *doltdb.DoltDB, tempTableDir string, refs []doltdb.RefWithHash, m bundle.Manifest, w io.Writer, statsCh chan pull.Stats) (bundle.Manifest, error) {
+	dir, err := os.MkdirTemp(tempTableDir, "bundle")
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	defer os.RemoveAll(dir)
+	storeUrl := dbfactory.FileScheme + "://" + filepath.ToSlash(dir)
+	destDB, err := doltdb.LoadDoltDB(ctx, srcDB.Format(), storeUrl, filesys.LocalFS)
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	// The store is deleted once it's bundled, so it mustn't be reused
+	defer dbfactory.DeleteFromSingletonCache(filepath.ToSlash(dir))
+
+	hashes := make([]hash.Hash, len(refs))
+	m.Refs = make([]bundle.Ref, len(refs))
+	for i, r := range refs {
+		hashes[i] = r.Hash
+		m.Refs[i] = bundle.Ref{Name: r.Ref.String(), Hash: r.Hash.String()}
+	}
+	err = destDB.PushChunks(ctx, tempTableDir, srcDB, hashes, statsCh)
+	for i := 0; err == nil && i < len(refs); i++ {
+		err = destDB.SetHead(ctx, refs[i].Ref, refs[i].Hash)
+	}
+	if cerr := destDB.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+
+	return bundle.Write(w, m, dir)
+}
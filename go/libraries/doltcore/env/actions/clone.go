@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -82,7 +83,7 @@ func EnvForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, di
 
 	dEnv.RSLoadErr = nil
 	if !env.IsEmptyRemote(r) {
-		dEnv.RepoState, err = env.CloneRepoState(dEnv.FS, r)
+		dEnv.RepoState, err = env.CloneRepoState(dEnv.FS, r.WithoutTransientParams())
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s; %s", ErrFailedToCreateRepoStateWithRemote, r.Name, err.Error())
 		}
@@ -91,64 +92,95 @@ func EnvForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, di
 	return dEnv, nil
 }
 
-func clonePrint(eventCh <-chan pull.TableFileEvent) {
-	var (
-		chunksC           int64
-		chunksDownloading int64
-		chunksDownloaded  int64
-		currStats         = make(map[string]iohelp.ReadStats)
-		tableFiles        = make(map[string]*chunks.TableFile)
-	)
+// CloneProgress is a snapshot of the progress of a full clone, measured in chunks.
+type CloneProgress struct {
+	ChunksTotal       int64
+	ChunksDownloading int64
+	ChunksDownloaded  int64
+}
+
+// CloneProgressFunc is called with the latest progress of a clone each time it changes.
+type CloneProgressFunc func(CloneProgress)
+
+// cloneTracker accumulates the table file events emitted during a clone into the overall progress of the clone and
+// the download stats of each table file currently being fetched.
+type cloneTracker struct {
+	progress   CloneProgress
+	currStats  map[string]iohelp.ReadStats
+	tableFiles map[string]*chunks.TableFile
+}
+
+func newCloneTracker() *cloneTracker {
+	return &cloneTracker{
+		currStats:  make(map[string]iohelp.ReadStats),
+		tableFiles: make(map[string]*chunks.TableFile),
+	}
+}
+
+func (t *cloneTracker) update(tblFEvt pull.TableFileEvent) {
+	switch tblFEvt.EventType {
+	case pull.Listed:
+		for _, tf := range tblFEvt.TableFiles {
+			c := tf
+			t.tableFiles[c.FileID()] = &c
+			t.progress.ChunksTotal += int64(tf.NumChunks())
+		}
+	case pull.DownloadStart:
+		for _, tf := range tblFEvt.TableFiles {
+			t.progress.ChunksDownloading += int64(tf.NumChunks())
+		}
+	case pull.DownloadStats:
+		for i, s := range tblFEvt.Stats {
+			tf := tblFEvt.TableFiles[i]
+			t.currStats[tf.FileID()] = s
+		}
+	case pull.DownloadSuccess:
+		for _, tf := range tblFEvt.TableFiles {
+			t.progress.ChunksDownloading -= int64(tf.NumChunks())
+			t.progress.ChunksDownloaded += int64(tf.NumChunks())
+			delete(t.currStats, tf.FileID())
+		}
+	case pull.DownloadFailed:
+		// Ignore for now and output errors on the main thread
+		for _, tf := range tblFEvt.TableFiles {
+			delete(t.currStats, tf.FileID())
+		}
+	}
+}
 
+func clonePrint(eventCh <-chan pull.TableFileEvent) {
+	t := newCloneTracker()
 	p := cli.NewEphemeralPrinter()
 
 	p.Printf("Retrieving remote information.\n")
 	p.Display()
 
 	for tblFEvt := range eventCh {
-		switch tblFEvt.EventType {
-		case pull.Listed:
-			for _, tf := range tblFEvt.TableFiles {
-				c := tf
-				tableFiles[c.FileID()] = &c
-				chunksC += int64(tf.NumChunks())
-			}
-		case pull.DownloadStart:
-			for _, tf := range tblFEvt.TableFiles {
-				chunksDownloading += int64(tf.NumChunks())
-			}
-		case pull.DownloadStats:
-			for i, s := range tblFEvt.Stats {
-				tf := tblFEvt.TableFiles[i]
-				currStats[tf.FileID()] = s
-			}
-		case pull.DownloadSuccess:
-			for _, tf := range tblFEvt.TableFiles {
-				chunksDownloading -= int64(tf.NumChunks())
-				chunksDownloaded += int64(tf.NumChunks())
-				delete(currStats, tf.FileID())
-			}
-		case pull.DownloadFailed:
-			// Ignore for now and output errors on the main thread
-			for _, tf := range tblFEvt.TableFiles {
-				delete(currStats, tf.FileID())
-			}
-		}
+		t.update(tblFEvt)
 
 		p.Printf("%s of %s chunks complete. %s chunks being downloaded currently.\n",
-			strhelp.CommaIfy(chunksDownloaded), strhelp.CommaIfy(chunksC), strhelp.CommaIfy(chunksDownloading))
-		for _, fileId := range sortedKeys(currStats) {
-			s := currStats[fileId]
+			strhelp.CommaIfy(t.progress.ChunksDownloaded), strhelp.CommaIfy(t.progress.ChunksTotal), strhelp.CommaIfy(t.progress.ChunksDownloading))
+		for _, fileId := range sortedKeys(t.currStats) {
+			s := t.currStats[fileId]
 			bps := float64(s.Read) / s.Elapsed.Seconds()
 			rate := humanize.Bytes(uint64(bps)) + "/s"
 			p.Printf("Downloading file: %s (%s chunks) - %.2f%% downloaded, %s\n",
-				fileId, strhelp.CommaIfy(int64((*tableFiles[fileId]).NumChunks())), s.Percent*100, rate)
+				fileId, strhelp.CommaIfy(int64((*t.tableFiles[fileId]).NumChunks())), s.Percent*100, rate)
 		}
 		p.Display()
 	}
 	p.Display()
 }
 
+// cloneReport passes the progress of a clone to |progress| as table file events arrive on |eventCh|.
+func cloneReport(eventCh <-chan pull.TableFileEvent, progress CloneProgressFunc) {
+	t := newCloneTracker()
+	for tblFEvt := range eventCh {
+		t.update(tblFEvt)
+		progress(t.progress)
+	}
+}
+
 func sortedKeys(m map[string]iohelp.ReadStats) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -161,8 +193,15 @@ func sortedKeys(m map[string]iohelp.ReadStats) []string {
 // CloneRemote - common entry point for both dolt_clone() and `dolt clone`
 // The database must be initialized with a remote before calling this function.
 //
-// The `branch` parameter is the branch to clone. If it is empty, the default branch is used.
-func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch bool, depth int, dEnv *env.DoltEnv) error {
+// The `branch` parameter is the branch to clone. If it is empty, the default branch is used. Tags are cloned unless
+// `noTags` is set.
+func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch, noTags bool, depth int, dEnv *env.DoltEnv) error {
+	return CloneRemoteWithProgress(ctx, srcDB, remoteName, branch, singleBranch, noTags, depth, dEnv, nil)
+}
+
+// CloneRemoteWithProgress is CloneRemote, but reports the progress of a full clone to |progress| instead of printing
+// it. If |progress| is nil, progress is printed to the CLI.
+func CloneRemoteWithProgress(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, singleBranch, noTags bool, depth int, dEnv *env.DoltEnv, progress CloneProgressFunc) error {
 	// We support two forms of cloning: full and shallow. These two approaches have little in common, with the exception
 	// of the first and last steps. Determining the branch to check out and setting the working set to the checked out commit.
 
@@ -176,6 +215,11 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 			return doltdb.ErrOperationNotSupportedInDetachedHead
 		}
 	}
+	if noTags {
+		srcRefHashes = slices.DeleteFunc(srcRefHashes, func(r doltdb.RefWithHash) bool {
+			return r.Ref.GetType() == ref.TagRefType
+		})
+	}
 	if remoteName == "" {
 		remoteName = "origin"
 	}
@@ -184,7 +228,7 @@ func CloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 
 	// Step 1) Pull the remote information we care about to a local disk.
 	if depth <= 0 {
-		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch)
+		checkedOutCommit, err = fullClone(ctx, srcDB, dEnv, srcRefHashes, branch, remoteName, singleBranch, progress)
 	} else {
 		checkedOutCommit, err = shallowCloneDataPull(ctx, dEnv.DbData(ctx), srcDB, remoteName, branch, depth)
 	}
@@ -254,13 +298,17 @@ func getSrcRefs(ctx context.Context, branch string, srcDB *doltdb.DoltDB, dEnv *
 	return srcRefHashes, branch, nil
 }
 
-func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool) (*doltdb.Commit, error) {
+func fullClone(ctx context.Context, srcDB *doltdb.DoltDB, dEnv *env.DoltEnv, srcRefHashes []doltdb.RefWithHash, branch, remoteName string, singleBranch bool, progress CloneProgressFunc) (*doltdb.Commit, error) {
 	eventCh := make(chan pull.TableFileEvent, 128)
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		clonePrint(eventCh)
+		if progress != nil {
+			cloneReport(eventCh, progress)
+		} else {
+			clonePrint(eventCh)
+		}
 	}()
 
 	err := srcDB.Clone(ctx, dEnv.DoltDB(ctx), eventCh)
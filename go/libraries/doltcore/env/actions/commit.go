@@ -16,6 +16,7 @@ package actions
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
@@ -32,6 +33,10 @@ type CommitStagedProps struct {
 	Force      bool
 	Name       string
 	Email      string
+	// CommitterName and CommitterEmail identify whoever creates the commit, when it isn't the author named by Name
+	// and Email.
+	CommitterName  string
+	CommitterEmail string
 }
 
 // GetCommitStaged returns a new pending commit with the roots and commit properties given.
@@ -110,5 +115,12 @@ func GetCommitStaged(
 		return nil, err
 	}
 
+	// the committer is only recorded when someone other than the author creates the commit
+	committerName, committerEmail := strings.TrimSpace(props.CommitterName), strings.TrimSpace(props.CommitterEmail)
+	if committerName != "" && (committerName != meta.Name || committerEmail != meta.Email) {
+		meta.CommitterName = committerName
+		meta.CommitterEmail = committerEmail
+	}
+
 	return db.NewPendingCommit(ctx, roots, mergeParents, props.Amend, meta)
 }
@@ -69,7 +69,7 @@ func Push(ctx context.Context, tempTableDir string, mode ref.UpdateMode, destRef
 		return err
 	}
 
-	err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{h}, statsCh, nil)
+	err = destDB.PushChunks(ctx, tempTableDir, srcDB, []hash.Hash{h}, statsCh)
 
 	if errors.Is(err, nbs.ErrGhostChunkRequested) {
 		err = ErrShallowPushImpossible
@@ -193,7 +193,7 @@ func PushTag(ctx context.Context, tempTableDir string, destRef ref.TagRef, srcDB
 		return err
 	}
 
-	err = destDB.PullChunks(ctx, tempTableDir, srcDB, []hash.Hash{addr}, statsCh, nil)
+	err = destDB.PushChunks(ctx, tempTableDir, srcDB, []hash.Hash{addr}, statsCh)
 
 	if err != nil {
 		return err
@@ -31,6 +31,8 @@ type TagProps struct {
 	TaggerName  string
 	TaggerEmail string
 	Description string
+	// Signature is the GPG signature of the tag, or empty to create an unsigned tag.
+	Signature string
 }
 
 func CreateTag(ctx context.Context, dEnv *env.DoltEnv, tagName, startPoint string, props TagProps) error {
@@ -73,6 +75,7 @@ func CreateTagOnDB(ctx context.Context, ddb *doltdb.DoltDB, tagName, startPoint
 	}
 
 	meta := datas.NewTagMeta(props.TaggerName, props.TaggerEmail, props.Description)
+	meta.Signature = props.Signature
 
 	return ddb.NewTagAtCommit(ctx, tagRef, cm, meta)
 }
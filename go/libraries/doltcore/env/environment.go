@@ -214,6 +214,10 @@ func LoadDoltDB(ctx context.Context, fs filesys.Filesys, urlStr string, dEnv *Do
 		dEnv.DBLoadError = dbLoadErr
 		dEnv.urlStr = urlStr
 
+		if dbLoadErr == nil {
+			ddb.SetChunkRepairer(dEnv.repairChunks)
+		}
+
 		if dbLoadErr == nil && dEnv.HasDoltDir() {
 			if !dEnv.HasDoltTempTableDir() {
 				tmpDir, err := dEnv.TempTableFilesDir()
@@ -356,7 +360,11 @@ func (dEnv *DoltEnv) HasDoltDir() bool {
 
 func (dEnv *DoltEnv) HasDoltDataDir() bool {
 	exists, isDir := dEnv.FS.Exists(dbfactory.DoltDataDir)
-	return exists && isDir
+	if exists {
+		return isDir
+	}
+	_, linked, err := dbfactory.LinkedDataDir(dEnv.FS)
+	return err == nil && linked
 }
 
 // HasDoltSqlServerInfo returns true if this Dolt environment has a sql-server.info file, indicating
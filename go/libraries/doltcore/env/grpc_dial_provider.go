@@ -17,6 +17,7 @@ package env
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
@@ -128,6 +129,11 @@ func (p GRPCDialProvider) GetGRPCDialParams(config grpcendpoint.Config) (dbfacto
 			if err != nil {
 				return dbfactory.GRPCRemoteConfig{}, err
 			}
+		} else if config.CredsKeyId != "" {
+			rpcCreds, err = p.getNamedRPCCreds(endpoint, config.CredsKeyId)
+			if err != nil {
+				return dbfactory.GRPCRemoteConfig{}, err
+			}
 		} else {
 			rpcCreds, err = p.getRPCCreds(endpoint)
 			if err != nil {
@@ -202,6 +208,31 @@ func (p GRPCDialProvider) getRPCCreds(endpoint string) (credentials.PerRPCCreden
 	}
 }
 
+// getNamedRPCCreds returns RPC credentials for the stored dolt credentials with the key id or public key |keyId|.
+func (p GRPCDialProvider) getNamedRPCCreds(endpoint, keyId string) (credentials.PerRPCCredentials, error) {
+	if p.dEnv == nil {
+		return nil, fmt.Errorf("credentials '%s' cannot be loaded without a dolt environment", keyId)
+	}
+
+	credsDir, err := p.dEnv.CredsDir()
+	if err != nil {
+		return nil, err
+	}
+	path, err := p.dEnv.FindCreds(credsDir, keyId)
+	if err != nil {
+		return nil, fmt.Errorf("error loading credentials '%s': %w", keyId, err)
+	}
+	dCreds, err := creds.JWKCredsReadFromFile(p.dEnv.FS, path)
+	if err != nil || !dCreds.IsPrivKeyValid() || !dCreds.IsPubKeyValid() {
+		return nil, ErrInvalidCredsFile
+	}
+
+	if OverrideGRPCJWTAudience != "" {
+		return dCreds.RPCCreds(OverrideGRPCJWTAudience), nil
+	}
+	return dCreds.RPCCreds(getHostFromEndpoint(endpoint)), nil
+}
+
 func getHostFromEndpoint(endpoint string) string {
 	host, _, err := net.SplitHostPort(endpoint)
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	goerrors "gopkg.in/src-d/go-errors.v1"
 
@@ -34,6 +35,10 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	filesys2 "github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -142,6 +147,30 @@ func (r Remote) WithParams(params map[string]string) Remote {
 	return r
 }
 
+// WithMaxBandwidth returns a copy of |r| whose table file transfers are limited to |maxBandwidth| bytes per second,
+// such as "10MB". Only remotesapi remotes support the limit, and it is not persisted with the remote.
+func (r Remote) WithMaxBandwidth(maxBandwidth string) (Remote, error) {
+	if _, err := iohelp.ParseBandwidth(maxBandwidth); err != nil {
+		return r, err
+	}
+	return r.WithParams(map[string]string{
+		dbfactory.GRPCMaxBandwidthParam: maxBandwidth,
+	}), nil
+}
+
+// WithoutTransientParams returns a copy of |r| without the params which only apply to a single operation, such as the
+// limit set by WithMaxBandwidth.
+func (r Remote) WithoutTransientParams() Remote {
+	params := make(map[string]string, len(r.Params))
+	for k, v := range r.Params {
+		if k != dbfactory.GRPCMaxBandwidthParam {
+			params[k] = v
+		}
+	}
+	r.Params = params
+	return r
+}
+
 // PushOptions contains information needed for push for
 // one or more branches or a tag for a specific remote database.
 type PushOptions[C doltdb.Context] struct {
@@ -659,6 +688,15 @@ func GetAbsRemoteUrl(fs filesys2.Filesys, cfg config.ReadableConfig, urlArg stri
 			return u.Scheme, absUrl, err
 		}
 
+		if u.Scheme == dbfactory.BundleScheme {
+			// bundles are files, which must already exist
+			absPath, err := fs.Abs(filepath.Clean(u.Host + u.Path))
+			if err != nil {
+				return "", "", err
+			}
+			return u.Scheme, u.Scheme + "://" + filepath.ToSlash(absPath), nil
+		}
+
 		return u.Scheme, urlArg, nil
 	} else if u.Host != "" {
 		return dbfactory.HTTPSScheme, "https://" + urlArg, nil
@@ -754,3 +792,60 @@ func SetRemoteUpstreamForRefSpec(rsw RepoStateWriter, refSpec ref.RefSpec, remot
 		Remote: remote,
 	})
 }
+
+// repairChunks fetches good copies of the chunks |hashes| from the remotes and then the backups configured for this
+// environment, in name order, stopping once every chunk has been found. It is used to repair chunks which are found
+// to be corrupt in the local database.
+func (dEnv *DoltEnv) repairChunks(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+	if dEnv.RepoState == nil {
+		return nil, nil
+	}
+
+	var sources []Remote
+	for _, rems := range []*concurrentmap.Map[string, Remote]{dEnv.RepoState.Remotes, dEnv.RepoState.Backups} {
+		if rems == nil {
+			continue
+		}
+		snapshot := rems.Snapshot()
+		names := make([]string, 0, len(snapshot))
+		for name := range snapshot {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sources = append(sources, snapshot[name])
+		}
+	}
+
+	remaining := hashes.Copy()
+	var found []chunks.Chunk
+	var errs []error
+	for _, r := range sources {
+		if remaining.Size() == 0 {
+			break
+		}
+		err := func() error {
+			rdb, err := r.GetRemoteDBWithoutCaching(ctx, types.Format_Default, dEnv)
+			if err != nil {
+				return err
+			}
+			defer rdb.Close()
+			cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(rdb))
+			var mu sync.Mutex
+			return cs.GetMany(ctx, remaining.Copy(), func(_ context.Context, c *chunks.Chunk) {
+				mu.Lock()
+				defer mu.Unlock()
+				found = append(found, *c)
+				remaining.Remove(c.Hash())
+			})
+		}()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Name, err))
+		}
+	}
+
+	if remaining.Size() > 0 && len(errs) > 0 {
+		return found, errors.Join(errs...)
+	}
+	return found, nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const worktreesFile = "worktrees.json"
+
+var ErrWorktreeBranchCheckedOut = errors.New("branch is already checked out")
+var ErrWorktreeNotFound = errors.New("worktree not found")
+
+// Worktree is a linked working directory which shares the chunk store of the repository it was created from, but has
+// its own repo state and therefore its own checked out branch and working set.
+type Worktree struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch"`
+}
+
+// sharedDataDir returns the absolute, symlink-resolved path of the noms directory backing this environment. For a
+// linked worktree this is the data directory of the repository the worktree was created from.
+func (dEnv *DoltEnv) sharedDataDir() (string, error) {
+	linked, ok, err := dbfactory.LinkedDataDir(dEnv.FS)
+	if err != nil {
+		return "", err
+	} else if ok {
+		return linked, nil
+	}
+
+	if sfs, ok := dEnv.FS.(filesys.SymlinkFS); ok {
+		return sfs.EvalSymlinks(dbfactory.DoltDataDir)
+	}
+	return dEnv.FS.Abs(dbfactory.DoltDataDir)
+}
+
+// mainRepoDir returns the absolute path of the repository which owns the chunk store backing this environment.
+func (dEnv *DoltEnv) mainRepoDir() (string, error) {
+	dataDir, err := dEnv.sharedDataDir()
+	if err != nil {
+		return "", err
+	}
+	// data dir is <repo>/.dolt/noms
+	return filepath.Dir(filepath.Dir(dataDir)), nil
+}
+
+// Worktrees returns the linked worktrees registered with the repository backing this environment, with the branch
+// each one currently has checked out.
+func (dEnv *DoltEnv) Worktrees() ([]Worktree, error) {
+	mainDir, err := dEnv.mainRepoDir()
+	if err != nil {
+		return nil, err
+	}
+	worktrees, err := loadWorktrees(dEnv.FS, mainDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, wt := range worktrees {
+		fs, err := dEnv.FS.WithWorkingDir(wt.Path)
+		if err != nil {
+			return nil, err
+		}
+		if rs, err := LoadRepoState(fs); err == nil {
+			worktrees[i].Branch = rs.CWBHeadRef().GetPath()
+		}
+	}
+	return worktrees, nil
+}
+
+// AddWorktree creates a new linked worktree at |dir| with |branch| checked out. The new worktree shares this
+// repository's chunk store, so commits made in either directory are immediately visible to the other, while each
+// directory keeps its own working set. A branch can only be checked out in one worktree at a time.
+func (dEnv *DoltEnv) AddWorktree(ctx context.Context, dir, branch string) error {
+	_, exists, err := dEnv.DoltDB(ctx).HasBranch(ctx, branch)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("branch '%s' not found", branch)
+	}
+
+	mainDir, err := dEnv.mainRepoDir()
+	if err != nil {
+		return err
+	}
+	worktrees, err := loadWorktrees(dEnv.FS, mainDir)
+	if err != nil {
+		return err
+	}
+
+	checkedOut, err := checkedOutBranches(dEnv.FS, mainDir, worktrees)
+	if err != nil {
+		return err
+	}
+	if path, ok := checkedOut[branch]; ok {
+		return fmt.Errorf("%w: '%s' is checked out at '%s'", ErrWorktreeBranchCheckedOut, branch, path)
+	}
+
+	absDir, err := dEnv.FS.Abs(dir)
+	if err != nil {
+		return err
+	}
+	if exists, _ := dEnv.FS.Exists(filepath.Join(absDir, dbfactory.DoltDir)); exists {
+		return fmt.Errorf(".dolt directory already exists at '%s'", absDir)
+	}
+
+	dataDir, err := dEnv.sharedDataDir()
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.FS.MkDirs(filepath.Join(absDir, dbfactory.DoltDir))
+	if err != nil {
+		return err
+	}
+
+	wtFs, err := dEnv.FS.WithWorkingDir(absDir)
+	if err != nil {
+		return err
+	}
+
+	err = linkDataDir(wtFs, dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to link data directory for worktree: %w", err)
+	}
+
+	rs := RepoState{
+		Head:     ref.MarshalableRef{Ref: ref.NewBranchRef(branch)},
+		Remotes:  dEnv.RepoState.Remotes.DeepCopy(),
+		Backups:  dEnv.RepoState.Backups.DeepCopy(),
+		Branches: dEnv.RepoState.Branches.DeepCopy(),
+	}
+	err = rs.Save(wtFs)
+	if err != nil {
+		return err
+	}
+
+	if dEnv.HasLocalConfig() {
+		data, err := dEnv.FS.ReadFile(getLocalConfigPath())
+		if err != nil {
+			return err
+		}
+		err = wtFs.WriteFile(getLocalConfigPath(), data, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
+	worktrees = append(worktrees, Worktree{Path: absDir, Branch: branch})
+	return saveWorktrees(dEnv.FS, mainDir, worktrees)
+}
+
+// linkDataDir points the worktree rooted at |wtFs| at the shared |dataDir|, with a symlink where the filesystem
+// supports them and a DoltDataDirLink pointer file otherwise.
+func linkDataDir(wtFs filesys.Filesys, dataDir string) error {
+	if sfs, ok := wtFs.(filesys.SymlinkFS); ok {
+		if err := sfs.Symlink(dataDir, dbfactory.DoltDataDir); err == nil {
+			return nil
+		}
+	}
+	return wtFs.WriteFile(dbfactory.DoltDataDirLink, []byte(dataDir), os.ModePerm)
+}
+
+// RemoveWorktree unregisters the linked worktree at |dir| and deletes its .dolt directory. Uncommitted changes in the
+// worktree's working set are left in the shared database and can be recovered by checking out its branch elsewhere.
+func (dEnv *DoltEnv) RemoveWorktree(dir string) error {
+	mainDir, err := dEnv.mainRepoDir()
+	if err != nil {
+		return err
+	}
+	worktrees, err := loadWorktrees(dEnv.FS, mainDir)
+	if err != nil {
+		return err
+	}
+
+	absDir, err := dEnv.FS.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	for i, wt := range worktrees {
+		if wt.Path != absDir {
+			continue
+		}
+
+		// remove the data dir link first so deleting the .dolt dir can never follow it into the shared store
+		for _, link := range []string{dbfactory.DoltDataDir, dbfactory.DoltDataDirLink} {
+			err = dEnv.FS.Delete(filepath.Join(absDir, link), false)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		err = dEnv.FS.Delete(filepath.Join(absDir, dbfactory.DoltDir), true)
+		if err != nil {
+			return err
+		}
+
+		worktrees = append(worktrees[:i], worktrees[i+1:]...)
+		return saveWorktrees(dEnv.FS, mainDir, worktrees)
+	}
+
+	return fmt.Errorf("%w: '%s'", ErrWorktreeNotFound, absDir)
+}
+
+// WorktreeForBranch returns the directory of the main repository or linked worktree, other than this environment's
+// own, which has |branch| checked out, and false if no other directory holds it.
+func (dEnv *DoltEnv) WorktreeForBranch(branch string) (string, bool, error) {
+	mainDir, err := dEnv.mainRepoDir()
+	if err != nil {
+		return "", false, err
+	}
+	worktrees, err := loadWorktrees(dEnv.FS, mainDir)
+	if err != nil {
+		return "", false, err
+	} else if len(worktrees) == 0 {
+		return "", false, nil
+	}
+
+	checkedOut, err := checkedOutBranches(dEnv.FS, mainDir, worktrees)
+	if err != nil {
+		return "", false, err
+	}
+	// worktree paths are registered unresolved, while the main repository's is resolved from its data dir
+	ownDir, err := dEnv.FS.Abs("")
+	if err != nil {
+		return "", false, err
+	}
+	isWorktree := false
+	for _, wt := range worktrees {
+		isWorktree = isWorktree || wt.Path == ownDir
+	}
+	if !isWorktree {
+		ownDir = mainDir
+	}
+
+	dir, ok := checkedOut[branch]
+	if !ok || dir == ownDir {
+		return "", false, nil
+	}
+	return dir, true, nil
+}
+
+// checkedOutBranches returns a map from branch name to the directory it is checked out in, for the main repository
+// and each of its registered worktrees.
+func checkedOutBranches(fs filesys.Filesys, mainDir string, worktrees []Worktree) (map[string]string, error) {
+	checkedOut := make(map[string]string)
+
+	dirs := []string{mainDir}
+	for _, wt := range worktrees {
+		dirs = append(dirs, wt.Path)
+	}
+
+	for _, dir := range dirs {
+		dirFs, err := fs.WithWorkingDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		rs, err := LoadRepoState(dirFs)
+		if err != nil {
+			// a worktree directory deleted out from under us doesn't hold a branch
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		checkedOut[rs.CWBHeadRef().GetPath()] = dir
+	}
+
+	return checkedOut, nil
+}
+
+func loadWorktrees(fs filesys.ReadableFS, mainDir string) ([]Worktree, error) {
+	data, err := fs.ReadFile(filepath.Join(mainDir, dbfactory.DoltDir, worktreesFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var worktrees []Worktree
+	err = json.Unmarshal(data, &worktrees)
+	if err != nil {
+		return nil, err
+	}
+	return worktrees, nil
+}
+
+func saveWorktrees(fs filesys.WritableFS, mainDir string, worktrees []Worktree) error {
+	data, err := json.MarshalIndent(worktrees, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(filepath.Join(mainDir, dbfactory.DoltDir, worktreesFile), data, os.ModePerm)
+}
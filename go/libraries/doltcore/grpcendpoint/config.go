@@ -33,6 +33,11 @@ type Config struct {
 	// comes from the OS environment variable DOLT_REMOTE_PASSWORD.
 	UserIdForOsEnvAuth string
 
+	// If this is non-empty, and WithEnvCreds is true, then the stored
+	// dolt credentials with this key id are used for JWT authentication,
+	// instead of the user's default credentials.
+	CredsKeyId string
+
 	// If non-nil, this is used for transport level security in the dial
 	// options, instead of a default option based on `Insecure`.
 	TLSConfig *tls.Config
@@ -17,6 +17,7 @@ package merge
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -29,6 +30,32 @@ import (
 
 var ErrFailedToDetermineMergeability = errors.New("failed to determine mergeability")
 
+// MergeStrategy selects how a three-way merge settles the rows changed differently on both sides of the merge.
+type MergeStrategy string
+
+const (
+	// StrategyResolveCellWise merges the changes to each cell of a row, and records a conflict for any row with a cell
+	// changed differently on both sides of the merge. This is the default strategy.
+	StrategyResolveCellWise MergeStrategy = "resolve-cell-wise"
+	// StrategyOurs merges the changes to each cell of a row like StrategyResolveCellWise, but resolves any conflicting
+	// row with its version on the branch being merged into.
+	StrategyOurs MergeStrategy = "ours"
+	// StrategyTheirs merges the changes to each cell of a row like StrategyResolveCellWise, but resolves any
+	// conflicting row with its version on the branch being merged.
+	StrategyTheirs MergeStrategy = "theirs"
+)
+
+// ParseMergeStrategy returns the MergeStrategy named |s|.
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	switch strategy := MergeStrategy(strings.ToLower(s)); strategy {
+	case StrategyResolveCellWise, StrategyOurs, StrategyTheirs:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("unknown merge strategy '%s'; valid strategies are '%s', '%s' and '%s'",
+			s, StrategyResolveCellWise, StrategyOurs, StrategyTheirs)
+	}
+}
+
 type MergeSpec struct {
 	HeadH           hash.Hash
 	MergeH          hash.Hash
@@ -42,6 +69,7 @@ type MergeSpec struct {
 	NoCommit        bool
 	NoEdit          bool
 	Force           bool
+	Strategy        MergeStrategy
 	Email           string
 	Name            string
 	Date            time.Time
@@ -79,6 +107,12 @@ func WithSquash(squash bool) MergeSpecOpt {
 	}
 }
 
+func WithStrategy(strategy MergeStrategy) MergeSpecOpt {
+	return func(ms *MergeSpec) {
+		ms.Strategy = strategy
+	}
+}
+
 // NewMergeSpec returns a MergeSpec with the arguments provided.
 func NewMergeSpec[C doltdb.Context](
 	ctx C,
@@ -147,6 +181,7 @@ func NewMergeSpec[C doltdb.Context](
 		MergeC:          mergeCM,
 		StompedTblNames: stompedTblNames,
 		WorkingDiffs:    workingDiffs,
+		Strategy:        StrategyResolveCellWise,
 		Email:           email,
 		Name:            name,
 		Date:            date,
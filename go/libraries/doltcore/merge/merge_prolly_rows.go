@@ -79,6 +79,10 @@ func mergeProllyTable(
 		return nil, nil, err
 	}
 	valueMerger := newValueMerger(mergedSch, tm.leftSch, tm.rightSch, tm.ancSch, leftRows.Pool(), tm.ns)
+	valueMerger.dontMergeCells, err = dontMergeCells(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	if !valueMerger.leftMapping.IsIdentityMapping() {
 		mergeInfo.LeftNeedsRewrite = true
@@ -107,6 +111,19 @@ func mergeProllyTable(
 	return mergeTbl, stats, nil
 }
 
+// dontMergeCells returns whether the dolt_dont_merge_cells session variable is set, in which case a row changed
+// differently on both sides of a merge is a conflict, even if the changes are to different columns.
+func dontMergeCells(ctx *sql.Context) (bool, error) {
+	v, err := ctx.Session.GetSessionVariable(ctx, "dolt_dont_merge_cells")
+	if err != nil {
+		if sql.ErrUnknownSystemVariable.Is(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return sql.ConvertToBool(ctx, v)
+}
+
 func computeProllyTreePatches(
 	ctx *sql.Context,
 	tm *TableMerger,
@@ -1678,6 +1695,7 @@ type valueMerger struct {
 	baseToResultMapping                    val.OrdinalMapping
 	syncPool                               pool.BuffPool
 	keyless                                bool
+	dontMergeCells                         bool
 	ns                                     tree.NodeStore
 }
 
@@ -1781,6 +1799,15 @@ func (m *valueMerger) tryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 		return nil, true, nil
 	}
 
+	if m.dontMergeCells {
+		// Without cell-wise merges, a row changed on both sides is a conflict, unless both sides changed it to the same
+		// values. A side whose bytes changed only because of a schema change hasn't changed the row.
+		bothChanged, ok := m.bothSidesChanged(ctx, left, right, base)
+		if !ok || bothChanged {
+			return nil, false, nil
+		}
+	}
+
 	mergedValues := make([][]byte, m.numCols)
 	for i := 0; i < m.numCols; i++ {
 		v, isConflict, err := m.processColumn(ctx, i, left, right, base)
@@ -1796,6 +1823,63 @@ func (m *valueMerger) tryMerge(ctx *sql.Context, left, right, base val.Tuple) (v
 	return val.NewTuple(m.syncPool, mergedValues...), true, nil
 }
 
+// bothSidesChanged returns whether the values of |left| and |right| differ from each other and from the values of
+// |base|, comparing them as the types of the merged schema. |ok| is false if a value can't be converted to its type in
+// the merged schema.
+func (m *valueMerger) bothSidesChanged(ctx *sql.Context, left, right, base val.Tuple) (changed, ok bool) {
+	leftValues, ok := m.mergedTypeValues(ctx, left, m.leftVD, m.leftMapping)
+	if !ok {
+		return false, false
+	}
+	rightValues, ok := m.mergedTypeValues(ctx, right, m.rightVD, m.rightMapping)
+	if !ok {
+		return false, false
+	}
+	if m.equalValues(ctx, leftValues, rightValues) {
+		return false, true
+	}
+	if base == nil {
+		return true, true
+	}
+	baseValues, ok := m.mergedTypeValues(ctx, base, m.baseVD, m.baseMapping)
+	if !ok {
+		return false, false
+	}
+	return !m.equalValues(ctx, leftValues, baseValues) && !m.equalValues(ctx, rightValues, baseValues), true
+}
+
+// mergedTypeValues returns the values of |tuple| for each column of the merged schema, converted to their types in
+// the merged schema. Columns missing from |tuple| are NULL.
+func (m *valueMerger) mergedTypeValues(ctx *sql.Context, tuple val.Tuple, vd val.TupleDesc, mapping val.OrdinalMapping) ([][]byte, bool) {
+	values := make([][]byte, m.numCols)
+	for i := 0; i < m.numCols; i++ {
+		col, colIdx, exists := getColumn(&tuple, &mapping, i)
+		if !exists || col == nil {
+			continue
+		}
+		converted, err := convert(ctx, vd, m.resultVD, m.resultSchema, colIdx, i, tuple, col, m.ns)
+		if err != nil {
+			return nil, false
+		}
+		values[i] = converted
+	}
+	return values, true
+}
+
+// equalValues returns whether |left| and |right|, values of the merged schema, are equal. Generated columns are
+// ignored, since they're computed again after the merge.
+func (m *valueMerger) equalValues(ctx *sql.Context, left, right [][]byte) bool {
+	for i := 0; i < m.numCols; i++ {
+		if m.resultSchema.GetNonPKCols().GetByIndex(i).Generated != "" {
+			continue
+		}
+		if !isEqual(ctx, m.resultVD.Comparator(), i, left[i], right[i], m.resultVD.Types[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // processBaseColumn returns whether column |i| of the base schema,
 // if removed on one side, causes a conflict when merged with the other side.
 func (m *valueMerger) processBaseColumn(ctx context.Context, i int, left, right, base val.Tuple) (conflict bool, err error) {
@@ -1991,9 +2075,11 @@ func (m *valueMerger) processColumn(ctx *sql.Context, i int, left, right, base v
 		rightModified = !isEqual(ctx, m.resultVD.Comparator(), i, rightCol, baseCol, resultType)
 	}
 
-	leftCol, err = convert(ctx, m.leftVD, m.resultVD, m.resultSchema, leftColIdx, i, left, leftCol, m.ns)
-	if err != nil {
-		return nil, true, nil
+	if leftColIdx != -1 {
+		leftCol, err = convert(ctx, m.leftVD, m.resultVD, m.resultSchema, leftColIdx, i, left, leftCol, m.ns)
+		if err != nil {
+			return nil, true, nil
+		}
 	}
 	if isEqual(ctx, m.resultVD.Comparator(), i, leftCol, rightCol, resultType) {
 		// Columns are equal, returning either would be correct.
@@ -2223,3 +2309,109 @@ func convert(ctx context.Context, fromDesc, toDesc val.TupleDesc, toSchema schem
 	typ.Nullable = true
 	return tree.Serialize(ctx, ns, typ, convertedCell)
 }
+
+// ValidateRowConstraints re-evaluates the enforced check constraints and the unique indexes of |sch| for the rows of
+// table |tableName| with the keys |srcHashes|, such as rows which were restored from one side of a merge when
+// resolving its constraint violations. |rows| and |indexes| are the table's current row data and indexes. Each row
+// which violates a constraint is recorded in |edits| as a violation from the rootish mapped to its key, along with any
+// row it collides with in a unique index. Keys which aren't in |rows| are skipped.
+func ValidateRowConstraints(ctx *sql.Context, tableName string, sch schema.Schema, rows prolly.Map, indexes durable.IndexSet, srcHashes map[string]hash.Hash, edits *prolly.ArtifactsEditor) error {
+	checks := make(map[string]sql.Expression)
+	for _, check := range sch.Checks().AllChecks() {
+		if !check.Enforced() {
+			continue
+		}
+		expr, err := expranalysis.ResolveCheckExpression(ctx, tableName, sch, check.Expression())
+		if err != nil {
+			return err
+		}
+		checks[check.Name()] = expr
+	}
+
+	var uniqs []uniqIndex
+	for _, def := range sch.Indexes().AllIndexes() {
+		if !def.IsUnique() {
+			continue
+		}
+		idx, err := indexes.GetIndex(ctx, sch, nil, def.Name())
+		if err != nil {
+			return err
+		}
+		secondary, err := durable.ProllyMapFromIndex(idx)
+		if err != nil {
+			return err
+		}
+		u, err := newUniqIndex(ctx, sch, tableName, def, rows, secondary)
+		if err != nil {
+			return err
+		}
+		uniqs = append(uniqs, u)
+	}
+
+	kd := rows.KeyDesc()
+	for k, srcHash := range srcHashes {
+		key := val.Tuple(k)
+		var value val.Tuple
+		err := rows.Get(ctx, key, func(_, v val.Tuple) error {
+			value = v
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			continue
+		}
+
+		if len(checks) > 0 {
+			row, err := index.BuildRow(ctx, key, value, sch, rows.NodeStore())
+			if err != nil {
+				return err
+			}
+			for checkName, checkExpression := range checks {
+				result, err := checkExpression.Eval(ctx, row)
+				if err != nil {
+					return err
+				}
+				// MySQL treats NULL as TRUE for a check constraint
+				if result == nil {
+					continue
+				}
+				ok, err := sql.ConvertToBool(ctx, result)
+				if err != nil {
+					return fmt.Errorf("unable to convert check constraint expression (%s) into boolean value: %v", checkName, err.Error())
+				}
+				if ok {
+					continue
+				}
+				meta, err := newCheckCVMeta(sch, checkName)
+				if err != nil {
+					return err
+				}
+				vinfo, err := json.Marshal(meta)
+				if err != nil {
+					return err
+				}
+				cvm := prolly.ConstraintViolationMeta{VInfo: vinfo, Value: value}
+				if err = edits.ReplaceConstraintViolation(ctx, key, srcHash, prolly.ArtifactTypeChkConsViol, cvm); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, u := range uniqs {
+			err = u.findCollisions(ctx, key, value, func(k, v val.Tuple) error {
+				vinfo, err := json.Marshal(u.meta)
+				if err != nil {
+					return err
+				}
+				cvm := prolly.ConstraintViolationMeta{VInfo: vinfo, Value: v}
+				return edits.ReplaceConstraintViolation(ctx, k, srcHash, prolly.ArtifactTypeUniqueKeyViol, cvm)
+			})
+			if err != nil {
+				return fmt.Errorf("unable to check unique key %s of row %s: %w", u.def.Name(), kd.Format(ctx, key), err)
+			}
+		}
+	}
+	return nil
+}
@@ -337,8 +337,8 @@ func (rm *RootMerger) makeTableMerger(ctx context.Context, tblName doltdb.TableN
 
 func (rm *RootMerger) maybeShortCircuit(ctx context.Context, tm *TableMerger, opts MergeOpts) (*doltdb.Table, doltdb.RootObject, *MergeStats, error) {
 	// If we need to re-verify all constraints as part of this merge, then we can't short
-	// circuit considering any tables, so return immediately
-	if opts.ReverifyAllConstraints {
+	// circuit considering any tables whose violations are being recorded, so return immediately
+	if opts.ReverifyAllConstraints && tm.recordViolations {
 		return nil, nil, nil, nil
 	}
 
@@ -222,6 +222,38 @@ func TestRowMerge(t *testing.T) {
 	}
 }
 
+func TestRowMergeWithoutCellMerge(t *testing.T) {
+	if types.Format_Default != types.Format_DOLT {
+		t.Skip()
+	}
+
+	ctx := sql.NewEmptyContext()
+
+	// rows changed differently on both sides conflict, even when they could be merged cell-wise
+	cases := append([]testCase{}, testCases...)
+	for i := range cases {
+		if cases[i].expectCellMerge {
+			cases[i].expectConflict = true
+			cases[i].expectedResult = nil
+		}
+	}
+	cases = append(cases, convergentEditCases[0], convergentEditCases[2])
+
+	for _, c := range cases {
+		test := createRowMergeStruct(c)
+		t.Run(test.name, func(t *testing.T) {
+			v := newValueMerger(test.mergedSch, test.leftSch, test.rightSch, test.baseSch, syncPool, nil)
+			v.dontMergeCells = true
+
+			merged, ok, err := v.tryMerge(ctx, test.row, test.mergeRow, test.ancRow)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectConflict, !ok)
+			vD := test.mergedSch.GetValueDescriptor(v.ns)
+			assert.Equal(t, vD.Format(ctx, test.expectedResult), vD.Format(ctx, merged))
+		})
+	}
+}
+
 func TestNomsRowMerge(t *testing.T) {
 	if types.Format_Default == types.Format_DOLT {
 		t.Skip()
@@ -28,8 +28,11 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/rowexec"
 	"github.com/dolthub/go-mysql-server/sql/transform"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/typed/noms"
 	"github.com/dolthub/dolt/go/store/types"
 )
@@ -57,6 +60,10 @@ type SqlEngineTableWriter struct {
 	importOption       TableImportOp
 	tableSchema        sql.PrimaryKeySchema
 	rowOperationSchema sql.PrimaryKeySchema
+
+	// deferredIndexes are the secondary indexes removed from the table while its rows are imported. They are built
+	// in parallel from the imported rows when the import is committed.
+	deferredIndexes []schema.Index
 }
 
 func NewSqlEngineTableWriter(ctx *sql.Context, engine *sqle.Engine, createTableSchema, rowOperationSchema schema.Schema, options *MoverOptions, statsCB noms.StatsCB) (*SqlEngineTableWriter, error) {
@@ -116,6 +123,14 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 		return err
 	}
 
+	// Tables created by the import have no secondary indexes, so there's nothing to defer for them
+	if s.importOption != CreateOp {
+		err = s.deferIndexBuildsIfEmpty()
+		if err != nil {
+			return err
+		}
+	}
+
 	updateStats := func(row sql.Row) {
 		if row == nil {
 			return
@@ -212,6 +227,11 @@ func (s *SqlEngineTableWriter) WriteRows(ctx context.Context, inputChannel chan
 }
 
 func (s *SqlEngineTableWriter) Commit(ctx context.Context) error {
+	err := s.buildDeferredIndexes()
+	if err != nil {
+		return err
+	}
+
 	_, iter, _, err := s.se.Query(s.sqlCtx, "COMMIT")
 	if err != nil {
 		return err
@@ -282,6 +302,122 @@ func (s *SqlEngineTableWriter) forceDropTableIfNeeded() error {
 	return nil
 }
 
+// deferIndexBuildsIfEmpty removes the secondary indexes of the table being imported into from its schema if the table
+// is empty, so that imported rows are only written to the primary index. Building every index from the sorted primary
+// row data once all rows are loaded is much cheaper than maintaining each of them row by row. Indexes that participate
+// in foreign keys, and Full-Text and vector indexes, are always maintained during the import. Unique indexes are too
+// when the import continues past bad rows, since a duplicate key must be reported for the row that caused it.
+func (s *SqlEngineTableWriter) deferIndexBuildsIfEmpty() error {
+	sess := dsess.DSessFromSess(s.sqlCtx.Session)
+	roots, ok := sess.GetRoots(s.sqlCtx, s.database)
+	if !ok {
+		return fmt.Errorf("no root value found in session for database %s", s.database)
+	}
+
+	tbl, tableName, ok, err := doltdb.GetTableInsensitive(s.sqlCtx, roots.Working, doltdb.TableName{Name: s.tableName})
+	if err != nil || !ok {
+		return err
+	}
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		return nil
+	}
+
+	rows, err := tbl.GetRowData(s.sqlCtx)
+	if err != nil {
+		return err
+	}
+	if empty, err := rows.Empty(); err != nil || !empty {
+		return err
+	}
+
+	fkc, err := roots.Working.GetForeignKeyCollection(s.sqlCtx)
+	if err != nil {
+		return err
+	}
+	declaredFks, referencedByFks := fkc.KeysForTable(doltdb.TableName{Name: tableName})
+	if len(declaredFks) > 0 || len(referencedByFks) > 0 {
+		return nil
+	}
+
+	sch, err := tbl.GetSchema(s.sqlCtx)
+	if err != nil {
+		return err
+	}
+
+	var deferred []schema.Index
+	newSch := sch.Copy()
+	for _, idx := range sch.Indexes().AllIndexes() {
+		if idx.IsFullText() || idx.IsVector() || (idx.IsUnique() && s.contOnErr) {
+			continue
+		}
+		if _, err = newSch.Indexes().RemoveIndex(idx.Name()); err != nil {
+			return err
+		}
+		if tbl, err = tbl.DeleteIndexRowData(s.sqlCtx, idx.Name()); err != nil {
+			return err
+		}
+		deferred = append(deferred, idx)
+	}
+	if len(deferred) == 0 {
+		return nil
+	}
+
+	tbl, err = tbl.UpdateSchema(s.sqlCtx, newSch)
+	if err != nil {
+		return err
+	}
+	newRoot, err := roots.Working.PutTable(s.sqlCtx, doltdb.TableName{Name: tableName}, tbl)
+	if err != nil {
+		return err
+	}
+	err = sess.SetWorkingRoot(s.sqlCtx, s.database, newRoot)
+	if err != nil {
+		return err
+	}
+
+	s.tableName = tableName
+	s.deferredIndexes = deferred
+	return nil
+}
+
+// buildDeferredIndexes builds the indexes removed by deferIndexBuildsIfEmpty from the imported rows and adds them back
+// to the table. The indexes are built concurrently.
+func (s *SqlEngineTableWriter) buildDeferredIndexes() error {
+	if len(s.deferredIndexes) == 0 {
+		return nil
+	}
+
+	sess := dsess.DSessFromSess(s.sqlCtx.Session)
+	dbState, ok, err := sess.LookupDbState(s.sqlCtx, s.database)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no session state found for database %s", s.database)
+	}
+
+	root := dbState.WorkingRoot()
+	tbl, ok, err := root.GetTable(s.sqlCtx, doltdb.TableName{Name: s.tableName})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return doltdb.ErrTableNotFound
+	}
+
+	tbl, err = creation.AddSecondaryIndexes(s.sqlCtx, tbl, s.tableName, s.deferredIndexes, dbState.EditOpts())
+	if err != nil {
+		return err
+	}
+	root, err = root.PutTable(s.sqlCtx, doltdb.TableName{Name: s.tableName}, tbl)
+	if err != nil {
+		return err
+	}
+
+	s.deferredIndexes = nil
+	return sess.SetWorkingRoot(s.sqlCtx, s.database, root)
+}
+
 // createOrEmptyTableIfNeeded either creates or truncates the table given a -c or -r parameter.
 func (s *SqlEngineTableWriter) createOrEmptyTableIfNeeded() error {
 	switch s.importOption {
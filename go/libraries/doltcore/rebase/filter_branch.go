@@ -17,10 +17,14 @@ package rebase
 import (
 	"context"
 	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -66,13 +70,27 @@ type RootReplayer interface {
 	ReplayRoot(ctx context.Context, root, parentRoot, rebasedParentRoot doltdb.RootValue) (rebaseRoot doltdb.RootValue, err error)
 }
 
-// CommitReplayer is something that takes a commit and rebases it with changes.
+// CommitReplayer is something that takes a commit and rebases it with changes. The root it returns must depend only
+// on |commit|, since commits are replayed concurrently and before their parents are rewritten.
 type CommitReplayer interface {
-	ReplayCommit(ctx context.Context, commit, parent, rebasedParent *doltdb.Commit) (rebaseRoot doltdb.RootValue, err error)
+	ReplayCommit(ctx context.Context, commit *doltdb.Commit) (rebaseRoot doltdb.RootValue, err error)
+}
+
+// RewriteOptions configures how history is rewritten.
+type RewriteOptions struct {
+	// Parallelism is the number of commits replayed at once. Values less than one replay a single commit at a time.
+	Parallelism int
+	// Tables, if set, restricts the rewrite to these tables. Changes the replayer makes to other tables are
+	// discarded, and commits which don't change any of these tables aren't replayed at all; their tables are copied
+	// from their rewritten first parent.
+	Tables []doltdb.TableName
+	// Progress, if set, is called after each commit is replayed with the number of commits replayed so far and the
+	// number of commits to replay. It is never called concurrently.
+	Progress func(replayed, total int)
 }
 
 // AllBranchesAndTags rewrites the history of all branches and tags in the repo using the |replay| function.
-func AllBranchesAndTags(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn) error {
+func AllBranchesAndTags(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, opts RewriteOptions) error {
 	branches, err := dEnv.DoltDB(ctx).GetBranches(ctx)
 	if err != nil {
 		return err
@@ -81,28 +99,28 @@ func AllBranchesAndTags(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted
 	if err != nil {
 		return err
 	}
-	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, append(branches, tags...)...)
+	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, opts, append(branches, tags...)...)
 }
 
 // AllBranches rewrites the history of all branches in the repo using the |replay| function.
-func AllBranches(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn) error {
+func AllBranches(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, opts RewriteOptions) error {
 	branches, err := dEnv.DoltDB(ctx).GetBranches(ctx)
 	if err != nil {
 		return err
 	}
-	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, branches...)
+	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, opts, branches...)
 }
 
 // CurrentBranch rewrites the history of the current branch using the |replay| function.
-func CurrentBranch(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn) error {
+func CurrentBranch(ctx context.Context, dEnv *env.DoltEnv, applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, opts RewriteOptions) error {
 	headRef, err := dEnv.RepoStateReader().CWBHeadRef(ctx)
 	if err != nil {
 		return nil
 	}
-	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, headRef)
+	return rebaseRefs(ctx, dEnv.DbData(ctx), applyUncommitted, commitReplayer, rootReplayer, nerf, opts, headRef)
 }
 
-func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, refs ...ref.DoltRef) error {
+func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUncommitted bool, commitReplayer CommitReplayer, rootReplayer RootReplayer, nerf NeedsRebaseFn, opts RewriteOptions, refs ...ref.DoltRef) error {
 	ddb := dbData.Ddb
 	heads := make([]*doltdb.Commit, len(refs))
 	for i, dRef := range refs {
@@ -148,7 +166,7 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 
 			if !hHash.Equal(wHash) {
 				var newWRoot doltdb.RootValue
-				newWRoot, err = rootReplayer.ReplayRoot(ctx, ws.WorkingRoot(), nil, nil)
+				newWRoot, err = replayRoot(ctx, rootReplayer, ws.WorkingRoot(), opts.Tables)
 				if err != nil {
 					return err
 				}
@@ -158,7 +176,7 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 			}
 			if !hHash.Equal(sHash) {
 				var newSRoot doltdb.RootValue
-				newSRoot, err = rootReplayer.ReplayRoot(ctx, ws.StagedRoot(), nil, nil)
+				newSRoot, err = replayRoot(ctx, rootReplayer, ws.StagedRoot(), opts.Tables)
 				if err != nil {
 					return err
 				}
@@ -172,7 +190,7 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 		}
 	}
 
-	newHeads, err := rebase(ctx, ddb, commitReplayer, nerf, heads...)
+	newHeads, err := rebase(ctx, ddb, commitReplayer, nerf, opts, heads...)
 	if err != nil {
 		return err
 	}
@@ -237,93 +255,328 @@ func rebaseRefs(ctx context.Context, dbData env.DbData[context.Context], applyUn
 	return nil
 }
 
-func rebase(ctx context.Context, ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn, origins ...*doltdb.Commit) ([]*doltdb.Commit, error) {
-	var rebasedCommits []*doltdb.Commit
-	vs := make(visitedSet)
-	for _, cm := range origins {
-		rc, err := rebaseRecursive(ctx, ddb, commitReplayer, nerf, vs, cm)
-
-		if err != nil {
-			return nil, err
-		}
-
-		rebasedCommits = append(rebasedCommits, rc)
+// replayRoot replays |root| with |rootReplayer|, keeping only the changes to |tables| if any are given.
+func replayRoot(ctx context.Context, rootReplayer RootReplayer, root doltdb.RootValue, tables []doltdb.TableName) (doltdb.RootValue, error) {
+	newRoot, err := rootReplayer.ReplayRoot(ctx, root, nil, nil)
+	if err != nil || len(tables) == 0 {
+		return newRoot, err
 	}
+	return copyTables(ctx, root, newRoot, tables)
+}
+
+// CommitRewriter rewrites commits with a CommitReplayer, writing the rewritten commits to |ddb| without referencing
+// them from any ref. It remembers the commits it has rewritten, so that rewriting a descendant of a commit it has
+// already rewritten only rewrites the commits since.
+type CommitRewriter struct {
+	hr *historyRewriter
+}
 
-	return rebasedCommits, nil
+// NewCommitRewriter returns a CommitRewriter which rewrites the commits of |ddb| with |commitReplayer|.
+func NewCommitRewriter(ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn) *CommitRewriter {
+	return &CommitRewriter{hr: newHistoryRewriter(ddb, commitReplayer, nerf, RewriteOptions{})}
 }
 
-func rebaseRecursive(ctx context.Context, ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn, vs visitedSet, commit *doltdb.Commit) (*doltdb.Commit, error) {
-	commitHash, err := commit.HashOf()
+// Rewrite returns the rewritten |commit|, rewriting any of its ancestors which haven't been rewritten yet.
+func (cr *CommitRewriter) Rewrite(ctx context.Context, commit *doltdb.Commit) (*doltdb.Commit, error) {
+	rewritten, err := cr.hr.rewrite(ctx, commit)
 	if err != nil {
 		return nil, err
 	}
-	visitedCommit, found := vs[commitHash]
-	if found {
-		// base case: reached previously rebased node
-		return visitedCommit, nil
-	}
+	return rewritten[0], nil
+}
 
-	needToRebase, err := nerf(ctx, commit)
+// Prune forgets every rewritten commit if any of them is no longer present in the database, which happens when they
+// are garbage collected, since no ref references them. They are rewritten again as needed.
+func (cr *CommitRewriter) Prune(ctx context.Context) error {
+	if len(cr.hr.vs) == 0 {
+		return nil
+	}
+	rewritten := make(hash.HashSet, len(cr.hr.vs))
+	for _, cm := range cr.hr.vs {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		rewritten.Insert(h)
+	}
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(cr.hr.ddb))
+	absent, err := cs.HasMany(ctx, rewritten)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	if !needToRebase {
-		// base case: reached bottom of DFS,
-		return commit, nil
+	if len(absent) == 0 {
+		return nil
 	}
+	cr.hr.vs = make(visitedSet)
+	return nil
+}
+
+func rebase(ctx context.Context, ddb *doltdb.DoltDB, commitReplayer CommitReplayer, nerf NeedsRebaseFn, opts RewriteOptions, origins ...*doltdb.Commit) ([]*doltdb.Commit, error) {
+	return newHistoryRewriter(ddb, commitReplayer, nerf, opts).rewrite(ctx, origins...)
+}
+
+// historyRewriter rewrites commits in three passes. The commits to rewrite are found first, then their roots are
+// replayed concurrently, since replaying a commit doesn't depend on how its parents were rewritten, and finally the
+// rewritten commits are written, parents first.
+type historyRewriter struct {
+	ddb      *doltdb.DoltDB
+	replayer CommitReplayer
+	nerf     NeedsRebaseFn
+	opts     RewriteOptions
+
+	// vs maps the commits which were visited to their rewritten commits, or to themselves for the commits which
+	// don't need rebasing.
+	vs visitedSet
+}
+
+// pendingCommit is a commit to be rewritten.
+type pendingCommit struct {
+	commit  *doltdb.Commit
+	hash    hash.Hash
+	parents []*doltdb.Commit
+	// next is the index of the next parent to visit when finding the commits to rewrite
+	next int
+
+	// root and rootHash are the replayed root of |commit|. |root| is nil if |commit| doesn't need replaying,
+	// because it didn't change any of the rewritten tables.
+	root     doltdb.RootValue
+	rootHash hash.Hash
+}
+
+func newHistoryRewriter(ddb *doltdb.DoltDB, replayer CommitReplayer, nerf NeedsRebaseFn, opts RewriteOptions) *historyRewriter {
+	return &historyRewriter{ddb: ddb, replayer: replayer, nerf: nerf, opts: opts, vs: make(visitedSet)}
+}
 
-	allOptParents, err := ddb.ResolveAllParents(ctx, commit)
+// rewrite returns the rewritten |heads|, rewriting each of their ancestors which haven't been rewritten yet.
+func (hr *historyRewriter) rewrite(ctx context.Context, heads ...*doltdb.Commit) ([]*doltdb.Commit, error) {
+	pending, err := hr.findPending(ctx, heads)
 	if err != nil {
 		return nil, err
 	}
-
-	if len(allOptParents) < 1 {
-		panic(fmt.Sprintf("commit: %s has no parents", commitHash.String()))
+	if err = hr.replay(ctx, pending); err != nil {
+		return nil, err
+	}
+	for _, pc := range pending {
+		if err = hr.writeCommit(ctx, pc); err != nil {
+			return nil, err
+		}
 	}
 
-	// convert allOptParents to allParents
-	var allParents []*doltdb.Commit
-	for _, optParent := range allOptParents {
-		parent, ok := optParent.ToCommit()
-		if !ok {
-			return nil, doltdb.ErrGhostCommitEncountered
+	rewritten := make([]*doltdb.Commit, len(heads))
+	for i, head := range heads {
+		h, err := head.HashOf()
+		if err != nil {
+			return nil, err
 		}
-		allParents = append(allParents, parent)
+		rewritten[i] = hr.vs[h]
 	}
+	return rewritten, nil
+}
+
+// findPending returns the ancestors of |heads| which need rewriting, parents before children.
+func (hr *historyRewriter) findPending(ctx context.Context, heads []*doltdb.Commit) ([]*pendingCommit, error) {
+	var pending []*pendingCommit
+	var stack []*pendingCommit
+	queued := make(hash.HashSet)
 
-	var allRebasedParents []*doltdb.Commit
-	for _, p := range allParents {
-		rp, err := rebaseRecursive(ctx, ddb, commitReplayer, nerf, vs, p)
+	// visit pushes |cm| onto |stack| if it needs rewriting, or records it as its own rewrite if it doesn't.
+	visit := func(cm *doltdb.Commit) error {
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		if _, ok := hr.vs[h]; ok || queued.Has(h) {
+			return nil
+		}
+		needToRebase, err := hr.nerf(ctx, cm)
+		if err != nil {
+			return err
+		}
+		if !needToRebase {
+			// base case: reached bottom of DFS
+			hr.vs[h] = cm
+			return nil
+		}
 
+		optParents, err := hr.ddb.ResolveAllParents(ctx, cm)
 		if err != nil {
+			return err
+		}
+		if len(optParents) < 1 {
+			return fmt.Errorf("commit: %s has no parents", h.String())
+		}
+		pc := &pendingCommit{commit: cm, hash: h}
+		for _, optParent := range optParents {
+			parent, ok := optParent.ToCommit()
+			if !ok {
+				return doltdb.ErrGhostCommitEncountered
+			}
+			pc.parents = append(pc.parents, parent)
+		}
+		queued.Insert(h)
+		stack = append(stack, pc)
+		return nil
+	}
+
+	for _, head := range heads {
+		if err := visit(head); err != nil {
 			return nil, err
 		}
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			if top.next < len(top.parents) {
+				top.next++
+				if err := visit(top.parents[top.next-1]); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			stack = stack[:len(stack)-1]
+			pending = append(pending, top)
+		}
+	}
+	return pending, nil
+}
 
-		allRebasedParents = append(allRebasedParents, rp)
+// replay replays the roots of |pending| concurrently.
+func (hr *historyRewriter) replay(ctx context.Context, pending []*pendingCommit) error {
+	var mu sync.Mutex
+	var replayed int
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(hr.opts.Parallelism, 1))
+	for _, pc := range pending {
+		eg.Go(func() error {
+			if err := hr.replayCommit(egCtx, pc); err != nil {
+				return err
+			}
+			if hr.opts.Progress != nil {
+				mu.Lock()
+				defer mu.Unlock()
+				replayed++
+				hr.opts.Progress(replayed, len(pending))
+			}
+			return nil
+		})
 	}
+	return eg.Wait()
+}
 
-	rebasedRoot, err := commitReplayer.ReplayCommit(ctx, commit, allParents[0], allRebasedParents[0])
+func (hr *historyRewriter) replayCommit(ctx context.Context, pc *pendingCommit) error {
+	root, err := pc.commit.GetRootValue(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if len(hr.opts.Tables) > 0 {
+		parentRoot, err := pc.parents[0].GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		changed, err := tablesChanged(ctx, root, parentRoot, hr.opts.Tables)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
 	}
 
-	r, valueHash, err := ddb.WriteRootValue(ctx, rebasedRoot)
+	rebasedRoot, err := hr.replayer.ReplayCommit(ctx, pc.commit)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if len(hr.opts.Tables) > 0 {
+		if rebasedRoot, err = copyTables(ctx, root, rebasedRoot, hr.opts.Tables); err != nil {
+			return err
+		}
 	}
-	rebasedRoot = r
+	pc.root, pc.rootHash, err = hr.ddb.WriteRootValue(ctx, rebasedRoot)
+	return err
+}
 
-	oldMeta, err := commit.GetCommitMeta(ctx)
-	if err != nil {
-		return nil, err
+// writeCommit writes the rewritten |pc|, whose parents must already be rewritten.
+func (hr *historyRewriter) writeCommit(ctx context.Context, pc *pendingCommit) error {
+	rebasedParents := make([]*doltdb.Commit, len(pc.parents))
+	for i, p := range pc.parents {
+		h, err := p.HashOf()
+		if err != nil {
+			return err
+		}
+		rebasedParents[i] = hr.vs[h]
+	}
+
+	if pc.root == nil {
+		// the rewritten tables didn't change in |pc|, so they're the same as in its rewritten first parent
+		root, err := pc.commit.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		parentRoot, err := rebasedParents[0].GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		if root, err = copyTables(ctx, root, parentRoot, hr.opts.Tables); err != nil {
+			return err
+		}
+		if pc.root, pc.rootHash, err = hr.ddb.WriteRootValue(ctx, root); err != nil {
+			return err
+		}
 	}
 
-	rebasedCommit, err := ddb.CommitDanglingWithParentCommits(ctx, valueHash, allRebasedParents, oldMeta)
+	oldMeta, err := pc.commit.GetCommitMeta(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	rebasedCommit, err := hr.ddb.CommitDanglingWithParentCommits(ctx, pc.rootHash, rebasedParents, oldMeta)
+	if err != nil {
+		return err
+	}
+	hr.vs[pc.hash] = rebasedCommit
+	// the replayed root is no longer needed
+	pc.root = nil
+	return nil
+}
+
+// tablesChanged returns whether any of |tables| differs between |root| and |parentRoot|.
+func tablesChanged(ctx context.Context, root, parentRoot doltdb.RootValue, tables []doltdb.TableName) (bool, error) {
+	for _, name := range tables {
+		h, ok, err := root.GetTableHash(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		parentH, parentOk, err := parentRoot.GetTableHash(ctx, name)
+		if err != nil {
+			return false, err
+		}
+		if ok != parentOk || h != parentH {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	vs[commitHash] = rebasedCommit
-	return rebasedCommit, nil
+// copyTables returns |dest| with |tables| replaced by their versions in |src|. Tables which aren't in |src| are
+// removed from |dest|.
+func copyTables(ctx context.Context, dest, src doltdb.RootValue, tables []doltdb.TableName) (doltdb.RootValue, error) {
+	var drop []doltdb.TableName
+	for _, name := range tables {
+		tbl, ok, err := src.GetTable(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if dest, err = dest.PutTable(ctx, name, tbl); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if ok, err = dest.HasTable(ctx, name); err != nil {
+			return nil, err
+		} else if ok {
+			drop = append(drop, name)
+		}
+	}
+	if len(drop) == 0 {
+		return dest, nil
+	}
+	return dest.RemoveTables(ctx, false, true, drop...)
 }
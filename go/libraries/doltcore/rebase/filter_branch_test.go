@@ -174,6 +174,52 @@ func filterBranchTests() []filterBranchTest {
 				},
 			},
 		},
+		{
+			name: "filter-branch with tables",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "CREATE TABLE other (pk int PRIMARY KEY);"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO other VALUES (1),(2),(3);"}},
+				{cmd.AddCmd{}, args{"-A"}},
+				{cmd.CommitCmd{}, args{"-m", "added other"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (4,4),(5,5),(6,6);"}},
+				{cmd.AddCmd{}, args{"-A"}},
+				{cmd.CommitCmd{}, args{"-m", "added more rows"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO other VALUES (4),(5),(6);"}},
+				{cmd.AddCmd{}, args{"-A"}},
+				{cmd.CommitCmd{}, args{"-m", "added more rows to other"}},
+				// the first commits which changed test predate other, so deleting from it fails on them
+				{cmd.FilterBranchCmd{}, args{"--continue", "--tables", "test", "--parallelism", "2", "-q", "DELETE FROM test WHERE pk > 4; DELETE FROM other WHERE pk > 1;"}},
+			},
+			asserts: []testAssertion{
+				{
+					query: "SELECT * FROM test",
+					rows: []sql.Row{
+						{int32(0), int32(0)},
+						{int32(1), int32(1)},
+						{int32(2), int32(2)},
+						{int32(4), int32(4)},
+					},
+				},
+				{
+					query: "SELECT count(*) FROM other",
+					rows: []sql.Row{
+						{int64(6)},
+					},
+				},
+				{
+					query: "SELECT count(*) FROM test AS OF 'HEAD~1'",
+					rows: []sql.Row{
+						{int64(4)},
+					},
+				},
+				{
+					query: "SELECT count(*) FROM other AS OF 'HEAD~1'",
+					rows: []sql.Row{
+						{int64(3)},
+					},
+				},
+			},
+		},
 		{
 			name: "filter-branch with missing table",
 			setup: []testCommand{
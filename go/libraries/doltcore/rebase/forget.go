@@ -0,0 +1,269 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// ForgottenRef is a ref whose history was rewritten, or deleted, by ForgetHistory.
+type ForgottenRef struct {
+	Ref ref.DoltRef
+	Old hash.Hash
+	// New is the commit the ref points to once its history is rewritten. It is empty for deleted refs, and for
+	// every ref of a dry run.
+	New hash.Hash
+	// Deleted is true for remote tracking branches, which are deleted rather than rewritten.
+	Deleted bool
+}
+
+// forgetter holds the state of a single ForgetHistory call.
+type forgetter struct {
+	ddb        *doltdb.DoltDB
+	baseHash   hash.Hash
+	baseHeight uint64
+	dryRun     bool
+
+	// rewritten maps the commits which were visited to their rewritten commits, or to nil for the commits which
+	// don't descend from the base commit.
+	rewritten map[hash.Hash]*doltdb.Commit
+}
+
+// ForgetHistory rewrites the history of every branch, tag and workspace of |ddb| so that |base| becomes a commit
+// without parents, discarding the history before it. Every branch, tag and workspace must contain |base|. The commits
+// descending from |base| are rewritten with the same root values and metadata, and the parents of merge commits
+// which don't descend from |base| are dropped. Remote tracking branches are deleted, since the history they track is
+// no longer in the database.
+//
+// The discarded history stays in |ddb| until it is garbage collected. If |dryRun| is true, the refs which would be
+// rewritten are checked and returned, but nothing is written.
+func ForgetHistory(ctx context.Context, ddb *doltdb.DoltDB, base *doltdb.Commit, dryRun bool) ([]ForgottenRef, error) {
+	baseHash, err := base.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	baseHeight, err := base.Height()
+	if err != nil {
+		return nil, err
+	}
+	f := &forgetter{
+		ddb:        ddb,
+		baseHash:   baseHash,
+		baseHeight: baseHeight,
+		dryRun:     dryRun,
+		rewritten:  make(map[hash.Hash]*doltdb.Commit),
+	}
+
+	if err = f.checkNoReferencedCommits(ctx); err != nil {
+		return nil, err
+	}
+
+	refs, err := ddb.GetRefsOfType(ctx, map[ref.RefType]struct{}{
+		ref.BranchRefType:    {},
+		ref.TagRefType:       {},
+		ref.WorkspaceRefType: {},
+		ref.RemoteRefType:    {},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].String() < refs[j].String()
+	})
+
+	// every new head is written before any ref changes, and the refs are changed all at once, so that a failure
+	// leaves the history as it was
+	var forgotten []ForgottenRef
+	updates := make([]doltdb.RefUpdate, len(refs))
+	var missing []string
+	for i, r := range refs {
+		updates[i].Ref = r
+		var head *doltdb.Commit
+		if r.GetType() == ref.TagRefType {
+			tag, err := ddb.ResolveTag(ctx, ref.NewTagRef(r.GetPath()))
+			if err != nil {
+				return nil, err
+			}
+			head = tag.Commit
+			updates[i].TagMeta = tag.Meta
+		} else {
+			head, err = ddb.ResolveCommitRef(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		headHash, err := head.HashOf()
+		if err != nil {
+			return nil, err
+		}
+
+		if r.GetType() == ref.RemoteRefType {
+			forgotten = append(forgotten, ForgottenRef{Ref: r, Old: headHash, Deleted: true})
+			continue
+		}
+		updates[i].Commit, err = f.rewrite(ctx, head)
+		if err != nil {
+			return nil, err
+		}
+		if updates[i].Commit == nil {
+			missing = append(missing, r.String())
+			continue
+		}
+		fr := ForgottenRef{Ref: r, Old: headHash}
+		if !dryRun {
+			if fr.New, err = updates[i].Commit.HashOf(); err != nil {
+				return nil, err
+			}
+		}
+		forgotten = append(forgotten, fr)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("commit %s is not in the history of %s; delete them or choose a commit they contain",
+			baseHash.String(), strings.Join(missing, ", "))
+	}
+	if dryRun {
+		return forgotten, nil
+	}
+
+	if err = ddb.UpdateRefs(ctx, updates); err != nil {
+		return nil, err
+	}
+	return forgotten, nil
+}
+
+// checkNoReferencedCommits returns an error if a working set or stash references a commit, which would be left
+// dangling once the history it's in is garbage collected.
+func (f *forgetter) checkNoReferencedCommits(ctx context.Context) error {
+	branches, err := f.ddb.GetRefsOfType(ctx, map[ref.RefType]struct{}{
+		ref.BranchRefType:    {},
+		ref.WorkspaceRefType: {},
+	})
+	if err != nil {
+		return err
+	}
+	for _, b := range branches {
+		wsRef, err := ref.WorkingSetRefForHead(b)
+		if err != nil {
+			return err
+		}
+		ws, err := f.ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if ws.MergeActive() {
+			return fmt.Errorf("%s has a merge in progress; commit or abort it first", b.String())
+		}
+		if ws.RebaseActive() {
+			return fmt.Errorf("%s has a rebase in progress; continue or abort it first", b.String())
+		}
+	}
+
+	stashes, err := f.ddb.GetStashes(ctx)
+	if err != nil {
+		return err
+	}
+	if len(stashes) > 0 {
+		return fmt.Errorf("the database has %d stashes; apply or drop them first", len(stashes))
+	}
+	return nil
+}
+
+// rewrite returns the rewritten |cm|, or nil if |cm| doesn't descend from the base commit. During a dry run, the
+// commits aren't written, and |cm| itself is returned if it descends from the base commit.
+func (f *forgetter) rewrite(ctx context.Context, cm *doltdb.Commit) (*doltdb.Commit, error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if rewritten, ok := f.rewritten[h]; ok {
+		return rewritten, nil
+	}
+
+	var parents []*doltdb.Commit
+	descends := h == f.baseHash
+	if !descends {
+		height, err := cm.Height()
+		if err != nil {
+			return nil, err
+		}
+		if height <= f.baseHeight {
+			// only commits higher than the base commit can descend from it
+			f.rewritten[h] = nil
+			return nil, nil
+		}
+
+		optParents, err := f.ddb.ResolveAllParents(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+		for _, optParent := range optParents {
+			parent, ok := optParent.ToCommit()
+			if !ok {
+				// ghost commits from shallow clones are older than any commit with data
+				continue
+			}
+			rewrittenParent, err := f.rewrite(ctx, parent)
+			if err != nil {
+				return nil, err
+			}
+			if rewrittenParent != nil {
+				parents = append(parents, rewrittenParent)
+			}
+		}
+		descends = len(parents) > 0
+	}
+
+	if !descends {
+		f.rewritten[h] = nil
+		return nil, nil
+	}
+	if f.dryRun {
+		f.rewritten[h] = cm
+		return cm, nil
+	}
+
+	rv, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rvHash, err := rv.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := cm.GetCommitMeta(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rewritten *doltdb.Commit
+	if len(parents) == 0 {
+		rewritten, err = f.ddb.CommitDanglingWithoutParents(ctx, rvHash, meta)
+	} else {
+		rewritten, err = f.ddb.CommitDanglingWithParentCommits(ctx, rvHash, parents, meta)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.rewritten[h] = rewritten
+	return rewritten, nil
+}
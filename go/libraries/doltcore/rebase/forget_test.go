@@ -0,0 +1,140 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rebase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmd "github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+// setupForgetTest creates a database whose main branch has three commits, the last of which is tagged, and whose
+// other branch has a fourth commit on top of the second.
+func setupForgetTest(t *testing.T, ctx context.Context) *env.DoltEnv {
+	dEnv := setupFilterBranchTests(t)
+	cliCtx, err := cmd.NewArgFreeCliContext(ctx, dEnv, dEnv.FS)
+	require.NoError(t, err)
+	for _, c := range []testCommand{
+		{cmd.SqlCmd{}, args{"-q", "insert into test values (3,3);"}},
+		{cmd.CommitCmd{}, args{"-am", "second"}},
+		{cmd.BranchCmd{}, args{"other"}},
+		{cmd.SqlCmd{}, args{"-q", "insert into test values (4,4);"}},
+		{cmd.CommitCmd{}, args{"-am", "third"}},
+		{cmd.TagCmd{}, args{"v1"}},
+		{cmd.CheckoutCmd{}, args{"other"}},
+		{cmd.SqlCmd{}, args{"-q", "insert into test values (5,5);"}},
+		{cmd.CommitCmd{}, args{"-am", "fourth"}},
+		{cmd.CheckoutCmd{}, args{"main"}},
+	} {
+		require.Equal(t, 0, c.cmd.Exec(ctx, c.cmd.Name(), c.args, dEnv, cliCtx))
+	}
+	return dEnv
+}
+
+func resolveBranch(t *testing.T, ctx context.Context, ddb *doltdb.DoltDB, name string) *doltdb.Commit {
+	cm, err := ddb.ResolveCommitRef(ctx, ref.NewBranchRef(name))
+	require.NoError(t, err)
+	return cm
+}
+
+func parentOf(t *testing.T, ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) *doltdb.Commit {
+	require.Equal(t, 1, cm.NumParents())
+	optCmt, err := ddb.ResolveParent(ctx, cm, 0)
+	require.NoError(t, err)
+	parent, ok := optCmt.ToCommit()
+	require.True(t, ok)
+	return parent
+}
+
+func TestForgetHistory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rewrites branches and tags", func(t *testing.T) {
+		dEnv := setupForgetTest(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+		ddb := dEnv.DoltDB(ctx)
+		main := resolveBranch(t, ctx, ddb, "main")
+		second := parentOf(t, ctx, ddb, main)
+		oldRoot, err := main.GetRootValue(ctx)
+		require.NoError(t, err)
+
+		forgotten, err := rebase.ForgetHistory(ctx, ddb, second, false)
+		require.NoError(t, err)
+		require.Len(t, forgotten, 3)
+
+		main = resolveBranch(t, ctx, ddb, "main")
+		newSecond := parentOf(t, ctx, ddb, main)
+		assert.Equal(t, 0, newSecond.NumParents())
+		meta, err := newSecond.GetCommitMeta(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "second", meta.Description)
+		newRoot, err := main.GetRootValue(ctx)
+		require.NoError(t, err)
+		oldHash, err := oldRoot.HashOf()
+		require.NoError(t, err)
+		newHash, err := newRoot.HashOf()
+		require.NoError(t, err)
+		assert.Equal(t, oldHash, newHash)
+
+		other := resolveBranch(t, ctx, ddb, "other")
+		assert.Equal(t, 0, parentOf(t, ctx, ddb, other).NumParents())
+
+		tag, err := ddb.ResolveTag(ctx, ref.NewTagRef("v1"))
+		require.NoError(t, err)
+		tagHash, err := tag.Commit.HashOf()
+		require.NoError(t, err)
+		mainHash, err := main.HashOf()
+		require.NoError(t, err)
+		assert.Equal(t, mainHash, tagHash)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		dEnv := setupForgetTest(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+		ddb := dEnv.DoltDB(ctx)
+		main := resolveBranch(t, ctx, ddb, "main")
+		mainHash, err := main.HashOf()
+		require.NoError(t, err)
+
+		forgotten, err := rebase.ForgetHistory(ctx, ddb, parentOf(t, ctx, ddb, main), true)
+		require.NoError(t, err)
+		require.Len(t, forgotten, 3)
+		for _, fr := range forgotten {
+			assert.True(t, fr.New.IsEmpty())
+		}
+		h, err := resolveBranch(t, ctx, ddb, "main").HashOf()
+		require.NoError(t, err)
+		assert.Equal(t, mainHash, h)
+	})
+
+	t.Run("every branch must contain the commit", func(t *testing.T) {
+		dEnv := setupForgetTest(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+		ddb := dEnv.DoltDB(ctx)
+		main := resolveBranch(t, ctx, ddb, "main")
+
+		_, err := rebase.ForgetHistory(ctx, ddb, main, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "refs/heads/other")
+	})
+}
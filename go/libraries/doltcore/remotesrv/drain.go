@@ -0,0 +1,75 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	HealthzPath = "/healthz"
+	ReadyzPath  = "/readyz"
+)
+
+// drainState tracks whether a server is shutting down. While draining, new uploads are rejected so that clients retry
+// them against another server, while downloads, and uploads which are already in flight, are allowed to finish.
+type drainState struct {
+	draining atomic.Bool
+}
+
+func (d *drainState) isDraining() bool {
+	return d.draining.Load()
+}
+
+// UnaryInterceptor rejects the RPCs which begin uploads and pushes while draining.
+func (d *drainState) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if d.isDraining() && SUPER_USER_RPC_METHODS[info.FullMethod] {
+			return nil, status.Error(codes.Unavailable, "server is shutting down")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// wrapHandler serves the health check endpoints for load balancers, and rejects table file uploads to |next| while
+// draining. HealthzPath reports whether the server is running, and ReadyzPath reports whether it is accepting new
+// requests, which it stops doing once it begins draining.
+func (d *drainState) wrapHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == HealthzPath:
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok\n"))
+		case req.URL.Path == ReadyzPath:
+			if d.isDraining() {
+				http.Error(w, "draining", http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("ok\n"))
+		case d.isDraining() && req.Method == http.MethodPut:
+			w.Header().Set("Connection", "close")
+			http.Error(w, "server is shutting down", http.StatusServiceUnavailable)
+		default:
+			next.ServeHTTP(w, req)
+		}
+	})
+}
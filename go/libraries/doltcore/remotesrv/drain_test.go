@@ -0,0 +1,71 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDrainHandler(t *testing.T) {
+	var d drainState
+	h := d.wrapHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(method, path string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, HealthzPath))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, ReadyzPath))
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/db/table-file"))
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPut, "/db/table-file"))
+
+	d.draining.Store(true)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, HealthzPath))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, ReadyzPath))
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/db/table-file"))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodPut, "/db/table-file"))
+}
+
+func TestDrainUnaryInterceptor(t *testing.T) {
+	var d drainState
+	interceptor := d.UnaryInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	call := func(method string) error {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	upload := "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetUploadLocations"
+	download := "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/GetDownloadLocations"
+	assert.NoError(t, call(upload))
+	assert.NoError(t, call(download))
+
+	d.draining.Store(true)
+	assert.Equal(t, codes.Unavailable, status.Code(call(upload)))
+	assert.NoError(t, call(download))
+}
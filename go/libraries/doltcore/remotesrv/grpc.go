@@ -92,7 +92,7 @@ func getRepoPath(req repoRequest) string {
 }
 
 func (rs *RemoteChunkStore) HasChunks(ctx context.Context, req *remotesapi.HasChunksRequest) (*remotesapi.HasChunksResponse, error) {
-	logger := getReqLogger(rs.lgr, "HasChunks")
+	logger := getReqLogger(ctx, rs.lgr, "HasChunks")
 	if err := ValidateHasChunksRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -150,7 +150,7 @@ func (rs *RemoteChunkStore) getRelativeStorePath(cs RemoteSrvStore) (string, err
 }
 
 func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remotesapi.GetDownloadLocsRequest) (*remotesapi.GetDownloadLocsResponse, error) {
-	logger := getReqLogger(rs.lgr, "GetDownloadLocations")
+	logger := getReqLogger(ctx, rs.lgr, "GetDownloadLocations")
 	if err := ValidateGetDownloadLocsRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -223,7 +223,7 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 }
 
 func (rs *RemoteChunkStore) StreamDownloadLocations(stream remotesapi.ChunkStoreService_StreamDownloadLocationsServer) error {
-	ologger := getReqLogger(rs.lgr, "StreamDownloadLocations")
+	ologger := getReqLogger(stream.Context(), rs.lgr, "StreamDownloadLocations")
 	numMessages := 0
 	numHashes := 0
 	numUrls := 0
@@ -371,7 +371,7 @@ func getTableFileDetails(req *remotesapi.GetUploadLocsRequest) ([]*remotesapi.Ta
 }
 
 func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotesapi.GetUploadLocsRequest) (*remotesapi.GetUploadLocsResponse, error) {
-	logger := getReqLogger(rs.lgr, "GetUploadLocations")
+	logger := getReqLogger(ctx, rs.lgr, "GetUploadLocations")
 	if err := ValidateGetUploadLocsRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -432,7 +432,7 @@ func (rs *RemoteChunkStore) getUploadUrl(md metadata.MD, repoPath string, tfd *r
 }
 
 func (rs *RemoteChunkStore) Rebase(ctx context.Context, req *remotesapi.RebaseRequest) (*remotesapi.RebaseResponse, error) {
-	logger := getReqLogger(rs.lgr, "Rebase")
+	logger := getReqLogger(ctx, rs.lgr, "Rebase")
 	if err := ValidateRebaseRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -449,7 +449,7 @@ func (rs *RemoteChunkStore) Rebase(ctx context.Context, req *remotesapi.RebaseRe
 }
 
 func (rs *RemoteChunkStore) Root(ctx context.Context, req *remotesapi.RootRequest) (*remotesapi.RootResponse, error) {
-	logger := getReqLogger(rs.lgr, "Root")
+	logger := getReqLogger(ctx, rs.lgr, "Root")
 	if err := ValidateRootRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -472,7 +472,7 @@ func (rs *RemoteChunkStore) Root(ctx context.Context, req *remotesapi.RootReques
 }
 
 func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRequest) (*remotesapi.CommitResponse, error) {
-	logger := getReqLogger(rs.lgr, "Commit")
+	logger := getReqLogger(ctx, rs.lgr, "Commit")
 	if err := ValidateCommitRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -522,7 +522,7 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 }
 
 func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi.GetRepoMetadataRequest) (*remotesapi.GetRepoMetadataResponse, error) {
-	logger := getReqLogger(rs.lgr, "GetRepoMetadata")
+	logger := getReqLogger(ctx, rs.lgr, "GetRepoMetadata")
 	if err := ValidateGetRepoMetadataRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -551,7 +551,7 @@ func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi
 }
 
 func (rs *RemoteChunkStore) ListTableFiles(ctx context.Context, req *remotesapi.ListTableFilesRequest) (*remotesapi.ListTableFilesResponse, error) {
-	logger := getReqLogger(rs.lgr, "ListTableFiles")
+	logger := getReqLogger(ctx, rs.lgr, "ListTableFiles")
 	if err := ValidateListTableFilesRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -629,7 +629,7 @@ func getTableFileInfo(
 
 // AddTableFiles updates the remote manifest with new table files without modifying the root hash.
 func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.AddTableFilesRequest) (*remotesapi.AddTableFilesResponse, error) {
-	logger := getReqLogger(rs.lgr, "AddTableFiles")
+	logger := getReqLogger(ctx, rs.lgr, "AddTableFiles")
 	if err := ValidateAddTableFilesRequest(req); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -707,10 +707,12 @@ func incReqId() int {
 	return int(atomic.AddInt32(&requestId, 1))
 }
 
-func getReqLogger(lgr *logrus.Entry, method string) *logrus.Entry {
+// getReqLogger returns a logger for a request to |method|, whose entries carry the ID of the request of |ctx|.
+func getReqLogger(ctx context.Context, lgr *logrus.Entry, method string) *logrus.Entry {
 	lgr = lgr.WithFields(logrus.Fields{
-		"method":      method,
-		"request_num": strconv.Itoa(incReqId()),
+		"method":       method,
+		"request_num":  strconv.Itoa(incReqId()),
+		RequestIDField: RequestIDFromContext(ctx),
 	})
 	lgr.Trace("starting request")
 	return lgr
@@ -30,10 +30,12 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
@@ -65,7 +67,7 @@ func newFileHandler(lgr *logrus.Entry, dbCache DBCache, fs filesys.Filesys, read
 }
 
 func (fh filehandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
-	logger := getReqLogger(fh.lgr, req.Method+"_"+req.RequestURI)
+	logger := getReqLogger(req.Context(), fh.lgr, req.Method+"_"+req.RequestURI)
 	defer func() { logger.Trace("finished") }()
 
 	var err error
@@ -447,9 +449,29 @@ func validateFileName(fileName string) bool {
 		_, ok := hash.MaybeParse(fileName)
 		return ok
 	}
-	if len(fileName) == 32+len(nbs.ArchiveFileSuffix) && strings.HasSuffix(fileName, nbs.ArchiveFileSuffix) {
-		_, ok := hash.MaybeParse(fileName[:32])
-		return ok
+	for _, suffix := range []string{nbs.ArchiveFileSuffix, nbs.DeltaFileSuffix} {
+		if len(fileName) == 32+len(suffix) && strings.HasSuffix(fileName, suffix) {
+			_, ok := hash.MaybeParse(fileName[:32])
+			return ok
+		}
 	}
 	return false
 }
+
+// limitBandwidth limits the combined rate of the request bodies read by
+// |h| and the response bodies written by it with |lim|.
+func limitBandwidth(h http.Handler, lim *rate.Limiter) http.Handler {
+	return http.HandlerFunc(func(respWr http.ResponseWriter, req *http.Request) {
+		req.Body = iohelp.NewRateLimitedReader(req.Context(), req.Body, lim)
+		h.ServeHTTP(rateLimitedResponseWriter{respWr, iohelp.NewRateLimitedWriter(req.Context(), respWr, lim)}, req)
+	})
+}
+
+type rateLimitedResponseWriter struct {
+	http.ResponseWriter
+	wr io.Writer
+}
+
+func (w rateLimitedResponseWriter) Write(p []byte) (int, error) {
+	return w.wr.Write(p)
+}
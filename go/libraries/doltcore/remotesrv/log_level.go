@@ -0,0 +1,56 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogLevelPath is the path of the admin endpoint which reports and changes the log level of a running server.
+const LogLevelPath = "/admin/loglevel"
+
+// NewLogLevelHandler returns a handler which serves LogLevelPath for |lgr|. A GET responds with the current level, and
+// a PUT or POST with a level query parameter, such as ?level=debug, changes it. The handler is unauthenticated, so it
+// should only be served on an address which is reachable by administrators, and not on the address serving remotes.
+func NewLogLevelHandler(lgr *logrus.Logger) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LogLevelPath, func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			level, err := logrus.ParseLevel(req.URL.Query().Get("level"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if level != lgr.GetLevel() {
+				lgr.WithFields(logrus.Fields{
+					"from": lgr.GetLevel().String(),
+					"to":   level.String(),
+				}).Info("changing log level")
+				lgr.SetLevel(level)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		fmt.Fprintln(w, lgr.GetLevel().String())
+	})
+	return mux
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	lgr := logrus.New()
+	lgr.SetOutput(io.Discard)
+	lgr.SetLevel(logrus.InfoLevel)
+	h := NewLogLevelHandler(lgr)
+	serve := func(method, target string) (int, string) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+		return rec.Code, rec.Body.String()
+	}
+
+	code, body := serve(http.MethodGet, LogLevelPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "info\n", body)
+
+	code, body = serve(http.MethodPut, LogLevelPath+"?level=debug")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "debug\n", body)
+	assert.Equal(t, logrus.DebugLevel, lgr.GetLevel())
+
+	code, _ = serve(http.MethodPut, LogLevelPath+"?level=loud")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, logrus.DebugLevel, lgr.GetLevel())
+
+	code, _ = serve(http.MethodDelete, LogLevelPath)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+}
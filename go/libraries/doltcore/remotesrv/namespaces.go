@@ -0,0 +1,320 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v2"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// NamespaceConfig configures a namespace of a multi-tenant remotesrv. The databases of a namespace are addressed as
+// <namespace>/<database>, and are only accessible with one of the namespace's tokens.
+type NamespaceConfig struct {
+	Name string `yaml:"name"`
+	// ReadTokens grant clone, fetch and pull access to the databases of the namespace.
+	ReadTokens []string `yaml:"read_tokens"`
+	// WriteTokens grant push access, in addition to read access, to the databases of the namespace.
+	WriteTokens []string `yaml:"write_tokens"`
+	// Quota limits the total size of each database of the namespace, such as 10GB. Empty means unlimited.
+	Quota string `yaml:"quota"`
+	// CreateOnPush creates a database which doesn't exist yet when it is accessed with a write token. Otherwise,
+	// databases must be created on the server before they can be pushed to.
+	CreateOnPush bool `yaml:"create_on_push"`
+}
+
+type namespacesConfig struct {
+	Namespaces []NamespaceConfig `yaml:"namespaces"`
+}
+
+type namespace struct {
+	NamespaceConfig
+	quota uint64
+}
+
+// Namespaces is the set of namespaces hosted by a multi-tenant remotesrv.
+type Namespaces struct {
+	byName map[string]namespace
+}
+
+// LoadNamespaces reads the namespaces configured by the YAML file at |path|.
+func LoadNamespaces(fs filesys.ReadableFS, path string) (*Namespaces, error) {
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseNamespaces(data)
+}
+
+// ParseNamespaces parses namespaces from YAML of the form:
+//
+//	namespaces:
+//	  - name: acme
+//	    read_tokens: [...]
+//	    write_tokens: [...]
+//	    quota: 10GB
+//	    create_on_push: true
+func ParseNamespaces(data []byte) (*Namespaces, error) {
+	var cfg namespacesConfig
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return nil, err
+	}
+	n := &Namespaces{byName: make(map[string]namespace, len(cfg.Namespaces))}
+	for _, nc := range cfg.Namespaces {
+		if !isValidPathSegment(nc.Name) {
+			return nil, fmt.Errorf("invalid namespace name '%s'", nc.Name)
+		}
+		if _, ok := n.byName[nc.Name]; ok {
+			return nil, fmt.Errorf("namespace '%s' is configured more than once", nc.Name)
+		}
+		ns := namespace{NamespaceConfig: nc}
+		if nc.Quota != "" {
+			quota, err := humanize.ParseBytes(nc.Quota)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quota for namespace '%s': %w", nc.Name, err)
+			}
+			ns.quota = quota
+		}
+		n.byName[nc.Name] = ns
+	}
+	return n, nil
+}
+
+func isValidPathSegment(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, "/\\")
+}
+
+// resolve returns the namespace of the database at |repoPath|, which must be of the form <namespace>/<database>.
+func (n *Namespaces) resolve(repoPath string) (namespace, error) {
+	nsName, dbName, ok := strings.Cut(repoPath, "/")
+	if !ok || !isValidPathSegment(nsName) || !isValidPathSegment(dbName) {
+		return namespace{}, status.Errorf(codes.InvalidArgument, "invalid database path '%s', expected <namespace>/<database>", repoPath)
+	}
+	ns, ok := n.byName[nsName]
+	if !ok {
+		return namespace{}, status.Errorf(codes.NotFound, "namespace '%s' not found", nsName)
+	}
+	return ns, nil
+}
+
+type namespaceAccess int
+
+const (
+	namespaceNoAccess namespaceAccess = iota
+	namespaceReadAccess
+	namespaceWriteAccess
+)
+
+func (ns namespace) access(token string) namespaceAccess {
+	if token == "" {
+		return namespaceNoAccess
+	}
+	for _, t := range ns.WriteTokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return namespaceWriteAccess
+		}
+	}
+	for _, t := range ns.ReadTokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			return namespaceReadAccess
+		}
+	}
+	return namespaceNoAccess
+}
+
+type namespaceWriteAccessKey struct{}
+
+// hasNamespaceWriteAccess returns whether the request of |ctx| was authorized to write to its namespace.
+func hasNamespaceWriteAccess(ctx context.Context) bool {
+	ok, _ := ctx.Value(namespaceWriteAccessKey{}).(bool)
+	return ok
+}
+
+// extractNamespaceToken returns the token of the request of |ctx|, which is sent either as a bearer token, or as the
+// password of basic auth credentials, as set by dolt's --user flag and DOLT_REMOTE_PASSWORD.
+func extractNamespaceToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+	auths := md.Get("authorization")
+	if len(auths) != 1 {
+		return "", nil
+	}
+	auth := auths[0]
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		return token, nil
+	}
+	if encoded, ok := strings.CutPrefix(auth, "Basic "); ok {
+		decoded, err := base64.URLEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("incoming request authorization header failed to decode: %v", err)
+		}
+		_, password, _ := strings.Cut(string(decoded), ":")
+		return password, nil
+	}
+	return "", fmt.Errorf("bad request: unsupported authorization header")
+}
+
+// NewNamespacedDBCache returns a DBCache which only serves the databases of |namespaces| from |inner|. |fs| is the
+// filesystem |inner| stores databases in, and is used to tell whether a database exists. A database which doesn't
+// exist is only created when its namespace allows creation on push and the request was authorized to write.
+func NewNamespacedDBCache(inner DBCache, fs filesys.Filesys, namespaces *Namespaces) DBCache {
+	return namespacedDBCache{inner: inner, fs: fs, namespaces: namespaces}
+}
+
+type namespacedDBCache struct {
+	inner      DBCache
+	fs         filesys.Filesys
+	namespaces *Namespaces
+}
+
+func (c namespacedDBCache) Get(ctx context.Context, path, nbfVerStr string) (RemoteSrvStore, error) {
+	ns, err := c.namespaces.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	if exists, _ := c.fs.Exists(filepath.FromSlash(path)); !exists {
+		if !ns.CreateOnPush || !hasNamespaceWriteAccess(ctx) {
+			return nil, status.Errorf(codes.NotFound, "database '%s' not found", path)
+		}
+	}
+	return c.inner.Get(ctx, path, nbfVerStr)
+}
+
+// NamespaceInterceptor authorizes the requests made to a multi-tenant remotesrv with the tokens of the namespace of
+// the requested database, and enforces the storage quotas of the namespaces.
+type NamespaceInterceptor struct {
+	Lgr        *logrus.Entry
+	Namespaces *Namespaces
+	DBCache    DBCache
+}
+
+func (ni *NamespaceInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := ni.authorize(ctx, req, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if uploadReq, ok := req.(*remotesapi.GetUploadLocsRequest); ok {
+			if err := ni.checkQuota(ctx, uploadReq); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (ni *NamespaceInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, namespaceServerStream{ServerStream: ss, ni: ni, method: info.FullMethod})
+	}
+}
+
+func (ni *NamespaceInterceptor) Options() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(ni.Unary()),
+		grpc.ChainStreamInterceptor(ni.Stream()),
+	}
+}
+
+// authorize checks that the token of the request |req| to |method| grants access to the namespace of the requested
+// database, returning a context which records whether the request may write to it.
+func (ni *NamespaceInterceptor) authorize(ctx context.Context, req interface{}, method string) (context.Context, error) {
+	repoReq, ok := req.(repoRequest)
+	if !ok || (repoReq.GetRepoPath() == "" && repoReq.GetRepoId() == nil) {
+		return nil, status.Error(codes.InvalidArgument, "request does not name a database")
+	}
+	repoPath := getRepoPath(repoReq)
+	ns, err := ni.Namespaces.resolve(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := extractNamespaceToken(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	needsWrite, err := requireSuperUser(method)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	access := ns.access(token)
+	switch {
+	case access == namespaceNoAccess:
+		ni.Lgr.Warnf("namespace authentication failed for %s", repoPath)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token for namespace '%s'", ns.Name)
+	case needsWrite && access != namespaceWriteAccess:
+		ni.Lgr.Warnf("namespace authorization failed for %s", repoPath)
+		return nil, status.Errorf(codes.PermissionDenied, "token does not grant write access to namespace '%s'", ns.Name)
+	}
+	return context.WithValue(ctx, namespaceWriteAccessKey{}, access == namespaceWriteAccess), nil
+}
+
+// checkQuota fails uploads which would grow a database past the quota of its namespace.
+func (ni *NamespaceInterceptor) checkQuota(ctx context.Context, req *remotesapi.GetUploadLocsRequest) error {
+	repoPath := getRepoPath(req)
+	ns, err := ni.Namespaces.resolve(repoPath)
+	if err != nil || ns.quota == 0 {
+		return err
+	}
+	cs, err := ni.DBCache.Get(ctx, repoPath, types.Format_Default.VersionString())
+	if err != nil {
+		return err
+	}
+	size, err := cs.Size(ctx)
+	if err != nil {
+		return err
+	}
+	for _, tfd := range req.GetTableFileDetails() {
+		size += tfd.GetContentLength()
+	}
+	if size > ns.quota {
+		return status.Errorf(codes.ResourceExhausted, "push to '%s' would use %s, exceeding the %s quota of namespace '%s'",
+			repoPath, humanize.Bytes(size), humanize.Bytes(ns.quota), ns.Name)
+	}
+	return nil
+}
+
+// namespaceServerStream authorizes each request received on a stream.
+type namespaceServerStream struct {
+	grpc.ServerStream
+	ni     *NamespaceInterceptor
+	method string
+}
+
+func (s namespaceServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	_, err := s.ni.authorize(s.Context(), m, s.method)
+	return err
+}
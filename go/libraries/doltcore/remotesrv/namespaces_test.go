@@ -0,0 +1,87 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+)
+
+const testNamespaces = `
+namespaces:
+  - name: acme
+    read_tokens: ["acme-read"]
+    write_tokens: ["acme-write"]
+    quota: 1MB
+    create_on_push: true
+  - name: globex
+    write_tokens: ["globex-write"]
+`
+
+func TestParseNamespaces(t *testing.T) {
+	n, err := ParseNamespaces([]byte(testNamespaces))
+	require.NoError(t, err)
+	require.Len(t, n.byName, 2)
+	assert.Equal(t, uint64(1_000_000), n.byName["acme"].quota)
+	assert.Equal(t, uint64(0), n.byName["globex"].quota)
+
+	_, err = ParseNamespaces([]byte("namespaces:\n  - name: a/b\n"))
+	assert.Error(t, err)
+	_, err = ParseNamespaces([]byte("namespaces:\n  - name: a\n  - name: a\n"))
+	assert.Error(t, err)
+	_, err = ParseNamespaces([]byte("namespaces:\n  - name: a\n    quota: lots\n"))
+	assert.Error(t, err)
+}
+
+func TestNamespaceInterceptorAuthorize(t *testing.T) {
+	n, err := ParseNamespaces([]byte(testNamespaces))
+	require.NoError(t, err)
+	ni := &NamespaceInterceptor{Lgr: logrus.NewEntry(logrus.New()), Namespaces: n}
+
+	const rootMethod = "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Root"
+	const commitMethod = "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/Commit"
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	ctx, err := ni.authorize(withToken("acme-read"), &remotesapi.RootRequest{RepoPath: "acme/db"}, rootMethod)
+	require.NoError(t, err)
+	assert.False(t, hasNamespaceWriteAccess(ctx))
+
+	_, err = ni.authorize(withToken("acme-read"), &remotesapi.CommitRequest{RepoPath: "acme/db"}, commitMethod)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx, err = ni.authorize(withToken("acme-write"), &remotesapi.CommitRequest{RepoPath: "acme/db"}, commitMethod)
+	require.NoError(t, err)
+	assert.True(t, hasNamespaceWriteAccess(ctx))
+
+	_, err = ni.authorize(withToken("globex-write"), &remotesapi.RootRequest{RepoPath: "acme/db"}, rootMethod)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = ni.authorize(withToken("acme-write"), &remotesapi.RootRequest{RepoPath: "initech/db"}, rootMethod)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = ni.authorize(withToken("acme-write"), &remotesapi.RootRequest{RepoPath: "acme/../globex"}, rootMethod)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDHeader is the gRPC metadata key and HTTP header which carries the ID of a request. A client may supply
+	// one to correlate its requests with the server's logs. Otherwise the server generates one. Either way, it is
+	// returned to the client in the response headers.
+	RequestIDHeader = "x-request-id"
+	// RequestIDField is the field of the log entries of a request which holds its ID.
+	RequestIDField = "request_id"
+)
+
+// validRequestID limits the request IDs accepted from clients to a reasonable length and to characters which are safe
+// to write to logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type requestIDKey struct{}
+
+// RequestIDFromContext returns the ID of the request of |ctx|, or "" if it doesn't have one.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ContextWithRequestID returns a copy of |ctx| which carries the request ID |id|, so that the operations it is passed
+// to, including those of the chunk store serving the request, can log it.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestIDOrNew returns |id| if it is a valid request ID, or a new one otherwise.
+func requestIDOrNew(id string) string {
+	if validRequestID.MatchString(id) {
+		return id
+	}
+	return uuid.NewString()
+}
+
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return requestIDOrNew("")
+	}
+	ids := md.Get(RequestIDHeader)
+	if len(ids) != 1 {
+		return requestIDOrNew("")
+	}
+	return requestIDOrNew(ids[0])
+}
+
+// requestIDUnaryInterceptor assigns each RPC a request ID, taken from its metadata when the client supplied one.
+func requestIDUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingRequestID(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+		return handler(ContextWithRequestID(ctx, id), req)
+	}
+}
+
+// requestIDStreamInterceptor assigns each streaming RPC a request ID, taken from its metadata when the client
+// supplied one.
+func requestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(ss.Context())
+		ss.SetHeader(metadata.Pairs(RequestIDHeader, id))
+		return handler(srv, requestIDServerStream{ServerStream: ss, ctx: ContextWithRequestID(ss.Context(), id)})
+	}
+}
+
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withRequestID assigns each HTTP request to |next| a request ID, taken from its headers when the client supplied one.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := requestIDOrNew(req.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, req.WithContext(ContextWithRequestID(req.Context(), id)))
+	})
+}
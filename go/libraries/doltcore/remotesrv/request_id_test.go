@@ -0,0 +1,66 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	var seen string
+	h := withRequestID(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = RequestIDFromContext(req.Context())
+	}))
+	serve := func(id string) string {
+		req := httptest.NewRequest(http.MethodGet, "/db/table-file", nil)
+		if id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+		return seen
+	}
+
+	assert.Equal(t, "client-id-1", serve("client-id-1"))
+	generated := serve("")
+	assert.NotEmpty(t, generated)
+	assert.NotEqual(t, generated, serve(""))
+	assert.NotEqual(t, "bad id\nwith newline", serve("bad id\nwith newline"))
+	assert.NotEqual(t, strings.Repeat("a", 129), serve(strings.Repeat("a", 129)))
+}
+
+func TestRequestIDUnaryInterceptor(t *testing.T) {
+	interceptor := requestIDUnaryInterceptor()
+	call := func(ctx context.Context) string {
+		res, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return RequestIDFromContext(ctx), nil
+		})
+		assert.NoError(t, err)
+		return res.(string)
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "client-id-2"))
+	assert.Equal(t, "client-id-2", call(ctx))
+	assert.NotEmpty(t, call(context.Background()))
+}
@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/http2"
@@ -29,9 +30,11 @@ import (
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 )
 
 type Server struct {
+	lgr      *logrus.Entry
 	wg       sync.WaitGroup
 	stopChan chan struct{}
 
@@ -44,9 +47,16 @@ type Server struct {
 	grpcHttpReqsWG sync.WaitGroup
 
 	tlsConfig *tls.Config
+
+	drain        drainState
+	drainTimeout time.Duration
 }
 
+// GracefulStop stops the server from accepting new connections and new uploads, and waits for in-flight requests to
+// finish. If the server was created with a DrainTimeout, requests which are still in flight when it elapses are
+// aborted.
 func (s *Server) GracefulStop() {
+	s.drain.draining.Store(true)
 	close(s.stopChan)
 	s.wg.Wait()
 }
@@ -65,12 +75,25 @@ type ServerArgs struct {
 
 	ConcurrencyControl remotesapi.PushConcurrencyControl
 
+	// If non-zero, the table files uploaded to and downloaded from
+	// the server are limited to this many bytes per second in total.
+	MaxBandwidth uint64
+
 	HttpInterceptor func(http.Handler) http.Handler
 
 	// If supplied, the listener(s) returned from Listeners() will be TLS
 	// listeners. The scheme used in the URLs returned from the gRPC server
 	// will be https.
 	TLSConfig *tls.Config
+
+	// If non-zero, GracefulStop waits at most this long for in-flight
+	// requests to finish before aborting them. Otherwise it waits for as
+	// long as they take.
+	DrainTimeout time.Duration
+
+	// If non-zero, limits the number of concurrent streams, which is the
+	// number of concurrent requests, of each HTTP/2 connection.
+	MaxConcurrentStreams uint32
 }
 
 func NewServer(args ServerArgs) (*Server, error) {
@@ -79,6 +102,7 @@ func NewServer(args ServerArgs) (*Server, error) {
 	}
 
 	s := new(Server)
+	s.lgr = args.Logger
 	s.stopChan = make(chan struct{})
 
 	sealer, err := NewSingleSymmetricKeySealer()
@@ -91,10 +115,19 @@ func NewServer(args ServerArgs) (*Server, error) {
 		scheme = "https"
 	}
 	s.tlsConfig = args.TLSConfig
+	s.drainTimeout = args.DrainTimeout
 
 	s.wg.Add(2)
 	s.grpcListenAddr = args.GrpcListenAddr
-	s.grpcSrv = grpc.NewServer(append([]grpc.ServerOption{grpc.MaxRecvMsgSize(128 * 1024 * 1024)}, args.Options...)...)
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(128 * 1024 * 1024),
+		grpc.ChainUnaryInterceptor(requestIDUnaryInterceptor(), s.drain.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(requestIDStreamInterceptor()),
+	}
+	if args.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(args.MaxConcurrentStreams))
+	}
+	s.grpcSrv = grpc.NewServer(append(opts, args.Options...)...)
 	var chnkSt remotesapi.ChunkStoreServiceServer = NewHttpFSBackedChunkStore(args.Logger, args.HttpHost, args.DBCache, args.FS, scheme, args.ConcurrencyControl, sealer)
 
 	if args.ReadOnly {
@@ -103,11 +136,17 @@ func NewServer(args ServerArgs) (*Server, error) {
 	remotesapi.RegisterChunkStoreServiceServer(s.grpcSrv, chnkSt)
 
 	var handler http.Handler = newFileHandler(args.Logger, args.DBCache, args.FS, args.ReadOnly, sealer)
+	if args.MaxBandwidth > 0 {
+		handler = limitBandwidth(handler, iohelp.NewBandwidthLimiter(args.MaxBandwidth))
+	}
 	if args.HttpInterceptor != nil {
 		handler = args.HttpInterceptor(handler)
 	}
+	handler = withRequestID(handler)
+	// Health checks are not subject to the interceptor, which may require authentication.
+	handler = s.drain.wrapHandler(handler)
 	if args.HttpListenAddr == args.GrpcListenAddr {
-		handler = s.grpcMultiplexHandler(s.grpcSrv, handler)
+		handler = s.grpcMultiplexHandler(s.grpcSrv, handler, args.MaxConcurrentStreams)
 	} else {
 		s.wg.Add(2)
 	}
@@ -117,12 +156,18 @@ func NewServer(args ServerArgs) (*Server, error) {
 		Addr:    args.HttpListenAddr,
 		Handler: handler,
 	}
+	if args.MaxConcurrentStreams > 0 && args.TLSConfig != nil {
+		err = http2.ConfigureServer(&s.httpSrv, &http2.Server{MaxConcurrentStreams: args.MaxConcurrentStreams})
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return s, nil
 }
 
-func (s *Server) grpcMultiplexHandler(grpcSrv *grpc.Server, handler http.Handler) http.Handler {
-	h2s := &http2.Server{}
+func (s *Server) grpcMultiplexHandler(grpcSrv *grpc.Server, handler http.Handler, maxConcurrentStreams uint32) http.Handler {
+	h2s := &http2.Server{MaxConcurrentStreams: maxConcurrentStreams}
 	newHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
 			s.grpcHttpReqsWG.Add(1)
@@ -193,31 +238,36 @@ func (s *Server) Serve(listeners Listeners) {
 	if listeners.grpc != nil {
 		go func() {
 			defer s.wg.Done()
-			logrus.Println("Starting grpc server on", s.grpcListenAddr)
+			s.lgr.WithField("addr", s.grpcListenAddr).Info("starting grpc server")
 			err := s.grpcSrv.Serve(listeners.grpc)
-			logrus.Println("grpc server exited. error:", err)
+			s.lgr.WithError(err).Info("grpc server exited")
 		}()
 		go func() {
 			defer s.wg.Done()
 			<-s.stopChan
-			logrus.Traceln("Calling grpcSrv.GracefulStop")
-			s.grpcSrv.GracefulStop()
-			logrus.Traceln("Finished calling grpcSrv.GracefulStop")
+			s.lgr.Trace("calling grpcSrv.GracefulStop")
+			s.gracefulStopGrpc()
+			s.lgr.Trace("finished calling grpcSrv.GracefulStop")
 		}()
 	}
 
 	go func() {
 		defer s.wg.Done()
-		logrus.Println("Starting http server on", s.httpListenAddr)
+		s.lgr.WithField("addr", s.httpListenAddr).Info("starting http server")
 		err := s.httpSrv.Serve(listeners.http)
-		logrus.Println("http server exited. exit error:", err)
+		s.lgr.WithError(err).Info("http server exited")
 	}()
 	go func() {
 		defer s.wg.Done()
 		<-s.stopChan
-		logrus.Traceln("Calling httpSrv.Shutdown")
-		s.httpSrv.Shutdown(context.Background())
-		logrus.Traceln("Finished calling httpSrv.Shutdown")
+		s.lgr.Trace("calling httpSrv.Shutdown")
+		ctx, cancel := s.drainContext()
+		if err := s.httpSrv.Shutdown(ctx); err != nil {
+			s.lgr.WithError(err).Warn("http server did not drain in time, closing remaining connections")
+			s.httpSrv.Close()
+		}
+		cancel()
+		s.lgr.Trace("finished calling httpSrv.Shutdown")
 
 		// If we are multiplexing HTTP and gRPC requests on the same
 		// listener, we need to stop the gRPC server here as well. We
@@ -225,12 +275,38 @@ func (s *Server) Serve(listeners Listeners) {
 		// here, we guarantee all the handler threads are cleaned up
 		// before we return.
 		if listeners.grpc == nil {
-			logrus.Traceln("Calling grpcSrv.Stop")
+			s.lgr.Trace("calling grpcSrv.Stop")
 			s.grpcSrv.Stop()
 			s.grpcHttpReqsWG.Wait()
-			logrus.Traceln("Finished calling grpcSrv.Stop")
+			s.lgr.Trace("finished calling grpcSrv.Stop")
 		}
 	}()
 
 	s.wg.Wait()
 }
+
+// drainContext returns a context which is canceled when the drain timeout elapses, if the server has one.
+func (s *Server) drainContext() (context.Context, context.CancelFunc) {
+	if s.drainTimeout > 0 {
+		return context.WithTimeout(context.Background(), s.drainTimeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// gracefulStopGrpc gracefully stops the gRPC server, forcefully stopping it if it does not drain in time.
+func (s *Server) gracefulStopGrpc() {
+	ctx, cancel := s.drainContext()
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		s.grpcSrv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.lgr.Warn("grpc server did not drain in time, stopping remaining streams")
+		s.grpcSrv.Stop()
+		<-done
+	}
+}
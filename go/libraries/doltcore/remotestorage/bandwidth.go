@@ -0,0 +1,59 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+// WithMaxBandwidth returns a copy of this chunk store whose table file uploads and chunk downloads are limited to
+// |bytesPerSec| bytes per second in total.
+func (dcs *DoltChunkStore) WithMaxBandwidth(bytesPerSec uint64) *DoltChunkStore {
+	ret := dcs.clone()
+	ret.httpFetcher = rateLimitedFetcher{
+		fetcher: dcs.httpFetcher,
+		lim:     iohelp.NewBandwidthLimiter(bytesPerSec),
+	}
+	// The limit is shared by concurrent downloads, which can each be slower than the minimum throughput.
+	ret.params.ThroughputMinimumBytesPerCheck = 0
+	return ret
+}
+
+// rateLimitedFetcher is an HTTPFetcher which limits the rate of the request bodies it sends and the response bodies
+// it receives.
+type rateLimitedFetcher struct {
+	fetcher HTTPFetcher
+	lim     *rate.Limiter
+}
+
+func (f rateLimitedFetcher) Do(req *http.Request) (*http.Response, error) {
+	fetcher := f.fetcher
+	if fetcher == nil {
+		fetcher = globalHttpFetcher
+	}
+	if req.Body != nil {
+		req.Body = iohelp.NewRateLimitedReader(req.Context(), req.Body, f.lim)
+	}
+	resp, err := fetcher.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = iohelp.NewRateLimitedReader(req.Context(), resp.Body, f.lim)
+	return resp, nil
+}
@@ -132,6 +132,7 @@ type DoltChunkStore struct {
 	stats       cacheStats
 	logger      chunks.DebugLogger
 	wsValidate  bool
+	reads       *readQueue
 }
 
 func NewDoltChunkStoreFromPath(ctx context.Context, nbf *types.NomsBinFormat, path, host string, wsval bool, csClient remotesapi.ChunkStoreServiceClient) (*DoltChunkStore, error) {
@@ -179,6 +180,7 @@ func NewDoltChunkStoreFromPath(ctx context.Context, nbf *types.NomsBinFormat, pa
 		httpFetcher: globalHttpFetcher,
 		params:      defaultRequestParams,
 		wsValidate:  wsval,
+		reads:       newReadQueue(defaultConcurrentReads),
 	}
 	err = cs.loadRoot(ctx)
 	if err != nil {
@@ -229,6 +231,16 @@ func (dcs *DoltChunkStore) WithNetworkRequestParams(params NetworkRequestParams)
 	return ret
 }
 
+// WithMaxConcurrentDownloads returns a copy of this chunk store which downloads at most |n| table file ranges at once.
+func (dcs *DoltChunkStore) WithMaxConcurrentDownloads(n int) *DoltChunkStore {
+	ret := dcs.clone()
+	ret.params.MaximumConcurrentDownloads = n
+	if ret.params.StartingConcurrentDownloads > n {
+		ret.params.StartingConcurrentDownloads = n
+	}
+	return ret
+}
+
 func (dcs *DoltChunkStore) SetLogger(logger chunks.DebugLogger) {
 	dcs.logger = logger
 }
@@ -264,6 +276,11 @@ type CacheStats interface {
 	CacheHits() uint32
 }
 
+// Path returns the host and path of the remote repository, which identify it.
+func (dcs *DoltChunkStore) Path() (string, bool) {
+	return dcs.host + "/" + dcs.repoPath, true
+}
+
 func (dcs *DoltChunkStore) ChunkFetcher(ctx context.Context) nbs.ChunkFetcher {
 	return NewChunkFetcher(ctx, dcs)
 }
@@ -329,7 +346,7 @@ func (dcs *DoltChunkStore) GetManyCompressed(ctx context.Context, hashes hash.Ha
 	}
 
 	if len(notCached) > 0 {
-		err := dcs.readChunksAndCache(ctx, notCached, found)
+		err := dcs.fetchChunks(ctx, notCached, found)
 
 		if err != nil {
 			return err
@@ -339,6 +356,19 @@ func (dcs *DoltChunkStore) GetManyCompressed(ctx context.Context, hashes hash.Ha
 	return nil
 }
 
+// fetchChunks fetches |hashes| from the remote database, once the reads of |dcs| with a higher priority than the
+// chunks.FetchPriority of |ctx| have started. It fails without fetching anything if the FetchBudget of |ctx| is spent.
+func (dcs *DoltChunkStore) fetchChunks(ctx context.Context, hashes []hash.Hash, found func(context.Context, nbs.ToChunker)) error {
+	if FetchBudgetFromContext(ctx).Exceeded() {
+		return ErrFetchBudgetExceeded
+	}
+	if err := dcs.reads.acquire(ctx, chunks.FetchPriorityFromContext(ctx)); err != nil {
+		return err
+	}
+	defer dcs.reads.release()
+	return dcs.readChunksAndCache(ctx, hashes, found)
+}
+
 // GetRange is structurally similar to remotesapi.HttpGetRange, but
 // with added functions.
 type GetRange struct {
@@ -433,6 +463,7 @@ func (gr *GetRange) GetDownloadFunc(ctx context.Context, stats StatsRecorder, he
 			}
 			return url, nil
 		}
+		budget := FetchBudgetFromContext(ctx)
 		rangeLen := gr.RangeLen()
 		resp := reliable.StreamingRangeDownload(ctx, reliable.StreamingRangeRequest{
 			Fetcher: fetcher,
@@ -461,6 +492,9 @@ func (gr *GetRange) GetDownloadFunc(ctx context.Context, stats StatsRecorder, he
 			if err != nil {
 				return err
 			}
+			if err = budget.spend(len(bs)); err != nil {
+				return err
+			}
 			err = resCb(ctx, bs, rang)
 			if err != nil {
 				return err
@@ -1065,9 +1099,11 @@ func (dcs *DoltChunkStore) SupportedOperations() chunks.TableFileStoreOps {
 // WriteTableFile reads a table file from the provided reader and writes it to the chunk store.
 func (dcs *DoltChunkStore) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
 	suffix := ""
-	if strings.HasSuffix(fileId, nbs.ArchiveFileSuffix) {
-		suffix = nbs.ArchiveFileSuffix
-		fileId = strings.TrimSuffix(fileId, nbs.ArchiveFileSuffix)
+	for _, s := range []string{nbs.ArchiveFileSuffix, nbs.DeltaFileSuffix} {
+		if strings.HasSuffix(fileId, s) {
+			suffix = s
+			fileId = strings.TrimSuffix(fileId, s)
+		}
 	}
 
 	fileIdBytes := hash.Parse(fileId)
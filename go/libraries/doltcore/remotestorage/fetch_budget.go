@@ -0,0 +1,78 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// ErrFetchBudgetExceeded is returned by reads which fetch more bytes from remote databases than the FetchBudget of
+// their context allows.
+var ErrFetchBudgetExceeded = errors.New("remote fetch budget exceeded")
+
+// A FetchBudget limits the number of bytes that the reads of a single query fetch from remote databases, so that a
+// query which would read much more of a remote database than expected fails rather than fetching it all over a slow
+// link. Chunks which are already cached don't count against it.
+type FetchBudget struct {
+	max     int64
+	fetched atomic.Int64
+}
+
+// NewFetchBudget returns a FetchBudget which allows |max| bytes to be fetched.
+func NewFetchBudget(max int64) *FetchBudget {
+	return &FetchBudget{max: max}
+}
+
+type fetchBudgetKey struct{}
+
+// WithFetchBudget returns a context in which reads from remote databases are limited by |b|.
+func WithFetchBudget(ctx context.Context, b *FetchBudget) context.Context {
+	return context.WithValue(ctx, fetchBudgetKey{}, b)
+}
+
+// FetchBudgetFromContext returns the FetchBudget of |ctx|, or nil if reads in |ctx| aren't limited.
+func FetchBudgetFromContext(ctx context.Context) *FetchBudget {
+	b, _ := ctx.Value(fetchBudgetKey{}).(*FetchBudget)
+	return b
+}
+
+// Max returns the number of bytes this budget allows to be fetched.
+func (b *FetchBudget) Max() int64 {
+	return b.max
+}
+
+// Fetched returns the number of bytes fetched within this budget.
+func (b *FetchBudget) Fetched() int64 {
+	return b.fetched.Load()
+}
+
+// Exceeded returns whether more bytes were fetched than this budget allows.
+func (b *FetchBudget) Exceeded() bool {
+	return b != nil && b.fetched.Load() > b.max
+}
+
+// spend records that |n| bytes were fetched, and returns ErrFetchBudgetExceeded if that's more than this budget
+// allows. A nil budget allows any number of bytes to be fetched.
+func (b *FetchBudget) spend(n int) error {
+	if b == nil {
+		return nil
+	}
+	if b.fetched.Add(int64(n)) > b.max {
+		return ErrFetchBudgetExceeded
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+// defaultConcurrentReads is the number of reads which fetch chunks that aren't cached from a remote database at once.
+// Each read fetches many table file ranges concurrently, so more reads than this don't fetch any faster over a slow
+// link, and only delay the reads that queries are waiting on most.
+const defaultConcurrentReads = 8
+
+// readQueue limits the number of reads which fetch chunks from a remote database at once. Reads which wait to fetch
+// their chunks are started in order of their chunks.FetchPriority, and in the order they arrived within a priority.
+type readQueue struct {
+	mu      sync.Mutex
+	free    int
+	waiting [chunks.FetchPriorityHigh + 1][]chan struct{}
+}
+
+func newReadQueue(n int) *readQueue {
+	return &readQueue{free: n}
+}
+
+// acquire waits until a read with priority |p| can start, and returns the error of |ctx| if it's canceled first. Every
+// successful call must be followed by a call to release.
+func (q *readQueue) acquire(ctx context.Context, p chunks.FetchPriority) error {
+	p = max(chunks.FetchPriorityPrefetch, min(p, chunks.FetchPriorityHigh))
+
+	q.mu.Lock()
+	if q.free > 0 {
+		q.free--
+		q.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	q.waiting[p] = append(q.waiting[p], ready)
+	q.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if i := slices.Index(q.waiting[p], ready); i >= 0 {
+			q.waiting[p] = slices.Delete(q.waiting[p], i, i+1)
+			return context.Cause(ctx)
+		}
+		// this read was started as |ctx| was canceled, so it's passed on to the next one
+		q.releaseLocked()
+		return context.Cause(ctx)
+	}
+}
+
+// release ends a read, starting the next waiting read with the highest priority.
+func (q *readQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.releaseLocked()
+}
+
+func (q *readQueue) releaseLocked() {
+	for p := len(q.waiting) - 1; p >= 0; p-- {
+		if len(q.waiting[p]) > 0 {
+			next := q.waiting[p][0]
+			q.waiting[p] = q.waiting[p][1:]
+			close(next)
+			return
+		}
+	}
+	q.free++
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+func TestReadQueuePriority(t *testing.T) {
+	ctx := context.Background()
+	q := newReadQueue(1)
+	require.NoError(t, q.acquire(ctx, chunks.FetchPriorityNormal))
+
+	started := make(chan chunks.FetchPriority, 3)
+	wait := func(p chunks.FetchPriority) {
+		go func() {
+			if q.acquire(ctx, p) == nil {
+				started <- p
+			}
+		}()
+		// wait for the read to be queued, so that the order they arrived in is known
+		require.Eventually(t, func() bool {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			return len(q.waiting[p]) > 0
+		}, time.Second, time.Millisecond)
+	}
+	wait(chunks.FetchPriorityPrefetch)
+	wait(chunks.FetchPriorityNormal)
+	wait(chunks.FetchPriorityHigh)
+
+	for _, expected := range []chunks.FetchPriority{chunks.FetchPriorityHigh, chunks.FetchPriorityNormal, chunks.FetchPriorityPrefetch} {
+		q.release()
+		assert.Equal(t, expected, <-started)
+	}
+	q.release()
+	assert.Equal(t, 1, q.free)
+}
+
+func TestReadQueueCancel(t *testing.T) {
+	q := newReadQueue(1)
+	require.NoError(t, q.acquire(context.Background(), chunks.FetchPriorityNormal))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := q.acquire(ctx, chunks.FetchPriorityHigh)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, q.waiting[chunks.FetchPriorityHigh])
+
+	// the canceled read doesn't hold on to the read it was waiting for
+	q.release()
+	require.NoError(t, q.acquire(context.Background(), chunks.FetchPriorityPrefetch))
+	q.release()
+	assert.Equal(t, 1, q.free)
+}
+
+func TestFetchBudget(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, FetchBudgetFromContext(ctx))
+	var unlimited *FetchBudget
+	assert.NoError(t, unlimited.spend(1<<30))
+	assert.False(t, unlimited.Exceeded())
+
+	b := NewFetchBudget(100)
+	ctx = WithFetchBudget(ctx, b)
+	assert.Equal(t, b, FetchBudgetFromContext(ctx))
+	assert.NoError(t, b.spend(60))
+	assert.NoError(t, b.spend(40))
+	assert.False(t, b.Exceeded())
+	assert.ErrorIs(t, b.spend(1), ErrFetchBudgetExceeded)
+	assert.True(t, b.Exceeded())
+	assert.Equal(t, int64(101), b.Fetched())
+}
@@ -16,6 +16,7 @@ package remotestorage
 
 import (
 	"context"
+	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"google.golang.org/grpc"
@@ -36,3 +37,12 @@ func RetryingUnaryClientInterceptor(ctx context.Context, method string, req, rep
 	}
 	return backoff.Retry(doit, grpcBackOff(ctx))
 }
+
+// TimeoutUnaryClientInterceptor returns an interceptor which fails unary calls which take longer than |timeout|.
+func TimeoutUnaryClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotestorage
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	prevTracer, prevPropagator := tracer, otel.GetTextMapPropagator()
+	tracer = tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(sr)).Tracer("test")
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		tracer = prevTracer
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return sr
+}
+
+func spanAttrs(s tracesdk.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingUnaryClientInterceptor(t *testing.T) {
+	sr := recordSpans(t)
+	method := "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/HasChunks"
+
+	var traceparent []string
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "token")
+	err := TracingUnaryClientInterceptor(ctx, method, nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		traceparent = md.Get("traceparent")
+		assert.Equal(t, []string{"token"}, md.Get("authorization"))
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = TracingUnaryClientInterceptor(context.Background(), method, nil, nil, nil, func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "remote is down")
+	})
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "remotesapi.HasChunks", spans[0].Name())
+	attrs := spanAttrs(spans[0])
+	assert.Equal(t, "dolt.services.remotesapi.v1alpha1.ChunkStoreService", attrs["rpc.service"].AsString())
+	assert.Equal(t, int64(codes.OK), attrs["rpc.grpc.status_code"].AsInt64())
+	require.Len(t, traceparent, 1)
+	assert.Contains(t, traceparent[0], spans[0].SpanContext().SpanID().String())
+
+	assert.Equal(t, otelcodes.Error, spans[1].Status().Code)
+	assert.Equal(t, int64(codes.Unavailable), spanAttrs(spans[1])["rpc.grpc.status_code"].AsInt64())
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	msgs int
+}
+
+func (s *fakeClientStream) RecvMsg(interface{}) error {
+	if s.msgs == 0 {
+		return io.EOF
+	}
+	s.msgs--
+	return nil
+}
+
+func TestTracingStreamClientInterceptor(t *testing.T) {
+	sr := recordSpans(t)
+	method := "/dolt.services.remotesapi.v1alpha1.ChunkStoreService/StreamDownloadLocations"
+	cs, err := TracingStreamClientInterceptor(context.Background(), &grpc.StreamDesc{}, nil, method, func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &fakeClientStream{msgs: 2}, nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, cs.RecvMsg(nil))
+	require.NoError(t, cs.RecvMsg(nil))
+	assert.Empty(t, sr.Ended())
+	assert.Equal(t, io.EOF, cs.RecvMsg(nil))
+	assert.Equal(t, io.EOF, cs.RecvMsg(nil))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "remotesapi.StreamDownloadLocations", spans[0].Name())
+	assert.Equal(t, otelcodes.Unset, spans[0].Status().Code)
+}
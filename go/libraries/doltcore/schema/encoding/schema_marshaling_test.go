@@ -279,6 +279,83 @@ func TestSchemaMarshalling(t *testing.T) {
 	}
 }
 
+func TestSchemaMarshallingDescendingIndex(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_DOLT
+	vrw := getTestVRW(nbf)
+
+	sch := createTestSchema()
+	_, err := sch.Indexes().AddIndexByColTags("idx_age_last", []uint64{3, 2}, nil, schema.IndexProperties{
+		IsUserDefined: true,
+		Descending:    []bool{true, false},
+	})
+	require.NoError(t, err)
+
+	v, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchema(ctx, nbf, v)
+	require.NoError(t, err)
+	assert.Equal(t, sch, s)
+
+	idx := s.Indexes().GetByName("idx_age_last")
+	require.NotNil(t, idx)
+	assert.Equal(t, []bool{true, false}, idx.Descending())
+	assert.Nil(t, s.Indexes().GetByName("idx_age").Descending())
+
+	kd := idx.Schema().GetKeyDescriptor(nil)
+	assert.True(t, kd.IsDescending(0))
+	assert.False(t, kd.IsDescending(1))
+	assert.False(t, kd.IsDescending(2))
+}
+
+func TestSchemaMarshallingInvisibleIndex(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_DOLT
+	vrw := getTestVRW(nbf)
+
+	sch := createTestSchema()
+	_, err := sch.Indexes().AddIndexByColTags("idx_age_hidden", []uint64{3}, nil, schema.IndexProperties{
+		IsUserDefined: true,
+		IsInvisible:   true,
+	})
+	require.NoError(t, err)
+
+	v, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchema(ctx, nbf, v)
+	require.NoError(t, err)
+	assert.Equal(t, sch, s)
+
+	assert.True(t, s.Indexes().GetByName("idx_age_hidden").IsInvisible())
+	assert.False(t, s.Indexes().GetByName("idx_age").IsInvisible())
+}
+
+func TestSchemaMarshallingPartitioning(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_DOLT
+	vrw := getTestVRW(nbf)
+
+	sch := createTestSchema()
+	sch.SetPartitioning(&schema.Partitioning{
+		Method: schema.PartitionMethodRange,
+		Column: "id",
+		Partitions: []schema.PartitionDefinition{
+			{Name: "p0", LessThan: "100"},
+			{Name: "p1", LessThan: schema.PartitionMaxValue},
+		},
+	})
+
+	v, err := MarshalSchema(ctx, vrw, sch)
+	require.NoError(t, err)
+	s, err := UnmarshalSchema(ctx, nbf, v)
+	require.NoError(t, err)
+	assert.Equal(t, sch, s)
+	assert.True(t, schema.SchemasAreEqual(sch, s))
+
+	s.SetPartitioning(nil)
+	assert.False(t, schema.SchemasAreEqual(sch, s))
+}
+
 func getTypeinfo(t *testing.T) (ti []typeinfo.TypeInfo) {
 	st := getSqlTypes()
 	ti = make([]typeinfo.TypeInfo, len(st))
@@ -58,6 +58,14 @@ func serializeSchemaAsFlatbuffer(sch schema.Schema) ([]byte, error) {
 	indexes := serializeSecondaryIndexes(b, sch, sch.Indexes().AllIndexes())
 	checks := serializeChecks(b, sch.Checks().AllChecks())
 	comment := b.CreateString(sch.GetComment())
+	partitioningStr, err := schema.MarshalPartitioning(sch.GetPartitioning())
+	if err != nil {
+		return nil, err
+	}
+	var partitioning fb.UOffsetT
+	if partitioningStr != "" {
+		partitioning = b.CreateString(partitioningStr)
+	}
 
 	var hasFeaturesAfterTryAccessors bool
 	for _, col := range sch.GetAllCols().GetColumns() {
@@ -77,6 +85,10 @@ func serializeSchemaAsFlatbuffer(sch schema.Schema) ([]byte, error) {
 		serial.TableSchemaAddComment(b, comment)
 		hasFeaturesAfterTryAccessors = true
 	}
+	if partitioningStr != "" {
+		serial.TableSchemaAddPartitioning(b, partitioning)
+		hasFeaturesAfterTryAccessors = true
+	}
 	if hasFeaturesAfterTryAccessors {
 		serial.TableSchemaAddHasFeaturesAfterTryAccessors(b, hasFeaturesAfterTryAccessors)
 	}
@@ -131,6 +143,12 @@ func deserializeSchemaFromFlatbuffer(ctx context.Context, buf []byte) (schema.Sc
 	sch.SetCollation(schema.Collation(s.Collation()))
 	sch.SetComment(string(s.Comment()))
 
+	partitioning, err := schema.UnmarshalPartitioning(string(s.Partitioning()))
+	if err != nil {
+		return nil, err
+	}
+	sch.SetPartitioning(partitioning)
+
 	return sch, nil
 }
 
@@ -411,6 +429,17 @@ func serializeSecondaryIndexes(b *fb.Builder, sch schema.Schema, indexes []schem
 		}
 		po := b.EndVector(len(prefixLengths))
 
+		// serialize column directions, only for indexes with descending columns
+		var do fb.UOffsetT
+		descending := idx.Descending()
+		if len(descending) > 0 {
+			serial.IndexStartDescendingColumnsVector(b, len(descending))
+			for j := len(descending) - 1; j >= 0; j-- {
+				b.PrependBool(descending[j])
+			}
+			do = b.EndVector(len(descending))
+		}
+
 		var ftInfo fb.UOffsetT
 		if idx.IsFullText() {
 			ftInfo = serializeFullTextInfo(b, idx)
@@ -439,6 +468,12 @@ func serializeSecondaryIndexes(b *fb.Builder, sch schema.Schema, indexes []schem
 			serial.IndexAddVectorKey(b, true)
 			serial.IndexAddVectorInfo(b, vectorInfo)
 		}
+		if len(descending) > 0 {
+			serial.IndexAddDescendingColumns(b, do)
+		}
+		if idx.IsInvisible() {
+			serial.IndexAddInvisible(b, true)
+		}
 		offs[i] = serial.IndexEnd(b)
 	}
 
@@ -479,6 +514,7 @@ func deserializeSecondaryIndexes(sch schema.Schema, s *serial.TableSchema) error
 			Comment:            string(idx.Comment()),
 			FullTextProperties: fti,
 			VectorProperties:   vi,
+			IsInvisible:        idx.Invisible(),
 		}
 
 		tags := make([]uint64, idx.IndexColumnsLength())
@@ -500,6 +536,13 @@ func deserializeSecondaryIndexes(sch schema.Schema, s *serial.TableSchema) error
 			}
 		}
 
+		if n := idx.DescendingColumnsLength(); n > 0 {
+			props.Descending = make([]bool, n)
+			for j := range props.Descending {
+				props.Descending[j] = idx.DescendingColumns(j)
+			}
+		}
+
 		_, err = sch.Indexes().AddIndexByColTags(name, tags, prefixLengths, props)
 		if err != nil {
 			return err
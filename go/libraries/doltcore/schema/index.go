@@ -66,6 +66,12 @@ type Index interface {
 	FullTextProperties() FullTextProperties
 	// VectorProperties returns all properties belonging to a vector index.
 	VectorProperties() VectorProperties
+	// Descending returns whether each of the indexed columns is stored in descending order. Returns nil if every
+	// column is ascending.
+	Descending() []bool
+	// IsInvisible returns whether the index is hidden from the optimizer. Invisible indexes are still maintained on
+	// writes, so they can be made visible again without a rebuild.
+	IsInvisible() bool
 }
 
 var _ Index = (*indexImpl)(nil)
@@ -84,6 +90,8 @@ type indexImpl struct {
 	prefixLengths    []uint16
 	fullTextProps    FullTextProperties
 	vectorProperties VectorProperties
+	descending       []bool
+	isInvisible      bool
 }
 
 func NewIndex(name string, tags, allTags []uint64, indexColl IndexCollection, props IndexProperties) Index {
@@ -105,6 +113,8 @@ func NewIndex(name string, tags, allTags []uint64, indexColl IndexCollection, pr
 		comment:          props.Comment,
 		fullTextProps:    props.FullTextProperties,
 		vectorProperties: props.VectorProperties,
+		descending:       props.Descending,
+		isInvisible:      props.IsInvisible,
 	}
 }
 
@@ -150,6 +160,8 @@ func (ix *indexImpl) Equals(other Index) bool {
 	return ix.IsUnique() == other.IsUnique() &&
 		ix.IsSpatial() == other.IsSpatial() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
+		compareDirections(ix.Descending(), other.Descending()) &&
+		ix.IsInvisible() == other.IsInvisible() &&
 		ix.Comment() == other.Comment() &&
 		ix.Name() == other.Name()
 }
@@ -172,6 +184,8 @@ func (ix *indexImpl) DeepEquals(other Index) bool {
 	return ix.IsUnique() == other.IsUnique() &&
 		ix.IsSpatial() == other.IsSpatial() &&
 		compareUint16Slices(ix.PrefixLengths(), other.PrefixLengths()) &&
+		compareDirections(ix.Descending(), other.Descending()) &&
+		ix.IsInvisible() == other.IsInvisible() &&
 		ix.Comment() == other.Comment() &&
 		ix.Name() == other.Name()
 }
@@ -192,6 +206,17 @@ func compareUint16Slices(a, b []uint16) bool {
 	return true
 }
 
+// compareDirections returns true if |a| and |b| describe the same column sort directions. A missing direction is
+// ascending.
+func compareDirections(a, b []bool) bool {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		if (i < len(a) && a[i]) != (i < len(b) && b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // GetColumn implements Index.
 func (ix *indexImpl) GetColumn(tag uint64) (Column, bool) {
 	return ix.indexColl.colColl.GetByTag(tag)
@@ -272,6 +297,7 @@ func (ix *indexImpl) Schema() Schema {
 		indexCollection:     NewIndexCollection(nil, nil),
 		checkCollection:     NewCheckCollection(),
 		contentHashedFields: contentHashedFields,
+		descendingFields:    ix.Descending(),
 	}
 }
 
@@ -327,6 +353,25 @@ func (ix *indexImpl) VectorProperties() VectorProperties {
 	return ix.vectorProperties
 }
 
+// Descending implements Index.
+func (ix *indexImpl) Descending() []bool {
+	descending := ix.descending
+	if len(descending) > len(ix.tags) {
+		descending = descending[:len(ix.tags)]
+	}
+	for _, d := range descending {
+		if d {
+			return descending
+		}
+	}
+	return nil
+}
+
+// IsInvisible implements Index.
+func (ix *indexImpl) IsInvisible() bool {
+	return ix.isInvisible
+}
+
 // copy returns an exact copy of the calling index.
 func (ix *indexImpl) copy() *indexImpl {
 	newIx := *ix
@@ -338,6 +383,10 @@ func (ix *indexImpl) copy() *indexImpl {
 		newIx.prefixLengths = make([]uint16, len(ix.prefixLengths))
 		_ = copy(newIx.prefixLengths, ix.prefixLengths)
 	}
+	if len(ix.descending) > 0 {
+		newIx.descending = make([]bool, len(ix.descending))
+		_ = copy(newIx.descending, ix.descending)
+	}
 	if len(newIx.fullTextProps.KeyPositions) > 0 {
 		newIx.fullTextProps.KeyPositions = make([]uint16, len(ix.fullTextProps.KeyPositions))
 		_ = copy(newIx.fullTextProps.KeyPositions, ix.fullTextProps.KeyPositions)
@@ -70,6 +70,8 @@ type IndexCollection interface {
 	RemoveIndex(indexName string) (Index, error)
 	// RenameIndex renames an index in the table metadata.
 	RenameIndex(oldName, newName string) (Index, error)
+	// SetIndexInvisible sets whether an index is hidden from the optimizer in the table metadata.
+	SetIndexInvisible(indexName string, invisible bool) (Index, error)
 	//SetPks changes the pks or pk ordinals
 	SetPks([]uint64) error
 	// ContainsFullTextIndex returns whether the collection contains at least one Full-Text index.
@@ -87,6 +89,10 @@ type IndexProperties struct {
 	FullTextProperties
 	IsVector bool
 	VectorProperties
+	// Descending holds the sort direction of each indexed column, true for descending. Nil means all ascending.
+	Descending []bool
+	// IsInvisible hides the index from the optimizer while it continues to be maintained.
+	IsInvisible bool
 }
 
 type FullTextProperties struct {
@@ -231,6 +237,8 @@ func (ixc *indexCollectionImpl) AddIndexByColTags(indexName string, tags []uint6
 		prefixLengths:    prefixLengths,
 		fullTextProps:    props.FullTextProperties,
 		vectorProperties: props.VectorProperties,
+		descending:       props.Descending,
+		isInvisible:      props.IsInvisible,
 	}
 	ixc.indexes[lowerName] = index
 	for _, tag := range tags {
@@ -258,6 +266,8 @@ func (ixc *indexCollectionImpl) UnsafeAddIndexByColTags(indexName string, tags [
 		comment:       props.Comment,
 		prefixLengths: prefixLengths,
 		fullTextProps: props.FullTextProperties,
+		descending:    props.Descending,
+		isInvisible:   props.IsInvisible,
 	}
 	ixc.indexes[strings.ToLower(indexName)] = index
 	for _, tag := range tags {
@@ -445,6 +455,8 @@ func (ixc *indexCollectionImpl) Merge(indexes ...Index) {
 				comment:       index.Comment(),
 				prefixLengths: index.PrefixLengths(),
 				fullTextProps: index.FullTextProperties(),
+				descending:    index.Descending(),
+				isInvisible:   index.IsInvisible(),
 			}
 			ixc.AddIndex(newIndex)
 		}
@@ -487,6 +499,23 @@ func (ixc *indexCollectionImpl) RenameIndex(oldName, newName string) (Index, err
 	return index, nil
 }
 
+func (ixc *indexCollectionImpl) SetIndexInvisible(indexName string, invisible bool) (Index, error) {
+	index, ok := ixc.indexes[strings.ToLower(indexName)]
+	if !ok {
+		return nil, fmt.Errorf("`%s` does not exist as an index for this table", indexName)
+	}
+	index.isInvisible = invisible
+	// indexes by column may be distinct copies of the same index
+	for _, tag := range index.tags {
+		for _, tagIndex := range ixc.colTagToIndex[tag] {
+			if tagIndex.name == index.name {
+				tagIndex.isInvisible = invisible
+			}
+		}
+	}
+	return index, nil
+}
+
 func (ixc *indexCollectionImpl) columnNamesToTags(cols []string) ([]uint64, bool) {
 	tags := make([]uint64, len(cols))
 	for i, colName := range cols {
@@ -430,6 +430,28 @@ func TestIndexCollectionRenameIndex(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIndexCollectionSetIndexInvisible(t *testing.T) {
+	colColl := NewColCollection(
+		NewColumn("pk1", 1, types.IntKind, true, NotNullConstraint{}),
+		NewColumn("v1", 2, types.IntKind, false),
+	)
+	indexColl := NewIndexCollection(colColl, nil)
+	_, err := indexColl.AddIndexByColTags("idx_a", []uint64{2}, nil, IndexProperties{IsUserDefined: true})
+	require.NoError(t, err)
+
+	index, err := indexColl.SetIndexInvisible("IDX_A", true)
+	require.NoError(t, err)
+	assert.True(t, index.IsInvisible())
+	assert.True(t, indexColl.GetByName("idx_a").IsInvisible())
+
+	index, err = indexColl.SetIndexInvisible("idx_a", false)
+	require.NoError(t, err)
+	assert.False(t, index.IsInvisible())
+
+	_, err = indexColl.SetIndexInvisible("idx_b", true)
+	assert.Error(t, err)
+}
+
 func TestIndexCollectionDuplicateIndexes(t *testing.T) {
 	colColl := NewColCollection(
 		NewColumn("pk1", 1, types.IntKind, true, NotNullConstraint{}),
@@ -0,0 +1,163 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PartitionMethod is the method used to assign rows to the partitions of a table.
+type PartitionMethod string
+
+const (
+	// PartitionMethodRange assigns rows to partitions by comparing the partitioning column to each partition's upper bound.
+	PartitionMethodRange PartitionMethod = "RANGE"
+	// PartitionMethodKey spreads rows evenly across a fixed number of partitions.
+	PartitionMethodKey PartitionMethod = "KEY"
+)
+
+// PartitionMaxValue is the upper bound of a RANGE partition with no upper bound.
+const PartitionMaxValue = "MAXVALUE"
+
+// Partitioning describes the logical partitions of a table. Partitions don't change how a table is stored, every
+// table is still a single map ordered by its primary key. They are used to split scans of the table along the
+// partition boundaries.
+type Partitioning struct {
+	Method PartitionMethod `json:"method"`
+	// Column is the partitioning column, which must be the leading column of the primary key
+	Column string `json:"column"`
+	// Partitions are the RANGE partitions in increasing order of their upper bounds
+	Partitions []PartitionDefinition `json:"partitions,omitempty"`
+	// NumPartitions is the number of KEY partitions
+	NumPartitions int `json:"num_partitions,omitempty"`
+}
+
+// PartitionDefinition is a single RANGE partition, holding the rows whose partitioning column is less than LessThan.
+type PartitionDefinition struct {
+	Name string `json:"name"`
+	// LessThan is an integer literal, or PartitionMaxValue
+	LessThan string `json:"less_than"`
+}
+
+// UpperBound returns the exclusive upper bound of this partition, and false if it has none.
+func (pd PartitionDefinition) UpperBound() (int64, bool) {
+	if strings.EqualFold(pd.LessThan, PartitionMaxValue) {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(pd.LessThan, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v, true
+}
+
+// Validate returns an error if this partitioning can't be applied to |sch|.
+func (p *Partitioning) Validate(sch Schema) error {
+	pkCols := sch.GetPKCols()
+	if pkCols.Size() == 0 || !strings.EqualFold(pkCols.GetByIndex(0).Name, p.Column) {
+		return fmt.Errorf("partitioning column '%s' must be the first column of the primary key", p.Column)
+	}
+
+	switch p.Method {
+	case PartitionMethodRange:
+		if len(p.Partitions) == 0 {
+			return fmt.Errorf("RANGE partitioning requires at least one partition definition")
+		}
+		names := make(map[string]struct{}, len(p.Partitions))
+		var prev *int64
+		for i, pd := range p.Partitions {
+			if _, ok := names[strings.ToLower(pd.Name)]; ok {
+				return fmt.Errorf("duplicate partition name '%s'", pd.Name)
+			}
+			names[strings.ToLower(pd.Name)] = struct{}{}
+
+			bound, ok := pd.UpperBound()
+			if !ok {
+				if !strings.EqualFold(pd.LessThan, PartitionMaxValue) {
+					return fmt.Errorf("partition '%s' has a non-integer upper bound '%s'", pd.Name, pd.LessThan)
+				}
+				if i != len(p.Partitions)-1 {
+					return fmt.Errorf("MAXVALUE can only be used in the last partition definition")
+				}
+				continue
+			}
+			if prev != nil && bound <= *prev {
+				return fmt.Errorf("VALUES LESS THAN value must be strictly increasing for each partition")
+			}
+			prev = &bound
+		}
+	case PartitionMethodKey:
+		if p.NumPartitions < 1 {
+			return fmt.Errorf("number of partitions must be at least 1")
+		}
+	default:
+		return fmt.Errorf("unsupported partitioning method '%s'", p.Method)
+	}
+	return nil
+}
+
+// Equals returns whether |p| and |other| describe the same partitions.
+func (p *Partitioning) Equals(other *Partitioning) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	if p.Method != other.Method || !strings.EqualFold(p.Column, other.Column) ||
+		p.NumPartitions != other.NumPartitions || len(p.Partitions) != len(other.Partitions) {
+		return false
+	}
+	for i := range p.Partitions {
+		if p.Partitions[i] != other.Partitions[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a copy of |p| that can be modified independently.
+func (p *Partitioning) Copy() *Partitioning {
+	if p == nil {
+		return nil
+	}
+	cp := *p
+	cp.Partitions = append([]PartitionDefinition(nil), p.Partitions...)
+	return &cp
+}
+
+// MarshalPartitioning encodes |p| for storage with a table's schema.
+func MarshalPartitioning(p *Partitioning) (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	bs, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// UnmarshalPartitioning decodes a partitioning encoded with MarshalPartitioning. Returns nil if |s| is empty.
+func UnmarshalPartitioning(s string) (*Partitioning, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var p Partitioning
+	if err := json.Unmarshal([]byte(s), &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
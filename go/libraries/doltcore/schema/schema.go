@@ -101,6 +101,12 @@ type Schema interface {
 	// SetComment sets the table's comment.
 	SetComment(comment string)
 
+	// GetPartitioning returns the table's partitioning, or nil if it isn't partitioned.
+	GetPartitioning() *Partitioning
+
+	// SetPartitioning sets the table's partitioning.
+	SetPartitioning(partitioning *Partitioning)
+
 	// Copy returns a copy of this Schema that can be safely modified independently.
 	Copy() Schema
 }
@@ -207,6 +213,10 @@ func SchemasAreEqual(sch1, sch2 Schema) bool {
 		return false
 	}
 
+	if !sch1.GetPartitioning().Equals(sch2.GetPartitioning()) {
+		return false
+	}
+
 	return sch1.Indexes().Equals(sch2.Indexes())
 }
 
@@ -47,6 +47,9 @@ type schemaImpl struct {
 	collation                  Collation
 	contentHashedFields        []uint64
 	comment                    string
+	partitioning               *Partitioning
+	// descendingFields holds the sort direction of the leading key columns of an index schema, true for descending
+	descendingFields []bool
 }
 
 var _ Schema = (*schemaImpl)(nil)
@@ -324,6 +327,14 @@ func (si *schemaImpl) SetComment(comment string) {
 	si.comment = comment
 }
 
+func (si *schemaImpl) GetPartitioning() *Partitioning {
+	return si.partitioning
+}
+
+func (si *schemaImpl) SetPartitioning(partitioning *Partitioning) {
+	si.partitioning = partitioning
+}
+
 // GetAllCols gets the collection of all columns (pk and non-pk)
 func (si *schemaImpl) GetAllCols() *ColCollection {
 	return si.allCols
@@ -554,15 +565,17 @@ func (si *schemaImpl) getKeyColumnsDescriptor(vs val.ValueStore, convertAddressC
 		return
 	})
 
+	var cmp val.TupleComparator
 	if useCollations {
 		if len(collations) != len(tt) {
 			panic(fmt.Errorf("cannot create tuple descriptor from %d collations and %d types", len(collations), len(tt)))
 		}
-		cmp := CollationTupleComparator{Collations: collations}
-		return val.NewTupleDescriptorWithArgs(val.TupleDescriptorArgs{Comparator: cmp, Handlers: handlers}, tt...)
-	} else {
-		return val.NewTupleDescriptorWithArgs(val.TupleDescriptorArgs{Handlers: handlers}, tt...)
+		cmp = CollationTupleComparator{Collations: collations}
+	}
+	if len(si.descendingFields) > 0 {
+		cmp = val.NewDescendingTupleComparator(cmp, si.descendingFields)
 	}
+	return val.NewTupleDescriptorWithArgs(val.TupleDescriptorArgs{Comparator: cmp, Handlers: handlers}, tt...)
 }
 
 // GetValueDescriptor implements the Schema interface.
@@ -687,6 +700,7 @@ func (si schemaImpl) Copy() Schema {
 
 	si.indexCollection = si.indexCollection.Copy()
 	si.checkCollection = si.checkCollection.Copy()
+	si.partitioning = si.partitioning.Copy()
 
 	return &si
 }
@@ -77,6 +77,22 @@ const (
 	DefaultCompressionLevel          = 0
 )
 
+// The values of MetricsEmitterConfig.Type.
+const (
+	MetricsEmitterStatsD = "statsd"
+	MetricsEmitterOTLP   = "otlp"
+)
+
+// The values of AuditLogConfig.Target and AuditLogConfig.Queries.
+const (
+	AuditLogTargetFile  = "file"
+	AuditLogTargetTable = "table"
+	AuditLogQueriesNone = "none"
+	AuditLogQueriesDDL  = "ddl"
+	AuditLogQueriesDML  = "dml"
+	AuditLogQueriesAll  = "all"
+)
+
 func ptr[T any](t T) *T {
 	return &t
 }
@@ -125,6 +141,100 @@ type ClusterStandbyRemoteConfig interface {
 	RemoteURLTemplate() string
 }
 
+// ExternalFunctionConfig configures a SQL function which sql-server evaluates by calling an external service that
+// implements the udfapi ExternalFunctionService gRPC service.
+type ExternalFunctionConfig interface {
+	// Name is the name the function is called by in SQL.
+	Name() string
+	// Endpoint is the address of the gRPC service evaluating the function.
+	Endpoint() string
+	// Returns is the SQL type of the function's result.
+	Returns() string
+	// Timeout is how long a single call to the service may take.
+	Timeout() time.Duration
+	// BatchSize is the maximum number of rows sent to the service in a single call.
+	BatchSize() int
+	// FailureThreshold is the number of consecutive failed calls after which calls to the service are rejected
+	// without being attempted.
+	FailureThreshold() int
+	// Cooldown is how long calls are rejected for once FailureThreshold is reached.
+	Cooldown() time.Duration
+}
+
+// ExternalTableCredentialConfig configures a credential which federated external tables may name with the cred
+// parameter of their location. The credential's password is read from the environment of the server, and is only
+// sent to the configured hosts.
+type ExternalTableCredentialConfig interface {
+	// Name is the name locations refer to the credential by.
+	Name() string
+	// Hosts are the host:port addresses of the remote databases the credential may be used for. A host without a
+	// port matches any port.
+	Hosts() []string
+}
+
+// MetricsEmitterConfig configures a metrics emitter, which periodically pushes the server's metrics to a telemetry
+// backend, in addition to them being available for Prometheus to scrape.
+type MetricsEmitterConfig interface {
+	// Type is the protocol metrics are pushed with: "statsd" or "otlp".
+	Type() string
+	// Endpoint is the host:port of the StatsD server, or the URL of the OTLP/HTTP metrics endpoint.
+	Endpoint() string
+	// Prefix is prepended, followed by a ".", to the name of each metric sent to StatsD.
+	Prefix() string
+	// Headers are added to each request sent to the OTLP endpoint, such as for authentication.
+	Headers() map[string]string
+	// Interval is how often metrics are pushed.
+	Interval() time.Duration
+}
+
+// AuditLogConfig configures the audit log, which records the connections to sql-server and the queries they run.
+type AuditLogConfig interface {
+	// Target is where entries are written: "file" appends them as JSON lines to the file at Path, and "table" keeps
+	// the most recent MaxEntries of them in memory, where they can be queried through the dolt_audit_log system table.
+	Target() string
+	// Path is the file entries are written to when Target is "file".
+	Path() string
+	// Queries is which queries are recorded: "none", "ddl", "dml" for DDL and statements which write data, or "all".
+	// Connection events are always recorded.
+	Queries() string
+	// MaxSizeMB is the size the file at Path may grow to before it is rotated, or 0 to never rotate it.
+	MaxSizeMB() int
+	// MaxFiles is the number of rotated files which are kept, in addition to the file at Path.
+	MaxFiles() int
+	// MaxEntries is the number of entries kept in memory when Target is "table".
+	MaxEntries() int
+}
+
+// QuerySchedulerConfig configures the query scheduler, which limits how many statements run at once and shares the
+// statements which may run between the databases they run against, so that one database's queries can't starve the
+// others.
+type QuerySchedulerConfig interface {
+	// MaxConcurrentQueries is the number of statements which may run at once across all databases, or 0 for no limit.
+	MaxConcurrentQueries() int
+	// QueueTimeout is how long a statement waits to run before it fails, or 0 to wait until it is killed.
+	QueueTimeout() time.Duration
+	// DefaultWeight is the weight of databases which aren't listed in Databases.
+	DefaultWeight() int
+	// DefaultMaxConcurrentQueries is the number of statements which may run at once against each database which
+	// isn't listed in Databases, or 0 for no limit.
+	DefaultMaxConcurrentQueries() int
+	// Databases are the weights and limits of individual databases.
+	Databases() []QuerySchedulerDatabaseConfig
+}
+
+// QuerySchedulerDatabaseConfig is the weight and limit of a database in the query scheduler. While statements are
+// waiting to run against several databases, each database is given a share of the statements which start running in
+// proportion to its weight.
+type QuerySchedulerDatabaseConfig interface {
+	// Name is the name of the database, which applies to all of its branches.
+	Name() string
+	// Weight is the share of statements given to the database relative to the other databases.
+	Weight() int
+	// MaxConcurrentQueries is the number of statements which may run at once against the database, or 0 for no
+	// limit.
+	MaxConcurrentQueries() int
+}
+
 type JwksConfig struct {
 	Name        string            `yaml:"name"`
 	LocationUrl string            `yaml:"location_url"`
@@ -192,6 +302,8 @@ type ServerConfig interface {
 	MetricsLabels() map[string]string
 	MetricsHost() string
 	MetricsPort() int
+	// MetricsEmitters returns the emitters which push metrics to telemetry backends.
+	MetricsEmitters() []MetricsEmitterConfig
 	// PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 	// JSON string.
 	PrivilegeFilePath() string
@@ -214,6 +326,9 @@ type ServerConfig interface {
 	RemotesapiPort() *int
 	// RemotesapiReadOnly is true if the remotesapi interface should be read only.
 	RemotesapiReadOnly() *bool
+	// RemotesapiMaxBandwidth limits the table files uploaded to and downloaded from the remotesapi interface to a
+	// number of bytes per second, such as "10MB".
+	RemotesapiMaxBandwidth() *string
 	// ClusterConfig is the configuration for clustering in this sql-server.
 	ClusterConfig() ClusterConfig
 	// EventSchedulerStatus is the configuration for enabling or disabling the event scheduler in this server.
@@ -222,6 +337,14 @@ type ServerConfig interface {
 	ValueSet(value string) bool
 	// AutoGCBehavior defines parameters around how auto-GC works for the running server.
 	AutoGCBehavior() AutoGCBehavior
+	// ExternalFunctions returns the functions this server evaluates by calling out to external services.
+	ExternalFunctions() []ExternalFunctionConfig
+	// ExternalTableCredentials returns the credentials federated external tables may use.
+	ExternalTableCredentials() []ExternalTableCredentialConfig
+	// AuditLog returns the configuration of the audit log, or nil if it is disabled.
+	AuditLog() AuditLogConfig
+	// QueryScheduler returns the configuration of the query scheduler, or nil if it is disabled.
+	QueryScheduler() QuerySchedulerConfig
 }
 
 // DefaultServerConfig creates a `*ServerConfig` that has all of the options set to their default values.
@@ -302,6 +425,21 @@ func ValidateConfig(config ServerConfig) error {
 	if config.RequireSecureTransport() && config.TLSCert() == "" && config.TLSKey() == "" {
 		return fmt.Errorf("require_secure_transport can only be `true` when a tls_key and tls_cert are provided.")
 	}
+	if err := ValidateExternalFunctions(config.ExternalFunctions()); err != nil {
+		return err
+	}
+	if err := ValidateExternalTableCredentials(config.ExternalTableCredentials()); err != nil {
+		return err
+	}
+	if err := ValidateAuditLogConfig(config.AuditLog()); err != nil {
+		return err
+	}
+	if err := ValidateQuerySchedulerConfig(config.QueryScheduler()); err != nil {
+		return err
+	}
+	if err := ValidateMetricsEmitters(config.MetricsEmitters()); err != nil {
+		return err
+	}
 	return ValidateClusterConfig(config.ClusterConfig())
 }
 
@@ -331,6 +469,7 @@ const (
 	MetricsLabelsKey                = "metrics_labels"
 	MetricsHostKey                  = "metrics_host"
 	MetricsPortKey                  = "metrics_port"
+	MetricsEmittersKey              = "metrics_emitters"
 	PrivilegeFilePathKey            = "privilege_file_path"
 	BranchControlFilePathKey        = "branch_control_file_path"
 	UserVarsKey                     = "user_vars"
@@ -340,8 +479,13 @@ const (
 	SocketKey                       = "socket"
 	RemotesapiPortKey               = "remotesapi_port"
 	RemotesapiReadOnlyKey           = "remotesapi_read_only"
+	RemotesapiMaxBandwidthKey       = "remotesapi_max_bandwidth"
 	ClusterConfigKey                = "cluster_config"
 	EventSchedulerKey               = "event_scheduler"
+	ExternalFunctionsKey            = "external_functions"
+	ExternalTableCredentialsKey     = "external_table_credentials"
+	AuditLogKey                     = "audit_log"
+	QuerySchedulerKey               = "query_scheduler"
 )
 
 type SystemVariableTarget interface {
@@ -381,6 +525,136 @@ func ApplySystemVariables(ctx *sql.Context, cfg ServerConfig, sysVarTarget Syste
 	return nil
 }
 
+func ValidateExternalFunctions(fns []ExternalFunctionConfig) error {
+	seen := make(map[string]struct{}, len(fns))
+	for i, fn := range fns {
+		if fn.Name() == "" {
+			return fmt.Errorf("external_functions[%d]: name: Cannot be empty", i)
+		}
+		if _, ok := seen[strings.ToLower(fn.Name())]; ok {
+			return fmt.Errorf("external_functions[%d]: name: \"%s\" is defined more than once", i, fn.Name())
+		}
+		seen[strings.ToLower(fn.Name())] = struct{}{}
+		if fn.Endpoint() == "" {
+			return fmt.Errorf("external_functions[%d]: endpoint: Cannot be empty", i)
+		}
+		switch strings.ToLower(fn.Returns()) {
+		case "int", "integer", "bigint", "float", "double", "text", "varchar", "longtext":
+		default:
+			return fmt.Errorf("external_functions[%d]: returns: is \"%s\" but must be one of int, bigint, float, double or text", i, fn.Returns())
+		}
+		if fn.BatchSize() < 1 {
+			return fmt.Errorf("external_functions[%d]: batch_size: is %d but must be >= 1", i, fn.BatchSize())
+		}
+	}
+	return nil
+}
+
+func ValidateExternalTableCredentials(creds []ExternalTableCredentialConfig) error {
+	seen := make(map[string]struct{}, len(creds))
+	for i, cred := range creds {
+		if cred.Name() == "" {
+			return fmt.Errorf("external_table_credentials[%d]: name: Cannot be empty", i)
+		}
+		if _, ok := seen[strings.ToLower(cred.Name())]; ok {
+			return fmt.Errorf("external_table_credentials[%d]: name: \"%s\" is defined more than once", i, cred.Name())
+		}
+		seen[strings.ToLower(cred.Name())] = struct{}{}
+		if len(cred.Hosts()) == 0 {
+			return fmt.Errorf("external_table_credentials[%d]: hosts: Cannot be empty", i)
+		}
+		for j, host := range cred.Hosts() {
+			if host == "" {
+				return fmt.Errorf("external_table_credentials[%d]: hosts[%d]: Cannot be empty", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func ValidateMetricsEmitters(emitters []MetricsEmitterConfig) error {
+	for i, e := range emitters {
+		switch e.Type() {
+		case MetricsEmitterStatsD, MetricsEmitterOTLP:
+		default:
+			return fmt.Errorf("metrics: emitters[%d]: type: is \"%s\" but must be one of statsd or otlp", i, e.Type())
+		}
+		if e.Endpoint() == "" {
+			return fmt.Errorf("metrics: emitters[%d]: endpoint: Cannot be empty", i)
+		}
+		if e.Interval() <= 0 {
+			return fmt.Errorf("metrics: emitters[%d]: interval_millis: must be > 0", i)
+		}
+	}
+	return nil
+}
+
+func ValidateAuditLogConfig(config AuditLogConfig) error {
+	if config == nil {
+		return nil
+	}
+	switch config.Target() {
+	case AuditLogTargetFile:
+		if config.Path() == "" {
+			return fmt.Errorf("audit_log: path: Cannot be empty when target is \"file\"")
+		}
+	case AuditLogTargetTable:
+	default:
+		return fmt.Errorf("audit_log: target: is \"%s\" but must be one of file or table", config.Target())
+	}
+	switch config.Queries() {
+	case AuditLogQueriesNone, AuditLogQueriesDDL, AuditLogQueriesDML, AuditLogQueriesAll:
+	default:
+		return fmt.Errorf("audit_log: queries: is \"%s\" but must be one of none, ddl, dml or all", config.Queries())
+	}
+	if config.MaxSizeMB() < 0 {
+		return fmt.Errorf("audit_log: max_size_mb: is %d but must be >= 0", config.MaxSizeMB())
+	}
+	if config.MaxFiles() < 0 {
+		return fmt.Errorf("audit_log: max_files: is %d but must be >= 0", config.MaxFiles())
+	}
+	if config.MaxEntries() < 1 {
+		return fmt.Errorf("audit_log: max_entries: is %d but must be >= 1", config.MaxEntries())
+	}
+	return nil
+}
+
+func ValidateQuerySchedulerConfig(config QuerySchedulerConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.MaxConcurrentQueries() < 0 {
+		return fmt.Errorf("query_scheduler: max_concurrent_queries: is %d but must be >= 0", config.MaxConcurrentQueries())
+	}
+	if config.QueueTimeout() < 0 {
+		return fmt.Errorf("query_scheduler: queue_timeout_millis: must be >= 0")
+	}
+	if config.DefaultWeight() < 1 {
+		return fmt.Errorf("query_scheduler: default_weight: is %d but must be >= 1", config.DefaultWeight())
+	}
+	if config.DefaultMaxConcurrentQueries() < 0 {
+		return fmt.Errorf("query_scheduler: default_max_concurrent_queries: is %d but must be >= 0", config.DefaultMaxConcurrentQueries())
+	}
+	names := make(map[string]struct{})
+	for i, db := range config.Databases() {
+		if db.Name() == "" {
+			return fmt.Errorf("query_scheduler: databases[%d]: name: Cannot be empty", i)
+		}
+		name := strings.ToLower(db.Name())
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("query_scheduler: databases[%d]: name: %s is listed more than once", i, db.Name())
+		}
+		names[name] = struct{}{}
+		if db.Weight() < 1 {
+			return fmt.Errorf("query_scheduler: databases[%d]: weight: is %d but must be >= 1", i, db.Weight())
+		}
+		if db.MaxConcurrentQueries() < 0 {
+			return fmt.Errorf("query_scheduler: databases[%d]: max_concurrent_queries: is %d but must be >= 0", i, db.MaxConcurrentQueries())
+		}
+	}
+	return nil
+}
+
 func ValidateClusterConfig(config ClusterConfig) error {
 	if config == nil {
 		return nil
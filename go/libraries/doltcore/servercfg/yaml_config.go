@@ -104,14 +104,16 @@ type PerformanceYAMLConfig struct {
 }
 
 type MetricsYAMLConfig struct {
-	Labels map[string]string `yaml:"labels"`
-	Host   *string           `yaml:"host,omitempty"`
-	Port   *int              `yaml:"port,omitempty"`
+	Labels   map[string]string          `yaml:"labels"`
+	Host     *string                    `yaml:"host,omitempty"`
+	Port     *int                       `yaml:"port,omitempty"`
+	Emitters []MetricsEmitterYAMLConfig `yaml:"emitters,omitempty" minver:"TBD"`
 }
 
 type RemotesapiYAMLConfig struct {
-	Port_     *int  `yaml:"port,omitempty"`
-	ReadOnly_ *bool `yaml:"read_only,omitempty" minver:"1.30.5"`
+	Port_         *int    `yaml:"port,omitempty"`
+	ReadOnly_     *bool   `yaml:"read_only,omitempty" minver:"1.30.5"`
+	MaxBandwidth_ *string `yaml:"max_bandwidth,omitempty" minver:"TBD"`
 }
 
 func (r RemotesapiYAMLConfig) Port() int {
@@ -143,12 +145,16 @@ type YAMLConfig struct {
 	PrivilegeFile     *string                `yaml:"privilege_file,omitempty"`
 	BranchControlFile *string                `yaml:"branch_control_file,omitempty"`
 	// TODO: Rename to UserVars_
-	Vars            []UserSessionVars      `yaml:"user_session_vars"`
-	SystemVars_     map[string]interface{} `yaml:"system_variables,omitempty" minver:"1.11.1"`
-	Jwks            []JwksConfig           `yaml:"jwks"`
-	GoldenMysqlConn *string                `yaml:"golden_mysql_conn,omitempty"`
-	MetricsConfig   MetricsYAMLConfig      `yaml:"metrics,omitempty"`
-	ClusterCfg      *ClusterYAMLConfig     `yaml:"cluster,omitempty"`
+	Vars            []UserSessionVars                   `yaml:"user_session_vars"`
+	SystemVars_     map[string]interface{}              `yaml:"system_variables,omitempty" minver:"1.11.1"`
+	Jwks            []JwksConfig                        `yaml:"jwks"`
+	GoldenMysqlConn *string                             `yaml:"golden_mysql_conn,omitempty"`
+	MetricsConfig   MetricsYAMLConfig                   `yaml:"metrics,omitempty"`
+	ClusterCfg      *ClusterYAMLConfig                  `yaml:"cluster,omitempty"`
+	ExternalFuncs   []ExternalFunctionYAMLConfig        `yaml:"external_functions,omitempty" minver:"TBD"`
+	ExternalCreds   []ExternalTableCredentialYAMLConfig `yaml:"external_table_credentials,omitempty" minver:"TBD"`
+	AuditLogCfg     *AuditLogYAMLConfig                 `yaml:"audit_log,omitempty" minver:"TBD"`
+	SchedulerCfg    *QuerySchedulerYAMLConfig           `yaml:"query_scheduler,omitempty" minver:"TBD"`
 }
 
 var _ ServerConfig = YAMLConfig{}
@@ -213,13 +219,15 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		DataDirStr: ptr(cfg.DataDir()),
 		CfgDirStr:  ptr(cfg.CfgDir()),
 		MetricsConfig: MetricsYAMLConfig{
-			Labels: cfg.MetricsLabels(),
-			Host:   nillableStrPtr(cfg.MetricsHost()),
-			Port:   ptr(cfg.MetricsPort()),
+			Labels:   cfg.MetricsLabels(),
+			Host:     nillableStrPtr(cfg.MetricsHost()),
+			Port:     ptr(cfg.MetricsPort()),
+			Emitters: metricsEmittersAsYAMLConfig(cfg.MetricsEmitters()),
 		},
 		RemotesapiConfig: RemotesapiYAMLConfig{
-			Port_:     cfg.RemotesapiPort(),
-			ReadOnly_: cfg.RemotesapiReadOnly(),
+			Port_:         cfg.RemotesapiPort(),
+			ReadOnly_:     cfg.RemotesapiReadOnly(),
+			MaxBandwidth_: cfg.RemotesapiMaxBandwidth(),
 		},
 		ClusterCfg:        clusterConfigAsYAMLConfig(cfg.ClusterConfig()),
 		PrivilegeFile:     ptr(cfg.PrivilegeFilePath()),
@@ -227,9 +235,98 @@ func ServerConfigAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		SystemVars_:       systemVars,
 		Vars:              cfg.UserVars(),
 		Jwks:              cfg.JwksConfig(),
+		ExternalFuncs:     externalFunctionsAsYAMLConfig(cfg.ExternalFunctions()),
+		ExternalCreds:     externalTableCredentialsAsYAMLConfig(cfg.ExternalTableCredentials()),
+		AuditLogCfg:       auditLogConfigAsYAMLConfig(cfg.AuditLog()),
+		SchedulerCfg:      querySchedulerConfigAsYAMLConfig(cfg.QueryScheduler()),
 	}
 }
 
+func auditLogConfigAsYAMLConfig(config AuditLogConfig) *AuditLogYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	return &AuditLogYAMLConfig{
+		Target_:     ptr(config.Target()),
+		Path_:       ptr(config.Path()),
+		Queries_:    ptr(config.Queries()),
+		MaxSizeMB_:  ptr(config.MaxSizeMB()),
+		MaxFiles_:   ptr(config.MaxFiles()),
+		MaxEntries_: ptr(config.MaxEntries()),
+	}
+}
+
+func querySchedulerConfigAsYAMLConfig(config QuerySchedulerConfig) *QuerySchedulerYAMLConfig {
+	if config == nil {
+		return nil
+	}
+	var dbs []QuerySchedulerDatabaseYAMLConfig
+	for _, db := range config.Databases() {
+		dbs = append(dbs, QuerySchedulerDatabaseYAMLConfig{
+			Name_:                 ptr(db.Name()),
+			Weight_:               ptr(db.Weight()),
+			MaxConcurrentQueries_: ptr(db.MaxConcurrentQueries()),
+		})
+	}
+	return &QuerySchedulerYAMLConfig{
+		MaxConcurrentQueries_:        ptr(config.MaxConcurrentQueries()),
+		QueueTimeoutMillis_:          ptr(uint64(config.QueueTimeout().Milliseconds())),
+		DefaultWeight_:               ptr(config.DefaultWeight()),
+		DefaultMaxConcurrentQueries_: ptr(config.DefaultMaxConcurrentQueries()),
+		Databases_:                   dbs,
+	}
+}
+
+func metricsEmittersAsYAMLConfig(emitters []MetricsEmitterConfig) []MetricsEmitterYAMLConfig {
+	if len(emitters) == 0 {
+		return nil
+	}
+	ret := make([]MetricsEmitterYAMLConfig, len(emitters))
+	for i, e := range emitters {
+		ret[i] = MetricsEmitterYAMLConfig{
+			Type_:           ptr(e.Type()),
+			Endpoint_:       ptr(e.Endpoint()),
+			Prefix_:         ptr(e.Prefix()),
+			Headers_:        e.Headers(),
+			IntervalMillis_: ptr(uint64(e.Interval().Milliseconds())),
+		}
+	}
+	return ret
+}
+
+func externalFunctionsAsYAMLConfig(fns []ExternalFunctionConfig) []ExternalFunctionYAMLConfig {
+	if len(fns) == 0 {
+		return nil
+	}
+	ret := make([]ExternalFunctionYAMLConfig, len(fns))
+	for i, fn := range fns {
+		ret[i] = ExternalFunctionYAMLConfig{
+			Name_:             ptr(fn.Name()),
+			Endpoint_:         ptr(fn.Endpoint()),
+			Returns_:          ptr(fn.Returns()),
+			TimeoutMillis_:    ptr(uint64(fn.Timeout().Milliseconds())),
+			BatchSize_:        ptr(fn.BatchSize()),
+			FailureThreshold_: ptr(fn.FailureThreshold()),
+			CooldownMillis_:   ptr(uint64(fn.Cooldown().Milliseconds())),
+		}
+	}
+	return ret
+}
+
+func externalTableCredentialsAsYAMLConfig(creds []ExternalTableCredentialConfig) []ExternalTableCredentialYAMLConfig {
+	if len(creds) == 0 {
+		return nil
+	}
+	ret := make([]ExternalTableCredentialYAMLConfig, len(creds))
+	for i, cred := range creds {
+		ret[i] = ExternalTableCredentialYAMLConfig{
+			Name_:  ptr(cred.Name()),
+			Hosts_: cred.Hosts(),
+		}
+	}
+	return ret
+}
+
 func clusterConfigAsYAMLConfig(config ClusterConfig) *ClusterYAMLConfig {
 	if config == nil {
 		return nil
@@ -285,13 +382,15 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		DataDirStr: zeroIf(ptr(cfg.DataDir()), !cfg.ValueSet(DataDirKey)),
 		CfgDirStr:  zeroIf(ptr(cfg.CfgDir()), !cfg.ValueSet(CfgDirKey)),
 		MetricsConfig: MetricsYAMLConfig{
-			Labels: zeroIf(cfg.MetricsLabels(), !cfg.ValueSet(MetricsLabelsKey)),
-			Host:   zeroIf(ptr(cfg.MetricsHost()), !cfg.ValueSet(MetricsHostKey)),
-			Port:   zeroIf(ptr(cfg.MetricsPort()), !cfg.ValueSet(MetricsPortKey)),
+			Labels:   zeroIf(cfg.MetricsLabels(), !cfg.ValueSet(MetricsLabelsKey)),
+			Host:     zeroIf(ptr(cfg.MetricsHost()), !cfg.ValueSet(MetricsHostKey)),
+			Port:     zeroIf(ptr(cfg.MetricsPort()), !cfg.ValueSet(MetricsPortKey)),
+			Emitters: zeroIf(metricsEmittersAsYAMLConfig(cfg.MetricsEmitters()), !cfg.ValueSet(MetricsEmittersKey)),
 		},
 		RemotesapiConfig: RemotesapiYAMLConfig{
-			Port_:     zeroIf(cfg.RemotesapiPort(), !cfg.ValueSet(RemotesapiPortKey)),
-			ReadOnly_: zeroIf(cfg.RemotesapiReadOnly(), !cfg.ValueSet(RemotesapiReadOnlyKey)),
+			Port_:         zeroIf(cfg.RemotesapiPort(), !cfg.ValueSet(RemotesapiPortKey)),
+			ReadOnly_:     zeroIf(cfg.RemotesapiReadOnly(), !cfg.ValueSet(RemotesapiReadOnlyKey)),
+			MaxBandwidth_: zeroIf(cfg.RemotesapiMaxBandwidth(), !cfg.ValueSet(RemotesapiMaxBandwidthKey)),
 		},
 		ClusterCfg:        zeroIf(clusterConfigAsYAMLConfig(cfg.ClusterConfig()), !cfg.ValueSet(ClusterConfigKey)),
 		PrivilegeFile:     zeroIf(ptr(cfg.PrivilegeFilePath()), !cfg.ValueSet(PrivilegeFilePathKey)),
@@ -299,6 +398,10 @@ func ServerConfigSetValuesAsYAMLConfig(cfg ServerConfig) *YAMLConfig {
 		SystemVars_:       zeroIf(systemVars, !cfg.ValueSet(SystemVarsKey)),
 		Vars:              zeroIf(cfg.UserVars(), !cfg.ValueSet(UserVarsKey)),
 		Jwks:              zeroIf(cfg.JwksConfig(), !cfg.ValueSet(JwksConfigKey)),
+		ExternalFuncs:     zeroIf(externalFunctionsAsYAMLConfig(cfg.ExternalFunctions()), !cfg.ValueSet(ExternalFunctionsKey)),
+		ExternalCreds:     zeroIf(externalTableCredentialsAsYAMLConfig(cfg.ExternalTableCredentials()), !cfg.ValueSet(ExternalTableCredentialsKey)),
+		AuditLogCfg:       zeroIf(auditLogConfigAsYAMLConfig(cfg.AuditLog()), !cfg.ValueSet(AuditLogKey)),
+		SchedulerCfg:      zeroIf(querySchedulerConfigAsYAMLConfig(cfg.QueryScheduler()), !cfg.ValueSet(QuerySchedulerKey)),
 	}
 }
 
@@ -734,6 +837,10 @@ func (cfg YAMLConfig) RemotesapiReadOnly() *bool {
 	return cfg.RemotesapiConfig.ReadOnly_
 }
 
+func (cfg YAMLConfig) RemotesapiMaxBandwidth() *string {
+	return cfg.RemotesapiConfig.MaxBandwidth_
+}
+
 // PrivilegeFilePath returns the path to the file which contains all needed privilege information in the form of a
 // JSON string.
 func (cfg YAMLConfig) PrivilegeFilePath() string {
@@ -861,6 +968,53 @@ func (cfg YAMLConfig) GoldenMysqlConnectionString() (s string) {
 	return
 }
 
+func (cfg YAMLConfig) ExternalFunctions() []ExternalFunctionConfig {
+	if len(cfg.ExternalFuncs) == 0 {
+		return nil
+	}
+	ret := make([]ExternalFunctionConfig, len(cfg.ExternalFuncs))
+	for i := range cfg.ExternalFuncs {
+		ret[i] = cfg.ExternalFuncs[i]
+	}
+	return ret
+}
+
+func (cfg YAMLConfig) ExternalTableCredentials() []ExternalTableCredentialConfig {
+	if len(cfg.ExternalCreds) == 0 {
+		return nil
+	}
+	ret := make([]ExternalTableCredentialConfig, len(cfg.ExternalCreds))
+	for i := range cfg.ExternalCreds {
+		ret[i] = cfg.ExternalCreds[i]
+	}
+	return ret
+}
+
+func (cfg YAMLConfig) MetricsEmitters() []MetricsEmitterConfig {
+	if len(cfg.MetricsConfig.Emitters) == 0 {
+		return nil
+	}
+	ret := make([]MetricsEmitterConfig, len(cfg.MetricsConfig.Emitters))
+	for i := range cfg.MetricsConfig.Emitters {
+		ret[i] = cfg.MetricsConfig.Emitters[i]
+	}
+	return ret
+}
+
+func (cfg YAMLConfig) AuditLog() AuditLogConfig {
+	if cfg.AuditLogCfg == nil {
+		return nil
+	}
+	return *cfg.AuditLogCfg
+}
+
+func (cfg YAMLConfig) QueryScheduler() QuerySchedulerConfig {
+	if cfg.SchedulerCfg == nil {
+		return nil
+	}
+	return *cfg.SchedulerCfg
+}
+
 func (cfg YAMLConfig) ClusterConfig() ClusterConfig {
 	if cfg.ClusterCfg == nil {
 		return nil
@@ -981,10 +1135,285 @@ func (cfg YAMLConfig) ValueSet(value string) bool {
 		return cfg.ListenerConfig.MaxConnectionsTimeoutMs != nil
 	case EventSchedulerKey:
 		return cfg.BehaviorConfig.EventSchedulerStatus != nil
+	case ExternalFunctionsKey:
+		return cfg.ExternalFuncs != nil
+	case ExternalTableCredentialsKey:
+		return cfg.ExternalCreds != nil
+	case AuditLogKey:
+		return cfg.AuditLogCfg != nil
+	case QuerySchedulerKey:
+		return cfg.SchedulerCfg != nil
+	case MetricsEmittersKey:
+		return cfg.MetricsConfig.Emitters != nil
 	}
 	return false
 }
 
+const (
+	defaultAuditLogMaxSizeMB  = 100
+	defaultAuditLogMaxFiles   = 10
+	defaultAuditLogMaxEntries = 10000
+)
+
+// AuditLogYAMLConfig is the YAML config for an AuditLogConfig, the audit_log section.
+type AuditLogYAMLConfig struct {
+	Target_     *string `yaml:"target,omitempty" minver:"TBD"`
+	Path_       *string `yaml:"path,omitempty" minver:"TBD"`
+	Queries_    *string `yaml:"queries,omitempty" minver:"TBD"`
+	MaxSizeMB_  *int    `yaml:"max_size_mb,omitempty" minver:"TBD"`
+	MaxFiles_   *int    `yaml:"max_files,omitempty" minver:"TBD"`
+	MaxEntries_ *int    `yaml:"max_entries,omitempty" minver:"TBD"`
+}
+
+func (c AuditLogYAMLConfig) Target() string {
+	if c.Target_ == nil {
+		return AuditLogTargetFile
+	}
+	return strings.ToLower(*c.Target_)
+}
+
+func (c AuditLogYAMLConfig) Path() string {
+	if c.Path_ == nil {
+		return ""
+	}
+	return *c.Path_
+}
+
+func (c AuditLogYAMLConfig) Queries() string {
+	if c.Queries_ == nil {
+		return AuditLogQueriesDDL
+	}
+	return strings.ToLower(*c.Queries_)
+}
+
+func (c AuditLogYAMLConfig) MaxSizeMB() int {
+	if c.MaxSizeMB_ == nil {
+		return defaultAuditLogMaxSizeMB
+	}
+	return *c.MaxSizeMB_
+}
+
+func (c AuditLogYAMLConfig) MaxFiles() int {
+	if c.MaxFiles_ == nil {
+		return defaultAuditLogMaxFiles
+	}
+	return *c.MaxFiles_
+}
+
+func (c AuditLogYAMLConfig) MaxEntries() int {
+	if c.MaxEntries_ == nil {
+		return defaultAuditLogMaxEntries
+	}
+	return *c.MaxEntries_
+}
+
+const defaultQuerySchedulerWeight = 1
+
+// QuerySchedulerYAMLConfig is the YAML config for a QuerySchedulerConfig, the query_scheduler section.
+type QuerySchedulerYAMLConfig struct {
+	MaxConcurrentQueries_        *int                               `yaml:"max_concurrent_queries,omitempty" minver:"TBD"`
+	QueueTimeoutMillis_          *uint64                            `yaml:"queue_timeout_millis,omitempty" minver:"TBD"`
+	DefaultWeight_               *int                               `yaml:"default_weight,omitempty" minver:"TBD"`
+	DefaultMaxConcurrentQueries_ *int                               `yaml:"default_max_concurrent_queries,omitempty" minver:"TBD"`
+	Databases_                   []QuerySchedulerDatabaseYAMLConfig `yaml:"databases,omitempty" minver:"TBD"`
+}
+
+func (c QuerySchedulerYAMLConfig) MaxConcurrentQueries() int {
+	if c.MaxConcurrentQueries_ == nil {
+		return 0
+	}
+	return *c.MaxConcurrentQueries_
+}
+
+func (c QuerySchedulerYAMLConfig) QueueTimeout() time.Duration {
+	if c.QueueTimeoutMillis_ == nil {
+		return 0
+	}
+	return time.Duration(*c.QueueTimeoutMillis_) * time.Millisecond
+}
+
+func (c QuerySchedulerYAMLConfig) DefaultWeight() int {
+	if c.DefaultWeight_ == nil {
+		return defaultQuerySchedulerWeight
+	}
+	return *c.DefaultWeight_
+}
+
+func (c QuerySchedulerYAMLConfig) DefaultMaxConcurrentQueries() int {
+	if c.DefaultMaxConcurrentQueries_ == nil {
+		return 0
+	}
+	return *c.DefaultMaxConcurrentQueries_
+}
+
+func (c QuerySchedulerYAMLConfig) Databases() []QuerySchedulerDatabaseConfig {
+	ret := make([]QuerySchedulerDatabaseConfig, len(c.Databases_))
+	for i := range c.Databases_ {
+		ret[i] = c.Databases_[i]
+	}
+	return ret
+}
+
+// QuerySchedulerDatabaseYAMLConfig is the YAML config for a QuerySchedulerDatabaseConfig, one entry of
+// query_scheduler.databases.
+type QuerySchedulerDatabaseYAMLConfig struct {
+	Name_                 *string `yaml:"name,omitempty" minver:"TBD"`
+	Weight_               *int    `yaml:"weight,omitempty" minver:"TBD"`
+	MaxConcurrentQueries_ *int    `yaml:"max_concurrent_queries,omitempty" minver:"TBD"`
+}
+
+func (c QuerySchedulerDatabaseYAMLConfig) Name() string {
+	if c.Name_ == nil {
+		return ""
+	}
+	return *c.Name_
+}
+
+func (c QuerySchedulerDatabaseYAMLConfig) Weight() int {
+	if c.Weight_ == nil {
+		return defaultQuerySchedulerWeight
+	}
+	return *c.Weight_
+}
+
+func (c QuerySchedulerDatabaseYAMLConfig) MaxConcurrentQueries() int {
+	if c.MaxConcurrentQueries_ == nil {
+		return 0
+	}
+	return *c.MaxConcurrentQueries_
+}
+
+const (
+	defaultMetricsEmitterIntervalMillis = 10000
+	defaultStatsDPrefix                 = "dolt"
+)
+
+// MetricsEmitterYAMLConfig is the YAML config for a MetricsEmitterConfig, one entry of metrics.emitters.
+type MetricsEmitterYAMLConfig struct {
+	Type_           *string           `yaml:"type,omitempty" minver:"TBD"`
+	Endpoint_       *string           `yaml:"endpoint,omitempty" minver:"TBD"`
+	Prefix_         *string           `yaml:"prefix,omitempty" minver:"TBD"`
+	Headers_        map[string]string `yaml:"headers,omitempty" minver:"TBD"`
+	IntervalMillis_ *uint64           `yaml:"interval_millis,omitempty" minver:"TBD"`
+}
+
+func (c MetricsEmitterYAMLConfig) Type() string {
+	if c.Type_ == nil {
+		return ""
+	}
+	return strings.ToLower(*c.Type_)
+}
+
+func (c MetricsEmitterYAMLConfig) Endpoint() string {
+	if c.Endpoint_ == nil {
+		return ""
+	}
+	return *c.Endpoint_
+}
+
+func (c MetricsEmitterYAMLConfig) Prefix() string {
+	if c.Prefix_ == nil {
+		return defaultStatsDPrefix
+	}
+	return *c.Prefix_
+}
+
+func (c MetricsEmitterYAMLConfig) Headers() map[string]string {
+	return c.Headers_
+}
+
+func (c MetricsEmitterYAMLConfig) Interval() time.Duration {
+	if c.IntervalMillis_ == nil {
+		return defaultMetricsEmitterIntervalMillis * time.Millisecond
+	}
+	return time.Duration(*c.IntervalMillis_) * time.Millisecond
+}
+
+const (
+	defaultExternalFunctionTimeoutMillis    = 5000
+	defaultExternalFunctionBatchSize        = 128
+	defaultExternalFunctionFailureThreshold = 5
+	defaultExternalFunctionCooldownMillis   = 30000
+)
+
+// ExternalFunctionYAMLConfig is the YAML config for an ExternalFunctionConfig, one entry of external_functions.
+type ExternalFunctionYAMLConfig struct {
+	Name_             *string `yaml:"name,omitempty" minver:"TBD"`
+	Endpoint_         *string `yaml:"endpoint,omitempty" minver:"TBD"`
+	Returns_          *string `yaml:"returns,omitempty" minver:"TBD"`
+	TimeoutMillis_    *uint64 `yaml:"timeout_millis,omitempty" minver:"TBD"`
+	BatchSize_        *int    `yaml:"batch_size,omitempty" minver:"TBD"`
+	FailureThreshold_ *int    `yaml:"failure_threshold,omitempty" minver:"TBD"`
+	CooldownMillis_   *uint64 `yaml:"cooldown_millis,omitempty" minver:"TBD"`
+}
+
+func (c ExternalFunctionYAMLConfig) Name() string {
+	if c.Name_ == nil {
+		return ""
+	}
+	return *c.Name_
+}
+
+func (c ExternalFunctionYAMLConfig) Endpoint() string {
+	if c.Endpoint_ == nil {
+		return ""
+	}
+	return *c.Endpoint_
+}
+
+func (c ExternalFunctionYAMLConfig) Returns() string {
+	if c.Returns_ == nil {
+		return "text"
+	}
+	return *c.Returns_
+}
+
+func (c ExternalFunctionYAMLConfig) Timeout() time.Duration {
+	if c.TimeoutMillis_ == nil {
+		return defaultExternalFunctionTimeoutMillis * time.Millisecond
+	}
+	return time.Duration(*c.TimeoutMillis_) * time.Millisecond
+}
+
+func (c ExternalFunctionYAMLConfig) BatchSize() int {
+	if c.BatchSize_ == nil {
+		return defaultExternalFunctionBatchSize
+	}
+	return *c.BatchSize_
+}
+
+func (c ExternalFunctionYAMLConfig) FailureThreshold() int {
+	if c.FailureThreshold_ == nil {
+		return defaultExternalFunctionFailureThreshold
+	}
+	return *c.FailureThreshold_
+}
+
+func (c ExternalFunctionYAMLConfig) Cooldown() time.Duration {
+	if c.CooldownMillis_ == nil {
+		return defaultExternalFunctionCooldownMillis * time.Millisecond
+	}
+	return time.Duration(*c.CooldownMillis_) * time.Millisecond
+}
+
+// ExternalTableCredentialYAMLConfig is the YAML config for an ExternalTableCredentialConfig, one entry of
+// external_table_credentials.
+type ExternalTableCredentialYAMLConfig struct {
+	Name_  *string  `yaml:"name,omitempty" minver:"TBD"`
+	Hosts_ []string `yaml:"hosts,omitempty" minver:"TBD"`
+}
+
+func (c ExternalTableCredentialYAMLConfig) Name() string {
+	if c.Name_ == nil {
+		return ""
+	}
+	return *c.Name_
+}
+
+func (c ExternalTableCredentialYAMLConfig) Hosts() []string {
+	return c.Hosts_
+}
+
 type AutoGCBehaviorYAMLConfig struct {
 	Enable_       *bool `yaml:"enable,omitempty" minver:"1.50.0"`
 	ArchiveLevel_ *int  `yaml:"archive_level,omitempty" minver:"1.52.1"`
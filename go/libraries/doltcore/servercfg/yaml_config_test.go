@@ -16,6 +16,7 @@ package servercfg
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -288,6 +289,269 @@ cluster:
 	}
 }
 
+func TestUnmarshallExternalFunctions(t *testing.T) {
+	testStr := `
+external_functions:
+  - name: geocode
+    endpoint: localhost:50052
+    returns: text
+    timeout_millis: 250
+    batch_size: 64
+    failure_threshold: 3
+    cooldown_millis: 1000
+  - name: score
+    endpoint: localhost:50053
+    returns: double
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.True(t, config.ValueSet(ExternalFunctionsKey))
+	fns := config.ExternalFunctions()
+	require.Len(t, fns, 2)
+	require.Equal(t, "geocode", fns[0].Name())
+	require.Equal(t, "localhost:50052", fns[0].Endpoint())
+	require.Equal(t, "text", fns[0].Returns())
+	require.Equal(t, 250*time.Millisecond, fns[0].Timeout())
+	require.Equal(t, 64, fns[0].BatchSize())
+	require.Equal(t, 3, fns[0].FailureThreshold())
+	require.Equal(t, time.Second, fns[0].Cooldown())
+
+	require.Equal(t, "score", fns[1].Name())
+	require.Equal(t, 5*time.Second, fns[1].Timeout())
+	require.Equal(t, 128, fns[1].BatchSize())
+	require.Equal(t, 5, fns[1].FailureThreshold())
+	require.Equal(t, 30*time.Second, fns[1].Cooldown())
+	require.NoError(t, ValidateExternalFunctions(fns))
+
+	config, err = NewYamlConfig([]byte(`
+external_functions:
+  - name: geocode
+    endpoint: localhost:50052
+  - name: GEOCODE
+    endpoint: localhost:50053
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateExternalFunctions(config.ExternalFunctions()))
+
+	config, err = NewYamlConfig([]byte(`
+external_functions:
+  - name: geocode
+    endpoint: localhost:50052
+    returns: blob
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateExternalFunctions(config.ExternalFunctions()))
+}
+
+func TestUnmarshallExternalTableCredentials(t *testing.T) {
+	testStr := `
+external_table_credentials:
+  - name: legacy
+    hosts:
+      - db.internal:3306
+      - 10.0.0.5
+  - name: warehouse
+    hosts: [pg.internal]
+`
+	config, err := NewYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	require.True(t, config.ValueSet(ExternalTableCredentialsKey))
+	creds := config.ExternalTableCredentials()
+	require.Len(t, creds, 2)
+	require.Equal(t, "legacy", creds[0].Name())
+	require.Equal(t, []string{"db.internal:3306", "10.0.0.5"}, creds[0].Hosts())
+	require.Equal(t, "warehouse", creds[1].Name())
+	require.Equal(t, []string{"pg.internal"}, creds[1].Hosts())
+	require.NoError(t, ValidateExternalTableCredentials(creds))
+
+	config, err = NewYamlConfig([]byte(`
+external_table_credentials:
+  - name: legacy
+    hosts: [db.internal]
+  - name: LEGACY
+    hosts: [other.internal]
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateExternalTableCredentials(config.ExternalTableCredentials()))
+
+	config, err = NewYamlConfig([]byte(`
+external_table_credentials:
+  - name: legacy
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateExternalTableCredentials(config.ExternalTableCredentials()))
+}
+
+func TestUnmarshallMetricsEmitters(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+metrics:
+  labels:
+    env: prod
+  emitters:
+    - type: statsd
+      endpoint: localhost:8125
+    - type: OTLP
+      endpoint: https://otel-collector:4318/v1/metrics
+      headers:
+        authorization: Bearer token
+      interval_millis: 30000
+`))
+	require.NoError(t, err)
+	require.True(t, config.ValueSet(MetricsEmittersKey))
+	emitters := config.MetricsEmitters()
+	require.Len(t, emitters, 2)
+	require.Equal(t, MetricsEmitterStatsD, emitters[0].Type())
+	require.Equal(t, "localhost:8125", emitters[0].Endpoint())
+	require.Equal(t, "dolt", emitters[0].Prefix())
+	require.Equal(t, 10*time.Second, emitters[0].Interval())
+	require.Equal(t, MetricsEmitterOTLP, emitters[1].Type())
+	require.Equal(t, map[string]string{"authorization": "Bearer token"}, emitters[1].Headers())
+	require.Equal(t, 30*time.Second, emitters[1].Interval())
+	require.NoError(t, ValidateMetricsEmitters(emitters))
+
+	config, err = NewYamlConfig([]byte(`
+metrics:
+  emitters:
+    - type: graphite
+      endpoint: localhost:2003
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateMetricsEmitters(config.MetricsEmitters()))
+
+	config, err = NewYamlConfig([]byte(`
+metrics:
+  emitters:
+    - type: statsd
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateMetricsEmitters(config.MetricsEmitters()))
+
+	config, err = NewYamlConfig([]byte(`
+metrics:
+  host: localhost
+  port: 9091
+`))
+	require.NoError(t, err)
+	require.False(t, config.ValueSet(MetricsEmittersKey))
+	require.Nil(t, config.MetricsEmitters())
+}
+
+func TestUnmarshallAuditLog(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+audit_log:
+  target: file
+  path: /var/log/dolt/audit.log
+  queries: dml
+  max_size_mb: 50
+  max_files: 3
+`))
+	require.NoError(t, err)
+	require.True(t, config.ValueSet(AuditLogKey))
+	auditLog := config.AuditLog()
+	require.NotNil(t, auditLog)
+	require.Equal(t, AuditLogTargetFile, auditLog.Target())
+	require.Equal(t, "/var/log/dolt/audit.log", auditLog.Path())
+	require.Equal(t, AuditLogQueriesDML, auditLog.Queries())
+	require.Equal(t, 50, auditLog.MaxSizeMB())
+	require.Equal(t, 3, auditLog.MaxFiles())
+	require.Equal(t, 10000, auditLog.MaxEntries())
+	require.NoError(t, ValidateAuditLogConfig(auditLog))
+
+	config, err = NewYamlConfig([]byte(`
+audit_log:
+  target: table
+`))
+	require.NoError(t, err)
+	require.Equal(t, AuditLogTargetTable, config.AuditLog().Target())
+	require.Equal(t, AuditLogQueriesDDL, config.AuditLog().Queries())
+	require.NoError(t, ValidateAuditLogConfig(config.AuditLog()))
+
+	config, err = NewYamlConfig([]byte(`
+audit_log:
+  target: file
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateAuditLogConfig(config.AuditLog()))
+
+	config, err = NewYamlConfig([]byte(`
+audit_log:
+  target: table
+  queries: selects
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateAuditLogConfig(config.AuditLog()))
+
+	config, err = NewYamlConfig([]byte(`
+log_level: info
+`))
+	require.NoError(t, err)
+	require.False(t, config.ValueSet(AuditLogKey))
+	require.Nil(t, config.AuditLog())
+}
+
+func TestUnmarshallQueryScheduler(t *testing.T) {
+	config, err := NewYamlConfig([]byte(`
+query_scheduler:
+  max_concurrent_queries: 16
+  queue_timeout_millis: 30000
+  default_max_concurrent_queries: 8
+  databases:
+    - name: app
+      weight: 4
+    - name: analytics
+      max_concurrent_queries: 2
+`))
+	require.NoError(t, err)
+	require.True(t, config.ValueSet(QuerySchedulerKey))
+	scheduler := config.QueryScheduler()
+	require.NotNil(t, scheduler)
+	require.Equal(t, 16, scheduler.MaxConcurrentQueries())
+	require.Equal(t, 30*time.Second, scheduler.QueueTimeout())
+	require.Equal(t, 1, scheduler.DefaultWeight())
+	require.Equal(t, 8, scheduler.DefaultMaxConcurrentQueries())
+	dbs := scheduler.Databases()
+	require.Len(t, dbs, 2)
+	require.Equal(t, "app", dbs[0].Name())
+	require.Equal(t, 4, dbs[0].Weight())
+	require.Equal(t, 0, dbs[0].MaxConcurrentQueries())
+	require.Equal(t, "analytics", dbs[1].Name())
+	require.Equal(t, 1, dbs[1].Weight())
+	require.Equal(t, 2, dbs[1].MaxConcurrentQueries())
+	require.NoError(t, ValidateQuerySchedulerConfig(scheduler))
+
+	config, err = NewYamlConfig([]byte(`
+query_scheduler:
+  databases:
+    - name: app
+      weight: 0
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateQuerySchedulerConfig(config.QueryScheduler()))
+
+	config, err = NewYamlConfig([]byte(`
+query_scheduler:
+  databases:
+    - name: app
+    - name: APP
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateQuerySchedulerConfig(config.QueryScheduler()))
+
+	config, err = NewYamlConfig([]byte(`
+query_scheduler:
+  max_concurrent_queries: -1
+`))
+	require.NoError(t, err)
+	require.Error(t, ValidateQuerySchedulerConfig(config.QueryScheduler()))
+
+	config, err = NewYamlConfig([]byte(`
+log_level: info
+`))
+	require.NoError(t, err)
+	require.False(t, config.ValueSet(QuerySchedulerKey))
+	require.Nil(t, config.QueryScheduler())
+}
+
 // Tests that a common YAML error (incorrect indentation) throws an error
 func TestUnmarshallError(t *testing.T) {
 	testStr := `
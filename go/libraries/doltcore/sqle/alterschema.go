@@ -273,6 +273,8 @@ func replaceColumnInSchema(sch schema.Schema, oldCol schema.Column, newCol schem
 				Comment:            index.Comment(),
 				FullTextProperties: index.FullTextProperties(),
 				VectorProperties:   index.VectorProperties(),
+				Descending:         index.Descending(),
+				IsInvisible:        index.IsInvisible(),
 			})
 		if err != nil {
 			return nil, err
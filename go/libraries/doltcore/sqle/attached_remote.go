@@ -0,0 +1,200 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/concurrentmap"
+	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrAttachedRemoteReadOnly = errors.New("attached remote databases are read-only")
+
+// AttachRemoteDatabase implements dsess.DoltDatabaseProvider. It adds the database at |remoteUrl| as the read-only
+// database |dbName| without cloning it. Chunks are fetched from the remote as queries read them and are cached in
+// memory, so querying part of a large database only downloads that part. Every branch, tag and commit of the remote
+// can be read as a revision database or with AS OF. Reads see the branches of the remote as they were when it was
+// attached, ignoring any working changes it has. Attached remotes aren't persisted, and DROP DATABASE detaches them.
+func (p *DoltDatabaseProvider) AttachRemoteDatabase(ctx *sql.Context, dbName, remoteUrl string, remoteParams map[string]string) error {
+	if strings.Contains(dbName, dsess.DbRevisionDelimiter) {
+		return fmt.Errorf("invalid database name: %s", dbName)
+	}
+	if p.remoteDialer == nil {
+		return fmt.Errorf("unable to attach remote database; no remote dialer configured")
+	}
+	if _, ok := p.catalog.Load().databases[formatDbMapKeyName(dbName)]; ok {
+		return sql.ErrDatabaseExists.New(dbName)
+	}
+
+	r := env.NewRemote(dbName, remoteUrl, remoteParams)
+	ddb, err := r.GetRemoteDB(ctx, types.Format_Default, p.remoteDialer)
+	if err != nil {
+		return err
+	}
+	head, err := attachedRemoteHead(ctx, ddb, p.defaultBranch)
+	if err != nil {
+		return err
+	}
+	gs, err := dsess.NewGlobalStateStoreForDb(ctx, dbName, ddb)
+	if err != nil {
+		return err
+	}
+	// local file databases are opened once per process and shared with every other user of their path
+	shared := false
+	if u, err := earl.Parse(remoteUrl); err == nil {
+		shared = u.Scheme == dbfactory.FileScheme || u.Scheme == dbfactory.LocalBSScheme
+	}
+	rs := attachedRemoteRepoState{head: head, shared: shared}
+	db := ReadOnlyDatabase{Database: Database{
+		baseName:      dbName,
+		requestedName: dbName,
+		ddb:           ddb,
+		rsr:           rs,
+		rsw:           rs,
+		gs:            gs,
+	}}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	dbKey := formatDbMapKeyName(dbName)
+	if _, ok := p.databases[dbKey]; ok {
+		return sql.ErrDatabaseExists.New(dbName)
+	}
+	p.databases[dbKey] = db
+	p.publishCatalog()
+
+	// The database needs to be added to a transaction which is already running to be used in it
+	if tx, ok := ctx.GetTransaction().(*dsess.DoltTransaction); ok {
+		return tx.AddDb(ctx, db)
+	}
+	return nil
+}
+
+// attachedRemoteHead returns the branch of |ddb| which an attached remote reads by default: |defaultBranch| if the
+// remote has it, then main or master, and otherwise the first of its branches.
+func attachedRemoteHead(ctx context.Context, ddb *doltdb.DoltDB, defaultBranch string) (ref.DoltRef, error) {
+	branches, err := ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(branches) == 0 {
+		return nil, fmt.Errorf("remote database has no branches")
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].GetPath() < branches[j].GetPath()
+	})
+	for _, name := range []string{defaultBranch, env.DefaultInitBranch, "master"} {
+		for _, b := range branches {
+			if name != "" && b.GetPath() == name {
+				return b, nil
+			}
+		}
+	}
+	return branches[0], nil
+}
+
+// closeAttachedRemote closes the DoltDB of the attached remote |db| unless it's shared with other users of its
+// location.
+func closeAttachedRemote(db ReadOnlyDatabase) error {
+	rs, ok := db.rsr.(attachedRemoteRepoState)
+	if !ok || rs.shared {
+		return nil
+	}
+	return db.ddb.Close()
+}
+
+// isAttachedRemote returns whether |db|, or the database it's a revision of, is an attached remote.
+func isAttachedRemote(db dsess.SqlDatabase) bool {
+	rsr := db.DbData().Rsr
+	if static, ok := rsr.(staticRepoState); ok {
+		rsr = static.RepoStateReader
+	}
+	_, ok := rsr.(attachedRemoteRepoState)
+	return ok
+}
+
+// attachedRemoteRepoState is the repo state of an attached remote, which has no remotes, backups or branch config of
+// its own and can't be changed.
+type attachedRemoteRepoState struct {
+	head ref.DoltRef
+	// shared is whether the remote's DoltDB is shared with other users of its location, in which case detaching the
+	// remote doesn't close it
+	shared bool
+}
+
+var _ env.RepoStateReader[*sql.Context] = attachedRemoteRepoState{}
+var _ env.RepoStateWriter = attachedRemoteRepoState{}
+
+func (rs attachedRemoteRepoState) CWBHeadRef(*sql.Context) (ref.DoltRef, error) {
+	return rs.head, nil
+}
+
+func (rs attachedRemoteRepoState) CWBHeadSpec(*sql.Context) (*doltdb.CommitSpec, error) {
+	return doltdb.NewCommitSpec(rs.head.GetPath())
+}
+
+func (rs attachedRemoteRepoState) GetRemotes() (*concurrentmap.Map[string, env.Remote], error) {
+	return concurrentmap.New[string, env.Remote](), nil
+}
+
+func (rs attachedRemoteRepoState) GetBackups() (*concurrentmap.Map[string, env.Remote], error) {
+	return concurrentmap.New[string, env.Remote](), nil
+}
+
+func (rs attachedRemoteRepoState) GetBranches() (*concurrentmap.Map[string, env.BranchConfig], error) {
+	return concurrentmap.New[string, env.BranchConfig](), nil
+}
+
+func (rs attachedRemoteRepoState) SetCWBHeadRef(context.Context, ref.MarshalableRef) error {
+	return ErrAttachedRemoteReadOnly
+}
+
+func (rs attachedRemoteRepoState) AddRemote(env.Remote) error {
+	return ErrAttachedRemoteReadOnly
+}
+
+func (rs attachedRemoteRepoState) AddBackup(env.Remote) error {
+	return ErrAttachedRemoteReadOnly
+}
+
+func (rs attachedRemoteRepoState) RemoveRemote(context.Context, string) error {
+	return ErrAttachedRemoteReadOnly
+}
+
+func (rs attachedRemoteRepoState) RemoveBackup(context.Context, string) error {
+	return ErrAttachedRemoteReadOnly
+}
+
+func (rs attachedRemoteRepoState) TempTableFilesDir() (string, error) {
+	return os.TempDir(), nil
+}
+
+func (rs attachedRemoteRepoState) UpdateBranch(string, env.BranchConfig) error {
+	return ErrAttachedRemoteReadOnly
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditlog records the connections to sql-server and the queries they run, for deployments which must keep
+// an audit trail. Entries are either appended to a rotated file of JSON lines, or kept in memory, where they can be
+// queried through the dolt_audit_log system table.
+package auditlog
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The events recorded in the audit log.
+const (
+	EventConnect    = "connect"
+	EventDisconnect = "disconnect"
+	EventQuery      = "query"
+)
+
+// The kinds of queries, as returned by Classify.
+const (
+	KindDDL   = "ddl"
+	KindDML   = "dml"
+	KindRead  = "read"
+	KindOther = "other"
+)
+
+// The targets entries can be written to.
+const (
+	TargetFile  = "file"
+	TargetTable = "table"
+)
+
+// The sets of queries which can be recorded. Each includes the kinds of queries of the ones before it.
+const (
+	QueriesNone = "none"
+	QueriesDDL  = "ddl"
+	QueriesDML  = "dml"
+	QueriesAll  = "all"
+)
+
+// Entry is a single event in the audit log.
+type Entry struct {
+	Time         time.Time     `json:"time"`
+	Event        string        `json:"event"`
+	ConnectionID uint32        `json:"connection_id"`
+	User         string        `json:"user"`
+	Client       string        `json:"client,omitempty"`
+	Database     string        `json:"database,omitempty"`
+	Branch       string        `json:"branch,omitempty"`
+	Kind         string        `json:"kind,omitempty"`
+	Query        string        `json:"query,omitempty"`
+	Tables       []string      `json:"tables,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Duration     time.Duration `json:"duration_ns,omitempty"`
+}
+
+// Options configures a Logger.
+type Options struct {
+	// Target is TargetFile or TargetTable.
+	Target string
+	// Path is the file entries are written to for TargetFile.
+	Path string
+	// Queries is which queries are recorded, one of QueriesNone, QueriesDDL, QueriesDML or QueriesAll.
+	Queries string
+	// MaxSize is the size in bytes the file may grow to before it is rotated, or 0 to never rotate it.
+	MaxSize int64
+	// MaxFiles is the number of rotated files kept in addition to the current one.
+	MaxFiles int
+	// MaxEntries is the number of entries kept in memory for TargetTable.
+	MaxEntries int
+}
+
+type sink interface {
+	write(Entry) error
+	close() error
+}
+
+// Logger writes entries to the audit log. It is safe for concurrent use.
+type Logger struct {
+	queries string
+	sink    sink
+	memory  *memorySink
+}
+
+// NewLogger returns a Logger configured by |opts|. For TargetFile, the file is opened, and created if necessary.
+func NewLogger(opts Options) (*Logger, error) {
+	l := &Logger{queries: opts.Queries}
+	switch opts.Target {
+	case TargetFile:
+		f, err := newFileSink(opts.Path, opts.MaxSize, opts.MaxFiles)
+		if err != nil {
+			return nil, err
+		}
+		l.sink = f
+	case TargetTable:
+		l.memory = newMemorySink(opts.MaxEntries)
+		l.sink = l.memory
+	default:
+		return nil, fmt.Errorf("unknown audit log target: %s", opts.Target)
+	}
+	return l, nil
+}
+
+// RecordsQueries returns whether queries of |kind| are recorded.
+func (l *Logger) RecordsQueries(kind string) bool {
+	switch l.queries {
+	case QueriesAll:
+		return true
+	case QueriesDML:
+		return kind == KindDDL || kind == KindDML
+	case QueriesDDL:
+		return kind == KindDDL
+	default:
+		return false
+	}
+}
+
+// Log writes |e| to the audit log, setting its time to now if it isn't set. Failures to write are logged rather
+// than returned, so that they don't fail the query being recorded.
+func (l *Logger) Log(e Entry) {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if err := l.sink.write(e); err != nil {
+		logrus.WithError(err).Error("failed to write audit log entry")
+	}
+}
+
+// Entries returns the entries held in memory, oldest first. Only a Logger with TargetTable holds any.
+func (l *Logger) Entries() []Entry {
+	if l.memory == nil {
+		return nil
+	}
+	return l.memory.entries()
+}
+
+// Close closes the file being written to, if any.
+func (l *Logger) Close() error {
+	return l.sink.close()
+}
+
+var current atomic.Pointer[Logger]
+
+// SetCurrent makes |l| the audit log of this process, whose entries are exposed through the dolt_audit_log system
+// table. |l| may be nil to disable it.
+func SetCurrent(l *Logger) {
+	current.Store(l)
+}
+
+// Current returns the audit log of this process, or nil if there isn't one.
+func Current() *Logger {
+	return current.Load()
+}
+
+// memorySink keeps the most recent entries in a ring buffer.
+type memorySink struct {
+	mu    sync.Mutex
+	buf   []Entry
+	next  int
+	count int
+}
+
+func newMemorySink(size int) *memorySink {
+	if size < 1 {
+		size = 1
+	}
+	return &memorySink{buf: make([]Entry, size)}
+}
+
+func (m *memorySink) write(e Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.buf[m.next] = e
+	m.next = (m.next + 1) % len(m.buf)
+	if m.count < len(m.buf) {
+		m.count++
+	}
+	return nil
+}
+
+func (m *memorySink) entries() []Entry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ret := make([]Entry, 0, m.count)
+	start := (m.next - m.count + len(m.buf)) % len(m.buf)
+	for i := 0; i < m.count; i++ {
+		ret = append(ret, m.buf[(start+i)%len(m.buf)])
+	}
+	return ret
+}
+
+func (m *memorySink) close() error {
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		query  string
+		kind   string
+		tables []string
+	}{
+		{"create table t (pk int primary key)", KindDDL, []string{"t"}},
+		{"alter table t add column c int", KindDDL, []string{"t"}},
+		{"rename table a to b", KindDDL, []string{"a", "b"}},
+		{"create database db2", KindDDL, nil},
+		{"create user 'u'@'%'", KindDDL, nil},
+		{"insert into t values (1)", KindDML, []string{"t"}},
+		{"update t set c = 1 where t.pk = 2", KindDML, []string{"t"}},
+		{"delete from db2.t where pk in (select pk from u)", KindDML, []string{"db2.t", "u"}},
+		{"call dolt_commit('-am', 'msg')", KindDML, nil},
+		{"select * from t join u on t.pk = u.pk", KindRead, []string{"t", "u"}},
+		{"show tables", KindRead, nil},
+		{"set @@autocommit = 0", KindOther, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			stmt, err := sqlparser.Parse(test.query)
+			require.NoError(t, err)
+			kind, tables := Classify(stmt)
+			assert.Equal(t, test.kind, kind)
+			assert.Equal(t, test.tables, tables)
+		})
+	}
+}
+
+func TestRecordsQueries(t *testing.T) {
+	kinds := []string{KindDDL, KindDML, KindRead, KindOther}
+	expected := map[string][]bool{
+		QueriesNone: {false, false, false, false},
+		QueriesDDL:  {true, false, false, false},
+		QueriesDML:  {true, true, false, false},
+		QueriesAll:  {true, true, true, true},
+	}
+	for queries, records := range expected {
+		l, err := NewLogger(Options{Target: TargetTable, Queries: queries, MaxEntries: 1})
+		require.NoError(t, err)
+		for i, kind := range kinds {
+			assert.Equal(t, records[i], l.RecordsQueries(kind), "%s records %s", queries, kind)
+		}
+	}
+}
+
+func TestMemoryTarget(t *testing.T) {
+	l, err := NewLogger(Options{Target: TargetTable, Queries: QueriesAll, MaxEntries: 3})
+	require.NoError(t, err)
+	assert.Empty(t, l.Entries())
+
+	for _, q := range []string{"q1", "q2", "q3", "q4", "q5"} {
+		l.Log(Entry{Event: EventQuery, Query: q})
+	}
+	entries := l.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "q3", entries[0].Query)
+	assert.Equal(t, "q4", entries[1].Query)
+	assert.Equal(t, "q5", entries[2].Query)
+	assert.False(t, entries[0].Time.IsZero())
+	require.NoError(t, l.Close())
+}
+
+func TestFileTarget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	line, err := json.Marshal(Entry{Event: EventQuery, Query: "q0"})
+	require.NoError(t, err)
+	// Each file holds roughly two entries
+	l, err := NewLogger(Options{Target: TargetFile, Path: path, Queries: QueriesAll, MaxSize: int64(len(line)*2 + 64), MaxFiles: 2})
+	require.NoError(t, err)
+	for _, q := range []string{"q1", "q2", "q3", "q4", "q5", "q6", "q7"} {
+		l.Log(Entry{Event: EventQuery, Query: q})
+	}
+	require.NoError(t, l.Close())
+	assert.Nil(t, l.Entries())
+
+	assert.Equal(t, []string{"q7"}, readQueries(t, path))
+	assert.Equal(t, []string{"q5", "q6"}, readQueries(t, path+".1"))
+	assert.Equal(t, []string{"q3", "q4"}, readQueries(t, path+".2"))
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func readQueries(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var queries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(scanner.Text())), &e))
+		queries = append(queries, e.Query)
+	}
+	require.NoError(t, scanner.Err())
+	return queries
+}
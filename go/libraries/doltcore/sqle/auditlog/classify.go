@@ -0,0 +1,99 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// Classify returns the kind of |stmt|, one of KindDDL, KindDML, KindRead or KindOther, and the tables it names.
+// Statements which change schemas, databases, users or privileges are DDL, and statements which may write data,
+// including calls to stored procedures such as dolt_commit(), are DML. Tables qualified by a database are returned
+// as database.table.
+func Classify(stmt sqlparser.Statement) (string, []string) {
+	return kindOf(stmt), tablesOf(stmt)
+}
+
+func kindOf(stmt sqlparser.Statement) string {
+	switch stmt.(type) {
+	case *sqlparser.DDL, *sqlparser.AlterTable, *sqlparser.DBDDL,
+		*sqlparser.CreateUser, *sqlparser.RenameUser, *sqlparser.DropUser,
+		*sqlparser.CreateRole, *sqlparser.DropRole,
+		*sqlparser.GrantPrivilege, *sqlparser.GrantRole, *sqlparser.GrantProxy,
+		*sqlparser.RevokePrivilege, *sqlparser.RevokeAllPrivileges, *sqlparser.RevokeRole, *sqlparser.RevokeProxy:
+		return KindDDL
+	case *sqlparser.Insert, *sqlparser.Update, *sqlparser.Delete, *sqlparser.Load, *sqlparser.Call:
+		return KindDML
+	case sqlparser.SelectStatement, *sqlparser.Show, *sqlparser.Explain, *sqlparser.ShowGrants, *sqlparser.ShowPrivileges:
+		return KindRead
+	default:
+		return KindOther
+	}
+}
+
+func tablesOf(stmt sqlparser.Statement) []string {
+	seen := make(map[string]struct{})
+	add := func(t sqlparser.TableName) {
+		name := t.Name.String()
+		if name == "" {
+			return
+		}
+		if db := t.DbQualifier.String(); db != "" {
+			name = db + "." + name
+		}
+		seen[strings.ToLower(name)] = struct{}{}
+	}
+	addDDL := func(ddl *sqlparser.DDL) {
+		add(ddl.Table)
+		for _, t := range ddl.FromTables {
+			add(t)
+		}
+		for _, t := range ddl.ToTables {
+			add(t)
+		}
+	}
+
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		switch n := node.(type) {
+		case *sqlparser.ColName:
+			// The qualifier of a column is a table name or alias which is already named in the FROM clause
+			return false, nil
+		case sqlparser.TableName:
+			add(n)
+		case *sqlparser.TableName:
+			add(*n)
+		case *sqlparser.DDL:
+			addDDL(n)
+		case *sqlparser.AlterTable:
+			for _, ddl := range n.Statements {
+				addDDL(ddl)
+			}
+		}
+		return true, nil
+	}, stmt)
+
+	if len(seen) == 0 {
+		return nil
+	}
+	tables := make([]string, 0, len(seen))
+	for t := range seen {
+		tables = append(tables, t)
+	}
+	sort.Strings(tables)
+	return tables
+}
@@ -15,6 +15,7 @@
 package binlogreplication
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -801,6 +802,60 @@ func TestBinlogPrimary_InsertUpdateDelete(t *testing.T) {
 	})
 }
 
+// TestBinlogPrimary_StatementBasedChecksums tests that sessions that set @@binlog_format to STATEMENT, as
+// pt-table-checksum does, log their autocommitted statements so that replicas compute their own checksums.
+func TestBinlogPrimary_StatementBasedChecksums(t *testing.T) {
+	h := newHarness(t)
+	h.startSqlServersWithDoltSystemVars(doltReplicationPrimarySystemVars)
+	h.setupForDoltToMySqlReplication()
+	h.startReplicationAndCreateTestDb(h.doltPort)
+
+	h.primaryDatabase.MustExec("create table db01.t (pk int primary key, c1 varchar(20));")
+	h.primaryDatabase.MustExec("create table db01.checksums (tbl varchar(64) primary key, this_crc varchar(40), this_cnt int);")
+	h.primaryDatabase.MustExec("insert into db01.t values (1, 'one'), (2, NULL), (3, 'three');")
+	h.waitForReplicaToCatchUp()
+
+	// Make the replica diverge from the primary
+	h.replicaDatabase.MustExec("update db01.t set c1 = 'tres' where pk = 3;")
+
+	checksumQuery := "replace into db01.checksums select 't', coalesce(lower(conv(bit_xor(cast(crc32(concat_ws('#', pk, c1, concat(isnull(c1)))) as unsigned)), 10, 16)), 0), count(*) from db01.t;"
+	var primaryCrc, replicaCrc string
+
+	// Row-based logging copies the primary's checksum to the replica
+	h.primaryDatabase.MustExec(checksumQuery)
+	h.waitForReplicaToCatchUp()
+	require.NoError(t, h.primaryDatabase.QueryRowx("select this_crc from db01.checksums;").Scan(&primaryCrc))
+	require.NoError(t, h.replicaDatabase.QueryRowx("select this_crc from db01.checksums;").Scan(&replicaCrc))
+	require.Equal(t, primaryCrc, replicaCrc)
+
+	// Statement-based logging makes the replica compute its own checksum
+	conn, err := h.primaryDatabase.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(context.Background(), "set session binlog_format = 'STATEMENT';")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), checksumQuery)
+	require.NoError(t, err)
+	h.waitForReplicaToCatchUp()
+	require.NoError(t, h.primaryDatabase.QueryRowx("select this_crc from db01.checksums;").Scan(&primaryCrc))
+	require.NoError(t, h.replicaDatabase.QueryRowx("select this_crc from db01.checksums;").Scan(&replicaCrc))
+	require.NotEqual(t, primaryCrc, replicaCrc)
+
+	// Changes made in explicit transactions are still logged as rows
+	_, err = conn.ExecContext(context.Background(), "start transaction;")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), "insert into db01.t values (4, left(uuid(), 20));")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(context.Background(), "commit;")
+	require.NoError(t, err)
+	h.waitForReplicaToCatchUp()
+
+	var primaryC1, replicaC1 string
+	require.NoError(t, h.primaryDatabase.QueryRowx("select c1 from db01.t where pk = 4;").Scan(&primaryC1))
+	require.NoError(t, h.replicaDatabase.QueryRowx("select c1 from db01.t where pk = 4;").Scan(&replicaC1))
+	require.Equal(t, primaryC1, replicaC1)
+}
+
 // TestBinlogPrimary_OnlyReplicateMainBranch tests that binlog events are only generated for the main branch of a Dolt repository.
 func TestBinlogPrimary_OnlyReplicateMainBranch(t *testing.T) {
 	h := newHarness(t)
@@ -125,19 +125,28 @@ func (b *binlogProducer) WorkingRootUpdated(ctx *sql.Context, databaseName strin
 		// Send a Query BEGIN event to start the new transaction
 		binlogEvents = append(binlogEvents, b.newQueryEvent(databaseName, "BEGIN"))
 
-		// Create TableMap events describing the schemas of the tables being updated
-		tableMapEvents, tablesToId, err := b.createTableMapEvents(ctx, databaseName, tableDeltas)
-		if err != nil {
-			return err
-		}
-		binlogEvents = append(binlogEvents, tableMapEvents...)
+		if query, ok := statementToLog(ctx); ok {
+			// The session asked for statement-based logging, so replicas execute the statement themselves
+			currentDb := ctx.GetCurrentDatabase()
+			if currentDb == "" {
+				currentDb = databaseName
+			}
+			binlogEvents = append(binlogEvents, b.newQueryEvent(currentDb, query))
+		} else {
+			// Create TableMap events describing the schemas of the tables being updated
+			tableMapEvents, tablesToId, err := b.createTableMapEvents(ctx, databaseName, tableDeltas)
+			if err != nil {
+				return err
+			}
+			binlogEvents = append(binlogEvents, tableMapEvents...)
 
-		// Loop over the tableDeltas to pull out their diff contents
-		rowEvents, err := b.createRowEvents(ctx, tableDeltas, tablesToId)
-		if err != nil {
-			return err
+			// Loop over the tableDeltas to pull out their diff contents
+			rowEvents, err := b.createRowEvents(ctx, tableDeltas, tablesToId)
+			if err != nil {
+				return err
+			}
+			binlogEvents = append(binlogEvents, rowEvents...)
 		}
-		binlogEvents = append(binlogEvents, rowEvents...)
 
 		// Add an XID event to mark the transaction as completed
 		binlogEvents = append(binlogEvents, b.newXIDEvent())
@@ -275,6 +284,34 @@ func (b *binlogProducer) createGtidEvent(ctx *sql.Context) (mysql.BinlogEvent, e
 	return binlogEvent, nil
 }
 
+// statementToLog returns the statement being executed by |ctx| and true if the session has set @@binlog_format to
+// STATEMENT and the data change being logged came from a single, autocommitted INSERT, REPLACE, UPDATE or DELETE
+// statement. Replicas then execute the statement themselves instead of applying the primary's row changes, which is
+// what checksum tools such as pt-table-checksum rely on to compare a replica's data with its primary. Changes
+// committed by an explicit COMMIT, and changes made by any other statements, are always logged as row events,
+// since the statements that made them can't be replayed on a MySQL replica.
+func statementToLog(ctx *sql.Context) (string, bool) {
+	format, err := ctx.GetSessionVariable(ctx, binlogFormatSysVar)
+	if err != nil {
+		return "", false
+	}
+	if s, ok := format.(string); !ok || !strings.EqualFold(s, "STATEMENT") {
+		return "", false
+	}
+
+	query := strings.TrimSpace(ctx.Query())
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return "", false
+	}
+	switch strings.ToUpper(words[0]) {
+	case "INSERT", "REPLACE", "UPDATE", "DELETE":
+		return query, true
+	default:
+		return "", false
+	}
+}
+
 // createSchemaChangeQueryEvents processes the specified |tableDeltas| for the database |databaseName| at |newRoot| and returns
 // a slice of binlog events that replicate any schema changes in the TableDeltas, as well as a boolean indicating if
 // any TableDeltas were seen that contain data changes that need to be replicated.
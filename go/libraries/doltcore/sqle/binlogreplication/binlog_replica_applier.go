@@ -39,6 +39,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqlserver"
@@ -1010,7 +1011,7 @@ func executeQueryWithEngine(ctx *sql.Context, engine *gms.Engine, query string)
 	_, iter, _, err := engine.Query(queryCtx, query)
 	if err != nil {
 		// Log any errors, except for commits with "nothing to commit"
-		if err.Error() != "nothing to commit" {
+		if !dprocedures.HasErrorCode(err, dprocedures.ErrCodeNothingToCommit) {
 			queryCtx.GetLogger().WithFields(logrus.Fields{
 				"error": err.Error(),
 				"query": query,
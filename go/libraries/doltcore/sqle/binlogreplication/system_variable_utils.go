@@ -22,6 +22,9 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/types"
 )
 
+// binlogFormatSysVar is the system variable that chooses between row-based and statement-based logging.
+const binlogFormatSysVar = "binlog_format"
+
 // getServerId returns the @@server_id global system variable value. If the value of @@server_id is 0 or is not a
 // uint32 value, then an error is returned.
 func getServerId(ctx *sql.Context) (uint32, error) {
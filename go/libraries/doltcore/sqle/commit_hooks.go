@@ -18,13 +18,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
 )
@@ -33,6 +36,7 @@ type PushOnWriteHook struct {
 	destDB *doltdb.DoltDB
 	tmpDir string
 	out    io.Writer
+	filter *pushFilter
 }
 
 var _ doltdb.CommitHook = (*PushOnWriteHook)(nil)
@@ -43,28 +47,34 @@ func NewPushOnWriteHook(destDB *doltdb.DoltDB, tmpDir string) *PushOnWriteHook {
 	return &PushOnWriteHook{
 		destDB: destDB,
 		tmpDir: tmpDir,
+		filter: newPushFilter(),
 	}
 }
 
 // Execute implements CommitHook, replicates head updates to the destDb field
 func (ph *PushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db *doltdb.DoltDB) (func(context.Context) error, error) {
-	return nil, pushDataset(ctx, ph.destDB, db, ds, ph.tmpDir)
+	return nil, pushDataset(ctx, ph.destDB, db, ds, ph.tmpDir, ph.filter)
 }
 
-func pushDataset(ctx context.Context, destDB, srcDB *doltdb.DoltDB, ds datas.Dataset, tmpDir string) error {
+func pushDataset(ctx context.Context, destDB, srcDB *doltdb.DoltDB, ds datas.Dataset, tmpDir string, filter *pushFilter) error {
+	rf, err := ref.Parse(ds.ID())
+	if err != nil {
+		return err
+	}
+
 	addr, ok := ds.MaybeHeadAddr()
-	if !ok {
-		// TODO: fix up hack usage.
-		_, err := doltdb.HackDatasDatabaseFromDoltDB(destDB).Delete(ctx, ds, "")
+	addr, push, err := filter.apply(ctx, srcDB, rf, addr)
+	if err != nil || !push {
 		return err
 	}
 
-	err := destDB.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{addr}, nil, nil)
-	if err != nil {
+	if !ok {
+		// TODO: fix up hack usage.
+		_, err := doltdb.HackDatasDatabaseFromDoltDB(destDB).Delete(ctx, ds, "")
 		return err
 	}
 
-	rf, err := ref.Parse(ds.ID())
+	err = destDB.PullChunks(ctx, tmpDir, srcDB, []hash.Hash{addr}, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -100,26 +110,49 @@ type PushArg struct {
 }
 
 type AsyncPushOnWriteHook struct {
-	out io.Writer
-	ch  chan PushArg
+	out       io.Writer
+	ch        chan PushArg
+	drop      bool
+	saturated atomic.Bool
 }
 
 const (
 	asyncPushBufferSize    = 2048
 	asyncPushInterval      = 500 * time.Millisecond
+	asyncPushMaxBackoff    = time.Minute
 	asyncPushProcessCommit = "async_push_process_commit"
 	asyncPushSyncReplica   = "async_push_sync_replica"
 )
 
 var _ doltdb.CommitHook = (*AsyncPushOnWriteHook)(nil)
 
-// NewAsyncPushOnWriteHook creates a AsyncReplicateHook
+// NewAsyncPushOnWriteHook creates a AsyncReplicateHook. Its queue of head updates is sized by
+// @@dolt_async_replication_queue_size, and @@dolt_async_replication_drop_policy decides whether commits block or
+// drop their head update when the queue is full.
 func NewAsyncPushOnWriteHook(destDB *doltdb.DoltDB, tmpDir string, logger io.Writer) (*AsyncPushOnWriteHook, RunAsyncThreads) {
-	ch := make(chan PushArg, asyncPushBufferSize)
+	size, drop := asyncReplicationQueueConfig()
+	ch := make(chan PushArg, size)
 	runThreads := func(bThreads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error)) error {
 		return RunAsyncReplicationThreads(bThreads, ctxF, ch, destDB, tmpDir, logger)
 	}
-	return &AsyncPushOnWriteHook{ch: ch}, runThreads
+	return &AsyncPushOnWriteHook{ch: ch, drop: drop}, runThreads
+}
+
+// asyncReplicationQueueConfig returns the configured size of the async replication queue, and whether head updates
+// are dropped rather than blocking commits when it is full.
+func asyncReplicationQueueConfig() (int, bool) {
+	size := asyncPushBufferSize
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.AsyncReplicationQueueSize); ok {
+		if i, ok := val.(int64); ok && i > 0 {
+			size = int(i)
+		}
+	}
+	var drop bool
+	if _, val, ok := sql.SystemVariables.GetGlobal(dsess.AsyncReplicationDropPolicy); ok {
+		policy, _ := val.(string)
+		drop = strings.EqualFold(policy, "drop")
+	}
+	return size, drop
 }
 
 func (*AsyncPushOnWriteHook) ExecuteForWorkingSets() bool {
@@ -129,11 +162,46 @@ func (*AsyncPushOnWriteHook) ExecuteForWorkingSets() bool {
 // Execute implements CommitHook, replicates head updates to the destDb field
 func (ah *AsyncPushOnWriteHook) Execute(ctx context.Context, ds datas.Dataset, db *doltdb.DoltDB) (func(context.Context) error, error) {
 	addr, _ := ds.MaybeHeadAddr()
-	// TODO: Unconditional push here seems dangerous.
-	ah.ch <- PushArg{ds: ds, db: db, hash: addr}
+	p := PushArg{ds: ds, db: db, hash: addr}
+	if ah.drop {
+		select {
+		case ah.ch <- p:
+		default:
+			// The replica catches up on the next commit to the same ref, which pushes its history as well.
+			asyncReplicationMetrics.dropped.Add(1)
+			ah.warn(fmt.Sprintf("async replication queue is full, dropped replication of %s to %s\n", ds.ID(), addr.String()))
+			return nil, ctx.Err()
+		}
+	} else {
+		// TODO: Unconditional push here seems dangerous.
+		ah.ch <- p
+	}
+	asyncReplicationMetrics.queued.Add(1)
+	asyncReplicationMetrics.queueLength.Add(1)
+
+	if n := len(ah.ch); n >= cap(ah.ch)*3/4 {
+		if ah.saturated.CompareAndSwap(false, true) {
+			ah.warn(fmt.Sprintf("async replication queue is %d%% full, commits will %s when it is full\n", n*100/cap(ah.ch), ah.saturationEffect()))
+		}
+	} else if n < cap(ah.ch)/2 {
+		ah.saturated.Store(false)
+	}
 	return nil, ctx.Err()
 }
 
+func (ah *AsyncPushOnWriteHook) saturationEffect() string {
+	if ah.drop {
+		return "be dropped from replication"
+	}
+	return "block"
+}
+
+func (ah *AsyncPushOnWriteHook) warn(msg string) {
+	if ah.out != nil {
+		ah.out.Write([]byte(msg))
+	}
+}
+
 // HandleError implements CommitHook
 func (ah *AsyncPushOnWriteHook) HandleError(ctx context.Context, err error) error {
 	if ah.out != nil {
@@ -187,9 +255,59 @@ func (*LogHook) ExecuteForWorkingSets() bool {
 	return false
 }
 
+// AsyncReplicationStats reports the activity of the async push on write hooks of every database.
+type AsyncReplicationStats struct {
+	// QueueLength is the number of head updates waiting to be coalesced.
+	QueueLength int64
+	// Queued is the number of head updates queued since the server started.
+	Queued int64
+	// Dropped is the number of head updates dropped because the queue was full.
+	Dropped int64
+	// Pushed is the number of successful pushes to replicas.
+	Pushed int64
+	// Failed is the number of failed pushes to replicas, including retries.
+	Failed int64
+	// Retrying is the number of refs whose push is waiting to be retried.
+	Retrying int64
+}
+
+var asyncReplicationMetrics struct {
+	queueLength atomic.Int64
+	queued      atomic.Int64
+	dropped     atomic.Int64
+	pushed      atomic.Int64
+	failed      atomic.Int64
+	retrying    atomic.Int64
+}
+
+// GetAsyncReplicationStats returns the current AsyncReplicationStats.
+func GetAsyncReplicationStats() AsyncReplicationStats {
+	return AsyncReplicationStats{
+		QueueLength: asyncReplicationMetrics.queueLength.Load(),
+		Queued:      asyncReplicationMetrics.queued.Load(),
+		Dropped:     asyncReplicationMetrics.dropped.Load(),
+		Pushed:      asyncReplicationMetrics.pushed.Load(),
+		Failed:      asyncReplicationMetrics.failed.Load(),
+		Retrying:    asyncReplicationMetrics.retrying.Load(),
+	}
+}
+
+// asyncPushRetry is a failed push which is retried once |next| has passed, unless a newer head is pushed first.
+type asyncPushRetry struct {
+	arg      PushArg
+	attempts int
+	next     time.Time
+}
+
+// asyncPushBackoff returns how long to wait before retrying a push which has failed |attempts| times.
+func asyncPushBackoff(attempts int) time.Duration {
+	return min(asyncPushInterval<<min(attempts, 10), asyncPushMaxBackoff)
+}
+
 func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ctxF func(context.Context) (*sql.Context, error), ch chan PushArg, destDB *doltdb.DoltDB, tmpDir string, logger io.Writer) error {
 	mu := &sync.Mutex{}
 	var newHeads = make(map[string]PushArg, asyncPushBufferSize)
+	filter := newPushFilter()
 
 	updateHead := func(p PushArg) {
 		mu.Lock()
@@ -214,6 +332,7 @@ func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ctxF func(conte
 				if !ok {
 					return
 				}
+				asyncReplicationMetrics.queueLength.Add(-1)
 				updateHead(p)
 			case <-ctx.Done():
 				stop()
@@ -238,33 +357,65 @@ func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ctxF func(conte
 		return toRet
 	}
 
-	flush := func(newHeads map[string]PushArg, latestHeads map[string]hash.Hash) {
-		newHeadsCopy := getHeadsCopy()
-		if len(newHeadsCopy) == 0 {
-			return
+	push := func(newCm PushArg) error {
+		// use background context to drain after sql context is canceled
+		sqlCtx, err := ctxF(context.Background())
+		if err != nil {
+			return fmt.Errorf("could not create *sql.Context: %w", err)
+		}
+		defer sql.SessionEnd(sqlCtx.Session)
+		sql.SessionCommandBegin(sqlCtx.Session)
+		defer sql.SessionCommandEnd(sqlCtx.Session)
+		return pushDataset(sqlCtx, destDB, newCm.db, newCm.ds, tmpDir, filter)
+	}
+
+	// flush pushes the coalesced head updates, along with the failed pushes which are due for a retry. A newer head
+	// for the same ref replaces a pending retry. When |final| is set, every pending retry is attempted once more and
+	// any which fail again are abandoned.
+	flush := func(latestHeads map[string]hash.Hash, retries map[string]asyncPushRetry, final bool) {
+		toPush := getHeadsCopy()
+		if toPush == nil {
+			toPush = make(map[string]PushArg)
 		}
-		for id, newCm := range newHeadsCopy {
-			if latest, ok := latestHeads[id]; !ok || latest != newCm.hash {
-				func() {
-					// use background context to drain after sql context is canceled
-					sqlCtx, err := ctxF(context.Background())
-					if err != nil {
-						logger.Write([]byte("replication failed: could not create *sql.Context: " + err.Error()))
-					} else {
-						defer sql.SessionEnd(sqlCtx.Session)
-						sql.SessionCommandBegin(sqlCtx.Session)
-						defer sql.SessionCommandEnd(sqlCtx.Session)
-						err := pushDataset(sqlCtx, destDB, newCm.db, newCm.ds, tmpDir)
-						if err != nil {
-							logger.Write([]byte("replication failed: " + err.Error()))
-						}
-						if newCm.hash.IsEmpty() {
-							delete(latestHeads, id)
-						} else {
-							latestHeads[id] = newCm.hash
-						}
-					}
-				}()
+		pending := len(retries)
+		defer func() {
+			asyncReplicationMetrics.retrying.Add(int64(len(retries) - pending))
+		}()
+
+		now := time.Now()
+		for id, r := range retries {
+			if _, ok := toPush[id]; ok {
+				delete(retries, id)
+			} else if final || !now.Before(r.next) {
+				toPush[id] = r.arg
+			}
+		}
+
+		for id, newCm := range toPush {
+			if latest, ok := latestHeads[id]; ok && latest == newCm.hash {
+				delete(retries, id)
+				continue
+			}
+			err := push(newCm)
+			if err != nil {
+				asyncReplicationMetrics.failed.Add(1)
+				if final {
+					delete(retries, id)
+					logger.Write([]byte("replication failed: " + err.Error()))
+					continue
+				}
+				attempts := retries[id].attempts + 1
+				backoff := asyncPushBackoff(attempts)
+				retries[id] = asyncPushRetry{arg: newCm, attempts: attempts, next: now.Add(backoff)}
+				logger.Write([]byte(fmt.Sprintf("replication failed, retrying in %s: %s", backoff, err.Error())))
+				continue
+			}
+			asyncReplicationMetrics.pushed.Add(1)
+			delete(retries, id)
+			if newCm.hash.IsEmpty() {
+				delete(latestHeads, id)
+			} else {
+				latestHeads[id] = newCm.hash
 			}
 		}
 	}
@@ -275,14 +426,15 @@ func RunAsyncReplicationThreads(bThreads *sql.BackgroundThreads, ctxF func(conte
 	err = bThreads.Add(asyncPushSyncReplica, func(ctx context.Context) {
 		defer close(ch)
 		var latestHeads = make(map[string]hash.Hash, asyncPushBufferSize)
+		var retries = make(map[string]asyncPushRetry)
 		ticker := time.NewTicker(asyncPushInterval)
 		for {
 			select {
 			case <-newCtx.Done():
-				flush(newHeads, latestHeads)
+				flush(latestHeads, retries, true)
 				return
 			case <-ticker.C:
-				flush(newHeads, latestHeads)
+				flush(latestHeads, retries, false)
 			}
 		}
 	})
@@ -435,3 +435,10 @@ func createHooksTestTable(vrw types.ValueReadWriter, ns tree.NodeStore, tSchema
 
 	return tbl, nil
 }
+
+func TestAsyncPushBackoff(t *testing.T) {
+	assert.Equal(t, 2*asyncPushInterval, asyncPushBackoff(1))
+	assert.Equal(t, 4*asyncPushInterval, asyncPushBackoff(2))
+	assert.Equal(t, asyncPushMaxBackoff, asyncPushBackoff(10))
+	assert.Equal(t, asyncPushMaxBackoff, asyncPushBackoff(100))
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costmodel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// ErrNoRows is returned by Calibrate for a root with no rows to measure.
+var ErrNoRows = errors.New("calibration needs a table with rows in the working set")
+
+const (
+	// sampleChunks is the number of leaf chunks read to measure chunk read latency.
+	sampleChunks = 1000
+	// minMeasureTime is the minimum time spent measuring decode and comparison costs, to make timer overhead
+	// negligible.
+	minMeasureTime = 50 * time.Millisecond
+)
+
+// Measurements are the costs of the work done by a plan on this machine, measured on a table of a database.
+type Measurements struct {
+	// Table is the table measured, the one with the most rows.
+	Table string
+	// Chunks is the number of leaf chunks read.
+	Chunks int
+	// ChunkRead is the mean latency of reading a leaf chunk from storage.
+	ChunkRead time.Duration
+	// RowsPerChunk is the mean number of rows in a leaf chunk.
+	RowsPerChunk float64
+	// Decode is the mean time to decode the fields of a row.
+	Decode time.Duration
+	// Compare is the mean time to compare two keys.
+	Compare time.Duration
+}
+
+// Coefficients returns the coefficients of the cost model for |m|, relative to the cost of reading a row during a
+// scan: a chunk read for every RowsPerChunk rows and a decode. Reading a row by a point lookup costs a chunk read, a
+// binary search of the chunk and a decode, and a comparison costs Compare. The memory cost of a row isn't measured, so
+// it keeps its default ratio to the cost of a scan.
+func (m Measurements) Coefficients() Coefficients {
+	rowsPerChunk := math.Max(1, m.RowsPerChunk)
+	seqRow := float64(m.ChunkRead)/rowsPerChunk + float64(m.Decode)
+	randRow := float64(m.ChunkRead) + math.Log2(rowsPerChunk)*float64(m.Compare) + float64(m.Decode)
+	if seqRow <= 0 {
+		return Default
+	}
+	// coefficients must be positive, so a comparison too fast to measure costs a nanosecond
+	return Coefficients{
+		CPU:    math.Max(float64(m.Compare), 1) / seqRow,
+		SeqIO:  1,
+		RandIO: math.Max(randRow/seqRow, 1),
+		Mem:    Default.Mem / Default.SeqIO,
+	}
+}
+
+// Calibrate measures the costs of the work done by a plan against the table of |root| with the most rows: the latency
+// of reading a random sample of its leaf chunks from the storage of |ddb|, and the costs of decoding and comparing the
+// rows of those chunks. Chunks are read from storage rather than from the caches of the database, though they may
+// still be served by the caches of the operating system.
+func Calibrate(ctx context.Context, ddb *doltdb.DoltDB, root doltdb.RootValue) (Measurements, error) {
+	name, m, err := largestTable(ctx, root)
+	if err != nil {
+		return Measurements{}, err
+	}
+	addrs, err := leafAddresses(ctx, m)
+	if err != nil {
+		return Measurements{}, err
+	}
+	rand.Shuffle(len(addrs), func(i, j int) {
+		addrs[i], addrs[j] = addrs[j], addrs[i]
+	})
+	if len(addrs) > sampleChunks {
+		addrs = addrs[:sampleChunks]
+	}
+
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	leaves := make([]tree.Node, 0, len(addrs))
+	var read time.Duration
+	rows := 0
+	for _, addr := range addrs {
+		start := time.Now()
+		c, err := cs.Get(ctx, addr)
+		read += time.Since(start)
+		if err != nil {
+			return Measurements{}, err
+		}
+		nd, _, err := tree.NodeFromBytes(c.Data())
+		if err != nil {
+			return Measurements{}, err
+		}
+		leaves = append(leaves, nd)
+		rows += nd.Count()
+	}
+	if rows == 0 {
+		return Measurements{}, ErrNoRows
+	}
+
+	decode, err := measureDecode(ctx, m, leaves, rows)
+	if err != nil {
+		return Measurements{}, err
+	}
+	return Measurements{
+		Table:        name,
+		Chunks:       len(leaves),
+		ChunkRead:    read / time.Duration(len(leaves)),
+		RowsPerChunk: float64(rows) / float64(len(leaves)),
+		Decode:       decode,
+		Compare:      measureCompare(ctx, m.KeyDesc(), leaves),
+	}, nil
+}
+
+// largestTable returns the row data of the table of |root| with the most rows.
+func largestTable(ctx context.Context, root doltdb.RootValue) (string, prolly.Map, error) {
+	names, err := root.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return "", prolly.Map{}, err
+	}
+	var largest string
+	var largestMap prolly.Map
+	largestCount := 0
+	for _, name := range names {
+		tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return "", prolly.Map{}, err
+		} else if !ok {
+			continue
+		}
+		rowData, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return "", prolly.Map{}, err
+		}
+		m, err := durable.ProllyMapFromIndex(rowData)
+		if err != nil {
+			return "", prolly.Map{}, err
+		}
+		cnt, err := m.Count()
+		if err != nil {
+			return "", prolly.Map{}, err
+		}
+		if cnt > largestCount {
+			largest, largestMap, largestCount = name, m, cnt
+		}
+	}
+	if largestCount == 0 {
+		return "", prolly.Map{}, ErrNoRows
+	}
+	return largest, largestMap, nil
+}
+
+// leafAddresses returns the addresses of the leaf chunks of |m|, reading only its internal nodes.
+func leafAddresses(ctx context.Context, m prolly.Map) ([]hash.Hash, error) {
+	root := m.Node()
+	if root.IsLeaf() {
+		return []hash.Hash{root.HashOf()}, nil
+	}
+	var addrs []hash.Hash
+	var walk func(nd tree.Node) error
+	walk = func(nd tree.Node) error {
+		for i := 0; i < nd.Count(); i++ {
+			addr := hash.New(nd.GetValue(i))
+			if nd.Level() == 1 {
+				addrs = append(addrs, addr)
+				continue
+			}
+			child, err := m.NodeStore().Read(ctx, addr)
+			if err != nil {
+				return err
+			}
+			if err = walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return addrs, walk(root)
+}
+
+// measureDecode returns the mean time to decode every field of the |rows| rows of |leaves|.
+func measureDecode(ctx context.Context, m prolly.Map, leaves []tree.Node, rows int) (time.Duration, error) {
+	kd, vd := m.Descriptors()
+	ns := m.NodeStore()
+	decoded := 0
+	start := time.Now()
+	for decoded == 0 || time.Since(start) < minMeasureTime {
+		for _, nd := range leaves {
+			for i := 0; i < nd.Count(); i++ {
+				k, v := val.Tuple(nd.GetKey(i)), val.Tuple(nd.GetValue(i))
+				for j := 0; j < kd.Count(); j++ {
+					if _, err := tree.GetField(ctx, kd, j, k, ns); err != nil {
+						return 0, err
+					}
+				}
+				for j := 0; j < vd.Count(); j++ {
+					if _, err := tree.GetField(ctx, vd, j, v, ns); err != nil {
+						return 0, err
+					}
+				}
+			}
+		}
+		decoded += rows
+	}
+	return time.Since(start) / time.Duration(decoded), nil
+}
+
+// measureCompare returns the mean time to compare adjacent keys of |leaves|.
+func measureCompare(ctx context.Context, kd val.TupleDesc, leaves []tree.Node) time.Duration {
+	compared := 0
+	start := time.Now()
+	for compared == 0 || time.Since(start) < minMeasureTime {
+		for _, nd := range leaves {
+			for i := 1; i < nd.Count(); i++ {
+				kd.Compare(ctx, val.Tuple(nd.GetKey(i-1)), val.Tuple(nd.GetKey(i)))
+			}
+			compared += max(nd.Count()-1, 0)
+		}
+		if compared == 0 {
+			// every leaf has a single key
+			return 0
+		}
+	}
+	return time.Since(start) / time.Duration(compared)
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costmodel
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/memo"
+)
+
+// The constants of the default coster of go-mysql-server which aren't coefficients of the hardware.
+const (
+	degeneratePenalty         = 2.0
+	perKeyCostReductionFactor = 0.5
+	defaultTableSize          = 100
+)
+
+// NewCoster returns a coster which estimates costs with the formulas of the default coster of go-mysql-server, using
+// the coefficients |c| in place of its fixed ones.
+func NewCoster(c Coefficients) memo.Coster {
+	return &coster{c: c, def: memo.NewDefaultCoster()}
+}
+
+type coster struct {
+	c   Coefficients
+	def memo.Coster
+}
+
+var _ memo.Coster = (*coster)(nil)
+
+func (c *coster) EstimateCost(ctx *sql.Context, n memo.RelExpr, s sql.StatsProvider) (float64, error) {
+	cpu, seqIO, mem := c.c.CPU, c.c.SeqIO, c.c.Mem
+	switch n := n.(type) {
+	case *memo.Project:
+		return float64(n.Child.RelProps.GetStats().RowCount()) * cpu, nil
+	case *memo.Distinct:
+		return float64(n.Child.RelProps.GetStats().RowCount()) * (cpu + .75*mem), nil
+	case *memo.Filter:
+		return float64(n.Child.RelProps.GetStats().RowCount()) * cpu * float64(len(n.Filters)), nil
+	case *memo.LookupJoin, *memo.ConcatJoin:
+		return c.costIndexJoin(ctx, n, s)
+	case memo.JoinRel:
+		jp := n.JoinPrivate()
+		lBest := math.Max(1, float64(jp.Left.RelProps.GetStats().RowCount()))
+		rBest := math.Max(1, float64(jp.Right.RelProps.GetStats().RowCount()))
+		selfJoinCard := math.Max(1, float64(n.Group().RelProps.GetStats().RowCount()))
+
+		switch {
+		case jp.Op.IsInner():
+			return (lBest*rBest+1)*seqIO + (lBest*rBest)*cpu, nil
+		case jp.Op.IsDegenerate():
+			return ((lBest*rBest)*seqIO + (lBest*rBest)*cpu) * degeneratePenalty, nil
+		case jp.Op.IsHash():
+			if jp.Op.IsPartial() {
+				return lBest * (rBest / 2.0) * (seqIO + cpu) * .5, nil
+			}
+			return lBest*(seqIO+cpu) + rBest*(seqIO+mem) + selfJoinCard*cpu, nil
+		case jp.Op.IsLateral():
+			return (lBest*rBest-1)*seqIO + (lBest*rBest)*cpu, nil
+		case jp.Op.IsMerge():
+			mj := n.(*memo.MergeJoin)
+			if !mj.Injective {
+				selfJoinCard += math.Max(0, 4-float64(mj.CmpCnt))
+			}
+			lTableScan := tableScanRows(ctx, jp.Left, s)
+			rTableScan := tableScanRows(ctx, jp.Right, s)
+			return (lTableScan+rTableScan)*(seqIO+cpu) + cpu*selfJoinCard, nil
+		case jp.Op.IsRange():
+			return lBest * rBest * perKeyCostReductionFactor * seqIO, nil
+		case jp.Op.IsPartial():
+			return lBest*seqIO + lBest*(rBest/2.0)*(seqIO+cpu), nil
+		case jp.Op.IsFullOuter():
+			return ((lBest*rBest-1)*seqIO + (lBest*rBest)*cpu) * degeneratePenalty, nil
+		case jp.Op.IsLeftOuter():
+			return (lBest*rBest-1)*seqIO + (lBest*rBest)*cpu, nil
+		}
+		return 0, fmt.Errorf("unhandled join type: %T (%s)", n, jp.Op)
+	}
+	return c.def.EstimateCost(ctx, n, s)
+}
+
+// tableScanRows returns the number of rows read by a full scan of |g|, which is more than its row count when it's an
+// index scan.
+func tableScanRows(ctx *sql.Context, g *memo.ExprGroup, s sql.StatsProvider) float64 {
+	if iScan, ok := g.Best.(*memo.IndexScan); ok {
+		n, err := s.RowCount(ctx, iScan.Table.Database().Name(), iScan.Table)
+		if err != nil {
+			return defaultTableSize
+		}
+		return float64(n)
+	}
+	return math.Max(1, float64(g.RelProps.GetStats().RowCount()))
+}
+
+// costIndexJoin returns the cost of a lookup or concat join. Their default costs depend on the selectivity of their
+// lookups, which is only computed by the default coster, so the default cost is decomposed into the number of rows
+// read by lookups and by scans, which are then costed with the coefficients of |c|.
+func (c *coster) costIndexJoin(ctx *sql.Context, n memo.RelExpr, s sql.StatsProvider) (float64, error) {
+	d, err := c.def.EstimateCost(ctx, n, s)
+	if err != nil {
+		return 0, err
+	}
+	def := Default
+	switch n := n.(type) {
+	case *memo.LookupJoin:
+		// the default cost is lBest*seqIO + lookups*(randIO+seqIO)
+		lBest := math.Max(1, float64(n.Left.RelProps.GetStats().RowCount()))
+		lookups := (d - lBest*def.SeqIO) / (def.RandIO + def.SeqIO)
+		return lBest*c.c.SeqIO + lookups*(c.c.RandIO+c.c.SeqIO), nil
+	case *memo.ConcatJoin:
+		// the default cost is lookups*(randIO+cpu) - rRows*seqIO
+		rRows := float64(n.Right.RelProps.GetStats().RowCount())
+		lookups := (d + rRows*def.SeqIO) / (def.RandIO + def.CPU)
+		return lookups*(c.c.RandIO+c.c.CPU) - rRows*c.c.SeqIO, nil
+	}
+	return d, nil
+}
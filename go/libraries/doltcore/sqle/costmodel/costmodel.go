@@ -0,0 +1,84 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package costmodel holds the coefficients of the optimizer's cost model and calibrates them against the storage and
+// CPU of the machine Dolt runs on, so that plans are chosen for the relative costs of reading chunks, decoding rows
+// and comparing keys on that machine rather than for fixed costs.
+package costmodel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+// Coefficients are the relative costs the optimizer assigns to the work done by a plan. Only their ratios matter.
+type Coefficients struct {
+	// CPU is the cost of evaluating a comparison or filter on a row.
+	CPU float64
+	// SeqIO is the cost of reading a row during a scan.
+	SeqIO float64
+	// RandIO is the cost of reading a row by a point lookup.
+	RandIO float64
+	// Mem is the cost of holding a row in memory, as in the build side of a hash join.
+	Mem float64
+}
+
+// Default are the coefficients of the default cost model of go-mysql-server.
+var Default = Coefficients{
+	CPU:    0.01,
+	SeqIO:  1,
+	RandIO: 1.3,
+	Mem:    2,
+}
+
+func (c *Coefficients) keys() map[string]*float64 {
+	return map[string]*float64{
+		config.CostModelCPUKey:    &c.CPU,
+		config.CostModelSeqIOKey:  &c.SeqIO,
+		config.CostModelRandIOKey: &c.RandIO,
+		config.CostModelMemKey:    &c.Mem,
+	}
+}
+
+// FromConfig returns the coefficients stored in |cfg|, with the default for any which aren't set. It returns false if
+// none are set.
+func FromConfig(cfg config.ReadableConfig) (Coefficients, bool, error) {
+	c := Default
+	found := false
+	for k, v := range c.keys() {
+		s, err := cfg.GetString(k)
+		if err == config.ErrConfigParamNotFound {
+			continue
+		} else if err != nil {
+			return Coefficients{}, false, err
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || f <= 0 {
+			return Coefficients{}, false, fmt.Errorf("invalid value for %s: %q", k, s)
+		}
+		*v, found = f, true
+	}
+	return c, found, nil
+}
+
+// Save stores |c| in |cfg|.
+func (c Coefficients) Save(cfg config.WritableConfig) error {
+	updates := make(map[string]string)
+	for k, v := range c.keys() {
+		updates[k] = strconv.FormatFloat(*v, 'g', 4, 64)
+	}
+	return cfg.SetStrings(updates)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costmodel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+)
+
+func TestFromConfig(t *testing.T) {
+	cfg := config.NewEmptyMapConfig()
+	c, ok, err := FromConfig(cfg)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, Default, c)
+
+	require.NoError(t, config.SetString(cfg, config.CostModelRandIOKey, "4.5"))
+	c, ok, err = FromConfig(cfg)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Coefficients{CPU: Default.CPU, SeqIO: Default.SeqIO, RandIO: 4.5, Mem: Default.Mem}, c)
+
+	saved := Coefficients{CPU: 0.002, SeqIO: 1, RandIO: 12.5, Mem: 2}
+	require.NoError(t, saved.Save(cfg))
+	c, ok, err = FromConfig(cfg)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, saved, c)
+
+	for _, invalid := range []string{"fast", "0", "-1"} {
+		require.NoError(t, config.SetString(cfg, config.CostModelCPUKey, invalid))
+		_, _, err = FromConfig(cfg)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestMeasurementsCoefficients(t *testing.T) {
+	m := Measurements{
+		ChunkRead:    100 * time.Microsecond,
+		RowsPerChunk: 128,
+		Decode:       200 * time.Nanosecond,
+		Compare:      50 * time.Nanosecond,
+	}
+	// a scanned row costs 100µs/128 + 200ns = 981.25ns, and a looked up row 100µs + 7*50ns + 200ns = 100.55µs
+	c := m.Coefficients()
+	assert.Equal(t, 1.0, c.SeqIO)
+	assert.InDelta(t, 100550/981.25, c.RandIO, 1e-9)
+	assert.InDelta(t, 50/981.25, c.CPU, 1e-9)
+	assert.Equal(t, Default.Mem, c.Mem)
+
+	// slower storage makes lookups relatively more expensive than scans
+	m.ChunkRead = 10 * time.Millisecond
+	assert.Greater(t, m.Coefficients().RandIO, c.RandIO)
+	assert.Less(t, m.Coefficients().CPU, c.CPU)
+
+	// lookups never cost less than scans, and comparisons are never free
+	fast := Measurements{RowsPerChunk: 1, Decode: time.Microsecond}
+	assert.Equal(t, 1.0, fast.Coefficients().RandIO)
+	assert.Greater(t, fast.Coefficients().CPU, 0.0)
+}
@@ -43,9 +43,11 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/events"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -315,6 +317,10 @@ func (db Database) GetTableInsensitiveAsOf(ctx *sql.Context, tableName string, a
 		}
 		return versionedTable, true, nil
 
+	case *dtables.ExternalTable, *dtables.FederatedTable:
+		// external tables are read from their files or remote databases, which aren't versioned
+		return t, true, nil
+
 	case *plan.EmptyTable:
 		// getTableInsensitive returns *plan.EmptyTable if the table doesn't exist in the data root, but
 		// schemas have been locked to a commit where the table does exist. Since the table is empty,
@@ -631,7 +637,7 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			return nil, false, err
 		}
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
-			dt, found = dtables.NewTagsTable(ctx, lwrName, db.ddb), true
+			dt, found = dtables.NewTagsTable(ctx, lwrName, db), true
 		}
 	case dtables.AccessTableName:
 		basCtx := branch_control.GetBranchAwareSession(ctx)
@@ -666,6 +672,50 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 			versionableTable := backingTable.(dtables.VersionableTable)
 			dt, found = dtables.NewIgnoreTable(ctx, versionableTable, db.schemaName), true
 		}
+	case doltdb.MigrationsTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.MigrationsTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyMigrationsTable(ctx, db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewMigrationsTable(ctx, versionableTable, db.schemaName), true
+		}
+	case doltdb.SummaryTablesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.SummaryTablesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptySummaryTablesTable(ctx, db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewSummaryTablesTable(ctx, versionableTable, db.schemaName), true
+		}
+	case doltdb.ExternalTablesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.ExternalTablesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyExternalTablesTable(ctx, db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewExternalTablesTable(ctx, versionableTable, db.schemaName), true
+		}
+	case doltdb.PlanBaselinesTableName:
+		backingTable, _, err := db.getTable(ctx, root, doltdb.PlanBaselinesTableName)
+		if err != nil {
+			return nil, false, err
+		}
+		if backingTable == nil {
+			dt, found = dtables.NewEmptyPlanBaselinesTable(ctx, db.schemaName), true
+		} else {
+			versionableTable := backingTable.(dtables.VersionableTable)
+			dt, found = dtables.NewPlanBaselinesTable(ctx, versionableTable, db.schemaName), true
+		}
 	case doltdb.GetDocTableName(), doltdb.DocTableName:
 		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
 		if err != nil {
@@ -745,6 +795,70 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		if !resolve.UseSearchPath || isDoltgresSystemTable {
 			dt, found = dtables.NewBackupsTable(db, lwrName), true
 		}
+	case doltdb.GetOperationsTableName(), doltdb.OperationsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewOperationsTable(db.Name(), lwrName), true
+		}
+	case doltdb.GetCacheStatsTableName(), doltdb.CacheStatsTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewCacheStatsTable(db.Name(), lwrName), true
+		}
+	case doltdb.GetStorageUsageTableName(), doltdb.StorageUsageTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewStorageUsageTable(db, lwrName), true
+		}
+	case doltdb.GetTableSizesTableName(), doltdb.TableSizesTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewTableSizesTable(db, lwrName), true
+		}
+	case doltdb.GetAuditLogTableName(), doltdb.AuditLogTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewAuditLogTable(db.Name(), lwrName), true
+		}
+	case doltdb.GetQueryLogTableName(), doltdb.QueryLogTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewQueryLogTable(db.Name(), lwrName), true
+		}
+	case doltdb.GetQuerySummaryTableName(), doltdb.QuerySummaryTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewQuerySummaryTable(db.Name(), lwrName), true
+		}
+	case doltdb.GetEventsHistoryTableName(), doltdb.EventsHistoryTableName:
+		isDoltgresSystemTable, err := resolve.IsDoltgresSystemTable(ctx, tname, root)
+		if err != nil {
+			return nil, false, err
+		}
+		if !resolve.UseSearchPath || isDoltgresSystemTable {
+			dt, found = dtables.NewEventsHistoryTable(db.Name(), lwrName), true
+		}
 	}
 
 	if found {
@@ -766,11 +880,66 @@ func (db Database) getTableInsensitive(ctx *sql.Context, head *doltdb.Commit, ds
 		return table, found, err
 	}
 
+	table, found, err = db.getExternalTable(ctx, root, tblName)
+	if err != nil || found {
+		return table, found, err
+	}
+
 	// If the table wasn't found in the specified data root, check if there is an overridden
 	// schema commit that contains it and return an empty table if so.
 	return resolveOverriddenNonexistentTable(ctx, tblName, db)
 }
 
+// getExternalTable returns the external table with the given name, if it's defined in the dolt_external_tables
+// table of the root given. This is called for every table name that isn't found, so it only reads the definitions if
+// the root has any, and the returned table doesn't read its file or remote database until it's used.
+func (db Database) getExternalTable(ctx *sql.Context, root doltdb.RootValue, tableName string) (sql.Table, bool, error) {
+	if doltdb.HasDoltPrefix(tableName) {
+		return nil, false, nil
+	}
+	if ok, err := root.HasTable(ctx, doltdb.TableName{Name: doltdb.ExternalTablesTableName, Schema: db.schemaName}); err != nil || !ok {
+		return nil, false, err
+	}
+	defs, found, err := db.getTable(ctx, root, doltdb.ExternalTablesTableName)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	partitions, err := defs.Partitions(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	rows := sql.NewTableRowIter(ctx, defs, partitions)
+	defer rows.Close(ctx)
+	for {
+		row, err := rows.Next(ctx)
+		if err == io.EOF {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		name, _ := row[0].(string)
+		if strings.EqualFold(name, tableName) {
+			format, _ := row[1].(string)
+			location, _ := row[2].(string)
+			if dtables.IsFederatedTableFormat(format) {
+				t, err := dtables.NewFederatedTable(ctx, name, format, location)
+				if err != nil {
+					return nil, false, err
+				}
+				return t, true, nil
+			}
+			t, err := dtables.NewExternalTable(ctx, name, format, location)
+			if err != nil {
+				return nil, false, err
+			}
+			return t, true, nil
+		}
+	}
+}
+
 // workingSetStagedRoot returns the staged root for the current session in the database
 // named |dbName|. If a working set is not available (e.g. if a commit or tag is checked
 // out), this function returns an ErrOperationNotSupportedInDetachedHead error.
@@ -1478,6 +1647,7 @@ func (db Database) createSqlTable(ctx *sql.Context, table string, schemaName str
 		return err
 	}
 	doltSch.SetComment(comment)
+	applyPartitioning(ctx, doltSch)
 
 	// Prevent any tables that use Spatial Types as Primary Key from being created
 	if schema.IsUsingSpatialColAsKey(doltSch) {
@@ -1529,6 +1699,7 @@ func (db Database) createIndexedSqlTable(ctx *sql.Context, table string, schemaN
 	if err != nil {
 		return err
 	}
+	applyPartitioning(ctx, doltSch)
 
 	// Prevent any tables that use Spatial Types as Primary Key from being created
 	if schema.IsUsingSpatialColAsKey(doltSch) {
@@ -2008,6 +2179,49 @@ func (db Database) DropTrigger(ctx *sql.Context, name string) error {
 	return db.dropFragFromSchemasTable(ctx, "trigger", name, sql.ErrTriggerDoesNotExist.New(name))
 }
 
+// GetUserFunction returns the serialized definition of the user defined function named, as stored in dolt_schemas.
+func (db Database) GetUserFunction(ctx *sql.Context, name string) (string, bool, error) {
+	tbl, _, err := db.GetTableInsensitive(ctx, doltdb.SchemasTableName)
+	if err != nil {
+		return "", false, err
+	}
+
+	wrapper, ok := tbl.(*SchemaTable)
+	if !ok {
+		return "", false, fmt.Errorf("expected a SchemaTable, but found %T", tbl)
+	}
+	if wrapper.backingTable == nil {
+		return "", false, nil
+	}
+
+	frags, err := getSchemaFragmentsOfType(ctx, wrapper.backingTable, functionFragment)
+	if err != nil {
+		return "", false, err
+	}
+	for _, frag := range frags {
+		if strings.EqualFold(frag.name, name) {
+			return frag.fragment, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// CreateUserFunction stores the user defined function named in dolt_schemas.
+func (db Database) CreateUserFunction(ctx *sql.Context, name, definition string) error {
+	return db.addFragToSchemasTable(ctx,
+		functionFragment,
+		name,
+		definition,
+		time.Now(),
+		dfunctions.ErrUserFunctionExists.New(name),
+	)
+}
+
+// DropUserFunction removes the user defined function named from dolt_schemas.
+func (db Database) DropUserFunction(ctx *sql.Context, name string) error {
+	return db.dropFragFromSchemasTable(ctx, functionFragment, name, sql.ErrFunctionNotFound.New(name))
+}
+
 // GetEvent implements sql.EventDatabase.
 func (db Database) GetEvent(ctx *sql.Context, name string) (sql.EventDefinition, bool, error) {
 	tbl, _, err := db.GetTableInsensitive(ctx, doltdb.SchemasTableName)
@@ -2155,11 +2369,10 @@ func (db Database) getEventScheduler(ctx *sql.Context) sql.EventScheduler {
 
 // SaveEvent implements sql.EventDatabase.
 func (db Database) SaveEvent(ctx *sql.Context, event sql.EventDefinition) (bool, error) {
-	// If the database is NOT on the DefaultInitBranch, then we disable the event, since
-	// events only run from a single branch. We check this by looking at the database's
-	// revision and ensuring it either matches DefaultInitBranch or is empty.
-	// TODO: need better way to determine the default branch; currently it checks only 'main'
-	if (db.revision != env.DefaultInitBranch && db.revision != "") && event.Status == sql.EventStatus_Enable.String() {
+	// If the database is NOT on the DefaultInitBranch or a branch named by @@dolt_event_branches, then we
+	// disable the event, since events only run from those branches. We check this by looking at the
+	// database's revision.
+	if !events.BranchEnabled(db.revision) && event.Status == sql.EventStatus_Enable.String() {
 		ctx.GetLogger().Debugf("disabling event %s (db.revision == %s)", event.Name, db.revision)
 		event.Status = sql.EventStatus_Disable.String()
 		ctx.Session.Warn(&sql.Warning{
@@ -2169,8 +2382,12 @@ func (db Database) SaveEvent(ctx *sql.Context, event sql.EventDefinition) (bool,
 		})
 	}
 
+	// The event scheduler runs the events of other branches against their revision databases, rather than this one,
+	// and picks them up when it reloads events, so it isn't notified of them here.
+	notify := event.Status == sql.EventStatus_Enable.String() && (db.revision == "" || db.revision == env.DefaultInitBranch)
+
 	// TODO: store LastAltered, LastExecuted and TimezoneOffset in appropriate place
-	return event.Status == sql.EventStatus_Enable.String(), db.addFragToSchemasTable(ctx,
+	return notify, db.addFragToSchemasTable(ctx,
 		eventFragment,
 		event.Name,
 		event.CreateEventStatement(),
@@ -2200,13 +2417,12 @@ func (db Database) UpdateLastExecuted(ctx *sql.Context, eventName string, lastEx
 	return nil
 }
 
-// updateEventStatusTemporarilyForNonDefaultBranch updates the event status from ENABLE to DISABLE if it's not default branch.
-// The event status metadata is not updated in storage, but only for display purposes we return event status as 'DISABLE'.
-// This function is used temporarily to implement logic of only allowing enabled events to be executed on default branch.
+// updateEventStatusTemporarilyForNonDefaultBranch updates the event status from ENABLE to DISABLE if it's not default branch
+// or a branch named by @@dolt_event_branches. The event status metadata is not updated in storage, but only for display
+// purposes we return event status as 'DISABLE'. This function is used temporarily to implement logic of only allowing
+// enabled events to be executed on those branches.
 func updateEventStatusTemporarilyForNonDefaultBranch(revision, createStmt string) string {
-	// TODO: need better way to determine the default branch; currently it checks only 'main'
-
-	if revision == "" || revision == env.DefaultInitBranch {
+	if events.BranchEnabled(revision) {
 		return createStmt
 	}
 	return strings.Replace(createStmt, "ENABLE", "DISABLE", 1)
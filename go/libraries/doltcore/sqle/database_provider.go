@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
@@ -45,19 +46,28 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/lockutil"
 	"github.com/dolthub/dolt/go/libraries/utils/valctx"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
 type DoltDatabaseProvider struct {
 	// dbLocations maps a database name to its file system root
-	dbLocations        map[string]filesys.Filesys
-	databases          map[string]dsess.SqlDatabase
-	functions          map[string]sql.Function
-	tableFunctions     map[string]sql.TableFunction
-	externalProcedures sql.ExternalStoredProcedureRegistry
-	InitDatabaseHooks  []InitDatabaseHook
-	DropDatabaseHooks  []DropDatabaseHook
-	mu                 *sync.RWMutex
+	dbLocations         map[string]filesys.Filesys
+	databases           map[string]dsess.SqlDatabase
+	functions           map[string]sql.Function
+	tableFunctions      map[string]sql.TableFunction
+	externalProcedures  sql.ExternalStoredProcedureRegistry
+	InitDatabaseHooks   []InitDatabaseHook
+	CreateDatabaseHooks []CreateDatabaseHook
+	DropDatabaseHooks   []DropDatabaseHook
+	mu                  *sync.RWMutex
+	// catalog is the snapshot of |databases| and |dbLocations| read by lookups, republished under |mu| whenever they
+	// change
+	catalog *atomic.Pointer[providerCatalog]
+	// cloning holds the lowercased names of the databases being cloned, guarded by |mu|. A clone reserves its name
+	// under the lock, then downloads without holding it, so that no other statement can create the same database in
+	// the meantime.
+	cloning map[string]struct{}
 
 	droppedDatabaseManager *droppedDatabaseManager
 
@@ -67,6 +77,21 @@ type DoltDatabaseProvider struct {
 
 	dbFactoryUrl string
 	isStandby    *bool
+
+	// statementRunner is the engine this provider serves, used to run statements on behalf of stored procedures
+	statementRunner sql.StatementRunner
+}
+
+// providerCatalog is an immutable snapshot of the databases a DoltDatabaseProvider manages. Lookups read it without
+// taking the provider's mutex, which is held for the whole of creating, dropping, undropping and cloning a database.
+// Otherwise every transaction start, which pins the roots of every database, and every information_schema or dolt
+// system table query, which enumerate them, would stall until such a statement finished. The databases found this way
+// are then read at the roots pinned by the transaction, see initialDbState, so a query's reads don't contend with
+// concurrent writes to the database either.
+type providerCatalog struct {
+	databases   map[string]dsess.SqlDatabase
+	dbLocations map[string]filesys.Filesys
+	standby     bool
 }
 
 var _ sql.DatabaseProvider = (*DoltDatabaseProvider)(nil)
@@ -148,19 +173,37 @@ func NewDoltDatabaseProviderWithDatabases(defaultBranch string, fs filesys.Files
 		dbFactoryUrl = doltdb.InMemDoltDB
 	}
 
-	return &DoltDatabaseProvider{
+	p := &DoltDatabaseProvider{
 		dbLocations:            dbLocations,
 		databases:              dbs,
 		functions:              funcs,
 		tableFunctions:         tableFuncs,
 		externalProcedures:     externalProcedures,
 		mu:                     &sync.RWMutex{},
+		catalog:                &atomic.Pointer[providerCatalog]{},
+		cloning:                make(map[string]struct{}),
 		fs:                     fs,
 		defaultBranch:          defaultBranch,
 		dbFactoryUrl:           dbFactoryUrl,
 		isStandby:              new(bool),
 		droppedDatabaseManager: newDroppedDatabaseManager(fs),
-	}, nil
+	}
+	p.publishCatalog()
+	return p, nil
+}
+
+// publishCatalog publishes a new snapshot of the provider's databases for lookups to read. It must be called with the
+// provider's mutex locked, after any change to |databases|, |dbLocations| or |isStandby|.
+func (p *DoltDatabaseProvider) publishCatalog() {
+	dbs := make(map[string]dsess.SqlDatabase, len(p.databases))
+	for name, db := range p.databases {
+		dbs[name] = db
+	}
+	locations := make(map[string]filesys.Filesys, len(p.dbLocations))
+	for name, loc := range p.dbLocations {
+		locations[name] = loc
+	}
+	p.catalog.Store(&providerCatalog{databases: dbs, dbLocations: locations, standby: *p.isStandby})
 }
 
 // WithFunctions returns a copy of this provider with the functions given. Any previous functions are removed.
@@ -200,6 +243,12 @@ func (p *DoltDatabaseProvider) AddInitDatabaseHook(hook InitDatabaseHook) {
 	p.InitDatabaseHooks = append(p.InitDatabaseHooks, hook)
 }
 
+// AddCreateDatabaseHook adds a CreateDatabaseHook to this provider. The hook will be invoked
+// whenever a database is created with CREATE DATABASE, once it's usable.
+func (p *DoltDatabaseProvider) AddCreateDatabaseHook(hook CreateDatabaseHook) {
+	p.CreateDatabaseHooks = append(p.CreateDatabaseHooks, hook)
+}
+
 // AddDropDatabaseHook adds a DropDatabaseHook to this provider. The hook will be invoked
 // whenever this provider drops a database.
 func (p *DoltDatabaseProvider) AddDropDatabaseHook(hook DropDatabaseHook) {
@@ -229,18 +278,27 @@ func (p *DoltDatabaseProvider) SetIsStandby(standby bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	*p.isStandby = standby
+	p.publishCatalog()
+}
+
+// SetStatementRunner sets the engine that serves this provider, which stored procedures use to run queries with the
+// calling session's privileges.
+func (p *DoltDatabaseProvider) SetStatementRunner(runner sql.StatementRunner) {
+	p.statementRunner = runner
+}
+
+// StatementRunner implements dsess.DoltDatabaseProvider
+func (p *DoltDatabaseProvider) StatementRunner() sql.StatementRunner {
+	return p.statementRunner
 }
 
 // FileSystemForDatabase returns a filesystem, with the working directory set to the root directory
 // of the requested database. If the requested database isn't found, a database not found error
 // is returned.
 func (p *DoltDatabaseProvider) FileSystemForDatabase(dbname string) (filesys.Filesys, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
 	baseName, _ := dsess.SplitRevisionDbName(dbname)
 
-	dbLocation, ok := p.dbLocations[strings.ToLower(baseName)]
+	dbLocation, ok := p.catalog.Load().dbLocations[strings.ToLower(baseName)]
 	if !ok {
 		return nil, sql.ErrDatabaseNotFound.New(dbname)
 	}
@@ -327,7 +385,7 @@ func (p *DoltDatabaseProvider) attemptCloneReplica(ctx *sql.Context, dbName stri
 	// TODO: remote params for AWS, others
 	// TODO: this needs to be robust in the face of the DB not having the default branch
 	// TODO: this treats every database not found error as a clone error, need to tighten
-	err := p.CloneDatabaseFromRemote(ctx, dbName, p.defaultBranch, remoteName, remoteUrl, -1, nil)
+	err := p.CloneDatabaseFromRemote(ctx, dbName, p.defaultBranch, remoteName, remoteUrl, -1, false, nil)
 	if err != nil {
 		return err
 	}
@@ -347,11 +405,11 @@ func (p *DoltDatabaseProvider) AllDatabases(ctx *sql.Context) (all []sql.Databas
 	currentDb := ctx.GetCurrentDatabase()
 	_, currRev := dsess.SplitRevisionDbName(currentDb)
 
-	p.mu.RLock()
 	showBranches, _ := dsess.GetBooleanSystemVar(ctx, dsess.ShowBranchDatabases)
 
-	all = make([]sql.Database, 0, len(p.databases))
-	for _, db := range p.databases {
+	catalog := p.catalog.Load()
+	all = make([]sql.Database, 0, len(catalog.databases))
+	for _, db := range catalog.databases {
 		all = append(all, db)
 
 		if showBranches && db.Name() != clusterdb.DoltClusterDbName {
@@ -364,7 +422,6 @@ func (p *DoltDatabaseProvider) AllDatabases(ctx *sql.Context) (all []sql.Databas
 			all = append(all, revisionDbs...)
 		}
 	}
-	p.mu.RUnlock()
 
 	// If there's a revision database in use, include it in the list (but don't double-count)
 	if currRev != "" && !showBranches {
@@ -387,12 +444,10 @@ func (p *DoltDatabaseProvider) AllDatabases(ctx *sql.Context) (all []sql.Databas
 
 // DoltDatabases implements the dsess.DoltDatabaseProvider interface
 func (p *DoltDatabaseProvider) DoltDatabases() []dsess.SqlDatabase {
-	p.mu.RLock()
-	defer p.mu.RUnlock()
-
-	dbs := make([]dsess.SqlDatabase, len(p.databases))
+	catalog := p.catalog.Load()
+	dbs := make([]dsess.SqlDatabase, len(catalog.databases))
 	i := 0
-	for _, db := range p.databases {
+	for _, db := range catalog.databases {
 		dbs[i] = db
 		i++
 	}
@@ -461,12 +516,28 @@ func commitTransaction(ctx *sql.Context, dSess *dsess.DoltSession, rsc *doltdb.R
 	return nil
 }
 
-func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name string, collation sql.CollationID) (err error) {
+func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name string, collation sql.CollationID) error {
+	newEnv, err := p.createCollatedDatabase(ctx, name, collation)
+	if err != nil {
+		return err
+	}
+
+	// The database is registered and committed, so unlike InitDatabaseHooks, these hooks may run queries against it.
+	// If one fails the database still exists, so that it can be inspected or dropped.
+	for _, hook := range p.CreateDatabaseHooks {
+		if err = hook(ctx, name, newEnv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *DoltDatabaseProvider) createCollatedDatabase(ctx *sql.Context, name string, collation sql.CollationID) (newEnv *env.DoltEnv, err error) {
 	exists, isDir := p.fs.Exists(name)
 	if exists && isDir {
-		return sql.ErrDatabaseExists.New(name)
+		return nil, sql.ErrDatabaseExists.New(name)
 	} else if exists {
-		return fmt.Errorf("Cannot create DB, file exists at %s", name)
+		return nil, fmt.Errorf("Cannot create DB, file exists at %s", name)
 	}
 
 	sess := dsess.DSessFromSess(ctx.Session)
@@ -476,7 +547,7 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 	// one after we create the new DB
 	err = commitTransaction(ctx, sess, &rsc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	p.mu.Lock()
@@ -487,9 +558,15 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 		}
 	}()
 
+	// a clone creates its directory without holding the lock, so check for one here rather than relying on the
+	// directory check above
+	if p.isCloning(name) {
+		return nil, sql.ErrDatabaseExists.New(name)
+	}
+
 	err = p.fs.MkDirs(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func() {
 		// We do not want to leave this directory behind if we do not
@@ -501,16 +578,16 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 
 	newFs, err := p.fs.WithWorkingDir(name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// TODO: fill in version appropriately
-	newEnv := env.Load(ctx, env.GetCurrentUserHomeDir, newFs, p.dbFactoryUrl, "TODO")
+	newEnv = env.Load(ctx, env.GetCurrentUserHomeDir, newFs, p.dbFactoryUrl, "TODO")
 
 	newDbStorageFormat := types.Format_Default
 	err = newEnv.InitRepo(ctx, newDbStorageFormat, sess.Username(), sess.Email(), p.defaultBranch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	updatedCollation, updatedSchemas := false, false
@@ -519,18 +596,18 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 	if collation != sql.Collation_Default {
 		workingRoot, err := newEnv.WorkingRoot(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		newRoot, err := workingRoot.SetCollation(ctx, schema.Collation(collation))
 		if err != nil {
-			return err
+			return nil, err
 		}
 		// As this is a newly created database, we set both the working and staged roots to the same root value
 		if err = newEnv.UpdateWorkingRoot(ctx, newRoot); err != nil {
-			return err
+			return nil, err
 		}
 		if err = newEnv.UpdateStagedRoot(ctx, newRoot); err != nil {
-			return err
+			return nil, err
 		}
 
 		updatedCollation = true
@@ -541,33 +618,33 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 	if resolve.UseSearchPath {
 		workingRoot, err := newEnv.WorkingRoot(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		workingRoot, err = workingRoot.CreateDatabaseSchema(ctx, schema.DatabaseSchema{
 			Name: "public",
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 		workingRoot, err = workingRoot.CreateDatabaseSchema(ctx, schema.DatabaseSchema{
 			Name: "pg_catalog",
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 		workingRoot, err = workingRoot.CreateDatabaseSchema(ctx, schema.DatabaseSchema{
 			Name: doltdb.DoltNamespace,
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if err = newEnv.UpdateWorkingRoot(ctx, workingRoot); err != nil {
-			return err
+			return nil, err
 		}
 		if err = newEnv.UpdateStagedRoot(ctx, workingRoot); err != nil {
-			return err
+			return nil, err
 		}
 
 		updatedSchemas = true
@@ -575,7 +652,7 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 
 	err = p.registerNewDatabase(ctx, name, newEnv)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Since we just created this database, we need to commit the current transaction so that the new database is
@@ -587,7 +664,7 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 
 	err = commitTransaction(ctx, sess, &rsc)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	needsDoltCommit := updatedSchemas || updatedCollation
@@ -599,33 +676,39 @@ func (p *DoltDatabaseProvider) CreateCollatedDatabase(ctx *sql.Context, name str
 		//  commit
 		roots, ok := sess.GetRoots(ctx, name)
 		if !ok {
-			return fmt.Errorf("unable to get roots for database %s", name)
+			return nil, fmt.Errorf("unable to get roots for database %s", name)
 		}
 
 		t := ctx.QueryTime()
 		userName := ctx.Client().User
 		userEmail := fmt.Sprintf("%s@%s", ctx.Client().User, ctx.Client().Address)
 
-		pendingCommit, err := sess.NewPendingCommit(ctx, name, roots, actions.CommitStagedProps{
+		props := actions.CommitStagedProps{
 			Message: "CREATE DATABASE",
 			Date:    t,
 			Name:    userName,
 			Email:   userEmail,
-		})
+		}
+		if err = dsess.SetCommitIdentity(ctx, &props, ""); err != nil {
+			return nil, err
+		}
+
+		pendingCommit, err := sess.NewPendingCommit(ctx, name, roots, props)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		_, err = sess.DoltCommit(ctx, name, sess.GetTransaction(), pendingCommit)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	return nil
+	return newEnv, nil
 }
 
 type InitDatabaseHook func(ctx *sql.Context, pro *DoltDatabaseProvider, name string, env *env.DoltEnv, db dsess.SqlDatabase) error
+type CreateDatabaseHook func(ctx *sql.Context, name string, env *env.DoltEnv) error
 type DropDatabaseHook func(ctx *sql.Context, name string)
 
 // NewConfigureReplicationDatabaseHook sets up the hooks to push to a remote to replicate a newly created database.
@@ -692,22 +775,25 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 	ctx *sql.Context,
 	dbName, branch, remoteName, remoteUrl string,
 	depth int,
+	noTags bool,
 	remoteParams map[string]string,
 ) error {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	exists, isDir := p.fs.Exists(dbName)
-	if exists && isDir {
-		return sql.ErrDatabaseExists.New(dbName)
-	} else if exists {
-		return fmt.Errorf("cannot create DB, file exists at %s", dbName)
+	if err := p.reserveCloneName(dbName); err != nil {
+		return err
 	}
+	defer p.releaseCloneName(dbName)
 
-	err := p.cloneDatabaseFromRemote(ctx, dbName, remoteName, branch, remoteUrl, depth, remoteParams)
+	op := dsess.Operations.Start(ctx, "clone", remoteUrl)
+	defer dsess.Operations.Finish(op)
+
+	err := p.cloneDatabaseFromRemote(ctx, op, dbName, remoteName, branch, remoteUrl, depth, noTags, remoteParams)
 	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("clone of '%s' canceled: %w", remoteUrl, err)
+		}
 		// Make a best effort to clean up any artifacts on disk from a failed clone
 		// before we return the error
+		p.cleanUpFailedClone(dbName)
 		exists, _ := p.fs.Exists(dbName)
 		if exists {
 			deleteErr := p.fs.Delete(dbName, true)
@@ -722,20 +808,66 @@ func (p *DoltDatabaseProvider) CloneDatabaseFromRemote(
 	return nil
 }
 
+// reserveCloneName reserves |dbName| for a clone, returning an error if a database or file by that name already exists
+// or another clone of it is in progress.
+func (p *DoltDatabaseProvider) reserveCloneName(dbName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.cloning[strings.ToLower(dbName)]; ok {
+		return sql.ErrDatabaseExists.New(dbName)
+	}
+	exists, isDir := p.fs.Exists(dbName)
+	if exists && isDir {
+		return sql.ErrDatabaseExists.New(dbName)
+	} else if exists {
+		return fmt.Errorf("cannot create DB, file exists at %s", dbName)
+	}
+	p.cloning[strings.ToLower(dbName)] = struct{}{}
+	return nil
+}
+
+// releaseCloneName releases the reservation of |dbName| made by reserveCloneName.
+func (p *DoltDatabaseProvider) releaseCloneName(dbName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.cloning, strings.ToLower(dbName))
+}
+
+// isCloning returns whether a clone of |dbName| is in progress. It must be called with the provider's mutex locked.
+func (p *DoltDatabaseProvider) isCloning(dbName string) bool {
+	_, ok := p.cloning[strings.ToLower(dbName)]
+	return ok
+}
+
+// cleanUpFailedClone evicts the chunk store of a partially cloned database from the singleton cache, so that a later
+// clone into the same directory doesn't pick up the store of the failed one.
+func (p *DoltDatabaseProvider) cleanUpFailedClone(dbName string) {
+	absPath, err := p.fs.Abs(dbName)
+	if err != nil {
+		return
+	}
+	_ = dbfactory.DeleteFromSingletonCache(filepath.ToSlash(absPath + "/.dolt/noms"))
+}
+
 // cloneDatabaseFromRemote encapsulates the inner logic for cloning a database so that if any error
 // is returned by this function, the caller can capture the error and safely clean up the failed
 // clone directory before returning the error to the user. This function should not be used directly;
-// use CloneDatabaseFromRemote instead.
+// use CloneDatabaseFromRemote instead. It must be called with the name |dbName| reserved, and without the provider
+// mutex locked, which it only takes to register the cloned database.
 func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 	ctx *sql.Context,
+	op *dsess.Operation,
 	dbName, remoteName, branch, remoteUrl string,
 	depth int,
+	noTags bool,
 	remoteParams map[string]string,
 ) error {
 	if p.remoteDialer == nil {
 		return fmt.Errorf("unable to clone remote database; no remote dialer configured")
 	}
 
+	op.SetStatus("connecting to remote")
 	r := env.NewRemote(remoteName, remoteUrl, remoteParams)
 	srcDB, err := r.GetRemoteDB(ctx, types.Format_Default, p.remoteDialer)
 	if err != nil {
@@ -747,21 +879,53 @@ func (p *DoltDatabaseProvider) cloneDatabaseFromRemote(
 		return err
 	}
 
-	err = actions.CloneRemote(ctx, srcDB, remoteName, branch, false, depth, dEnv)
+	op.SetStatus("downloading")
+	err = actions.CloneRemoteWithProgress(ctx, srcDB, remoteName, branch, false, noTags, depth, dEnv, cloneProgressReporter(ctx, op, dbName))
 	if err != nil {
+		_ = dEnv.DoltDB(ctx).Close()
 		return err
 	}
+	if ctx.ProcessList != nil {
+		ctx.ProcessList.RemoveTableProgress(ctx.Pid(), dbName)
+	}
+	// a KILL QUERY which lands after the data is fetched still aborts the clone
+	if err = ctx.Err(); err != nil {
+		_ = dEnv.DoltDB(ctx).Close()
+		return err
+	}
+	op.SetStatus("registering database")
 
 	err = dEnv.RepoStateWriter().UpdateBranch(dEnv.RepoState.CWBHeadRef().GetPath(), env.BranchConfig{
 		Merge:  dEnv.RepoState.Head,
 		Remote: remoteName,
 	})
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.registerNewDatabase(ctx, dbName, dEnv)
 }
 
+// cloneProgressReporter returns a function which reports the progress of a clone, in chunks, to both |op| and the
+// connection's process list entry, so that it is visible in dolt_operations and SHOW PROCESSLIST.
+func cloneProgressReporter(ctx *sql.Context, op *dsess.Operation, dbName string) actions.CloneProgressFunc {
+	var reported actions.CloneProgress
+	return func(progress actions.CloneProgress) {
+		op.SetProgress(progress.ChunksDownloaded, progress.ChunksTotal)
+		if ctx.ProcessList == nil {
+			return
+		}
+		if progress.ChunksTotal != reported.ChunksTotal {
+			ctx.ProcessList.AddTableProgress(ctx.Pid(), dbName, progress.ChunksTotal)
+			ctx.ProcessList.UpdateTableProgress(ctx.Pid(), dbName, progress.ChunksDownloaded)
+		} else if delta := progress.ChunksDownloaded - reported.ChunksDownloaded; delta > 0 {
+			ctx.ProcessList.UpdateTableProgress(ctx.Pid(), dbName, delta)
+		}
+		reported = progress
+	}
+}
+
 // DropDatabase implements the sql.MutableDatabaseProvider interface
-func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error {
+func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) (err error) {
 	_, revision := dsess.SplitRevisionDbName(name)
 	if revision != "" {
 		return fmt.Errorf("unable to drop revision database: %s", name)
@@ -774,6 +938,16 @@ func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error
 	dbKey := formatDbMapKeyName(name)
 	db := p.databases[dbKey]
 
+	// Attached remotes have nothing stored locally, so dropping one only detaches it. Like a dropped database, it's
+	// removed from the catalog before it's closed.
+	if ro, ok := db.(ReadOnlyDatabase); ok && isAttachedRemote(ro) {
+		_ = p.forgetDatabase(dbKey)
+		if err = closeAttachedRemote(ro); err != nil {
+			return err
+		}
+		return p.invalidateDbStateInAllSessions(ctx, name)
+	}
+
 	var database *doltdb.DoltDB
 	if ddb, ok := db.(Database); ok {
 		database = ddb.ddb
@@ -781,17 +955,29 @@ func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error
 		return fmt.Errorf("unable to drop database: %s", name)
 	}
 
-	err := database.Close()
-	if err != nil {
-		return err
-	}
-
 	// get location of database that's being dropped
 	dbLoc := p.dbLocations[dbKey]
 	if dbLoc == nil {
 		return sql.ErrDatabaseNotFound.New(db.Name())
 	}
 
+	// This is published before the database is closed, so that lookups made while it's being dropped don't find a
+	// closed database. If dropping it fails, it's published again, as it would have remained had it never been removed.
+	forgotten := p.forgetDatabase(dbKey)
+	defer func() {
+		if err != nil && forgotten != nil {
+			for dbName, db := range forgotten {
+				p.databases[dbName] = db
+			}
+			p.publishCatalog()
+		}
+	}()
+
+	err = database.Close()
+	if err != nil {
+		return err
+	}
+
 	dropDbLoc, err := dbLoc.Abs("")
 	if err != nil {
 		return err
@@ -811,6 +997,8 @@ func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error
 	if err != nil {
 		return err
 	}
+	// the database's files are gone, so there's nothing left to publish again
+	forgotten = nil
 
 	for _, dropHook := range p.DropDatabaseHooks {
 		// For symmetry with InitDatabaseHook and the names we see in
@@ -818,17 +1006,28 @@ func (p *DoltDatabaseProvider) DropDatabase(ctx *sql.Context, name string) error
 		dropHook(ctx, name)
 	}
 
-	// We not only have to delete tracking metadata for this database, but also for any derivative
-	// ones we've stored as a result of USE or connection strings
+	return p.invalidateDbStateInAllSessions(ctx, name)
+}
+
+// forgetDatabase removes the database |dbKey| from the databases of this provider and publishes the change. We not
+// only have to delete tracking metadata for this database, but also for any derivative ones we've stored as a result
+// of USE or connection strings. Returns the databases it removed, by name. It must be called with the provider's mutex
+// locked.
+func (p *DoltDatabaseProvider) forgetDatabase(dbKey string) map[string]dsess.SqlDatabase {
+	forgotten := make(map[string]dsess.SqlDatabase)
 	derivativeNamePrefix := strings.ToLower(dbKey + dsess.DbRevisionDelimiter)
-	for dbName := range p.databases {
+	for dbName, db := range p.databases {
 		if strings.HasPrefix(strings.ToLower(dbName), derivativeNamePrefix) {
+			forgotten[dbName] = db
 			delete(p.databases, dbName)
 		}
 	}
+	if db, ok := p.databases[dbKey]; ok {
+		forgotten[dbKey] = db
+	}
 	delete(p.databases, dbKey)
-
-	return p.invalidateDbStateInAllSessions(ctx, name)
+	p.publishCatalog()
+	return forgotten
 }
 
 func (p *DoltDatabaseProvider) ListDroppedDatabases(ctx *sql.Context) ([]string, error) {
@@ -846,6 +1045,9 @@ func (p *DoltDatabaseProvider) UndropDatabase(ctx *sql.Context, name string) (er
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	if p.isCloning(name) {
+		return sql.ErrDatabaseExists.New(name)
+	}
 	newFs, exactCaseName, err := p.droppedDatabaseManager.UndropDatabase(ctx, name)
 	if err != nil {
 		return err
@@ -910,6 +1112,7 @@ func (p *DoltDatabaseProvider) registerNewDatabase(ctx *sql.Context, name string
 	formattedName := formatDbMapKeyName(db.Name())
 	p.databases[formattedName] = sdb
 	p.dbLocations[formattedName] = newEnv.FS
+	p.publishCatalog()
 	return nil
 }
 
@@ -964,9 +1167,7 @@ func (p *DoltDatabaseProvider) databaseForRevision(ctx *sql.Context, revisionQua
 		return db, true, nil
 	}
 
-	p.mu.RLock()
-	srcDb, ok := p.databases[formatDbMapKeyName(baseName)]
-	p.mu.RUnlock()
+	srcDb, ok := p.catalog.Load().databases[formatDbMapKeyName(baseName)]
 	if !ok {
 		return nil, false, nil
 	}
@@ -1007,6 +1208,11 @@ func (p *DoltDatabaseProvider) databaseForRevision(ctx *sql.Context, revisionQua
 			srcDb = replicaDb.Database
 		}
 
+		// attached remotes are read-only
+		if ro, ok := srcDb.(ReadOnlyDatabase); ok {
+			srcDb = ro.Database
+		}
+
 		srcDb, ok = srcDb.(Database)
 		if !ok {
 			return nil, false, nil
@@ -1026,6 +1232,11 @@ func (p *DoltDatabaseProvider) databaseForRevision(ctx *sql.Context, revisionQua
 			srcDb = replicaDb.Database
 		}
 
+		// attached remotes are read-only
+		if ro, ok := srcDb.(ReadOnlyDatabase); ok {
+			srcDb = ro.Database
+		}
+
 		srcDb, ok = srcDb.(Database)
 		if !ok {
 			return nil, false, nil
@@ -1122,9 +1333,23 @@ func initialDbState(ctx *sql.Context, db dsess.SqlDatabase, branch string) (dses
 		}
 	}
 
+	// Read the branch at the root pinned by the current transaction, if there is one, so that looking up a database
+	// doesn't see changes made after the transaction started.
+	var nomsRoot hash.Hash
+	var pinned bool
+	if tx, ok := ctx.GetTransaction().(*dsess.DoltTransaction); ok {
+		nomsRoot, pinned = tx.GetInitialRoot(db.Name())
+	}
+
 	var retainedErr error
 
-	headCommit, err := ddb.ResolveCommitRef(ctx, r)
+	var headCommit *doltdb.Commit
+	var err error
+	if pinned {
+		headCommit, err = ddb.ResolveCommitRefAtRoot(ctx, r, nomsRoot)
+	} else {
+		headCommit, err = ddb.ResolveCommitRef(ctx, r)
+	}
 	if err == doltdb.ErrBranchNotFound {
 		retainedErr = err
 		err = nil
@@ -1140,7 +1365,11 @@ func initialDbState(ctx *sql.Context, db dsess.SqlDatabase, branch string) (dses
 			return dsess.InitialDbState{}, err
 		}
 
-		ws, err = db.DbData().Ddb.ResolveWorkingSet(ctx, workingSetRef)
+		if pinned {
+			ws, err = ddb.ResolveWorkingSetAtRoot(ctx, workingSetRef, nomsRoot)
+		} else {
+			ws, err = ddb.ResolveWorkingSet(ctx, workingSetRef)
+		}
 		if err != nil {
 			return dsess.InitialDbState{}, err
 		}
@@ -1252,7 +1481,7 @@ func (p *DoltDatabaseProvider) databaseForClone(ctx *sql.Context, revDB string)
 	//  DB is first referenced
 	tx, ok := ctx.GetTransaction().(*dsess.DoltTransaction)
 	if ok {
-		db := p.databases[dbName]
+		db := p.catalog.Load().databases[dbName]
 		err = tx.AddDb(ctx, db)
 		if err != nil {
 			return nil, err
@@ -1330,11 +1559,7 @@ func (p *DoltDatabaseProvider) BaseDatabase(ctx *sql.Context, name string) (dses
 		baseName = parts[0]
 	}
 
-	var ok bool
-	p.mu.RLock()
-	db, ok := p.databases[strings.ToLower(baseName)]
-	p.mu.RUnlock()
-
+	db, ok := p.catalog.Load().databases[strings.ToLower(baseName)]
 	return db, ok
 }
 
@@ -1350,11 +1575,9 @@ func (p *DoltDatabaseProvider) SessionDatabase(ctx *sql.Context, name string) (d
 		baseName = parts[0]
 	}
 
-	var ok bool
-	p.mu.RLock()
-	db, ok := p.databases[strings.ToLower(baseName)]
-	standby := *p.isStandby
-	p.mu.RUnlock()
+	catalog := p.catalog.Load()
+	db, ok := catalog.databases[strings.ToLower(baseName)]
+	standby := catalog.standby
 
 	// If the database doesn't exist and this is a read replica, attempt to clone it from the remote
 	if !ok {
@@ -1421,13 +1644,56 @@ func (p *DoltDatabaseProvider) SessionDatabase(ctx *sql.Context, name string) (d
 	return wrapForStandby(db, standby), true, nil
 }
 
-// Function implements the FunctionProvider interface
-func (p *DoltDatabaseProvider) Function(_ *sql.Context, name string) (sql.Function, bool) {
+// Function implements the FunctionProvider interface. Besides the Dolt functions, this resolves the user defined
+// functions created in this session with dolt_create_function('--temporary', ...) and those stored in the dolt_schemas
+// table of the current database.
+func (p *DoltDatabaseProvider) Function(ctx *sql.Context, name string) (sql.Function, bool) {
 	fn, ok := p.functions[strings.ToLower(name)]
+	if ok {
+		return fn, true
+	}
+	if ctx == nil || dfunctions.IsReservedFunctionName(name) {
+		return nil, false
+	}
+	return p.userFunction(ctx, name)
+}
+
+func (p *DoltDatabaseProvider) userFunction(ctx *sql.Context, name string) (sql.Function, bool) {
+	var definition string
+	if sess, ok := ctx.Session.(*dsess.DoltSession); ok {
+		definition, ok = sess.GetTemporaryFunction(name)
+		if ok {
+			return newUserFunction(name, definition)
+		}
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	if dbName == "" {
+		return nil, false
+	}
+	db, err := p.Database(ctx, dbName)
+	if err != nil {
+		return nil, false
+	}
+	udb, ok := db.(interface {
+		GetUserFunction(ctx *sql.Context, name string) (string, bool, error)
+	})
 	if !ok {
 		return nil, false
 	}
-	return fn, true
+	definition, ok, err = udb.GetUserFunction(ctx, name)
+	if err != nil || !ok {
+		return nil, false
+	}
+	return newUserFunction(name, definition)
+}
+
+func newUserFunction(name, definition string) (sql.Function, bool) {
+	def, err := dfunctions.ParseUserFunctionDefinition(definition)
+	if err != nil {
+		return nil, false
+	}
+	return dfunctions.NewUserFunction(name, def), true
 }
 
 func (p *DoltDatabaseProvider) Register(d sql.ExternalStoredProcedureDetails) {
@@ -1564,11 +1830,17 @@ func initialStateForBranchDb(ctx *sql.Context, srcDb dsess.SqlDatabase) (dsess.I
 		return dsess.InitialDbState{}, err
 	}
 
+	// Attached remotes are read at the commits of their branches, without any working changes.
+	attached := isAttachedRemote(srcDb)
+
 	// Working set may not exist. Upstream code which calls here must
 	// handle the WorkingSet field being nil.q
-	ws, err := srcDb.DbData().Ddb.ResolveWorkingSetAtRoot(ctx, wsRef, rootHash)
-	if err != nil && err != doltdb.ErrWorkingSetNotFound {
-		return dsess.InitialDbState{}, err
+	var ws *doltdb.WorkingSet
+	if !attached {
+		ws, err = srcDb.DbData().Ddb.ResolveWorkingSetAtRoot(ctx, wsRef, rootHash)
+		if err != nil && err != doltdb.ErrWorkingSetNotFound {
+			return dsess.InitialDbState{}, err
+		}
 	}
 
 	static := staticRepoState{
@@ -1596,6 +1868,7 @@ func initialStateForBranchDb(ctx *sql.Context, srcDb dsess.SqlDatabase) (dsess.I
 		Db:         srcDb,
 		HeadCommit: cm,
 		WorkingSet: ws,
+		ReadOnly:   attached,
 		DbData: env.DbData[*sql.Context]{
 			Ddb: srcDb.DbData().Ddb,
 			Rsw: static,
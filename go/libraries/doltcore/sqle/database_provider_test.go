@@ -17,7 +17,9 @@ package sqle
 import (
 	"context"
 	"io"
+	"os"
 	"testing"
+	"time"
 
 	sqle "github.com/dolthub/go-mysql-server"
 	"github.com/dolthub/go-mysql-server/sql"
@@ -140,6 +142,164 @@ func TestDatabaseProvider(t *testing.T) {
 	})
 }
 
+func TestDatabaseProviderLookupsDontBlockOnCreateDatabase(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+
+	engine, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	pro := sess.Provider().(*DoltDatabaseProvider)
+	config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
+
+	// Stall the creation of a database while the provider's mutex is held
+	creating := make(chan struct{})
+	release := make(chan struct{})
+	pro.AddInitDatabaseHook(func(ctx *sql.Context, pro *DoltDatabaseProvider, name string, dEnv *env.DoltEnv, db dsess.SqlDatabase) error {
+		close(creating)
+		<-release
+		return nil
+	})
+	created := make(chan error, 1)
+	go func() {
+		createCtx := NewTestSQLCtxWithProvider(ctx, pro, config, nil, sess.GCSafepointController())
+		created <- pro.CreateDatabase(createCtx, "newdb")
+	}()
+	<-creating
+
+	queried := make(chan error, 1)
+	go func() {
+		readCtx := NewTestSQLCtxWithProvider(ctx, pro, config, nil, sess.GCSafepointController())
+		for _, query := range []string{
+			"SELECT schema_name FROM information_schema.schemata",
+			"SELECT name FROM dolt_branches",
+		} {
+			_, iter, _, err := engine.Query(readCtx, query)
+			if err == nil {
+				_, err = sql.RowIterToRows(readCtx, iter)
+			}
+			if err != nil {
+				queried <- err
+				return
+			}
+		}
+		queried <- nil
+	}()
+
+	select {
+	case err := <-queried:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Error("queries blocked behind CREATE DATABASE")
+	}
+	close(release)
+	require.NoError(t, <-created)
+	assert.True(t, pro.HasDatabase(sqlCtx, "newdb"))
+}
+
+func TestDatabaseProviderCloneReservesName(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+
+	_, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	pro := dsess.DSessFromSess(sqlCtx.Session).Provider().(*DoltDatabaseProvider)
+
+	// While a clone of a database is in progress, no other statement may create a database by that name
+	require.NoError(t, pro.reserveCloneName("Cloned"))
+	assert.True(t, sql.ErrDatabaseExists.Is(pro.reserveCloneName("cloned")))
+	assert.True(t, sql.ErrDatabaseExists.Is(pro.CreateDatabase(sqlCtx, "cloned")))
+	assert.True(t, sql.ErrDatabaseExists.Is(pro.UndropDatabase(sqlCtx, "cloned")))
+	assert.False(t, pro.HasDatabase(sqlCtx, "cloned"))
+
+	pro.releaseCloneName("Cloned")
+	require.NoError(t, pro.CreateDatabase(sqlCtx, "cloned"))
+	assert.True(t, sql.ErrDatabaseExists.Is(pro.reserveCloneName("cloned")))
+}
+
+func TestDatabaseProviderDropDatabaseFailure(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+
+	_, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	pro := dsess.DSessFromSess(sqlCtx.Session).Provider().(*DoltDatabaseProvider)
+	require.NoError(t, pro.CreateDatabase(sqlCtx, "dropped"))
+
+	// A file in place of the directory dropped databases are moved to keeps the database from being dropped
+	require.NoError(t, pro.fs.WriteFile(droppedDatabaseDirectoryName, []byte{}, os.ModePerm))
+	require.Error(t, pro.DropDatabase(sqlCtx, "dropped"))
+	assert.True(t, pro.HasDatabase(sqlCtx, "dropped"))
+	_, err = pro.Database(sqlCtx, "dropped")
+	assert.NoError(t, err)
+}
+
+func TestSystemTablesReadPinnedRoot(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+
+	engine, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	pro := sess.Provider().(*DoltDatabaseProvider)
+	config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
+	newSession := func() *sql.Context {
+		sqlCtx := NewTestSQLCtxWithProvider(ctx, pro, config, nil, sess.GCSafepointController())
+		sqlCtx.SetCurrentDatabase(db.Name())
+		return sqlCtx
+	}
+
+	_, err = runQuery(sqlCtx, engine, "call dolt_tag('v1')")
+	require.NoError(t, err)
+
+	// A reader's transaction doesn't see the tags and tables written after it started
+	readerCtx := newSession()
+	_, err = runQuery(readerCtx, engine, "start transaction")
+	require.NoError(t, err)
+	writerCtx := newSession()
+	for _, query := range []string{
+		"create table t (pk int primary key)",
+		"call dolt_commit('-Am', 'create t', '--author', 'writer <writer@example.com>')",
+		"call dolt_tag('--author', 'writer <writer@example.com>', 'v2')",
+	} {
+		_, err = runQuery(writerCtx, engine, query)
+		require.NoError(t, err)
+	}
+
+	rows, err := runQuery(readerCtx, engine, "select tag_name from dolt_tags")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{"v1"}}, rows)
+	rows, err = runQuery(readerCtx, engine, "select table_name from information_schema.tables where table_schema = 'dolt'")
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+
+	_, err = runQuery(readerCtx, engine, "commit")
+	require.NoError(t, err)
+	rows, err = runQuery(readerCtx, engine, "select tag_name from dolt_tags order by tag_name")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{"v1"}, {"v2"}}, rows)
+}
+
 type snoopingCommitHook struct {
 }
 
@@ -0,0 +1,377 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+
+	udfapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/udfapi/v1alpha1"
+)
+
+// ErrExternalFunctionUnavailable is returned for calls to an external function whose service has failed too many
+// times in a row, until its cooldown has elapsed.
+var ErrExternalFunctionUnavailable = goerrors.NewKind("external function %s is unavailable: too many recent failures calling %s")
+
+var errExternalFunctionClosed = errors.New("external function client closed")
+
+// ExternalFunctionSpec describes a function evaluated by an external ExternalFunctionService.
+type ExternalFunctionSpec struct {
+	Name             string
+	Endpoint         string
+	Returns          string
+	Timeout          time.Duration
+	BatchSize        int
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+// DialExternalFunctions connects to the services implementing |specs| and returns a sql.Function for each of them.
+// Functions sharing an endpoint share a connection. The returned func closes the connections and must be called once
+// the functions are no longer in use.
+func DialExternalFunctions(specs []ExternalFunctionSpec) ([]sql.Function, func() error, error) {
+	conns := make(map[string]*grpc.ClientConn)
+	var clients []*ExternalFunctionClient
+	closeAll := func() error {
+		for _, c := range clients {
+			c.Close()
+		}
+		var err error
+		for _, conn := range conns {
+			err = errors.Join(err, conn.Close())
+		}
+		return err
+	}
+
+	fns := make([]sql.Function, len(specs))
+	for i, spec := range specs {
+		conn, ok := conns[spec.Endpoint]
+		if !ok {
+			var err error
+			conn, err = grpc.Dial(spec.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("error dialing external function %s at %s: %w", spec.Name, spec.Endpoint, err)
+			}
+			conns[spec.Endpoint] = conn
+		}
+		client := NewExternalFunctionClient(spec, udfapi.NewExternalFunctionServiceClient(conn))
+		clients = append(clients, client)
+		fns[i] = client.Function()
+	}
+	return fns, closeAll, nil
+}
+
+// ExternalFunctionClient evaluates an external function. Calls in the select list of a query are evaluated a batch of
+// rows at a time, up to the function's batch size, by the node AddExternalFunctionBatchingRule adds to the query. Other
+// calls are evaluated a row at a time.
+type ExternalFunctionClient struct {
+	spec    ExternalFunctionSpec
+	client  udfapi.ExternalFunctionServiceClient
+	breaker *circuitBreaker
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// NewExternalFunctionClient returns a client evaluating the function described by |spec| with |client|. The client
+// must be closed when no longer in use.
+func NewExternalFunctionClient(spec ExternalFunctionSpec, client udfapi.ExternalFunctionServiceClient) *ExternalFunctionClient {
+	if spec.BatchSize < 1 {
+		spec.BatchSize = 1
+	}
+	return &ExternalFunctionClient{
+		spec:    spec,
+		client:  client,
+		breaker: newCircuitBreaker(spec.FailureThreshold, spec.Cooldown),
+		closed:  make(chan struct{}),
+	}
+}
+
+// Close stops the client. Calls made after Close fail.
+func (c *ExternalFunctionClient) Close() {
+	c.once.Do(func() {
+		close(c.closed)
+	})
+}
+
+// Function returns the sql.Function calling this client.
+func (c *ExternalFunctionClient) Function() sql.Function {
+	return sql.FunctionN{
+		Name: c.spec.Name,
+		Fn: func(args ...sql.Expression) (sql.Expression, error) {
+			return &ExternalFunction{client: c, children: args}, nil
+		},
+	}
+}
+
+// Call evaluates the function for a single row of arguments.
+func (c *ExternalFunctionClient) Call(ctx context.Context, row *udfapi.Row) (*udfapi.Value, error) {
+	results, err := c.send(ctx, []*udfapi.Row{row})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// CallBatch evaluates the function for each of |rows|, sending at most the function's batch size of rows in each
+// request to the service.
+func (c *ExternalFunctionClient) CallBatch(ctx context.Context, rows []*udfapi.Row) ([]*udfapi.Value, error) {
+	results := make([]*udfapi.Value, 0, len(rows))
+	for len(rows) > 0 {
+		n := min(len(rows), c.spec.BatchSize)
+		batch, err := c.send(ctx, rows[:n])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, batch...)
+		rows = rows[n:]
+	}
+	return results, nil
+}
+
+func (c *ExternalFunctionClient) send(ctx context.Context, rows []*udfapi.Row) ([]*udfapi.Value, error) {
+	select {
+	case <-c.closed:
+		return nil, errExternalFunctionClosed
+	default:
+	}
+	if !c.breaker.allow() {
+		return nil, ErrExternalFunctionUnavailable.New(c.spec.Name, c.spec.Endpoint)
+	}
+
+	req := &udfapi.EvaluateRequest{
+		FunctionName: c.spec.Name,
+		Rows:         rows,
+	}
+	if c.spec.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.spec.Timeout)
+		defer cancel()
+	}
+	resp, err := c.client.Evaluate(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.DeadlineExceeded {
+			err = fmt.Errorf("external function %s timed out after %s", c.spec.Name, c.spec.Timeout)
+		} else {
+			err = fmt.Errorf("error calling external function %s: %w", c.spec.Name, err)
+		}
+	} else if len(resp.Results) != len(rows) {
+		err = fmt.Errorf("external function %s returned %d results for %d rows", c.spec.Name, len(resp.Results), len(rows))
+	}
+	c.breaker.record(err)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// circuitBreaker rejects calls to a service once |threshold| consecutive calls to it have failed. After |cooldown|, a
+// single call is let through to probe the service, which closes the breaker again if it succeeds.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (b *circuitBreaker) tripped() bool {
+	return b.threshold > 0 && b.failures >= b.threshold
+}
+
+// isOpen returns whether calls are currently being rejected.
+func (b *circuitBreaker) isOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tripped() && b.now().Before(b.openUntil)
+}
+
+// allow returns whether a call may be made. Once the cooldown has elapsed, allow returns true for one probing call
+// and restarts the cooldown.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.tripped() {
+		return true
+	}
+	now := b.now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+// record records the outcome of a call.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.tripped() {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
+
+// ExternalFunction is a call to a function evaluated by an external service.
+type ExternalFunction struct {
+	client   *ExternalFunctionClient
+	children []sql.Expression
+}
+
+var _ sql.FunctionExpression = (*ExternalFunction)(nil)
+var _ sql.NonDeterministicExpression = (*ExternalFunction)(nil)
+
+// FunctionName implements sql.FunctionExpression.
+func (f *ExternalFunction) FunctionName() string {
+	return f.client.spec.Name
+}
+
+// Description implements sql.FunctionExpression.
+func (f *ExternalFunction) Description() string {
+	return fmt.Sprintf("external function evaluated by %s", f.client.spec.Endpoint)
+}
+
+// IsNonDeterministic implements sql.NonDeterministicExpression. External functions aren't known to be deterministic,
+// so they are never constant folded or cached.
+func (f *ExternalFunction) IsNonDeterministic() bool {
+	return true
+}
+
+// Children implements sql.Expression.
+func (f *ExternalFunction) Children() []sql.Expression {
+	return f.children
+}
+
+// Resolved implements sql.Expression.
+func (f *ExternalFunction) Resolved() bool {
+	for _, c := range f.children {
+		if !c.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements sql.Expression.
+func (f *ExternalFunction) IsNullable() bool {
+	return true
+}
+
+// Type implements sql.Expression.
+func (f *ExternalFunction) Type() sql.Type {
+	return userFunctionType(f.client.spec.Returns)
+}
+
+// String implements sql.Expression.
+func (f *ExternalFunction) String() string {
+	args := make([]string, len(f.children))
+	for i, c := range f.children {
+		args[i] = c.String()
+	}
+	return fmt.Sprintf("%s(%s)", f.client.spec.Name, strings.Join(args, ", "))
+}
+
+// WithChildren implements sql.Expression.
+func (f *ExternalFunction) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(f.children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), len(f.children))
+	}
+	return &ExternalFunction{client: f.client, children: children}, nil
+}
+
+// Eval implements sql.Expression.
+func (f *ExternalFunction) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args, err := f.args(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	res, err := f.client.Call(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return f.value(res)
+}
+
+// args evaluates the arguments of the function for |row|.
+func (f *ExternalFunction) args(ctx *sql.Context, row sql.Row) (*udfapi.Row, error) {
+	args := &udfapi.Row{Values: make([]*udfapi.Value, len(f.children))}
+	for i, c := range f.children {
+		v, err := c.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		args.Values[i] = externalValue(v)
+	}
+	return args, nil
+}
+
+// value converts |res|, a result returned by the service, to the function's return type.
+func (f *ExternalFunction) value(res *udfapi.Value) (interface{}, error) {
+	if res.IsNull {
+		return nil, nil
+	}
+
+	switch strings.ToLower(f.client.spec.Returns) {
+	case "int", "integer":
+		i, err := strconv.ParseInt(res.Text, 10, 32)
+		return int32(i), err
+	case "bigint":
+		return strconv.ParseInt(res.Text, 10, 64)
+	case "float":
+		fl, err := strconv.ParseFloat(res.Text, 32)
+		return float32(fl), err
+	case "double":
+		return strconv.ParseFloat(res.Text, 64)
+	default:
+		return res.Text, nil
+	}
+}
+
+// externalValue returns |v| in its MySQL text representation.
+func externalValue(v interface{}) *udfapi.Value {
+	switch v := v.(type) {
+	case nil:
+		return &udfapi.Value{IsNull: true}
+	case string:
+		return &udfapi.Value{Text: v}
+	case []byte:
+		return &udfapi.Value{Text: string(v)}
+	case time.Time:
+		return &udfapi.Value{Text: v.Format(sql.TimestampDatetimeLayout)}
+	default:
+		return &udfapi.Value{Text: fmt.Sprint(v)}
+	}
+}
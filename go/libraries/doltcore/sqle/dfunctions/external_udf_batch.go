@@ -0,0 +1,347 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+
+	udfapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/udfapi/v1alpha1"
+)
+
+// ExternalFunctionBatchingRuleId is the id of the analyzer rule added by AddExternalFunctionBatchingRule.
+const ExternalFunctionBatchingRuleId analyzer.RuleId = -4
+
+// externalFunctionFlushInterval is the longest an ExternalFunctionBatch waits for its child to produce rows before
+// sending the rows it has to the service, so that a slow child doesn't hold back the rows it already produced.
+const externalFunctionFlushInterval = 10 * time.Millisecond
+
+// AddExternalFunctionBatchingRule adds the rule which batches the external function calls in the select lists of
+// queries to |a|. The rule runs once execution indexes have been assigned, and evaluates each call in a projection,
+// whose arguments don't themselves call external functions, in an ExternalFunctionBatch below the projection.
+func AddExternalFunctionBatchingRule(a *analyzer.Analyzer) {
+	for _, b := range a.Batches {
+		if b.Desc != "after-all" {
+			continue
+		}
+		for i, r := range b.Rules {
+			if r.Id.String() == "assignExecIndexes" {
+				rules := append([]analyzer.Rule{}, b.Rules[:i+1]...)
+				rules = append(rules, analyzer.Rule{Id: ExternalFunctionBatchingRuleId, Apply: batchExternalFunctions})
+				b.Rules = append(rules, b.Rules[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// batchExternalFunctions moves the external function calls in the projections of |n| into ExternalFunctionBatch nodes.
+func batchExternalFunctions(_ *sql.Context, a *analyzer.Analyzer, n sql.Node, _ *plan.Scope, _ analyzer.RuleSelector, _ *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	return transform.Node(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		p, ok := n.(*plan.Project)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		if _, ok := p.Child.(*ExternalFunctionBatch); ok {
+			return n, transform.SameTree, nil
+		}
+
+		var calls []*ExternalFunction
+		projections := make([]sql.Expression, len(p.Projections))
+		for i, e := range p.Projections {
+			var err error
+			projections[i], _, err = transform.Expr(e, func(e sql.Expression) (sql.Expression, transform.TreeIdentity, error) {
+				f, ok := e.(*ExternalFunction)
+				if !ok || callsExternalFunction(f.children) {
+					return e, transform.SameTree, nil
+				}
+				calls = append(calls, f)
+				return &batchedExternalResult{call: f, idx: len(calls) - 1}, transform.NewTree, nil
+			})
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+		}
+		if len(calls) == 0 {
+			return n, transform.SameTree, nil
+		}
+		for _, e := range projections {
+			transform.InspectExpr(e, func(e sql.Expression) bool {
+				if r, ok := e.(*batchedExternalResult); ok {
+					r.numCalls = len(calls)
+				}
+				return false
+			})
+		}
+
+		batch := &ExternalFunctionBatch{child: p.Child, calls: calls, builder: a.ExecBuilder}
+		ret, err := p.WithChildren(batch)
+		if err != nil {
+			return nil, transform.SameTree, err
+		}
+		ret, err = ret.(*plan.Project).WithExpressions(projections...)
+		return ret, transform.NewTree, err
+	})
+}
+
+// callsExternalFunction returns whether any of |exprs| calls an external function, batched or not.
+func callsExternalFunction(exprs []sql.Expression) bool {
+	for _, e := range exprs {
+		if transform.InspectExpr(e, func(e sql.Expression) bool {
+			switch e.(type) {
+			case *ExternalFunction, *batchedExternalResult:
+				return true
+			default:
+				return false
+			}
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalFunctionBatch evaluates external function calls for the rows of its child a batch at a time. Each row it
+// returns is a row of its child followed by the result of each call for that row. It reads ahead of its parent by up
+// to the largest batch size of its functions, so that a LIMIT above it may leave some calls unused.
+type ExternalFunctionBatch struct {
+	child   sql.Node
+	calls   []*ExternalFunction
+	builder sql.NodeExecBuilder
+}
+
+var _ sql.ExecSourceRel = (*ExternalFunctionBatch)(nil)
+var _ sql.Expressioner = (*ExternalFunctionBatch)(nil)
+
+// Resolved implements sql.Node.
+func (b *ExternalFunctionBatch) Resolved() bool {
+	return b.child.Resolved()
+}
+
+// String implements sql.Node.
+func (b *ExternalFunctionBatch) String() string {
+	calls := make([]string, len(b.calls))
+	for i, c := range b.calls {
+		calls[i] = c.String()
+	}
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("ExternalFunctionBatch(%s)", strings.Join(calls, ", "))
+	_ = pr.WriteChildren(b.child.String())
+	return pr.String()
+}
+
+// Schema implements sql.Node.
+func (b *ExternalFunctionBatch) Schema() sql.Schema {
+	sch := append(sql.Schema{}, b.child.Schema()...)
+	for _, c := range b.calls {
+		sch = append(sch, &sql.Column{Name: c.String(), Type: c.Type(), Nullable: true})
+	}
+	return sch
+}
+
+// Children implements sql.Node.
+func (b *ExternalFunctionBatch) Children() []sql.Node {
+	return []sql.Node{b.child}
+}
+
+// WithChildren implements sql.Node.
+func (b *ExternalFunctionBatch) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 1 {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(children), 1)
+	}
+	nb := *b
+	nb.child = children[0]
+	return &nb, nil
+}
+
+// IsReadOnly implements sql.Node.
+func (b *ExternalFunctionBatch) IsReadOnly() bool {
+	return b.child.IsReadOnly()
+}
+
+// Expressions implements sql.Expressioner.
+func (b *ExternalFunctionBatch) Expressions() []sql.Expression {
+	exprs := make([]sql.Expression, len(b.calls))
+	for i, c := range b.calls {
+		exprs[i] = c
+	}
+	return exprs
+}
+
+// WithExpressions implements sql.Expressioner.
+func (b *ExternalFunctionBatch) WithExpressions(exprs ...sql.Expression) (sql.Node, error) {
+	if len(exprs) != len(b.calls) {
+		return nil, sql.ErrInvalidChildrenNumber.New(b, len(exprs), len(b.calls))
+	}
+	calls := make([]*ExternalFunction, len(exprs))
+	for i, e := range exprs {
+		f, ok := e.(*ExternalFunction)
+		if !ok {
+			return nil, fmt.Errorf("expected an external function call but found %T", e)
+		}
+		calls[i] = f
+	}
+	nb := *b
+	nb.calls = calls
+	return &nb, nil
+}
+
+// RowIter implements sql.ExecSourceRel.
+func (b *ExternalFunctionBatch) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	child, err := b.builder.Build(ctx, b.child, row)
+	if err != nil {
+		return nil, err
+	}
+	size := 1
+	for _, c := range b.calls {
+		size = max(size, c.client.spec.BatchSize)
+	}
+	return &externalFunctionBatchIter{child: child, calls: b.calls, size: size}, nil
+}
+
+// externalFunctionBatchIter is the iterator of an ExternalFunctionBatch.
+type externalFunctionBatchIter struct {
+	child sql.RowIter
+	calls []*ExternalFunction
+	size  int
+	// rows holds the rows of the current batch which haven't been returned yet
+	rows []sql.Row
+	// err is the error the child returned while reading the current batch, returned once the batch is exhausted
+	err error
+}
+
+var _ sql.RowIter = (*externalFunctionBatchIter)(nil)
+
+// Next implements sql.RowIter.
+func (i *externalFunctionBatchIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if len(i.rows) == 0 {
+		if i.err != nil {
+			return nil, i.err
+		}
+		if err := i.nextBatch(ctx); err != nil {
+			return nil, err
+		}
+		if len(i.rows) == 0 {
+			return nil, i.err
+		}
+	}
+	row := i.rows[0]
+	i.rows = i.rows[1:]
+	return row, nil
+}
+
+// nextBatch reads the next batch of rows from the child, until the batch is full, the child runs out of rows or
+// externalFunctionFlushInterval elapses, and evaluates the calls for them.
+func (i *externalFunctionBatchIter) nextBatch(ctx *sql.Context) error {
+	var rows []sql.Row
+	start := time.Now()
+	for len(rows) < i.size && time.Since(start) < externalFunctionFlushInterval {
+		row, err := i.child.Next(ctx)
+		if err != nil {
+			i.err = err
+			break
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	results := make([]sql.Row, len(rows))
+	for j, row := range rows {
+		results[j] = append(row[:len(row):len(row)], make(sql.Row, len(i.calls))...)
+	}
+	for c, call := range i.calls {
+		args := make([]*udfapi.Row, len(rows))
+		for j, row := range rows {
+			var err error
+			if args[j], err = call.args(ctx, row); err != nil {
+				return err
+			}
+		}
+		vals, err := call.client.CallBatch(ctx, args)
+		if err != nil {
+			return err
+		}
+		for j, val := range vals {
+			v, err := call.value(val)
+			if err != nil {
+				return err
+			}
+			results[j][len(rows[j])+c] = v
+		}
+	}
+	i.rows = results
+	return nil
+}
+
+// Close implements sql.RowIter.
+func (i *externalFunctionBatchIter) Close(ctx *sql.Context) error {
+	return i.child.Close(ctx)
+}
+
+// batchedExternalResult replaces an external function call evaluated by an ExternalFunctionBatch. It reads the call's
+// result from the columns the batch appends to each row, which are last in the row whatever precedes them.
+type batchedExternalResult struct {
+	call     *ExternalFunction
+	idx      int
+	numCalls int
+}
+
+var _ sql.Expression = (*batchedExternalResult)(nil)
+
+// Resolved implements sql.Expression.
+func (r *batchedExternalResult) Resolved() bool {
+	return true
+}
+
+// String implements sql.Expression.
+func (r *batchedExternalResult) String() string {
+	return r.call.String()
+}
+
+// Type implements sql.Expression.
+func (r *batchedExternalResult) Type() sql.Type {
+	return r.call.Type()
+}
+
+// IsNullable implements sql.Expression.
+func (r *batchedExternalResult) IsNullable() bool {
+	return true
+}
+
+// Eval implements sql.Expression.
+func (r *batchedExternalResult) Eval(_ *sql.Context, row sql.Row) (interface{}, error) {
+	return row[len(row)-r.numCalls+r.idx], nil
+}
+
+// Children implements sql.Expression.
+func (r *batchedExternalResult) Children() []sql.Expression {
+	return nil
+}
+
+// WithChildren implements sql.Expression.
+func (r *batchedExternalResult) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 0 {
+		return nil, sql.ErrInvalidChildrenNumber.New(r, len(children), 0)
+	}
+	return r, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/memory"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	udfapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/udfapi/v1alpha1"
+)
+
+type fakeFunctionService struct {
+	mu       sync.Mutex
+	requests []*udfapi.EvaluateRequest
+	evaluate func(ctx context.Context, req *udfapi.EvaluateRequest) (*udfapi.EvaluateResponse, error)
+}
+
+func (f *fakeFunctionService) Evaluate(ctx context.Context, in *udfapi.EvaluateRequest, _ ...grpc.CallOption) (*udfapi.EvaluateResponse, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, in)
+	f.mu.Unlock()
+	return f.evaluate(ctx, in)
+}
+
+func (f *fakeFunctionService) numRequests() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+// upper returns the upper case of each row's first argument.
+func upper(_ context.Context, req *udfapi.EvaluateRequest) (*udfapi.EvaluateResponse, error) {
+	resp := &udfapi.EvaluateResponse{}
+	for _, row := range req.Rows {
+		if row.Values[0].IsNull {
+			resp.Results = append(resp.Results, &udfapi.Value{IsNull: true})
+		} else {
+			resp.Results = append(resp.Results, &udfapi.Value{Text: strings.ToUpper(row.Values[0].Text)})
+		}
+	}
+	return resp, nil
+}
+
+func TestExternalFunctionEval(t *testing.T) {
+	svc := &fakeFunctionService{evaluate: upper}
+	client := NewExternalFunctionClient(ExternalFunctionSpec{Name: "upper_ext", Returns: "text", BatchSize: 8}, svc)
+	defer client.Close()
+
+	ctx := sql.NewEmptyContext()
+	expr, err := client.Function().NewInstance([]sql.Expression{expression.NewGetField(0, types.Text, "s", true)})
+	require.NoError(t, err)
+	assert.Equal(t, types.LongText, expr.Type())
+
+	res, err := expr.Eval(ctx, sql.Row{"abc"})
+	require.NoError(t, err)
+	assert.Equal(t, "ABC", res)
+
+	res, err = expr.Eval(ctx, sql.Row{nil})
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	require.Len(t, svc.requests, 2)
+	assert.Equal(t, "upper_ext", svc.requests[0].FunctionName)
+}
+
+func TestExternalFunctionCallBatch(t *testing.T) {
+	svc := &fakeFunctionService{evaluate: upper}
+	client := NewExternalFunctionClient(ExternalFunctionSpec{Name: "upper_ext", Returns: "text", BatchSize: 4}, svc)
+	defer client.Close()
+
+	const n = 10
+	rows := make([]*udfapi.Row, n)
+	for i := range rows {
+		rows[i] = &udfapi.Row{Values: []*udfapi.Value{{Text: strings.Repeat("a", i+1)}}}
+	}
+	results, err := client.CallBatch(context.Background(), rows)
+	require.NoError(t, err)
+	require.Len(t, results, n)
+	for i, res := range results {
+		assert.Equal(t, strings.Repeat("A", i+1), res.Text)
+	}
+
+	// the rows are sent in requests of at most the batch size
+	require.Equal(t, 3, svc.numRequests())
+	assert.Len(t, svc.requests[0].Rows, 4)
+	assert.Len(t, svc.requests[1].Rows, 4)
+	assert.Len(t, svc.requests[2].Rows, 2)
+}
+
+func TestExternalFunctionBatchingRule(t *testing.T) {
+	svc := &fakeFunctionService{evaluate: upper}
+	client := NewExternalFunctionClient(ExternalFunctionSpec{Name: "upper_ext", Returns: "text", BatchSize: 16}, svc)
+	defer client.Close()
+
+	pro := memory.NewDBProvider(memory.NewDatabase("mydb"))
+	engine := sqle.NewDefault(pro)
+	AddExternalFunctionBatchingRule(engine.Analyzer)
+	ctx := sql.NewContext(context.Background(), sql.WithSession(memory.NewSession(sql.NewBaseSession(), pro)))
+	ctx.SetCurrentDatabase("mydb")
+	engine.Analyzer.Catalog.RegisterFunction(ctx, client.Function())
+
+	query := func(q string) []sql.Row {
+		_, iter, _, err := engine.Query(ctx, q)
+		require.NoError(t, err)
+		rows, err := sql.RowIterToRows(ctx, iter)
+		require.NoError(t, err)
+		return rows
+	}
+	query("create table t (pk int primary key, s text)")
+	query("insert into t values (1, 'a'), (2, 'b'), (3, null), (4, 'd'), (5, 'e')")
+	require.Equal(t, 0, svc.numRequests())
+
+	rows := query("select pk, upper_ext(s), concat(upper_ext(s), '!') from t order by pk")
+	assert.Equal(t, []sql.Row{
+		{int32(1), "A", "A!"},
+		{int32(2), "B", "B!"},
+		{int32(3), nil, nil},
+		{int32(4), "D", "D!"},
+		{int32(5), "E", "E!"},
+	}, rows)
+	// each call is evaluated for every row of the table in one request
+	require.Equal(t, 2, svc.numRequests())
+	assert.Len(t, svc.requests[0].Rows, 5)
+	assert.Len(t, svc.requests[1].Rows, 5)
+
+	// a call whose argument calls an external function is evaluated a row at a time, after the batched inner call
+	rows = query("select upper_ext(upper_ext(s)) from t where pk < 3 order by pk")
+	assert.Equal(t, []sql.Row{{"A"}, {"B"}}, rows)
+	require.Equal(t, 5, svc.numRequests())
+	assert.Len(t, svc.requests[2].Rows, 2)
+	assert.Len(t, svc.requests[3].Rows, 1)
+	assert.Len(t, svc.requests[4].Rows, 1)
+}
+
+func TestExternalFunctionTimeout(t *testing.T) {
+	svc := &fakeFunctionService{}
+	svc.evaluate = func(ctx context.Context, req *udfapi.EvaluateRequest) (*udfapi.EvaluateResponse, error) {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	client := NewExternalFunctionClient(ExternalFunctionSpec{Name: "slow", Returns: "bigint", Timeout: 10 * time.Millisecond}, svc)
+	defer client.Close()
+
+	_, err := client.Call(context.Background(), &udfapi.Row{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "external function slow timed out")
+}
+
+func TestExternalFunctionCircuitBreaker(t *testing.T) {
+	fail := true
+	svc := &fakeFunctionService{}
+	svc.evaluate = func(ctx context.Context, req *udfapi.EvaluateRequest) (*udfapi.EvaluateResponse, error) {
+		if fail {
+			return nil, errors.New("connection refused")
+		}
+		return &udfapi.EvaluateResponse{Results: []*udfapi.Value{{Text: "42"}}}, nil
+	}
+	client := NewExternalFunctionClient(ExternalFunctionSpec{
+		Name:             "flaky",
+		Returns:          "bigint",
+		BatchSize:        1,
+		FailureThreshold: 2,
+		Cooldown:         time.Minute,
+	}, svc)
+	defer client.Close()
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		_, err := client.Call(ctx, &udfapi.Row{})
+		require.Error(t, err)
+		assert.False(t, ErrExternalFunctionUnavailable.Is(err))
+	}
+
+	// the breaker is open, so calls fail without reaching the service
+	_, err := client.Call(ctx, &udfapi.Row{})
+	require.Error(t, err)
+	assert.True(t, ErrExternalFunctionUnavailable.Is(err))
+	assert.Equal(t, 2, svc.numRequests())
+
+	// once the cooldown elapses, a probing call closes the breaker again
+	fail = false
+	now = now.Add(time.Minute)
+	res, err := client.Call(ctx, &udfapi.Row{})
+	require.NoError(t, err)
+	assert.Equal(t, "42", res.Text)
+	assert.Equal(t, 3, svc.numRequests())
+	assert.False(t, client.breaker.isOpen())
+}
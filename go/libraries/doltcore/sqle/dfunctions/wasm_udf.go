@@ -0,0 +1,660 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression/function"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	goerrors "gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrUserFunctionExists is returned when creating a user defined function whose name is already taken.
+var ErrUserFunctionExists = goerrors.NewKind("function '%s' is already registered")
+
+const (
+	// WasmLanguage is the only language user defined functions can currently be written in.
+	WasmLanguage = "wasm"
+
+	// wasmAllocExport is the function a module must export to receive TEXT arguments. It takes a size in bytes and
+	// returns the offset of a buffer of that size in the module's memory.
+	wasmAllocExport = "alloc"
+
+	// wasmMemoryLimitPages caps the linear memory of a user defined function at 16MB.
+	wasmMemoryLimitPages = 256
+
+	// wasmModuleCacheSize is the number of compiled modules which are cached. The least recently used module is evicted
+	// when another is compiled.
+	wasmModuleCacheSize = 32
+
+	// wasmIdleInstances is the number of idle instances of each cached module which are kept for reuse by the query which
+	// instantiated them. Instances beyond these are closed when the call which instantiated them returns.
+	wasmIdleInstances = 2
+)
+
+// UserFunctionDefinition is the definition of a user defined function, as stored in dolt_schemas.
+//
+// Numeric parameters and results are passed as the corresponding WebAssembly value types: INT as i32, BIGINT as i64,
+// FLOAT as f32 and DOUBLE as f64. TEXT parameters are copied into a buffer returned by the module's exported alloc
+// function and passed as an (i32 offset, i32 length) pair. A TEXT result is returned as an i64 holding the offset of
+// the string in the high 32 bits and its length in the low 32 bits.
+type UserFunctionDefinition struct {
+	Language string   `json:"language"`
+	Export   string   `json:"export"`
+	Params   []string `json:"params"`
+	Returns  string   `json:"returns"`
+	Module   []byte   `json:"module"`
+}
+
+// ParseUserFunctionDefinition parses a definition previously serialized with Encode.
+func ParseUserFunctionDefinition(s string) (UserFunctionDefinition, error) {
+	var def UserFunctionDefinition
+	if err := json.Unmarshal([]byte(s), &def); err != nil {
+		return UserFunctionDefinition{}, fmt.Errorf("invalid function definition: %w", err)
+	}
+	return def, nil
+}
+
+// Encode serializes the definition for storage.
+func (def UserFunctionDefinition) Encode() (string, error) {
+	b, err := json.Marshal(def)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Validate checks that the definition's types are supported and that its module exports a function with a matching
+// signature.
+func (def UserFunctionDefinition) Validate(ctx context.Context) error {
+	if !strings.EqualFold(def.Language, WasmLanguage) {
+		return fmt.Errorf("unsupported function language '%s', only %s is supported", def.Language, WasmLanguage)
+	}
+
+	var wantParams []api.ValueType
+	hasText := false
+	for _, p := range def.Params {
+		vts, err := wasmParamTypes(p)
+		if err != nil {
+			return err
+		}
+		wantParams = append(wantParams, vts...)
+		hasText = hasText || isWasmText(p)
+	}
+	wantResult, err := wasmResultType(def.Returns)
+	if err != nil {
+		return err
+	}
+
+	m, err := compileWasmModule(ctx, def.Module)
+	if err != nil {
+		return err
+	}
+	exports := m.compiled.ExportedFunctions()
+	fnDef, ok := exports[def.Export]
+	if !ok {
+		return fmt.Errorf("module does not export a function named '%s'", def.Export)
+	}
+	if !equalValueTypes(fnDef.ParamTypes(), wantParams) || !equalValueTypes(fnDef.ResultTypes(), []api.ValueType{wantResult}) {
+		return fmt.Errorf("exported function '%s' does not match the declared parameter and return types", def.Export)
+	}
+	if hasText || isWasmText(def.Returns) {
+		if _, ok := exports[wasmAllocExport]; !ok {
+			return fmt.Errorf("module must export an '%s' function to use TEXT parameters or results", wasmAllocExport)
+		}
+	}
+	return nil
+}
+
+// IsReservedFunctionName returns whether |name| is the name of a built-in or Dolt function, which user defined
+// functions may not shadow.
+func IsReservedFunctionName(name string) bool {
+	for _, fns := range [][]sql.Function{function.BuiltIns, DoltFunctions} {
+		for _, fn := range fns {
+			if strings.EqualFold(fn.FunctionName(), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewUserFunction returns a sql.Function which evaluates the user defined function |name| described by |def|.
+func NewUserFunction(name string, def UserFunctionDefinition) sql.Function {
+	return sql.FunctionN{
+		Name: name,
+		Fn: func(args ...sql.Expression) (sql.Expression, error) {
+			if len(args) != len(def.Params) {
+				return nil, sql.ErrInvalidArgumentNumber.New(name, len(def.Params), len(args))
+			}
+			return &UserFunction{name: name, def: def, children: args, module: &userFunctionModule{}}, nil
+		},
+	}
+}
+
+// UserFunction is an invocation of a user defined function. The function's module runs in a sandbox with no host
+// imports, so it can only compute on its arguments.
+type UserFunction struct {
+	name     string
+	def      UserFunctionDefinition
+	children []sql.Expression
+	module   *userFunctionModule
+}
+
+// userFunctionModule is the compiled module of a UserFunction. It's looked up in the module cache the first time the
+// function is called, rather than for every row, and shared by the copies WithChildren makes of the expression.
+type userFunctionModule struct {
+	mu sync.Mutex
+	m  *wasmModule
+}
+
+// get returns the compiled module |bin|, compiling it if it hasn't been looked up yet or was evicted since.
+func (u *userFunctionModule) get(ctx context.Context, bin []byte) (*wasmModule, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.m == nil || u.m.isEvicted() {
+		m, err := compileWasmModule(ctx, bin)
+		if err != nil {
+			return nil, err
+		}
+		u.m = m
+	}
+	return u.m, nil
+}
+
+var _ sql.FunctionExpression = (*UserFunction)(nil)
+
+// FunctionName implements sql.FunctionExpression.
+func (f *UserFunction) FunctionName() string {
+	return f.name
+}
+
+// Description implements sql.FunctionExpression.
+func (f *UserFunction) Description() string {
+	return "user defined function"
+}
+
+// Children implements sql.Expression.
+func (f *UserFunction) Children() []sql.Expression {
+	return f.children
+}
+
+// Resolved implements sql.Expression.
+func (f *UserFunction) Resolved() bool {
+	for _, c := range f.children {
+		if !c.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements sql.Expression.
+func (f *UserFunction) IsNullable() bool {
+	return true
+}
+
+// Type implements sql.Expression.
+func (f *UserFunction) Type() sql.Type {
+	return userFunctionType(f.def.Returns)
+}
+
+// userFunctionType returns the SQL type of a user defined function returning |returns|.
+func userFunctionType(returns string) sql.Type {
+	switch strings.ToLower(returns) {
+	case "int", "integer":
+		return types.Int32
+	case "bigint":
+		return types.Int64
+	case "float":
+		return types.Float32
+	case "double":
+		return types.Float64
+	default:
+		return types.LongText
+	}
+}
+
+// String implements sql.Expression.
+func (f *UserFunction) String() string {
+	args := make([]string, len(f.children))
+	for i, c := range f.children {
+		args[i] = c.String()
+	}
+	return fmt.Sprintf("%s(%s)", f.name, strings.Join(args, ", "))
+}
+
+// WithChildren implements sql.Expression.
+func (f *UserFunction) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != len(f.children) {
+		return nil, sql.ErrInvalidChildrenNumber.New(f, len(children), len(f.children))
+	}
+	return &UserFunction{name: f.name, def: f.def, children: children, module: f.module}, nil
+}
+
+// Eval implements sql.Expression.
+func (f *UserFunction) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	args := make([]interface{}, len(f.children))
+	for i, c := range f.children {
+		v, err := c.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, nil
+		}
+		args[i] = v
+	}
+
+	var m *wasmModule
+	var mod api.Module
+	for mod == nil {
+		var err error
+		m, err = f.module.get(ctx, f.def.Module)
+		if err != nil {
+			return nil, err
+		}
+		mod, err = m.acquire(ctx)
+		if err != nil && !errors.Is(err, errWasmModuleEvicted) {
+			return nil, err
+		}
+	}
+	w := wasmInstance{mod: mod}
+	res, err := w.call(ctx, f.def, args)
+	// An instance whose call failed may have been left in any state, so it isn't reused.
+	m.release(ctx, mod, err == nil)
+	return res, err
+}
+
+// wasmInstance is an instance of the module of a user defined function, taken from the module's pool for a single call.
+type wasmInstance struct {
+	mod api.Module
+}
+
+func (w wasmInstance) call(ctx *sql.Context, def UserFunctionDefinition, args []interface{}) (interface{}, error) {
+	var params []uint64
+	for i, p := range def.Params {
+		vals, err := w.lowerArg(ctx, p, args[i])
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, vals...)
+	}
+
+	res, err := w.mod.ExportedFunction(def.Export).Call(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+	return w.liftResult(def.Returns, res[0])
+}
+
+func (w wasmInstance) lowerArg(ctx *sql.Context, typ string, v interface{}) ([]uint64, error) {
+	switch strings.ToLower(typ) {
+	case "int", "integer":
+		i, err := toInt64(v)
+		return []uint64{api.EncodeI32(int32(i))}, err
+	case "bigint":
+		i, err := toInt64(v)
+		return []uint64{api.EncodeI64(i)}, err
+	case "float":
+		f, err := toFloat64(v)
+		return []uint64{api.EncodeF32(float32(f))}, err
+	case "double":
+		f, err := toFloat64(v)
+		return []uint64{api.EncodeF64(f)}, err
+	default:
+		var b []byte
+		switch v := v.(type) {
+		case string:
+			b = []byte(v)
+		case []byte:
+			b = v
+		default:
+			b = []byte(fmt.Sprint(v))
+		}
+		res, err := w.mod.ExportedFunction(wasmAllocExport).Call(ctx, api.EncodeI32(int32(len(b))))
+		if err != nil {
+			return nil, err
+		}
+		ptr := uint32(res[0])
+		if !w.mod.Memory().Write(ptr, b) {
+			return nil, fmt.Errorf("function argument out of range of module memory")
+		}
+		return []uint64{uint64(ptr), uint64(len(b))}, nil
+	}
+}
+
+func (w wasmInstance) liftResult(typ string, res uint64) (interface{}, error) {
+	switch strings.ToLower(typ) {
+	case "int", "integer":
+		return api.DecodeI32(res), nil
+	case "bigint":
+		return int64(res), nil
+	case "float":
+		return api.DecodeF32(res), nil
+	case "double":
+		return api.DecodeF64(res), nil
+	default:
+		ptr, length := uint32(res>>32), uint32(res)
+		b, ok := w.mod.Memory().Read(ptr, length)
+		if !ok {
+			return nil, fmt.Errorf("function result out of range of module memory")
+		}
+		return string(b), nil
+	}
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	default:
+		return strconv.ParseInt(fmt.Sprint(v), 10, 64)
+	}
+}
+
+func toFloat64(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	default:
+		if i, err := toInt64(v); err == nil {
+			return float64(i), nil
+		}
+		return strconv.ParseFloat(fmt.Sprint(v), 64)
+	}
+}
+
+func isWasmText(typ string) bool {
+	switch strings.ToLower(typ) {
+	case "text", "varchar", "longtext":
+		return true
+	}
+	return false
+}
+
+func wasmParamTypes(typ string) ([]api.ValueType, error) {
+	if isWasmText(typ) {
+		return []api.ValueType{api.ValueTypeI32, api.ValueTypeI32}, nil
+	}
+	vt, err := wasmNumericType(typ)
+	if err != nil {
+		return nil, err
+	}
+	return []api.ValueType{vt}, nil
+}
+
+func wasmResultType(typ string) (api.ValueType, error) {
+	if isWasmText(typ) {
+		return api.ValueTypeI64, nil
+	}
+	return wasmNumericType(typ)
+}
+
+func wasmNumericType(typ string) (api.ValueType, error) {
+	switch strings.ToLower(typ) {
+	case "int", "integer":
+		return api.ValueTypeI32, nil
+	case "bigint":
+		return api.ValueTypeI64, nil
+	case "float":
+		return api.ValueTypeF32, nil
+	case "double":
+		return api.ValueTypeF64, nil
+	default:
+		return 0, fmt.Errorf("unsupported user defined function type '%s'", typ)
+	}
+}
+
+func equalValueTypes(a, b []api.ValueType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	wasmRuntimeOnce sync.Once
+	wasmRt          wazero.Runtime
+
+	wasmModules = struct {
+		mu    sync.Mutex
+		byKey map[[sha256.Size]byte]*list.Element
+		lru   *list.List
+	}{byKey: make(map[[sha256.Size]byte]*list.Element), lru: list.New()}
+)
+
+// wasmRuntime returns the runtime shared by all user defined functions. Module instances are closed when the query
+// that is calling them is canceled, so KILL QUERY interrupts a long running function.
+func wasmRuntime() wazero.Runtime {
+	wasmRuntimeOnce.Do(func() {
+		cfg := wazero.NewRuntimeConfig().
+			WithCloseOnContextDone(true).
+			WithMemoryLimitPages(wasmMemoryLimitPages)
+		wasmRt = wazero.NewRuntimeWithConfig(context.Background(), cfg)
+	})
+	return wasmRt
+}
+
+// wasmModule is a compiled module, cached by its content, along with its idle instances.
+type wasmModule struct {
+	key      [sha256.Size]byte
+	compiled wazero.CompiledModule
+
+	mu      sync.Mutex
+	idle    []wasmIdleInstance
+	evicted bool
+}
+
+// wasmIdleInstance is an idle instance of a module, along with the query which instantiated it.
+type wasmIdleInstance struct {
+	mod   api.Module
+	query wasmQuery
+}
+
+// wasmQuery identifies a query. The linear memory of an instance holds whatever the function left there, including
+// the arguments it was called with, so an instance is only reused by the query which instantiated it.
+type wasmQuery struct {
+	session uint32
+	pid     uint64
+	time    int64
+}
+
+func wasmQueryOf(ctx context.Context) wasmQuery {
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		return wasmQuery{}
+	}
+	var q wasmQuery
+	if sqlCtx.Session != nil {
+		q.session = sqlCtx.Session.ID()
+	}
+	q.pid, q.time = sqlCtx.Pid(), sqlCtx.QueryTime().UnixNano()
+	return q
+}
+
+// errWasmModuleEvicted is returned when acquiring an instance of a module which was evicted from the cache after it
+// was looked up.
+var errWasmModuleEvicted = errors.New("module was evicted")
+
+// isEvicted returns whether the module was evicted from the cache, and its compiled code closed.
+func (m *wasmModule) isEvicted() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.evicted
+}
+
+// acquire returns an idle instance of the module which was instantiated by the current query, or a new instance if
+// there are none. Idle instances left behind by other queries are closed.
+func (m *wasmModule) acquire(ctx context.Context) (api.Module, error) {
+	q := wasmQueryOf(ctx)
+	var stale []api.Module
+	defer func() {
+		for _, mod := range stale {
+			mod.Close(ctx)
+		}
+	}()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.evicted {
+		return nil, errWasmModuleEvicted
+	}
+	var found api.Module
+	idle := m.idle[:0]
+	for _, inst := range m.idle {
+		switch {
+		case inst.mod.IsClosed():
+		case inst.query != q:
+			stale = append(stale, inst.mod)
+		case found == nil:
+			found = inst.mod
+		default:
+			idle = append(idle, inst)
+		}
+	}
+	m.idle = idle
+	if found != nil {
+		return found, nil
+	}
+	return wasmRuntime().InstantiateModule(ctx, m.compiled, wazero.NewModuleConfig().WithName(""))
+}
+
+// release returns |mod| to the module's idle instances if |reuse| is set and there is room, and closes it otherwise.
+func (m *wasmModule) release(ctx context.Context, mod api.Module, reuse bool) {
+	if mod.IsClosed() {
+		return
+	}
+	m.mu.Lock()
+	if reuse && !m.evicted && len(m.idle) < wasmIdleInstances {
+		m.idle = append(m.idle, wasmIdleInstance{mod: mod, query: wasmQueryOf(ctx)})
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+	mod.Close(ctx)
+}
+
+// close closes the module's idle instances and its compiled code. Instances which are in use are closed when they're
+// released.
+func (m *wasmModule) close(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, inst := range m.idle {
+		inst.mod.Close(ctx)
+	}
+	m.idle, m.evicted = nil, true
+	m.compiled.Close(ctx)
+}
+
+// compileWasmModule compiles |bin|, caching the result by the module's content. The module is compiled without
+// holding the cache's lock, so that calls to other functions aren't blocked by the compilation.
+func compileWasmModule(ctx context.Context, bin []byte) (*wasmModule, error) {
+	key := sha256.Sum256(bin)
+	if m := cachedWasmModule(key); m != nil {
+		return m, nil
+	}
+
+	compiled, err := wasmRuntime().CompileModule(ctx, bin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WebAssembly module: %w", err)
+	}
+	if len(compiled.ImportedFunctions()) > 0 {
+		compiled.Close(ctx)
+		return nil, fmt.Errorf("invalid WebAssembly module: user defined functions may not import host functions")
+	}
+
+	wasmModules.mu.Lock()
+	defer wasmModules.mu.Unlock()
+	if e, ok := wasmModules.byKey[key]; ok {
+		// another call compiled the same module in the meantime
+		compiled.Close(ctx)
+		wasmModules.lru.MoveToFront(e)
+		return e.Value.(*wasmModule), nil
+	}
+	m := &wasmModule{key: key, compiled: compiled}
+	wasmModules.byKey[key] = wasmModules.lru.PushFront(m)
+	for wasmModules.lru.Len() > wasmModuleCacheSize {
+		evictWasmModule(ctx, wasmModules.lru.Back())
+	}
+	return m, nil
+}
+
+// cachedWasmModule returns the cached module with the content hash |key|, or nil if it isn't cached.
+func cachedWasmModule(key [sha256.Size]byte) *wasmModule {
+	wasmModules.mu.Lock()
+	defer wasmModules.mu.Unlock()
+	if e, ok := wasmModules.byKey[key]; ok {
+		wasmModules.lru.MoveToFront(e)
+		return e.Value.(*wasmModule)
+	}
+	return nil
+}
+
+// EvictUserFunctionModule removes the compiled |module| of a dropped user defined function from the cache. Any other
+// function with the same module compiles it again the next time it's called.
+func EvictUserFunctionModule(ctx context.Context, module []byte) {
+	key := sha256.Sum256(module)
+	wasmModules.mu.Lock()
+	defer wasmModules.mu.Unlock()
+	if e, ok := wasmModules.byKey[key]; ok {
+		evictWasmModule(ctx, e)
+	}
+}
+
+func evictWasmModule(ctx context.Context, e *list.Element) {
+	m := wasmModules.lru.Remove(e).(*wasmModule)
+	delete(wasmModules.byKey, m.key)
+	m.close(ctx)
+}
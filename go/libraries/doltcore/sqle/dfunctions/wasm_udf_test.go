@@ -0,0 +1,101 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wasmAddModule exports add(i64, i64) i64.
+const wasmAddModule = "AGFzbQEAAAABBwFgAn5+AX4DAgEABwcBA2FkZAAACgkBBwAgACABfAs="
+
+func addFunction(t *testing.T, module []byte) sql.Expression {
+	def := UserFunctionDefinition{Language: WasmLanguage, Export: "add", Params: []string{"bigint", "bigint"}, Returns: "bigint", Module: module}
+	fn, err := NewUserFunction("add_ints", def).NewInstance([]sql.Expression{
+		expression.NewLiteral(int64(40), types.Int64),
+		expression.NewLiteral(int64(2), types.Int64),
+	})
+	require.NoError(t, err)
+	return fn
+}
+
+func cachedWasmModule(module []byte) (*wasmModule, bool) {
+	wasmModules.mu.Lock()
+	defer wasmModules.mu.Unlock()
+	e, ok := wasmModules.byKey[sha256.Sum256(module)]
+	if !ok {
+		return nil, false
+	}
+	return e.Value.(*wasmModule), true
+}
+
+func TestUserFunctionInstances(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	module, err := base64.StdEncoding.DecodeString(wasmAddModule)
+	require.NoError(t, err)
+	fn := addFunction(t, module)
+
+	for i := 0; i < 3; i++ {
+		res, err := fn.Eval(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), res)
+	}
+	m, ok := cachedWasmModule(module)
+	require.True(t, ok)
+	assert.Len(t, m.idle, 1, "the instance should be reused across calls")
+
+	EvictUserFunctionModule(ctx, module)
+	_, ok = cachedWasmModule(module)
+	assert.False(t, ok)
+	assert.True(t, m.evicted)
+	assert.Empty(t, m.idle)
+
+	// The module is compiled again for functions which still use it.
+	res, err := fn.Eval(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), res)
+}
+
+func TestUserFunctionModuleCacheSize(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	module, err := base64.StdEncoding.DecodeString(wasmAddModule)
+	require.NoError(t, err)
+
+	var modules [][]byte
+	for i := 0; i <= wasmModuleCacheSize; i++ {
+		// A custom section makes each module distinct without changing what it does.
+		distinct := append(append([]byte{}, module...), 0x00, 0x02, 0x01, byte(i))
+		res, err := addFunction(t, distinct).Eval(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), res)
+		modules = append(modules, distinct)
+	}
+
+	_, ok := cachedWasmModule(modules[0])
+	assert.False(t, ok, "the least recently used module should be evicted")
+	for _, m := range modules[1:] {
+		_, ok := cachedWasmModule(m)
+		assert.True(t, ok)
+	}
+	assert.LessOrEqual(t, len(wasmModules.byKey), wasmModuleCacheSize)
+}
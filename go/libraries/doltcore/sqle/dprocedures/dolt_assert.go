@@ -0,0 +1,174 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltAssert is the stored procedure version of the function `dolt_assert(query, [message])`. The query must return a
+// single row with a single column that is truthy, otherwise the enclosing transaction is rolled back and an error is
+// returned.
+func doltAssert(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("dolt_assert expects a query and an optional message")
+	}
+	query, msg := args[0], assertMessage(args, 1)
+
+	rows, err := runAssertQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return nil, assertFailed(ctx, "dolt_assert", msg, "query must return exactly one value, got %d rows", len(rows))
+	}
+	ok, err := sql.ConvertToBool(ctx, rows[0][0])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, assertFailed(ctx, "dolt_assert", msg, "query returned %v", rows[0][0])
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltAssertRowCount is the stored procedure version of the function `dolt_assert_row_count(table, expected, [message])`.
+// If the table in the current database does not contain exactly |expected| rows, the enclosing transaction is rolled
+// back and an error is returned.
+func doltAssertRowCount(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) < 2 || len(args) > 3 {
+		return nil, fmt.Errorf("dolt_assert_row_count expects a table name, an expected row count and an optional message")
+	}
+	table, msg := args[0], assertMessage(args, 2)
+	expected, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expected row count '%s' for dolt_assert_row_count", args[1])
+	}
+
+	rows, err := runAssertQuery(ctx, "SELECT COUNT(*) FROM "+sql.QuoteIdentifier(table))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) != 1 || len(rows[0]) != 1 {
+		return nil, fmt.Errorf("unexpected result counting rows of table %s", table)
+	}
+	actual, ok := rows[0][0].(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected row count type %T for table %s", rows[0][0], table)
+	}
+	if actual != expected {
+		return nil, assertFailed(ctx, "dolt_assert_row_count", msg, "expected %d rows in table %s, found %d", expected, table, actual)
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltAssertQueryEmpty is the stored procedure version of the function `dolt_assert_query_empty(query, [message])`. If
+// the query returns any rows, the enclosing transaction is rolled back and an error is returned.
+func doltAssertQueryEmpty(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, fmt.Errorf("dolt_assert_query_empty expects a query and an optional message")
+	}
+	query, msg := args[0], assertMessage(args, 1)
+
+	rows, err := runAssertQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		return nil, assertFailed(ctx, "dolt_assert_query_empty", msg, "expected no rows, found %d", len(rows))
+	}
+	return rowToIter(int64(0)), nil
+}
+
+func assertMessage(args []string, idx int) string {
+	if len(args) > idx {
+		return args[idx]
+	}
+	return ""
+}
+
+// runAssertQuery runs |query| in the current transaction and returns all of its rows. The query must be a SELECT, and
+// is run through the engine serving the session, so that it's authorized against the calling user's privileges.
+func runAssertQuery(ctx *sql.Context, query string) ([]sql.Row, error) {
+	parsed, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	// SELECT ... INTO writes to variables or files, so isn't allowed either
+	if s, ok := parsed.(sqlparser.SelectStatement); !ok || s.GetInto() != nil {
+		return nil, fmt.Errorf("assertion queries must be SELECT statements")
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	runner := sess.Provider().StatementRunner()
+	if runner == nil {
+		return nil, fmt.Errorf("assertion queries can't be run without a SQL engine")
+	}
+	_, iter, _, err := runner.QueryWithBindings(ctx, query, parsed, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for {
+		row, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			iter.Close(ctx)
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	if err = iter.Close(ctx); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// assertFailed rolls back the enclosing transaction and returns an error describing the failed assertion, so that
+// scripts run with `dolt sql --file` stop without persisting any of their earlier writes.
+func assertFailed(ctx *sql.Context, procName, msg string, format string, args ...interface{}) error {
+	var sb strings.Builder
+	sb.WriteString(procName)
+	sb.WriteString(" failed")
+	if msg != "" {
+		sb.WriteString(": ")
+		sb.WriteString(msg)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(fmt.Sprintf(format, args...))
+	assertErr := fmt.Errorf("%s", sb.String())
+
+	if tx := ctx.GetTransaction(); tx != nil {
+		sess := dsess.DSessFromSess(ctx.Session)
+		if err := sess.Rollback(ctx, tx); err != nil {
+			return fmt.Errorf("%w; additionally, rolling back the transaction failed: %s", assertErr, err.Error())
+		}
+		// Clear out the transaction so that a new one will begin on the next statement, matching ROLLBACK
+		ctx.SetTransaction(nil)
+		ctx.SetIgnoreAutoCommit(false)
+	}
+	return assertErr
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// doltAttachRemote is the stored procedure which attaches a remote database read-only, without cloning it. It takes
+// the URL of the remote and the name of the database, which defaults to the last element of the URL, as with
+// dolt_clone(). DROP DATABASE detaches it.
+func doltAttachRemote(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	ap := cli.CreateAttachRemoteArgParser()
+	apr, err := ap.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName, urlStr, err := getDirectoryAndUrlString(apr)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	_, remoteUrl, err := env.GetAbsRemoteUrl(sess.Provider().FileSystem(), emptyConfig(), urlStr)
+	if err != nil {
+		return nil, errhand.BuildDError("error: '%s' is not valid.", urlStr).Build()
+	}
+
+	remoteParms := map[string]string{}
+	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
+		remoteParms[dbfactory.GRPCUsernameAuthParam] = user
+	}
+
+	err = sess.Provider().AttachRemoteDatabase(ctx, dbName, remoteUrl, remoteParms)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowToIter(int64(0)), nil
+}
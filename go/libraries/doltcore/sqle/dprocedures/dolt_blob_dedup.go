@@ -0,0 +1,217 @@
+// Copyright 2022 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"context"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// blobDedupSchema is the result of dolt_blob_dedup: one row per table, followed by a row for all the tables,
+// whose table is NULL.
+var blobDedupSchema = sql.Schema{
+	&sql.Column{Name: "table", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "blob_refs", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "unique_blobs", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "logical_bytes", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "stored_bytes", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "saved_bytes", Type: types.Int64, Nullable: false},
+}
+
+// doltBlobDedup reports how much storage is shared by the large values (BLOB, TEXT, JSON and geometry values
+// stored outside of their rows) in the working set. Large values are content-addressed chunk trees, so identical
+// values, and identical chunks of different values, are only stored once. |logical_bytes| is the size of every
+// referenced value, |stored_bytes| is the size of the distinct chunks storing them.
+func doltBlobDedup(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	rows, err := doDoltBlobDedup(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func doDoltBlobDedup(ctx *sql.Context, args []string) ([]sql.Row, error) {
+	apr, err := argparser.NewArgParserWithVariableArgs("dolt_blob_dedup").Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+	workingSet, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	workingRoot := workingSet.WorkingRoot()
+
+	tableSet, err := parseTablesToCheck(ctx, workingRoot, apr)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &blobDedupScan{total: newBlobDedupStats(), sizes: make(map[hash.Hash]int64)}
+	var rows []sql.Row
+	for _, tableName := range tableSet.AsSortedSlice() {
+		tbl, ok, err := workingRoot.GetTable(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, sql.ErrTableNotFound.New(tableName.String())
+		}
+		scan.table = newBlobDedupStats()
+		if err = scan.scanTable(ctx, tbl); err != nil {
+			return nil, err
+		}
+		rows = append(rows, scan.table.row(tableName.Name))
+	}
+	return append(rows, scan.total.row(nil)), nil
+}
+
+type blobDedupStats struct {
+	refs    int64
+	blobs   hash.HashSet
+	logical int64
+	chunks  hash.HashSet
+	stored  int64
+}
+
+func newBlobDedupStats() *blobDedupStats {
+	return &blobDedupStats{blobs: hash.NewHashSet(), chunks: hash.NewHashSet()}
+}
+
+func (s *blobDedupStats) addChunk(h hash.Hash, size int64) {
+	if !s.chunks.Has(h) {
+		s.chunks.Insert(h)
+		s.stored += size
+	}
+}
+
+func (s *blobDedupStats) row(table interface{}) sql.Row {
+	return sql.NewRow(table, s.refs, int64(s.blobs.Size()), s.logical, s.stored, s.logical-s.stored)
+}
+
+// blobDedupScan accumulates the large values referenced by the rows of each table into the stats for the table
+// and for all tables.
+type blobDedupScan struct {
+	table, total *blobDedupStats
+	// sizes are the sizes of the values that have been walked.
+	sizes map[hash.Hash]int64
+}
+
+func (s *blobDedupScan) scanTable(ctx context.Context, tbl *doltdb.Table) error {
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+	m, err := durable.ProllyMapFromIndex(rowData)
+	if err != nil {
+		return err
+	}
+	kd, vd := m.Descriptors()
+	ns := m.NodeStore()
+
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		for _, tup := range []struct {
+			td  val.TupleDesc
+			tup val.Tuple
+		}{{kd, k}, {vd, v}} {
+			if err = s.addTupleRefs(ctx, ns, tup.td, tup.tup); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// addTupleRefs adds the values in |tup| that are stored outside of it.
+func (s *blobDedupScan) addTupleRefs(ctx context.Context, ns tree.NodeStore, td val.TupleDesc, tup val.Tuple) error {
+	for i, typ := range td.Types {
+		var addr hash.Hash
+		switch {
+		case typ.Enc == val.CommitAddrEnc:
+			continue
+		case val.IsAddrEncoding(typ.Enc):
+			f := td.GetField(i, tup)
+			if len(f) != hash.ByteLen {
+				continue
+			}
+			addr = hash.New(f)
+		case val.IsAdaptiveEncoding(typ.Enc):
+			var ok bool
+			if addr, ok = val.AdaptiveValue(td.GetField(i, tup)).OutOfBandAddress(); !ok {
+				continue
+			}
+		default:
+			continue
+		}
+		if addr.IsEmpty() {
+			continue
+		}
+		if err := s.addRef(ctx, ns, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *blobDedupScan) addRef(ctx context.Context, ns tree.NodeStore, addr hash.Hash) error {
+	if !s.table.blobs.Has(addr) {
+		// tables are scanned one at a time, so a value new to the table may have been walked for a previous table
+		root, err := ns.Read(ctx, addr)
+		if err != nil {
+			return err
+		}
+		var size int64
+		err = tree.WalkNodes(ctx, root, ns, func(ctx context.Context, nd tree.Node) error {
+			h, sz := nd.HashOf(), int64(nd.Size())
+			size += sz
+			s.table.addChunk(h, sz)
+			s.total.addChunk(h, sz)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		s.sizes[addr] = size
+		s.table.blobs.Insert(addr)
+		s.total.blobs.Insert(addr)
+	}
+	s.table.refs++
+	s.total.refs++
+	s.table.logical += s.sizes[addr]
+	s.total.logical += s.sizes[addr]
+	return nil
+}
@@ -116,6 +116,22 @@ func doDoltCheckout(ctx *sql.Context, args []string) (statusCode int, successMes
 		}
 	}
 
+	// Handle dolt_checkout -- table1 table2, where the separator makes the arguments tables even if they name branches
+	if apr.PositionalArgsSeparatorIndex == 0 {
+		roots, ok := dSess.GetRoots(ctx, currentDbName)
+		if !ok {
+			return 1, "", fmt.Errorf("Could not load database %s", currentDbName)
+		}
+		err = checkoutTablesFromHead(ctx, roots, currentDbName, apr.Args)
+		if err != nil {
+			return 1, "", err
+		}
+		return 0, "", nil
+	}
+	if apr.PositionalArgsSeparatorIndex > 1 {
+		return 1, "", errors.New("Improper usage. Only one commit may be given before --.")
+	}
+
 	branchName := apr.Arg(0)
 	if len(branchName) == 0 {
 		return 1, "", ErrEmptyBranchName
@@ -137,7 +153,7 @@ func doDoltCheckout(ctx *sql.Context, args []string) (statusCode int, successMes
 		}
 		err = checkoutTablesFromCommit(ctx, database, branchName, apr.Args[1:])
 		if err != nil {
-			return 0, "", err
+			return 1, "", err
 		}
 
 		dsess.WaitForReplicationController(ctx, rsc)
@@ -498,7 +514,8 @@ func checkoutExistingBranch(ctx *sql.Context, dbName string, branchName string,
 }
 
 // checkoutTablesFromCommit checks out the tables named from the branch named and overwrites those tables in the
-// staged and working roots.
+// staged and working roots. Like git checkout <commit> -- <path>, the tables named are staged, and the changes to any
+// other tables are left as they are.
 func checkoutTablesFromCommit(
 	ctx *sql.Context,
 	databaseName string,
@@ -565,12 +582,16 @@ func checkoutTablesFromCommit(
 		}
 	}
 
-	newRoot, err := actions.MoveTablesBetweenRoots(ctx, tableNames, headRoot, ws.WorkingRoot())
+	newStaged, err := actions.MoveTablesBetweenRoots(ctx, tableNames, headRoot, ws.StagedRoot())
+	if err != nil {
+		return err
+	}
+	newWorking, err := actions.MoveTablesBetweenRoots(ctx, tableNames, headRoot, ws.WorkingRoot())
 	if err != nil {
 		return err
 	}
 
-	return dSess.SetWorkingSet(ctx, databaseName, ws.WithStagedRoot(newRoot).WithWorkingRoot(newRoot))
+	return dSess.SetWorkingSet(ctx, databaseName, ws.WithStagedRoot(newStaged).WithWorkingRoot(newWorking))
 }
 
 // doGlobalCheckout implements the behavior of the `dolt checkout` command line, moving the working set into
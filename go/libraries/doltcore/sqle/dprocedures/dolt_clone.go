@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 )
 
 // doltClone is the stored procedure version for the CLI command `dolt clone`.
@@ -51,18 +52,25 @@ func doltClone(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	}
 
 	// There are several remote params (AWS/GCP/OCI paths, creds, etc) which are pulled from the global server using
-	// server config, environment vars and such. The --user flag is the only one that we can override with a command flag.
+	// server config, environment vars and such. The --user and --max-bandwidth flags are the only ones that we can override
+	// with a command flag.
 	remoteParms := map[string]string{}
 	if user, hasUser := apr.GetValue(cli.UserFlag); hasUser {
 		remoteParms[dbfactory.GRPCUsernameAuthParam] = user
 	}
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		if _, err := iohelp.ParseBandwidth(maxBandwidth); err != nil {
+			return nil, err
+		}
+		remoteParms[dbfactory.GRPCMaxBandwidthParam] = maxBandwidth
+	}
 
 	depth, ok := apr.GetInt(cli.DepthFlag)
 	if !ok {
 		depth = -1
 	}
 
-	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, remoteParms)
+	err = sess.Provider().CloneDatabaseFromRemote(ctx, dir, branch, remoteName, remoteUrl, depth, apr.Contains(cli.NoTagsFlag), remoteParms)
 	if err != nil {
 		return nil, err
 	}
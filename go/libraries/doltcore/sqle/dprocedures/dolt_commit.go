@@ -15,7 +15,6 @@
 package dprocedures
 
 import (
-	"errors"
 	"fmt"
 	"strings"
 
@@ -28,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/gpg"
 	"github.com/dolthub/dolt/go/store/datas"
 )
@@ -36,7 +36,7 @@ import (
 func doltCommit(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	commitHash, skipped, err := doDoltCommit(ctx, args)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err)
 	}
 	if skipped {
 		return nil, nil
@@ -49,7 +49,7 @@ func doltCommit(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 func doltCommitHashOut(ctx *sql.Context, outHash *string, args ...string) (sql.RowIter, error) {
 	commitHash, skipped, err := doDoltCommit(ctx, args)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err)
 	}
 	if skipped {
 		return nil, nil
@@ -104,19 +104,6 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		}
 	}
 
-	var name, email string
-	if authorStr, ok := apr.GetValue(cli.AuthorParam); ok {
-		name, email, err = cli.ParseAuthor(authorStr)
-		if err != nil {
-			return "", false, err
-		}
-	} else {
-		// In SQL mode, use the current SQL user as the commit author, instead of the `dolt config` configured values.
-		// We won't have an email address for the SQL user though, so instead use the MySQL user@address notation.
-		name = ctx.Client().User
-		email = fmt.Sprintf("%s@%s", ctx.Client().User, ctx.Client().Address)
-	}
-
 	amend := apr.Contains(cli.AmendFlag)
 
 	msg, msgOk := apr.GetValue(cli.MessageArg)
@@ -162,8 +149,14 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 		SkipEmpty:  apr.Contains(cli.SkipEmptyFlag),
 		Amend:      amend,
 		Force:      apr.Contains(cli.ForceFlag),
-		Name:       name,
-		Email:      email,
+		// In SQL mode, use the current SQL user as the commit author, instead of the `dolt config` configured values.
+		// We won't have an email address for the SQL user though, so instead use the MySQL user@address notation.
+		Name:  ctx.Client().User,
+		Email: fmt.Sprintf("%s@%s", ctx.Client().User, ctx.Client().Address),
+	}
+	authorStr, _ := apr.GetValue(cli.AuthorParam)
+	if err = dsess.SetCommitIdentity(ctx, &csp, authorStr); err != nil {
+		return "", false, err
 	}
 
 	shouldSign, err := dsess.GetBooleanSystemVar(ctx, "gpgsign")
@@ -180,22 +173,16 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 	if pendingCommit == nil && apr.Contains(cli.SkipEmptyFlag) {
 		return "", true, nil
 	} else if pendingCommit == nil {
-		return "", false, errors.New("nothing to commit")
+		return "", false, ErrNothingToCommit
 	}
 
 	if apr.Contains(cli.SignFlag) || shouldSign {
-		keyId := apr.GetValueOrDefault(cli.SignFlag, "")
-
-		if keyId == "" {
-			v, err := ctx.GetSessionVariable(ctx, "signingkey")
-			if err != nil && !sql.ErrUnknownSystemVariable.Is(err) {
-				return "", false, fmt.Errorf("failed to get signingkey: %w", err)
-			} else if err == nil {
-				keyId = v.(string)
-			}
+		keyId, err := gpgSigningKey(ctx, apr)
+		if err != nil {
+			return "", false, err
 		}
 
-		strToSign, err := commitSignatureStr(ctx, dbName, roots, csp)
+		strToSign, err := commitSignatureStr(ctx, dbName, pendingCommit.Roots, csp)
 		if err != nil {
 			return "", false, err
 		}
@@ -221,6 +208,21 @@ func doDoltCommit(ctx *sql.Context, args []string) (string, bool, error) {
 	return h.String(), false, nil
 }
 
+// gpgSigningKey returns the id of the key to sign with, given by the --gpg-sign option or else the signingkey system
+// variable. An empty id signs with the default key.
+func gpgSigningKey(ctx *sql.Context, apr *argparser.ArgParseResults) (string, error) {
+	keyId := apr.GetValueOrDefault(cli.SignFlag, "")
+	if keyId == "" {
+		v, err := ctx.GetSessionVariable(ctx, "signingkey")
+		if err != nil && !sql.ErrUnknownSystemVariable.Is(err) {
+			return "", fmt.Errorf("failed to get signingkey: %w", err)
+		} else if err == nil {
+			keyId = v.(string)
+		}
+	}
+	return keyId, nil
+}
+
 func getDoltArgs(ctx *sql.Context, row sql.Row, children []sql.Expression) ([]string, error) {
 	args := make([]string, len(children))
 	for i := range children {
@@ -409,68 +409,78 @@ func ResolveSchemaConflicts(ctx *sql.Context, ddb *doltdb.DoltDB, ws *doltdb.Wor
 }
 
 func ResolveDataConflicts(ctx *sql.Context, dSess *dsess.DoltSession, root doltdb.RootValue, dbName string, ours bool, tblNames []doltdb.TableName) error {
+	state, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	var opts editor.Options
+	if ok && state.WriteSession() != nil {
+		opts = state.WriteSession().GetOptions()
+	}
+
 	for _, tblName := range tblNames {
-		tbl, ok, err := root.GetTable(ctx, tblName)
+		root, err = resolveTableDataConflicts(ctx, opts, root, tblName, ours)
 		if err != nil {
 			return err
 		}
-		if !ok {
-			return doltdb.ErrTableNotFound
-		}
+	}
+	return dSess.SetWorkingRoot(ctx, dbName, root)
+}
 
-		if has, err := tbl.HasConflicts(ctx); err != nil {
-			return err
-		} else if !has {
-			continue
-		}
+// resolveTableDataConflicts resolves the data conflicts of table |tblName| in |root| with our or their version of each
+// conflicting row, and returns the updated root.
+func resolveTableDataConflicts(ctx *sql.Context, opts editor.Options, root doltdb.RootValue, tblName doltdb.TableName, ours bool) (doltdb.RootValue, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, doltdb.ErrTableNotFound
+	}
 
-		sch, err := tbl.GetSchema(ctx)
-		if err != nil {
-			return err
-		}
-		_, ourSch, theirSch, err := tbl.GetConflictSchemas(ctx, tblName)
-		if err != nil {
-			return err
-		}
+	if has, err := tbl.HasConflicts(ctx); err != nil {
+		return nil, err
+	} else if !has {
+		return root, nil
+	}
 
-		if ours && !schema.ColCollsAreEqual(sch.GetAllCols(), ourSch.GetAllCols()) {
-			return ErrConfSchIncompatible
-		} else if !ours && !schema.ColCollsAreEqual(sch.GetAllCols(), theirSch.GetAllCols()) {
-			return ErrConfSchIncompatible
-		}
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	_, ourSch, theirSch, err := tbl.GetConflictSchemas(ctx, tblName)
+	if err != nil {
+		return nil, err
+	}
 
-		if !ours {
-			if tbl.Format() == types.Format_DOLT {
-				tbl, err = resolveProllyConflicts(ctx, tbl, tblName.Name, ourSch, sch)
-			} else {
-				state, _, err := dSess.LookupDbState(ctx, dbName)
-				if err != nil {
-					return err
-				}
-				var opts editor.Options
-				if ws := state.WriteSession(); ws != nil {
-					opts = ws.GetOptions()
-				}
-				tbl, err = resolveNomsConflicts(ctx, opts, tbl, tblName.Name, sch)
-			}
-			if err != nil {
-				return err
-			}
-		}
+	if ours && !schema.ColCollsAreEqual(sch.GetAllCols(), ourSch.GetAllCols()) {
+		return nil, ErrConfSchIncompatible
+	} else if !ours && !schema.ColCollsAreEqual(sch.GetAllCols(), theirSch.GetAllCols()) {
+		return nil, ErrConfSchIncompatible
+	}
 
-		newRoot, err := clearTableAndUpdateRoot(ctx, root, tbl, tblName)
-		if err != nil {
-			return err
+	if !ours {
+		if tbl.Format() == types.Format_DOLT {
+			tbl, err = resolveProllyConflicts(ctx, tbl, tblName.Name, ourSch, sch)
+		} else {
+			tbl, err = resolveNomsConflicts(ctx, opts, tbl, tblName.Name, sch)
 		}
-
-		err = validateConstraintViolations(ctx, root, newRoot, tblName)
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
 
-		root = newRoot
+	newRoot, err := clearTableAndUpdateRoot(ctx, root, tbl, tblName)
+	if err != nil {
+		return nil, err
 	}
-	return dSess.SetWorkingRoot(ctx, dbName, root)
+
+	err = validateConstraintViolations(ctx, root, newRoot, tblName)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRoot, nil
 }
 
 func DoDoltConflictsResolve(ctx *sql.Context, args []string) (int, error) {
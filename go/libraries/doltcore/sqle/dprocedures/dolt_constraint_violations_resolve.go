@@ -0,0 +1,333 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var ErrViolationSchIncompatible = errors.New("the schema of the table the violating rows came from is not equal to the current schema, please resolve manually")
+
+// doltConstraintViolationsResolve is the stored procedure that resolves constraint violations by replacing each
+// violating row with its version from one side of the merge that produced it.
+func doltConstraintViolationsResolve(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	res, err := DoDoltConstraintViolationsResolve(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(res)), nil
+}
+
+func DoDoltConstraintViolationsResolve(ctx *sql.Context, args []string) (int, error) {
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return 1, err
+	}
+	dbName := ctx.GetCurrentDatabase()
+
+	apr, err := cli.CreateConstraintViolationsResolveArgParser().Parse(args)
+	if err != nil {
+		return 1, err
+	}
+
+	ours := apr.Contains(cli.OursFlag)
+	theirs := apr.Contains(cli.TheirsFlag)
+	if ours && theirs {
+		return 1, fmt.Errorf("specify only either --ours or --theirs")
+	} else if !ours && !theirs {
+		return 1, fmt.Errorf("--ours or --theirs must be supplied")
+	}
+	if apr.NArg() == 0 {
+		return 1, fmt.Errorf("specify at least one table to resolve constraint violations")
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	ws, err := dSess.WorkingSet(ctx, dbName)
+	if err != nil {
+		return 1, err
+	}
+	if !types.IsFormat_DOLT(ws.WorkingRoot().VRW().Format()) {
+		return 1, fmt.Errorf("resolving constraint violations is only supported on the current storage format, run `dolt migrate` to upgrade")
+	}
+
+	var tableNames []doltdb.TableName
+	if apr.NArg() == 1 && apr.Arg(0) == "." {
+		tableNames = actions.GetAllTableNames(ctx, ws.WorkingRoot())
+	} else {
+		for _, tblName := range apr.Args {
+			tn, _, ok, err := resolve.Table(ctx, ws.WorkingRoot(), tblName)
+			if err != nil {
+				return 1, err
+			}
+			if !ok {
+				return 1, doltdb.ErrTableNotFound
+			}
+			tableNames = append(tableNames, tn)
+		}
+	}
+
+	// "ours" is the working set before the merge that recorded the violations, or HEAD if no merge is in progress
+	var ourRoot doltdb.RootValue
+	if ours {
+		if ws.MergeActive() {
+			ourRoot = ws.MergeState().PreMergeWorkingRoot()
+		} else {
+			ourRoot, _, _, err = dSess.ResolveRootForRef(ctx, dbName, "HEAD")
+			if err != nil {
+				return 1, err
+			}
+		}
+	}
+
+	root := ws.WorkingRoot()
+	for _, tblName := range tableNames {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+		if err != nil {
+			return 1, err
+		}
+		if !ok {
+			return 1, doltdb.ErrTableNotFound
+		}
+
+		artIdx, err := tbl.GetArtifacts(ctx)
+		if err != nil {
+			return 1, err
+		}
+		if cnt, err := artIdx.ConstraintViolationCount(ctx); err != nil {
+			return 1, err
+		} else if cnt == 0 {
+			continue
+		}
+
+		newTbl, err := resolveProllyConstraintViolations(ctx, tbl, tblName, ourRoot)
+		if err != nil {
+			return 1, err
+		}
+		newRoot, err := root.PutTable(ctx, tblName, newTbl)
+		if err != nil {
+			return 1, err
+		}
+
+		err = validateConstraintViolations(ctx, root, newRoot, tblName)
+		if err != nil {
+			return 1, err
+		}
+		root = newRoot
+	}
+
+	if err = dSess.SetWorkingRoot(ctx, dbName, root); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// resolveProllyConstraintViolations replaces each row of |tbl| with a constraint violation by its version in
+// |ourRoot|, or by its version in the root the violation came from if |ourRoot| is nil, and then clears the
+// violations. Rows that don't exist in the chosen root are deleted. The check constraints and unique keys of the
+// replaced rows are evaluated again, and the violations of any which still fail them are recorded anew.
+func resolveProllyConstraintViolations(ctx *sql.Context, tbl *doltdb.Table, tblName doltdb.TableName, ourRoot doltdb.RootValue) (*doltdb.Table, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	artIdx, err := tbl.GetArtifacts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	artMap := durable.ProllyMapFromArtifactIndex(artIdx)
+	iter, err := artMap.IterAllCVs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowIdx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := durable.ProllyMapFromIndex(rowIdx)
+	if err != nil {
+		return nil, err
+	}
+	mutMap := rows.Mutate()
+
+	idxSet, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mutIdxs, err := merge.GetMutableSecondaryIdxs(ctx, sch, sch, tblName.Name, idxSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var srcMap prolly.Map
+	if ourRoot != nil {
+		srcMap, err = violationSourceRows(ctx, ourRoot, tblName, sch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	artEditor := artMap.Editor()
+	var srcRoot hash.Hash
+	// the rootish of the first violation of each replaced row, by key
+	replaced := make(map[string]hash.Hash)
+	for {
+		art, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if ourRoot == nil && srcRoot != art.SourceRootish {
+			root, err := doltdb.LoadRootValueFromRootIshAddr(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), art.SourceRootish)
+			if err != nil {
+				return nil, err
+			}
+			srcMap, err = violationSourceRows(ctx, root, tblName, sch)
+			if err != nil {
+				return nil, err
+			}
+			srcRoot = art.SourceRootish
+		}
+
+		// a row can have several violations, so it may have already been replaced
+		var curRow, srcRow val.Tuple
+		err = mutMap.Get(ctx, art.SourceKey, func(_, v val.Tuple) error {
+			curRow = v
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = srcMap.Get(ctx, art.SourceKey, func(_, v val.Tuple) error {
+			srcRow = v
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(srcRow) == 0 {
+			err = mutMap.Delete(ctx, art.SourceKey)
+		} else {
+			err = mutMap.Put(ctx, art.SourceKey, srcRow)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mutIdx := range mutIdxs {
+			if len(curRow) == 0 && len(srcRow) == 0 {
+				continue
+			} else if len(curRow) == 0 {
+				err = mutIdx.InsertEntry(ctx, art.SourceKey, srcRow)
+			} else if len(srcRow) == 0 {
+				err = mutIdx.DeleteEntry(ctx, art.SourceKey, curRow)
+			} else {
+				err = mutIdx.UpdateEntry(ctx, art.SourceKey, curRow, srcRow)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if err = artEditor.Delete(ctx, art.ArtKey); err != nil {
+			return nil, err
+		}
+		if _, ok := replaced[string(art.SourceKey)]; !ok {
+			replaced[string(art.SourceKey)] = art.SourceRootish
+		}
+	}
+
+	newMap, err := mutMap.Map(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newTbl, err := tbl.UpdateRows(ctx, durable.IndexFromProllyMap(newMap))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mutIdx := range mutIdxs {
+		m, err := mutIdx.Map(ctx)
+		if err != nil {
+			return nil, err
+		}
+		idxSet, err = idxSet.PutIndex(ctx, mutIdx.Name, durable.IndexFromProllyMap(m))
+		if err != nil {
+			return nil, err
+		}
+	}
+	newTbl, err = newTbl.SetIndexSet(ctx, idxSet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = merge.ValidateRowConstraints(ctx, tblName.Name, sch, newMap, idxSet, replaced, artEditor); err != nil {
+		return nil, err
+	}
+
+	newArtMap, err := artEditor.Flush(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newTbl.SetArtifacts(ctx, durable.ArtifactIndexFromProllyMap(newArtMap))
+}
+
+// violationSourceRows returns the row data of table |tblName| in |root|, which must have the same columns as |sch|.
+// Returns an empty map if the table doesn't exist in |root|.
+func violationSourceRows(ctx *sql.Context, root doltdb.RootValue, tblName doltdb.TableName, sch schema.Schema) (prolly.Map, error) {
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	if !ok {
+		kd, vd := sch.GetMapDescriptors(root.NodeStore())
+		return prolly.NewMapFromTuples(ctx, root.NodeStore(), kd, vd)
+	}
+
+	srcSch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	if !schema.ColCollsAreEqual(sch.GetAllCols(), srcSch.GetAllCols()) {
+		return prolly.Map{}, ErrViolationSchIncompatible
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
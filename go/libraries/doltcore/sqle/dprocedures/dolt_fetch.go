@@ -80,6 +80,13 @@ func doDoltFetch(ctx *sql.Context, args []string) (int, error) {
 		})
 	}
 
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		remote, err = remote.WithMaxBandwidth(maxBandwidth)
+		if err != nil {
+			return cmdFailure, err
+		}
+	}
+
 	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, false)
 	if err != nil {
 		return 1, err
@@ -0,0 +1,163 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// userFunctionDatabase is a database which stores user defined functions in its dolt_schemas table.
+type userFunctionDatabase interface {
+	GetUserFunction(ctx *sql.Context, name string) (string, bool, error)
+	CreateUserFunction(ctx *sql.Context, name, definition string) error
+	DropUserFunction(ctx *sql.Context, name string) error
+}
+
+// doltCreateFunction creates a user defined function implemented by a WebAssembly module. Unless --temporary is
+// given, the function is stored in the dolt_schemas table of the current database and versioned along with it. Only
+// admins may create functions, since their modules run in the server process.
+func doltCreateFunction(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	apr, err := cli.CreateCreateFunctionArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() != 1 {
+		return nil, fmt.Errorf("dolt_create_function requires the name of the function to create")
+	}
+	name := apr.Arg(0)
+	if dfunctions.IsReservedFunctionName(name) {
+		return nil, dfunctions.ErrUserFunctionExists.New(name)
+	}
+
+	returns, ok := apr.GetValue("returns")
+	if !ok {
+		return nil, fmt.Errorf("dolt_create_function requires --returns")
+	}
+	encoded, ok := apr.GetValue("module")
+	if !ok {
+		return nil, fmt.Errorf("dolt_create_function requires --module")
+	}
+	module, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --module: %w", err)
+	}
+
+	var params []string
+	if p, ok := apr.GetValue("params"); ok && strings.TrimSpace(p) != "" {
+		for _, param := range strings.Split(p, ",") {
+			params = append(params, strings.TrimSpace(param))
+		}
+	}
+
+	def := dfunctions.UserFunctionDefinition{
+		Language: apr.GetValueOrDefault("language", dfunctions.WasmLanguage),
+		Export:   apr.GetValueOrDefault("export", name),
+		Params:   params,
+		Returns:  returns,
+		Module:   module,
+	}
+	if err = def.Validate(ctx); err != nil {
+		return nil, err
+	}
+	definition, err := def.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	if apr.Contains("temporary") {
+		if _, ok := dSess.GetTemporaryFunction(name); ok {
+			return nil, dfunctions.ErrUserFunctionExists.New(name)
+		}
+		dSess.AddTemporaryFunction(name, definition)
+		return rowToIter(int64(0)), nil
+	}
+
+	db, err := currentUserFunctionDatabase(ctx, dSess)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.CreateUserFunction(ctx, name, definition); err != nil {
+		return nil, err
+	}
+	return rowToIter(int64(0)), nil
+}
+
+// doltDropFunction drops a user defined function created with dolt_create_function.
+func doltDropFunction(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	apr, err := cli.CreateDropFunctionArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() != 1 {
+		return nil, fmt.Errorf("dolt_drop_function requires the name of the function to drop")
+	}
+	name := apr.Arg(0)
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	if apr.Contains("temporary") {
+		definition, ok := dSess.GetTemporaryFunction(name)
+		if !ok || !dSess.DropTemporaryFunction(name) {
+			return nil, sql.ErrFunctionNotFound.New(name)
+		}
+		evictUserFunctionModule(ctx, definition)
+		return rowToIter(int64(0)), nil
+	}
+
+	db, err := currentUserFunctionDatabase(ctx, dSess)
+	if err != nil {
+		return nil, err
+	}
+	definition, _, err := db.GetUserFunction(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err = db.DropUserFunction(ctx, name); err != nil {
+		return nil, err
+	}
+	evictUserFunctionModule(ctx, definition)
+	return rowToIter(int64(0)), nil
+}
+
+// evictUserFunctionModule frees the compiled module of the dropped function with the serialized |definition|.
+func evictUserFunctionModule(ctx *sql.Context, definition string) {
+	if def, err := dfunctions.ParseUserFunctionDefinition(definition); err == nil {
+		dfunctions.EvictUserFunctionModule(ctx, def.Module)
+	}
+}
+
+func currentUserFunctionDatabase(ctx *sql.Context, dSess *dsess.DoltSession) (userFunctionDatabase, error) {
+	dbName := ctx.GetCurrentDatabase()
+	if len(dbName) == 0 {
+		return nil, sql.ErrNoDatabaseSelected.New()
+	}
+	db, err := dSess.Provider().Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	udb, ok := db.(userFunctionDatabase)
+	if !ok {
+		return nil, fmt.Errorf("database %s does not support user defined functions", dbName)
+	}
+	return udb, nil
+}
@@ -19,10 +19,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dustin/go-humanize"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
@@ -56,16 +59,32 @@ func init() {
 var DoltGCFeatureFlag = true
 var UseSessionAwareSafepointController = false
 
+var doltGCSchema = []*sql.Column{
+	{
+		Name:     "status",
+		Type:     gmstypes.Int64,
+		Nullable: false,
+	},
+	{
+		Name:     "message",
+		Type:     gmstypes.LongText,
+		Nullable: true,
+	},
+}
+
 // doltGC is the stored procedure to run online garbage collection on a database.
 func doltGC(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	if !DoltGCFeatureFlag {
 		return nil, errors.New("DOLT_GC() stored procedure disabled")
 	}
-	res, err := doDoltGC(ctx, args)
+	res, message, err := doDoltGC(ctx, args)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(int64(res)), nil
+	if message == "" {
+		return rowToIter(int64(res), nil), nil
+	}
+	return rowToIter(int64(res), message), nil
 }
 
 var ErrServerPerformedGC = errors.New("this connection was established when this server performed an online garbage collection. this connection can no longer be used. please reconnect.")
@@ -214,39 +233,50 @@ func (sc *sessionAwareSafepointController) CancelSafepoint() {
 	sc.waiter.Wait(canceledCtx)
 }
 
-func doDoltGC(ctx *sql.Context, args []string) (int, error) {
+// doDoltGC runs dolt_gc with |args|, and returns its status and a message, which is empty unless --dry-run or
+// --verbose was given.
+func doDoltGC(ctx *sql.Context, args []string) (int, string, error) {
 	dbName := ctx.GetCurrentDatabase()
 
 	if len(dbName) == 0 {
-		return cmdFailure, fmt.Errorf("Empty database name.")
+		return cmdFailure, "", fmt.Errorf("Empty database name.")
 	}
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
-		return cmdFailure, err
+		return cmdFailure, "", err
 	}
 
 	apr, err := cli.CreateGCArgParser().Parse(args)
 	if err != nil {
-		return cmdFailure, err
+		return cmdFailure, "", err
 	}
 
 	if apr.NArg() != 0 {
-		return cmdFailure, InvalidArgErr
+		return cmdFailure, "", InvalidArgErr
 	}
 
 	dSess := dsess.DSessFromSess(ctx.Session)
 	ddb, ok := dSess.GetDoltDB(ctx, dbName)
 	if !ok {
-		return cmdFailure, fmt.Errorf("Could not load database %s", dbName)
+		return cmdFailure, "", fmt.Errorf("Could not load database %s", dbName)
 	}
 
 	if apr.Contains(cli.ShallowFlag) && apr.Contains(cli.FullFlag) {
-		return cmdFailure, fmt.Errorf("cannot supply both --shallow and --full to dolt_gc: %w", InvalidArgErr)
+		return cmdFailure, "", fmt.Errorf("cannot supply both --shallow and --full to dolt_gc: %w", InvalidArgErr)
+	}
+	if apr.Contains(cli.ShallowFlag) && apr.Contains(cli.DryRunFlag) {
+		return cmdFailure, "", fmt.Errorf("cannot supply both --shallow and --dry-run to dolt_gc: %w", InvalidArgErr)
 	}
 
+	var message string
 	if apr.Contains(cli.ShallowFlag) {
+		start := time.Now()
 		err = ddb.ShallowGC(ctx)
 		if err != nil {
-			return cmdFailure, err
+			return cmdFailure, "", err
+		}
+		if apr.Contains(cli.VerboseFlag) {
+			message = fmt.Sprintf("shallow gc: %s", time.Since(start).Round(time.Millisecond))
+			ctx.GetLogger().Infof("dolt_gc: %s", message)
 		}
 	} else {
 		mode := types.GCModeDefault
@@ -254,28 +284,115 @@ func doDoltGC(ctx *sql.Context, args []string) (int, error) {
 			mode = types.GCModeFull
 		}
 
+		if apr.Contains(cli.DryRunFlag) {
+			plan, err := ddb.GCDryRun(ctx, mode)
+			if err != nil {
+				return cmdFailure, "", err
+			}
+			return cmdSuccess, formatGCPlan(plan), nil
+		}
+
 		cmpLvl := chunks.NoArchive
 		if apr.Contains(cli.ArchiveLevelParam) {
 			lvl, ok := apr.GetInt(cli.ArchiveLevelParam)
 			if !ok {
-				return cmdFailure, fmt.Errorf("parse error for value for %s: %s", cli.ArchiveLevelParam, apr.GetValues()[cli.ArchiveLevelParam])
+				return cmdFailure, "", fmt.Errorf("parse error for value for %s: %s", cli.ArchiveLevelParam, apr.GetValues()[cli.ArchiveLevelParam])
 			}
 			if lvl < int(chunks.NoArchive) || lvl > int(chunks.MaxArchiveLevel) {
-				return cmdFailure, fmt.Errorf("invalid value for %s: %d", cli.ArchiveLevelParam, lvl)
+				return cmdFailure, "", fmt.Errorf("invalid value for %s: %d", cli.ArchiveLevelParam, lvl)
 			}
 			cmpLvl = chunks.GCArchiveLevel(lvl)
 		}
 
-		err := RunDoltGC(ctx, ddb, mode, cmpLvl, ctx.GetCurrentDatabase())
+		phases, err := runDoltGC(ctx, ddb, mode, cmpLvl, ctx.GetCurrentDatabase(), apr.Contains(cli.VerboseFlag))
 		if err != nil {
-			return cmdFailure, err
+			return cmdFailure, "", err
 		}
+		message = strings.Join(phases, "\n")
 	}
 
-	return cmdSuccess, nil
+	return cmdSuccess, message, nil
+}
+
+// formatGCPlan describes |plan| for the message returned by dolt_gc('--dry-run').
+func formatGCPlan(plan types.GCPlan) string {
+	lines := []string{
+		"dry run: no chunks were collected",
+		fmt.Sprintf("table files to rewrite: %d", len(plan.TableFiles)),
+	}
+	for _, f := range plan.TableFiles {
+		lines = append(lines, "  "+f)
+	}
+	lines = append(lines,
+		fmt.Sprintf("chunks: %d total, %d reachable, %d to collect", plan.TotalChunks, plan.ReachableChunks, plan.CollectedChunks()),
+		fmt.Sprintf("bytes: %s total, ~%s to collect", humanize.Bytes(plan.TotalBytes), humanize.Bytes(plan.CollectedBytes())),
+		fmt.Sprintf("table files pending conjoin: %d", len(plan.ConjoinTableFiles)),
+	)
+	for _, f := range plan.ConjoinTableFiles {
+		lines = append(lines, "  "+f)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// timedSafepointController wraps a GCSafepointController to record how long each phase of a GC takes, for
+// dolt_gc('--verbose'). Each phase is logged as it completes.
+type timedSafepointController struct {
+	types.GCSafepointController
+	ctx    *sql.Context
+	start  time.Time
+	last   time.Time
+	phases []string
+}
+
+func newTimedSafepointController(ctx *sql.Context, sc types.GCSafepointController) *timedSafepointController {
+	now := time.Now()
+	return &timedSafepointController{GCSafepointController: sc, ctx: ctx, start: now, last: now}
+}
+
+// phaseDone records that the phase |name| ended now, having started when the previous phase ended.
+func (sc *timedSafepointController) phaseDone(name string) {
+	now := time.Now()
+	phase := fmt.Sprintf("%s: %s", name, now.Sub(sc.last).Round(time.Millisecond))
+	sc.last = now
+	sc.phases = append(sc.phases, phase)
+	sc.ctx.GetLogger().Infof("dolt_gc: %s", phase)
+}
+
+func (sc *timedSafepointController) BeginGC(ctx context.Context, keeper func(hash.Hash) bool) error {
+	err := sc.GCSafepointController.BeginGC(ctx, keeper)
+	sc.phaseDone("begin")
+	return err
+}
+
+func (sc *timedSafepointController) EstablishPreFinalizeSafepoint(ctx context.Context) error {
+	sc.phaseDone("mark and copy reachable chunks")
+	err := sc.GCSafepointController.EstablishPreFinalizeSafepoint(ctx)
+	sc.phaseDone("pre-finalize safepoint")
+	return err
+}
+
+func (sc *timedSafepointController) EstablishPostFinalizeSafepoint(ctx context.Context) error {
+	sc.phaseDone("finalize")
+	err := sc.GCSafepointController.EstablishPostFinalizeSafepoint(ctx)
+	sc.phaseDone("post-finalize safepoint")
+	return err
+}
+
+// done records the end of the GC, and returns the phases it recorded.
+func (sc *timedSafepointController) done() []string {
+	sc.phaseDone("swap in new table files")
+	total := fmt.Sprintf("total: %s", time.Since(sc.start).Round(time.Millisecond))
+	sc.ctx.GetLogger().Infof("dolt_gc: %s", total)
+	return append(sc.phases, total)
 }
 
 func RunDoltGC(ctx *sql.Context, ddb *doltdb.DoltDB, mode types.GCMode, cmp chunks.GCArchiveLevel, dbname string) error {
+	_, err := runDoltGC(ctx, ddb, mode, cmp, dbname, false)
+	return err
+}
+
+// runDoltGC runs GC on |ddb|. If |verbose| is true, it also returns how long each phase of the GC took.
+func runDoltGC(ctx *sql.Context, ddb *doltdb.DoltDB, mode types.GCMode, cmp chunks.GCArchiveLevel, dbname string, verbose bool) ([]string, error) {
 	dSess := dsess.DSessFromSess(ctx.Session)
 	var sc types.GCSafepointController
 	var statsDoneCh chan struct{}
@@ -320,11 +437,11 @@ func RunDoltGC(ctx *sql.Context, ddb *doltdb.DoltDB, mode types.GCMode, cmp chun
 		if _, role, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleVariable); ok {
 			// TODO: magic constant...
 			if role.(string) != "primary" {
-				return fmt.Errorf("cannot run a full dolt_gc() while cluster replication is enabled and role is %s; must be the primary", role.(string))
+				return nil, fmt.Errorf("cannot run a full dolt_gc() while cluster replication is enabled and role is %s; must be the primary", role.(string))
 			}
 			_, epoch, ok := sql.SystemVariables.GetGlobal(dsess.DoltClusterRoleEpochVariable)
 			if !ok {
-				return fmt.Errorf("internal error: cannot run a full dolt_gc(); cluster replication is enabled but could not read %s", dsess.DoltClusterRoleEpochVariable)
+				return nil, fmt.Errorf("internal error: cannot run a full dolt_gc(); cluster replication is enabled but could not read %s", dsess.DoltClusterRoleEpochVariable)
 			}
 			origepoch = epoch.(int)
 		}
@@ -335,5 +452,12 @@ func RunDoltGC(ctx *sql.Context, ddb *doltdb.DoltDB, mode types.GCMode, cmp chun
 			statsDoneCh: statsDoneCh,
 		}
 	}
-	return ddb.GC(ctx, mode, cmp, sc)
+	if !verbose {
+		return nil, ddb.GC(ctx, mode, cmp, sc)
+	}
+	timed := newTimedSafepointController(ctx, sc)
+	if err := ddb.GC(ctx, mode, cmp, timed); err != nil {
+		return nil, err
+	}
+	return timed.done(), nil
 }
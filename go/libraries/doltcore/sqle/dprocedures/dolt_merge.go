@@ -81,7 +81,7 @@ var doltMergeSchema = []*sql.Column{
 func doltMerge(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	commitHash, hasConflicts, ff, message, err := doDoltMerge(ctx, args)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err)
 	}
 	if message == "" {
 		return rowToIter(commitHash, int64(ff), int64(hasConflicts), nil), nil
@@ -206,7 +206,7 @@ func performMerge(
 	}
 
 	if len(spec.StompedTblNames) != 0 {
-		return ws, "", noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("error: local changes would be stomped by merge:\n\t%s\n Please commit your changes before you merge.", strings.Join(doltdb.FlattenTableNames(spec.StompedTblNames), "\n\t"))
+		return ws, "", noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("%w:\n\t%s\n Please commit your changes before you merge.", ErrLocalChangesStomped, strings.Join(doltdb.FlattenTableNames(spec.StompedTblNames), "\n\t"))
 	}
 
 	dbData, ok := sess.GetDbData(ctx, dbName)
@@ -271,7 +271,7 @@ func performMerge(
 		return ws, "", noConflictsOrViolations, threeWayMerge, "", sql.ErrDatabaseNotFound.New(dbName)
 	}
 
-	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
+	ws, err = executeMerge(ctx, sess, dbName, spec.Squash, spec.Force, spec.Strategy, spec.HeadC, spec.MergeC, spec.MergeCSpecStr, ws, dbState.EditOpts(), spec.WorkingDiffs)
 	if err == doltdb.ErrUnresolvedConflictsOrViolations {
 		// if there are unresolved conflicts, write the resulting working set back to the session and return an
 		// error message
@@ -313,6 +313,7 @@ func executeMerge(
 	dbName string,
 	squash bool,
 	force bool,
+	strategy merge.MergeStrategy,
 	head, cm *doltdb.Commit,
 	cmSpec string,
 	ws *doltdb.WorkingSet,
@@ -330,9 +331,34 @@ func executeMerge(
 			return nil, err
 		}
 	}
+	if strategy == merge.StrategyOurs || strategy == merge.StrategyTheirs {
+		result, err = resolveMergeConflicts(ctx, result, strategy == merge.StrategyOurs, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return mergeRootToWorking(ctx, sess, dbName, squash, force, ws, result, workingDiffs, cm, cmSpec)
 }
 
+// resolveMergeConflicts resolves the data conflicts of |result| with our or their version of each conflicting row.
+// Schema conflicts and constraint violations are left for the user to resolve.
+func resolveMergeConflicts(ctx *sql.Context, result *merge.Result, ours bool, opts editor.Options) (*merge.Result, error) {
+	root := result.Root
+	for tblName, stats := range result.Stats {
+		if !stats.HasDataConflicts() {
+			continue
+		}
+		var err error
+		root, err = resolveTableDataConflicts(ctx, opts, root, tblName, ours)
+		if err != nil {
+			return nil, err
+		}
+		stats.DataConflicts = 0
+	}
+	result.Root = root
+	return result, nil
+}
+
 func executeFFMerge(ctx *sql.Context, dbName string, squash bool, ws *doltdb.WorkingSet, dbData env.DbData[*sql.Context], cm2 *doltdb.Commit, spec *merge.MergeSpec) (*doltdb.WorkingSet, error) {
 	stagedRoot, err := cm2.GetRootValue(ctx)
 	if err != nil {
@@ -429,19 +455,22 @@ func executeNoFFMerge(
 		return ws.WithStagedRoot(roots.Staged), nil, nil
 	}
 
-	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, actions.CommitStagedProps{
+	props := actions.CommitStagedProps{
 		Message: msg,
 		Date:    spec.Date,
 		Force:   spec.Force,
-		Name:    spec.Name,
-		Email:   spec.Email,
-	})
+	}
+	if err = dsess.SetCommitIdentity(ctx, &props, fmt.Sprintf("%s <%s>", spec.Name, spec.Email)); err != nil {
+		return nil, nil, err
+	}
+
+	pendingCommit, err := dSess.NewPendingCommit(ctx, dbName, roots, props)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if pendingCommit == nil {
-		return nil, nil, errors.New("nothing to commit")
+		return nil, nil, ErrNothingToCommit
 	}
 
 	commit, err := dSess.DoltCommit(ctx, dbName, dSess.GetTransaction(), pendingCommit)
@@ -478,6 +507,15 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 	if apr.Contains(cli.NoCommitFlag) && apr.Contains(cli.CommitFlag) {
 		return nil, errors.New("cannot define both 'commit' and 'no-commit' flags at the same time")
 	}
+
+	strategy := merge.StrategyResolveCellWise
+	if strategyStr, ok := apr.GetValue(cli.StrategyParam); ok {
+		strategy, err = merge.ParseMergeStrategy(strategyStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return merge.NewMergeSpec(
 		ctx,
 		dbData.Rsr,
@@ -492,6 +530,7 @@ func createMergeSpec(ctx *sql.Context, sess *dsess.DoltSession, dbName string, a
 		merge.WithForce(apr.Contains(cli.ForceFlag)),
 		merge.WithNoCommit(apr.Contains(cli.NoCommitFlag)),
 		merge.WithNoEdit(apr.Contains(cli.NoEditFlag)),
+		merge.WithStrategy(strategy),
 	)
 }
 
@@ -503,7 +542,9 @@ func getNameAndEmail(ctx *sql.Context, apr *argparser.ArgParseResults) (string,
 		if err != nil {
 			return "", "", err
 		}
-	} else {
+	} else if name, email, err = dsess.SessionCommitAuthor(ctx); err != nil {
+		return "", "", err
+	} else if name == "" {
 		name = ctx.Client().User
 		email = fmt.Sprintf("%s@%s", ctx.Client().User, ctx.Client().Address)
 	}
@@ -0,0 +1,107 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"fmt"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/planbaseline"
+)
+
+// doltPlanBaseline pins the plan currently chosen for a query as the baseline for the query's digest, in the
+// dolt_plan_baselines table of the current database. Queries with the same digest then raise a warning if their plan
+// differs from the baseline, or fail with --enforce.
+func doltPlanBaseline(ctx *sql.Context, args ...string) (sql.RowIter, error) {
+	apr, err := cli.CreatePlanBaselineArgParser().Parse(args)
+	if err != nil {
+		return nil, err
+	}
+	if apr.NArg() != 1 {
+		return nil, fmt.Errorf("dolt_plan_baseline requires the query to pin the plan of")
+	}
+	query := apr.Arg(0)
+
+	dSess := dsess.DSessFromSess(ctx.Session)
+	engine := gms.NewDefault(dSess.Provider())
+	engine.Analyzer.Catalog.StatsProvider = dSess.StatsProvider()
+	index.AddDescendingIndexSortRule(engine.Analyzer)
+	index.AddSpatialIndexCostingRule(engine.Analyzer)
+	binder := planbuilder.New(ctx, engine.Analyzer.Catalog, engine.EventScheduler, engine.Parser)
+	parsed, _, _, qFlags, err := binder.Parse(query, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	analyzed, err := engine.Analyzer.Analyze(ctx, parsed, nil, qFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	b := planbaseline.NewBaseline(query, analyzed, apr.Contains("enforce"))
+	if err = writePlanBaseline(ctx, dSess, b); err != nil {
+		return nil, err
+	}
+	return rowToIter(b.Digest, b.Plan), nil
+}
+
+// writePlanBaseline writes |b| to the dolt_plan_baselines table of the current database, replacing any baseline for
+// the same digest.
+func writePlanBaseline(ctx *sql.Context, dSess *dsess.DoltSession, b planbaseline.Baseline) error {
+	dbName := ctx.GetCurrentDatabase()
+	if dbName == "" {
+		return sql.ErrNoDatabaseSelected.New()
+	}
+	db, err := dSess.Provider().Database(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	tbl, ok, err := db.GetTableInsensitive(ctx, doltdb.PlanBaselinesTableName)
+	if err != nil {
+		return err
+	}
+	rt, isReplaceable := tbl.(sql.ReplaceableTable)
+	if !ok || !isReplaceable {
+		return fmt.Errorf("%s is not writable in database %s", doltdb.PlanBaselinesTableName, dbName)
+	}
+
+	var enforced int8
+	if b.Enforced {
+		enforced = 1
+	}
+	row := sql.NewRow(b.Digest, b.DigestText, b.Plan, enforced)
+	replacer := rt.Replacer(ctx)
+	replacer.StatementBegin(ctx)
+	err = replacer.Delete(ctx, row)
+	if err == nil || sql.ErrDeleteRowNotFound.Is(err) {
+		err = replacer.Insert(ctx, row)
+	}
+	if err != nil {
+		_ = replacer.DiscardChanges(ctx, err)
+		_ = replacer.Close(ctx)
+		return err
+	}
+	if err = replacer.StatementComplete(ctx); err != nil {
+		_ = replacer.Close(ctx)
+		return err
+	}
+	return replacer.Close(ctx)
+}
@@ -61,7 +61,7 @@ var doltPullSchema = []*sql.Column{
 func doltPull(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	conflicts, ff, msg, err := doDoltPull(ctx, args)
 	if err != nil {
-		return nil, err
+		return nil, classifyError(err)
 	}
 
 	if msg == "" {
@@ -127,6 +127,13 @@ func doDoltPull(ctx *sql.Context, args []string) (int, int, string, error) {
 		})
 	}
 
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		pullSpec.Remote, err = pullSpec.Remote.WithMaxBandwidth(maxBandwidth)
+		if err != nil {
+			return noConflictsOrViolations, threeWayMerge, "", err
+		}
+	}
+
 	srcDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), pullSpec.Remote, false)
 	if err != nil {
 		return noConflictsOrViolations, threeWayMerge, "", fmt.Errorf("failed to get remote db; %w", err)
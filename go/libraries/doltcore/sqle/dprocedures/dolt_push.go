@@ -48,7 +48,7 @@ var doltPushSchema = []*sql.Column{
 // doltPush is the stored procedure version for the CLI command `dolt push`.
 func doltPush(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	res, message, err := doDoltPush(ctx, args)
-	return rowToIter(int64(res), message), err
+	return rowToIter(int64(res), message), classifyError(err)
 }
 
 func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
@@ -90,6 +90,14 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 		remote = &rmt
 	}
 
+	if maxBandwidth, ok := apr.GetValue(cli.MaxBandwidthFlag); ok {
+		rmt, err := (*remote).WithMaxBandwidth(maxBandwidth)
+		if err != nil {
+			return cmdFailure, "", err
+		}
+		remote = &rmt
+	}
+
 	remoteDB, err := sess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), *remote, true)
 	if err != nil {
 		return cmdFailure, "", actions.HandleInitRemoteStorageClientErr(remote.Name, remote.Url, err)
@@ -128,7 +136,7 @@ func doDoltPush(ctx *sql.Context, args []string) (int, string, error) {
 				// before the error message. We currently cannot return success message
 				// if there was a failed push with error. So, we need to include the success
 				// message in the error message before returning.
-				err = fmt.Errorf("%s\n%s", returnMsg, err.Error())
+				err = fmt.Errorf("%s\n%w", returnMsg, err)
 			}
 			return cmdFailure, "", err
 		}
@@ -39,7 +39,7 @@ func doltRemote(ctx *sql.Context, args ...string) (sql.RowIter, error) {
 	return rowToIter(int64(res)), nil
 }
 
-// doDoltRemote is used as sql dolt_remote command for only creating or deleting remotes, not listing.
+// doDoltRemote is used as sql dolt_remote command for creating, updating and deleting remotes, not listing.
 // To list remotes, dolt_remotes system table is used.
 func doDoltRemote(ctx *sql.Context, args []string) (int, error) {
 	dbName := ctx.GetCurrentDatabase()
@@ -71,6 +71,12 @@ func doDoltRemote(ctx *sql.Context, args []string) (int, error) {
 		err = addRemote(ctx, dbName, dbData, apr, dSess)
 	case "remove", "rm":
 		err = removeRemote(ctx, dbData, apr, &rsc)
+	case "rename":
+		err = renameRemote(ctx, dbData, apr, &rsc)
+	case "set-url":
+		err = setRemoteUrl(ctx, dbName, dbData, apr, dSess)
+	case "set":
+		err = setRemoteParams(ctx, dbData, apr)
 	default:
 		err = fmt.Errorf("error: invalid argument")
 	}
@@ -97,15 +103,169 @@ func addRemote(_ *sql.Context, dbName string, dbd env.DbData[*sql.Context], apr
 		return err
 	}
 
-	_, absRemoteUrl, err := env.GetAbsRemoteUrl(dbFs, &config.MapConfig{}, remoteUrl)
+	scheme, absRemoteUrl, err := env.GetAbsRemoteUrl(dbFs, &config.MapConfig{}, remoteUrl)
 	if err != nil {
 		return err
 	}
 
-	r := env.NewRemote(remoteName, absRemoteUrl, map[string]string{})
+	params, err := parseRemoteParams(apr, scheme, absRemoteUrl)
+	if err != nil {
+		return err
+	}
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+
+	r := env.NewRemote(remoteName, absRemoteUrl, params)
 	return dbd.Rsw.AddRemote(r)
 }
 
+// parseRemoteParams returns the params given by the options of |apr| for a remote at |remoteUrl|.
+func parseRemoteParams(apr *argparser.ArgParseResults, scheme, remoteUrl string) (map[string]string, error) {
+	params, err := cli.ProcessBackupArgs(apr, scheme, remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+	if err = cli.AddGRPCParams(remoteUrl, apr, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// updateRemote replaces the persisted remote named |name| with |r|.
+func updateRemote(ctx *sql.Context, dbd env.DbData[*sql.Context], name string, r env.Remote) error {
+	if err := dbd.Rsw.RemoveRemote(ctx, name); err != nil {
+		return err
+	}
+	return dbd.Rsw.AddRemote(r)
+}
+
+func getRemote(dbd env.DbData[*sql.Context], name string) (env.Remote, error) {
+	remotes, err := dbd.Rsr.GetRemotes()
+	if err != nil {
+		return env.Remote{}, err
+	}
+	remote, ok := remotes.Get(name)
+	if !ok {
+		return env.Remote{}, fmt.Errorf("error: unknown remote: '%s'", name)
+	}
+	return remote, nil
+}
+
+// setRemoteUrl changes the url of a remote, keeping its options.
+func setRemoteUrl(ctx *sql.Context, dbName string, dbd env.DbData[*sql.Context], apr *argparser.ArgParseResults, sess *dsess.DoltSession) error {
+	if apr.NArg() != 3 {
+		return fmt.Errorf("error: invalid argument")
+	}
+
+	remote, err := getRemote(dbd, strings.TrimSpace(apr.Arg(1)))
+	if err != nil {
+		return err
+	}
+
+	dbFs, err := sess.Provider().FileSystemForDatabase(dbName)
+	if err != nil {
+		return err
+	}
+	_, absRemoteUrl, err := env.GetAbsRemoteUrl(dbFs, &config.MapConfig{}, apr.Arg(2))
+	if err != nil {
+		return err
+	}
+
+	remote.Url = absRemoteUrl
+	return updateRemote(ctx, dbd, remote.Name, remote)
+}
+
+// setRemoteParams sets the options of a remote given by |apr|. An option given an empty value is removed.
+func setRemoteParams(ctx *sql.Context, dbd env.DbData[*sql.Context], apr *argparser.ArgParseResults) error {
+	if apr.NArg() != 2 {
+		return fmt.Errorf("error: invalid argument")
+	}
+
+	remote, err := getRemote(dbd, strings.TrimSpace(apr.Arg(1)))
+	if err != nil {
+		return err
+	}
+
+	scheme, _, _ := strings.Cut(remote.Url, "://")
+	params, err := parseRemoteParams(apr, scheme, remote.Url)
+	if err != nil {
+		return err
+	}
+	if len(params) == 0 {
+		return fmt.Errorf("error: no remote options given")
+	}
+
+	updated := make(map[string]string, len(remote.Params)+len(params))
+	for k, v := range remote.Params {
+		updated[k] = v
+	}
+	for k, v := range params {
+		if v == "" {
+			delete(updated, k)
+		} else {
+			updated[k] = v
+		}
+	}
+	remote.Params = updated
+	return updateRemote(ctx, dbd, remote.Name, remote)
+}
+
+// renameRemote renames a remote, along with its remote tracking refs.
+func renameRemote(ctx *sql.Context, dbd env.DbData[*sql.Context], apr *argparser.ArgParseResults, rsc *doltdb.ReplicationStatusController) error {
+	if apr.NArg() != 3 {
+		return fmt.Errorf("error: invalid argument")
+	}
+
+	remote, err := getRemote(dbd, strings.TrimSpace(apr.Arg(1)))
+	if err != nil {
+		return err
+	}
+	newName := strings.TrimSpace(apr.Arg(2))
+	remotes, err := dbd.Rsr.GetRemotes()
+	if err != nil {
+		return err
+	}
+	if _, ok := remotes.Get(newName); ok {
+		return env.ErrRemoteAlreadyExists
+	}
+
+	renamed := remote
+	renamed.Name = newName
+	renamed.FetchSpecs = make([]string, len(remote.FetchSpecs))
+	for i, spec := range remote.FetchSpecs {
+		renamed.FetchSpecs[i] = strings.ReplaceAll(spec, "refs/remotes/"+remote.Name+"/", "refs/remotes/"+newName+"/")
+	}
+	if err = updateRemote(ctx, dbd, remote.Name, renamed); err != nil {
+		return err
+	}
+
+	ddb := dbd.Ddb
+	refs, err := ddb.GetRemoteRefs(ctx)
+	if err != nil {
+		return fmt.Errorf("error: %w, cause: %s", env.ErrFailedToReadFromDb, err.Error())
+	}
+	for _, r := range refs {
+		rr := r.(ref.RemoteRef)
+		if rr.GetRemote() != remote.Name {
+			continue
+		}
+		cm, err := ddb.ResolveCommitRef(ctx, rr)
+		if err != nil {
+			return err
+		}
+		if err = ddb.SetHeadToCommit(ctx, ref.NewRemoteRef(newName, rr.GetBranch()), cm); err != nil {
+			return err
+		}
+		if err = ddb.DeleteBranch(ctx, rr, rsc); err != nil {
+			return fmt.Errorf("failed to delete remote tracking ref '%s'; %s", rr.String(), err.Error())
+		}
+	}
+	return nil
+}
+
 func removeRemote(ctx *sql.Context, dbd env.DbData[*sql.Context], apr *argparser.ArgParseResults, rsc *doltdb.ReplicationStatusController) error {
 	if apr.NArg() != 2 {
 		return fmt.Errorf("error: invalid argument")
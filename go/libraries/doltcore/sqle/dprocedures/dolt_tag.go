@@ -16,12 +16,18 @@ package dprocedures
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/gpg"
 )
 
 // doltTag is the stored procedure version for the CLI command `dolt tag`.
@@ -61,6 +67,13 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 		if apr.Contains(cli.MessageArg) {
 			return 1, fmt.Errorf("delete and tag message options are incompatible")
 		}
+		if remoteName, ok := apr.GetValue(cli.RemoteParam); ok {
+			err = deleteRemoteTags(ctx, dSess, dbData, remoteName, apr.Args)
+			if err != nil {
+				return 1, err
+			}
+			return 0, nil
+		}
 		err = actions.DeleteTagsOnDB(ctx, dbData.Ddb, apr.Args...)
 		if err != nil {
 			return 1, err
@@ -72,6 +85,9 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 	if len(apr.Args) > 2 {
 		return 1, fmt.Errorf("create tag takes at most two args")
 	}
+	if apr.Contains(cli.RemoteParam) {
+		return 1, fmt.Errorf("--remote is only supported when deleting tags")
+	}
 
 	var name, email string
 	if authorStr, ok := apr.GetValue(cli.AuthorParam); ok {
@@ -85,6 +101,9 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 	}
 
 	msg, _ := apr.GetValue(cli.MessageArg)
+	if apr.Contains(cli.AnnotateFlag) && strings.TrimSpace(msg) == "" {
+		return 1, fmt.Errorf("an annotated tag requires a message, given with -m")
+	}
 
 	props := actions.TagProps{
 		TaggerName:  name,
@@ -101,6 +120,23 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 	if err != nil {
 		return 0, err
 	}
+
+	if apr.Contains(cli.SignFlag) {
+		keyId, err := gpgSigningKey(ctx, apr)
+		if err != nil {
+			return 1, err
+		}
+		strToSign, err := tagSignatureStr(ctx, dbData.Ddb, dbName, tagName, startPoint, props, headRef)
+		if err != nil {
+			return 1, err
+		}
+		signature, err := gpg.Sign(ctx, keyId, []byte(strToSign))
+		if err != nil {
+			return 1, err
+		}
+		props.Signature = string(signature)
+	}
+
 	err = actions.CreateTagOnDB(ctx, dbData.Ddb, tagName, startPoint, props, headRef)
 	if err != nil {
 		return 1, err
@@ -108,3 +144,52 @@ func doDoltTag(ctx *sql.Context, args []string) (int, error) {
 
 	return 0, nil
 }
+
+// tagSignatureStr returns the string signed for the tag |tagName| of the commit |startPoint|.
+func tagSignatureStr(ctx *sql.Context, ddb *doltdb.DoltDB, dbName, tagName, startPoint string, props actions.TagProps, headRef ref.DoltRef) (string, error) {
+	cs, err := doltdb.NewCommitSpec(startPoint)
+	if err != nil {
+		return "", err
+	}
+	optCmt, err := ddb.Resolve(ctx, cs, headRef)
+	if err != nil {
+		return "", err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return "", doltdb.ErrGhostCommitEncountered
+	}
+	h, err := cm.HashOf()
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	lines = append(lines, fmt.Sprint("db: ", dbName))
+	lines = append(lines, fmt.Sprint("Tag: ", tagName))
+	lines = append(lines, fmt.Sprint("Commit: ", h.String()))
+	lines = append(lines, fmt.Sprint("Message: ", props.Description))
+	lines = append(lines, fmt.Sprint("Name: ", props.TaggerName))
+	lines = append(lines, fmt.Sprint("Email: ", props.TaggerEmail))
+	return strings.Join(lines, "\n"), nil
+}
+
+// deleteRemoteTags deletes the tags |tagNames| from the remote named |remoteName|.
+func deleteRemoteTags(ctx *sql.Context, dSess *dsess.DoltSession, dbData env.DbData[*sql.Context], remoteName string, tagNames []string) error {
+	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+	remote, err := getRemote(dbData, remoteName)
+	if err != nil {
+		return err
+	}
+	remoteDB, err := dSess.Provider().GetRemoteDB(ctx, dbData.Ddb.ValueReadWriter().Format(), remote, true)
+	if err != nil {
+		return actions.HandleInitRemoteStorageClientErr(remote.Name, remote.Url, err)
+	}
+	err = remoteDB.Rebase(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read latest version of remote database %s@%s: %w", remote.Name, remote.Url, err)
+	}
+	return actions.DeleteTagsOnDB(ctx, remoteDB, tagNames...)
+}
@@ -15,132 +15,159 @@
 package dprocedures
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/libraries/doltcore/branch_control"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/resolve"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/store/prolly"
 )
 
+// verifyConstraintsConcurrencyFlag limits the number of tables verified at the same time.
+const verifyConstraintsConcurrencyFlag = "concurrency"
+
+// verifyConstraintsSchema is the result of dolt_verify_constraints: one row per violated constraint.
+var verifyConstraintsSchema = sql.Schema{
+	&sql.Column{Name: "table", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "violation_type", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "constraint_name", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "violations", Type: types.Int64, Nullable: false},
+}
+
 // doltVerifyConstraints is the stored procedure version for the CLI command `dolt constraints verify`.
 func doltVerifyConstraints(ctx *sql.Context, args ...string) (sql.RowIter, error) {
-	res, err := doDoltConstraintsVerify(ctx, args)
+	rows, err := doDoltConstraintsVerify(ctx, args)
 	if err != nil {
 		return nil, err
 	}
-	return rowToIter(int64(res)), nil
+	return sql.RowsToRowIter(rows...), nil
 }
 
-func doDoltConstraintsVerify(ctx *sql.Context, args []string) (int, error) {
+func doDoltConstraintsVerify(ctx *sql.Context, args []string) ([]sql.Row, error) {
 	if err := branch_control.CheckAccess(ctx, branch_control.Permissions_Write); err != nil {
-		return 1, err
+		return nil, err
 	}
 
 	dbName := ctx.GetCurrentDatabase()
 	dSess := dsess.DSessFromSess(ctx.Session)
 	workingSet, err := dSess.WorkingSet(ctx, dbName)
 	if err != nil {
-		return 1, err
+		return nil, err
 	}
 	workingRoot := workingSet.WorkingRoot()
 	headCommit, err := dSess.GetHeadCommit(ctx, dbName)
 	if err != nil {
-		return 1, err
+		return nil, err
 	}
 
-	apr, err := cli.CreateVerifyConstraintsArgParser("doltVerifyConstraints").Parse(args)
+	ap := cli.CreateVerifyConstraintsArgParser("doltVerifyConstraints")
+	ap.SupportsInt(verifyConstraintsConcurrencyFlag, "", "n", "The maximum number of tables to verify at the same time. Defaults to the number of CPUs.")
+	apr, err := ap.Parse(args)
 	if err != nil {
-		return 1, err
+		return nil, err
 	}
 
 	verifyAll := apr.Contains(cli.AllFlag)
 	outputOnly := apr.Contains(cli.OutputOnlyFlag)
+	concurrency := apr.GetIntOrDefault(verifyConstraintsConcurrencyFlag, runtime.NumCPU())
+	if concurrency < 1 {
+		return nil, fmt.Errorf("--%s must be at least 1", verifyConstraintsConcurrencyFlag)
+	}
 
 	var comparingRoot doltdb.RootValue
 	if verifyAll {
 		comparingRoot, err = doltdb.EmptyRootValue(ctx, workingRoot.VRW(), workingRoot.NodeStore())
 		if err != nil {
-			return 1, err
+			return nil, err
 		}
 	} else {
 		comparingRoot, err = headCommit.GetRootValue(ctx)
 		if err != nil {
-			return 1, err
+			return nil, err
 		}
 	}
 
 	tableSet, err := parseTablesToCheck(ctx, workingRoot, apr)
 	if err != nil {
-		return 1, err
+		return nil, err
 	}
 
 	// Check for all non-FK constraint violations
-	newRoot, tablesWithViolations, err := calculateViolations(ctx, workingRoot, comparingRoot, tableSet)
+	newRoot, tablesWithViolations, err := calculateViolations(ctx, workingRoot, comparingRoot, tableSet, concurrency)
 	if err != nil {
-		return 1, err
+		return nil, err
 	}
 
 	if !outputOnly {
 		err = dSess.SetWorkingRoot(ctx, dbName, newRoot)
 		if err != nil {
-			return 1, err
+			return nil, err
 		}
 	}
 
-	if tablesWithViolations.Size() == 0 {
-		// no violations were found
-		return 0, nil
-	}
-
-	// TODO: We only return 1 or 0 to indicate if there were any constraint violations or not. This isn't
-	//       super useful to customers, and not how the CLI command works. It would be better to return
-	//       results that indicate the total number of violations found for the specified tables, and
-	//       potentially also a human readable message.
-	return 1, nil
+	return summarizeViolations(ctx, newRoot, tablesWithViolations)
 }
 
 // calculateViolations calculates all constraint violations between |workingRoot| and |comparingRoot| for the
-// tables in |tableSet|. Returns the new root with the violations, and a set of table names that have violations.
-// Note that constraint violations detected for ALL existing tables will be stored in the dolt_constraint_violations
-// tables, but the returned set of table names will be a subset of |tableSet|.
-func calculateViolations(ctx *sql.Context, workingRoot, comparingRoot doltdb.RootValue, tableSet *doltdb.TableNameSet) (doltdb.RootValue, *doltdb.TableNameSet, error) {
-	var recordViolationsForTables map[doltdb.TableName]struct{} = nil
-	if tableSet.Size() > 0 {
-		recordViolationsForTables = make(map[doltdb.TableName]struct{})
-		for _, table := range tableSet.AsSlice() {
-			recordViolationsForTables[table.ToLower()] = struct{}{}
-		}
-	}
-
-	mergeOpts := merge.MergeOpts{
-		IsCherryPick:              false,
-		KeepSchemaConflicts:       true,
-		ReverifyAllConstraints:    true,
-		RecordViolationsForTables: recordViolationsForTables,
-	}
-	mergeResults, err := merge.MergeRoots(ctx, comparingRoot, workingRoot, comparingRoot, workingRoot, comparingRoot,
-		editor.Options{}, mergeOpts)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error calculating constraint violations: %w", err)
+// tables in |tableSet|, verifying up to |concurrency| tables at the same time. Returns the new root with the
+// violations, and a set of table names that have violations.
+func calculateViolations(ctx *sql.Context, workingRoot, comparingRoot doltdb.RootValue, tableSet *doltdb.TableNameSet, concurrency int) (doltdb.RootValue, *doltdb.TableNameSet, error) {
+	tableNames := tableSet.AsSortedSlice()
+	tables := make([]*doltdb.Table, len(tableNames))
+
+	// Each table is verified by its own merge, which only records violations for that table. Tables that aren't
+	// being recorded short circuit in the merge, so the merges don't repeat each other's work.
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(concurrency)
+	for i, tableName := range tableNames {
+		eg.Go(func() error {
+			tblCtx := ctx.WithContext(egCtx)
+			mergeOpts := merge.MergeOpts{
+				IsCherryPick:              false,
+				KeepSchemaConflicts:       true,
+				ReverifyAllConstraints:    true,
+				RecordViolationsForTables: map[doltdb.TableName]struct{}{tableName.ToLower(): {}},
+			}
+			mergeResults, err := merge.MergeRoots(tblCtx, comparingRoot, workingRoot, comparingRoot, workingRoot, comparingRoot,
+				editor.Options{}, mergeOpts)
+			if err != nil {
+				return fmt.Errorf("error calculating constraint violations: %w", err)
+			}
+
+			table, ok, err := mergeResults.Root.GetTable(tblCtx, tableName)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("table %s not found", tableName)
+			}
+			tables[i] = table
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, nil, err
 	}
 
+	newRoot := workingRoot
 	tablesWithViolations := doltdb.NewTableNameSet(nil)
-	for _, tableName := range tableSet.AsSlice() {
-		table, ok, err := mergeResults.Root.GetTable(ctx, tableName)
-		if err != nil {
-			return nil, nil, err
-		}
-		if !ok {
-			return nil, nil, fmt.Errorf("table %s not found", tableName)
-		}
-		artifacts, err := table.GetArtifacts(ctx)
+	for i, tableName := range tableNames {
+		artifacts, err := tables[i].GetArtifacts(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -151,9 +178,98 @@ func calculateViolations(ctx *sql.Context, workingRoot, comparingRoot doltdb.Roo
 		if constraintViolationCount > 0 {
 			tablesWithViolations.Add(tableName)
 		}
+
+		newRoot, err = newRoot.PutTable(ctx, tableName, tables[i])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return newRoot, tablesWithViolations, nil
+}
+
+// summarizeViolations returns a row for each constraint violated in the tables of |tableSet|, with the number of
+// rows violating it.
+func summarizeViolations(ctx *sql.Context, root doltdb.RootValue, tableSet *doltdb.TableNameSet) ([]sql.Row, error) {
+	var rows []sql.Row
+	for _, tableName := range tableSet.AsSortedSlice() {
+		table, ok, err := root.GetTable(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("table %s not found", tableName)
+		}
+		artIdx, err := table.GetArtifacts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		iter, err := durable.ProllyMapFromArtifactIndex(artIdx).IterAllCVs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		type constraintKey struct{ violationType, name string }
+		counts := make(map[constraintKey]int64)
+		for {
+			art, err := iter.Next(ctx)
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			violationType, name, err := describeViolation(art)
+			if err != nil {
+				return nil, err
+			}
+			counts[constraintKey{violationType, name}]++
+		}
+
+		keys := make([]constraintKey, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].violationType != keys[j].violationType {
+				return keys[i].violationType < keys[j].violationType
+			}
+			return keys[i].name < keys[j].name
+		})
+		for _, k := range keys {
+			rows = append(rows, sql.Row{tableName.String(), k.violationType, k.name, counts[k]})
+		}
+	}
+	return rows, nil
+}
+
+// describeViolation returns the violation type and the name of the violated constraint of the constraint violation
+// artifact |art|. Not null violations are named by the columns that were null.
+func describeViolation(art prolly.Artifact) (string, string, error) {
+	var meta prolly.ConstraintViolationMeta
+	if err := json.Unmarshal(art.Metadata, &meta); err != nil {
+		return "", "", err
 	}
 
-	return mergeResults.Root, tablesWithViolations, nil
+	switch art.ArtType {
+	case prolly.ArtifactTypeForeignKeyViol:
+		var m merge.FkCVMeta
+		err := json.Unmarshal(meta.VInfo, &m)
+		return "foreign key", m.ForeignKey, err
+	case prolly.ArtifactTypeUniqueKeyViol:
+		var m merge.UniqCVMeta
+		err := json.Unmarshal(meta.VInfo, &m)
+		return "unique index", m.Name, err
+	case prolly.ArtifactTypeChkConsViol:
+		var m merge.CheckCVMeta
+		err := json.Unmarshal(meta.VInfo, &m)
+		return "check constraint", m.Name, err
+	case prolly.ArtifactTypeNullViol:
+		var m merge.NullViolationMeta
+		err := json.Unmarshal(meta.VInfo, &m)
+		return "not null", strings.Join(m.Columns, ","), err
+	default:
+		return "", "", fmt.Errorf("unknown constraint violation type %d", art.ArtType)
+	}
 }
 
 // parseTablesToCheck returns a set of table names to check for constraint violations. If no tables are specified, then
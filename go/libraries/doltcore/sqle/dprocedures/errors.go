@@ -0,0 +1,123 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dprocedures
+
+import (
+	"errors"
+
+	"github.com/dolthub/vitess/go/mysql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// ErrNothingToCommit is returned when a commit is requested but there are no staged changes to commit.
+var ErrNothingToCommit = errors.New("nothing to commit")
+
+// ErrLocalChangesStomped is returned when a merge would overwrite uncommitted changes to tables it modifies.
+var ErrLocalChangesStomped = errors.New("error: local changes would be stomped by merge")
+
+// The error numbers and SQLSTATEs returned by dolt_commit, dolt_merge, dolt_push and dolt_pull for errors which clients
+// may want to handle, so that they can branch on the class of an error rather than on its message. The numbers are in
+// the range MySQL reserves for third parties, and the SQLSTATEs use the implementation defined class XD. They are
+// stable, and new classes are only ever added at the end. Errors which don't belong to any of these classes are returned
+// with MySQL's ER_UNKNOWN_ERROR (1105) and SQLSTATE HY000, as before.
+//
+//	Number  SQLSTATE  Class
+//	50001   XD001     Nothing to commit: there are no staged changes, and --allow-empty was not given.
+//	50002   XD002     Conflicts present: the merge has unresolved conflicts or constraint violations.
+//	50003   XD003     Merge in progress: a merge must be committed or aborted before another can begin.
+//	50004   XD004     Non-fast-forward: the remote branch has commits that the local branch doesn't, so a push
+//	                  needs --force, or the branch must be merged first.
+//	50005   XD005     Uncommitted changes: the working set has changes which the operation would overwrite.
+//	50006   XD006     Remote not found: no remote with the given name is configured.
+//	50007   XD007     Branch not found: the branch to merge, push or pull doesn't exist.
+const (
+	ErrCodeNothingToCommit     = 50001
+	ErrCodeConflictsPresent    = dsess.ErrCodeConflictsPresent
+	ErrCodeMergeInProgress     = 50003
+	ErrCodeNonFastForward      = 50004
+	ErrCodeUncommittedChanges  = 50005
+	ErrCodeRemoteNotFound      = 50006
+	ErrCodeBranchNotFound      = 50007
+	sqlStateNothingToCommit    = "XD001"
+	sqlStateConflictsPresent   = dsess.SqlStateConflictsPresent
+	sqlStateMergeInProgress    = "XD003"
+	sqlStateNonFastForward     = "XD004"
+	sqlStateUncommittedChanges = "XD005"
+	sqlStateRemoteNotFound     = "XD006"
+	sqlStateBranchNotFound     = "XD007"
+)
+
+type errorClass struct {
+	code     int
+	sqlState string
+	is       func(error) bool
+}
+
+func isAny(targets ...error) func(error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+var errorClasses = []errorClass{
+	{ErrCodeNothingToCommit, sqlStateNothingToCommit, isAny(ErrNothingToCommit)},
+	{ErrCodeConflictsPresent, sqlStateConflictsPresent, isAny(doltdb.ErrUnresolvedConflictsOrViolations,
+		dsess.ErrUnresolvedConflictsCommit, dsess.ErrUnresolvedConflictsAutoCommit, dsess.ErrUnresolvedConstraintViolationsCommit)},
+	{ErrCodeMergeInProgress, sqlStateMergeInProgress, isAny(doltdb.ErrMergeActive)},
+	{ErrCodeNonFastForward, sqlStateNonFastForward, func(err error) bool {
+		return env.ErrFailedToPush.Is(err) || isAny(datas.ErrMergeNeeded, actions.ErrCantFF)(err)
+	}},
+	{ErrCodeUncommittedChanges, sqlStateUncommittedChanges, func(err error) bool {
+		return ErrUncommittedChanges.Is(err) || isAny(ErrLocalChangesStomped)(err)
+	}},
+	{ErrCodeRemoteNotFound, sqlStateRemoteNotFound, func(err error) bool {
+		return env.ErrInvalidRepository.Is(err) || isAny(env.ErrRemoteNotFound, env.ErrUnknownRemote)(err)
+	}},
+	{ErrCodeBranchNotFound, sqlStateBranchNotFound, isAny(doltdb.ErrBranchNotFound)},
+}
+
+// classifyError returns |err| as a *mysql.SQLError with the error number and SQLSTATE of its class, which is what
+// the server sends to clients, or returns |err| unchanged if it doesn't belong to any class.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var sqlErr *mysql.SQLError
+	if errors.As(err, &sqlErr) {
+		return err
+	}
+	for _, class := range errorClasses {
+		if class.is(err) {
+			return mysql.NewSQLError(class.code, class.sqlState, "%s", err.Error())
+		}
+	}
+	return err
+}
+
+// HasErrorCode returns whether |err| was returned by a dolt procedure with the error number |code|.
+func HasErrorCode(err error, code int) bool {
+	var sqlErr *mysql.SQLError
+	return errors.As(err, &sqlErr) && sqlErr.Num == code
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	gosql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// ErrArgsWithMultiStatements is returned for queries of several statements with arguments, since it's ambiguous which
+// statements they belong to.
+var ErrArgsWithMultiStatements = errors.New("arguments are not supported in queries of several statements")
+
+var errEmptyQuery = errors.New("query was empty")
+
+// Conn is a connection to an embedded Dolt engine, with its own session.
+type Conn struct {
+	connector     *Connector
+	session       sql.Session
+	ownsConnector bool
+}
+
+var _ driver.Conn = (*Conn)(nil)
+var _ driver.ConnPrepareContext = (*Conn)(nil)
+var _ driver.ConnBeginTx = (*Conn)(nil)
+var _ driver.ExecerContext = (*Conn)(nil)
+var _ driver.QueryerContext = (*Conn)(nil)
+
+// Prepare implements driver.Conn.
+func (c *Conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext implements driver.ConnPrepareContext.
+func (c *Conn) PrepareContext(_ context.Context, query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+// Close implements driver.Conn. It ends the session of this connection, rolling back any transaction it has begun.
+func (c *Conn) Close() error {
+	sql.SessionEnd(c.session)
+	if c.ownsConnector {
+		return c.connector.Close()
+	}
+	return nil
+}
+
+// Begin implements driver.Conn.
+func (c *Conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx implements driver.ConnBeginTx. Dolt transactions are always REPEATABLE READ.
+func (c *Conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	switch level := gosql.IsolationLevel(opts.Isolation); level {
+	case gosql.LevelDefault, gosql.LevelRepeatableRead:
+	default:
+		return nil, fmt.Errorf("isolation level %s is not supported", level)
+	}
+	query := "START TRANSACTION"
+	if opts.ReadOnly {
+		query += " READ ONLY"
+	}
+	if _, err := c.ExecContext(ctx, query, nil); err != nil {
+		return nil, err
+	}
+	return &tx{conn: c}, nil
+}
+
+// ExecContext implements driver.ExecerContext. The result of a query of several statements is the sum of their
+// affected rows, and the last insert id of the last of them that inserted one.
+func (c *Conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	bindings, err := namedValuesToBindings(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var res result
+	for first := true; first || query != ""; first = false {
+		var rs *rows
+		rs, query, err = c.run(ctx, query, bindings)
+		if err != nil {
+			return nil, err
+		}
+		if rs == nil && first {
+			return nil, errEmptyQuery
+		} else if rs == nil {
+			break
+		}
+		if err = rs.drain(&res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// QueryContext implements driver.QueryerContext. Each statement of a query of several statements is a result set of
+// the rows returned.
+func (c *Conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	bindings, err := namedValuesToBindings(args)
+	if err != nil {
+		return nil, err
+	}
+	rs, _, err := c.run(ctx, query, bindings)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, errEmptyQuery
+	}
+	return rs, nil
+}
+
+// run starts the first statement of |query|, and returns its rows and the remaining statements. The rows are nil if
+// |query| has no statements left.
+func (c *Conn) run(ctx context.Context, query string, bindings map[string]sqlparser.Expr) (*rows, string, error) {
+	se := c.connector.se
+	multi := c.connector.ds.MultiStatements
+	sqlCtx := se.ContextFactory(ctx, sql.WithSession(c.session))
+
+	parsed, stmtStr, remainder, err := sql.GlobalParser.Parse(sqlCtx, query, multi)
+	if errors.Is(err, sqlparser.ErrEmpty) {
+		return nil, "", nil
+	} else if err != nil {
+		return nil, "", sql.ErrSyntaxError.New(err.Error())
+	}
+	if len(bindings) > 0 && strings.TrimSpace(remainder) != "" {
+		return nil, "", ErrArgsWithMultiStatements
+	}
+
+	if err = sql.SessionCommandBegin(c.session); err != nil {
+		return nil, "", err
+	}
+	sqlCtx = sqlCtx.WithQuery(stmtStr)
+	sch, iter, _, err := se.QueryWithBindings(sqlCtx, stmtStr, parsed, bindings, nil)
+	if err != nil {
+		sql.SessionCommandEnd(c.session)
+		return nil, "", err
+	}
+	rs := &rows{conn: c, ctx: sqlCtx, sch: sch, iter: iter, remainder: remainder}
+	return rs, remainder, nil
+}
+
+type tx struct {
+	conn *Conn
+}
+
+var _ driver.Tx = (*tx)(nil)
+
+// Commit implements driver.Tx.
+func (t *tx) Commit() error {
+	_, err := t.conn.ExecContext(context.Background(), "COMMIT", nil)
+	return err
+}
+
+// Rollback implements driver.Tx.
+func (t *tx) Rollback() error {
+	_, err := t.conn.ExecContext(context.Background(), "ROLLBACK", nil)
+	return err
+}
+
+// stmt is a statement prepared by Conn.Prepare. It's parsed each time it's run, so that statements which change the
+// schema of the tables it reads don't invalidate it.
+type stmt struct {
+	conn  *Conn
+	query string
+}
+
+var _ driver.Stmt = (*stmt)(nil)
+var _ driver.StmtExecContext = (*stmt)(nil)
+var _ driver.StmtQueryContext = (*stmt)(nil)
+
+// Close implements driver.Stmt.
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput implements driver.Stmt. The number of placeholders isn't known until the statement is run.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+// Exec implements driver.Stmt.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamedValues(args))
+}
+
+// Query implements driver.Stmt.
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamedValues(args))
+}
+
+// ExecContext implements driver.StmtExecContext.
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+// QueryContext implements driver.StmtQueryContext.
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func valuesToNamedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// namedValuesToBindings returns the bindings of the placeholders of a query for |args|. Positional placeholders are
+// bound by the engine as v1, v2 and so on.
+func namedValuesToBindings(args []driver.NamedValue) (map[string]sqlparser.Expr, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	bindings := make(map[string]sqlparser.Expr, len(args))
+	for _, arg := range args {
+		name := arg.Name
+		if name == "" {
+			name = "v" + strconv.Itoa(arg.Ordinal)
+		}
+		val := arg.Value
+		if t, ok := val.(time.Time); ok {
+			val = t.Format(time.RFC3339Nano)
+		}
+		bv, err := sqltypes.BuildBindVariable(val)
+		if err != nil {
+			return nil, err
+		}
+		v, err := sqltypes.BindVariableToValue(bv)
+		if err != nil {
+			return nil, err
+		}
+		if bindings[name], err = sqlparser.ExprFromValue(v); err != nil {
+			return nil, err
+		}
+	}
+	return bindings, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/commands/engine"
+	"github.com/dolthub/dolt/go/cmd/dolt/doltversion"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/servercfg"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/utils/config"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	// connections are local to the process, so they connect as a superuser, like the dolt sql shell
+	localUser = "root"
+	localHost = "localhost"
+)
+
+// Connector is a driver.Connector for a data source. It runs the engine which its connections share, which is closed
+// by Close.
+type Connector struct {
+	driver   *Driver
+	ds       DataSource
+	se       *engine.SqlEngine
+	database string
+}
+
+var _ driver.Connector = (*Connector)(nil)
+
+func newConnector(ctx context.Context, d *Driver, ds DataSource) (*Connector, error) {
+	fs, err := filesys.LocalFilesysWithWorkingDir(ds.Directory)
+	if err != nil {
+		return nil, err
+	}
+	cfgDir, err := fs.Abs(servercfg.DefaultCfgDir)
+	if err != nil {
+		return nil, err
+	}
+
+	props := make(map[string]string)
+	if ds.CommitName != "" {
+		props[config.UserNameKey] = ds.CommitName
+	}
+	if ds.CommitEmail != "" {
+		props[config.UserEmailKey] = ds.CommitEmail
+	}
+
+	mrEnv, err := env.MultiEnvForDirectory(ctx, config.NewMapConfig(props), fs, doltversion.Version, nil)
+	if err != nil {
+		return nil, err
+	}
+	se, err := engine.NewSqlEngine(ctx, mrEnv, &engine.SqlEngineConfig{
+		IsReadOnly:         ds.ReadOnly,
+		DoltCfgDirPath:     cfgDir,
+		PrivFilePath:       filepath.Join(cfgDir, servercfg.DefaultPrivilegeFilePath),
+		BranchCtrlFilePath: filepath.Join(cfgDir, servercfg.DefaultBranchControlFilePath),
+		ServerUser:         localUser,
+		ServerHost:         localHost,
+		Autocommit:         true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = se.InitStats(ctx); err != nil {
+		se.Close()
+		return nil, err
+	}
+
+	mysqlDb := se.GetUnderlyingEngine().Analyzer.Catalog.MySQLDb
+	ed := mysqlDb.Editor()
+	mysqlDb.AddEphemeralSuperUser(ed, localUser, localHost, "")
+	ed.Close()
+
+	return &Connector{
+		driver:   d,
+		ds:       ds,
+		se:       se,
+		database: ds.revisionDatabase(mrEnv.GetFirstDatabase()),
+	}, nil
+}
+
+// Connect implements driver.Connector. Each connection is a new session, which starts in the database, branch or
+// commit of the data source.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	sqlCtx, err := c.se.NewDefaultContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sqlCtx.Session.SetClient(sql.Client{User: localUser, Address: localHost, Capabilities: 0})
+
+	if c.ds.CommitName != "" {
+		// the session's commits are authored and committed by the data source's identity rather than its SQL user
+		identity := fmt.Sprintf("%s <%s>", c.ds.CommitName, c.ds.CommitEmail)
+		for _, name := range []string{dsess.DoltCommitAuthor, dsess.DoltCommitCommitter} {
+			if err = sqlCtx.SetSessionVariable(sqlCtx, name, identity); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	conn := &Conn{connector: c, session: sqlCtx.Session}
+	if c.database != "" {
+		// USE resolves the branch or commit, so that a data source which names one that doesn't exist fails here
+		if _, err = conn.ExecContext(ctx, "USE `"+strings.ReplaceAll(c.database, "`", "``")+"`", nil); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// Driver implements driver.Connector.
+func (c *Connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// Close closes the engine of this connector. database/sql calls it when the *sql.DB it was opened for is closed.
+func (c *Connector) Close() error {
+	// the engine cancels its background threads when it shuts them down, and reports that as an error
+	if err := c.se.Close(); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package driver is a database/sql driver which runs Dolt embedded in the application, without a sql-server or the
+// dolt CLI. It's registered as "dolt":
+//
+//	db, err := sql.Open("dolt", "file:///path/to/dbs?commitname=Jane&commitemail=jane@example.com&database=mydb&branch=feature")
+//
+// The directory of the data source name is a Dolt database, or a directory of Dolt databases, like the data dir of a
+// sql-server. Its parameters are described by DataSource.
+//
+// Concurrency: a *sql.DB opened with this driver runs one embedded engine for its directory, which is shared by every
+// connection in its pool, and closed by (*sql.DB).Close. Each connection is a separate session, with its own current
+// database and branch, session variables and transaction, just like a connection to a sql-server, so connections may
+// be used concurrently and their transactions are isolated and merged on commit in the same way. A single connection
+// must not be used concurrently, which database/sql ensures. Since a USE statement changes the database of the pooled
+// connection it ran on, use a *sql.Conn to run statements which depend on session state. A directory must only be
+// opened by one *sql.DB, and no sql-server, at a time: open it once and share the *sql.DB.
+package driver
+
+import (
+	"context"
+	gosql "database/sql"
+	"database/sql/driver"
+)
+
+// DriverName is the name this driver is registered with database/sql as.
+const DriverName = "dolt"
+
+func init() {
+	gosql.Register(DriverName, &Driver{})
+}
+
+// Driver is the database/sql driver for embedded Dolt databases.
+type Driver struct{}
+
+var _ driver.Driver = (*Driver)(nil)
+var _ driver.DriverContext = (*Driver)(nil)
+
+// Open implements driver.Driver. It starts an engine for the single connection it returns, which is closed with the
+// connection. database/sql calls OpenConnector instead, so that the connections of a *sql.DB share their engine.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.openConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.Connect(context.Background())
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	conn.(*Conn).ownsConnector = true
+	return conn, nil
+}
+
+// OpenConnector implements driver.DriverContext.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	c, err := d.openConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (d *Driver) openConnector(dsn string) (*Connector, error) {
+	ds, err := ParseDataSource(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return newConnector(context.Background(), d, ds)
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	gosql "database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDataSource(t *testing.T) {
+	tests := []struct {
+		dsn      string
+		expected DataSource
+		err      string
+	}{
+		{
+			dsn:      "file:///var/lib/dolt",
+			expected: DataSource{Directory: filepath.FromSlash("/var/lib/dolt")},
+		},
+		{
+			dsn:      "file://./dbs?database=mydb&branch=feature&commitname=Jane&commitemail=jane@example.com",
+			expected: DataSource{Directory: filepath.FromSlash("./dbs"), Database: "mydb", Branch: "feature", CommitName: "Jane", CommitEmail: "jane@example.com"},
+		},
+		{
+			dsn:      "file:///dbs?commit=v1&multistatements=true&readonly=1",
+			expected: DataSource{Directory: filepath.FromSlash("/dbs"), Commit: "v1", MultiStatements: true, ReadOnly: true},
+		},
+		{dsn: "/var/lib/dolt", err: "expected a file:// url"},
+		{dsn: "file:///dbs?branch=main&commit=v1", err: "only one of 'branch' and 'commit'"},
+		{dsn: "file:///dbs?commitname=Jane", err: "'commitname' and 'commitemail' must be given together"},
+		{dsn: "file:///dbs?multistatements=yes", err: "parameter 'multistatements' must be true or false"},
+		{dsn: "file:///dbs?user=root", err: "unknown parameter 'user'"},
+	}
+	for _, test := range tests {
+		t.Run(test.dsn, func(t *testing.T) {
+			ds, err := ParseDataSource(test.dsn)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ds)
+		})
+	}
+}
+
+func TestRevisionDatabase(t *testing.T) {
+	assert.Equal(t, "first", DataSource{}.revisionDatabase("first"))
+	assert.Equal(t, "mydb", DataSource{Database: "mydb"}.revisionDatabase("first"))
+	assert.Equal(t, "mydb/feature", DataSource{Database: "mydb", Branch: "feature"}.revisionDatabase("first"))
+	assert.Equal(t, "first/v1", DataSource{Commit: "v1"}.revisionDatabase("first/main"))
+	assert.Equal(t, "", DataSource{Branch: "feature"}.revisionDatabase(""))
+}
+
+func TestDriver(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dsn := "file://" + filepath.ToSlash(dir) + "?commitname=Jane&commitemail=jane@example.com"
+
+	db, err := gosql.Open(DriverName, dsn)
+	require.NoError(t, err)
+	exec(t, db, "create database testdb")
+	require.NoError(t, db.Close())
+
+	db, err = gosql.Open(DriverName, dsn+"&database=testdb")
+	require.NoError(t, err)
+	exec(t, db, "create table t (id int primary key auto_increment, name varchar(20), created datetime)")
+	res, err := db.ExecContext(ctx, "insert into t (name, created) values (?, ?), (?, ?)", "a", "2025-01-02 03:04:05", "b", nil)
+	require.NoError(t, err)
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+	exec(t, db, "call dolt_commit('-Am', 'add t')")
+	exec(t, db, "call dolt_tag('v1')")
+	exec(t, db, "call dolt_branch('feature')")
+
+	var name string
+	var author string
+	require.NoError(t, db.QueryRowContext(ctx, "select name from t where id = ?", 1).Scan(&name))
+	assert.Equal(t, "a", name)
+	require.NoError(t, db.QueryRowContext(ctx, "select committer from dolt_log limit 1").Scan(&author))
+	assert.Equal(t, "Jane", author)
+
+	t.Run("transactions", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		_, err = tx.ExecContext(ctx, "insert into t (name) values ('c')")
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+		assert.Equal(t, 2, count(t, db, "select count(*) from t"))
+
+		_, err = db.BeginTx(ctx, &gosql.TxOptions{Isolation: gosql.LevelSerializable})
+		assert.ErrorContains(t, err, "isolation level Serializable is not supported")
+	})
+
+	t.Run("sessions are isolated", func(t *testing.T) {
+		conn1, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn1.Close()
+		conn2, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn2.Close()
+
+		_, err = conn1.ExecContext(ctx, "call dolt_checkout('feature')")
+		require.NoError(t, err)
+		_, err = conn1.ExecContext(ctx, "insert into t (name) values ('on feature')")
+		require.NoError(t, err)
+
+		var branch string
+		require.NoError(t, conn1.QueryRowContext(ctx, "select active_branch()").Scan(&branch))
+		assert.Equal(t, "feature", branch)
+		require.NoError(t, conn2.QueryRowContext(ctx, "select active_branch()").Scan(&branch))
+		assert.Equal(t, "main", branch)
+		assert.Equal(t, 2, count(t, conn2, "select count(*) from t"))
+
+		// closing a *sql.Conn returns it to the pool with its session, so switch back for the statements that follow
+		_, err = conn1.ExecContext(ctx, "call dolt_checkout('main')")
+		require.NoError(t, err)
+	})
+
+	t.Run("multiple statements need multistatements", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "insert into t (name) values ('c'); insert into t (name) values ('d')")
+		assert.Error(t, err)
+		assert.Equal(t, 2, count(t, db, "select count(*) from t"))
+	})
+	require.NoError(t, db.Close())
+
+	t.Run("branch", func(t *testing.T) {
+		db, err := gosql.Open(DriverName, dsn+"&database=testdb&branch=feature")
+		require.NoError(t, err)
+		defer db.Close()
+		assert.Equal(t, 3, count(t, db, "select count(*) from t"))
+		var branch string
+		require.NoError(t, db.QueryRowContext(ctx, "select active_branch()").Scan(&branch))
+		assert.Equal(t, "feature", branch)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		db, err := gosql.Open(DriverName, dsn+"&database=testdb&commit=v1")
+		require.NoError(t, err)
+		defer db.Close()
+		assert.Equal(t, 2, count(t, db, "select count(*) from t"))
+		_, err = db.ExecContext(ctx, "insert into t (name) values ('c')")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing branch", func(t *testing.T) {
+		db, err := gosql.Open(DriverName, dsn+"&database=testdb&branch=nosuchbranch")
+		require.NoError(t, err)
+		defer db.Close()
+		assert.Error(t, db.PingContext(ctx))
+	})
+
+	t.Run("multistatements", func(t *testing.T) {
+		db, err := gosql.Open(DriverName, dsn+"&database=testdb&multistatements=true")
+		require.NoError(t, err)
+		defer db.Close()
+
+		res, err := db.ExecContext(ctx, "insert into t (name) values ('c'); insert into t (name) values ('d'), ('e');")
+		require.NoError(t, err)
+		affected, err := res.RowsAffected()
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), affected)
+
+		rows, err := db.QueryContext(ctx, "select count(*) from t; select name from t where id = 1")
+		require.NoError(t, err)
+		var n int
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&n))
+		assert.Equal(t, 5, n)
+		require.True(t, rows.NextResultSet())
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&name))
+		assert.Equal(t, "a", name)
+		assert.False(t, rows.NextResultSet())
+		require.NoError(t, rows.Close())
+
+		_, err = db.ExecContext(ctx, "select ?; select ?", 1, 2)
+		assert.ErrorIs(t, err, ErrArgsWithMultiStatements)
+	})
+}
+
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *gosql.Row
+}
+
+func exec(t *testing.T, db *gosql.DB, query string) {
+	_, err := db.ExecContext(context.Background(), query)
+	require.NoError(t, err)
+}
+
+func count(t *testing.T, q queryer, query string) int {
+	var n int
+	require.NoError(t, q.QueryRowContext(context.Background(), query).Scan(&n))
+	return n
+}
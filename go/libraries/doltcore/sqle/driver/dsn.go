@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strconv"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const (
+	// DatabaseParam is the database connections start in. It defaults to the database of the directory, or the
+	// first database in it.
+	DatabaseParam = "database"
+	// BranchParam is the branch of the database connections start on. It defaults to the default branch.
+	BranchParam = "branch"
+	// CommitParam is a commit hash or tag of the database which connections start on, read only.
+	CommitParam = "commit"
+	// CommitNameParam is the name of the author and committer of the commits made by connections.
+	CommitNameParam = "commitname"
+	// CommitEmailParam is the email of the author and committer of the commits made by connections.
+	CommitEmailParam = "commitemail"
+	// MultiStatementsParam allows queries of several statements separated by semicolons, if it's true.
+	MultiStatementsParam = "multistatements"
+	// ReadOnlyParam makes every database read only, if it's true.
+	ReadOnlyParam = "readonly"
+)
+
+// DataSource is a parsed data source name of this driver, which is a file URL of a directory with parameters:
+//
+//	file:///path/to/dbs?database=mydb&branch=feature&commitname=Jane&commitemail=jane@example.com&multistatements=true
+//
+// A relative directory is written as file://./path/to/dbs.
+type DataSource struct {
+	Directory       string
+	Database        string
+	Branch          string
+	Commit          string
+	CommitName      string
+	CommitEmail     string
+	MultiStatements bool
+	ReadOnly        bool
+}
+
+// ParseDataSource parses the data source name |dsn|.
+func ParseDataSource(dsn string) (DataSource, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return DataSource{}, err
+	}
+	if u.Scheme != "file" {
+		return DataSource{}, fmt.Errorf("invalid data source name '%s': expected a file:// url", dsn)
+	}
+	ds := DataSource{Directory: filepath.FromSlash(u.Host + u.Path)}
+	if ds.Directory == "" {
+		return DataSource{}, fmt.Errorf("invalid data source name '%s': no directory", dsn)
+	}
+
+	for name, vals := range u.Query() {
+		val := vals[len(vals)-1]
+		switch name {
+		case DatabaseParam:
+			ds.Database = val
+		case BranchParam:
+			ds.Branch = val
+		case CommitParam:
+			ds.Commit = val
+		case CommitNameParam:
+			ds.CommitName = val
+		case CommitEmailParam:
+			ds.CommitEmail = val
+		case MultiStatementsParam:
+			ds.MultiStatements, err = strconv.ParseBool(val)
+		case ReadOnlyParam:
+			ds.ReadOnly, err = strconv.ParseBool(val)
+		default:
+			return DataSource{}, fmt.Errorf("invalid data source name '%s': unknown parameter '%s'", dsn, name)
+		}
+		if err != nil {
+			return DataSource{}, fmt.Errorf("invalid data source name '%s': parameter '%s' must be true or false", dsn, name)
+		}
+	}
+
+	if ds.Branch != "" && ds.Commit != "" {
+		return DataSource{}, fmt.Errorf("invalid data source name '%s': only one of '%s' and '%s' may be given", dsn, BranchParam, CommitParam)
+	}
+	if (ds.CommitName == "") != (ds.CommitEmail == "") {
+		return DataSource{}, fmt.Errorf("invalid data source name '%s': '%s' and '%s' must be given together", dsn, CommitNameParam, CommitEmailParam)
+	}
+	return ds, nil
+}
+
+// revisionDatabase returns the name of the database connections start in, which is a revision database of |db| if a
+// branch or commit is given.
+func (ds DataSource) revisionDatabase(db string) string {
+	if ds.Database != "" {
+		db = ds.Database
+	}
+	if db == "" {
+		return ""
+	}
+	rev := ds.Branch
+	if rev == "" {
+		rev = ds.Commit
+	}
+	if rev == "" {
+		return db
+	}
+	base, _ := dsess.SplitRevisionDbName(db)
+	return dsess.RevisionDbName(base, rev)
+}
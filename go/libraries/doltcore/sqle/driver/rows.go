@@ -0,0 +1,192 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/sqltypes"
+)
+
+// rows are the rows returned by a statement. Rows of queries of several statements have a result set for each
+// statement, which is run when the rows move on to it. Closing the rows runs the statements which are left, so that
+// every statement of a query is run, as with a MySQL server.
+type rows struct {
+	conn      *Conn
+	ctx       *sql.Context
+	sch       sql.Schema
+	iter      sql.RowIter
+	remainder string
+	closed    bool
+}
+
+var _ driver.Rows = (*rows)(nil)
+var _ driver.RowsNextResultSet = (*rows)(nil)
+
+// Columns implements driver.Rows.
+func (r *rows) Columns() []string {
+	if types.IsOkResultSchema(r.sch) {
+		return nil
+	}
+	names := make([]string, len(r.sch))
+	for i, col := range r.sch {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// Next implements driver.Rows.
+func (r *rows) Next(dest []driver.Value) error {
+	for {
+		row, err := r.iter.Next(r.ctx)
+		if err != nil {
+			return err
+		}
+		if types.IsOkResult(row) {
+			continue
+		}
+		for i := range dest {
+			if dest[i], err = toDriverValue(r.ctx, r.sch[i].Type, row[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Close implements driver.Rows.
+func (r *rows) Close() error {
+	err := r.closeResultSet()
+	for err == nil && r.HasNextResultSet() {
+		var next *rows
+		next, r.remainder, err = r.conn.run(r.ctx, r.remainder, nil)
+		if err == nil && next != nil {
+			err = next.drain(&result{})
+		}
+	}
+	return err
+}
+
+// HasNextResultSet implements driver.RowsNextResultSet.
+func (r *rows) HasNextResultSet() bool {
+	return strings.TrimSpace(r.remainder) != ""
+}
+
+// NextResultSet implements driver.RowsNextResultSet.
+func (r *rows) NextResultSet() error {
+	if err := r.closeResultSet(); err != nil {
+		return err
+	}
+	next, remainder, err := r.conn.run(r.ctx, r.remainder, nil)
+	r.remainder = remainder
+	if err != nil {
+		return err
+	} else if next == nil {
+		return io.EOF
+	}
+	*r = *next
+	return nil
+}
+
+// closeResultSet closes the rows of the current statement, and ends it.
+func (r *rows) closeResultSet() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	defer sql.SessionCommandEnd(r.conn.session)
+	return r.iter.Close(r.ctx)
+}
+
+// drain reads the rows of the current statement, adds its OK result to |res|, and closes them.
+func (r *rows) drain(res *result) error {
+	var err error
+	for err == nil {
+		var row sql.Row
+		row, err = r.iter.Next(r.ctx)
+		if err == nil && types.IsOkResult(row) {
+			res.add(types.GetOkResult(row))
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		err = nil
+	}
+	if cerr := r.closeResultSet(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// toDriverValue returns the driver.Value of |val|, a value of |typ|. Integers and floats are returned as int64, uint64
+// and float64, dates and times as time.Time, text as strings, and everything else, including decimals and JSON, as
+// their MySQL wire format bytes.
+func toDriverValue(ctx *sql.Context, typ sql.Type, val interface{}) (driver.Value, error) {
+	if val == nil {
+		return nil, nil
+	}
+	if t, ok := val.(time.Time); ok && types.IsTime(typ) {
+		return t, nil
+	}
+	v, err := typ.SQL(ctx, nil, val)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case v.IsNull():
+		return nil, nil
+	case sqltypes.IsSigned(v.Type()):
+		return strconv.ParseInt(string(v.ToBytes()), 10, 64)
+	case sqltypes.IsUnsigned(v.Type()):
+		return strconv.ParseUint(string(v.ToBytes()), 10, 64)
+	case sqltypes.IsFloat(v.Type()):
+		return strconv.ParseFloat(string(v.ToBytes()), 64)
+	case sqltypes.IsText(v.Type()):
+		return string(v.ToBytes()), nil
+	default:
+		return v.ToBytes(), nil
+	}
+}
+
+// result is the driver.Result of a statement, or the sum of the results of several statements.
+type result struct {
+	rowsAffected int64
+	lastInsertId int64
+}
+
+var _ driver.Result = result{}
+
+func (r *result) add(ok types.OkResult) {
+	r.rowsAffected += int64(ok.RowsAffected)
+	if ok.InsertID != 0 {
+		r.lastInsertId = int64(ok.InsertID)
+	}
+}
+
+// LastInsertId implements driver.Result.
+func (r result) LastInsertId() (int64, error) {
+	return r.lastInsertId, nil
+}
+
+// RowsAffected implements driver.Result.
+func (r result) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
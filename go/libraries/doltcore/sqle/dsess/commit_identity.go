@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+)
+
+// SessionCommitAuthor returns the name and email set with @@dolt_commit_author as the author of the commits made by
+// the session, or empty strings if it isn't set.
+func SessionCommitAuthor(ctx *sql.Context) (name, email string, err error) {
+	author, err := getStringSystemVar(ctx, DoltCommitAuthor)
+	if err != nil || author == "" {
+		return "", "", err
+	}
+	return cli.ParseAuthor(author)
+}
+
+// SetCommitIdentity sets the author and committer in |props| for a commit made by the session. |author| is the author
+// named for this commit in the "Name <email>" format, like with the --author option of dolt_commit. When it's empty,
+// the author set with @@dolt_commit_author is used, and without one the author already in |props| is kept.
+//
+// Sessions which set @@dolt_commit_author commit on behalf of others, so like git their commits record the SQL user as
+// their committer. @@dolt_commit_committer records another committer instead, which impersonates them and so requires
+// the SUPER privilege. The committer is only stored in the commit when it isn't its author.
+func SetCommitIdentity(ctx *sql.Context, props *actions.CommitStagedProps, author string) error {
+	sessionName, sessionEmail, err := SessionCommitAuthor(ctx)
+	if err != nil {
+		return err
+	}
+	if author != "" {
+		props.Name, props.Email, err = cli.ParseAuthor(author)
+		if err != nil {
+			return err
+		}
+	} else if sessionName != "" {
+		props.Name, props.Email = sessionName, sessionEmail
+	}
+	if sessionName != "" {
+		props.CommitterName = ctx.Client().User
+		props.CommitterEmail = fmt.Sprintf("%s@%s", ctx.Client().User, ctx.Client().Address)
+	}
+
+	committer, err := getStringSystemVar(ctx, DoltCommitCommitter)
+	if err != nil || committer == "" {
+		return err
+	}
+
+	privs, counter := ctx.GetPrivilegeSet()
+	if counter == 0 {
+		return fmt.Errorf("unable to check user privileges for %s", DoltCommitCommitter)
+	}
+	if !privs.Has(sql.PrivilegeType_Super) {
+		return fmt.Errorf("%w: committing as another committer with %s requires the SUPER privilege",
+			sql.ErrPrivilegeCheckFailed.New(ctx.Client().User), DoltCommitCommitter)
+	}
+	props.CommitterName, props.CommitterEmail, err = cli.ParseAuthor(committer)
+	return err
+}
+
+// getStringSystemVar returns the trimmed value of the string system variable named.
+func getStringSystemVar(ctx *sql.Context, varName string) (string, error) {
+	val, err := ctx.GetSessionVariable(ctx, varName)
+	if err != nil {
+		return "", err
+	}
+	s, ok := val.(string)
+	if !ok && val != nil {
+		return "", fmt.Errorf("unexpected type for variable %s: %T", varName, val)
+	}
+	return strings.TrimSpace(s), nil
+}
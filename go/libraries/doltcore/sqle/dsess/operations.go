@@ -0,0 +1,133 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+)
+
+// Operations tracks the long-running operations, such as clones, currently executing on this server. Its contents
+// are exposed through the dolt_operations system table, which lists only a user's own operations unless they have the
+// PROCESS privilege.
+var Operations = NewOperationTracker()
+
+// Operation is a single long-running operation started by a stored procedure.
+type Operation struct {
+	ID           uint64
+	ConnectionID uint32
+	User         string
+	Kind         string
+	Target       string
+	Started      time.Time
+
+	mu       sync.Mutex
+	status   string
+	done     int64
+	total    int64
+	progress bool
+}
+
+// SetStatus updates the human readable description of what the operation is currently doing.
+func (op *Operation) SetStatus(status string) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.status = status
+}
+
+// SetProgress records that |done| of |total| units of work have completed.
+func (op *Operation) SetProgress(done, total int64) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.done, op.total, op.progress = done, total, true
+}
+
+// Status returns the current status of the operation, and its progress if any has been reported.
+func (op *Operation) Status() (status string, done, total int64, hasProgress bool) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	return op.status, op.done, op.total, op.progress
+}
+
+// OperationTracker is a registry of running operations.
+type OperationTracker struct {
+	mu     sync.Mutex
+	nextID uint64
+	ops    map[uint64]*Operation
+}
+
+func NewOperationTracker() *OperationTracker {
+	return &OperationTracker{ops: make(map[uint64]*Operation)}
+}
+
+// Start registers a new operation of |kind| acting on |target| for the connection of |ctx|. Callers must call Finish
+// once the operation completes, whether or not it succeeded.
+func (t *OperationTracker) Start(ctx *sql.Context, kind, target string) *Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nextID++
+	op := &Operation{
+		ID:           t.nextID,
+		ConnectionID: ctx.Session.ID(),
+		User:         ctx.Client().User,
+		Kind:         kind,
+		Target:       target,
+		Started:      time.Now(),
+		status:       "starting",
+	}
+	t.ops[op.ID] = op
+	return op
+}
+
+// Finish removes |op| from the registry.
+func (t *OperationTracker) Finish(op *Operation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.ops, op.ID)
+}
+
+// List returns the running operations, ordered by when they started.
+func (t *OperationTracker) List() []*Operation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ops := make([]*Operation, 0, len(t.ops))
+	for _, op := range t.ops {
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].ID < ops[j].ID
+	})
+	return ops
+}
+
+// ListVisible returns the running operations which the user of |ctx| may see, ordered by when they started. Like SHOW
+// PROCESSLIST, that's every operation for users with the PROCESS privilege, and only their own for everyone else.
+func (t *OperationTracker) ListVisible(ctx *sql.Context) []*Operation {
+	ops := t.List()
+	if privs, counter := ctx.GetPrivilegeSet(); counter != 0 && privs.Has(sql.PrivilegeType_Process) {
+		return ops
+	}
+	user := ctx.Client().User
+	visible := ops[:0]
+	for _, op := range ops {
+		if op.User == user {
+			visible = append(visible, op)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/mysql_db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationTracker(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	tracker := NewOperationTracker()
+	assert.Empty(t, tracker.List())
+
+	op1 := tracker.Start(ctx, "clone", "file:///one")
+	op2 := tracker.Start(ctx, "clone", "file:///two")
+	ops := tracker.List()
+	assert.Len(t, ops, 2)
+	assert.Equal(t, op1.ID, ops[0].ID)
+	assert.Equal(t, op2.ID, ops[1].ID)
+
+	status, _, _, hasProgress := op1.Status()
+	assert.Equal(t, "starting", status)
+	assert.False(t, hasProgress)
+
+	op1.SetStatus("downloading")
+	op1.SetProgress(5, 10)
+	status, done, total, hasProgress := op1.Status()
+	assert.Equal(t, "downloading", status)
+	assert.True(t, hasProgress)
+	assert.Equal(t, int64(5), done)
+	assert.Equal(t, int64(10), total)
+
+	tracker.Finish(op1)
+	ops = tracker.List()
+	assert.Len(t, ops, 1)
+	assert.Equal(t, op2.ID, ops[0].ID)
+
+	tracker.Finish(op2)
+	assert.Empty(t, tracker.List())
+}
+
+func TestOperationTrackerListVisible(t *testing.T) {
+	newCtx := func(user string, id uint32, privs ...sql.PrivilegeType) *sql.Context {
+		sess := sql.NewBaseSessionWithClientServer("server", sql.Client{User: user, Address: "localhost"}, id)
+		privSet := mysql_db.NewPrivilegeSet()
+		privSet.AddGlobalStatic(privs...)
+		sess.SetPrivilegeSet(privSet, 1)
+		return sql.NewContext(context.Background(), sql.WithSession(sess))
+	}
+	alice := newCtx("alice", 1)
+	bob := newCtx("bob", 2)
+	admin := newCtx("admin", 3, sql.PrivilegeType_Process)
+
+	tracker := NewOperationTracker()
+	aliceOp := tracker.Start(alice, "clone", "file:///alice")
+	bobOp := tracker.Start(bob, "clone", "file:///bob")
+
+	ops := tracker.ListVisible(alice)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, aliceOp.ID, ops[0].ID)
+	ops = tracker.ListVisible(bob)
+	assert.Len(t, ops, 1)
+	assert.Equal(t, bobOp.ID, ops[0].ID)
+	assert.Len(t, tracker.ListVisible(admin), 2)
+	// the filtering doesn't affect the unfiltered list
+	assert.Len(t, tracker.List(), 2)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// ScratchSpace returns the TempFileProvider for the scratch data written by statements in this session, such as the
+// sorted runs of an index build. Its files are created in @@dolt_scratch_dir, or in the default temp directory if that
+// is empty, and hold at most @@dolt_scratch_quota bytes at once, or any amount if that is 0. Both variables can be set
+// globally for the server, or per session or per user, so that one session can't fill the disk of a shared server.
+// Writes which would exceed the quota fail with an error wrapping tempfiles.ErrScratchQuotaExceeded.
+func (d *DoltSession) ScratchSpace(ctx *sql.Context) (tempfiles.TempFileProvider, error) {
+	dir, err := d.scratchDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quotaVal, err := d.GetSessionVariable(ctx, ScratchQuota)
+	if err != nil {
+		return nil, err
+	}
+	quota, ok := quotaVal.(int64)
+	if !ok {
+		return nil, sql.ErrInvalidSystemVariableValue.New(ScratchQuota)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// Statements share the scratch space of their session, so that the quota limits the session as a whole, such as
+	// when several indexes are built in parallel
+	if d.scratch == nil || d.scratch.GetTempDir() != dir || d.scratch.Quota() != quota {
+		d.scratch = tempfiles.NewScratchSpace(dir, quota)
+	}
+	return d.scratch, nil
+}
+
+func (d *DoltSession) scratchDir(ctx *sql.Context) (string, error) {
+	val, err := d.GetSessionVariable(ctx, ScratchDir)
+	if err != nil {
+		return "", err
+	}
+	dir, ok := val.(string)
+	if !ok {
+		return "", sql.ErrInvalidSystemVariableValue.New(ScratchDir)
+	}
+	if dir == "" {
+		return tempfiles.MovableTempFileProvider.GetTempDir(), nil
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("unable to create scratch directory '%s' from @@%s: %w", dir, ScratchDir, err)
+	}
+	return dir, nil
+}
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -40,6 +41,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
 )
 
 const (
@@ -59,6 +61,7 @@ type DoltSession struct {
 	dbCache               *DatabaseCache
 	provider              DoltDatabaseProvider
 	tempTables            map[string][]sql.Table
+	tempFunctions         map[string]string
 	globalsConf           config.ReadWriteConfig
 	branchController      *branch_control.Controller
 	statsProv             sql.StatsProvider
@@ -66,6 +69,10 @@ type DoltSession struct {
 	fs                    filesys.Filesys
 	writeSessProv         WriteSessFunc
 	gcSafepointController *gcctx.GCSafepointController
+	// scratch is the scratch space last returned by ScratchSpace, which is reused while its settings are unchanged.
+	scratch *tempfiles.ScratchSpace
+	// rowsExamined counts the rows read from tables by this session's statements while the query log is enabled.
+	rowsExamined *atomic.Int64
 
 	// If non-nil, this will be returned from ValidateSession.
 	// Used by sqle/cluster to put a session into a terminal err state.
@@ -77,6 +84,17 @@ var _ sql.PersistableSession = (*DoltSession)(nil)
 var _ sql.TransactionSession = (*DoltSession)(nil)
 var _ branch_control.ContextConvertible = (*DoltSession)(nil)
 
+// AddRowsExamined adds |n| to the rows examined by this session's statements.
+func (d *DoltSession) AddRowsExamined(n int64) {
+	d.rowsExamined.Add(n)
+}
+
+// RowsExamined returns the number of rows examined by this session's statements. The rows examined by a statement
+// are the difference between this before and after it runs.
+func (d *DoltSession) RowsExamined() int64 {
+	return d.rowsExamined.Load()
+}
+
 // DefaultSession creates a DoltSession with default values
 func DefaultSession(pro DoltDatabaseProvider, sessFunc WriteSessFunc) *DoltSession {
 	return &DoltSession{
@@ -87,9 +105,11 @@ func DefaultSession(pro DoltDatabaseProvider, sessFunc WriteSessFunc) *DoltSessi
 		dbCache:          newDatabaseCache(),
 		provider:         pro,
 		tempTables:       make(map[string][]sql.Table),
+		tempFunctions:    make(map[string]string),
 		globalsConf:      config.NewMapConfig(make(map[string]string)),
 		branchController: branch_control.CreateDefaultController(context.TODO()), // Default sessions are fine with the default controller
 		mu:               &sync.Mutex{},
+		rowsExamined:     &atomic.Int64{},
 		fs:               pro.FileSystem(),
 		writeSessProv:    sessFunc,
 	}
@@ -117,10 +137,12 @@ func NewDoltSession(
 		dbCache:               newDatabaseCache(),
 		provider:              pro,
 		tempTables:            make(map[string][]sql.Table),
+		tempFunctions:         make(map[string]string),
 		globalsConf:           globals,
 		branchController:      branchController,
 		statsProv:             statsProvider,
 		mu:                    &sync.Mutex{},
+		rowsExamined:          &atomic.Int64{},
 		fs:                    pro.FileSystem(),
 		writeSessProv:         writeSessProv,
 		gcSafepointController: gcSafepointController,
@@ -457,8 +479,13 @@ func (d *DoltSession) newWorkingSetForHead(ctx *sql.Context, wsRef ref.WorkingSe
 
 // CommitTransaction commits the in-progress transaction. Depending on session settings, this may write only a new
 // working set, or may additionally create a new dolt commit for the current HEAD. If more than one branch head has
-// changes, the transaction is rejected.
+// changes, the transaction is rejected. Commits which fail because of unresolved conflicts or constraint violations
+// return ErrCodeConflictsPresent.
 func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (err error) {
+	defer func() {
+		err = classifyCommitError(err)
+	}()
+
 	// Any non-error path must set the ctx's transaction to nil even if no work was done, because the engine only clears
 	// out transaction state in some cases. Changes to only branch heads (creating a new branch, reset, etc.) have no
 	// changes to commit visible to the transaction logic, but they still need a new transaction on the next statement.
@@ -516,15 +543,20 @@ func (d *DoltSession) CommitTransaction(ctx *sql.Context, tx sql.Transaction) (e
 			message = trimmedString
 		}
 
-		var pendingCommit *doltdb.PendingCommit
-		pendingCommit, err = d.PendingCommitAllStaged(ctx, dirtyBranchState, actions.CommitStagedProps{
+		props := actions.CommitStagedProps{
 			Message:    message,
 			Date:       ctx.QueryTime(),
 			AllowEmpty: false,
 			Force:      false,
 			Name:       d.Username(),
 			Email:      d.Email(),
-		})
+		}
+		if err = SetCommitIdentity(ctx, &props, ""); err != nil {
+			return err
+		}
+
+		var pendingCommit *doltdb.PendingCommit
+		pendingCommit, err = d.PendingCommitAllStaged(ctx, dirtyBranchState, props)
 		if err != nil {
 			return err
 		}
@@ -733,6 +765,11 @@ func (d *DoltSession) newPendingCommit(ctx *sql.Context, branchState *branchStat
 		return nil, doltdb.ErrOperationNotSupportedInDetachedHead
 	}
 
+	roots, err := d.updateSummaryTables(ctx, branchState, roots)
+	if err != nil {
+		return nil, err
+	}
+
 	var mergeParentCommits []*doltdb.Commit
 	if branchState.WorkingSet().MergeCommitParents() {
 		mergeParentCommits = []*doltdb.Commit{branchState.WorkingSet().MergeState().Commit()}
@@ -1476,6 +1513,31 @@ func (d *DoltSession) GetAllTemporaryTables(ctx *sql.Context, db string) ([]sql.
 	return d.tempTables[strings.ToLower(db)], nil
 }
 
+// AddTemporaryFunction adds a user defined function that is visible only to this session, replacing any temporary
+// function with the same name. |definition| is the function's serialized definition.
+func (d *DoltSession) AddTemporaryFunction(name, definition string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tempFunctions[strings.ToLower(name)] = definition
+}
+
+// DropTemporaryFunction drops the temporary function named, returning whether it existed.
+func (d *DoltSession) DropTemporaryFunction(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.tempFunctions[strings.ToLower(name)]
+	delete(d.tempFunctions, strings.ToLower(name))
+	return ok
+}
+
+// GetTemporaryFunction returns the serialized definition of the temporary function named.
+func (d *DoltSession) GetTemporaryFunction(name string) (string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	def, ok := d.tempFunctions[strings.ToLower(name)]
+	return def, ok
+}
+
 // CWBHeadRef returns the branch ref for this session HEAD for the database named
 func (d *DoltSession) CWBHeadRef(ctx *sql.Context, dbName string) (ref.DoltRef, error) {
 	branchState, ok, err := d.lookupDbState(ctx, dbName)
@@ -62,6 +62,19 @@ const (
 	RevisionTypeCommit
 )
 
+func (r RevisionType) String() string {
+	switch r {
+	case RevisionTypeBranch:
+		return "branch"
+	case RevisionTypeTag:
+		return "tag"
+	case RevisionTypeCommit:
+		return "commit"
+	default:
+		return "none"
+	}
+}
+
 // RemoteReadReplicaDatabase is a database that pulls from a connected remote when a transaction begins.
 type RemoteReadReplicaDatabase interface {
 	// ValidReplicaState returns whether this read replica is in a valid state to pull from the remote
@@ -88,7 +101,11 @@ type DoltDatabaseProvider interface {
 	// dbName is the name for the new database, branch is an optional parameter indicating which branch to clone
 	// (otherwise all branches are cloned), remoteName is the name for the remote created in the new database, and
 	// remoteUrl is a URL (e.g. "file:///dbs/db1") or an <org>/<database> path indicating a database hosted on DoltHub.
-	CloneDatabaseFromRemote(ctx *sql.Context, dbName, branch, remoteName, remoteUrl string, depth int, remoteParams map[string]string) error
+	// Tags are cloned unless noTags is set.
+	CloneDatabaseFromRemote(ctx *sql.Context, dbName, branch, remoteName, remoteUrl string, depth int, noTags bool, remoteParams map[string]string) error
+	// AttachRemoteDatabase adds the database at remoteUrl as the read-only database dbName without cloning it. Its
+	// data is fetched from the remote as it's read. Dropping the database detaches it.
+	AttachRemoteDatabase(ctx *sql.Context, dbName, remoteUrl string, remoteParams map[string]string) error
 	// SessionDatabase returns the SessionDatabase for the specified database, which may name a revision of a base
 	// database.
 	SessionDatabase(ctx *sql.Context, dbName string) (SqlDatabase, bool, error)
@@ -111,6 +128,9 @@ type DoltDatabaseProvider interface {
 	// PurgeDroppedDatabases permanently deletes any dropped databases that are being held in temporary storage
 	// in case they need to be restored. This operation is not reversible, so use with caution!
 	PurgeDroppedDatabases(ctx *sql.Context) error
+	// StatementRunner returns the engine serving this provider, or nil if none has been set. Statements run through it
+	// are analyzed and authorized like any other statement of the calling session.
+	StatementRunner() sql.StatementRunner
 }
 
 type SessionDatabaseBranchSpec struct {
@@ -0,0 +1,147 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// BranchStateInfo describes the state a session holds for a database at one revision. It's meant for debugging why a
+// session sees the data it does, e.g. data which is stale compared to other sessions.
+type BranchStateInfo struct {
+	// Database is the revision-qualified name of the database
+	Database     string
+	RevisionType RevisionType
+	// CheckedOut is true for the revision the unqualified database name refers to
+	CheckedOut bool
+	ReadOnly   bool
+	// HeadCommit is the hash of the head commit, and empty for databases pinned to a root value
+	HeadCommit  string
+	HeadRoot    hash.Hash
+	WorkingRoot hash.Hash
+	StagedRoot  hash.Hash
+	// Dirty is true if the session has changes to the database which aren't committed in a SQL transaction yet
+	Dirty bool
+	// DirtyTables are the tables of the working root which changed since the current SQL transaction began
+	DirtyTables []doltdb.TableName
+	// TransactionStartRoot is the hash of the database's storage root when the current SQL transaction began, which
+	// is the snapshot of the database the transaction reads. It's empty outside a transaction.
+	TransactionStartRoot hash.Hash
+}
+
+// BranchStateInfos returns the state of every database and revision the session has accessed, ordered by database.
+func (d *DoltSession) BranchStateInfos(ctx *sql.Context) ([]BranchStateInfo, error) {
+	var states []*branchState
+	d.mu.Lock()
+	for _, dbState := range d.dbStates {
+		for _, bs := range dbState.heads {
+			states = append(states, bs)
+		}
+	}
+	d.mu.Unlock()
+
+	tx, _ := ctx.GetTransaction().(*DoltTransaction)
+
+	var infos []BranchStateInfo
+	for _, bs := range states {
+		roots := bs.roots()
+		if roots.Head == nil {
+			// the state of a database which failed to load
+			continue
+		}
+		info := BranchStateInfo{
+			Database:     bs.RevisionDbName(),
+			RevisionType: bs.revisionType,
+			CheckedOut:   strings.EqualFold(bs.head, bs.dbState.checkedOutRevSpec),
+			ReadOnly:     bs.readOnly,
+			Dirty:        bs.dirty,
+		}
+
+		var err error
+		if bs.headCommit != nil {
+			h, err := bs.headCommit.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			info.HeadCommit = h.String()
+		}
+		if info.HeadRoot, err = roots.Head.HashOf(); err != nil {
+			return nil, err
+		}
+		if info.WorkingRoot, err = roots.Working.HashOf(); err != nil {
+			return nil, err
+		}
+		if info.StagedRoot, err = roots.Staged.HashOf(); err != nil {
+			return nil, err
+		}
+
+		if tx != nil {
+			info.TransactionStartRoot, _ = tx.GetInitialRoot(bs.dbState.dbName)
+		}
+		if bs.dirty {
+			if info.DirtyTables, err = dirtyTables(ctx, bs, info.TransactionStartRoot); err != nil {
+				return nil, err
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return strings.ToLower(infos[i].Database) < strings.ToLower(infos[j].Database)
+	})
+	return infos, nil
+}
+
+// dirtyTables returns the tables of the working root of |bs| which differ from its working root at the storage root
+// |startRoot|. Without a start root, or when the working set didn't exist then, they're compared with the head root.
+func dirtyTables(ctx *sql.Context, bs *branchState, startRoot hash.Hash) ([]doltdb.TableName, error) {
+	startWorking := bs.roots().Head
+	if ws := bs.WorkingSet(); ws != nil && !startRoot.IsEmpty() {
+		startWs, err := bs.dbData.Ddb.ResolveWorkingSetAtRoot(ctx, ws.Ref(), startRoot)
+		if err == nil {
+			startWorking = startWs.WorkingRoot()
+		} else if !errors.Is(err, doltdb.ErrWorkingSetNotFound) {
+			return nil, err
+		}
+	}
+
+	working := bs.WorkingRoot()
+	names, err := doltdb.UnionTableNames(ctx, startWorking, working)
+	if err != nil {
+		return nil, err
+	}
+	var dirty []doltdb.TableName
+	for _, name := range names {
+		h, ok, err := working.GetTableHash(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		startH, startOk, err := startWorking.GetTableHash(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok != startOk || h != startH {
+			dirty = append(dirty, name)
+		}
+	}
+	return dirty, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dustin/go-humanize"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+)
+
+// ErrStorageQuotaExceeded is returned when committing a transaction would grow a database past its storage quota.
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// StorageQuota returns the storage quota, in bytes, of the database |dbName|, and whether it has one. Quotas for
+// individual databases are set by @@dolt_database_storage_quotas, a comma separated list of db=size entries, such as
+// "db1=10GB,db2=500MB". Databases without an entry use @@dolt_storage_quota. A quota of 0 means no quota.
+func StorageQuota(dbName string) (uint64, bool, error) {
+	if _, val, ok := sql.SystemVariables.GetGlobal(DatabaseStorageQuotas); ok {
+		quotas, ok := val.(string)
+		if !ok {
+			return 0, false, sql.ErrInvalidSystemVariableValue.New(DatabaseStorageQuotas)
+		}
+		quota, found, err := parseDatabaseStorageQuota(quotas, dbName)
+		if err != nil || found {
+			return quota, quota > 0, err
+		}
+	}
+
+	_, val, ok := sql.SystemVariables.GetGlobal(StorageQuotaBytes)
+	if !ok {
+		return 0, false, nil
+	}
+	quota, ok := val.(int64)
+	if !ok {
+		return 0, false, sql.ErrInvalidSystemVariableValue.New(StorageQuotaBytes)
+	}
+	return uint64(quota), quota > 0, nil
+}
+
+// parseDatabaseStorageQuota returns the quota of |dbName| in |quotas|, and whether it has an entry.
+func parseDatabaseStorageQuota(quotas, dbName string) (uint64, bool, error) {
+	for _, entry := range strings.Split(quotas, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, size, ok := strings.Cut(entry, "=")
+		if !ok {
+			return 0, false, fmt.Errorf("invalid entry '%s' in @@%s, expected db=size", entry, DatabaseStorageQuotas)
+		}
+		if !strings.EqualFold(strings.TrimSpace(name), dbName) {
+			continue
+		}
+		quota, err := humanize.ParseBytes(strings.TrimSpace(size))
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid size for database '%s' in @@%s: %w", dbName, DatabaseStorageQuotas, err)
+		}
+		return quota, true, nil
+	}
+	return 0, false, nil
+}
+
+// checkStorageQuota returns ErrStorageQuotaExceeded if the database |dbName| has a storage quota which its current
+// size, along with the chunks written by the transaction which have yet to be persisted, exceeds.
+func checkStorageQuota(ctx context.Context, ddb *doltdb.DoltDB, dbName string) error {
+	quota, ok, err := StorageQuota(dbName)
+	if err != nil || !ok {
+		return err
+	}
+	sizes, err := ddb.StoreSizes(ctx)
+	if err != nil {
+		return err
+	}
+	if used := sizes.TotalBytes + sizes.PendingBytes; used > quota {
+		return fmt.Errorf("%w: database '%s' would use %s of its %s quota, transaction rolled back. "+
+			"Run dolt_gc() to reclaim unreferenced storage, or raise the quota",
+			ErrStorageQuotaExceeded, dbName, humanize.Bytes(used), humanize.Bytes(quota))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDatabaseStorageQuota(t *testing.T) {
+	quotas := "db1=10GB, DB2 = 500MB,db3=0"
+
+	quota, ok, err := parseDatabaseStorageQuota(quotas, "db1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10_000_000_000), quota)
+
+	quota, ok, err = parseDatabaseStorageQuota(quotas, "db2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(500_000_000), quota)
+
+	quota, ok, err = parseDatabaseStorageQuota(quotas, "db3")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), quota)
+
+	_, ok, err = parseDatabaseStorageQuota(quotas, "db4")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = parseDatabaseStorageQuota("db1", "db1")
+	assert.Error(t, err)
+	_, _, err = parseDatabaseStorageQuota("db1=lots", "db1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,606 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/shopspring/decimal"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// summaryTable is a summary table defined in the dolt_summary_tables system table. Each row of a summary table holds
+// the aggregates of the rows of its source table with the same values of the group by columns, which are the primary
+// key of the summary table.
+type summaryTable struct {
+	name       string
+	source     string
+	groupBy    []string
+	aggregates []summaryAggregate
+	// definition is the definition as written in dolt_summary_tables, used to detect changed definitions
+	definition string
+}
+
+// summaryAggregate is an aggregate column of a summary table. Only aggregates which can be maintained from the rows
+// added and removed from the source table are supported: count(*), count(col) and sum(col).
+type summaryAggregate struct {
+	column string
+	fn     string
+	// arg is the source column aggregated, or "*" for count(*)
+	arg string
+}
+
+var summaryAggregateRegex = regexp.MustCompile("(?i)^`?(\\w+)`?\\s*=\\s*(count|sum)\\s*\\(\\s*(\\*|`?\\w+`?)\\s*\\)$")
+
+// parseSummaryAggregates parses the comma separated aggregates of a summary table, such as
+// "orders = count(*), revenue = sum(amount)".
+func parseSummaryAggregates(s string) ([]summaryAggregate, error) {
+	var aggs []summaryAggregate
+	hasCountStar := false
+	for _, part := range strings.Split(s, ",") {
+		m := summaryAggregateRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if m == nil {
+			return nil, fmt.Errorf("invalid aggregate '%s', expected <column> = count(*), count(<column>) or sum(<column>)", strings.TrimSpace(part))
+		}
+		agg := summaryAggregate{column: m[1], fn: strings.ToLower(m[2]), arg: strings.Trim(m[3], "`")}
+		if agg.fn == "sum" && agg.arg == "*" {
+			return nil, fmt.Errorf("invalid aggregate '%s', sum requires a column", strings.TrimSpace(part))
+		}
+		if agg.fn == "count" && agg.arg == "*" {
+			hasCountStar = true
+		}
+		aggs = append(aggs, agg)
+	}
+	// the count of rows of each group is needed to know when all of its rows were deleted
+	if !hasCountStar {
+		return nil, fmt.Errorf("aggregates must include a count(*) column")
+	}
+	return aggs, nil
+}
+
+// loadSummaryTables returns the summary tables defined in the dolt_summary_tables table of |root|, by name.
+func loadSummaryTables(ctx *sql.Context, root doltdb.RootValue) (map[string]*summaryTable, error) {
+	defs, ok, err := root.GetTable(ctx, doltdb.TableName{Name: doltdb.SummaryTablesTableName})
+	if err != nil || !ok {
+		return nil, err
+	}
+	rows, err := tableRows(ctx, defs)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]*summaryTable, len(rows))
+	for _, row := range rows {
+		name, _ := row[0].(string)
+		source, _ := row[1].(string)
+		groupBy, _ := row[2].(string)
+		aggregates, _ := row[3].(string)
+
+		st := &summaryTable{
+			name:       name,
+			source:     source,
+			definition: strings.Join([]string{source, groupBy, aggregates}, "\x00"),
+		}
+		for _, col := range strings.Split(groupBy, ",") {
+			if col = strings.Trim(strings.TrimSpace(col), "`"); col != "" {
+				st.groupBy = append(st.groupBy, col)
+			}
+		}
+		if len(st.groupBy) == 0 {
+			return nil, fmt.Errorf("summary table %s: group_by must name at least one column", name)
+		}
+		st.aggregates, err = parseSummaryAggregates(aggregates)
+		if err != nil {
+			return nil, fmt.Errorf("summary table %s: %w", name, err)
+		}
+		tables[strings.ToLower(name)] = st
+	}
+
+	for _, st := range tables {
+		if _, ok := tables[strings.ToLower(st.source)]; ok {
+			return nil, fmt.Errorf("summary table %s: the source table %s is a summary table", st.name, st.source)
+		}
+	}
+	return tables, nil
+}
+
+// updateSummaryTables brings the summary tables defined in the staged root of |roots| up to date with the staged
+// version of their source tables, and returns the updated roots. Each summary table is updated from its version in
+// the HEAD root with the diff of its source table between the HEAD and staged roots, so that only the groups of
+// changed rows are rewritten. A summary table is rebuilt from all the rows of its source table if it or its
+// definition is new, or if its schema or the schema of its source table changed. The updated summary tables are
+// written to both the staged and working roots, replacing any changes made to them directly. Every pending commit
+// made by the session is updated by this method, see newPendingCommit.
+func (d *DoltSession) updateSummaryTables(ctx *sql.Context, branchState *branchState, roots doltdb.Roots) (doltdb.Roots, error) {
+	dbName := branchState.dbState.dbName
+	tables, err := loadSummaryTables(ctx, roots.Staged)
+	if err != nil || len(tables) == 0 {
+		return roots, err
+	}
+	if !types.IsFormat_DOLT(roots.Staged.VRW().Format()) {
+		return roots, fmt.Errorf("summary tables are not supported by the storage format of database %s", dbName)
+	}
+	headTables, err := loadSummaryTables(ctx, roots.Head)
+	if err != nil {
+		return roots, err
+	}
+
+	// each summary table is written starting from its HEAD version, or from its staged version when it's rebuilt
+	base := roots.Staged
+	headSources := make(map[string]*doltdb.Table, len(tables))
+	for key, st := range tables {
+		summary, headSource, err := summaryTableBase(ctx, roots, st, headTables[key])
+		if err != nil {
+			return roots, err
+		}
+		headSources[key] = headSource
+		base, err = base.PutTable(ctx, doltdb.TableName{Name: st.name}, summary)
+		if err != nil {
+			return roots, err
+		}
+	}
+
+	ws := branchState.WorkingSet().WithWorkingRoot(base)
+	tracker, err := NewAutoIncrementTracker(ctx, dbName, ws)
+	if err != nil {
+		return roots, err
+	}
+	writeSession := d.writeSessProv(base.VRW().Format(), ws, tracker, branchState.EditOpts())
+
+	for key, st := range tables {
+		if err = st.update(ctx, dbName, writeSession, base, headSources[key], roots.Staged); err != nil {
+			return roots, fmt.Errorf("summary table %s: %w", st.name, err)
+		}
+	}
+
+	ws, err = writeSession.Flush(ctx)
+	if err != nil {
+		return roots, err
+	}
+	for _, st := range tables {
+		name := doltdb.TableName{Name: st.name}
+		tbl, _, err := ws.WorkingRoot().GetTable(ctx, name)
+		if err != nil {
+			return roots, err
+		}
+		if roots.Staged, err = roots.Staged.PutTable(ctx, name, tbl); err != nil {
+			return roots, err
+		}
+		if roots.Working, err = roots.Working.PutTable(ctx, name, tbl); err != nil {
+			return roots, err
+		}
+	}
+	return roots, nil
+}
+
+// summaryTableBase returns the version of the summary table |st| to update and the HEAD version of its source
+// table. The returned source table is nil if the summary table needs to be rebuilt, in which case the staged version
+// of the summary table is returned.
+func summaryTableBase(ctx *sql.Context, roots doltdb.Roots, st, head *summaryTable) (*doltdb.Table, *doltdb.Table, error) {
+	staged, ok, err := roots.Staged.GetTable(ctx, doltdb.TableName{Name: st.name})
+	if err != nil {
+		return nil, nil, err
+	} else if !ok {
+		return nil, nil, fmt.Errorf("summary table %s: table not found", st.name)
+	}
+	source, ok, err := roots.Staged.GetTable(ctx, doltdb.TableName{Name: st.source})
+	if err != nil {
+		return nil, nil, err
+	} else if !ok {
+		return nil, nil, fmt.Errorf("summary table %s: source table %s not found", st.name, st.source)
+	}
+	if head == nil || head.definition != st.definition {
+		return staged, nil, nil
+	}
+
+	headSummary, ok, err := roots.Head.GetTable(ctx, doltdb.TableName{Name: st.name})
+	if err != nil || !ok {
+		return staged, nil, err
+	}
+	headSource, ok, err := roots.Head.GetTable(ctx, doltdb.TableName{Name: st.source})
+	if err != nil || !ok {
+		return staged, nil, err
+	}
+	for _, pair := range [][2]*doltdb.Table{{headSummary, staged}, {headSource, source}} {
+		from, err := pair[0].GetSchemaHash(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		to, err := pair[1].GetSchemaHash(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if from != to {
+			return staged, nil, nil
+		}
+	}
+	return headSummary, headSource, nil
+}
+
+// summaryGroup is the change of the aggregates of a group of a summary table
+type summaryGroup struct {
+	key    sql.Row
+	deltas []decimal.Decimal
+}
+
+// update writes the changes to the summary table |st| in |base| with |writeSession|. The changes are computed from the
+// diff of its source table between |headSource| and |staged|, or from all the rows of its source table in |staged|
+// if |headSource| is nil, in which case the summary table is rebuilt.
+func (st *summaryTable) update(ctx *sql.Context, dbName string, writeSession WriteSession, base doltdb.RootValue, headSource *doltdb.Table, staged doltdb.RootValue) error {
+	summary, _, err := base.GetTable(ctx, doltdb.TableName{Name: st.name})
+	if err != nil {
+		return err
+	}
+	summarySch, err := summary.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	source, _, err := staged.GetTable(ctx, doltdb.TableName{Name: st.source})
+	if err != nil {
+		return err
+	}
+	sourceSch, err := source.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	layout, err := st.newLayout(summarySch, sourceSch)
+	if err != nil {
+		return err
+	}
+
+	groups := make(map[uint64]*summaryGroup)
+	addRows := func(rows []sql.Row, sign int64) error {
+		for _, row := range rows {
+			if err := layout.add(ctx, groups, row, sign); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if headSource == nil {
+		rows, err := tableRows(ctx, source)
+		if err != nil {
+			return err
+		}
+		if err = addRows(rows, 1); err != nil {
+			return err
+		}
+	} else {
+		from, err := prollyRowData(ctx, headSource)
+		if err != nil {
+			return err
+		}
+		to, err := prollyRowData(ctx, source)
+		if err != nil {
+			return err
+		}
+		err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, diff tree.Diff) error {
+			if diff.From != nil {
+				rows, err := tupleRows(ctx, sourceSch, from, val.Tuple(diff.Key), val.Tuple(diff.From))
+				if err != nil {
+					return err
+				}
+				if err = addRows(rows, -1); err != nil {
+					return err
+				}
+			}
+			if diff.To != nil {
+				rows, err := tupleRows(ctx, sourceSch, to, val.Tuple(diff.Key), val.Tuple(diff.To))
+				if err != nil {
+					return err
+				}
+				if err = addRows(rows, 1); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	tw, err := writeSession.GetTableWriter(ctx, doltdb.TableName{Name: st.name}, dbName, func(*sql.Context, string, doltdb.RootValue) error { return nil }, false)
+	if err != nil {
+		return err
+	}
+	tw.StatementBegin(ctx)
+	if err = layout.write(ctx, tw, summary, groups, headSource == nil); err != nil {
+		tw.DiscardChanges(ctx, err)
+		return err
+	}
+	return tw.StatementComplete(ctx)
+}
+
+// summaryLayout maps the columns of a summary table to the columns of its source table
+type summaryLayout struct {
+	st *summaryTable
+	// groupIdx and argIdx are the indexes of the group by columns and of the aggregated columns in the source rows.
+	// The argIdx of count(*) is -1.
+	groupIdx []int
+	argIdx   []int
+	// groupCols and aggCols are the indexes of the group by columns and of the aggregates in the summary rows
+	groupCols []int
+	aggCols   []int
+	summary   []schema.Column
+	countStar int
+}
+
+func (st *summaryTable) newLayout(summarySch, sourceSch schema.Schema) (*summaryLayout, error) {
+	l := &summaryLayout{st: st, summary: summarySch.GetAllCols().GetColumns()}
+	sourceCols := sourceSch.GetAllCols().GetColumns()
+	mapped := make([]bool, len(l.summary))
+	mapCol := func(name string) (int, error) {
+		idx := columnIndex(l.summary, name)
+		if idx < 0 {
+			return -1, fmt.Errorf("column %s not found", name)
+		} else if mapped[idx] {
+			return -1, fmt.Errorf("column %s is defined more than once", name)
+		}
+		mapped[idx] = true
+		return idx, nil
+	}
+
+	for _, col := range st.groupBy {
+		srcIdx := columnIndex(sourceCols, col)
+		if srcIdx < 0 {
+			return nil, fmt.Errorf("group by column %s not found in source table %s", col, st.source)
+		}
+		idx, err := mapCol(col)
+		if err != nil {
+			return nil, err
+		}
+		if !l.summary[idx].IsPartOfPK {
+			return nil, fmt.Errorf("group by column %s must be part of the primary key", col)
+		}
+		l.groupIdx = append(l.groupIdx, srcIdx)
+		l.groupCols = append(l.groupCols, idx)
+	}
+	if len(l.groupCols) != summarySch.GetPKCols().Size() {
+		return nil, fmt.Errorf("the primary key must be the group by columns")
+	}
+
+	for i, agg := range st.aggregates {
+		srcIdx := -1
+		if agg.arg != "*" {
+			srcIdx = columnIndex(sourceCols, agg.arg)
+			if srcIdx < 0 {
+				return nil, fmt.Errorf("aggregated column %s not found in source table %s", agg.arg, st.source)
+			}
+		} else {
+			l.countStar = i
+		}
+		idx, err := mapCol(agg.column)
+		if err != nil {
+			return nil, err
+		}
+		l.argIdx = append(l.argIdx, srcIdx)
+		l.aggCols = append(l.aggCols, idx)
+	}
+
+	for i, ok := range mapped {
+		if !ok {
+			return nil, fmt.Errorf("column %s is neither a group by column nor an aggregate", l.summary[i].Name)
+		}
+	}
+	return l, nil
+}
+
+// add adds the aggregates of the source row |row| to its group in |groups|, or subtracts them if |sign| is negative.
+func (l *summaryLayout) add(ctx *sql.Context, groups map[uint64]*summaryGroup, row sql.Row, sign int64) error {
+	key := make(sql.Row, len(l.groupIdx))
+	for i, idx := range l.groupIdx {
+		v, _, err := l.summary[l.groupCols[i]].TypeInfo.ToSqlType().Convert(ctx, row[idx])
+		if err != nil {
+			return err
+		}
+		key[i] = v
+	}
+	h, err := sql.HashOf(ctx, key)
+	if err != nil {
+		return err
+	}
+	g, ok := groups[h]
+	if !ok {
+		g = &summaryGroup{key: key, deltas: make([]decimal.Decimal, len(l.aggCols))}
+		groups[h] = g
+	}
+
+	for i, agg := range l.st.aggregates {
+		if agg.arg == "*" {
+			g.deltas[i] = g.deltas[i].Add(decimal.NewFromInt(sign))
+			continue
+		}
+		v := row[l.argIdx[i]]
+		if v == nil {
+			continue
+		}
+		if agg.fn == "count" {
+			g.deltas[i] = g.deltas[i].Add(decimal.NewFromInt(sign))
+			continue
+		}
+		d, err := gmstypes.InternalDecimalType.ConvertToNullDecimal(v)
+		if err != nil {
+			return err
+		}
+		g.deltas[i] = g.deltas[i].Add(d.Decimal.Mul(decimal.NewFromInt(sign)))
+	}
+	return nil
+}
+
+// write applies the changes of |groups| to the rows of |summary| with |tw|. If |rebuild| is true, the existing rows
+// of |summary| are replaced by the rows of |groups|.
+func (l *summaryLayout) write(ctx *sql.Context, tw TableWriter, summary *doltdb.Table, groups map[uint64]*summaryGroup, rebuild bool) error {
+	rows, err := tableRows(ctx, summary)
+	if err != nil {
+		return err
+	}
+	for _, old := range rows {
+		if rebuild {
+			if err = tw.Delete(ctx, old); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := make(sql.Row, len(l.groupCols))
+		for i, idx := range l.groupCols {
+			key[i] = old[idx]
+		}
+		h, err := sql.HashOf(ctx, key)
+		if err != nil {
+			return err
+		}
+		g, ok := groups[h]
+		if !ok {
+			continue
+		}
+		delete(groups, h)
+
+		updated, err := l.row(ctx, g.key, old, g.deltas)
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			err = tw.Delete(ctx, old)
+		} else {
+			err = tw.Update(ctx, old, updated)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, g := range groups {
+		added, err := l.row(ctx, g.key, nil, g.deltas)
+		if err != nil {
+			return err
+		}
+		if added != nil {
+			if err = tw.Insert(ctx, added); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// row returns the summary row of the group |key| with |deltas| added to the aggregates of |old|, which is nil for a
+// new group. It returns nil if the group has no rows left.
+func (l *summaryLayout) row(ctx *sql.Context, key sql.Row, old sql.Row, deltas []decimal.Decimal) (sql.Row, error) {
+	vals := make([]decimal.Decimal, len(deltas))
+	for i, idx := range l.aggCols {
+		vals[i] = deltas[i]
+		if old == nil {
+			continue
+		}
+		d, err := gmstypes.InternalDecimalType.ConvertToNullDecimal(old[idx])
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = vals[i].Add(d.Decimal)
+	}
+	if vals[l.countStar].Sign() <= 0 {
+		return nil, nil
+	}
+
+	row := make(sql.Row, len(l.summary))
+	for i, idx := range l.groupCols {
+		row[idx] = key[i]
+	}
+	for i, idx := range l.aggCols {
+		typ := l.summary[idx].TypeInfo.ToSqlType()
+		v, inRange, err := typ.Convert(ctx, vals[i])
+		if err != nil {
+			return nil, err
+		} else if inRange == sql.OutOfRange {
+			return nil, sql.ErrValueOutOfRange.New(vals[i], typ)
+		}
+		row[idx] = v
+	}
+	return row, nil
+}
+
+// columnIndex returns the index of the column named |name| in |cols|, ignoring case, or -1 if there isn't one.
+func columnIndex(cols []schema.Column, name string) int {
+	for i, col := range cols {
+		if strings.EqualFold(col.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func prollyRowData(ctx *sql.Context, tbl *doltdb.Table) (prolly.Map, error) {
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	return durable.ProllyMapFromIndex(idx)
+}
+
+// tableRows returns all the rows of |tbl|, with the columns in schema order.
+func tableRows(ctx *sql.Context, tbl *doltdb.Table) ([]sql.Row, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := prollyRowData(ctx, tbl)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, index.NewProllyRowIterForMap(sch, m, iter, sch.GetAllCols().Tags))
+}
+
+// tupleRows returns the rows stored in the key and value tuples of |m| given, of which there are more than one for a
+// keyless table with duplicate rows.
+func tupleRows(ctx *sql.Context, sch schema.Schema, m prolly.Map, key, value val.Tuple) ([]sql.Row, error) {
+	iter := &singleTupleIter{key: key, value: value}
+	return sql.RowIterToRows(ctx, index.NewProllyRowIterForMap(sch, m, iter, sch.GetAllCols().Tags))
+}
+
+// singleTupleIter is a prolly.MapIter of a single key and value tuple
+type singleTupleIter struct {
+	key, value val.Tuple
+}
+
+var _ prolly.MapIter = (*singleTupleIter)(nil)
+
+func (it *singleTupleIter) Next(context.Context) (val.Tuple, val.Tuple, error) {
+	if it.key == nil {
+		return nil, nil, io.EOF
+	}
+	key, value := it.key, it.value
+	it.key, it.value = nil, nil
+	return key, value, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dsess
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+)
+
+// TransactionalDDLRuleId is the id of the analyzer rule added by AddTransactionalDDLRule.
+const TransactionalDDLRuleId analyzer.RuleId = -5
+
+// AddTransactionalDDLRule adds the rule which lets DDL statements join the explicit transaction they run in when
+// dolt_transactional_ddl is enabled to |a|. The engine commits the transaction after any statement flagged as DDL, so
+// the rule runs last and clears those flags, leaving the commit to the transaction. Creating and dropping databases
+// still commits.
+func AddTransactionalDDLRule(a *analyzer.Analyzer) {
+	for _, b := range a.Batches {
+		if b.Desc == "after-all" {
+			b.Rules = append(b.Rules, analyzer.Rule{Id: TransactionalDDLRuleId, Apply: deferImplicitCommit})
+		}
+	}
+}
+
+// deferImplicitCommit clears the DDL flags of |qFlags| when the statement runs in an explicit transaction and
+// dolt_transactional_ddl is enabled.
+func deferImplicitCommit(ctx *sql.Context, _ *analyzer.Analyzer, n sql.Node, _ *plan.Scope, _ analyzer.RuleSelector, qFlags *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	if qFlags == nil || qFlags.IsSet(sql.QFlagDBDDL) || !(qFlags.IsSet(sql.QFlagDDL) || qFlags.IsSet(sql.QFlagAlterTable)) {
+		return n, transform.SameTree, nil
+	}
+	enabled, err := ctx.GetSessionVariable(ctx, TransactionalDDL)
+	if err != nil {
+		return nil, transform.SameTree, err
+	}
+	if enabled, ok := enabled.(int8); !ok || enabled == 0 {
+		return n, transform.SameTree, nil
+	}
+	if !ctx.GetIgnoreAutoCommit() {
+		autocommit, err := plan.IsSessionAutocommit(ctx)
+		if err != nil || autocommit {
+			return n, transform.SameTree, err
+		}
+	}
+	qFlags.Unset(sql.QFlagDDL)
+	qFlags.Unset(sql.QFlagAlterTable)
+	return n, transform.SameTree, nil
+}
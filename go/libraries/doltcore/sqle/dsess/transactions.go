@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/vitess/go/mysql"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
@@ -52,6 +55,46 @@ var ErrUnresolvedConstraintViolationsCommit = errors.New("Committing this transa
 	"Constraint violations from a merge can be resolved using the dolt_constraint_violations table before committing the transaction. " +
 	"To allow transactions to be committed with constraint violations from a merge or transaction sequencing set @@dolt_force_transaction_commit=1.")
 
+// The error number and SQLSTATE of transaction commits which fail because the working set would have unresolved
+// conflicts or constraint violations. Unlike ErrRetryTransaction, which is a serialization failure, retrying these
+// transactions fails the same way. They're the same as the class of conflict errors returned by dolt procedures.
+const (
+	ErrCodeConflictsPresent  = 50002
+	SqlStateConflictsPresent = "XD002"
+)
+
+// classifyCommitError returns |err| as a *mysql.SQLError with ErrCodeConflictsPresent if it's a transaction commit
+// failure for unresolved conflicts or constraint violations, so that clients can tell it from a serialization failure
+// without parsing its message. Other errors are returned unchanged.
+func classifyCommitError(err error) error {
+	if errors.Is(err, ErrUnresolvedConflictsCommit) || errors.Is(err, ErrUnresolvedConflictsAutoCommit) ||
+		errors.Is(err, ErrUnresolvedConstraintViolationsCommit) {
+		return mysql.NewSQLError(ErrCodeConflictsPresent, SqlStateConflictsPresent, "%s", err.Error())
+	}
+	return err
+}
+
+// CommitStats are the counts of the transactions this process has committed to its databases.
+type CommitStats struct {
+	// Transactions is the number of transactions which wrote to a working set, including those which made Dolt commits.
+	Transactions int64
+	// DoltCommits is the number of transactions which made a Dolt commit.
+	DoltCommits int64
+}
+
+var commitMetrics struct {
+	transactions atomic.Int64
+	doltCommits  atomic.Int64
+}
+
+// GetCommitStats returns the current CommitStats.
+func GetCommitStats() CommitStats {
+	return CommitStats{
+		Transactions: commitMetrics.transactions.Load(),
+		DoltCommits:  commitMetrics.doltCommits.Load(),
+	}
+}
+
 // TODO: remove this
 func TransactionsDisabled(ctx *sql.Context) bool {
 	enabled, err := ctx.GetSessionVariable(ctx, TransactionsDisabledSysVar)
@@ -371,6 +414,9 @@ func (tx *DoltTransaction) doCommit(
 	writeFn transactionWrite,
 	dbName string,
 ) (*doltdb.WorkingSet, *doltdb.Commit, error) {
+	span, ctx := ctx.Span("dsess.DoltTransaction.Commit", trace.WithAttributes(attribute.String("database", dbName)))
+	defer span.End()
+
 	sess := DSessFromSess(ctx.Session)
 	branchState, ok, err := sess.lookupDbState(ctx, dbName)
 	if err != nil {
@@ -394,6 +440,13 @@ func (tx *DoltTransaction) doCommit(
 
 	// TODO: no-op if the working set hasn't changed since the transaction started
 
+	if commit != nil || !workingAndStagedEqual(workingSet, startState) {
+		err = checkStorageQuota(ctx, startPoint.db, branchState.dbState.dbName)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	mergeOpts := branchState.EditOpts()
 
 	lockID := dbName + "\u0000" + workingSet.Ref().String()
@@ -475,12 +528,20 @@ func (tx *DoltTransaction) doCommit(
 		if err != nil {
 			return nil, nil, err
 		} else if updatedWs != nil {
+			commitMetrics.transactions.Add(1)
+			if newCommit != nil {
+				commitMetrics.doltCommits.Add(1)
+			}
 			return updatedWs, newCommit, nil
 		}
 	}
 
-	// TODO: different error type for retries exhausted
-	return nil, nil, datas.ErrOptimisticLockFailed
+	// Every attempt lost a race with another commit to the working set, which is a serialization failure like any
+	// other, so the transaction is rolled back for the statement to be retried in a new one
+	if err := tx.rollback(ctx); err != nil {
+		return nil, nil, err
+	}
+	return nil, nil, sql.ErrLockDeadlock.New(ErrRetryTransaction.Error())
 }
 
 // mergeRoots merges the roots in the existing working set with the one being committed and returns the resulting
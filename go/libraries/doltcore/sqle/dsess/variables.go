@@ -35,6 +35,8 @@ const (
 const (
 	DoltCommitOnTransactionCommit        = "dolt_transaction_commit"
 	DoltCommitOnTransactionCommitMessage = "dolt_transaction_commit_message"
+	DoltCommitAuthor                     = "dolt_commit_author"
+	DoltCommitCommitter                  = "dolt_commit_committer"
 	TransactionsDisabledSysVar           = "dolt_transactions_disabled"
 	ForceTransactionCommit               = "dolt_force_transaction_commit"
 	CurrentBatchModeKey                  = "batch_mode"
@@ -47,13 +49,45 @@ const (
 	SkipReplicationErrors                = "dolt_skip_replication_errors"
 	ReplicateHeads                       = "dolt_replicate_heads"
 	ReplicateAllHeads                    = "dolt_replicate_all_heads"
+	ReplicateIncludeBranches             = "dolt_replicate_include_branches"
+	ReplicateExcludeBranches             = "dolt_replicate_exclude_branches"
+	ReplicateIncludeTables               = "dolt_replicate_include_tables"
+	ReplicateExcludeTables               = "dolt_replicate_exclude_tables"
+	ReplicateTags                        = "dolt_replicate_tags"
 	AsyncReplication                     = "dolt_async_replication"
+	AsyncReplicationQueueSize            = "dolt_async_replication_queue_size"
+	AsyncReplicationDropPolicy           = "dolt_async_replication_drop_policy"
+	StorageQuotaBytes                    = "dolt_storage_quota"
+	DatabaseStorageQuotas                = "dolt_database_storage_quotas"
 	AwsCredsFile                         = "aws_credentials_file"
 	AwsCredsProfile                      = "aws_credentials_profile"
 	AwsCredsRegion                       = "aws_credentials_region"
 	ShowBranchDatabases                  = "dolt_show_branch_databases"
 	DoltLogLevel                         = "dolt_log_level"
 	ShowSystemTables                     = "dolt_show_system_tables"
+	OnlineIndexBuild                     = "dolt_online_index_build"
+	ScanParallelism                      = "dolt_scan_parallelism"
+	BackgroundFlush                      = "dolt_background_flush"
+	MaxResultRows                        = "dolt_max_result_rows"
+	RemoteFetchBudget                    = "dolt_remote_fetch_budget"
+	AutocommitRetries                    = "dolt_autocommit_retries"
+	ScratchDir                           = "dolt_scratch_dir"
+	ScratchQuota                         = "dolt_scratch_quota"
+	QueryLog                             = "dolt_query_log"
+	QueryLogSize                         = "dolt_query_log_size"
+	QueryLogMaxDigests                   = "dolt_query_log_max_digests"
+	ProfileInterval                      = "dolt_profile_interval"
+	ProfileTypes                         = "dolt_profile_types"
+	ProfileCPUSeconds                    = "dolt_profile_cpu_seconds"
+	ProfileDir                           = "dolt_profile_dir"
+	ProfileEndpoint                      = "dolt_profile_endpoint"
+	ProfileRetention                     = "dolt_profile_retention"
+	DatabaseTemplate                     = "dolt_database_template"
+	TransactionalDDL                     = "dolt_transactional_ddl"
+	EventBranches                        = "dolt_event_branches"
+	EventCatchUp                         = "dolt_event_catch_up"
+	EventsHistorySize                    = "dolt_events_history_size"
+	ExternalTablesDir                    = "dolt_external_tables_dir"
 
 	DoltClusterRoleVariable         = "dolt_cluster_role"
 	DoltClusterRoleEpochVariable    = "dolt_cluster_role_epoch"
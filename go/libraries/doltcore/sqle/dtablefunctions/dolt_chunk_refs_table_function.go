@@ -0,0 +1,388 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+	storetypes "github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.TableFunction = (*ChunkRefsTableFunction)(nil)
+var _ sql.ExecSourceRel = (*ChunkRefsTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*ChunkRefsTableFunction)(nil)
+
+// The kinds of objects in a commit that reference a chunk, from the outermost to the innermost.
+const (
+	// chunkRefKindCommit is a chunk that is the commit.
+	chunkRefKindCommit = "commit"
+	// chunkRefKindRoot is a chunk of the commit's root value that isn't part of a table, e.g. its table map.
+	chunkRefKindRoot = "root"
+	// chunkRefKindTable is a chunk of a table that isn't part of its rows or indexes, e.g. its schema.
+	chunkRefKindTable = "table"
+	// chunkRefKindRows is a chunk of a table's primary index, including the values stored outside of its rows.
+	chunkRefKindRows = "rows"
+	// chunkRefKindIndex is a chunk of a secondary index.
+	chunkRefKindIndex = "index"
+)
+
+var chunkRefsTableSchema = sql.Schema{
+	&sql.Column{Name: "commit_hash", Type: types.LongText, Nullable: false},
+	&sql.Column{Name: "table_name", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "index_name", Type: types.LongText, Nullable: true},
+	&sql.Column{Name: "kind", Type: types.LongText, Nullable: false},
+}
+
+// ChunkRefsTableFunction implements dolt_chunk_refs, which returns the commits, tables and indexes referencing a
+// chunk, so that large chunks found in the storage files can be traced back to the data they store:
+//
+//	dolt_chunk_refs('<chunk hash>') searches the history of every branch
+//	dolt_chunk_refs('<chunk hash>', '<revision>') searches the history of <revision>
+//
+// A chunk referenced by no commit is either garbage or only referenced by a working set.
+type ChunkRefsTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	exprs    []sql.Expression
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (crtf *ChunkRefsTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &ChunkRefsTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// RowIter implements the sql.Node interface
+func (crtf *ChunkRefsTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqlDb, ok := crtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", crtf.database)
+	}
+
+	args := make([]string, len(crtf.exprs))
+	for i, expr := range crtf.exprs {
+		v, err := expr.Eval(ctx, row)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating expression (%s): %s", expr.String(), err.Error())
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument (%v) is not a string value, but a %T", v, v)
+		}
+		args[i] = s
+	}
+
+	target, ok := hash.MaybeParse(strings.TrimSpace(args[0]))
+	if !ok {
+		return nil, fmt.Errorf("invalid chunk hash: %s", args[0])
+	}
+
+	ddb := sqlDb.DbData().Ddb
+	var itr doltdb.CommitItr[*sql.Context]
+	if len(args) == 2 {
+		sess := dsess.DSessFromSess(ctx.Session)
+		headRef, err := sess.CWBHeadRef(ctx, sqlDb.RevisionQualifiedName())
+		if err != nil {
+			return nil, err
+		}
+		cs, err := doltdb.NewCommitSpec(args[1])
+		if err != nil {
+			return nil, err
+		}
+		optCmt, err := ddb.Resolve(ctx, cs, headRef)
+		if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			return nil, doltdb.ErrGhostCommitEncountered
+		}
+		itr = doltdb.CommitItrForRoots[*sql.Context](ddb, cm)
+	} else {
+		var err error
+		if itr, err = doltdb.CommitItrForAllBranches[*sql.Context](ctx, ddb); err != nil {
+			return nil, err
+		}
+	}
+
+	search := &chunkRefSearch{
+		vrw:    ddb.ValueReadWriter(),
+		target: target,
+		memo:   make(map[hash.Hash]bool),
+		tables: make(map[hash.Hash][]chunkRef),
+	}
+	var rows []sql.Row
+	for {
+		h, optCmt, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			// the history of a shallow clone ends at a ghost commit
+			continue
+		}
+		refs, err := search.commitRefs(ctx, h, cm)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range refs {
+			rows = append(rows, sql.Row{h.String(), r.table, r.index, r.kind})
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// chunkRef is an object of a commit that references the chunk being searched for. |table| and |index| are nil
+// for objects that aren't part of a table or index.
+type chunkRef struct {
+	table interface{}
+	index interface{}
+	kind  string
+}
+
+// chunkRefSearch finds the objects of commits that reference |target|. Chunks are shared between commits, so
+// whether each chunk references |target| is only computed once.
+type chunkRefSearch struct {
+	vrw    storetypes.ValueReadWriter
+	target hash.Hash
+	// memo records whether each chunk that has been walked references |target|.
+	memo map[hash.Hash]bool
+	// tables are the refs found within each table that has been walked.
+	tables map[hash.Hash][]chunkRef
+}
+
+func (s *chunkRefSearch) commitRefs(ctx context.Context, h hash.Hash, cm *doltdb.Commit) ([]chunkRef, error) {
+	if h == s.target {
+		return []chunkRef{{kind: chunkRefKindCommit}}, nil
+	}
+
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rootHash, err := root.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := s.references(ctx, rootHash); err != nil || !ok {
+		return nil, err
+	}
+
+	var refs []chunkRef
+	err = root.IterTables(ctx, func(name doltdb.TableName, table *doltdb.Table, sch schema.Schema) (stop bool, err error) {
+		tableRefs, err := s.tableRefs(ctx, name, table, sch)
+		refs = append(refs, tableRefs...)
+		return false, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		refs = append(refs, chunkRef{kind: chunkRefKindRoot})
+	}
+	return refs, nil
+}
+
+func (s *chunkRefSearch) tableRefs(ctx context.Context, name doltdb.TableName, table *doltdb.Table, sch schema.Schema) ([]chunkRef, error) {
+	h, err := table.HashOf()
+	if err != nil {
+		return nil, err
+	}
+	if refs, ok := s.tables[h]; ok {
+		return refs, nil
+	}
+
+	var refs []chunkRef
+	if h == s.target {
+		refs = append(refs, chunkRef{table: name.String(), kind: chunkRefKindTable})
+	} else if ok, err := s.references(ctx, h); err != nil {
+		return nil, err
+	} else if ok {
+		rowData, err := table.GetRowData(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rowsHash, err := rowData.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := s.references(ctx, rowsHash); err != nil {
+			return nil, err
+		} else if ok {
+			refs = append(refs, chunkRef{table: name.String(), kind: chunkRefKindRows})
+		}
+
+		indexes, err := table.GetIndexSet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, def := range sch.Indexes().AllIndexes() {
+			idx, err := indexes.GetIndex(ctx, sch, nil, def.Name())
+			if err != nil {
+				return nil, err
+			}
+			idxHash, err := idx.HashOf()
+			if err != nil {
+				return nil, err
+			}
+			if ok, err := s.references(ctx, idxHash); err != nil {
+				return nil, err
+			} else if ok {
+				refs = append(refs, chunkRef{table: name.String(), index: def.Name(), kind: chunkRefKindIndex})
+			}
+		}
+
+		if len(refs) == 0 {
+			refs = append(refs, chunkRef{table: name.String(), kind: chunkRefKindTable})
+		}
+	}
+
+	s.tables[h] = refs
+	return refs, nil
+}
+
+// references returns whether |h| is |s.target| or transitively references it.
+func (s *chunkRefSearch) references(ctx context.Context, h hash.Hash) (bool, error) {
+	if h == s.target {
+		return true, nil
+	}
+	if found, ok := s.memo[h]; ok {
+		return found, nil
+	}
+
+	v, err := s.vrw.ReadValue(ctx, h)
+	if err != nil {
+		return false, err
+	}
+	var found bool
+	if v != nil {
+		err = storetypes.WalkAddrs(v, s.vrw.Format(), func(addr hash.Hash, _ bool) error {
+			if found {
+				return nil
+			}
+			var err error
+			found, err = s.references(ctx, addr)
+			return err
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	s.memo[h] = found
+	return found, nil
+}
+
+// Schema implements the sql.Node interface
+func (crtf *ChunkRefsTableFunction) Schema() sql.Schema {
+	return chunkRefsTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (crtf *ChunkRefsTableFunction) Resolved() bool {
+	for _, expr := range crtf.exprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (crtf *ChunkRefsTableFunction) String() string {
+	var args []string
+	for _, expr := range crtf.exprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_CHUNK_REFS(%s)", strings.Join(args, ", "))
+}
+
+// Children implements the sql.Node interface
+func (crtf *ChunkRefsTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (crtf *ChunkRefsTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return crtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (crtf *ChunkRefsTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: crtf.database.Name()}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+func (crtf *ChunkRefsTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface
+func (crtf *ChunkRefsTableFunction) Expressions() []sql.Expression {
+	return crtf.exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (crtf *ChunkRefsTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 1 || len(expression) > 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(crtf.Name(), "1 or 2", len(expression))
+	}
+
+	new := *crtf
+	new.exprs = expression
+	return &new, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (crtf *ChunkRefsTableFunction) Name() string {
+	return "dolt_chunk_refs"
+}
+
+// Database implements the sql.Databaser interface
+func (crtf *ChunkRefsTableFunction) Database() sql.Database {
+	return crtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (crtf *ChunkRefsTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	new := *crtf
+	new.database = database
+	return &new, nil
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var _ sql.TableFunction = (*DagTableFunction)(nil)
+var _ sql.ExecSourceRel = (*DagTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*DagTableFunction)(nil)
+
+const (
+	dagAllArg  = "--all"
+	dagStatArg = "--stat"
+)
+
+var dagTableSchema = sql.Schema{
+	&sql.Column{Name: "commit_hash", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "parents", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "refs", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "committer", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "email", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "date", Type: types.Datetime, Nullable: false},
+	&sql.Column{Name: "message", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "tables_changed", Type: types.Int64, Nullable: true},
+}
+
+// DagTableFunction implements dolt_dag, which returns every commit reachable from a set of revisions along with its
+// parents and refs, so that the commit graph can be rendered with a single query:
+//
+//	dolt_dag() returns the commits reachable from HEAD
+//	dolt_dag('<revision>'...) returns the commits reachable from any of the revisions
+//	dolt_dag('--all') returns the commits reachable from any branch
+//
+// With '--stat', tables_changed is the number of tables each commit added, dropped or modified relative to its
+// first parent. It is NULL without '--stat' and for commits without parents.
+type DagTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	exprs    []sql.Expression
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (dtf *DagTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &DagTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// RowIter implements the sql.Node interface
+func (dtf *DagTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqlDb, ok := dtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", dtf.database)
+	}
+
+	args, err := getDoltArgs(ctx, dtf.exprs, dtf.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	var all, stat bool
+	var revisions []string
+	for _, arg := range args {
+		switch strings.ToLower(arg) {
+		case dagAllArg:
+			all = true
+		case dagStatArg:
+			stat = true
+		default:
+			if strings.HasPrefix(arg, "-") {
+				return nil, sql.ErrInvalidArgumentDetails.New(dtf.Name(), fmt.Sprintf("unknown option %s", arg))
+			}
+			revisions = append(revisions, arg)
+		}
+	}
+	if all && len(revisions) > 0 {
+		return nil, sql.ErrInvalidArgumentDetails.New(dtf.Name(), "revisions cannot be given with --all")
+	}
+
+	ddb := sqlDb.DbData().Ddb
+	sess := dsess.DSessFromSess(ctx.Session)
+	var itr doltdb.CommitItr[*sql.Context]
+	if all {
+		if itr, err = doltdb.CommitItrForAllBranches[*sql.Context](ctx, ddb); err != nil {
+			return nil, err
+		}
+	} else if len(revisions) == 0 {
+		head, err := sess.GetHeadCommit(ctx, sqlDb.RevisionQualifiedName())
+		if err != nil {
+			return nil, err
+		}
+		itr = doltdb.CommitItrForRoots[*sql.Context](ddb, head)
+	} else {
+		headRef, err := sess.CWBHeadRef(ctx, sqlDb.RevisionQualifiedName())
+		if err != nil {
+			return nil, err
+		}
+		var commits []*doltdb.Commit
+		for _, revision := range revisions {
+			cs, err := doltdb.NewCommitSpec(revision)
+			if err != nil {
+				return nil, err
+			}
+			optCmt, err := ddb.Resolve(ctx, cs, headRef)
+			if err != nil {
+				return nil, err
+			}
+			cm, ok := optCmt.ToCommit()
+			if !ok {
+				return nil, doltdb.ErrGhostCommitEncountered
+			}
+			commits = append(commits, cm)
+		}
+		itr = doltdb.CommitItrForRoots[*sql.Context](ddb, commits...)
+	}
+
+	cHashToRefs, err := getCommitHashToRefs(ctx, ddb, "short")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for {
+		h, optCmt, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			// the history of a shallow clone ends at a ghost commit
+			continue
+		}
+
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		parents, err := getParentsString(ctx, cm)
+		if err != nil {
+			return nil, err
+		}
+
+		var tablesChanged interface{}
+		if stat && cm.NumParents() > 0 {
+			n, err := countChangedTables(ctx, cm)
+			if err != nil {
+				return nil, err
+			}
+			tablesChanged = int64(n)
+		}
+
+		rows = append(rows, sql.Row{
+			h.String(),
+			parents,
+			strings.Join(cHashToRefs[h], ", "),
+			meta.Name,
+			meta.Email,
+			meta.Time(),
+			meta.Description,
+			tablesChanged,
+		})
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// countChangedTables returns the number of tables |cm| added, dropped or modified relative to its first parent.
+// Full-Text index tables are implementation details of their parent table and are not counted.
+func countChangedTables(ctx *sql.Context, cm *doltdb.Commit) (int, error) {
+	optCmt, err := cm.GetParent(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+	parent, ok := optCmt.ToCommit()
+	if !ok {
+		return 0, doltdb.ErrGhostCommitEncountered
+	}
+
+	root, err := cm.GetRootValue(ctx)
+	if err != nil {
+		return 0, err
+	}
+	parentRoot, err := parent.GetRootValue(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	names, err := root.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return 0, err
+	}
+	parentNames, err := parentRoot.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]bool)
+	changed := 0
+	for _, name := range append(names, parentNames...) {
+		if seen[name] || doltdb.IsFullTextTable(name) {
+			continue
+		}
+		seen[name] = true
+		ok, err := didTableChangeBetweenRootValues(ctx, root, parentRoot, nil, name)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// Schema implements the sql.Node interface
+func (dtf *DagTableFunction) Schema() sql.Schema {
+	return dagTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (dtf *DagTableFunction) Resolved() bool {
+	for _, expr := range dtf.exprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (dtf *DagTableFunction) String() string {
+	var args []string
+	for _, expr := range dtf.exprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_DAG(%s)", strings.Join(args, ", "))
+}
+
+// Children implements the sql.Node interface
+func (dtf *DagTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (dtf *DagTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return dtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (dtf *DagTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: dtf.database.Name()}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+func (dtf *DagTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface
+func (dtf *DagTableFunction) Expressions() []sql.Expression {
+	return dtf.exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (dtf *DagTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	new := *dtf
+	new.exprs = expression
+	return &new, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (dtf *DagTableFunction) Name() string {
+	return "dolt_dag"
+}
+
+// Database implements the sql.Databaser interface
+func (dtf *DagTableFunction) Database() sql.Database {
+	return dtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (dtf *DagTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	new := *dtf
+	new.database = database
+	return &new, nil
+}
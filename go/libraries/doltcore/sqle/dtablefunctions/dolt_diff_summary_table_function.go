@@ -17,12 +17,14 @@ package dtablefunctions
 import (
 	"fmt"
 	"io"
+	"runtime"
 	"sort"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/types"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
@@ -32,6 +34,10 @@ import (
 
 const diffSummaryDefaultRowCount = 10
 
+// diffSummaryStatArg is the option which adds the number of rows added, deleted and modified in each table to the
+// summary. Counting them requires diffing the rows of the tables, so it isn't done by default.
+const diffSummaryStatArg = "--stat"
+
 var _ sql.TableFunction = (*DiffSummaryTableFunction)(nil)
 var _ sql.ExecSourceRel = (*DiffSummaryTableFunction)(nil)
 var _ sql.AuthorizationCheckerNode = (*DiffSummaryTableFunction)(nil)
@@ -43,6 +49,7 @@ type DiffSummaryTableFunction struct {
 	toCommitExpr   sql.Expression
 	dotCommitExpr  sql.Expression
 	tableNameExpr  sql.Expression
+	statExpr       sql.Expression
 	database       sql.Database
 }
 
@@ -54,6 +61,12 @@ var diffSummaryTableSchema = sql.Schema{
 	&sql.Column{Name: "schema_change", Type: types.Boolean, Nullable: false},
 }
 
+var diffSummaryStatTableSchema = append(diffSummaryTableSchema.Copy(),
+	&sql.Column{Name: "rows_added", Type: types.Int64, Nullable: true},
+	&sql.Column{Name: "rows_deleted", Type: types.Int64, Nullable: true},
+	&sql.Column{Name: "rows_modified", Type: types.Int64, Nullable: true},
+)
+
 // NewInstance creates a new instance of TableFunction interface
 func (ds *DiffSummaryTableFunction) NewInstance(ctx *sql.Context, db sql.Database, expressions []sql.Expression) (sql.Node, error) {
 	newInstance := &DiffSummaryTableFunction{
@@ -120,6 +133,13 @@ func (ds *DiffSummaryTableFunction) IsReadOnly() bool {
 
 // String implements the Stringer interface
 func (ds *DiffSummaryTableFunction) String() string {
+	if ds.statExpr != nil {
+		args := make([]string, len(ds.Expressions()))
+		for i, expr := range ds.Expressions() {
+			args[i] = expr.String()
+		}
+		return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s)", strings.Join(args, ", "))
+	}
 	if ds.dotCommitExpr != nil {
 		if ds.tableNameExpr != nil {
 			return fmt.Sprintf("DOLT_DIFF_SUMMARY(%s, %s)", ds.dotCommitExpr.String(), ds.tableNameExpr.String())
@@ -134,6 +154,9 @@ func (ds *DiffSummaryTableFunction) String() string {
 
 // Schema implements the sql.Node interface.
 func (ds *DiffSummaryTableFunction) Schema() sql.Schema {
+	if ds.statExpr != nil {
+		return diffSummaryStatTableSchema
+	}
 	return diffSummaryTableSchema
 }
 
@@ -191,6 +214,9 @@ func (ds *DiffSummaryTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.Pr
 // Expressions implements the sql.Expressioner interface.
 func (ds *DiffSummaryTableFunction) Expressions() []sql.Expression {
 	exprs := []sql.Expression{}
+	if ds.statExpr != nil {
+		exprs = append(exprs, ds.statExpr)
+	}
 	if ds.dotCommitExpr != nil {
 		exprs = append(exprs, ds.dotCommitExpr)
 	} else {
@@ -219,6 +245,15 @@ func (ds *DiffSummaryTableFunction) WithExpressions(exprs ...sql.Expression) (sq
 	}
 
 	newDstf := *ds
+	newDstf.statExpr = nil
+	if isDiffSummaryStatArg(exprs[0]) {
+		if len(exprs) < 2 {
+			return nil, sql.ErrInvalidArgumentNumber.New(newDstf.Name(), "2 to 4", len(exprs))
+		}
+		newDstf.statExpr = exprs[0]
+		exprs = exprs[1:]
+	}
+
 	if strings.Contains(exprs[0].String(), "..") {
 		if len(exprs) < 1 || len(exprs) > 2 {
 			return nil, sql.ErrInvalidArgumentNumber.New(newDstf.Name(), "1 or 2", len(exprs))
@@ -261,6 +296,16 @@ func (ds *DiffSummaryTableFunction) WithExpressions(exprs ...sql.Expression) (sq
 	return &newDstf, nil
 }
 
+// isDiffSummaryStatArg returns whether |expr| is the --stat option.
+func isDiffSummaryStatArg(expr sql.Expression) bool {
+	lit, ok := expr.(*expression.Literal)
+	if !ok || !types.IsText(lit.Type()) {
+		return false
+	}
+	val, ok := lit.Value().(string)
+	return ok && strings.EqualFold(val, diffSummaryStatArg)
+}
+
 // RowIter implements the sql.Node interface
 func (ds *DiffSummaryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
 	fromCommitVal, toCommitVal, dotCommitVal, tableName, err := ds.evaluateArguments()
@@ -297,25 +342,92 @@ func (ds *DiffSummaryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.
 		}
 
 		summs := []*diff.TableDeltaSummary{}
+		var counts []sql.Row
 		if summ != nil {
 			summs = []*diff.TableDeltaSummary{summ}
+			if ds.statExpr != nil {
+				c, err := getRowCountsForDelta(ctx, delta, summ)
+				if err != nil {
+					return nil, err
+				}
+				counts = []sql.Row{c}
+			}
 		}
 
-		return NewDiffSummaryTableFunctionRowIter(summs), nil
+		return NewDiffSummaryTableFunctionRowIter(summs, counts), nil
 	}
 
-	var diffSummaries []*diff.TableDeltaSummary
+	// Tables whose primary keys changed can't be summarized, which is only a warning when summarizing every table.
+	// Warnings aren't safe to add concurrently, so they're skipped before the tables are summarized.
+	var diffable []diff.TableDelta
 	for _, delta := range deltas {
-		summ, err := getSummaryForDelta(ctx, delta, sqledb, fromDetails, toDetails, false)
-		if err != nil {
-			return nil, err
+		if delta.FromTable != nil || delta.ToTable != nil || delta.FromRootObject != nil || delta.ToRootObject != nil {
+			if !schema.ArePrimaryKeySetsDiffable(delta.Format(), delta.FromSch, delta.ToSch) {
+				ctx.Warn(dtables.PrimaryKeyChangeWarningCode, dtables.PrimaryKeyChangeWarning, fromDetails.hashStr, toDetails.hashStr)
+				continue
+			}
 		}
+		diffable = append(diffable, delta)
+	}
+
+	// Each table is summarized independently, so they're summarized concurrently.
+	summs := make([]*diff.TableDeltaSummary, len(diffable))
+	var counts []sql.Row
+	if ds.statExpr != nil {
+		counts = make([]sql.Row, len(diffable))
+	}
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(runtime.GOMAXPROCS(0))
+	for i, delta := range diffable {
+		eg.Go(func() error {
+			tblCtx := ctx.WithContext(egCtx)
+			summ, err := getSummaryForDelta(tblCtx, delta, sqledb, fromDetails, toDetails, false)
+			if err != nil || summ == nil {
+				return err
+			}
+			summs[i] = summ
+			if counts != nil {
+				counts[i], err = getRowCountsForDelta(tblCtx, delta, summ)
+			}
+			return err
+		})
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	var diffSummaries []*diff.TableDeltaSummary
+	var diffCounts []sql.Row
+	for i, summ := range summs {
 		if summ != nil {
 			diffSummaries = append(diffSummaries, summ)
+			if counts != nil {
+				diffCounts = append(diffCounts, counts[i])
+			}
 		}
 	}
 
-	return NewDiffSummaryTableFunctionRowIter(diffSummaries), nil
+	return NewDiffSummaryTableFunctionRowIter(diffSummaries, diffCounts), nil
+}
+
+// getRowCountsForDelta returns the rows_added, rows_deleted and rows_modified columns for the table diff summary
+// |summ| of |delta|. They're null for deltas which aren't tables, and rows_modified is null for keyless tables, which
+// can't tell a modified row from a deleted and an added row.
+func getRowCountsForDelta(ctx *sql.Context, delta diff.TableDelta, summ *diff.TableDeltaSummary) (sql.Row, error) {
+	if delta.FromTable == nil && delta.ToTable == nil {
+		return sql.Row{nil, nil, nil}, nil
+	}
+	if !summ.DataChange {
+		return sql.Row{int64(0), int64(0), int64(0)}, nil
+	}
+	dsp, _, keyless, err := getDiffStat(ctx, delta)
+	if err != nil {
+		return nil, err
+	}
+	if keyless {
+		return sql.Row{int64(dsp.Adds), int64(dsp.Removes), nil}, nil
+	}
+	return sql.Row{int64(dsp.Adds), int64(dsp.Removes), int64(dsp.Changes)}, nil
 }
 
 func getSummaryForDelta(ctx *sql.Context, delta diff.TableDelta, sqledb dsess.SqlDatabase, fromDetails, toDetails *refDetails, shouldErrorOnPKChange bool) (*diff.TableDeltaSummary, error) {
@@ -394,7 +506,9 @@ var _ sql.RowIter = &diffSummaryTableFunctionRowIter{}
 
 type diffSummaryTableFunctionRowIter struct {
 	summaries []*diff.TableDeltaSummary
-	diffIdx   int
+	// counts are the row counts of each summary, if they were requested with the --stat option.
+	counts  []sql.Row
+	diffIdx int
 }
 
 func (d *diffSummaryTableFunctionRowIter) incrementIndexes() {
@@ -405,9 +519,10 @@ func (d *diffSummaryTableFunctionRowIter) incrementIndexes() {
 	}
 }
 
-func NewDiffSummaryTableFunctionRowIter(ds []*diff.TableDeltaSummary) sql.RowIter {
+func NewDiffSummaryTableFunctionRowIter(ds []*diff.TableDeltaSummary, counts []sql.Row) sql.RowIter {
 	return &diffSummaryTableFunctionRowIter{
 		summaries: ds,
+		counts:    counts,
 	}
 }
 
@@ -422,6 +537,9 @@ func (d *diffSummaryTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error
 	}
 
 	ds := d.summaries[d.diffIdx]
+	if d.counts != nil {
+		return append(getRowFromSummary(ds), d.counts[d.diffIdx]...), nil
+	}
 	return getRowFromSummary(ds), nil
 }
 
@@ -0,0 +1,259 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+var _ sql.TableFunction = (*EstimateDiffTableFunction)(nil)
+var _ sql.ExecSourceRel = (*EstimateDiffTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*EstimateDiffTableFunction)(nil)
+
+// estimateDiffMaxNodes is the most nodes of each table's row data read to estimate its diff. Each node holds a few
+// hundred rows or subtrees, so the estimate of a table stays cheap no matter how large it is.
+const estimateDiffMaxNodes = 512
+
+var estimateDiffTableSchema = sql.Schema{
+	&sql.Column{Name: "table_name", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "from_row_count", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "to_row_count", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_changed_lower_bound", Type: types.Int64, Nullable: false},
+	&sql.Column{Name: "rows_changed_upper_bound", Type: types.Int64, Nullable: false},
+}
+
+// EstimateDiffTableFunction implements dolt_estimate_diff, which bounds the number of rows added, deleted or modified
+// in each table between two revisions without diffing their rows:
+//
+//	dolt_estimate_diff('<from_revision>', '<to_revision>') returns a row for each table which changed
+//	dolt_estimate_diff('<from_revision>', '<to_revision>', '<table>') returns a row for the table if it changed
+//
+// The row data of a table at both revisions is compared a level of its tree at a time, skipping the subtrees they
+// share, until the leaf nodes are reached or estimateDiffMaxNodes would be exceeded. The bounds are exact for tables
+// which were added or dropped, and otherwise narrow the further the comparison got.
+type EstimateDiffTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	exprs    []sql.Expression
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (dtf *EstimateDiffTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &EstimateDiffTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// RowIter implements the sql.Node interface
+func (dtf *EstimateDiffTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqlDb, ok := dtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", dtf.database)
+	}
+
+	args, err := getDoltArgs(ctx, dtf.exprs, dtf.Name())
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != len(dtf.exprs) {
+		return nil, sql.ErrInvalidArgumentDetails.New(dtf.Name(), "arguments cannot be null")
+	}
+
+	fromDetails, toDetails, err := loadDetailsForRefs(ctx, args[0], args[1], nil, sqlDb)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromDetails.root, toDetails.root)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 3 {
+		deltas = []diff.TableDelta{findMatchingDelta(deltas, args[2])}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	var rows []sql.Row
+	for _, delta := range deltas {
+		if delta.FromTable == nil && delta.ToTable == nil {
+			continue
+		}
+		r, err := estimateTableDiff(ctx, delta)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			rows = append(rows, r)
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// estimateTableDiff returns the row of dolt_estimate_diff for |delta|, or nil if its row data didn't change.
+func estimateTableDiff(ctx *sql.Context, delta diff.TableDelta) (sql.Row, error) {
+	from, fromCount, err := getRowDataForEstimate(ctx, delta.FromTable)
+	if err != nil {
+		return nil, err
+	}
+	to, toCount, err := getRowDataForEstimate(ctx, delta.ToTable)
+	if err != nil {
+		return nil, err
+	}
+
+	var lower, upper uint64
+	if delta.FromTable != nil && delta.ToTable != nil {
+		if from.HashOf() == to.HashOf() {
+			return nil, nil
+		}
+		est, err := tree.EstimateDiff(ctx, to.NodeStore(), from.Node(), to.Node(), estimateDiffMaxNodes)
+		if err != nil {
+			return nil, err
+		}
+		lower, upper = est.LowerBound(), est.UpperBound()
+	} else {
+		// every row of an added or dropped table changed
+		lower = uint64(fromCount + toCount)
+		upper = lower
+	}
+
+	return sql.Row{
+		delta.CurName(),
+		int64(fromCount),
+		int64(toCount),
+		int64(lower),
+		int64(upper),
+	}, nil
+}
+
+func getRowDataForEstimate(ctx *sql.Context, tbl *doltdb.Table) (prolly.Map, int, error) {
+	if tbl == nil {
+		return prolly.Map{}, 0, nil
+	}
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+	m, err := durable.ProllyMapFromIndex(idx)
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+	cnt, err := m.Count()
+	if err != nil {
+		return prolly.Map{}, 0, err
+	}
+	return m, cnt, nil
+}
+
+// Schema implements the sql.Node interface
+func (dtf *EstimateDiffTableFunction) Schema() sql.Schema {
+	return estimateDiffTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (dtf *EstimateDiffTableFunction) Resolved() bool {
+	for _, expr := range dtf.exprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (dtf *EstimateDiffTableFunction) String() string {
+	var args []string
+	for _, expr := range dtf.exprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_ESTIMATE_DIFF(%s)", strings.Join(args, ", "))
+}
+
+// Children implements the sql.Node interface
+func (dtf *EstimateDiffTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (dtf *EstimateDiffTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return dtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (dtf *EstimateDiffTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: dtf.database.Name()}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+func (dtf *EstimateDiffTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface
+func (dtf *EstimateDiffTableFunction) Expressions() []sql.Expression {
+	return dtf.exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (dtf *EstimateDiffTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 2 || len(expression) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(dtf.Name(), "2 or 3", len(expression))
+	}
+	new := *dtf
+	new.exprs = expression
+	return &new, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (dtf *EstimateDiffTableFunction) Name() string {
+	return "dolt_estimate_diff"
+}
+
+// Database implements the sql.Databaser interface
+func (dtf *EstimateDiffTableFunction) Database() sql.Database {
+	return dtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (dtf *EstimateDiffTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	new := *dtf
+	new.database = database
+	return &new, nil
+}
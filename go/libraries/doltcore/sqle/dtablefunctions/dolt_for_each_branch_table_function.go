@@ -0,0 +1,498 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+const forEachBranchDefaultRowCount = 100
+
+// forEachBranchCachedRows is the number of rows dolt_for_each_branch keeps to return again for branches whose tables
+// read by the query are the same as an earlier branch's. The query is run again for branches whose rows weren't kept.
+const forEachBranchCachedRows = 10_000
+
+var _ sql.TableFunction = (*ForEachBranchTableFunction)(nil)
+var _ sql.CatalogTableFunction = (*ForEachBranchTableFunction)(nil)
+var _ sql.ExecSourceRel = (*ForEachBranchTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*ForEachBranchTableFunction)(nil)
+
+// ForEachBranchTableFunction implements the dolt_for_each_branch table function, which runs a SELECT query against the
+// working set of every branch of the database, and returns its rows prefixed by the name of the branch they came from.
+// Unqualified table names in the query resolve to the tables of each branch.
+//
+// Tables are content addressed, so branches whose tables read by the query have the same hash, such as branches which
+// haven't changed them since they were created, share the same subtrees and get the same rows. The query is only run
+// once for each distinct set of these tables, rather than once for each branch, as long as its rows fit in
+// forEachBranchCachedRows. Rows are returned as they're read, one branch at a time.
+type ForEachBranchTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	sqlSch   sql.Schema
+	query    sql.Expression
+
+	runner queryAnalyzer
+	qStr   string
+	parsed sqlparser.Statement
+	// tables are the tables of the database read by the query.
+	tables []string
+	// perBranch is true if the rows of the query may depend on the branch beyond the tables it reads, in which case
+	// it's run against every branch.
+	perBranch bool
+}
+
+// queryAnalyzer is the engine serving the session, which analyzes and runs the query of dolt_for_each_branch so that
+// it's authorized against the calling user's privileges.
+type queryAnalyzer interface {
+	sql.StatementRunner
+	AnalyzeQuery(ctx *sql.Context, query string) (sql.Node, error)
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (tf *ForEachBranchTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &ForEachBranchTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// WithCatalog implements the sql.CatalogTableFunction interface
+func (tf *ForEachBranchTableFunction) WithCatalog(c sql.Catalog) (sql.TableFunction, error) {
+	newInstance := *tf
+	runner, ok := dsess.DSessFromSess(tf.ctx.Session).Provider().StatementRunner().(queryAnalyzer)
+	if !ok {
+		return nil, fmt.Errorf("%s can't be run without a SQL engine", tf.Name())
+	}
+	newInstance.runner = runner
+	err := newInstance.analyzeQuery()
+	if err != nil {
+		return nil, err
+	}
+	return &newInstance, nil
+}
+
+// analyzeQuery analyzes the query against the current branch to get its schema and the tables it reads.
+func (tf *ForEachBranchTableFunction) analyzeQuery() error {
+	q, err := tf.query.Eval(tf.ctx, nil)
+	if err != nil {
+		return err
+	}
+	qStr, isStr := q.(string)
+	if !isStr {
+		return fmt.Errorf("query must be a string, not %T", q)
+	}
+	parsed, err := sqlparser.ParseWithOptions(tf.ctx, qStr, sql.LoadSqlMode(tf.ctx).ParserOptions())
+	if err != nil {
+		return err
+	}
+	// SELECT ... INTO writes to variables or files, so isn't allowed either
+	if s, ok := parsed.(sqlparser.SelectStatement); !ok || s.GetInto() != nil {
+		return fmt.Errorf("query must be a SELECT statement")
+	}
+	node, err := tf.runner.AnalyzeQuery(tf.ctx, qStr)
+	if err != nil {
+		return err
+	}
+
+	tf.qStr = qStr
+	tf.parsed = parsed
+	tf.tables, tf.perBranch = tablesRead(node, tf.baseName())
+	tf.sqlSch = sql.Schema{{Name: "branch", Type: gmstypes.Text, Source: tf.Name(), Nullable: false}}
+	for _, col := range node.Schema() {
+		c := col.Copy()
+		c.Source = tf.Name()
+		c.PrimaryKey = false
+		tf.sqlSch = append(tf.sqlSch, c)
+	}
+	return nil
+}
+
+// branchFunctions are the functions whose results depend on the branch of the database they're run against.
+var branchFunctions = map[string]struct{}{
+	"active_branch": {},
+	"database":      {},
+	"schema":        {},
+}
+
+// tablesRead returns the names of the tables of the database |dbName| read by |node|, and whether its rows may depend
+// on the branch beyond these tables, such as when it reads system tables or calls table functions or functions which
+// look at the branch.
+func tablesRead(node sql.Node, dbName string) ([]string, bool) {
+	seen := make(map[string]struct{})
+	var tables []string
+	var perBranch bool
+	var inspect func(n sql.Node)
+	inspect = func(n sql.Node) {
+		transform.Inspect(n, func(n sql.Node) bool {
+			switch n := n.(type) {
+			case nil:
+				return false
+			case sql.TableFunction:
+				perBranch = true
+				return false
+			case sql.TableNode:
+				if n.Database() == nil {
+					return true
+				}
+				base, _ := dsess.SplitRevisionDbName(n.Database().Name())
+				if !strings.EqualFold(base, dbName) {
+					return true
+				}
+				if doltdb.HasDoltPrefix(n.Name()) {
+					perBranch = true
+				}
+				if _, ok := seen[strings.ToLower(n.Name())]; !ok {
+					seen[strings.ToLower(n.Name())] = struct{}{}
+					tables = append(tables, n.Name())
+				}
+			}
+			return true
+		})
+		transform.InspectExpressions(n, func(e sql.Expression) bool {
+			switch e := e.(type) {
+			case *plan.Subquery:
+				inspect(e.Query)
+			case sql.FunctionExpression:
+				name := strings.ToLower(e.FunctionName())
+				if _, ok := branchFunctions[name]; ok || doltdb.HasDoltPrefix(name) {
+					perBranch = true
+				}
+			}
+			return true
+		})
+	}
+	inspect(node)
+	sort.Strings(tables)
+	return tables, perBranch
+}
+
+// baseName returns the name of the database without its revision.
+func (tf *ForEachBranchTableFunction) baseName() string {
+	if sqlDb, ok := tf.database.(dsess.SqlDatabase); ok {
+		base, _ := dsess.SplitRevisionDbName(sqlDb.RevisionQualifiedName())
+		return base
+	}
+	return tf.database.Name()
+}
+
+// rootKey returns a key identifying the tables read by the query in |root|, which is the same for any two roots whose
+// tables read by the query are the same. View definitions are stored in the dolt_schemas table, so it's always part
+// of the key.
+func (tf *ForEachBranchTableFunction) rootKey(ctx *sql.Context, root doltdb.RootValue) (string, error) {
+	var sb strings.Builder
+	for _, name := range append([]string{doltdb.SchemasTableName}, tf.tables...) {
+		resolved, ok, err := root.ResolveTableName(ctx, doltdb.TableName{Name: name})
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(strings.ToLower(name))
+		sb.WriteByte(':')
+		if ok {
+			h, _, err := root.GetTableHash(ctx, doltdb.TableName{Name: resolved})
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(h.String())
+		}
+		sb.WriteByte(';')
+	}
+	return sb.String(), nil
+}
+
+// RowIter implements the sql.Node interface
+func (tf *ForEachBranchTableFunction) RowIter(ctx *sql.Context, _ sql.Row) (sql.RowIter, error) {
+	sqlDb, ok := tf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", tf.database)
+	}
+	branches, err := sqlDb.DbData().Ddb.GetBranches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(branches))
+	for i, branch := range branches {
+		names[i] = branch.GetPath()
+	}
+	sort.Strings(names)
+
+	return &forEachBranchIter{
+		tf:       tf,
+		baseName: tf.baseName(),
+		branches: names,
+		results:  make(map[string][]sql.Row),
+	}, nil
+}
+
+// forEachBranchIter returns the rows of the query of a ForEachBranchTableFunction for each branch in turn, reading
+// them from the query's iterator as they're returned.
+type forEachBranchIter struct {
+	tf       *ForEachBranchTableFunction
+	baseName string
+	branches []string
+	next     int
+
+	// branch and revisionDb are the branch whose rows are being returned, and its revision database.
+	branch     string
+	revisionDb string
+	// iter is the iterator of the query run against the current branch, nil if the branch's rows are cached.
+	iter sql.RowIter
+	// cached are the remaining rows of the current branch, when they were cached for an earlier branch.
+	cached    []sql.Row
+	replaying bool
+
+	// key is the root key of the current branch, see rootKey. Rows read from |iter| are kept in |buf| while
+	// |buffering| is set, and kept in |results| by key once the branch is done.
+	key       string
+	buf       []sql.Row
+	buffering bool
+	results   map[string][]sql.Row
+	// kept is the number of rows kept in |results| and |buf|.
+	kept int
+}
+
+var _ sql.RowIter = (*forEachBranchIter)(nil)
+
+// Next implements the sql.RowIter interface
+func (it *forEachBranchIter) Next(ctx *sql.Context) (sql.Row, error) {
+	for {
+		if it.replaying {
+			if len(it.cached) > 0 {
+				row := it.cached[0]
+				it.cached = it.cached[1:]
+				return append(sql.Row{it.branch}, row...), nil
+			}
+			it.replaying = false
+			continue
+		}
+
+		if it.iter != nil {
+			var row sql.Row
+			err := it.onBranch(ctx, func() (err error) {
+				row, err = it.iter.Next(ctx)
+				return err
+			})
+			if err == io.EOF {
+				if err = it.closeBranch(ctx); err != nil {
+					return nil, err
+				}
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			if len(row) != len(it.tf.sqlSch)-1 {
+				return nil, fmt.Errorf("query returns %d columns on database %s, but %d on the current branch", len(row), it.revisionDb, len(it.tf.sqlSch)-1)
+			}
+			if it.buffering {
+				if it.kept < forEachBranchCachedRows {
+					it.buf = append(it.buf, row)
+					it.kept++
+				} else {
+					it.kept -= len(it.buf)
+					it.buf, it.buffering = nil, false
+				}
+			}
+			return append(sql.Row{it.branch}, row...), nil
+		}
+
+		if it.next >= len(it.branches) {
+			return nil, io.EOF
+		}
+		if err := it.startBranch(ctx, it.branches[it.next]); err != nil {
+			return nil, err
+		}
+		it.next++
+	}
+}
+
+// startBranch starts returning the rows of |branch|, from the cached rows of an earlier branch whose tables read by
+// the query are the same, or by running the query against it.
+func (it *forEachBranchIter) startBranch(ctx *sql.Context, branch string) error {
+	it.branch = branch
+	it.revisionDb = dsess.RevisionDbName(it.baseName, branch)
+	it.key = ""
+	if !it.tf.perBranch {
+		roots, ok := dsess.DSessFromSess(ctx.Session).GetRoots(ctx, it.revisionDb)
+		if !ok {
+			return sql.ErrDatabaseNotFound.New(it.revisionDb)
+		}
+		key, err := it.tf.rootKey(ctx, roots.Working)
+		if err != nil {
+			return err
+		}
+		if rows, ok := it.results[key]; ok {
+			it.cached, it.replaying = rows, true
+			return nil
+		}
+		it.key = key
+	}
+
+	it.buf, it.buffering = nil, !it.tf.perBranch
+	return it.onBranch(ctx, func() (err error) {
+		_, it.iter, _, err = it.tf.runner.QueryWithBindings(ctx, it.tf.qStr, it.tf.parsed, nil, nil)
+		return err
+	})
+}
+
+// closeBranch closes the iterator of the current branch, and keeps its rows for later branches if they were all kept.
+func (it *forEachBranchIter) closeBranch(ctx *sql.Context) error {
+	iter := it.iter
+	it.iter = nil
+	if it.buffering {
+		it.results[it.key] = it.buf
+	}
+	it.buf, it.buffering = nil, false
+	return it.onBranch(ctx, func() error {
+		return iter.Close(ctx)
+	})
+}
+
+// onBranch runs |f| with the revision database of the current branch as the current database, which functions like
+// active_branch() read as rows are returned.
+func (it *forEachBranchIter) onBranch(ctx *sql.Context, f func() error) error {
+	currentDb := ctx.GetCurrentDatabase()
+	ctx.SetCurrentDatabase(it.revisionDb)
+	defer ctx.SetCurrentDatabase(currentDb)
+	return f()
+}
+
+// Close implements the sql.RowIter interface
+func (it *forEachBranchIter) Close(ctx *sql.Context) error {
+	if it.iter == nil {
+		return nil
+	}
+	iter := it.iter
+	it.iter = nil
+	return it.onBranch(ctx, func() error {
+		return iter.Close(ctx)
+	})
+}
+
+func (tf *ForEachBranchTableFunction) DataLength(ctx *sql.Context) (uint64, error) {
+	numBytesPerRow := schema.SchemaAvgLength(tf.Schema())
+	numRows, _, err := tf.RowCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return numBytesPerRow * numRows, nil
+}
+
+func (tf *ForEachBranchTableFunction) RowCount(_ *sql.Context) (uint64, bool, error) {
+	return forEachBranchDefaultRowCount, false, nil
+}
+
+// Database implements the sql.Databaser interface
+func (tf *ForEachBranchTableFunction) Database() sql.Database {
+	return tf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (tf *ForEachBranchTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	ntf := *tf
+	ntf.database = database
+	return &ntf, nil
+}
+
+// Expressions implements the sql.Expressioner interface
+func (tf *ForEachBranchTableFunction) Expressions() []sql.Expression {
+	return []sql.Expression{tf.query}
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (tf *ForEachBranchTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New(tf.Name(), "1", len(expression))
+	}
+
+	expr := expression[0]
+	if !expr.Resolved() {
+		return nil, ErrInvalidNonLiteralArgument.New(tf.Name(), expr.String())
+	}
+	// prepared statements resolve functions beforehand, so above check fails
+	if _, ok := expr.(sql.FunctionExpression); ok {
+		return nil, ErrInvalidNonLiteralArgument.New(tf.Name(), expr.String())
+	}
+
+	ntf := *tf
+	ntf.query = expr
+	return &ntf, nil
+}
+
+// Children implements the sql.Node interface
+func (tf *ForEachBranchTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (tf *ForEachBranchTableFunction) WithChildren(node ...sql.Node) (sql.Node, error) {
+	if len(node) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return tf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (tf *ForEachBranchTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: tf.database.Name()}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+// Schema implements the sql.Node interface
+func (tf *ForEachBranchTableFunction) Schema() sql.Schema {
+	if !tf.Resolved() {
+		return nil
+	}
+	if tf.sqlSch == nil {
+		panic("schema hasn't been generated yet")
+	}
+	return tf.sqlSch
+}
+
+// Resolved implements the sql.Resolvable interface
+func (tf *ForEachBranchTableFunction) Resolved() bool {
+	return tf.query.Resolved()
+}
+
+func (tf *ForEachBranchTableFunction) IsReadOnly() bool {
+	// Like dolt_query_diff, the query is checked to be a SELECT when it's analyzed.
+	return true
+}
+
+// String implements the Stringer interface
+func (tf *ForEachBranchTableFunction) String() string {
+	return fmt.Sprintf("DOLT_FOR_EACH_BRANCH('%s')", tf.query.String())
+}
+
+// Name implements the sql.TableFunction interface
+func (tf *ForEachBranchTableFunction) Name() string {
+	return "dolt_for_each_branch"
+}
@@ -49,6 +49,7 @@ type LogTableFunction struct {
 	minParents    int
 	showParents   bool
 	showSignature bool
+	showCommitter bool
 	decoration    string
 
 	database sql.Database
@@ -149,6 +150,10 @@ func (ltf *LogTableFunction) getOptionsString() string {
 		options = append(options, fmt.Sprintf("--%s", cli.ShowSignatureFlag))
 	}
 
+	if ltf.showCommitter {
+		options = append(options, fmt.Sprintf("--%s", cli.ShowCommitterFlag))
+	}
+
 	if len(ltf.decoration) > 0 && ltf.decoration != "auto" {
 		options = append(options, fmt.Sprintf("--%s %s", cli.DecorateFlag, ltf.decoration))
 	}
@@ -173,6 +178,11 @@ func (ltf *LogTableFunction) Schema() sql.Schema {
 	if ltf.showSignature {
 		logSchema = append(logSchema, &sql.Column{Name: "signature", Type: types.Text})
 	}
+	if ltf.showCommitter {
+		logSchema = append(logSchema,
+			&sql.Column{Name: "committed_by", Type: types.Text},
+			&sql.Column{Name: "committed_by_email", Type: types.Text})
+	}
 
 	return logSchema
 }
@@ -266,6 +276,7 @@ func (ltf *LogTableFunction) addOptions(expression []sql.Expression) error {
 	ltf.minParents = minParents
 	ltf.showParents = apr.Contains(cli.ParentsFlag)
 	ltf.showSignature = apr.Contains(cli.ShowSignatureFlag)
+	ltf.showCommitter = apr.Contains(cli.ShowCommitterFlag)
 
 	decorateOption := apr.GetValueOrDefault(cli.DecorateFlag, "auto")
 	switch decorateOption {
@@ -609,6 +620,7 @@ type logTableFunctionRowIter struct {
 	child         doltdb.CommitItr[*sql.Context]
 	showParents   bool
 	showSignature bool
+	showCommitter bool
 	decoration    string
 	cHashToRefs   map[hash.Hash][]string
 	headHash      hash.Hash
@@ -631,6 +643,7 @@ func (ltf *LogTableFunction) NewLogTableFunctionRowIter(ctx *sql.Context, ddb *d
 		child:         child,
 		showParents:   ltf.showParents,
 		showSignature: ltf.showSignature,
+		showCommitter: ltf.showCommitter,
 		decoration:    ltf.decoration,
 		cHashToRefs:   cHashToRefs,
 		headHash:      h,
@@ -672,6 +685,7 @@ func (ltf *LogTableFunction) NewDotDotLogTableFunctionRowIter(ctx *sql.Context,
 		child:         child,
 		showParents:   ltf.showParents,
 		showSignature: ltf.showSignature,
+		showCommitter: ltf.showCommitter,
 		decoration:    ltf.decoration,
 		cHashToRefs:   cHashToRefs,
 		headHash:      headHash,
@@ -793,6 +807,11 @@ func (itr *logTableFunctionRowIter) Next(ctx *sql.Context) (sql.Row, error) {
 		}
 	}
 
+	if itr.showCommitter {
+		name, email := meta.Committer()
+		row = row.Append(sql.NewRow(name, email))
+	}
+
 	return row, nil
 }
 
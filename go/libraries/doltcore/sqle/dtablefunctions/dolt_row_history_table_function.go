@@ -0,0 +1,388 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+var _ sql.TableFunction = (*RowHistoryTableFunction)(nil)
+var _ sql.ExecSourceRel = (*RowHistoryTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*RowHistoryTableFunction)(nil)
+
+// RowHistoryTableFunction implements dolt_row_history, which returns every version of a single row of a table in the
+// history of HEAD. The row is named by the values of its primary key columns, in primary key order:
+//
+//	dolt_row_history('<table>', <primary key value>...)
+//
+// Like dolt_history_<table>, rows have the columns of the table in the working set followed by commit_hash, committer
+// and commit_date, and there is a row for every commit in which the row exists. Columns which didn't exist at a
+// commit, or had another type, are NULL. Rather than scanning the table at every commit, the row is looked up by its
+// primary key, and only for commits which changed the table.
+type RowHistoryTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	exprs    []sql.Expression
+
+	tableName string
+	sch       schema.Schema
+	sqlSch    sql.Schema
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (rtf *RowHistoryTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &RowHistoryTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// RowIter implements the sql.Node interface
+func (rtf *RowHistoryTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sqlDb, ok := rtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return nil, fmt.Errorf("unexpected database type: %T", rtf.database)
+	}
+
+	key, err := rtf.evaluateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	head, err := sess.GetHeadCommit(ctx, sqlDb.RevisionQualifiedName())
+	if err != nil {
+		return nil, err
+	}
+	itr := doltdb.CommitItrForRoots[*sql.Context](sqlDb.DbData().Ddb, head)
+
+	// the version of the row in the last table looked up, which commits that didn't change the table share
+	var lastTable hash.Hash
+	var version sql.Row
+
+	var rows []sql.Row
+	for {
+		h, optCmt, err := itr.Next(ctx)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		cm, ok := optCmt.ToCommit()
+		if !ok {
+			// the history of a shallow clone ends at a ghost commit
+			continue
+		}
+
+		root, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tbl, ok, err := root.GetTable(ctx, doltdb.TableName{Name: rtf.tableName})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		tblHash, err := tbl.HashOf()
+		if err != nil {
+			return nil, err
+		}
+		if tblHash != lastTable {
+			if version, err = rtf.lookupRow(ctx, tbl, key); err != nil {
+				return nil, err
+			}
+			lastTable = tblHash
+		}
+		if version == nil {
+			continue
+		}
+
+		meta, err := cm.GetCommitMeta(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r := make(sql.Row, 0, len(rtf.sqlSch))
+		r = append(r, version...)
+		rows = append(rows, append(r, h.String(), meta.Name, meta.Time()))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// evaluateKey returns the primary key values of the row, converted to the types of the primary key columns of the
+// table in the working set.
+func (rtf *RowHistoryTableFunction) evaluateKey(ctx *sql.Context) ([]interface{}, error) {
+	pkCols := rtf.sch.GetPKCols().GetColumns()
+	key := make([]interface{}, len(pkCols))
+	for i, col := range pkCols {
+		v, err := rtf.exprs[i+1].Eval(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, sql.ErrInvalidArgumentDetails.New(rtf.Name(), fmt.Sprintf("primary key column %s cannot be NULL", col.Name))
+		}
+		v, inRange, err := col.TypeInfo.ToSqlType().Convert(ctx, v)
+		if err != nil {
+			return nil, err
+		} else if !inRange {
+			return nil, sql.ErrInvalidArgumentDetails.New(rtf.Name(), fmt.Sprintf("%v is out of range for primary key column %s", v, col.Name))
+		}
+		key[i] = v
+	}
+	return key, nil
+}
+
+// lookupRow returns the row of |tbl| with the primary key |key| in the schema of the table in the working set, or nil
+// if |tbl| doesn't have it. Key values which can't be converted to the primary key of |tbl| aren't in it.
+func (rtf *RowHistoryTableFunction) lookupRow(ctx *sql.Context, tbl *doltdb.Table, key []interface{}) (sql.Row, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pkCols := sch.GetPKCols()
+	if schema.IsKeyless(sch) || pkCols.Size() != len(key) {
+		return nil, nil
+	}
+
+	idx, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := durable.ProllyMapFromIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	ns := m.NodeStore()
+	kd, vd := m.Descriptors()
+
+	tb := val.NewTupleBuilder(kd, ns)
+	for i, col := range pkCols.GetColumns() {
+		v, inRange, err := col.TypeInfo.ToSqlType().Convert(ctx, key[i])
+		if err != nil || !inRange {
+			return nil, nil
+		}
+		if err = tree.PutField(ctx, ns, tb, i, v); err != nil {
+			return nil, err
+		}
+	}
+	k, err := tb.Build(ns.Pool())
+	if err != nil {
+		return nil, err
+	}
+
+	var v val.Tuple
+	err = m.Get(ctx, k, func(key, value val.Tuple) error {
+		if key != nil {
+			v = value
+		}
+		return nil
+	})
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	row := make(sql.Row, len(rtf.sqlSch)-3)
+	for i, col := range rtf.sch.GetAllCols().GetColumns() {
+		histCol, ok := sch.GetAllCols().GetByNameCaseInsensitive(col.Name)
+		if !ok || histCol.Virtual || !histCol.TypeInfo.Equals(col.TypeInfo) {
+			continue
+		}
+		if j, ok := pkCols.TagToIdx[histCol.Tag]; ok {
+			row[i], err = tree.GetField(ctx, kd, j, k, ns)
+		} else if j, ok := sch.GetNonPKCols().StoredIndexByTag(histCol.Tag); ok {
+			row[i], err = tree.GetField(ctx, vd, j, v, ns)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// Schema implements the sql.Node interface
+func (rtf *RowHistoryTableFunction) Schema() sql.Schema {
+	return rtf.sqlSch
+}
+
+// Resolved implements the sql.Resolvable interface
+func (rtf *RowHistoryTableFunction) Resolved() bool {
+	for _, expr := range rtf.exprs {
+		if !expr.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface
+func (rtf *RowHistoryTableFunction) String() string {
+	var args []string
+	for _, expr := range rtf.exprs {
+		args = append(args, expr.String())
+	}
+	return fmt.Sprintf("DOLT_ROW_HISTORY(%s)", strings.Join(args, ", "))
+}
+
+// Children implements the sql.Node interface
+func (rtf *RowHistoryTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (rtf *RowHistoryTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return rtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode.
+func (rtf *RowHistoryTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	subject := sql.PrivilegeCheckSubject{Database: rtf.database.Name(), Table: rtf.tableName}
+	return opChecker.UserHasPrivileges(ctx, sql.NewPrivilegedOperation(subject, sql.PrivilegeType_Select))
+}
+
+func (rtf *RowHistoryTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface
+func (rtf *RowHistoryTableFunction) Expressions() []sql.Expression {
+	return rtf.exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (rtf *RowHistoryTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(rtf.Name(), "at least 2", len(expression))
+	}
+
+	// the schema depends on the table, so like dolt_diff only literal arguments are supported
+	for _, expr := range expression {
+		if !expr.Resolved() {
+			return nil, ErrInvalidNonLiteralArgument.New(rtf.Name(), expr.String())
+		}
+		// prepared statements resolve functions beforehand, so above check fails
+		if _, ok := expr.(sql.FunctionExpression); ok {
+			return nil, ErrInvalidNonLiteralArgument.New(rtf.Name(), expr.String())
+		}
+	}
+
+	newRtf := *rtf
+	newRtf.exprs = expression
+	if err := newRtf.generateSchema(rtf.ctx); err != nil {
+		return nil, err
+	}
+	return &newRtf, nil
+}
+
+// generateSchema resolves the table named by the first argument in the working set, and sets the schema of the
+// results from its schema.
+func (rtf *RowHistoryTableFunction) generateSchema(ctx *sql.Context) error {
+	if !types.IsText(rtf.exprs[0].Type()) {
+		return sql.ErrInvalidArgumentDetails.New(rtf.Name(), rtf.exprs[0].String())
+	}
+	tableNameVal, err := rtf.exprs[0].Eval(ctx, nil)
+	if err != nil {
+		return err
+	}
+	tableName, ok := tableNameVal.(string)
+	if !ok {
+		return ErrInvalidTableName.New(rtf.exprs[0].String())
+	}
+
+	sqlDb, ok := rtf.database.(dsess.SqlDatabase)
+	if !ok {
+		return fmt.Errorf("unexpected database type: %T", rtf.database)
+	}
+	sess := dsess.DSessFromSess(ctx.Session)
+	roots, ok := sess.GetRoots(ctx, sqlDb.RevisionQualifiedName())
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(sqlDb.Name())
+	}
+	tbl, name, ok, err := doltdb.GetTableInsensitive(ctx, roots.Working, doltdb.TableName{Name: tableName})
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrTableNotFound.New(tableName)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return err
+	}
+	if schema.IsKeyless(sch) {
+		return sql.ErrInvalidArgumentDetails.New(rtf.Name(), fmt.Sprintf("table %s has no primary key", name))
+	}
+	if n := sch.GetPKCols().Size(); n != len(rtf.exprs)-1 {
+		return sql.ErrInvalidArgumentDetails.New(rtf.Name(), fmt.Sprintf("table %s has %d primary key columns, but %d values were given", name, n, len(rtf.exprs)-1))
+	}
+
+	// see DiffTableFunction.generateSchema for why the columns have no source
+	sqlSch, err := sqlutil.FromDoltSchema("", "", sch)
+	if err != nil {
+		return err
+	}
+	rtf.tableName = name
+	rtf.sch = sch
+	rtf.sqlSch = append(sqlSch.Schema.Copy(),
+		&sql.Column{Name: "commit_hash", Type: types.Text},
+		&sql.Column{Name: "committer", Type: types.Text},
+		&sql.Column{Name: "commit_date", Type: types.Datetime},
+	)
+	return nil
+}
+
+// Name implements the sql.TableFunction interface
+func (rtf *RowHistoryTableFunction) Name() string {
+	return "dolt_row_history"
+}
+
+// Database implements the sql.Databaser interface
+func (rtf *RowHistoryTableFunction) Database() sql.Database {
+	return rtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (rtf *RowHistoryTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	new := *rtf
+	new.database = database
+	return &new, nil
+}
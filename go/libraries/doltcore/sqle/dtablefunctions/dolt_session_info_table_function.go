@@ -0,0 +1,183 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtablefunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+var _ sql.TableFunction = (*SessionInfoTableFunction)(nil)
+var _ sql.ExecSourceRel = (*SessionInfoTableFunction)(nil)
+var _ sql.AuthorizationCheckerNode = (*SessionInfoTableFunction)(nil)
+
+var sessionInfoTableSchema = sql.Schema{
+	&sql.Column{Name: "database", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "revision_type", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "checked_out", Type: types.Boolean, Nullable: false},
+	&sql.Column{Name: "read_only", Type: types.Boolean, Nullable: false},
+	&sql.Column{Name: "head_commit", Type: types.Text, Nullable: true},
+	&sql.Column{Name: "head_root", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "working_root", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "staged_root", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "dirty", Type: types.Boolean, Nullable: false},
+	&sql.Column{Name: "dirty_tables", Type: types.Text, Nullable: false},
+	&sql.Column{Name: "transaction_start_root", Type: types.Text, Nullable: true},
+}
+
+// SessionInfoTableFunction implements dolt_session_info, which returns the state the session holds for every database
+// and revision it has accessed, to debug why a session sees stale or unexpected data:
+//
+//	dolt_session_info()
+//
+// head_root, working_root and staged_root are the hashes of the session's roots, as returned by dolt_hashof_db.
+// head_commit is NULL for databases pinned to a root value. dirty is true when the session has changes which aren't
+// committed in a SQL transaction yet, and dirty_tables names the tables they changed. transaction_start_root is the
+// hash of the storage root the current SQL transaction reads, and is NULL outside a transaction.
+type SessionInfoTableFunction struct {
+	ctx      *sql.Context
+	database sql.Database
+	exprs    []sql.Expression
+}
+
+// NewInstance creates a new instance of TableFunction interface
+func (dtf *SessionInfoTableFunction) NewInstance(ctx *sql.Context, database sql.Database, expressions []sql.Expression) (sql.Node, error) {
+	newInstance := &SessionInfoTableFunction{
+		ctx:      ctx,
+		database: database,
+	}
+
+	node, err := newInstance.WithExpressions(expressions...)
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// RowIter implements the sql.Node interface
+func (dtf *SessionInfoTableFunction) RowIter(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	sess := dsess.DSessFromSess(ctx.Session)
+	infos, err := sess.BranchStateInfos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(infos))
+	for i, info := range infos {
+		var headCommit, txStartRoot interface{}
+		if info.HeadCommit != "" {
+			headCommit = info.HeadCommit
+		}
+		if !info.TransactionStartRoot.IsEmpty() {
+			txStartRoot = info.TransactionStartRoot.String()
+		}
+		dirtyTables := make([]string, len(info.DirtyTables))
+		for j, name := range info.DirtyTables {
+			dirtyTables[j] = name.String()
+		}
+
+		rows[i] = sql.Row{
+			info.Database,
+			info.RevisionType.String(),
+			info.CheckedOut,
+			info.ReadOnly,
+			headCommit,
+			info.HeadRoot.String(),
+			info.WorkingRoot.String(),
+			info.StagedRoot.String(),
+			info.Dirty,
+			strings.Join(dirtyTables, ", "),
+			txStartRoot,
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// Schema implements the sql.Node interface
+func (dtf *SessionInfoTableFunction) Schema() sql.Schema {
+	return sessionInfoTableSchema
+}
+
+// Resolved implements the sql.Resolvable interface
+func (dtf *SessionInfoTableFunction) Resolved() bool {
+	return true
+}
+
+// String implements the Stringer interface
+func (dtf *SessionInfoTableFunction) String() string {
+	return "DOLT_SESSION_INFO()"
+}
+
+// Children implements the sql.Node interface
+func (dtf *SessionInfoTableFunction) Children() []sql.Node {
+	return nil
+}
+
+// WithChildren implements the sql.Node interface
+func (dtf *SessionInfoTableFunction) WithChildren(children ...sql.Node) (sql.Node, error) {
+	if len(children) != 0 {
+		return nil, fmt.Errorf("unexpected children")
+	}
+	return dtf, nil
+}
+
+// CheckAuth implements the interface sql.AuthorizationCheckerNode. The session only holds state for the databases
+// its user could already access, so no privileges are required.
+func (dtf *SessionInfoTableFunction) CheckAuth(ctx *sql.Context, opChecker sql.PrivilegedOperationChecker) bool {
+	return true
+}
+
+func (dtf *SessionInfoTableFunction) IsReadOnly() bool {
+	return true
+}
+
+// Expressions implements the sql.Expressioner interface
+func (dtf *SessionInfoTableFunction) Expressions() []sql.Expression {
+	return dtf.exprs
+}
+
+// WithExpressions implements the sql.Expressioner interface
+func (dtf *SessionInfoTableFunction) WithExpressions(expression ...sql.Expression) (sql.Node, error) {
+	if len(expression) != 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(dtf.Name(), "0", len(expression))
+	}
+	new := *dtf
+	new.exprs = expression
+	return &new, nil
+}
+
+// Name implements the sql.TableFunction interface
+func (dtf *SessionInfoTableFunction) Name() string {
+	return "dolt_session_info"
+}
+
+// Database implements the sql.Databaser interface
+func (dtf *SessionInfoTableFunction) Database() sql.Database {
+	return dtf.database
+}
+
+// WithDatabase implements the sql.Databaser interface
+func (dtf *SessionInfoTableFunction) WithDatabase(database sql.Database) (sql.Node, error) {
+	new := *dtf
+	new.database = database
+	return &new, nil
+}
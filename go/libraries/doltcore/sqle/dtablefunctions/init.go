@@ -25,4 +25,10 @@ var DoltTableFunctions = []sql.TableFunction{
 	&SchemaDiffTableFunction{},
 	&ReflogTableFunction{},
 	&QueryDiffTableFunction{},
+	&ForEachBranchTableFunction{},
+	&ChunkRefsTableFunction{},
+	&DagTableFunction{},
+	&RowHistoryTableFunction{},
+	&EstimateDiffTableFunction{},
+	&SessionInfoTableFunction{},
 }
@@ -0,0 +1,102 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/auditlog"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// AuditLogTable is a system table listing the entries of the sql-server audit log which concern this database. It
+// only has rows when the audit log is configured with the table target, which keeps recent entries in memory.
+type AuditLogTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*AuditLogTable)(nil)
+
+func NewAuditLogTable(dbName, tableName string) *AuditLogTable {
+	return &AuditLogTable{dbName: dbName, tableName: tableName}
+}
+
+func (at AuditLogTable) Name() string {
+	return at.tableName
+}
+
+func (at AuditLogTable) String() string {
+	return at.tableName
+}
+
+func (at AuditLogTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "time", Type: types.Datetime, Source: at.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: at.dbName},
+		{Name: "event", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: at.dbName},
+		{Name: "connection_id", Type: types.Uint32, Source: at.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: at.dbName},
+		{Name: "user", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: at.dbName},
+		{Name: "client", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+		{Name: "branch", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+		{Name: "kind", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+		{Name: "query", Type: types.LongText, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+		{Name: "tables", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+		{Name: "error", Type: types.Text, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+		{Name: "duration_ms", Type: types.Float64, Source: at.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: at.dbName},
+	}
+}
+
+func (at AuditLogTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (at AuditLogTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (at AuditLogTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	log := auditlog.Current()
+	if log == nil {
+		return sql.RowsToRowIter(), nil
+	}
+	baseName, _ := dsess.SplitRevisionDbName(at.dbName)
+	var rows []sql.Row
+	for _, e := range log.Entries() {
+		if !strings.EqualFold(e.Database, baseName) {
+			continue
+		}
+		var tables, duration interface{}
+		if len(e.Tables) > 0 {
+			tables = strings.Join(e.Tables, ",")
+		}
+		if e.Event == auditlog.EventQuery {
+			duration = float64(e.Duration.Microseconds()) / 1000
+		}
+		rows = append(rows, sql.NewRow(e.Time, e.Event, e.ConnectionID, e.User, nullIfEmpty(e.Client),
+			nullIfEmpty(e.Branch), nullIfEmpty(e.Kind), nullIfEmpty(e.Query), tables, nullIfEmpty(e.Error), duration))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
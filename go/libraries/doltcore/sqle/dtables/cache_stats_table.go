@@ -0,0 +1,73 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+// CacheStatsTable is a system table reporting the statistics of the cache of prolly tree nodes shared by the
+// databases of the server, with a row for the pool of leaf nodes and a row for the pool of internal nodes.
+type CacheStatsTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*CacheStatsTable)(nil)
+
+func NewCacheStatsTable(dbName, tableName string) *CacheStatsTable {
+	return &CacheStatsTable{dbName: dbName, tableName: tableName}
+}
+
+func (ct CacheStatsTable) Name() string {
+	return ct.tableName
+}
+
+func (ct CacheStatsTable) String() string {
+	return ct.tableName
+}
+
+func (ct CacheStatsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "pool", Type: types.Text, Source: ct.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "entries", Type: types.Int64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "bytes", Type: types.Int64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "protected_bytes", Type: types.Int64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "hits", Type: types.Uint64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "inserts", Type: types.Uint64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+		{Name: "evictions", Type: types.Uint64, Source: ct.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ct.dbName},
+	}
+}
+
+func (ct CacheStatsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (ct CacheStatsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (ct CacheStatsTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	stats := tree.CacheStats()
+	rows := make([]sql.Row, len(stats))
+	for i, s := range stats {
+		rows[i] = sql.NewRow(s.Pool, int64(s.Entries), int64(s.Bytes), int64(s.ProtectedBytes), s.Hits, s.Inserts, s.Evictions)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
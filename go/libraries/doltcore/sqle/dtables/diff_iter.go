@@ -286,11 +286,17 @@ func newProllyDiffIter(ctx *sql.Context, dp DiffPartition, targetFromSchema, tar
 	if err != nil {
 		return prollyDiffIter{}, err
 	}
+	if fromConverter, err = fromConverter.WithVirtualColumns(ctx); err != nil {
+		return prollyDiffIter{}, err
+	}
 
 	toConverter, err := NewProllyRowConverter(tsch, targetToSchema, ctx.Warn, nodeStore)
 	if err != nil {
 		return prollyDiffIter{}, err
 	}
+	if toConverter, err = toConverter.WithVirtualColumns(ctx); err != nil {
+		return prollyDiffIter{}, err
+	}
 
 	keyless := schema.IsKeyless(targetFromSchema) && schema.IsKeyless(targetToSchema)
 	child, cancel := context.WithCancel(ctx)
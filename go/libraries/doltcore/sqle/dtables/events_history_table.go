@@ -0,0 +1,84 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/events"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// EventsHistoryTable is a system table listing the recent executions of the events of this database by the sql-server
+// event scheduler, on every branch they ran on, including the executions of missed events when sql-server started.
+type EventsHistoryTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*EventsHistoryTable)(nil)
+
+func NewEventsHistoryTable(dbName, tableName string) *EventsHistoryTable {
+	return &EventsHistoryTable{dbName: dbName, tableName: tableName}
+}
+
+func (et EventsHistoryTable) Name() string {
+	return et.tableName
+}
+
+func (et EventsHistoryTable) String() string {
+	return et.tableName
+}
+
+func (et EventsHistoryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "event", Type: types.Text, Source: et.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: et.dbName},
+		{Name: "branch", Type: types.Text, Source: et.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: et.dbName},
+		{Name: "start_time", Type: types.Datetime, Source: et.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: et.dbName},
+		{Name: "end_time", Type: types.Datetime, Source: et.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: et.dbName},
+		{Name: "latency_ms", Type: types.Float64, Source: et.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: et.dbName},
+		{Name: "catch_up", Type: types.Boolean, Source: et.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: et.dbName},
+		{Name: "error", Type: types.Text, Source: et.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: et.dbName},
+	}
+}
+
+func (et EventsHistoryTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (et EventsHistoryTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (et EventsHistoryTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	h := events.Current()
+	if h == nil {
+		return sql.RowsToRowIter(), nil
+	}
+	baseName, _ := dsess.SplitRevisionDbName(et.dbName)
+	var rows []sql.Row
+	for _, e := range h.Executions() {
+		if !strings.EqualFold(e.Database, baseName) {
+			continue
+		}
+		rows = append(rows, sql.NewRow(e.Event, nullIfEmpty(e.Branch), e.Start, e.End, milliseconds(e.End.Sub(e.Start)),
+			e.CatchUp, nullIfEmpty(e.Error)))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table"
+	tjson "github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// delimiters of the delimited file formats supported by external tables
+var externalTableDelims = map[string]string{
+	"csv": ",",
+	"psv": "|",
+	"tsv": "\t",
+}
+
+const externalTableJSONFormat = "json"
+
+var _ sql.Table = (*ExternalTable)(nil)
+
+// ExternalTable is a read-only table whose rows are read from a file each time it's queried. External tables are
+// defined by the rows of the dolt_external_tables system table, so that raw files can be queried and joined against
+// versioned tables without importing them. All of the columns of an external table are nullable text columns, named
+// by the header line of delimited files, or by the keys of the first row of json files, which have the format that
+// `dolt table import` accepts. Reading an external table requires the FILE privilege.
+type ExternalTable struct {
+	name   string
+	format string
+	path   string
+
+	// the columns are read from the file the first time the schema is needed
+	once    sync.Once
+	cols    []string
+	sch     sql.Schema
+	loadErr error
+}
+
+// NewExternalTable returns the external table |name| that reads the file at |location|, which is either a path or a
+// file:// URL. Relative paths are relative to the working directory of the process. The file must be in the
+// secure_file_priv directory or the dolt_external_tables_dir directory, see externalTablePath. The file isn't opened
+// until the table is used.
+func NewExternalTable(ctx *sql.Context, name, format, location string) (*ExternalTable, error) {
+	if err := checkGlobalPrivilege(ctx, sql.PrivilegeType_File, name); err != nil {
+		return nil, err
+	}
+	path, err := externalTablePath(location)
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalTable{name: name, format: strings.ToLower(format), path: path}, nil
+}
+
+// checkGlobalPrivilege returns an error if the user of |ctx| doesn't have the global privilege |priv|, which is
+// needed to define or read the external table |name|.
+func checkGlobalPrivilege(ctx *sql.Context, priv sql.PrivilegeType, name interface{}) error {
+	privs, counter := ctx.GetPrivilegeSet()
+	if counter == 0 {
+		return fmt.Errorf("unable to check user privileges for external table %v", name)
+	}
+	if !privs.Has(priv) {
+		return sql.ErrPrivilegeCheckFailed.New(ctx.Session.Client().User)
+	}
+	return nil
+}
+
+// checkExternalFileTableDef returns an error if the row |r| of dolt_external_tables defines a table read from a file,
+// and the user doesn't have the FILE privilege or the file isn't in a directory external tables may read.
+func checkExternalFileTableDef(ctx *sql.Context, r sql.Row) error {
+	if err := checkGlobalPrivilege(ctx, sql.PrivilegeType_File, r[0]); err != nil {
+		return err
+	}
+	location, _ := r[2].(string)
+	_, err := externalTablePath(location)
+	return err
+}
+
+// externalTablePath returns the absolute path of the file at |location|. The file must be in the secure_file_priv
+// directory if it's set, and in the dolt_external_tables_dir directory if that's set. If neither is set, no file may
+// be read.
+func externalTablePath(location string) (string, error) {
+	path := location
+	if strings.Contains(location, "://") {
+		u, err := url.Parse(location)
+		if err != nil {
+			return "", err
+		}
+		if u.Scheme != "file" {
+			return "", fmt.Errorf("unsupported external table location: %s", location)
+		}
+		path = filepath.FromSlash(u.Path)
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	var restricted bool
+	for _, sysVar := range []string{"secure_file_priv", dsess.ExternalTablesDir} {
+		_, v, ok := sql.SystemVariables.GetGlobal(sysVar)
+		dir, _ := v.(string)
+		if !ok || dir == "" {
+			continue
+		}
+		restricted = true
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("external table location %s is not in the %s directory %s", location, sysVar, dir)
+		}
+	}
+	if !restricted {
+		return "", fmt.Errorf("external table location %s can't be read, set secure_file_priv or %s to the "+
+			"directory external table files are read from", location, dsess.ExternalTablesDir)
+	}
+	return path, nil
+}
+
+// load reads the columns of the file, once.
+func (t *ExternalTable) load() error {
+	t.once.Do(func() {
+		t.cols, t.loadErr = t.columnNames()
+		if t.loadErr != nil {
+			t.loadErr = fmt.Errorf("unable to read external table %s: %w", t.name, t.loadErr)
+			return
+		}
+		if len(t.cols) == 0 {
+			t.loadErr = fmt.Errorf("unable to read external table %s: %s has no columns", t.name, t.path)
+			return
+		}
+		for _, col := range t.cols {
+			t.sch = append(t.sch, &sql.Column{Name: col, Type: sqlTypes.LongText, Source: t.name, Nullable: true})
+		}
+	})
+	return t.loadErr
+}
+
+// columnNames returns the names of the columns of the file, in order.
+func (t *ExternalTable) columnNames() ([]string, error) {
+	if t.format == externalTableJSONFormat {
+		return jsonColumnNames(t.path)
+	}
+	rd, err := t.openReader()
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close(context.Background())
+	return rd.GetSchema().GetAllCols().GetColumnNames(), nil
+}
+
+// jsonColumnNames returns the sorted keys of the first row of the json file at |path|, which has the format
+// { "rows": [ json_row_objects... ] }.
+func jsonColumnNames(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	for _, expected := range []json.Token{json.Delim('{'), "rows", json.Delim('[')} {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		if tok != expected {
+			return nil, errors.New(`unexpected JSON format, expected format: { "rows": [ json_row_objects... ] }`)
+		}
+	}
+	var row map[string]interface{}
+	if err := dec.Decode(&row); err != nil {
+		return nil, err
+	}
+
+	cols := make([]string, 0, len(row))
+	for k := range row {
+		cols = append(cols, k)
+	}
+	sort.Strings(cols)
+	return cols, nil
+}
+
+// externalTableReader reads the rows of an external table's file.
+type externalTableReader interface {
+	table.SqlTableReader
+	table.Closer
+}
+
+func (t *ExternalTable) openReader() (externalTableReader, error) {
+	if t.format == externalTableJSONFormat {
+		_, sch := untyped.NewUntypedSchema(t.cols...)
+		return tjson.OpenJSONReader(nil, t.path, filesys.LocalFS, sch)
+	}
+	delim, ok := externalTableDelims[t.format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported external table format: %s", t.format)
+	}
+	return csv.OpenCSVReader(types.Format_Default, t.path, filesys.LocalFS, csv.NewCSVInfo().SetDelim(delim))
+}
+
+func (t *ExternalTable) Name() string {
+	return t.name
+}
+
+func (t *ExternalTable) String() string {
+	return t.name
+}
+
+// Schema is a sql.Table interface function that returns the columns of the file. If the file can't be read, the
+// schema is empty, and PartitionRows returns the error.
+func (t *ExternalTable) Schema() sql.Schema {
+	t.load()
+	return t.sch
+}
+
+func (t *ExternalTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a single partition for the whole file.
+func (t *ExternalTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+// PartitionRows is a sql.Table interface function that reads the rows of the file.
+func (t *ExternalTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	if err := checkGlobalPrivilege(ctx, sql.PrivilegeType_File, t.name); err != nil {
+		return nil, err
+	}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	rd, err := t.openReader()
+	if err != nil {
+		return nil, err
+	}
+	return &externalTableRowIter{t: t, rd: rd}, nil
+}
+
+type externalTableRowIter struct {
+	t  *ExternalTable
+	rd externalTableReader
+}
+
+func (itr *externalTableRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := itr.rd.ReadSqlRow(ctx)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read external table %s: %w", itr.t.name, err)
+	}
+	if len(r) != len(itr.t.sch) {
+		return nil, fmt.Errorf("unable to read external table %s: the columns of %s changed", itr.t.name, itr.t.path)
+	}
+	return r, nil
+}
+
+func (itr *externalTableRowIter) Close(ctx *sql.Context) error {
+	return itr.rd.Close(ctx)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*ExternalTablesTable)(nil)
+var _ sql.UpdatableTable = (*ExternalTablesTable)(nil)
+var _ sql.DeletableTable = (*ExternalTablesTable)(nil)
+var _ sql.InsertableTable = (*ExternalTablesTable)(nil)
+var _ sql.ReplaceableTable = (*ExternalTablesTable)(nil)
+var _ sql.IndexAddressableTable = (*ExternalTablesTable)(nil)
+
+// ExternalTablesTable is the system table that stores the definitions of external tables, which are read-only tables
+// read from files or remote databases when they are queried. See ExternalTable and FederatedTable. Users need the
+// FILE privilege to define tables read from files, and SUPER access to define federated tables.
+type ExternalTablesTable struct {
+	backingTable VersionableTable
+	schemaName   string
+}
+
+func (i *ExternalTablesTable) Name() string {
+	return doltdb.ExternalTablesTableName
+}
+
+func (i *ExternalTablesTable) String() string {
+	return doltdb.ExternalTablesTableName
+}
+
+func doltExternalTablesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.ExternalTablesNameCol, Type: sqlTypes.Text, Source: doltdb.ExternalTablesTableName, PrimaryKey: true},
+		{Name: doltdb.ExternalTablesFormatCol, Type: sqlTypes.Text, Source: doltdb.ExternalTablesTableName, Nullable: false},
+		{Name: doltdb.ExternalTablesLocationCol, Type: sqlTypes.Text, Source: doltdb.ExternalTablesTableName, Nullable: false},
+	}
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_external_tables system table.
+func (i *ExternalTablesTable) Schema() sql.Schema {
+	return doltExternalTablesSchema()
+}
+
+func (i *ExternalTablesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (i *ExternalTablesTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return i.backingTable.Partitions(context)
+}
+
+func (i *ExternalTablesTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+
+	return i.backingTable.PartitionRows(context, partition)
+}
+
+// NewExternalTablesTable creates a ExternalTablesTable
+func NewExternalTablesTable(_ *sql.Context, backingTable VersionableTable, schemaName string) sql.Table {
+	return &ExternalTablesTable{backingTable: backingTable, schemaName: schemaName}
+}
+
+// NewEmptyExternalTablesTable creates a ExternalTablesTable with no backing table
+func NewEmptyExternalTablesTable(_ *sql.Context, schemaName string) sql.Table {
+	return &ExternalTablesTable{schemaName: schemaName}
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (it *ExternalTablesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return it.writer()
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (it *ExternalTablesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return it.writer()
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (it *ExternalTablesTable) Inserter(*sql.Context) sql.RowInserter {
+	return it.writer()
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (it *ExternalTablesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return it.writer()
+}
+
+func (it *ExternalTablesTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if it.backingTable == nil {
+		return it, nil
+	}
+	return it.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements IndexAddressableTable, but ExternalTablesTable has no indexes.
+// Thus, this should never be called.
+func (it *ExternalTablesTable) IndexedAccess(ctx *sql.Context, lookup sql.IndexLookup) sql.IndexedTable {
+	panic("Unreachable")
+}
+
+// GetIndexes implements IndexAddressableTable, but ExternalTablesTable has no indexes.
+func (it *ExternalTablesTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (i *ExternalTablesTable) PreciseMatch() bool {
+	return true
+}
+
+// checkExternalTableDef returns an error if the user can't define the external table of the row |r|. See
+// checkFederatedTableDef and checkExternalFileTableDef.
+func checkExternalTableDef(ctx *sql.Context, r sql.Row) error {
+	if format, _ := r[1].(string); IsFederatedTableFormat(format) {
+		return checkFederatedTableDef(ctx, r)
+	}
+	return checkExternalFileTableDef(ctx, r)
+}
+
+// checkFederatedTableDef returns an error if the user doesn't have SUPER access, or the location of the federated
+// table defined by the row |r| isn't valid, see parseFederatedLocation. Federated tables connect to other servers from
+// the server process, with credentials configured for the process, so only admins may define them.
+func checkFederatedTableDef(ctx *sql.Context, r sql.Row) error {
+	format, _ := r[1].(string)
+	location, _ := r[2].(string)
+	if _, _, _, err := parseFederatedLocation(format, location); err != nil {
+		return err
+	}
+	return checkGlobalPrivilege(ctx, sql.PrivilegeType_Super, r[0])
+}
+
+// writer returns a writer for the rows of the table.
+func (it *ExternalTablesTable) writer() *versionedTableWriter {
+	return newVersionedTableWriter(doltdb.TableName{Name: doltdb.ExternalTablesTableName, Schema: it.schemaName}, it.Schema(), checkExternalTableDef)
+}
@@ -0,0 +1,433 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	gosql "database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+const (
+	federatedMySQLFormat    = "mysql"
+	federatedPostgresFormat = "postgres"
+
+	// federatedCredParam is the parameter of a federated table's location which names its credential.
+	federatedCredParam = "cred"
+)
+
+// IsFederatedTableFormat returns whether |format| is the format of an external table that proxies a table in a
+// remote database, rather than a file.
+func IsFederatedTableFormat(format string) bool {
+	format = strings.ToLower(format)
+	return format == federatedMySQLFormat || format == federatedPostgresFormat
+}
+
+// federatedPoolIdleTimeout is how long the connection pool to a remote database is kept after it was last used.
+const federatedPoolIdleTimeout = 10 * time.Minute
+
+type federatedPool struct {
+	db       *gosql.DB
+	lastUsed time.Time
+}
+
+// federatedConns are the connection pools to remote databases, by driver and data source name. Pools which haven't
+// been used for federatedPoolIdleTimeout are closed, and the rest are closed by CloseFederatedConns.
+var federatedConns = struct {
+	mu    sync.Mutex
+	pools map[string]*federatedPool
+}{pools: make(map[string]*federatedPool)}
+
+func federatedConn(driver, dsn string) (*gosql.DB, error) {
+	federatedConns.mu.Lock()
+	defer federatedConns.mu.Unlock()
+	now := time.Now()
+	for key, p := range federatedConns.pools {
+		if now.Sub(p.lastUsed) > federatedPoolIdleTimeout {
+			p.db.Close()
+			delete(federatedConns.pools, key)
+		}
+	}
+
+	key := driver + " " + dsn
+	if p, ok := federatedConns.pools[key]; ok {
+		p.lastUsed = now
+		return p.db, nil
+	}
+	db, err := gosql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	db.SetConnMaxIdleTime(federatedPoolIdleTimeout)
+	federatedConns.pools[key] = &federatedPool{db: db, lastUsed: now}
+	return db, nil
+}
+
+// CloseFederatedConns closes the connection pools to the remote databases of federated tables.
+func CloseFederatedConns() error {
+	federatedConns.mu.Lock()
+	defer federatedConns.mu.Unlock()
+	var errs []error
+	for key, p := range federatedConns.pools {
+		errs = append(errs, p.db.Close())
+		delete(federatedConns.pools, key)
+	}
+	return errors.Join(errs...)
+}
+
+// FederatedCredential is a credential which federated tables may name with the cred parameter of their location.
+type FederatedCredential struct {
+	// Name is the name locations refer to the credential by.
+	Name string
+	// Hosts are the addresses of the remote databases the credential's password may be sent to. A host without a
+	// port matches any port.
+	Hosts []string
+}
+
+// federatedCredentials are the configured credentials, by lowercased name. See SetFederatedCredentials.
+var federatedCredentials = struct {
+	mu    sync.RWMutex
+	creds map[string]FederatedCredential
+}{}
+
+// SetFederatedCredentials sets the credentials federated tables may use. Credentials which aren't configured can't be
+// used, so that a location can't send a credential's password to a host chosen by whoever defined the table.
+func SetFederatedCredentials(creds []FederatedCredential) {
+	m := make(map[string]FederatedCredential, len(creds))
+	for _, c := range creds {
+		m[strings.ToLower(c.Name)] = c
+	}
+	federatedCredentials.mu.Lock()
+	defer federatedCredentials.mu.Unlock()
+	federatedCredentials.creds = m
+}
+
+// parseFederatedLocation parses and validates the |location| of a federated table of the |format| given, and returns
+// it with the names of the remote database and table. Definitions are validated both when they're written and when
+// the table is opened, since they can also arrive through a merge, pull or clone.
+func parseFederatedLocation(format, location string) (u *url.URL, dbName, tableName string, err error) {
+	u, err = url.Parse(location)
+	if err != nil {
+		return nil, "", "", err
+	}
+	format = strings.ToLower(format)
+	if u.Scheme != format && !(format == federatedPostgresFormat && u.Scheme == "postgresql") {
+		return nil, "", "", fmt.Errorf("unsupported location for %s external table: %s", format, u.Redacted())
+	}
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(path) != 2 || path[0] == "" || path[1] == "" {
+		return nil, "", "", fmt.Errorf("external table location %s must name a database and a table", u.Redacted())
+	}
+	if _, ok := u.User.Password(); ok {
+		return nil, "", "", fmt.Errorf("external table location %s must not contain a password, use a cred "+
+			"parameter naming a credential instead", u.Redacted())
+	}
+	if cred := u.Query().Get(federatedCredParam); cred != "" {
+		if err = checkFederatedCredentialHost(format, cred, u); err != nil {
+			return nil, "", "", err
+		}
+	}
+	return u, path[0], path[1], nil
+}
+
+// checkFederatedCredentialHost returns an error if the credential |cred| isn't configured, or may not be sent to the
+// host of |u|.
+func checkFederatedCredentialHost(format, cred string, u *url.URL) error {
+	federatedCredentials.mu.RLock()
+	c, ok := federatedCredentials.creds[strings.ToLower(cred)]
+	federatedCredentials.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("credential %s of external table location %s is not configured, add it to the "+
+			"external_table_credentials of the server config", cred, u.Redacted())
+	}
+
+	port := u.Port()
+	if port == "" {
+		port = "3306"
+		if format == federatedPostgresFormat {
+			port = "5432"
+		}
+	}
+	for _, allowed := range c.Hosts {
+		host, allowedPort, err := net.SplitHostPort(allowed)
+		if err != nil {
+			host, allowedPort = allowed, ""
+		}
+		if strings.EqualFold(host, u.Hostname()) && (allowedPort == "" || allowedPort == port) {
+			return nil
+		}
+	}
+	return fmt.Errorf("credential %s of external table location %s may not be used for host %s", cred, u.Redacted(),
+		net.JoinHostPort(u.Hostname(), port))
+}
+
+// federatedPassword returns the password for the remote database at |u|, which parseFederatedLocation validated.
+// Locations are versioned and pushed along with the rest of the database, so they may not contain a password.
+// Instead, the cred parameter of the location names a credential configured for the server, whose password is read
+// from the environment variable dconfig.EnvExternalTableCredPrefix + the uppercased name, e.g.
+// DOLT_EXTERNAL_TABLE_CRED_LEGACY for cred=legacy.
+func federatedPassword(u *url.URL) (string, error) {
+	cred := u.Query().Get(federatedCredParam)
+	if cred == "" {
+		return "", nil
+	}
+	envVar := dconfig.EnvExternalTableCredPrefix + strings.ToUpper(cred)
+	password, ok := os.LookupEnv(envVar)
+	if !ok {
+		return "", fmt.Errorf("credential %s of external table location %s is not defined, set %s", cred, u.Redacted(), envVar)
+	}
+	return password, nil
+}
+
+var _ sql.Table = (*FederatedTable)(nil)
+
+// FederatedTable is a read-only external table that proxies a table in a remote MySQL or Postgres database, so that
+// it can be compared with or migrated to a versioned table. Like ExternalTable, it's defined by a row of the
+// dolt_external_tables system table, with a location of the form
+//
+//	mysql://user@host:port/database/table[?cred=name]
+//	postgres://user@host:port/database/[schema.]table[?cred=name&sslmode=disable]
+//
+// The password of the user is given by the credential named by the cred parameter, which must be configured for the
+// host of the location; see SetFederatedCredentials and federatedPassword. Rows are selected from the remote table
+// each time it's queried.
+type FederatedTable struct {
+	name   string
+	db     *gosql.DB
+	query  string
+	remote string
+
+	// the columns are read from the remote database the first time the schema is needed
+	once    sync.Once
+	sch     sql.Schema
+	loadErr error
+}
+
+// federatedSchemaTimeout bounds the query for the columns of a remote table, which isn't run for a statement.
+const federatedSchemaTimeout = 30 * time.Second
+
+// NewFederatedTable returns the federated table |name| for the remote table at |location|. The remote database isn't
+// queried until the table is used.
+func NewFederatedTable(ctx *sql.Context, name, format, location string) (*FederatedTable, error) {
+	u, dbName, tableName, err := parseFederatedLocation(format, location)
+	if err != nil {
+		return nil, err
+	}
+	format = strings.ToLower(format)
+	password, err := federatedPassword(u)
+	if err != nil {
+		return nil, err
+	}
+
+	var driver, dsn, quotedTable string
+	switch format {
+	case federatedMySQLFormat:
+		cfg := mysql.NewConfig()
+		cfg.User = u.User.Username()
+		cfg.Passwd = password
+		cfg.Net = "tcp"
+		cfg.Addr = u.Host
+		if u.Port() == "" {
+			cfg.Addr = net.JoinHostPort(u.Hostname(), "3306")
+		}
+		cfg.DBName = dbName
+		driver, dsn = "mysql", cfg.FormatDSN()
+		quotedTable = sql.QuoteIdentifier(tableName)
+	case federatedPostgresFormat:
+		pgURL := *u
+		pgURL.Scheme = "postgres"
+		pgURL.Path = "/" + dbName
+		if password != "" {
+			pgURL.User = url.UserPassword(u.User.Username(), password)
+		}
+		q := pgURL.Query()
+		q.Del(federatedCredParam)
+		pgURL.RawQuery = q.Encode()
+		driver, dsn = "postgres", pgURL.String()
+		parts := strings.Split(tableName, ".")
+		for i := range parts {
+			parts[i] = `"` + strings.ReplaceAll(parts[i], `"`, `""`) + `"`
+		}
+		quotedTable = strings.Join(parts, ".")
+	}
+
+	db, err := federatedConn(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &FederatedTable{name: name, db: db, query: "SELECT * FROM " + quotedTable, remote: u.Redacted()}, nil
+}
+
+// load reads the columns of the remote table, once.
+func (t *FederatedTable) load() error {
+	t.once.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), federatedSchemaTimeout)
+		defer cancel()
+		if err := t.loadSchema(ctx); err != nil {
+			t.sch = nil
+			t.loadErr = fmt.Errorf("unable to read external table %s from %s: %w", t.name, t.remote, err)
+		}
+	})
+	return t.loadErr
+}
+
+// loadSchema maps the types of the remote table's columns to SQL types. Columns of types that aren't mapped are
+// text columns.
+func (t *FederatedTable) loadSchema(ctx context.Context) error {
+	rows, err := t.db.QueryContext(ctx, t.query+" WHERE 1 = 0")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+
+	for _, ct := range colTypes {
+		var typ sql.Type = sqlTypes.LongText
+		switch dbType := strings.ToUpper(ct.DatabaseTypeName()); {
+		case strings.HasPrefix(dbType, "UNSIGNED") && strings.HasSuffix(dbType, "INT"):
+			typ = sqlTypes.Uint64
+		case strings.HasSuffix(dbType, "INT") || dbType == "INT2" || dbType == "INT4" || dbType == "INT8":
+			typ = sqlTypes.Int64
+		case dbType == "FLOAT" || dbType == "DOUBLE" || dbType == "REAL" || dbType == "FLOAT4" || dbType == "FLOAT8":
+			typ = sqlTypes.Float64
+		case dbType == "DECIMAL" || dbType == "NUMERIC":
+			if precision, scale, ok := ct.DecimalSize(); ok && precision > 0 && precision <= 65 && scale <= 30 {
+				typ = sqlTypes.MustCreateDecimalType(uint8(precision), uint8(scale))
+			}
+		case dbType == "BOOL":
+			typ = sqlTypes.Boolean
+		case dbType == "DATE":
+			typ = sqlTypes.Date
+		case dbType == "DATETIME" || dbType == "TIMESTAMP" || dbType == "TIMESTAMPTZ":
+			typ = sqlTypes.DatetimeMaxPrecision
+		}
+		nullable, ok := ct.Nullable()
+		t.sch = append(t.sch, &sql.Column{Name: ct.Name(), Type: typ, Source: t.name, Nullable: nullable || !ok})
+	}
+	return rows.Err()
+}
+
+func (t *FederatedTable) Name() string {
+	return t.name
+}
+
+func (t *FederatedTable) String() string {
+	return t.name
+}
+
+// Schema is a sql.Table interface function that returns the columns of the remote table. If they can't be read, the
+// schema is empty, and PartitionRows returns the error.
+func (t *FederatedTable) Schema() sql.Schema {
+	t.load()
+	return t.sch
+}
+
+func (t *FederatedTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a single partition for the whole remote table.
+func (t *FederatedTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sql.PartitionsToPartitionIter(federatedPartition{}), nil
+}
+
+// federatedPartition is the single partition of a FederatedTable.
+type federatedPartition struct{}
+
+func (federatedPartition) Key() []byte {
+	return []byte("federated")
+}
+
+// PartitionRows is a sql.Table interface function that selects the rows of the remote table.
+func (t *FederatedTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	rows, err := t.db.QueryContext(ctx, t.query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read external table %s from %s: %w", t.name, t.remote, err)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+	if len(cols) != len(t.sch) {
+		rows.Close()
+		return nil, fmt.Errorf("unable to read external table %s: the columns of %s changed", t.name, t.remote)
+	}
+	return &federatedRowIter{t: t, rows: rows}, nil
+}
+
+type federatedRowIter struct {
+	t    *FederatedTable
+	rows *gosql.Rows
+}
+
+func (itr *federatedRowIter) Next(ctx *sql.Context) (sql.Row, error) {
+	if !itr.rows.Next() {
+		if err := itr.rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+
+	vals := make([]interface{}, len(itr.t.sch))
+	ptrs := make([]interface{}, len(vals))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err := itr.rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+
+	row := make(sql.Row, len(vals))
+	for i, v := range vals {
+		if bs, ok := v.([]byte); ok {
+			v = string(bs)
+		}
+		if v == nil {
+			continue
+		}
+		cv, _, err := itr.t.sch[i].Type.Convert(ctx, v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert column %s of external table %s: %w", itr.t.sch[i].Name, itr.t.name, err)
+		}
+		row[i] = cv
+	}
+	return row, nil
+}
+
+func (itr *federatedRowIter) Close(*sql.Context) error {
+	return itr.rows.Close()
+}
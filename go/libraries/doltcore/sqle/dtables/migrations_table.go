@@ -0,0 +1,143 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*MigrationsTable)(nil)
+var _ sql.UpdatableTable = (*MigrationsTable)(nil)
+var _ sql.DeletableTable = (*MigrationsTable)(nil)
+var _ sql.InsertableTable = (*MigrationsTable)(nil)
+var _ sql.ReplaceableTable = (*MigrationsTable)(nil)
+var _ sql.IndexAddressableTable = (*MigrationsTable)(nil)
+
+// MigrationsTable is the system table that stores the versioned schema migrations applied by `dolt migrate up` and
+// reverted by `dolt migrate down`.
+type MigrationsTable struct {
+	backingTable VersionableTable
+	schemaName   string
+}
+
+func (i *MigrationsTable) Name() string {
+	return doltdb.MigrationsTableName
+}
+
+func (i *MigrationsTable) String() string {
+	return doltdb.MigrationsTableName
+}
+
+func doltMigrationsSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.MigrationsVersionCol, Type: sqlTypes.Int64, Source: doltdb.MigrationsTableName, PrimaryKey: true},
+		{Name: doltdb.MigrationsDescriptionCol, Type: sqlTypes.Text, Source: doltdb.MigrationsTableName, Nullable: true},
+		{Name: doltdb.MigrationsUpScriptCol, Type: sqlTypes.LongText, Source: doltdb.MigrationsTableName, Nullable: false},
+		{Name: doltdb.MigrationsDownScriptCol, Type: sqlTypes.LongText, Source: doltdb.MigrationsTableName, Nullable: true},
+		{Name: doltdb.MigrationsAppliedAtCol, Type: sqlTypes.Datetime, Source: doltdb.MigrationsTableName, Nullable: true},
+	}
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_migrations system table.
+func (i *MigrationsTable) Schema() sql.Schema {
+	return doltMigrationsSchema()
+}
+
+func (i *MigrationsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (i *MigrationsTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return i.backingTable.Partitions(context)
+}
+
+func (i *MigrationsTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+
+	return i.backingTable.PartitionRows(context, partition)
+}
+
+// NewMigrationsTable creates a MigrationsTable
+func NewMigrationsTable(_ *sql.Context, backingTable VersionableTable, schemaName string) sql.Table {
+	return &MigrationsTable{backingTable: backingTable, schemaName: schemaName}
+}
+
+// NewEmptyMigrationsTable creates a MigrationsTable with no backing table
+func NewEmptyMigrationsTable(_ *sql.Context, schemaName string) sql.Table {
+	return &MigrationsTable{schemaName: schemaName}
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (it *MigrationsTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return it.writer()
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (it *MigrationsTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return it.writer()
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (it *MigrationsTable) Inserter(*sql.Context) sql.RowInserter {
+	return it.writer()
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (it *MigrationsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return it.writer()
+}
+
+func (it *MigrationsTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if it.backingTable == nil {
+		return it, nil
+	}
+	return it.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements IndexAddressableTable, but MigrationsTable has no indexes.
+// Thus, this should never be called.
+func (it *MigrationsTable) IndexedAccess(ctx *sql.Context, lookup sql.IndexLookup) sql.IndexedTable {
+	panic("Unreachable")
+}
+
+// GetIndexes implements IndexAddressableTable, but MigrationsTable has no indexes.
+func (it *MigrationsTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (i *MigrationsTable) PreciseMatch() bool {
+	return true
+}
+
+// writer returns a writer for the rows of the table.
+func (it *MigrationsTable) writer() *versionedTableWriter {
+	return newVersionedTableWriter(doltdb.TableName{Name: doltdb.MigrationsTableName, Schema: it.schemaName}, it.Schema(), nil)
+}
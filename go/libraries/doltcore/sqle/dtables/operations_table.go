@@ -0,0 +1,79 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// OperationsTable is a system table listing the long-running operations, such as dolt_clone(), currently executing
+// on the server. Users without the PROCESS privilege only see their own operations.
+type OperationsTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*OperationsTable)(nil)
+
+func NewOperationsTable(dbName, tableName string) *OperationsTable {
+	return &OperationsTable{dbName: dbName, tableName: tableName}
+}
+
+func (ot OperationsTable) Name() string {
+	return ot.tableName
+}
+
+func (ot OperationsTable) String() string {
+	return ot.tableName
+}
+
+func (ot OperationsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "id", Type: types.Uint64, Source: ot.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: ot.dbName},
+		{Name: "connection_id", Type: types.Uint32, Source: ot.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ot.dbName},
+		{Name: "operation", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ot.dbName},
+		{Name: "target", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ot.dbName},
+		{Name: "status", Type: types.Text, Source: ot.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ot.dbName},
+		{Name: "done", Type: types.Int64, Source: ot.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: ot.dbName},
+		{Name: "total", Type: types.Int64, Source: ot.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: ot.dbName},
+		{Name: "started_at", Type: types.Datetime, Source: ot.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: ot.dbName},
+	}
+}
+
+func (ot OperationsTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (ot OperationsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (ot OperationsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	ops := dsess.Operations.ListVisible(ctx)
+	rows := make([]sql.Row, len(ops))
+	for i, op := range ops {
+		status, done, total, hasProgress := op.Status()
+		var doneVal, totalVal interface{}
+		if hasProgress {
+			doneVal, totalVal = done, total
+		}
+		rows[i] = sql.NewRow(op.ID, op.ConnectionID, op.Kind, op.Target, status, doneVal, totalVal, op.Started)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
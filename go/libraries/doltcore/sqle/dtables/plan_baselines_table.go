@@ -0,0 +1,142 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*PlanBaselinesTable)(nil)
+var _ sql.UpdatableTable = (*PlanBaselinesTable)(nil)
+var _ sql.DeletableTable = (*PlanBaselinesTable)(nil)
+var _ sql.InsertableTable = (*PlanBaselinesTable)(nil)
+var _ sql.ReplaceableTable = (*PlanBaselinesTable)(nil)
+var _ sql.IndexAddressableTable = (*PlanBaselinesTable)(nil)
+
+// PlanBaselinesTable is the system table that stores the plans pinned for query digests. Being versioned, the
+// baselines are pinned per branch. See planbaseline.
+type PlanBaselinesTable struct {
+	backingTable VersionableTable
+	schemaName   string
+}
+
+func (i *PlanBaselinesTable) Name() string {
+	return doltdb.PlanBaselinesTableName
+}
+
+func (i *PlanBaselinesTable) String() string {
+	return doltdb.PlanBaselinesTableName
+}
+
+func doltPlanBaselinesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.PlanBaselinesDigestCol, Type: sqlTypes.Text, Source: doltdb.PlanBaselinesTableName, PrimaryKey: true},
+		{Name: doltdb.PlanBaselinesDigestTextCol, Type: sqlTypes.LongText, Source: doltdb.PlanBaselinesTableName, Nullable: false},
+		{Name: doltdb.PlanBaselinesPlanCol, Type: sqlTypes.LongText, Source: doltdb.PlanBaselinesTableName, Nullable: false},
+		{Name: doltdb.PlanBaselinesEnforcedCol, Type: sqlTypes.Boolean, Source: doltdb.PlanBaselinesTableName, Nullable: false},
+	}
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_plan_baselines system table.
+func (i *PlanBaselinesTable) Schema() sql.Schema {
+	return doltPlanBaselinesSchema()
+}
+
+func (i *PlanBaselinesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (i *PlanBaselinesTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return i.backingTable.Partitions(context)
+}
+
+func (i *PlanBaselinesTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+
+	return i.backingTable.PartitionRows(context, partition)
+}
+
+// NewPlanBaselinesTable creates a PlanBaselinesTable
+func NewPlanBaselinesTable(_ *sql.Context, backingTable VersionableTable, schemaName string) sql.Table {
+	return &PlanBaselinesTable{backingTable: backingTable, schemaName: schemaName}
+}
+
+// NewEmptyPlanBaselinesTable creates a PlanBaselinesTable with no backing table
+func NewEmptyPlanBaselinesTable(_ *sql.Context, schemaName string) sql.Table {
+	return &PlanBaselinesTable{schemaName: schemaName}
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (it *PlanBaselinesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return it.writer()
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (it *PlanBaselinesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return it.writer()
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (it *PlanBaselinesTable) Inserter(*sql.Context) sql.RowInserter {
+	return it.writer()
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (it *PlanBaselinesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return it.writer()
+}
+
+func (it *PlanBaselinesTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if it.backingTable == nil {
+		return it, nil
+	}
+	return it.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements IndexAddressableTable, but PlanBaselinesTable has no indexes.
+// Thus, this should never be called.
+func (it *PlanBaselinesTable) IndexedAccess(ctx *sql.Context, lookup sql.IndexLookup) sql.IndexedTable {
+	panic("Unreachable")
+}
+
+// GetIndexes implements IndexAddressableTable, but PlanBaselinesTable has no indexes.
+func (it *PlanBaselinesTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (i *PlanBaselinesTable) PreciseMatch() bool {
+	return true
+}
+
+// writer returns a writer for the rows of the table.
+func (it *PlanBaselinesTable) writer() *versionedTableWriter {
+	return newVersionedTableWriter(doltdb.TableName{Name: doltdb.PlanBaselinesTableName, Schema: it.schemaName}, it.Schema(), nil)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/expranalysis"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/val"
 )
@@ -38,6 +39,15 @@ type ProllyRowConverter struct {
 	nonPkTargetTypes []sql.Type
 	warnFn           rowconv.WarnFunction
 	ns               tree.NodeStore
+	// virtualCols are the generated expressions of the virtual columns of |outSchema|, which aren't stored and are
+	// computed from the rest of the converted row.
+	virtualCols []virtualColumn
+}
+
+type virtualColumn struct {
+	idx  int
+	expr sql.Expression
+	typ  sql.Type
 }
 
 func NewProllyRowConverter(inSch, outSch schema.Schema, warnFn rowconv.WarnFunction, ns tree.NodeStore) (ProllyRowConverter, error) {
@@ -108,7 +118,50 @@ func (c ProllyRowConverter) PutConverted(ctx context.Context, key, value val.Tup
 		return err
 	}
 
-	return c.putFields(ctx, value, c.valProj, c.valDesc, c.nonPkTargetTypes, dstRow, false)
+	err = c.putFields(ctx, value, c.valProj, c.valDesc, c.nonPkTargetTypes, dstRow, false)
+	if err != nil || len(c.virtualCols) == 0 {
+		return err
+	}
+
+	sqlCtx, ok := ctx.(*sql.Context)
+	if !ok {
+		sqlCtx = sql.NewContext(ctx)
+	}
+	for _, vc := range c.virtualCols {
+		v, err := vc.expr.Eval(sqlCtx, dstRow)
+		if err != nil {
+			return err
+		}
+		dstRow[vc.idx], _, err = vc.typ.Convert(sqlCtx, v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithVirtualColumns returns a copy of this converter that computes the values of the virtual generated columns of its
+// output schema. Without them, virtual columns are converted as NULL.
+func (c ProllyRowConverter) WithVirtualColumns(ctx *sql.Context) (ProllyRowConverter, error) {
+	if !schema.IsVirtual(c.outSchema) {
+		return c, nil
+	}
+
+	var virtualCols []virtualColumn
+	for i, col := range c.outSchema.GetAllCols().GetColumns() {
+		if !col.Virtual || col.Generated == "" {
+			continue
+		}
+		// generated expressions reference columns by their position in the full schema, which is also the layout of
+		// the converted row, so the table name they are resolved against doesn't matter
+		expr, err := expranalysis.ResolveExpression(ctx, "generated", c.outSchema, col.Generated)
+		if err != nil {
+			return ProllyRowConverter{}, err
+		}
+		virtualCols = append(virtualCols, virtualColumn{idx: i, expr: expr, typ: col.TypeInfo.ToSqlType()})
+	}
+	c.virtualCols = virtualCols
+	return c, nil
 }
 
 func (c ProllyRowConverter) putFields(ctx context.Context, tup val.Tuple, proj val.OrdinalMapping, desc val.TupleDesc, targetTypes []sql.Type, dstRow sql.Row, isPk bool) error {
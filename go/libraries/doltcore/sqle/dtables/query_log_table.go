@@ -0,0 +1,168 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/querylog"
+)
+
+// QueryLogTable is a system table listing the recent statements recorded by the sql-server query log which ran against
+// this database. Statements are only recorded while the dolt_query_log system variable is enabled.
+type QueryLogTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*QueryLogTable)(nil)
+
+func NewQueryLogTable(dbName, tableName string) *QueryLogTable {
+	return &QueryLogTable{dbName: dbName, tableName: tableName}
+}
+
+func (qt QueryLogTable) Name() string {
+	return qt.tableName
+}
+
+func (qt QueryLogTable) String() string {
+	return qt.tableName
+}
+
+func (qt QueryLogTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "time", Type: types.Datetime, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "connection_id", Type: types.Uint32, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "user", Type: types.Text, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "digest", Type: types.Text, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "query", Type: types.LongText, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "rows_examined", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "rows_sent", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "rows_affected", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "error", Type: types.Text, Source: qt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: qt.dbName},
+	}
+}
+
+func (qt QueryLogTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (qt QueryLogTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (qt QueryLogTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	log := querylog.Current()
+	if log == nil {
+		return sql.RowsToRowIter(), nil
+	}
+	baseName, _ := dsess.SplitRevisionDbName(qt.dbName)
+	var rows []sql.Row
+	for _, s := range log.Statements() {
+		if !strings.EqualFold(s.Database, baseName) {
+			continue
+		}
+		rows = append(rows, sql.NewRow(s.Time, s.ConnectionID, s.User, s.Digest, s.Query, milliseconds(s.Duration),
+			s.RowsExamined, s.RowsSent, s.RowsAffected, nullIfEmpty(s.Error)))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// QuerySummaryTable is a system table aggregating the statements recorded by the sql-server query log which ran
+// against this database by their digest, a fingerprint of the statement with its literal values removed. Once the
+// query log holds dolt_query_log_max_digests digests, the statements of any further digests are aggregated into a
+// single row with a NULL digest.
+//
+// Rows examined are the rows read from tables by scans and index lookups. They don't include the rows read by joins
+// and counts which are executed directly against storage.
+type QuerySummaryTable struct {
+	dbName    string
+	tableName string
+}
+
+var _ sql.Table = (*QuerySummaryTable)(nil)
+
+func NewQuerySummaryTable(dbName, tableName string) *QuerySummaryTable {
+	return &QuerySummaryTable{dbName: dbName, tableName: tableName}
+}
+
+func (qt QuerySummaryTable) Name() string {
+	return qt.tableName
+}
+
+func (qt QuerySummaryTable) String() string {
+	return qt.tableName
+}
+
+func (qt QuerySummaryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "digest", Type: types.Text, Source: qt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: qt.dbName},
+		{Name: "digest_text", Type: types.LongText, Source: qt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: qt.dbName},
+		{Name: "query_sample", Type: types.LongText, Source: qt.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: qt.dbName},
+		{Name: "count", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "errors", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "total_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "avg_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "min_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "max_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "p50_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "p95_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "p99_latency_ms", Type: types.Float64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "rows_examined", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "rows_sent", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "rows_affected", Type: types.Int64, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "first_seen", Type: types.Datetime, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+		{Name: "last_seen", Type: types.Datetime, Source: qt.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: qt.dbName},
+	}
+}
+
+func (qt QuerySummaryTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (qt QuerySummaryTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (qt QuerySummaryTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	log := querylog.Current()
+	if log == nil {
+		return sql.RowsToRowIter(), nil
+	}
+	baseName, _ := dsess.SplitRevisionDbName(qt.dbName)
+	var rows []sql.Row
+	for _, s := range log.Summaries() {
+		if !strings.EqualFold(s.Database, baseName) {
+			continue
+		}
+		rows = append(rows, sql.NewRow(nullIfEmpty(s.Digest), nullIfEmpty(s.DigestText), nullIfEmpty(s.QuerySample),
+			s.Count, s.Errors, milliseconds(s.TotalLatency), milliseconds(s.TotalLatency)/float64(s.Count),
+			milliseconds(s.MinLatency), milliseconds(s.MaxLatency), milliseconds(s.P50Latency),
+			milliseconds(s.P95Latency), milliseconds(s.P99Latency), s.RowsExamined, s.RowsSent, s.RowsAffected,
+			s.FirstSeen, s.LastSeen))
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
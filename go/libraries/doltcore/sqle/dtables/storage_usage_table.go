@@ -0,0 +1,80 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+// StorageUsageTable is a system table reporting the storage used by the database, along with its storage quota.
+// Transactions which would grow the database past its quota fail to commit.
+type StorageUsageTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+var _ sql.Table = (*StorageUsageTable)(nil)
+
+func NewStorageUsageTable(db dsess.SqlDatabase, tableName string) *StorageUsageTable {
+	return &StorageUsageTable{db: db, tableName: tableName}
+}
+
+func (st StorageUsageTable) Name() string {
+	return st.tableName
+}
+
+func (st StorageUsageTable) String() string {
+	return st.tableName
+}
+
+func (st StorageUsageTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "database", Type: types.Text, Source: st.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: st.db.Name()},
+		{Name: "bytes", Type: types.Uint64, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.db.Name()},
+		{Name: "pending_bytes", Type: types.Uint64, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.db.Name()},
+		{Name: "journal_bytes", Type: types.Uint64, Source: st.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: st.db.Name()},
+		{Name: "quota_bytes", Type: types.Uint64, Source: st.tableName, PrimaryKey: false, Nullable: true, DatabaseSource: st.db.Name()},
+	}
+}
+
+func (st StorageUsageTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (st StorageUsageTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (st StorageUsageTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	sizes, err := st.db.DbData().Ddb.StoreSizes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dbName := st.db.AliasedName()
+	quota, ok, err := dsess.StorageQuota(dbName)
+	if err != nil {
+		return nil, err
+	}
+	var quotaBytes interface{}
+	if ok {
+		quotaBytes = quota
+	}
+	row := sql.NewRow(dbName, sizes.TotalBytes, sizes.PendingBytes, sizes.JournalBytes, quotaBytes)
+	return sql.RowsToRowIter(row), nil
+}
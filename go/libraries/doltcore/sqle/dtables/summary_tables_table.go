@@ -0,0 +1,142 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	sqlTypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+)
+
+var _ sql.Table = (*SummaryTablesTable)(nil)
+var _ sql.UpdatableTable = (*SummaryTablesTable)(nil)
+var _ sql.DeletableTable = (*SummaryTablesTable)(nil)
+var _ sql.InsertableTable = (*SummaryTablesTable)(nil)
+var _ sql.ReplaceableTable = (*SummaryTablesTable)(nil)
+var _ sql.IndexAddressableTable = (*SummaryTablesTable)(nil)
+
+// SummaryTablesTable is the system table that stores the definitions of summary tables, which are maintained from
+// their source tables incrementally on each commit.
+type SummaryTablesTable struct {
+	backingTable VersionableTable
+	schemaName   string
+}
+
+func (i *SummaryTablesTable) Name() string {
+	return doltdb.SummaryTablesTableName
+}
+
+func (i *SummaryTablesTable) String() string {
+	return doltdb.SummaryTablesTableName
+}
+
+func doltSummaryTablesSchema() sql.Schema {
+	return []*sql.Column{
+		{Name: doltdb.SummaryTablesNameCol, Type: sqlTypes.Text, Source: doltdb.SummaryTablesTableName, PrimaryKey: true},
+		{Name: doltdb.SummaryTablesSourceCol, Type: sqlTypes.Text, Source: doltdb.SummaryTablesTableName, Nullable: false},
+		{Name: doltdb.SummaryTablesGroupByCol, Type: sqlTypes.Text, Source: doltdb.SummaryTablesTableName, Nullable: false},
+		{Name: doltdb.SummaryTablesAggregatesCol, Type: sqlTypes.Text, Source: doltdb.SummaryTablesTableName, Nullable: false},
+	}
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the dolt_summary_tables system table.
+func (i *SummaryTablesTable) Schema() sql.Schema {
+	return doltSummaryTablesSchema()
+}
+
+func (i *SummaryTablesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data.
+func (i *SummaryTablesTable) Partitions(context *sql.Context) (sql.PartitionIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return index.SinglePartitionIterFromNomsMap(nil), nil
+	}
+	return i.backingTable.Partitions(context)
+}
+
+func (i *SummaryTablesTable) PartitionRows(context *sql.Context, partition sql.Partition) (sql.RowIter, error) {
+	if i.backingTable == nil {
+		// no backing table; return an empty iter.
+		return sql.RowsToRowIter(), nil
+	}
+
+	return i.backingTable.PartitionRows(context, partition)
+}
+
+// NewSummaryTablesTable creates a SummaryTablesTable
+func NewSummaryTablesTable(_ *sql.Context, backingTable VersionableTable, schemaName string) sql.Table {
+	return &SummaryTablesTable{backingTable: backingTable, schemaName: schemaName}
+}
+
+// NewEmptySummaryTablesTable creates a SummaryTablesTable with no backing table
+func NewEmptySummaryTablesTable(_ *sql.Context, schemaName string) sql.Table {
+	return &SummaryTablesTable{schemaName: schemaName}
+}
+
+// Replacer returns a RowReplacer for this table. The RowReplacer will have Insert and optionally Delete called once
+// for each row, followed by a call to Close() when all rows have been processed.
+func (it *SummaryTablesTable) Replacer(ctx *sql.Context) sql.RowReplacer {
+	return it.writer()
+}
+
+// Updater returns a RowUpdater for this table. The RowUpdater will have Update called once for each row to be
+// updated, followed by a call to Close() when all rows have been processed.
+func (it *SummaryTablesTable) Updater(ctx *sql.Context) sql.RowUpdater {
+	return it.writer()
+}
+
+// Inserter returns an Inserter for this table. The Inserter will get one call to Insert() for each row to be
+// inserted, and will end with a call to Close() to finalize the insert operation.
+func (it *SummaryTablesTable) Inserter(*sql.Context) sql.RowInserter {
+	return it.writer()
+}
+
+// Deleter returns a RowDeleter for this table. The RowDeleter will get one call to Delete for each row to be deleted,
+// and will end with a call to Close() to finalize the delete operation.
+func (it *SummaryTablesTable) Deleter(*sql.Context) sql.RowDeleter {
+	return it.writer()
+}
+
+func (it *SummaryTablesTable) LockedToRoot(ctx *sql.Context, root doltdb.RootValue) (sql.IndexAddressableTable, error) {
+	if it.backingTable == nil {
+		return it, nil
+	}
+	return it.backingTable.LockedToRoot(ctx, root)
+}
+
+// IndexedAccess implements IndexAddressableTable, but SummaryTablesTable has no indexes.
+// Thus, this should never be called.
+func (it *SummaryTablesTable) IndexedAccess(ctx *sql.Context, lookup sql.IndexLookup) sql.IndexedTable {
+	panic("Unreachable")
+}
+
+// GetIndexes implements IndexAddressableTable, but SummaryTablesTable has no indexes.
+func (it *SummaryTablesTable) GetIndexes(ctx *sql.Context) ([]sql.Index, error) {
+	return nil, nil
+}
+
+func (i *SummaryTablesTable) PreciseMatch() bool {
+	return true
+}
+
+// writer returns a writer for the rows of the table.
+func (it *SummaryTablesTable) writer() *versionedTableWriter {
+	return newVersionedTableWriter(doltdb.TableName{Name: doltdb.SummaryTablesTableName, Schema: it.schemaName}, it.Schema(), nil)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/tablesize"
+)
+
+// TableSizesTable is a system table attributing the storage of the database to the row data and secondary indexes of
+// its tables: their current versions, the history retained by its refs, and the versions only found in its reflog,
+// which dolt gc can reclaim. Reading it reads the whole history of the database.
+type TableSizesTable struct {
+	db        dsess.SqlDatabase
+	tableName string
+}
+
+var _ sql.Table = (*TableSizesTable)(nil)
+
+func NewTableSizesTable(db dsess.SqlDatabase, tableName string) *TableSizesTable {
+	return &TableSizesTable{db: db, tableName: tableName}
+}
+
+func (tst TableSizesTable) Name() string {
+	return tst.tableName
+}
+
+func (tst TableSizesTable) String() string {
+	return tst.tableName
+}
+
+func (tst TableSizesTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: types.Text, Source: tst.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "index_name", Type: types.Text, Source: tst.tableName, PrimaryKey: true, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "chunks", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "bytes", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "history_chunks", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "history_bytes", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "reclaimable_chunks", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: tst.db.Name()},
+		{Name: "reclaimable_bytes", Type: types.Uint64, Source: tst.tableName, PrimaryKey: false, Nullable: false, DatabaseSource: tst.db.Name()},
+	}
+}
+
+func (tst TableSizesTable) Collation() sql.CollationID {
+	return sql.Collation_Default
+}
+
+func (tst TableSizesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return index.SinglePartitionIterFromNomsMap(nil), nil
+}
+
+func (tst TableSizesTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	root, err := tst.db.GetRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := tablesize.Analyze(ctx, tst.db.DbData().Ddb, root)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]sql.Row, len(sizes))
+	for i, sz := range sizes {
+		rows[i] = sql.NewRow(
+			sz.Table,
+			sz.Index,
+			sz.Current.Chunks,
+			sz.Current.Bytes,
+			sz.History.Chunks,
+			sz.History.Bytes,
+			sz.Reclaimable.Chunks,
+			sz.Reclaimable.Bytes,
+		)
+	}
+	return sql.RowsToRowIter(rows...), nil
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 )
 
@@ -33,12 +34,12 @@ var _ sql.StatisticsTable = (*TagsTable)(nil)
 // TagsTable is a sql.Table implementation that implements a system table which shows the dolt tags
 type TagsTable struct {
 	tableName string
-	ddb       *doltdb.DoltDB
+	db        dsess.SqlDatabase
 }
 
 // NewTagsTable creates a TagsTable
-func NewTagsTable(_ *sql.Context, tableName string, ddb *doltdb.DoltDB) sql.Table {
-	return &TagsTable{tableName: tableName, ddb: ddb}
+func NewTagsTable(_ *sql.Context, tableName string, db dsess.SqlDatabase) sql.Table {
+	return &TagsTable{tableName: tableName, db: db}
 }
 
 func (tt *TagsTable) DataLength(ctx *sql.Context) (uint64, error) {
@@ -88,7 +89,7 @@ func (tt *TagsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
 
 // PartitionRows is a sql.Table interface function that gets a row iterator for a partition
 func (tt *TagsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
-	return NewTagsItr(ctx, tt.ddb)
+	return NewTagsItr(ctx, tt.db)
 }
 
 // TagsItr is a sql.RowItr implementation which iterates over each commit as if it's a row in the table.
@@ -97,9 +98,14 @@ type TagsItr struct {
 	idx          int
 }
 
-// NewTagsItr creates a TagsItr from the current environment.
-func NewTagsItr(ctx *sql.Context, ddb *doltdb.DoltDB) (*TagsItr, error) {
-	tagsWithHash, err := ddb.GetTagsWithHashes(ctx)
+// NewTagsItr creates a TagsItr from the tags of |db| as of the start of the current transaction.
+func NewTagsItr(ctx *sql.Context, db dsess.SqlDatabase) (*TagsItr, error) {
+	txRoot, err := dsess.TransactionRoot(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	tagsWithHash, err := db.DbData().Ddb.GetTagsWithHashesByNomsRoot(ctx, txRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,179 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+)
+
+var _ sql.RowReplacer = (*versionedTableWriter)(nil)
+var _ sql.RowUpdater = (*versionedTableWriter)(nil)
+var _ sql.RowInserter = (*versionedTableWriter)(nil)
+var _ sql.RowDeleter = (*versionedTableWriter)(nil)
+
+// versionedTableWriter writes the rows of a versioned system table, such as dolt_migrations, which is stored as a
+// table of the working root and versioned along with the rest of the database. The table is created on the first
+// write to it.
+type versionedTableWriter struct {
+	tableName doltdb.TableName
+	sch       sql.Schema
+	// checkRow, if set, is called with each row before it's inserted or updated, and rejects the row if it returns
+	// an error.
+	checkRow func(ctx *sql.Context, r sql.Row) error
+
+	errDuringStatementBegin error
+	tableWriter             dsess.TableWriter
+}
+
+func newVersionedTableWriter(tableName doltdb.TableName, sch sql.Schema, checkRow func(*sql.Context, sql.Row) error) *versionedTableWriter {
+	return &versionedTableWriter{tableName: tableName, sch: sch, checkRow: checkRow}
+}
+
+// Insert inserts the row given, returning an error if it cannot. Insert will be called once for each row to process
+// for the insert operation, which may involve many rows. After all rows in an operation have been processed, Close
+// is called.
+func (w *versionedTableWriter) Insert(ctx *sql.Context, r sql.Row) error {
+	if err := w.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if w.checkRow != nil {
+		if err := w.checkRow(ctx, r); err != nil {
+			return err
+		}
+	}
+	return w.tableWriter.Insert(ctx, r)
+}
+
+// Update the given row. Provides both the old and new rows.
+func (w *versionedTableWriter) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	if err := w.errDuringStatementBegin; err != nil {
+		return err
+	}
+	if w.checkRow != nil {
+		if err := w.checkRow(ctx, new); err != nil {
+			return err
+		}
+	}
+	return w.tableWriter.Update(ctx, old, new)
+}
+
+// Delete deletes the given row. Returns ErrDeleteRowNotFound if the row was not found. Delete will be called once for
+// each row to process for the delete operation, which may involve many rows. After all rows have been processed,
+// Close is called.
+func (w *versionedTableWriter) Delete(ctx *sql.Context, r sql.Row) error {
+	if err := w.errDuringStatementBegin; err != nil {
+		return err
+	}
+	return w.tableWriter.Delete(ctx, r)
+}
+
+// StatementBegin is called before the first operation of a statement. Integrators should mark the state of the data
+// in some way that it may be returned to in the case of an error.
+func (w *versionedTableWriter) StatementBegin(ctx *sql.Context) {
+	if err := w.statementBegin(ctx); err != nil {
+		w.errDuringStatementBegin = err
+	}
+}
+
+func (w *versionedTableWriter) statementBegin(ctx *sql.Context) error {
+	dbName := ctx.GetCurrentDatabase()
+	dSess := dsess.DSessFromSess(ctx.Session)
+
+	roots, _ := dSess.GetRoots(ctx, dbName)
+	dbState, ok, err := dSess.LookupDbState(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("no root value found in session")
+	}
+
+	found, err := roots.Working.HasTable(ctx, w.tableName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		sch := sql.NewPrimaryKeySchema(w.sch)
+		doltSch, err := sqlutil.ToDoltSchema(ctx, roots.Working, w.tableName, sch, roots.Head, sql.Collation_Default)
+		if err != nil {
+			return err
+		}
+
+		// underlying table doesn't exist. Record this, then create the table.
+		newRootValue, err := doltdb.CreateEmptyTable(ctx, roots.Working, w.tableName, doltSch)
+		if err != nil {
+			return err
+		}
+
+		if dbState.WorkingSet() == nil {
+			return doltdb.ErrOperationNotSupportedInDetachedHead
+		}
+
+		// We use WriteSession.SetWorkingSet instead of DoltSession.SetWorkingRoot because we want to avoid modifying the root
+		// until the end of the transaction, but we still want the WriteSession to be able to find the newly
+		// created table.
+		if ws := dbState.WriteSession(); ws != nil {
+			err = ws.SetWorkingSet(ctx, dbState.WorkingSet().WithWorkingRoot(newRootValue))
+			if err != nil {
+				return err
+			}
+		}
+
+		dSess.SetWorkingRoot(ctx, dbName, newRootValue)
+	}
+
+	if ws := dbState.WriteSession(); ws != nil {
+		tableWriter, err := ws.GetTableWriter(ctx, w.tableName, dbName, dSess.SetWorkingRoot, false)
+		if err != nil {
+			return err
+		}
+		w.tableWriter = tableWriter
+		tableWriter.StatementBegin(ctx)
+	}
+	return nil
+}
+
+// DiscardChanges is called if a statement encounters an error, and all current changes since the statement beginning
+// should be discarded.
+func (w *versionedTableWriter) DiscardChanges(ctx *sql.Context, errorEncountered error) error {
+	if w.tableWriter != nil {
+		return w.tableWriter.DiscardChanges(ctx, errorEncountered)
+	}
+	return nil
+}
+
+// StatementComplete is called after the last operation of the statement, indicating that it has successfully completed.
+// The mark set in StatementBegin may be removed, and a new one should be created on the next StatementBegin.
+func (w *versionedTableWriter) StatementComplete(ctx *sql.Context) error {
+	if w.tableWriter != nil {
+		return w.tableWriter.StatementComplete(ctx)
+	}
+	return nil
+}
+
+// Close finalizes the write operation, persisting the result.
+func (w *versionedTableWriter) Close(ctx *sql.Context) error {
+	if w.tableWriter != nil {
+		return w.tableWriter.Close(ctx)
+	}
+	return nil
+}
@@ -35,7 +35,10 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/auditlog"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/events"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/querylog"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/statspro"
 	"github.com/dolthub/dolt/go/libraries/utils/config"
 	"github.com/dolthub/dolt/go/store/types"
@@ -1471,6 +1474,81 @@ func TestBackupsSystemTable(t *testing.T) {
 	enginetest.TestScript(t, h, BackupsSystemTableQueries)
 }
 
+func TestOperationsSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, OperationsSystemTableQueries)
+}
+
+func TestAuditLogSystemTable(t *testing.T) {
+	log, err := auditlog.NewLogger(auditlog.Options{Target: auditlog.TargetTable, Queries: auditlog.QueriesAll, MaxEntries: 10})
+	require.NoError(t, err)
+	log.Log(auditlog.Entry{Event: auditlog.EventConnect, ConnectionID: 1, User: "root", Database: "mydb", Branch: "main"})
+	log.Log(auditlog.Entry{Event: auditlog.EventQuery, ConnectionID: 1, User: "root", Database: "mydb", Branch: "main",
+		Kind: auditlog.KindDDL, Query: "create table t (pk int primary key)", Tables: []string{"t"}, Duration: time.Millisecond})
+	log.Log(auditlog.Entry{Event: auditlog.EventQuery, ConnectionID: 2, User: "root", Database: "otherdb", Branch: "main",
+		Kind: auditlog.KindRead, Query: "select * from t", Tables: []string{"t"}})
+	log.Log(auditlog.Entry{Event: auditlog.EventQuery, ConnectionID: 1, User: "root", Database: "mydb", Branch: "main",
+		Kind: auditlog.KindDML, Query: "insert into t values (1), (1)", Tables: []string{"t"}, Error: "duplicate primary key given: [1]"})
+	auditlog.SetCurrent(log)
+	defer auditlog.SetCurrent(nil)
+
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, AuditLogSystemTableQueries)
+}
+
+func TestQueryLogSystemTables(t *testing.T) {
+	log := querylog.NewLog(querylog.Options{Size: 10, MaxDigests: 10})
+	log.Record(querylog.Statement{ConnectionID: 1, User: "root", Database: "mydb", Query: "select * from t where pk = 1",
+		Duration: 2 * time.Millisecond, RowsExamined: 1, RowsSent: 1})
+	log.Record(querylog.Statement{ConnectionID: 1, User: "root", Database: "mydb", Query: "SELECT * FROM t WHERE pk = 2",
+		Duration: 4 * time.Millisecond, RowsExamined: 1, RowsSent: 1})
+	log.Record(querylog.Statement{ConnectionID: 2, User: "root", Database: "otherdb", Query: "select * from t"})
+	log.Record(querylog.Statement{ConnectionID: 1, User: "root", Database: "mydb", Query: "insert into t values (1), (1)",
+		Duration: 10 * time.Millisecond, Error: "duplicate primary key given: [1]"})
+	querylog.SetCurrent(log)
+	defer querylog.SetCurrent(nil)
+
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, QueryLogSystemTableQueries)
+}
+
+func TestEventsHistorySystemTable(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	h := events.NewHistory(10)
+	h.Record(events.Execution{Database: "mydb", Branch: "main", Event: "e1", Start: start, End: start.Add(5 * time.Millisecond)})
+	h.Record(events.Execution{Database: "otherdb", Branch: "main", Event: "e1", Start: start, End: start})
+	h.Record(events.Execution{Database: "mydb", Branch: "feature", Event: "e2", Start: start, End: start.Add(time.Millisecond), CatchUp: true})
+	h.Record(events.Execution{Database: "mydb", Branch: "main", Event: "e1", Start: start.Add(time.Minute),
+		End: start.Add(time.Minute + 2*time.Millisecond), Error: "duplicate primary key given: [1]"})
+	events.SetCurrent(h)
+	defer events.SetCurrent(nil)
+
+	harness := newDoltHarness(t)
+	defer harness.Close()
+	enginetest.TestScript(t, harness, EventsHistorySystemTableQueries)
+}
+
+func TestCacheStatsSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, CacheStatsSystemTableQueries)
+}
+
+func TestStorageUsageSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, StorageUsageSystemTableQueries)
+}
+
+func TestTableSizesSystemTable(t *testing.T) {
+	h := newDoltHarness(t)
+	defer h.Close()
+	enginetest.TestScript(t, h, TableSizesSystemTableQueries)
+}
+
 func TestHistorySystemTable(t *testing.T) {
 	harness := newDoltEnginetestHarness(t).WithParallelism(2)
 	RunHistorySystemTableTests(t, harness)
@@ -1619,6 +1697,11 @@ func TestQueryDiff(t *testing.T) {
 	RunQueryDiffTests(t, harness)
 }
 
+func TestForEachBranch(t *testing.T) {
+	harness := newDoltEnginetestHarness(t)
+	RunForEachBranchTests(t, harness)
+}
+
 func TestSystemTableIndexes(t *testing.T) {
 	harness := newDoltEnginetestHarness(t)
 	RunSystemTableIndexesTests(t, harness)
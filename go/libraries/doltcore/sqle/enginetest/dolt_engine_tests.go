@@ -1390,6 +1390,17 @@ func RunQueryDiffTests(t *testing.T, harness DoltEnginetestHarness) {
 	}
 }
 
+func RunForEachBranchTests(t *testing.T, harness DoltEnginetestHarness) {
+	for _, test := range ForEachBranchTableScriptTests {
+		t.Run(test.Name, func(t *testing.T) {
+			harness = harness.NewHarness(t)
+			defer harness.Close()
+			harness.Setup(setup.MydbData)
+			enginetest.TestScript(t, harness, test)
+		})
+	}
+}
+
 func RunSystemTableIndexesTests(t *testing.T, harness DoltEnginetestHarness) {
 	if !types.IsFormat_DOLT(types.Format_Default) {
 		t.Skip("only new format support system table indexing")
@@ -1819,6 +1830,7 @@ func RunThreeWayMergeWithSchemaChangeScripts(t *testing.T, h DoltEnginetestHarne
 	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsSchemaConflicts, "schema conflicts", false)
 	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsGeneratedColumns, "generated columns", false)
 	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsForJsonConflicts, "json merge", false)
+	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsForCellMerges, "cell merges", false)
 
 	// Run non-symmetric schema merge tests in just one direction
 	t.Run("type changes", func(t *testing.T) {
@@ -1842,6 +1854,7 @@ func RunThreeWayMergeWithSchemaChangeScriptsPrepared(t *testing.T, h DoltEnginet
 	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsSchemaConflicts, "schema conflicts", false)
 	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsGeneratedColumns, "generated columns", false)
 	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsForJsonConflicts, "json merge", false)
+	runMergeScriptTestsInBothDirections(t, SchemaChangeTestsForCellMerges, "cell merges", false)
 
 	// Run non-symmetric schema merge tests in just one direction
 	t.Run("type changes", func(t *testing.T) {
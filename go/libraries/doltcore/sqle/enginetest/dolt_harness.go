@@ -38,7 +38,9 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/kvexec"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/planbaseline"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/statspro"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/writer"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
@@ -273,6 +275,11 @@ func (d *DoltHarness) NewEngine(t *testing.T) (enginetest.QueryEngine, error) {
 			return nil, err
 		}
 		e.Analyzer.ExecBuilder = rowexec.NewOverrideBuilder(kvexec.Builder{})
+		planbaseline.AddCheckRule(e.Analyzer)
+		dsess.AddTransactionalDDLRule(e.Analyzer)
+		index.AddDescendingIndexSortRule(e.Analyzer)
+		index.AddSpatialIndexCostingRule(e.Analyzer)
+		doltProvider.SetStatementRunner(e)
 		d.engine = e
 
 		sqlCtx := enginetest.NewContext(d)
@@ -475,6 +482,7 @@ func (d *DoltHarness) NewDatabases(names ...string) []sql.Database {
 
 	e := enginetest.NewEngineWithProvider(d.t, d, d.provider)
 	require.NoError(d.t, err)
+	doltProvider.SetStatementRunner(e)
 	d.engine = e
 
 	for _, name := range names {
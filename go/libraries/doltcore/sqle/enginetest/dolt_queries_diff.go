@@ -1545,13 +1545,33 @@ on a.to_pk = b.to_pk;`,
 			{
 				Query: "select to_i, to_jk, from_i, from_jk from dolt_diff_t;",
 				Expected: []sql.Row{
-					{1, nil, nil, nil},
+					{1, 23, nil, nil},
 				},
 			},
 			{
 				Query: "select to_i, to_jk, from_i, from_jk from dolt_diff('HEAD', 'WORKING', 't');",
 				Expected: []sql.Row{
-					{1, nil, nil, nil},
+					{1, 23, nil, nil},
+				},
+			},
+			{
+				Query:            "call dolt_commit('-am', 'inserted row');",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:            "update t set k = 5 where i = 1;",
+				SkipResultsCheck: true,
+			},
+			{
+				Query: "select to_i, to_jk, from_i, from_jk, diff_type from dolt_diff('HEAD', 'WORKING', 't');",
+				Expected: []sql.Row{
+					{1, 25, 1, 23, "modified"},
+				},
+			},
+			{
+				Query: "select to_i, to_jk, from_i, from_jk, diff_type from dolt_diff('HEAD~1', 'HEAD', 't');",
+				Expected: []sql.Row{
+					{1, 23, nil, nil, "added"},
 				},
 			},
 		},
@@ -3061,6 +3081,65 @@ var DiffSummaryTableFunctionScriptTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "row counts with --stat",
+		SetUpScript: []string{
+			"create table t (pk int primary key, c1 varchar(20));",
+			"create table keyless (c1 int);",
+			"create table dropped (pk int primary key);",
+			"insert into t values (1, 'one'), (2, 'two'), (3, 'three');",
+			"insert into keyless values (1), (1), (2);",
+			"insert into dropped values (1), (2);",
+			"call dolt_commit('-Am', 'first commit');",
+
+			"insert into t values (4, 'four');",
+			"update t set c1 = 'uno' where pk = 1;",
+			"delete from t where pk = 2;",
+			"delete from keyless where c1 = 1;",
+			"insert into keyless values (3);",
+			"drop table dropped;",
+			"create table added (pk int primary key);",
+			"create table altered (pk int primary key);",
+			"call dolt_commit('-Am', 'second commit');",
+			"alter table altered add column c1 int;",
+			"call dolt_commit('-am', 'third commit');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:       "SELECT * from dolt_diff_summary('--stat');",
+				ExpectedErr: sql.ErrInvalidArgumentNumber,
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('--stat', 'HEAD~2', 'HEAD~');",
+				Expected: []sql.Row{
+					{"dropped", "", "dropped", true, true, 0, 2, 0},
+					{"", "added", "added", false, true, 0, 0, 0},
+					{"keyless", "keyless", "modified", true, false, 1, 2, nil},
+					{"t", "t", "modified", true, false, 1, 1, 1},
+					{"", "altered", "added", false, true, 0, 0, 0},
+				},
+			},
+			{
+				Query: "SELECT * from dolt_diff_summary('--stat', 'HEAD~2..HEAD~', 't');",
+				Expected: []sql.Row{
+					{"t", "t", "modified", true, false, 1, 1, 1},
+				},
+			},
+			{
+				Query: "SELECT to_table_name, rows_added, rows_deleted, rows_modified from dolt_diff_summary('--STAT', 'HEAD~', 'HEAD');",
+				Expected: []sql.Row{
+					{"altered", 0, 0, 0},
+				},
+			},
+			{
+				// the summary without --stat is unchanged
+				Query: "SELECT * from dolt_diff_summary('HEAD~', 'HEAD');",
+				Expected: []sql.Row{
+					{"altered", "altered", "modified", false, true},
+				},
+			},
+		},
+	},
 }
 
 var PatchTableFunctionScriptTests = []queries.ScriptTest{
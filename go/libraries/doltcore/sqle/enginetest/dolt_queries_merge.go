@@ -697,7 +697,7 @@ var MergeScripts = []queries.ScriptTest{
 			{
 				// errors because creating a new branch implicitly commits the current transaction
 				Query:          "CALL DOLT_CHECKOUT('-b', 'other-branch')",
-				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error(),
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error() + " (errno 50002) (sqlstate XD002)",
 			},
 		},
 	},
@@ -1034,6 +1034,120 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "CALL DOLT_MERGE with the theirs strategy",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c1 int, c2 int);",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_BRANCH('other');",
+			"UPDATE t SET c1 = 10 WHERE pk = 1;",
+			"UPDATE t SET c2 = 20 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'update main');",
+			"CALL DOLT_CHECKOUT('other');",
+			"UPDATE t SET c1 = 100 WHERE pk = 1;",
+			"UPDATE t SET c1 = 200 WHERE pk = 2;",
+			"INSERT INTO t VALUES (3, 3, 3);",
+			"CALL DOLT_COMMIT('-am', 'update other');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other', '--strategy', 'theirs')",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				// the conflicting row takes their version, and the other changes are merged cell-wise
+				Query:    "SELECT * FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, 100, 1}, {2, 200, 20}, {3, 3, 3}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_conflicts",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_commit_ancestors WHERE commit_hash = HASHOF('main')",
+				Expected: []sql.Row{{2}},
+			},
+		},
+	},
+	{
+		Name: "CALL DOLT_MERGE with the ours strategy, --squash and --no-commit",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c1 int, c2 int);",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_BRANCH('other');",
+			"UPDATE t SET c1 = 10 WHERE pk = 1;",
+			"UPDATE t SET c2 = 20 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'update main');",
+			"CALL DOLT_CHECKOUT('other');",
+			"UPDATE t SET c1 = 100 WHERE pk = 1;",
+			"UPDATE t SET c1 = 200 WHERE pk = 2;",
+			"INSERT INTO t VALUES (3, 3, 3);",
+			"CALL DOLT_COMMIT('-am', 'update other');",
+			"CALL DOLT_CHECKOUT('main');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "CALL DOLT_MERGE('other', '-s', 'ours', '--squash', '--no-commit')",
+				Expected: []sql.Row{{"", 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, 10, 1}, {2, 200, 20}, {3, 3, 3}},
+			},
+			{
+				Query:    "SELECT * FROM dolt_status",
+				Expected: []sql.Row{{"t", true, "modified"}},
+			},
+			{
+				Query:    "SELECT is_merging FROM dolt_merge_status",
+				Expected: []sql.Row{{false}},
+			},
+			{
+				Query:    "SELECT COUNT(*) FROM dolt_log",
+				Expected: []sql.Row{{3}},
+			},
+		},
+	},
+	{
+		Name: "CALL DOLT_MERGE with the resolve-cell-wise strategy",
+		SetUpScript: []string{
+			"CREATE TABLE t (pk int primary key, c1 int, c2 int);",
+			"INSERT INTO t VALUES (1, 1, 1), (2, 2, 2);",
+			"CALL DOLT_COMMIT('-Am', 'base');",
+			"CALL DOLT_BRANCH('other');",
+			"UPDATE t SET c1 = 10 WHERE pk = 1;",
+			"UPDATE t SET c2 = 20 WHERE pk = 2;",
+			"CALL DOLT_COMMIT('-am', 'update main');",
+			"CALL DOLT_CHECKOUT('other');",
+			"UPDATE t SET c1 = 100 WHERE pk = 1;",
+			"UPDATE t SET c1 = 200 WHERE pk = 2;",
+			"INSERT INTO t VALUES (3, 3, 3);",
+			"CALL DOLT_COMMIT('-am', 'update other');",
+			"CALL DOLT_CHECKOUT('main');",
+			"SET @@dolt_allow_commit_conflicts = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_MERGE('other', '--strategy', 'octopus')",
+				ExpectedErrStr: "unknown merge strategy 'octopus'; valid strategies are 'resolve-cell-wise', 'ours' and 'theirs'",
+			},
+			{
+				Query:    "CALL DOLT_MERGE('other', '--strategy', 'resolve-cell-wise')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "SELECT our_pk, our_c1, their_c1 FROM dolt_conflicts_t",
+				Expected: []sql.Row{{1, 10, 100}},
+			},
+			{
+				Query:    "SELECT * FROM t ORDER BY pk",
+				Expected: []sql.Row{{1, 10, 1}, {2, 200, 20}, {3, 3, 3}},
+			},
+		},
+	},
 	{
 		Name: "CALL DOLT_MERGE when current or ahead results in a no-op",
 		SetUpScript: []string{
@@ -1108,7 +1222,7 @@ var MergeScripts = []queries.ScriptTest{
 			},
 			{
 				Query:          "CALL DOLT_MERGE('feature-branch')",
-				ExpectedErrStr: dsess.ErrUnresolvedConflictsAutoCommit.Error(),
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsAutoCommit.Error() + " (errno 50002) (sqlstate XD002)",
 			},
 			{
 				Query:    "SELECT count(*) from dolt_conflicts_test", // transaction has been rolled back, 0 results
@@ -1216,7 +1330,7 @@ var MergeScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:          "CALL DOLT_MERGE('feature-branch', '-m', 'this is a merge')",
-				ExpectedErrStr: "error: local changes would be stomped by merge:\n\ttest\n Please commit your changes before you merge.",
+				ExpectedErrStr: "error: local changes would be stomped by merge:\n\ttest\n Please commit your changes before you merge. (errno 50005) (sqlstate XD005)",
 			},
 			{
 				Query:    "SELECT is_merging, source, target, unmerged_tables FROM DOLT_MERGE_STATUS;",
@@ -1224,6 +1338,31 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "dolt procedures return error codes by class",
+		SetUpScript: []string{
+			"CREATE TABLE test (pk int primary key, val int)",
+			"CALL DOLT_COMMIT('-Am', 'create table');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:          "CALL DOLT_COMMIT('-am', 'nothing here');",
+				ExpectedErrStr: "nothing to commit (errno 50001) (sqlstate XD001)",
+			},
+			{
+				Query:          "CALL DOLT_MERGE('nosuchbranch');",
+				ExpectedErrStr: "branch not found: nosuchbranch (errno 50007) (sqlstate XD007)",
+			},
+			{
+				Query:          "CALL DOLT_PUSH('nosuchremote', 'main');",
+				ExpectedErrStr: "fatal: remote 'nosuchremote' not found.\nPlease make sure the remote exists. (errno 50006) (sqlstate XD006)",
+			},
+			{
+				Query:          "CALL DOLT_BRANCH('-d', 'nosuchbranch');",
+				ExpectedErrStr: "branch not found",
+			},
+		},
+	},
 	{
 		Name: "Drop and add primary key on two branches converges to same schema",
 		SetUpScript: []string{
@@ -2364,6 +2503,142 @@ var MergeScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "resolve check constraint violations with --ours",
+		SetUpScript: []string{
+			"SET dolt_force_transaction_commit = on;",
+			"create table t (pk int primary key, i int, key (i))",
+			"insert into t values (1, 1), (2, 2);",
+			"call dolt_commit('-Am', 'new table');",
+			"call dolt_checkout('-b', 'other')",
+			"update t set i = 20 where pk = 1;",
+			"insert into t values (3, 30);",
+			"call dolt_commit('-am', 'large values')",
+			"call dolt_checkout('main');",
+			"alter table t add constraint chk_i check (i < 10);",
+			"call dolt_commit('-am', 'added check');",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('other')",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query: "select violation_type, pk, i, cast(violation_info as char) from dolt_constraint_violations_t order by pk",
+				Expected: []sql.Row{
+					{"check constraint", 1, 20, `{"Name": "chk_i", "Expression": "(i < 10)"}`},
+					{"check constraint", 3, 30, `{"Name": "chk_i", "Expression": "(i < 10)"}`},
+				},
+			},
+			{
+				Query:          "call dolt_constraint_violations_resolve('t')",
+				ExpectedErrStr: "--ours or --theirs must be supplied",
+			},
+			{
+				Query:    "call dolt_constraint_violations_resolve('--ours', 't')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+			{
+				Query:    "select pk from t where i = 20",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "select * from dolt_constraint_violations",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "call dolt_commit('-am', 'merged other')",
+				Expected: []sql.Row{{doltCommit}},
+			},
+		},
+	},
+	{
+		Name: "resolve check constraint violations with --theirs",
+		SetUpScript: []string{
+			"SET dolt_force_transaction_commit = on;",
+			"create table t (pk int primary key, i int, key (i))",
+			"insert into t values (1, 1), (2, 2);",
+			"call dolt_commit('-Am', 'new table');",
+			"call dolt_checkout('-b', 'other')",
+			"update t set i = 20 where pk = 1;",
+			"call dolt_commit('-am', 'large values')",
+			"call dolt_checkout('main');",
+			"alter table t add constraint chk_i check (i < 10);",
+			"call dolt_commit('-am', 'added check');",
+			"call dolt_merge('other')",
+			"update t set i = 5 where pk = 1;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select violation_type, pk, i from dolt_constraint_violations_t",
+				Expected: []sql.Row{{"check constraint", 1, 20}},
+			},
+			{
+				Query:    "call dolt_constraint_violations_resolve('--theirs', '.')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 20}, {2, 2}},
+			},
+			{
+				Query:    "select pk from t where i = 20",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				// their version of the row still fails the check, so its violation is kept
+				Query:    "select violation_type, pk, i from dolt_constraint_violations_t",
+				Expected: []sql.Row{{"check constraint", 1, 20}},
+			},
+		},
+	},
+	{
+		Name: "resolve unique key violations",
+		SetUpScript: []string{
+			"SET dolt_force_transaction_commit = on;",
+			"create table t (pk int primary key, u int, unique key (u))",
+			"insert into t values (1, 1), (2, 2);",
+			"call dolt_commit('-Am', 'new table');",
+			"call dolt_checkout('-b', 'other')",
+			"update t set u = 1 where pk = 2;",
+			"call dolt_commit('-am', 'duplicate u')",
+			"call dolt_checkout('main');",
+			"insert into t values (3, 3);",
+			"call dolt_commit('-am', 'insert 3');",
+			"call dolt_merge('other')",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select violation_type, pk, u from dolt_constraint_violations_t order by pk",
+				Expected: []sql.Row{{"unique index", 1, 1}, {"unique index", 2, 1}},
+			},
+			{
+				// their versions of both rows still collide, so their violations are kept
+				Query:    "call dolt_constraint_violations_resolve('--theirs', 't')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select violation_type, pk, u from dolt_constraint_violations_t order by pk",
+				Expected: []sql.Row{{"unique index", 1, 1}, {"unique index", 2, 1}},
+			},
+			{
+				Query:    "call dolt_constraint_violations_resolve('--ours', 't')",
+				Expected: []sql.Row{{0}},
+			},
+			{
+				Query:    "select * from t order by pk",
+				Expected: []sql.Row{{1, 1}, {2, 2}, {3, 3}},
+			},
+			{
+				Query:    "select * from dolt_constraint_violations",
+				Expected: []sql.Row{},
+			},
+		},
+	},
 	{
 		Name: "merge fulltext with renamed table",
 		SetUpScript: []string{
@@ -3838,7 +4113,7 @@ var SchemaConflictScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:          "call dolt_merge('other')",
-				ExpectedErrStr: dsess.ErrUnresolvedConflictsAutoCommit.Error(),
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsAutoCommit.Error() + " (errno 50002) (sqlstate XD002)",
 			},
 			{
 				Query:    "select * from dolt_schema_conflicts",
@@ -583,7 +583,7 @@ var SchemaChangeTestsBasicCases = []MergeScriptTest{
 			{
 				Skip:           true,
 				Query:          "call dolt_merge('right');",
-				ExpectedErrStr: "Merge conflict detected, @autocommit transaction rolled back. @autocommit must be disabled so that merge conflicts can be resolved using the dolt_conflicts and dolt_schema_conflicts tables before manually committing the transaction. Alternatively, to commit transactions with merge conflicts, set @@dolt_allow_commit_conflicts = 1",
+				ExpectedErrStr: "Merge conflict detected, @autocommit transaction rolled back. @autocommit must be disabled so that merge conflicts can be resolved using the dolt_conflicts and dolt_schema_conflicts tables before manually committing the transaction. Alternatively, to commit transactions with merge conflicts, set @@dolt_allow_commit_conflicts = 1 (errno 50002) (sqlstate XD002)",
 			},
 		},
 	},
@@ -2639,6 +2639,96 @@ var SchemaChangeTestsForJsonConflicts = []MergeScriptTest{
 	},
 }
 
+var SchemaChangeTestsForCellMerges = []MergeScriptTest{
+	{
+		Name: "changes to different columns of the same row merge cell-wise",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"CREATE table t (pk int primary key, c1 int, c2 varchar(20), c3 json, c4 text, c5 double);",
+			`INSERT into t values (1, 1, 'a', '{}', 'x', 1.5);`,
+		},
+		RightSetUpScript: []string{
+			`update t set c1 = 2, c3 = '{"a": 1}', c5 = 2.5;`,
+		},
+		LeftSetUpScript: []string{
+			`update t set c2 = 'b', c4 = 'y';`,
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select * from t;",
+				Expected: []sql.Row{{1, 2, "b", `{"a": 1}`, "y", 2.5}},
+			},
+		},
+	},
+	{
+		Name: "changes to different columns merge cell-wise when one side changes the schema",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"CREATE table t (pk int primary key, c1 int, c2 int, c3 int);",
+			"INSERT into t values (1, 1, 1, 1), (2, 2, 2, 2);",
+		},
+		RightSetUpScript: []string{
+			"alter table t modify column c1 bigint;",
+			"alter table t rename column c3 to c4;",
+			"update t set c1 = 10 where pk = 1;",
+		},
+		LeftSetUpScript: []string{
+			"update t set c2 = 20 where pk = 1;",
+			"update t set c3 = 30 where pk = 2;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{doltCommit, 0, 0, "merge successful"}},
+			},
+			{
+				Query:    "select pk, c1, c2, c4 from t order by pk;",
+				Expected: []sql.Row{{1, 10, 20, 1}, {2, 2, 2, 30}},
+			},
+		},
+	},
+	{
+		Name: "changes to different columns conflict with @@dolt_dont_merge_cells",
+		AncSetUpScript: []string{
+			"set autocommit = 0;",
+			"set @@dolt_dont_merge_cells = 1;",
+			"CREATE table t (pk int primary key, c1 int, c2 int);",
+			"INSERT into t values (1, 1, 1), (2, 2, 2);",
+		},
+		RightSetUpScript: []string{
+			"update t set c1 = 10 where pk = 1;",
+			"update t set c1 = 20 where pk = 2;",
+		},
+		LeftSetUpScript: []string{
+			"update t set c2 = 10 where pk = 1;",
+			"update t set c1 = 20 where pk = 2;",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "call dolt_merge('right');",
+				Expected: []sql.Row{{"", 0, 1, "conflicts found"}},
+			},
+			{
+				Query:    "select * from dolt_conflicts;",
+				Expected: []sql.Row{{"t", uint(1)}},
+			},
+			{
+				Query:    "select base_pk, base_c1, base_c2 from dolt_conflicts_t;",
+				Expected: []sql.Row{{1, 1, 1}},
+			},
+			{
+				// identical changes on both sides aren't conflicts
+				Query:    "select * from t where pk = 2;",
+				Expected: []sql.Row{{2, 20, 2}},
+			},
+		},
+	},
+}
+
 // These tests are not run because they cause panics during set-up.
 // Each one is labeled with a GitHub issue.
 var DisabledSchemaChangeTests = []MergeScriptTest{}
@@ -104,7 +104,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('child1')",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations",
@@ -112,7 +112,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all', 'child1');",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations",
@@ -130,7 +130,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS();",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(1)}, {"child4", "foreign key", "fk_name2", int64(1)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -148,7 +148,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('child3');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(1)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -166,7 +166,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('child3', 'child4');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(1)}, {"child4", "foreign key", "fk_name2", int64(1)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -184,7 +184,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(2)}, {"child4", "foreign key", "fk_name2", int64(2)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -202,7 +202,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all', 'child3');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(2)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -220,7 +220,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all', 'child3', 'child4');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(2)}, {"child4", "foreign key", "fk_name2", int64(2)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -234,7 +234,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--output-only', 'child3', 'child4');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(1)}, {"child4", "foreign key", "fk_name2", int64(1)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -248,7 +248,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all', '--output-only', 'child3', 'child4');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(2)}, {"child4", "foreign key", "fk_name2", int64(2)}},
 			},
 			{
 				Query:    "SELECT * from dolt_constraint_violations;",
@@ -256,6 +256,32 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name:        "verify-constraints: FK violations: --concurrency",
+		SetUpScript: verifyConstraintsFkViolationsSetupScript,
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:            "SET DOLT_FORCE_TRANSACTION_COMMIT = 1;",
+				SkipResultsCheck: true,
+			},
+			{
+				Query:          "CALL DOLT_VERIFY_CONSTRAINTS('--concurrency', '0');",
+				ExpectedErrStr: "--concurrency must be at least 1",
+			},
+			{
+				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all', '--concurrency', '1');",
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(2)}, {"child4", "foreign key", "fk_name2", int64(2)}},
+			},
+			{
+				Query:    "SELECT * from dolt_constraint_violations;",
+				Expected: []sql.Row{{"child3", uint64(2)}, {"child4", uint64(2)}},
+			},
+			{
+				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('--all', '--concurrency', '8', 'child1', 'child2', 'child3', 'child4');",
+				Expected: []sql.Row{{"child3", "foreign key", "fk_name1", int64(2)}, {"child4", "foreign key", "fk_name2", int64(2)}},
+			},
+		},
+	},
 	{
 		Name: "verify-constraints: FK violations: bad compound primary key reuse as index - no error",
 		SetUpScript: []string{
@@ -269,7 +295,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:    "call DOLT_VERIFY_CONSTRAINTS('--all');",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -291,7 +317,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:    "call DOLT_VERIFY_CONSTRAINTS('--all');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child", "foreign key", "child_ibfk_1", int64(1)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -311,7 +337,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 		Assertions: []queries.ScriptTestAssertion{
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('child')",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				Query:            "set foreign_key_checks = 0;",
@@ -327,7 +353,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			},
 			{
 				Query:    "CALL DOLT_VERIFY_CONSTRAINTS('child')",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"child", "foreign key", "child_ibfk_1", int64(1)}},
 			},
 		},
 	},
@@ -364,7 +390,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// verify constraints in working set
 				Query:    "call dolt_verify_constraints();",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "unique index", "col1", int64(2)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -413,12 +439,12 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// no violations in the working set
 				Query:    "call dolt_verify_constraints();",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				// one unique violation in all the data
 				Query:    "call dolt_verify_constraints('--all');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "unique index", "col1", int64(2)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -464,7 +490,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// verify constraints in working set
 				Query:    "call dolt_verify_constraints('t');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "unique index", "col1", int64(2)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -510,7 +536,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// verify constraints in working set
 				Query:    "call dolt_verify_constraints('otherTable');",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				// Nothing in dolt_constraint_violations because we only verified otherTable
@@ -553,12 +579,12 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// no violations in the working set
 				Query:    "call dolt_verify_constraints();",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				// one unique violation in all the data
 				Query:    "call dolt_verify_constraints('--all', '--output-only');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "unique index", "col1", int64(2)}},
 			},
 			{
 				// no output recorded because of --output-only
@@ -599,7 +625,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// verify constraints in working set
 				Query:    "call dolt_verify_constraints();",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "check constraint", "t_chk_5eebhnk4", int64(1)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -646,12 +672,12 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// no violations in the working set
 				Query:    "call dolt_verify_constraints();",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				// one unique violation in all the data
 				Query:    "call dolt_verify_constraints('--all');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "check constraint", "t_chk_5eebhnk4", int64(1)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -695,7 +721,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// verify constraints in working set
 				Query:    "call dolt_verify_constraints('t');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "check constraint", "t_chk_5eebhnk4", int64(1)}},
 			},
 			{
 				Query:    "select * from dolt_constraint_violations;",
@@ -739,7 +765,7 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// verify constraints in working set
 				Query:    "call dolt_verify_constraints('otherTable');",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				// Nothing in dolt_constraint_violations because we only verify otherTable
@@ -781,12 +807,12 @@ var DoltVerifyConstraintsTestScripts = []queries.ScriptTest{
 			{
 				// no violations in the working set
 				Query:    "call dolt_verify_constraints();",
-				Expected: []sql.Row{{0}},
+				Expected: []sql.Row{},
 			},
 			{
 				// one unique violation in all the data
 				Query:    "call dolt_verify_constraints('--all', '--output-only');",
-				Expected: []sql.Row{{1}},
+				Expected: []sql.Row{{"t", "check constraint", "t_chk_5eebhnk4", int64(1)}},
 			},
 			{
 				// no output recorded because of --output-only
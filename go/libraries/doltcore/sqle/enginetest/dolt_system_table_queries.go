@@ -17,6 +17,7 @@ package enginetest
 import (
 	"github.com/dolthub/go-mysql-server/enginetest/queries"
 	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/types"
 )
 
 var BrokenSystemTableQueries = []queries.QueryTest{
@@ -103,3 +104,199 @@ var BackupsSystemTableQueries = queries.ScriptTest{
 		},
 	},
 }
+
+var OperationsSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_operations table",
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			// operations are only listed while they are running
+			Query:    "select * from dolt_operations;",
+			Expected: []sql.Row{},
+		},
+		{
+			Query:          "insert into dolt_operations (id) values (1);",
+			ExpectedErrStr: "table doesn't support INSERT INTO",
+		},
+	},
+}
+
+// AuditLogSystemTableQueries expects the audit log to hold the entries logged by TestAuditLogSystemTable.
+var AuditLogSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_audit_log table",
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			// only entries for the current database are listed
+			Query: "select event, connection_id, user, branch, kind, query, tables, error from dolt_audit_log;",
+			Expected: []sql.Row{
+				{"connect", uint32(1), "root", "main", nil, nil, nil, nil},
+				{"query", uint32(1), "root", "main", "ddl", "create table t (pk int primary key)", "t", nil},
+				{"query", uint32(1), "root", "main", "dml", "insert into t values (1), (1)", "t", "duplicate primary key given: [1]"},
+			},
+		},
+		{
+			Query:    "select count(*) from dolt_audit_log where duration_ms is not null;",
+			Expected: []sql.Row{{2}},
+		},
+		{
+			Query:          "insert into dolt_audit_log (event) values ('query');",
+			ExpectedErrStr: "table doesn't support INSERT INTO",
+		},
+	},
+}
+
+// QueryLogSystemTableQueries expects the query log to hold the statements recorded by TestQueryLogSystemTables.
+var QueryLogSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_query_log and dolt_query_summary tables",
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			// only statements run against the current database are listed
+			Query: "select connection_id, user, query, latency_ms, rows_examined, rows_sent, error from dolt_query_log;",
+			Expected: []sql.Row{
+				{uint32(1), "root", "select * from t where pk = 1", 2.0, int64(1), int64(1), nil},
+				{uint32(1), "root", "SELECT * FROM t WHERE pk = 2", 4.0, int64(1), int64(1), nil},
+				{uint32(1), "root", "insert into t values (1), (1)", 10.0, int64(0), int64(0), "duplicate primary key given: [1]"},
+			},
+		},
+		{
+			Query:    "select count(distinct digest) from dolt_query_log;",
+			Expected: []sql.Row{{2}},
+		},
+		{
+			// summaries are ordered by their total latency
+			Query: "select digest_text, query_sample, count, errors, total_latency_ms, avg_latency_ms, min_latency_ms, max_latency_ms, rows_examined, rows_sent from dolt_query_summary;",
+			Expected: []sql.Row{
+				{"insert into t values (...)", "insert into t values (1), (1)", int64(1), int64(1), 10.0, 10.0, 10.0, 10.0, int64(0), int64(0)},
+				{"select * from t where pk = ?", "select * from t where pk = 1", int64(2), int64(0), 6.0, 3.0, 2.0, 4.0, int64(2), int64(2)},
+			},
+		},
+		{
+			Query:    "select count(*) from dolt_query_summary where p50_latency_ms between min_latency_ms and max_latency_ms and p99_latency_ms = max_latency_ms;",
+			Expected: []sql.Row{{2}},
+		},
+		{
+			Query:    "select count(*) from dolt_query_summary s join dolt_query_log l on s.digest = l.digest;",
+			Expected: []sql.Row{{3}},
+		},
+		{
+			Query:          "delete from dolt_query_summary;",
+			ExpectedErrStr: "table doesn't support DELETE FROM",
+		},
+	},
+}
+
+// EventsHistorySystemTableQueries expects the events history to hold the executions recorded by
+// TestEventsHistorySystemTable.
+var EventsHistorySystemTableQueries = queries.ScriptTest{
+	Name: "dolt_events_history table",
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			// only executions of the events of the current database are listed
+			Query: "select event, branch, latency_ms, catch_up, error from dolt_events_history;",
+			Expected: []sql.Row{
+				{"e1", "main", 5.0, false, nil},
+				{"e2", "feature", 1.0, true, nil},
+				{"e1", "main", 2.0, false, "duplicate primary key given: [1]"},
+			},
+		},
+		{
+			Query:    "select count(*) from dolt_events_history where end_time >= start_time;",
+			Expected: []sql.Row{{3}},
+		},
+		{
+			Query:          "insert into dolt_events_history (event) values ('e3');",
+			ExpectedErrStr: "table doesn't support INSERT INTO",
+		},
+	},
+}
+
+var CacheStatsSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_cache_stats table",
+	SetUpScript: []string{
+		"create table t (pk int primary key);",
+		"insert into t values (1), (2), (3);",
+	},
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			Query:    "select pool from dolt_cache_stats order by pool;",
+			Expected: []sql.Row{{"internal"}, {"leaf"}},
+		},
+		{
+			Query:    "select count(*) from t;",
+			Expected: []sql.Row{{3}},
+		},
+		{
+			Query:    "select pool, entries > 0, bytes >= protected_bytes from dolt_cache_stats where pool = 'leaf';",
+			Expected: []sql.Row{{"leaf", true, true}},
+		},
+		{
+			Query:          "insert into dolt_cache_stats (pool) values ('leaf');",
+			ExpectedErrStr: "table doesn't support INSERT INTO",
+		},
+	},
+}
+
+var StorageUsageSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_storage_usage table",
+	SetUpScript: []string{
+		"create table t (pk int primary key);",
+		"insert into t values (1), (2), (3);",
+	},
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			Query:    "select `database`, bytes > 0, quota_bytes from dolt_storage_usage;",
+			Expected: []sql.Row{{"mydb", true, nil}},
+		},
+		{
+			Query:    "set @@global.dolt_database_storage_quotas = 'otherdb=1GB, mydb=10B';",
+			Expected: []sql.Row{{types.NewOkResult(0)}},
+		},
+		{
+			Query:    "select quota_bytes from dolt_storage_usage;",
+			Expected: []sql.Row{{uint64(10)}},
+		},
+		{
+			Query:          "insert into t values (4);",
+			ExpectedErrStr: "storage quota exceeded",
+		},
+		{
+			Query:    "select count(*) from t;",
+			Expected: []sql.Row{{3}},
+		},
+		{
+			Query:    "set @@global.dolt_database_storage_quotas = '';",
+			Expected: []sql.Row{{types.NewOkResult(0)}},
+		},
+		{
+			Query:    "insert into t values (4);",
+			Expected: []sql.Row{{types.NewOkResult(1)}},
+		},
+	},
+}
+
+var TableSizesSystemTableQueries = queries.ScriptTest{
+	Name: "dolt_table_sizes table",
+	SetUpScript: []string{
+		"create table t (pk int primary key, v int, key v_idx (v));",
+		"create table u (pk int primary key);",
+		"insert into t values (1, 1), (2, 2), (3, 3);",
+		"insert into u values (1);",
+		"call dolt_commit('-Am', 'created tables');",
+		"alter table t drop index v_idx;",
+		"delete from t where pk = 3;",
+		"drop table u;",
+	},
+	Assertions: []queries.ScriptTestAssertion{
+		{
+			Query: "select table_name, index_name, chunks > 0, bytes > 0, history_bytes > 0 from dolt_table_sizes;",
+			Expected: []sql.Row{
+				{"t", "PRIMARY", true, true, true},
+				{"t", "v_idx", false, false, true},
+				{"u", "PRIMARY", false, false, true},
+			},
+		},
+		{
+			Query:          "insert into dolt_table_sizes (table_name, index_name) values ('t', 'PRIMARY');",
+			ExpectedErrStr: "table doesn't support INSERT INTO",
+		},
+	},
+}
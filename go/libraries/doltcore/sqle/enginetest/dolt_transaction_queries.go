@@ -1244,7 +1244,7 @@ var DoltConflictHandlingTests = []queries.TransactionTest{
 			},
 			{
 				Query:          "/* client b */ commit",
-				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error(),
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error() + " (errno 50002) (sqlstate XD002)",
 			},
 			{ // our transaction got rolled back, so we lose the above insert
 				Query:    "/* client b */ select * from test order by 1",
@@ -1318,7 +1318,7 @@ var DoltConflictHandlingTests = []queries.TransactionTest{
 			{
 				Query: "/* client b */ COMMIT;",
 				// Retrying did not help. But at-least the error makes sense.
-				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error(),
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsCommit.Error() + " (errno 50002) (sqlstate XD002)",
 			},
 		},
 	},
@@ -1432,6 +1432,133 @@ var DoltConflictHandlingTests = []queries.TransactionTest{
 			},
 		},
 	},
+	{
+		Name: "transactional DDL: schema changes are committed with the transaction",
+		SetUpScript: []string{
+			"create table t (x int primary key, y int)",
+			"insert into t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set dolt_transactional_ddl = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ create table t2 (a int primary key)",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client a */ insert into t2 values (1)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ alter table t add column z int",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client a */ select * from t2",
+				Expected: []sql.Row{{1}},
+			},
+			{
+				Query:    "/* client b */ show tables",
+				Expected: []sql.Row{{"t"}},
+			},
+			{
+				Query:    "/* client b */ select * from t",
+				Expected: []sql.Row{{1, 1}},
+			},
+			{
+				Query:    "/* client a */ commit",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ show tables",
+				Expected: []sql.Row{{"t"}, {"t2"}},
+			},
+			{
+				Query:    "/* client b */ select * from t",
+				Expected: []sql.Row{{1, 1, nil}},
+			},
+		},
+	},
+	{
+		Name: "transactional DDL: schema changes are discarded by rollback",
+		SetUpScript: []string{
+			"create table t (x int primary key, y int)",
+			"insert into t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ set autocommit = off, dolt_transactional_ddl = 1",
+				Expected: []sql.Row{{}},
+			},
+			{
+				Query:    "/* client a */ insert into t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ create table t2 (a int primary key)",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client a */ drop table t",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client a */ show tables",
+				Expected: []sql.Row{{"t2"}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ show tables",
+				Expected: []sql.Row{{"t"}},
+			},
+			{
+				Query:    "/* client a */ select * from t",
+				Expected: []sql.Row{{1, 1}},
+			},
+		},
+	},
+	{
+		Name: "transactional DDL: DDL statements commit the transaction by default",
+		SetUpScript: []string{
+			"create table t (x int primary key, y int)",
+			"insert into t values (1, 1)",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "/* client a */ start transaction",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client a */ insert into t values (2, 2)",
+				Expected: []sql.Row{{types.NewOkResult(1)}},
+			},
+			{
+				Query:    "/* client a */ create table t2 (a int primary key)",
+				Expected: []sql.Row{{types.NewOkResult(0)}},
+			},
+			{
+				Query:    "/* client a */ rollback",
+				Expected: []sql.Row{},
+			},
+			{
+				Query:    "/* client b */ show tables",
+				Expected: []sql.Row{{"t"}, {"t2"}},
+			},
+			{
+				Query:    "/* client b */ select * from t",
+				Expected: []sql.Row{{1, 1}, {2, 2}},
+			},
+		},
+	},
 }
 
 var DoltStoredProcedureTransactionTests = []queries.TransactionTest{
@@ -1509,7 +1636,7 @@ var DoltStoredProcedureTransactionTests = []queries.TransactionTest{
 			},
 			{
 				Query:          "/* client a */ CALL DOLT_MERGE('feature-branch')",
-				ExpectedErrStr: dsess.ErrUnresolvedConflictsAutoCommit.Error(),
+				ExpectedErrStr: dsess.ErrUnresolvedConflictsAutoCommit.Error() + " (errno 50002) (sqlstate XD002)",
 			},
 			{ // client rolled back on merge with conflicts
 				Query:    "/* client a */ SELECT count(*) from dolt_conflicts_test",
@@ -2092,7 +2219,7 @@ var DoltConstraintViolationTransactionTests = []queries.TransactionTest{
 					"\tTable: child,\n" +
 					"\tReferencedTable: ,\n" +
 					"\tIndex: parent_fk,\n" +
-					"\tReferencedIndex: ",
+					"\tReferencedIndex:  (errno 50002) (sqlstate XD002)",
 			},
 			{
 				Query:          "/* client b */ INSERT INTO child VALUES (1, 1);",
@@ -2125,7 +2252,7 @@ var DoltConstraintViolationTransactionTests = []queries.TransactionTest{
 					"Constraint violations: \n" +
 					"Type: Unique Key Constraint Violation,\n" +
 					"\tName: col1,\n" +
-					"\tColumns: [col1]",
+					"\tColumns: [col1] (errno 50002) (sqlstate XD002)",
 			},
 			{
 				Query:    "/* client a */ SELECT * from DOLT_CONSTRAINT_VIOLATIONS;",
@@ -2215,7 +2342,7 @@ var DoltConstraintViolationTransactionTests = []queries.TransactionTest{
 					"\tTable: child,\n" +
 					"\tReferencedTable: v1,\n" +
 					"\tIndex: fk_name,\n" +
-					"\tReferencedIndex: v1",
+					"\tReferencedIndex: v1 (errno 50002) (sqlstate XD002)",
 			},
 		},
 	},
@@ -2607,7 +2734,7 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 			},
 			{
 				Query:          "call dolt_commit('-am', 'changes on b1')",
-				ExpectedErrStr: "nothing to commit", // this error is different from what you get with @@dolt_transaction_commit
+				ExpectedErrStr: "nothing to commit (errno 50001) (sqlstate XD001)", // this error is different from what you get with @@dolt_transaction_commit
 			},
 			{
 				Query:    "use mydb/b1",
@@ -2826,7 +2953,7 @@ var MultiDbTransactionTests = []queries.ScriptTest{
 			},
 			{
 				Query:          "call dolt_commit('-am', 'changes on b1')",
-				ExpectedErrStr: "nothing to commit", // this error is different from what you get with @@dolt_transaction_commit
+				ExpectedErrStr: "nothing to commit (errno 50001) (sqlstate XD001)", // this error is different from what you get with @@dolt_transaction_commit
 			},
 			{
 				Query:    "use db1/b1",
@@ -330,6 +330,51 @@ var DoltHistogramTests = []queries.ScriptTest{
 			},
 		},
 	},
+	{
+		Name: "spatial histograms choose between spatial index lookups and table scans",
+		SetUpScript: []string{
+			"create table pts (pk int primary key, p point not null srid 0, spatial index (p))",
+			"insert into pts select x, point(x % 10, floor(x / 10)) from (with recursive inputs(x) as (select 0 union select x+1 from inputs where x < 99) select * from inputs) dt",
+			"analyze table pts",
+		},
+		Assertions: []queries.ScriptTestAssertion{
+			{
+				Query:    "select count(*) from pts where st_intersects(p, st_geomfromtext('polygon((0 0, 1 0, 1 1, 0 1, 0 0))'))",
+				Expected: []sql.Row{{4}},
+			},
+			{
+				// a small bounding box uses the spatial index
+				Query: "explain plan select pk from pts where st_intersects(p, st_geomfromtext('polygon((0 0, 1 0, 1 1, 0 1, 0 0))'))",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [pts.pk]"},
+					{" └─ Filter"},
+					{"     ├─ st_intersects(pts.p,{0 [{0 [{0 0 0} {0 1 0} {0 1 1} {0 0 1} {0 0 0}]}]})"},
+					{"     └─ IndexedTableAccess(pts)"},
+					{"         ├─ index: [pts.p]"},
+					{"         ├─ filters: [{[{0 0 0}, {0 1 1}]}]"},
+					{"         └─ columns: [pk p]"},
+				},
+			},
+			{
+				Query:    "select count(*) from pts where st_intersects(p, st_geomfromtext('polygon((-1 -1, 10 -1, 10 10, -1 10, -1 -1))'))",
+				Expected: []sql.Row{{100}},
+			},
+			{
+				// the histogram estimates a bounding box covering the whole table matches every index entry
+				Query: "explain plan select pk from pts where st_intersects(p, st_geomfromtext('polygon((-1 -1, 10 -1, 10 10, -1 10, -1 -1))'))",
+				Expected: []sql.Row{
+					{"Project"},
+					{" ├─ columns: [pts.pk]"},
+					{" └─ Filter"},
+					{"     ├─ st_intersects(pts.p,{0 [{0 [{0 -1 -1} {0 10 -1} {0 10 10} {0 -1 10} {0 -1 -1}]}]})"},
+					{"     └─ Table"},
+					{"         ├─ name: pts"},
+					{"         └─ columns: [pk p]"},
+				},
+			},
+		},
+	},
 }
 
 var DoltStatsStorageTests = []queries.ScriptTest{
@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestHistory(t *testing.T) {
+	h := NewHistory(3)
+	for _, name := range []string{"e1", "e2", "e3", "e4"} {
+		h.Record(Execution{Database: "db", Event: name})
+	}
+	execs := h.Executions()
+	require.Len(t, execs, 3)
+	assert.Equal(t, "e2", execs[0].Event)
+	assert.Equal(t, "e4", execs[2].Event)
+
+	// shrinking keeps the most recent executions
+	h.SetSize(2)
+	h.Record(Execution{Database: "db", Event: "e5"})
+	execs = h.Executions()
+	assert.Equal(t, []string{"e4", "e5"}, []string{execs[0].Event, execs[1].Event})
+}
+
+func TestState(t *testing.T) {
+	fs := filesys.EmptyInMemFS("/")
+	path := "/.doltcfg/" + StateFileName
+	s, err := LoadState(fs, path)
+	require.NoError(t, err)
+	_, ok := s.LastExecuted("db", "e")
+	assert.False(t, ok)
+
+	ran := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, s.SetLastExecuted("db/feature", "E", ran))
+
+	// the state is stored in its file, and event names are case-insensitive
+	s, err = LoadState(fs, path)
+	require.NoError(t, err)
+	last, ok := s.LastExecuted("db/feature", "e")
+	require.True(t, ok)
+	assert.True(t, ran.Equal(last))
+	_, ok = s.LastExecuted("db", "e")
+	assert.False(t, ok)
+
+	// without a path the state is only kept in memory
+	s, err = LoadState(fs, "")
+	require.NoError(t, err)
+	require.NoError(t, s.SetLastExecuted("db", "e", ran))
+	_, ok = s.LastExecuted("db", "e")
+	assert.True(t, ok)
+}
+
+func TestMissedExecution(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	enabled := sql.EventStatus_Enable.String()
+	hourly := sql.EventDefinition{
+		Name:         "hourly",
+		Status:       enabled,
+		ExecuteEvery: "1 HOUR",
+		Starts:       now.Add(-24 * time.Hour),
+	}
+
+	// an event which last ran more than an interval ago missed an execution
+	assert.True(t, missedExecution(hourly, now.Add(-90*time.Minute), true, now))
+	assert.False(t, missedExecution(hourly, now.Add(-30*time.Minute), true, now))
+	// an event which never ran isn't known to have missed one
+	assert.False(t, missedExecution(hourly, time.Time{}, false, now))
+
+	disabled := hourly
+	disabled.Status = sql.EventStatus_Disable.String()
+	assert.False(t, missedExecution(disabled, now.Add(-90*time.Minute), true, now))
+
+	// an event whose next execution was after it ended didn't miss it
+	ended := hourly
+	ended.HasEnds = true
+	ended.Ends = now.Add(-2 * time.Hour)
+	assert.False(t, missedExecution(ended, now.Add(-150*time.Minute), true, now))
+	ended.Ends = now.Add(-time.Minute)
+	assert.True(t, missedExecution(ended, now.Add(-90*time.Minute), true, now))
+
+	// a one-time event which is still enabled after its time never ran
+	once := sql.EventDefinition{
+		Name:         "once",
+		Status:       enabled,
+		HasExecuteAt: true,
+		ExecuteAt:    now.Add(-time.Hour),
+	}
+	assert.True(t, missedExecution(once, time.Time{}, false, now))
+	once.ExecuteAt = now.Add(time.Hour)
+	assert.False(t, missedExecution(once, time.Time{}, false, now))
+}
+
+func TestDefinerAccount(t *testing.T) {
+	user, address, err := definerAccount("`root`@`localhost`")
+	require.NoError(t, err)
+	assert.Equal(t, "root", user)
+	assert.Equal(t, "localhost", address)
+
+	user, address, err = definerAccount("'app'@'%'")
+	require.NoError(t, err)
+	assert.Equal(t, "app", user)
+	assert.Equal(t, "%", address)
+
+	_, _, err = definerAccount("root")
+	assert.Error(t, err)
+}
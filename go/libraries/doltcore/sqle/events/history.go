@@ -0,0 +1,131 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events runs the events created with CREATE EVENT in sql-server. Events are stored in the dolt_schemas table
+// of each branch, and run by the event scheduler of go-mysql-server, which this package extends: the events of the
+// branches named by the dolt_event_branches system variable run against those branches, in addition to the events of
+// the default branch, the events missed while sql-server was down are run once when it starts if the
+// dolt_event_catch_up system variable is "once", and every execution is recorded in memory and exposed through the
+// dolt_events_history system table.
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// Execution is a single execution of an event recorded in the history.
+type Execution struct {
+	Database string
+	Branch   string
+	Event    string
+	Start    time.Time
+	End      time.Time
+	// CatchUp is true for the executions of events missed while sql-server was down.
+	CatchUp bool
+	Error   string
+}
+
+// History records the most recent executions of events. It is safe for concurrent use.
+type History struct {
+	mu    sync.Mutex
+	buf   []Execution
+	next  int
+	count int
+}
+
+// NewHistory returns a History keeping the |size| most recent executions.
+func NewHistory(size int) *History {
+	h := &History{}
+	h.SetSize(size)
+	return h
+}
+
+// SetSize changes the number of executions kept by |h|. If it holds more executions than |size|, the oldest are
+// discarded.
+func (h *History) SetSize(size int) {
+	size = max(size, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if size == len(h.buf) {
+		return
+	}
+	execs := h.executions()
+	if len(execs) > size {
+		execs = execs[len(execs)-size:]
+	}
+	h.buf = make([]Execution, size)
+	copy(h.buf, execs)
+	h.count = len(execs)
+	h.next = h.count % size
+}
+
+// Record records |e|.
+func (h *History) Record(e Execution) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buf[h.next] = e
+	h.next = (h.next + 1) % len(h.buf)
+	if h.count < len(h.buf) {
+		h.count++
+	}
+}
+
+// Executions returns the most recent executions, oldest first.
+func (h *History) Executions() []Execution {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.executions()
+}
+
+func (h *History) executions() []Execution {
+	ret := make([]Execution, 0, h.count)
+	if h.count == 0 {
+		return ret
+	}
+	start := (h.next - h.count + len(h.buf)) % len(h.buf)
+	for i := 0; i < h.count; i++ {
+		ret = append(ret, h.buf[(start+i)%len(h.buf)])
+	}
+	return ret
+}
+
+var current atomic.Pointer[History]
+
+// SetCurrent makes |h| the history of this process, which the executions of events are recorded to, and which is
+// exposed through the dolt_events_history system table. |h| may be nil to disable it.
+func SetCurrent(h *History) {
+	current.Store(h)
+}
+
+// Current returns the history of this process, or nil if there isn't one.
+func Current() *History {
+	return current.Load()
+}
+
+// GlobalHistorySize returns the number of executions kept in the history set by the dolt_events_history_size system
+// variable.
+func GlobalHistorySize() int {
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.EventsHistorySize)
+	if !ok {
+		return 0
+	}
+	i, _ := val.(int64)
+	return int(i)
+}
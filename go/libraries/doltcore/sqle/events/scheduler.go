@@ -0,0 +1,395 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gms "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/eventscheduler"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/rowexec"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// catchUpOnce is the value of the dolt_event_catch_up system variable which runs missed events once.
+const catchUpOnce = "once"
+
+// InitEventScheduler initializes the event scheduler of |engine|, which runs the events of the databases of |pro| and
+// of the branches named by the dolt_event_branches system variable. |getCtx| returns a new context for each execution.
+// If |status| is ON and the dolt_event_catch_up system variable is "once", the events which missed an execution since
+// they last ran, according to |state|, are run once before the scheduler starts.
+func InitEventScheduler(
+	engine *gms.Engine,
+	pro sql.DatabaseProvider,
+	getCtx func() (*sql.Context, error),
+	status eventscheduler.SchedulerStatus,
+	period int,
+	state *State,
+) error {
+	bp := branchProvider{DatabaseProvider: pro}
+	r := &runner{engine: engine, state: state}
+	if status == eventscheduler.SchedulerOn && globalCatchUp() == catchUpOnce {
+		if err := r.catchUp(getCtx, bp); err != nil {
+			return err
+		}
+	}
+
+	// the scheduler finds events in the databases of the catalog of its analyzer, and only uses the catalog
+	cat := analyzer.NewCatalog(bp)
+	cat.MySQLDb = engine.Analyzer.Catalog.MySQLDb
+	cat.InfoSchema = engine.Analyzer.Catalog.InfoSchema
+	cat.AuthHandler = engine.Analyzer.Catalog.AuthHandler
+	es, err := eventscheduler.InitEventScheduler(&analyzer.Analyzer{Catalog: cat}, engine.BackgroundThreads, getCtx, status, r.run, period)
+	if err != nil {
+		return err
+	}
+	engine.EventScheduler = es
+	return nil
+}
+
+// Branches returns the branches named by the dolt_event_branches system variable, whose events run in addition to
+// those of the default branch.
+func Branches() []string {
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.EventBranches)
+	if !ok {
+		return nil
+	}
+	s, _ := val.(string)
+	var branches []string
+	for _, b := range strings.Split(s, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			branches = append(branches, b)
+		}
+	}
+	return branches
+}
+
+// BranchEnabled returns whether the events of the revision database with the revision |revision| can be enabled: those
+// of the default branch and of the branches named by the dolt_event_branches system variable.
+// TODO: need better way to determine the default branch; currently it checks only 'main'
+func BranchEnabled(revision string) bool {
+	if revision == "" || revision == env.DefaultInitBranch {
+		return true
+	}
+	for _, b := range Branches() {
+		if b == revision {
+			return true
+		}
+	}
+	return false
+}
+
+func globalCatchUp() string {
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.EventCatchUp)
+	if !ok {
+		return ""
+	}
+	s, _ := val.(string)
+	return strings.ToLower(s)
+}
+
+// branchProvider is a database provider whose databases include a revision database for each branch named by the
+// dolt_event_branches system variable, so that the event scheduler runs their events.
+type branchProvider struct {
+	sql.DatabaseProvider
+}
+
+func (p branchProvider) AllDatabases(ctx *sql.Context) []sql.Database {
+	all := p.DatabaseProvider.AllDatabases(ctx)
+	branches := Branches()
+	if len(branches) == 0 {
+		return all
+	}
+	names := make(map[string]struct{}, len(all))
+	for _, db := range all {
+		names[strings.ToLower(db.Name())] = struct{}{}
+	}
+	for _, db := range all {
+		if _, ok := db.(sql.EventDatabase); !ok {
+			continue
+		}
+		if _, rev := dsess.SplitRevisionDbName(db.Name()); rev != "" {
+			continue
+		}
+		for _, b := range branches {
+			name := db.Name() + dsess.DbRevisionDelimiter + b
+			if _, ok := names[strings.ToLower(name)]; ok || b == env.DefaultInitBranch {
+				continue
+			}
+			rdb, err := p.Database(ctx, name)
+			if err != nil {
+				// the branch doesn't exist in this database
+				continue
+			}
+			names[strings.ToLower(name)] = struct{}{}
+			all = append(all, rdb)
+		}
+	}
+	return all
+}
+
+// runner executes events and records their executions.
+type runner struct {
+	engine *gms.Engine
+	state  *State
+}
+
+// run executes the event created by |createEventStatement| against the database |dbName| as the account identified by
+// |username| and |address|, for the event scheduler.
+func (r *runner) run(ctx *sql.Context, dbName, createEventStatement, username, address string) error {
+	return r.execute(ctx, dbName, createEventStatement, username, address, false)
+}
+
+func (r *runner) execute(ctx *sql.Context, dbName, createEventStatement, username, address string, catchUp bool) error {
+	start := time.Now().UTC()
+	name, err := r.executeEvent(ctx, dbName, createEventStatement, username, address)
+	r.record(ctx, dbName, name, start, catchUp, err)
+	return err
+}
+
+// executeEvent executes the body of the event created by |createEventStatement| and returns the name of the event.
+// It matches the execution of events by the engine.
+func (r *runner) executeEvent(ctx *sql.Context, dbName, createEventStatement, username, address string) (string, error) {
+	ctx.SetCurrentDatabase(dbName)
+	ctx.Session.SetClient(sql.Client{User: username, Address: address})
+
+	planTree, err := r.engine.AnalyzeQuery(ctx, createEventStatement)
+	if err != nil {
+		return "", err
+	}
+	var createEvent *plan.CreateEvent
+	transform.Inspect(planTree, func(node sql.Node) bool {
+		if ce, ok := node.(*plan.CreateEvent); ok {
+			createEvent = ce
+			return false
+		}
+		return true
+	})
+	if createEvent == nil {
+		return "", fmt.Errorf("unable to find create event node in plan tree: %v", planTree)
+	}
+
+	iter, err := r.engine.Analyzer.ExecBuilder.Build(ctx, createEvent.DefinitionNode, nil)
+	if err == nil {
+		iter, _, err = rowexec.FinalizeIters(ctx, createEvent.DefinitionNode, nil, iter)
+	}
+	if err != nil {
+		if clearErr := clearAutocommitTransaction(ctx); clearErr != nil {
+			return createEvent.EventName, clearErr
+		}
+		return createEvent.EventName, err
+	}
+	_, err = sql.RowIterToRows(ctx, iter)
+	return createEvent.EventName, err
+}
+
+// record records an execution of the event |name| of the database |dbName| which started at |start| in the history
+// and the state.
+func (r *runner) record(ctx *sql.Context, dbName, name string, start time.Time, catchUp bool, err error) {
+	if name != "" {
+		if stateErr := r.state.SetLastExecuted(dbName, name, start); stateErr != nil {
+			logrus.Warnf("unable to store the last execution of event %s.%s: %s", dbName, name, stateErr.Error())
+		}
+	}
+
+	h := Current()
+	if h == nil {
+		return
+	}
+	baseName, branch := dsess.SplitRevisionDbName(dbName)
+	if branch == "" {
+		branch, _ = dsess.DSessFromSess(ctx.Session).GetBranch(ctx)
+	}
+	e := Execution{
+		Database: baseName,
+		Branch:   branch,
+		Event:    name,
+		Start:    start,
+		End:      time.Now().UTC(),
+		CatchUp:  catchUp,
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	h.SetSize(GlobalHistorySize())
+	h.Record(e)
+}
+
+type missedEvent struct {
+	dbName string
+	event  sql.EventDefinition
+}
+
+// catchUp runs each event of the databases of |pro| which missed an execution since it last ran once.
+func (r *runner) catchUp(getCtx func() (*sql.Context, error), pro sql.DatabaseProvider) error {
+	missed, err := r.missedEvents(getCtx, pro)
+	if err != nil {
+		return err
+	}
+	for _, m := range missed {
+		r.runMissed(getCtx, m)
+	}
+	return nil
+}
+
+// missedEvents returns the events of the databases of |pro| which missed an execution since they last ran.
+func (r *runner) missedEvents(getCtx func() (*sql.Context, error), pro sql.DatabaseProvider) ([]missedEvent, error) {
+	ctx, err := getCtx()
+	if err != nil {
+		return nil, err
+	}
+	defer sql.SessionEnd(ctx.Session)
+	sql.SessionCommandBegin(ctx.Session)
+	defer sql.SessionCommandEnd(ctx.Session)
+	if err = beginTx(ctx); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var missed []missedEvent
+	for _, db := range pro.AllDatabases(ctx) {
+		edb, ok := db.(sql.EventDatabase)
+		if !ok {
+			continue
+		}
+		ctx.SetCurrentDatabase(edb.Name())
+		events, _, err := edb.GetEvents(ctx)
+		if err != nil {
+			rollbackTx(ctx)
+			return nil, err
+		}
+		for _, event := range events {
+			last, ok := r.state.LastExecuted(edb.Name(), event.Name)
+			if missedExecution(event, last, ok, now) {
+				missed = append(missed, missedEvent{dbName: edb.Name(), event: event})
+			}
+		}
+	}
+	return missed, commitTx(ctx)
+}
+
+// missedExecution returns whether |event|, which last ran at |last| if |ran|, missed an execution before |now|. A
+// recurring event which never ran isn't known to have missed one.
+func missedExecution(event sql.EventDefinition, last time.Time, ran bool, now time.Time) bool {
+	if event.Status != sql.EventStatus_Enable.String() {
+		return false
+	}
+	if event.HasExecuteAt {
+		// a one-time event is dropped or disabled once it runs, so one which is still enabled after its time never
+		// ran. Once its time has passed, the scheduler drops or disables it without running it.
+		_, ended, err := event.GetNextExecutionTime(now)
+		return err == nil && ended
+	}
+	if !ran {
+		return false
+	}
+	event.LastExecuted = last
+	next, ended, err := event.GetNextExecutionTime(last)
+	return err == nil && !ended && next.Before(now)
+}
+
+// runMissed runs the missed event |m| in its own transaction. Errors are recorded in the history rather than
+// returned, so that one failing event doesn't keep the others from catching up.
+func (r *runner) runMissed(getCtx func() (*sql.Context, error), m missedEvent) {
+	username, address, err := definerAccount(m.event.Definer)
+	if err != nil {
+		logrus.Errorf("unable to catch up event %s.%s: %s", m.dbName, m.event.Name, err.Error())
+		return
+	}
+	ctx, err := getCtx()
+	if err != nil {
+		logrus.Errorf("unable to get context to catch up event %s.%s: %s", m.dbName, m.event.Name, err.Error())
+		return
+	}
+	defer sql.SessionEnd(ctx.Session)
+	sql.SessionCommandBegin(ctx.Session)
+	defer sql.SessionCommandEnd(ctx.Session)
+	if err = beginTx(ctx); err != nil {
+		logrus.Errorf("unable to begin transaction to catch up event %s.%s: %s", m.dbName, m.event.Name, err.Error())
+		return
+	}
+
+	logrus.Infof("catching up event %s.%s", m.dbName, m.event.Name)
+	err = r.execute(ctx, m.dbName, m.event.CreateEventStatement(), username, address, true)
+	if err != nil {
+		logrus.WithField("query", m.event.EventBody).Errorf("unable to catch up event %s.%s: %s", m.dbName, m.event.Name, err.Error())
+		rollbackTx(ctx)
+		return
+	}
+	if err = commitTx(ctx); err != nil {
+		logrus.WithField("query", m.event.EventBody).Errorf("unable to commit transaction: %s", err.Error())
+	}
+}
+
+// definerAccount returns the user name and address of the definer of an event.
+func definerAccount(definer string) (string, string, error) {
+	ua := strings.Split(definer, "@")
+	if len(ua) != 2 {
+		return "", "", fmt.Errorf("invalid definer for the event")
+	}
+	return strings.Trim(ua[0], "`'"), strings.Trim(ua[1], "`'"), nil
+}
+
+func beginTx(ctx *sql.Context) error {
+	if ts, ok := ctx.Session.(sql.TransactionSession); ok {
+		tr, err := ts.StartTransaction(ctx, sql.ReadWrite)
+		if err != nil {
+			return err
+		}
+		ts.SetTransaction(tr)
+	}
+	return nil
+}
+
+func commitTx(ctx *sql.Context) error {
+	if ts, ok := ctx.Session.(sql.TransactionSession); ok {
+		defer ts.SetTransaction(nil)
+		return ts.CommitTransaction(ctx, ts.GetTransaction())
+	}
+	return nil
+}
+
+func rollbackTx(ctx *sql.Context) error {
+	if ts, ok := ctx.Session.(sql.TransactionSession); ok {
+		defer ts.SetTransaction(nil)
+		return ts.Rollback(ctx, ts.GetTransaction())
+	}
+	return nil
+}
+
+// clearAutocommitTransaction clears the transaction of |ctx| if it was started implicitly for autocommit, after an
+// event fails to build, so that it isn't committed.
+func clearAutocommitTransaction(ctx *sql.Context) error {
+	if ctx.GetIgnoreAutoCommit() {
+		return nil
+	}
+	autocommit, err := plan.IsSessionAutocommit(ctx)
+	if err != nil {
+		return err
+	}
+	if autocommit {
+		ctx.SetTransaction(nil)
+	}
+	return nil
+}
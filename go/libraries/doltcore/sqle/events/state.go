@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+// StateFileName is the name of the file in the config directory of sql-server which holds the State of its events.
+const StateFileName = "event_state.json"
+
+// State holds the time each event last ran. It isn't versioned with the events, since running an event on one branch
+// shouldn't change the events of any branch, and it's kept in a file so that the events missed while sql-server was
+// down can be found when it starts. It is safe for concurrent use.
+type State struct {
+	mu           sync.Mutex
+	fs           filesys.Filesys
+	path         string
+	lastExecuted map[string]time.Time
+}
+
+// LoadState returns the State stored in the file at |path| of |fs|, or an empty State if it doesn't exist. An empty
+// |path| returns a State which is only kept in memory.
+func LoadState(fs filesys.Filesys, path string) (*State, error) {
+	s := &State{fs: fs, path: path, lastExecuted: make(map[string]time.Time)}
+	if path == "" {
+		return s, nil
+	}
+	if exists, isDir := fs.Exists(path); !exists || isDir {
+		return s, nil
+	}
+	data, err := fs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &s.lastExecuted); err != nil {
+		return nil, fmt.Errorf("failed to deserialize event state at '%s': %w", path, err)
+	}
+	return s, nil
+}
+
+// LastExecuted returns the time the event |event| of the database |dbName| last ran, and whether it's known.
+// Revision databases such as mydb/branch have their own events.
+func (s *State) LastExecuted(dbName, event string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.lastExecuted[stateKey(dbName, event)]
+	return t, ok
+}
+
+// SetLastExecuted records that the event |event| of the database |dbName| ran at |t|, and stores the State in its file.
+func (s *State) SetLastExecuted(dbName, event string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastExecuted[stateKey(dbName, event)] = t.UTC()
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.lastExecuted)
+	if err != nil {
+		return err
+	}
+	if err = s.fs.MkDirs(filepath.Dir(s.path)); err != nil {
+		return err
+	}
+	return s.fs.WriteFile(s.path, data, 0660)
+}
+
+func stateKey(dbName, event string) string {
+	return strings.ToLower(dbName + "." + event)
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+)
+
+// DescendingIndexSortRuleId is the id of the analyzer rule added by AddDescendingIndexSortRule.
+const DescendingIndexSortRuleId analyzer.RuleId = -2
+
+// AddDescendingIndexSortRule adds the rule which replaces sorts with scans of indexes that have descending columns to
+// |a|. The engine's own sort replacement assumes every index is ascending, so it can't use such indexes. The rule runs
+// right after the engine's, on the sorts it left in place.
+func AddDescendingIndexSortRule(a *analyzer.Analyzer) {
+	for _, b := range a.Batches {
+		if b.Desc != "once-after" {
+			continue
+		}
+		for i, r := range b.Rules {
+			if r.Id.String() == "replaceIdxSort" {
+				rules := append([]analyzer.Rule{}, b.Rules[:i+1]...)
+				rules = append(rules, analyzer.Rule{Id: DescendingIndexSortRuleId, Apply: replaceSortWithDescendingIndex})
+				b.Rules = append(rules, b.Rules[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// replaceSortWithDescendingIndex replaces a sort of a table, or of a filter of a table, with a full scan of an index
+// whose column directions match the sort fields, either exactly or all reversed.
+func replaceSortWithDescendingIndex(ctx *sql.Context, _ *analyzer.Analyzer, n sql.Node, _ *plan.Scope, _ analyzer.RuleSelector, _ *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	return transform.Node(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		s, ok := n.(*plan.Sort)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		switch child := s.Child.(type) {
+		case *plan.ResolvedTable:
+			ita, err := descendingIndexScan(ctx, child, s.SortFields)
+			if err != nil || ita == nil {
+				return n, transform.SameTree, err
+			}
+			return ita, transform.NewTree, nil
+		case *plan.Filter:
+			rt, ok := child.Child.(*plan.ResolvedTable)
+			if !ok {
+				return n, transform.SameTree, nil
+			}
+			ita, err := descendingIndexScan(ctx, rt, s.SortFields)
+			if err != nil || ita == nil {
+				return n, transform.SameTree, err
+			}
+			filter, err := child.WithChildren(ita)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			return filter, transform.NewTree, nil
+		default:
+			return n, transform.SameTree, nil
+		}
+	})
+}
+
+// descendingIndexScan returns a scan of |rt| that produces rows in the order of |sfs|, using an index with descending
+// columns. Returns nil if no such index matches.
+func descendingIndexScan(ctx *sql.Context, rt *plan.ResolvedTable, sfs sql.SortFields) (sql.Node, error) {
+	idxTbl, ok := rt.UnderlyingTable().(sql.IndexAddressableTable)
+	if !ok {
+		return nil, nil
+	}
+	idxs, err := idxTbl.GetIndexes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, idx := range idxs {
+		coi, ok := idx.(ColumnOrderedIndex)
+		if !ok {
+			continue
+		}
+		reverse, ok := sortMatchesColumnOrders(rt.Name(), sfs, idx.Expressions(), coi.ColumnOrders())
+		if !ok || (reverse && !coi.Reversible()) {
+			continue
+		}
+
+		lookup, err := sql.NewMySQLIndexBuilder(idx).Build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ranges, ok := lookup.Ranges.(sql.MySQLRangeCollection)
+		if !ok || !idx.CanSupport(ctx, ranges.ToRanges()...) {
+			continue
+		}
+		if reverse {
+			lookup = sql.NewIndexLookup(lookup.Index, ranges, lookup.IsPointLookup, lookup.IsEmptyRange, lookup.IsSpatialLookup, true)
+		}
+		return plan.NewStaticIndexedAccessForTableNode(ctx, rt, lookup)
+	}
+	return nil, nil
+}
+
+// sortMatchesColumnOrders returns whether |sfs| sort the columns of table |tblName| in the order of a prefix of an
+// index with expressions |exprs| and column directions |orders|, which must include a descending column. The returned
+// |reverse| is true if every sort field is in the opposite direction of its index column.
+func sortMatchesColumnOrders(tblName string, sfs sql.SortFields, exprs []string, orders []sql.IndexOrder) (reverse bool, ok bool) {
+	if len(sfs) == 0 || len(sfs) > len(exprs) || len(orders) < len(exprs) {
+		return false, false
+	}
+	hasDescending := false
+	for _, o := range orders {
+		hasDescending = hasDescending || o == sql.IndexOrderDesc
+	}
+	if !hasDescending {
+		// ascending indexes are left to the engine's own sort replacement
+		return false, false
+	}
+
+	same, opposite := true, true
+	for i, sf := range sfs {
+		gf, isField := sf.Column.(*expression.GetField)
+		if !isField || !strings.EqualFold(gf.Table(), tblName) || !strings.EqualFold(exprs[i], tblName+"."+gf.Name()) {
+			return false, false
+		}
+		if (sf.Order == sql.Descending) == (orders[i] == sql.IndexOrderDesc) {
+			opposite = false
+		} else {
+			same = false
+		}
+	}
+	if !same && !opposite {
+		return false, false
+	}
+	return opposite, true
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/expression"
 	"github.com/dolthub/go-mysql-server/sql/expression/function/vector"
 	"github.com/dolthub/go-mysql-server/sql/fulltext"
+	"github.com/dolthub/go-mysql-server/sql/transform"
 	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
@@ -385,6 +386,17 @@ func getSecondaryIndex(ctx context.Context, db, tbl string, t *doltdb.Table, sch
 	}
 	vrw := t.ValueReadWriter()
 
+	order := sql.IndexOrderAsc
+	if idx.Descending() != nil {
+		// the analyzer assumes that index columns are sorted ascending, so an index with descending columns reports
+		// the direction of each column through ColumnOrders instead
+		order = sql.IndexOrderNone
+	}
+
+	if idx.IsInvisible() {
+		order = sql.IndexOrderNone
+	}
+
 	return &doltIndex{
 		id:                            idx.Name(),
 		tblName:                       tbl,
@@ -401,12 +413,14 @@ func getSecondaryIndex(ctx context.Context, db, tbl string, t *doltdb.Table, sch
 		vrw:                           vrw,
 		ns:                            t.NodeStore(),
 		keyBld:                        keyBld,
-		order:                         sql.IndexOrderAsc,
+		order:                         order,
 		constrainedToLookupExpression: true,
 		doltBinFormat:                 types.IsFormat_DOLT(vrw.Format()),
 		prefixLengths:                 idx.PrefixLengths(),
 		fullTextProps:                 idx.FullTextProperties(),
 		vectorProps:                   idx.VectorProperties(),
+		invisible:                     idx.IsInvisible(),
+		descending:                    idx.Descending(),
 	}, nil
 }
 
@@ -578,6 +592,12 @@ type doltIndex struct {
 	prefixLengths []uint16
 	fullTextProps schema.FullTextProperties
 	vectorProps   schema.VectorProperties
+
+	// invisible indexes are maintained by writes but are never chosen by the optimizer
+	invisible bool
+
+	// descending is whether each indexed column is stored in descending order, nil if every column is ascending
+	descending []bool
 }
 
 type LookupMeta struct {
@@ -591,7 +611,8 @@ func GetStrictLookups(schCols *schema.ColCollection, indexes []sql.Index) []Look
 	var lookups []LookupMeta
 	for _, i := range indexes {
 		idx := i.(*doltIndex)
-		if !idx.IsUnique() {
+		if !idx.IsUnique() || idx.invisible {
+			// strict lookups aren't checked against index visibility
 			continue
 		}
 		var nullAccepting bool
@@ -617,22 +638,119 @@ func GetStrictLookups(schCols *schema.ColCollection, indexes []sql.Index) []Look
 	return lookups
 }
 
+// GetSkipScanLookup returns a lookup for equality filters on the columns that follow the leading column of a
+// multi-column index. The lookup leaves the leading column unbound, and prolly.Map.IterRange reads it with a skip scan
+// of the index. |filters| are all the filters on the table: if any of them references the leading column of an index,
+// no lookup is returned, since the costed index scan can use that index without skipping. The returned bools report
+// which of |lookupCols| the lookup covers.
+func GetSkipScanLookup(ctx *sql.Context, schCols *schema.ColCollection, indexes []sql.Index, filters []sql.Expression, lookupCols []expression.LookupColumn) (sql.IndexLookup, *sql.FuncDepSet, []bool, bool, error) {
+	referenced := make(map[string]struct{})
+	for _, f := range filters {
+		transform.InspectExpr(f, func(e sql.Expression) bool {
+			if gf, ok := e.(*expression.GetField); ok {
+				referenced[strings.ToLower(gf.Name())] = struct{}{}
+			}
+			return false
+		})
+	}
+	eqs := make(map[string]int, len(lookupCols))
+	for i, c := range lookupCols {
+		if c.Lit.Value() != nil {
+			eqs[c.Col] = i
+		}
+	}
+
+	var best *doltIndex
+	var bestCnt int
+	for _, i := range indexes {
+		idx, ok := i.(*doltIndex)
+		if !ok || idx.invisible || len(idx.columns) == 0 {
+			continue
+		}
+		if _, ok := referenced[strings.ToLower(idx.columns[0].Name)]; ok {
+			return sql.IndexLookup{}, nil, nil, false, nil
+		}
+		if !idx.canSkipScan() {
+			continue
+		}
+		cnt := 0
+		for _, col := range idx.columns[1:] {
+			if _, ok := eqs[strings.ToLower(col.Name)]; !ok {
+				break
+			}
+			cnt++
+		}
+		if cnt > bestCnt {
+			best, bestCnt = idx, cnt
+		}
+	}
+	if best == nil {
+		return sql.IndexLookup{}, nil, nil, false, nil
+	}
+
+	covered := make([]bool, len(lookupCols))
+	constants := sql.NewFastIntSet()
+	rb := sql.NewEqualityIndexBuilder(best)
+	for j, col := range best.columns[1 : bestCnt+1] {
+		i := eqs[strings.ToLower(col.Name)]
+		if err := rb.AddEquality(ctx, j+1, lookupCols[i].Lit.Value()); err != nil {
+			return sql.IndexLookup{}, nil, nil, false, nil
+		}
+		covered[i] = true
+		constants.Add(schCols.TagToIdx[col.Tag] + 1)
+	}
+	lookup, err := rb.Build(ctx)
+	if err != nil {
+		return sql.IndexLookup{}, nil, nil, false, err
+	}
+	if !lookup.IsEmptyRange {
+		// the leading column and the columns after the equalities are unbound
+		rng := lookup.Ranges.(sql.MySQLRangeCollection)[0]
+		cets := best.ColumnExpressionTypes()
+		rng[0] = sql.AllRangeColumnExpr(cets[0].Type)
+		for j := bestCnt + 1; j < len(rng); j++ {
+			rng[j] = sql.AllRangeColumnExpr(cets[j].Type)
+		}
+	}
+
+	fds := &sql.FuncDepSet{}
+	fds.AddConstants(sql.NewColSetFromIntSet(constants))
+	return lookup, fds, covered, true, nil
+}
+
+// canSkipScan returns whether lookups on this index can leave its leading column unbound.
+func (di *doltIndex) canSkipScan() bool {
+	return di.doltBinFormat && len(di.columns) > 1 && !di.spatial && !di.fulltext && !di.vector &&
+		len(di.prefixLengths) == 0 && !di.HasContentHashedField()
+}
+
 var _ DoltIndex = (*doltIndex)(nil)
 var _ sql.ExtendedIndex = (*doltIndex)(nil)
+var _ ColumnOrderedIndex = (*doltIndex)(nil)
+
+// ColumnOrderedIndex is an index whose columns may be stored in different directions. sql.OrderedIndex describes a
+// single direction for the whole index, so an index with descending columns reports sql.IndexOrderNone there and the
+// direction of each of its columns here.
+type ColumnOrderedIndex interface {
+	sql.OrderedIndex
+	// ColumnOrders returns the direction of each indexed column, or nil if scanning the index doesn't produce rows in
+	// a useful order.
+	ColumnOrders() []sql.IndexOrder
+}
 
 func (di *doltIndex) String() string {
 	return di.dbName + "." + di.tblName + "." + di.id
 }
 
-// CanSupport implements sql.Index
+// CanSupport implements sql.Index. An invisible index supports no lookups.
 func (di *doltIndex) CanSupport(*sql.Context, ...sql.Range) bool {
-	return true
+	return !di.invisible
 }
 
 // CanSupportOrderBy implements the interface sql.Index.
 func (di *doltIndex) CanSupportOrderBy(expr sql.Expression) bool {
 	distance, ok := expr.(*vector.Distance)
-	if !ok {
+	if !ok || di.invisible {
 		return false
 	}
 	return di.vector && di.vectorProps.DistanceType.CanEval(distance.DistanceType)
@@ -954,6 +1072,24 @@ func (di *doltIndex) Order() sql.IndexOrder {
 	return di.order
 }
 
+// ColumnOrders implements ColumnOrderedIndex.
+func (di *doltIndex) ColumnOrders() []sql.IndexOrder {
+	if di.HasContentHashedField() || di.invisible {
+		return nil
+	}
+	if di.descending == nil && di.order == sql.IndexOrderNone {
+		return nil
+	}
+	orders := make([]sql.IndexOrder, len(di.columns))
+	for i := range orders {
+		orders[i] = sql.IndexOrderAsc
+		if i < len(di.descending) && di.descending[i] {
+			orders[i] = sql.IndexOrderDesc
+		}
+	}
+	return orders
+}
+
 func (di *doltIndex) Reversible() bool {
 	if di.HasContentHashedField() {
 		return false
@@ -1314,6 +1450,13 @@ func (di *doltIndex) prollyRangesFromSqlRanges(ctx context.Context, ns tree.Node
 			fields[i].Hi.Value = tup.GetField(i)
 		}
 
+		// descending fields are stored in reverse order, so their bounds are swapped in storage order
+		for i := range fields {
+			if di.keyBld.Desc.IsDescending(i) {
+				fields[i].Lo, fields[i].Hi = fields[i].Hi, fields[i].Lo
+			}
+		}
+
 		order := di.keyBld.Desc.Comparator()
 		var foundDiscontinuity bool
 		var isContiguous bool = true
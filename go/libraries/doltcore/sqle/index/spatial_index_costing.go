@@ -0,0 +1,169 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"github.com/dolthub/go-mysql-server/sql/types"
+)
+
+// SpatialIndexCostingRuleId is the id of the analyzer rule added by AddSpatialIndexCostingRule.
+const SpatialIndexCostingRuleId analyzer.RuleId = -3
+
+// maxSpatialLookupSelectivity is the largest fraction of a spatial index's entries a lookup can be estimated to match
+// before a table scan is used instead. Spatial lookups are imprecise and every row they return is filtered again, so
+// reading most of the index costs more than scanning the table once.
+const maxSpatialLookupSelectivity = 0.5
+
+// SpatialStatsProvider is implemented by stats providers which collect histograms for spatial indexes.
+type SpatialStatsProvider interface {
+	// SpatialSelectivity returns the estimated fraction of the entries of the spatial index |qual| which intersect the
+	// bounding box [lo, hi], or false if there are no statistics for the index.
+	SpatialSelectivity(ctx *sql.Context, qual sql.StatQualifier, lo, hi types.Point) (float64, bool)
+}
+
+// IndexLookupTable is implemented by tables which read an index lookup of another table.
+type IndexLookupTable interface {
+	// UnindexedTable returns the table read without the index.
+	UnindexedTable() sql.Table
+}
+
+// AddSpatialIndexCostingRule adds the rule which costs spatial index lookups with the histograms of the engine's stats
+// provider to |a|. The engine has no distribution for spatial indexes, so it uses one for any filter it
+// supports. The rule runs right after the engine picks each table's access path, and replaces a spatial index lookup
+// with a table scan when the statistics estimate the lookup's bounding box covers most of the index.
+func AddSpatialIndexCostingRule(a *analyzer.Analyzer) {
+	for _, b := range a.Batches {
+		if b.Desc != "once-after" {
+			continue
+		}
+		for i, r := range b.Rules {
+			if r.Id.String() == "optimizeJoins" {
+				rules := append([]analyzer.Rule{}, b.Rules[:i+1]...)
+				rules = append(rules, analyzer.Rule{Id: SpatialIndexCostingRuleId, Apply: costSpatialIndexLookups})
+				b.Rules = append(rules, b.Rules[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// costSpatialIndexLookups replaces the spatial index lookups under filters in |n| with table scans when they're
+// estimated to match too much of their index.
+func costSpatialIndexLookups(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node, _ *plan.Scope, _ analyzer.RuleSelector, _ *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	sp, ok := a.Catalog.StatsProvider.(SpatialStatsProvider)
+	if !ok {
+		return n, transform.SameTree, nil
+	}
+	return transform.Node(n, func(n sql.Node) (sql.Node, transform.TreeIdentity, error) {
+		f, ok := n.(*plan.Filter)
+		if !ok {
+			return n, transform.SameTree, nil
+		}
+		switch child := f.Child.(type) {
+		case *plan.IndexedTableAccess:
+			rt, err := spatialTableScan(ctx, sp, child)
+			if err != nil || rt == nil {
+				return n, transform.SameTree, err
+			}
+			ret, err := f.WithChildren(rt)
+			return ret, transform.NewTree, err
+		case *plan.TableAlias:
+			ita, ok := child.Child.(*plan.IndexedTableAccess)
+			if !ok {
+				return n, transform.SameTree, nil
+			}
+			rt, err := spatialTableScan(ctx, sp, ita)
+			if err != nil || rt == nil {
+				return n, transform.SameTree, err
+			}
+			alias, err := child.WithChildren(rt)
+			if err != nil {
+				return nil, transform.SameTree, err
+			}
+			ret, err := f.WithChildren(alias)
+			return ret, transform.NewTree, err
+		default:
+			return n, transform.SameTree, nil
+		}
+	})
+}
+
+// spatialTableScan returns a scan of the table read by |ita| if it's a spatial index lookup that the index's statistics
+// estimate to match more than maxSpatialLookupSelectivity of the index. Returns nil otherwise, including when the
+// index has no statistics.
+func spatialTableScan(ctx *sql.Context, sp SpatialStatsProvider, ita *plan.IndexedTableAccess) (sql.Node, error) {
+	idx := ita.Index()
+	if idx == nil || !idx.IsSpatial() || !ita.IsStatic() {
+		return nil, nil
+	}
+	rt, ok := ita.TableNode.(*plan.ResolvedTable)
+	if !ok {
+		return nil, nil
+	}
+	lt, ok := rt.UnderlyingTable().(IndexLookupTable)
+	if !ok {
+		return nil, nil
+	}
+	lookup, err := ita.GetLookup(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	lo, hi, ok := spatialLookupBounds(lookup)
+	if !ok {
+		return nil, nil
+	}
+
+	var schemaName string
+	if schTab, ok := rt.UnderlyingTable().(sql.DatabaseSchemaTable); ok {
+		schemaName = strings.ToLower(schTab.DatabaseSchema().SchemaName())
+	}
+	qual := sql.NewStatQualifier(strings.ToLower(rt.Database().Name()), schemaName, strings.ToLower(rt.UnderlyingTable().Name()), strings.ToLower(idx.ID()))
+	selectivity, ok := sp.SpatialSelectivity(ctx, qual, lo, hi)
+	if !ok || !preferSpatialTableScan(selectivity) {
+		return nil, nil
+	}
+	return rt.WithTable(lt.UnindexedTable())
+}
+
+// preferSpatialTableScan returns whether a spatial index lookup estimated to match |selectivity| of its index should
+// be replaced with a table scan. A lookup matching exactly maxSpatialLookupSelectivity keeps the index.
+func preferSpatialTableScan(selectivity float64) bool {
+	return selectivity > maxSpatialLookupSelectivity
+}
+
+// spatialLookupBounds returns the bounding box searched by the spatial index lookup |lookup|.
+func spatialLookupBounds(lookup sql.IndexLookup) (lo, hi types.Point, ok bool) {
+	ranges, ok := lookup.Ranges.(sql.MySQLRangeCollection)
+	if !ok || len(ranges) != 1 || len(ranges[0]) != 1 {
+		return types.Point{}, types.Point{}, false
+	}
+	lower, ok := ranges[0][0].LowerBound.(sql.Below)
+	if !ok {
+		return types.Point{}, types.Point{}, false
+	}
+	upper, ok := ranges[0][0].UpperBound.(sql.Above)
+	if !ok {
+		return types.Point{}, types.Point{}, false
+	}
+	lo, lok := lower.Key.(types.Point)
+	hi, hok := upper.Key.(types.Point)
+	return lo, hi, lok && hok
+}
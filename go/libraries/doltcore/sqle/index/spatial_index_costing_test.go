@@ -0,0 +1,30 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferSpatialTableScan(t *testing.T) {
+	require.False(t, preferSpatialTableScan(0))
+	require.False(t, preferSpatialTableScan(math.Nextafter(maxSpatialLookupSelectivity, 0)))
+	require.False(t, preferSpatialTableScan(maxSpatialLookupSelectivity))
+	require.True(t, preferSpatialTableScan(math.Nextafter(maxSpatialLookupSelectivity, 1)))
+	require.True(t, preferSpatialTableScan(1))
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+)
+
+// indexDirectionsFromStatement returns whether each column of |idx| is declared DESC by the CREATE INDEX, ALTER TABLE
+// or CREATE TABLE statement |query|. The engine drops the direction of index columns when it builds a sql.IndexDef, so
+// it's read back from the statement itself. Returns nil if every column is ascending, or if |query| doesn't declare
+// |idx|.
+func indexDirectionsFromStatement(query string, idx sql.IndexDef) []bool {
+	if query == "" || (idx.Constraint != sql.IndexConstraint_None && idx.Constraint != sql.IndexConstraint_Unique) {
+		return nil
+	}
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil
+	}
+
+	var candidates [][]*sqlparser.IndexColumn
+	var names []string
+	switch stmt := stmt.(type) {
+	case *sqlparser.AlterTable:
+		for _, ddl := range stmt.Statements {
+			if ddl.IndexSpec != nil && strings.EqualFold(ddl.IndexSpec.Action, sqlparser.CreateStr) {
+				candidates = append(candidates, ddl.IndexSpec.Columns)
+				names = append(names, ddl.IndexSpec.ToName.String())
+			}
+		}
+	case *sqlparser.DDL:
+		if stmt.TableSpec != nil {
+			for _, def := range stmt.TableSpec.Indexes {
+				if def.Info != nil && !def.Info.Primary {
+					candidates = append(candidates, def.Columns)
+					names = append(names, def.Info.Name.String())
+				}
+			}
+		}
+	}
+
+	for i, cols := range candidates {
+		if names[i] != "" && idx.Name != "" && !strings.EqualFold(names[i], idx.Name) {
+			continue
+		}
+		if !sameIndexColumns(cols, idx.Columns) {
+			continue
+		}
+		var descending []bool
+		for j, col := range cols {
+			if col.Order == sqlparser.DescScr {
+				if descending == nil {
+					descending = make([]bool, len(cols))
+				}
+				descending[j] = true
+			}
+		}
+		return descending
+	}
+	return nil
+}
+
+func sameIndexColumns(parsed []*sqlparser.IndexColumn, cols []sql.IndexColumn) bool {
+	if len(parsed) != len(cols) {
+		return false
+	}
+	for i := range parsed {
+		if !strings.EqualFold(parsed[i].Column.String(), cols[i].Name) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexDirectionsFromStatement(t *testing.T) {
+	ab := sql.IndexDef{Name: "ab", Columns: []sql.IndexColumn{{Name: "a"}, {Name: "b"}}}
+	tests := []struct {
+		name     string
+		query    string
+		idx      sql.IndexDef
+		expected []bool
+	}{
+		{
+			name:     "create index",
+			query:    "create index ab on t (a desc, b asc)",
+			idx:      ab,
+			expected: []bool{true, false},
+		},
+		{
+			name:  "ascending",
+			query: "create index ab on t (a, b asc)",
+			idx:   ab,
+		},
+		{
+			name:     "alter table",
+			query:    "alter table t add index ab (a, b desc)",
+			idx:      ab,
+			expected: []bool{false, true},
+		},
+		{
+			name:     "create table",
+			query:    "create table t (pk int primary key, a int, b int, key other (b desc), key ab (a desc, b desc))",
+			idx:      ab,
+			expected: []bool{true, true},
+		},
+		{
+			name:  "different index",
+			query: "create index other on t (a desc, b)",
+			idx:   ab,
+		},
+		{
+			name:  "fulltext",
+			query: "create fulltext index ab on t (a desc, b)",
+			idx:   sql.IndexDef{Name: "ab", Constraint: sql.IndexConstraint_Fulltext, Columns: ab.Columns},
+		},
+		{
+			name:  "not ddl",
+			query: "insert into t values (1, 2)",
+			idx:   ab,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, indexDirectionsFromStatement(test.query, test.idx))
+		})
+	}
+}
@@ -45,11 +45,17 @@ func NewIndexedDoltTable(t *DoltTable, idx index.DoltIndex) *IndexedDoltTable {
 
 var _ sql.IndexedTable = (*IndexedDoltTable)(nil)
 var _ sql.CommentedTable = (*IndexedDoltTable)(nil)
+var _ index.IndexLookupTable = (*IndexedDoltTable)(nil)
 
 func (idt *IndexedDoltTable) Index() index.DoltIndex {
 	return idt.idx
 }
 
+// UnindexedTable implements index.IndexLookupTable
+func (idt *IndexedDoltTable) UnindexedTable() sql.Table {
+	return idt.DoltTable
+}
+
 func (t *IndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexScanBuilder, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -86,7 +92,8 @@ func (idt *IndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Partition)
 		}
 	}
 
-	return idt.lb.NewPartitionRowIter(ctx, part)
+	iter, err := idt.lb.NewPartitionRowIter(ctx, part)
+	return countRowsExamined(ctx, iter, err)
 }
 
 func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition) (sql.RowIter, error) {
@@ -103,7 +110,8 @@ func (idt *IndexedDoltTable) PartitionRows2(ctx *sql.Context, part sql.Partition
 		}
 	}
 
-	return idt.lb.NewPartitionRowIter(ctx, part)
+	iter, err := idt.lb.NewPartitionRowIter(ctx, part)
+	return countRowsExamined(ctx, iter, err)
 }
 
 var _ sql.IndexedTable = (*WritableIndexedDoltTable)(nil)
@@ -112,6 +120,7 @@ var _ sql.DeletableTable = (*WritableIndexedDoltTable)(nil)
 var _ sql.ReplaceableTable = (*WritableIndexedDoltTable)(nil)
 var _ sql.StatisticsTable = (*WritableIndexedDoltTable)(nil)
 var _ sql.ProjectedTable = (*WritableIndexedDoltTable)(nil)
+var _ index.IndexLookupTable = (*WritableIndexedDoltTable)(nil)
 
 func NewWritableIndexedDoltTable(t *WritableDoltTable, idx index.DoltIndex) *WritableIndexedDoltTable {
 	return &WritableIndexedDoltTable{
@@ -134,6 +143,11 @@ func (t *WritableIndexedDoltTable) Index() index.DoltIndex {
 	return t.idx
 }
 
+// UnindexedTable implements index.IndexLookupTable
+func (t *WritableIndexedDoltTable) UnindexedTable() sql.Table {
+	return t.WritableDoltTable
+}
+
 func (t *WritableIndexedDoltTable) LookupBuilder(ctx *sql.Context) (index.IndexScanBuilder, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -172,7 +186,8 @@ func (t *WritableIndexedDoltTable) PartitionRows(ctx *sql.Context, part sql.Part
 		}
 	}
 
-	return t.lb.NewPartitionRowIter(ctx, part)
+	iter, err := t.lb.NewPartitionRowIter(ctx, part)
+	return countRowsExamined(ctx, iter, err)
 }
 
 // WithProjections implements sql.ProjectedTable
@@ -104,6 +104,14 @@ func (b Builder) Build(ctx *sql.Context, n sql.Node, r sql.Row) (sql.RowIter, er
 					}
 				}
 			}
+		} else if len(r) == 0 {
+			iter, ok, err := newLooseIndexScanKvIter(ctx, n)
+			if ok && err == nil {
+				// (1) grouping on the first column of an index
+				// (2) only the grouping column and MIN or MAX of the second column
+				// (3) table as child (no filters)
+				return iter, nil
+			}
 		}
 	default:
 	}
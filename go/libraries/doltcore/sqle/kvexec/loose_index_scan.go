@@ -0,0 +1,305 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvexec
+
+import (
+	"io"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+	"github.com/dolthub/go-mysql-server/sql/expression/function/aggregation"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	sqltypes "github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+type looseScanOutput uint8
+
+const (
+	looseScanGroup looseScanOutput = iota
+	looseScanMin
+	looseScanMax
+)
+
+// newLooseIndexScanKvIter returns an iterator for a GROUP BY on the first
+// column of an index which selects only that column, and the MIN or MAX of
+// the second column of the index. Rather than reading every row, the
+// iterator seeks to the first and last entries of each group.
+func newLooseIndexScanKvIter(ctx *sql.Context, n *plan.GroupBy) (sql.RowIter, bool, error) {
+	if len(n.GroupByExprs) != 1 {
+		return nil, false, nil
+	}
+	group, ok := n.GroupByExprs[0].(*expression.GetField)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var aggCol string
+	outputs := make([]looseScanOutput, len(n.SelectedExprs))
+	for i, e := range n.SelectedExprs {
+		var child sql.Expression
+		switch e := e.(type) {
+		case *expression.GetField:
+			if !strings.EqualFold(e.Name(), group.Name()) {
+				return nil, false, nil
+			}
+			outputs[i] = looseScanGroup
+			continue
+		case *aggregation.Min:
+			if e.Window() != nil {
+				return nil, false, nil
+			}
+			child, outputs[i] = e.Child, looseScanMin
+		case *aggregation.Max:
+			if e.Window() != nil {
+				return nil, false, nil
+			}
+			child, outputs[i] = e.Child, looseScanMax
+		default:
+			return nil, false, nil
+		}
+		gf, ok := child.(*expression.GetField)
+		if !ok || (aggCol != "" && !strings.EqualFold(gf.Name(), aggCol)) {
+			return nil, false, nil
+		}
+		aggCol = gf.Name()
+	}
+	if aggCol == "" {
+		return nil, false, nil
+	}
+
+	table, ok, err := getLooseScanTable(ctx, n.Child)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	m, ok, err := getLooseScanIndex(ctx, table, group.Name(), aggCol)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return &looseIndexScanKvIter{m: m, outputs: outputs}, true, nil
+}
+
+// getLooseScanTable returns the table read by |n|, if |n| reads every row of
+// a Dolt table.
+func getLooseScanTable(ctx *sql.Context, n sql.Node) (*doltdb.Table, bool, error) {
+	switch n := n.(type) {
+	case *plan.TableAlias:
+		return getLooseScanTable(ctx, n.Child)
+	case *plan.ResolvedTable:
+		var dt *sqle.DoltTable
+		switch t := n.UnderlyingTable().(type) {
+		case *sqle.WritableDoltTable:
+			dt = t.DoltTable
+		case *sqle.AlterableDoltTable:
+			dt = t.DoltTable
+		case *sqle.DoltTable:
+			dt = t
+		default:
+			return nil, false, nil
+		}
+		table, err := dt.DoltTable(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		return table, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// getLooseScanIndex returns the primary or secondary index of |table| whose
+// first two columns are |groupCol| and |aggCol|, if one can be used for a
+// loose index scan.
+func getLooseScanIndex(ctx *sql.Context, table *doltdb.Table, groupCol, aggCol string) (prolly.Map, bool, error) {
+	sch, err := table.GetSchema(ctx)
+	if err != nil {
+		return prolly.Map{}, false, err
+	}
+	cols := sch.GetAllCols()
+	gc, ok := cols.LowerNameToCol[strings.ToLower(groupCol)]
+	if !ok {
+		return prolly.Map{}, false, nil
+	}
+	ac, ok := cols.LowerNameToCol[strings.ToLower(aggCol)]
+	if !ok || gc.Tag == ac.Tag {
+		return prolly.Map{}, false, nil
+	}
+	// text and blob fields are stored out of band or content hashed,
+	// so their index order isn't their value order
+	if sqltypes.IsTextBlob(gc.TypeInfo.ToSqlType()) || sqltypes.IsTextBlob(ac.TypeInfo.ToSqlType()) {
+		return prolly.Map{}, false, nil
+	}
+
+	var rowData durable.Index
+	if pks := sch.GetPKCols(); pks.Size() >= 2 && pks.GetByIndex(0).Tag == gc.Tag && pks.GetByIndex(1).Tag == ac.Tag {
+		if rowData, err = table.GetRowData(ctx); err != nil {
+			return prolly.Map{}, false, err
+		}
+	} else {
+		for _, idx := range sch.Indexes().AllIndexes() {
+			if !looseScanIndexMatches(idx, gc.Tag, ac.Tag) {
+				continue
+			}
+			if rowData, err = table.GetIndexRowData(ctx, idx.Name()); err != nil {
+				return prolly.Map{}, false, err
+			}
+			break
+		}
+	}
+	if rowData == nil || rowData.Format() != types.Format_DOLT {
+		return prolly.Map{}, false, nil
+	}
+	m, err := durable.ProllyMapFromIndex(rowData)
+	if err != nil {
+		return prolly.Map{}, false, err
+	}
+	return m, true, nil
+}
+
+// looseScanIndexMatches returns true if |idx| is an ordinary index whose first
+// two columns are |groupTag| and |aggTag|, in ascending order.
+func looseScanIndexMatches(idx schema.Index, groupTag, aggTag uint64) bool {
+	if idx.IsSpatial() || idx.IsFullText() || idx.IsVector() || idx.IsInvisible() {
+		return false
+	}
+	tags := idx.IndexedColumnTags()
+	if len(tags) < 2 || tags[0] != groupTag || tags[1] != aggTag {
+		return false
+	}
+	for i, l := range idx.PrefixLengths() {
+		if i < 2 && l != 0 {
+			return false
+		}
+	}
+	for i, desc := range idx.Descending() {
+		if i < 2 && desc {
+			return false
+		}
+	}
+	return true
+}
+
+// looseIndexScanKvIter returns one row per distinct value of the first field
+// of |m|, with the MIN and MAX of the second field. NULLs sort first, so the
+// MIN of a group is its first entry with a non-NULL second field, and the MAX
+// is its last entry.
+type looseIndexScanKvIter struct {
+	m       prolly.Map
+	outputs []looseScanOutput
+
+	// prev is the first field of the last group returned
+	prev    []byte
+	started bool
+}
+
+var _ sql.RowIter = (*looseIndexScanKvIter)(nil)
+
+func (l *looseIndexScanKvIter) Next(ctx *sql.Context) (sql.Row, error) {
+	desc := l.m.KeyDesc()
+	next := prolly.Range{Fields: []prolly.RangeField{{}}, Desc: desc}
+	if l.started {
+		next.Fields[0].Lo = prolly.Bound{Binding: true, Inclusive: false, Value: l.prev}
+	}
+	first, err := l.first(ctx, next, false)
+	if err != nil {
+		return nil, err
+	}
+	if first == nil {
+		return nil, io.EOF
+	}
+	l.prev, l.started = desc.GetField(0, first), true
+
+	group := prolly.RangeField{
+		Lo:             prolly.Bound{Binding: true, Inclusive: true, Value: l.prev},
+		Hi:             prolly.Bound{Binding: true, Inclusive: true, Value: l.prev},
+		BoundsAreEqual: true,
+	}
+	var minTup, maxTup val.Tuple
+	row := make(sql.Row, len(l.outputs))
+	for i, o := range l.outputs {
+		var tup val.Tuple
+		switch o {
+		case looseScanGroup:
+			tup = first
+		case looseScanMin:
+			if minTup == nil {
+				minTup = first
+				if first.FieldIsNull(1) {
+					notNull := prolly.RangeField{Lo: prolly.Bound{Binding: true, Inclusive: false}}
+					rng := prolly.Range{Fields: []prolly.RangeField{group, notNull}, Desc: desc}
+					if minTup, err = l.first(ctx, rng, false); err != nil {
+						return nil, err
+					}
+				}
+			}
+			tup = minTup
+		case looseScanMax:
+			if maxTup == nil {
+				rng := prolly.Range{Fields: []prolly.RangeField{group}, Desc: desc}
+				if maxTup, err = l.first(ctx, rng, true); err != nil {
+					return nil, err
+				}
+			}
+			tup = maxTup
+		}
+		if tup == nil {
+			// every entry of the group has a NULL second field
+			continue
+		}
+		field := 1
+		if o == looseScanGroup {
+			field = 0
+		}
+		if row[i], err = tree.GetField(ctx, desc, field, tup, l.m.NodeStore()); err != nil {
+			return nil, err
+		}
+	}
+	return row, nil
+}
+
+// first returns the first key of |rng|, or the last if |reverse| is true. It
+// returns nil if |rng| is empty.
+func (l *looseIndexScanKvIter) first(ctx *sql.Context, rng prolly.Range, reverse bool) (val.Tuple, error) {
+	var iter prolly.MapIter
+	var err error
+	if reverse {
+		iter, err = l.m.IterRangeReverse(ctx, rng)
+	} else {
+		iter, err = l.m.IterRange(ctx, rng)
+	}
+	if err != nil {
+		return nil, err
+	}
+	k, _, err := iter.Next(ctx)
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (l *looseIndexScanKvIter) Close(_ *sql.Context) error {
+	return nil
+}
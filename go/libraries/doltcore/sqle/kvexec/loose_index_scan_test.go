@@ -0,0 +1,166 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvexec
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/planbuilder"
+	"github.com/dolthub/go-mysql-server/sql/rowexec"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+// TestLooseIndexScan ensures that we trigger the operator replacement for
+// expected query patterns, and that it returns the same rows as a full scan.
+func TestLooseIndexScan(t *testing.T) {
+	setup := []string{
+		"create table xyz (x int primary key, y int, z int, w varchar(10), key yz_idx(y, z), key zw_idx(z, w))",
+		"insert into xyz values (1, 1, 5, 'a'), (2, 1, 3, 'b'), (3, 1, null, 'c'), (4, 2, 7, 'd'), (5, 2, 7, null)",
+		"insert into xyz values (6, 3, null, 'e'), (7, null, 2, 'f'), (8, null, 9, 'g'), (9, 4, -1, 'h'), (10, 4, 8, 'i')",
+		"create table ab (a int, b int, c int, primary key (a, b))",
+		"insert into ab values (1, 1, 1), (1, 2, 2), (2, 5, 3), (3, 0, 4), (3, 9, 5)",
+	}
+	tests := []struct {
+		name      string
+		query     string
+		doRowexec bool
+	}{
+		{
+			name:      "accept min per group",
+			query:     "select y, min(z) from xyz group by y",
+			doRowexec: true,
+		},
+		{
+			name:      "accept max per group",
+			query:     "select max(z) from xyz group by y",
+			doRowexec: true,
+		},
+		{
+			name:      "accept min and max per group",
+			query:     "select min(z), y, max(z) from xyz group by y",
+			doRowexec: true,
+		},
+		{
+			name:      "accept aliased table",
+			query:     "select t.y, max(t.z) from xyz t group by t.y",
+			doRowexec: true,
+		},
+		{
+			name:      "accept primary key",
+			query:     "select a, min(b), max(b) from ab group by a",
+			doRowexec: true,
+		},
+		{
+			name:      "accept varchar second column",
+			query:     "select z, min(w), max(w) from xyz group by z",
+			doRowexec: true,
+		},
+		{
+			name:      "reject second column not in an index",
+			query:     "select a, min(c) from ab group by a",
+			doRowexec: false,
+		},
+		{
+			name:      "reject grouping on second column",
+			query:     "select z, min(y) from xyz group by z",
+			doRowexec: false,
+		},
+		{
+			name:      "reject other aggregates",
+			query:     "select y, min(z), count(z) from xyz group by y",
+			doRowexec: false,
+		},
+		{
+			name:      "reject filter child",
+			query:     "select y, min(z) from xyz where x > 1 group by y",
+			doRowexec: false,
+		},
+		{
+			name:      "reject distinct aggregate",
+			query:     "select y, min(distinct z) from xyz group by y",
+			doRowexec: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dEnv := dtestutils.CreateTestEnv()
+			defer dEnv.DoltDB(ctx).Close()
+
+			tmpDir, err := dEnv.TempTableFilesDir()
+			require.NoError(t, err)
+
+			opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+			db, err := sqle.NewDatabase(context.Background(), "dolt", dEnv.DbData(ctx), opts)
+			require.NoError(t, err)
+
+			engine, sqlCtx, err := sqle.NewTestEngine(dEnv, context.Background(), db)
+			require.NoError(t, err)
+
+			err = sqlCtx.Session.SetSessionVariable(sqlCtx, sql.AutoCommitSessionVar, false)
+			require.NoError(t, err)
+
+			for _, q := range setup {
+				_, iter, _, err := engine.Query(sqlCtx, q)
+				require.NoError(t, err)
+				_, err = sql.RowIterToRows(sqlCtx, iter)
+				require.NoError(t, err)
+			}
+
+			binder := planbuilder.New(sqlCtx, engine.EngineAnalyzer().Catalog, engine.EngineEventScheduler(), engine.Parser)
+			node, _, _, qFlags, err := binder.Parse(tt.query, nil, false)
+			require.NoError(t, err)
+			node, err = engine.EngineAnalyzer().Analyze(sqlCtx, node, nil, qFlags)
+			require.NoError(t, err)
+
+			j := getAgg(node)
+			require.NotNil(t, j)
+
+			iter, err := Builder{}.Build(sqlCtx, j, nil)
+			require.NoError(t, err)
+			_, ok := iter.(*looseIndexScanKvIter)
+			require.Equalf(t, tt.doRowexec, ok, "expected do row exec: %t", tt.doRowexec)
+			if !ok {
+				return
+			}
+
+			actual, err := sql.RowIterToRows(sqlCtx, iter)
+			require.NoError(t, err)
+			expIter, err := rowexec.DefaultBuilder.Build(sqlCtx, j, nil)
+			require.NoError(t, err)
+			expected, err := sql.RowIterToRows(sqlCtx, expIter)
+			require.NoError(t, err)
+			require.Equal(t, sortedRows(expected), sortedRows(actual))
+		})
+	}
+}
+
+func sortedRows(rows []sql.Row) []string {
+	res := make([]string, len(rows))
+	for i, r := range rows {
+		res[i] = fmt.Sprint(r)
+	}
+	sort.Strings(res)
+	return res
+}
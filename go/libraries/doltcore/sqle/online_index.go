@@ -0,0 +1,155 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// onlineIndexCatchUpHook, if set, is called before an online index build reads the branch's latest row data to catch
+// up with. Tests use it to write to the table while an index is being built.
+var onlineIndexCatchUpHook func(ctx *sql.Context)
+
+// onlineIndexBuilder returns an index builder which builds |idxDef| online when @@dolt_online_index_build is enabled.
+// An online build reads a snapshot of the table, then catches up with rows written to the branch's working set by
+// other sessions while it was building, so that long index builds don't hold up concurrent writers. Returns false if
+// the index should be built the regular way.
+func (t *AlterableDoltTable) onlineIndexBuilder(ctx *sql.Context, idxDef sql.IndexDef) (creation.IndexBuildFn, bool, error) {
+	enabled, err := ctx.GetSessionVariable(ctx, dsess.OnlineIndexBuild)
+	if err != nil {
+		return nil, false, err
+	}
+	if enabled != int8(1) || !types.IsFormat_DOLT(t.Format()) || idxDef.Constraint == sql.IndexConstraint_Fulltext || idxDef.Constraint == sql.IndexConstraint_Vector {
+		return nil, false, nil
+	}
+
+	sess := dsess.DSessFromSess(ctx.Session)
+	ws, err := sess.WorkingSet(ctx, t.db.Name())
+	if err != nil {
+		// detached heads can't be written to concurrently
+		return nil, false, nil
+	}
+
+	origTable, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	origSchHash, err := origTable.GetSchemaHash(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ddb := t.db.DbData().Ddb
+	tableName := t.TableName()
+	opName := fmt.Sprintf("%s.%s.%s", t.db.Name(), t.Name(), idxDef.Name)
+
+	// persistedRows returns the row data of the table in the branch's working set, or false if the table has been
+	// dropped or had its schema altered since the build started and can no longer be caught up with.
+	persistedRows := func(ctx *sql.Context) (prolly.Map, bool, error) {
+		curr, err := ddb.ResolveWorkingSet(ctx, ws.Ref())
+		if err != nil {
+			return prolly.Map{}, false, err
+		}
+		tbl, ok, err := curr.WorkingRoot().GetTable(ctx, tableName)
+		if err != nil || !ok {
+			return prolly.Map{}, false, err
+		}
+		schHash, err := tbl.GetSchemaHash(ctx)
+		if err != nil || schHash != origSchHash {
+			return prolly.Map{}, false, err
+		}
+		rows, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return prolly.Map{}, false, err
+		}
+		m, err := durable.ProllyMapFromIndex(rows)
+		return m, err == nil, err
+	}
+
+	build := func(ctx *sql.Context, tbl *doltdb.Table, idx schema.Index) (*doltdb.Table, durable.Index, error) {
+		op := dsess.Operations.Start(ctx, "index build", opName)
+		defer dsess.Operations.Finish(op)
+
+		rows, err := tbl.GetRowData(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		snapshot, err := durable.ProllyMapFromIndex(rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Only chase the branch's writes if this session's view of the table matches the branch's. Otherwise the
+		// session has its own uncommitted changes to the table, which swapping in newer row data would lose.
+		persisted, ok, err := persistedRows(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		chase := ok && persisted.HashOf() == snapshot.HashOf()
+		last := snapshot
+		latest := func(ctx *sql.Context) (prolly.Map, error) {
+			if onlineIndexCatchUpHook != nil {
+				onlineIndexCatchUpHook(ctx)
+			}
+			if !chase {
+				return last, nil
+			}
+			m, ok, err := persistedRows(ctx)
+			if err != nil {
+				return prolly.Map{}, err
+			}
+			if !ok {
+				chase = false
+				return last, nil
+			}
+			last = m
+			return m, nil
+		}
+
+		progress := func(phase string, done, total int64) {
+			op.SetStatus(phase)
+			op.SetProgress(done, total)
+		}
+		indexRows, caughtUp, err := creation.BuildSecondaryIndexOnline(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), sch, t.Name(), idx, snapshot, latest, progress)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		op.SetStatus("swapping")
+		if caughtUp.HashOf() != snapshot.HashOf() {
+			tbl, err = tbl.UpdateRows(ctx, durable.IndexFromProllyMap(caughtUp))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		return tbl, indexRows, nil
+	}
+
+	return build, true, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sqle "github.com/dolthub/go-mysql-server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+func TestOnlineIndexBuildWithConcurrentWriter(t *testing.T) {
+	ctx := context.Background()
+	dEnv := dtestutils.CreateTestEnv()
+	defer dEnv.DoltDB(ctx).Close()
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+
+	engine, sqlCtx, err := NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	sess := dsess.DSessFromSess(sqlCtx.Session)
+	pro := sess.Provider().(*DoltDatabaseProvider)
+	config, _ := dEnv.Config.GetConfig(env.GlobalConfig)
+	newSession := func() *sql.Context {
+		sqlCtx := NewTestSQLCtxWithProvider(ctx, pro, config, nil, sess.GCSafepointController())
+		sqlCtx.SetCurrentDatabase(db.Name())
+		return sqlCtx
+	}
+
+	for _, query := range []string{
+		"create table t (pk int primary key, c1 int)",
+		"insert into t values (1, 10), (2, 20), (3, 30)",
+		"set @@dolt_online_index_build = 1",
+	} {
+		_, err = runQuery(sqlCtx, engine, query)
+		require.NoError(t, err)
+	}
+
+	// Once the index has been built from its snapshot of the table, another session writes to the table and commits
+	// before the build catches up.
+	wrote := make(chan error, 1)
+	catchUpReads := 0
+	onlineIndexCatchUpHook = func(*sql.Context) {
+		if catchUpReads++; catchUpReads > 1 {
+			return
+		}
+		go func() {
+			writerCtx := newSession()
+			if _, err := runQuery(writerCtx, engine, "insert into t values (4, 40)"); err != nil {
+				wrote <- err
+				return
+			}
+			_, err := runQuery(writerCtx, engine, "update t set c1 = 21 where pk = 2")
+			wrote <- err
+		}()
+		select {
+		case err := <-wrote:
+			assert.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			t.Error("writes blocked behind the online index build")
+		}
+	}
+	defer func() { onlineIndexCatchUpHook = nil }()
+
+	_, err = runQuery(sqlCtx, engine, "alter table t add index c1_idx (c1)")
+	require.NoError(t, err)
+	// the build applied the concurrent writes, then found nothing more to catch up with
+	require.Equal(t, 2, catchUpReads)
+
+	readerCtx := newSession()
+	rows, err := runQuery(readerCtx, engine, "select pk from t where c1 = 40")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(4)}}, rows)
+	rows, err = runQuery(readerCtx, engine, "select pk from t where c1 = 21")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(2)}}, rows)
+	rows, err = runQuery(readerCtx, engine, "select pk from t where c1 = 20")
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+	rows, err = runQuery(readerCtx, engine, "select pk, c1 from t order by pk")
+	require.NoError(t, err)
+	assert.Equal(t, []sql.Row{{int32(1), int32(10)}, {int32(2), int32(21)}, {int32(3), int32(30)}, {int32(4), int32(40)}}, rows)
+}
+
+func runQuery(ctx *sql.Context, engine *sqle.Engine, query string) ([]sql.Row, error) {
+	_, iter, _, err := engine.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return sql.RowIterToRows(ctx, iter)
+}
@@ -0,0 +1,342 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// The engine accepts, but doesn't interpret, the partition options of a CREATE TABLE statement. We read the
+// RANGE and KEY options from the statement text so that they can be stored with the table's schema.
+
+type partitionToken struct {
+	text   string
+	quoted bool
+}
+
+func (t partitionToken) is(keyword string) bool {
+	return !t.quoted && strings.EqualFold(t.text, keyword)
+}
+
+// tokenizePartitionClause returns the tokens of |query| following a top-level PARTITION BY, or nil if there is none.
+func tokenizePartitionClause(query string) []partitionToken {
+	var toks []partitionToken
+	depth, start := 0, -1
+	for i := 0; i < len(query); {
+		c := rune(query[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '`':
+			j := strings.IndexByte(query[i+1:], '`')
+			if j < 0 {
+				return nil
+			}
+			toks = append(toks, partitionToken{text: query[i+1 : i+1+j], quoted: true})
+			i += j + 2
+		case c == '\'' || c == '"':
+			j := i + 1
+			for j < len(query) && rune(query[j]) != c {
+				if query[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			toks = append(toks, partitionToken{text: query[i+1 : min(j, len(query))], quoted: true})
+			i = j + 1
+		case c == '(' || c == ')' || c == ',' || c == '=' || c == ';':
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+			}
+			toks = append(toks, partitionToken{text: string(c)})
+			i++
+		default:
+			j := i
+			for j < len(query) && (query[j] == '_' || query[j] == '-' || query[j] == '$' || query[j] == '.' ||
+				unicode.IsLetter(rune(query[j])) || unicode.IsDigit(rune(query[j]))) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			toks = append(toks, partitionToken{text: query[i:j]})
+			i = j
+		}
+
+		n := len(toks)
+		if start < 0 && depth == 0 && n >= 2 && toks[n-2].is("PARTITION") && toks[n-1].is("BY") {
+			start = n
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+	return toks[start:]
+}
+
+// partitioningFromCreateStatement returns the partitioning declared by the CREATE TABLE statement |query|. Returns
+// nil if the statement has no partition options, or uses a partitioning method other than RANGE or KEY.
+func partitioningFromCreateStatement(query string) (*schema.Partitioning, error) {
+	toks := tokenizePartitionClause(query)
+	if len(toks) == 0 {
+		return nil, nil
+	}
+
+	pos := 0
+	next := func() partitionToken {
+		if pos >= len(toks) {
+			return partitionToken{}
+		}
+		pos++
+		return toks[pos-1]
+	}
+	peek := func() partitionToken {
+		if pos >= len(toks) {
+			return partitionToken{}
+		}
+		return toks[pos]
+	}
+	expect := func(s string) error {
+		if t := next(); !t.is(s) {
+			return fmt.Errorf("invalid partition options: expected '%s' but found '%s'", s, t.text)
+		}
+		return nil
+	}
+	column := func() (string, error) {
+		if err := expect("("); err != nil {
+			return "", err
+		}
+		col := next()
+		if col.text == "" || peek().is(",") {
+			return "", fmt.Errorf("invalid partition options: partitioning by more than one column is not supported")
+		}
+		return col.text, expect(")")
+	}
+
+	if peek().is("LINEAR") {
+		next()
+	}
+
+	p := &schema.Partitioning{}
+	switch method := next(); {
+	case method.is("RANGE"):
+		p.Method = schema.PartitionMethodRange
+		if peek().is("COLUMNS") {
+			next()
+		}
+		col, err := column()
+		if err != nil {
+			return nil, err
+		}
+		p.Column = col
+
+		if err := expect("("); err != nil {
+			return nil, err
+		}
+		for {
+			if err := expect("PARTITION"); err != nil {
+				return nil, err
+			}
+			name := next()
+			for _, kw := range []string{"VALUES", "LESS", "THAN"} {
+				if err := expect(kw); err != nil {
+					return nil, err
+				}
+			}
+			var bound partitionToken
+			if peek().is(schema.PartitionMaxValue) {
+				bound = next()
+			} else {
+				if err := expect("("); err != nil {
+					return nil, err
+				}
+				bound = next()
+				if err := expect(")"); err != nil {
+					return nil, err
+				}
+			}
+			if !bound.is(schema.PartitionMaxValue) {
+				if _, err := strconv.ParseInt(bound.text, 10, 64); err != nil {
+					return nil, fmt.Errorf("invalid partition options: partition '%s' must have an integer upper bound", name.text)
+				}
+			}
+			p.Partitions = append(p.Partitions, schema.PartitionDefinition{Name: name.text, LessThan: strings.ToUpper(bound.text)})
+
+			// skip any trailing partition options, like ENGINE or COMMENT
+			for depth := 0; peek().text != "" && (depth > 0 || !(peek().is(",") || peek().is(")"))); {
+				if t := next(); t.is("(") {
+					depth++
+				} else if t.is(")") {
+					depth--
+				}
+			}
+			if t := next(); t.is(")") {
+				break
+			} else if !t.is(",") {
+				return nil, fmt.Errorf("invalid partition options: unexpected end of partition definitions")
+			}
+		}
+
+	case method.is("KEY"):
+		p.Method = schema.PartitionMethodKey
+		if peek().is("ALGORITHM") {
+			next()
+			if err := expect("="); err != nil {
+				return nil, err
+			}
+			next()
+		}
+		col, err := column()
+		if err != nil {
+			return nil, err
+		}
+		p.Column = col
+		p.NumPartitions = 1
+		if peek().is("PARTITIONS") {
+			next()
+			n, err := strconv.Atoi(next().text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid partition options: PARTITIONS must be followed by an integer")
+			}
+			p.NumPartitions = n
+		}
+
+	default:
+		return nil, nil
+	}
+
+	return p, nil
+}
+
+// partitionsForSchema returns the row partitions of |rows| aligned to the partitioning of |sch|, or false if the
+// table has no partitioning that can be used to split its rows.
+func partitionsForSchema(ctx context.Context, sch schema.Schema, rows durable.Index) ([]doltTablePartition, bool, error) {
+	p := sch.GetPartitioning()
+	if p == nil || !types.IsFormat_DOLT(rows.Format()) {
+		return nil, false, nil
+	}
+	m, err := durable.ProllyMapFromIndex(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	count, err := m.Count()
+	if err != nil || count == 0 {
+		return nil, false, err
+	}
+	numElements := uint64(count)
+
+	var bounds []uint64
+	switch p.Method {
+	case schema.PartitionMethodRange:
+		for _, pd := range p.Partitions {
+			upper, ok := pd.UpperBound()
+			if !ok {
+				break
+			}
+			ord, err := ordinalForLeadingKey(ctx, m, upper)
+			if err != nil {
+				return nil, false, err
+			}
+			bounds = append(bounds, ord)
+		}
+	case schema.PartitionMethodKey:
+		for i := 1; i < p.NumPartitions; i++ {
+			bounds = append(bounds, numElements*uint64(i)/uint64(p.NumPartitions))
+		}
+	default:
+		return nil, false, nil
+	}
+	bounds = append(bounds, numElements)
+
+	var partitions []doltTablePartition
+	var start uint64
+	for _, end := range bounds {
+		if end <= start {
+			continue
+		}
+		// keep large partitions from becoming a bottleneck for parallel scans
+		for ; end-start > MaxRowsPerPartition; start += MaxRowsPerPartition {
+			partitions = append(partitions, doltTablePartition{start: start, end: start + MaxRowsPerPartition, rowData: rows})
+		}
+		partitions = append(partitions, doltTablePartition{start: start, end: end, rowData: rows})
+		start = end
+	}
+	return partitions, true, nil
+}
+
+// ordinalForLeadingKey returns the ordinal of the first row of |m| whose leading key field is at least |v|.
+func ordinalForLeadingKey(ctx context.Context, m prolly.Map, v int64) (uint64, error) {
+	kd := m.KeyDesc()
+	tb := val.NewTupleBuilder(kd, m.NodeStore())
+	// the remaining key fields are left NULL, which sorts before any other value
+	var field interface{}
+	switch kd.Types[0].Enc {
+	case val.Int8Enc:
+		field = int8(max(min(v, 127), -128))
+	case val.Uint8Enc:
+		field = uint8(max(min(v, 255), 0))
+	case val.Int16Enc:
+		field = int16(max(min(v, 32767), -32768))
+	case val.Uint16Enc:
+		field = uint16(max(min(v, 65535), 0))
+	case val.Int32Enc:
+		field = int32(max(min(v, 2147483647), -2147483648))
+	case val.Uint32Enc:
+		field = uint32(max(min(v, 4294967295), 0))
+	case val.Int64Enc:
+		field = v
+	case val.Uint64Enc:
+		field = uint64(max(v, 0))
+	default:
+		return 0, fmt.Errorf("RANGE partitioning is only supported for integer columns")
+	}
+	if err := tree.PutField(ctx, m.NodeStore(), tb, 0, field); err != nil {
+		return 0, err
+	}
+	key, err := tb.Build(m.Pool())
+	if err != nil {
+		return 0, err
+	}
+	return m.GetOrdinalForKey(ctx, key)
+}
+
+// applyPartitioning sets the partitioning declared by the CREATE TABLE statement being executed on |sch|. Partition
+// options that can't be applied to the table are ignored with a warning, as they were before partitions were stored.
+func applyPartitioning(ctx *sql.Context, sch schema.Schema) {
+	p, err := partitioningFromCreateStatement(ctx.Query())
+	if err == nil && p != nil {
+		err = p.Validate(sch)
+	}
+	if err != nil {
+		ctx.Warn(1105, "partition options ignored: %s", err.Error())
+		return
+	}
+	sch.SetPartitioning(p)
+}
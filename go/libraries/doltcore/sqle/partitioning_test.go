@@ -0,0 +1,96 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+)
+
+func TestPartitioningFromCreateStatement(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected *schema.Partitioning
+		err      bool
+	}{
+		{
+			name:  "no partitions",
+			query: "create table t (pk int primary key, v varchar(20) default 'partition by')",
+		},
+		{
+			name: "range",
+			query: "create table t (pk int primary key, v int) partition by range (pk) (" +
+				"partition p0 values less than (100), partition `p 1` values less than (200) comment = 'x', partition pmax values less than maxvalue)",
+			expected: &schema.Partitioning{
+				Method: schema.PartitionMethodRange,
+				Column: "pk",
+				Partitions: []schema.PartitionDefinition{
+					{Name: "p0", LessThan: "100"},
+					{Name: "p 1", LessThan: "200"},
+					{Name: "pmax", LessThan: "MAXVALUE"},
+				},
+			},
+		},
+		{
+			name:  "range columns in a version comment",
+			query: "CREATE TABLE `t` (`pk` int NOT NULL, PRIMARY KEY (`pk`)) /*!50500 PARTITION BY RANGE  COLUMNS(`pk`)\n(PARTITION p0 VALUES LESS THAN (-5) ENGINE = InnoDB) */",
+			expected: &schema.Partitioning{
+				Method:     schema.PartitionMethodRange,
+				Column:     "pk",
+				Partitions: []schema.PartitionDefinition{{Name: "p0", LessThan: "-5"}},
+			},
+		},
+		{
+			name:     "key",
+			query:    "create table t (pk int primary key) partition by linear key algorithm=2 (pk) partitions 4",
+			expected: &schema.Partitioning{Method: schema.PartitionMethodKey, Column: "pk", NumPartitions: 4},
+		},
+		{
+			name:  "hash is ignored",
+			query: "create table t (pk int primary key) partition by hash (pk) partitions 4",
+		},
+		{
+			name:  "window functions are ignored",
+			query: "create table t as select row_number() over (partition by a) from u",
+		},
+		{
+			name:  "multiple columns",
+			query: "create table t (a int, b int, primary key (a, b)) partition by key (a, b)",
+			err:   true,
+		},
+		{
+			name:  "non-integer bound",
+			query: "create table t (pk int primary key) partition by range (pk) (partition p0 values less than ('a'))",
+			err:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := partitioningFromCreateStatement(test.query)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, p)
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package planbaseline checks the plans of queries against the plans pinned for their digests in the
+// dolt_plan_baselines system table, so that a plan regression after an upgrade or a statistics update is detected,
+// or prevented for enforced baselines.
+package planbaseline
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/analyzer"
+	"github.com/dolthub/go-mysql-server/sql/plan"
+	"github.com/dolthub/go-mysql-server/sql/transform"
+	"gopkg.in/src-d/go-errors.v1"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/querylog"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// CheckRuleId is the id of the analyzer rule added by AddCheckRule.
+const CheckRuleId analyzer.RuleId = -1
+
+// ErrPlanBaselineMismatch is returned for a query whose plan doesn't match its enforced baseline.
+var ErrPlanBaselineMismatch = errors.NewKind("the plan of this query doesn't match its enforced baseline %s in dolt_plan_baselines:\nbaseline:\n%s\ncurrent:\n%s")
+
+// Baseline is a row of the dolt_plan_baselines system table.
+type Baseline struct {
+	// Digest is the digest of the queries the baseline applies to, as returned by querylog.Digest.
+	Digest string
+	// DigestText is the normalized text of the queries the baseline applies to, as returned by querylog.Normalize.
+	DigestText string
+	// Plan is the shape of the pinned plan, as returned by Shape.
+	Plan string
+	// Enforced is whether queries whose plan doesn't match the baseline fail, rather than raising a warning.
+	Enforced bool
+}
+
+// NewBaseline returns the baseline pinning the plan |n| for the digest of |query|.
+func NewBaseline(query string, n sql.Node, enforced bool) Baseline {
+	digestText := querylog.Normalize(query)
+	return Baseline{
+		Digest:     querylog.Digest(digestText),
+		DigestText: digestText,
+		Plan:       Shape(n),
+		Enforced:   enforced,
+	}
+}
+
+// AddCheckRule adds the rule which checks the plans of queries against their baselines to |a|. The rule runs after
+// every other rule, so it sees the final plan.
+func AddCheckRule(a *analyzer.Analyzer) {
+	for _, b := range a.Batches {
+		if b.Desc == "after-all" {
+			b.Rules = append(b.Rules, analyzer.Rule{Id: CheckRuleId, Apply: checkBaseline})
+		}
+	}
+}
+
+// checkBaseline checks the plan of the query being analyzed against the baseline for its digest in the
+// dolt_plan_baselines table of the current database, if there is one. A mismatch raises a warning, or fails the query
+// if the baseline is enforced.
+func checkBaseline(ctx *sql.Context, _ *analyzer.Analyzer, n sql.Node, scope *plan.Scope, _ analyzer.RuleSelector, _ *sql.QueryFlags) (sql.Node, transform.TreeIdentity, error) {
+	// subqueries and other nested plans are checked as part of the query's plan
+	if !scope.IsEmpty() || ctx.Query() == "" {
+		return n, transform.SameTree, nil
+	}
+	baselines, err := currentBaselines(ctx)
+	if err != nil || len(baselines) == 0 {
+		return n, transform.SameTree, err
+	}
+	b, ok := baselines[querylog.Digest(querylog.Normalize(ctx.Query()))]
+	if !ok {
+		return n, transform.SameTree, nil
+	}
+	if shape := Shape(n); shape != b.Plan {
+		if b.Enforced {
+			return nil, transform.SameTree, ErrPlanBaselineMismatch.New(b.Digest, b.Plan, shape)
+		}
+		ctx.Warn(1105, "the plan of this query doesn't match its baseline %s in dolt_plan_baselines", b.Digest)
+	}
+	return n, transform.SameTree, nil
+}
+
+// baselineCache holds the baselines of recently read dolt_plan_baselines tables, by table hash, so that they're only
+// read once rather than for every query.
+var baselineCache = struct {
+	mu     sync.Mutex
+	tables map[hash.Hash]map[string]Baseline
+}{tables: make(map[hash.Hash]map[string]Baseline)}
+
+// maxCachedTables is the number of versions of dolt_plan_baselines kept in baselineCache.
+const maxCachedTables = 16
+
+// currentBaselines returns the baselines in the working set of the current database, by digest.
+func currentBaselines(ctx *sql.Context) (map[string]Baseline, error) {
+	dbName := ctx.GetCurrentDatabase()
+	dSess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok || dbName == "" {
+		return nil, nil
+	}
+	roots, ok := dSess.GetRoots(ctx, dbName)
+	if !ok {
+		return nil, nil
+	}
+	tName := doltdb.TableName{Name: doltdb.PlanBaselinesTableName}
+	h, ok, err := roots.Working.GetTableHash(ctx, tName)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	baselineCache.mu.Lock()
+	baselines, ok := baselineCache.tables[h]
+	baselineCache.mu.Unlock()
+	if ok {
+		return baselines, nil
+	}
+
+	tbl, ok, err := roots.Working.GetTable(ctx, tName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if baselines, err = readBaselines(ctx, tbl); err != nil {
+		return nil, err
+	}
+
+	baselineCache.mu.Lock()
+	defer baselineCache.mu.Unlock()
+	if len(baselineCache.tables) >= maxCachedTables {
+		baselineCache.tables = make(map[hash.Hash]map[string]Baseline)
+	}
+	baselineCache.tables[h] = baselines
+	return baselines, nil
+}
+
+// readBaselines returns the baselines stored in the dolt_plan_baselines table |tbl|, by digest.
+func readBaselines(ctx *sql.Context, tbl *doltdb.Table) (map[string]Baseline, error) {
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := durable.ProllyMapFromIndex(rowData)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := sql.RowIterToRows(ctx, index.NewProllyRowIterForMap(sch, m, iter, sch.GetAllCols().Tags))
+	if err != nil {
+		return nil, err
+	}
+
+	baselines := make(map[string]Baseline, len(rows))
+	for _, row := range rows {
+		if len(row) != 4 {
+			return nil, fmt.Errorf("unexpected schema for %s", doltdb.PlanBaselinesTableName)
+		}
+		var b Baseline
+		b.Digest, _ = row[0].(string)
+		b.DigestText, _ = row[1].(string)
+		b.Plan, _ = row[2].(string)
+		if b.Enforced, err = sql.ConvertToBool(ctx, row[3]); err != nil {
+			return nil, err
+		}
+		baselines[b.Digest] = b
+	}
+	return baselines, nil
+}
+
+// Shape returns the shape of the plan |n|: its operators, join types, and the tables and indexes it reads, one per
+// line and indented by depth. Unlike the description of the plan, it has no literal values or estimates, so it's the
+// same for queries with the same digest unless a different plan is chosen for them.
+func Shape(n sql.Node) string {
+	var sb strings.Builder
+	writeShape(&sb, n, 0)
+	return sb.String()
+}
+
+func writeShape(sb *strings.Builder, n sql.Node, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(nodeLabel(n))
+	sb.WriteByte('\n')
+	if ne, ok := n.(sql.Expressioner); ok {
+		for _, e := range ne.Expressions() {
+			transform.InspectExpr(e, func(e sql.Expression) bool {
+				if sq, ok := e.(*plan.Subquery); ok && sq.Query != nil {
+					writeShape(sb, sq.Query, depth+1)
+				}
+				return false
+			})
+		}
+	}
+	for _, child := range n.Children() {
+		writeShape(sb, child, depth+1)
+	}
+}
+
+func nodeLabel(n sql.Node) string {
+	switch n := n.(type) {
+	case *plan.JoinNode:
+		return n.Op.String()
+	case *plan.IndexedTableAccess:
+		return fmt.Sprintf("IndexedTableAccess(%s, %s)", n.Name(), n.Index().ID())
+	case *plan.ResolvedTable:
+		return fmt.Sprintf("Table(%s)", n.Name())
+	case *plan.TableAlias:
+		return fmt.Sprintf("TableAlias(%s)", n.Name())
+	case *plan.SubqueryAlias:
+		return fmt.Sprintf("SubqueryAlias(%s)", n.Name())
+	}
+	name := reflect.TypeOf(n).String()
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profiler continuously profiles sql-server. While the dolt_profile_interval system variable is non-zero, the
+// server periodically captures pprof profiles of itself, of the types in dolt_profile_types, and writes them to the
+// directory in dolt_profile_dir and pushes them to the Pyroscope compatible server at dolt_profile_endpoint. The
+// system variables are read before each capture, so profiling can be started, stopped and reconfigured on a running
+// server.
+package profiler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// CPUProfile is the type of a cpu profile. Every other type is the name of a profile of runtime/pprof.
+const CPUProfile = "cpu"
+
+// pollInterval is how often the options are read, and so how quickly a change to them takes effect.
+const pollInterval = time.Second
+
+// Options configures the profiles a Profiler captures.
+type Options struct {
+	// Interval is the time between captures, or 0 to disable profiling.
+	Interval time.Duration
+	// Types are the types of profile captured.
+	Types []string
+	// CPUDuration is how long each cpu profile samples for.
+	CPUDuration time.Duration
+	// Dir is the directory profiles are written to, or "" to not write them to files.
+	Dir string
+	// Endpoint is the URL of the Pyroscope compatible server profiles are pushed to, or "" to not push them.
+	Endpoint string
+	// Retention is the number of profiles of each type kept in Dir, or 0 to keep all of them.
+	Retention int
+}
+
+// GlobalOptions returns the options set by the dolt_profile_* system variables.
+func GlobalOptions() Options {
+	opts := Options{
+		Interval:    time.Duration(globalInt(dsess.ProfileInterval)) * time.Second,
+		CPUDuration: time.Duration(globalInt(dsess.ProfileCPUSeconds)) * time.Second,
+		Dir:         globalString(dsess.ProfileDir),
+		Endpoint:    globalString(dsess.ProfileEndpoint),
+		Retention:   int(globalInt(dsess.ProfileRetention)),
+	}
+	for _, t := range strings.Split(globalString(dsess.ProfileTypes), ",") {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			opts.Types = append(opts.Types, t)
+		}
+	}
+	return opts
+}
+
+func globalInt(name string) int64 {
+	_, val, ok := sql.SystemVariables.GetGlobal(name)
+	if !ok {
+		return 0
+	}
+	i, _ := val.(int64)
+	return i
+}
+
+func globalString(name string) string {
+	_, val, ok := sql.SystemVariables.GetGlobal(name)
+	if !ok {
+		return ""
+	}
+	s, _ := val.(string)
+	return s
+}
+
+// Profile is a captured profile, in the gzipped protobuf format of pprof.
+type Profile struct {
+	Type  string
+	Start time.Time
+	End   time.Time
+	Data  []byte
+}
+
+// Capture captures a profile of type |typ|. A cpu profile samples for |cpuDuration|, or until |ctx| is done, and
+// fails if cpu profiling is already running, such as when dolt was started with --prof cpu.
+func Capture(ctx context.Context, typ string, cpuDuration time.Duration) (Profile, error) {
+	p := Profile{Type: typ, Start: time.Now()}
+	var buf bytes.Buffer
+	if typ == CPUProfile {
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return Profile{}, err
+		}
+		timer := time.NewTimer(cpuDuration)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+		pprof.StopCPUProfile()
+	} else {
+		prof := pprof.Lookup(typ)
+		if prof == nil {
+			return Profile{}, fmt.Errorf("unknown profile type: %s", typ)
+		}
+		if err := prof.WriteTo(&buf, 0); err != nil {
+			return Profile{}, err
+		}
+	}
+	p.End = time.Now()
+	p.Data = buf.Bytes()
+	return p, nil
+}
+
+// Sink is a destination of captured profiles.
+type Sink interface {
+	Write(ctx context.Context, p Profile) error
+}
+
+// Profiler captures profiles, as configured by the options it reads before each capture, and writes them to its
+// sinks.
+type Profiler struct {
+	options  func() Options
+	appName  string
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mu       sync.Mutex
+	lastWarn string
+}
+
+// NewProfiler returns a Profiler configured by |options|, which pushes profiles to Pyroscope under the application
+// name |appName|.
+func NewProfiler(options func() Options, appName string) *Profiler {
+	return &Profiler{options: options, appName: appName, stop: make(chan struct{})}
+}
+
+// Run captures profiles until |ctx| is done or Stop is called. A capture starts once the interval of the current
+// options has passed since the last one started.
+func (p *Profiler) Run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	var last time.Time
+	for {
+		if opts := p.options(); opts.Interval > 0 && time.Since(last) >= opts.Interval {
+			last = time.Now()
+			p.CaptureAll(ctx, opts)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop stops Run, interrupting any cpu profile being captured.
+func (p *Profiler) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+}
+
+// CaptureAll captures a profile of each type of |opts| and writes it to the sinks of |opts|.
+func (p *Profiler) CaptureAll(ctx context.Context, opts Options) {
+	sinks := p.sinks(opts)
+	if len(sinks) == 0 {
+		p.warnOnce("profiling is enabled by dolt_profile_interval, but neither dolt_profile_dir nor dolt_profile_endpoint is set")
+		return
+	}
+	for _, typ := range opts.Types {
+		if ctx.Err() != nil {
+			return
+		}
+		prof, err := Capture(ctx, typ, opts.CPUDuration)
+		if err != nil {
+			p.warnOnce(fmt.Sprintf("unable to capture %s profile: %v", typ, err))
+			continue
+		}
+		for _, s := range sinks {
+			if err := s.Write(ctx, prof); err != nil {
+				logrus.Warnf("unable to write %s profile: %v", typ, err)
+			}
+		}
+	}
+}
+
+func (p *Profiler) sinks(opts Options) []Sink {
+	var sinks []Sink
+	if opts.Dir != "" {
+		sinks = append(sinks, NewDirSink(opts.Dir, opts.Retention))
+	}
+	if opts.Endpoint != "" {
+		sinks = append(sinks, NewPyroscopeSink(opts.Endpoint, p.appName))
+	}
+	return sinks
+}
+
+// warnOnce logs |msg| unless it was the last warning logged, so that a misconfiguration is logged once rather than on
+// every capture.
+func (p *Profiler) warnOnce(msg string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if msg != p.lastWarn {
+		p.lastWarn = msg
+		logrus.Warn(msg)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gzipMagic starts every profile, as pprof profiles are gzipped protobufs
+var gzipMagic = []byte{0x1f, 0x8b}
+
+func TestCapture(t *testing.T) {
+	ctx := context.Background()
+	for _, typ := range []string{CPUProfile, "heap", "goroutine"} {
+		t.Run(typ, func(t *testing.T) {
+			p, err := Capture(ctx, typ, 10*time.Millisecond)
+			require.NoError(t, err)
+			assert.Equal(t, typ, p.Type)
+			assert.False(t, p.End.Before(p.Start))
+			require.Greater(t, len(p.Data), 2)
+			assert.Equal(t, gzipMagic, p.Data[:2])
+		})
+	}
+
+	_, err := Capture(ctx, "flamegraph", time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestDirSink(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewDirSink(dir, 2)
+	start := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, sink.Write(context.Background(), Profile{Type: "heap", Start: start.Add(time.Duration(i) * time.Second), Data: []byte{byte(i)}}))
+	}
+	require.NoError(t, sink.Write(context.Background(), Profile{Type: "goroutine", Start: start, Data: []byte{9}}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{
+		"goroutine-20250102T150405.000Z.pb.gz",
+		"heap-20250102T150406.000Z.pb.gz",
+		"heap-20250102T150407.000Z.pb.gz",
+	}, names)
+}
+
+func TestPyroscopeSink(t *testing.T) {
+	var query map[string]string
+	var profile []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ingest", r.URL.Path)
+		query = make(map[string]string)
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		f, _, err := r.FormFile("profile")
+		if assert.NoError(t, err) {
+			profile, _ = io.ReadAll(f)
+		}
+	}))
+	defer srv.Close()
+
+	start := time.Unix(1700000000, 0)
+	sink := NewPyroscopeSink(srv.URL+"/", "dolt-sql-server")
+	err := sink.Write(context.Background(), Profile{Type: CPUProfile, Start: start, End: start.Add(10 * time.Second), Data: []byte("pprof")})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"name":       "dolt-sql-server",
+		"from":       "1700000000",
+		"until":      "1700000010",
+		"format":     "pprof",
+		"spyName":    "gospy",
+		"sampleRate": "100",
+	}, query)
+	assert.Equal(t, []byte("pprof"), profile)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad profile", http.StatusBadRequest)
+	}))
+	defer failing.Close()
+	err = NewPyroscopeSink(failing.URL, "dolt").Write(context.Background(), Profile{Type: "heap", Data: []byte("pprof")})
+	assert.ErrorContains(t, err, "bad profile")
+}
+
+func TestProfilerRun(t *testing.T) {
+	dir := t.TempDir()
+	p := NewProfiler(func() Options {
+		return Options{Interval: time.Hour, Types: []string{"goroutine", "heap"}, Dir: dir}
+	}, "dolt")
+
+	done := make(chan struct{})
+	go func() {
+		p.Run(context.Background())
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		entries, _ := os.ReadDir(dir)
+		return len(entries) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	p.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("profiler did not stop")
+	}
+}
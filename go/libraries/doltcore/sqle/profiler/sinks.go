@@ -0,0 +1,157 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	profileFileExt = ".pb.gz"
+	// profileTimeFormat names profile files by the time their capture started, so that they sort by it
+	profileTimeFormat = "20060102T150405.000Z"
+
+	pyroscopeIngestPath = "/ingest"
+	// pyroscopeCPUSampleRate is the rate, in hertz, runtime/pprof samples cpu profiles at
+	pyroscopeCPUSampleRate = 100
+	pyroscopeTimeout       = 30 * time.Second
+)
+
+// DirSink writes profiles to files in a directory, named by their type and the time their capture started, e.g.
+// heap-20250102T150405.000Z.pb.gz.
+type DirSink struct {
+	dir       string
+	retention int
+}
+
+var _ Sink = DirSink{}
+
+// NewDirSink returns a sink which writes profiles to |dir|, keeping the |retention| most recent profiles of each
+// type, or all of them if |retention| is 0.
+func NewDirSink(dir string, retention int) DirSink {
+	return DirSink{dir: dir, retention: retention}
+}
+
+func (s DirSink) Write(_ context.Context, p Profile) error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return err
+	}
+	name := p.Type + "-" + p.Start.UTC().Format(profileTimeFormat) + profileFileExt
+	if err := os.WriteFile(filepath.Join(s.dir, name), p.Data, 0644); err != nil {
+		return err
+	}
+	return s.prune(p.Type)
+}
+
+// prune removes the oldest profiles of type |typ| beyond the retention of the sink.
+func (s DirSink) prune(typ string) error {
+	if s.retention == 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if !e.IsDir() && strings.HasPrefix(name, typ+"-") && strings.HasSuffix(name, profileFileExt) {
+			names = append(names, name)
+		}
+	}
+	if len(names) <= s.retention {
+		return nil
+	}
+	sort.Strings(names)
+	for _, name := range names[:len(names)-s.retention] {
+		if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// PyroscopeSink pushes profiles to the ingest API of a Pyroscope server, or of an agent which accepts the same API,
+// such as Grafana Alloy.
+type PyroscopeSink struct {
+	endpoint string
+	appName  string
+	client   *http.Client
+}
+
+var _ Sink = PyroscopeSink{}
+
+// NewPyroscopeSink returns a sink which pushes profiles to the Pyroscope server at |endpoint|, under the application
+// name |appName|.
+func NewPyroscopeSink(endpoint, appName string) PyroscopeSink {
+	return PyroscopeSink{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		appName:  appName,
+		client:   &http.Client{Timeout: pyroscopeTimeout},
+	}
+}
+
+func (s PyroscopeSink) Write(ctx context.Context, p Profile) error {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(p.Data); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("name", s.appName)
+	params.Set("from", strconv.FormatInt(p.Start.Unix(), 10))
+	params.Set("until", strconv.FormatInt(p.End.Unix(), 10))
+	params.Set("format", "pprof")
+	params.Set("spyName", "gospy")
+	if p.Type == CPUProfile {
+		params.Set("sampleRate", strconv.Itoa(pyroscopeCPUSampleRate))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+pyroscopeIngestPath+"?"+params.Encode(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("pyroscope ingest returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
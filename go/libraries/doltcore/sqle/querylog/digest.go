@@ -0,0 +1,285 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Normalize returns the digest text of |query|, which is the same for statements which differ only in their literal
+// values, comments, whitespace and the case of their keywords and unquoted identifiers. Literals are replaced by
+// ?, lists of literals such as IN (1, 2, 3) are replaced by (...), and the repeated rows of a VALUES list are
+// replaced by a single row.
+func Normalize(query string) string {
+	toks := collapseLists(tokenize(query))
+	var sb strings.Builder
+	for i, tok := range toks {
+		if i > 0 && spaceBetween(toks[i-1], tok) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(tok.text)
+	}
+	return sb.String()
+}
+
+// Digest returns the digest of the normalized statement |digestText|, as returned by Normalize.
+func Digest(digestText string) string {
+	h := sha256.Sum256([]byte(digestText))
+	return hex.EncodeToString(h[:])
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokQuotedIdent
+	tokLiteral
+	tokPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) is(text string) bool {
+	return t.kind == tokPunct && t.text == text
+}
+
+// operatorChars are the characters which combine into multi-character operators, such as <= and :=.
+const operatorChars = "<>=!|&:"
+
+func tokenize(query string) []token {
+	var toks []token
+	i := 0
+	for i < len(query) {
+		c := query[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '#' || (c == '-' && strings.HasPrefix(query[i:], "--") && (i+2 == len(query) || isSpace(query[i+2]))):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return toks
+			}
+			i += end + 1
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return toks
+			}
+			i += end + 4
+		case c == '\'' || c == '"':
+			i = skipQuoted(query, i)
+			toks = append(toks, token{tokLiteral, "?"})
+		case c == '`':
+			start := i
+			i = skipQuoted(query, i)
+			toks = append(toks, token{tokQuotedIdent, query[start:i]})
+		case isDigit(c) || (c == '.' && i+1 < len(query) && isDigit(query[i+1]) && !followsName(toks)):
+			i = skipNumber(query, i)
+			// a sign before a number is part of the literal when the sign can't be a binary operator
+			if n := len(toks); n > 0 && (toks[n-1].is("-") || toks[n-1].is("+")) && !followsOperand(toks[:n-1]) {
+				toks = toks[:n-1]
+			}
+			toks = append(toks, token{tokLiteral, "?"})
+		case isWordChar(c) || c == '@':
+			start := i
+			i++
+			for i < len(query) && (isWordChar(query[i]) || query[i] == '@') {
+				i++
+			}
+			toks = append(toks, token{tokWord, strings.ToLower(query[start:i])})
+		case strings.IndexByte(operatorChars, c) >= 0:
+			start := i
+			for i < len(query) && strings.IndexByte(operatorChars, query[i]) >= 0 {
+				i++
+			}
+			toks = append(toks, token{tokPunct, query[start:i]})
+		case c == ';':
+			i++
+			// a trailing ; doesn't change the statement
+			if strings.TrimSpace(query[i:]) != "" {
+				toks = append(toks, token{tokPunct, ";"})
+			}
+		default:
+			toks = append(toks, token{tokPunct, query[i : i+1]})
+			i++
+		}
+	}
+	return toks
+}
+
+// skipQuoted returns the index after the quoted string starting at |start|. The quote is escaped by doubling it, or,
+// other than for identifiers, with a backslash.
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+	i := start + 1
+	for i < len(query) {
+		switch {
+		case query[i] == '\\' && quote != '`':
+			i += 2
+		case query[i] == quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i += 2
+			} else {
+				return i + 1
+			}
+		default:
+			i++
+		}
+	}
+	return len(query)
+}
+
+// skipNumber returns the index after the number starting at |start|, including hex literals and exponents.
+func skipNumber(query string, start int) int {
+	i := start
+	if strings.HasPrefix(query[i:], "0x") || strings.HasPrefix(query[i:], "0X") {
+		i += 2
+		for i < len(query) && isHexDigit(query[i]) {
+			i++
+		}
+		return i
+	}
+	for i < len(query) && (isDigit(query[i]) || query[i] == '.') {
+		i++
+	}
+	if i < len(query) && (query[i] == 'e' || query[i] == 'E') {
+		j := i + 1
+		if j < len(query) && (query[j] == '+' || query[j] == '-') {
+			j++
+		}
+		if j < len(query) && isDigit(query[j]) {
+			i = j
+			for i < len(query) && isDigit(query[i]) {
+				i++
+			}
+		}
+	}
+	// digits followed by letters, such as 1a, are an identifier
+	for i < len(query) && isWordChar(query[i]) {
+		i++
+	}
+	return i
+}
+
+// followsOperand returns whether the last of |toks| ends an operand, so that a following sign is a binary operator.
+func followsOperand(toks []token) bool {
+	if len(toks) == 0 {
+		return false
+	}
+	last := toks[len(toks)-1]
+	switch last.kind {
+	case tokLiteral, tokQuotedIdent:
+		return true
+	case tokWord:
+		return !isKeywordBeforeOperand(last.text)
+	default:
+		return last.is(")") || last.is("?")
+	}
+}
+
+// followsName returns whether the last of |toks| is a name, so that a following . qualifies it.
+func followsName(toks []token) bool {
+	if len(toks) == 0 {
+		return false
+	}
+	last := toks[len(toks)-1]
+	return last.kind == tokQuotedIdent || (last.kind == tokWord && !isKeywordBeforeOperand(last.text))
+}
+
+// isKeywordBeforeOperand returns whether |word| is a keyword which is followed by an operand, rather than being one.
+func isKeywordBeforeOperand(word string) bool {
+	switch word {
+	case "select", "where", "and", "or", "not", "on", "set", "values", "value", "in", "is", "like", "between",
+		"when", "then", "else", "case", "limit", "offset", "by", "having", "return", "div", "mod", "xor", "interval":
+		return true
+	}
+	return false
+}
+
+// collapseLists replaces the lists of literals in |toks| with (...), and removes the repeated (...) rows of a VALUES
+// list.
+func collapseLists(toks []token) []token {
+	ret := make([]token, 0, len(toks))
+	for i := 0; i < len(toks); i++ {
+		if toks[i].is("(") {
+			if end := literalListEnd(toks, i); end > 0 {
+				// drop a repeated row of a VALUES list, along with the comma separating it from the one before
+				if n := len(ret); n >= 5 && ret[n-1].is(",") && ret[n-2].is(")") && ret[n-3].text == "..." && ret[n-4].is("(") &&
+					(ret[n-5].text == "values" || ret[n-5].text == "value") {
+					ret = ret[:n-1]
+				} else {
+					ret = append(ret, toks[i], token{tokPunct, "..."}, toks[end])
+				}
+				i = end
+				continue
+			}
+		}
+		ret = append(ret, toks[i])
+	}
+	return ret
+}
+
+// literalListEnd returns the index of the ) closing the list of literals opened by the ( at |start| in |toks|, or 0
+// if it doesn't open a list of only literals.
+func literalListEnd(toks []token, start int) int {
+	for i := start + 1; i < len(toks); i += 2 {
+		if toks[i].kind != tokLiteral && !toks[i].is("?") {
+			return 0
+		}
+		if i+1 >= len(toks) {
+			return 0
+		}
+		if toks[i+1].is(")") {
+			return i + 1
+		}
+		if !toks[i+1].is(",") {
+			return 0
+		}
+	}
+	return 0
+}
+
+// spaceBetween returns whether the digest text has a space between |prev| and |next|.
+func spaceBetween(prev, next token) bool {
+	switch {
+	case prev.is("(") || prev.is(".") || next.is(")") || next.is(",") || next.is(".") || next.is(";"):
+		return false
+	case next.is("("):
+		// function calls have no space before their parentheses
+		return !followsName([]token{prev})
+	}
+	return true
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
@@ -0,0 +1,345 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package querylog profiles the workload of sql-server. When the dolt_query_log system variable is enabled, each
+// statement is recorded with its latency and the rows it examined, sent and affected. The most recent statements are
+// kept in memory and exposed through the dolt_query_log system table, and every statement is aggregated by its digest,
+// a fingerprint of the statement with its literal values removed, into the dolt_query_summary system table, much like
+// the events_statements_summary_by_digest table of MySQL's performance_schema.
+package querylog
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+)
+
+// Statement is a single statement recorded in the query log.
+type Statement struct {
+	Time         time.Time
+	ConnectionID uint32
+	User         string
+	Database     string
+	Query        string
+	// Digest is the digest of Query, and is set by Log.Record.
+	Digest       string
+	Duration     time.Duration
+	RowsExamined int64
+	RowsSent     int64
+	RowsAffected int64
+	Error        string
+}
+
+// Summary is the aggregate of the statements with the same digest run against the same database.
+type Summary struct {
+	Database string
+	// Digest and DigestText are empty for the summary of the statements which were recorded after the log held its
+	// most digests.
+	Digest       string
+	DigestText   string
+	QuerySample  string
+	Count        int64
+	Errors       int64
+	TotalLatency time.Duration
+	MinLatency   time.Duration
+	MaxLatency   time.Duration
+	P50Latency   time.Duration
+	P95Latency   time.Duration
+	P99Latency   time.Duration
+	RowsExamined int64
+	RowsSent     int64
+	RowsAffected int64
+	FirstSeen    time.Time
+	LastSeen     time.Time
+}
+
+// Options configures a Log.
+type Options struct {
+	// Size is the number of recent statements kept.
+	Size int
+	// MaxDigests is the number of digests aggregated. The statements of any further digests are aggregated together.
+	MaxDigests int
+}
+
+type digestKey struct {
+	database string
+	digest   string
+}
+
+// Log records statements and aggregates them by digest. It is safe for concurrent use.
+type Log struct {
+	mu         sync.Mutex
+	buf        []Statement
+	next       int
+	count      int
+	maxDigests int
+	digests    map[digestKey]*aggregate
+	overflow   map[string]*aggregate
+}
+
+// NewLog returns a Log configured by |opts|.
+func NewLog(opts Options) *Log {
+	l := &Log{}
+	l.SetLimits(opts)
+	l.Reset()
+	return l
+}
+
+// SetLimits changes the number of statements and digests kept by |l|. If it holds more statements than |opts|
+// allows, the oldest are discarded. Existing digests are kept even if there are more of them than |opts| allows.
+func (l *Log) SetLimits(opts Options) {
+	size := max(opts.Size, 1)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxDigests = opts.MaxDigests
+	if size == len(l.buf) {
+		return
+	}
+	stmts := l.statements()
+	if len(stmts) > size {
+		stmts = stmts[len(stmts)-size:]
+	}
+	l.buf = make([]Statement, size)
+	copy(l.buf, stmts)
+	l.count = len(stmts)
+	l.next = l.count % size
+}
+
+// Reset discards all the statements and digests |l| holds.
+func (l *Log) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	clear(l.buf)
+	l.next, l.count = 0, 0
+	l.digests = make(map[digestKey]*aggregate)
+	l.overflow = make(map[string]*aggregate)
+}
+
+// Record records |s|, setting its time to now if it isn't set, and its digest.
+func (l *Log) Record(s Statement) {
+	if s.Time.IsZero() {
+		s.Time = time.Now().UTC()
+	}
+	text := Normalize(s.Query)
+	s.Digest = Digest(text)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buf[l.next] = s
+	l.next = (l.next + 1) % len(l.buf)
+	if l.count < len(l.buf) {
+		l.count++
+	}
+
+	key := digestKey{database: s.Database, digest: s.Digest}
+	agg, ok := l.digests[key]
+	if !ok {
+		if len(l.digests) < l.maxDigests {
+			agg = &aggregate{text: text, sample: s.Query}
+			l.digests[key] = agg
+		} else if agg, ok = l.overflow[s.Database]; !ok {
+			agg = &aggregate{}
+			l.overflow[s.Database] = agg
+		}
+	}
+	agg.add(s)
+}
+
+// Statements returns the most recent statements, oldest first.
+func (l *Log) Statements() []Statement {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.statements()
+}
+
+func (l *Log) statements() []Statement {
+	ret := make([]Statement, 0, l.count)
+	if l.count == 0 {
+		return ret
+	}
+	start := (l.next - l.count + len(l.buf)) % len(l.buf)
+	for i := 0; i < l.count; i++ {
+		ret = append(ret, l.buf[(start+i)%len(l.buf)])
+	}
+	return ret
+}
+
+// Summaries returns the aggregate of each digest, ordered by their total latency, most first.
+func (l *Log) Summaries() []Summary {
+	l.mu.Lock()
+	ret := make([]Summary, 0, len(l.digests)+len(l.overflow))
+	for key, agg := range l.digests {
+		ret = append(ret, agg.summary(key.database, key.digest))
+	}
+	for db, agg := range l.overflow {
+		ret = append(ret, agg.summary(db, ""))
+	}
+	l.mu.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].TotalLatency != ret[j].TotalLatency {
+			return ret[i].TotalLatency > ret[j].TotalLatency
+		}
+		if ret[i].Database != ret[j].Database {
+			return ret[i].Database < ret[j].Database
+		}
+		return ret[i].Digest < ret[j].Digest
+	})
+	return ret
+}
+
+// aggregate accumulates the statements of a digest.
+type aggregate struct {
+	text         string
+	sample       string
+	count        int64
+	errors       int64
+	total        time.Duration
+	min          time.Duration
+	max          time.Duration
+	latencies    latencyHistogram
+	rowsExamined int64
+	rowsSent     int64
+	rowsAffected int64
+	firstSeen    time.Time
+	lastSeen     time.Time
+}
+
+func (a *aggregate) add(s Statement) {
+	if a.count == 0 {
+		a.min, a.firstSeen = s.Duration, s.Time
+	}
+	a.count++
+	if s.Error != "" {
+		a.errors++
+	}
+	a.total += s.Duration
+	a.min = min(a.min, s.Duration)
+	a.max = max(a.max, s.Duration)
+	a.latencies.add(s.Duration)
+	a.rowsExamined += s.RowsExamined
+	a.rowsSent += s.RowsSent
+	a.rowsAffected += s.RowsAffected
+	a.lastSeen = s.Time
+}
+
+func (a *aggregate) summary(database, digest string) Summary {
+	return Summary{
+		Database:     database,
+		Digest:       digest,
+		DigestText:   a.text,
+		QuerySample:  a.sample,
+		Count:        a.count,
+		Errors:       a.errors,
+		TotalLatency: a.total,
+		MinLatency:   a.min,
+		MaxLatency:   a.max,
+		P50Latency:   a.latencies.percentile(0.50, a.count, a.min, a.max),
+		P95Latency:   a.latencies.percentile(0.95, a.count, a.min, a.max),
+		P99Latency:   a.latencies.percentile(0.99, a.count, a.min, a.max),
+		RowsExamined: a.rowsExamined,
+		RowsSent:     a.rowsSent,
+		RowsAffected: a.rowsAffected,
+		FirstSeen:    a.firstSeen,
+		LastSeen:     a.lastSeen,
+	}
+}
+
+const (
+	// histogramBuckets is the number of buckets of a latencyHistogram. Bucket i counts the latencies of up to
+	// histogramBase * histogramGrowth^i, so the buckets cover latencies of up to about 6 hours, each within 10%.
+	histogramBuckets = 250
+	histogramBase    = time.Microsecond
+	histogramGrowth  = 1.1
+)
+
+// latencyHistogram counts latencies in exponentially sized buckets, from which percentiles are estimated.
+type latencyHistogram [histogramBuckets]uint32
+
+func (h *latencyHistogram) add(d time.Duration) {
+	i := 0
+	if d > histogramBase {
+		i = int(math.Ceil(math.Log(float64(d)/float64(histogramBase)) / math.Log(histogramGrowth)))
+	}
+	h[min(i, histogramBuckets-1)]++
+}
+
+// percentile returns an estimate of the |p| percentile of the |count| latencies in |h|, which are between |lo| and
+// |hi|. The estimate is the upper bound of the bucket the percentile falls in.
+func (h *latencyHistogram) percentile(p float64, count int64, lo, hi time.Duration) time.Duration {
+	rank := uint64(math.Ceil(p * float64(count)))
+	var seen uint64
+	for i, n := range h {
+		seen += uint64(n)
+		if seen >= rank && n > 0 {
+			bound := time.Duration(float64(histogramBase) * math.Pow(histogramGrowth, float64(i)))
+			return max(lo, min(bound, hi))
+		}
+	}
+	return hi
+}
+
+var current atomic.Pointer[Log]
+
+// SetCurrent makes |l| the query log of this process, which statements are recorded to while the dolt_query_log
+// system variable is enabled, and which is exposed through the dolt_query_log and dolt_query_summary system tables.
+// |l| may be nil to disable it.
+func SetCurrent(l *Log) {
+	current.Store(l)
+}
+
+// Current returns the query log of this process, or nil if there isn't one.
+func Current() *Log {
+	return current.Load()
+}
+
+// Enabled returns the query log of this process if statements are being recorded to it, or nil if they aren't.
+func Enabled() *Log {
+	l := Current()
+	if l == nil {
+		return nil
+	}
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.QueryLog)
+	if !ok {
+		return nil
+	}
+	if on, _ := val.(int8); on != 1 {
+		return nil
+	}
+	return l
+}
+
+// GlobalLimits returns the limits on the query log set by the dolt_query_log_size and dolt_query_log_max_digests
+// system variables.
+func GlobalLimits() Options {
+	return Options{
+		Size:       globalInt(dsess.QueryLogSize),
+		MaxDigests: globalInt(dsess.QueryLogMaxDigests),
+	}
+}
+
+func globalInt(name string) int {
+	_, val, ok := sql.SystemVariables.GetGlobal(name)
+	if !ok {
+		return 0
+	}
+	i, _ := val.(int64)
+	return int(i)
+}
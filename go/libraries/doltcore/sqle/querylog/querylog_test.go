@@ -0,0 +1,121 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package querylog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT * FROM t WHERE id = 1", "select * from t where id = ?"},
+		{"select *\n  from T\twhere ID = 'abc' ;", "select * from t where id = ?"},
+		{"SELECT a, b FROM t WHERE c > -1.5e3 AND d = \"x\\\"y\"", "select a, b from t where c > ? and d = ?"},
+		{"select a-1, b - 2 from t", "select a - ?, b - ? from t"},
+		{"select count(*) from `My Table` where x in (1, 2, 3)", "select count(*) from `My Table` where x in (...)"},
+		{"select * from t where x in (?, ?) and y = ?", "select * from t where x in (...) and y = ?"},
+		{"insert into t (a, b) values (1, 'a'), (2, 'b'), (3, 'c')", "insert into t(a, b) values (...)"},
+		{"insert into t values (1,2)", "insert into t values (...)"},
+		{"select concat(1, 2), (3)", "select concat(...), (...)"},
+		{"select /* comment */ 1 -- trailing\n", "select ?"},
+		{"# comment\nselect t.a from db.t where t.b <=> 0x1f", "select t.a from db.t where t.b <=> ?"},
+		{"set @@session.x = 10; select @v", "set @@session.x = ?; select @v"},
+		{"call dolt_commit('-am', 'it''s done')", "call dolt_commit(...)"},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			assert.Equal(t, test.expected, Normalize(test.query))
+		})
+	}
+
+	assert.Equal(t, Digest(Normalize("SELECT 1")), Digest(Normalize("select 2;")))
+	assert.NotEqual(t, Digest(Normalize("select 1")), Digest(Normalize("select 1 from dual")))
+	assert.Len(t, Digest("select ?"), 64)
+}
+
+func TestLogStatements(t *testing.T) {
+	l := NewLog(Options{Size: 3, MaxDigests: 10})
+	for _, q := range []string{"select 1", "select 2", "select 3", "select 4"} {
+		l.Record(Statement{Database: "db", Query: q})
+	}
+	stmts := l.Statements()
+	require.Len(t, stmts, 3)
+	assert.Equal(t, "select 2", stmts[0].Query)
+	assert.Equal(t, "select 4", stmts[2].Query)
+	assert.Equal(t, Digest("select ?"), stmts[0].Digest)
+	assert.False(t, stmts[0].Time.IsZero())
+
+	// shrinking keeps the most recent statements
+	l.SetLimits(Options{Size: 2, MaxDigests: 10})
+	stmts = l.Statements()
+	require.Len(t, stmts, 2)
+	assert.Equal(t, "select 3", stmts[0].Query)
+	l.Record(Statement{Database: "db", Query: "select 5"})
+	stmts = l.Statements()
+	assert.Equal(t, []string{"select 4", "select 5"}, []string{stmts[0].Query, stmts[1].Query})
+
+	l.Reset()
+	assert.Empty(t, l.Statements())
+	assert.Empty(t, l.Summaries())
+}
+
+func TestLogSummaries(t *testing.T) {
+	l := NewLog(Options{Size: 10, MaxDigests: 2})
+	for i := 1; i <= 100; i++ {
+		l.Record(Statement{Database: "db", Query: "select * from t where id = 1", Duration: time.Duration(i) * time.Millisecond,
+			RowsExamined: 10, RowsSent: 1})
+	}
+	l.Record(Statement{Database: "db", Query: "insert into t values (1)", Duration: time.Second, RowsAffected: 1,
+		Error: "duplicate primary key"})
+	// the log only aggregates 2 digests, so further digests are aggregated together
+	l.Record(Statement{Database: "db", Query: "delete from t", Duration: time.Millisecond})
+	l.Record(Statement{Database: "db", Query: "update t set a = 1", Duration: time.Millisecond})
+
+	sums := l.Summaries()
+	require.Len(t, sums, 3)
+
+	sel := sums[0]
+	assert.Equal(t, Digest("select * from t where id = ?"), sel.Digest)
+	assert.Equal(t, "select * from t where id = ?", sel.DigestText)
+	assert.Equal(t, "select * from t where id = 1", sel.QuerySample)
+	assert.Equal(t, int64(100), sel.Count)
+	assert.Equal(t, int64(0), sel.Errors)
+	assert.Equal(t, 5050*time.Millisecond, sel.TotalLatency)
+	assert.Equal(t, time.Millisecond, sel.MinLatency)
+	assert.Equal(t, 100*time.Millisecond, sel.MaxLatency)
+	assert.InEpsilon(t, float64(50*time.Millisecond), float64(sel.P50Latency), 0.1)
+	assert.InEpsilon(t, float64(95*time.Millisecond), float64(sel.P95Latency), 0.1)
+	assert.InEpsilon(t, float64(99*time.Millisecond), float64(sel.P99Latency), 0.1)
+	assert.Equal(t, int64(1000), sel.RowsExamined)
+	assert.Equal(t, int64(100), sel.RowsSent)
+
+	ins := sums[1]
+	assert.Equal(t, int64(1), ins.Count)
+	assert.Equal(t, int64(1), ins.Errors)
+	assert.Equal(t, int64(1), ins.RowsAffected)
+	assert.Equal(t, time.Second, ins.P99Latency)
+
+	other := sums[2]
+	assert.Equal(t, "", other.Digest)
+	assert.Equal(t, int64(2), other.Count)
+	assert.Equal(t, 2*time.Millisecond, other.TotalLatency)
+}
@@ -139,6 +139,11 @@ func (rrd ReadReplicaDatabase) PullFromRemote(ctx *sql.Context) error {
 		return err
 	}
 
+	filter, err := loadReplicationFilter()
+	if err != nil {
+		return err
+	}
+
 	switch {
 	case headsArg != "" && allHeads == dsess.SysVarTrue:
 		ctx.GetLogger().Warnf("cannot set both @@dolt_replicate_heads and @@dolt_replicate_all_heads, replication disabled")
@@ -197,18 +202,18 @@ func (rrd ReadReplicaDatabase) PullFromRemote(ctx *sql.Context) error {
 		}
 
 		remoteRefs = prunedRefs
-		_, err = pullBranches(ctx, rrd, remoteRefs, localRefs, behavior)
+		_, err = pullBranches(ctx, rrd, filter.filterRefs(remoteRefs), localRefs, behavior)
 		if err != nil {
 			return err
 		}
 
 	case allHeads == int8(1):
-		_, err = pullBranches(ctx, rrd, remoteRefs, localRefs, behavior)
+		_, err = pullBranches(ctx, rrd, filter.filterRefs(remoteRefs), localRefs, behavior)
 		if err != nil {
 			return err
 		}
 
-		err = deleteBranches(ctx, rrd, toDelete)
+		err = deleteBranches(ctx, rrd, filter.filterRefs(toDelete))
 		if err != nil {
 			return err
 		}
@@ -224,7 +229,15 @@ func (rrd ReadReplicaDatabase) PullFromRemote(ctx *sql.Context) error {
 // it. This is only used for initializing a new local branch being pulled from a remote during connection
 // initialization, and doesn't do the full work of remote synchronization that happens on transaction start.
 func (rrd ReadReplicaDatabase) CreateLocalBranchFromRemote(ctx *sql.Context, branchRef ref.BranchRef) error {
-	_, err := rrd.limiter.Run(ctx, "pullNewBranch", func() (any, error) {
+	filter, err := loadReplicationFilter()
+	if err != nil {
+		return err
+	}
+	if !filter.replicatesRef(branchRef) {
+		return fmt.Errorf("branch %q is not replicated from %q", branchRef.GetPath(), rrd.remote.Name)
+	}
+
+	_, err = rrd.limiter.Run(ctx, "pullNewBranch", func() (any, error) {
 		// because several clients can queue up waiting to create the same local branch, double check to see if this
 		// work was already done and bail early if so
 		_, branchExists, err := rrd.ddb.HasBranch(ctx, branchRef.GetPath())
@@ -0,0 +1,238 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rebase"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// replicationFilter decides which branches, and which tables of their commits, are replicated, as configured by the
+// @@dolt_replicate_include_branches, @@dolt_replicate_exclude_branches, @@dolt_replicate_include_tables and
+// @@dolt_replicate_exclude_tables system variables. Each of them holds a comma separated list of names, which may
+// use '*' wildcards. A branch or table is replicated if it matches an include pattern, or there are none, and it
+// doesn't match an exclude pattern. Tags are replicated unless @@dolt_replicate_tags is disabled.
+//
+// Branch and tag filters apply to both push on write and read replication. Table filters only apply to push on write
+// replication: the tables which aren't replicated are removed from every commit pushed to the replica, which
+// receives rewritten commits as a result. Tags can't be rewritten and aren't replicated while tables are filtered.
+type replicationFilter struct {
+	includeBranches []string
+	excludeBranches []string
+	includeTables   []string
+	excludeTables   []string
+	tags            bool
+}
+
+// loadReplicationFilter returns the replicationFilter configured by the current values of the global replication
+// filter system variables.
+func loadReplicationFilter() (replicationFilter, error) {
+	var f replicationFilter
+	for _, v := range []struct {
+		name     string
+		patterns *[]string
+	}{
+		{dsess.ReplicateIncludeBranches, &f.includeBranches},
+		{dsess.ReplicateExcludeBranches, &f.excludeBranches},
+		{dsess.ReplicateIncludeTables, &f.includeTables},
+		{dsess.ReplicateExcludeTables, &f.excludeTables},
+	} {
+		_, val, ok := sql.SystemVariables.GetGlobal(v.name)
+		if !ok {
+			return replicationFilter{}, sql.ErrUnknownSystemVariable.New(v.name)
+		}
+		s, ok := val.(string)
+		if !ok {
+			return replicationFilter{}, sql.ErrInvalidSystemVariableValue.New(v.name)
+		}
+		*v.patterns = splitReplicationPatterns(s)
+	}
+	_, val, ok := sql.SystemVariables.GetGlobal(dsess.ReplicateTags)
+	if !ok {
+		return replicationFilter{}, sql.ErrUnknownSystemVariable.New(dsess.ReplicateTags)
+	}
+	tags, ok := val.(int8)
+	if !ok {
+		return replicationFilter{}, sql.ErrInvalidSystemVariableValue.New(dsess.ReplicateTags)
+	}
+	f.tags = tags == 1
+	return f, nil
+}
+
+func splitReplicationPatterns(s string) []string {
+	var patterns []string
+	for _, p := range strings.Split(s, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns
+}
+
+func matchesAnyPattern(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if matchWildcardPattern(p, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f replicationFilter) equals(other replicationFilter) bool {
+	return slices.Equal(f.includeBranches, other.includeBranches) &&
+		slices.Equal(f.excludeBranches, other.excludeBranches) &&
+		slices.Equal(f.includeTables, other.includeTables) &&
+		slices.Equal(f.excludeTables, other.excludeTables) &&
+		f.tags == other.tags
+}
+
+// replicatesRef returns whether |r| is replicated. Only branches and tags are filtered.
+func (f replicationFilter) replicatesRef(r ref.DoltRef) bool {
+	switch r.GetType() {
+	case ref.TagRefType:
+		return f.tags
+	case ref.BranchRefType:
+	default:
+		return true
+	}
+	name := r.GetPath()
+	if len(f.includeBranches) > 0 && !matchesAnyPattern(f.includeBranches, name) {
+		return false
+	}
+	return !matchesAnyPattern(f.excludeBranches, name)
+}
+
+// filterRefs returns the refs of |refs| which are replicated.
+func (f replicationFilter) filterRefs(refs []doltdb.RefWithHash) []doltdb.RefWithHash {
+	if len(f.includeBranches) == 0 && len(f.excludeBranches) == 0 && f.tags {
+		return refs
+	}
+	filtered := make([]doltdb.RefWithHash, 0, len(refs))
+	for _, r := range refs {
+		if f.replicatesRef(r.Ref) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// filtersTables returns whether any tables are left out of replicated commits.
+func (f replicationFilter) filtersTables() bool {
+	return len(f.includeTables) > 0 || len(f.excludeTables) > 0
+}
+
+// replicatesTable returns whether the table |name| is replicated. Include patterns don't apply to system tables,
+// such as dolt_schemas, which are replicated unless they are excluded.
+func (f replicationFilter) replicatesTable(name string) bool {
+	if len(f.includeTables) > 0 && !doltdb.HasDoltPrefix(name) && !matchesAnyPattern(f.includeTables, name) {
+		return false
+	}
+	return !matchesAnyPattern(f.excludeTables, name)
+}
+
+var _ rebase.CommitReplayer = replicationFilter{}
+
+// ReplayCommit implements rebase.CommitReplayer, returning the root of |commit| without the tables which aren't
+// replicated.
+func (f replicationFilter) ReplayCommit(ctx context.Context, commit *doltdb.Commit) (doltdb.RootValue, error) {
+	root, err := commit.GetRootValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names, err := root.GetTableNames(ctx, doltdb.DefaultSchemaName)
+	if err != nil {
+		return nil, err
+	}
+	var drop []doltdb.TableName
+	for _, name := range names {
+		if !f.replicatesTable(name) {
+			drop = append(drop, doltdb.TableName{Name: name, Schema: doltdb.DefaultSchemaName})
+		}
+	}
+	// Foreign keys referencing dropped tables are left unresolved, as with foreign_key_checks disabled.
+	return root.RemoveTables(ctx, false, true, drop...)
+}
+
+// pushFilter applies the replicationFilter to the datasets pushed by a push on write hook. It rewrites the commits of
+// branches when tables are filtered, and remembers the commits it rewrote between pushes.
+type pushFilter struct {
+	mu       sync.Mutex
+	filter   replicationFilter
+	srcDB    *doltdb.DoltDB
+	rewriter *rebase.CommitRewriter
+}
+
+func newPushFilter() *pushFilter {
+	return &pushFilter{}
+}
+
+// apply returns the address to push for the ref |rf| of |srcDB| whose head is |addr|, and whether |rf| should be
+// pushed at all.
+func (pf *pushFilter) apply(ctx context.Context, srcDB *doltdb.DoltDB, rf ref.DoltRef, addr hash.Hash) (hash.Hash, bool, error) {
+	filter, err := loadReplicationFilter()
+	if err != nil {
+		return hash.Hash{}, false, err
+	}
+	if !filter.replicatesRef(rf) {
+		return hash.Hash{}, false, nil
+	}
+	if !filter.filtersTables() || addr.IsEmpty() {
+		return addr, true, nil
+	}
+	if rf.GetType() != ref.BranchRefType {
+		return hash.Hash{}, false, nil
+	}
+
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+	if pf.rewriter == nil || pf.srcDB != srcDB || !pf.filter.equals(filter) {
+		pf.filter, pf.srcDB = filter, srcDB
+		pf.rewriter = rebase.NewCommitRewriter(srcDB, filter, rebase.EntireHistory())
+	} else if err := pf.rewriter.Prune(ctx); err != nil {
+		return hash.Hash{}, false, err
+	}
+
+	spec, err := doltdb.NewCommitSpec(addr.String())
+	if err != nil {
+		return hash.Hash{}, false, err
+	}
+	optCmt, err := srcDB.Resolve(ctx, spec, nil)
+	if err != nil {
+		return hash.Hash{}, false, err
+	}
+	cm, ok := optCmt.ToCommit()
+	if !ok {
+		return hash.Hash{}, false, doltdb.ErrGhostCommitEncountered
+	}
+	rewritten, err := pf.rewriter.Rewrite(ctx, cm)
+	if err != nil {
+		return hash.Hash{}, false, err
+	}
+	h, err := rewritten.HashOf()
+	if err != nil {
+		return hash.Hash{}, false, err
+	}
+	return h, true, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+)
+
+func TestSplitReplicationPatterns(t *testing.T) {
+	assert.Nil(t, splitReplicationPatterns(""))
+	assert.Nil(t, splitReplicationPatterns(" , "))
+	assert.Equal(t, []string{"main", "feature/*"}, splitReplicationPatterns("main, feature/* ,"))
+}
+
+func TestReplicationFilterRefs(t *testing.T) {
+	f := replicationFilter{
+		includeBranches: []string{"main", "release/*"},
+		excludeBranches: []string{"release/old*"},
+		tags:            true,
+	}
+	assert.True(t, f.replicatesRef(ref.NewBranchRef("main")))
+	assert.True(t, f.replicatesRef(ref.NewBranchRef("release/1.0")))
+	assert.False(t, f.replicatesRef(ref.NewBranchRef("release/old-1.0")))
+	assert.False(t, f.replicatesRef(ref.NewBranchRef("scratch")))
+	assert.True(t, f.replicatesRef(ref.NewTagRef("v1")))
+
+	f = replicationFilter{excludeBranches: []string{"exp-*"}}
+	assert.True(t, f.replicatesRef(ref.NewBranchRef("main")))
+	assert.False(t, f.replicatesRef(ref.NewBranchRef("exp-1")))
+	assert.False(t, f.replicatesRef(ref.NewTagRef("v1")))
+
+	refs := []doltdb.RefWithHash{{Ref: ref.NewBranchRef("main")}, {Ref: ref.NewTagRef("v1")}}
+	assert.Len(t, replicationFilter{tags: true}.filterRefs(refs), 2)
+	assert.Equal(t, refs[:1], replicationFilter{}.filterRefs(refs))
+}
+
+func TestReplicationFilterTables(t *testing.T) {
+	f := replicationFilter{}
+	assert.False(t, f.filtersTables())
+	assert.True(t, f.replicatesTable("scratch"))
+
+	f = replicationFilter{
+		includeTables: []string{"users", "orders*"},
+		excludeTables: []string{"orders_tmp"},
+	}
+	assert.True(t, f.filtersTables())
+	assert.True(t, f.replicatesTable("users"))
+	assert.True(t, f.replicatesTable("orders_2024"))
+	assert.False(t, f.replicatesTable("orders_tmp"))
+	assert.False(t, f.replicatesTable("scratch"))
+	assert.True(t, f.replicatesTable("dolt_schemas"))
+
+	f = replicationFilter{excludeTables: []string{"scratch_*", "dolt_schemas"}}
+	assert.False(t, f.replicatesTable("scratch_1"))
+	assert.False(t, f.replicatesTable("dolt_schemas"))
+	assert.True(t, f.replicatesTable("users"))
+}
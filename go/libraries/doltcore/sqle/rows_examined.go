@@ -0,0 +1,57 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqle
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/querylog"
+)
+
+// countRowsExamined wraps |iter|, the rows of a table partition, to count the rows read from it as examined by the
+// session of |ctx|. Rows are only counted while the query log is enabled, which is the only consumer of the count.
+func countRowsExamined(ctx *sql.Context, iter sql.RowIter, err error) (sql.RowIter, error) {
+	if err != nil || querylog.Enabled() == nil {
+		return iter, err
+	}
+	sess, ok := ctx.Session.(*dsess.DoltSession)
+	if !ok {
+		return iter, nil
+	}
+	return &rowsExaminedIter{RowIter: iter, sess: sess}, nil
+}
+
+// rowsExaminedIter counts the rows returned by a table's row iterator, and adds them to the rows examined by its
+// session when it's closed.
+type rowsExaminedIter struct {
+	sql.RowIter
+	sess *dsess.DoltSession
+	rows int64
+}
+
+func (i *rowsExaminedIter) Next(ctx *sql.Context) (sql.Row, error) {
+	r, err := i.RowIter.Next(ctx)
+	if err == nil {
+		i.rows++
+	}
+	return r, err
+}
+
+func (i *rowsExaminedIter) Close(ctx *sql.Context) error {
+	i.sess.AddRowsExamined(i.rows)
+	i.rows = 0
+	return i.RowIter.Close(ctx)
+}
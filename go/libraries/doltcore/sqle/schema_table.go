@@ -34,9 +34,10 @@ import (
 )
 
 const (
-	viewFragment    = "view"
-	triggerFragment = "trigger"
-	eventFragment   = "event"
+	viewFragment     = "view"
+	triggerFragment  = "trigger"
+	eventFragment    = "event"
+	functionFragment = "function"
 )
 
 type Extra struct {
@@ -16,6 +16,7 @@ package sqlfmt
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -263,7 +264,7 @@ func SqlRowAsCreateProcStmt(r sql.Row) (string, error) {
 	b.WriteString(" ") // add a space
 
 	// Write definition
-	defStmt, err := sqlparser.Parse(r[1].(string))
+	defStmt, err := parseStoredStatement(r, 1, 4)
 	if err != nil {
 		return "", err
 	}
@@ -275,6 +276,19 @@ func SqlRowAsCreateProcStmt(r sql.Row) (string, error) {
 	return b.String(), nil
 }
 
+// parseStoredStatement parses the statement in column |stmtIdx| of a dolt_schemas or dolt_procedures row with the
+// SQL_MODE it was created under, held in column |sqlModeIdx|. Rows written before the SQL_MODE column existed are
+// parsed with the default parser options.
+func parseStoredStatement(r sql.Row, stmtIdx, sqlModeIdx int) (sqlparser.Statement, error) {
+	var opts sqlparser.ParserOptions
+	if sqlModeIdx < len(r) {
+		if sqlMode, ok := r[sqlModeIdx].(string); ok {
+			opts = sql.NewSqlModeFromString(sqlMode).ParserOptions()
+		}
+	}
+	return sqlparser.ParseWithOptions(context.Background(), r[stmtIdx].(string), opts)
+}
+
 // SqlRowAsCreateFragStmt Converts a Row into either a CREATE TRIGGER or CREATE VIEW statement
 // This function expects a row from the dolt_schemas table
 func SqlRowAsCreateFragStmt(r sql.Row) (string, error) {
@@ -290,7 +304,7 @@ func SqlRowAsCreateFragStmt(r sql.Row) (string, error) {
 	}
 
 	// Parse statement to extract definition (and remove any weird whitespace issues)
-	defStmt, err := sqlparser.Parse(r[2].(string))
+	defStmt, err := parseStoredStatement(r, 2, 4)
 	if err != nil {
 		return "", err
 	}
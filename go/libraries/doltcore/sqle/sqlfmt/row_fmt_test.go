@@ -15,8 +15,10 @@
 package sqlfmt_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -318,3 +320,22 @@ func TestValueAsSqlString(t *testing.T) {
 func strPointer(s string) *string {
 	return &s
 }
+
+func TestSqlRowAsCreateStmtWithSqlMode(t *testing.T) {
+	viewRow := sql.Row{"view", "v", `CREATE VIEW "v" AS SELECT "a" FROM "t"`, nil, "ANSI_QUOTES"}
+	stmt, err := sqlfmt.SqlRowAsCreateFragStmt(viewRow)
+	require.NoError(t, err)
+	assert.Contains(t, stmt, "CREATE VIEW")
+	assert.NotContains(t, stmt, `"a"`)
+
+	// without ANSI_QUOTES, the double quoted name is a string literal and the statement doesn't parse
+	viewRow[4] = ""
+	_, err = sqlfmt.SqlRowAsCreateFragStmt(viewRow)
+	assert.Error(t, err)
+
+	procRow := sql.Row{"p", `CREATE PROCEDURE p() SELECT "a" FROM "t"`, nil, nil, "ANSI_QUOTES"}
+	stmt, err = sqlfmt.SqlRowAsCreateProcStmt(procRow)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stmt, "CREATE PROCEDURE `p` "), stmt)
+	assert.NotContains(t, stmt, `"a"`)
+}
@@ -16,6 +16,7 @@ package sqlfmt
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/dolthub/go-mysql-server/sql"
@@ -221,8 +222,23 @@ func GenerateCreateTableIndentedColumnDefinition(col schema.Column, tableCollati
 
 // GenerateCreateTableIndexDefinition returns index definition for CREATE TABLE statement with indentation of 2 spaces
 func GenerateCreateTableIndexDefinition(index schema.Index) (string, bool) {
-	return sql.GenerateCreateTableIndexDefinition(index.IsUnique(), index.IsSpatial(), index.IsFullText(), index.IsVector(), index.Name(),
-		sql.QuoteIdentifiers(index.ColumnNames()), index.Comment())
+	definition, shouldInclude := sql.GenerateCreateTableIndexDefinition(index.IsUnique(), index.IsSpatial(), index.IsFullText(), index.IsVector(), index.Name(),
+		indexKeyParts(index), index.Comment())
+	if index.IsInvisible() {
+		definition += " /*!80000 INVISIBLE */"
+	}
+	return definition, shouldInclude
+}
+
+// indexKeyParts returns the quoted key parts of |index|. Descending key parts are followed by DESC.
+func indexKeyParts(index schema.Index) []string {
+	parts := sql.QuoteIdentifiers(index.ColumnNames())
+	for i, desc := range index.Descending() {
+		if desc && i < len(parts) {
+			parts[i] += " DESC"
+		}
+	}
+	return parts
 }
 
 // GenerateCreateTableForeignKeyDefinition returns foreign key definition for CREATE TABLE statement with indentation of 2 spaces
@@ -368,11 +384,7 @@ func AlterTableAddIndexStmt(tableName string, idx schema.Index) string {
 	b.WriteString(QuoteIdentifier(tableName))
 	b.WriteString(" ADD INDEX ")
 	b.WriteString(QuoteIdentifier(idx.Name()))
-	var cols []string
-	for _, cn := range idx.ColumnNames() {
-		cols = append(cols, QuoteIdentifier(cn))
-	}
-	b.WriteString("(" + strings.Join(cols, ",") + ");")
+	b.WriteString("(" + strings.Join(indexKeyParts(idx), ",") + ");")
 	return b.String()
 }
 
@@ -480,9 +492,43 @@ func GenerateCreateTableStatement(tblName string, sch schema.Schema, fks []doltd
 
 	coll := sql.CollationID(sch.GetCollation())
 	createTableStmt := sql.GenerateCreateTableStatement(tblName, colStmts, "", "", coll.CharacterSet().Name(), coll.Name(), sch.GetComment())
+	if p := sch.GetPartitioning(); p != nil {
+		createTableStmt += "\n" + GeneratePartitionClause(p)
+	}
 	return fmt.Sprintf("%s;", createTableStmt), nil
 }
 
+// GeneratePartitionClause returns the PARTITION BY clause of a CREATE TABLE statement for the partitioning given.
+func GeneratePartitionClause(p *schema.Partitioning) string {
+	var b strings.Builder
+	b.WriteString("/*!50100 PARTITION BY ")
+	b.WriteString(string(p.Method))
+	b.WriteString(" (")
+	b.WriteString(QuoteIdentifier(p.Column))
+	b.WriteString(")")
+	switch p.Method {
+	case schema.PartitionMethodRange:
+		b.WriteString("\n(")
+		for i, pd := range p.Partitions {
+			if i > 0 {
+				b.WriteString(",\n ")
+			}
+			b.WriteString("PARTITION ")
+			b.WriteString(QuoteIdentifier(pd.Name))
+			if _, ok := pd.UpperBound(); ok {
+				b.WriteString(" VALUES LESS THAN (" + pd.LessThan + ")")
+			} else {
+				b.WriteString(" VALUES LESS THAN " + schema.PartitionMaxValue)
+			}
+		}
+		b.WriteString(")")
+	case schema.PartitionMethodKey:
+		b.WriteString("\nPARTITIONS " + strconv.Itoa(p.NumPartitions))
+	}
+	b.WriteString(" */")
+	return b.String()
+}
+
 // isPrimaryKeyIndex returns whether the index given matches the table's primary key columns. Order is not considered.
 func isPrimaryKeyIndex(index schema.Index, sch schema.Schema) bool {
 	var pks = sch.GetPKCols().GetColumns()
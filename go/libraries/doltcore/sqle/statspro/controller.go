@@ -29,6 +29,7 @@ import (
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/stats"
+	gmstypes "github.com/dolthub/go-mysql-server/sql/types"
 	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/doltversion"
@@ -38,6 +39,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dprocedures"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dsess"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/statspro/jobqueue"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
@@ -48,6 +50,7 @@ import (
 )
 
 var _ sql.StatsProvider = (*StatsController)(nil)
+var _ index.SpatialStatsProvider = (*StatsController)(nil)
 
 type ctxFactory func(ctx context.Context) (*sql.Context, error)
 
@@ -109,6 +112,7 @@ type rootStats struct {
 	hash            uint64
 	hashes          map[tableIndexesKey]hash.Hash
 	stats           map[tableIndexesKey][]*stats.Statistic
+	spatial         map[tableIndexesKey]map[string]*SpatialHistogram
 	DbCnt           int `json:"dbCnt"`
 	BucketWrites    int `json:"bucketWrites"`
 	TablesProcessed int `json:"tablesProcessed"`
@@ -117,8 +121,9 @@ type rootStats struct {
 
 func newRootStats() *rootStats {
 	return &rootStats{
-		hashes: make(map[tableIndexesKey]hash.Hash),
-		stats:  make(map[tableIndexesKey][]*stats.Statistic),
+		hashes:  make(map[tableIndexesKey]hash.Hash),
+		stats:   make(map[tableIndexesKey][]*stats.Statistic),
+		spatial: make(map[tableIndexesKey]map[string]*SpatialHistogram),
 	}
 }
 
@@ -331,6 +336,11 @@ func (sc *StatsController) AnalyzeTable(ctx *sql.Context, table sql.Table, dbNam
 	for k, v := range newStats.stats {
 		sc.Stats.stats[k] = v
 		sc.Stats.hashes[k] = newStats.hashes[k]
+		if spatial, ok := newStats.spatial[k]; ok {
+			sc.Stats.spatial[k] = spatial
+		} else {
+			delete(sc.Stats.spatial, k)
+		}
 	}
 	sc.mu.Unlock()
 
@@ -376,6 +386,31 @@ func (sc *StatsController) GetStats(ctx *sql.Context, qual sql.StatQualifier, co
 	return nil, false
 }
 
+// GetSpatialStats returns the grid histogram for the spatial index |qual| on the session's current branch, which the
+// planner can use to estimate the number of rows matched by a bounding box filter.
+func (sc *StatsController) GetSpatialStats(ctx *sql.Context, qual sql.StatQualifier) (*SpatialHistogram, bool) {
+	key, err := sc.statsKey(ctx, qual.Database, qual.Table())
+	if err != nil {
+		return nil, false
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.Stats == nil {
+		return nil, false
+	}
+	sh, ok := sc.Stats.spatial[key][strings.ToLower(qual.Index())]
+	return sh, ok
+}
+
+// SpatialSelectivity implements index.SpatialStatsProvider with the grid histogram of the spatial index |qual|.
+func (sc *StatsController) SpatialSelectivity(ctx *sql.Context, qual sql.StatQualifier, lo, hi gmstypes.Point) (float64, bool) {
+	sh, ok := sc.GetSpatialStats(ctx, qual)
+	if !ok {
+		return 0, false
+	}
+	return sh.Selectivity(lo, hi), true
+}
+
 func (sc *StatsController) GetTableDoltStats(ctx *sql.Context, branch, db, schema, table string) ([]*stats.Statistic, error) {
 	key := tableIndexesKey{
 		db:     strings.ToLower(db),
@@ -399,6 +434,7 @@ func (sc *StatsController) DropStats(ctx *sql.Context, qual sql.StatQualifier, c
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 	delete(sc.Stats.stats, key)
+	delete(sc.Stats.spatial, key)
 	return nil
 }
 
@@ -428,6 +464,7 @@ func (sc *StatsController) DropDbStats(ctx *sql.Context, dbName string, flush bo
 	}
 	for _, k := range deleteKeys {
 		delete(sc.Stats.stats, k)
+		delete(sc.Stats.spatial, k)
 	}
 	return nil
 }
@@ -0,0 +1,248 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statspro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql/types"
+
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+)
+
+// spatialGridSize is the number of grid cells along each axis of a spatial histogram.
+const spatialGridSize = 16
+
+// SpatialHistogram is a fixed-size grid histogram over the extent of a spatial index. Spatial index keys are z-order
+// cells rather than ordered column values, so the regular bucket histograms do not apply. Each index entry is counted
+// in the grid cell containing the center of its z-cell. Entries whose z-cell has no finite extent (very large
+// geometries) are tracked separately and are assumed to intersect every query.
+type SpatialHistogram struct {
+	MinX, MinY float64
+	MaxX, MaxY float64
+	GridSize   int
+	Counts     []uint64
+	Unbounded  uint64
+	RowCnt     uint64
+}
+
+// buildSpatialHistogram reads the keys of the spatial index |m| and returns a grid histogram describing their
+// distribution.
+func buildSpatialHistogram(ctx context.Context, m prolly.Map) (*SpatialHistogram, error) {
+	sh := &SpatialHistogram{
+		MinX:     math.Inf(1),
+		MinY:     math.Inf(1),
+		MaxX:     math.Inf(-1),
+		MaxY:     math.Inf(-1),
+		GridSize: spatialGridSize,
+		Counts:   make([]uint64, spatialGridSize*spatialGridSize),
+	}
+
+	// first pass finds the extent, second pass fills the grid
+	err := iterSpatialCells(ctx, m, func(lo, hi types.Point, finite bool) {
+		sh.RowCnt++
+		if !finite {
+			sh.Unbounded++
+			return
+		}
+		sh.MinX, sh.MinY = math.Min(sh.MinX, lo.X), math.Min(sh.MinY, lo.Y)
+		sh.MaxX, sh.MaxY = math.Max(sh.MaxX, hi.X), math.Max(sh.MaxY, hi.Y)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sh.RowCnt == sh.Unbounded {
+		sh.MinX, sh.MinY, sh.MaxX, sh.MaxY = 0, 0, 0, 0
+		return sh, nil
+	}
+
+	err = iterSpatialCells(ctx, m, func(lo, hi types.Point, finite bool) {
+		if !finite {
+			return
+		}
+		x := sh.gridIndex((lo.X+hi.X)/2, sh.MinX, sh.MaxX)
+		y := sh.gridIndex((lo.Y+hi.Y)/2, sh.MinY, sh.MaxY)
+		sh.Counts[y*sh.GridSize+x]++
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sh, nil
+}
+
+func iterSpatialCells(ctx context.Context, m prolly.Map, cb func(lo, hi types.Point, finite bool)) error {
+	iter, err := m.IterAll(ctx)
+	if err != nil {
+		return err
+	}
+	kd := m.KeyDesc()
+	for {
+		k, _, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		cell, ok := kd.GetCell(0, k)
+		if !ok {
+			continue
+		}
+		lo, hi := tree.ZCellBounds(cell)
+		cb(lo, hi, isFinitePoint(lo) && isFinitePoint(hi))
+	}
+}
+
+func isFinitePoint(p types.Point) bool {
+	return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+}
+
+// gridIndex returns the grid column (or row) containing |v| for an axis spanning [min, max].
+func (sh *SpatialHistogram) gridIndex(v, axisMin, axisMax float64) int {
+	if axisMax <= axisMin {
+		return 0
+	}
+	i := int((v - axisMin) / (axisMax - axisMin) * float64(sh.GridSize))
+	if i < 0 {
+		return 0
+	} else if i >= sh.GridSize {
+		return sh.GridSize - 1
+	}
+	return i
+}
+
+// axisOverlap returns the fraction of grid slot |i| on an axis spanning [min, max] that is covered by the query range
+// [lo, hi].
+func (sh *SpatialHistogram) axisOverlap(i int, axisMin, axisMax, lo, hi float64) float64 {
+	if axisMax <= axisMin {
+		// degenerate axis, every entry shares one coordinate
+		if lo <= axisMin && axisMin <= hi {
+			return 1
+		}
+		return 0
+	}
+	width := (axisMax - axisMin) / float64(sh.GridSize)
+	slotLo := axisMin + float64(i)*width
+	slotHi := slotLo + width
+	overlap := math.Min(hi, slotHi) - math.Max(lo, slotLo)
+	if overlap <= 0 {
+		// a query touching the slot edge still matches points on it
+		if overlap == 0 && lo == hi {
+			return 1
+		}
+		return 0
+	}
+	return overlap / width
+}
+
+// EstimateRowCount estimates how many index entries intersect the bounding box [lo, hi], assuming a uniform
+// distribution within each grid cell.
+func (sh *SpatialHistogram) EstimateRowCount(lo, hi types.Point) float64 {
+	est := float64(sh.Unbounded)
+	if sh.RowCnt == sh.Unbounded || hi.X < sh.MinX || hi.Y < sh.MinY || lo.X > sh.MaxX || lo.Y > sh.MaxY {
+		return est
+	}
+	for y := 0; y < sh.GridSize; y++ {
+		fy := sh.axisOverlap(y, sh.MinY, sh.MaxY, lo.Y, hi.Y)
+		if fy == 0 {
+			continue
+		}
+		for x := 0; x < sh.GridSize; x++ {
+			cnt := sh.Counts[y*sh.GridSize+x]
+			if cnt == 0 {
+				continue
+			}
+			est += float64(cnt) * fy * sh.axisOverlap(x, sh.MinX, sh.MaxX, lo.X, hi.X)
+		}
+	}
+	return est
+}
+
+// Selectivity returns the estimated fraction of index entries that intersect the bounding box [lo, hi].
+func (sh *SpatialHistogram) Selectivity(lo, hi types.Point) float64 {
+	if sh.RowCnt == 0 {
+		return 0
+	}
+	return math.Min(1, sh.EstimateRowCount(lo, hi)/float64(sh.RowCnt))
+}
+
+// encode serializes the histogram into the string form stored in the statistics table's upper bound column.
+func (sh *SpatialHistogram) encode() string {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(sh.GridSize))
+	for _, f := range []float64{sh.MinX, sh.MinY, sh.MaxX, sh.MaxY} {
+		b.WriteByte(';')
+		b.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	b.WriteByte(';')
+	b.WriteString(strconv.FormatUint(sh.Unbounded, 10))
+	b.WriteByte(';')
+	for i, c := range sh.Counts {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatUint(c, 10))
+	}
+	return b.String()
+}
+
+func decodeSpatialHistogram(s string, rowCnt uint64) (*SpatialHistogram, error) {
+	parts := strings.Split(s, ";")
+	if len(parts) != 7 {
+		return nil, fmt.Errorf("invalid spatial histogram encoding")
+	}
+	gridSize, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	var bounds [4]float64
+	for i := range bounds {
+		bounds[i], err = strconv.ParseFloat(parts[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	unbounded, err := strconv.ParseUint(parts[5], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	cnts := strings.Split(parts[6], ",")
+	if len(cnts) != gridSize*gridSize {
+		return nil, fmt.Errorf("invalid spatial histogram encoding: expected %d cells, found %d", gridSize*gridSize, len(cnts))
+	}
+	counts := make([]uint64, len(cnts))
+	for i, c := range cnts {
+		counts[i], err = strconv.ParseUint(c, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &SpatialHistogram{
+		MinX:      bounds[0],
+		MinY:      bounds[1],
+		MaxX:      bounds[2],
+		MaxY:      bounds[3],
+		GridSize:  gridSize,
+		Counts:    counts,
+		Unbounded: unbounded,
+		RowCnt:    rowCnt,
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statspro
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testSpatialHistogram() *SpatialHistogram {
+	sh := &SpatialHistogram{
+		MinX:      0,
+		MinY:      0,
+		MaxX:      16,
+		MaxY:      16,
+		GridSize:  spatialGridSize,
+		Counts:    make([]uint64, spatialGridSize*spatialGridSize),
+		Unbounded: 2,
+	}
+	// 10 entries in every cell of the lower left quadrant
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			sh.Counts[y*sh.GridSize+x] = 10
+		}
+	}
+	sh.RowCnt = 8*8*10 + sh.Unbounded
+	return sh
+}
+
+func TestSpatialHistogramEstimate(t *testing.T) {
+	sh := testSpatialHistogram()
+
+	t.Run("full extent", func(t *testing.T) {
+		est := sh.EstimateRowCount(types.Point{X: -1, Y: -1}, types.Point{X: 20, Y: 20})
+		require.InDelta(t, float64(sh.RowCnt), est, 0.001)
+		require.InDelta(t, 1.0, sh.Selectivity(types.Point{X: -1, Y: -1}, types.Point{X: 20, Y: 20}), 0.001)
+	})
+	t.Run("empty quadrant", func(t *testing.T) {
+		est := sh.EstimateRowCount(types.Point{X: 8, Y: 8}, types.Point{X: 16, Y: 16})
+		require.InDelta(t, float64(sh.Unbounded), est, 0.001)
+	})
+	t.Run("outside extent", func(t *testing.T) {
+		est := sh.EstimateRowCount(types.Point{X: 100, Y: 100}, types.Point{X: 200, Y: 200})
+		require.InDelta(t, float64(sh.Unbounded), est, 0.001)
+	})
+	t.Run("half of the bounded entries", func(t *testing.T) {
+		half := testSpatialHistogram()
+		half.RowCnt -= half.Unbounded
+		half.Unbounded = 0
+		// the left half of the filled quadrant, the boundary at which lookups switch to table scans
+		require.Equal(t, 0.5, half.Selectivity(types.Point{X: 0, Y: 0}, types.Point{X: 4, Y: 8}))
+	})
+	t.Run("cell boundaries", func(t *testing.T) {
+		// two whole cells, touching but not overlapping their neighbors
+		est := sh.EstimateRowCount(types.Point{X: 0, Y: 0}, types.Point{X: 2, Y: 1})
+		require.InDelta(t, 2*10+float64(sh.Unbounded), est, 0.001)
+	})
+}
+
+func TestSpatialHistogramEncode(t *testing.T) {
+	sh := testSpatialHistogram()
+	sh.MinX, sh.MaxY = -1.5, 1e10
+
+	decoded, err := decodeSpatialHistogram(sh.encode(), sh.RowCnt)
+	require.NoError(t, err)
+	require.Equal(t, sh, decoded)
+
+	_, err = decodeSpatialHistogram("16;0;0;1;1;0;1,2,3", 3)
+	require.Error(t, err)
+	_, err = decodeSpatialHistogram("not a histogram", 3)
+	require.Error(t, err)
+}
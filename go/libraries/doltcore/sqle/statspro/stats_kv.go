@@ -47,6 +47,8 @@ type StatsKv interface {
 	PutTemplate(key templateCacheKey, stat stats.Statistic)
 	GetBound(h hash.Hash, len int) (sql.Row, bool)
 	PutBound(h hash.Hash, r sql.Row, l int)
+	PutSpatialHistogram(ctx context.Context, h hash.Hash, sh *SpatialHistogram) error
+	GetSpatialHistogram(ctx context.Context, h hash.Hash) (*SpatialHistogram, bool, error)
 	Flush(ctx context.Context, sq *jobqueue.SerialQueue) (int, error)
 	Len() int
 	GcGen() uint64
@@ -62,6 +64,7 @@ func NewMemStats() *memStats {
 		buckets:   make(map[bucketKey]*stats.Bucket),
 		templates: make(map[templateCacheKey]stats.Statistic),
 		bounds:    make(map[bucketKey]sql.Row),
+		spatial:   make(map[hash.Hash]*SpatialHistogram),
 		gcFlusher: make(map[*val.TupleBuilder][]bucketKey),
 	}
 }
@@ -73,10 +76,13 @@ type memStats struct {
 	buckets   map[bucketKey]*stats.Bucket
 	templates map[templateCacheKey]stats.Statistic
 	bounds    map[bucketKey]sql.Row
+	// spatial histograms are keyed by the root hash of the spatial index they describe
+	spatial map[hash.Hash]*SpatialHistogram
 
 	// gcFlusher tracks state require to lazily swap from
 	// a *memStats to *prollyStats
 	gcFlusher map[*val.TupleBuilder][]bucketKey
+	gcSpatial []hash.Hash
 }
 
 func (m *memStats) StorageCnt(context.Context) (int, error) {
@@ -146,6 +152,19 @@ func (m *memStats) GcMark(from StatsKv, nodes []tree.Node, buckets []*stats.Buck
 	return true
 }
 
+func (m *memStats) GcMarkSpatial(from StatsKv, h hash.Hash, sh *SpatialHistogram) bool {
+	if from.GcGen() > m.GcGen() {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spatial[h] = sh
+	m.gcSpatial = append(m.gcSpatial, h)
+	return true
+}
+
 func (m *memStats) GcGen() uint64 {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -177,12 +196,30 @@ func (m *memStats) GetBucket(_ context.Context, h hash.Hash, tupB *val.TupleBuil
 	return b, ok, nil
 }
 
+func (m *memStats) PutSpatialHistogram(_ context.Context, h hash.Hash, sh *SpatialHistogram) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.spatial[h] = sh
+	return nil
+}
+
+func (m *memStats) GetSpatialHistogram(_ context.Context, h hash.Hash) (*SpatialHistogram, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if h.IsEmpty() {
+		return nil, false, nil
+	}
+	sh, ok := m.spatial[h]
+	return sh, ok, nil
+}
+
 func (m *memStats) Flush(context.Context, *jobqueue.SerialQueue) (int, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if m.gcFlusher != nil {
 		m.gcFlusher = nil
 	}
+	m.gcSpatial = nil
 	return 0, nil
 }
 
@@ -295,6 +332,65 @@ func (p *prollyStats) GetBucket(ctx context.Context, h hash.Hash, tupB *val.Tupl
 	return b, true, nil
 }
 
+// PutSpatialHistogram persists |sh| in the statistics map next to the regular histogram buckets. Spatial histograms use
+// a zero prefix length in their key, which no regular index bucket can have.
+func (p *prollyStats) PutSpatialHistogram(ctx context.Context, h hash.Hash, sh *SpatialHistogram) error {
+	if err := p.mem.PutSpatialHistogram(ctx, h, sh); err != nil {
+		return err
+	}
+
+	k, err := p.encodeHash(h, 0)
+	if err != nil {
+		return err
+	}
+	v, err := p.encodeSpatialHistogram(sh)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.m.Put(ctx, k, v)
+}
+
+func (p *prollyStats) GetSpatialHistogram(ctx context.Context, h hash.Hash) (*SpatialHistogram, bool, error) {
+	if h.IsEmpty() {
+		return nil, false, nil
+	}
+	sh, ok, err := p.mem.GetSpatialHistogram(ctx, h)
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		return sh, true, nil
+	}
+
+	k, err := p.encodeHash(h, 0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var v val.Tuple
+	err = p.m.Get(ctx, k, func(key val.Tuple, value val.Tuple) error {
+		if key != nil {
+			ok = true
+			v = value
+		}
+		return nil
+	})
+	if !ok || err != nil {
+		return nil, false, err
+	}
+
+	sh, err = p.decodeSpatialHistogramTuple(v)
+	if err != nil {
+		return nil, false, err
+	}
+
+	p.mem.PutSpatialHistogram(ctx, h, sh)
+	return sh, true, nil
+}
+
 func (p *prollyStats) GcGen() uint64 {
 	return p.mem.gcGen
 }
@@ -329,6 +425,24 @@ func (p *prollyStats) LoadFromMem(ctx context.Context, sq *jobqueue.SerialQueue)
 		}
 	}
 	p.mem.gcFlusher = nil
+	for _, h := range p.mem.gcSpatial {
+		sh, ok := p.mem.spatial[h]
+		if !ok {
+			return fmt.Errorf("memory KV inconsistent, missing spatial histogram for: %s", h)
+		}
+		tupK, err := p.encodeHash(h, 0)
+		if err != nil {
+			return err
+		}
+		tupV, err := p.encodeSpatialHistogram(sh)
+		if err != nil {
+			return err
+		}
+		if err := p.m.Put(ctx, tupK, tupV); err != nil {
+			return err
+		}
+	}
+	p.mem.gcSpatial = nil
 	return nil
 }
 
@@ -464,6 +578,37 @@ func (p *prollyStats) encodeBucket(ctx context.Context, b *stats.Bucket, tupB *v
 	return p.vb.Build(p.m.NodeStore().Pool())
 }
 
+func (p *prollyStats) encodeSpatialHistogram(sh *SpatialHistogram) (val.Tuple, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.vb.PutInt64(0, schema.StatsVersion)
+	p.vb.PutInt64(1, int64(sh.RowCnt))
+	p.vb.PutInt64(2, 0)
+	p.vb.PutInt64(3, 0)
+	if err := p.vb.PutString(4, sh.encode()); err != nil {
+		return nil, err
+	}
+	p.vb.PutInt64(5, 0)
+	if err := p.vb.PutString(10, ""); err != nil {
+		return nil, err
+	}
+	return p.vb.Build(p.m.NodeStore().Pool())
+}
+
+func (p *prollyStats) decodeSpatialHistogramTuple(v val.Tuple) (*SpatialHistogram, error) {
+	version, _ := p.vb.Desc.GetInt64(0, v)
+	if version != schema.StatsVersion {
+		return nil, fmt.Errorf("%w: write version %d does not match read version %d", ErrIncompatibleVersion, version, schema.StatsVersion)
+	}
+	rowCnt, _ := p.vb.Desc.GetInt64(1, v)
+	enc, ok := p.vb.Desc.GetString(4, v)
+	if !ok {
+		return nil, fmt.Errorf("unexpected null spatial histogram")
+	}
+	return decodeSpatialHistogram(enc, uint64(rowCnt))
+}
+
 func (p *prollyStats) NewEmpty(ctx context.Context) (StatsKv, error) {
 	kd, vd := schema.StatsTableDoltSchema.GetMapDescriptors(nil)
 	newMap, err := prolly.NewMapFromTuples(ctx, p.destDb.DbData().Ddb.NodeStore(), kd, vd)
@@ -536,6 +681,18 @@ func (sc *StatsController) PutBound(h hash.Hash, r sql.Row, l int) {
 	sc.kv.PutBound(h, r, l)
 }
 
+func (sc *StatsController) PutSpatialHistogram(ctx context.Context, h hash.Hash, sh *SpatialHistogram) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.kv.PutSpatialHistogram(ctx, h, sh)
+}
+
+func (sc *StatsController) GetSpatialHistogram(ctx context.Context, h hash.Hash) (*SpatialHistogram, bool, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.kv.GetSpatialHistogram(ctx, h)
+}
+
 func (sc *StatsController) Flush(ctx context.Context, sq *jobqueue.SerialQueue) (int, error) {
 	sqlCtx, err := sc.ctxGen(ctx)
 	if err != nil {
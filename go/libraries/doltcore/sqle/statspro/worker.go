@@ -265,13 +265,13 @@ func (sc *StatsController) newStatsForRoot(baseCtx context.Context, gcKv *memSta
 	return newStats, nil
 }
 
-func (sc *StatsController) preexistingStats(k tableIndexesKey, h hash.Hash) ([]*stats.Statistic, bool) {
+func (sc *StatsController) preexistingStats(k tableIndexesKey, h hash.Hash) ([]*stats.Statistic, map[string]*SpatialHistogram, bool) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 	if sc.Stats.hashes[k].Equal(h) {
-		return sc.Stats.stats[k], true
+		return sc.Stats.stats[k], sc.Stats.spatial[k], true
 	}
-	return nil, false
+	return nil, nil, false
 }
 
 func (sc *StatsController) finalizeHistogram(template stats.Statistic, buckets []*stats.Bucket, firstBound sql.Row) *stats.Statistic {
@@ -412,8 +412,11 @@ func (sc *StatsController) updateTable(ctx *sql.Context, newStats *rootStats, ta
 		return err
 	}
 	if gcKv == nil {
-		if stats, ok := sc.preexistingStats(tableKey, tableHash); ok {
+		if stats, spatial, ok := sc.preexistingStats(tableKey, tableHash); ok {
 			newStats.stats[tableKey] = stats
+			if spatial != nil {
+				newStats.spatial[tableKey] = spatial
+			}
 			newStats.hashes[tableKey] = tableHash
 			newStats.TablesSkipped++
 			return nil
@@ -429,8 +432,21 @@ func (sc *StatsController) updateTable(ctx *sql.Context, newStats *rootStats, ta
 	}
 
 	var newTableStats []*stats.Statistic
+	var newSpatial map[string]*SpatialHistogram
 	for _, sqlIdx := range indexes {
-		if sqlIdx.IsSpatial() || sqlIdx.IsFullText() || sqlIdx.IsGenerated() || sqlIdx.IsVector() {
+		if sqlIdx.IsSpatial() {
+			sh, err := sc.updateSpatialIndex(ctx, dTab, sqlIdx, gcKv)
+			if err != nil {
+				sc.descError("spatial histogram", err)
+				continue
+			}
+			if newSpatial == nil {
+				newSpatial = make(map[string]*SpatialHistogram)
+			}
+			newSpatial[strings.ToLower(sqlIdx.ID())] = sh
+			continue
+		}
+		if sqlIdx.IsFullText() || sqlIdx.IsGenerated() || sqlIdx.IsVector() {
 			continue
 		}
 		var idx durable.Index
@@ -510,11 +526,52 @@ func (sc *StatsController) updateTable(ctx *sql.Context, newStats *rootStats, ta
 		}
 	}
 	newStats.stats[tableKey] = newTableStats
+	if newSpatial != nil {
+		newStats.spatial[tableKey] = newSpatial
+	}
 	newStats.hashes[tableKey] = tableHash
 	newStats.TablesProcessed++
 	return nil
 }
 
+// updateSpatialIndex returns the grid histogram for the spatial index |sqlIdx|, reading it from the stats cache if the
+// index is unchanged since it was last built.
+func (sc *StatsController) updateSpatialIndex(ctx *sql.Context, dTab *doltdb.Table, sqlIdx sql.Index, gcKv *memStats) (*SpatialHistogram, error) {
+	var prollyMap prolly.Map
+	if err := sc.sq.DoSync(ctx, func() error {
+		idx, err := dTab.GetIndexRowData(ctx, sqlIdx.ID())
+		if err != nil {
+			return err
+		}
+		prollyMap, err = durable.ProllyMapFromIndex(idx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	h := prollyMap.HashOf()
+	sh, ok, err := sc.GetSpatialHistogram(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := sc.sq.DoSync(ctx, func() (err error) {
+			sh, err = buildSpatialHistogram(ctx, prollyMap)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		if err := sc.PutSpatialHistogram(ctx, h, sh); err != nil {
+			return nil, err
+		}
+	}
+
+	if gcKv != nil && !gcKv.GcMarkSpatial(sc.kv, h, sh) {
+		return nil, fmt.Errorf("GC interrupted updated")
+	}
+	return sh, nil
+}
+
 // GetLatestTable will get the WORKING root table for the current database/branch
 func GetLatestTable(ctx *sql.Context, tableName string, sqlDb sql.Database) (*sqle.DoltTable, *doltdb.Table, error) {
 	var db sqle.Database
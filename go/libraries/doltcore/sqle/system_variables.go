@@ -39,6 +39,16 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemStringType("log_bin_branch"),
 		Default:           "main",
 	},
+	// Dolt always logs row changes unless a session selects STATEMENT, which replication tools such as
+	// pt-table-checksum do so that replicas compute their own checksums.
+	&sql.MysqlSystemVariable{
+		Name:              "binlog_format",
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemEnumType("binlog_format", "ROW", "STATEMENT", "MIXED"),
+		Default:           "ROW",
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.DoltOverrideSchema,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -103,6 +113,46 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemBoolType(dsess.ReplicateAllHeads),
 		Default:           int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReplicateIncludeBranches,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.ReplicateIncludeBranches),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReplicateExcludeBranches,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.ReplicateExcludeBranches),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReplicateIncludeTables,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.ReplicateIncludeTables),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReplicateExcludeTables,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.ReplicateExcludeTables),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.ReplicateTags,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemBoolType(dsess.ReplicateTags),
+		Default:           int8(1),
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.AsyncReplication,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
@@ -111,6 +161,38 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 		Default:           int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.AsyncReplicationQueueSize,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.AsyncReplicationQueueSize, 1, math.MaxInt32, false),
+		Default:           int64(2048),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.AsyncReplicationDropPolicy,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemEnumType(dsess.AsyncReplicationDropPolicy, "block", "drop"),
+		Default:           "block",
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.StorageQuotaBytes,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemIntType(dsess.StorageQuotaBytes, 0, math.MaxInt64, false),
+		Default:           int64(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:              dsess.DatabaseStorageQuotas,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.DatabaseStorageQuotas),
+		Default:           "",
+	},
 	&sql.MysqlSystemVariable{ // If true, causes a Dolt commit to occur when you commit a transaction.
 		Name:              dsess.DoltCommitOnTransactionCommit,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -127,6 +209,22 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:              types.NewSystemStringType(dsess.DoltCommitOnTransactionCommitMessage),
 		Default:           "",
 	},
+	&sql.MysqlSystemVariable{ // If set, the author of the session's commits, in the "Name <email>" format
+		Name:              dsess.DoltCommitAuthor,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Session),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.DoltCommitAuthor),
+		Default:           "",
+	},
+	&sql.MysqlSystemVariable{ // If set, the committer of the session's commits instead of the SQL user. Requires SUPER.
+		Name:              dsess.DoltCommitCommitter,
+		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Session),
+		Dynamic:           true,
+		SetVarHintApplies: false,
+		Type:              types.NewSystemStringType(dsess.DoltCommitCommitter),
+		Default:           "",
+	},
 	&sql.MysqlSystemVariable{
 		Name:              dsess.TransactionsDisabledSysVar,
 		Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Session),
@@ -205,6 +303,167 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType(dsess.ShowSystemTables),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.OnlineIndexBuild,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemBoolType(dsess.OnlineIndexBuild),
+		Default: int8(0),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.ScanParallelism,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.ScanParallelism, 0, 1024, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{ // If non-zero, statements which return more rows than this fail on the sql-server
+		Name:    dsess.MaxResultRows,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.MaxResultRows, 0, math.MaxInt64, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{ // If non-zero, statements which fetch more bytes than this from remote databases fail on the sql-server
+		Name:    dsess.RemoteFetchBudget,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.RemoteFetchBudget, 0, math.MaxInt64, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{ // The number of times the sql-server retries an autocommit write whose transaction conflicts with another
+		Name:    dsess.AutocommitRetries,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.AutocommitRetries, 0, 10, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{ // The directory for scratch files, such as the sorted runs of index builds. Empty means the default temp directory
+		Name:    dsess.ScratchDir,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemStringType(dsess.ScratchDir),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{ // If non-zero, the most bytes of scratch files a session may hold at once
+		Name:    dsess.ScratchQuota,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemIntType(dsess.ScratchQuota, 0, math.MaxInt64, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{ // Whether sql-server records statements in dolt_query_log and aggregates them in dolt_query_summary
+		Name:    dsess.QueryLog,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemBoolType(dsess.QueryLog),
+		Default: int8(0),
+	},
+	&sql.MysqlSystemVariable{ // The number of recent statements kept in dolt_query_log
+		Name:    dsess.QueryLogSize,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.QueryLogSize, 1, math.MaxInt32, false),
+		Default: int64(1000),
+	},
+	&sql.MysqlSystemVariable{ // The number of digests aggregated in dolt_query_summary, after which new digests are counted together
+		Name:    dsess.QueryLogMaxDigests,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.QueryLogMaxDigests, 1, math.MaxInt32, false),
+		Default: int64(10000),
+	},
+	&sql.MysqlSystemVariable{ // If non-zero, the seconds between the profiles sql-server captures of itself. Zero disables profiling
+		Name:    dsess.ProfileInterval,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.ProfileInterval, 0, math.MaxInt32, false),
+		Default: int64(0),
+	},
+	&sql.MysqlSystemVariable{ // The comma separated types of profile captured: cpu, or any profile of runtime/pprof such as heap or goroutine
+		Name:    dsess.ProfileTypes,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.ProfileTypes),
+		Default: "cpu,heap,goroutine",
+	},
+	&sql.MysqlSystemVariable{ // The seconds each cpu profile samples for
+		Name:    dsess.ProfileCPUSeconds,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.ProfileCPUSeconds, 1, 3600, false),
+		Default: int64(10),
+	},
+	&sql.MysqlSystemVariable{ // The directory profiles are written to. Empty means they aren't written to files
+		Name:    dsess.ProfileDir,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.ProfileDir),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{ // The URL of a Pyroscope compatible server profiles are pushed to. Empty means they aren't pushed
+		Name:    dsess.ProfileEndpoint,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.ProfileEndpoint),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{ // The number of profiles of each type kept in dolt_profile_dir, or zero to keep all of them
+		Name:    dsess.ProfileRetention,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.ProfileRetention, 0, math.MaxInt32, false),
+		Default: int64(24),
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.DatabaseTemplate,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.DatabaseTemplate),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.TransactionalDDL,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemBoolType(dsess.TransactionalDDL),
+		Default: int8(0),
+	},
+	&sql.MysqlSystemVariable{ // The comma separated branches whose events sql-server runs, in addition to those of the default branch
+		Name:    dsess.EventBranches,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.EventBranches),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{ // Whether sql-server runs the events it missed while it was down once when it starts, or skips them
+		Name:    dsess.EventCatchUp,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemEnumType(dsess.EventCatchUp, "skip", "once"),
+		Default: "skip",
+	},
+	&sql.MysqlSystemVariable{ // The number of recent event executions kept in dolt_events_history
+		Name:    dsess.EventsHistorySize,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemIntType(dsess.EventsHistorySize, 1, math.MaxInt32, false),
+		Default: int64(1000),
+	},
+	&sql.MysqlSystemVariable{ // The directory external tables may read files from, in addition to any secure_file_priv restriction
+		Name:    dsess.ExternalTablesDir,
+		Dynamic: false,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+		Type:    types.NewSystemStringType(dsess.ExternalTablesDir),
+		Default: "",
+	},
+	&sql.MysqlSystemVariable{
+		Name:    dsess.BackgroundFlush,
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemBoolType(dsess.BackgroundFlush),
+		Default: int8(0),
+	},
 	&sql.MysqlSystemVariable{
 		Name:    "dolt_dont_merge_json",
 		Dynamic: true,
@@ -212,6 +471,13 @@ var DoltSystemVariables = []sql.SystemVariable{
 		Type:    types.NewSystemBoolType("dolt_dont_merge_json"),
 		Default: int8(0),
 	},
+	&sql.MysqlSystemVariable{ // Report a conflict for any row changed differently on both sides of a merge, instead of merging the changes cell by cell
+		Name:    "dolt_dont_merge_cells",
+		Dynamic: true,
+		Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+		Type:    types.NewSystemBoolType("dolt_dont_merge_cells"),
+		Default: int8(0),
+	},
 	&sql.MysqlSystemVariable{
 		Name:    "dolt_optimize_json",
 		Dynamic: true,
@@ -280,6 +546,14 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemStringType("log_bin_branch"),
 			Default:           "main",
 		},
+		&sql.MysqlSystemVariable{
+			Name:              "binlog_format",
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemEnumType("binlog_format", "ROW", "STATEMENT", "MIXED"),
+			Default:           "ROW",
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.DoltOverrideSchema,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -344,6 +618,46 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.ReplicateAllHeads),
 			Default:           int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReplicateIncludeBranches,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.ReplicateIncludeBranches),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReplicateExcludeBranches,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.ReplicateExcludeBranches),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReplicateIncludeTables,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.ReplicateIncludeTables),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReplicateExcludeTables,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.ReplicateExcludeTables),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.ReplicateTags,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemBoolType(dsess.ReplicateTags),
+			Default:           int8(1),
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.AsyncReplication,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
@@ -352,6 +666,38 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemBoolType(dsess.AsyncReplication),
 			Default:           int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.AsyncReplicationQueueSize,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.AsyncReplicationQueueSize, 1, math.MaxInt32, false),
+			Default:           int64(2048),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.AsyncReplicationDropPolicy,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemEnumType(dsess.AsyncReplicationDropPolicy, "block", "drop"),
+			Default:           "block",
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.StorageQuotaBytes,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemIntType(dsess.StorageQuotaBytes, 0, math.MaxInt64, false),
+			Default:           int64(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:              dsess.DatabaseStorageQuotas,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.DatabaseStorageQuotas),
+			Default:           "",
+		},
 		&sql.MysqlSystemVariable{ // If true, causes a Dolt commit to occur when you commit a transaction.
 			Name:              dsess.DoltCommitOnTransactionCommit,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Both),
@@ -368,6 +714,22 @@ func AddDoltSystemVariables() {
 			Type:              types.NewSystemStringType(dsess.DoltCommitOnTransactionCommitMessage),
 			Default:           "",
 		},
+		&sql.MysqlSystemVariable{ // If set, the author of the session's commits, in the "Name <email>" format
+			Name:              dsess.DoltCommitAuthor,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Session),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.DoltCommitAuthor),
+			Default:           "",
+		},
+		&sql.MysqlSystemVariable{ // If set, the committer of the session's commits instead of the SQL user. Requires SUPER.
+			Name:              dsess.DoltCommitCommitter,
+			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Session),
+			Dynamic:           true,
+			SetVarHintApplies: false,
+			Type:              types.NewSystemStringType(dsess.DoltCommitCommitter),
+			Default:           "",
+		},
 		&sql.MysqlSystemVariable{
 			Name:              dsess.TransactionsDisabledSysVar,
 			Scope:             sql.GetMysqlScope(sql.SystemVariableScope_Session),
@@ -446,6 +808,167 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType(dsess.ShowSystemTables),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.OnlineIndexBuild,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemBoolType(dsess.OnlineIndexBuild),
+			Default: int8(0),
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.ScanParallelism,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemIntType(dsess.ScanParallelism, 0, 1024, false),
+			Default: int64(0),
+		},
+		&sql.MysqlSystemVariable{ // If non-zero, statements which return more rows than this fail on the sql-server
+			Name:    dsess.MaxResultRows,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemIntType(dsess.MaxResultRows, 0, math.MaxInt64, false),
+			Default: int64(0),
+		},
+		&sql.MysqlSystemVariable{ // If non-zero, statements which fetch more bytes than this from remote databases fail on the sql-server
+			Name:    dsess.RemoteFetchBudget,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemIntType(dsess.RemoteFetchBudget, 0, math.MaxInt64, false),
+			Default: int64(0),
+		},
+		&sql.MysqlSystemVariable{ // The number of times the sql-server retries an autocommit write whose transaction conflicts with another
+			Name:    dsess.AutocommitRetries,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemIntType(dsess.AutocommitRetries, 0, 10, false),
+			Default: int64(0),
+		},
+		&sql.MysqlSystemVariable{ // The directory for scratch files, such as the sorted runs of index builds. Empty means the default temp directory
+			Name:    dsess.ScratchDir,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemStringType(dsess.ScratchDir),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{ // If non-zero, the most bytes of scratch files a session may hold at once
+			Name:    dsess.ScratchQuota,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemIntType(dsess.ScratchQuota, 0, math.MaxInt64, false),
+			Default: int64(0),
+		},
+		&sql.MysqlSystemVariable{ // Whether sql-server records statements in dolt_query_log and aggregates them in dolt_query_summary
+			Name:    dsess.QueryLog,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemBoolType(dsess.QueryLog),
+			Default: int8(0),
+		},
+		&sql.MysqlSystemVariable{ // The number of recent statements kept in dolt_query_log
+			Name:    dsess.QueryLogSize,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.QueryLogSize, 1, math.MaxInt32, false),
+			Default: int64(1000),
+		},
+		&sql.MysqlSystemVariable{ // The number of digests aggregated in dolt_query_summary, after which new digests are counted together
+			Name:    dsess.QueryLogMaxDigests,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.QueryLogMaxDigests, 1, math.MaxInt32, false),
+			Default: int64(10000),
+		},
+		&sql.MysqlSystemVariable{ // If non-zero, the seconds between the profiles sql-server captures of itself. Zero disables profiling
+			Name:    dsess.ProfileInterval,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.ProfileInterval, 0, math.MaxInt32, false),
+			Default: int64(0),
+		},
+		&sql.MysqlSystemVariable{ // The comma separated types of profile captured: cpu, or any profile of runtime/pprof such as heap or goroutine
+			Name:    dsess.ProfileTypes,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.ProfileTypes),
+			Default: "cpu,heap,goroutine",
+		},
+		&sql.MysqlSystemVariable{ // The seconds each cpu profile samples for
+			Name:    dsess.ProfileCPUSeconds,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.ProfileCPUSeconds, 1, 3600, false),
+			Default: int64(10),
+		},
+		&sql.MysqlSystemVariable{ // The directory profiles are written to. Empty means they aren't written to files
+			Name:    dsess.ProfileDir,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.ProfileDir),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{ // The URL of a Pyroscope compatible server profiles are pushed to. Empty means they aren't pushed
+			Name:    dsess.ProfileEndpoint,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.ProfileEndpoint),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{ // The number of profiles of each type kept in dolt_profile_dir, or zero to keep all of them
+			Name:    dsess.ProfileRetention,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.ProfileRetention, 0, math.MaxInt32, false),
+			Default: int64(24),
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.DatabaseTemplate,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.DatabaseTemplate),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.TransactionalDDL,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemBoolType(dsess.TransactionalDDL),
+			Default: int8(0),
+		},
+		&sql.MysqlSystemVariable{ // The comma separated branches whose events sql-server runs, in addition to those of the default branch
+			Name:    dsess.EventBranches,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.EventBranches),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{ // Whether sql-server runs the events it missed while it was down once when it starts, or skips them
+			Name:    dsess.EventCatchUp,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemEnumType(dsess.EventCatchUp, "skip", "once"),
+			Default: "skip",
+		},
+		&sql.MysqlSystemVariable{ // The number of recent event executions kept in dolt_events_history
+			Name:    dsess.EventsHistorySize,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemIntType(dsess.EventsHistorySize, 1, math.MaxInt32, false),
+			Default: int64(1000),
+		},
+		&sql.MysqlSystemVariable{ // The directory external tables may read files from, in addition to any secure_file_priv restriction
+			Name:    dsess.ExternalTablesDir,
+			Dynamic: false,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Global),
+			Type:    types.NewSystemStringType(dsess.ExternalTablesDir),
+			Default: "",
+		},
+		&sql.MysqlSystemVariable{
+			Name:    dsess.BackgroundFlush,
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemBoolType(dsess.BackgroundFlush),
+			Default: int8(0),
+		},
 		&sql.MysqlSystemVariable{
 			Name:    "dolt_dont_merge_json",
 			Dynamic: true,
@@ -453,6 +976,13 @@ func AddDoltSystemVariables() {
 			Type:    types.NewSystemBoolType("dolt_dont_merge_json"),
 			Default: int8(0),
 		},
+		&sql.MysqlSystemVariable{ // Report a conflict for any row changed differently on both sides of a merge, instead of merging the changes cell by cell
+			Name:    "dolt_dont_merge_cells",
+			Dynamic: true,
+			Scope:   sql.GetMysqlScope(sql.SystemVariableScope_Both),
+			Type:    types.NewSystemBoolType("dolt_dont_merge_cells"),
+			Default: int8(0),
+		},
 		&sql.MysqlSystemVariable{
 			Name:    dsess.DoltStatsEnabled,
 			Dynamic: true,
@@ -50,6 +50,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor/creation"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -212,8 +213,25 @@ func (t *DoltTable) LookupForExpressions(ctx *sql.Context, exprs ...sql.Expressi
 		}
 	}
 
-	return sql.IndexLookup{}, nil, nil, false, nil
-
+	// without a strict lookup, equalities on the trailing columns of an index can still skip-scan it
+	indexes, err := t.GetIndexes(ctx)
+	if err != nil {
+		return sql.IndexLookup{}, nil, nil, false, err
+	}
+	skipLookup, fds, covered, ok, err := index.GetSkipScanLookup(ctx, schCols, indexes, exprs, lookupCols)
+	if err != nil || !ok {
+		return sql.IndexLookup{}, nil, nil, false, err
+	}
+	for i, c := range lookupCols {
+		if !covered[i] || !expression.PreciseComparison(c.Eq) {
+			if leftoverExpr == nil {
+				leftoverExpr = c.Eq
+			} else {
+				leftoverExpr = expression.NewAnd(leftoverExpr, c.Eq)
+			}
+		}
+	}
+	return skipLookup, fds, leftoverExpr, true, nil
 }
 
 func NewDoltTable(name string, sch schema.Schema, tbl *doltdb.Table, db dsess.SqlDatabase, opts editor.Options) (*DoltTable, error) {
@@ -561,6 +579,18 @@ func (t *DoltTable) Partitions(ctx *sql.Context) (sql.PartitionIter, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	// partitioned tables are split along their partition boundaries
+	if t.overriddenSchema == nil {
+		partitions, ok, err := partitionsForSchema(ctx, t.sch, rows)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return newDoltTablePartitionIter(rows, partitions...), nil
+		}
+	}
+
 	partitions, err := partitionsFromRows(ctx, rows)
 	if err != nil {
 		return nil, err
@@ -627,6 +657,7 @@ func (t *DoltTable) PartitionRows(ctx *sql.Context, partition sql.Partition) (sq
 	}
 
 	originalRowIter, err := partitionRows(ctx, table, projCols, partition)
+	originalRowIter, err = countRowsExamined(ctx, originalRowIter, err)
 	if err != nil {
 		return originalRowIter, err
 	}
@@ -1482,7 +1513,7 @@ type doltTablePartition struct {
 	rowData durable.Index
 }
 
-func partitionsFromRows(ctx context.Context, rows durable.Index) ([]doltTablePartition, error) {
+func partitionsFromRows(ctx *sql.Context, rows durable.Index) ([]doltTablePartition, error) {
 	empty, err := rows.Empty()
 	if err != nil {
 		return nil, err
@@ -1493,10 +1524,80 @@ func partitionsFromRows(ctx context.Context, rows durable.Index) ([]doltTablePar
 		}, nil
 	}
 
-	return partitionsFromTableRows(rows)
+	parallelism, err := scanParallelism(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if types.IsFormat_DOLT(rows.Format()) {
+		return partitionsFromSubtrees(ctx, rows, parallelism)
+	}
+	return partitionsFromTableRows(rows, parallelism)
 }
 
-func partitionsFromTableRows(rows durable.Index) ([]doltTablePartition, error) {
+// scanParallelism returns the number of threads table scans should be split for, which is the value of
+// @@dolt_scan_parallelism, or the number of CPUs if it isn't set.
+func scanParallelism(ctx *sql.Context) (int, error) {
+	v, err := ctx.GetSessionVariable(ctx, dsess.ScanParallelism)
+	if err != nil {
+		return 0, err
+	}
+	if parallelism, ok := v.(int64); ok && parallelism > 0 {
+		return int(parallelism), nil
+	}
+	return runtime.NumCPU(), nil
+}
+
+// numPartitionsForRows returns the number of partitions to split |numElements| rows into for |parallelism| threads.
+func numPartitionsForRows(numElements uint64, parallelism int) uint64 {
+	numPartitions := (numElements / MaxRowsPerPartition) + 1
+	if target := uint64(partitionMultiplier * parallelism); numPartitions < target {
+		numPartitions = min(target, numElements)
+	}
+	return max(numPartitions, 1)
+}
+
+// partitionsFromSubtrees splits |rows| into partitions whose bounds fall on the bounds of the subtrees of the row
+// map, so that each partition reads whole chunks and no two partitions read the same chunk.
+func partitionsFromSubtrees(ctx context.Context, rows durable.Index, parallelism int) ([]doltTablePartition, error) {
+	m, err := durable.ProllyMapFromIndex(rows)
+	if err != nil {
+		return nil, err
+	}
+	cnt, err := m.Count()
+	if err != nil {
+		return nil, err
+	}
+	numElements := uint64(cnt)
+	numPartitions := numPartitionsForRows(numElements, parallelism)
+
+	bounds, err := tree.SubtreeBoundaries(ctx, m.Tuples(), int(numPartitions))
+	if err != nil {
+		return nil, err
+	}
+
+	// group adjacent subtrees into partitions of roughly equal size
+	partitions := make([]doltTablePartition, 0, numPartitions)
+	var start uint64
+	for i := uint64(1); i <= numPartitions && len(bounds) > 0; i++ {
+		target := numElements * i / numPartitions
+		j := 0
+		for j < len(bounds)-1 && bounds[j] < target {
+			j++
+		}
+		if bounds[j] <= start {
+			continue
+		}
+		partitions = append(partitions, doltTablePartition{start: start, end: bounds[j], rowData: rows})
+		start = bounds[j]
+		bounds = bounds[j+1:]
+	}
+	if start < numElements {
+		partitions = append(partitions, doltTablePartition{start: start, end: numElements, rowData: rows})
+	}
+	return partitions, nil
+}
+
+func partitionsFromTableRows(rows durable.Index, parallelism int) ([]doltTablePartition, error) {
 	numElements, err := rows.Count()
 	if err != nil {
 		return nil, err
@@ -1504,8 +1605,8 @@ func partitionsFromTableRows(rows durable.Index) ([]doltTablePartition, error) {
 	itemsPerPartition := MaxRowsPerPartition
 	numPartitions := (numElements / itemsPerPartition) + 1
 
-	if numPartitions < uint64(partitionMultiplier*runtime.NumCPU()) {
-		itemsPerPartition = numElements / uint64(partitionMultiplier*runtime.NumCPU())
+	if numPartitions < uint64(partitionMultiplier*parallelism) {
+		itemsPerPartition = numElements / uint64(partitionMultiplier*parallelism)
 		if itemsPerPartition == 0 {
 			itemsPerPartition = numElements
 			numPartitions = 1
@@ -2097,6 +2198,8 @@ func modifyIndexesForTableRewrite(ctx *sql.Context, oldSch schema.Schema, oldCol
 				Comment:            index.Comment(),
 				FullTextProperties: index.FullTextProperties(),
 				VectorProperties:   index.VectorProperties(),
+				Descending:         index.Descending(),
+				IsInvisible:        index.IsInvisible(),
 			})
 	}
 
@@ -2550,6 +2653,48 @@ func (t *AlterableDoltTable) RenameIndex(ctx *sql.Context, fromIndexName string,
 	return t.updateFromRoot(ctx, newRoot)
 }
 
+// AlterIndexVisibility implements sql.IndexVisibilityAlterableTable. Invisible indexes keep being maintained on
+// writes but are ignored by the optimizer, so that dropping an index can be tested without losing its data.
+func (t *AlterableDoltTable) AlterIndexVisibility(ctx *sql.Context, indexName string, visible bool) error {
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+	if strings.EqualFold(indexName, "PRIMARY") {
+		return fmt.Errorf("a primary key index cannot be invisible")
+	}
+
+	newSch := t.sch.Copy()
+	_, err := newSch.Indexes().SetIndexInvisible(indexName, !visible)
+	if err != nil {
+		return err
+	}
+
+	table, err := t.DoltTable.DoltTable(ctx)
+	if err != nil {
+		return err
+	}
+
+	newTable, err := table.UpdateSchema(ctx, newSch)
+	if err != nil {
+		return err
+	}
+
+	root, err := t.getRoot(ctx)
+	if err != nil {
+		return err
+	}
+	newRoot, err := root.PutTable(ctx, t.TableName(), newTable)
+	if err != nil {
+		return err
+	}
+
+	err = t.setRoot(ctx, newRoot)
+	if err != nil {
+		return err
+	}
+	return t.updateFromRoot(ctx, newRoot)
+}
+
 // CreateFulltextIndex implements fulltext.IndexAlterableTable
 func (t *AlterableDoltTable) CreateFulltextIndex(ctx *sql.Context, idx sql.IndexDef, keyCols fulltext.KeyColumns, tableNames fulltext.IndexTableNames) error {
 	if !types.IsFormat_DOLT(t.Format()) {
@@ -2585,7 +2730,7 @@ func (t *AlterableDoltTable) createIndex(ctx *sql.Context, idx sql.IndexDef, key
 		}
 	}
 
-	ret, err := creation.CreateIndex(ctx, table, t.Name(), idx.Name, columns, allocatePrefixLengths(idx.Columns), schema.IndexProperties{
+	props := schema.IndexProperties{
 		IsUnique:      idx.Constraint == sql.IndexConstraint_Unique,
 		IsSpatial:     idx.Constraint == sql.IndexConstraint_Spatial,
 		IsFullText:    idx.Constraint == sql.IndexConstraint_Fulltext,
@@ -2603,7 +2748,19 @@ func (t *AlterableDoltTable) createIndex(ctx *sql.Context, idx sql.IndexDef, key
 			KeyPositions:     keyPositions,
 		},
 		VectorProperties: vectorProperties,
-	}, t.opts)
+		Descending:       indexDirectionsFromStatement(ctx.Query(), idx),
+	}
+
+	build, online, err := t.onlineIndexBuilder(ctx, idx)
+	if err != nil {
+		return err
+	}
+	var ret *creation.CreateIndexReturn
+	if online {
+		ret, err = creation.CreateIndexWithBuilder(ctx, table, t.Name(), idx.Name, columns, allocatePrefixLengths(idx.Columns), props, build)
+	} else {
+		ret, err = creation.CreateIndex(ctx, table, t.Name(), idx.Name, columns, allocatePrefixLengths(idx.Columns), props, t.opts)
+	}
 	if err != nil {
 		return err
 	}
@@ -3233,8 +3390,91 @@ func (t *AlterableDoltTable) DropCheck(ctx *sql.Context, chName string) error {
 	return t.updateFromRoot(ctx, newRoot)
 }
 
+// ModifyStoredCollation implements sql.CollationAlterableTable. It converts every character column to |collation| and
+// makes it the table's default collation. The table is rewritten so that stored values are checked against the new
+// character set and the primary and secondary indexes are rebuilt in the new collation's order.
 func (t *AlterableDoltTable) ModifyStoredCollation(ctx *sql.Context, collation sql.CollationID) error {
-	return fmt.Errorf("converting the collations of columns is not yet supported")
+	if err := dsess.CheckAccessForDb(ctx, t.db, branch_control.Permissions_Write); err != nil {
+		return err
+	}
+
+	oldSchema := t.PrimaryKeySchema()
+	newSchema, converted, err := convertSchemaCollation(oldSchema, collation)
+	if err != nil {
+		return err
+	}
+	if !converted {
+		return t.ModifyDefaultCollation(ctx, collation)
+	}
+
+	// the partitions read the table as of this point, before the rewrite truncates it
+	partitions, err := t.Partitions(ctx)
+	if err != nil {
+		return err
+	}
+	rows := sql.NewTableRowIter(ctx, t, partitions)
+	defer rows.Close(ctx)
+
+	inserter, err := t.RewriteInserter(ctx, oldSchema, newSchema, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	inserter.StatementBegin(ctx)
+
+	err = func() error {
+		for {
+			row, err := rows.Next(ctx)
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+
+			newRow := make(sql.Row, len(row))
+			for i, v := range row {
+				newRow[i], _, err = newSchema.Schema[i].Type.Convert(ctx, v)
+				if err != nil {
+					return err
+				}
+			}
+			if err = inserter.Insert(ctx, newRow); err != nil {
+				return err
+			}
+		}
+	}()
+	if err != nil {
+		_ = inserter.DiscardChanges(ctx, err)
+		_ = inserter.Close(ctx)
+		return err
+	}
+	if err = inserter.StatementComplete(ctx); err != nil {
+		return err
+	}
+	if err = inserter.Close(ctx); err != nil {
+		return err
+	}
+
+	return t.ModifyDefaultCollation(ctx, collation)
+}
+
+// convertSchemaCollation returns a copy of |sch| with every character column converted to |collation|. Binary columns
+// are left unchanged. Returns false if the schema has no character columns.
+func convertSchemaCollation(sch sql.PrimaryKeySchema, collation sql.CollationID) (sql.PrimaryKeySchema, bool, error) {
+	var converted bool
+	newSch := make(sql.Schema, len(sch.Schema))
+	for i, col := range sch.Schema {
+		newCol := col.Copy()
+		if collatedType, ok := col.Type.(sql.TypeWithCollation); ok && collatedType.Collation() != sql.Collation_binary {
+			typ, err := collatedType.WithNewCollation(collation)
+			if err != nil {
+				return sql.PrimaryKeySchema{}, false, err
+			}
+			newCol.Type = typ
+			converted = true
+		}
+		newSch[i] = newCol
+	}
+	return sql.NewPrimaryKeySchema(newSch, sch.PkOrdinals...), converted, nil
 }
 
 func (t *AlterableDoltTable) ModifyDefaultCollation(ctx *sql.Context, collation sql.CollationID) error {
@@ -15,12 +15,54 @@
 package sqle
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
 )
 
 func TestMinRowsPerPartitionInTests(t *testing.T) {
 	// If this fails then the method for determining if we are running in a test doesn't work all the time.
 	assert.Equal(t, uint64(2), MinRowsPerPartition)
 }
+
+func TestPartitionsFromSubtrees(t *testing.T) {
+	ctx := context.Background()
+	ns := tree.NewTestNodeStore()
+
+	for _, count := range []int{1, 100, 10_000, 100_000} {
+		tuples, desc := tree.AscendingUintTuples(count)
+		flat := make([]val.Tuple, 0, len(tuples)*2)
+		for _, tup := range tuples {
+			flat = append(flat, tup[0], tup[1])
+		}
+		m, err := prolly.NewMapFromTuples(ctx, ns, desc, desc, flat...)
+		require.NoError(t, err)
+		rows := durable.IndexFromProllyMap(m)
+
+		for _, parallelism := range []int{1, 4, 16} {
+			t.Run(fmt.Sprintf("count: %d, parallelism: %d", count, parallelism), func(t *testing.T) {
+				partitions, err := partitionsFromSubtrees(ctx, rows, parallelism)
+				require.NoError(t, err)
+				require.NotEmpty(t, partitions)
+				assert.LessOrEqual(t, uint64(len(partitions)), numPartitionsForRows(uint64(count), parallelism))
+
+				// partitions cover every row exactly once
+				var start uint64
+				for _, p := range partitions {
+					assert.Equal(t, start, p.start)
+					assert.Less(t, p.start, p.end)
+					start = p.end
+				}
+				assert.Equal(t, uint64(count), start)
+			})
+		}
+	}
+}
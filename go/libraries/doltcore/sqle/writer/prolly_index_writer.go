@@ -30,7 +30,17 @@ import (
 	"github.com/dolthub/dolt/go/store/val"
 )
 
-func getPrimaryProllyWriter(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState) (prollyIndexWriter, error) {
+// mutateForWrites returns a MutableMap of |m| for a write session. If |backgroundFlush| is set, pending writes are
+// flushed on a background goroutine, so that large writes don't stall while each batch is applied to the tree.
+func mutateForWrites(m prolly.Map, backgroundFlush bool) *prolly.MutableMap {
+	mut := m.Mutate()
+	if backgroundFlush {
+		mut = mut.WithBackgroundFlush()
+	}
+	return mut
+}
+
+func getPrimaryProllyWriter(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState, backgroundFlush bool) (prollyIndexWriter, error) {
 	idx, err := t.GetRowDataWithDescriptors(ctx, schState.PkKeyDesc, schState.PkValDesc)
 	if err != nil {
 		return prollyIndexWriter{}, err
@@ -44,7 +54,7 @@ func getPrimaryProllyWriter(ctx context.Context, t *doltdb.Table, schState *dses
 	keyDesc, valDesc := m.Descriptors()
 
 	return prollyIndexWriter{
-		mut:    m.Mutate(),
+		mut:    mutateForWrites(m, backgroundFlush),
 		keyBld: val.NewTupleBuilder(keyDesc, m.NodeStore()),
 		keyMap: schState.PriIndex.KeyMapping,
 		valBld: val.NewTupleBuilder(valDesc, m.NodeStore()),
@@ -52,7 +62,7 @@ func getPrimaryProllyWriter(ctx context.Context, t *doltdb.Table, schState *dses
 	}, nil
 }
 
-func getPrimaryKeylessProllyWriter(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState) (prollyKeylessWriter, error) {
+func getPrimaryKeylessProllyWriter(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState, backgroundFlush bool) (prollyKeylessWriter, error) {
 	idx, err := t.GetRowData(ctx)
 	if err != nil {
 		return prollyKeylessWriter{}, err
@@ -66,7 +76,7 @@ func getPrimaryKeylessProllyWriter(ctx context.Context, t *doltdb.Table, schStat
 	keyDesc, valDesc := m.Descriptors()
 
 	return prollyKeylessWriter{
-		mut:    m.Mutate(),
+		mut:    mutateForWrites(m, backgroundFlush),
 		keyBld: val.NewTupleBuilder(keyDesc, m.NodeStore()),
 		valBld: val.NewTupleBuilder(valDesc, m.NodeStore()),
 		valMap: schState.PriIndex.ValMapping,
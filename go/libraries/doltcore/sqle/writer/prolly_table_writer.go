@@ -26,6 +26,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/globalstate"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
 	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
@@ -59,7 +60,7 @@ type prollyTableWriter struct {
 var _ dsess.TableWriter = &prollyTableWriter{}
 var _ AutoIncrementGetter = &prollyTableWriter{}
 
-func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState) (map[string]indexWriter, error) {
+func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState, backgroundFlush bool) (map[string]indexWriter, error) {
 	s, err := t.GetIndexSet(ctx)
 	if err != nil {
 		return nil, err
@@ -82,10 +83,15 @@ func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, schSta
 
 		keyDesc, _ := idxMap.Descriptors()
 
+		mut := idxMap.MutateInterface()
+		if m, ok := mut.(*prolly.MutableMap); ok && backgroundFlush {
+			mut = m.WithBackgroundFlush()
+		}
+
 		// mapping from secondary index key to primary key
 		writers[defName] = prollySecondaryIndexWriter{
 			name:          defName,
-			mut:           idxMap.MutateInterface(),
+			mut:           mut,
 			unique:        def.IsUnique,
 			prefixLengths: def.PrefixLengths,
 			idxCols:       def.Count,
@@ -99,7 +105,7 @@ func getSecondaryProllyIndexWriters(ctx context.Context, t *doltdb.Table, schSta
 	return writers, nil
 }
 
-func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState, primary prollyKeylessWriter) (map[string]indexWriter, error) {
+func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, schState *dsess.WriterState, primary prollyKeylessWriter, backgroundFlush bool) (map[string]indexWriter, error) {
 	s, err := t.GetIndexSet(ctx)
 	if err != nil {
 		return nil, err
@@ -125,7 +131,7 @@ func getSecondaryKeylessProllyWriters(ctx context.Context, t *doltdb.Table, schS
 
 		writers[defName] = prollyKeylessSecondaryWriter{
 			name:          defName,
-			mut:           m.Mutate(),
+			mut:           mutateForWrites(m, backgroundFlush),
 			primary:       primary,
 			unique:        def.IsUnique,
 			spatial:       def.IsSpatial,
@@ -298,25 +304,29 @@ func (w *prollyTableWriter) Reset(ctx *sql.Context, sess *prollyWriteSession, tb
 	if err != nil {
 		return err
 	}
+	backgroundFlush, err := backgroundFlushEnabled(ctx)
+	if err != nil {
+		return err
+	}
 
 	var newPrimary indexWriter
 
 	var newSecondaries map[string]indexWriter
 	if schema.IsKeyless(sch) {
-		newPrimary, err = getPrimaryKeylessProllyWriter(ctx, tbl, schState)
+		newPrimary, err = getPrimaryKeylessProllyWriter(ctx, tbl, schState, backgroundFlush)
 		if err != nil {
 			return err
 		}
-		newSecondaries, err = getSecondaryKeylessProllyWriters(ctx, tbl, schState, newPrimary.(prollyKeylessWriter))
+		newSecondaries, err = getSecondaryKeylessProllyWriters(ctx, tbl, schState, newPrimary.(prollyKeylessWriter), backgroundFlush)
 		if err != nil {
 			return err
 		}
 	} else {
-		newPrimary, err = getPrimaryProllyWriter(ctx, tbl, schState)
+		newPrimary, err = getPrimaryProllyWriter(ctx, tbl, schState, backgroundFlush)
 		if err != nil {
 			return err
 		}
-		newSecondaries, err = getSecondaryProllyIndexWriters(ctx, tbl, schState)
+		newSecondaries, err = getSecondaryProllyIndexWriters(ctx, tbl, schState, backgroundFlush)
 		if err != nil {
 			return err
 		}
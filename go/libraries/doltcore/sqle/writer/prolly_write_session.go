@@ -72,24 +72,28 @@ func (s *prollyWriteSession) GetTableWriter(ctx *sql.Context, tableName doltdb.T
 	if err != nil {
 		return nil, err
 	}
+	backgroundFlush, err := backgroundFlushEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	var pw indexWriter
 	var sws map[string]indexWriter
 	if schema.IsKeyless(schState.DoltSchema) {
-		pw, err = getPrimaryKeylessProllyWriter(ctx, t, schState)
+		pw, err = getPrimaryKeylessProllyWriter(ctx, t, schState, backgroundFlush)
 		if err != nil {
 			return nil, err
 		}
-		sws, err = getSecondaryKeylessProllyWriters(ctx, t, schState, pw.(prollyKeylessWriter))
+		sws, err = getSecondaryKeylessProllyWriters(ctx, t, schState, pw.(prollyKeylessWriter), backgroundFlush)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		pw, err = getPrimaryProllyWriter(ctx, t, schState)
+		pw, err = getPrimaryProllyWriter(ctx, t, schState, backgroundFlush)
 		if err != nil {
 			return nil, err
 		}
-		sws, err = getSecondaryProllyIndexWriters(ctx, t, schState)
+		sws, err = getSecondaryProllyIndexWriters(ctx, t, schState, backgroundFlush)
 		if err != nil {
 			return nil, err
 		}
@@ -114,6 +118,16 @@ func (s *prollyWriteSession) GetTableWriter(ctx *sql.Context, tableName doltdb.T
 	return twr, nil
 }
 
+// backgroundFlushEnabled returns whether table writers should flush pending writes on a background goroutine, which
+// is controlled by @@dolt_background_flush.
+func backgroundFlushEnabled(ctx *sql.Context) (bool, error) {
+	v, err := ctx.GetSessionVariable(ctx, dsess.BackgroundFlush)
+	if err != nil {
+		return false, err
+	}
+	return v == int8(1), nil
+}
+
 // Flush implemented WriteSession.
 func (s *prollyWriteSession) Flush(ctx *sql.Context) (*doltdb.WorkingSet, error) {
 	s.mut.Lock()
@@ -37,6 +37,19 @@ const (
 	fileMax   = 128
 )
 
+// scratchSession is implemented by sessions which configure where, and how much, scratch data their statements write.
+type scratchSession interface {
+	ScratchSpace(ctx *sql.Context) (tempfiles.TempFileProvider, error)
+}
+
+// scratchSpace returns the TempFileProvider for the sorted runs of an index build in |ctx|.
+func scratchSpace(ctx *sql.Context) (tempfiles.TempFileProvider, error) {
+	if sess, ok := ctx.Session.(scratchSession); ok {
+		return sess.ScratchSpace(ctx)
+	}
+	return tempfiles.MovableTempFileProvider, nil
+}
+
 // BuildProllyIndexExternal builds unique and non-unique indexes with a
 // single prolly tree materialization by presorting the index keys in an
 // intermediate file format.
@@ -62,9 +75,13 @@ func BuildProllyIndexExternal(ctx *sql.Context, vrw types.ValueReadWriter, ns tr
 		return BuildProximityIndex(ctx, ns, idx, keyDesc, prefixDesc, iter, secondaryBld, uniqCb)
 	}
 
+	scratch, err := scratchSpace(ctx)
+	if err != nil {
+		return nil, err
+	}
 	sorter := sort.NewTupleSorter(batchSize, fileMax, func(t1, t2 val.Tuple) bool {
 		return keyDesc.Compare(ctx, t1, t2) < 0
-	}, tempfiles.MovableTempFileProvider)
+	}, scratch)
 	defer sorter.Close()
 
 	for {
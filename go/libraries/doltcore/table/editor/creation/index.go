@@ -41,6 +41,10 @@ type CreateIndexReturn struct {
 	NewIndex schema.Index
 }
 
+// IndexBuildFn builds the row data for the new secondary index |idx| of |tbl|, whose schema already includes |idx|.
+// It returns the table to store the index with, which may have row data newer than |tbl|, and the index row data.
+type IndexBuildFn func(ctx *sql.Context, tbl *doltdb.Table, idx schema.Index) (*doltdb.Table, durable.Index, error)
+
 // CreateIndex creates the given index on the given table with the given schema. Returns the updated table, updated schema, and created index.
 func CreateIndex(
 	ctx *sql.Context,
@@ -51,6 +55,24 @@ func CreateIndex(
 	prefixLengths []uint16,
 	props schema.IndexProperties,
 	opts editor.Options,
+) (*CreateIndexReturn, error) {
+	build := func(ctx *sql.Context, tbl *doltdb.Table, idx schema.Index) (*doltdb.Table, durable.Index, error) {
+		indexRows, err := BuildSecondaryIndex(ctx, tbl, idx, tableName, opts)
+		return tbl, indexRows, err
+	}
+	return CreateIndexWithBuilder(ctx, table, tableName, indexName, columns, prefixLengths, props, build)
+}
+
+// CreateIndexWithBuilder is CreateIndex, but uses |build| to build the index row data.
+func CreateIndexWithBuilder(
+	ctx *sql.Context,
+	table *doltdb.Table,
+	tableName string,
+	indexName string,
+	columns []string,
+	prefixLengths []uint16,
+	props schema.IndexProperties,
+	build IndexBuildFn,
 ) (*CreateIndexReturn, error) {
 	sch, err := table.GetSchema(ctx)
 	if err != nil {
@@ -114,7 +136,7 @@ func CreateIndex(
 
 	// TODO: in the case that we're replacing an implicit index with one the user specified, we could do this more
 	//  cheaply in some cases by just renaming it, rather than building it from scratch. But that's harder to get right.
-	indexRows, err := BuildSecondaryIndex(ctx, newTable, index, tableName, opts)
+	newTable, indexRows, err := build(ctx, newTable, index)
 	if err != nil {
 		return nil, err
 	}
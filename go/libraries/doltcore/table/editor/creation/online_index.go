@@ -0,0 +1,324 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/index"
+	"github.com/dolthub/dolt/go/store/pool"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/sort"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// maxCatchUpRounds bounds how many times an online index build chases concurrent writes. If the table is still
+// changing after this many rounds, the index is returned for the latest row data it has caught up to, and the
+// remaining changes are reconciled when the transaction commits.
+const maxCatchUpRounds = 8
+
+// minRowsPerOnlineBuildWorker is the fewest snapshot rows each worker of an online index build is given. Smaller
+// snapshots are built with fewer workers, since merging their sorted runs would cost more than it saves.
+var minRowsPerOnlineBuildWorker = 64 * 1024
+
+// onlineBuildProgressInterval is how many rows an online index build worker reads between progress updates.
+const onlineBuildProgressInterval = 4096
+
+// OnlineIndexBuildWorkers is the number of background workers an online index build splits the table's rows between.
+var OnlineIndexBuildWorkers = runtime.GOMAXPROCS(0)
+
+// LatestRowsFn returns the most recent row data of the table an online index is being built for.
+type LatestRowsFn func(ctx *sql.Context) (prolly.Map, error)
+
+// OnlineProgressFn receives the phase of an online index build and how far through that phase it is.
+type OnlineProgressFn func(phase string, done, total int64)
+
+// BuildSecondaryIndexOnline builds |idx| from the |snapshot| row data without blocking writers to the table, then
+// catches up with writes made while it was building by applying the changes between |snapshot| and the row data
+// returned by |latest| to the new index. The snapshot is split between OnlineIndexBuildWorkers background workers,
+// and each catch-up round only applies the rows changed since the previous round. It returns the index along with the row data the index reflects, which the
+// caller must swap in together with the index.
+func BuildSecondaryIndexOnline(
+	ctx *sql.Context,
+	vrw types.ValueReadWriter,
+	ns tree.NodeStore,
+	sch schema.Schema,
+	tableName string,
+	idx schema.Index,
+	snapshot prolly.Map,
+	latest LatestRowsFn,
+	progress OnlineProgressFn,
+) (durable.Index, prolly.Map, error) {
+	secondary, err := buildSnapshotIndex(ctx, vrw, ns, sch, tableName, idx, snapshot, OnlineIndexBuildWorkers, progress)
+	if err != nil {
+		return nil, prolly.Map{}, err
+	}
+
+	for i := 0; i < maxCatchUpRounds; i++ {
+		if err = ctx.Err(); err != nil {
+			return nil, prolly.Map{}, err
+		}
+		curr, err := latest(ctx)
+		if err != nil {
+			return nil, prolly.Map{}, err
+		}
+		if curr.HashOf() == snapshot.HashOf() {
+			break
+		}
+		progress("catching up", int64(i), maxCatchUpRounds)
+		secondary, err = ApplyPrimaryChangesToSecondary(ctx, sch, tableName, idx, secondary, snapshot, curr)
+		if err != nil {
+			return nil, prolly.Map{}, err
+		}
+		snapshot = curr
+	}
+
+	return durable.IndexFromProllyMap(secondary), snapshot, nil
+}
+
+// buildSnapshotIndex builds |idx| from the |snapshot| row data with up to |workers| goroutines. Each worker computes
+// the index keys of a contiguous range of the snapshot's rows and sorts them into its own run, and the sorted runs are
+// then merged into the index's prolly tree.
+func buildSnapshotIndex(
+	ctx *sql.Context,
+	vrw types.ValueReadWriter,
+	ns tree.NodeStore,
+	sch schema.Schema,
+	tableName string,
+	idx schema.Index,
+	snapshot prolly.Map,
+	workers int,
+	progress OnlineProgressFn,
+) (prolly.Map, error) {
+	cnt, err := snapshot.Count()
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	total := int64(cnt)
+	progress("building", 0, total)
+
+	if workers < 1 {
+		workers = 1
+	}
+	if maxWorkers := (cnt + minRowsPerOnlineBuildWorker - 1) / minRowsPerOnlineBuildWorker; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if workers <= 1 {
+		built, err := BuildSecondaryProllyIndex(ctx, vrw, ns, sch, tableName, idx, snapshot)
+		if err != nil {
+			return prolly.Map{}, err
+		}
+		progress("building", total, total)
+		return durable.ProllyMapFromIndex(built)
+	}
+
+	keyDesc, _ := idx.Schema().GetMapDescriptors(ns)
+	if schema.IsKeyless(sch) {
+		keyDesc = prolly.AddHashToSchema(keyDesc)
+	}
+	prefixDesc := keyDesc.PrefixDesc(idx.Count())
+	var uniqCb DupEntryCb
+	if idx.IsUnique() {
+		kd := idx.Schema().GetKeyDescriptor(ns)
+		uniqCb = func(ctx context.Context, existingKey, newKey val.Tuple) error {
+			msg := FormatKeyForUniqKeyErr(ctx, newKey, kd)
+			return sql.NewUniqueKeyErr(msg, false, nil)
+		}
+	}
+	keyCmp := func(t1, t2 val.Tuple) bool {
+		return keyDesc.Compare(ctx, t1, t2) < 0
+	}
+	scratch, err := scratchSpace(ctx)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+
+	runs := make([]sortedRun, workers)
+	defer func() {
+		for _, run := range runs {
+			if run != nil {
+				run.Close()
+			}
+		}
+	}()
+
+	var done atomic.Int64
+	eg, egCtx := errgroup.WithContext(ctx)
+	sqlEgCtx := ctx.WithContext(egCtx)
+	for i := 0; i < workers; i++ {
+		i := i
+		start, stop := uint64(cnt*i/workers), uint64(cnt*(i+1)/workers)
+		eg.Go(func() error {
+			// key builders and buffer pools aren't safe for concurrent use, so each worker gets its own
+			p := pool.NewBuffPool()
+			secondaryBld, err := index.NewSecondaryKeyBuilder(sqlEgCtx, tableName, sch, idx, keyDesc, p, ns)
+			if err != nil {
+				return err
+			}
+			iter, err := snapshot.IterOrdinalRange(sqlEgCtx, start, stop)
+			if err != nil {
+				return err
+			}
+
+			sorter := sort.NewTupleSorter(batchSize/workers, fileMax, keyCmp, scratch)
+			defer sorter.Close()
+			var read int64
+			for {
+				k, v, err := iter.Next(sqlEgCtx)
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					return err
+				}
+				if read++; read%onlineBuildProgressInterval == 0 {
+					progress("building", done.Add(onlineBuildProgressInterval), total)
+				}
+
+				idxKey, err := secondaryBld.SecondaryKeyFromRow(sqlEgCtx, k, v)
+				if err != nil {
+					return err
+				}
+				if uniqCb != nil && prefixDesc.HasNulls(idxKey) {
+					continue
+				}
+				if err := sorter.Insert(sqlEgCtx, idxKey); err != nil {
+					return err
+				}
+			}
+			progress("building", done.Add(read%onlineBuildProgressInterval), total)
+
+			runs[i], err = sorter.Flush(sqlEgCtx)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return prolly.Map{}, err
+	}
+
+	iters := make([]sort.KeyIter, len(runs))
+	for i, run := range runs {
+		iters[i], err = run.IterAll(ctx)
+		if err != nil {
+			for _, iter := range iters[:i] {
+				iter.Close()
+			}
+			return prolly.Map{}, err
+		}
+	}
+	merged, err := sort.MergeKeyIters(ctx, keyCmp, iters...)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	defer merged.Close()
+
+	empty, err := durable.NewEmptyIndexFromTableSchema(ctx, vrw, ns, idx, sch)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	secondary, err := durable.ProllyMapFromIndex(empty)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+
+	tupIter := &tupleIterWithCb{iter: merged, prefixDesc: prefixDesc, uniqCb: uniqCb}
+	secondary, err = prolly.MutateMapWithTupleIter(ctx, secondary, tupIter)
+	if err != nil {
+		return prolly.Map{}, err
+	}
+	if tupIter.err != nil {
+		return prolly.Map{}, tupIter.err
+	}
+	return secondary, nil
+}
+
+// sortedRun is the sorted output of a tuple sorter.
+type sortedRun interface {
+	IterAll(context.Context) (sort.KeyIter, error)
+	Close()
+}
+
+// ApplyPrimaryChangesToSecondary updates the secondary index |secondary| for the row changes between the primary row
+// data |from| and |to|. For unique indexes, a sql.UniqueKeyErr is returned if a changed row collides with an existing
+// index entry.
+func ApplyPrimaryChangesToSecondary(
+	ctx *sql.Context,
+	sch schema.Schema,
+	tableName string,
+	idx schema.Index,
+	secondary prolly.Map,
+	from, to prolly.Map,
+) (prolly.Map, error) {
+	kd := secondary.KeyDesc()
+	prefixDesc := kd.PrefixDesc(idx.Count())
+	secondaryBld, err := index.NewSecondaryKeyBuilder(ctx, tableName, sch, idx, kd, to.Pool(), secondary.NodeStore())
+	if err != nil {
+		return prolly.Map{}, err
+	}
+
+	// deletes are applied before inserts so that rows which swap indexed values don't collide with each other
+	mut := secondary.Mutate()
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, diff tree.Diff) error {
+		if diff.Type == tree.AddedDiff {
+			return nil
+		}
+		idxKey, err := secondaryBld.SecondaryKeyFromRow(ctx, val.Tuple(diff.Key), val.Tuple(diff.From))
+		if err != nil {
+			return err
+		}
+		return mut.Delete(ctx, idxKey)
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return prolly.Map{}, err
+	}
+
+	err = prolly.DiffMaps(ctx, from, to, false, func(_ context.Context, diff tree.Diff) error {
+		if diff.Type == tree.RemovedDiff {
+			return nil
+		}
+		idxKey, err := secondaryBld.SecondaryKeyFromRow(ctx, val.Tuple(diff.Key), val.Tuple(diff.To))
+		if err != nil {
+			return err
+		}
+		if idx.IsUnique() && !prefixDesc.HasNulls(idxKey) {
+			err = mut.GetPrefix(ctx, idxKey, prefixDesc, func(existingKey, _ val.Tuple) error {
+				if existingKey != nil {
+					msg := FormatKeyForUniqKeyErr(ctx, idxKey, kd)
+					return sql.NewUniqueKeyErr(msg, false, nil)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return mut.Put(ctx, idxKey, val.EmptyTuple)
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return prolly.Map{}, err
+	}
+
+	return mut.Map(ctx)
+}
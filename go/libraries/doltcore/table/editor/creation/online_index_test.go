@@ -0,0 +1,104 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creation
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+func TestBuildSnapshotIndexWorkers(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	vrw := types.NewMemoryValueStore()
+	ns := tree.NewTestNodeStore()
+
+	sch := schema.MustSchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 1, types.IntKind, true, schema.NotNullConstraint{}),
+		schema.NewColumn("c1", 2, types.IntKind, false),
+	))
+	c1Idx, err := sch.Indexes().AddIndexByColTags("c1_idx", []uint64{2}, nil, schema.IndexProperties{IsUserDefined: true})
+	require.NoError(t, err)
+	uniqIdx, err := sch.Indexes().AddIndexByColTags("uniq_idx", []uint64{2}, nil, schema.IndexProperties{IsUnique: true, IsUserDefined: true})
+	require.NoError(t, err)
+
+	const rows = 1000
+	kd, vd := sch.GetMapDescriptors(ns)
+	kb, vb := val.NewTupleBuilder(kd, ns), val.NewTupleBuilder(vd, ns)
+	tups := make([]val.Tuple, 0, rows*2)
+	for i := 0; i < rows; i++ {
+		kb.PutInt64(0, int64(i))
+		vb.PutInt64(0, int64((i*7919)%rows))
+		k, err := kb.Build(ns.Pool())
+		require.NoError(t, err)
+		v, err := vb.Build(ns.Pool())
+		require.NoError(t, err)
+		tups = append(tups, k, v)
+	}
+	primary, err := prolly.NewMapFromTuples(ctx, ns, kd, vd, tups...)
+	require.NoError(t, err)
+
+	defer func(minRows int) { minRowsPerOnlineBuildWorker = minRows }(minRowsPerOnlineBuildWorker)
+	minRowsPerOnlineBuildWorker = 100
+
+	var lastDone, lastTotal int64
+	progress := func(phase string, done, total int64) {
+		lastDone, lastTotal = done, total
+	}
+
+	for _, idx := range []schema.Index{c1Idx, uniqIdx} {
+		t.Run(idx.Name(), func(t *testing.T) {
+			expected, err := BuildSecondaryProllyIndex(ctx, vrw, ns, sch, "t", idx, primary)
+			require.NoError(t, err)
+			expectedMap, err := durable.ProllyMapFromIndex(expected)
+			require.NoError(t, err)
+
+			for _, workers := range []int{1, 3, 8, 64} {
+				actual, err := buildSnapshotIndex(ctx, vrw, ns, sch, "t", idx, primary, workers, progress)
+				require.NoError(t, err)
+				assert.Equal(t, expectedMap.HashOf(), actual.HashOf(), "workers: %d", workers)
+				assert.Equal(t, int64(rows), lastDone)
+				assert.Equal(t, int64(rows), lastTotal)
+			}
+		})
+	}
+
+	t.Run("unique violation across workers", func(t *testing.T) {
+		// row 0 and the last row land in different workers' runs
+		mut := primary.Mutate()
+		kb.PutInt64(0, rows-1)
+		vb.PutInt64(0, 0)
+		k, err := kb.Build(ns.Pool())
+		require.NoError(t, err)
+		v, err := vb.Build(ns.Pool())
+		require.NoError(t, err)
+		require.NoError(t, mut.Put(ctx, k, v))
+		dupes, err := mut.Map(ctx)
+		require.NoError(t, err)
+
+		_, err = buildSnapshotIndex(ctx, vrw, ns, sch, "t", uniqIdx, dupes, 4, progress)
+		require.Error(t, err)
+		assert.True(t, sql.ErrUniqueKeyViolation.Is(err), err.Error())
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creation
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// DefaultIndexBuildParallelism is the number of indexes built concurrently by AddSecondaryIndexes.
+var DefaultIndexBuildParallelism = runtime.GOMAXPROCS(0)
+
+// AddSecondaryIndexes adds |idxs| to the schema of |tbl| and builds their row data from the primary row data of |tbl|.
+// This is used to build all the secondary indexes of a table at once after its rows have been loaded, e.g. by an import,
+// rather than maintaining each index row by row. The indexes must not already exist in the table's schema.
+func AddSecondaryIndexes(ctx *sql.Context, tbl *doltdb.Table, tableName string, idxs []schema.Index, opts editor.Options) (*doltdb.Table, error) {
+	if len(idxs) == 0 {
+		return tbl, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sch = sch.Copy()
+	for _, idx := range idxs {
+		if sch.Indexes().Contains(idx.Name()) {
+			return nil, fmt.Errorf("index `%s` already exists on table `%s`", idx.Name(), tableName)
+		}
+		sch.Indexes().AddIndex(idx)
+	}
+
+	tbl, err = tbl.UpdateSchema(ctx, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	built, err := BuildSecondaryIndexes(ctx, tbl, idxs, tableName, opts, DefaultIndexBuildParallelism)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, idx := range idxs {
+		tbl, err = tbl.SetIndexRows(ctx, idx.Name(), built[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tbl, nil
+}
+
+// BuildSecondaryIndexes builds the row data for each of |idxs|, which must be part of the schema of |tbl|. For the
+// DOLT format, each index's prolly tree is built by its own worker goroutine, with at most |parallelism| running at
+// once. All workers read from the same, already sorted, primary row data. The results are in the same order as |idxs|.
+func BuildSecondaryIndexes(ctx *sql.Context, tbl *doltdb.Table, idxs []schema.Index, tableName string, opts editor.Options, parallelism int) ([]durable.Index, error) {
+	built := make([]durable.Index, len(idxs))
+
+	if !types.IsFormat_DOLT(tbl.Format()) {
+		// the old format rebuilds indexes through a shared edit accumulator, so we build them one at a time
+		for i, idx := range idxs {
+			var err error
+			built[i], err = BuildSecondaryIndex(ctx, tbl, idx, tableName, opts)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return built, nil
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	primary, err := durable.ProllyMapFromIndex(m)
+	if err != nil {
+		return nil, err
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	sqlEgCtx := ctx.WithContext(egCtx)
+	if parallelism > 0 {
+		eg.SetLimit(parallelism)
+	}
+
+	for i := range idxs {
+		i := i
+		eg.Go(func() error {
+			idx, err := BuildSecondaryProllyIndex(sqlEgCtx, tbl.ValueReadWriter(), tbl.NodeStore(), sch, tableName, idxs[i], primary)
+			if err != nil {
+				return err
+			}
+			built[i] = idx
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return built, nil
+}
@@ -0,0 +1,442 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tablesize attributes the chunks of a Dolt database to the tables and indexes which reference them, to show
+// what is consuming storage and what garbage collection could reclaim.
+package tablesize
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// PrimaryIndex is the index name the row data of a table is reported under.
+const PrimaryIndex = "PRIMARY"
+
+// Usage is a number of chunks and their total size. Sizes are of the uncompressed chunks, so the table files holding
+// them are usually smaller.
+type Usage struct {
+	Chunks uint64
+	Bytes  uint64
+}
+
+func (u *Usage) add(o Usage) {
+	u.Chunks += o.Chunks
+	u.Bytes += o.Bytes
+}
+
+// Size is the storage attributed to an index of a table. Each chunk is attributed to the first index found to
+// reference it, so chunks shared between tables, such as those of tables with identical rows, are attributed to the
+// table whose name sorts first.
+type Size struct {
+	Table string
+	// Index is the name of a secondary index, or PrimaryIndex for the row data of the table, along with its schema
+	// and any conflicts or constraint violations.
+	Index string
+	// Current is the storage referenced by the table in the analyzed root.
+	Current Usage
+	// History is the storage referenced only by other versions of the table retained by the database: in the
+	// commits of its branches, tags and remotes, and in the working and staged roots of its branches.
+	History Usage
+	// Reclaimable is the storage referenced only by versions of the table which are no longer retained, found
+	// through the reflog of the database. It is removed by dolt gc.
+	Reclaimable Usage
+}
+
+// Total returns the sum of the current, history and reclaimable storage of the index.
+func (s Size) Total() Usage {
+	var u Usage
+	u.add(s.Current)
+	u.add(s.History)
+	u.add(s.Reclaimable)
+	return u
+}
+
+type category int
+
+const (
+	current category = iota
+	history
+	reclaimable
+)
+
+type sizeKey struct {
+	table string
+	index string
+}
+
+// analyzer holds the state of a single Analyze call.
+type analyzer struct {
+	ddb       *doltdb.DoltDB
+	cs        chunks.ChunkStore
+	walkAddrs func(chunks.Chunk, func(h hash.Hash, isleaf bool) error) error
+
+	// claimed holds every chunk attributed so far, along with every chunk looked for but not found.
+	claimed hash.HashSet
+	roots   hash.HashSet
+	commits hash.HashSet
+	sizes   map[sizeKey]*Size
+}
+
+// Analyze attributes the chunks referenced by the tables of |root|, a root of |ddb|, to their indexes, followed by
+// the chunks referenced by every other version of those tables retained by |ddb| and found in its reflog. The whole
+// history of |ddb| is read. Chunks of commits, root values and other metadata which aren't part of a table aren't
+// attributed.
+func Analyze(ctx context.Context, ddb *doltdb.DoltDB, root doltdb.RootValue) ([]Size, error) {
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, errors.New("analyzing table sizes requires a database in the __DOLT__ format")
+	}
+	cs := datas.ChunkStoreFromDatabase(doltdb.HackDatasDatabaseFromDoltDB(ddb))
+	walkAddrs, err := types.WalkAddrsForChunkStore(cs)
+	if err != nil {
+		return nil, err
+	}
+	a := &analyzer{
+		ddb:       ddb,
+		cs:        cs,
+		walkAddrs: walkAddrs,
+		claimed:   hash.NewHashSet(),
+		roots:     hash.NewHashSet(),
+		commits:   hash.NewHashSet(),
+		sizes:     make(map[sizeKey]*Size),
+	}
+
+	if err = a.analyzeRoot(ctx, root, current); err != nil {
+		return nil, err
+	}
+	if err = a.analyzeRefs(ctx); err != nil {
+		return nil, err
+	}
+	if err = a.analyzeReflog(ctx); err != nil {
+		return nil, err
+	}
+
+	sizes := make([]Size, 0, len(a.sizes))
+	for _, s := range a.sizes {
+		sizes = append(sizes, *s)
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].Table != sizes[j].Table {
+			return sizes[i].Table < sizes[j].Table
+		}
+		if (sizes[i].Index == PrimaryIndex) != (sizes[j].Index == PrimaryIndex) {
+			return sizes[i].Index == PrimaryIndex
+		}
+		return sizes[i].Index < sizes[j].Index
+	})
+	return sizes, nil
+}
+
+// analyzeRefs attributes the chunks of the working and staged roots of every branch, and of the root of every commit
+// reachable from a branch, tag, remote or workspace.
+func (a *analyzer) analyzeRefs(ctx context.Context) error {
+	refs, err := a.ddb.GetRefsOfType(ctx, map[ref.RefType]struct{}{
+		ref.BranchRefType:    {},
+		ref.TagRefType:       {},
+		ref.RemoteRefType:    {},
+		ref.WorkspaceRefType: {},
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].String() < refs[j].String()
+	})
+
+	var heads []*doltdb.Commit
+	for _, r := range refs {
+		if r.GetType() == ref.TagRefType {
+			tag, err := a.ddb.ResolveTag(ctx, ref.NewTagRef(r.GetPath()))
+			if err != nil {
+				return err
+			}
+			heads = append(heads, tag.Commit)
+			continue
+		}
+
+		cm, err := a.ddb.ResolveCommitRef(ctx, r)
+		if err == doltdb.ErrGhostCommitEncountered {
+			continue
+		} else if err != nil {
+			return err
+		}
+		heads = append(heads, cm)
+
+		if r.GetType() != ref.BranchRefType {
+			continue
+		}
+		wsRef, err := ref.WorkingSetRefForHead(r)
+		if err != nil {
+			return err
+		}
+		ws, err := a.ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err = a.analyzeWorkingSet(ctx, ws, history); err != nil {
+			return err
+		}
+	}
+
+	// working sets are analyzed before commits, so the history of a table is attributed to its most recent versions
+	for _, cm := range heads {
+		if err = a.analyzeCommits(ctx, cm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// analyzeCommits attributes the chunks of the roots of |head| and its ancestors to history.
+func (a *analyzer) analyzeCommits(ctx context.Context, head *doltdb.Commit) error {
+	stack := []*doltdb.Commit{head}
+	for len(stack) > 0 {
+		cm := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		h, err := cm.HashOf()
+		if err != nil {
+			return err
+		}
+		if a.commits.Has(h) {
+			continue
+		}
+		a.commits.Insert(h)
+
+		rv, err := cm.GetRootValue(ctx)
+		if err != nil {
+			return err
+		}
+		if err = a.analyzeRoot(ctx, rv, history); err != nil {
+			return err
+		}
+
+		for i := 0; i < cm.NumParents(); i++ {
+			optCmt, err := a.ddb.ResolveParent(ctx, cm, i)
+			if err != nil {
+				return err
+			}
+			if parent, ok := optCmt.ToCommit(); ok {
+				// ghost commits from shallow clones have no data
+				stack = append(stack, parent)
+			}
+		}
+	}
+	return nil
+}
+
+// analyzeReflog attributes the chunks of the roots of the refs and working sets recorded in the reflog, which aren't
+// otherwise retained, as reclaimable. A database without a chunk journal has no reflog.
+func (a *analyzer) analyzeReflog(ctx context.Context) error {
+	journal := a.ddb.ChunkJournal()
+	if journal == nil {
+		return nil
+	}
+	var storeRoots []hash.Hash
+	err := journal.IterateRoots(func(root string, _ *time.Time) error {
+		storeRoots = append(storeRoots, hash.Parse(root))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the most recent roots are analyzed first, as in the history of the refs
+	for i := len(storeRoots) - 1; i >= 0; i-- {
+		storeRoot := storeRoots[i]
+		datasets, err := a.ddb.DatasetsByRootHash(ctx, storeRoot)
+		if err != nil {
+			return err
+		}
+		err = datasets.IterAll(ctx, func(id string, _ hash.Hash) error {
+			if ref.IsWorkingSet(id) {
+				ws, err := a.ddb.ResolveWorkingSetAtRoot(ctx, ref.NewWorkingSetRef(id), storeRoot)
+				if err == doltdb.ErrWorkingSetNotFound {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				return a.analyzeWorkingSet(ctx, ws, reclaimable)
+			}
+
+			r, err := ref.Parse(id)
+			if err != nil {
+				return err
+			}
+			switch r.GetType() {
+			case ref.BranchRefType, ref.RemoteRefType, ref.WorkspaceRefType:
+			default:
+				return nil
+			}
+			cm, err := a.ddb.ResolveCommitRefAtRoot(ctx, r, storeRoot)
+			if err == doltdb.ErrGhostCommitEncountered {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			rv, err := cm.GetRootValue(ctx)
+			if err != nil {
+				return err
+			}
+			return a.analyzeRoot(ctx, rv, reclaimable)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *analyzer) analyzeWorkingSet(ctx context.Context, ws *doltdb.WorkingSet, cat category) error {
+	if err := a.analyzeRoot(ctx, ws.WorkingRoot(), cat); err != nil {
+		return err
+	}
+	return a.analyzeRoot(ctx, ws.StagedRoot(), cat)
+}
+
+// analyzeRoot attributes the chunks of the tables of |rv| which haven't been attributed yet to |cat|.
+func (a *analyzer) analyzeRoot(ctx context.Context, rv doltdb.RootValue, cat category) error {
+	h, err := rv.HashOf()
+	if err != nil {
+		return err
+	}
+	if a.roots.Has(h) {
+		return nil
+	}
+	a.roots.Insert(h)
+
+	return rv.IterTables(ctx, func(name doltdb.TableName, tbl *doltdb.Table, sch schema.Schema) (bool, error) {
+		tblHash, err := tbl.HashOf()
+		if err != nil {
+			return true, err
+		}
+		if a.claimed.Has(tblHash) && cat != current {
+			// every chunk of the table was attributed along with it. Current tables are always reported, even when
+			// identical to a table attributed before them.
+			return false, nil
+		}
+		return false, a.analyzeTable(ctx, name.String(), tblHash, tbl, sch, cat)
+	})
+}
+
+// analyzeTable attributes the chunks of the row data and secondary indexes of |tbl|, followed by the rest of the
+// chunks of the table, which are attributed to its row data.
+func (a *analyzer) analyzeTable(ctx context.Context, name string, tblHash hash.Hash, tbl *doltdb.Table, sch schema.Schema, cat category) error {
+	rowData, err := tbl.GetRowDataHash(ctx)
+	if err != nil {
+		return err
+	}
+	u, err := a.claim(ctx, rowData)
+	if err != nil {
+		return err
+	}
+	a.attribute(name, PrimaryIndex, cat, u)
+
+	indexes, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		return err
+	}
+	for _, def := range sch.Indexes().AllIndexes() {
+		idx, err := indexes.GetIndex(ctx, sch, nil, def.Name())
+		if err != nil {
+			return err
+		}
+		idxHash, err := idx.HashOf()
+		if err != nil {
+			return err
+		}
+		u, err = a.claim(ctx, idxHash)
+		if err != nil {
+			return err
+		}
+		a.attribute(name, def.Name(), cat, u)
+	}
+
+	u, err = a.claim(ctx, tblHash)
+	if err != nil {
+		return err
+	}
+	a.attribute(name, PrimaryIndex, cat, u)
+	return nil
+}
+
+func (a *analyzer) attribute(table, index string, cat category, u Usage) {
+	key := sizeKey{table: table, index: index}
+	s, ok := a.sizes[key]
+	if !ok {
+		s = &Size{Table: table, Index: index}
+		a.sizes[key] = s
+	}
+	switch cat {
+	case current:
+		s.Current.add(u)
+	case history:
+		s.History.add(u)
+	case reclaimable:
+		s.Reclaimable.add(u)
+	}
+}
+
+// claim walks the chunks reachable from |addr|, stopping at chunks which were already claimed, and returns the
+// number and size of the chunks it claimed.
+func (a *analyzer) claim(ctx context.Context, addr hash.Hash) (Usage, error) {
+	var u Usage
+	if a.claimed.Has(addr) {
+		return u, nil
+	}
+	next := hash.NewHashSet(addr)
+	for next.Size() > 0 {
+		a.claimed.InsertAll(next)
+
+		var mu sync.Mutex
+		var walkErr error
+		found := hash.NewHashSet()
+		err := a.cs.GetMany(ctx, next, func(_ context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			u.Chunks++
+			u.Bytes += uint64(len(c.Data()))
+			err := a.walkAddrs(*c, func(h hash.Hash, _ bool) error {
+				if !a.claimed.Has(h) {
+					found.Insert(h)
+				}
+				return nil
+			})
+			if err != nil && walkErr == nil {
+				walkErr = err
+			}
+		})
+		if err != nil {
+			return u, err
+		}
+		if walkErr != nil {
+			return u, walkErr
+		}
+		next = found
+	}
+	return u, nil
+}
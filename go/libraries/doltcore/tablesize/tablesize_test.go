@@ -0,0 +1,104 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablesize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/libraries/doltcore/tablesize"
+)
+
+// execSql runs |queries| in a single autocommit session against the working set of |dEnv|.
+func execSql(t *testing.T, ctx context.Context, dEnv *env.DoltEnv, queries ...string) {
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	for _, q := range queries {
+		_, iter, _, err := engine.Query(sqlCtx, q)
+		require.NoError(t, err, q)
+		_, err = sql.RowIterToRows(sqlCtx, iter)
+		require.NoError(t, err, q)
+	}
+}
+
+func analyze(t *testing.T, ctx context.Context, dEnv *env.DoltEnv) map[string]tablesize.Size {
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	sizes, err := tablesize.Analyze(ctx, dEnv.DoltDB(ctx), root)
+	require.NoError(t, err)
+	byName := make(map[string]tablesize.Size)
+	for _, sz := range sizes {
+		byName[sz.Table+"."+sz.Index] = sz
+	}
+	return byName
+}
+
+func TestAnalyze(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("CurrentAndHistory", func(t *testing.T) {
+		dEnv := dtestutils.CreateTestEnv()
+		defer dEnv.DoltDB(ctx).Close()
+		execSql(t, ctx, dEnv,
+			"CREATE TABLE t (pk int primary key, v varchar(20), key v_idx (v))",
+			"INSERT INTO t VALUES (1, 'one'), (2, 'two'), (3, 'three')",
+			"CALL dolt_commit('-Am', 'created t', '--author', 'test <test@example.com>')",
+			"ALTER TABLE t DROP INDEX v_idx",
+			"UPDATE t SET v = 'uno' WHERE pk = 1",
+		)
+
+		sizes := analyze(t, ctx, dEnv)
+		require.Len(t, sizes, 2)
+		primary := sizes["t."+tablesize.PrimaryIndex]
+		assert.NotZero(t, primary.Current.Chunks)
+		assert.NotZero(t, primary.Current.Bytes)
+		assert.NotZero(t, primary.History.Chunks)
+		idx := sizes["t.v_idx"]
+		assert.Zero(t, idx.Current.Chunks)
+		assert.NotZero(t, idx.History.Chunks)
+		assert.Equal(t, idx.History, idx.Total())
+	})
+
+	t.Run("SharedChunksAttributedOnce", func(t *testing.T) {
+		dEnv := dtestutils.CreateTestEnv()
+		defer dEnv.DoltDB(ctx).Close()
+		execSql(t, ctx, dEnv,
+			"CREATE TABLE a (pk int primary key, v int)",
+			"CREATE TABLE b (pk int primary key, v int)",
+			"INSERT INTO a VALUES (1, 1), (2, 2)",
+			"INSERT INTO b VALUES (1, 1), (2, 2)",
+		)
+
+		sizes := analyze(t, ctx, dEnv)
+		require.Len(t, sizes, 2)
+		// the rows of b are identical to those of a, and only its table and schema chunks are attributed to it
+		a, b := sizes["a."+tablesize.PrimaryIndex], sizes["b."+tablesize.PrimaryIndex]
+		assert.NotZero(t, b.Current.Chunks)
+		assert.Less(t, b.Current.Chunks, a.Current.Chunks)
+	})
+}
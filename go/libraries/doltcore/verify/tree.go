@@ -0,0 +1,88 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// TreeOrder checks the ordering invariants of the prolly tree backing |m|: the keys of every leaf are in strictly
+// increasing order across the whole tree, every child of an internal node is one level below it, every key of an
+// internal node is the last key of the subtree it points to, and the row count recorded at the root matches the
+// number of leaf entries. It returns the number of leaf entries.
+func TreeOrder(ctx context.Context, m prolly.MapInterface) (int, error) {
+	kd := m.KeyDesc()
+	ns := m.NodeStore()
+
+	rows := 0
+	var prev val.Tuple
+	var walk func(nd tree.Node) (val.Tuple, error)
+	walk = func(nd tree.Node) (val.Tuple, error) {
+		if nd.Count() == 0 {
+			return nil, nil
+		}
+
+		for i := 0; i < nd.Count(); i++ {
+			k := val.Tuple(nd.GetKey(i))
+			if nd.IsLeaf() {
+				if prev != nil && kd.Compare(ctx, prev, k) >= 0 {
+					return nil, fmt.Errorf("node %s: key %s is not greater than the key before it, %s",
+						nd.HashOf().String(), kd.Format(ctx, k), kd.Format(ctx, prev))
+				}
+				prev = k
+				rows++
+				continue
+			}
+
+			addr := hash.New(nd.GetValue(i))
+			child, err := ns.Read(ctx, addr)
+			if err != nil {
+				return nil, fmt.Errorf("node %s: unable to read child %s: %w", nd.HashOf().String(), addr.String(), err)
+			}
+			if child.Level() != nd.Level()-1 {
+				return nil, fmt.Errorf("node %s: child %s is at level %d, expected %d",
+					nd.HashOf().String(), addr.String(), child.Level(), nd.Level()-1)
+			}
+			last, err := walk(child)
+			if err != nil {
+				return nil, err
+			}
+			if last == nil || kd.Compare(ctx, last, k) != 0 {
+				return nil, fmt.Errorf("node %s: key %s does not match the last key of child %s",
+					nd.HashOf().String(), kd.Format(ctx, k), addr.String())
+			}
+		}
+		return val.Tuple(nd.GetKey(nd.Count() - 1)), nil
+	}
+
+	if _, err := walk(m.Node()); err != nil {
+		return rows, err
+	}
+
+	count, err := m.Count()
+	if err != nil {
+		return rows, err
+	}
+	if count != rows {
+		return rows, fmt.Errorf("tree records %d rows but has %d", count, rows)
+	}
+	return rows, nil
+}
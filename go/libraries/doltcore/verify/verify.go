@@ -0,0 +1,441 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verify checks the integrity of a Dolt database: the hashes of its chunks, the ordering of its prolly
+// trees, the consistency of secondary indexes with their tables, and the foreign keys between tables.
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// Options configures Database.
+type Options struct {
+	// Workers is the number of tables and roots verified at the same time.
+	Workers int
+	// History verifies the root of every commit reachable from a ref, instead of only the commits refs point to.
+	History bool
+	// SkipChunks skips verifying the content hash of every chunk in the store.
+	SkipChunks bool
+}
+
+// Problem is an integrity problem found by Database.
+type Problem struct {
+	// Where names the ref and table the problem was first found through, e.g. "refs/heads/main: table t".
+	Where string
+	Err   error
+}
+
+func (p Problem) Error() string {
+	if p.Where == "" {
+		return p.Err.Error()
+	}
+	return p.Where + ": " + p.Err.Error()
+}
+
+// Report summarizes the work done by Database and the problems it found.
+type Report struct {
+	Chunks      uint32
+	Refs        int
+	Roots       int
+	Tables      int
+	Indexes     int
+	ForeignKeys int
+	Rows        uint64
+	Problems    []Problem
+}
+
+// root is a root value to verify, along with a description of the ref it was found through.
+type root struct {
+	where string
+	value doltdb.RootValue
+}
+
+// verifier holds the state shared by the workers of a single Database call.
+type verifier struct {
+	ddb      *doltdb.DoltDB
+	progress chan<- string
+
+	mu       sync.Mutex
+	problems []Problem
+	tables   map[hash.Hash]struct{}
+	report   *Report
+
+	rows        uint64
+	indexes     int64
+	foreignKeys int64
+}
+
+// Database verifies every ref of |ddb| and returns a report of the problems found. Problems with the data are
+// returned in the report; the returned error is only for failures that prevent verification from continuing. If
+// |progress| is non-nil, a line is sent to it as each ref and table is verified.
+func Database(ctx context.Context, ddb *doltdb.DoltDB, opts Options, progress chan<- string) (*Report, error) {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	v := &verifier{
+		ddb:      ddb,
+		progress: progress,
+		tables:   make(map[hash.Hash]struct{}),
+		report:   &Report{},
+	}
+
+	if !opts.SkipChunks {
+		if err := v.verifyChunks(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if !types.IsFormat_DOLT(ddb.Format()) {
+		return nil, errors.New("verifying tables requires a database in the __DOLT__ format")
+	}
+
+	roots, err := v.collectRoots(ctx, opts.History)
+	if err != nil {
+		return nil, err
+	}
+	v.report.Roots = len(roots)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(opts.Workers)
+	for _, r := range roots {
+		r := r
+		v.notify("verifying %s", r.where)
+		eg.Go(func() error {
+			return v.verifyForeignKeys(egCtx, r)
+		})
+		err = r.value.IterTables(ctx, func(name doltdb.TableName, tbl *doltdb.Table, sch schema.Schema) (bool, error) {
+			h, err := tbl.HashOf()
+			if err != nil {
+				return true, err
+			}
+			if !v.firstSighting(h) {
+				return false, nil
+			}
+			where := fmt.Sprintf("%s: table %s", r.where, name.String())
+			eg.Go(func() error {
+				return v.verifyTable(egCtx, where, tbl, sch)
+			})
+			return false, nil
+		})
+		if err != nil {
+			eg.Wait()
+			return nil, err
+		}
+	}
+	if err = eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	v.report.Tables = len(v.tables)
+	v.report.Rows = v.rows
+	v.report.Indexes = int(v.indexes)
+	v.report.ForeignKeys = int(v.foreignKeys)
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Where < v.problems[j].Where
+	})
+	v.report.Problems = append(v.report.Problems, v.problems...)
+	return v.report, nil
+}
+
+func (v *verifier) notify(format string, args ...interface{}) {
+	if v.progress != nil {
+		v.progress <- fmt.Sprintf(format, args...)
+	}
+}
+
+func (v *verifier) addProblem(where string, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.problems = append(v.problems, Problem{Where: where, Err: err})
+}
+
+// firstSighting returns true the first time it is called with the hash of a table. Tables shared between refs are
+// only verified once.
+func (v *verifier) firstSighting(h hash.Hash) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, ok := v.tables[h]; ok {
+		return false
+	}
+	v.tables[h] = struct{}{}
+	return true
+}
+
+// verifyChunks checks the content hash of every chunk in the store.
+func (v *verifier) verifyChunks(ctx context.Context) error {
+	v.notify("verifying chunks")
+	fsckProgress := make(chan string, 32)
+	go func() {
+		for range fsckProgress {
+		}
+	}()
+	fsck, err := v.ddb.FSCK(ctx, fsckProgress)
+	close(fsckProgress)
+	if err != nil {
+		return err
+	}
+	v.report.Chunks = fsck.ChunkCount
+	for _, p := range fsck.Problems {
+		v.report.Problems = append(v.report.Problems, Problem{Err: p})
+	}
+	return nil
+}
+
+// collectRoots returns the distinct roots of every branch, tag, remote tracking branch and workspace, along with the
+// working and staged roots of every branch. If |history| is true, the roots of every ancestor commit are included.
+func (v *verifier) collectRoots(ctx context.Context, history bool) ([]root, error) {
+	var roots []root
+	seenRoots := make(map[hash.Hash]struct{})
+	addRoot := func(where string, rv doltdb.RootValue) error {
+		h, err := rv.HashOf()
+		if err != nil {
+			return err
+		}
+		if _, ok := seenRoots[h]; ok {
+			return nil
+		}
+		seenRoots[h] = struct{}{}
+		roots = append(roots, root{where: where, value: rv})
+		return nil
+	}
+
+	seenCommits := make(map[hash.Hash]struct{})
+	addCommit := func(where string, cm *doltdb.Commit) error {
+		type pending struct {
+			where string
+			cm    *doltdb.Commit
+		}
+		stack := []pending{{where: where, cm: cm}}
+		for len(stack) > 0 {
+			next := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			h, err := next.cm.HashOf()
+			if err != nil {
+				return err
+			}
+			if _, ok := seenCommits[h]; ok {
+				continue
+			}
+			seenCommits[h] = struct{}{}
+
+			rv, err := next.cm.GetRootValue(ctx)
+			if err != nil {
+				return err
+			}
+			if err = addRoot(next.where, rv); err != nil {
+				return err
+			}
+			if !history {
+				continue
+			}
+
+			for i := 0; i < next.cm.NumParents(); i++ {
+				optCmt, err := v.ddb.ResolveParent(ctx, next.cm, i)
+				if err != nil {
+					return err
+				}
+				parent, ok := optCmt.ToCommit()
+				if !ok {
+					// ghost commits from shallow clones have no data to verify
+					continue
+				}
+				stack = append(stack, pending{where: "commit " + optCmt.Addr.String(), cm: parent})
+			}
+		}
+		return nil
+	}
+
+	refs, err := v.ddb.GetRefsOfType(ctx, map[ref.RefType]struct{}{
+		ref.BranchRefType:    {},
+		ref.TagRefType:       {},
+		ref.RemoteRefType:    {},
+		ref.WorkspaceRefType: {},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].String() < refs[j].String()
+	})
+	v.report.Refs = len(refs)
+
+	for _, r := range refs {
+		var cm *doltdb.Commit
+		if r.GetType() == ref.TagRefType {
+			tag, err := v.ddb.ResolveTag(ctx, ref.NewTagRef(r.GetPath()))
+			if err != nil {
+				return nil, err
+			}
+			cm = tag.Commit
+		} else {
+			cm, err = v.ddb.ResolveCommitRef(ctx, r)
+			if err == doltdb.ErrGhostCommitEncountered {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+		}
+		if err = addCommit(r.String(), cm); err != nil {
+			return nil, err
+		}
+
+		if r.GetType() != ref.BranchRefType {
+			continue
+		}
+		wsRef, err := ref.WorkingSetRefForHead(r)
+		if err != nil {
+			return nil, err
+		}
+		ws, err := v.ddb.ResolveWorkingSet(ctx, wsRef)
+		if err == doltdb.ErrWorkingSetNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if err = addRoot(wsRef.String()+" (working)", ws.WorkingRoot()); err != nil {
+			return nil, err
+		}
+		if err = addRoot(wsRef.String()+" (staged)", ws.StagedRoot()); err != nil {
+			return nil, err
+		}
+	}
+	return roots, nil
+}
+
+// verifyTable checks the prolly trees of the row data and secondary indexes of |tbl|, and the consistency of each
+// secondary index with the row data.
+func (v *verifier) verifyTable(ctx context.Context, where string, tbl *doltdb.Table, sch schema.Schema) error {
+	v.notify("verifying %s", where)
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		v.addProblem(where, err)
+		return nil
+	}
+	primary := durable.MapFromIndex(rowData)
+	rows, err := TreeOrder(ctx, primary)
+	if err != nil {
+		v.addProblem(where, fmt.Errorf("row data: %w", err))
+		return nil
+	}
+	atomic.AddUint64(&v.rows, uint64(rows))
+
+	indexes, err := tbl.GetIndexSet(ctx)
+	if err != nil {
+		v.addProblem(where, err)
+		return nil
+	}
+	for _, def := range sch.Indexes().AllIndexes() {
+		atomic.AddInt64(&v.indexes, 1)
+		idx, err := indexes.GetIndex(ctx, sch, nil, def.Name())
+		if err != nil {
+			v.addProblem(where, fmt.Errorf("index %s: %w", def.Name(), err))
+			continue
+		}
+		secondary := durable.MapFromIndex(idx)
+		if !def.IsVector() {
+			// vector indexes are ordered by proximity rather than by key
+			if _, err = TreeOrder(ctx, secondary); err != nil {
+				v.addProblem(where, fmt.Errorf("index %s: %w", def.Name(), err))
+				continue
+			}
+		}
+		if err = IndexConsistency(ctx, sch, def, primary, secondary); err != nil {
+			v.addProblem(where, fmt.Errorf("index %s: %w", def.Name(), err))
+		}
+	}
+	return nil
+}
+
+// verifyForeignKeys checks that every row of every table in |r| satisfies the foreign keys it is a child of.
+func (v *verifier) verifyForeignKeys(ctx context.Context, r root) error {
+	fkColl, err := r.value.GetForeignKeyCollection(ctx)
+	if err != nil {
+		v.addProblem(r.where, err)
+		return nil
+	}
+	if fkColl.Count() == 0 {
+		return nil
+	}
+	atomic.AddInt64(&v.foreignKeys, int64(fkColl.Count()))
+
+	emptyRoot, err := doltdb.EmptyRootValue(ctx, r.value.VRW(), r.value.NodeStore())
+	if err != nil {
+		return err
+	}
+	counter := &fkViolationCounter{}
+	err = merge.GetForeignKeyViolations(ctx, r.value, emptyRoot, doltdb.NewTableNameSet(nil), counter)
+	if err != nil {
+		v.addProblem(r.where, fmt.Errorf("foreign keys: %w", err))
+		return nil
+	}
+	for _, fk := range counter.violated {
+		v.addProblem(fmt.Sprintf("%s: table %s", r.where, fk.fk.TableName.String()),
+			fmt.Errorf("foreign key %s: %d rows reference missing parent rows in %s", fk.fk.Name, fk.count, fk.fk.ReferencedTableName.String()))
+	}
+	return nil
+}
+
+type fkViolations struct {
+	fk    doltdb.ForeignKey
+	count int
+}
+
+// fkViolationCounter is a merge.FKViolationReceiver that counts the violations of each foreign key.
+type fkViolationCounter struct {
+	curr     fkViolations
+	violated []fkViolations
+}
+
+var _ merge.FKViolationReceiver = (*fkViolationCounter)(nil)
+
+func (f *fkViolationCounter) StartFK(_ context.Context, fk doltdb.ForeignKey) error {
+	f.curr = fkViolations{fk: fk}
+	return nil
+}
+
+func (f *fkViolationCounter) EndCurrFK(_ context.Context) error {
+	if f.curr.count > 0 {
+		f.violated = append(f.violated, f.curr)
+	}
+	return nil
+}
+
+func (f *fkViolationCounter) NomsFKViolationFound(_ context.Context, _, _ types.Tuple) error {
+	f.curr.count++
+	return nil
+}
+
+func (f *fkViolationCounter) ProllyFKViolationFound(_ context.Context, _, _ val.Tuple) error {
+	f.curr.count++
+	return nil
+}
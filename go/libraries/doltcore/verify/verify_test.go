@@ -0,0 +1,148 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb/durable"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+)
+
+func setupVerifyEnv(t *testing.T, ctx context.Context) *env.DoltEnv {
+	dEnv := dtestutils.CreateTestEnv()
+	execSql(t, ctx, dEnv,
+		"CREATE TABLE parent (id int primary key, name varchar(20), unique key (name))",
+		"CREATE TABLE child (id int primary key, parent_id int, note text, key (note(4)), foreign key (parent_id) references parent (id))",
+		"CREATE TABLE keyless (a int, b int, key (b))",
+		"INSERT INTO parent VALUES (1, 'one'), (2, 'two'), (3, 'three')",
+		"INSERT INTO child VALUES (10, 1, 'first'), (20, 2, 'second'), (30, NULL, 'none')",
+		"INSERT INTO keyless VALUES (1, 1), (1, 1), (2, 2)",
+	)
+	return dEnv
+}
+
+// execSql runs |queries| in a single autocommit session against the working set of |dEnv|.
+func execSql(t *testing.T, ctx context.Context, dEnv *env.DoltEnv, queries ...string) {
+	tmpDir, err := dEnv.TempTableFilesDir()
+	require.NoError(t, err)
+	opts := editor.Options{Deaf: dEnv.DbEaFactory(ctx), Tempdir: tmpDir}
+	db, err := sqle.NewDatabase(ctx, "dolt", dEnv.DbData(ctx), opts)
+	require.NoError(t, err)
+	engine, sqlCtx, err := sqle.NewTestEngine(dEnv, ctx, db)
+	require.NoError(t, err)
+	for _, q := range queries {
+		_, iter, _, err := engine.Query(sqlCtx, q)
+		require.NoError(t, err, q)
+		_, err = sql.RowIterToRows(sqlCtx, iter)
+		require.NoError(t, err, q)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	ctx := context.Background()
+	opts := Options{Workers: 2, SkipChunks: true}
+
+	t.Run("NoProblems", func(t *testing.T) {
+		dEnv := setupVerifyEnv(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+
+		report, err := Database(ctx, dEnv.DoltDB(ctx), opts, nil)
+		require.NoError(t, err)
+		assert.Empty(t, report.Problems)
+		assert.Equal(t, 3, report.Tables)
+		// the duplicate rows of keyless are a single entry with a cardinality of 2
+		assert.Equal(t, uint64(8), report.Rows)
+		// the foreign key of child adds an index on parent_id
+		assert.Equal(t, 4, report.Indexes)
+		assert.Equal(t, 1, report.ForeignKeys)
+		assert.Equal(t, 1, report.Refs)
+	})
+
+	t.Run("MissingIndexEntries", func(t *testing.T) {
+		dEnv := setupVerifyEnv(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+
+		root, err := dEnv.WorkingRoot(ctx)
+		require.NoError(t, err)
+		name := doltdb.TableName{Name: "parent"}
+		tbl, ok, err := root.GetTable(ctx, name)
+		require.NoError(t, err)
+		require.True(t, ok)
+		sch, err := tbl.GetSchema(ctx)
+		require.NoError(t, err)
+		def := sch.Indexes().GetByName("name")
+		require.NotNil(t, def)
+		empty, err := durable.NewEmptyIndexFromTableSchema(ctx, tbl.ValueReadWriter(), tbl.NodeStore(), def, sch)
+		require.NoError(t, err)
+		tbl, err = tbl.SetIndexRows(ctx, def.Name(), empty)
+		require.NoError(t, err)
+		root, err = root.PutTable(ctx, name, tbl)
+		require.NoError(t, err)
+		require.NoError(t, dEnv.UpdateWorkingRoot(ctx, root))
+
+		report, err := Database(ctx, dEnv.DoltDB(ctx), opts, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Problems, 1)
+		assert.Contains(t, report.Problems[0].Where, "table parent")
+		assert.Contains(t, report.Problems[0].Error(), "index name")
+		assert.Contains(t, report.Problems[0].Error(), "does not match secondary index row count")
+	})
+
+	t.Run("ForeignKeyViolations", func(t *testing.T) {
+		dEnv := setupVerifyEnv(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+
+		execSql(t, ctx, dEnv,
+			"SET foreign_key_checks = 0",
+			"INSERT INTO child VALUES (40, 4, 'orphan'), (50, 5, 'orphan')",
+		)
+
+		report, err := Database(ctx, dEnv.DoltDB(ctx), opts, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Problems, 1)
+		assert.Contains(t, report.Problems[0].Where, "table child")
+		assert.Contains(t, report.Problems[0].Error(), "2 rows reference missing parent rows in parent")
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		dEnv := setupVerifyEnv(t, ctx)
+		defer dEnv.DoltDB(ctx).Close()
+
+		progress := make(chan string, 32)
+		var lines []string
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for l := range progress {
+				lines = append(lines, l)
+			}
+		}()
+		_, err := Database(ctx, dEnv.DoltDB(ctx), opts, progress)
+		close(progress)
+		<-done
+		require.NoError(t, err)
+		assert.Contains(t, lines, "verifying refs/heads/main")
+	})
+}
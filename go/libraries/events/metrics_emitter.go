@@ -0,0 +1,460 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sirupsen/logrus"
+)
+
+// MetricKind is the kind of value a MetricPoint holds.
+type MetricKind int
+
+const (
+	// MetricKindCounter is a cumulative count, which only increases over the life of the process.
+	MetricKindCounter MetricKind = iota
+	// MetricKindGauge is a value which can go up and down, such as a queue length.
+	MetricKindGauge
+)
+
+// MetricPoint is the value of a metric, with one set of labels, at the time it was gathered.
+type MetricPoint struct {
+	Name   string
+	Kind   MetricKind
+	Value  float64
+	Labels map[string]string
+}
+
+// MetricsEmitter pushes metrics to a telemetry backend. Unlike an Emitter, which sends usage events to DoltHub, a
+// MetricsEmitter sends the operational metrics of a server to infrastructure its operator runs.
+type MetricsEmitter interface {
+	// EmitMetrics pushes |points|, which were all gathered at |ts|.
+	EmitMetrics(ctx context.Context, ts time.Time, points []MetricPoint) error
+	// Close releases the resources of the emitter.
+	Close() error
+}
+
+// GatherMetricPoints returns the current values of the metrics registered with |g|. Histograms and summaries are
+// reported as a counter of their observations, with the suffix "_count", and a counter of their sum, with the suffix
+// "_sum".
+func GatherMetricPoints(g prometheus.Gatherer) ([]MetricPoint, error) {
+	families, err := g.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	var points []MetricPoint
+	for _, mf := range families {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			labels := make(map[string]string, len(m.GetLabel()))
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				points = append(points, MetricPoint{name, MetricKindCounter, m.GetCounter().GetValue(), labels})
+			case dto.MetricType_GAUGE:
+				points = append(points, MetricPoint{name, MetricKindGauge, m.GetGauge().GetValue(), labels})
+			case dto.MetricType_UNTYPED:
+				points = append(points, MetricPoint{name, MetricKindGauge, m.GetUntyped().GetValue(), labels})
+			case dto.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				points = append(points,
+					MetricPoint{name + "_count", MetricKindCounter, float64(h.GetSampleCount()), labels},
+					MetricPoint{name + "_sum", MetricKindCounter, h.GetSampleSum(), labels})
+			case dto.MetricType_SUMMARY:
+				s := m.GetSummary()
+				points = append(points,
+					MetricPoint{name + "_count", MetricKindCounter, float64(s.GetSampleCount()), labels},
+					MetricPoint{name + "_sum", MetricKindCounter, s.GetSampleSum(), labels})
+			}
+		}
+	}
+	return points, nil
+}
+
+// MetricsPusher periodically gathers metrics and pushes them to a MetricsEmitter.
+type MetricsPusher struct {
+	gatherer prometheus.Gatherer
+	emitter  MetricsEmitter
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+
+	mu      sync.Mutex
+	running bool
+	stopped bool
+}
+
+// NewMetricsPusher creates a MetricsPusher which pushes the metrics registered with |g| to |emitter| every
+// |interval|.
+func NewMetricsPusher(g prometheus.Gatherer, emitter MetricsEmitter, interval time.Duration) *MetricsPusher {
+	return &MetricsPusher{
+		gatherer: g,
+		emitter:  emitter,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Run pushes metrics until Stop is called. Failed pushes are logged and retried at the next interval. Once stopped,
+// the metrics are pushed a final time and the emitter is closed.
+func (p *MetricsPusher) Run(ctx context.Context) {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.running = true
+	p.mu.Unlock()
+
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			if err := p.Push(ctx); err != nil {
+				logrus.Warnf("error pushing metrics: %v", err)
+			}
+			if err := p.emitter.Close(); err != nil {
+				logrus.Warnf("error closing metrics emitter: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := p.Push(ctx); err != nil {
+				logrus.Warnf("error pushing metrics: %v", err)
+			}
+		}
+	}
+}
+
+// Push gathers the current metrics and pushes them once.
+func (p *MetricsPusher) Push(ctx context.Context) error {
+	points, err := GatherMetricPoints(p.gatherer)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+	return p.emitter.EmitMetrics(ctx, time.Now(), points)
+}
+
+// Stop stops a running MetricsPusher and waits for it to finish. If it was never run, its emitter is closed.
+func (p *MetricsPusher) Stop() {
+	p.mu.Lock()
+	running, stopped := p.running, p.stopped
+	p.stopped = true
+	p.mu.Unlock()
+
+	if stopped {
+		return
+	}
+	close(p.stop)
+	if running {
+		<-p.done
+	} else if err := p.emitter.Close(); err != nil {
+		logrus.Warnf("error closing metrics emitter: %v", err)
+	}
+}
+
+// statsDMaxPacketSize keeps each datagram under the typical 1500 byte MTU of a network.
+const statsDMaxPacketSize = 1432
+
+// StatsDEmitter sends metrics to a StatsD server over UDP. Tags are sent in the DogStatsD format, which is supported
+// by Datadog, Telegraf and the StatsD exporter for Prometheus. Counters are sent as the amount they changed by since
+// they were last sent, as StatsD expects.
+type StatsDEmitter struct {
+	conn   net.Conn
+	prefix string
+	last   map[string]float64
+}
+
+var _ MetricsEmitter = (*StatsDEmitter)(nil)
+
+// NewStatsDEmitter creates a StatsDEmitter which sends metrics to the StatsD server at |addr|, a host:port, prefixing
+// their names with |prefix| and a "." if |prefix| is not empty.
+func NewStatsDEmitter(addr, prefix string) (*StatsDEmitter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsDEmitter{conn: conn, prefix: prefix, last: make(map[string]float64)}, nil
+}
+
+func (e *StatsDEmitter) EmitMetrics(ctx context.Context, ts time.Time, points []MetricPoint) error {
+	var packet bytes.Buffer
+	for _, p := range points {
+		line, ok := e.statsDLine(p)
+		if !ok {
+			continue
+		}
+		if packet.Len() > 0 && packet.Len()+1+len(line) > statsDMaxPacketSize {
+			if _, err := e.conn.Write(packet.Bytes()); err != nil {
+				return err
+			}
+			packet.Reset()
+		}
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+		packet.WriteString(line)
+	}
+	if packet.Len() > 0 {
+		if _, err := e.conn.Write(packet.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statsDLine returns the StatsD line for |p|, or false if it has nothing to send.
+func (e *StatsDEmitter) statsDLine(p MetricPoint) (string, bool) {
+	name := p.Name
+	if e.prefix != "" {
+		name = e.prefix + "." + name
+	}
+	tags := sortedLabels(p.Labels, ":", ",")
+
+	value, typ := p.Value, "g"
+	if p.Kind == MetricKindCounter {
+		key := name + "|" + tags
+		value = p.Value - e.last[key]
+		e.last[key] = p.Value
+		if value == 0 {
+			return "", false
+		} else if value < 0 {
+			// the counter was reset
+			value = p.Value
+		}
+		typ = "c"
+	}
+
+	line := name + ":" + strconv.FormatFloat(value, 'f', -1, 64) + "|" + typ
+	if tags != "" {
+		line += "|#" + tags
+	}
+	return line, true
+}
+
+func (e *StatsDEmitter) Close() error {
+	return e.conn.Close()
+}
+
+// sortedLabels returns |labels| as key<kvSep>value pairs, sorted by key and separated by |sep|.
+func sortedLabels(labels map[string]string, kvSep, sep string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + kvSep + labels[k]
+	}
+	return strings.Join(pairs, sep)
+}
+
+// otlpMetricsPath is the path of the metrics endpoint of an OTLP/HTTP collector.
+const otlpMetricsPath = "/v1/metrics"
+
+// OTLPMetricsEmitter sends metrics to an OpenTelemetry collector with OTLP/HTTP, using its JSON encoding. Counters are
+// sent as cumulative, monotonic sums, and gauges as gauges.
+type OTLPMetricsEmitter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	resource map[string]string
+	start    time.Time
+}
+
+var _ MetricsEmitter = (*OTLPMetricsEmitter)(nil)
+
+// NewOTLPMetricsEmitter creates an OTLPMetricsEmitter which posts metrics to |endpoint|, adding |headers| to each
+// request. If |endpoint| has no path, the standard /v1/metrics path is used. |resource| are the attributes of the
+// OTLP resource the metrics are reported for, such as service.name.
+func NewOTLPMetricsEmitter(endpoint string, headers, resource map[string]string) (*OTLPMetricsEmitter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid OTLP endpoint '%s': must be an http or https URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = otlpMetricsPath
+	}
+	return &OTLPMetricsEmitter{
+		endpoint: u.String(),
+		headers:  headers,
+		client:   &http.Client{},
+		resource: resource,
+		start:    time.Now(),
+	}, nil
+}
+
+func (e *OTLPMetricsEmitter) EmitMetrics(ctx context.Context, ts time.Time, points []MetricPoint) error {
+	body, err := json.Marshal(e.otlpRequest(ts, points))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("OTLP endpoint %s returned %s: %s", e.endpoint, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func (e *OTLPMetricsEmitter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// The JSON encoding of an OTLP ExportMetricsServiceRequest, limited to the fields this emitter sends.
+type otlpRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpMetric struct {
+	Name  string     `json:"name"`
+	Sum   *otlpSum   `json:"sum,omitempty"`
+	Gauge *otlpGauge `json:"gauge,omitempty"`
+}
+
+// otlpCumulative is AGGREGATION_TEMPORALITY_CUMULATIVE.
+const otlpCumulative = 2
+
+type otlpSum struct {
+	DataPoints             []otlpDataPoint `json:"dataPoints"`
+	AggregationTemporality int             `json:"aggregationTemporality"`
+	IsMonotonic            bool            `json:"isMonotonic"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpDataPoint `json:"dataPoints"`
+}
+
+type otlpDataPoint struct {
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string          `json:"timeUnixNano"`
+	AsDouble          float64         `json:"asDouble"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func otlpAttributes(labels map[string]string) []otlpAttribute {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]otlpAttribute, len(keys))
+	for i, k := range keys {
+		attrs[i] = otlpAttribute{Key: k, Value: otlpAnyValue{StringValue: labels[k]}}
+	}
+	return attrs
+}
+
+// otlpRequest groups |points| into one OTLP metric per name.
+func (e *OTLPMetricsEmitter) otlpRequest(ts time.Time, points []MetricPoint) otlpRequest {
+	now := strconv.FormatInt(ts.UnixNano(), 10)
+	start := strconv.FormatInt(e.start.UnixNano(), 10)
+
+	var metrics []otlpMetric
+	byName := make(map[string]int)
+	for _, p := range points {
+		i, ok := byName[p.Name]
+		if !ok {
+			m := otlpMetric{Name: p.Name}
+			if p.Kind == MetricKindCounter {
+				m.Sum = &otlpSum{AggregationTemporality: otlpCumulative, IsMonotonic: true}
+			} else {
+				m.Gauge = &otlpGauge{}
+			}
+			i = len(metrics)
+			byName[p.Name] = i
+			metrics = append(metrics, m)
+		}
+
+		dp := otlpDataPoint{Attributes: otlpAttributes(p.Labels), TimeUnixNano: now, AsDouble: p.Value}
+		if m := &metrics[i]; m.Sum != nil {
+			dp.StartTimeUnixNano = start
+			m.Sum.DataPoints = append(m.Sum.DataPoints, dp)
+		} else {
+			m.Gauge.DataPoints = append(m.Gauge.DataPoints, dp)
+		}
+	}
+
+	return otlpRequest{ResourceMetrics: []otlpResourceMetrics{{
+		Resource:     otlpResource{Attributes: otlpAttributes(e.resource)},
+		ScopeMetrics: []otlpScopeMetrics{{Scope: otlpScope{Name: "github.com/dolthub/dolt"}, Metrics: metrics}},
+	}}}
+}
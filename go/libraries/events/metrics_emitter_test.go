@@ -0,0 +1,152 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatherMetricPoints(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	labels := prometheus.Labels{"env": "test"}
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "queries", ConstLabels: labels})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "connections", ConstLabels: labels})
+	hist := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "query_duration", ConstLabels: labels})
+	reg.MustRegister(counter, gauge, hist)
+
+	counter.Add(3)
+	gauge.Set(2)
+	hist.Observe(0.5)
+	hist.Observe(1.5)
+
+	points, err := GatherMetricPoints(reg)
+	require.NoError(t, err)
+	byName := make(map[string]MetricPoint)
+	for _, p := range points {
+		byName[p.Name] = p
+	}
+	assert.Equal(t, MetricPoint{"queries", MetricKindCounter, 3, labels}, byName["queries"])
+	assert.Equal(t, MetricPoint{"connections", MetricKindGauge, 2, labels}, byName["connections"])
+	assert.Equal(t, MetricPoint{"query_duration_count", MetricKindCounter, 2, labels}, byName["query_duration_count"])
+	assert.Equal(t, MetricPoint{"query_duration_sum", MetricKindCounter, 2, labels}, byName["query_duration_sum"])
+}
+
+func TestStatsDEmitter(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	e, err := NewStatsDEmitter(conn.LocalAddr().String(), "dolt")
+	require.NoError(t, err)
+	defer e.Close()
+
+	read := func() []string {
+		buf := make([]byte, statsDMaxPacketSize)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		return strings.Split(string(buf[:n]), "\n")
+	}
+
+	ctx := context.Background()
+	points := []MetricPoint{
+		{"queries", MetricKindCounter, 5, map[string]string{"env": "test", "db": "mydb"}},
+		{"connections", MetricKindGauge, 2, nil},
+	}
+	require.NoError(t, e.EmitMetrics(ctx, time.Now(), points))
+	assert.Equal(t, []string{
+		"dolt.queries:5|c|#db:mydb,env:test",
+		"dolt.connections:2|g",
+	}, read())
+
+	// counters are sent as the amount they changed by, and not at all if they didn't change
+	points[0].Value = 8
+	require.NoError(t, e.EmitMetrics(ctx, time.Now(), points))
+	assert.Equal(t, []string{
+		"dolt.queries:3|c|#db:mydb,env:test",
+		"dolt.connections:2|g",
+	}, read())
+	require.NoError(t, e.EmitMetrics(ctx, time.Now(), points))
+	assert.Equal(t, []string{"dolt.connections:2|g"}, read())
+}
+
+func TestOTLPMetricsEmitter(t *testing.T) {
+	var received otlpRequest
+	var path, auth string
+	var unauthorized bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unauthorized {
+			http.Error(w, "bad credentials", http.StatusUnauthorized)
+			return
+		}
+		path = r.URL.Path
+		auth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	e, err := NewOTLPMetricsEmitter(srv.URL, map[string]string{"Authorization": "Bearer token"}, map[string]string{"service.name": "dolt"})
+	require.NoError(t, err)
+	defer e.Close()
+
+	err = e.EmitMetrics(context.Background(), time.Now(), []MetricPoint{
+		{"queries", MetricKindCounter, 5, map[string]string{"db": "a"}},
+		{"queries", MetricKindCounter, 7, map[string]string{"db": "b"}},
+		{"connections", MetricKindGauge, 2, nil},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, otlpMetricsPath, path)
+	assert.Equal(t, "Bearer token", auth)
+
+	require.Len(t, received.ResourceMetrics, 1)
+	rm := received.ResourceMetrics[0]
+	assert.Equal(t, []otlpAttribute{{"service.name", otlpAnyValue{"dolt"}}}, rm.Resource.Attributes)
+	require.Len(t, rm.ScopeMetrics, 1)
+	metrics := rm.ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 2)
+
+	assert.Equal(t, "queries", metrics[0].Name)
+	require.NotNil(t, metrics[0].Sum)
+	assert.True(t, metrics[0].Sum.IsMonotonic)
+	assert.Equal(t, otlpCumulative, metrics[0].Sum.AggregationTemporality)
+	require.Len(t, metrics[0].Sum.DataPoints, 2)
+	assert.Equal(t, 7.0, metrics[0].Sum.DataPoints[1].AsDouble)
+	assert.Equal(t, []otlpAttribute{{"db", otlpAnyValue{"b"}}}, metrics[0].Sum.DataPoints[1].Attributes)
+
+	assert.Equal(t, "connections", metrics[1].Name)
+	require.NotNil(t, metrics[1].Gauge)
+	require.Len(t, metrics[1].Gauge.DataPoints, 1)
+	assert.Equal(t, 2.0, metrics[1].Gauge.DataPoints[0].AsDouble)
+
+	unauthorized = true
+	err = e.EmitMetrics(context.Background(), time.Now(), nil)
+	assert.ErrorContains(t, err, "bad credentials")
+
+	_, err = NewOTLPMetricsEmitter("localhost:4318", nil, nil)
+	assert.Error(t, err)
+}
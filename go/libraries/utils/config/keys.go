@@ -20,6 +20,7 @@ var ConfigOptions = map[string]struct{}{
 	UserCreds:             {},
 	DoltEditor:            {},
 	InitBranchName:        {},
+	InitTemplate:          {},
 	RemotesApiHostKey:     {},
 	RemotesApiHostPortKey: {},
 	AddCredsUrlKey:        {},
@@ -31,6 +32,10 @@ var ConfigOptions = map[string]struct{}{
 	PushAutoSetupRemote:   {},
 	ProfileKey:            {},
 	VersionCheckDisabled:  {},
+	CostModelCPUKey:       {},
+	CostModelSeqIOKey:     {},
+	CostModelRandIOKey:    {},
+	CostModelMemKey:       {},
 }
 
 const UserEmailKey = "user.email"
@@ -43,6 +48,8 @@ const DoltEditor = "core.editor"
 
 const InitBranchName = "init.defaultbranch"
 
+const InitTemplate = "init.template"
+
 const RemotesApiHostKey = "remotes.default_host"
 
 const RemotesApiHostPortKey = "remotes.default_port"
@@ -68,3 +75,11 @@ const VersionCheckDisabled = "versioncheck.disabled"
 const SignCommitsKey = "commit.gpgsign"
 
 const GPGSigningKeyKey = "user.signingkey"
+
+const CostModelCPUKey = "costmodel.cpu"
+
+const CostModelSeqIOKey = "costmodel.seq_io"
+
+const CostModelRandIOKey = "costmodel.rand_io"
+
+const CostModelMemKey = "costmodel.mem"
@@ -112,6 +112,16 @@ type Filesys interface {
 	WithWorkingDir(path string) (Filesys, error)
 }
 
+// SymlinkFS is implemented by filesystems which support symbolic links. Callers should type assert for it and fall back
+// to another mechanism when it isn't available.
+type SymlinkFS interface {
+	// Symlink creates newname as a symbolic link to oldname
+	Symlink(oldname, newname string) error
+
+	// EvalSymlinks returns the absolute path name after the evaluation of any symbolic links
+	EvalSymlinks(path string) (string, error)
+}
+
 func UnmarshalJSONFile(fs ReadableFS, path string, dest interface{}) error {
 	data, err := fs.ReadFile(path)
 
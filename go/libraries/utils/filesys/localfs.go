@@ -312,6 +312,26 @@ func (fs *localFS) Abs(path string) (string, error) {
 	}
 }
 
+// Symlink creates newname as a symbolic link to oldname
+func (fs *localFS) Symlink(oldname, newname string) error {
+	newname, err := fs.Abs(newname)
+	if err != nil {
+		return err
+	}
+
+	return os.Symlink(oldname, newname)
+}
+
+// EvalSymlinks returns the absolute path name after the evaluation of any symbolic links
+func (fs *localFS) EvalSymlinks(path string) (string, error) {
+	path, err := fs.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(path)
+}
+
 // LastModified gets the last modified timestamp for a file or directory at a given path
 func (fs *localFS) LastModified(path string) (t time.Time, exists bool) {
 	var err error
@@ -0,0 +1,120 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iohelp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/dustin/go-humanize"
+	"golang.org/x/time/rate"
+)
+
+// minRateLimitBurst is the smallest number of bytes a rate limited reader or writer transfers at once.
+const minRateLimitBurst = 4 * 1024
+
+// ParseBandwidth parses a number of bytes per second, such as "10MB" or "512KiB". A bare number is a number of bytes.
+func ParseBandwidth(s string) (uint64, error) {
+	n, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth '%s': %w", s, err)
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("invalid bandwidth '%s': must be greater than 0", s)
+	}
+	return n, nil
+}
+
+// NewBandwidthLimiter returns a token bucket which refills at |bytesPerSec| bytes per second, for sharing between the
+// readers and writers which should be limited to that rate in total.
+func NewBandwidthLimiter(bytesPerSec uint64) *rate.Limiter {
+	burst := bytesPerSec / 10
+	if burst < minRateLimitBurst {
+		burst = minRateLimitBurst
+	} else if burst > math.MaxInt32 {
+		burst = math.MaxInt32
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSec), int(burst))
+}
+
+// RateLimitedReader is an io.ReadCloser which waits on a rate.Limiter for each byte read from the wrapped reader.
+type RateLimitedReader struct {
+	ctx context.Context
+	rd  io.Reader
+	lim *rate.Limiter
+}
+
+var _ io.ReadCloser = (*RateLimitedReader)(nil)
+
+// NewRateLimitedReader returns a reader which limits the rate of reads from |rd| with |lim|. Waiting on |lim| fails
+// once |ctx| is done.
+func NewRateLimitedReader(ctx context.Context, rd io.Reader, lim *rate.Limiter) *RateLimitedReader {
+	return &RateLimitedReader{ctx: ctx, rd: rd, lim: lim}
+}
+
+func (r *RateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > r.lim.Burst() {
+		p = p[:r.lim.Burst()]
+	}
+	n, err := r.rd.Read(p)
+	if n > 0 {
+		if werr := r.lim.WaitN(r.ctx, n); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+// Close closes the wrapped reader if it is an io.Closer.
+func (r *RateLimitedReader) Close() error {
+	if closer, ok := r.rd.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// RateLimitedWriter is an io.Writer which waits on a rate.Limiter for each byte written to the wrapped writer.
+type RateLimitedWriter struct {
+	ctx context.Context
+	wr  io.Writer
+	lim *rate.Limiter
+}
+
+var _ io.Writer = (*RateLimitedWriter)(nil)
+
+// NewRateLimitedWriter returns a writer which limits the rate of writes to |wr| with |lim|. Waiting on |lim| fails
+// once |ctx| is done.
+func NewRateLimitedWriter(ctx context.Context, wr io.Writer, lim *rate.Limiter) *RateLimitedWriter {
+	return &RateLimitedWriter{ctx: ctx, wr: wr, lim: lim}
+}
+
+func (w *RateLimitedWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		n := min(len(p), w.lim.Burst())
+		if err := w.lim.WaitN(w.ctx, n); err != nil {
+			return written, err
+		}
+		n, err := w.wr.Write(p[:n])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iohelp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	for s, expected := range map[string]uint64{
+		"1000":   1000,
+		"10MB":   10 * 1000 * 1000,
+		"512KiB": 512 * 1024,
+		"1.5 GB": 1500 * 1000 * 1000,
+	} {
+		actual, err := ParseBandwidth(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expected, actual, s)
+	}
+
+	for _, s := range []string{"", "0", "fast", "-1MB"} {
+		_, err := ParseBandwidth(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRateLimitedReaderAndWriter(t *testing.T) {
+	const bytesPerSec = 200 * 1024
+	const size = 100 * 1024
+	data := make([]byte, size)
+
+	newLimiter := func() *rate.Limiter {
+		return rate.NewLimiter(bytesPerSec, 20*1024)
+	}
+	// The bucket starts full, so only the bytes after the first burst are limited.
+	minElapsed := time.Duration(float64(size-20*1024) / bytesPerSec * float64(time.Second))
+
+	t.Run("Reader", func(t *testing.T) {
+		start := time.Now()
+		rd := NewRateLimitedReader(context.Background(), bytes.NewReader(data), newLimiter())
+		n, err := io.Copy(io.Discard, rd)
+		require.NoError(t, err)
+		assert.Equal(t, int64(size), n)
+		assert.GreaterOrEqual(t, time.Since(start), minElapsed)
+		assert.NoError(t, rd.Close())
+	})
+
+	t.Run("Writer", func(t *testing.T) {
+		start := time.Now()
+		var buf bytes.Buffer
+		n, err := NewRateLimitedWriter(context.Background(), &buf, newLimiter()).Write(data)
+		require.NoError(t, err)
+		assert.Equal(t, size, n)
+		assert.Equal(t, size, buf.Len())
+		assert.GreaterOrEqual(t, time.Since(start), minElapsed)
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		lim := newLimiter()
+		_, err := io.Copy(io.Discard, NewRateLimitedReader(ctx, bytes.NewReader(data), lim))
+		assert.Error(t, err)
+		_, err = NewRateLimitedWriter(ctx, io.Discard, lim).Write(data)
+		assert.Error(t, err)
+	})
+}
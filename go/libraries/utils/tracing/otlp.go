@@ -0,0 +1,279 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const otlpExportTimeout = 10 * time.Second
+
+// OTLPExporter exports spans to an OpenTelemetry collector with OTLP over HTTP, using the JSON encoding of the
+// protocol, which every collector accepts alongside the protobuf one.
+type OTLPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+	stopped  atomic.Bool
+}
+
+var _ tracesdk.SpanExporter = (*OTLPExporter)(nil)
+
+// NewOTLPExporter returns an exporter which posts spans to |endpoint|, the full URL of the collector's traces
+// endpoint, e.g. http://localhost:4318/v1/traces, with the HTTP headers in |headers|.
+func NewOTLPExporter(endpoint string, headers map[string]string) *OTLPExporter {
+	return &OTLPExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: otlpExportTimeout},
+	}
+}
+
+// ExportSpans implements tracesdk.SpanExporter.
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	if e.stopped.Load() || len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("OTLP endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Shutdown implements tracesdk.SpanExporter. Spans exported after shutdown are dropped.
+func (e *OTLPExporter) Shutdown(context.Context) error {
+	e.stopped.Store(true)
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// The types below are the JSON encoding of an OTLP ExportTraceServiceRequest. Trace and span ids are hex encoded and
+// 64-bit integers are strings, as the protocol's JSON mapping requires.
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+// otlp status codes, which are numbered differently than codes.Code
+const (
+	otlpStatusUnset = 0
+	otlpStatusOk    = 1
+	otlpStatusError = 2
+)
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+// newExportRequest groups |spans| by their resource and instrumentation scope.
+func newExportRequest(spans []tracesdk.ReadOnlySpan) otlpExportRequest {
+	var req otlpExportRequest
+	resources := make(map[string]int)
+	scopes := make(map[string]int)
+	for _, s := range spans {
+		resKey := s.Resource().Encoded(attribute.DefaultEncoder())
+		ri, ok := resources[resKey]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			resources[resKey] = ri
+			req.ResourceSpans = append(req.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: otlpAttributes(s.Resource().Attributes())},
+			})
+		}
+
+		scope := s.InstrumentationScope()
+		scopeKey := resKey + "\x00" + scope.Name + "\x00" + scope.Version
+		si, ok := scopes[scopeKey]
+		if !ok {
+			si = len(req.ResourceSpans[ri].ScopeSpans)
+			scopes[scopeKey] = si
+			req.ResourceSpans[ri].ScopeSpans = append(req.ResourceSpans[ri].ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+		ss := &req.ResourceSpans[ri].ScopeSpans[si]
+		ss.Spans = append(ss.Spans, newOtlpSpan(s))
+	}
+	return req
+}
+
+func newOtlpSpan(s tracesdk.ReadOnlySpan) otlpSpan {
+	sc := s.SpanContext()
+	span := otlpSpan{
+		TraceID:           sc.TraceID().String(),
+		SpanID:            sc.SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: unixNanos(s.StartTime()),
+		EndTimeUnixNano:   unixNanos(s.EndTime()),
+		Attributes:        otlpAttributes(s.Attributes()),
+	}
+	if parent := s.Parent(); parent.HasSpanID() {
+		span.ParentSpanID = parent.SpanID().String()
+	}
+	for _, ev := range s.Events() {
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: unixNanos(ev.Time),
+			Name:         ev.Name,
+			Attributes:   otlpAttributes(ev.Attributes),
+		})
+	}
+	switch s.Status().Code {
+	case codes.Ok:
+		span.Status.Code = otlpStatusOk
+	case codes.Error:
+		span.Status.Code = otlpStatusError
+		span.Status.Message = s.Status().Description
+	default:
+		span.Status.Code = otlpStatusUnset
+	}
+	return span
+}
+
+func unixNanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []otlpKeyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	kvs := make([]otlpKeyValue, len(attrs))
+	for i, kv := range attrs {
+		kvs[i] = otlpKeyValue{Key: string(kv.Key), Value: otlpValue(kv.Value)}
+	}
+	return kvs
+}
+
+func otlpValue(v attribute.Value) otlpAnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return otlpAnyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return otlpAnyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return otlpAnyValue{DoubleValue: &f}
+	case attribute.BOOLSLICE:
+		return otlpArray(v.AsBoolSlice(), attribute.BoolValue)
+	case attribute.INT64SLICE:
+		return otlpArray(v.AsInt64Slice(), attribute.Int64Value)
+	case attribute.FLOAT64SLICE:
+		return otlpArray(v.AsFloat64Slice(), attribute.Float64Value)
+	case attribute.STRINGSLICE:
+		return otlpArray(v.AsStringSlice(), attribute.StringValue)
+	default:
+		s := v.Emit()
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+func otlpArray[T any](vals []T, toValue func(T) attribute.Value) otlpAnyValue {
+	arr := &otlpArrayValue{Values: make([]otlpAnyValue, len(vals))}
+	for i, v := range vals {
+		arr.Values[i] = otlpValue(toValue(v))
+	}
+	return otlpAnyValue{ArrayValue: arr}
+}
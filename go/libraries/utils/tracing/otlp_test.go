@@ -0,0 +1,128 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var received otlpExportRequest
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		header = r.Header
+		received = otlpExportRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPExporter(srv.URL+"/v1/traces", map[string]string{"Authorization": "Bearer token"})
+	tp := tracesdk.NewTracerProvider(
+		tracesdk.WithSyncer(exp),
+		tracesdk.WithResource(resource.NewSchemaless(attribute.String("service.name", "dolt"))),
+	)
+	ctx, parent := tp.Tracer("sql").Start(context.Background(), "query", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := tp.Tracer("nbs").Start(ctx, "nbs.Put", trace.WithAttributes(
+		attribute.Int("size", 42),
+		attribute.StringSlice("tables", []string{"a", "b"}),
+	))
+	child.RecordError(errors.New("disk full"))
+	child.SetStatus(codes.Error, "disk full")
+	child.End()
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	require.Len(t, received.ResourceSpans, 1)
+	rs := received.ResourceSpans[0]
+	require.Len(t, rs.Resource.Attributes, 1)
+	assert.Equal(t, "service.name", rs.Resource.Attributes[0].Key)
+	require.Len(t, rs.ScopeSpans, 1)
+	assert.Equal(t, "nbs", rs.ScopeSpans[0].Scope.Name)
+	require.Len(t, rs.ScopeSpans[0].Spans, 1)
+
+	span := rs.ScopeSpans[0].Spans[0]
+	assert.Equal(t, "nbs.Put", span.Name)
+	assert.Equal(t, parent.SpanContext().TraceID().String(), span.TraceID)
+	assert.Equal(t, parent.SpanContext().SpanID().String(), span.ParentSpanID)
+	assert.Equal(t, int(trace.SpanKindInternal), span.Kind)
+	assert.Equal(t, otlpStatus{Code: otlpStatusError, Message: "disk full"}, span.Status)
+	require.Len(t, span.Attributes, 2)
+	assert.Equal(t, "size", span.Attributes[0].Key)
+	assert.Equal(t, "42", *span.Attributes[0].Value.IntValue)
+	require.NotNil(t, span.Attributes[1].Value.ArrayValue)
+	assert.Equal(t, "b", *span.Attributes[1].Value.ArrayValue.Values[1].StringValue)
+	require.Len(t, span.Events, 1)
+	assert.Equal(t, "exception", span.Events[0].Name)
+
+	parent.End()
+	span = received.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "query", span.Name)
+	assert.Empty(t, span.ParentSpanID)
+	assert.Equal(t, int(trace.SpanKindServer), span.Kind)
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestOTLPExporterError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad spans", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPExporter(srv.URL, nil)
+	spans := tracetestSpans(t)
+	err := exp.ExportSpans(context.Background(), spans)
+	assert.ErrorContains(t, err, "bad spans")
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.NoError(t, exp.ExportSpans(context.Background(), spans))
+}
+
+// tracetestSpans returns a finished span to export.
+func tracetestSpans(t *testing.T) []tracesdk.ReadOnlySpan {
+	var spans []tracesdk.ReadOnlySpan
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(spanCollector(func(s []tracesdk.ReadOnlySpan) {
+		spans = append(spans, s...)
+	})))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	require.Len(t, spans, 1)
+	return spans
+}
+
+type spanCollector func([]tracesdk.ReadOnlySpan)
+
+func (c spanCollector) ExportSpans(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+	c(spans)
+	return nil
+}
+
+func (c spanCollector) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing configures the export of the OpenTelemetry spans dolt emits, from SQL queries down through
+// transaction commits, prolly tree reads and writes, chunk store operations and remote API calls. Export is
+// configured with the standard OTEL_* environment variables of OpenTelemetry SDKs.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+)
+
+const (
+	// EnvOtlpEndpoint is the base URL of the collector spans are exported to, to which /v1/traces is appended.
+	EnvOtlpEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// EnvOtlpTracesEndpoint is the full URL spans are exported to. It takes precedence over EnvOtlpEndpoint.
+	EnvOtlpTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	// EnvOtlpHeaders are the HTTP headers sent with exported spans, as comma separated key=value pairs.
+	EnvOtlpHeaders = "OTEL_EXPORTER_OTLP_HEADERS"
+	// EnvOtlpTracesHeaders are added to, and override, the headers in EnvOtlpHeaders.
+	EnvOtlpTracesHeaders = "OTEL_EXPORTER_OTLP_TRACES_HEADERS"
+	// EnvOtlpProtocol is the transport protocol of the exporter. Only http/json is supported.
+	EnvOtlpProtocol = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	// EnvServiceName is the service name spans are reported under.
+	EnvServiceName = "OTEL_SERVICE_NAME"
+	// EnvSamplerArg is the fraction, between 0 and 1, of traces which are sampled. Defaults to 1.
+	EnvSamplerArg = "OTEL_TRACES_SAMPLER_ARG"
+
+	otlpTracesPath   = "/v1/traces"
+	otlpHTTPJSON     = "http/json"
+	defaultSampleArg = 1.0
+)
+
+// Config configures the export of spans.
+type Config struct {
+	// Endpoint is the full URL of the collector's traces endpoint, or "" if spans aren't exported.
+	Endpoint string
+	// Headers are the HTTP headers sent with exported spans.
+	Headers map[string]string
+	// ServiceName is the service name spans are reported under.
+	ServiceName string
+	// SampleRatio is the fraction of traces which are sampled.
+	SampleRatio float64
+}
+
+// ConfigFromEnv returns the export configuration set by the OTEL_* environment variables, using |serviceName| if
+// OTEL_SERVICE_NAME isn't set.
+func ConfigFromEnv(serviceName string) (Config, error) {
+	cfg := Config{ServiceName: serviceName, SampleRatio: defaultSampleArg}
+	if name := os.Getenv(EnvServiceName); name != "" {
+		cfg.ServiceName = name
+	}
+
+	if endpoint := os.Getenv(EnvOtlpTracesEndpoint); endpoint != "" {
+		cfg.Endpoint = endpoint
+	} else if endpoint = os.Getenv(EnvOtlpEndpoint); endpoint != "" {
+		cfg.Endpoint = strings.TrimSuffix(endpoint, "/") + otlpTracesPath
+	} else {
+		return cfg, nil
+	}
+	if _, err := url.Parse(cfg.Endpoint); err != nil {
+		return Config{}, fmt.Errorf("invalid OTLP endpoint %q: %w", cfg.Endpoint, err)
+	}
+
+	if protocol := os.Getenv(EnvOtlpProtocol); protocol != "" && protocol != otlpHTTPJSON {
+		return Config{}, fmt.Errorf("unsupported %s %q, only %s is supported", EnvOtlpProtocol, protocol, otlpHTTPJSON)
+	}
+
+	cfg.Headers = make(map[string]string)
+	for _, env := range []string{EnvOtlpHeaders, EnvOtlpTracesHeaders} {
+		if err := parseHeaders(os.Getenv(env), cfg.Headers); err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", env, err)
+		}
+	}
+
+	if arg := os.Getenv(EnvSamplerArg); arg != "" {
+		ratio, err := strconv.ParseFloat(arg, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return Config{}, fmt.Errorf("invalid %s %q, must be a number between 0 and 1", EnvSamplerArg, arg)
+		}
+		cfg.SampleRatio = ratio
+	}
+	return cfg, nil
+}
+
+// parseHeaders parses comma separated key=value pairs, with URL encoded values, into |headers|.
+func parseHeaders(s string, headers map[string]string) error {
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return fmt.Errorf("expected key=value, got %q", pair)
+		}
+		v, err := url.QueryUnescape(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+		headers[strings.TrimSpace(k)] = v
+	}
+	return nil
+}
+
+// Init installs a global tracer provider which exports spans as configured by |cfg|, and returns a function which
+// flushes and stops the export. Returns false if |cfg| doesn't export spans, in which case nothing is installed.
+func Init(cfg Config) (shutdown func(context.Context) error, ok bool) {
+	if cfg.Endpoint == "" {
+		return nil, false
+	}
+	tp := tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(NewOTLPExporter(cfg.Endpoint, cfg.Headers)),
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(cfg.SampleRatio))),
+		tracesdk.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(cfg.ServiceName),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown, true
+}
+
+// Enabled returns whether spans are recorded, because a tracer provider was installed by Init or dolt's --jaeger
+// flag. The global tracer provider otherwise discards them.
+func Enabled() bool {
+	_, ok := otel.GetTracerProvider().(*tracesdk.TracerProvider)
+	return ok
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	cfg, err := ConfigFromEnv("dolt")
+	require.NoError(t, err)
+	assert.Equal(t, Config{ServiceName: "dolt", SampleRatio: 1}, cfg)
+	_, ok := Init(cfg)
+	assert.False(t, ok)
+
+	t.Setenv(EnvOtlpEndpoint, "http://collector:4318/")
+	t.Setenv(EnvOtlpHeaders, "authorization=Bearer%20token, x-team=db")
+	t.Setenv(EnvOtlpTracesHeaders, "x-team=storage")
+	t.Setenv(EnvServiceName, "dolt-sql-server")
+	t.Setenv(EnvSamplerArg, "0.25")
+	cfg, err = ConfigFromEnv("dolt")
+	require.NoError(t, err)
+	assert.Equal(t, Config{
+		Endpoint:    "http://collector:4318/v1/traces",
+		Headers:     map[string]string{"authorization": "Bearer token", "x-team": "storage"},
+		ServiceName: "dolt-sql-server",
+		SampleRatio: 0.25,
+	}, cfg)
+
+	t.Setenv(EnvOtlpTracesEndpoint, "http://traces:4318/custom")
+	cfg, err = ConfigFromEnv("dolt")
+	require.NoError(t, err)
+	assert.Equal(t, "http://traces:4318/custom", cfg.Endpoint)
+
+	t.Setenv(EnvSamplerArg, "2")
+	_, err = ConfigFromEnv("dolt")
+	assert.Error(t, err)
+	t.Setenv(EnvSamplerArg, "1")
+
+	t.Setenv(EnvOtlpHeaders, "novalue")
+	_, err = ConfigFromEnv("dolt")
+	assert.Error(t, err)
+	t.Setenv(EnvOtlpHeaders, "")
+
+	t.Setenv(EnvOtlpProtocol, "grpc")
+	_, err = ConfigFromEnv("dolt")
+	assert.ErrorContains(t, err, "http/json")
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	runner "github.com/dolthub/dolt/go/performance/utils/benchmark_runner"
+)
+
+const usage = "usage: benchmark-runner compare [options] <baseline results> <candidate results>\n" +
+	"compares two csv or json result sets and exits with status 1 if any test regressed\n" +
+	"by more than -threshold percent with a p-value below -alpha\n"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "compare":
+		os.Exit(compare(os.Args[2:]))
+	default:
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+}
+
+func compare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	metricName := fs.String("metric", "latency_percentile", "the result field to compare")
+	threshold := fs.Float64("threshold", 5, "the percent a test may regress by before failing the comparison")
+	alpha := fs.Float64("alpha", 0.05, "the significance level of regressions, for tests with multiple runs in both result sets")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return 2
+	}
+
+	metric, err := runner.GetMetric(*metricName)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	baseline, err := runner.ReadResults(fs.Arg(0))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	candidate, err := runner.ReadResults(fs.Arg(1))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	comps := runner.CompareResults(baseline, candidate, runner.CompareOptions{
+		Metric:    metric,
+		Threshold: *threshold,
+		Alpha:     *alpha,
+	})
+	if len(comps) == 0 {
+		log.Fatalln("the result sets have no tests in common")
+	}
+	if err = runner.WriteComparisons(os.Stdout, metric, comps); err != nil {
+		log.Fatalln(err)
+	}
+
+	if regressions := comps.Regressions(); len(regressions) > 0 {
+		fmt.Printf("\n%d of %d tests regressed by more than %g%%\n", len(regressions), len(comps), *threshold)
+		return 1
+	}
+	return 0
+}
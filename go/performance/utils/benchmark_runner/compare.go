@@ -0,0 +1,289 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Metric is a field of a Result which is compared between two result sets
+type Metric struct {
+	// Name is the name of the field in csv and json results
+	Name string
+
+	// HigherIsBetter is whether an increase is an improvement, as for throughput, rather than a regression, as for latency
+	HigherIsBetter bool
+
+	value func(r *Result) float64
+}
+
+// Metrics are the metrics results can be compared by
+var Metrics = []Metric{
+	{Name: "latency_percentile", value: func(r *Result) float64 { return r.LatencyPercentile }},
+	{Name: "latency_average_ms", value: func(r *Result) float64 { return r.LatencyAvgMS }},
+	{Name: "sql_transactions_per_second", HigherIsBetter: true, value: func(r *Result) float64 { return r.TransactionsPerSecond }},
+	{Name: "sql_total_queries_per_second", HigherIsBetter: true, value: func(r *Result) float64 { return r.SqlTotalQueriesPerSecond }},
+}
+
+// GetMetric returns the Metric named |name|
+func GetMetric(name string) (Metric, error) {
+	names := make([]string, len(Metrics))
+	for i, m := range Metrics {
+		if m.Name == name {
+			return m, nil
+		}
+		names[i] = m.Name
+	}
+	return Metric{}, fmt.Errorf("unsupported metric %s, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// CompareOptions are the options used to compare two result sets
+type CompareOptions struct {
+	// Metric is the metric compared
+	Metric Metric
+
+	// Threshold is the percent by which a test may regress before the regression fails the comparison
+	Threshold float64
+
+	// Alpha is the significance level of regressions. A regression only fails the comparison if the two-sided p-value
+	// of Welch's t-test of the runs of the test is below Alpha. Tests with fewer than two runs in either result set
+	// can't be tested, so their regressions fail the comparison on the Threshold alone.
+	Alpha float64
+}
+
+// Comparison is the comparison of the runs of a test in two result sets
+type Comparison struct {
+	// TestName is the name of the test
+	TestName string
+
+	// BaselineRuns and CandidateRuns are the number of runs of the test in each result set
+	BaselineRuns  int
+	CandidateRuns int
+
+	// BaselineMean and CandidateMean are the mean of the metric over the runs of the test in each result set
+	BaselineMean  float64
+	CandidateMean float64
+
+	// Delta is the change of the mean from the baseline to the candidate in percent
+	Delta float64
+
+	// PValue is the p-value of the difference of the means, or NaN if there weren't enough runs to test it
+	PValue float64
+
+	// Regression is whether the test regressed enough to fail the comparison
+	Regression bool
+}
+
+// Comparisons is a slice of Comparison
+type Comparisons []*Comparison
+
+// Regressions returns the comparisons which failed
+func (c Comparisons) Regressions() Comparisons {
+	var regressions Comparisons
+	for _, comp := range c {
+		if comp.Regression {
+			regressions = append(regressions, comp)
+		}
+	}
+	return regressions
+}
+
+// CompareResults compares the runs of each test in |baseline| with its runs in |candidate|. Runs of a test with
+// different thread or connection counts are compared separately. Tests which only appear in one of the result sets
+// are skipped. Each result set should contain the results of a single server.
+func CompareResults(baseline, candidate Results, opts CompareOptions) Comparisons {
+	baseRuns := groupByTest(baseline, opts.Metric)
+	candRuns := groupByTest(candidate, opts.Metric)
+
+	comps := make(Comparisons, 0, len(baseRuns))
+	for name, base := range baseRuns {
+		cand, ok := candRuns[name]
+		if !ok {
+			continue
+		}
+		comps = append(comps, compareRuns(name, base, cand, opts))
+	}
+	sort.Slice(comps, func(i, j int) bool {
+		return comps[i].TestName < comps[j].TestName
+	})
+	return comps
+}
+
+func groupByTest(results Results, metric Metric) map[string][]float64 {
+	runs := make(map[string][]float64)
+	for _, r := range results {
+		name := r.MatrixName()
+		runs[name] = append(runs[name], metric.value(r))
+	}
+	return runs
+}
+
+func compareRuns(name string, base, cand []float64, opts CompareOptions) *Comparison {
+	baseMean, baseVar := meanAndVariance(base)
+	candMean, candVar := meanAndVariance(cand)
+	c := &Comparison{
+		TestName:      name,
+		BaselineRuns:  len(base),
+		CandidateRuns: len(cand),
+		BaselineMean:  baseMean,
+		CandidateMean: candMean,
+		PValue:        math.NaN(),
+	}
+	if baseMean != 0 {
+		c.Delta = (candMean - baseMean) / math.Abs(baseMean) * 100
+	}
+	if len(base) > 1 && len(cand) > 1 {
+		c.PValue = welchTTest(baseMean, baseVar, len(base), candMean, candVar, len(cand))
+	}
+
+	regressed := c.Delta > opts.Threshold
+	if opts.Metric.HigherIsBetter {
+		regressed = -c.Delta > opts.Threshold
+	}
+	c.Regression = regressed && (math.IsNaN(c.PValue) || c.PValue < opts.Alpha)
+	return c
+}
+
+// meanAndVariance returns the mean and sample variance of |vals|
+func meanAndVariance(vals []float64) (float64, float64) {
+	var sum float64
+	for _, v := range vals {
+		sum += v
+	}
+	mean := sum / float64(len(vals))
+	if len(vals) < 2 {
+		return mean, 0
+	}
+	var sq float64
+	for _, v := range vals {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, sq / float64(len(vals)-1)
+}
+
+// welchTTest returns the two-sided p-value of Welch's t-test of two samples with the given means, sample variances
+// and sizes
+func welchTTest(m1, v1 float64, n1 int, m2, v2 float64, n2 int) float64 {
+	se1, se2 := v1/float64(n1), v2/float64(n2)
+	if se1+se2 == 0 {
+		if m1 == m2 {
+			return 1
+		}
+		return 0
+	}
+	t := (m1 - m2) / math.Sqrt(se1+se2)
+	df := (se1 + se2) * (se1 + se2) / (se1*se1/float64(n1-1) + se2*se2/float64(n2-1))
+	return regularizedIncompleteBeta(df/2, 0.5, df/(df+t*t))
+}
+
+// regularizedIncompleteBeta returns I_x(a, b), evaluated with its continued fraction
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	} else if x >= 1 {
+		return 1
+	}
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log(1-x))
+	// the continued fraction converges quickly for x < (a+1)/(a+b+2), so use the symmetry I_x(a, b) = 1 - I_1-x(b, a)
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+func betaContinuedFraction(a, b, x float64) float64 {
+	const maxIterations = 300
+	const epsilon = 1e-14
+	const tiny = 1e-300
+
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		// even step
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		// odd step
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
+
+// ReadResults reads a csv or json results file, depending on its extension
+func ReadResults(filename string) (Results, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case CsvExt:
+		return ReadResultsCsv(filename)
+	case JsonExt:
+		return ReadResultsJson(filename)
+	default:
+		return nil, fmt.Errorf("unsupported results file %s, expected a %s or %s file", filename, CsvExt, JsonExt)
+	}
+}
+
+// WriteComparisons writes |comps| as a table to |w|
+func WriteComparisons(w io.Writer, metric Metric, comps Comparisons) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "test\tbaseline %s\tcandidate %s\tdelta\tp-value\tresult\n", metric.Name, metric.Name)
+	for _, c := range comps {
+		pValue := "n/a"
+		if !math.IsNaN(c.PValue) {
+			pValue = fmt.Sprintf("%.4f", c.PValue)
+		}
+		result := "ok"
+		if c.Regression {
+			result = "REGRESSION"
+		}
+		fmt.Fprintf(tw, "%s\t%.2f (n=%d)\t%.2f (n=%d)\t%+.2f%%\t%s\t%s\n", c.TestName, c.BaselineMean, c.BaselineRuns,
+			c.CandidateMean, c.CandidateRuns, c.Delta, pValue, result)
+	}
+	return tw.Flush()
+}
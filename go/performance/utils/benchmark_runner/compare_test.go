@@ -0,0 +1,154 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWelchTTest(t *testing.T) {
+	// I_x(1, 1) = x and I_x(a, 1) = x^a
+	assert.InDelta(t, 0.3, regularizedIncompleteBeta(1, 1, 0.3), 1e-9)
+	assert.InDelta(t, math.Pow(0.7, 3.5), regularizedIncompleteBeta(3.5, 1, 0.7), 1e-9)
+
+	// with 2 degrees of freedom, the two-sided p-value of t is 1 - |t|/sqrt(2+t^2)
+	m1, v1 := meanAndVariance([]float64{1, 3})
+	m2, v2 := meanAndVariance([]float64{2, 4})
+	tStat := -1 / math.Sqrt(2)
+	assert.InDelta(t, 1-math.Abs(tStat)/math.Sqrt(2+tStat*tStat), welchTTest(m1, v1, 2, m2, v2, 2), 1e-9)
+
+	// with 1 degree of freedom, the two-sided p-value of t=1 is 0.5
+	assert.InDelta(t, 0.5, welchTTest(1, 2, 2, 0, 0, 2), 1e-9)
+
+	assert.Equal(t, 1.0, welchTTest(5, 0, 3, 5, 0, 3))
+	assert.Equal(t, 0.0, welchTTest(5, 0, 3, 6, 0, 3))
+}
+
+func results(testName string, latencies ...float64) Results {
+	var res Results
+	for _, l := range latencies {
+		res = append(res, &Result{TestName: testName, LatencyPercentile: l, TransactionsPerSecond: 1000 / l})
+	}
+	return res
+}
+
+func TestCompareResults(t *testing.T) {
+	baseline := append(append(append(append(
+		results("stable", 10, 10.2, 9.8, 10.1),
+		results("slower", 10, 10.1, 9.9, 10)...),
+		results("noisy", 10, 20, 5, 15)...),
+		results("single", 10)...),
+		results("baseline_only", 1)...)
+	candidate := append(append(append(append(
+		results("stable", 10.1, 9.9, 10, 10.2),
+		results("slower", 12, 12.1, 11.9, 12)...),
+		results("noisy", 20, 5, 25, 10)...),
+		results("single", 11)...),
+		results("candidate_only", 1)...)
+
+	latency, err := GetMetric("latency_percentile")
+	require.NoError(t, err)
+	comps := CompareResults(baseline, candidate, CompareOptions{Metric: latency, Threshold: 5, Alpha: 0.05})
+	require.Len(t, comps, 4)
+
+	byName := make(map[string]*Comparison)
+	for _, c := range comps {
+		byName[c.TestName] = c
+	}
+
+	stable := byName["stable"]
+	assert.Equal(t, 4, stable.BaselineRuns)
+	assert.InDelta(t, 0.25, stable.Delta, 0.01)
+	assert.False(t, stable.Regression)
+
+	slower := byName["slower"]
+	assert.InDelta(t, 20, slower.Delta, 0.01)
+	assert.Less(t, slower.PValue, 0.001)
+	assert.True(t, slower.Regression)
+
+	// a large but insignificant regression passes
+	noisy := byName["noisy"]
+	assert.Greater(t, noisy.Delta, 5.0)
+	assert.Greater(t, noisy.PValue, 0.05)
+	assert.False(t, noisy.Regression)
+
+	// a regression without enough runs to test fails on the threshold alone
+	single := byName["single"]
+	assert.True(t, math.IsNaN(single.PValue))
+	assert.True(t, single.Regression)
+
+	assert.Len(t, comps.Regressions(), 2)
+
+	// for throughput an increase is an improvement
+	tps, err := GetMetric("sql_transactions_per_second")
+	require.NoError(t, err)
+	comps = CompareResults(candidate, baseline, CompareOptions{Metric: tps, Threshold: 5, Alpha: 0.05})
+	assert.Empty(t, comps.Regressions())
+	comps = CompareResults(baseline, candidate, CompareOptions{Metric: tps, Threshold: 5, Alpha: 0.05})
+	assert.Len(t, comps.Regressions(), 2)
+
+	_, err = GetMetric("bogus")
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteComparisons(&buf, tps, comps))
+	assert.Contains(t, buf.String(), "REGRESSION")
+	assert.Contains(t, buf.String(), "n/a")
+
+	// runs with different thread and connection counts are compared separately
+	matrix := func(res Results, threads int) Results {
+		for _, r := range res {
+			r.Threads, r.Connections = threads, 1
+		}
+		return res
+	}
+	baseline = append(matrix(results("scaling", 10, 10, 10), 1), matrix(results("scaling", 20, 20, 20), 32)...)
+	candidate = append(matrix(results("scaling", 10, 10, 10), 1), matrix(results("scaling", 30, 30, 30), 32)...)
+	comps = CompareResults(baseline, candidate, CompareOptions{Metric: latency, Threshold: 5, Alpha: 0.05})
+	require.Len(t, comps, 2)
+	assert.Equal(t, "scaling (threads=1, connections=1)", comps[0].TestName)
+	assert.False(t, comps[0].Regression)
+	assert.Equal(t, "scaling (threads=32, connections=1)", comps[1].TestName)
+	assert.True(t, comps[1].Regression)
+}
+
+func TestReadResults(t *testing.T) {
+	dir := t.TempDir()
+	res := Results{genRandomResult(), genRandomResult()}
+
+	csvFile := filepath.Join(dir, "results"+CsvExt)
+	require.NoError(t, WriteResultsCsv(csvFile, res))
+	read, err := ReadResults(csvFile)
+	require.NoError(t, err)
+	assert.Len(t, read, 2)
+
+	jsonFile := filepath.Join(dir, "results"+JsonExt)
+	require.NoError(t, WriteResultsJson(jsonFile, res))
+	read, err = ReadResults(jsonFile)
+	require.NoError(t, err)
+	assert.Len(t, read, 2)
+
+	txtFile := filepath.Join(dir, "results.txt")
+	require.NoError(t, os.WriteFile(txtFile, nil, 0644))
+	_, err = ReadResults(txtFile)
+	assert.Error(t, err)
+}
@@ -31,9 +31,14 @@ type SysbenchConfig interface {
 	Config
 	GetTestOptions() []string
 	GetTestConfigs() []TestConfig
+	GetThreads() []int
+	GetConnections() []int
+	GetResultsDb() string
+	GetResultsDbExec() string
 }
 
 type TpccConfig interface {
 	Config
 	GetScaleFactors() []int
+	GetReportInterval() int
 }
@@ -21,6 +21,7 @@ const (
 	Doltgres ServerType = "doltgres"
 	Postgres ServerType = "postgres"
 	MySql    ServerType = "mysql"
+	MariaDb  ServerType = "mariadb"
 
 	CsvFormat  = "csv"
 	JsonFormat = "json"
@@ -36,7 +37,9 @@ const (
 	defaultDoltgresPort = 5432
 	defaultPostgresPort = defaultDoltgresPort
 
-	defaultMysqlSocket = "/var/run/mysqld/mysqld.sock"
+	defaultMysqlSocket   = "/var/run/mysqld/mysqld.sock"
+	defaultMariaDbPort   = defaultMysqlPort
+	defaultMariaDbSocket = "/run/mysqld/mysqld.sock"
 
 	tcpProtocol  = "tcp"
 	unixProtocol = "unix"
@@ -48,6 +51,7 @@ const (
 	sysbenchDbPsModeDisable      = "disable"
 	sysbenchRandTypeFlag         = "--rand-type"
 	sysbenchRandTypeUniform      = "uniform"
+	sysbenchThreadsFlag          = "--threads"
 	sysbenchMysqlDbFlag          = "--mysql-db"
 	sysbenchDbDriverFlag         = "--db-driver"
 	sysbenchMysqlHostFlag        = "--mysql-host"
@@ -74,6 +78,7 @@ const (
 	doltgresDataDirFlag         = "--data-dir"
 	MysqlDataDirFlag            = "--datadir"
 	MysqlInitializeInsecureFlag = "--initialize-insecure"
+	socketFlag                  = "--socket"
 	cpuProfileFilename          = "cpu.pprof"
 
 	sysbenchOltpReadOnlyTestName       = "oltp_read_only"
@@ -118,6 +123,13 @@ const (
 	doltCloneCommand      = "clone"
 	doltVersionCommand    = "version"
 	doltInitCommand       = "init"
+	doltSqlCommand        = "sql"
+	doltSqlResultFlag     = "-r"
+	doltCommitCommand     = "commit"
+	doltCommitAllFlag     = "-A"
+	doltCommitMessageFlag = "-m"
+	defaultResultsDbExec  = "dolt"
+	resultsDbDatetime     = "2006-01-02 15:04:05"
 	dbName                = "test"
 	bigEmptyRepo          = "max-hoffman/big-empty"
 	nbfEnvVar             = "DOLT_DEFAULT_BIN_FORMAT"
@@ -162,6 +174,7 @@ const (
 	tpccTimeFlag             = "--time"
 	tpccThreadsFlag          = "--threads"
 	tpccReportIntervalFlag   = "--report_interval"
+	tpccHistogramFlag        = "--histogram"
 	tpccTablesFlag           = "--tables"
 	tpccScaleFlag            = "--scale"
 	tpccTransactionLevelFlag = "--trx_level"
@@ -180,6 +193,12 @@ const (
 	mysqlSetGlobalLocalInfileSql     = "SET GLOBAL local_infile = 'ON';"
 	mysqlSetGlobalSqlModeSql         = "SET GLOBAL sql_mode=(SELECT REPLACE(@@sql_mode,'ONLY_FULL_GROUP_BY',''));"
 
+	mariadbAuthRootNormalFlag    = "--auth-root-authentication-method=normal"
+	mariadbSkipTestDbFlag        = "--skip-test-db"
+	mariadbVersionFlag           = "--version"
+	mariadbVersionMarker         = "MariaDB"
+	mariadbCreateUserSqlTemplate = "CREATE USER %s IDENTIFIED BY '%s';"
+
 	postgresInitDbDataDirFlag         = "--pgdata"
 	postgresUsernameFlag              = "--username"
 	postgresUsername                  = "postgres"
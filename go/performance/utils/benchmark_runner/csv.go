@@ -16,10 +16,12 @@ package benchmark_runner
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 )
 
@@ -36,6 +38,8 @@ func FromResultCsvHeaders() []string {
 		"server_params",
 		"test_name",
 		"test_params",
+		"threads",
+		"connections",
 		"created_at",
 
 		// benchmark headers
@@ -57,6 +61,8 @@ func FromResultCsvHeaders() []string {
 		"latency_maximum_ms",
 		"latency_percentile",
 		"latency_sum_ms",
+		"transaction_latencies",
+		"intervals",
 	}
 }
 
@@ -88,6 +94,10 @@ func FromHeaderResultColumnValue(h string, r *Result) (string, error) {
 		val = r.TestName
 	case "test_params":
 		val = r.TestParams
+	case "threads":
+		val = fmt.Sprintf(intTemplate, r.Threads)
+	case "connections":
+		val = fmt.Sprintf(intTemplate, r.Connections)
 	case "created_at":
 		val = r.CreatedAt
 
@@ -128,6 +138,10 @@ func FromHeaderResultColumnValue(h string, r *Result) (string, error) {
 		val = fmt.Sprintf(floatTemplate, r.LatencyPercentile)
 	case "latency_sum_ms":
 		val = fmt.Sprintf(floatTemplate, r.LatencySumMS)
+	case "transaction_latencies":
+		return toJsonColumnValue(r.TransactionLatencies, len(r.TransactionLatencies))
+	case "intervals":
+		return toJsonColumnValue(r.Intervals, len(r.Intervals))
 	default:
 		return "", ErrUnsupportedHeaderField
 	}
@@ -158,6 +172,18 @@ func FromHeaderResultFieldValue(field string, val string, r *Result) error {
 		r.TestName = val
 	case "test_params":
 		r.TestParams = val
+	case "threads":
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+		r.Threads = i
+	case "connections":
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return err
+		}
+		r.Connections = i
 	case "created_at":
 		_, err := time.Parse(stampFormat, val)
 		if err != nil {
@@ -234,12 +260,36 @@ func FromHeaderResultFieldValue(field string, val string, r *Result) error {
 		return updateResult(r, percentile, val)
 	case "latency_sum_ms":
 		return updateResult(r, sum, val)
+	case "transaction_latencies":
+		return fromJsonColumnValue(val, &r.TransactionLatencies)
+	case "intervals":
+		return fromJsonColumnValue(val, &r.Intervals)
 	default:
 		return ErrUnsupportedHeaderField
 	}
 	return nil
 }
 
+// toJsonColumnValue encodes a list valued field of a Result as json, or as an empty string if the list is empty
+func toJsonColumnValue(v interface{}, n int) (string, error) {
+	if n == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// fromJsonColumnValue decodes a list valued field of a Result encoded by toJsonColumnValue
+func fromJsonColumnValue(val string, v interface{}) error {
+	if val == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(val), v)
+}
+
 // WriteResultsCsv writes Results to a csv
 func WriteResultsCsv(filename string, results Results) (err error) {
 	dir := filepath.Dir(filename)
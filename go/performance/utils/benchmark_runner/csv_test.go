@@ -31,6 +31,8 @@ func TestWriteReadResultsCsv(t *testing.T) {
 
 	expectedOne := genRandomResult()
 	expectedTwo := genRandomResult()
+	expectedOne.TransactionLatencies = []TransactionLatency{{Type: "all", Count: 10, P50MS: 1.5, P95MS: 3.25, P99MS: 4}}
+	expectedOne.Intervals = []IntervalResult{{TimeSeconds: 10, Threads: 1, TransactionsPerSecond: 45.69, LatencyPercentileMS: 48.34}}
 
 	filename := filepath.Join(tmpDir, fmt.Sprintf("test-results%s", CsvExt))
 	err = WriteResultsCsv(filename, []*Result{expectedOne, expectedTwo})
@@ -43,4 +45,10 @@ func TestWriteReadResultsCsv(t *testing.T) {
 	assert.Equal(t, expectedOne.LatencySumMS, actual[0].LatencySumMS)
 	assert.Equal(t, expectedTwo.SqlTotalQueries, actual[1].SqlTotalQueries)
 	assert.Equal(t, expectedTwo.LatencySumMS, actual[1].LatencySumMS)
+	assert.Equal(t, expectedOne.Threads, actual[0].Threads)
+	assert.Equal(t, expectedOne.Connections, actual[0].Connections)
+	assert.Equal(t, expectedOne.TransactionLatencies, actual[0].TransactionLatencies)
+	assert.Equal(t, expectedOne.Intervals, actual[0].Intervals)
+	assert.Nil(t, actual[1].TransactionLatencies)
+	assert.Nil(t, actual[1].Intervals)
 }
@@ -0,0 +1,348 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resultsDbMigrations are the statements which create and update the schema of a results database. The schema of a
+// results database is at version N once the first N migrations have been applied, and the version is stored in its
+// schema_version table. New migrations must be appended, and existing migrations must not be changed.
+var resultsDbMigrations = []string{
+	`CREATE TABLE schema_version (version int primary key);
+CREATE TABLE runs (
+  suite_id varchar(64) primary key,
+  server_name varchar(64) not null,
+  server_version varchar(255) not null,
+  server_params text,
+  runtime_os varchar(64),
+  runtime_goarch varchar(64),
+  hostname varchar(255),
+  cpu_model varchar(255),
+  cpu_count int,
+  memory_bytes bigint,
+  created_at datetime
+);
+CREATE TABLE results (
+  id varchar(64) primary key,
+  suite_id varchar(64) not null,
+  test_id varchar(64),
+  runtime_os varchar(64),
+  runtime_goarch varchar(64),
+  server_name varchar(64),
+  server_version varchar(255),
+  server_params text,
+  test_name varchar(255),
+  test_params text,
+  threads int,
+  connections int,
+  created_at datetime,
+  sql_read_queries bigint,
+  sql_write_queries bigint,
+  sql_other_queries bigint,
+  sql_total_queries bigint,
+  sql_total_queries_per_second double,
+  sql_transactions_total bigint,
+  sql_transactions_per_second double,
+  sql_ignored_errors_total bigint,
+  sql_ignored_errors_per_second double,
+  sql_reconnects_total bigint,
+  sql_reconnects_per_second double,
+  total_time_seconds double,
+  total_number_of_events bigint,
+  latency_minimum_ms double,
+  latency_average_ms double,
+  latency_maximum_ms double,
+  latency_percentile double,
+  latency_sum_ms double,
+  index (suite_id),
+  index (test_name)
+);`,
+	`ALTER TABLE results ADD COLUMN transaction_latencies json;
+ALTER TABLE results ADD COLUMN intervals json;`,
+}
+
+// HardwareInfo describes the machine benchmarks ran on
+type HardwareInfo struct {
+	Hostname    string
+	CpuModel    string
+	CpuCount    int
+	MemoryBytes int64
+}
+
+// GetHardwareInfo returns the HardwareInfo of this machine. Fields which can't be determined are left empty.
+func GetHardwareInfo(ctx context.Context) HardwareInfo {
+	info := HardwareInfo{CpuCount: runtime.NumCPU()}
+	info.Hostname, _ = os.Hostname()
+	switch runtime.GOOS {
+	case "linux":
+		if b, err := os.ReadFile("/proc/cpuinfo"); err == nil {
+			info.CpuModel = parseCpuInfoModel(string(b))
+		}
+		if b, err := os.ReadFile("/proc/meminfo"); err == nil {
+			info.MemoryBytes = parseMemInfoTotal(string(b))
+		}
+	case "darwin":
+		if out, err := exec.CommandContext(ctx, "sysctl", "-n", "machdep.cpu.brand_string").Output(); err == nil {
+			info.CpuModel = strings.TrimSpace(string(out))
+		}
+		if out, err := exec.CommandContext(ctx, "sysctl", "-n", "hw.memsize").Output(); err == nil {
+			info.MemoryBytes, _ = strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+		}
+	}
+	return info
+}
+
+// parseCpuInfoModel returns the first model name in the contents of /proc/cpuinfo
+func parseCpuInfoModel(cpuInfo string) string {
+	for _, line := range strings.Split(cpuInfo, "\n") {
+		key, val, ok := strings.Cut(line, ":")
+		if ok && strings.TrimSpace(key) == "model name" {
+			return strings.TrimSpace(val)
+		}
+	}
+	return ""
+}
+
+// parseMemInfoTotal returns the total memory in bytes in the contents of /proc/meminfo
+func parseMemInfoTotal(memInfo string) int64 {
+	for _, line := range strings.Split(memInfo, "\n") {
+		key, val, ok := strings.Cut(line, ":")
+		if !ok || key != "MemTotal" {
+			continue
+		}
+		fields := strings.Fields(val)
+		if len(fields) == 0 {
+			return 0
+		}
+		kb, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
+
+// ResultsDb is a Dolt database results are written to. Each call to Write inserts the results of a server into the
+// results table, records the server and the hardware it ran on in the runs table, and commits them, so that the
+// history of benchmark results can be queried across runs.
+type ResultsDb struct {
+	// Dir is the directory of the database, which is created if it doesn't exist
+	Dir string
+
+	// DoltExec is the dolt binary used to write to the database
+	DoltExec string
+
+	// Hardware is the machine the results were produced on
+	Hardware HardwareInfo
+}
+
+// NewResultsDb returns a ResultsDb for the database in |dir|, written with |doltExec|, or the dolt binary on the PATH
+// if it's empty
+func NewResultsDb(dir, doltExec string, hardware HardwareInfo) *ResultsDb {
+	if doltExec == "" {
+		doltExec = defaultResultsDbExec
+	}
+	return &ResultsDb{Dir: dir, DoltExec: doltExec, Hardware: hardware}
+}
+
+// Write inserts |results|, which are the results of a single server, and commits them
+func (db *ResultsDb) Write(ctx context.Context, results Results) error {
+	if len(results) == 0 {
+		return nil
+	}
+	if err := db.init(ctx); err != nil {
+		return err
+	}
+	version, err := db.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	script, err := resultsDbScript(version, results, db.Hardware)
+	if err != nil {
+		return err
+	}
+	if _, err = db.sql(ctx, script); err != nil {
+		return err
+	}
+
+	msg := resultsDbCommitMessage(results[0], db.Hardware)
+	return db.command(ctx, doltCommitCommand, doltCommitAllFlag, doltCommitMessageFlag, msg).Run()
+}
+
+// init creates the database if it doesn't exist
+func (db *ResultsDb) init(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Join(db.Dir, doltDataDir)); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(db.Dir, os.ModePerm); err != nil {
+		return err
+	}
+	if err := CheckSetDoltConfig(ctx, db.DoltExec, doltConfigUsernameKey, doltBenchmarkUser); err != nil {
+		return err
+	}
+	if err := CheckSetDoltConfig(ctx, db.DoltExec, doltConfigEmailKey, doltBenchmarkEmail); err != nil {
+		return err
+	}
+	return db.command(ctx, doltInitCommand).Run()
+}
+
+// schemaVersion returns the version of the schema of the database, which is zero for a new database
+func (db *ResultsDb) schemaVersion(ctx context.Context) (int, error) {
+	exists, err := db.queryValue(ctx, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = database() AND table_name = 'schema_version';")
+	if err != nil || exists == "0" {
+		return 0, err
+	}
+	version, err := db.queryValue(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version;")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(version)
+}
+
+// queryValue returns the value of the first column of the first row returned by |query|
+func (db *ResultsDb) queryValue(ctx context.Context, query string) (string, error) {
+	out, err := db.sql(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		return "", err
+	}
+	if len(rows) < 2 || len(rows[1]) == 0 {
+		return "", fmt.Errorf("query returned no rows: %s", query)
+	}
+	return rows[1][0], nil
+}
+
+// sql runs |script| in the database and returns its output in csv format
+func (db *ResultsDb) sql(ctx context.Context, script string) (string, error) {
+	cmd := db.command(ctx, doltSqlCommand, doltSqlResultFlag, CsvFormat)
+	cmd.Stdin = strings.NewReader(script)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("error writing to results database %s: %w: %s", db.Dir, err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+func (db *ResultsDb) command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := ExecCommand(ctx, db.DoltExec, args...)
+	cmd.Dir = db.Dir
+	return cmd
+}
+
+// resultsDbScript returns the statements which migrate a results database from schema version |version| to the
+// latest version and insert |results|
+func resultsDbScript(version int, results Results, hardware HardwareInfo) (string, error) {
+	if version > len(resultsDbMigrations) {
+		return "", fmt.Errorf("results database schema version %d is newer than the latest supported version %d", version, len(resultsDbMigrations))
+	}
+
+	var sb strings.Builder
+	for i := version; i < len(resultsDbMigrations); i++ {
+		sb.WriteString(resultsDbMigrations[i])
+		sb.WriteString("\n")
+	}
+	if version < len(resultsDbMigrations) {
+		fmt.Fprintf(&sb, "REPLACE INTO schema_version VALUES (%d);\n", len(resultsDbMigrations))
+	}
+
+	first := results[0]
+	createdAt, err := resultsDbTime(first.CreatedAt)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(&sb, "REPLACE INTO runs VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %d, %d, %s);\n",
+		sqlString(first.SuiteId), sqlString(first.ServerName), sqlString(first.ServerVersion), sqlString(first.ServerParams),
+		sqlString(first.RuntimeOS), sqlString(first.RuntimeGoArch), sqlString(hardware.Hostname), sqlString(hardware.CpuModel),
+		hardware.CpuCount, hardware.MemoryBytes, createdAt)
+
+	headers := FromResultCsvHeaders()
+	fmt.Fprintf(&sb, "INSERT INTO results (%s) VALUES\n", strings.Join(headers, ", "))
+	for i, r := range results {
+		vals := make([]string, len(headers))
+		for j, h := range headers {
+			val, err := FromHeaderResultColumnValue(h, r)
+			if err != nil {
+				return "", err
+			}
+			if h == "created_at" {
+				vals[j], err = resultsDbTime(val)
+				if err != nil {
+					return "", err
+				}
+				continue
+			}
+			if val == "" && (h == "transaction_latencies" || h == "intervals") {
+				vals[j] = "NULL"
+				continue
+			}
+			vals[j] = sqlString(val)
+		}
+		sep := ","
+		if i == len(results)-1 {
+			sep = ";"
+		}
+		fmt.Fprintf(&sb, "(%s)%s\n", strings.Join(vals, ", "), sep)
+	}
+	return sb.String(), nil
+}
+
+// resultsDbCommitMessage returns the commit message of the results of a server, which records the server and the
+// hardware it ran on
+func resultsDbCommitMessage(r *Result, hardware HardwareInfo) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Add %s %s benchmark results\n\n", r.ServerName, r.ServerVersion)
+	fmt.Fprintf(&sb, "suite_id: %s\n", r.SuiteId)
+	fmt.Fprintf(&sb, "server_params: %s\n", r.ServerParams)
+	fmt.Fprintf(&sb, "runtime: %s/%s\n", r.RuntimeOS, r.RuntimeGoArch)
+	fmt.Fprintf(&sb, "hostname: %s\n", hardware.Hostname)
+	fmt.Fprintf(&sb, "cpu: %s (%d cpus)\n", hardware.CpuModel, hardware.CpuCount)
+	fmt.Fprintf(&sb, "memory_bytes: %d\n", hardware.MemoryBytes)
+	return sb.String()
+}
+
+// resultsDbTime converts a result timestamp to a SQL datetime literal
+func resultsDbTime(stamp string) (string, error) {
+	if stamp == "" {
+		return "NULL", nil
+	}
+	t, err := time.Parse(stampFormat, stamp)
+	if err != nil {
+		return "", err
+	}
+	return sqlString(t.UTC().Format(resultsDbDatetime)), nil
+}
+
+func sqlString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultsDbScript(t *testing.T) {
+	first, second := genRandomResult(), genRandomResult()
+	first.SuiteId, second.SuiteId = testSuiteId, testSuiteId
+	first.ServerVersion = "it's-1.0"
+	hardware := HardwareInfo{Hostname: "bench-1", CpuModel: "Test CPU", CpuCount: 8, MemoryBytes: 1 << 30}
+
+	script, err := resultsDbScript(0, Results{first, second}, hardware)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(script, resultsDbMigrations[0]))
+	assert.Contains(t, script, fmt.Sprintf("REPLACE INTO schema_version VALUES (%d);", len(resultsDbMigrations)))
+	assert.Contains(t, script, "REPLACE INTO runs VALUES ('test-suite-id', 'test-server-name', 'it''s-1.0', '--test-server-param=1', "+
+		"'test-runtime-os', 'test-runtime-goarch', 'bench-1', 'Test CPU', 8, 1073741824, '2019-08-19 00:00:00');")
+	assert.Contains(t, script, "INSERT INTO results ("+strings.Join(FromResultCsvHeaders(), ", ")+") VALUES\n")
+	assert.Equal(t, 1, strings.Count(script, "INSERT INTO results"))
+	assert.True(t, strings.HasSuffix(script, ");\n"))
+
+	// results without latency histograms or intervals insert NULL
+	assert.Contains(t, script, ", NULL, NULL),\n")
+
+	// a database at an older version is migrated
+	script, err = resultsDbScript(1, Results{first}, hardware)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(script, resultsDbMigrations[1]))
+
+	// an up to date database is only inserted into
+	script, err = resultsDbScript(len(resultsDbMigrations), Results{first}, hardware)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(script, "REPLACE INTO runs"))
+
+	_, err = resultsDbScript(len(resultsDbMigrations)+1, Results{first}, hardware)
+	assert.Error(t, err)
+}
+
+func TestResultsDbCommitMessage(t *testing.T) {
+	r := genRandomResult()
+	r.SuiteId, r.ServerVersion = testSuiteId, testServerVersion
+	msg := resultsDbCommitMessage(r, HardwareInfo{Hostname: "bench-1", CpuModel: "Test CPU", CpuCount: 8, MemoryBytes: 1024})
+	assert.True(t, strings.HasPrefix(msg, "Add test-server-name test-version benchmark results\n\n"))
+	assert.Contains(t, msg, "suite_id: test-suite-id\n")
+	assert.Contains(t, msg, "cpu: Test CPU (8 cpus)\n")
+	assert.Contains(t, msg, "memory_bytes: 1024\n")
+}
+
+func TestParseHardwareInfo(t *testing.T) {
+	cpuInfo := "processor\t: 0\nvendor_id\t: GenuineIntel\nmodel name\t: Intel(R) Xeon(R) CPU @ 2.20GHz\n\nprocessor\t: 1\nmodel name\t: Intel(R) Xeon(R) CPU @ 2.20GHz\n"
+	assert.Equal(t, "Intel(R) Xeon(R) CPU @ 2.20GHz", parseCpuInfoModel(cpuInfo))
+	assert.Equal(t, "", parseCpuInfoModel("processor\t: 0\n"))
+
+	memInfo := "MemTotal:       16303428 kB\nMemFree:         1195380 kB\n"
+	assert.Equal(t, int64(16303428*1024), parseMemInfoTotal(memInfo))
+	assert.Equal(t, int64(0), parseMemInfoTotal("MemFree: 1 kB\n"))
+}
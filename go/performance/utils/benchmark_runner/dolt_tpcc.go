@@ -109,6 +109,9 @@ func GetTpccTests(config TpccConfig) []Test {
 	for _, sf := range config.GetScaleFactors() {
 		params := NewDefaultTpccParams()
 		params.ScaleFactor = sf
+		if config.GetReportInterval() > 0 {
+			params.ReportInterval = config.GetReportInterval()
+		}
 		test := NewTpccTest(fmt.Sprintf(tpccScaleFactorTemplate, sf), params)
 		tests = append(tests, test)
 	}
@@ -0,0 +1,127 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+type mariadbBenchmarkerImpl struct {
+	dir          string // cwd
+	config       SysbenchConfig
+	serverConfig InitProtocolServerConfig
+}
+
+var _ Benchmarker = &mariadbBenchmarkerImpl{}
+
+func NewMariaDbBenchmarker(dir string, config SysbenchConfig, serverConfig InitProtocolServerConfig) *mariadbBenchmarkerImpl {
+	return &mariadbBenchmarkerImpl{
+		dir:          dir,
+		config:       config,
+		serverConfig: serverConfig,
+	}
+}
+
+func (b *mariadbBenchmarkerImpl) getDsn() (string, error) {
+	return GetMysqlDsn(b.serverConfig.GetHost(), b.serverConfig.GetSocket(), b.serverConfig.GetConnectionProtocol(), b.serverConfig.GetPort())
+}
+
+func (b *mariadbBenchmarkerImpl) createTestingDb(ctx context.Context) error {
+	dsn, err := b.getDsn()
+	if err != nil {
+		return err
+	}
+	return CreateMariaDbTestingDb(ctx, dsn, dbName)
+}
+
+func (b *mariadbBenchmarkerImpl) Benchmark(ctx context.Context) (Results, error) {
+	serverDir, err := InitMariaDbDataDir(ctx, b.serverConfig, dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverParams, err := b.serverConfig.GetServerArgs()
+	if err != nil {
+		return nil, err
+	}
+	serverParams = append(serverParams, fmt.Sprintf("%s=%s", MysqlDataDirFlag, serverDir))
+
+	server := NewServer(ctx, serverDir, b.serverConfig, syscall.SIGTERM, serverParams)
+	err = server.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.createTestingDb(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tests, err := GetTests(b.config, b.serverConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(Results, 0)
+	runs := b.config.GetRuns()
+	for i := 0; i < runs; i++ {
+		for _, test := range tests {
+			t, ok := test.(SysbenchTest)
+			if !ok {
+				return nil, ErrNotSysbenchTest
+			}
+			tester := NewSysbenchTester(b.config, b.serverConfig, t, serverParams, stampFunc)
+			r, err := tester.Test(ctx)
+			if err != nil {
+				server.Stop()
+				return nil, err
+			}
+			results = append(results, r)
+		}
+	}
+
+	err = server.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	return results, os.RemoveAll(serverDir)
+}
+
+// InitMariaDbDataDir creates a server directory for |dbName| and initializes it with mariadb-install-db, since
+// MariaDB servers don't support MySQL's --initialize-insecure
+func InitMariaDbDataDir(ctx context.Context, serverConfig InitProtocolServerConfig, dbName string) (string, error) {
+	serverDir, err := CreateServerDir(dbName)
+	if err != nil {
+		return "", err
+	}
+
+	mdbInit := ExecCommand(ctx, serverConfig.GetInitDbExec(), serverConfig.GetInitArgs(serverDir)...)
+	err = mdbInit.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return serverDir, nil
+}
+
+// CreateMariaDbTestingDb creates |dbName| and the sysbench user. MariaDB doesn't support MySQL's
+// IDENTIFIED WITH ... BY syntax, so the user is created with its default authentication plugin.
+func CreateMariaDbTestingDb(ctx context.Context, dsn, dbName string) error {
+	return createTestingDb(ctx, dsn, dbName, mariadbCreateUserSqlTemplate)
+}
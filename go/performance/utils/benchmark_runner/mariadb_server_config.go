@@ -0,0 +1,236 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type mariadbServerConfigImpl struct {
+	// Id is a unique id for this servers benchmarking
+	Id string
+
+	// Host is the server host
+	Host string
+
+	// Port is the server port
+	Port int
+
+	// Version is the server version. If it's empty, it's captured from the server executable
+	Version string
+
+	// ResultsFormat is the format the results should be written in
+	ResultsFormat string
+
+	// ServerExec is the path to a server executable
+	ServerExec string
+
+	// InitExec is the path to the mariadb-install-db executable
+	InitExec string
+
+	// ServerUser is the user account that should start the server
+	ServerUser string
+
+	// SkipLogBin will skip bin logging
+	SkipLogBin bool
+
+	// ServerArgs are the args used to start a server
+	ServerArgs []string
+
+	// ConnectionProtocol defines the protocol for connecting to the server
+	ConnectionProtocol string
+
+	// Socket is the path to the server socket
+	Socket string
+}
+
+var _ InitProtocolServerConfig = &mariadbServerConfigImpl{}
+
+func NewMariaDbServerConfig(version, serverExec, initExec, serverUser, host, resultsFormat, protocol, socket string, port int, serverArgs []string, skipBinLog bool) *mariadbServerConfigImpl {
+	return &mariadbServerConfigImpl{
+		Id:                 uuid.New().String(),
+		Host:               host,
+		Port:               port,
+		Version:            version,
+		ResultsFormat:      resultsFormat,
+		ServerExec:         serverExec,
+		InitExec:           initExec,
+		ServerUser:         serverUser,
+		SkipLogBin:         skipBinLog,
+		ServerArgs:         serverArgs,
+		ConnectionProtocol: protocol,
+		Socket:             socket,
+	}
+}
+
+func (sc *mariadbServerConfigImpl) GetServerExec() string {
+	return sc.ServerExec
+}
+
+func (sc *mariadbServerConfigImpl) GetInitDbExec() string {
+	return sc.InitExec
+}
+
+func (sc *mariadbServerConfigImpl) GetId() string {
+	return sc.Id
+}
+
+func (sc *mariadbServerConfigImpl) GetHost() string {
+	return sc.Host
+}
+
+func (sc *mariadbServerConfigImpl) GetPort() int {
+	return sc.Port
+}
+
+func (sc *mariadbServerConfigImpl) GetVersion() string {
+	return sc.Version
+}
+
+func (sc *mariadbServerConfigImpl) GetServerType() ServerType {
+	return MariaDb
+}
+
+func (sc *mariadbServerConfigImpl) GetResultsFormat() string {
+	return sc.ResultsFormat
+}
+
+func (sc *mariadbServerConfigImpl) GetConnectionProtocol() string {
+	return sc.ConnectionProtocol
+}
+
+func (sc *mariadbServerConfigImpl) GetSocket() string {
+	return sc.Socket
+}
+
+func (sc *mariadbServerConfigImpl) GetServerArgs() ([]string, error) {
+	params := make([]string, 0)
+	if sc.ServerUser != "" {
+		params = append(params, fmt.Sprintf("%s=%s", userFlag, sc.ServerUser))
+	}
+	if sc.SkipLogBin {
+		params = append(params, skipBinLogFlag)
+	}
+	if sc.Port != 0 {
+		params = append(params, fmt.Sprintf("%s=%d", portFlag, sc.Port))
+	}
+	// MariaDB packages disagree on the default socket path, so the server is always told where to listen
+	if sc.Socket != "" {
+		params = append(params, fmt.Sprintf("%s=%s", socketFlag, sc.Socket))
+	}
+	params = append(params, sc.ServerArgs...)
+	return params, nil
+}
+
+// GetInitArgs returns the args used to initialize the data directory |dataDir| with mariadb-install-db. The root
+// user is created with a password, which is empty, instead of unix socket authentication, so that the benchmarks
+// can connect to the server as root like they do for MySQL.
+func (sc *mariadbServerConfigImpl) GetInitArgs(dataDir string) []string {
+	params := []string{
+		fmt.Sprintf("%s=%s", MysqlDataDirFlag, dataDir),
+		mariadbAuthRootNormalFlag,
+		mariadbSkipTestDbFlag,
+	}
+	if sc.ServerUser != "" {
+		params = append(params, fmt.Sprintf("%s=%s", userFlag, sc.ServerUser))
+	}
+	return params
+}
+
+func (sc *mariadbServerConfigImpl) GetTestingParams(testConfig TestConfig) TestParams {
+	params := NewSysbenchTestParams()
+	params.Append(defaultSysbenchParams...)
+	params.Append(fmt.Sprintf("%s=%s", sysbenchMysqlDbFlag, dbName))
+	params.Append(fmt.Sprintf("%s=%s", sysbenchDbDriverFlag, mysqlDriverName))
+	params.Append(fmt.Sprintf("%s=%s", sysbenchMysqlHostFlag, sc.Host))
+	if sc.Port != 0 {
+		params.Append(fmt.Sprintf("%s=%d", sysbenchMysqlPortFlag, sc.Port))
+	}
+	params.Append(fmt.Sprintf("%s=%s", sysbenchMysqlUserFlag, sysbenchCommand))
+	params.Append(fmt.Sprintf("%s=%s", sysbenchMysqlPasswordFlag, sysbenchPassLocal))
+	params.Append(testConfig.GetOptions()...)
+	params.Append(testConfig.GetName())
+	return params
+}
+
+func (sc *mariadbServerConfigImpl) Validate() error {
+	if sc.ResultsFormat == "" {
+		return getMustSupplyError("results format")
+	}
+	if sc.ServerExec == "" {
+		return getMustSupplyError("server exec")
+	}
+	err := CheckProtocol(sc.ConnectionProtocol)
+	if err != nil {
+		return err
+	}
+	err = CheckExec(sc.ServerExec, "server exec")
+	if err != nil {
+		return err
+	}
+	return CheckExec(sc.InitExec, "mariadb-install-db exec")
+}
+
+func (sc *mariadbServerConfigImpl) SetDefaults() error {
+	if sc.Host == "" {
+		sc.Host = defaultHost
+	}
+	if sc.Port < 1 {
+		sc.Port = defaultMariaDbPort
+	}
+	if sc.Socket == "" {
+		sc.Socket = defaultMariaDbSocket
+	}
+	if sc.Version == "" {
+		version, err := GetMariaDbVersion(context.Background(), sc.ServerExec)
+		if err != nil {
+			return err
+		}
+		sc.Version = version
+	}
+	return nil
+}
+
+// GetMariaDbVersion returns the version of the MariaDB server executable |serverExec|
+func GetMariaDbVersion(ctx context.Context, serverExec string) (string, error) {
+	out, err := exec.CommandContext(ctx, serverExec, mariadbVersionFlag).Output()
+	if err != nil {
+		return "", err
+	}
+	return parseMariaDbVersion(string(out))
+}
+
+// parseMariaDbVersion parses the version from the output of mariadbd --version, which looks like
+// "mariadbd  Ver 11.4.2-MariaDB-ubu2404 for debian-linux-gnu on x86_64 (mariadb.org binary distribution)"
+func parseMariaDbVersion(output string) (string, error) {
+	fields := strings.Fields(output)
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] != "Ver" {
+			continue
+		}
+		ver := fields[i+1]
+		if !strings.Contains(ver, mariadbVersionMarker) {
+			return "", fmt.Errorf("server exec is not MariaDB, its version is %s", ver)
+		}
+		ver, _, _ = strings.Cut(ver, "-")
+		return ver, nil
+	}
+	return "", fmt.Errorf("unable to parse MariaDB version from %q", strings.TrimSpace(output))
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMariaDbVersion(t *testing.T) {
+	v, err := parseMariaDbVersion("mariadbd  Ver 11.4.2-MariaDB-ubu2404 for debian-linux-gnu on x86_64 (mariadb.org binary distribution)\n")
+	require.NoError(t, err)
+	assert.Equal(t, "11.4.2", v)
+
+	v, err = parseMariaDbVersion("mysqld  Ver 10.6.12-MariaDB-0ubuntu0.22.04.1 for debian-linux-gnu on x86_64 (Ubuntu 22.04)")
+	require.NoError(t, err)
+	assert.Equal(t, "10.6.12", v)
+
+	_, err = parseMariaDbVersion("/usr/sbin/mysqld  Ver 8.0.35 for Linux on x86_64 (MySQL Community Server - GPL)")
+	assert.ErrorContains(t, err, "not MariaDB")
+
+	_, err = parseMariaDbVersion("")
+	assert.Error(t, err)
+}
+
+func TestMariaDbServerConfig(t *testing.T) {
+	sc := NewMariaDbServerConfig("11.4.2", "/usr/sbin/mariadbd", "/usr/bin/mariadb-install-db", "mysql", "", CsvFormat, unixProtocol, "", 0, []string{"--innodb-buffer-pool-size=1G"}, true)
+	require.NoError(t, sc.SetDefaults())
+	assert.Equal(t, MariaDb, sc.GetServerType())
+	assert.Equal(t, defaultHost, sc.GetHost())
+	assert.Equal(t, defaultMariaDbPort, sc.GetPort())
+	assert.Equal(t, "11.4.2", sc.GetVersion())
+
+	args, err := sc.GetServerArgs()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--user=mysql", "--skip-log-bin", "--port=3306", "--socket=" + defaultMariaDbSocket, "--innodb-buffer-pool-size=1G"}, args)
+
+	assert.Equal(t, []string{"--datadir=/tmp/test", "--auth-root-authentication-method=normal", "--skip-test-db", "--user=mysql"}, sc.GetInitArgs("/tmp/test"))
+
+	dsn, err := GetMysqlDsn(sc.GetHost(), sc.GetSocket(), sc.GetConnectionProtocol(), sc.GetPort())
+	require.NoError(t, err)
+	assert.Equal(t, "root@unix("+defaultMariaDbSocket+")/", dsn)
+}
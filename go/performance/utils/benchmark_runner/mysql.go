@@ -121,7 +121,13 @@ func InitMysqlDataDir(ctx context.Context, serverExec, dbName string) (string, e
 	return serverDir, nil
 }
 
-func CreateMysqlTestingDb(ctx context.Context, dsn, dbName string) (err error) {
+func CreateMysqlTestingDb(ctx context.Context, dsn, dbName string) error {
+	return createTestingDb(ctx, dsn, dbName, mysqlCreateUserSqlTemplate)
+}
+
+// createTestingDb creates |dbName| and the sysbench user on a MySQL compatible server, creating the user with
+// |createUserTemplate|
+func createTestingDb(ctx context.Context, dsn, dbName, createUserTemplate string) (err error) {
 	var db *sql.DB
 	db, err = sql.Open(mysqlDriverName, dsn)
 	if err != nil {
@@ -143,7 +149,7 @@ func CreateMysqlTestingDb(ctx context.Context, dsn, dbName string) (err error) {
 		fmt.Sprintf(mysqlDropDatabaseSqlTemplate, dbName),
 		fmt.Sprintf(mysqlCreateDatabaseSqlTemplate, dbName),
 		fmt.Sprintf(mysqlDropUserSqlTemplate, sysbenchUserLocal),
-		fmt.Sprintf(mysqlCreateUserSqlTemplate, sysbenchUserLocal, sysbenchPassLocal),
+		fmt.Sprintf(createUserTemplate, sysbenchUserLocal, sysbenchPassLocal),
 		fmt.Sprintf(mysqlGrantPermissionsSqlTemplate, dbName, sysbenchUserLocal),
 		mysqlSetGlobalLocalInfileSql,
 		mysqlSetGlobalSqlModeSql, // Required for running groupby_scan.lua without error
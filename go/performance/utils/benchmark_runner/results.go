@@ -16,6 +16,8 @@ package benchmark_runner
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -60,6 +62,12 @@ type Result struct {
 	// TestParams are the params used to run the test
 	TestParams string `json:"test_params"`
 
+	// Threads is the number of sysbench threads per client the test ran with, or zero for sysbench's default
+	Threads int `json:"threads"`
+
+	// Connections is the number of concurrent sysbench clients the test ran with, or zero for a single client
+	Connections int `json:"connections"`
+
 	// CreatedAt is the time the result was created UTC
 	CreatedAt string `json:"created_at"`
 
@@ -116,11 +124,60 @@ type Result struct {
 
 	// LatencySumMS is the latency sum in milliseconds
 	LatencySumMS float64 `json:"latency_sum_ms"`
+
+	// TransactionLatencies are the latency percentiles of each type of transaction, for tests which report
+	// latency histograms
+	TransactionLatencies []TransactionLatency `json:"transaction_latencies,omitempty"`
+
+	// Intervals are the throughput and latency reported at each interval of the test, for tests run with a
+	// report interval
+	Intervals []IntervalResult `json:"intervals,omitempty"`
 }
 
 // Results is a slice of Result
 type Results []*Result
 
+// MatrixName returns the name of the test along with the thread and connection counts it ran with, if they were set
+func (r *Result) MatrixName() string {
+	if r.Threads == 0 && r.Connections == 0 {
+		return r.TestName
+	}
+	return fmt.Sprintf("%s (threads=%d, connections=%d)", r.TestName, r.Threads, r.Connections)
+}
+
+// AggregateResults combines the results of sysbench clients which ran the same test concurrently into a single
+// result. Counts and rates are summed, and latency extremes are taken over all clients. The latency percentile of
+// the clients can't be combined exactly, so the highest is used as an upper bound.
+func AggregateResults(results Results) *Result {
+	if len(results) == 0 {
+		return nil
+	}
+	agg := *results[0]
+	for _, r := range results[1:] {
+		agg.SqlReadQueries += r.SqlReadQueries
+		agg.SqlWriteQueries += r.SqlWriteQueries
+		agg.SqlOtherQueries += r.SqlOtherQueries
+		agg.SqlTotalQueries += r.SqlTotalQueries
+		agg.SqlTotalQueriesPerSecond += r.SqlTotalQueriesPerSecond
+		agg.TransactionsTotal += r.TransactionsTotal
+		agg.TransactionsPerSecond += r.TransactionsPerSecond
+		agg.IgnoredErrorsTotal += r.IgnoredErrorsTotal
+		agg.IgnoredErrorsPerSecond += r.IgnoredErrorsPerSecond
+		agg.ReconnectsTotal += r.ReconnectsTotal
+		agg.ReconnectsPerSecond += r.ReconnectsPerSecond
+		agg.TotalNumberOfEvents += r.TotalNumberOfEvents
+		agg.LatencySumMS += r.LatencySumMS
+		agg.TotalTimeSeconds = math.Max(agg.TotalTimeSeconds, r.TotalTimeSeconds)
+		agg.LatencyMinMS = math.Min(agg.LatencyMinMS, r.LatencyMinMS)
+		agg.LatencyMaxMS = math.Max(agg.LatencyMaxMS, r.LatencyMaxMS)
+		agg.LatencyPercentile = math.Max(agg.LatencyPercentile, r.LatencyPercentile)
+	}
+	if agg.TotalNumberOfEvents > 0 {
+		agg.LatencyAvgMS = agg.LatencySumMS / float64(agg.TotalNumberOfEvents)
+	}
+	return &agg
+}
+
 // Stamp timestamps the result using the provided stamp function
 func (r *Result) Stamp(stampFunc func() string) {
 	if r.CreatedAt != "" || stampFunc == nil {
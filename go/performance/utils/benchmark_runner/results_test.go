@@ -46,6 +46,8 @@ func genRandomResult() *Result {
 		ServerParams:             testServerParams,
 		TestName:                 testTestName,
 		TestParams:               testTestParams,
+		Threads:                  rand.Intn(128) + 1,
+		Connections:              rand.Intn(8) + 1,
 		CreatedAt:                testStamp,
 		SqlReadQueries:           rand.Int63(),
 		SqlWriteQueries:          rand.Int63(),
@@ -332,3 +334,31 @@ func TestFromOutputResults(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregateResults(t *testing.T) {
+	assert.Nil(t, AggregateResults(nil))
+
+	one := &Result{TestName: "oltp_read_only", SqlTotalQueries: 100, SqlTotalQueriesPerSecond: 10, TransactionsPerSecond: 5,
+		TotalTimeSeconds: 10, TotalNumberOfEvents: 50, LatencyMinMS: 1, LatencyMaxMS: 20, LatencyPercentile: 8, LatencySumMS: 200}
+	two := &Result{TestName: "oltp_read_only", SqlTotalQueries: 300, SqlTotalQueriesPerSecond: 30, TransactionsPerSecond: 15,
+		TotalTimeSeconds: 10.5, TotalNumberOfEvents: 150, LatencyMinMS: 0.5, LatencyMaxMS: 15, LatencyPercentile: 9, LatencySumMS: 400}
+
+	agg := AggregateResults(Results{one, two})
+	assert.Equal(t, "oltp_read_only", agg.TestName)
+	assert.Equal(t, int64(400), agg.SqlTotalQueries)
+	assert.Equal(t, 40.0, agg.SqlTotalQueriesPerSecond)
+	assert.Equal(t, 20.0, agg.TransactionsPerSecond)
+	assert.Equal(t, 10.5, agg.TotalTimeSeconds)
+	assert.Equal(t, int64(200), agg.TotalNumberOfEvents)
+	assert.Equal(t, 0.5, agg.LatencyMinMS)
+	assert.Equal(t, 20.0, agg.LatencyMaxMS)
+	assert.Equal(t, 9.0, agg.LatencyPercentile)
+	assert.Equal(t, 3.0, agg.LatencyAvgMS)
+
+	// the inputs aren't modified
+	assert.Equal(t, int64(100), one.SqlTotalQueries)
+
+	agg.Threads, agg.Connections = 8, 2
+	assert.Equal(t, "oltp_read_only (threads=8, connections=2)", agg.MatrixName())
+	assert.Equal(t, "oltp_read_only", one.MatrixName())
+}
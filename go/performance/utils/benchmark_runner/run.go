@@ -24,6 +24,7 @@ import (
 
 var ErrNotProtocolServerConfig = errors.New("protocol server config required")
 var ErrNotInitDbServerConfig = errors.New("init db server config required")
+var ErrNotInitProtocolServerConfig = errors.New("init db and protocol server config required")
 
 // Run runs sysbench runner
 func Run(ctx context.Context, config SysbenchConfig) error {
@@ -42,6 +43,11 @@ func Run(ctx context.Context, config SysbenchConfig) error {
 		return err
 	}
 
+	var resultsDb *ResultsDb
+	if dir := config.GetResultsDb(); dir != "" {
+		resultsDb = NewResultsDb(dir, config.GetResultsDbExec(), GetHardwareInfo(ctx))
+	}
+
 	svs := config.GetServerConfigs()
 	for _, serverConfig := range svs {
 		var results Results
@@ -71,6 +77,13 @@ func Run(ctx context.Context, config SysbenchConfig) error {
 			}
 			fmt.Println("Running mysql sysbench tests")
 			b = NewMysqlBenchmarker(cwd, config, sc)
+		case MariaDb:
+			sc, ok := serverConfig.(InitProtocolServerConfig)
+			if !ok {
+				return ErrNotInitProtocolServerConfig
+			}
+			fmt.Println("Running mariadb sysbench tests")
+			b = NewMariaDbBenchmarker(cwd, config, sc)
 		case Postgres:
 			sc, ok := serverConfig.(InitServerConfig)
 			if !ok {
@@ -89,7 +102,11 @@ func Run(ctx context.Context, config SysbenchConfig) error {
 
 		fmt.Printf("Successfully finished %s\n", st)
 
-		err = WriteResults(serverConfig, results)
+		if resultsDb != nil {
+			err = resultsDb.Write(ctx, results)
+		} else {
+			err = WriteResults(serverConfig, results)
+		}
 		if err != nil {
 			return err
 		}
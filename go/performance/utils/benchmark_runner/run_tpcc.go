@@ -48,6 +48,14 @@ func RunTpcc(ctx context.Context, config TpccConfig) error {
 
 			fmt.Println("Running mysql tpcc benchmarks")
 			b = NewMysqlTpccBenchmarker(cwd, config, sc)
+		case MariaDb:
+			sc, ok := serverConfig.(InitProtocolServerConfig)
+			if !ok {
+				return ErrNotInitProtocolServerConfig
+			}
+
+			fmt.Println("Running mariadb tpcc benchmarks")
+			b = NewMariaDbTpccBenchmarker(cwd, config, sc)
 		default:
 			panic(fmt.Sprintf("unexpected server type: %s", st))
 		}
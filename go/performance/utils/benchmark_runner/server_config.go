@@ -41,6 +41,12 @@ type ProtocolServerConfig interface {
 	GetSocket() string
 }
 
+type InitProtocolServerConfig interface {
+	InitServerConfig
+	ProtocolServerConfig
+	GetInitArgs(dataDir string) []string
+}
+
 type ProfilingServerConfig interface {
 	ServerConfig
 	GetServerProfile() ServerProfile
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 )
 
 type sysbenchTesterImpl struct {
@@ -100,7 +101,45 @@ func (t *sysbenchTesterImpl) prepare(ctx context.Context) error {
 	return cmd.Run()
 }
 
+// run runs the test with the number of concurrent sysbench clients it was configured with, and aggregates the
+// results of the clients
 func (t *sysbenchTesterImpl) run(ctx context.Context) (*Result, error) {
+	clients := t.test.GetConnections()
+	if clients < 1 {
+		clients = 1
+	}
+
+	outputs := make([][]byte, clients)
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := range outputs {
+		eg.Go(func() error {
+			out, err := t.runClient(egCtx)
+			outputs[i] = out
+			return err
+		})
+	}
+	err := eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(Results, clients)
+	for i, out := range outputs {
+		results[i], err = t.outputToResult(out)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rs := AggregateResults(results)
+	rs.Threads = t.test.GetThreads()
+	rs.Connections = t.test.GetConnections()
+	rs.Stamp(t.stampFunc)
+
+	return rs, nil
+}
+
+func (t *sysbenchTesterImpl) runClient(ctx context.Context) ([]byte, error) {
 	cmd := exec.CommandContext(ctx, sysbenchCommand, t.test.GetRunArgs(t.serverConfig)...)
 	if t.test.GetFromScript() {
 		lp := filepath.Join(t.config.GetScriptDir(), luaPath)
@@ -118,14 +157,7 @@ func (t *sysbenchTesterImpl) run(ctx context.Context) (*Result, error) {
 		fmt.Print(string(out))
 	}
 
-	rs, err := t.outputToResult(out)
-	if err != nil {
-		return nil, err
-	}
-
-	rs.Stamp(t.stampFunc)
-
-	return rs, nil
+	return out, nil
 }
 
 func (t *sysbenchTesterImpl) cleanup(ctx context.Context) error {
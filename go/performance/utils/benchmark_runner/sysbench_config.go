@@ -90,12 +90,20 @@ type sysbenchRunnerConfigImpl struct {
 	Tests []TestConfig
 	// TestOptions a list of sysbench test options to apply to all tests
 	TestOptions []string
+	// Threads are the sysbench thread counts to run tests which don't define their own with
+	Threads []int
+	// Connections are the concurrent sysbench client counts to run tests which don't define their own with
+	Connections []int
 	// ScriptDir is a path to a directory of lua scripts
 	ScriptDir string
 	// InitBigRepo downloads a database with existing chunks and commits
 	InitBigRepo bool
 	// NomsBinFormat specifies the NomsBinFormat
 	NomsBinFormat string
+	// ResultsDb is the directory of a Dolt database to write results to, instead of results files
+	ResultsDb string
+	// ResultsDbExec is the dolt binary used to write to ResultsDb, by default the dolt binary on the PATH
+	ResultsDbExec string
 }
 
 var _ SysbenchConfig = &sysbenchRunnerConfigImpl{}
@@ -135,6 +143,22 @@ func (c *sysbenchRunnerConfigImpl) GetTestOptions() []string {
 	return c.TestOptions
 }
 
+func (c *sysbenchRunnerConfigImpl) GetThreads() []int {
+	return c.Threads
+}
+
+func (c *sysbenchRunnerConfigImpl) GetConnections() []int {
+	return c.Connections
+}
+
+func (c *sysbenchRunnerConfigImpl) GetResultsDb() string {
+	return c.ResultsDb
+}
+
+func (c *sysbenchRunnerConfigImpl) GetResultsDbExec() string {
+	return c.ResultsDbExec
+}
+
 func (c *sysbenchRunnerConfigImpl) GetServerConfigs() []ServerConfig {
 	return c.Servers
 }
@@ -160,7 +184,7 @@ func (c *sysbenchRunnerConfigImpl) validateServerConfigs() error {
 	portMap := make(map[int]ServerType)
 	for _, s := range c.Servers {
 		st := s.GetServerType()
-		if st != Dolt && st != MySql && st != Doltgres && st != Postgres {
+		if st != Dolt && st != MySql && st != MariaDb && st != Doltgres && st != Postgres {
 			return fmt.Errorf("unsupported server type: %s", st)
 		}
 
@@ -221,6 +245,13 @@ func (c *sysbenchRunnerConfigImpl) setDefaults() error {
 	if c.Runs < 1 {
 		c.Runs = 1
 	}
+	if c.ResultsDb != "" {
+		abs, err := filepath.Abs(c.ResultsDb)
+		if err != nil {
+			return err
+		}
+		c.ResultsDb = abs
+	}
 	return nil
 }
 
@@ -314,6 +345,7 @@ func GetTests(config SysbenchConfig, serverConfig ServerConfig) ([]Test, error)
 		for _, o := range opts {
 			t.AppendOption(o)
 		}
+		t.SetDefaultMatrix(config.GetThreads(), config.GetConnections())
 		tests, err := t.GetTests(serverConfig)
 		if err != nil {
 			return nil, err
@@ -33,6 +33,8 @@ func TestConfigTestGetTests(t *testing.T) {
 		Options: []string{"--create_secondary=on", "--auto_inc=off"},
 	}
 
+	matrix := &testConfigImpl{Name: "test_matrix", N: 2, Threads: []int{1, 32}}
+
 	serverConfig := &doltServerConfigImpl{Version: "test-version", Host: "localhost", ResultsFormat: CsvFormat}
 
 	tests := []struct {
@@ -82,6 +84,29 @@ func TestConfigTestGetTests(t *testing.T) {
 				&testTestImpl{&sysbenchTestImpl{Name: "test_three", Params: serverConfig.GetTestingParams(three)}},
 			},
 		},
+		{
+			description: "should return N tests for each thread and connection count",
+			config: &sysbenchRunnerConfigImpl{
+				Servers:     []ServerConfig{serverConfig},
+				Tests:       []TestConfig{matrix},
+				Connections: []int{4},
+			},
+			expectedTests: []testTest{
+				&testTestImpl{&sysbenchTestImpl{Name: "test_matrix", Params: serverConfig.GetTestingParams(matrix.withThreads(1)), Threads: 1, Connections: 4}},
+				&testTestImpl{&sysbenchTestImpl{Name: "test_matrix", Params: serverConfig.GetTestingParams(matrix.withThreads(1)), Threads: 1, Connections: 4}},
+				&testTestImpl{&sysbenchTestImpl{Name: "test_matrix", Params: serverConfig.GetTestingParams(matrix.withThreads(32)), Threads: 32, Connections: 4}},
+				&testTestImpl{&sysbenchTestImpl{Name: "test_matrix", Params: serverConfig.GetTestingParams(matrix.withThreads(32)), Threads: 32, Connections: 4}},
+			},
+		},
+		{
+			description: "should error on non-positive thread counts",
+			config: &sysbenchRunnerConfigImpl{
+				Servers: []ServerConfig{serverConfig},
+				Tests:   []TestConfig{&testConfigImpl{Name: "test_bad_matrix", Threads: []int{8, 0}}},
+			},
+			expectedTests: nil,
+			expectedError: ErrInvalidTestMatrix,
+		},
 		{
 			description: "should apply user options to test params",
 			config: &sysbenchRunnerConfigImpl{
@@ -44,6 +44,12 @@ type sysbenchTestImpl struct {
 
 	// FromScript indicates if this test is from a lua script
 	FromScript bool
+
+	// Threads is the number of sysbench threads the test runs with, or zero for sysbench's default
+	Threads int
+
+	// Connections is the number of sysbench clients the test runs concurrently, or zero for a single client
+	Connections int
 }
 
 var _ SysbenchTest = &sysbenchTestImpl{}
@@ -57,6 +63,15 @@ func NewSysbenchTest(id, name string, params TestParams, fromScript bool) *sysbe
 	}
 }
 
+// NewSysbenchMatrixTest returns a test which runs with |threads| sysbench threads in each of |connections|
+// concurrent sysbench clients
+func NewSysbenchMatrixTest(id, name string, params TestParams, fromScript bool, threads, connections int) *sysbenchTestImpl {
+	t := NewSysbenchTest(id, name, params, fromScript)
+	t.Threads = threads
+	t.Connections = connections
+	return t
+}
+
 func (t *sysbenchTestImpl) GetId() string {
 	return t.id
 }
@@ -73,6 +88,14 @@ func (t *sysbenchTestImpl) GetFromScript() bool {
 	return t.FromScript
 }
 
+func (t *sysbenchTestImpl) GetThreads() int {
+	return t.Threads
+}
+
+func (t *sysbenchTestImpl) GetConnections() int {
+	return t.Connections
+}
+
 // PrepareArgs returns a test's args for sysbench's prepare step
 func (t *sysbenchTestImpl) GetPrepareArgs(serverConfig ServerConfig) []string {
 	return withCommand(t.Params, sysbenchPrepareCommand)
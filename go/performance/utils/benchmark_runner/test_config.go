@@ -14,7 +14,14 @@
 
 package benchmark_runner
 
-import "github.com/google/uuid"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidTestMatrix = errors.New("test thread and connection counts must be positive")
 
 type TestConfig interface {
 	GetName() string
@@ -22,6 +29,7 @@ type TestConfig interface {
 	AppendOption(opt string)
 	GetTests(serverConfig ServerConfig) ([]Test, error)
 	NewId() string
+	SetDefaultMatrix(threads, connections []int)
 }
 
 type testConfigImpl struct {
@@ -36,6 +44,14 @@ type testConfigImpl struct {
 
 	// FromScript is a boolean indicating that this test is from a lua script
 	FromScript bool
+
+	// Threads are the numbers of sysbench threads to run the test with. The test is run N times for each
+	// combination of Threads and Connections. If none are provided, sysbench's default is used
+	Threads []int
+
+	// Connections are the numbers of sysbench clients to run the test with concurrently, each with its own
+	// threads and connections to the server. If none are provided, a single client is used
+	Connections []int
 }
 
 var _ TestConfig = &testConfigImpl{}
@@ -67,6 +83,17 @@ func (ct *testConfigImpl) AppendOption(opt string) {
 	ct.Options = append(ct.Options, opt)
 }
 
+// SetDefaultMatrix sets the thread and connection counts of the test to |threads| and |connections| if it
+// doesn't define its own
+func (ct *testConfigImpl) SetDefaultMatrix(threads, connections []int) {
+	if len(ct.Threads) == 0 {
+		ct.Threads = threads
+	}
+	if len(ct.Connections) == 0 {
+		ct.Connections = connections
+	}
+}
+
 func (ct *testConfigImpl) GetTests(serverConfig ServerConfig) ([]Test, error) {
 	if ct.Name == "" {
 		return nil, ErrTestNameNotDefined
@@ -75,14 +102,42 @@ func (ct *testConfigImpl) GetTests(serverConfig ServerConfig) ([]Test, error) {
 		ct.N = 1
 	}
 
-	params := serverConfig.GetTestingParams(ct)
-	tests := make([]Test, 0)
+	for _, n := range append(append([]int{}, ct.Threads...), ct.Connections...) {
+		if n < 1 {
+			return nil, ErrInvalidTestMatrix
+		}
+	}
+
+	// zero counts leave the thread count to sysbench and run a single client
+	threads := ct.Threads
+	if len(threads) == 0 {
+		threads = []int{0}
+	}
+	connections := ct.Connections
+	if len(connections) == 0 {
+		connections = []int{0}
+	}
 
-	for i := 0; i < ct.N; i++ {
-		//p := make([]string, params.Len())
-		//copy(p, params)
-		tests = append(tests, NewSysbenchTest(ct.NewId(), ct.Name, params, ct.FromScript))
+	tests := make([]Test, 0)
+	for _, th := range threads {
+		for _, conns := range connections {
+			params := serverConfig.GetTestingParams(ct.withThreads(th))
+			for i := 0; i < ct.N; i++ {
+				tests = append(tests, NewSysbenchMatrixTest(ct.NewId(), ct.Name, params, ct.FromScript, th, conns))
+			}
+		}
 	}
 
 	return tests, nil
 }
+
+// withThreads returns a copy of the test config whose options set the sysbench thread count to |threads|, or the
+// test config itself if |threads| is zero
+func (ct *testConfigImpl) withThreads(threads int) *testConfigImpl {
+	if threads == 0 {
+		return ct
+	}
+	cp := *ct
+	cp.Options = append(append(make([]string, 0, len(ct.Options)+1), ct.Options...), fmt.Sprintf("%s=%d", sysbenchThreadsFlag, threads))
+	return &cp
+}
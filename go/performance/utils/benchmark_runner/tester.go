@@ -32,6 +32,8 @@ type Test interface {
 type SysbenchTest interface {
 	Test
 	GetFromScript() bool
+	GetThreads() int
+	GetConnections() int
 }
 
 type TestParams interface {
@@ -24,7 +24,7 @@ import (
 
 type tpccTesterImpl struct {
 	test         Test
-	config       Config
+	config       TpccConfig
 	serverConfig ServerConfig
 	tpccCommand  string
 	serverParams []string
@@ -48,7 +48,24 @@ func NewTpccTester(config TpccConfig, serverConfig ServerConfig, test Test, serv
 }
 
 func (t *tpccTesterImpl) outputToResult(output []byte) (*Result, error) {
-	return OutputToResult(output, t.serverConfig.GetServerType(), t.serverConfig.GetVersion(), t.test.GetName(), t.test.GetId(), t.suiteId, t.config.GetRuntimeOs(), t.config.GetRuntimeGoArch(), t.serverParams, t.test.GetParamsToSlice(), nil, false)
+	rs, err := OutputToResult(output, t.serverConfig.GetServerType(), t.serverConfig.GetVersion(), t.test.GetName(), t.test.GetId(), t.suiteId, t.config.GetRuntimeOs(), t.config.GetRuntimeGoArch(), t.serverParams, t.test.GetParamsToSlice(), nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.TransactionLatencies, err = ParseTransactionLatencies(output)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.config.GetReportInterval() > 0 {
+		rs.Intervals, err = ParseIntervals(output)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rs, nil
 }
 
 func (t *tpccTesterImpl) prepare(ctx context.Context) error {
@@ -45,6 +45,10 @@ type tpccConfigImpl struct {
 
 	// NomsBinFormat specifies the NomsBinFormat
 	NomsBinFormat string
+
+	// ReportInterval is the interval in seconds at which the throughput and latency of each test are reported and
+	// recorded in its result. When zero, only the totals of each test are recorded.
+	ReportInterval int
 }
 
 var _ TpccConfig = &tpccConfigImpl{}
@@ -93,6 +97,10 @@ func (c *tpccConfigImpl) GetServerConfigs() []ServerConfig {
 	return c.Servers
 }
 
+func (c *tpccConfigImpl) GetReportInterval() int {
+	return c.ReportInterval
+}
+
 func (c *tpccConfigImpl) setDefaults() {
 	// TODO: Eventually we need to support scale factors all the way to 10
 	if len(c.ScaleFactors) == 0 {
@@ -111,7 +119,7 @@ func (c *tpccConfigImpl) validateServerConfigs() error {
 	portMap := make(map[int]ServerType)
 	for _, s := range c.Servers {
 		st := s.GetServerType()
-		if st != Dolt && st != MySql && st != Doltgres && st != Postgres {
+		if st != Dolt && st != MySql && st != MariaDb && st != Doltgres && st != Postgres {
 			return fmt.Errorf("unsupported server type: %s", st)
 		}
 
@@ -140,6 +148,9 @@ func (c *tpccConfigImpl) Validate(ctx context.Context) error {
 	if len(c.Servers) > 2 {
 		return ErrTooManyServersDefined
 	}
+	if c.ReportInterval < 0 {
+		return fmt.Errorf("invalid report interval %d, must not be negative", c.ReportInterval)
+	}
 	c.setDefaults()
 	return c.validateServerConfigs()
 }
@@ -0,0 +1,188 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TransactionLatency is the latency distribution of a type of transaction
+type TransactionLatency struct {
+	// Type is the transaction type, or "all" for the distribution of every transaction
+	Type string `json:"type"`
+
+	// Count is the number of transactions in the distribution
+	Count int64 `json:"count"`
+
+	// P50MS, P95MS and P99MS are the 50th, 95th and 99th latency percentiles in milliseconds
+	P50MS float64 `json:"p50_ms"`
+	P95MS float64 `json:"p95_ms"`
+	P99MS float64 `json:"p99_ms"`
+}
+
+// IntervalResult is the throughput and latency sysbench reported for an interval of a test
+type IntervalResult struct {
+	// TimeSeconds is the time since the start of the test at the end of the interval
+	TimeSeconds int `json:"time_seconds"`
+
+	// Threads is the number of threads running during the interval
+	Threads int `json:"threads"`
+
+	// TransactionsPerSecond is the number of transactions per second during the interval
+	TransactionsPerSecond float64 `json:"transactions_per_second"`
+
+	// QueriesPerSecond is the number of queries per second during the interval
+	QueriesPerSecond float64 `json:"queries_per_second"`
+
+	// LatencyPercentileMS is the latency percentile during the interval in milliseconds
+	LatencyPercentileMS float64 `json:"latency_percentile_ms"`
+
+	// ErrorsPerSecond is the number of ignored errors per second during the interval
+	ErrorsPerSecond float64 `json:"errors_per_second"`
+
+	// ReconnectsPerSecond is the number of reconnects per second during the interval
+	ReconnectsPerSecond float64 `json:"reconnects_per_second"`
+}
+
+const allTransactionsType = "all"
+
+var (
+	// histogramHeaderRegex matches the first line of a sysbench latency histogram, which may be prefixed with the
+	// type of the transactions it covers
+	histogramHeaderRegex = regexp.MustCompile(`^(?:(\S+) )?[Ll]atency histogram \(values are in milliseconds\)$`)
+
+	// histogramBucketRegex matches a bucket of a sysbench latency histogram, e.g. "  12.975 |****   24"
+	histogramBucketRegex = regexp.MustCompile(`^([0-9.]+)\s*\|[* ]*?\s*([0-9]+)$`)
+
+	// intervalRegex matches an interval report of sysbench, e.g.
+	// "[ 10s ] thds: 1 tps: 45.69 qps: 1311.93 (r/w/o: 597.45/622.69/91.79) lat (ms,95%): 48.34 err/s: 0.20 reconn/s: 0.00"
+	intervalRegex = regexp.MustCompile(`^\[\s*([0-9]+)s\s*\]\s+thds:\s*([0-9]+)\s+tps:\s*([0-9.]+)\s+qps:\s*([0-9.]+).*lat \(ms,[0-9.]+%\):\s*([0-9.]+)\s+err/s:?\s*([0-9.]+)\s+reconn/s:\s*([0-9.]+)`)
+)
+
+// latencyBucket is a bucket of a latency histogram
+type latencyBucket struct {
+	valueMS float64
+	count   int64
+}
+
+// ParseTransactionLatencies returns the latency percentiles of the latency histograms in the output of sysbench run
+// with --histogram, in the order they were printed. The histogram sysbench prints covers every transaction. Per
+// transaction type histograms are printed in the same format, with the transaction type before the header, e.g.
+// "new_order latency histogram (values are in milliseconds)".
+func ParseTransactionLatencies(output []byte) ([]TransactionLatency, error) {
+	var latencies []TransactionLatency
+	var current *TransactionLatency
+	var buckets []latencyBucket
+	finish := func() {
+		if current != nil {
+			latencies = append(latencies, newTransactionLatency(current.Type, buckets))
+		}
+		current, buckets = nil, nil
+	}
+
+	for _, l := range strings.Split(string(output), "\n") {
+		trimmed := strings.TrimSpace(l)
+		if m := histogramHeaderRegex.FindStringSubmatch(trimmed); m != nil {
+			finish()
+			typ := m[1]
+			if typ == "" {
+				typ = allTransactionsType
+			}
+			current = &TransactionLatency{Type: typ}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "value") {
+			continue
+		}
+		m := histogramBucketRegex.FindStringSubmatch(trimmed)
+		if m == nil {
+			finish()
+			continue
+		}
+		v, err := fromStringFloat64(m[1])
+		if err != nil {
+			return nil, err
+		}
+		c, err := fromStringInt64(m[2])
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, latencyBucket{valueMS: v, count: c})
+	}
+	finish()
+
+	return latencies, nil
+}
+
+func newTransactionLatency(typ string, buckets []latencyBucket) TransactionLatency {
+	tl := TransactionLatency{Type: typ}
+	for _, b := range buckets {
+		tl.Count += b.count
+	}
+	tl.P50MS = histogramPercentile(buckets, tl.Count, 50)
+	tl.P95MS = histogramPercentile(buckets, tl.Count, 95)
+	tl.P99MS = histogramPercentile(buckets, tl.Count, 99)
+	return tl
+}
+
+// histogramPercentile returns the value of the bucket the |p|th percentile of a histogram of |total| values falls
+// in, which is how sysbench computes percentiles
+func histogramPercentile(buckets []latencyBucket, total int64, p float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	target := int64(math.Ceil(float64(total) * p / 100))
+	var seen int64
+	for _, b := range buckets {
+		seen += b.count
+		if seen >= target {
+			return b.valueMS
+		}
+	}
+	return buckets[len(buckets)-1].valueMS
+}
+
+// ParseIntervals returns the interval reports in the output of sysbench run with --report-interval
+func ParseIntervals(output []byte) ([]IntervalResult, error) {
+	var intervals []IntervalResult
+	for _, l := range strings.Split(string(output), "\n") {
+		m := intervalRegex.FindStringSubmatch(strings.TrimSpace(l))
+		if m == nil {
+			continue
+		}
+		var ir IntervalResult
+		var err error
+		if ir.TimeSeconds, err = strconv.Atoi(m[1]); err != nil {
+			return nil, err
+		}
+		if ir.Threads, err = strconv.Atoi(m[2]); err != nil {
+			return nil, err
+		}
+		floats := []*float64{&ir.TransactionsPerSecond, &ir.QueriesPerSecond, &ir.LatencyPercentileMS, &ir.ErrorsPerSecond, &ir.ReconnectsPerSecond}
+		for i, f := range floats {
+			if *f, err = fromStringFloat64(m[i+3]); err != nil {
+				return nil, err
+			}
+		}
+		intervals = append(intervals, ir)
+	}
+	return intervals, nil
+}
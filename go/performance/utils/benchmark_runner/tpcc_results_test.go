@@ -0,0 +1,90 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark_runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sampleTpccOutput = `
+Running the test with following options:
+Number of threads: 2
+Report intermediate results every 10 second(s)
+Initializing random number generator from current time
+
+
+Initializing worker threads...
+
+Threads started!
+
+[ 10s ] thds: 2 tps: 45.69 qps: 1311.93 (r/w/o: 597.45/622.69/91.79) lat (ms,95%): 48.34 err/s 0.20 reconn/s: 0.00
+[ 20s ] thds: 2 tps: 47.10 qps: 1350.20 (r/w/o: 610.00/645.00/95.20) lat (ms,95%): 46.63 err/s: 0.00 reconn/s: 0.00
+Latency histogram (values are in milliseconds)
+       value  ------------- distribution ------------- count
+       1.759 |**                                       2
+       2.030 |****************************************  40
+       9.560 |**********                               10
+      48.339 |                                         1
+
+new_order latency histogram (values are in milliseconds)
+       value  ------------- distribution ------------- count
+       9.560 |****************************************  19
+      48.339 |**                                       1
+
+SQL statistics:
+    queries performed:
+        read:                            9464
+        write:                           0
+        other:                           1352
+        total:                           10816
+    transactions:                        53     (2.65 per sec.)
+    queries:                             10816  (540.80 per sec.)
+    ignored errors:                      0      (0.00 per sec.)
+    reconnects:                          0      (0.00 per sec.)
+`
+
+func TestParseTransactionLatencies(t *testing.T) {
+	latencies, err := ParseTransactionLatencies([]byte(sampleTpccOutput))
+	require.NoError(t, err)
+	assert.Equal(t, []TransactionLatency{
+		{Type: "all", Count: 53, P50MS: 2.030, P95MS: 9.560, P99MS: 48.339},
+		{Type: "new_order", Count: 20, P50MS: 9.560, P95MS: 9.560, P99MS: 48.339},
+	}, latencies)
+
+	latencies, err = ParseTransactionLatencies([]byte(sampleOutput1))
+	require.NoError(t, err)
+	assert.Empty(t, latencies)
+}
+
+func TestParseIntervals(t *testing.T) {
+	intervals, err := ParseIntervals([]byte(sampleTpccOutput))
+	require.NoError(t, err)
+	assert.Equal(t, []IntervalResult{
+		{TimeSeconds: 10, Threads: 2, TransactionsPerSecond: 45.69, QueriesPerSecond: 1311.93, LatencyPercentileMS: 48.34, ErrorsPerSecond: 0.20},
+		{TimeSeconds: 20, Threads: 2, TransactionsPerSecond: 47.10, QueriesPerSecond: 1350.20, LatencyPercentileMS: 46.63},
+	}, intervals)
+}
+
+func TestHistogramPercentile(t *testing.T) {
+	buckets := []latencyBucket{{valueMS: 1, count: 50}, {valueMS: 2, count: 49}, {valueMS: 3, count: 1}}
+	assert.Equal(t, float64(1), histogramPercentile(buckets, 100, 50))
+	assert.Equal(t, float64(2), histogramPercentile(buckets, 100, 51))
+	assert.Equal(t, float64(2), histogramPercentile(buckets, 100, 99))
+	assert.Equal(t, float64(3), histogramPercentile(buckets, 100, 100))
+	assert.Equal(t, float64(0), histogramPercentile(nil, 0, 99))
+}
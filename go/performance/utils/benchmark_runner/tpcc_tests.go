@@ -198,7 +198,7 @@ func (t *tpccTestImpl) getArgs(serverConfig ServerConfig) []string {
 		return t.doltgresArgs(serverConfig)
 	case Postgres:
 		return t.postgresArgs(serverConfig)
-	case MySql:
+	case MySql, MariaDb:
 		return t.mysqlArgs(serverConfig)
 	default:
 		panic(fmt.Sprintf("unexpected server type: %s", st))
@@ -229,6 +229,7 @@ func (t *tpccTestImpl) GetRunArgs(serverConfg ServerConfig) []string {
 	args := make([]string, 0)
 	serverArgs := t.getArgs(serverConfg)
 	args = append(args, serverArgs...)
+	args = append(args, tpccHistogramFlag)
 	args = append(args, sysbenchRunCommand)
 	return args
 }
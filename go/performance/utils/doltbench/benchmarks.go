@@ -0,0 +1,253 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltbench
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// benchmark is a dolt operation timed by doltbench
+type benchmark struct {
+	name string
+
+	// usesDeltas is true if the benchmark changes rows, and runs once for each delta of the config
+	usesDeltas bool
+
+	// run sets up a database in |dir| and returns the time each of |config.Runs| runs of the operation took
+	run func(ctx context.Context, config *Config, dir string, delta int) ([]time.Duration, error)
+}
+
+// benchmarks are the benchmarks doltbench runs, in the order they run by default
+var benchmarks = []benchmark{
+	{name: commitBenchmarkName, usesDeltas: true, run: benchmarkCommit},
+	{name: branchBenchmarkName, run: benchmarkBranch},
+	{name: diffBenchmarkName, usesDeltas: true, run: benchmarkDiff},
+	{name: mergeBenchmarkName, usesDeltas: true, run: benchmarkMerge},
+	{name: mergeConflictsBenchmarkName, usesDeltas: true, run: benchmarkMergeConflicts},
+	{name: cloneBenchmarkName, run: benchmarkClone},
+	{name: pushBenchmarkName, usesDeltas: true, run: benchmarkPush},
+}
+
+var benchmarksByName = func() map[string]benchmark {
+	m := make(map[string]benchmark, len(benchmarks))
+	for _, b := range benchmarks {
+		m[b.name] = b
+	}
+	return m
+}()
+
+// benchmarkCommit times committing |delta| changed rows
+func benchmarkCommit(ctx context.Context, config *Config, dir string, delta int) ([]time.Duration, error) {
+	r, err := newRepo(ctx, dir, config.DoltExec, config.Rows)
+	if err != nil {
+		return nil, err
+	}
+	timings := make([]time.Duration, 0, config.Runs)
+	for i := 1; i <= config.Runs; i++ {
+		if err = r.update(ctx, "c0", i, delta); err != nil {
+			return nil, err
+		}
+		d, err := r.timed(ctx, "commit", "-am", fmt.Sprintf("Update %d rows", delta))
+		if err != nil {
+			return nil, err
+		}
+		timings = append(timings, d)
+	}
+	return timings, nil
+}
+
+// benchmarkBranch times creating a branch
+func benchmarkBranch(ctx context.Context, config *Config, dir string, _ int) ([]time.Duration, error) {
+	r, err := newRepo(ctx, dir, config.DoltExec, config.Rows)
+	if err != nil {
+		return nil, err
+	}
+	timings := make([]time.Duration, 0, config.Runs)
+	for i := 1; i <= config.Runs; i++ {
+		d, err := r.timed(ctx, "branch", fmt.Sprintf("branch_%d", i))
+		if err != nil {
+			return nil, err
+		}
+		timings = append(timings, d)
+	}
+	return timings, nil
+}
+
+// benchmarkDiff times diffing two commits |delta| rows apart
+func benchmarkDiff(ctx context.Context, config *Config, dir string, delta int) ([]time.Duration, error) {
+	r, err := newRepo(ctx, dir, config.DoltExec, config.Rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.commitDelta(ctx, "c0", 1, delta); err != nil {
+		return nil, err
+	}
+	timings := make([]time.Duration, 0, config.Runs)
+	for i := 1; i <= config.Runs; i++ {
+		d, err := r.timed(ctx, "diff", "HEAD~1", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		timings = append(timings, d)
+	}
+	return timings, nil
+}
+
+// benchmarkMerge times merging a branch that changed |delta| rows into a branch that changed other columns of the
+// same rows, which merges without conflicts
+func benchmarkMerge(ctx context.Context, config *Config, dir string, delta int) ([]time.Duration, error) {
+	return timeMerges(ctx, config, dir, delta, false)
+}
+
+// benchmarkMergeConflicts times merging a branch that changed |delta| rows into a branch that changed the same
+// columns of the same rows, which merges with |delta| conflicts
+func benchmarkMergeConflicts(ctx context.Context, config *Config, dir string, delta int) ([]time.Duration, error) {
+	return timeMerges(ctx, config, dir, delta, true)
+}
+
+func timeMerges(ctx context.Context, config *Config, dir string, delta int, conflicts bool) ([]time.Duration, error) {
+	r, err := newRepo(ctx, dir, config.DoltExec, config.Rows)
+	if err != nil {
+		return nil, err
+	}
+	base, err := r.head(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// without conflicts, main changes c1 of the rows the branch changes c0 of
+	mainColumn, mainStep := "c1", 1
+	if conflicts {
+		mainColumn, mainStep = "c0", -1
+	}
+
+	timings := make([]time.Duration, 0, config.Runs)
+	for i := 1; i <= config.Runs; i++ {
+		branch := fmt.Sprintf("branch_%d", i)
+		if _, err = r.dolt(ctx, "checkout", "-b", branch); err != nil {
+			return nil, err
+		}
+		if err = r.commitDelta(ctx, "c0", 1, delta); err != nil {
+			return nil, err
+		}
+		if _, err = r.dolt(ctx, "checkout", benchBranchName); err != nil {
+			return nil, err
+		}
+		if err = r.commitDelta(ctx, mainColumn, mainStep, delta); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		out, err := r.dolt(ctx, "merge", "--no-edit", branch)
+		d := time.Since(start)
+		hasConflicts := strings.Contains(out, mergeConflictMarker)
+		if err != nil && !(conflicts && hasConflicts) {
+			return nil, err
+		}
+		if conflicts != hasConflicts {
+			return nil, fmt.Errorf("merge of %s expected conflicts: %t, output: %s", branch, conflicts, out)
+		}
+		timings = append(timings, d)
+
+		if conflicts {
+			if _, err = r.dolt(ctx, "merge", "--abort"); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = r.dolt(ctx, "reset", "--hard", base); err != nil {
+			return nil, err
+		}
+		if _, err = r.dolt(ctx, "branch", "-D", branch); err != nil {
+			return nil, err
+		}
+	}
+	return timings, nil
+}
+
+// benchmarkClone times cloning the database from remotesrv
+func benchmarkClone(ctx context.Context, config *Config, dir string, _ int) ([]time.Duration, error) {
+	r, err := newRepo(ctx, filepath.Join(dir, benchDbName), config.DoltExec, config.Rows)
+	if err != nil {
+		return nil, err
+	}
+	srv, err := startRemotesrv(ctx, config.RemotesrvExec, filepath.Join(dir, "remote"), config.RemotesrvPort)
+	if err != nil {
+		return nil, err
+	}
+	defer srv.stop()
+
+	if err = pushToRemote(ctx, r, srv); err != nil {
+		return nil, err
+	}
+
+	parent := &repo{dir: dir, doltExec: config.DoltExec}
+	timings := make([]time.Duration, 0, config.Runs)
+	for i := 1; i <= config.Runs; i++ {
+		clone := fmt.Sprintf("clone_%d", i)
+		d, err := parent.timed(ctx, "clone", srv.url, clone)
+		if err != nil {
+			return nil, err
+		}
+		timings = append(timings, d)
+		if err = os.RemoveAll(filepath.Join(dir, clone)); err != nil {
+			return nil, err
+		}
+	}
+	return timings, nil
+}
+
+// benchmarkPush times pushing a commit of |delta| changed rows to remotesrv
+func benchmarkPush(ctx context.Context, config *Config, dir string, delta int) ([]time.Duration, error) {
+	r, err := newRepo(ctx, filepath.Join(dir, benchDbName), config.DoltExec, config.Rows)
+	if err != nil {
+		return nil, err
+	}
+	srv, err := startRemotesrv(ctx, config.RemotesrvExec, filepath.Join(dir, "remote"), config.RemotesrvPort)
+	if err != nil {
+		return nil, err
+	}
+	defer srv.stop()
+
+	if err = pushToRemote(ctx, r, srv); err != nil {
+		return nil, err
+	}
+
+	timings := make([]time.Duration, 0, config.Runs)
+	for i := 1; i <= config.Runs; i++ {
+		if err = r.commitDelta(ctx, "c0", i, delta); err != nil {
+			return nil, err
+		}
+		d, err := r.timed(ctx, "push", benchRemoteName, benchBranchName)
+		if err != nil {
+			return nil, err
+		}
+		timings = append(timings, d)
+	}
+	return timings, nil
+}
+
+// pushToRemote adds |srv| as the remote of |r| and pushes the database to it
+func pushToRemote(ctx context.Context, r *repo, srv *remotesrv) error {
+	if _, err := r.dolt(ctx, "remote", "add", benchRemoteName, srv.url); err != nil {
+		return err
+	}
+	_, err := r.dolt(ctx, "push", benchRemoteName, benchBranchName)
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	runner "github.com/dolthub/dolt/go/performance/utils/benchmark_runner"
+	"github.com/dolthub/dolt/go/performance/utils/doltbench"
+)
+
+const usage = "usage: doltbench -dolt-exec <dolt> -results <path> [options]\n" +
+	"times dolt commit, branch, diff, merge, clone and push and writes\n" +
+	"the results in the csv or json format of benchmark-runner\n"
+
+var (
+	doltExec      = flag.String("dolt-exec", "", "path of the dolt binary to benchmark")
+	remotesrvExec = flag.String("remotesrv-exec", "", "path of the remotesrv binary the clone and push benchmarks use, defaults to remotesrv on the PATH")
+	remotesrvPort = flag.Int("remotesrv-port", 0, "port remotesrv listens on, defaults to 50051")
+	version       = flag.String("version", "", "version of dolt benchmarked, defaults to the output of dolt version")
+	suiteId       = flag.String("id", "", "suite id of the results, defaults to a random uuid")
+	rows          = flag.Int("rows", 0, "number of rows in the benchmarked table, defaults to 10000")
+	deltas        = flag.String("deltas", "", "comma separated numbers of rows changed by the commit, diff, merge and push benchmarks, defaults to 10,1000")
+	runs          = flag.Int("runs", 0, "number of times each benchmark is timed, defaults to 5")
+	benchmarks    = flag.String("benchmarks", "", "comma separated benchmarks to run, defaults to all of commit,branch,diff,merge,merge_conflicts,clone,push")
+	resultsFormat = flag.String("results-format", runner.CsvFormat, "format of the results, csv or json")
+	resultsPath   = flag.String("results", "", "path the results are written to")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *doltExec == "" || *resultsPath == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config := &doltbench.Config{
+		Id:            *suiteId,
+		DoltExec:      *doltExec,
+		RemotesrvExec: *remotesrvExec,
+		RemotesrvPort: *remotesrvPort,
+		Version:       *version,
+		Rows:          *rows,
+		Runs:          *runs,
+		ResultsFormat: *resultsFormat,
+		ResultsPath:   *resultsPath,
+	}
+	if *benchmarks != "" {
+		config.Benchmarks = strings.Split(*benchmarks, ",")
+	}
+	if *deltas != "" {
+		for _, d := range strings.Split(*deltas, ",") {
+			delta, err := strconv.Atoi(strings.TrimSpace(d))
+			if err != nil {
+				log.Fatalf("invalid delta %s: %v", d, err)
+			}
+			config.Deltas = append(config.Deltas, delta)
+		}
+	}
+
+	if err := doltbench.Run(context.Background(), config); err != nil {
+		log.Fatal(err)
+	}
+
+	os.Exit(0)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltbench
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	"github.com/google/uuid"
+
+	runner "github.com/dolthub/dolt/go/performance/utils/benchmark_runner"
+)
+
+var (
+	ErrNoDoltExec    = errors.New("no dolt executable defined")
+	ErrNoResultsPath = errors.New("no results path defined")
+)
+
+// Config is the configuration of a run of the dolt-native benchmarks
+type Config struct {
+	// Id is the suite id of the results. Defaults to a random uuid.
+	Id string
+
+	// DoltExec is the path of the dolt binary benchmarked
+	DoltExec string
+
+	// RemotesrvExec is the path of the remotesrv binary the clone and push benchmarks push to. Defaults to
+	// remotesrv on the PATH.
+	RemotesrvExec string
+
+	// RemotesrvPort is the port remotesrv listens on. Defaults to 50051.
+	RemotesrvPort int
+
+	// Version is the version of dolt benchmarked. Defaults to the version reported by DoltExec.
+	Version string
+
+	// Rows is the number of rows in the table of the benchmarked database. Defaults to 10000.
+	Rows int
+
+	// Deltas are the numbers of rows changed by each commit, diff, merge and push benchmarked. Each benchmark that
+	// changes rows runs once for each delta. Defaults to 10 and 1000.
+	Deltas []int
+
+	// Runs is the number of times each benchmark is timed. Defaults to 5.
+	Runs int
+
+	// Benchmarks are the names of the benchmarks to run. Defaults to all of them.
+	Benchmarks []string
+
+	// ResultsFormat is the format of the results, csv or json
+	ResultsFormat string
+
+	// ResultsPath is the path the results are written to
+	ResultsPath string
+
+	// RuntimeOS is the platform the benchmarks ran on
+	RuntimeOS string
+
+	// RuntimeGoArch is the runtime architecture
+	RuntimeGoArch string
+}
+
+// Validate checks the config and sets the defaults of unset fields
+func (c *Config) Validate() error {
+	if c.DoltExec == "" {
+		return ErrNoDoltExec
+	}
+	if c.ResultsPath == "" {
+		return ErrNoResultsPath
+	}
+	if c.ResultsFormat != runner.CsvFormat && c.ResultsFormat != runner.JsonFormat {
+		return fmt.Errorf("unsupported results format: %s", c.ResultsFormat)
+	}
+	if c.Rows < 0 || c.Runs < 0 || c.RemotesrvPort < 0 {
+		return fmt.Errorf("rows, runs and remotesrv port must not be negative")
+	}
+	for _, name := range c.Benchmarks {
+		if _, ok := benchmarksByName[name]; !ok {
+			return fmt.Errorf("unsupported benchmark: %s", name)
+		}
+	}
+
+	c.setDefaults()
+
+	for _, d := range c.Deltas {
+		if d < 1 || d > c.Rows {
+			return fmt.Errorf("invalid delta %d, must be between 1 and the number of rows %d", d, c.Rows)
+		}
+	}
+	return nil
+}
+
+func (c *Config) setDefaults() {
+	if c.Id == "" {
+		c.Id = uuid.New().String()
+	}
+	if c.RemotesrvExec == "" {
+		c.RemotesrvExec = defaultRemotesrvExec
+	}
+	if c.RemotesrvPort == 0 {
+		c.RemotesrvPort = defaultRemotesrvPort
+	}
+	if c.Rows == 0 {
+		c.Rows = defaultRows
+	}
+	if len(c.Deltas) == 0 {
+		for _, d := range defaultDeltas {
+			if d <= c.Rows {
+				c.Deltas = append(c.Deltas, d)
+			}
+		}
+	}
+	if c.Runs == 0 {
+		c.Runs = defaultRuns
+	}
+	if len(c.Benchmarks) == 0 {
+		for _, b := range benchmarks {
+			c.Benchmarks = append(c.Benchmarks, b.name)
+		}
+	}
+	if c.RuntimeOS == "" {
+		c.RuntimeOS = runtime.GOOS
+	}
+	if c.RuntimeGoArch == "" {
+		c.RuntimeGoArch = runtime.GOARCH
+	}
+	if abs, err := filepath.Abs(c.ResultsPath); err == nil {
+		c.ResultsPath = abs
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltbench
+
+import "time"
+
+const (
+	defaultRemotesrvExec = "remotesrv"
+	defaultRemotesrvPort = 50051
+	defaultRows          = 10000
+	defaultRuns          = 5
+
+	commitBenchmarkName         = "commit"
+	branchBenchmarkName         = "branch"
+	diffBenchmarkName           = "diff"
+	mergeBenchmarkName          = "merge"
+	mergeConflictsBenchmarkName = "merge_conflicts"
+	cloneBenchmarkName          = "clone"
+	pushBenchmarkName           = "push"
+
+	benchDbName     = "doltbench"
+	benchTableName  = "bench"
+	benchRowsFile   = "rows.csv"
+	benchRemoteName = "origin"
+	benchBranchName = "main"
+	benchRemoteOrg  = "doltbench"
+
+	doltConfigUsernameKey = "user.name"
+	doltConfigEmailKey    = "user.email"
+	doltBenchmarkUser     = "benchmark"
+	doltBenchmarkEmail    = "benchmark@dolthub.com"
+
+	// mergeConflictMarker is printed by dolt merge when the merge has conflicts, which it exits with an error for
+	mergeConflictMarker = "CONFLICT"
+
+	// latencyPercentile is the percentile of the timings reported as a result's latency_percentile
+	latencyPercentile = 95
+
+	stampFormat = time.RFC3339
+
+	// remotesrvStartTimeout is how long to wait for remotesrv to accept connections
+	remotesrvStartTimeout = 10 * time.Second
+)
+
+var defaultDeltas = []int{10, 1000}
@@ -0,0 +1,186 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltbench
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	runner "github.com/dolthub/dolt/go/performance/utils/benchmark_runner"
+)
+
+// repo is a dolt database the benchmarks run dolt commands in
+type repo struct {
+	dir      string
+	doltExec string
+}
+
+// newRepo creates a database in |dir| with a table of |rows| rows and commits it
+func newRepo(ctx context.Context, dir, doltExec string, rows int) (*repo, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	r := &repo{dir: dir, doltExec: doltExec}
+	if _, err := r.dolt(ctx, "init"); err != nil {
+		return nil, err
+	}
+	if _, err := r.sql(ctx, fmt.Sprintf("CREATE TABLE %s (pk int primary key, c0 int, c1 varchar(64));", benchTableName)); err != nil {
+		return nil, err
+	}
+	if err := writeRowsCsv(filepath.Join(dir, benchRowsFile), rows); err != nil {
+		return nil, err
+	}
+	if _, err := r.dolt(ctx, "table", "import", "-u", benchTableName, benchRowsFile); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(filepath.Join(dir, benchRowsFile)); err != nil {
+		return nil, err
+	}
+	if _, err := r.dolt(ctx, "commit", "-Am", fmt.Sprintf("Add %d rows", rows)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// writeRowsCsv writes the csv of |rows| rows the benchmarked table is imported from
+func writeRowsCsv(path string, rows int) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := f.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	w := csv.NewWriter(f)
+	if err = w.Write([]string{"pk", "c0", "c1"}); err != nil {
+		return err
+	}
+	for i := 1; i <= rows; i++ {
+		if err = w.Write([]string{strconv.Itoa(i), strconv.Itoa(i), fmt.Sprintf("row %d", i)}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// dolt runs a dolt command in the database and returns its output
+func (r *repo) dolt(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, r.doltExec, args...)
+	cmd.Dir = r.dir
+	out, err := cmd.CombinedOutput()
+	if runner.Debug {
+		fmt.Print(string(out))
+	}
+	if err != nil {
+		return string(out), fmt.Errorf("dolt %s failed: %w: %s", strings.Join(args, " "), err, out)
+	}
+	return string(out), nil
+}
+
+// timed runs a dolt command in the database and returns how long it took
+func (r *repo) timed(ctx context.Context, args ...string) (time.Duration, error) {
+	start := time.Now()
+	_, err := r.dolt(ctx, args...)
+	return time.Since(start), err
+}
+
+func (r *repo) sql(ctx context.Context, query string) (string, error) {
+	return r.dolt(ctx, "sql", "-q", query)
+}
+
+// update changes a column of the first |delta| rows of the benchmarked table, to a value that differs from every
+// previous update with the same |step|
+func (r *repo) update(ctx context.Context, column string, step, delta int) error {
+	_, err := r.sql(ctx, fmt.Sprintf("UPDATE %s SET %s = %s WHERE pk <= %d;", benchTableName, column, updateExpr(column, step), delta))
+	return err
+}
+
+func updateExpr(column string, step int) string {
+	if column == "c1" {
+		return fmt.Sprintf("concat(c1, '+%d')", step)
+	}
+	return fmt.Sprintf("%s + %d", column, step)
+}
+
+// commitDelta updates the first |delta| rows of the benchmarked table and commits them
+func (r *repo) commitDelta(ctx context.Context, column string, step, delta int) error {
+	if err := r.update(ctx, column, step, delta); err != nil {
+		return err
+	}
+	_, err := r.dolt(ctx, "commit", "-am", fmt.Sprintf("Update %d rows", delta))
+	return err
+}
+
+// head returns the hash of the HEAD commit
+func (r *repo) head(ctx context.Context) (string, error) {
+	out, err := r.dolt(ctx, "sql", "-r", "csv", "-q", "SELECT hashof('HEAD');")
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// remotesrv is a remotesrv process serving the databases pushed to it from a directory
+type remotesrv struct {
+	cmd *exec.Cmd
+	url string
+}
+
+// startRemotesrv starts remotesrv in |dir| and waits for it to accept connections
+func startRemotesrv(ctx context.Context, remotesrvExec, dir string, port int) (*remotesrv, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	cmd := runner.ExecCommand(ctx, remotesrvExec, "--http-port", strconv.Itoa(port), "--grpc-port", strconv.Itoa(port))
+	cmd.Dir = dir
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	srv := &remotesrv{cmd: cmd, url: fmt.Sprintf("http://localhost:%d/%s/%s", port, benchRemoteOrg, benchDbName)}
+
+	addr := fmt.Sprintf("localhost:%d", port)
+	deadline := time.Now().Add(remotesrvStartTimeout)
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			return srv, nil
+		}
+		if time.Now().After(deadline) {
+			srv.stop()
+			return nil, fmt.Errorf("remotesrv did not start listening on %s: %w", addr, err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (s *remotesrv) stop() {
+	_ = s.cmd.Process.Kill()
+	_ = s.cmd.Wait()
+}
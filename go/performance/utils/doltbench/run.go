@@ -0,0 +1,154 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltbench
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	runner "github.com/dolthub/dolt/go/performance/utils/benchmark_runner"
+)
+
+// Run runs the benchmarks of |config| and writes their results to the config's results path
+func Run(ctx context.Context, config *Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if config.Version == "" {
+		version, err := doltVersion(ctx, config.DoltExec)
+		if err != nil {
+			return err
+		}
+		config.Version = version
+	}
+	if err := runner.CheckSetDoltConfig(ctx, config.DoltExec, doltConfigUsernameKey, doltBenchmarkUser); err != nil {
+		return err
+	}
+	if err := runner.CheckSetDoltConfig(ctx, config.DoltExec, doltConfigEmailKey, doltBenchmarkEmail); err != nil {
+		return err
+	}
+
+	workDir, err := os.MkdirTemp("", "doltbench")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workDir)
+
+	results := make(runner.Results, 0)
+	for _, name := range config.Benchmarks {
+		b := benchmarksByName[name]
+		deltas := []int{0}
+		if b.usesDeltas {
+			deltas = config.Deltas
+		}
+		for _, delta := range deltas {
+			testName := benchmarkTestName(b, delta)
+			fmt.Printf("Running doltbench %s\n", testName)
+			timings, err := b.run(ctx, config, filepath.Join(workDir, testName), delta)
+			if err != nil {
+				return fmt.Errorf("benchmark %s failed: %w", testName, err)
+			}
+			results = append(results, newResult(config, testName, delta, timings))
+		}
+	}
+
+	if config.ResultsFormat == runner.CsvFormat {
+		return runner.WriteResultsCsv(config.ResultsPath, results)
+	}
+	return runner.WriteResultsJson(config.ResultsPath, results)
+}
+
+// benchmarkTestName returns the test name of the results of |b| run with |delta|, e.g. diff_1000
+func benchmarkTestName(b benchmark, delta int) string {
+	if !b.usesDeltas {
+		return b.name
+	}
+	return fmt.Sprintf("%s_%d", b.name, delta)
+}
+
+// newResult returns the result of a benchmark whose runs took |timings|. Each run is counted as a transaction.
+func newResult(config *Config, testName string, delta int, timings []time.Duration) *runner.Result {
+	testParams := []string{fmt.Sprintf("--rows=%d", config.Rows), fmt.Sprintf("--runs=%d", config.Runs)}
+	if delta > 0 {
+		testParams = append(testParams, fmt.Sprintf("--delta=%d", delta))
+	}
+	r := runner.NewResult(runner.Dolt, config.Version, testName, uuid.New().String(), config.Id, config.RuntimeOS, config.RuntimeGoArch, nil, testParams, nil, false)
+	r.Threads = 1
+	r.Connections = 1
+	r.CreatedAt = time.Now().UTC().Format(stampFormat)
+
+	if len(timings) == 0 {
+		return r
+	}
+
+	ms := make([]float64, len(timings))
+	for i, t := range timings {
+		ms[i] = float64(t) / float64(time.Millisecond)
+	}
+	sort.Float64s(ms)
+
+	var sum float64
+	for _, m := range ms {
+		sum += m
+	}
+	r.TransactionsTotal = int64(len(ms))
+	r.TotalNumberOfEvents = int64(len(ms))
+	r.TotalTimeSeconds = sum / 1000
+	if r.TotalTimeSeconds > 0 {
+		r.TransactionsPerSecond = float64(len(ms)) / r.TotalTimeSeconds
+	}
+	r.LatencyMinMS = ms[0]
+	r.LatencyMaxMS = ms[len(ms)-1]
+	r.LatencyAvgMS = sum / float64(len(ms))
+	r.LatencySumMS = sum
+	r.LatencyPercentile = percentile(ms, latencyPercentile)
+	return r
+}
+
+// percentile returns the nearest rank |p|th percentile of |sorted|
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(float64(len(sorted)) * p / 100))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// doltVersion returns the version reported by dolt version, e.g. 1.42.0
+func doltVersion(ctx context.Context, doltExec string) (string, error) {
+	out, err := exec.CommandContext(ctx, doltExec, "version").Output()
+	if err != nil {
+		return "", fmt.Errorf("dolt version failed: %w", err)
+	}
+	for _, l := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(l)
+		if len(fields) >= 3 && fields[0] == "dolt" && fields[1] == "version" {
+			return fields[2], nil
+		}
+	}
+	return "", fmt.Errorf("unable to parse dolt version from: %s", out)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltbench
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runner "github.com/dolthub/dolt/go/performance/utils/benchmark_runner"
+)
+
+func TestNewResult(t *testing.T) {
+	config := &Config{Id: "suite", Version: "1.0.0", Rows: 100, Runs: 4, RuntimeOS: "linux", RuntimeGoArch: "amd64"}
+	timings := []time.Duration{40 * time.Millisecond, 10 * time.Millisecond, 30 * time.Millisecond, 20 * time.Millisecond}
+
+	r := newResult(config, "diff_10", 10, timings)
+	assert.Equal(t, "suite", r.SuiteId)
+	assert.Equal(t, string(runner.Dolt), r.ServerName)
+	assert.Equal(t, "1.0.0", r.ServerVersion)
+	assert.Equal(t, "diff_10", r.TestName)
+	assert.Equal(t, "--rows=100 --runs=4 --delta=10", r.TestParams)
+	assert.Equal(t, int64(4), r.TransactionsTotal)
+	assert.Equal(t, int64(4), r.TotalNumberOfEvents)
+	assert.InDelta(t, 0.1, r.TotalTimeSeconds, 1e-9)
+	assert.InDelta(t, 40, r.TransactionsPerSecond, 1e-9)
+	assert.InDelta(t, 10, r.LatencyMinMS, 1e-9)
+	assert.InDelta(t, 25, r.LatencyAvgMS, 1e-9)
+	assert.InDelta(t, 40, r.LatencyMaxMS, 1e-9)
+	assert.InDelta(t, 40, r.LatencyPercentile, 1e-9)
+	assert.InDelta(t, 100, r.LatencySumMS, 1e-9)
+
+	r = newResult(config, "clone", 0, nil)
+	assert.Equal(t, "--rows=100 --runs=4", r.TestParams)
+	assert.Equal(t, int64(0), r.TransactionsTotal)
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, float64(5), percentile(sorted, 50))
+	assert.Equal(t, float64(10), percentile(sorted, 95))
+	assert.Equal(t, float64(1), percentile(sorted, 0))
+	assert.Equal(t, float64(0), percentile(nil, 95))
+}
+
+func TestConfigValidate(t *testing.T) {
+	config := &Config{DoltExec: "dolt", ResultsFormat: runner.CsvFormat, ResultsPath: "results.csv", Rows: 100}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, []int{10}, config.Deltas)
+	assert.Equal(t, defaultRuns, config.Runs)
+	assert.Len(t, config.Benchmarks, len(benchmarks))
+	assert.NotEmpty(t, config.Id)
+
+	assert.ErrorIs(t, (&Config{ResultsFormat: runner.CsvFormat, ResultsPath: "results.csv"}).Validate(), ErrNoDoltExec)
+	assert.ErrorIs(t, (&Config{DoltExec: "dolt", ResultsFormat: runner.CsvFormat}).Validate(), ErrNoResultsPath)
+	assert.Error(t, (&Config{DoltExec: "dolt", ResultsFormat: "xml", ResultsPath: "results.xml"}).Validate())
+	assert.Error(t, (&Config{DoltExec: "dolt", ResultsFormat: runner.CsvFormat, ResultsPath: "results.csv", Benchmarks: []string{"rebase"}}).Validate())
+	assert.Error(t, (&Config{DoltExec: "dolt", ResultsFormat: runner.CsvFormat, ResultsPath: "results.csv", Rows: 100, Deltas: []int{1000}}).Validate())
+}
+
+func TestBenchmarkTestName(t *testing.T) {
+	assert.Equal(t, "diff_1000", benchmarkTestName(benchmarksByName[diffBenchmarkName], 1000))
+	assert.Equal(t, "clone", benchmarkTestName(benchmarksByName[cloneBenchmarkName], 0))
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunks
+
+import "context"
+
+// FetchPriority is the priority of a read of chunks which aren't held locally, and are fetched over the network by
+// ChunkStores backed by remote databases. When such a store is busy, reads with a higher priority are fetched first.
+// ChunkStores which hold their chunks locally ignore it.
+type FetchPriority int
+
+const (
+	// FetchPriorityPrefetch is the priority of chunks read ahead of when they're needed, which are only fetched
+	// after the chunks a query is waiting for.
+	FetchPriorityPrefetch FetchPriority = iota
+	// FetchPriorityNormal is the priority of reads which don't set one, such as reads of the leaf nodes of trees.
+	FetchPriorityNormal
+	// FetchPriorityHigh is the priority of reads of the root and internal nodes of trees, which every read of their
+	// leaves waits on.
+	FetchPriorityHigh
+)
+
+type fetchPriorityKey struct{}
+
+// WithFetchPriority returns a context in which chunks are read with priority |p|.
+func WithFetchPriority(ctx context.Context, p FetchPriority) context.Context {
+	return context.WithValue(ctx, fetchPriorityKey{}, p)
+}
+
+// FetchPriorityFromContext returns the priority of reads in |ctx|, which is FetchPriorityNormal if it wasn't set.
+func FetchPriorityFromContext(ctx context.Context) FetchPriority {
+	if p, ok := ctx.Value(fetchPriorityKey{}).(FetchPriority); ok {
+		return p
+	}
+	return FetchPriorityNormal
+}
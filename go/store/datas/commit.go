@@ -146,6 +146,15 @@ func NewCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.Valu
 	return newCommitForValue(ctx, cs, vrw, ns, v, opts)
 }
 
+// NewRootCommitForValue is like NewCommitForValue, but creates a commit without parents, which starts a new history.
+func NewRootCommitForValue(ctx context.Context, cs chunks.ChunkStore, vrw types.ValueReadWriter, ns tree.NodeStore, v types.Value, opts CommitOptions) (*Commit, error) {
+	if len(opts.Parents) > 0 {
+		return nil, errors.New("cannot create a root commit with parents")
+	}
+
+	return newCommitForValue(ctx, cs, vrw, ns, v, opts)
+}
+
 func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, parentsClosureAddr hash.Hash) (serial.Message, uint64) {
 	builder := flatbuffers.NewBuilder(1024)
 	vaddroff := builder.CreateByteVector(vaddr[:])
@@ -180,6 +189,13 @@ func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, pa
 		sigoff = builder.CreateString(opts.Meta.Signature)
 	}
 
+	// the committer is only written when it differs from the author, so that those commits stay readable by older clients
+	var committerNameOff, committerEmailOff flatbuffers.UOffsetT
+	if len(opts.Meta.CommitterName) != 0 {
+		committerNameOff = builder.CreateString(opts.Meta.CommitterName)
+		committerEmailOff = builder.CreateString(opts.Meta.CommitterEmail)
+	}
+
 	serial.CommitStart(builder)
 	serial.CommitAddRoot(builder, vaddroff)
 	serial.CommitAddHeight(builder, maxheight+1)
@@ -191,6 +207,8 @@ func commit_flatbuffer(vaddr hash.Hash, opts CommitOptions, heights []uint64, pa
 	serial.CommitAddTimestampMillis(builder, opts.Meta.Timestamp)
 	serial.CommitAddUserTimestampMillis(builder, opts.Meta.UserTimestamp)
 	serial.CommitAddSignature(builder, sigoff)
+	serial.CommitAddCommitterName(builder, committerNameOff)
+	serial.CommitAddCommitterEmail(builder, committerEmailOff)
 
 	bytes := serial.FinishMessage(builder, serial.CommitEnd(builder), []byte(serial.CommitFileID))
 	return bytes, maxheight + 1
@@ -590,6 +608,8 @@ func GetCommitMeta(ctx context.Context, cv types.Value) (*CommitMeta, error) {
 		ret.Timestamp = cmsg.TimestampMillis()
 		ret.UserTimestamp = cmsg.UserTimestampMillis()
 		ret.Signature = string(cmsg.Signature())
+		ret.CommitterName = string(cmsg.CommitterName())
+		ret.CommitterEmail = string(cmsg.CommitterEmail())
 		return ret, nil
 	}
 	c, ok := cv.(types.Struct)
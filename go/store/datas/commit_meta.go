@@ -62,6 +62,10 @@ type CommitMeta struct {
 	Description   string
 	UserTimestamp int64
 	Signature     string
+	// CommitterName and CommitterEmail identify whoever created the commit when it isn't its author, like a service
+	// committing on behalf of a user. They are empty when the author created the commit.
+	CommitterName  string
+	CommitterEmail string
 }
 
 // NewCommitMeta creates a CommitMeta instance from a name, email, and description and uses the current time for the
@@ -120,7 +124,7 @@ func NewCommitMetaWithUserTS(name, email, desc string, userTS time.Time) (*Commi
 	committerDateMillis := uint64(CommitterDate().UnixMilli())
 	authorDateMillis := userTS.UnixMilli()
 
-	return &CommitMeta{n, e, committerDateMillis, d, authorDateMillis, ""}, nil
+	return &CommitMeta{n, e, committerDateMillis, d, authorDateMillis, "", "", ""}, nil
 }
 
 func getRequiredFromSt(st types.Struct, k string) (types.Value, error) {
@@ -181,6 +185,8 @@ func CommitMetaFromNomsSt(st types.Struct) (*CommitMeta, error) {
 		string(d.(types.String)),
 		int64(userTS.(types.Int)),
 		string(signature.(types.String)),
+		"",
+		"",
 	}, nil
 }
 
@@ -198,6 +204,15 @@ func (cm *CommitMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, erro
 	return types.NewStruct(nbf, commitMetaStName, metadata)
 }
 
+// Committer returns the name and email of whoever created the commit, which is its author unless a different
+// committer was recorded.
+func (cm *CommitMeta) Committer() (name, email string) {
+	if cm.CommitterName == "" {
+		return cm.Name, cm.Email
+	}
+	return cm.CommitterName, cm.CommitterEmail
+}
+
 // Time returns the time at which the commit occurred
 func (cm *CommitMeta) Time() time.Time {
 	return time.UnixMilli(cm.UserTimestamp)
@@ -15,11 +15,15 @@
 package datas
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -37,3 +41,31 @@ func TestCommitMetaToAndFromNomsStruct(t *testing.T) {
 
 	t.Log(cm.String())
 }
+
+func TestCommitMetaCommitter(t *testing.T) {
+	ctx := context.Background()
+	cm, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "This is a test commit")
+	require.NoError(t, err)
+
+	msg, _ := commit_flatbuffer(hash.Hash{}, CommitOptions{Meta: cm}, nil, hash.Hash{})
+	var cmsg serial.Commit
+	require.NoError(t, serial.InitCommitRoot(&cmsg, msg, serial.MessagePrefixSz))
+	// commits without a committer don't use the committer fields, so older clients can still read them
+	assert.LessOrEqual(t, int(cmsg.Table().NumFields()), 10)
+	result, err := GetCommitMeta(ctx, types.SerialMessage(msg))
+	require.NoError(t, err)
+	assert.Equal(t, cm, result)
+	name, email := result.Committer()
+	assert.Equal(t, "Bill Billerson", name)
+	assert.Equal(t, "bigbillieb@fake.horse", email)
+
+	cm.CommitterName = "Service Account"
+	cm.CommitterEmail = "service@fake.horse"
+	msg, _ = commit_flatbuffer(hash.Hash{}, CommitOptions{Meta: cm}, nil, hash.Hash{})
+	result, err = GetCommitMeta(ctx, types.SerialMessage(msg))
+	require.NoError(t, err)
+	assert.Equal(t, cm, result)
+	name, email = result.Committer()
+	assert.Equal(t, "Service Account", name)
+	assert.Equal(t, "service@fake.horse", email)
+}
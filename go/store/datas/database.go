@@ -149,6 +149,16 @@ type Database interface {
 	// is not provided, no working set update will be performed.
 	SetHead(ctx context.Context, ds Dataset, newHeadAddr hash.Hash, workingSetPath string) (Dataset, error)
 
+	// SetHeads force-sets the heads of several datasets in a single update of the root, like SetHead does for one,
+	// so that either every dataset is updated or none of them are. |heads| maps dataset IDs to the addresses of the
+	// Commits or Tags they should point at, and an empty address deletes the dataset. Datasets which are already
+	// present must keep pointing at the same type of struct. Working sets are not updated.
+	SetHeads(ctx context.Context, heads map[string]hash.Hash) error
+
+	// WriteTag writes a new Tag struct pointing at |commitAddr|, with the metadata of |opts|, without storing it in
+	// any Dataset, and returns its address. The tag can then be made the head of a tag dataset with SetHeads.
+	WriteTag(ctx context.Context, commitAddr hash.Hash, opts TagOptions) (hash.Hash, error)
+
 	// FastForward takes a types.Ref to a Commit object and makes it the new
 	// Head of ds iff it is a descendant of the current Head. Intended to be
 	// used e.g. after a call to Pull(). If the update cannot be performed,
@@ -199,6 +209,9 @@ type GarbageCollector interface {
 	// GC traverses the database starting at the Root and removes
 	// all unreferenced data from persistent storage.
 	GC(ctx context.Context, mode types.GCMode, cmp chunks.GCArchiveLevel, oldGenRefs, newGenRefs hash.HashSet, safepointController types.GCSafepointController) error
+
+	// GCDryRun computes what GC would collect if it were run with the same arguments, without modifying the database.
+	GCDryRun(ctx context.Context, mode types.GCMode, oldGenRefs, newGenRefs hash.HashSet) (types.GCPlan, error)
 }
 
 // CanUsePuller returns true if a datas.Puller can be used to pull data from one Database into another.  Not all
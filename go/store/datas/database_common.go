@@ -423,6 +423,98 @@ func (db *database) doSetHead(ctx context.Context, ds Dataset, addr hash.Hash, w
 	})
 }
 
+func (db *database) SetHeads(ctx context.Context, heads map[string]hash.Hash) error {
+	headTypes := make(map[string]string, len(heads))
+	for id, addr := range heads {
+		if addr.IsEmpty() {
+			continue
+		}
+		head, err := db.readHead(ctx, addr)
+		if err != nil {
+			return err
+		}
+		switch head.TypeName() {
+		case commitName, tagName:
+			headTypes[id] = head.TypeName()
+		default:
+			return fmt.Errorf("SetHeads failed: value for %s is not a commit or a tag", id)
+		}
+	}
+	checkType := func(id string, curr hash.Hash) error {
+		newType, ok := headTypes[id]
+		if !ok || curr.IsEmpty() {
+			return nil
+		}
+		currHead, err := db.readHead(ctx, curr)
+		if err != nil {
+			return err
+		}
+		if currType := currHead.TypeName(); currType != newType {
+			return fmt.Errorf("cannot change type of head %s; currently points at %s but new value would point at %s", id, currType, newType)
+		}
+		return nil
+	}
+
+	return db.update(ctx, func(ctx context.Context, datasets types.Map) (types.Map, error) {
+		ed := datasets.Edit()
+		for id, addr := range heads {
+			key := types.String(id)
+			currRef, ok, err := datasets.MaybeGet(ctx, key)
+			if err != nil {
+				return types.Map{}, err
+			}
+			if ok {
+				if err = checkType(id, currRef.(types.Ref).TargetHash()); err != nil {
+					return types.Map{}, err
+				}
+			}
+			if addr.IsEmpty() {
+				ed.Remove(key)
+				continue
+			}
+			val, err := db.ReadValue(ctx, addr)
+			if err != nil {
+				return types.Map{}, err
+			}
+			vref, err := types.NewRef(val, db.Format())
+			if err != nil {
+				return types.Map{}, err
+			}
+			ref, err := types.ToRefOfValue(vref, db.Format())
+			if err != nil {
+				return types.Map{}, err
+			}
+			ed.Set(key, ref)
+		}
+		return ed.Map(ctx)
+	}, func(ctx context.Context, am prolly.AddressMap) (prolly.AddressMap, error) {
+		ae := am.Editor()
+		for id, addr := range heads {
+			curr, err := am.Get(ctx, id)
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+			if err = checkType(id, curr); err != nil {
+				return prolly.AddressMap{}, err
+			}
+			if addr.IsEmpty() {
+				err = ae.Delete(ctx, id)
+			} else {
+				err = ae.Update(ctx, id, addr)
+			}
+			if err != nil {
+				return prolly.AddressMap{}, err
+			}
+		}
+		return ae.Flush(ctx)
+	})
+}
+
+func (db *database) WriteTag(ctx context.Context, commitAddr hash.Hash, opts TagOptions) (hash.Hash, error) {
+	addr, _, err := newTag(ctx, db, commitAddr, opts.Meta)
+	return addr, err
+}
+
 func (db *database) FastForward(ctx context.Context, ds Dataset, newHeadAddr hash.Hash, wsPath string) (Dataset, error) {
 	return db.doHeadUpdate(ctx, ds, func(ds Dataset) error {
 		return db.doFastForward(ctx, ds, newHeadAddr, wsPath)
@@ -538,6 +538,43 @@ func (suite *DatabaseSuite) TestSetHead() {
 	suite.True(mustHeadValue(ds).Equals(b))
 }
 
+func (suite *DatabaseSuite) TestSetHeads() {
+	ctx := context.Background()
+	ds1, err := suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	ds1, err = CommitValue(ctx, suite.db, ds1, types.String("a"))
+	suite.NoError(err)
+	aCommitAddr := mustHeadAddr(ds1)
+	ds1, err = CommitValue(ctx, suite.db, ds1, types.String("b"))
+	suite.NoError(err)
+	bCommitAddr := mustHeadAddr(ds1)
+	ds2, err := suite.db.GetDataset(ctx, "ds2")
+	suite.NoError(err)
+	_, err = suite.db.SetHead(ctx, ds2, bCommitAddr, "")
+	suite.NoError(err)
+	tagAddr, err := suite.db.WriteTag(ctx, aCommitAddr, TagOptions{})
+	suite.NoError(err)
+
+	// ds1 can't point at a tag, so nothing changes
+	err = suite.db.SetHeads(ctx, map[string]hash.Hash{"ds1": tagAddr, "ds2": aCommitAddr})
+	suite.Error(err)
+	ds2, err = suite.db.GetDataset(ctx, "ds2")
+	suite.NoError(err)
+	suite.Equal(bCommitAddr, mustHeadAddr(ds2))
+
+	err = suite.db.SetHeads(ctx, map[string]hash.Hash{"ds1": aCommitAddr, "ds2": {}, "tag1": tagAddr})
+	suite.NoError(err)
+	ds1, err = suite.db.GetDataset(ctx, "ds1")
+	suite.NoError(err)
+	suite.Equal(aCommitAddr, mustHeadAddr(ds1))
+	ds2, err = suite.db.GetDataset(ctx, "ds2")
+	suite.NoError(err)
+	suite.False(ds2.HasHead())
+	tag1, err := suite.db.GetDataset(ctx, "tag1")
+	suite.NoError(err)
+	suite.True(tag1.HasHead())
+}
+
 func (suite *DatabaseSuite) TestFastForward() {
 	datasetID := "ds1"
 
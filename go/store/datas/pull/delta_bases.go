@@ -0,0 +1,151 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/delta"
+)
+
+// minDeltaChunkSize is the size of the smallest chunk that is delta encoded. Smaller chunks don't save enough to
+// be worth the cost of finding a base.
+const minDeltaChunkSize = 256
+
+// deltaFeatures is the number of similarity features computed for each chunk. Chunks which share a feature are
+// likely to share most of their content.
+const deltaFeatures = 4
+
+// featureWindow is the number of bytes after which a byte no longer affects the rolling hash.
+const featureWindow = 64
+
+var gearTable, featureMults, featureAdds = func() (gear [256]uint64, mults, adds [deltaFeatures]uint64) {
+	rng := rand.New(rand.NewSource(0x646f6c74))
+	for i := range gear {
+		gear[i] = rng.Uint64()
+	}
+	for i := range mults {
+		mults[i] = rng.Uint64() | 1
+		adds[i] = rng.Uint64()
+	}
+	return
+}()
+
+// chunkFeatures returns the similarity features of |data|, which are the minimums of different permutations of
+// a rolling hash over |data|.
+func chunkFeatures(data []byte) (fs [deltaFeatures]uint64, ok bool) {
+	if len(data) < minDeltaChunkSize {
+		return fs, false
+	}
+	for j := range fs {
+		fs[j] = math.MaxUint64
+	}
+	var h uint64
+	for i, b := range data {
+		h = h<<1 + gearTable[b]
+		if i < featureWindow {
+			continue
+		}
+		for j := range fs {
+			if v := h*featureMults[j] + featureAdds[j]; v < fs[j] {
+				fs[j] = v
+			}
+		}
+	}
+	return fs, true
+}
+
+// deltaBaseIndex finds chunks that the destination of a push already has, which are similar to a chunk that's
+// pushed, so that it can be sent as a delta against the similar chunk.
+type deltaBaseIndex struct {
+	src      chunks.ChunkStore
+	features [deltaFeatures]map[uint64]hash.Hash
+}
+
+// newDeltaBaseIndex indexes the chunks |haves| in |src|, which the destination of a push is known to have.
+func newDeltaBaseIndex(ctx context.Context, src chunks.ChunkStore, haves hash.HashSet) (*deltaBaseIndex, error) {
+	idx := &deltaBaseIndex{src: src}
+	for i := range idx.features {
+		idx.features[i] = make(map[uint64]hash.Hash)
+	}
+	var mu sync.Mutex
+	err := src.GetMany(ctx, haves, func(_ context.Context, c *chunks.Chunk) {
+		fs, ok := chunkFeatures(c.Data())
+		if !ok {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for i, f := range fs {
+			idx.features[i][f] = c.Hash()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// encode returns |chk| encoded as a delta against the base chunk most similar to it, if that's smaller than
+// |size|, the size of the chunk as it would otherwise be sent.
+func (idx *deltaBaseIndex) encode(ctx context.Context, chk chunks.Chunk, size int) (base hash.Hash, d []byte, ok bool, err error) {
+	fs, ok := chunkFeatures(chk.Data())
+	if !ok {
+		return hash.Hash{}, nil, false, nil
+	}
+
+	var candidates [deltaFeatures]hash.Hash
+	var matches [deltaFeatures]int
+	for i, f := range fs {
+		h, ok := idx.features[i][f]
+		if !ok || h == chk.Hash() {
+			continue
+		}
+		for j := range candidates {
+			if matches[j] == 0 || candidates[j] == h {
+				candidates[j] = h
+				matches[j]++
+				break
+			}
+		}
+	}
+	best := 0
+	for j := range matches {
+		if matches[j] > matches[best] {
+			best = j
+		}
+	}
+	if matches[best] == 0 {
+		return hash.Hash{}, nil, false, nil
+	}
+
+	b, err := idx.src.Get(ctx, candidates[best])
+	if err != nil {
+		return hash.Hash{}, nil, false, err
+	}
+	if b.IsEmpty() {
+		return hash.Hash{}, nil, false, nil
+	}
+	d = delta.Encode(b.Data(), chk.Data())
+	if len(d)+hash.ByteLen >= size {
+		return hash.Hash{}, nil, false, nil
+	}
+	return candidates[best], d, true, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/delta"
+)
+
+func TestDeltaBaseIndex(t *testing.T) {
+	ctx := context.Background()
+	cs := (&chunks.MemoryStorage{}).NewView()
+	rng := rand.New(rand.NewSource(0))
+	randBytes := func(n int) []byte {
+		bs := make([]byte, n)
+		rng.Read(bs)
+		return bs
+	}
+
+	var bases []chunks.Chunk
+	haves := hash.NewHashSet()
+	for i := 0; i < 32; i++ {
+		c := chunks.NewChunk(randBytes(4096))
+		require.NoError(t, cs.Put(ctx, c, noopGetAddrs))
+		bases = append(bases, c)
+		haves.Insert(c.Hash())
+	}
+	idx, err := newDeltaBaseIndex(ctx, cs, haves)
+	require.NoError(t, err)
+
+	for _, b := range bases {
+		edited := append([]byte{}, b.Data()...)
+		copy(edited[rng.Intn(4000):], "an updated column value")
+		chk := chunks.NewChunk(edited)
+
+		base, d, ok, err := idx.encode(ctx, chk, len(edited))
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, b.Hash(), base)
+		assert.Less(t, len(d), 128)
+		actual, err := delta.Apply(b.Data(), d)
+		require.NoError(t, err)
+		assert.Equal(t, edited, actual)
+	}
+
+	for _, bs := range [][]byte{randBytes(4096), bases[0].Data()[:minDeltaChunkSize-1]} {
+		_, _, ok, err := idx.encode(ctx, chunks.NewChunk(bs), len(bs))
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
+
+func noopGetAddrs(c chunks.Chunk) chunks.GetAddrsCb {
+	return func(context.Context, hash.HashSet, chunks.PendingRefExists) error {
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// NegotiateHaves returns the addresses of chunks that a sink is known to have, given |frontier|, the addresses of
+// chunks in |srcCS| that the sink advertised, e.g. the commits at the heads of its branches. A sink which has a
+// chunk has every chunk reachable from it, so a Puller doesn't need to ask the sink whether it has any of the
+// returned addresses.
+//
+// The chunks reachable from |frontier| are walked breadth first in |srcCS|, so that the chunks shared by the most
+// data, e.g. the root values and tables of the frontier commits, are found first. Addresses in |boundary|, e.g.
+// the parents of the frontier commits, are not walked. At most |budget| addresses are returned.
+func NegotiateHaves(ctx context.Context, srcCS chunks.ChunkStore, walkAddrs WalkAddrs, frontier []hash.Hash, boundary hash.HashSet, budget int) (hash.HashSet, error) {
+	haves := make(hash.HashSet)
+	level := make(hash.HashSet)
+	for _, h := range frontier {
+		if len(haves) >= budget {
+			break
+		}
+		haves.Insert(h)
+		level.Insert(h)
+	}
+
+	var mu sync.Mutex
+	var walkErr error
+	for len(level) > 0 && len(haves) < budget {
+		next := make(hash.HashSet)
+		err := srcCS.GetMany(ctx, level, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			if walkErr != nil {
+				return
+			}
+			walkErr = walkAddrs(*c, func(h hash.Hash, _ bool) error {
+				if len(haves) >= budget || haves.Has(h) || boundary.Has(h) {
+					return nil
+				}
+				haves.Insert(h)
+				next.Insert(h)
+				return nil
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if walkErr != nil {
+			return nil, walkErr
+		}
+		level = next
+	}
+	return haves, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// testGraph stores chunks whose data is a name followed by the addresses of their children.
+type testGraph struct {
+	cs    chunks.ChunkStore
+	addrs map[string]hash.Hash
+}
+
+func newTestGraph() *testGraph {
+	storage := &chunks.MemoryStorage{}
+	return &testGraph{cs: storage.NewView(), addrs: make(map[string]hash.Hash)}
+}
+
+func (g *testGraph) put(t *testing.T, name string, children ...string) {
+	data := append([]byte{byte(len(name))}, name...)
+	for _, c := range children {
+		addr := g.addrs[c]
+		data = append(data, addr[:]...)
+	}
+	chk := chunks.NewChunk(data)
+	err := g.cs.Put(context.Background(), chk, func(c chunks.Chunk) chunks.GetAddrsCb {
+		return func(ctx context.Context, addrs hash.HashSet, _ chunks.PendingRefExists) error {
+			return testGraphWalkAddrs(c, func(h hash.Hash, _ bool) error {
+				addrs.Insert(h)
+				return nil
+			})
+		}
+	})
+	require.NoError(t, err)
+	g.addrs[name] = chk.Hash()
+}
+
+func (g *testGraph) set(names ...string) hash.HashSet {
+	hs := make(hash.HashSet)
+	for _, n := range names {
+		hs.Insert(g.addrs[n])
+	}
+	return hs
+}
+
+func testGraphWalkAddrs(c chunks.Chunk, cb func(hash.Hash, bool) error) error {
+	data := c.Data()
+	for data = data[1+int(data[0]):]; len(data) > 0; data = data[hash.ByteLen:] {
+		if err := cb(hash.New(data[:hash.ByteLen]), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestNegotiateHaves(t *testing.T) {
+	ctx := context.Background()
+	g := newTestGraph()
+	// parent <- head -> root -> (table1 -> (leaf1, leaf2), table2 -> leaf3)
+	g.put(t, "parent root")
+	g.put(t, "parent", "parent root")
+	g.put(t, "leaf1")
+	g.put(t, "leaf2")
+	g.put(t, "leaf3")
+	g.put(t, "table1", "leaf1", "leaf2")
+	g.put(t, "table2", "leaf3")
+	g.put(t, "root", "table1", "table2")
+	g.put(t, "head", "parent", "root")
+
+	t.Run("walks the frontier", func(t *testing.T) {
+		haves, err := NegotiateHaves(ctx, g.cs, testGraphWalkAddrs, []hash.Hash{g.addrs["head"]}, g.set("parent"), 100)
+		require.NoError(t, err)
+		assert.Equal(t, g.set("head", "root", "table1", "table2", "leaf1", "leaf2", "leaf3"), haves)
+	})
+
+	t.Run("breadth first within the budget", func(t *testing.T) {
+		haves, err := NegotiateHaves(ctx, g.cs, testGraphWalkAddrs, []hash.Hash{g.addrs["head"]}, g.set("parent"), 4)
+		require.NoError(t, err)
+		assert.Equal(t, g.set("head", "root", "table1", "table2"), haves)
+	})
+
+	t.Run("no boundary", func(t *testing.T) {
+		haves, err := NegotiateHaves(ctx, g.cs, testGraphWalkAddrs, []hash.Hash{g.addrs["table2"]}, nil, 100)
+		require.NoError(t, err)
+		assert.Equal(t, g.set("table2", "leaf3"), haves)
+	})
+
+	t.Run("empty frontier", func(t *testing.T) {
+		haves, err := NegotiateHaves(ctx, g.cs, testGraphWalkAddrs, nil, nil, 100)
+		require.NoError(t, err)
+		assert.Empty(t, haves)
+	})
+}
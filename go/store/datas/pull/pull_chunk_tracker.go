@@ -31,6 +31,10 @@ type TrackerConfig struct {
 	BatchSize int
 
 	HasManyer HasManyer
+
+	// Haves are addresses which are known to be in the destination database, e.g. from NegotiateHaves. They are
+	// never fetched and are not checked with |HasManyer|.
+	Haves hash.HashSet
 }
 
 const hasManyThreadCount = 3
@@ -75,7 +79,7 @@ func (t *PullChunkTracker) Run(ctx context.Context, initial hash.HashSet) error
 }
 
 func (t *PullChunkTracker) Seen(ctx context.Context, h hash.Hash) {
-	if !t.seen.Has(h) {
+	if !t.seen.Has(h) && !t.cfg.Haves.Has(h) {
 		t.seen.Insert(h)
 		t.addUnchecked(ctx, h)
 	}
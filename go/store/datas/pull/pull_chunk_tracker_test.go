@@ -126,6 +126,51 @@ func TestPullChunkTracker(t *testing.T) {
 		assert.NoError(t, eg.Wait())
 	})
 
+	t.Run("Haves", func(t *testing.T) {
+		eg, ctx := errgroup.WithContext(context.Background())
+		haves := make(hash.HashSet)
+		for i := byte(1); i <= byte(5); i++ {
+			var h hash.Hash
+			h[1] = i
+			haves.Insert(h)
+		}
+		tracker := NewPullChunkTracker(TrackerConfig{
+			BatchSize: 64 * 1024,
+			HasManyer: hasNoneHaser{},
+			Haves:     haves,
+		})
+		eg.Go(func() error {
+			return tracker.Run(ctx, make(hash.HashSet))
+		})
+		eg.Go(func() error {
+			// the first five addresses are known to be in the destination
+			for i := byte(1); i <= byte(10); i++ {
+				var h hash.Hash
+				h[1] = i
+				tracker.Seen(ctx, h)
+			}
+
+			cnt := 0
+			for {
+				hs, ok, err := tracker.GetChunksToFetch(ctx)
+				assert.NoError(t, err)
+				if !ok {
+					break
+				}
+				for h := range hs {
+					assert.False(t, haves.Has(h))
+					cnt++
+					tracker.TickProcessed(ctx)
+				}
+			}
+			assert.Equal(t, 5, cnt)
+
+			tracker.Close()
+			return nil
+		})
+		assert.NoError(t, eg.Wait())
+	})
+
 	t.Run("HasManyError", func(t *testing.T) {
 		eg, ctx := errgroup.WithContext(context.Background())
 		hs := make(hash.HashSet)
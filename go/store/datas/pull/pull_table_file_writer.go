@@ -16,6 +16,7 @@ package pull
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -25,6 +26,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
@@ -37,9 +39,9 @@ import (
 // * Number of concurrent table file uploads.
 // * Number of pending table files awaiting upload.
 //
-// For the last configuration point, the basic observation is that pushes are
-// not currently resumable across `dolt push`/`call dolt_push` invocations.  It
-// is not necessarily in a user's best interest to buffer lots and lots of
+// For the last configuration point, the basic observation is that pushes only
+// resume across `dolt push`/`call dolt_push` invocations at the granularity of
+// uploaded table files, see |pushState|. It is not necessarily in a user's best interest to buffer lots and lots of
 // table files to the local disk while a user awaits the upload of the existing
 // buffered table files to the remote database. In the worst case, it can cause
 // 2x disk utilization on a pushing host, which is not what the user expects.
@@ -56,8 +58,8 @@ import (
 type PullTableFileWriter struct {
 	cfg PullTableFileWriterConfig
 
-	addChunkCh  chan nbs.ToChunker
-	newWriterCh chan nbs.GenericTableWriter
+	addChunkCh  chan pullChunk
+	newWriterCh chan pendingTableFile
 	doneCh      chan struct{}
 
 	getAddrs chunks.GetAddrsCurry
@@ -78,9 +80,24 @@ type PullTableFileWriterConfig struct {
 
 	TempDir string
 
+	// If true, a delta file is written along with each table
+	// file, and it's uploaded instead of the table file when
+	// it's smaller. See |nbs.DeltaFileSuffix|.
+	DeltaChunks bool
+
 	DestStore DestTableFileStore
 
 	GetAddrs chunks.GetAddrsCurry
+
+	// If non-nil, called with the id, the chunk count and the chunk
+	// addresses of each table file once it has been uploaded to
+	// DestStore, before it's added to DestStore's manifest.
+	OnUpload func(id string, numChunks int, addrs []hash.Hash) error
+
+	// Table files which were uploaded to DestStore by a previous
+	// attempt, and which are added to its manifest along with the
+	// table files uploaded by this writer.
+	Uploaded map[string]int
 }
 
 type DestTableFileStore interface {
@@ -103,8 +120,8 @@ type PullTableFileWriterStats struct {
 func NewPullTableFileWriter(cfg PullTableFileWriterConfig) *PullTableFileWriter {
 	ret := &PullTableFileWriter{
 		cfg:         cfg,
-		addChunkCh:  make(chan nbs.ToChunker),
-		newWriterCh: make(chan nbs.GenericTableWriter, cfg.MaximumBufferedFiles),
+		addChunkCh:  make(chan pullChunk),
+		newWriterCh: make(chan pendingTableFile, cfg.MaximumBufferedFiles),
 		doneCh:      make(chan struct{}),
 		getAddrs:    cfg.GetAddrs,
 	}
@@ -139,14 +156,43 @@ func (w *PullTableFileWriter) GetStats() PullTableFileWriterStats {
 // lot of buffered table files and we are waiting for uploads to succeed before
 // creating more table files.
 func (w *PullTableFileWriter) AddToChunker(ctx context.Context, chk nbs.ToChunker) error {
+	return w.addChunk(ctx, pullChunk{chk: chk})
+}
+
+// AddDeltaToChunker adds |chk| like AddToChunker, along with |d|, its
+// delta against the chunk |base| which the destination already has.
+// The delta is only sent if the writer is configured with DeltaChunks.
+func (w *PullTableFileWriter) AddDeltaToChunker(ctx context.Context, chk nbs.ToChunker, base hash.Hash, d []byte) error {
+	return w.addChunk(ctx, pullChunk{chk: chk, base: base, delta: d})
+}
+
+func (w *PullTableFileWriter) addChunk(ctx context.Context, pc pullChunk) error {
 	select {
-	case w.addChunkCh <- chk:
+	case w.addChunkCh <- pc:
 		return nil
 	case <-ctx.Done():
 		return context.Cause(ctx)
 	}
 }
 
+// A chunk to add to a table file, and optionally its delta
+// against a |base| chunk.
+type pullChunk struct {
+	chk   nbs.ToChunker
+	base  hash.Hash
+	delta []byte
+}
+
+// A table file waiting to be uploaded, and the delta file with
+// the same chunks, if the writer is configured with DeltaChunks.
+type pendingTableFile struct {
+	wr    nbs.GenericTableWriter
+	delta *nbs.DeltaFileWriter
+	// The addresses of the chunks in |wr|, if the writer is
+	// configured with OnUpload.
+	addrs []hash.Hash
+}
+
 func (w *PullTableFileWriter) uploadFilesAndAccumulateUpdates(ctx context.Context) (map[string]int, error) {
 	// respCh is where upload threads send responses. These
 	// get built into a manifest update which gets sent to
@@ -178,6 +224,13 @@ func (w *PullTableFileWriter) uploadFilesAndAccumulateUpdates(ctx context.Contex
 			if strings.HasSuffix(id, nbs.ArchiveFileSuffix) {
 				id = strings.TrimSuffix(id, nbs.ArchiveFileSuffix)
 			}
+			id = strings.TrimSuffix(id, nbs.DeltaFileSuffix)
+
+			if w.cfg.OnUpload != nil {
+				if err := w.cfg.OnUpload(id, ttf.numChunks, ttf.addrs); err != nil {
+					return err
+				}
+			}
 
 			manifestUpdates[id] = ttf.numChunks
 		}
@@ -198,10 +251,17 @@ func (w *PullTableFileWriter) uploadAndFinalizeThread(ctx context.Context) error
 	if err != nil {
 		return err
 	}
+	for id, numChunks := range w.cfg.Uploaded {
+		updates[id] = numChunks
+	}
 	if len(updates) == 0 {
 		return nil
 	}
-	return w.cfg.DestStore.AddTableFilesToManifest(ctx, updates, w.getAddrs)
+	err = w.cfg.DestStore.AddTableFilesToManifest(ctx, updates, w.getAddrs)
+	if err != nil && len(w.cfg.Uploaded) > 0 {
+		return fmt.Errorf("%w: %w", errAddTableFiles, err)
+	}
+	return err
 }
 
 // This thread reads from addChunkCh and writes the chunks to table files.
@@ -213,6 +273,8 @@ func (w *PullTableFileWriter) uploadAndFinalizeThread(ctx context.Context) error
 // closes newWriterCh and exits itself.
 func (w *PullTableFileWriter) addChunkThread(ctx context.Context) (err error) {
 	var curWr nbs.GenericTableWriter
+	var curDelta *nbs.DeltaFileWriter
+	var curAddrs []hash.Hash
 	var curBytes uint64
 
 	defer func() {
@@ -224,6 +286,9 @@ func (w *PullTableFileWriter) addChunkThread(ctx context.Context) (err error) {
 				rd.Close()
 			}
 		}
+		if curDelta != nil {
+			_ = curDelta.Remove()
+		}
 	}()
 
 	estimatedFooterSize := func(chunkCnt int) uint64 {
@@ -239,8 +304,10 @@ func (w *PullTableFileWriter) addChunkThread(ctx context.Context) (err error) {
 		select {
 		case <-ctx.Done():
 			return context.Cause(ctx)
-		case w.newWriterCh <- curWr:
+		case w.newWriterCh <- pendingTableFile{wr: curWr, delta: curDelta, addrs: curAddrs}:
 			curWr = nil
+			curDelta = nil
+			curAddrs = nil
 			curBytes = 0
 			return nil
 		}
@@ -258,7 +325,7 @@ LOOP:
 		select {
 		case <-ctx.Done():
 			return context.Cause(ctx)
-		case newChnk, ok := <-w.addChunkCh:
+		case pc, ok := <-w.addChunkCh:
 			if !ok {
 				break LOOP
 			}
@@ -273,14 +340,36 @@ LOOP:
 					curWr = nil
 					return err
 				}
+				// delta files are re-materialized as table files, not archives
+				if _, ok := curWr.(*nbs.CmpChunkTableWriter); ok && w.cfg.DeltaChunks {
+					curDelta, err = nbs.NewDeltaFileWriter(w.cfg.TempDir)
+					if err != nil {
+						return err
+					}
+				}
 			}
 
 			// Add the chunk to writer.
-			bytes, err := curWr.AddChunk(newChnk)
+			bytes, err := curWr.AddChunk(pc.chk)
 			if err != nil {
 				return err
 			}
 
+			if curDelta != nil {
+				if pc.delta != nil {
+					err = curDelta.AddDelta(pc.chk.Hash(), pc.base, pc.delta)
+				} else {
+					err = curDelta.AddChunk(pc.chk)
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			if w.cfg.OnUpload != nil {
+				curAddrs = append(curAddrs, pc.chk.Hash())
+			}
+
 			curBytes += uint64(bytes)
 
 			atomic.AddUint64(&w.bufferedSendBytes, uint64(bytes))
@@ -308,13 +397,14 @@ func (w *PullTableFileWriter) Close() {
 	<-w.doneCh
 }
 
-func (w *PullTableFileWriter) uploadThread(ctx context.Context, reqCh chan nbs.GenericTableWriter, respCh chan tempTblFile) error {
+func (w *PullTableFileWriter) uploadThread(ctx context.Context, reqCh chan pendingTableFile, respCh chan tempTblFile) error {
 	for {
 		select {
-		case wr, ok := <-reqCh:
+		case pending, ok := <-reqCh:
 			if !ok {
 				return nil
 			}
+			wr := pending.wr
 
 			_, id, err := wr.Finish()
 			if err != nil {
@@ -333,11 +423,27 @@ func (w *PullTableFileWriter) uploadThread(ctx context.Context, reqCh chan nbs.G
 				chunksLen:   chunkData,
 				contentLen:  wr.FullLength(),
 				contentHash: wr.GetMD5(),
+				addrs:       pending.addrs,
+			}
+			if pending.delta != nil {
+				err = pending.delta.Finish()
+				if err == nil && pending.delta.Size() < ttf.contentLen {
+					// The delta file is re-materialized as table file |id| by the destination.
+					ttf.id = id + nbs.DeltaFileSuffix
+					ttf.read = pending.delta
+					ttf.contentLen = pending.delta.Size()
+					ttf.contentHash = pending.delta.GetMD5()
+				}
+			}
+			if err == nil {
+				err = w.uploadTempTableFile(ctx, ttf)
 			}
-			err = w.uploadTempTableFile(ctx, ttf)
 
 			// Always remove the file...
 			wr.Remove()
+			if pending.delta != nil {
+				pending.delta.Remove()
+			}
 
 			if err != nil {
 				return err
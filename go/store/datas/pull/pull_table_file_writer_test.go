@@ -19,14 +19,18 @@ import (
 	"crypto/rand"
 	"errors"
 	"io"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 )
 
@@ -340,6 +344,79 @@ func TestPullTableFileWriter(t *testing.T) {
 		wr.Close()
 		assert.NoError(t, eg.Wait())
 	})
+
+	t.Run("DeltaChunks", func(t *testing.T) {
+		for _, deltaChunks := range []bool{false, true} {
+			var s noopTableFileDestStore
+			wr := NewPullTableFileWriter(PullTableFileWriterConfig{
+				ConcurrentUploads:    1,
+				TargetFileSize:       1 << 20,
+				MaximumBufferedFiles: 1,
+				TempDir:              t.TempDir(),
+				DeltaChunks:          deltaChunks,
+				DestStore:            &s,
+			})
+			eg, ctx := errgroup.WithContext(context.Background())
+			eg.Go(func() error {
+				return wr.Run(ctx)
+			})
+
+			for i := 0; i < 32; i++ {
+				bs := make([]byte, 1024)
+				_, err := rand.Read(bs)
+				assert.NoError(t, err)
+				cChk := nbs.ChunkToCompressedChunk(chunks.NewChunk(bs))
+				err = wr.AddDeltaToChunker(ctx, cChk, hash.Of([]byte("base")), []byte("delta"))
+				assert.NoError(t, err)
+			}
+
+			wr.Close()
+			assert.NoError(t, eg.Wait())
+			require.Len(t, s.ids, 1)
+			// the delta file is smaller, and replaces the table file in the upload but not in the manifest
+			assert.Equal(t, deltaChunks, strings.HasSuffix(s.ids[0], nbs.DeltaFileSuffix))
+			assert.Equal(t, map[string]int{strings.TrimSuffix(s.ids[0], nbs.DeltaFileSuffix): 32}, s.manifest)
+		}
+	})
+
+	t.Run("ResumeUploads", func(t *testing.T) {
+		var s noopTableFileDestStore
+		var recorded []hash.Hash
+		wr := NewPullTableFileWriter(PullTableFileWriterConfig{
+			ConcurrentUploads:    1,
+			TargetFileSize:       1 << 20,
+			MaximumBufferedFiles: 1,
+			TempDir:              t.TempDir(),
+			DestStore:            &s,
+			OnUpload: func(id string, numChunks int, addrs []hash.Hash) error {
+				assert.Len(t, addrs, numChunks)
+				recorded = append(recorded, addrs...)
+				return nil
+			},
+			Uploaded: map[string]int{"previous": 7},
+		})
+		eg, ctx := errgroup.WithContext(context.Background())
+		eg.Go(func() error {
+			return wr.Run(ctx)
+		})
+
+		var added []hash.Hash
+		for i := 0; i < 8; i++ {
+			bs := make([]byte, 1024)
+			_, err := rand.Read(bs)
+			assert.NoError(t, err)
+			cChk := nbs.ChunkToCompressedChunk(chunks.NewChunk(bs))
+			added = append(added, cChk.Hash())
+			assert.NoError(t, wr.AddToChunker(ctx, cChk))
+		}
+
+		wr.Close()
+		assert.NoError(t, eg.Wait())
+		require.Len(t, s.ids, 1)
+		assert.Equal(t, added, recorded)
+		// table files uploaded by a previous attempt are added to the manifest with the new ones
+		assert.Equal(t, map[string]int{s.ids[0]: 8, "previous": 7}, s.manifest)
+	})
 }
 
 type noopTableFileDestStore struct {
@@ -347,6 +424,9 @@ type noopTableFileDestStore struct {
 	writeCalled atomic.Uint32
 	addCalled   int
 	manifest    map[string]int
+
+	mu  sync.Mutex
+	ids []string
 }
 
 func (s *noopTableFileDestStore) WriteTableFile(ctx context.Context, id string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
@@ -354,6 +434,9 @@ func (s *noopTableFileDestStore) WriteTableFile(ctx context.Context, id string,
 		time.Sleep(s.writeDelay)
 	}
 	s.writeCalled.Add(1)
+	s.mu.Lock()
+	s.ids = append(s.ids, id)
+	s.mu.Unlock()
 	rd, _, _ := getRd()
 	if rd != nil {
 		rd.Close()
@@ -52,6 +52,9 @@ type Puller struct {
 	srcChunkStore nbs.NBSCompressedChunkStore
 	sinkDBCS      chunks.ChunkStore
 	hashes        hash.HashSet
+	haves         hash.HashSet
+	deltas        *deltaBaseIndex
+	state         *pushState
 
 	wr *PullTableFileWriter
 
@@ -62,7 +65,10 @@ type Puller struct {
 }
 
 // NewPuller creates a new Puller instance to do the syncing.  If a nil puller is returned without error that means
-// that there is nothing to pull and the sinkDB is already up to date.
+// that there is nothing to pull and the sinkDB is already up to date. |haves| are addresses known to be in the
+// sinkDB, which are not checked or pulled, and may be nil. If DOLT_PUSH_DELTA_CHUNKS is set, chunks similar to
+// |haves| are sent to the sinkDB as deltas against them. The table files uploaded to the sinkDB are recorded in
+// |tempDir|, so that a failed pull of the same |hashes| resumes without uploading them again.
 func NewPuller(
 	ctx context.Context,
 	tempDir string,
@@ -70,6 +76,7 @@ func NewPuller(
 	srcCS, sinkCS chunks.ChunkStore,
 	walkAddrs WalkAddrs,
 	hashes []hash.Hash,
+	haves hash.HashSet,
 	statsCh chan Stats,
 ) (*Puller, error) {
 	// Sanity Check
@@ -112,14 +119,33 @@ func NewPuller(
 		}
 	}
 
-	wr := NewPullTableFileWriter(PullTableFileWriterConfig{
+	var deltas *deltaBaseIndex
+	if pushDeltaChunks() && haves.Size() > 0 {
+		deltas, err = newDeltaBaseIndex(ctx, srcCS, haves)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := openPushState(tempDir, sinkCS, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	wrCfg := PullTableFileWriterConfig{
 		ConcurrentUploads:    2,
 		TargetFileSize:       targetFileSz,
 		MaximumBufferedFiles: 8,
 		TempDir:              tempDir,
+		DeltaChunks:          deltas != nil,
 		DestStore:            sinkCS.(chunks.TableFileStore),
 		GetAddrs:             getAddrs,
-	})
+	}
+	if state != nil {
+		wrCfg.OnUpload = state.record
+		wrCfg.Uploaded = state.uploaded()
+	}
+	wr := NewPullTableFileWriter(wrCfg)
 
 	var pushLogger *log.Logger
 	if dbg, ok := os.LookupEnv(dconfig.EnvPushLog); ok && strings.EqualFold(dbg, "true") {
@@ -136,6 +162,9 @@ func NewPuller(
 		srcChunkStore: srcChunkStore,
 		sinkDBCS:      sinkCS,
 		hashes:        hash.NewHashSet(hashes...),
+		haves:         haves,
+		deltas:        deltas,
+		state:         state,
 		wr:            wr,
 		pushLog:       pushLogger,
 		statsCh:       statsCh,
@@ -151,6 +180,13 @@ func NewPuller(
 	return p, nil
 }
 
+// pushDeltaChunks returns true if DOLT_PUSH_DELTA_CHUNKS is set. The destination of a push must be able to
+// re-materialize delta files, so it's disabled by default.
+func pushDeltaChunks() bool {
+	v := os.Getenv(dconfig.EnvPushDeltaChunks)
+	return v != "" && v != "0" && !strings.EqualFold(v, "false")
+}
+
 func (p *Puller) Logf(fmt string, args ...interface{}) {
 	if p.pushLog != nil {
 		p.pushLog.Printf(fmt, args...)
@@ -169,6 +205,7 @@ type tempTblFile struct {
 	chunksLen   uint64
 	contentLen  uint64
 	contentHash []byte
+	addrs       []hash.Hash
 }
 
 type countingReader struct {
@@ -307,6 +344,20 @@ func (p *Puller) Pull(ctx context.Context) error {
 		defer c()
 	}
 
+	err := p.pull(ctx)
+	if p.state != nil {
+		if err == nil || errors.Is(err, errAddTableFiles) {
+			// the table files in the state were added to the sinkDB, or they
+			// may no longer be in it, so a retry must start from scratch
+			err = errors.Join(err, p.state.remove())
+		} else {
+			err = errors.Join(err, p.state.close())
+		}
+	}
+	return err
+}
+
+func (p *Puller) pull(ctx context.Context) error {
 	eg, ctx := errgroup.WithContext(ctx)
 
 	rd := GetChunkFetcher(ctx, p.srcChunkStore)
@@ -315,6 +366,7 @@ func (p *Puller) Pull(ctx context.Context) error {
 	tracker := NewPullChunkTracker(TrackerConfig{
 		BatchSize: batchSize,
 		HasManyer: p.sinkDBCS,
+		Haves:     p.haves,
 	})
 
 	eg.Go(func() error {
@@ -398,7 +450,12 @@ func (p *Puller) Pull(ctx context.Context) error {
 			}
 			tracker.TickProcessed(ctx)
 
-			err = p.wr.AddToChunker(ctx, cChk)
+			if p.state != nil && p.state.has(chnk.Hash()) {
+				// uploaded by a previous attempt
+				continue
+			}
+
+			err = p.addToChunker(ctx, cChk, chnk)
 			if err != nil {
 				return err
 			}
@@ -407,3 +464,24 @@ func (p *Puller) Pull(ctx context.Context) error {
 
 	return eg.Wait()
 }
+
+// addToChunker adds |cChk| to the table file writer, along with its delta against a chunk the sinkDB has, if
+// it's smaller. |chnk| is |cChk| uncompressed.
+func (p *Puller) addToChunker(ctx context.Context, cChk nbs.ToChunker, chnk chunks.Chunk) error {
+	if p.deltas == nil {
+		return p.wr.AddToChunker(ctx, cChk)
+	}
+
+	size := len(chnk.Data())
+	if cc, ok := cChk.(nbs.CompressedChunk); ok {
+		size = len(cc.FullCompressedChunk)
+	}
+	base, d, ok, err := p.deltas.encode(ctx, chnk, size)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return p.wr.AddToChunker(ctx, cChk)
+	}
+	return p.wr.AddDeltaToChunker(ctx, cChk, base, d)
+}
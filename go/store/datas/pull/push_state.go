@@ -0,0 +1,240 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	pushStateFilePrefix = "push_state_"
+	pushStateFileSuffix = ".jsonl"
+
+	// pushStateMaxAge is how long the table files recorded in a push
+	// state are trusted to still be in the destination, which removes
+	// table files that are not in its manifest when it's garbage
+	// collected.
+	pushStateMaxAge = 24 * time.Hour
+)
+
+// errAddTableFiles wraps a failure to add table files, including those
+// recorded in a push state, to the manifest of the destination.
+var errAddTableFiles = errors.New("failed to add table files to the manifest of the destination")
+
+// A pushState records the table files a Puller has uploaded to its
+// destination, along with the addresses of their chunks, so that a failed
+// push or pull of the same hashes to the same destination can resume from
+// where it left off. Uploaded table files are not added to the manifest of
+// the destination until every table file has been uploaded, so a retry
+// fetches and walks the chunks in them again, but does not upload them
+// again, and adds them to the manifest along with the table files it
+// uploads.
+//
+// The state is kept in a file in the temp table file directory, keyed by
+// the destination and the target hashes. The first line of the file is a
+// |pushStateHeader| and every other line is a |pushStateTableFile|, which
+// is appended and synced once the table file is uploaded. A partially
+// written last line is ignored.
+type pushState struct {
+	path string
+
+	mu    sync.Mutex
+	f     *os.File
+	files map[string]int
+	addrs hash.HashSet
+}
+
+type pushStateHeader struct {
+	Dest    string    `json:"dest"`
+	Targets []string  `json:"targets"`
+	Created time.Time `json:"created"`
+}
+
+type pushStateTableFile struct {
+	Id        string   `json:"id"`
+	NumChunks int      `json:"num_chunks"`
+	Addrs     []string `json:"addrs"`
+}
+
+// openPushState opens the push state for pushing |targets| to |dest|
+// from |tempDir|, creating it if there isn't one. It returns nil if |dest|
+// has no stable location to key the state by.
+func openPushState(tempDir string, dest chunks.ChunkStore, targets []hash.Hash) (*pushState, error) {
+	pather, ok := dest.(interface{ Path() (string, bool) })
+	if !ok {
+		return nil, nil
+	}
+	destPath, ok := pather.Path()
+	if !ok || tempDir == "" {
+		return nil, nil
+	}
+
+	hdr := pushStateHeader{Dest: destPath, Created: time.Now().UTC()}
+	for _, h := range targets {
+		hdr.Targets = append(hdr.Targets, h.String())
+	}
+	sort.Strings(hdr.Targets)
+	key := hash.Of([]byte(hdr.Dest + "\n" + strings.Join(hdr.Targets, "\n")))
+
+	if err := os.MkdirAll(tempDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	removeExpiredPushStates(tempDir)
+
+	s := &pushState{
+		path:  filepath.Join(tempDir, pushStateFilePrefix+key.String()+pushStateFileSuffix),
+		files: make(map[string]int),
+		addrs: make(hash.HashSet),
+	}
+	valid, resumed, err := s.load(hdr)
+	if err != nil {
+		return nil, err
+	}
+	if resumed {
+		if err = os.Truncate(s.path, valid); err != nil {
+			return nil, err
+		}
+		s.f, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	s.files = make(map[string]int)
+	s.addrs = make(hash.HashSet)
+
+	s.f, err = os.OpenFile(s.path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.writeLine(hdr); err != nil {
+		s.f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads the table files recorded in the state file, if it exists
+// and it is for the same push as |hdr|. It returns the length of the
+// complete lines in the file.
+func (s *pushState) load(hdr pushStateHeader) (int64, bool, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+
+	var existing pushStateHeader
+	i := bytes.IndexByte(data, '\n')
+	if i < 0 || json.Unmarshal(data[:i], &existing) != nil {
+		return 0, false, nil
+	}
+	if existing.Dest != hdr.Dest ||
+		strings.Join(existing.Targets, ",") != strings.Join(hdr.Targets, ",") ||
+		time.Since(existing.Created) > pushStateMaxAge {
+		return 0, false, nil
+	}
+
+	valid := i + 1
+	for {
+		i = bytes.IndexByte(data[valid:], '\n')
+		if i < 0 {
+			// a partially written last line
+			break
+		}
+		var tf pushStateTableFile
+		if err := json.Unmarshal(data[valid:valid+i], &tf); err != nil {
+			return 0, false, nil
+		}
+		for _, a := range tf.Addrs {
+			h, ok := hash.MaybeParse(a)
+			if !ok {
+				return 0, false, nil
+			}
+			s.addrs.Insert(h)
+		}
+		s.files[tf.Id] = tf.NumChunks
+		valid += i + 1
+	}
+	return int64(valid), true, nil
+}
+
+// removeExpiredPushStates removes the state files of pushes which were
+// abandoned long enough ago that they can't be resumed.
+func removeExpiredPushStates(tempDir string) {
+	paths, _ := filepath.Glob(filepath.Join(tempDir, pushStateFilePrefix+"*"+pushStateFileSuffix))
+	for _, p := range paths {
+		if fi, err := os.Stat(p); err == nil && time.Since(fi.ModTime()) > pushStateMaxAge {
+			_ = os.Remove(p)
+		}
+	}
+}
+
+func (s *pushState) writeLine(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = s.f.Write(append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+// uploaded returns the table files which a previous attempt uploaded.
+func (s *pushState) uploaded() map[string]int {
+	return s.files
+}
+
+// has returns true if |h| is in a table file which a previous attempt
+// uploaded.
+func (s *pushState) has(h hash.Hash) bool {
+	return s.addrs.Has(h)
+}
+
+// record durably records that the table file |id| was uploaded.
+func (s *pushState) record(id string, numChunks int, addrs []hash.Hash) error {
+	tf := pushStateTableFile{Id: id, NumChunks: numChunks, Addrs: make([]string, len(addrs))}
+	for i, a := range addrs {
+		tf.Addrs[i] = a.String()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeLine(tf)
+}
+
+// close closes the state file, keeping it so that a later attempt
+// can resume.
+func (s *pushState) close() error {
+	return s.f.Close()
+}
+
+// remove closes and removes the state file.
+func (s *pushState) remove() error {
+	return errors.Join(s.f.Close(), os.Remove(s.path))
+}
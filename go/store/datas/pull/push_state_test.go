@@ -0,0 +1,136 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pull
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+type pathChunkStore struct {
+	chunks.ChunkStore
+	path string
+}
+
+func (cs pathChunkStore) Path() (string, bool) {
+	return cs.path, true
+}
+
+func TestPushState(t *testing.T) {
+	dest := pathChunkStore{path: "localhost/org/db"}
+	targets := []hash.Hash{hash.Of([]byte("a")), hash.Of([]byte("b"))}
+	addrs := []hash.Hash{hash.Of([]byte("c")), hash.Of([]byte("d"))}
+
+	t.Run("NoPath", func(t *testing.T) {
+		s, err := openPushState(t.TempDir(), (&chunks.MemoryStorage{}).NewView(), targets)
+		require.NoError(t, err)
+		assert.Nil(t, s)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		assert.Empty(t, s.uploaded())
+		require.NoError(t, s.record("file1", 2, addrs))
+		require.NoError(t, s.close())
+
+		// the order of the targets doesn't matter
+		s, err = openPushState(dir, dest, []hash.Hash{targets[1], targets[0]})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"file1": 2}, s.uploaded())
+		assert.True(t, s.has(addrs[0]))
+		assert.True(t, s.has(addrs[1]))
+		assert.False(t, s.has(targets[0]))
+		require.NoError(t, s.record("file2", 0, nil))
+		require.NoError(t, s.close())
+
+		s, err = openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"file1": 2, "file2": 0}, s.uploaded())
+		require.NoError(t, s.remove())
+
+		s, err = openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		assert.Empty(t, s.uploaded())
+		require.NoError(t, s.remove())
+	})
+
+	t.Run("DifferentPush", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		require.NoError(t, s.record("file1", 2, addrs))
+		require.NoError(t, s.close())
+
+		s, err = openPushState(dir, dest, targets[:1])
+		require.NoError(t, err)
+		assert.Empty(t, s.uploaded())
+		require.NoError(t, s.close())
+
+		s, err = openPushState(dir, pathChunkStore{path: "localhost/org/other"}, targets)
+		require.NoError(t, err)
+		assert.Empty(t, s.uploaded())
+		require.NoError(t, s.close())
+	})
+
+	t.Run("PartialLastLine", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		require.NoError(t, s.record("file1", 2, addrs))
+		_, err = s.f.Write([]byte(`{"id":"file2","num_chu`))
+		require.NoError(t, err)
+		require.NoError(t, s.close())
+
+		s, err = openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"file1": 2}, s.uploaded())
+		require.NoError(t, s.record("file3", 0, nil))
+		require.NoError(t, s.close())
+
+		s, err = openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"file1": 2, "file3": 0}, s.uploaded())
+		require.NoError(t, s.close())
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		dir := t.TempDir()
+		s, err := openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		require.NoError(t, s.record("file1", 2, addrs))
+		require.NoError(t, s.close())
+
+		old := time.Now().Add(-2 * pushStateMaxAge)
+		require.NoError(t, os.Chtimes(s.path, old, old))
+		s, err = openPushState(dir, dest, targets)
+		require.NoError(t, err)
+		assert.Empty(t, s.uploaded())
+		require.NoError(t, s.close())
+
+		paths, err := filepath.Glob(filepath.Join(dir, pushStateFilePrefix+"*"))
+		require.NoError(t, err)
+		assert.Len(t, paths, 1)
+	})
+}
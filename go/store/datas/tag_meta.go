@@ -28,6 +28,7 @@ const (
 	tagMetaDescKey      = "desc"
 	tagMetaTimestampKey = "timestamp"
 	tagMetaUserTSKey    = "user_timestamp"
+	tagMetaSignatureKey = "signature"
 	tagMetaVersionKey   = "metaversion"
 
 	tagMetaStName  = "metadata"
@@ -44,6 +45,8 @@ type TagMeta struct {
 	Timestamp     uint64
 	Description   string
 	UserTimestamp int64
+	// Signature is the GPG signature of the tag, or empty if it isn't signed.
+	Signature string
 }
 
 // NewTagMetaWithUserTS returns TagMeta that can be used to create a tag.
@@ -61,7 +64,7 @@ func NewTagMetaWithUserTS(name, email, desc string, userTS time.Time) *TagMeta {
 	ms := uint64(TagNowFunc().UnixMilli())
 	userMS := userTS.UnixMilli()
 
-	return &TagMeta{n, e, ms, d, userMS}
+	return &TagMeta{Name: n, Email: e, Timestamp: ms, Description: d, UserTimestamp: userMS}
 }
 
 func tagMetaFromNomsSt(st types.Struct) (*TagMeta, error) {
@@ -97,12 +100,20 @@ func tagMetaFromNomsSt(st types.Struct) (*TagMeta, error) {
 		userTS = types.Int(int64(uint64(ts.(types.Uint))))
 	}
 
+	var signature string
+	if sig, ok, err := st.MaybeGet(tagMetaSignatureKey); err != nil {
+		return nil, err
+	} else if ok {
+		signature = string(sig.(types.String))
+	}
+
 	return &TagMeta{
-		string(n.(types.String)),
-		string(e.(types.String)),
-		uint64(ts.(types.Uint)),
-		string(d.(types.String)),
-		int64(userTS.(types.Int)),
+		Name:          string(n.(types.String)),
+		Email:         string(e.(types.String)),
+		Timestamp:     uint64(ts.(types.Uint)),
+		Description:   string(d.(types.String)),
+		UserTimestamp: int64(userTS.(types.Int)),
+		Signature:     signature,
 	}, nil
 }
 
@@ -115,6 +126,9 @@ func (tm *TagMeta) toNomsStruct(nbf *types.NomsBinFormat) (types.Struct, error)
 		tagMetaVersionKey:   types.String(tagMetaVersion),
 		commitMetaUserTSKey: types.Int(tm.UserTimestamp),
 	}
+	if tm.Signature != "" {
+		metadata[tagMetaSignatureKey] = types.String(tm.Signature)
+	}
 
 	return types.NewStruct(nbf, tagMetaStName, metadata)
 }
@@ -21,7 +21,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/gen/fb/serial"
 	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -76,6 +78,31 @@ func TestNewTag(t *testing.T) {
 	assertTypeEquals(et, at)
 }
 
+func TestTagFlatbufferSignature(t *testing.T) {
+	addr := hash.Of([]byte("commit"))
+
+	meta := NewTagMeta("name", "name@example.com", "desc")
+	data := tag_flatbuffer(addr, meta)
+	head, err := newSerialTagHead(data, hash.Of(data))
+	require.NoError(t, err)
+	// unsigned tags don't write the signature field, so older versions can still read them
+	assert.LessOrEqual(t, int(head.msg.Table().NumFields()), 6)
+	read, readAddr, err := head.HeadTag()
+	require.NoError(t, err)
+	assert.Equal(t, addr, readAddr)
+	assert.Equal(t, "", read.Signature)
+
+	meta.Signature = "-----BEGIN PGP SIGNATURE-----"
+	data = tag_flatbuffer(addr, meta)
+	head, err = newSerialTagHead(data, hash.Of(data))
+	require.NoError(t, err)
+	assert.Equal(t, serial.TagNumFields, int(head.msg.Table().NumFields()))
+	read, _, err = head.HeadTag()
+	require.NoError(t, err)
+	assert.Equal(t, meta.Signature, read.Signature)
+	assert.Equal(t, meta.Description, read.Description)
+}
+
 func TestPersistedTagConsts(t *testing.T) {
 	// changing constants that are persisted requires a migration strategy
 	assert.Equal(t, "meta", tagMetaField)
@@ -0,0 +1,181 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+// minParallelCompression is the smallest number of chunks that are compressed in parallel. Fewer chunks are
+// compressed on the calling goroutine.
+const minParallelCompression = 64
+
+// compressionSampleInterval is how often the CPU usage of the process is sampled to size the compression pool.
+const compressionSampleInterval = 100 * time.Millisecond
+
+// compressionPool compresses chunks on a bounded number of goroutines, so that compression during large writes
+// doesn't run on a single goroutine, but also doesn't take every CPU away from concurrent queries. The number of
+// goroutines adapts to the CPU usage of the process: when other work keeps the CPUs busy, fewer chunks are compressed
+// at a time.
+type compressionPool struct {
+	// maxWorkers is the maximum number of chunks compressed at a time.
+	maxWorkers int
+	// active is the number of chunks being compressed.
+	active atomic.Int64
+
+	mu         sync.Mutex
+	workers    int
+	lastSample time.Time
+	lastTotal  float64
+	lastCPU    float64
+	samples    []metrics.Sample
+}
+
+var globalCompressionPool = newCompressionPool(compressionThreads())
+
+// compressionThreads returns the maximum number of chunks to compress at a time, which is half the available CPUs
+// unless it's set by DOLT_COMPRESSION_THREADS.
+func compressionThreads() int {
+	threads := max(runtime.GOMAXPROCS(0)/2, 1)
+	if v := os.Getenv(dconfig.EnvCompressionThreads); v != "" {
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 1 {
+			logrus.Warnf("unable to parse a positive integer value for %s from %s", dconfig.EnvCompressionThreads, v)
+		} else {
+			threads = i
+		}
+	}
+	return threads
+}
+
+func newCompressionPool(maxWorkers int) *compressionPool {
+	return &compressionPool{
+		maxWorkers: maxWorkers,
+		workers:    maxWorkers,
+		samples:    []metrics.Sample{{Name: "/cpu/classes/total:cpu-seconds"}, {Name: "/cpu/classes/idle:cpu-seconds"}},
+	}
+}
+
+// parallelism returns the number of chunks that should be compressed at a time, given the CPU usage of the process
+// since it was last sampled.
+func (p *compressionPool) parallelism() int {
+	if p.maxWorkers <= 1 {
+		return 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(p.lastSample) < compressionSampleInterval {
+		return p.workers
+	}
+
+	metrics.Read(p.samples)
+	if p.samples[0].Value.Kind() != metrics.KindFloat64 || p.samples[1].Value.Kind() != metrics.KindFloat64 {
+		return p.workers
+	}
+	total := p.samples[0].Value.Float64()
+	if total == p.lastTotal {
+		// the runtime only updates its CPU estimates periodically
+		return p.workers
+	}
+	busy := total - p.samples[1].Value.Float64()
+	if !p.lastSample.IsZero() {
+		// the CPUs used by work other than compression are not available for compression
+		elapsed := now.Sub(p.lastSample).Seconds()
+		busyProcs := (busy-p.lastCPU)/elapsed - float64(p.active.Load())
+		idleProcs := float64(runtime.GOMAXPROCS(0)) - busyProcs
+		p.workers = min(max(int(idleProcs), 1), p.maxWorkers)
+	}
+	p.lastSample, p.lastTotal, p.lastCPU = now, total, busy
+	return p.workers
+}
+
+// compressChunks compresses |n| chunks returned by |get| and calls |cb| with each compressed chunk, in order.
+// Chunks are compressed ahead of |cb| on up to parallelism() goroutines, and the number of compressed chunks
+// waiting for |cb| is bounded.
+func (p *compressionPool) compressChunks(ctx context.Context, n int, get func(i int) chunks.Chunk, cb func(CompressedChunk) error) error {
+	workers := p.parallelism()
+	if workers <= 1 || n < minParallelCompression {
+		for i := 0; i < n; i++ {
+			if err := cb(ChunkToCompressedChunk(get(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	// each result is sent on its own channel, which are queued in order
+	results := make(chan chan CompressedChunk, workers*4)
+	sem := make(chan struct{}, workers)
+
+	eg.Go(func() error {
+		defer close(results)
+		for i := 0; i < n; i++ {
+			res := make(chan CompressedChunk, 1)
+			select {
+			case results <- res:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+			select {
+			case sem <- struct{}{}:
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+			chk := get(i)
+			p.active.Add(1)
+			go func() {
+				defer func() {
+					p.active.Add(-1)
+					<-sem
+				}()
+				res <- ChunkToCompressedChunk(chk)
+			}()
+		}
+		return nil
+	})
+
+	eg.Go(func() error {
+		for res := range results {
+			select {
+			case cc := <-res:
+				if err := cb(cc); err != nil {
+					return err
+				}
+			case <-egCtx.Done():
+				return egCtx.Err()
+			}
+		}
+		return nil
+	})
+
+	return eg.Wait()
+}
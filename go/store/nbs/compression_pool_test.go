@@ -0,0 +1,78 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+)
+
+func TestCompressionPool(t *testing.T) {
+	ctx := context.Background()
+	chks := make([]chunks.Chunk, 1000)
+	for i := range chks {
+		chks[i] = chunks.NewChunk([]byte(fmt.Sprintf("chunk %d", i)))
+	}
+
+	for _, workers := range []int{1, 2, 8} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			p := newCompressionPool(workers)
+			for _, n := range []int{0, 10, minParallelCompression, len(chks)} {
+				var i int
+				err := p.compressChunks(ctx, n, func(i int) chunks.Chunk {
+					return chks[i]
+				}, func(cc CompressedChunk) error {
+					// chunks are returned in order
+					assert.Equal(t, chks[i].Hash(), cc.Hash())
+					chk, err := cc.ToChunk()
+					require.NoError(t, err)
+					assert.Equal(t, chks[i].Data(), chk.Data())
+					i++
+					return nil
+				})
+				require.NoError(t, err)
+				assert.Equal(t, n, i)
+			}
+
+			assert.GreaterOrEqual(t, p.parallelism(), 1)
+			assert.LessOrEqual(t, p.parallelism(), workers)
+			assert.Equal(t, int64(0), p.active.Load())
+		})
+	}
+
+	t.Run("callback error", func(t *testing.T) {
+		p := newCompressionPool(4)
+		expected := errors.New("callback error")
+		var calls int
+		err := p.compressChunks(ctx, len(chks), func(i int) chunks.Chunk {
+			return chks[i]
+		}, func(cc CompressedChunk) error {
+			calls++
+			if calls == 100 {
+				return expected
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, expected)
+		assert.Equal(t, 100, calls)
+	})
+}
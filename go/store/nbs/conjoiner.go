@@ -120,6 +120,9 @@ type conjoinOperation struct {
 	// This comes directly from persister.ConjoinAll, but needs to
 	// be run after the manifest update lands successfully.
 	cleanup cleanupFunc
+
+	// When the operation began, for logging how long it took.
+	started time.Time
 }
 
 // Compute what we will conjoin and prepare to do it. This should be
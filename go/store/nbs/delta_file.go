@@ -0,0 +1,241 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bufio"
+	"context"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	gohash "hash"
+	"io"
+	"os"
+
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/delta"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// DeltaFileSuffix is appended to the id of a table file uploaded as a delta file. A delta file holds the chunks
+// of the table file, in order, but chunks that are similar to a chunk the destination already has are encoded
+// as a delta against that base chunk. The destination re-materializes the table file from the delta file
+// before it's added to the manifest.
+const DeltaFileSuffix = ".delta"
+
+const deltaFileMagic = "DDF1"
+
+const (
+	deltaRecordChunk byte = iota
+	deltaRecordDelta
+)
+
+var ErrInvalidDeltaFile = errors.New("invalid delta file")
+
+// DeltaFileWriter writes a delta file to a temporary file.
+type DeltaFileWriter struct {
+	f     *os.File
+	wr    *bufio.Writer
+	md5   gohash.Hash
+	size  uint64
+	count int
+	buf   []byte
+}
+
+func NewDeltaFileWriter(tempDir string) (*DeltaFileWriter, error) {
+	f, err := tempfiles.MovableTempFileProvider.NewFile(tempDir, "delta_file_")
+	if err != nil {
+		return nil, err
+	}
+	dw := &DeltaFileWriter{f: f, md5: md5.New()}
+	dw.wr = bufio.NewWriter(io.MultiWriter(f, dw.md5))
+	if err := dw.write([]byte(deltaFileMagic)); err != nil {
+		dw.Remove()
+		return nil, err
+	}
+	return dw, nil
+}
+
+// AddChunk adds |tc| to the delta file as it is.
+func (dw *DeltaFileWriter) AddChunk(tc ToChunker) error {
+	cc, ok := tc.(CompressedChunk)
+	if !ok {
+		chk, err := tc.ToChunk()
+		if err != nil {
+			return err
+		}
+		cc = ChunkToCompressedChunk(chk)
+	}
+	dw.buf = append(dw.buf[:0], cc.H[:]...)
+	dw.buf = append(dw.buf, deltaRecordChunk)
+	dw.buf = binary.AppendUvarint(dw.buf, uint64(len(cc.FullCompressedChunk)))
+	if err := dw.write(dw.buf); err != nil {
+		return err
+	}
+	dw.count++
+	return dw.write(cc.FullCompressedChunk)
+}
+
+// AddDelta adds the chunk |addr| to the delta file as |d|, a delta against the chunk |base|.
+func (dw *DeltaFileWriter) AddDelta(addr, base hash.Hash, d []byte) error {
+	dw.buf = append(dw.buf[:0], addr[:]...)
+	dw.buf = append(dw.buf, deltaRecordDelta)
+	dw.buf = append(dw.buf, base[:]...)
+	dw.buf = binary.AppendUvarint(dw.buf, uint64(len(d)))
+	if err := dw.write(dw.buf); err != nil {
+		return err
+	}
+	dw.count++
+	return dw.write(d)
+}
+
+func (dw *DeltaFileWriter) write(bs []byte) error {
+	n, err := dw.wr.Write(bs)
+	dw.size += uint64(n)
+	return err
+}
+
+// ChunkCount returns the number of chunks added to the delta file.
+func (dw *DeltaFileWriter) ChunkCount() int {
+	return dw.count
+}
+
+// Size returns the number of bytes written to the delta file.
+func (dw *DeltaFileWriter) Size() uint64 {
+	return dw.size
+}
+
+// Finish flushes and closes the delta file. It must be called before Reader.
+func (dw *DeltaFileWriter) Finish() error {
+	if err := dw.wr.Flush(); err != nil {
+		return err
+	}
+	return dw.f.Close()
+}
+
+// GetMD5 returns the MD5 of the delta file, after Finish.
+func (dw *DeltaFileWriter) GetMD5() []byte {
+	return dw.md5.Sum(nil)
+}
+
+func (dw *DeltaFileWriter) Reader() (io.ReadCloser, error) {
+	return os.Open(dw.f.Name())
+}
+
+func (dw *DeltaFileWriter) Remove() error {
+	_ = dw.f.Close()
+	return file.Remove(dw.f.Name())
+}
+
+// readDeltaFile reads the chunks in the delta file from |r| and calls |cb| with each of them, in order. |getBase|
+// is called with the base of each delta, which must be present.
+func readDeltaFile(ctx context.Context, r io.Reader, getBase func(context.Context, hash.Hash) (chunks.Chunk, error), cb func(CompressedChunk) error) error {
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(deltaFileMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || string(magic) != deltaFileMagic {
+		return ErrInvalidDeltaFile
+	}
+
+	var addr, base hash.Hash
+	var data []byte
+	for {
+		if _, err := io.ReadFull(br, addr[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return ErrInvalidDeltaFile
+		}
+		kind, err := br.ReadByte()
+		if err != nil {
+			return ErrInvalidDeltaFile
+		}
+		if kind == deltaRecordDelta {
+			if _, err := io.ReadFull(br, base[:]); err != nil {
+				return ErrInvalidDeltaFile
+			}
+		} else if kind != deltaRecordChunk {
+			return ErrInvalidDeltaFile
+		}
+		n, err := binary.ReadUvarint(br)
+		if err != nil || n > maxChunkSize {
+			return ErrInvalidDeltaFile
+		}
+		data = make([]byte, n)
+		if _, err := io.ReadFull(br, data); err != nil {
+			return ErrInvalidDeltaFile
+		}
+
+		var cc CompressedChunk
+		if kind == deltaRecordChunk {
+			cc, err = NewCompressedChunk(addr, data)
+			if err != nil {
+				return err
+			}
+		} else {
+			b, err := getBase(ctx, base)
+			if err != nil {
+				return err
+			}
+			if b.IsEmpty() {
+				return fmt.Errorf("%w: base chunk %s of %s not found", ErrInvalidDeltaFile, base.String(), addr.String())
+			}
+			bs, err := delta.Apply(b.Data(), data)
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidDeltaFile, err)
+			}
+			chk := chunks.NewChunk(bs)
+			if chk.Hash() != addr {
+				return fmt.Errorf("%w: delta of %s produced %s", ErrInvalidDeltaFile, addr.String(), chk.Hash().String())
+			}
+			cc = ChunkToCompressedChunk(chk)
+		}
+		if err := cb(cc); err != nil {
+			return err
+		}
+	}
+}
+
+// writeDeltaTableFile re-materializes the table file |fileId| from the delta file read from |r| and persists it
+// with |tfp|. The base chunks of deltas are read from |getBase|.
+func writeDeltaTableFile(ctx context.Context, tfp tableFilePersister, fileId string, numChunks int, r io.Reader, getBase func(context.Context, hash.Hash) (chunks.Chunk, error)) error {
+	wr, err := NewCmpChunkTableWriter("")
+	if err != nil {
+		return err
+	}
+	defer wr.Cancel()
+
+	err = readDeltaFile(ctx, r, getBase, func(cc CompressedChunk) error {
+		_, err := wr.AddChunk(cc)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if wr.ChunkCount() != numChunks {
+		return fmt.Errorf("%w: expected %d chunks, found %d", ErrInvalidDeltaFile, numChunks, wr.ChunkCount())
+	}
+	if _, _, err = wr.Finish(); err != nil {
+		return err
+	}
+
+	rd, err := wr.Reader()
+	if err != nil {
+		return err
+	}
+	defer rd.Close()
+	return tfp.CopyTableFile(ctx, rd, fileId, wr.FullLength(), uint32(numChunks))
+}
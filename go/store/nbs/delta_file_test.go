@@ -0,0 +1,104 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/delta"
+)
+
+func TestDeltaFile(t *testing.T) {
+	ctx := context.Background()
+	st, _, _ := makeTestLocalStore(t, 8)
+	defer st.Close()
+
+	rng := rand.New(rand.NewSource(0))
+	randChunk := func(n int) chunks.Chunk {
+		bs := make([]byte, n)
+		rng.Read(bs)
+		return chunks.NewChunk(bs)
+	}
+
+	base := randChunk(4096)
+	require.NoError(t, st.Put(ctx, base, noopGetAddrs))
+	edited := append([]byte{}, base.Data()...)
+	copy(edited[100:], "an updated column value")
+	editedChk := chunks.NewChunk(edited)
+	other := randChunk(1024)
+
+	// the table file that the delta file is re-materialized as
+	tw, err := NewCmpChunkTableWriter("")
+	require.NoError(t, err)
+	for _, c := range []chunks.Chunk{editedChk, other} {
+		_, err = tw.AddChunk(ChunkToCompressedChunk(c))
+		require.NoError(t, err)
+	}
+	_, id, err := tw.Finish()
+	require.NoError(t, err)
+	defer tw.Cancel()
+
+	writeDeltaFile := func(baseAddr hash.Hash) *DeltaFileWriter {
+		dw, err := NewDeltaFileWriter("")
+		require.NoError(t, err)
+		require.NoError(t, dw.AddDelta(editedChk.Hash(), baseAddr, delta.Encode(base.Data(), edited)))
+		require.NoError(t, dw.AddChunk(ChunkToCompressedChunk(other)))
+		require.NoError(t, dw.Finish())
+		return dw
+	}
+	getRd := func(dw *DeltaFileWriter) func() (io.ReadCloser, uint64, error) {
+		return func() (io.ReadCloser, uint64, error) {
+			rd, err := dw.Reader()
+			return rd, dw.Size(), err
+		}
+	}
+
+	dw := writeDeltaFile(base.Hash())
+	defer dw.Remove()
+	assert.Equal(t, 2, dw.ChunkCount())
+	assert.Less(t, dw.Size(), tw.FullLength())
+
+	t.Run("invalid", func(t *testing.T) {
+		err := st.WriteTableFile(ctx, id+DeltaFileSuffix, 3, nil, getRd(dw))
+		assert.ErrorIs(t, err, ErrInvalidDeltaFile)
+
+		err = st.WriteTableFile(ctx, id+DeltaFileSuffix, 2, nil, func() (io.ReadCloser, uint64, error) {
+			return io.NopCloser(strings.NewReader("not a delta file")), 16, nil
+		})
+		assert.ErrorIs(t, err, ErrInvalidDeltaFile)
+
+		missing := writeDeltaFile(randChunk(16).Hash())
+		defer missing.Remove()
+		err = st.WriteTableFile(ctx, id+DeltaFileSuffix, 2, nil, getRd(missing))
+		assert.ErrorIs(t, err, ErrInvalidDeltaFile)
+	})
+
+	require.NoError(t, st.WriteTableFile(ctx, id+DeltaFileSuffix, 2, nil, getRd(dw)))
+	require.NoError(t, st.AddTableFilesToManifest(ctx, map[string]int{id: 2}, noopGetAddrs))
+	for _, c := range []chunks.Chunk{editedChk, other} {
+		out, err := st.Get(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, c.Data(), out.Data())
+	}
+}
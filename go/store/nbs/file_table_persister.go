@@ -69,7 +69,45 @@ var _ tablePersister = &fsTablePersister{}
 var _ tableFilePersister = &fsTablePersister{}
 
 func (ftp *fsTablePersister) Open(ctx context.Context, name hash.Hash, chunkCount uint32, stats *Stats) (chunkSource, error) {
-	return newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, stats)
+	cs, err := newFileTableReader(ctx, ftp.dir, name, chunkCount, ftp.q, stats)
+	if err != nil {
+		return nil, err
+	}
+	if ftr, ok := cs.(*fileTableReader); ok && ftr.bloomBuilt {
+		// the filter is rebuilt the next time the table file is opened if it can't be written
+		_ = ftp.persistBloomFilter(name, ftr.bloom)
+	}
+	return cs, nil
+}
+
+// persistBloomFilter writes |f| next to the table file |name|, so that it doesn't have to be built from the
+// table file's index when it's opened.
+func (ftp *fsTablePersister) persistBloomFilter(name hash.Hash, f *tableBloomFilter) error {
+	ftp.removeMu.Lock()
+	temp, err := tempfiles.MovableTempFileProvider.NewFile(ftp.dir, tempTablePrefix)
+	if err != nil {
+		ftp.removeMu.Unlock()
+		return err
+	}
+	tempName := filepath.Clean(temp.Name())
+	ftp.curTmps[tempName] = struct{}{}
+	ftp.removeMu.Unlock()
+
+	defer func() {
+		ftp.removeMu.Lock()
+		delete(ftp.curTmps, tempName)
+		ftp.removeMu.Unlock()
+	}()
+
+	_, err = temp.Write(f.marshal())
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		file.Remove(tempName)
+		return err
+	}
+	return file.Rename(tempName, filepath.Join(ftp.dir, name.String()+tableBloomFilterSuffix))
 }
 
 func (ftp *fsTablePersister) Exists(ctx context.Context, name string, chunkCount uint32, stats *Stats) (bool, error) {
@@ -179,42 +217,7 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 		return emptyChunkSource{}, nil
 	}
 
-	tempName, f, err := func() (tempName string, cleanup func(), ferr error) {
-		ftp.removeMu.Lock()
-		var temp *os.File
-		temp, ferr = tempfiles.MovableTempFileProvider.NewFile(ftp.dir, tempTablePrefix)
-		if ferr != nil {
-			ftp.removeMu.Unlock()
-			return "", func() {}, ferr
-		}
-		ftp.curTmps[filepath.Clean(temp.Name())] = struct{}{}
-		ftp.removeMu.Unlock()
-
-		cleanup = func() {
-			ftp.removeMu.Lock()
-			delete(ftp.curTmps, filepath.Clean(temp.Name()))
-			ftp.removeMu.Unlock()
-		}
-
-		defer func() {
-			closeErr := temp.Close()
-			if ferr == nil {
-				ferr = closeErr
-			}
-		}()
-
-		_, ferr = io.Copy(temp, bytes.NewReader(data))
-		if ferr != nil {
-			return "", cleanup, ferr
-		}
-
-		ferr = temp.Sync()
-		if ferr != nil {
-			return "", cleanup, ferr
-		}
-
-		return temp.Name(), cleanup, nil
-	}()
+	tempName, f, err := ftp.writeTempTable(data)
 	defer f()
 	if err != nil {
 		return nil, err
@@ -234,6 +237,45 @@ func (ftp *fsTablePersister) persistTable(ctx context.Context, name hash.Hash, d
 	return ftp.Open(ctx, name, chunkCount, stats)
 }
 
+// writeTempTable writes the table file |data| to a synced temp file in the store's directory, which is protected from
+// pruning until |cleanup| is called.
+func (ftp *fsTablePersister) writeTempTable(data []byte) (tempName string, cleanup func(), ferr error) {
+	ftp.removeMu.Lock()
+	var temp *os.File
+	temp, ferr = tempfiles.MovableTempFileProvider.NewFile(ftp.dir, tempTablePrefix)
+	if ferr != nil {
+		ftp.removeMu.Unlock()
+		return "", func() {}, ferr
+	}
+	ftp.curTmps[filepath.Clean(temp.Name())] = struct{}{}
+	ftp.removeMu.Unlock()
+
+	cleanup = func() {
+		ftp.removeMu.Lock()
+		delete(ftp.curTmps, filepath.Clean(temp.Name()))
+		ftp.removeMu.Unlock()
+	}
+
+	defer func() {
+		closeErr := temp.Close()
+		if ferr == nil {
+			ferr = closeErr
+		}
+	}()
+
+	_, ferr = io.Copy(temp, bytes.NewReader(data))
+	if ferr != nil {
+		return "", cleanup, ferr
+	}
+
+	ferr = temp.Sync()
+	if ferr != nil {
+		return "", cleanup, ferr
+	}
+
+	return temp.Name(), cleanup, nil
+}
+
 func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSources, stats *Stats) (chunkSource, cleanupFunc, error) {
 	plan, err := planRangeCopyConjoin(sources, stats)
 	if err != nil {
@@ -329,6 +371,7 @@ func (ftp *fsTablePersister) ConjoinAll(ctx context.Context, sources chunkSource
 	return cs, func() {
 		for _, s := range sources {
 			file.Remove(filepath.Join(ftp.dir, s.hash().String()))
+			file.Remove(filepath.Join(ftp.dir, s.hash().String()+tableBloomFilterSuffix))
 		}
 	}, nil
 }
@@ -368,6 +411,7 @@ func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, keeper func()
 
 	unfilteredTableFiles := make([]string, 0)
 	unfilteredTempFiles := make([]string, 0)
+	unfilteredBloomFilters := make([]string, 0)
 
 	for _, info := range fileInfos {
 		if info.IsDir() {
@@ -381,6 +425,13 @@ func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, keeper func()
 			continue
 		}
 
+		if tableName, ok := strings.CutSuffix(info.Name(), tableBloomFilterSuffix); ok {
+			if _, ok := hash.MaybeParse(tableName); ok {
+				unfilteredBloomFilters = append(unfilteredBloomFilters, filePath)
+			}
+			continue
+		}
+
 		if len(info.Name()) != 32 {
 			continue // not a table file
 		}
@@ -425,6 +476,21 @@ func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, keeper func()
 		ftp.removeMu.Unlock()
 	}
 
+	for _, p := range unfilteredBloomFilters {
+		// bloom filters are removed with their table files
+		tablePath := filepath.Clean(strings.TrimSuffix(p, tableBloomFilterSuffix))
+		ftp.removeMu.Lock()
+		if _, ok := ftp.toKeep[tablePath]; !ok {
+			if _, err := os.Stat(tablePath); errors.Is(err, fs.ErrNotExist) {
+				err = file.Remove(p)
+				if err != nil && !errors.Is(err, fs.ErrNotExist) {
+					ea.add(p, err)
+				}
+			}
+		}
+		ftp.removeMu.Unlock()
+	}
+
 	if !ea.isEmpty() {
 		return ea
 	}
@@ -432,6 +498,69 @@ func (ftp *fsTablePersister) PruneTableFiles(ctx context.Context, keeper func()
 	return nil
 }
 
+// replaceTable replaces the table file |name|, whose file has |suffix|, with the table file |data|. The replacement is
+// written to a temp file first, and the original is linked, or copied, into the quarantine directory of the store,
+// where it's no longer opened or pruned along with the rest of the store's table files and is kept for inspection.
+// Only then is the replacement renamed over the original. On any error, the original is left in place.
+func (ftp *fsTablePersister) replaceTable(ctx context.Context, name hash.Hash, suffix string, data []byte, chunkCount uint32, stats *Stats) (chunkSource, error) {
+	tempName, cleanup, err := ftp.writeTempTable(data)
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := name.String() + suffix
+	if err = quarantineFile(filepath.Join(ftp.dir, fileName), filepath.Join(ftp.dir, quarantineDir, fileName)); err != nil {
+		file.Remove(tempName)
+		return nil, err
+	}
+
+	// The replacement is always a table file. A replaced archive file is removed once the replacement is in place,
+	// though table files are opened in preference to archive files of the same name regardless.
+	newName := filepath.Join(ftp.dir, name.String())
+	ftp.removeMu.Lock()
+	if ftp.toKeep != nil {
+		ftp.toKeep[filepath.Clean(newName)] = struct{}{}
+	}
+	err = file.Rename(tempName, newName)
+	ftp.removeMu.Unlock()
+	if err != nil {
+		file.Remove(tempName)
+		return nil, err
+	}
+	if suffix != "" {
+		err = file.Remove(filepath.Join(ftp.dir, fileName))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	if err = file.SyncDirectoryHandle(ftp.dir); err != nil {
+		return nil, err
+	}
+
+	return ftp.Open(ctx, name, chunkCount, stats)
+}
+
+// quarantineFile hard links the file |src| to |dest|, or copies it where hard links aren't supported.
+func quarantineFile(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.Link(src, dest); err == nil {
+		return nil
+	} else if errors.Is(err, fs.ErrExist) {
+		// quarantined by an earlier repair, which failed after the original was linked
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return file.WriteFileAtomically(dest, in, 0444)
+}
+
 func (ftp *fsTablePersister) Close() error {
 	return nil
 }
@@ -31,6 +31,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -39,6 +41,9 @@ var ErrTableFileNotFound = errors.New("table file not found")
 type fileTableReader struct {
 	tableReader
 	h hash.Hash
+	// bloomBuilt is true if the bloom filter of the table file was built from its index, rather than read from
+	// the file next to it.
+	bloomBuilt bool
 }
 
 const (
@@ -151,15 +156,38 @@ func nomsFileTableReader(ctx context.Context, path string, h hash.Hash, chunkCou
 		return nil, errors.New("unexpected chunk count")
 	}
 
-	tr, err := newTableReader(index, fra, fileBlockSize)
+	var tra tableReaderAt = fra
+	if tableFileReadMode == tableFileReadModeMmap {
+		mra, err := newMmapReaderAt(fra)
+		if err != nil {
+			logrus.Warnf("unable to mmap table file %s, reading it with pread: %s", path, err)
+		} else {
+			tra = mra
+		}
+	}
+
+	tr, err := newTableReader(index, tra, fileBlockSize)
 	if err != nil {
 		index.Close()
-		fra.Close()
+		tra.Close()
 		return nil, err
 	}
+
+	bloomBuilt := false
+	tr.bloom = loadTableBloomFilter(path, chunkCount)
+	if tr.bloom == nil {
+		tr.bloom, err = buildTableBloomFilter(index)
+		if err != nil {
+			tr.close()
+			return nil, err
+		}
+		bloomBuilt = true
+	}
+
 	return &fileTableReader{
-		tr,
-		h,
+		tableReader: tr,
+		h:           h,
+		bloomBuilt:  bloomBuilt,
 	}, nil
 }
 
@@ -180,7 +208,7 @@ func (ftr *fileTableReader) clone() (chunkSource, error) {
 	if err != nil {
 		return &fileTableReader{}, err
 	}
-	return &fileTableReader{tr, ftr.h}, nil
+	return &fileTableReader{tableReader: tr, h: ftr.h}, nil
 }
 
 type fileReaderAt struct {
@@ -23,8 +23,10 @@ package nbs
 
 import (
 	"context"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/dolthub/dolt/go/libraries/utils/file"
@@ -34,6 +36,16 @@ import (
 )
 
 func TestMmapTableReader(t *testing.T) {
+	for _, mode := range []string{tableFileReadModePread, tableFileReadModeMmap} {
+		t.Run(mode, func(t *testing.T) {
+			defer func(m string) { tableFileReadMode = m }(tableFileReadMode)
+			tableFileReadMode = mode
+			testFileTableReader(t)
+		})
+	}
+}
+
+func testFileTableReader(t *testing.T) {
 	ctx := context.Background()
 	assert := assert.New(t)
 	dir, err := os.MkdirTemp("", "")
@@ -56,3 +68,53 @@ func TestMmapTableReader(t *testing.T) {
 	defer trc.close()
 	assertChunksInReader(chunks, trc, assert)
 }
+
+func TestMmapReaderAt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mmap table file reads are not supported on windows")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "table")
+	data := make([]byte, 4*mmapReadAheadBytes+17)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	require.NoError(t, os.WriteFile(path, data, 0666))
+
+	fra, err := newFileReaderAt(path)
+	require.NoError(t, err)
+	mra, err := newMmapReaderAt(fra)
+	require.NoError(t, err)
+
+	cln, err := mra.clone()
+	require.NoError(t, err)
+	require.NoError(t, mra.Close())
+
+	// sequential reads are read ahead
+	stats := &Stats{}
+	buf := make([]byte, 4096)
+	for off := int64(0); off < int64(len(data)); off += int64(len(buf)) {
+		n, err := cln.ReadAtWithStats(ctx, buf, off, stats)
+		if off+int64(len(buf)) > int64(len(data)) {
+			assert.Equal(t, io.EOF, err)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, data[off:off+int64(n)], buf[:n])
+	}
+	assert.Greater(t, cln.(*mmapReaderAt).advisedEnd.Load(), int64(0))
+
+	n, err := cln.ReadAtWithStats(ctx, buf, int64(len(data)), stats)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, io.EOF, err)
+
+	rd, err := cln.Reader(ctx)
+	require.NoError(t, err)
+	all, err := io.ReadAll(rd)
+	require.NoError(t, err)
+	require.NoError(t, rd.Close())
+	assert.Equal(t, data, all)
+
+	require.NoError(t, cln.Close())
+}
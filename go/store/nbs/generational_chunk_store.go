@@ -75,6 +75,12 @@ func (gcs *GenerationalNBS) AppendLoggerFields(fields logrus.Fields) {
 	gcs.newGen.AppendLoggerFields(fields)
 }
 
+// SetChunkRepairer sets the ChunkRepairer used by both generations to repair corrupt chunks found on reads.
+func (gcs *GenerationalNBS) SetChunkRepairer(r ChunkRepairer) {
+	gcs.oldGen.SetChunkRepairer(r)
+	gcs.newGen.SetChunkRepairer(r)
+}
+
 func (gcs *GenerationalNBS) NewGen() chunks.ChunkStoreGarbageCollector {
 	return gcs.newGen
 }
@@ -419,7 +425,8 @@ func (gcs *GenerationalNBS) Size(ctx context.Context) (uint64, error) {
 
 // WriteTableFile will read a table file from the provided reader and write it to the new gen TableFileStore
 func (gcs *GenerationalNBS) WriteTableFile(ctx context.Context, fileId string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
-	return gcs.newGen.WriteTableFile(ctx, fileId, numChunks, contentHash, getRd)
+	// the bases of a delta file may be in either generation
+	return gcs.newGen.writeTableFile(ctx, fileId, numChunks, getRd, gcs.Get)
 }
 
 // AddTableFilesToManifest adds table files to the manifest of the newgen cs
@@ -263,16 +263,21 @@ func (j *ChunkJournal) Persist(ctx context.Context, mt *memTable, haver chunkRea
 		sort.Sort(hasRecordByOrder(mt.order)) // restore "insertion" order for write
 	}
 
+	// chunks are compressed in parallel, but written in order
+	records := make([]hasRecord, 0, len(mt.order))
 	for _, record := range mt.order {
-		if record.has {
-			continue
-		}
-		c := chunks.NewChunkWithHash(hash.Hash(*record.a), mt.chunks[*record.a])
-		err := j.wr.writeCompressedChunk(ctx, ChunkToCompressedChunk(c))
-		if err != nil {
-			return nil, gcBehavior_Continue, err
+		if !record.has {
+			records = append(records, record)
 		}
 	}
+	err := globalCompressionPool.compressChunks(ctx, len(records), func(i int) chunks.Chunk {
+		return chunks.NewChunkWithHash(hash.Hash(*records[i].a), mt.chunks[*records[i].a])
+	}, func(cc CompressedChunk) error {
+		return j.wr.writeCompressedChunk(ctx, cc)
+	})
+	if err != nil {
+		return nil, gcBehavior_Continue, err
+	}
 	return journalChunkSource{journal: j.wr}, gcBehavior_Continue, nil
 }
 
@@ -295,6 +300,14 @@ func (j *ChunkJournal) Open(ctx context.Context, name hash.Hash, chunkCount uint
 	return j.persister.Open(ctx, name, chunkCount, stats)
 }
 
+// replaceTable implements tableReplacer.
+func (j *ChunkJournal) replaceTable(ctx context.Context, name hash.Hash, suffix string, data []byte, chunkCount uint32, stats *Stats) (chunkSource, error) {
+	if name == journalAddr {
+		return nil, errors.New("cannot replace the chunk journal")
+	}
+	return j.persister.replaceTable(ctx, name, suffix, data, chunkCount, stats)
+}
+
 // Exists implements tablePersister.
 func (j *ChunkJournal) Exists(ctx context.Context, name string, chunkCount uint32, stats *Stats) (bool, error) {
 	return j.persister.Exists(ctx, name, chunkCount, stats)
@@ -0,0 +1,302 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dolthub/fslock"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
+)
+
+// corruptManifestSuffix is appended to the name of the manifest file when it is moved aside by RewriteManifest.
+const corruptManifestSuffix = ".corrupt."
+
+// RecoveredTableFile is a table file, archive or chunk journal found in the directory of a store.
+type RecoveredTableFile struct {
+	Name       hash.Hash
+	ChunkCount uint32
+	Archive    bool
+	Journal    bool
+}
+
+// RecoveredRoot is a root hash which a rewritten manifest could point to.
+type RecoveredRoot struct {
+	Root hash.Hash
+	// Source is the name of the table file or chunk journal the root was found in.
+	Source hash.Hash
+	// Journal is true if the root is the last root written to the chunk journal.
+	Journal bool
+	// Timestamp is when the root was written to the chunk journal, or nil if it isn't known.
+	Timestamp *time.Time
+}
+
+// UnreadableFile is a file which looked like a table file but couldn't be read. It is left out of a rewritten
+// manifest.
+type UnreadableFile struct {
+	Name string
+	Err  error
+}
+
+// ManifestRecovery holds what was found on disk in the directory of a store whose manifest is missing or corrupt,
+// from which a new manifest can be written. Created with RecoverManifest.
+type ManifestRecovery struct {
+	dir string
+
+	// TableFiles are the readable table files, archives and chunk journal in the directory.
+	TableFiles []RecoveredTableFile
+	// Roots are the candidate roots of the store. If the store has a chunk journal, the first root is the last root
+	// written to it, which is the root of the store regardless of what its manifest says. Otherwise, they are the
+	// store roots found in its table files, if they were scanned, in no particular order.
+	Roots []RecoveredRoot
+	// Unreadable are the files which look like table files but couldn't be read.
+	Unreadable []UnreadableFile
+
+	journalRoot hash.Hash
+}
+
+// RecoverManifest finds the table files, archives and chunk journal in |dir|, the directory of a local store, and the
+// roots a new manifest for the store could point to. The root of a store with a chunk journal is always the last
+// root written to the journal. If |scanTableFiles| is true, or the store has no chunk journal, every chunk in the
+// table files is read to find store roots as well.
+//
+// The current manifest of the store, which is presumed to be corrupt, isn't read.
+func RecoverManifest(ctx context.Context, dir string, scanTableFiles bool) (*ManifestRecovery, error) {
+	if err := checkDir(dir); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ManifestRecovery{dir: dir}
+	stats := &Stats{}
+	q := NewUnlimitedMemQuotaProvider()
+	var sources chunkSources
+	defer func() {
+		for _, cs := range sources {
+			cs.close()
+		}
+	}()
+
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		name := e.Name()
+		archive := strings.HasSuffix(name, ArchiveFileSuffix)
+		h, ok := hash.MaybeParse(strings.TrimSuffix(name, ArchiveFileSuffix))
+		if !ok {
+			continue
+		}
+
+		if !archive && h == journalAddr {
+			tf, root, err := recoverJournal(ctx, filepath.Join(dir, name))
+			if err != nil {
+				r.Unreadable = append(r.Unreadable, UnreadableFile{Name: name, Err: err})
+				continue
+			}
+			r.TableFiles = append([]RecoveredTableFile{tf}, r.TableFiles...)
+			if !root.Root.IsEmpty() {
+				r.journalRoot = root.Root
+				r.Roots = append([]RecoveredRoot{root}, r.Roots...)
+			}
+			continue
+		}
+
+		cs, err := openRecoveredTableFile(ctx, dir, h, archive, q, stats)
+		if err != nil {
+			r.Unreadable = append(r.Unreadable, UnreadableFile{Name: name, Err: err})
+			continue
+		}
+		sources = append(sources, cs)
+		cnt, err := cs.count()
+		if err != nil {
+			r.Unreadable = append(r.Unreadable, UnreadableFile{Name: name, Err: err})
+			continue
+		}
+		r.TableFiles = append(r.TableFiles, RecoveredTableFile{Name: h, ChunkCount: cnt, Archive: archive})
+	}
+
+	if !scanTableFiles && !r.journalRoot.IsEmpty() {
+		return r, nil
+	}
+	for _, cs := range sources {
+		var found []hash.Hash
+		err := cs.iterateAllChunks(ctx, func(c chunks.Chunk) {
+			if serial.GetFileID(c.Data()) == serial.StoreRootFileID {
+				found = append(found, c.Hash())
+			}
+		}, stats)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning table file %s for roots: %w", cs.hash().String(), err)
+		}
+		for _, root := range found {
+			if root != r.journalRoot {
+				r.Roots = append(r.Roots, RecoveredRoot{Root: root, Source: cs.hash()})
+			}
+		}
+	}
+	return r, nil
+}
+
+// openRecoveredTableFile opens the table file or archive |name| in |dir| without knowing its chunk count, which is
+// otherwise read from the manifest.
+func openRecoveredTableFile(ctx context.Context, dir string, name hash.Hash, archive bool, q MemoryQuotaProvider, stats *Stats) (chunkSource, error) {
+	if archive {
+		// archives read their chunk count from their footer
+		return newArchiveChunkSource(ctx, dir, name, 0, q, stats)
+	}
+
+	f, err := os.Open(filepath.Join(dir, name.String()))
+	if err != nil {
+		return nil, err
+	}
+	cnt, _, err := ReadTableFooter(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return nomsFileTableReader(ctx, filepath.Join(dir, name.String()), name, cnt, q)
+}
+
+// recoverJournal reads the chunk journal at |path|, returning its table file spec and the last root written to it.
+func recoverJournal(ctx context.Context, path string) (RecoveredTableFile, RecoveredRoot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return RecoveredTableFile{}, RecoveredRoot{}, err
+	}
+	defer f.Close()
+
+	var root RecoveredRoot
+	addrs := hash.NewHashSet()
+	_, err = processJournalRecords(ctx, f, 0, func(_ int64, rec journalRec) error {
+		switch rec.kind {
+		case rootHashJournalRecKind:
+			root = RecoveredRoot{Root: rec.address, Source: journalAddr, Journal: true}
+			if !rec.timestamp.IsZero() {
+				ts := rec.timestamp
+				root.Timestamp = &ts
+			}
+		case chunkJournalRecKind:
+			addrs.Insert(rec.address)
+		}
+		return nil
+	})
+	if err != nil {
+		return RecoveredTableFile{}, RecoveredRoot{}, err
+	}
+	return RecoveredTableFile{Name: journalAddr, ChunkCount: uint32(addrs.Size()), Journal: true}, root, nil
+}
+
+// HasJournal returns whether the store has a chunk journal, whose last root is the root of the store.
+func (r *ManifestRecovery) HasJournal() bool {
+	return !r.journalRoot.IsEmpty()
+}
+
+// RewriteManifest writes a new manifest for the store, in format |nbfVers|, which holds every readable table file
+// found by RecoverManifest and points to |root|. If |root| is empty, the store's single candidate root is used. The
+// current manifest, if there is one, is kept next to the new one, and its path is returned.
+func (r *ManifestRecovery) RewriteManifest(ctx context.Context, nbfVers string, root hash.Hash) (string, error) {
+	if root.IsEmpty() {
+		switch {
+		case r.HasJournal():
+			root = r.journalRoot
+		case len(r.Roots) == 1:
+			root = r.Roots[0].Root
+		case len(r.Roots) == 0:
+			return "", errors.New("no roots were found in the chunk journal or table files of the store")
+		default:
+			return "", fmt.Errorf("%d candidate roots were found, one of them must be chosen", len(r.Roots))
+		}
+	} else if r.HasJournal() && root != r.journalRoot {
+		return "", fmt.Errorf("the store's chunk journal sets its root to %s, the last root written to it", r.journalRoot.String())
+	} else if !r.HasJournal() && !r.hasRoot(root) {
+		return "", fmt.Errorf("root %s is not a root found in the table files of the store", root.String())
+	}
+
+	specs := make([]tableSpec, len(r.TableFiles))
+	for i, tf := range r.TableFiles {
+		specs[i] = tableSpec{name: tf.Name, chunkCount: tf.ChunkCount}
+	}
+	contents := manifestContents{
+		manifestVers: StorageVersion,
+		nbfVers:      nbfVers,
+		root:         root,
+		specs:        specs,
+	}
+	contents.lock = generateLockHash(contents.root, contents.specs, contents.appendix, nil)
+
+	lock := fslock.New(filepath.Join(r.dir, lockFileName))
+	if err := tryFileLock(lock); err != nil {
+		return "", err
+	}
+	defer lock.Unlock()
+
+	manifestPath := filepath.Join(r.dir, manifestFileName)
+	var backup string
+	if _, err := os.Stat(manifestPath); err == nil {
+		backup = manifestPath + corruptManifestSuffix + time.Now().UTC().Format("20060102T150405Z")
+		if err := file.Rename(manifestPath, backup); err != nil {
+			return "", err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	temp, err := tempfiles.MovableTempFileProvider.NewFile(r.dir, "nbs_manifest_")
+	if err != nil {
+		return backup, err
+	}
+	defer file.Remove(temp.Name()) // no-op once renamed
+	err = writeManifest(temp, contents)
+	if err == nil {
+		err = temp.Sync()
+	}
+	if cerr := temp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return backup, err
+	}
+	if err = file.Rename(temp.Name(), manifestPath); err != nil {
+		return backup, err
+	}
+	return backup, file.SyncDirectoryHandle(r.dir)
+}
+
+// hasRoot returns whether |root| is one of the candidate roots.
+func (r *ManifestRecovery) hasRoot(root hash.Hash) bool {
+	for _, rr := range r.Roots {
+		if rr.Root == root {
+			return true
+		}
+	}
+	return false
+}
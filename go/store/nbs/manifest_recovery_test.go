@@ -0,0 +1,145 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/gen/fb/serial"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// storeRootChunk returns a chunk which is identified as a store root.
+func storeRootChunk(i int) chunks.Chunk {
+	data := make([]byte, 64)
+	copy(data[serial.MessagePrefixSz+4:], serial.StoreRootFileID)
+	copy(data[32:], fmt.Sprintf("root %d", i))
+	return chunks.NewChunk(data)
+}
+
+// putAndCommit puts |chnks| into |st|, commits |root| and closes |st|.
+func putAndCommit(t *testing.T, st *NomsBlockStore, root hash.Hash, chnks ...chunks.Chunk) {
+	ctx := context.Background()
+	for _, c := range chnks {
+		require.NoError(t, st.Put(ctx, c, noopGetAddrs))
+	}
+	last, err := st.Root(ctx)
+	require.NoError(t, err)
+	ok, err := st.Commit(ctx, root, last)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, st.Close())
+}
+
+func TestRecoverManifestWithJournal(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_Default.VersionString()
+	dir := t.TempDir()
+	st, err := NewLocalJournalingStore(ctx, nbf, dir, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	root := storeRootChunk(0)
+	data := chunks.NewChunk([]byte("data"))
+	putAndCommit(t, st, root.Hash(), root, data)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifestFileName), []byte("not a manifest"), 0644))
+	_, err = NewLocalJournalingStore(ctx, nbf, dir, NewUnlimitedMemQuotaProvider())
+	require.Error(t, err)
+
+	recovery, err := RecoverManifest(ctx, dir, false)
+	require.NoError(t, err)
+	assert.True(t, recovery.HasJournal())
+	require.Len(t, recovery.TableFiles, 1)
+	assert.True(t, recovery.TableFiles[0].Journal)
+	assert.Equal(t, uint32(2), recovery.TableFiles[0].ChunkCount)
+	require.Len(t, recovery.Roots, 1)
+	assert.Equal(t, root.Hash(), recovery.Roots[0].Root)
+	assert.True(t, recovery.Roots[0].Journal)
+	assert.NotNil(t, recovery.Roots[0].Timestamp)
+
+	_, err = recovery.RewriteManifest(ctx, nbf, data.Hash())
+	require.Error(t, err)
+	backup, err := recovery.RewriteManifest(ctx, nbf, hash.Hash{})
+	require.NoError(t, err)
+	contents, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, "not a manifest", string(contents))
+
+	st, err = NewLocalJournalingStore(ctx, nbf, dir, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer st.Close()
+	actual, err := st.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, root.Hash(), actual)
+	ok, err := st.Has(ctx, data.Hash())
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestRecoverManifestFromTableFiles(t *testing.T) {
+	ctx := context.Background()
+	nbf := types.Format_Default.VersionString()
+	dir := t.TempDir()
+	st, err := NewLocalStore(ctx, nbf, dir, defaultMemTableSize, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	first, second := storeRootChunk(1), storeRootChunk(2)
+	data := chunks.NewChunk([]byte("data"))
+	putAndCommit(t, st, first.Hash(), first, data)
+	st, err = NewLocalStore(ctx, nbf, dir, defaultMemTableSize, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	putAndCommit(t, st, second.Hash(), second)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, manifestFileName)))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash.Of([]byte("junk")).String()), []byte("junk"), 0644))
+
+	recovery, err := RecoverManifest(ctx, dir, false)
+	require.NoError(t, err)
+	assert.False(t, recovery.HasJournal())
+	assert.Len(t, recovery.TableFiles, 2)
+	assert.Len(t, recovery.Unreadable, 1)
+	var roots []hash.Hash
+	for _, r := range recovery.Roots {
+		roots = append(roots, r.Root)
+	}
+	assert.ElementsMatch(t, []hash.Hash{first.Hash(), second.Hash()}, roots)
+
+	_, err = recovery.RewriteManifest(ctx, nbf, hash.Hash{})
+	assert.ErrorContains(t, err, "2 candidate roots")
+	_, err = recovery.RewriteManifest(ctx, nbf, data.Hash())
+	assert.Error(t, err)
+	backup, err := recovery.RewriteManifest(ctx, nbf, first.Hash())
+	require.NoError(t, err)
+	assert.Empty(t, backup)
+
+	st, err = NewLocalStore(ctx, nbf, dir, defaultMemTableSize, NewUnlimitedMemQuotaProvider())
+	require.NoError(t, err)
+	defer st.Close()
+	actual, err := st.Root(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first.Hash(), actual)
+	for _, c := range []chunks.Chunk{first, second, data} {
+		ok, err := st.Has(ctx, c.Hash())
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+}
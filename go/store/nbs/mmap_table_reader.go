@@ -0,0 +1,157 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+)
+
+const (
+	// tableFileReadModePread reads chunks from table files with a read system call per read.
+	tableFileReadModePread = "pread"
+	// tableFileReadModeMmap reads chunks from table files mapped into memory, so reads are served from the page
+	// cache without a system call, and without buffering chunk data in the process.
+	tableFileReadModeMmap = "mmap"
+)
+
+// tableFileReadMode is how chunks are read from table files, set by DOLT_TABLE_FILE_READ_MODE.
+var tableFileReadMode = readTableFileReadMode()
+
+func readTableFileReadMode() string {
+	mode := strings.ToLower(os.Getenv(dconfig.EnvTableFileReadMode))
+	switch mode {
+	case "":
+		return tableFileReadModePread
+	case tableFileReadModePread, tableFileReadModeMmap:
+		return mode
+	default:
+		logrus.Warnf("unknown value for %s: %s, expected %s or %s", dconfig.EnvTableFileReadMode, mode, tableFileReadModePread, tableFileReadModeMmap)
+		return tableFileReadModePread
+	}
+}
+
+const (
+	// mmapSequentialReads is the number of consecutive reads, each starting where the last ended, after which
+	// the pages following the reads are prefetched.
+	mmapSequentialReads = 4
+	// mmapReadAheadBytes is the number of bytes prefetched ahead of sequential reads.
+	mmapReadAheadBytes = 1 << 20
+)
+
+// mmapRegion is a table file mapped into memory, shared by the clones of an mmapReaderAt.
+type mmapRegion struct {
+	data []byte
+	refs atomic.Int32
+}
+
+func (r *mmapRegion) release() error {
+	if r.refs.Add(-1) == 0 {
+		return munmap(r.data)
+	}
+	return nil
+}
+
+// mmapReaderAt is a tableReaderAt for a table file mapped into memory. The mapping is advised for random
+// access, which suits point lookups. When reads become sequential, as they do for scans, the pages ahead of them
+// are advised to be read ahead.
+type mmapReaderAt struct {
+	region *mmapRegion
+	path   string
+
+	// lastEnd is the end offset of the last read.
+	lastEnd atomic.Int64
+	// sequential is the number of consecutive reads that started at the end of the last read.
+	sequential atomic.Int32
+	// advisedEnd is the end offset of the pages last advised to be read ahead.
+	advisedEnd atomic.Int64
+}
+
+var _ tableReaderAt = &mmapReaderAt{}
+
+// newMmapReaderAt maps the file of |fra| into memory. The file is closed if it's mapped.
+func newMmapReaderAt(fra *fileReaderAt) (*mmapReaderAt, error) {
+	data, err := mmapFile(fra.f, fra.sz)
+	if err != nil {
+		return nil, err
+	}
+	region := &mmapRegion{data: data}
+	region.refs.Store(1)
+	// the mapping remains valid after the file is closed
+	_ = fra.f.Close()
+	return &mmapReaderAt{region: region, path: fra.path}, nil
+}
+
+func (mra *mmapReaderAt) clone() (tableReaderAt, error) {
+	mra.region.refs.Add(1)
+	return &mmapReaderAt{region: mra.region, path: mra.path}, nil
+}
+
+func (mra *mmapReaderAt) Close() error {
+	return mra.region.release()
+}
+
+func (mra *mmapReaderAt) Reader(ctx context.Context) (io.ReadCloser, error) {
+	return os.Open(mra.path)
+}
+
+func (mra *mmapReaderAt) ReadAtWithStats(ctx context.Context, p []byte, off int64, stats *Stats) (n int, err error) {
+	t1 := time.Now()
+	defer func() {
+		stats.FileBytesPerRead.Sample(uint64(len(p)))
+		stats.FileReadLatency.SampleTimeSince(t1)
+	}()
+
+	data := mra.region.data
+	if off < 0 || off >= int64(len(data)) {
+		return 0, io.EOF
+	}
+	end := off + int64(len(p))
+	mra.adviseReadAhead(off, min(end, int64(len(data))))
+
+	n = copy(p, data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// adviseReadAhead advises the pages after the read of [|off|, |end|) to be read ahead if the reads have become
+// sequential.
+func (mra *mmapReaderAt) adviseReadAhead(off, end int64) {
+	if mra.lastEnd.Swap(end) != off {
+		mra.sequential.Store(0)
+		return
+	}
+	if mra.sequential.Add(1) < mmapSequentialReads || end+mmapReadAheadBytes/2 < mra.advisedEnd.Load() {
+		return
+	}
+	data := mra.region.data
+	start := max(end, mra.advisedEnd.Load())
+	start -= start % mmapAlignment
+	stop := min(start+mmapReadAheadBytes, int64(len(data)))
+	if start < stop {
+		madviseWillNeed(data[start:stop])
+		mra.advisedEnd.Store(stop)
+	}
+}
@@ -17,6 +17,28 @@
 
 package nbs
 
-import "os"
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
 
 var mmapAlignment = int64(os.Getpagesize())
+
+func mmapFile(f *os.File, sz int64) ([]byte, error) {
+	data, err := unix.Mmap(int(f.Fd()), 0, int(sz), unix.PROT_READ, unix.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	// chunks are mostly read by point lookups, so the kernel shouldn't read ahead of them
+	_ = unix.Madvise(data, unix.MADV_RANDOM)
+	return data, nil
+}
+
+func munmap(data []byte) error {
+	return unix.Munmap(data)
+}
+
+func madviseWillNeed(data []byte) {
+	_ = unix.Madvise(data, unix.MADV_WILLNEED)
+}
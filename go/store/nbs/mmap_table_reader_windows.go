@@ -14,4 +14,19 @@
 
 package nbs
 
+import (
+	"errors"
+	"os"
+)
+
 var mmapAlignment = int64(64 * 1024)
+
+func mmapFile(f *os.File, sz int64) ([]byte, error) {
+	return nil, errors.New("mmap table file reads are not supported on windows")
+}
+
+func munmap(data []byte) error {
+	return nil
+}
+
+func madviseWillNeed(data []byte) {}
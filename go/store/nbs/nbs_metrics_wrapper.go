@@ -82,6 +82,10 @@ func (nbsMW *NBSMetricWrapper) Count() (uint32, error) {
 	return nbsMW.nbs.Count()
 }
 
+func (nbsMW *NBSMetricWrapper) ConjoinPlan() ([]string, error) {
+	return nbsMW.nbs.ConjoinPlan()
+}
+
 func (nbsMW *NBSMetricWrapper) IterateAllChunks(ctx context.Context, cb func(chunk chunks.Chunk)) error {
 	return nbsMW.nbs.IterateAllChunks(ctx, cb)
 }
@@ -0,0 +1,291 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// quarantineDir is the directory, relative to the store, that table files which were found to hold corrupt chunks
+// are kept in once they have been replaced by repaired copies.
+const quarantineDir = "quarantine"
+
+// CorruptChunkError is returned when the stored bytes of a chunk fail their checksum, can't be decompressed or don't
+// hash to the chunk's address.
+type CorruptChunkError struct {
+	Chunk hash.Hash
+	cause error
+}
+
+func (e *CorruptChunkError) Error() string {
+	return fmt.Sprintf("corrupt chunk %s: %s", e.Chunk.String(), e.cause.Error())
+}
+
+func (e *CorruptChunkError) Unwrap() error {
+	return e.cause
+}
+
+// ChunkRepairer fetches good copies of the chunks |hashes| from outside of the store, such as from a remote or a
+// backup. It returns the chunks it was able to find; the store checks the address of every returned chunk before
+// using it.
+type ChunkRepairer func(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error)
+
+// tableReplacer is implemented by table persisters which can replace a table file holding corrupt chunks with a
+// repaired copy of it, keeping the original in quarantine for inspection.
+type tableReplacer interface {
+	replaceTable(ctx context.Context, name hash.Hash, suffix string, data []byte, chunkCount uint32, stats *Stats) (chunkSource, error)
+}
+
+// SetChunkRepairer sets the ChunkRepairer used to repair corrupt chunks encountered on reads. When a read finds a
+// corrupt chunk, the table file holding it is quarantined and rewritten with good copies of its corrupt chunks, and
+// the read is retried.
+func (nbs *NomsBlockStore) SetChunkRepairer(r ChunkRepairer) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	nbs.repairer = r
+}
+
+// tryRepair attempts to repair the corrupt chunk reported by |err|. It returns true if the chunk was repaired and
+// the read which returned |err| should be retried. |attempted| holds the chunks already repaired for this read, so
+// that a chunk which is still corrupt after its repair fails the read instead of looping.
+func (nbs *NomsBlockStore) tryRepair(ctx context.Context, err error, attempted hash.HashSet) (bool, error) {
+	var cce *CorruptChunkError
+	if !errors.As(err, &cce) || attempted.Has(cce.Chunk) {
+		return false, err
+	}
+	nbs.mu.RLock()
+	repairer := nbs.repairer
+	nbs.mu.RUnlock()
+	if repairer == nil {
+		return false, err
+	}
+
+	attempted.Insert(cce.Chunk)
+	nbs.logger.WithField("chunk", cce.Chunk.String()).Warn("found corrupt chunk, attempting repair")
+	if rerr := nbs.RepairChunks(ctx, hash.NewHashSet(cce.Chunk)); rerr != nil {
+		nbs.logger.WithError(rerr).Warn("repair of corrupt chunk failed")
+		return false, errors.Join(err, fmt.Errorf("unable to repair chunk %s: %w", cce.Chunk.String(), rerr))
+	}
+	return true, nil
+}
+
+// RepairChunks repairs the corrupt chunks |hashes| using the store's ChunkRepairer. Every table file holding a
+// corrupt copy of one of |hashes| is checked in full, and good copies of all of its corrupt chunks are fetched.
+// Each such table file is then moved into quarantine and replaced by a rewritten copy holding good chunks.
+func (nbs *NomsBlockStore) RepairChunks(ctx context.Context, hashes hash.HashSet) error {
+	nbs.mu.RLock()
+	repairer := nbs.repairer
+	tables := nbs.tables
+	nbs.mu.RUnlock()
+	if repairer == nil {
+		return errors.New("no remote or backup is configured to repair chunks from")
+	}
+	if _, ok := nbs.persister.(tableReplacer); !ok {
+		return errors.New("repairing corrupt chunks is only supported for local stores")
+	}
+
+	for _, cs := range tables.novel {
+		bad, err := corruptCopies(ctx, cs, hashes, nbs.stats)
+		if err != nil {
+			return err
+		}
+		if len(bad) > 0 {
+			return fmt.Errorf("corrupt chunks found in table file %s, which has not been persisted", cs.hash().String())
+		}
+	}
+
+	// Find every upstream table file with a corrupt copy of one of |hashes|, and every other corrupt chunk in those
+	// files, since the files will be rewritten in their entirety.
+	toRepair := hash.NewHashSet()
+	var quarantine []hash.Hash
+	for name, cs := range tables.upstream {
+		bad, err := corruptCopies(ctx, cs, hashes, nbs.stats)
+		if err != nil {
+			return err
+		}
+		if len(bad) == 0 {
+			continue
+		}
+		if isJournalAddr(name) {
+			return errors.New("corrupt chunks found in the chunk journal, which can't be rewritten")
+		}
+		all, err := corruptChunks(ctx, cs, nbs.stats)
+		if err != nil {
+			return err
+		}
+		toRepair.InsertAll(all)
+		quarantine = append(quarantine, name)
+	}
+	if len(quarantine) == 0 {
+		return nil
+	}
+
+	fetched, err := repairer(ctx, toRepair)
+	if err != nil {
+		return err
+	}
+	repaired := make(map[hash.Hash][]byte, len(toRepair))
+	for _, c := range fetched {
+		if !toRepair.Has(c.Hash()) || c.IsEmpty() {
+			continue
+		}
+		if hash.Of(c.Data()) != c.Hash() {
+			continue
+		}
+		repaired[c.Hash()] = c.Data()
+	}
+	for h := range toRepair {
+		if _, ok := repaired[h]; !ok {
+			return fmt.Errorf("no remote or backup has a good copy of chunk %s", h.String())
+		}
+	}
+
+	return nbs.quarantineTables(ctx, quarantine, repaired)
+}
+
+// quarantineTables rewrites each of the upstream table files |names| with the chunks in |repaired| in place of its
+// corrupt copies. The rewritten table file holds the same chunks and takes the name of the original, so the manifest
+// is unchanged; the original is kept in quarantine.
+func (nbs *NomsBlockStore) quarantineTables(ctx context.Context, names []hash.Hash, repaired map[hash.Hash][]byte) error {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if nbs.gcInProgress {
+		return errors.New("cannot repair corrupt chunks while a garbage collection is in progress")
+	}
+
+	upstream := make(chunkSourceSet, len(nbs.tables.upstream))
+	for name, cs := range nbs.tables.upstream {
+		upstream[name] = cs
+	}
+	var replaced chunkSources
+	for _, name := range names {
+		cs, ok := upstream[name]
+		if !ok {
+			// The table file was conjoined or collected since we looked at it.
+			continue
+		}
+		src, err := nbs.rewriteTable(ctx, cs, repaired)
+		if err != nil {
+			return err
+		}
+		upstream[name] = src
+		replaced = append(replaced, cs)
+		nbs.logger.WithField("table_file", name.String()).Warn("repaired table file with corrupt chunks")
+	}
+
+	nbs.tables.upstream = upstream
+	for _, cs := range replaced {
+		if err := cs.close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteTable replaces the table file |cs| with a copy of it, built in memory, which takes the chunks in |repaired| in
+// place of the copies held by |cs|. Archive files are replaced by table files.
+func (nbs *NomsBlockStore) rewriteTable(ctx context.Context, cs chunkSource, repaired map[hash.Hash][]byte) (chunkSource, error) {
+	idx, err := cs.index()
+	if err != nil {
+		return nil, fmt.Errorf("unable to rewrite table file %s: %w", cs.hash().String(), err)
+	}
+
+	mt := newMemTable(math.MaxUint64)
+	for i := uint32(0); i < idx.chunkCount(); i++ {
+		var h hash.Hash
+		if _, err := idx.indexEntry(i, &h); err != nil {
+			return nil, err
+		}
+		data, ok := repaired[h]
+		if !ok {
+			data, err = getVerified(ctx, cs, h, nbs.stats)
+			if err != nil {
+				return nil, err
+			}
+		}
+		mt.addChunk(h, data)
+	}
+	// The name of the rewritten table file is derived from the order its chunks are written in, which can differ from
+	// the order the original was written in, so it takes the name of the original rather than its own.
+	_, data, chunkCount, _, err := mt.write(nil, nil, nbs.stats)
+	if err != nil {
+		return nil, err
+	}
+	if chunkCount != idx.chunkCount() {
+		return nil, fmt.Errorf("rewritten table file %s has %d chunks, expected %d", cs.hash().String(), chunkCount, idx.chunkCount())
+	}
+
+	return nbs.persister.(tableReplacer).replaceTable(ctx, cs.hash(), cs.suffix(), data, chunkCount, nbs.stats)
+}
+
+// getVerified reads the chunk |h| from |cs| and checks that its contents hash to |h|.
+func getVerified(ctx context.Context, cs chunkSource, h hash.Hash, stats *Stats) ([]byte, error) {
+	data, _, err := cs.get(ctx, h, nil, stats)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, fmt.Errorf("chunk %s not found in table file %s", h.String(), cs.hash().String())
+	}
+	if hash.Of(data) != h {
+		return nil, &CorruptChunkError{Chunk: h, cause: errors.New("hash mismatch")}
+	}
+	return data, nil
+}
+
+// corruptCopies returns the chunks of |hashes| which |cs| holds corrupt copies of.
+func corruptCopies(ctx context.Context, cs chunkSource, hashes hash.HashSet, stats *Stats) (hash.HashSet, error) {
+	bad := hash.NewHashSet()
+	for h := range hashes {
+		ok, _, err := cs.has(h, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if _, err := getVerified(ctx, cs, h, stats); err != nil {
+			var cce *CorruptChunkError
+			if !errors.As(err, &cce) {
+				return nil, err
+			}
+			bad.Insert(h)
+		}
+	}
+	return bad, nil
+}
+
+// corruptChunks returns every chunk which |cs| holds a corrupt copy of.
+func corruptChunks(ctx context.Context, cs chunkSource, stats *Stats) (hash.HashSet, error) {
+	idx, err := cs.index()
+	if err != nil {
+		return nil, fmt.Errorf("unable to check table file %s: %w", cs.hash().String(), err)
+	}
+	all := hash.NewHashSet()
+	for i := uint32(0); i < idx.chunkCount(); i++ {
+		var h hash.Hash
+		if _, err := idx.indexEntry(i, &h); err != nil {
+			return nil, err
+		}
+		all.Insert(h)
+	}
+	return corruptCopies(ctx, cs, all, stats)
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var repairTestChunks = [][]byte{[]byte("corrupt me"), []byte("leave me be"), []byte("me too")}
+
+// makeCorruptStore returns a local store holding a single table file with |repairTestChunks|, the first of which is
+// corrupt.
+func makeCorruptStore(t *testing.T) (st *NomsBlockStore, nomsDir string, fileID string, corrupt []byte) {
+	ctx := context.Background()
+	st, nomsDir, _ = makeTestLocalStore(t, defaultMaxTables)
+
+	data, addr, err := buildTable(repairTestChunks)
+	require.NoError(t, err)
+	corrupt = make([]byte, len(data))
+	copy(corrupt, data)
+	// The first chunk is written at the start of the table file.
+	corrupt[1] ^= 0xff
+
+	fileID = addr.String()
+	err = st.WriteTableFile(ctx, fileID, len(repairTestChunks), nil, func() (io.ReadCloser, uint64, error) {
+		return io.NopCloser(bytes.NewReader(corrupt)), uint64(len(corrupt)), nil
+	})
+	require.NoError(t, err)
+	err = st.AddTableFilesToManifest(ctx, map[string]int{fileID: len(repairTestChunks)}, noopGetAddrs)
+	require.NoError(t, err)
+	return st, nomsDir, fileID, corrupt
+}
+
+func goodCopyRepairer(requested hash.HashSet) ChunkRepairer {
+	return func(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+		requested.InsertAll(hashes)
+		return []chunks.Chunk{chunks.NewChunk(repairTestChunks[0])}, nil
+	}
+}
+
+func TestRepairCorruptChunks(t *testing.T) {
+	ctx := context.Background()
+	bad := computeAddr(repairTestChunks[0])
+
+	t.Run("NoRepairer", func(t *testing.T) {
+		st, _, _, _ := makeCorruptStore(t)
+		defer st.Close()
+
+		_, err := st.Get(ctx, bad)
+		var cce *CorruptChunkError
+		require.ErrorAs(t, err, &cce)
+		assert.Equal(t, bad, cce.Chunk)
+
+		c, err := st.Get(ctx, computeAddr(repairTestChunks[1]))
+		require.NoError(t, err)
+		assert.Equal(t, repairTestChunks[1], c.Data())
+	})
+
+	t.Run("RepairerWithoutGoodCopy", func(t *testing.T) {
+		st, nomsDir, fileID, corrupt := makeCorruptStore(t)
+		defer st.Close()
+
+		st.SetChunkRepairer(func(ctx context.Context, hashes hash.HashSet) ([]chunks.Chunk, error) {
+			return []chunks.Chunk{chunks.NewChunkWithHash(bad, []byte("not the chunk"))}, nil
+		})
+		_, err := st.Get(ctx, bad)
+		var cce *CorruptChunkError
+		require.ErrorAs(t, err, &cce)
+		assert.Contains(t, err.Error(), "no remote or backup has a good copy of chunk "+bad.String())
+
+		// Nothing is quarantined when the repair fails.
+		onDisk, err := os.ReadFile(filepath.Join(nomsDir, fileID))
+		require.NoError(t, err)
+		assert.Equal(t, corrupt, onDisk)
+		_, err = os.Stat(filepath.Join(nomsDir, quarantineDir, fileID))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		st, nomsDir, fileID, corrupt := makeCorruptStore(t)
+		defer func() {
+			st.Close()
+		}()
+
+		requested := hash.NewHashSet()
+		st.SetChunkRepairer(goodCopyRepairer(requested))
+		c, err := st.Get(ctx, bad)
+		require.NoError(t, err)
+		assert.Equal(t, repairTestChunks[0], c.Data())
+		assert.Equal(t, hash.NewHashSet(bad), requested)
+
+		for _, data := range repairTestChunks {
+			c, err := st.Get(ctx, computeAddr(data))
+			require.NoError(t, err)
+			assert.Equal(t, data, c.Data())
+		}
+
+		quarantined, err := os.ReadFile(filepath.Join(nomsDir, quarantineDir, fileID))
+		require.NoError(t, err)
+		assert.Equal(t, corrupt, quarantined)
+		_, err = os.Stat(filepath.Join(nomsDir, fileID))
+		require.NoError(t, err)
+
+		// The repaired table file is picked up by a freshly opened store.
+		require.NoError(t, st.Close())
+		st, err = NewLocalStore(ctx, st.Version(), nomsDir, defaultMemTableSize, NewUnlimitedMemQuotaProvider())
+		require.NoError(t, err)
+		c, err = st.Get(ctx, bad)
+		require.NoError(t, err)
+		assert.Equal(t, repairTestChunks[0], c.Data())
+	})
+
+	t.Run("QuarantineFails", func(t *testing.T) {
+		st, nomsDir, fileID, corrupt := makeCorruptStore(t)
+		defer st.Close()
+
+		// The quarantine directory can't be created, so the original must be left in place.
+		require.NoError(t, os.WriteFile(filepath.Join(nomsDir, quarantineDir), nil, 0644))
+		st.SetChunkRepairer(goodCopyRepairer(hash.NewHashSet()))
+		_, err := st.Get(ctx, bad)
+		require.Error(t, err)
+
+		onDisk, err := os.ReadFile(filepath.Join(nomsDir, fileID))
+		require.NoError(t, err)
+		assert.Equal(t, corrupt, onDisk)
+		c, err := st.Get(ctx, computeAddr(repairTestChunks[1]))
+		require.NoError(t, err)
+		assert.Equal(t, repairTestChunks[1], c.Data())
+	})
+
+	t.Run("GetMany", func(t *testing.T) {
+		st, _, _, _ := makeCorruptStore(t)
+		defer st.Close()
+
+		requested := hash.NewHashSet()
+		st.SetChunkRepairer(goodCopyRepairer(requested))
+		hashes := hash.NewHashSet()
+		for _, data := range repairTestChunks {
+			hashes.Insert(computeAddr(data))
+		}
+		var mu sync.Mutex
+		found := make(map[hash.Hash][]byte)
+		err := st.GetMany(ctx, hashes, func(_ context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			found[c.Hash()] = c.Data()
+		})
+		require.NoError(t, err)
+		assert.Equal(t, hash.NewHashSet(bad), requested)
+		require.Len(t, found, len(repairTestChunks))
+		for _, data := range repairTestChunks {
+			assert.Equal(t, data, found[computeAddr(data)])
+		}
+	})
+}
+
+func TestFSTablePersisterReplaceArchive(t *testing.T) {
+	ctx := context.Background()
+	dir := makeTempDir(t)
+	defer file.RemoveAll(dir)
+	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{}).(*fsTablePersister)
+
+	data, name, err := buildTable(repairTestChunks)
+	require.NoError(t, err)
+	archive := []byte("not really an archive")
+	archivePath := filepath.Join(dir, name.String()+ArchiveFileSuffix)
+	require.NoError(t, os.WriteFile(archivePath, archive, 0644))
+
+	src, err := fts.replaceTable(ctx, name, ArchiveFileSuffix, data, uint32(len(repairTestChunks)), &Stats{})
+	require.NoError(t, err)
+	defer src.close()
+	assert.Equal(t, name, src.hash())
+	assert.Equal(t, "", src.suffix())
+
+	_, err = os.Stat(archivePath)
+	assert.True(t, os.IsNotExist(err))
+	quarantined, err := os.ReadFile(filepath.Join(dir, quarantineDir, name.String()+ArchiveFileSuffix))
+	require.NoError(t, err)
+	assert.Equal(t, archive, quarantined)
+	for _, c := range repairTestChunks {
+		data, _, err := src.get(ctx, computeAddr(c), nil, &Stats{})
+		require.NoError(t, err)
+		assert.Equal(t, c, data)
+	}
+}
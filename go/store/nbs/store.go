@@ -29,6 +29,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -133,6 +134,9 @@ type NomsBlockStore struct {
 
 	hasCache *lru.TwoQueueCache[hash.Hash, struct{}]
 
+	// repairer, when set, is used to repair corrupt chunks found on reads.
+	repairer ChunkRepairer
+
 	stats *Stats
 }
 
@@ -272,17 +276,41 @@ func (nbs *NomsBlockStore) handleUnlockedRead(ctx context.Context, gcb gcBehavio
 	}
 }
 
+// ConjoinPlan returns the names of the table files the store would conjoin if it conjoined now, or nil if it doesn't
+// need to.
+func (nbs *NomsBlockStore) ConjoinPlan() ([]string, error) {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if !nbs.conjoiner.conjoinRequired(nbs.tables) {
+		return nil, nil
+	}
+	upstream := nbs.upstream
+	if upstream.NumAppendixSpecs() != 0 {
+		upstream, _ = upstream.removeAppendixSpecs()
+	}
+	conjoinees, _, err := nbs.conjoiner.chooseConjoinees(upstream.specs)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(conjoinees))
+	for i, spec := range conjoinees {
+		names[i] = spec.GetName()
+	}
+	return names, nil
+}
+
 func (nbs *NomsBlockStore) startConjoinIfRequired(ctx context.Context) error {
 	if nbs.conjoinOp != nil {
 		return nil
 	}
 	if nbs.conjoiner.conjoinRequired(nbs.tables) {
 		nbs.logger.WithField("upstream_len", len(nbs.tables.upstream)).Info("beginning conjoin of database")
-		var op = &conjoinOperation{}
+		var op = &conjoinOperation{started: time.Now()}
 		err := op.prepareConjoin(ctx, nbs.conjoiner, nbs.upstream)
 		if err != nil {
 			return err
 		}
+		nbs.logger.WithField("conjoinees", len(op.conjoinees)).Debug("chose table files to conjoin")
 		nbs.conjoinOp = op
 		go func(ctx context.Context) {
 			// We use context.Background(), since this context will outlive the caller
@@ -334,7 +362,12 @@ func (nbs *NomsBlockStore) finalizeConjoin(ctx context.Context, err error) {
 	nbs.upstream = newUpstream
 	oldTables := nbs.tables
 	nbs.tables = newTables
-	nbs.logger.WithField("new_upstream_len", len(nbs.tables.upstream)).Info("conjoin completed successfully")
+	nbs.logger.WithFields(logrus.Fields{
+		"new_upstream_len": len(nbs.tables.upstream),
+		"conjoinees":       len(nbs.conjoinOp.conjoinees),
+		"chunks":           nbs.conjoinOp.conjoined.chunkCount,
+		"duration":         time.Since(nbs.conjoinOp.started).String(),
+	}).Info("conjoin completed successfully")
 	err = oldTables.close()
 	if err != nil {
 		nbs.logger.WithError(err).Warn("during conjoin, closing old table files failed with error")
@@ -756,6 +789,8 @@ func (nbs *NomsBlockStore) waitForGC(ctx context.Context) error {
 
 func (nbs *NomsBlockStore) Put(ctx context.Context, c chunks.Chunk, getAddrs chunks.GetAddrsCurry) error {
 	valctx.ValidateContext(ctx)
+	ctx, span := tracer.Start(ctx, "nbs.Put", trace.WithAttributes(attribute.Int("size", c.Size())))
+	defer span.End()
 	return nbs.putChunk(ctx, c, getAddrs, nbs.refCheck)
 }
 
@@ -888,6 +923,7 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		nbs.stats.ChunksPerGet.Sample(1)
 	}()
 
+	attempted := hash.NewHashSet()
 	for {
 		nbs.mu.Lock()
 		if nbs.memtable != nil {
@@ -915,7 +951,11 @@ func (nbs *NomsBlockStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk,
 		data, gcb, err := tables.get(ctx, h, keeper, nbs.stats)
 		needContinue, err := nbs.handleUnlockedRead(ctx, gcb, endRead, err)
 		if err != nil {
-			return chunks.EmptyChunk, err
+			repaired, err := nbs.tryRepair(ctx, err, attempted)
+			if !repaired {
+				return chunks.EmptyChunk, err
+			}
+			continue
 		}
 		if needContinue {
 			continue
@@ -971,6 +1011,7 @@ func (nbs *NomsBlockStore) getManyWithFunc(
 	}()
 
 	reqs := toGetRecords(hashes)
+	attempted := hash.NewHashSet()
 
 	const ioParallelism = 16
 	for {
@@ -1010,7 +1051,11 @@ func (nbs *NomsBlockStore) getManyWithFunc(
 		}()
 		needContinue, err := nbs.handleUnlockedRead(ctx, gcb, endRead, err)
 		if err != nil {
-			return err
+			repaired, err := nbs.tryRepair(ctx, err, attempted)
+			if !repaired {
+				return err
+			}
+			continue
 		}
 		if needContinue {
 			continue
@@ -1111,6 +1156,8 @@ func (nbs *NomsBlockStore) Has(ctx context.Context, h hash.Hash) (bool, error) {
 
 func (nbs *NomsBlockStore) HasMany(ctx context.Context, hashes hash.HashSet) (hash.HashSet, error) {
 	valctx.ValidateContext(ctx)
+	ctx, span := tracer.Start(ctx, "nbs.HasMany", trace.WithAttributes(attribute.Int("num_hashes", len(hashes))))
+	defer span.End()
 	return nbs.hasManyDep(ctx, hashes, gcDependencyMode_TakeDependency)
 }
 
@@ -1307,6 +1354,8 @@ func (nbs *NomsBlockStore) Root(ctx context.Context) (hash.Hash, error) {
 
 func (nbs *NomsBlockStore) Commit(ctx context.Context, current, last hash.Hash) (success bool, err error) {
 	valctx.ValidateContext(ctx)
+	ctx, span := tracer.Start(ctx, "nbs.Commit")
+	defer span.End()
 	return nbs.commit(ctx, current, last, nbs.refCheck)
 }
 
@@ -1641,6 +1690,17 @@ func (nbs *NomsBlockStore) Size(ctx context.Context) (uint64, error) {
 	return size, nil
 }
 
+// PendingSize returns the size, in bytes, of the chunks which have been written to the store but not yet persisted
+// to a table file or the journal.
+func (nbs *NomsBlockStore) PendingSize() uint64 {
+	nbs.mu.Lock()
+	defer nbs.mu.Unlock()
+	if nbs.memtable == nil {
+		return 0
+	}
+	return nbs.memtable.totalData
+}
+
 func (nbs *NomsBlockStore) chunkSourcesByAddr() (map[hash.Hash]chunkSource, error) {
 	css := make(map[hash.Hash]chunkSource, len(nbs.tables.upstream)+len(nbs.tables.novel))
 	for _, cs := range nbs.tables.upstream {
@@ -1679,6 +1739,12 @@ func (nbs *NomsBlockStore) Path() (string, bool) {
 
 // WriteTableFile will read a table file from the provided reader and write it to the TableFileStore
 func (nbs *NomsBlockStore) WriteTableFile(ctx context.Context, fileName string, numChunks int, contentHash []byte, getRd func() (io.ReadCloser, uint64, error)) error {
+	return nbs.writeTableFile(ctx, fileName, numChunks, getRd, nbs.Get)
+}
+
+// writeTableFile writes the table file |fileName|. If it's a delta file, the table file is re-materialized with
+// base chunks read from |getBase|.
+func (nbs *NomsBlockStore) writeTableFile(ctx context.Context, fileName string, numChunks int, getRd func() (io.ReadCloser, uint64, error), getBase func(context.Context, hash.Hash) (chunks.Chunk, error)) error {
 	valctx.ValidateContext(ctx)
 	tfp, ok := nbs.persister.(tableFilePersister)
 	if !ok {
@@ -1690,6 +1756,9 @@ func (nbs *NomsBlockStore) WriteTableFile(ctx context.Context, fileName string,
 		return err
 	}
 	defer r.Close()
+	if strings.HasSuffix(fileName, DeltaFileSuffix) {
+		return writeDeltaTableFile(ctx, tfp, strings.TrimSuffix(fileName, DeltaFileSuffix), numChunks, r, getBase)
+	}
 	return tfp.CopyTableFile(ctx, r, fileName, sz, uint32(numChunks))
 }
 
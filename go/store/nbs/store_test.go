@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -238,7 +239,7 @@ func TestNBSPruneTableFiles(t *testing.T) {
 		require.NoError(t, err)
 		curr := set.NewStrSet(nil)
 		for _, fi := range infos {
-			if fi.Name() != manifestFileName && fi.Name() != lockFileName {
+			if fi.Name() != manifestFileName && fi.Name() != lockFileName && !strings.HasSuffix(fi.Name(), tableBloomFilterSuffix) {
 				curr.Add(fi.Name())
 			}
 		}
@@ -269,9 +270,16 @@ func TestNBSPruneTableFiles(t *testing.T) {
 	infos, err := os.ReadDir(nomsDir)
 	require.NoError(t, err)
 
-	// assert that we only have files for current sources,
-	// the manifest, and the lock file
-	assert.Equal(t, len(sources)+2, len(infos))
+	// assert that we only have files for current sources, their
+	// bloom filters, the manifest, and the lock file
+	var bloomFilters int
+	for _, fi := range infos {
+		if tableName, ok := strings.CutSuffix(fi.Name(), tableBloomFilterSuffix); ok {
+			assert.True(t, postGC.Contains(tableName))
+			bloomFilters++
+		}
+	}
+	assert.Equal(t, len(sources)+2, len(infos)-bloomFilters)
 
 	size, err := st.Size(ctx)
 	require.NoError(t, err)
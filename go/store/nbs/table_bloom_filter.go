@@ -0,0 +1,139 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+const (
+	// tableBloomFilterSuffix is the suffix of the file storing the bloom filter of a table file, next to it.
+	tableBloomFilterSuffix = ".bloom"
+
+	// bloomFilterBitsPerChunk and bloomFilterHashes give a false positive rate of about 1%.
+	bloomFilterBitsPerChunk = 10
+	bloomFilterHashes       = 7
+
+	bloomFilterMagic      = "NBBF"
+	bloomFilterHeaderSize = 4 + uint32Size + uint32Size
+)
+
+// tableBloomFilter is a bloom filter of the addresses of the chunks in a table file. It lets Has and HasMany skip
+// the index of a table file for most addresses that aren't in the table file.
+type tableBloomFilter struct {
+	chunkCount uint32
+	bits       []uint64
+}
+
+func newTableBloomFilter(chunkCount uint32) *tableBloomFilter {
+	words := (uint64(chunkCount)*bloomFilterBitsPerChunk + 63) / 64
+	return &tableBloomFilter{chunkCount: chunkCount, bits: make([]uint64, max(words, 1))}
+}
+
+// buildTableBloomFilter returns a bloom filter of the addresses in |idx|.
+func buildTableBloomFilter(idx tableIndex) (*tableBloomFilter, error) {
+	f := newTableBloomFilter(idx.chunkCount())
+	var h hash.Hash
+	for i := uint32(0); i < idx.chunkCount(); i++ {
+		if _, err := idx.indexEntry(i, &h); err != nil {
+			return nil, err
+		}
+		f.add(&h)
+	}
+	return f, nil
+}
+
+// probes returns the two hashes from which the bits of |h| are derived. Addresses are uniformly distributed, so
+// their bytes are used directly.
+func (f *tableBloomFilter) probes(h *hash.Hash) (uint64, uint64) {
+	return binary.BigEndian.Uint64(h[:8]), binary.BigEndian.Uint64(h[8:16]) | 1
+}
+
+func (f *tableBloomFilter) add(h *hash.Hash) {
+	m := uint64(len(f.bits)) * 64
+	h1, h2 := f.probes(h)
+	for i := uint64(0); i < bloomFilterHashes; i++ {
+		bit := (h1 + i*h2) % m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// mayContain returns false if |h| is not in the table file.
+func (f *tableBloomFilter) mayContain(h *hash.Hash) bool {
+	m := uint64(len(f.bits)) * 64
+	h1, h2 := f.probes(h)
+	for i := uint64(0); i < bloomFilterHashes; i++ {
+		bit := (h1 + i*h2) % m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// marshal encodes the filter as a magic number, the chunk count, the number of words, the words, and a CRC of
+// everything before it.
+func (f *tableBloomFilter) marshal() []byte {
+	buf := make([]byte, bloomFilterHeaderSize+len(f.bits)*uint64Size+checksumSize)
+	copy(buf, bloomFilterMagic)
+	binary.BigEndian.PutUint32(buf[4:], f.chunkCount)
+	binary.BigEndian.PutUint32(buf[4+uint32Size:], uint32(len(f.bits)))
+	off := bloomFilterHeaderSize
+	for _, w := range f.bits {
+		binary.BigEndian.PutUint64(buf[off:], w)
+		off += uint64Size
+	}
+	binary.BigEndian.PutUint32(buf[off:], crc(buf[:off]))
+	return buf
+}
+
+var errInvalidBloomFilter = errors.New("invalid table file bloom filter")
+
+func unmarshalTableBloomFilter(buf []byte) (*tableBloomFilter, error) {
+	if len(buf) < bloomFilterHeaderSize+checksumSize || string(buf[:4]) != bloomFilterMagic {
+		return nil, errInvalidBloomFilter
+	}
+	words := int(binary.BigEndian.Uint32(buf[4+uint32Size:]))
+	off := bloomFilterHeaderSize + words*uint64Size
+	if words == 0 || len(buf) != off+checksumSize {
+		return nil, errInvalidBloomFilter
+	}
+	if crc(buf[:off]) != binary.BigEndian.Uint32(buf[off:]) {
+		return nil, errInvalidBloomFilter
+	}
+	f := &tableBloomFilter{chunkCount: binary.BigEndian.Uint32(buf[4:]), bits: make([]uint64, words)}
+	for i := range f.bits {
+		f.bits[i] = binary.BigEndian.Uint64(buf[bloomFilterHeaderSize+i*uint64Size:])
+	}
+	return f, nil
+}
+
+// loadTableBloomFilter reads the bloom filter stored next to the table file at |path|. It returns nil if there is
+// no valid filter for a table file of |chunkCount| chunks.
+func loadTableBloomFilter(path string, chunkCount uint32) *tableBloomFilter {
+	buf, err := os.ReadFile(path + tableBloomFilterSuffix)
+	if err != nil {
+		return nil
+	}
+	f, err := unmarshalTableBloomFilter(buf)
+	if err != nil || f.chunkCount != chunkCount {
+		return nil
+	}
+	return f
+}
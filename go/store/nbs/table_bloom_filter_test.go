@@ -0,0 +1,126 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/file"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestTableBloomFilter(t *testing.T) {
+	const count = 10_000
+	f := newTableBloomFilter(count)
+	added := make([]hash.Hash, count)
+	for i := range added {
+		added[i] = chunks.NewChunk([]byte(fmt.Sprintf("chunk %d", i))).Hash()
+		f.add(&added[i])
+	}
+
+	check := func(t *testing.T, f *tableBloomFilter) {
+		for i := range added {
+			require.True(t, f.mayContain(&added[i]))
+		}
+		var falsePositives int
+		for i := 0; i < count; i++ {
+			h := chunks.NewChunk([]byte(fmt.Sprintf("missing %d", i))).Hash()
+			if f.mayContain(&h) {
+				falsePositives++
+			}
+		}
+		assert.Less(t, falsePositives, count/20)
+	}
+
+	t.Run("no false negatives", func(t *testing.T) {
+		check(t, f)
+	})
+
+	t.Run("marshal", func(t *testing.T) {
+		buf := f.marshal()
+		loaded, err := unmarshalTableBloomFilter(buf)
+		require.NoError(t, err)
+		assert.Equal(t, f, loaded)
+		check(t, loaded)
+
+		buf[bloomFilterHeaderSize] ^= 0xff
+		_, err = unmarshalTableBloomFilter(buf)
+		assert.ErrorIs(t, err, errInvalidBloomFilter)
+		_, err = unmarshalTableBloomFilter(buf[:len(buf)-1])
+		assert.ErrorIs(t, err, errInvalidBloomFilter)
+	})
+
+	t.Run("load", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "table")
+		assert.Nil(t, loadTableBloomFilter(path, count))
+		require.NoError(t, os.WriteFile(path+tableBloomFilterSuffix, f.marshal(), 0666))
+		assert.NotNil(t, loadTableBloomFilter(path, count))
+		// the filter of a different table file
+		assert.Nil(t, loadTableBloomFilter(path, count+1))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		f := newTableBloomFilter(0)
+		h := hash.Of([]byte("chunk"))
+		assert.False(t, f.mayContain(&h))
+		loaded, err := unmarshalTableBloomFilter(f.marshal())
+		require.NoError(t, err)
+		assert.Equal(t, f, loaded)
+	})
+}
+
+func TestFSTablePersisterBloomFilter(t *testing.T) {
+	ctx := context.Background()
+	dir := makeTempDir(t)
+	defer file.RemoveAll(dir)
+	fts := newFSTablePersister(dir, &UnlimitedQuotaProvider{})
+
+	name, err := writeTableData(dir, testChunks...)
+	require.NoError(t, err)
+	bloomPath := filepath.Join(dir, name.String()+tableBloomFilterSuffix)
+
+	src, err := fts.Open(ctx, name, uint32(len(testChunks)), &Stats{})
+	require.NoError(t, err)
+	_, err = os.Stat(bloomPath)
+	require.NoError(t, err, "the bloom filter is written when the table file is opened")
+	require.NoError(t, src.close())
+
+	src, err = fts.Open(ctx, name, uint32(len(testChunks)), &Stats{})
+	require.NoError(t, err)
+	defer src.close()
+	ftr := src.(*fileTableReader)
+	require.NotNil(t, ftr.bloom)
+	assert.False(t, ftr.bloomBuilt)
+
+	for _, c := range testChunks {
+		h := hash.Of(c)
+		ok, _, err := src.has(h, nil)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	missing := hash.Of([]byte("missing"))
+	ok, _, err := src.has(missing, nil)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -70,7 +70,7 @@ func NewCompressedChunk(h hash.Hash, buff []byte) (CompressedChunk, error) {
 	compressedData := buff[:dataLen]
 
 	if chksum != crc(compressedData) {
-		return CompressedChunk{}, errors.New("checksum error")
+		return CompressedChunk{}, &CorruptChunkError{Chunk: h, cause: errors.New("checksum error")}
 	}
 
 	return CompressedChunk{H: h, FullCompressedChunk: buff, CompressedData: compressedData}, nil
@@ -88,7 +88,7 @@ func (cmp CompressedChunk) ToChunk() (chunks.Chunk, error) {
 
 	data, err := snappy.Decode(nil, cmp.CompressedData)
 	if err != nil {
-		return chunks.Chunk{}, err
+		return chunks.Chunk{}, &CorruptChunkError{Chunk: cmp.H, cause: err}
 	}
 	return chunks.NewChunkWithHash(cmp.H, data), nil
 }
@@ -172,6 +172,8 @@ type tableReader struct {
 	idx       tableIndex
 	r         tableReaderAt
 	blockSize uint64
+	// bloom is an optional filter of the addresses in |idx|.
+	bloom *tableBloomFilter
 }
 
 // newTableReader parses a valid nbs table byte stream and returns a reader. buff must end with an NBS index
@@ -201,6 +203,11 @@ func (tr tableReader) hasMany(addrs []hasRecord, keeper keeperF) (bool, gcBehavi
 			continue
 		}
 
+		if tr.bloom != nil && !tr.bloom.mayContain(addr.a) {
+			remaining = true
+			continue
+		}
+
 		// Use binary search to find the location of the addr.prefix in
 		// the prefixes array. filterIdx will be at the first entry
 		// where its prefix >= addr.prefix after this search.
@@ -264,6 +271,9 @@ func (tr tableReader) index() (tableIndex, error) {
 
 // returns true iff |h| can be found in this table.
 func (tr tableReader) has(h hash.Hash, keeper keeperF) (bool, gcBehavior, error) {
+	if tr.bloom != nil && !tr.bloom.mayContain(&h) {
+		return false, gcBehavior_Continue, nil
+	}
 	_, ok, err := tr.idx.lookup(&h)
 	if ok && keeper != nil && keeper(h) {
 		return false, gcBehavior_Block, nil
@@ -765,6 +775,7 @@ func (tr tableReader) clone() (tableReader, error) {
 		idx:       idx,
 		r:         r,
 		blockSize: tr.blockSize,
+		bloom:     tr.bloom,
 	}, nil
 }
 
@@ -0,0 +1,145 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package benchmark
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/prolly"
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+const compositeKeyRows = 100_000
+
+// BenchmarkImportCompositeKeys writes rows with a multi-column integer primary key into a map in random order, the
+// shape of a bulk import. Each sub-benchmark runs with and without fixed access to the key fields, which is what
+// enables comparing their leading fields a word at a time.
+func BenchmarkImportCompositeKeys(b *testing.B) {
+	ctx := context.Background()
+	kd, vd := compositeKeyDescs()
+	ns := newTestNodeStore()
+	tups := generateCompositeKeyTuples(kd, vd, compositeKeyRows, ns)
+	rand.New(rand.NewSource(0)).Shuffle(len(tups), func(i, j int) {
+		tups[i], tups[j] = tups[j], tups[i]
+	})
+
+	bench := func(b *testing.B, kd val.TupleDesc) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			m, err := prolly.NewMapFromTuples(ctx, ns, kd, vd)
+			require.NoError(b, err)
+			mut := m.Mutate()
+			for _, tup := range tups {
+				require.NoError(b, mut.Put(ctx, tup[0], tup[1]))
+			}
+			_, err = mut.Map(ctx)
+			require.NoError(b, err)
+		}
+	}
+	b.Run("fixed access", func(b *testing.B) {
+		bench(b, kd)
+	})
+	b.Run("without fixed access", func(b *testing.B) {
+		bench(b, kd.WithoutFixedAccess())
+	})
+}
+
+// BenchmarkScanCompositeKeys iterates the middle half of a map with a multi-column integer primary key through a
+// range, which compares every key against the range's bounds.
+func BenchmarkScanCompositeKeys(b *testing.B) {
+	ctx := context.Background()
+	kd, vd := compositeKeyDescs()
+	ns := newTestNodeStore()
+	tups := generateCompositeKeyTuples(kd, vd, compositeKeyRows, ns)
+
+	bench := func(b *testing.B, kd val.TupleDesc) {
+		tt := make([]val.Tuple, 0, len(tups)*2)
+		for i := range tups {
+			tt = append(tt, tups[i][0], tups[i][1])
+		}
+		m, err := prolly.NewMapFromTuples(ctx, ns, kd, vd, tt...)
+		require.NoError(b, err)
+		rng := prolly.OpenStopRange(ctx, tups[len(tups)/4][0], tups[len(tups)*3/4][0], kd)
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			iter, err := m.IterRange(ctx, rng)
+			require.NoError(b, err)
+			for {
+				_, _, err = iter.Next(ctx)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(b, err)
+			}
+		}
+	}
+	b.Run("fixed access", func(b *testing.B) {
+		bench(b, kd)
+	})
+	b.Run("without fixed access", func(b *testing.B) {
+		bench(b, kd.WithoutFixedAccess())
+	})
+}
+
+func compositeKeyDescs() (kd, vd val.TupleDesc) {
+	kd = val.NewTupleDescriptor(
+		val.Type{Enc: val.Int32Enc, Nullable: false},
+		val.Type{Enc: val.Int64Enc, Nullable: false},
+		val.Type{Enc: val.Int64Enc, Nullable: false},
+	)
+	vd = val.NewTupleDescriptor(
+		val.Type{Enc: val.Int64Enc, Nullable: true},
+		val.Type{Enc: val.Int64Enc, Nullable: true},
+	)
+	return
+}
+
+// generateCompositeKeyTuples returns |size| sorted tuples whose leading key fields are shared by many rows, like a
+// (tenant, account, id) primary key.
+func generateCompositeKeyTuples(kd, vd val.TupleDesc, size int, ns tree.NodeStore) [][2]val.Tuple {
+	src := rand.NewSource(0)
+
+	tups := make([][2]val.Tuple, size)
+	kb := val.NewTupleBuilder(kd, ns)
+	vb := val.NewTupleBuilder(vd, ns)
+
+	var err error
+	for i := range tups {
+		kb.PutInt32(0, int32(i/(size/4)))
+		kb.PutInt64(1, int64(i/256))
+		kb.PutInt64(2, int64(i))
+		tups[i][0], err = kb.Build(shared)
+		if err != nil {
+			panic(err)
+		}
+
+		vb.PutInt64(0, src.Int63())
+		vb.PutInt64(1, src.Int63())
+		tups[i][1], err = vb.Build(shared)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return tups
+}
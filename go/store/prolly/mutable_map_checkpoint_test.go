@@ -43,6 +43,9 @@ func TestMutableMapCheckpoints(t *testing.T) {
 			t.Run("revert post-flush", func(t *testing.T) {
 				testRevertAfterFlush(t, s)
 			})
+			t.Run("revert post-background-flush", func(t *testing.T) {
+				testRevertAfterBackgroundFlush(t, s)
+			})
 		})
 	}
 }
@@ -169,3 +172,47 @@ func testRevertAfterFlush(t *testing.T, scale int) {
 		assert.False(t, ok)
 	}
 }
+
+func testRevertAfterBackgroundFlush(t *testing.T, scale int) {
+	// create map with |s| even int64s
+	ctx := context.Background()
+	m := ascendingIntMapWithStep(t, scale, 2)
+	// flush every few writes, so that writes are made while flushes are in progress
+	mut := m.Mutate().WithMaxPending(max(scale/50, 1)).WithBackgroundFlush()
+
+	// create 2 edit sets: pre- and post- checkpoint
+	edits := ascendingTuplesWithStepAndStart(scale/5, 2, 1)
+	pre, post := edits[:scale/10], edits[scale/10:]
+
+	for _, ed := range pre {
+		err := mut.Put(ctx, ed[0], ed[1])
+		require.NoError(t, err)
+	}
+
+	err := mut.Checkpoint(ctx)
+	require.NoError(t, err)
+
+	for _, ed := range post {
+		err = mut.Put(ctx, ed[0], ed[1])
+		require.NoError(t, err)
+	}
+
+	for _, ed := range edits {
+		ok, err := mut.Has(ctx, ed[0])
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	mut.Revert(ctx)
+
+	for _, ed := range pre {
+		ok, err := mut.Has(ctx, ed[0])
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+	for _, ed := range post {
+		ok, err := mut.Has(ctx, ed[0])
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+}
@@ -460,6 +460,56 @@ func testBulkInserts(t *testing.T, size int) {
 	}
 }
 
+func TestMutableMapBackgroundFlush(t *testing.T) {
+	ctx := context.Background()
+	for _, size := range []int{10, 100, 1000, 10_000} {
+		t.Run(fmt.Sprintf("size %d", size), func(t *testing.T) {
+			orig := ascendingIntMapWithStep(t, size, 2)
+			syncMut := orig.Mutate().WithMaxPending(8)
+			asyncMut := orig.Mutate().WithMaxPending(8).WithBackgroundFlush()
+
+			// insert odd keys, update every 3rd key and delete every 5th key
+			for i := 0; i < size*2; i++ {
+				k := int64(i)
+				var key, value val.Tuple
+				switch {
+				case i%5 == 0:
+					key = makeDelete(k)
+				case i%3 == 0:
+					key, value = makePut(k, -k)
+				default:
+					key, value = makePut(k, k)
+				}
+				for _, mut := range []*MutableMap{syncMut, asyncMut} {
+					var err error
+					if value == nil {
+						err = mut.Delete(ctx, key)
+					} else {
+						err = mut.Put(ctx, key, value)
+					}
+					require.NoError(t, err)
+				}
+
+				// reads see writes made while a flush is in progress
+				ok, err := asyncMut.Has(ctx, key)
+				require.NoError(t, err)
+				assert.Equal(t, value != nil, ok)
+				err = asyncMut.Get(ctx, key, func(k, v val.Tuple) error {
+					assert.Equal(t, value, v)
+					return nil
+				})
+				require.NoError(t, err)
+			}
+
+			expected, err := syncMut.Map(ctx)
+			require.NoError(t, err)
+			actual, err := asyncMut.Map(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, expected.HashOf(), actual.HashOf())
+		})
+	}
+}
+
 func testInternalNodeSplits(t *testing.T) {
 	const n = 100_000
 	var err error
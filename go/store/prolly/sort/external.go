@@ -42,6 +42,8 @@ type tupleSorter struct {
 	fileCnt   int
 	batchSize int
 	tmpProv   tempfiles.TempFileProvider
+	// quota is |tmpProv| if it limits the scratch space the sorter's files may use, and nil otherwise.
+	quota tempfiles.Quota
 }
 
 func NewTupleSorter(batchSize, fileMax int, keyCmp func(val.Tuple, val.Tuple) bool, tmpProv tempfiles.TempFileProvider) *tupleSorter {
@@ -56,6 +58,7 @@ func NewTupleSorter(batchSize, fileMax int, keyCmp func(val.Tuple, val.Tuple) bo
 		keyCmp:    keyCmp,
 		tmpProv:   tmpProv,
 	}
+	ret.quota, _ = tmpProv.(tempfiles.Quota)
 	ret.inProg = newKeyMem(batchSize)
 	return ret
 }
@@ -81,7 +84,7 @@ func (a *tupleSorter) Flush(ctx context.Context) (iter keyIterable, err error) {
 	if err != nil {
 		return nil, err
 	}
-	allKeys := newKeyFile(newF, a.inProg.byteLim)
+	allKeys := newKeyFile(newF, a.inProg.byteLim, a.quota)
 	defer func() {
 		if err != nil {
 			allKeys.Close()
@@ -123,7 +126,7 @@ func (a *tupleSorter) flushMem(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		newFile, err := a.inProg.flush(newF, a.keyCmp)
+		newFile, err := a.inProg.flush(newF, a.keyCmp, a.quota)
 		if err != nil {
 			return err
 		}
@@ -161,13 +164,13 @@ func (a *tupleSorter) shouldCompact() (int, bool) {
 }
 
 // compact merges the first `a.fileMax` files in `a.files[level]` into a single sorted file which is added to `a.files[level+1]`
-func (a *tupleSorter) compact(ctx context.Context, level int) error {
+func (a *tupleSorter) compact(ctx context.Context, level int) (err error) {
 	newF, err := a.newFile()
 	if err != nil {
 		return err
 	}
-	outF := newKeyFile(newF, a.batchSize)
-	func() {
+	outF := newKeyFile(newF, a.batchSize, a.quota)
+	defer func() {
 		if err != nil {
 			outF.Close()
 		}
@@ -215,11 +218,12 @@ func (k *keyMem) insert(key val.Tuple) bool {
 	return true
 }
 
-func (k *keyMem) flush(f *os.File, cmp func(val.Tuple, val.Tuple) bool) (*keyFile, error) {
+func (k *keyMem) flush(f *os.File, cmp func(val.Tuple, val.Tuple) bool, quota tempfiles.Quota) (*keyFile, error) {
 	k.sort(cmp)
-	kf := newKeyFile(f, k.byteLim)
+	kf := newKeyFile(f, k.byteLim, quota)
 	for _, k := range k.keys {
 		if err := kf.append(k); err != nil {
+			kf.Close()
 			return nil, err
 		}
 	}
@@ -266,14 +270,17 @@ func (k *keyMem) sort(cmp func(val.Tuple, val.Tuple) bool) {
 	})
 }
 
-func newKeyFile(f *os.File, batchSize int) *keyFile {
-	return &keyFile{f: f, buf: bufio.NewWriterSize(f, batchSize), batchSize: batchSize}
+func newKeyFile(f *os.File, batchSize int, quota tempfiles.Quota) *keyFile {
+	return &keyFile{f: f, buf: bufio.NewWriterSize(f, batchSize), batchSize: batchSize, quota: quota}
 }
 
 type keyFile struct {
 	f         *os.File
 	buf       *bufio.Writer
 	batchSize int
+	// quota, if not nil, has |size| bytes reserved for the file, which are released when it is removed.
+	quota tempfiles.Quota
+	size  int64
 }
 
 func (f *keyFile) IterAll(ctx context.Context) (KeyIter, error) {
@@ -285,19 +292,27 @@ func (f *keyFile) IterAll(ctx context.Context) (KeyIter, error) {
 	}
 	file := f.f
 	f.f = nil
-	return &keyFileReader{buf: bufio.NewReader(file), f: file}, nil
+	return &keyFileReader{buf: bufio.NewReader(file), f: file, quota: f.quota, size: f.size}, nil
 }
 
 func (f *keyFile) Close() {
 	if f != nil && f.f != nil {
 		f.f.Close()
 		os.Remove(f.f.Name())
+		releaseScratch(f.quota, f.size)
 	}
 	return
 }
 
 // append writes |keySize|key| to the intermediate file
 func (f *keyFile) append(k val.Tuple) error {
+	if f.quota != nil {
+		if err := f.quota.Reserve(int64(keyLenSz + len(k))); err != nil {
+			return err
+		}
+		f.size += int64(keyLenSz + len(k))
+	}
+
 	v := uint32(len(k))
 	var sizeBuf [4]byte
 	writeUint32(sizeBuf[:], v)
@@ -313,8 +328,16 @@ func (f *keyFile) append(k val.Tuple) error {
 }
 
 type keyFileReader struct {
-	buf *bufio.Reader
-	f   *os.File
+	buf   *bufio.Reader
+	f     *os.File
+	quota tempfiles.Quota
+	size  int64
+}
+
+func releaseScratch(quota tempfiles.Quota, size int64) {
+	if quota != nil {
+		quota.Release(size)
+	}
 }
 
 const (
@@ -348,6 +371,7 @@ func (r *keyFileReader) Close() {
 	if r != nil && r.f != nil {
 		r.f.Close()
 		os.Remove(r.f.Name())
+		releaseScratch(r.quota, r.size)
 	}
 }
 
@@ -466,7 +490,10 @@ func (m *fileMerger) run(ctx context.Context) error {
 			return m.out.buf.Flush()
 		}
 		reader := heap.Pop(m.mq).(*mergeFileReader)
-		m.out.append(reader.head)
+		if err := m.out.append(reader.head); err != nil {
+			reader.iter.Close()
+			return err
+		}
 		if ok, err := reader.next(ctx); ok {
 			heap.Push(m.mq, reader)
 		} else {
@@ -485,3 +512,57 @@ func (m *fileMerger) Close() {
 		}
 	}
 }
+
+// MergeKeyIters returns a KeyIter over the tuples of |iters|, each of which must already be sorted by |keyCmp|, in
+// sorted order. The returned iter takes ownership of |iters| and closes them when it's closed.
+func MergeKeyIters(ctx context.Context, keyCmp func(val.Tuple, val.Tuple) bool, iters ...KeyIter) (KeyIter, error) {
+	mq := &mergeQueue{keyCmp: keyCmp}
+	for i, iter := range iters {
+		reader, err := newMergeFileReader(ctx, iter)
+		if err == nil {
+			mq.files = append(mq.files, reader)
+			continue
+		}
+		iter.Close()
+		if !errors.Is(err, io.EOF) {
+			// empty iters are excluded from the merge queue
+			for _, f := range mq.files {
+				f.iter.Close()
+			}
+			for _, rest := range iters[i+1:] {
+				rest.Close()
+			}
+			return nil, err
+		}
+	}
+	heap.Init(mq)
+	return &mergedKeyIter{mq: mq}, nil
+}
+
+type mergedKeyIter struct {
+	mq *mergeQueue
+}
+
+func (m *mergedKeyIter) Next(ctx context.Context) (val.Tuple, error) {
+	if m.mq.Len() == 0 {
+		return nil, io.EOF
+	}
+	reader := heap.Pop(m.mq).(*mergeFileReader)
+	head := reader.head
+	if ok, err := reader.next(ctx); ok {
+		heap.Push(m.mq, reader)
+	} else {
+		reader.iter.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return head, nil
+}
+
+func (m *mergedKeyIter) Close() {
+	for _, f := range m.mq.files {
+		f.iter.Close()
+	}
+	m.mq.files = nil
+}
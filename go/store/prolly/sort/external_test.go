@@ -16,6 +16,7 @@ package sort
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -107,7 +108,7 @@ func TestFlush(t *testing.T) {
 			})
 
 			t.Run("file iter", func(t *testing.T) {
-				kf, err := km.flush(mustNewFile(t, tmpProv), keyCmp)
+				kf, err := km.flush(mustNewFile(t, tmpProv), keyCmp, nil)
 				require.NoError(t, err)
 				cnt, size := drainIterCntSize(t, kf)
 				require.Equal(t, tt.cnt, cnt)
@@ -192,14 +193,14 @@ func TestMerge(t *testing.T) {
 					expCnt++
 					require.True(t, km.insert(k))
 				}
-				kf, err := km.flush(mustNewFile(t, tmpProv), keyCmp)
+				kf, err := km.flush(mustNewFile(t, tmpProv), keyCmp, nil)
 				require.NoError(t, err)
 				keyFiles = append(keyFiles, kf)
 				keyMems = append(keyMems, km)
 			}
 
 			t.Run("mem merge", func(t *testing.T) {
-				target := newKeyFile(mustNewFile(t, tmpProv), batchSize)
+				target := newKeyFile(mustNewFile(t, tmpProv), batchSize, nil)
 
 				ctx := sql.NewEmptyContext()
 				m, _ := newFileMerger(ctx, keyCmp, target, keyMems...)
@@ -211,7 +212,7 @@ func TestMerge(t *testing.T) {
 			})
 
 			t.Run("file merge", func(t *testing.T) {
-				target := newKeyFile(mustNewFile(t, tmpProv), batchSize)
+				target := newKeyFile(mustNewFile(t, tmpProv), batchSize, nil)
 
 				m, _ := newFileMerger(ctx, keyCmp, target, keyFiles...)
 				m.run(ctx)
@@ -220,6 +221,35 @@ func TestMerge(t *testing.T) {
 				require.Equal(t, expCnt, cnt)
 				require.Equal(t, expSize, size)
 			})
+
+			t.Run("key iter merge", func(t *testing.T) {
+				var iters []KeyIter
+				for _, km := range keyMems {
+					iter, err := km.IterAll(ctx)
+					require.NoError(t, err)
+					iters = append(iters, iter)
+				}
+				m, err := MergeKeyIters(ctx, keyCmp, iters...)
+				require.NoError(t, err)
+				defer m.Close()
+
+				var keys []val.Tuple
+				size := 0
+				for {
+					k, err := m.Next(ctx)
+					if err != nil {
+						require.ErrorIs(t, err, io.EOF)
+						break
+					}
+					keys = append(keys, k)
+					size += len(k)
+				}
+				require.Equal(t, expCnt, len(keys))
+				require.Equal(t, expSize, size)
+				for i := 1; i < len(keys); i++ {
+					require.True(t, keyCmp(keys[i-1], keys[i]))
+				}
+			})
 		})
 	}
 }
@@ -297,7 +327,7 @@ func TestCompact(t *testing.T) {
 					expCnt++
 					require.True(t, km.insert(k))
 				}
-				kf, err := km.flush(mustNewFile(t, tmpProv), keyCmp)
+				kf, err := km.flush(mustNewFile(t, tmpProv), keyCmp, nil)
 				require.NoError(t, err)
 				keyFiles = append(keyFiles, kf)
 			}
@@ -460,6 +490,50 @@ func TestFileE2E(t *testing.T) {
 
 }
 
+func TestScratchQuota(t *testing.T) {
+	ctx := sql.NewEmptyContext()
+	td := val.NewTupleDescriptor(
+		val.Type{Enc: val.StringEnc, Nullable: false},
+	)
+	keyCmp := func(l, r val.Tuple) bool {
+		return td.Compare(ctx, l, r) <= 0
+	}
+	ns := tree.NewTestNodeStore()
+	keys := testTuples(ns, td, 5_000)
+
+	sortAll := func(t *testing.T, scratch *tempfiles.ScratchSpace) error {
+		s := NewTupleSorter(500, 4, keyCmp, scratch)
+		defer s.Close()
+		for _, k := range keys {
+			if err := s.Insert(ctx, k); err != nil {
+				return err
+			}
+		}
+		iterable, err := s.Flush(ctx)
+		if err != nil {
+			return err
+		}
+		cnt, _ := drainIterCntSize(t, iterable)
+		require.Equal(t, len(keys), cnt)
+		return nil
+	}
+
+	t.Run("unlimited", func(t *testing.T) {
+		scratch := tempfiles.NewScratchSpace(t.TempDir(), 0)
+		defer scratch.Clean()
+		require.NoError(t, sortAll(t, scratch))
+		require.Equal(t, int64(0), scratch.Used())
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		scratch := tempfiles.NewScratchSpace(t.TempDir(), 2_000)
+		defer scratch.Clean()
+		err := sortAll(t, scratch)
+		require.ErrorIs(t, err, tempfiles.ErrScratchQuotaExceeded)
+		require.Equal(t, int64(0), scratch.Used())
+	})
+}
+
 func testTuples(ns tree.NodeStore, kd val.TupleDesc, cnt int) []val.Tuple {
 	keyBuilder := val.NewTupleBuilder(kd, ns)
 
@@ -173,8 +173,12 @@ var zeroKeys = [][]byte{zeroKey}
 var leafSubtrees = []uint64{1}
 
 func (lw *blobLeafWriter) Write(ctx context.Context, r io.Reader) (hash.Hash, uint64, error) {
-	n, err := r.Read(lw.buf)
-	if err != nil {
+	// every leaf but the last is full, regardless of how |r| splits its reads, so that
+	// streamed values are chunked the same way as buffered values
+	n, err := io.ReadFull(r, lw.buf)
+	if err == io.ErrUnexpectedEOF {
+		err = nil
+	} else if err != nil {
 		return hash.Hash{}, 0, err
 	}
 	h, err := lw.bb.write(ctx, zeroKeys, [][]byte{lw.buf[:n]}, leafSubtrees, 0)
@@ -0,0 +1,111 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// BlobReader reads the bytes of a blob tree one leaf at a time, so that reading a large value
+// holds at most one leaf of it in memory.
+type BlobReader struct {
+	ctx context.Context
+	// cur is a cursor over the leaves of the blob, or nil for an empty blob.
+	cur *cursor
+	// buf is the unread part of the current leaf.
+	buf []byte
+}
+
+var _ io.Reader = &BlobReader{}
+
+// NewBlobReader returns a BlobReader for the blob tree at |addr|.
+func NewBlobReader(ctx context.Context, ns NodeStore, addr hash.Hash) (*BlobReader, error) {
+	if addr.IsEmpty() {
+		return &BlobReader{ctx: ctx}, nil
+	}
+	root, err := ns.Read(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := newCursorAtStart(ctx, ns, root)
+	if err != nil {
+		return nil, err
+	}
+	return &BlobReader{ctx: ctx, cur: cur}, nil
+}
+
+// Read implements io.Reader.
+func (r *BlobReader) Read(p []byte) (int, error) {
+	if err := r.fill(); err != nil {
+		return 0, err
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Discard skips the next |n| bytes of the blob, returning the number of bytes skipped.
+// Leaves that are skipped entirely are not copied.
+func (r *BlobReader) Discard(n int64) (int64, error) {
+	var skipped int64
+	for skipped < n {
+		if err := r.fill(); err == io.EOF {
+			return skipped, nil
+		} else if err != nil {
+			return skipped, err
+		}
+		k := min(n-skipped, int64(len(r.buf)))
+		r.buf = r.buf[k:]
+		skipped += k
+	}
+	return skipped, nil
+}
+
+// fill loads the next leaf of the blob into |r.buf| if the current leaf has been read,
+// returning io.EOF at the end of the blob.
+func (r *BlobReader) fill() error {
+	for len(r.buf) == 0 {
+		if r.cur == nil || !r.cur.Valid() {
+			return io.EOF
+		}
+		r.buf = r.cur.currentValue()
+		if err := r.cur.advance(r.ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadBlobRange returns up to |length| bytes of the blob tree at |addr|, starting at byte |offset|.
+// Fewer bytes are returned if the blob ends before |offset|+|length|. Only the leaves up to the
+// end of the range are read.
+func ReadBlobRange(ctx context.Context, ns NodeStore, addr hash.Hash, offset, length int64) ([]byte, error) {
+	r, err := NewBlobReader(ctx, ns, addr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = r.Discard(offset); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if _, err = io.CopyN(&buf, r, length); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
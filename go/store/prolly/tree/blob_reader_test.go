@@ -0,0 +1,73 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+func TestBlobReader(t *testing.T) {
+	ctx := context.Background()
+	ns := NewTestNodeStore()
+
+	for _, size := range []int{0, 10, 3*DefaultFixedChunkLength + 17, 1 << 20} {
+		t.Run(fmt.Sprintf("size %d", size), func(t *testing.T) {
+			data := make([]byte, size)
+			rand.Read(data)
+			_, addr, err := SerializeBytesToAddr(ctx, ns, bytes.NewReader(data), size)
+			require.NoError(t, err)
+
+			// a reader that returns one byte at a time is chunked the same way
+			_, oneByteAddr, err := SerializeBytesToAddr(ctx, ns, iotest.OneByteReader(bytes.NewReader(data)), size)
+			require.NoError(t, err)
+			assert.Equal(t, addr, oneByteAddr)
+
+			r, err := NewBlobReader(ctx, ns, addr)
+			require.NoError(t, err)
+			actual, err := io.ReadAll(iotest.HalfReader(r))
+			require.NoError(t, err)
+			assert.Equal(t, data, actual)
+
+			ranges := [][2]int64{{0, 0}, {0, 100}, {5, 5000}, {int64(size) / 2, int64(size)}, {int64(size), 10}, {int64(size) + 10, 10}}
+			for _, rng := range ranges {
+				offset, length := rng[0], rng[1]
+				actual, err := ReadBlobRange(ctx, ns, addr, offset, length)
+				require.NoError(t, err)
+				start := min(offset, int64(size))
+				end := min(offset+length, int64(size))
+				assert.Equal(t, data[start:end], actual, "range (%d, %d)", offset, length)
+			}
+		})
+	}
+
+	t.Run("empty address", func(t *testing.T) {
+		r, err := NewBlobReader(ctx, ns, hash.Hash{})
+		require.NoError(t, err)
+		actual, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
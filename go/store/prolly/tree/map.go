@@ -331,7 +331,7 @@ func (t StaticMap[K, V, O]) IterAll(ctx context.Context) (*OrderedTreeIter[K, V]
 		return &OrderedTreeIter[K, V]{curr: nil}, nil
 	}
 
-	return &OrderedTreeIter[K, V]{curr: c, stop: stop, step: c.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: c, stop: stop, step: prefetchingAdvance(c, s, scanPrefetchDepth)}, nil
 }
 
 func (t StaticMap[K, V, O]) IterAllReverse(ctx context.Context) (*OrderedTreeIter[K, V], error) {
@@ -391,7 +391,7 @@ func (t StaticMap[K, V, O]) IterOrdinalRange(ctx context.Context, start, stop ui
 		return curr.compare(hi) >= 0
 	}
 
-	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: lo.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: prefetchingAdvance(lo, hi, scanPrefetchDepth)}, nil
 }
 
 func (t StaticMap[K, V, O]) FetchOrdinalRange(ctx context.Context, start, stop uint64) (*orderedLeafSpanIter[K, V], error) {
@@ -443,7 +443,7 @@ func (t StaticMap[K, V, O]) IterKeyRange(ctx context.Context, start, stop K) (*O
 		return &OrderedTreeIter[K, V]{curr: nil}, nil
 	}
 
-	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: lo.advance}, nil
+	return &OrderedTreeIter[K, V]{curr: lo, stop: stopF, step: prefetchingAdvance(lo, hi, scanPrefetchDepth)}, nil
 }
 
 func (t StaticMap[K, V, O]) GetKeyRangeCardinality(ctx context.Context, start, stop K) (uint64, error) {
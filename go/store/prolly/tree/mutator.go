@@ -18,9 +18,14 @@ import (
 	"bytes"
 	"context"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/dolthub/dolt/go/store/prolly/message"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/store/prolly/tree")
+
 type MutationIter interface {
 	NextMutation(ctx context.Context) (key, value Item)
 	Close() error
@@ -69,6 +74,10 @@ func ApplyMutations[K ~[]byte, O Ordering[K], S message.Serializer](
 		return root, nil // no mutations
 	}
 
+	ctx, span := tracer.Start(ctx, "tree.ApplyMutations")
+	defer span.End()
+	applied := 0
+
 	cur, err := newCursorAtKey(ctx, ns, root, K(newKey), order)
 	if err != nil {
 		return Node{}, err
@@ -128,6 +137,7 @@ func ApplyMutations[K ~[]byte, O Ordering[K], S message.Serializer](
 		if err != nil {
 			return Node{}, err
 		}
+		applied++
 
 		prev := newKey
 		newKey, newValue = edits.NextMutation(ctx)
@@ -136,6 +146,7 @@ func ApplyMutations[K ~[]byte, O Ordering[K], S message.Serializer](
 		}
 	}
 
+	span.SetAttributes(attribute.Int("num_edits", applied))
 	return chkr.Done(ctx)
 }
 
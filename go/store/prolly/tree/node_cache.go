@@ -26,6 +26,73 @@ const (
 	stripeMask byte = 0b00011111
 )
 
+// The node cache is split into pools for leaf and internal nodes. Internal nodes are few and are read by every
+// point lookup, so a share of the cache is reserved for them: leaf nodes can't evict internal nodes while the
+// internal pool is within its share. Leaf nodes may use any capacity unused by internal nodes.
+const (
+	internalPoolShareDenominator = 4
+	protectedShareNumerator      = 4
+	protectedShareDenominator    = 5
+)
+
+// cachePool is the kind of node cached in an entry.
+type cachePool uint8
+
+const (
+	leafPool cachePool = iota
+	internalPool
+	numCachePools
+)
+
+func (p cachePool) String() string {
+	if p == internalPool {
+		return "internal"
+	}
+	return "leaf"
+}
+
+// cacheSegment is the segment of a pool that an entry is in. Each pool is a segmented LRU: nodes are inserted
+// into the probation segment and are promoted to the protected segment when they're read again. Nodes are
+// evicted from the probation segment first, so a large scan that reads each node once only evicts other nodes
+// that were read once.
+type cacheSegment uint8
+
+const (
+	probationSegment cacheSegment = iota
+	protectedSegment
+	numCacheSegments
+)
+
+func poolOf(node Node) cachePool {
+	if node.level > 0 {
+		return internalPool
+	}
+	return leafPool
+}
+
+// CachePoolStats are the statistics of a pool of the node cache.
+type CachePoolStats struct {
+	// Pool is the kind of node in the pool, either "leaf" or "internal".
+	Pool string
+	// Entries is the number of cached nodes.
+	Entries int
+	// Bytes is the size of the cached nodes.
+	Bytes int
+	// ProtectedBytes is the size of the cached nodes that have been read more than once.
+	ProtectedBytes int
+	// Hits is the number of reads of nodes that were cached.
+	Hits uint64
+	// Inserts is the number of nodes added to the cache.
+	Inserts uint64
+	// Evictions is the number of nodes evicted from the cache.
+	Evictions uint64
+}
+
+// CacheStats returns the statistics of the node cache shared by NodeStores.
+func CacheStats() []CachePoolStats {
+	return sharedCache.stats()
+}
+
 func newChunkCache(maxSize int) (c nodeCache) {
 	sz := maxSize / numStripes
 	for i := range c.stripes {
@@ -54,71 +121,152 @@ func (c nodeCache) purge() {
 	}
 }
 
+func (c nodeCache) stats() []CachePoolStats {
+	stats := make([]CachePoolStats, numCachePools)
+	for p := range stats {
+		stats[p].Pool = cachePool(p).String()
+	}
+	for _, s := range c.stripes {
+		s.addStats(stats)
+	}
+	return stats
+}
+
 type centry struct {
 	a    hash.Hash
 	n    Node
 	i    int
+	pool cachePool
+	seg  cacheSegment
 	prev *centry
 	next *centry
 }
 
+// lruList is a circular list of cache entries, ordered from most to least recently used.
+type lruList struct {
+	head *centry
+	sz   int
+	len  int
+}
+
+func (l *lruList) pushFront(e *centry) {
+	if l.head != nil {
+		e.next = l.head
+		e.prev = l.head.prev
+		l.head.prev = e
+		e.prev.next = e
+	} else {
+		e.next = e
+		e.prev = e
+	}
+	l.head = e
+	l.sz += e.n.Size()
+	l.len++
+}
+
+func (l *lruList) remove(e *centry) {
+	if e.next == e {
+		l.head = nil
+	} else if l.head == e {
+		l.head = e.next
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = e
+	e.next = e
+	l.sz -= e.n.Size()
+	l.len--
+}
+
+func (l *lruList) back() *centry {
+	if l.head == nil {
+		return nil
+	}
+	return l.head.prev
+}
+
+type poolCounters struct {
+	hits      uint64
+	inserts   uint64
+	evictions uint64
+}
+
 type stripe struct {
 	mu     *sync.Mutex
 	chunks map[hash.Hash]*centry
-	head   *centry
+	lists  [numCachePools][numCacheSegments]lruList
 	sz     int
 	maxSz  int
 	rev    int
+	counts [numCachePools]poolCounters
 }
 
 func newStripe(maxSize int) *stripe {
 	return &stripe{
-		&sync.Mutex{},
-		make(map[hash.Hash]*centry),
-		nil,
-		0,
-		maxSize,
-		0,
+		mu:     &sync.Mutex{},
+		chunks: make(map[hash.Hash]*centry),
+		maxSz:  maxSize,
 	}
 }
 
-func removeFromList(e *centry) {
-	e.prev.next = e.next
-	e.next.prev = e.prev
-	e.prev = e
-	e.next = e
-}
-
 func (s *stripe) purge() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.chunks = make(map[hash.Hash]*centry)
-	s.head = nil
+	s.lists = [numCachePools][numCacheSegments]lruList{}
 	s.sz = 0
 	s.rev = 0
 }
 
-func (s *stripe) moveToFront(e *centry) {
+// poolShare returns the capacity of the stripe reserved for |p|.
+func (s *stripe) poolShare(p cachePool) int {
+	if p == internalPool {
+		return s.maxSz / internalPoolShareDenominator
+	}
+	return s.maxSz - s.maxSz/internalPoolShareDenominator
+}
+
+func (s *stripe) poolSize(p cachePool) int {
+	return s.lists[p][probationSegment].sz + s.lists[p][protectedSegment].sz
+}
+
+func (s *stripe) pushFront(e *centry) {
 	e.i = s.rev
 	s.rev++
-	if s.head == e {
-		return
-	}
-	if s.head != nil {
-		removeFromList(e)
-		e.next = s.head
-		e.prev = s.head.prev
-		s.head.prev = e
-		e.prev.next = e
+	s.lists[e.pool][e.seg].pushFront(e)
+}
+
+// touch moves |e| to the front of its pool's protected segment.
+func (s *stripe) touch(e *centry) {
+	s.lists[e.pool][e.seg].remove(e)
+	if e.seg == probationSegment {
+		e.seg = protectedSegment
+		s.pushFront(e)
+		s.demoteProtected(e.pool)
+	} else {
+		s.pushFront(e)
+	}
+}
+
+// demoteProtected moves the least recently used entries of the protected segment of |p| to
+// the probation segment until the protected segment is within its share of the pool.
+func (s *stripe) demoteProtected(p cachePool) {
+	protected := &s.lists[p][protectedSegment]
+	maxProtected := s.poolShare(p) * protectedShareNumerator / protectedShareDenominator
+	for protected.sz > maxProtected && protected.len > 1 {
+		t := protected.back()
+		protected.remove(t)
+		t.seg = probationSegment
+		s.pushFront(t)
 	}
-	s.head = e
 }
 
 func (s *stripe) get(h hash.Hash) (Node, bool) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if e, ok := s.chunks[h]; ok {
-		s.moveToFront(e)
+		s.touch(e)
+		s.counts[e.pool].hits++
 		return e.n, true
 	} else {
 		return Node{}, false
@@ -130,69 +278,98 @@ func (s *stripe) insert(addr hash.Hash, node Node) {
 	defer s.mu.Unlock()
 
 	if e, ok := s.chunks[addr]; !ok {
-		e = &centry{addr, node, 0, nil, nil}
-		e.next = e
-		e.prev = e
-		s.moveToFront(e)
+		e = &centry{a: addr, n: node, pool: poolOf(node), seg: probationSegment}
+		s.pushFront(e)
 		s.chunks[addr] = e
 		s.sz += node.Size()
+		s.counts[e.pool].inserts++
 		s.shrinkToMaxSz()
 	} else {
-		s.moveToFront(e)
+		s.touch(e)
 	}
 }
 
 func (s *stripe) shrinkToMaxSz() {
 	for s.sz > s.maxSz {
-		if s.head != nil {
-			t := s.head.prev
-			removeFromList(t)
-			if t == s.head {
-				s.head = nil
-			}
-			delete(s.chunks, t.a)
-			s.sz -= t.n.Size()
-		} else {
+		p := leafPool
+		if s.poolSize(internalPool) > s.poolShare(internalPool) || s.poolSize(leafPool) == 0 {
+			p = internalPool
+		}
+		t := s.lists[p][probationSegment].back()
+		if t == nil {
+			t = s.lists[p][protectedSegment].back()
+		}
+		if t == nil {
 			panic("cache is empty but cache Size is > than max Size")
 		}
+		s.lists[p][t.seg].remove(t)
+		delete(s.chunks, t.a)
+		s.sz -= t.n.Size()
+		s.counts[p].evictions++
+	}
+}
+
+func (s *stripe) addStats(stats []CachePoolStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for p := range stats {
+		lists := s.lists[p]
+		stats[p].Entries += lists[probationSegment].len + lists[protectedSegment].len
+		stats[p].Bytes += lists[probationSegment].sz + lists[protectedSegment].sz
+		stats[p].ProtectedBytes += lists[protectedSegment].sz
+		stats[p].Hits += s.counts[p].hits
+		stats[p].Inserts += s.counts[p].inserts
+		stats[p].Evictions += s.counts[p].evictions
 	}
 }
 
 func (s *stripe) sanityCheck() {
-	if s.head != nil {
-		p := s.head.next
-		i := 1
-		sz := s.head.n.Size()
-		lasti := s.head.i
-		for p != s.head {
-			i++
-			sz += p.n.Size()
-			if p.i >= lasti {
-				panic("encountered lru list entry with higher rev later in the list.")
+	var entries, sz int
+	for p := range s.lists {
+		for seg := range s.lists[p] {
+			l := s.lists[p][seg]
+			if l.head == nil {
+				if l.len != 0 || l.sz != 0 {
+					panic("lru list is empty but its length or size is not 0")
+				}
+				continue
 			}
-			p = p.next
-		}
-		if i != len(s.chunks) {
-			panic(fmt.Sprintf("cache lru list has different Size than cache.chunks. %d vs %d", i, len(s.chunks)))
-		}
-		if sz != s.sz {
-			panic("entries reachable from lru list have different Size than cache.sz.")
-		}
-		j := 1
-		p = s.head.prev
-		for p != s.head {
-			j++
-			p = p.prev
-		}
-		if j != i {
-			panic("length of list backwards is not equal to length of list forward")
-		}
-	} else {
-		if len(s.chunks) != 0 {
-			panic("lru list is empty but s.chunks is not")
-		}
-		if s.sz != 0 {
-			panic("lru list is empty but s.sz is not 0")
+			i, lsz := 0, 0
+			lasti := l.head.i + 1
+			e := l.head
+			for {
+				i++
+				lsz += e.n.Size()
+				if e.i >= lasti {
+					panic("encountered lru list entry with higher rev later in the list.")
+				}
+				lasti = e.i
+				if s.chunks[e.a] != e {
+					panic("lru list entry is not in cache.chunks")
+				}
+				e = e.next
+				if e == l.head {
+					break
+				}
+			}
+			j := 1
+			for e = l.head.prev; e != l.head; e = e.prev {
+				j++
+			}
+			if i != l.len || j != i {
+				panic(fmt.Sprintf("lru list length is %d forward and %d backward, but recorded as %d", i, j, l.len))
+			}
+			if lsz != l.sz {
+				panic("entries reachable from lru list have different Size than the list's recorded size.")
+			}
+			entries += i
+			sz += lsz
 		}
 	}
+	if entries != len(s.chunks) {
+		panic(fmt.Sprintf("cache lru lists have different Size than cache.chunks. %d vs %d", entries, len(s.chunks)))
+	}
+	if sz != s.sz {
+		panic("entries reachable from lru lists have different Size than cache.sz.")
+	}
 }
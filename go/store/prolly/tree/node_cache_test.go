@@ -45,4 +45,62 @@ func TestNodeCache(t *testing.T) {
 			assert.False(t, ok)
 		}
 	})
+	t.Run("ScanResistance", func(t *testing.T) {
+		s := newStripe(16 * 1024)
+		leaf := Node{msg: make([]byte, 1024)}
+		// hot nodes are read more than once
+		for i := 0; i < 4; i++ {
+			s.insert(testCacheAddr(i), leaf)
+			_, ok := s.get(testCacheAddr(i))
+			assert.True(t, ok)
+			s.sanityCheck()
+		}
+		// a scan reads many nodes once
+		for i := 100; i < 1000; i++ {
+			s.insert(testCacheAddr(i), leaf)
+			s.sanityCheck()
+		}
+		for i := 0; i < 4; i++ {
+			_, ok := s.get(testCacheAddr(i))
+			assert.True(t, ok)
+		}
+		_, ok := s.get(testCacheAddr(100))
+		assert.False(t, ok)
+		s.sanityCheck()
+	})
+	t.Run("InternalPool", func(t *testing.T) {
+		s := newStripe(16 * 1024)
+		internal := Node{msg: make([]byte, 1024), level: 1}
+		leaf := Node{msg: make([]byte, 1024)}
+		for i := 0; i < 2; i++ {
+			s.insert(testCacheAddr(i), internal)
+		}
+		// leaves read more than once still don't evict internal nodes
+		for i := 100; i < 1000; i++ {
+			s.insert(testCacheAddr(i), leaf)
+			s.get(testCacheAddr(i))
+			s.sanityCheck()
+		}
+		for i := 0; i < 2; i++ {
+			_, ok := s.get(testCacheAddr(i))
+			assert.True(t, ok)
+		}
+
+		stats := make([]CachePoolStats, numCachePools)
+		s.addStats(stats)
+		assert.Equal(t, 2, stats[internalPool].Entries)
+		assert.Equal(t, uint64(2), stats[internalPool].Hits)
+		assert.Equal(t, uint64(0), stats[internalPool].Evictions)
+		assert.Equal(t, uint64(900), stats[leafPool].Inserts)
+		assert.Equal(t, uint64(900), stats[leafPool].Hits)
+		assert.Equal(t, 14, stats[leafPool].Entries)
+		assert.Equal(t, uint64(900-14), stats[leafPool].Evictions)
+		assert.Equal(t, 16*1024, stats[leafPool].Bytes+stats[internalPool].Bytes)
+	})
+}
+
+func testCacheAddr(i int) (addr hash.Hash) {
+	addr[0] = byte(i)
+	addr[1] = byte(i >> 8)
+	return
 }
@@ -26,6 +26,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
@@ -46,7 +47,7 @@ type Ordering[K ~[]byte] interface {
 func newCursorAtStart(ctx context.Context, ns NodeStore, nd Node) (cur *cursor, err error) {
 	cur = &cursor{nd: nd, nrw: ns}
 	for !cur.isLeaf() {
-		nd, err = fetchChild(ctx, ns, cur.currentRef())
+		nd, err = fetchChildOf(ctx, ns, cur)
 		if err != nil {
 			return nil, err
 		}
@@ -62,7 +63,7 @@ func newCursorAtEnd(ctx context.Context, ns NodeStore, nd Node) (cur *cursor, er
 	cur.skipToNodeEnd()
 
 	for !cur.isLeaf() {
-		nd, err = fetchChild(ctx, ns, cur.currentRef())
+		nd, err = fetchChildOf(ctx, ns, cur)
 		if err != nil {
 			return nil, err
 		}
@@ -168,7 +169,7 @@ func newCursorFromSearchFn(ctx context.Context, ns NodeStore, nd Node, search Se
 		// stay in bounds for internal nodes
 		cur.keepInBounds()
 
-		nd, err = fetchChild(ctx, ns, cur.currentRef())
+		nd, err = fetchChildOf(ctx, ns, cur)
 		if err != nil {
 			return cur, err
 		}
@@ -206,7 +207,7 @@ func newLeafCursorAtKey[K ~[]byte, O Ordering[K]](ctx context.Context, ns NodeSt
 		cur.keepInBounds()
 
 		// reuse |cur| object to keep stack alloc'd
-		cur.nd, err = fetchChild(ctx, ns, cur.currentRef())
+		cur.nd, err = fetchChildOf(ctx, ns, &cur)
 		if err != nil {
 			return cur, err
 		}
@@ -348,7 +349,7 @@ func Seek[K ~[]byte, O Ordering[K]](ctx context.Context, cur *cursor, key K, ord
 		// stay in bounds for internal nodes
 		cur.parent.keepInBounds()
 
-		cur.nd, err = fetchChild(ctx, cur.nrw, cur.parent.currentRef())
+		cur.nd, err = fetchChildOf(ctx, cur.nrw, cur.parent)
 		if err != nil {
 			return err
 		}
@@ -557,7 +558,7 @@ func (cur *cursor) retreat(ctx context.Context) error {
 // It's called whenever the cursor advances/retreats to a different chunk.
 func (cur *cursor) fetchNode(ctx context.Context) (err error) {
 	assertTrue(cur.parent != nil, "cannot fetch node for cursor with nil parent")
-	cur.nd, err = fetchChild(ctx, cur.nrw, cur.parent.currentRef())
+	cur.nd, err = fetchChildOf(ctx, cur.nrw, cur.parent)
 	cur.idx = -1 // caller must set
 	return err
 }
@@ -629,6 +630,15 @@ func fetchChild(ctx context.Context, ns NodeStore, ref hash.Hash) (Node, error)
 	return ns.Read(ctx, ref)
 }
 
+// fetchChildOf loads the child of |parent| that its index points to. Internal nodes are fetched with a high priority
+// from chunk stores backed by remote databases, since every read of the leaves below them waits on them.
+func fetchChildOf(ctx context.Context, ns NodeStore, parent *cursor) (Node, error) {
+	if parent.nd.Level() > 1 {
+		return readNode(ctx, ns, parent.currentRef(), chunks.FetchPriorityHigh)
+	}
+	return fetchChild(ctx, ns, parent.currentRef())
+}
+
 func assertTrue(b bool, msg string, args ...any) {
 	if !b {
 		panic(fmt.Sprintf("assertion failed: "+msg, args...))
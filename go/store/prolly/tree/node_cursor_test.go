@@ -15,6 +15,7 @@
 package tree
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -22,7 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/prolly/message"
+	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
@@ -69,6 +73,57 @@ func TestNodeCursor(t *testing.T) {
 		}
 		assert.Equal(t, 10_000/2, i)
 	})
+
+	t.Run("fetch priority", func(t *testing.T) {
+		testFetchPriority(t)
+	})
+}
+
+// priorityRecordingStore records the chunks.FetchPriority of every chunk read from it.
+type priorityRecordingStore struct {
+	chunks.ChunkStore
+	priorities map[hash.Hash]chunks.FetchPriority
+}
+
+func (cs *priorityRecordingStore) Get(ctx context.Context, h hash.Hash) (chunks.Chunk, error) {
+	cs.priorities[h] = chunks.FetchPriorityFromContext(ctx)
+	return cs.ChunkStore.Get(ctx, h)
+}
+
+func testFetchPriority(t *testing.T) {
+	tuples, desc := AscendingUintTuples(200_000)
+
+	ctx := sql.NewEmptyContext()
+	ts := &chunks.TestStorage{}
+	cs := &priorityRecordingStore{
+		ChunkStore: ts.NewViewWithFormat(types.Format_DOLT.VersionString()),
+		priorities: make(map[hash.Hash]chunks.FetchPriority),
+	}
+	ns := NewNodeStore(cs)
+	serializer := message.NewProllyMapSerializer(desc, ns.Pool())
+	chkr, err := newEmptyChunker(ctx, ns, serializer)
+	require.NoError(t, err)
+	for _, item := range tuples {
+		require.NoError(t, chkr.AddPair(ctx, Item(item[0]), Item(item[1])))
+	}
+	root, err := chkr.Done(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, root.Level(), 2)
+
+	// read the tree from the chunk store rather than the cache
+	ns.PurgeCaches()
+	cur, err := newCursorAtKey(ctx, ns, root, tuples[len(tuples)/2][0], desc)
+	require.NoError(t, err)
+	require.Len(t, cs.priorities, root.Level())
+
+	// internal nodes are fetched before leaves, which are fetched with the normal priority
+	for h, p := range cs.priorities {
+		if h == cur.nd.HashOf() {
+			assert.Equal(t, chunks.FetchPriorityNormal, p)
+		} else {
+			assert.Equal(t, chunks.FetchPriorityHigh, p)
+		}
+	}
 }
 
 func testNewCursorAtItem(t *testing.T, count int) {
@@ -17,6 +17,7 @@ package tree
 import (
 	"bytes"
 	"context"
+	"io"
 	"sync"
 
 	"github.com/dolthub/dolt/go/store/chunks"
@@ -91,11 +92,19 @@ func NewNodeStore(cs chunks.ChunkStore) NodeStore {
 
 // Read implements NodeStore.
 func (ns *nodeStore) Read(ctx context.Context, ref hash.Hash) (Node, error) {
+	return ns.read(ctx, ref, chunks.FetchPriorityNormal)
+}
+
+// read reads the node |ref|, fetching it from the chunk store with priority |p| if it isn't cached.
+func (ns *nodeStore) read(ctx context.Context, ref hash.Hash, p chunks.FetchPriority) (Node, error) {
 	n, ok := ns.cache.get(ref)
 	if ok {
 		return n, nil
 	}
 
+	if p != chunks.FetchPriorityNormal {
+		ctx = chunks.WithFetchPriority(ctx, p)
+	}
 	c, err := ns.store.Get(ctx, ref)
 	if err != nil {
 		return Node{}, err
@@ -111,6 +120,14 @@ func (ns *nodeStore) Read(ctx context.Context, ref hash.Hash) (Node, error) {
 	return n, nil
 }
 
+// readNode reads the node |ref| from |ns|. If |ns| fetches it from a remote database, it's fetched with priority |p|.
+func readNode(ctx context.Context, ns NodeStore, ref hash.Hash, p chunks.FetchPriority) (Node, error) {
+	if s, ok := ns.(*nodeStore); ok {
+		return s.read(ctx, ref, p)
+	}
+	return ns.Read(ctx, ref)
+}
+
 // ReadMany implements NodeStore.
 func (ns *nodeStore) ReadMany(ctx context.Context, addrs hash.HashSlice) ([]Node, error) {
 	found := make(map[hash.Hash]Node)
@@ -228,4 +245,15 @@ func (ns *nodeStore) WriteBytes(ctx context.Context, b []byte) (hash.Hash, error
 	return h, err
 }
 
+// NewBytesReader implements val.StreamingValueStore.
+func (ns *nodeStore) NewBytesReader(ctx context.Context, h hash.Hash) (io.Reader, error) {
+	return NewBlobReader(ctx, ns, h)
+}
+
+// ReadBytesRange implements val.StreamingValueStore.
+func (ns *nodeStore) ReadBytesRange(ctx context.Context, h hash.Hash, offset, length int64) ([]byte, error) {
+	return ReadBlobRange(ctx, ns, h, offset, length)
+}
+
 var _ val.ValueStore = &nodeStore{}
+var _ val.StreamingValueStore = &nodeStore{}
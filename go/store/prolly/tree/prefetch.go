@@ -0,0 +1,132 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/dconfig"
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// defaultScanPrefetchDepth is the number of leaf nodes read ahead of forward range scans,
+// unless it's set by DOLT_SCAN_PREFETCH_DEPTH.
+const defaultScanPrefetchDepth = 4
+
+// scanPrefetchDepth is the number of leaf nodes that forward range scans read ahead of the leaf
+// being iterated. Zero disables read-ahead.
+var scanPrefetchDepth = defaultScanPrefetchDepth
+
+func init() {
+	if v := os.Getenv(dconfig.EnvScanPrefetchDepth); v != "" {
+		depth, err := strconv.Atoi(v)
+		if err != nil || depth < 0 {
+			logrus.Warnf("unable to parse a non-negative integer value for %s from %s", dconfig.EnvScanPrefetchDepth, v)
+		} else {
+			scanPrefetchDepth = depth
+		}
+	}
+}
+
+// prefetcher reads the leaf nodes following a leaf cursor into the NodeStore's cache, so that
+// the latency of fetching them from the chunk store overlaps with iterating the current leaf.
+// Leaves are read ahead within the cursor's parent node and up to the parent of the |stop| cursor.
+type prefetcher struct {
+	cur, stop *cursor
+	depth     int
+
+	started bool
+	// end is the index in |cur.parent| past the last child that was prefetched.
+	end int
+	// limit is the index in |cur.parent| past the last child in the iteration's range.
+	limit int
+	// inflight is closed when the last batch of prefetched nodes has been read.
+	inflight chan struct{}
+}
+
+// prefetchingAdvance returns a function that advances |cur| and reads up to |depth| of the leaves
+// following it in the background. |stop| is the cursor past the end of the iteration, if any.
+func prefetchingAdvance(cur, stop *cursor, depth int) func(context.Context) error {
+	if depth <= 0 || cur.parent == nil {
+		return cur.advance
+	}
+	p := &prefetcher{cur: cur, stop: stop, depth: depth}
+	return p.advance
+}
+
+func (p *prefetcher) advance(ctx context.Context) error {
+	if err := p.cur.advance(ctx); err != nil {
+		return err
+	}
+	if !p.started || (p.cur.idx == 0 && p.cur.Valid()) {
+		// first step or |p.cur| moved to a new leaf
+		p.prefetch(ctx)
+	}
+	return nil
+}
+
+func (p *prefetcher) prefetch(ctx context.Context) {
+	parent := p.cur.parent
+	if parent.outOfBounds() {
+		return
+	}
+
+	if !p.started || parent.idx == 0 {
+		// |parent| moved to a new node
+		p.started = true
+		p.end = parent.idx + 1
+		p.limit = int(parent.nd.count)
+		if p.stop != nil && p.stop.parent != nil &&
+			(parent.parent == nil || compareCursors(parent.parent, p.stop.parent.parent) == 0) {
+			// the iteration stops within the leaves of |parent|
+			p.limit = min(p.limit, p.stop.parent.idx+1)
+		}
+	}
+
+	if p.end >= p.limit || p.end-parent.idx-1 > p.depth/2 {
+		// the rest of the range has been prefetched, or enough leaves are already read ahead
+		return
+	}
+	if p.inflight != nil {
+		select {
+		case <-p.inflight:
+		default:
+			return
+		}
+	}
+
+	stop := min(parent.idx+1+p.depth, p.limit)
+	refs := make(hash.HashSlice, 0, stop-p.end)
+	for i := p.end; i < stop; i++ {
+		refs = append(refs, parent.nd.getAddress(i))
+	}
+	p.end = stop
+
+	done := make(chan struct{})
+	p.inflight = done
+	ns := p.cur.nrw
+	// leaves fetched from remote databases are read ahead after the nodes queries are waiting for
+	ctx = chunks.WithFetchPriority(ctx, chunks.FetchPriorityPrefetch)
+	go func() {
+		defer close(done)
+		// errors are returned when the leaves are read by the iterator
+		_, _ = ns.ReadMany(ctx, refs)
+	}()
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+type prefetchRecordingNodeStore struct {
+	NodeStore
+	mu         sync.Mutex
+	prefetched hash.HashSet
+	priorities []chunks.FetchPriority
+}
+
+func (ns *prefetchRecordingNodeStore) ReadMany(ctx context.Context, refs hash.HashSlice) ([]Node, error) {
+	ns.mu.Lock()
+	for _, r := range refs {
+		ns.prefetched.Insert(r)
+	}
+	ns.priorities = append(ns.priorities, chunks.FetchPriorityFromContext(ctx))
+	ns.mu.Unlock()
+	return ns.NodeStore.ReadMany(ctx, refs)
+}
+
+func (ns *prefetchRecordingNodeStore) count() int {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	return ns.prefetched.Size()
+}
+
+func TestPrefetchingIter(t *testing.T) {
+	ctx := context.Background()
+	root, items, testNs := randomTree(t, 20_000)
+	require.Greater(t, root.Level(), 0)
+
+	for _, depth := range []int{0, 1, 4, 16} {
+		t.Run(fmt.Sprintf("depth %d", depth), func(t *testing.T) {
+			defer func(d int) { scanPrefetchDepth = d }(scanPrefetchDepth)
+			scanPrefetchDepth = depth
+
+			ns := &prefetchRecordingNodeStore{NodeStore: testNs, prefetched: hash.NewHashSet()}
+			m := StaticMap[val.Tuple, val.Tuple, val.TupleDesc]{
+				Root:      root,
+				NodeStore: ns,
+				Order:     keyDesc,
+			}
+
+			iter, err := m.IterAll(ctx)
+			require.NoError(t, err)
+			assertIterItems(t, iter, items)
+			if depth == 0 {
+				assert.Zero(t, ns.count())
+			} else {
+				require.Eventually(t, func() bool { return ns.count() > 0 }, time.Second, time.Millisecond)
+			}
+
+			// prefetched nodes are leaves
+			ns.mu.Lock()
+			for r := range ns.prefetched {
+				nd, err := testNs.Read(ctx, r)
+				require.NoError(t, err)
+				assert.True(t, nd.IsLeaf())
+			}
+			// and are fetched after the nodes that queries are waiting for
+			for _, p := range ns.priorities {
+				assert.Equal(t, chunks.FetchPriorityPrefetch, p)
+			}
+			ns.mu.Unlock()
+
+			iter, err = m.IterOrdinalRange(ctx, 5_000, 5_500)
+			require.NoError(t, err)
+			assertIterItems(t, iter, items[5_000:5_500])
+
+			iter, err = m.IterKeyRange(ctx, val.Tuple(items[100][0]), val.Tuple(items[9_000][0]))
+			require.NoError(t, err)
+			assertIterItems(t, iter, items[100:9_000])
+		})
+	}
+
+	t.Run("range within a leaf", func(t *testing.T) {
+		ns := &prefetchRecordingNodeStore{NodeStore: testNs, prefetched: hash.NewHashSet()}
+		m := StaticMap[val.Tuple, val.Tuple, val.TupleDesc]{
+			Root:      root,
+			NodeStore: ns,
+			Order:     keyDesc,
+		}
+		iter, err := m.IterOrdinalRange(ctx, 0, 2)
+		require.NoError(t, err)
+		assertIterItems(t, iter, items[:2])
+		assert.Zero(t, ns.count())
+	})
+}
+
+func assertIterItems(t *testing.T, iter *OrderedTreeIter[val.Tuple, val.Tuple], expected [][2]Item) {
+	ctx := context.Background()
+	var i int
+	for {
+		k, v, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Less(t, i, len(expected))
+		assert.Equal(t, val.Tuple(expected[i][0]), k)
+		assert.Equal(t, val.Tuple(expected[i][1]), v)
+		i++
+	}
+	assert.Equal(t, len(expected), i)
+}
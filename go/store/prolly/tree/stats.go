@@ -164,3 +164,201 @@ func GetHistogramLevel[K, V ~[]byte, O Ordering[K]](ctx context.Context, m Stati
 	}
 	return currentLevel, nil
 }
+
+// SubtreeBoundaries returns the ordinal upper bounds of the subtrees at the
+// highest level of the tree that has at least |n| subtrees, or of the leaf
+// nodes if no internal level has that many. Each bound is exclusive and the
+// last bound is the count of the tree. Leaf nodes are never read, so this is
+// cheap compared to the size of the tree.
+func SubtreeBoundaries[K, V ~[]byte, O Ordering[K]](ctx context.Context, m StaticMap[K, V, O], n int) ([]uint64, error) {
+	if m.Root.IsLeaf() {
+		if m.Root.Count() == 0 {
+			return nil, nil
+		}
+		return []uint64{uint64(m.Root.Count())}, nil
+	}
+
+	currentLevel := []Node{m.Root}
+	for currentLevel[0].Level() > 1 {
+		subtrees := 0
+		for _, node := range currentLevel {
+			subtrees += node.Count()
+		}
+		if subtrees >= n {
+			break
+		}
+
+		var nextLevel []Node
+		for _, node := range currentLevel {
+			for i := 0; i < node.Count(); i++ {
+				child, err := fetchChild(ctx, m.NodeStore, node.getAddress(i))
+				if err != nil {
+					return nil, err
+				}
+				nextLevel = append(nextLevel, child)
+			}
+		}
+		currentLevel = nextLevel
+	}
+
+	var bounds []uint64
+	var ord uint64
+	for _, node := range currentLevel {
+		node, err := node.loadSubtrees()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < node.Count(); i++ {
+			cnt, err := node.getSubtreeCount(i)
+			if err != nil {
+				return nil, err
+			}
+			ord += cnt
+			bounds = append(bounds, ord)
+		}
+	}
+	return bounds, nil
+}
+
+// DiffEstimate bounds the number of rows that differ between two trees.
+type DiffEstimate struct {
+	// FromRows and ToRows count the rows of each tree that
+	// aren't in a subtree shared with the other tree.
+	FromRows, ToRows uint64
+	// Level is the level of the subtrees that were compared.
+	Level int
+}
+
+// LowerBound returns the least number of rows that may differ.
+func (e DiffEstimate) LowerBound() uint64 {
+	if e.FromRows > e.ToRows {
+		return e.FromRows - e.ToRows
+	}
+	return e.ToRows - e.FromRows
+}
+
+// UpperBound returns the greatest number of rows that may differ.
+func (e DiffEstimate) UpperBound() uint64 {
+	return e.FromRows + e.ToRows
+}
+
+type subtreeRef struct {
+	addr  hash.Hash
+	count uint64
+}
+
+// EstimateDiff bounds the number of rows that differ between |from| and
+// |to| without diffing their rows. Starting from the roots, it excludes the
+// subtrees with the same address in both trees and descends into the rest
+// one level at a time, until it reaches the leaf nodes or descending further
+// would read more than |maxNodes| nodes in total.
+func EstimateDiff(ctx context.Context, ns NodeStore, from, to Node, maxNodes int) (DiffEstimate, error) {
+	fromRefs, err := rootSubtreeRefs(from)
+	if err != nil {
+		return DiffEstimate{}, err
+	}
+	toRefs, err := rootSubtreeRefs(to)
+	if err != nil {
+		return DiffEstimate{}, err
+	}
+
+	fromLevel, toLevel := from.Level(), to.Level()
+	read := 0
+	for {
+		if fromLevel == toLevel {
+			fromRefs, toRefs = excludeSharedSubtrees(fromRefs, toRefs)
+		}
+		level := max(fromLevel, toLevel)
+		if level == 0 || (len(fromRefs) == 0 && len(toRefs) == 0) {
+			break
+		}
+
+		n := 0
+		if fromLevel == level {
+			n += len(fromRefs)
+		}
+		if toLevel == level {
+			n += len(toRefs)
+		}
+		if read+n > maxNodes {
+			break
+		}
+		read += n
+
+		// only the taller tree descends until both are at the same level
+		if fromLevel == level {
+			if fromRefs, err = childSubtreeRefs(ctx, ns, fromRefs); err != nil {
+				return DiffEstimate{}, err
+			}
+			fromLevel--
+		}
+		if toLevel == level {
+			if toRefs, err = childSubtreeRefs(ctx, ns, toRefs); err != nil {
+				return DiffEstimate{}, err
+			}
+			toLevel--
+		}
+	}
+
+	est := DiffEstimate{Level: max(fromLevel, toLevel)}
+	for _, ref := range fromRefs {
+		est.FromRows += ref.count
+	}
+	for _, ref := range toRefs {
+		est.ToRows += ref.count
+	}
+	return est, nil
+}
+
+func rootSubtreeRefs(root Node) ([]subtreeRef, error) {
+	cnt, err := root.TreeCount()
+	if err != nil || cnt == 0 {
+		return nil, err
+	}
+	return []subtreeRef{{addr: root.HashOf(), count: uint64(cnt)}}, nil
+}
+
+func childSubtreeRefs(ctx context.Context, ns NodeStore, refs []subtreeRef) ([]subtreeRef, error) {
+	var children []subtreeRef
+	for _, ref := range refs {
+		node, err := fetchChild(ctx, ns, ref.addr)
+		if err != nil {
+			return nil, err
+		}
+		if node, err = node.loadSubtrees(); err != nil {
+			return nil, err
+		}
+		for i := 0; i < node.Count(); i++ {
+			cnt, err := node.getSubtreeCount(i)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, subtreeRef{addr: node.getAddress(i), count: cnt})
+		}
+	}
+	return children, nil
+}
+
+// excludeSharedSubtrees removes the subtrees found in both |from| and |to|.
+// Keys are unique, so a subtree appears at most once in either tree.
+func excludeSharedSubtrees(from, to []subtreeRef) ([]subtreeRef, []subtreeRef) {
+	inFrom := make(map[hash.Hash]struct{}, len(from))
+	for _, ref := range from {
+		inFrom[ref.addr] = struct{}{}
+	}
+	inTo := make(map[hash.Hash]struct{}, len(to))
+	var toOnly []subtreeRef
+	for _, ref := range to {
+		inTo[ref.addr] = struct{}{}
+		if _, ok := inFrom[ref.addr]; !ok {
+			toOnly = append(toOnly, ref)
+		}
+	}
+	var fromOnly []subtreeRef
+	for _, ref := range from {
+		if _, ok := inTo[ref.addr]; !ok {
+			fromOnly = append(fromOnly, ref)
+		}
+	}
+	return fromOnly, toOnly
+}
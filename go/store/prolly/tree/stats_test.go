@@ -21,6 +21,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/store/prolly/message"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
@@ -92,3 +93,93 @@ func histLevelCount(t *testing.T, nodes []Node) int {
 	}
 	return cnt
 }
+
+func TestSubtreeBoundaries(t *testing.T) {
+	ctx := context.Background()
+
+	for _, count := range []int{10, 1e3, 1e5} {
+		for _, n := range []int{1, 8, 64} {
+			t.Run(fmt.Sprintf("count: %d, n: %d", count, n), func(t *testing.T) {
+				root, _, ns := randomTree(t, count*2)
+				m := StaticMap[val.Tuple, val.Tuple, val.TupleDesc]{
+					Root:      root,
+					NodeStore: ns,
+					Order:     keyDesc,
+				}
+				bounds, err := SubtreeBoundaries(ctx, m, n)
+				require.NoError(t, err)
+				require.NotEmpty(t, bounds)
+
+				treeCount, err := root.TreeCount()
+				require.NoError(t, err)
+				require.Equal(t, uint64(treeCount), bounds[len(bounds)-1])
+				for i := 1; i < len(bounds); i++ {
+					require.Less(t, bounds[i-1], bounds[i])
+				}
+				if root.Level() == 0 {
+					require.Len(t, bounds, 1)
+				}
+			})
+		}
+	}
+}
+
+func TestEstimateDiff(t *testing.T) {
+	ctx := context.Background()
+
+	for _, count := range []int{10, 1e3, 1e5} {
+		t.Run(fmt.Sprintf("count: %d", count), func(t *testing.T) {
+			from, items, ns := randomTree(t, count*2)
+
+			est, err := EstimateDiff(ctx, ns, from, from, 1024)
+			require.NoError(t, err)
+			require.Equal(t, DiffEstimate{Level: from.Level()}, est)
+
+			// delete every 100th row and change the value of the row after it
+			var changed [][2]Item
+			deleted, modified := 0, 0
+			for i := 0; i < len(items); i++ {
+				switch i % 100 {
+				case 0:
+					deleted++
+				case 1:
+					changed = append(changed, [2]Item{items[i][0], items[(i+1)%len(items)][1]})
+					modified++
+				default:
+					changed = append(changed, items[i])
+				}
+			}
+			to := treeFromItems(t, ns, changed)
+
+			for _, maxNodes := range []int{0, 16, 1 << 20} {
+				est, err = EstimateDiff(ctx, ns, from, to, maxNodes)
+				require.NoError(t, err)
+				require.Equal(t, uint64(deleted), est.LowerBound())
+				require.LessOrEqual(t, uint64(deleted+modified), est.UpperBound())
+				require.LessOrEqual(t, est.UpperBound(), uint64(2*count))
+				if maxNodes == 0 {
+					require.Equal(t, uint64(2*count-deleted), est.UpperBound())
+				}
+			}
+
+			empty := treeFromItems(t, ns, nil)
+			est, err = EstimateDiff(ctx, ns, empty, to, 1024)
+			require.NoError(t, err)
+			require.Equal(t, uint64(len(changed)), est.LowerBound())
+			require.Equal(t, uint64(len(changed)), est.UpperBound())
+		})
+	}
+}
+
+func treeFromItems(t *testing.T, ns NodeStore, items [][2]Item) Node {
+	ctx := context.Background()
+	serializer := message.NewProllyMapSerializer(valDesc, ns.Pool())
+	chkr, err := newEmptyChunker(ctx, ns, serializer)
+	require.NoError(t, err)
+	for _, item := range items {
+		require.NoError(t, chkr.AddPair(ctx, item[0], item[1]))
+	}
+	nd, err := chkr.Done(ctx)
+	require.NoError(t, err)
+	return nd
+}
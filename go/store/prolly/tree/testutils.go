@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"math"
 	"math/rand"
 	"sort"
@@ -309,6 +310,14 @@ func (v nodeStoreValidator) WriteBytes(ctx context.Context, val []byte) (hash.Ha
 	return h, err
 }
 
+func (v nodeStoreValidator) NewBytesReader(ctx context.Context, h hash.Hash) (io.Reader, error) {
+	return NewBlobReader(ctx, v, h)
+}
+
+func (v nodeStoreValidator) ReadBytesRange(ctx context.Context, h hash.Hash, offset, length int64) ([]byte, error) {
+	return ReadBlobRange(ctx, v, h, offset, length)
+}
+
 func (v nodeStoreValidator) Read(ctx context.Context, ref hash.Hash) (Node, error) {
 	nd, err := v.ns.Read(ctx, ref)
 	if err != nil {
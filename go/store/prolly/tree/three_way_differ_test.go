@@ -40,11 +40,12 @@ func (d testDiff) String() string {
 
 func TestThreeWayDiffer(t *testing.T) {
 	tests := []struct {
-		name  string
-		base  [][]int
-		left  [][]int
-		right [][]int
-		exp   []testDiff
+		name     string
+		base     [][]int
+		left     [][]int
+		right    [][]int
+		diffInfo ThreeWayDiffInfo
+		exp      []testDiff
 	}{
 		{
 			name:  "left adds",
@@ -138,6 +139,17 @@ func TestThreeWayDiffer(t *testing.T) {
 				{op: DiffOpConvergentAdd, k: 5},
 			},
 		},
+		{
+			name:     "identical edits with differing schemas",
+			base:     [][]int{{1, 1}, {4, 4}},
+			left:     [][]int{{1, 2}, {2, 2}, {4, 4}},
+			right:    [][]int{{1, 2}, {2, 2}, {4, 4}},
+			diffInfo: ThreeWayDiffInfo{LeftAndRightSchemasDiffer: true},
+			exp: []testDiff{
+				{op: DiffOpDivergentModifyResolved, k: 1, m: []int{2}},
+				{op: DiffOpDivergentModifyResolved, k: 2, m: []int{2}},
+			},
+		},
 		{
 			name:  "clash edits",
 			base:  [][]int{{1, 1}, {4, 4}},
@@ -193,8 +205,7 @@ func TestThreeWayDiffer(t *testing.T) {
 			left := newTestMap(t, ctx, tt.left, ns, valDesc)
 			right := newTestMap(t, ctx, tt.right, ns, valDesc)
 
-			var diffInfo ThreeWayDiffInfo
-			iter, err := NewThreeWayDiffer(ctx, ns, left, right, base, testResolver(t, ns, valDesc, val.NewTupleBuilder(valDesc, ns)), false, diffInfo, keyDesc)
+			iter, err := NewThreeWayDiffer(ctx, ns, left, right, base, testResolver(t, ns, valDesc, val.NewTupleBuilder(valDesc, ns)), false, tt.diffInfo, keyDesc)
 			require.NoError(t, err)
 
 			var cmp []testDiff
@@ -162,6 +162,24 @@ func ZMask(level byte, zVal ZVal) val.Cell {
 	return cell
 }
 
+// ZCellBounds returns the lower-left and upper-right corners of the region covered by |cell|. This is the inverse of
+// ZMask: the lower corner has all masked bits cleared and the upper corner has them all set. Cells at high levels can
+// cover regions whose corners are not finite, callers should be prepared to handle infinities and NaNs.
+func ZCellBounds(cell val.Cell) (lo, hi types.Point) {
+	level := cell[0]
+	var zMin, zMax ZVal
+	zMin[0] = binary.BigEndian.Uint64(cell[1:])
+	zMin[1] = binary.BigEndian.Uint64(cell[9:])
+	zMax = zMin
+	if level < 32 {
+		zMax[1] |= (uint64(1) << (level << 1)) - 1
+	} else {
+		zMax[0] |= (uint64(1) << ((level - 32) << 1)) - 1
+		zMax[1] = math.MaxUint64
+	}
+	return UnZValue(zMin), UnZValue(zMax)
+}
+
 // ZCell converts the GeometryValue into a Cell
 // Note: there is an inefficiency here where small polygons may be placed into a level that's significantly larger
 func ZCell(v types.GeometryValue) val.Cell {
@@ -335,6 +335,36 @@ var testZVals = []ZVal{
 	{0, 16}, // (4, 0)
 }
 
+func TestZCellBounds(t *testing.T) {
+	t.Run("point cell bounds are the point", func(t *testing.T) {
+		p := types.Point{X: 1, Y: 2}
+		lo, hi := ZCellBounds(ZCell(p))
+		assert.Equal(t, p, lo)
+		assert.Equal(t, p, hi)
+	})
+
+	t.Run("cell bounds contain geometry bbox", func(t *testing.T) {
+		geoms := []types.GeometryValue{
+			types.LineString{Points: []types.Point{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}},
+			types.LineString{Points: []types.Point{{X: -1, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: -1}}},
+			types.LineString{Points: []types.Point{{X: 0.5, Y: 0.25}, {X: 0.75, Y: 0.5}}},
+		}
+		for _, g := range geoms {
+			bbox := spatial.FindBBox(g)
+			lo, hi := ZCellBounds(ZCell(g))
+			// the coarsest cells extend past the finite floats
+			if !math.IsNaN(lo.X) && !math.IsNaN(lo.Y) {
+				assert.LessOrEqual(t, lo.X, bbox[0])
+				assert.LessOrEqual(t, lo.Y, bbox[1])
+			}
+			if !math.IsNaN(hi.X) && !math.IsNaN(hi.Y) {
+				assert.GreaterOrEqual(t, hi.X, bbox[2])
+				assert.GreaterOrEqual(t, hi.Y, bbox[3])
+			}
+		}
+	})
+}
+
 func TestSplitZRanges(t *testing.T) {
 	t.Run("split point z-range", func(t *testing.T) {
 		zRange := ZRange{testZVals[0], testZVals[0]} // (0, 0) -> (0, 0)
@@ -20,6 +20,8 @@ import (
 	"io"
 	"strings"
 
+	"go.opentelemetry.io/otel"
+
 	"github.com/dolthub/dolt/go/store/prolly/message"
 
 	"github.com/dolthub/dolt/go/store/hash"
@@ -29,6 +31,8 @@ import (
 	"github.com/dolthub/dolt/go/store/val"
 )
 
+var tracer = otel.Tracer("github.com/dolthub/dolt/go/store/prolly")
+
 type Map struct {
 	tuples  tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]
 	keyDesc val.TupleDesc
@@ -257,6 +261,7 @@ func (m Map) WalkNodes(ctx context.Context, cb tree.NodeCb) error {
 
 // Get searches for the key-value pair keyed by |key| and passes the results to the callback.
 // If |key| is not present in the map, a nil key-value pair are passed.
+// Point lookups are too frequent, and too cheap, to trace: starting a span allocates even when tracing is disabled.
 func (m Map) Get(ctx context.Context, key val.Tuple, cb tree.KeyValueFn[val.Tuple, val.Tuple]) (err error) {
 	return m.tuples.Get(ctx, key, cb)
 }
@@ -313,12 +318,21 @@ func (m Map) HasPrefix(ctx context.Context, preKey val.Tuple, preDesc val.TupleD
 
 // IterRange returns a mutableMapIter that iterates over a Range.
 func (m Map) IterRange(ctx context.Context, rng Range) (iter MapIter, err error) {
+	ctx, span := tracer.Start(ctx, "prolly.Map.IterRange")
+	defer span.End()
 	stop, ok, err := rng.KeyRangeLookup(ctx, m.Pool(), m.NodeStore())
 	if err != nil {
 		return nil, err
 	}
 	if ok {
 		iter, err = m.IterKeyRange(ctx, rng.Tup, stop)
+	} else if skip := skipScanField(rng); skip >= 0 {
+		// skipScanIter filters non-matching tuples itself
+		ssi, err := newSkipScanIter(ctx, m.tuples.Root, m.tuples.NodeStore, rng, skip)
+		if err != nil {
+			return nil, err
+		}
+		return ssi, nil
 	} else {
 		iter, err = treeIterFromRange(ctx, m.tuples.Root, m.tuples.NodeStore, rng)
 	}
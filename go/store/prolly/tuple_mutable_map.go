@@ -22,6 +22,7 @@ import (
 
 	"github.com/dolthub/dolt/go/store/prolly/message"
 	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/skip"
 	"github.com/dolthub/dolt/go/store/val"
 )
 
@@ -50,6 +51,28 @@ type GenericMutableMap[MapType MapInterface, TreeMap tree.MapInterface[val.Tuple
 	// buffer size
 	maxPending int
 	flusher    MutableMapFlusher[MapType, TreeMap]
+
+	// backgroundFlush controls whether pending writes are flushed on a
+	// background goroutine when the pending buffer is full.
+	backgroundFlush bool
+	// flushing, if not nil, is a background flush of a previous buffer
+	// of pending writes that hasn't been applied to |tuples.Static| yet.
+	flushing *pendingFlush[TreeMap]
+	// spare is an empty buffer left over from a finished background
+	// flush, which is reused for the next one.
+	spare *skip.List
+}
+
+// pendingFlush is a buffer of pending writes being applied to a tree in the background.
+type pendingFlush[TreeMap tree.MapInterface[val.Tuple, val.Tuple, val.TupleDesc]] struct {
+	// base is the tree being flushed to, and the buffer of writes being flushed.
+	// It's read-only while the flush is in progress.
+	base tree.MutableMap[val.Tuple, val.Tuple, val.TupleDesc, TreeMap]
+	done chan struct{}
+
+	// result and err are set before |done| is closed
+	result TreeMap
+	err    error
 }
 
 type MutableMap = GenericMutableMap[Map, tree.StaticMap[val.Tuple, val.Tuple, val.TupleDesc]]
@@ -62,6 +85,10 @@ func (mut *GenericMutableMap[M, T]) MapInterface(ctx context.Context) (MapInterf
 // Map materializes all pending and applied mutations in the GenericMutableMap, producing the specific MapInterface implementation
 // that the struct has been specialized with.
 func (mut *GenericMutableMap[M, T]) Map(ctx context.Context) (M, error) {
+	if err := mut.finishFlush(ctx); err != nil {
+		var m M
+		return m, err
+	}
 	return mut.flusher.Map(ctx, mut)
 }
 
@@ -89,6 +116,10 @@ func newMutableMapWithDescriptors(m Map, kd, vd val.TupleDesc) *MutableMap {
 }
 
 func (mut *GenericMutableMap[M, T]) flushWithSerializer(ctx context.Context, s message.Serializer) (T, error) {
+	if err := mut.finishFlush(ctx); err != nil {
+		var t T
+		return t, err
+	}
 	return mut.flusher.ApplyMutationsWithSerializer(ctx, s, mut)
 }
 
@@ -99,6 +130,16 @@ func (mut *GenericMutableMap[M, T]) WithMaxPending(max int) *GenericMutableMap[M
 	return &ret
 }
 
+// WithBackgroundFlush returns a MutableMap that flushes its pending writes on a
+// background goroutine when the pending buffer is full, so that writes can
+// continue while the previous buffer is applied to the tree. At most one buffer
+// is flushed at a time, so the map holds at most twice as many pending writes.
+func (mut *GenericMutableMap[M, T]) WithBackgroundFlush() *GenericMutableMap[M, T] {
+	ret := *mut
+	ret.backgroundFlush = true
+	return &ret
+}
+
 // NodeStore returns the map's NodeStore
 func (mut *GenericMutableMap[M, T]) NodeStore() tree.NodeStore {
 	return mut.tuples.Static.GetNodeStore()
@@ -110,6 +151,9 @@ func (mut *GenericMutableMap[M, T]) Put(ctx context.Context, key, value val.Tupl
 		return err
 	}
 	if mut.tuples.Edits.Count() > mut.maxPending {
+		if mut.backgroundFlush {
+			return mut.flushPendingInBackground(ctx)
+		}
 		return mut.flushPending(ctx)
 	}
 	return nil
@@ -123,25 +167,51 @@ func (mut *GenericMutableMap[M, T]) Delete(ctx context.Context, key val.Tuple) e
 // Get fetches the Tuple pair keyed by |key|, if it exists, and passes it to |cb|.
 // If the |key| is not present in the MutableMap, a nil Tuple pair is passed to |cb|.
 func (mut *GenericMutableMap[M, T]) Get(ctx context.Context, key val.Tuple, cb tree.KeyValueFn[val.Tuple, val.Tuple]) (err error) {
+	if mut.flushing != nil {
+		// writes made since the background flush started take precedence
+		// over the writes being flushed
+		if value, ok := mut.tuples.Edits.Get(ctx, key); ok {
+			if value == nil {
+				key = nil // there is a pending delete of |key|
+			}
+			return cb(key, value)
+		}
+		return mut.flushing.base.Get(ctx, key, cb)
+	}
 	return mut.tuples.Get(ctx, key, cb)
 }
 
 func (mut *GenericMutableMap[M, T]) GetPrefix(ctx context.Context, key val.Tuple, prefixDesc val.TupleDesc, cb tree.KeyValueFn[val.Tuple, val.Tuple]) (err error) {
+	if err = mut.finishFlush(ctx); err != nil {
+		return err
+	}
 	return mut.tuples.GetPrefix(ctx, key, prefixDesc, cb)
 }
 
 // Has returns true if |key| is present in the MutableMap.
 func (mut *GenericMutableMap[M, T]) Has(ctx context.Context, key val.Tuple) (ok bool, err error) {
+	if mut.flushing != nil {
+		if value, ok := mut.tuples.Edits.Get(ctx, key); ok {
+			return value != nil, nil
+		}
+		return mut.flushing.base.Has(ctx, key)
+	}
 	return mut.tuples.Has(ctx, key)
 }
 
 // HasPrefix returns true if a key with a matching prefix to |key| is present in the MutableMap.
 func (mut *GenericMutableMap[M, T]) HasPrefix(ctx context.Context, key val.Tuple, prefixDesc val.TupleDesc) (ok bool, err error) {
+	if err = mut.finishFlush(ctx); err != nil {
+		return false, err
+	}
 	return mut.tuples.HasPrefix(ctx, key, prefixDesc)
 }
 
 // Checkpoint records a checkpoint that can be reverted to.
-func (mut *GenericMutableMap[M, T]) Checkpoint(context.Context) error {
+func (mut *GenericMutableMap[M, T]) Checkpoint(ctx context.Context) error {
+	if err := mut.finishFlush(ctx); err != nil {
+		return err
+	}
 	// discard previous stash, if one exists
 	mut.stash = nil
 	mut.tuples.Edits.Checkpoint()
@@ -154,13 +224,30 @@ func (mut *GenericMutableMap[M, T]) Revert(ctx context.Context) {
 	// since we check-pointed, our last checkpoint
 	// may be stashed in a separate tree.MutableMap
 	if mut.stash != nil {
+		// the stash doesn't depend on a background flush,
+		// so its result can be discarded
+		mut.flushing = nil
 		mut.tuples = *mut.stash
 		return
 	}
+
+	if mut.flushing != nil {
+		<-mut.flushing.done
+		if mut.flushing.err != nil {
+			// keep the failed flush so that the error is
+			// returned by the next operation that needs it
+			return
+		}
+		mut.tuples.Static = mut.flushing.result
+		mut.flushing = nil
+	}
 	mut.tuples.Edits.Revert(ctx)
 }
 
 func (mut *GenericMutableMap[M, T]) flushPending(ctx context.Context) error {
+	if err := mut.finishFlush(ctx); err != nil {
+		return err
+	}
 	stash := mut.stash
 	// if our in-memory edit set contains a checkpoint, we
 	// must stash a copy of |mut.tuples| we can revert to.
@@ -180,6 +267,71 @@ func (mut *GenericMutableMap[M, T]) flushPending(ctx context.Context) error {
 	return nil
 }
 
+// flushPendingInBackground applies the pending writes to the tree on a background
+// goroutine, and starts a new buffer for subsequent writes. If a previous
+// background flush is still in progress, it waits for it to finish first.
+func (mut *GenericMutableMap[M, T]) flushPendingInBackground(ctx context.Context) error {
+	if err := mut.finishFlush(ctx); err != nil {
+		return err
+	}
+
+	stash := mut.stash
+	// if our in-memory edit set contains a checkpoint, we
+	// must stash a copy of |mut.tuples| we can revert to.
+	if mut.tuples.Edits.HasCheckpoint() {
+		cp := mut.tuples.Copy()
+		cp.Edits.Revert(ctx)
+		stash = &cp
+	}
+
+	flushing := &pendingFlush[T]{
+		base: mut.tuples,
+		done: make(chan struct{}),
+	}
+	// the flusher reads the pending writes from the map it's given,
+	// so it flushes a copy that won't see any subsequent writes
+	snapshot := *mut
+	snapshot.flushing = nil
+	serializer := mut.flusher.GetDefaultSerializer(ctx, mut)
+	// the flush can outlive the statement that started it, so it
+	// mustn't be canceled along with the statement's context
+	flushCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer close(flushing.done)
+		flushing.result, flushing.err = mut.flusher.ApplyMutationsWithSerializer(flushCtx, serializer, &snapshot)
+	}()
+
+	if mut.spare != nil {
+		mut.tuples.Edits, mut.spare = mut.spare, nil
+	} else {
+		mut.tuples.Edits = mut.tuples.Edits.Empty()
+	}
+	mut.stash = stash
+	mut.flushing = flushing
+	return nil
+}
+
+// finishFlush waits for a background flush, if there is one, and applies its
+// result to the map.
+func (mut *GenericMutableMap[M, T]) finishFlush(ctx context.Context) error {
+	if mut.flushing == nil {
+		return nil
+	}
+	select {
+	case <-mut.flushing.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if mut.flushing.err != nil {
+		return mut.flushing.err
+	}
+	mut.tuples.Static = mut.flushing.result
+	mut.spare = mut.flushing.base.Edits
+	mut.spare.Truncate()
+	mut.flushing = nil
+	return nil
+}
+
 // IterAll returns a mutableMapIter that iterates over the entire MutableMap.
 func (mut *GenericMutableMap[M, T]) IterAll(ctx context.Context) (MapIter, error) {
 	rng := Range{Fields: nil, Desc: mut.keyDesc}
@@ -190,11 +342,17 @@ func (mut *GenericMutableMap[M, T]) IterAll(ctx context.Context) (MapIter, error
 // |stop|. If |start| and/or |stop| is nil, the range will be open
 // towards that end.
 func (mut *GenericMutableMap[M, T]) IterKeyRange(ctx context.Context, start, stop val.Tuple) (MapIter, error) {
+	if err := mut.finishFlush(ctx); err != nil {
+		return nil, err
+	}
 	return mut.tuples.Static.IterKeyRange(ctx, start, stop)
 }
 
 // IterRange returns a MapIter that iterates over a Range.
 func (mut *GenericMutableMap[M, T]) IterRange(ctx context.Context, rng Range) (MapIter, error) {
+	if err := mut.finishFlush(ctx); err != nil {
+		return nil, err
+	}
 	treeIter, err := treeIterFromRange(ctx, mut.tuples.Static.GetRoot(), mut.tuples.Static.GetNodeStore(), rng)
 	if err != nil {
 		return nil, err
@@ -213,7 +371,7 @@ func (mut *GenericMutableMap[M, T]) IterRange(ctx context.Context, rng Range) (M
 // HasEdits returns true when the MutableMap has performed at least one Put or Delete operation. This does not indicate
 // whether the materialized map contains different values to the contained unedited map.
 func (mut *GenericMutableMap[M, T]) HasEdits() bool {
-	return mut.tuples.Edits.Count() > 0
+	return mut.tuples.Edits.Count() > 0 || mut.flushing != nil
 }
 
 // Descriptors returns the key and value val.TupleDesc.
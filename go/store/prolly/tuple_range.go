@@ -226,6 +226,11 @@ func (r Range) KeyRangeLookup(ctx context.Context, pool pool.BuffPool, ns tree.N
 		return nil, false, nil
 	}
 
+	if r.Desc.IsDescending(n) {
+		// incrementing a descending field would move the stop key before the start key
+		return nil, false, nil
+	}
+
 	for _, typ := range r.Desc.Types[n+1:] {
 		if !typ.Nullable {
 			// this is checked separately because fulltext descriptors
@@ -216,6 +216,197 @@ func TestRangeSearch(t *testing.T) {
 	}
 }
 
+func TestDescendingRangeSearch(t *testing.T) {
+	intType := val.Type{Enc: val.Int32Enc}
+	// c0 is descending, c1 is ascending
+	desc := val.NewTupleDescriptorWithArgs(val.TupleDescriptorArgs{
+		Comparator: val.NewDescendingTupleComparator(nil, []bool{true, false}),
+	}, intType, intType)
+
+	tuples := []val.Tuple{
+		intTuple(4, 1), // 0
+		intTuple(4, 2), // 1
+		intTuple(3, 1), // 2
+		intTuple(3, 2), // 3
+		intTuple(2, 1), // 4
+		intTuple(2, 2), // 5
+		intTuple(1, 1), // 6
+		intTuple(1, 2), // 7
+	}
+	values := make([]val.Tuple, len(tuples))
+	for i := range values {
+		values[i] = make(val.Tuple, 2)
+	}
+	testNode := tree.NewTupleLeafNode(tuples, values)
+	tm := NewMap(testNode, ns, desc, val.TupleDesc{})
+
+	// bounds of descending fields are given in storage order, so the logical upper bound is |Lo|
+	tests := []struct {
+		name      string
+		testRange Range
+		expected  []int
+	}{
+		{
+			name: "2 <= c0 <= 3",
+			testRange: Range{
+				Fields: []RangeField{
+					{
+						Lo: Bound{Binding: true, Inclusive: true, Value: intVal(3)},
+						Hi: Bound{Binding: true, Inclusive: true, Value: intVal(2)},
+					},
+				},
+				Desc: desc,
+			},
+			expected: []int{2, 3, 4, 5},
+		},
+		{
+			name: "c0 > 2",
+			testRange: Range{
+				Fields: []RangeField{
+					{
+						Hi: Bound{Binding: true, Inclusive: false, Value: intVal(2)},
+					},
+				},
+				Desc: desc,
+			},
+			expected: []int{0, 1, 2, 3},
+		},
+		{
+			name: "c0 = 3 AND c1 > 1",
+			testRange: Range{
+				Fields: []RangeField{
+					{
+						Lo:             Bound{Binding: true, Inclusive: true, Value: intVal(3)},
+						Hi:             Bound{Binding: true, Inclusive: true, Value: intVal(3)},
+						BoundsAreEqual: true,
+					},
+					{
+						Lo: Bound{Binding: true, Inclusive: false, Value: intVal(1)},
+					},
+				},
+				Desc: desc,
+			},
+			expected: []int{3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			iter, err := tm.IterRange(ctx, test.testRange)
+			require.NoError(t, err)
+
+			var actual []val.Tuple
+			for {
+				k, _, err := iter.Next(ctx)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				actual = append(actual, k)
+			}
+
+			expected := make([]val.Tuple, len(test.expected))
+			for i, j := range test.expected {
+				expected[i] = tuples[j]
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestDescendingRangeSearchNulls(t *testing.T) {
+	// c0 is descending, so NULLs are stored after every other value
+	desc := val.NewTupleDescriptorWithArgs(val.TupleDescriptorArgs{
+		Comparator: val.NewDescendingTupleComparator(nil, []bool{true}),
+	}, val.Type{Enc: val.Int32Enc, Nullable: true})
+
+	three, two, one := int32(3), int32(2), int32(1)
+	tuples := []val.Tuple{
+		intNullTuple(&three), // 0
+		intNullTuple(&two),   // 1
+		intNullTuple(&one),   // 2
+		intNullTuple(nil),    // 3
+	}
+	values := make([]val.Tuple, len(tuples))
+	for i := range values {
+		values[i] = make(val.Tuple, 2)
+	}
+	testNode := tree.NewTupleLeafNode(tuples, values)
+	tm := NewMap(testNode, ns, desc, val.TupleDesc{})
+
+	// these are the ranges built for each filter, with the bounds of c0 swapped into storage order
+	tests := []struct {
+		name      string
+		testRange Range
+		expected  []int
+	}{
+		{
+			name: "c0 IS NULL",
+			testRange: Range{
+				Fields: []RangeField{
+					{
+						Lo:             Bound{Binding: true, Inclusive: true},
+						Hi:             Bound{Binding: true, Inclusive: true},
+						BoundsAreEqual: true,
+					},
+				},
+				Desc: desc,
+			},
+			expected: []int{3},
+		},
+		{
+			name: "c0 IS NOT NULL",
+			testRange: Range{
+				Fields: []RangeField{
+					{
+						Hi: Bound{Binding: true, Inclusive: false},
+					},
+				},
+				Desc: desc,
+			},
+			expected: []int{0, 1, 2},
+		},
+		{
+			name: "c0 < 2",
+			testRange: Range{
+				Fields: []RangeField{
+					{
+						Lo: Bound{Binding: true, Inclusive: false, Value: intVal(2)},
+						Hi: Bound{Binding: true, Inclusive: false},
+					},
+				},
+				Desc: desc,
+			},
+			expected: []int{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			iter, err := tm.IterRange(ctx, test.testRange)
+			require.NoError(t, err)
+
+			var actual []val.Tuple
+			for {
+				k, _, err := iter.Next(ctx)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				actual = append(actual, k)
+			}
+
+			expected := make([]val.Tuple, len(test.expected))
+			for i, j := range test.expected {
+				expected[i] = tuples[j]
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
 func intVal(i int32) (buf []byte) {
 	buf = make([]byte, 4)
 	binary.LittleEndian.PutUint32(buf, uint32(i))
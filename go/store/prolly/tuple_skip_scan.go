@@ -0,0 +1,151 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prolly
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+// skipScanThreshold is the number of consecutive non-matching tuples a
+// skipScanIter reads before it seeks past them. Reading a few tuples
+// sequentially is cheaper than a seek, so short gaps are scanned and
+// only long gaps are skipped.
+const skipScanThreshold = 16
+
+// skipScanField returns the index of the field a Range can skip-scan,
+// or -1 if it can't be skip-scanned. A Range can be skip-scanned when
+// its first non-equality field is followed by a bound field: the
+// physical partition of the Range is bounded by its first non-equality
+// field, and the later fields filter out tuples within it. A skip-scan
+// seeks to the matching tuples for each distinct value of the skipped
+// field, rather than reading the whole partition.
+func skipScanField(rng Range) int {
+	skip := -1
+	for i := range rng.Fields {
+		if skip < 0 {
+			if !rng.Fields[i].BoundsAreEqual {
+				skip = i
+			}
+			continue
+		}
+		if rng.Fields[i].Lo.Binding || rng.Fields[i].Hi.Binding {
+			return skip
+		}
+	}
+	return -1
+}
+
+// skipScanIter iterates over the tuples matching a Range whose physical
+// partition is sparse. It reads the partition sequentially, and when
+// it reads a run of non-matching tuples it seeks either to the first
+// matching tuple for the current value of the skipped field, or to the
+// next value of the skipped field.
+type skipScanIter struct {
+	root tree.Node
+	ns   tree.NodeStore
+	rng  Range
+	// skip is the index of the skipped field of |rng|
+	skip int
+
+	iter   *tree.OrderedTreeIter[val.Tuple, val.Tuple]
+	misses int
+}
+
+var _ MapIter = &skipScanIter{}
+
+func newSkipScanIter(ctx context.Context, root tree.Node, ns tree.NodeStore, rng Range, skip int) (*skipScanIter, error) {
+	iter, err := treeIterFromRange(ctx, root, ns, rng)
+	if err != nil {
+		return nil, err
+	}
+	return &skipScanIter{root: root, ns: ns, rng: rng, skip: skip, iter: iter}, nil
+}
+
+func (it *skipScanIter) Next(ctx context.Context) (k, v val.Tuple, err error) {
+	for {
+		k, v, err = it.iter.Next(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if it.rng.Matches(ctx, k) {
+			it.misses = 0
+			return k, v, nil
+		}
+
+		it.misses++
+		if it.misses < skipScanThreshold {
+			continue
+		}
+		sub := it.valueRange(k)
+		if !sub.aboveStart(ctx, k) {
+			// |k| precedes the matching tuples for its value
+			err = it.seek(ctx, sub)
+		} else if !sub.belowStop(ctx, k) {
+			// |k| follows the matching tuples for its value
+			err = it.seek(ctx, it.nextValueRange(k))
+		} else {
+			// |k| is among the tuples for its value that are
+			// filtered by the later fields, keep scanning
+			it.misses = 0
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		// if the tuple we seeked to doesn't match, we've likely
+		// moved to a value with no matching tuples, seek again
+		it.misses = skipScanThreshold - 1
+	}
+}
+
+// valueRange returns the sub-range of |it.rng| whose skipped field is
+// bound to the value of that field in |k|.
+func (it *skipScanIter) valueRange(k val.Tuple) Range {
+	sub := it.rng
+	sub.Fields = make([]RangeField, len(it.rng.Fields))
+	copy(sub.Fields, it.rng.Fields)
+	value := it.rng.Desc.GetField(it.skip, k)
+	sub.Fields[it.skip] = RangeField{
+		Lo:             Bound{Binding: true, Inclusive: true, Value: value},
+		Hi:             Bound{Binding: true, Inclusive: true, Value: value},
+		BoundsAreEqual: true,
+	}
+	return sub
+}
+
+// nextValueRange returns the sub-range of |it.rng| whose skipped field
+// is greater than the value of that field in |k|, ignoring the later
+// fields of |it.rng|.
+func (it *skipScanIter) nextValueRange(k val.Tuple) Range {
+	next := it.rng
+	next.Fields = make([]RangeField, it.skip+1)
+	copy(next.Fields, it.rng.Fields)
+	next.Fields[it.skip] = RangeField{
+		Lo: Bound{Binding: true, Inclusive: false, Value: it.rng.Desc.GetField(it.skip, k)},
+		Hi: it.rng.Fields[it.skip].Hi,
+	}
+	return next
+}
+
+// seek moves the iterator forward to the start of |rng|, keeping the
+// stopping point of |it.rng|.
+func (it *skipScanIter) seek(ctx context.Context, rng Range) (err error) {
+	findStart, findStop := rangeStartSearchFn(rng), rangeStopSearchFn(it.rng)
+	it.iter, err = tree.OrderedTreeIterFromCursors[val.Tuple, val.Tuple](ctx, it.root, it.ns, findStart, findStop)
+	return err
+}
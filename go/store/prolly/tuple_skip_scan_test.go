@@ -0,0 +1,134 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prolly
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/store/prolly/tree"
+	"github.com/dolthub/dolt/go/store/val"
+)
+
+func TestSkipScanField(t *testing.T) {
+	eq := RangeField{
+		Lo:             Bound{Binding: true, Inclusive: true, Value: intVal(1)},
+		Hi:             Bound{Binding: true, Inclusive: true, Value: intVal(1)},
+		BoundsAreEqual: true,
+	}
+	gt := RangeField{Lo: Bound{Binding: true, Value: intVal(1)}}
+	all := RangeField{}
+
+	tests := []struct {
+		name   string
+		fields []RangeField
+		skip   int
+	}{
+		{name: "equality prefix", fields: []RangeField{eq, eq}, skip: -1},
+		{name: "trailing interval", fields: []RangeField{eq, gt}, skip: -1},
+		{name: "unbound suffix", fields: []RangeField{gt, all}, skip: -1},
+		{name: "second field only", fields: []RangeField{all, eq}, skip: 0},
+		{name: "interval then equality", fields: []RangeField{gt, eq}, skip: 0},
+		{name: "interval then interval", fields: []RangeField{gt, gt}, skip: 0},
+		{name: "equality then gap", fields: []RangeField{eq, all, gt}, skip: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.skip, skipScanField(Range{Fields: test.fields}))
+		})
+	}
+}
+
+func TestSkipScanIterRange(t *testing.T) {
+	ctx := context.Background()
+	ns := tree.NewTestNodeStore()
+	kd := val.NewTupleDescriptor(
+		val.Type{Enc: val.Int32Enc},
+		val.Type{Enc: val.Int32Enc},
+	)
+	vd := val.NewTupleDescriptor()
+
+	// 20 leading values with 200 tuples each
+	var tuples []val.Tuple
+	for i := int32(0); i < 20; i++ {
+		for j := int32(0); j < 200; j++ {
+			tuples = append(tuples, intTuple(i, j), intTuple())
+		}
+	}
+	m, err := NewMapFromTuples(ctx, ns, kd, vd, tuples...)
+	require.NoError(t, err)
+
+	eq := func(v int32) RangeField {
+		return RangeField{
+			Lo:             Bound{Binding: true, Inclusive: true, Value: intVal(v)},
+			Hi:             Bound{Binding: true, Inclusive: true, Value: intVal(v)},
+			BoundsAreEqual: true,
+		}
+	}
+	between := func(lo, hi int32) RangeField {
+		return RangeField{
+			Lo: Bound{Binding: true, Inclusive: true, Value: intVal(lo)},
+			Hi: Bound{Binding: true, Inclusive: true, Value: intVal(hi)},
+		}
+	}
+	greater := func(lo int32) RangeField {
+		return RangeField{Lo: Bound{Binding: true, Value: intVal(lo)}}
+	}
+
+	tests := []struct {
+		name   string
+		fields []RangeField
+		count  int
+	}{
+		{name: "second field only", fields: []RangeField{{}, eq(5)}, count: 20},
+		{name: "second field first value", fields: []RangeField{{}, eq(0)}, count: 20},
+		{name: "second field last value", fields: []RangeField{{}, eq(199)}, count: 20},
+		{name: "second field missing", fields: []RangeField{{}, eq(500)}, count: 0},
+		{name: "interval then equality", fields: []RangeField{greater(10), eq(5)}, count: 9},
+		{name: "interval then interval", fields: []RangeField{between(3, 6), greater(189)}, count: 40},
+		{name: "short gaps", fields: []RangeField{{}, between(2, 197)}, count: 20 * 196},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rng := Range{Fields: test.fields, Desc: kd}
+			require.True(t, skipScanField(rng) >= 0)
+
+			var exp []val.Tuple
+			for i := 0; i < len(tuples); i += 2 {
+				if rng.Matches(ctx, tuples[i]) {
+					exp = append(exp, tuples[i])
+				}
+			}
+			require.Equal(t, test.count, len(exp))
+
+			iter, err := m.IterRange(ctx, rng)
+			require.NoError(t, err)
+			var act []val.Tuple
+			for {
+				k, _, err := iter.Next(ctx)
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				act = append(act, k)
+			}
+			assert.Equal(t, exp, act)
+		})
+	}
+}
@@ -194,6 +194,11 @@ func (l *List) Put(ctx context.Context, key, val []byte) {
 	}
 }
 
+// Empty returns a new, empty List with the same key order as |l|.
+func (l *List) Empty() *List {
+	return NewSkipList(l.keyOrder)
+}
+
 func (l *List) Copy() *List {
 	copies := make([]skipNode, len(l.nodes))
 	copy(copies, l.nodes)
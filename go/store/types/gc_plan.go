@@ -0,0 +1,189 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dolthub/dolt/go/store/chunks"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// GCPlan describes what a GC would do, as computed by GCDryRun without modifying the store.
+type GCPlan struct {
+	// TableFiles are the table files which GC would rewrite. Their reachable chunks are copied into new table files,
+	// and then they are removed.
+	TableFiles []string
+	// TotalChunks is the number of chunks in TableFiles.
+	TotalChunks uint64
+	// ReachableChunks is the number of chunks in TableFiles which are reachable, and so would be kept.
+	ReachableChunks uint64
+	// TotalBytes is the size of TableFiles, or 0 if the store doesn't keep its chunks in table files.
+	TotalBytes uint64
+	// ConjoinTableFiles are the table files which are due to be conjoined into a single table file, independently of
+	// GC. It is empty if the store doesn't need conjoining.
+	ConjoinTableFiles []string
+}
+
+// CollectedChunks is the number of chunks GC would remove.
+func (p GCPlan) CollectedChunks() uint64 {
+	if p.ReachableChunks >= p.TotalChunks {
+		return 0
+	}
+	return p.TotalChunks - p.ReachableChunks
+}
+
+// CollectedBytes estimates the number of bytes GC would free, assuming the chunks it removes are of average size.
+func (p GCPlan) CollectedBytes() uint64 {
+	if p.TotalChunks == 0 {
+		return 0
+	}
+	return uint64(float64(p.TotalBytes) * float64(p.CollectedChunks()) / float64(p.TotalChunks))
+}
+
+// conjoinPlanner is implemented by chunk stores which periodically conjoin their table files.
+type conjoinPlanner interface {
+	// ConjoinPlan returns the table files the store would conjoin if it conjoined now, or nil if it doesn't need to.
+	ConjoinPlan() ([]string, error)
+}
+
+type chunkCounter interface {
+	Count() (uint32, error)
+}
+
+// GCDryRun computes what GC would collect when run with |mode| and the same refs, by walking the chunks reachable
+// from the root and from |oldGenRefs| and |newGenRefs| the way GC does, but without copying any of them.
+func (lvs *ValueStore) GCDryRun(ctx context.Context, mode GCMode, oldGenRefs, newGenRefs hash.HashSet) (GCPlan, error) {
+	lvs.versOnce.Do(lvs.expectVersion)
+
+	var plan GCPlan
+	var stores []chunks.ChunkStore
+	var skip chunks.HasManyFunc
+	if gcs, ok := lvs.cs.(chunks.GenerationalCS); ok {
+		switch mode {
+		case GCModeDefault:
+			// Only the new gen is rewritten, and the walk stops at chunks which are already in the old gen.
+			stores = []chunks.ChunkStore{gcs.NewGen()}
+			skip = gcs.OldGenGCFilter()
+		case GCModeFull:
+			stores = []chunks.ChunkStore{gcs.NewGen(), gcs.OldGen()}
+		default:
+			return GCPlan{}, fmt.Errorf("unsupported GCMode %v", mode)
+		}
+	} else if _, ok := lvs.cs.(chunks.ChunkStoreGarbageCollector); ok {
+		stores = []chunks.ChunkStore{lvs.cs}
+	} else {
+		return GCPlan{}, chunks.ErrUnsupportedOperation
+	}
+
+	for _, cs := range stores {
+		if err := addStoreToPlan(ctx, cs, &plan); err != nil {
+			return GCPlan{}, err
+		}
+	}
+
+	root, err := lvs.Root(ctx)
+	if err != nil {
+		return GCPlan{}, err
+	}
+	toVisit := make(hash.HashSet)
+	toVisit.InsertAll(oldGenRefs)
+	toVisit.InsertAll(newGenRefs)
+	if !root.IsEmpty() {
+		toVisit.Insert(root)
+	}
+	plan.ReachableChunks, err = lvs.countReachable(ctx, toVisit, skip)
+	if err != nil {
+		return GCPlan{}, err
+	}
+	return plan, nil
+}
+
+func addStoreToPlan(ctx context.Context, cs chunks.ChunkStore, plan *GCPlan) error {
+	if tfs, ok := cs.(chunks.TableFileStore); ok {
+		_, files, _, err := tfs.Sources(ctx)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			plan.TableFiles = append(plan.TableFiles, f.FileID())
+			plan.TotalChunks += uint64(f.NumChunks())
+		}
+		size, err := tfs.Size(ctx)
+		if err != nil {
+			return err
+		}
+		plan.TotalBytes += size
+	} else if counter, ok := cs.(chunkCounter); ok {
+		count, err := counter.Count()
+		if err != nil {
+			return err
+		}
+		plan.TotalChunks += uint64(count)
+	}
+	if planner, ok := cs.(conjoinPlanner); ok {
+		files, err := planner.ConjoinPlan()
+		if err != nil {
+			return err
+		}
+		plan.ConjoinTableFiles = append(plan.ConjoinTableFiles, files...)
+	}
+	return nil
+}
+
+// countReachable returns the number of chunks reachable from |toVisit| which are present in the store. If |skip| is
+// not nil, the chunks it reports as present are neither counted nor walked through.
+func (lvs *ValueStore) countReachable(ctx context.Context, toVisit hash.HashSet, skip chunks.HasManyFunc) (uint64, error) {
+	visited := make(hash.HashSet)
+	var count uint64
+	for len(toVisit) > 0 {
+		batch := make(hash.HashSet, len(toVisit))
+		for h := range toVisit {
+			if !visited.Has(h) {
+				batch.Insert(h)
+			}
+		}
+		if skip != nil && len(batch) > 0 {
+			var err error
+			batch, err = skip(ctx, batch)
+			if err != nil {
+				return 0, err
+			}
+		}
+		visited.InsertAll(batch)
+
+		next := make(hash.HashSet)
+		var mu sync.Mutex
+		var walkErr error
+		err := lvs.cs.GetMany(ctx, batch, func(ctx context.Context, c *chunks.Chunk) {
+			mu.Lock()
+			defer mu.Unlock()
+			count++
+			if walkErr == nil {
+				walkErr = AddrsFromNomsValue(*c, lvs.nbf, next)
+			}
+		})
+		if err != nil {
+			return 0, err
+		}
+		if walkErr != nil {
+			return 0, walkErr
+		}
+		toVisit = next
+	}
+	return count, nil
+}
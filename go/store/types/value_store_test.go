@@ -212,6 +212,41 @@ func TestGC(t *testing.T) {
 	assert.Nil(v2)
 }
 
+func TestGCDryRun(t *testing.T) {
+	ctx := context.Background()
+	vs := newTestValueStore()
+	vs.skipWriteCaching = true
+	r1 := mustRef(vs.WriteValue(ctx, String("committed")))
+	r2 := mustRef(vs.WriteValue(ctx, String("unreferenced")))
+	set1 := mustSet(NewSet(ctx, vs, r1))
+	set2 := mustSet(NewSet(ctx, vs, r2))
+
+	h1 := mustRef(vs.WriteValue(ctx, set1)).TargetHash()
+	rt, err := vs.Root(ctx)
+	require.NoError(t, err)
+	ok, err := vs.Commit(ctx, h1, rt)
+	require.NoError(t, err)
+	require.True(t, ok)
+	h2 := mustRef(vs.WriteValue(ctx, set2)).TargetHash()
+
+	plan, err := vs.GCDryRun(ctx, GCModeDefault, hash.HashSet{}, hash.HashSet{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), plan.TotalChunks)
+	assert.Equal(t, uint64(2), plan.ReachableChunks)
+	assert.Equal(t, uint64(2), plan.CollectedChunks())
+
+	// A dry run doesn't collect anything
+	v2, err := vs.ReadValue(ctx, h2)
+	require.NoError(t, err)
+	assert.NotNil(t, v2)
+
+	// Chunks reachable from the refs passed in are kept too
+	plan, err = vs.GCDryRun(ctx, GCModeDefault, hash.HashSet{}, hash.NewHashSet(h2))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), plan.ReachableChunks)
+	assert.Equal(t, uint64(0), plan.CollectedChunks())
+}
+
 func TestGCStateDetails(t *testing.T) {
 	// In the absence of concurrency, these tests call
 	// |waitForNoGC| without gcMu held.
@@ -0,0 +1,123 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package delta encodes a byte slice as a binary delta against a similar base slice, as a sequence of
+// ranges copied from the base and bytes inserted from the target.
+package delta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// minMatch is the length of the shortest range of the base that is copied instead of inserted.
+const minMatch = 16
+
+// ErrInvalidDelta is returned by Apply when a delta is malformed or doesn't apply to its base.
+var ErrInvalidDelta = errors.New("invalid delta")
+
+// A delta starts with the uvarint length of the target, followed by a sequence of ops. Each op starts with a
+// uvarint |n|. If the low bit of |n| is clear, it's followed by n>>1 bytes inserted into the target. If it's
+// set, it's followed by a uvarint offset into the base, from which n>>1 bytes are copied into the target.
+
+// Encode returns a delta that reconstructs |target| from |base|.
+func Encode(base, target []byte) []byte {
+	idx := make(map[[minMatch]byte]int, max(len(base)-minMatch+1, 0))
+	for i := len(base) - minMatch; i >= 0; i-- {
+		// the earliest offset of each window is kept
+		idx[[minMatch]byte(base[i:i+minMatch])] = i
+	}
+
+	delta := binary.AppendUvarint(nil, uint64(len(target)))
+	insertStart := 0
+	i := 0
+	for i+minMatch <= len(target) {
+		off, ok := idx[[minMatch]byte(target[i:i+minMatch])]
+		if !ok {
+			i++
+			continue
+		}
+
+		// extend the match backwards into the pending insert, and forwards
+		start, bStart := i, off
+		for start > insertStart && bStart > 0 && target[start-1] == base[bStart-1] {
+			start--
+			bStart--
+		}
+		end := i + minMatch
+		for end < len(target) && off+end-i < len(base) && target[end] == base[off+end-i] {
+			end++
+		}
+
+		delta = appendInsert(delta, target[insertStart:start])
+		delta = binary.AppendUvarint(delta, uint64(end-start)<<1|1)
+		delta = binary.AppendUvarint(delta, uint64(bStart))
+		i, insertStart = end, end
+	}
+	return appendInsert(delta, target[insertStart:])
+}
+
+func appendInsert(delta, data []byte) []byte {
+	if len(data) == 0 {
+		return delta
+	}
+	delta = binary.AppendUvarint(delta, uint64(len(data))<<1)
+	return append(delta, data...)
+}
+
+// Apply returns the target reconstructed from |base| and |delta|.
+func Apply(base, delta []byte) ([]byte, error) {
+	r := bytes.NewReader(delta)
+	sz, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, ErrInvalidDelta
+	}
+
+	target := make([]byte, 0, min(sz, uint64(len(base)+len(delta))))
+	for r.Len() > 0 {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, ErrInvalidDelta
+		}
+		l := n >> 1
+		if l == 0 || uint64(len(target))+l > sz {
+			return nil, ErrInvalidDelta
+		}
+
+		if n&1 == 0 {
+			if uint64(r.Len()) < l {
+				return nil, ErrInvalidDelta
+			}
+			start := len(delta) - r.Len()
+			target = append(target, delta[start:start+int(l)]...)
+			if _, err := r.Seek(int64(l), io.SeekCurrent); err != nil {
+				return nil, ErrInvalidDelta
+			}
+			continue
+		}
+
+		off, err := binary.ReadUvarint(r)
+		if err != nil || off > uint64(len(base)) || l > uint64(len(base))-off {
+			return nil, ErrInvalidDelta
+		}
+		target = append(target, base[off:off+l]...)
+	}
+
+	if uint64(len(target)) != sz {
+		return nil, ErrInvalidDelta
+	}
+	return target, nil
+}
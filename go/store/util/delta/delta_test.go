@@ -0,0 +1,76 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delta
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelta(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	randBytes := func(n int) []byte {
+		bs := make([]byte, n)
+		rng.Read(bs)
+		return bs
+	}
+
+	base := randBytes(4096)
+	edited := append([]byte{}, base...)
+	copy(edited[1000:], "an updated column value")
+	edited = append(edited[:3000], append(randBytes(40), edited[3000:]...)...)
+
+	tests := []struct {
+		name         string
+		base, target []byte
+		small        bool
+	}{
+		{"identical", base, base, true},
+		{"edited", base, edited, true},
+		{"prefix", base, base[:2048], true},
+		{"repeated", base[:512], append(append([]byte{}, base[:512]...), base[:512]...), true},
+		{"unrelated", base, randBytes(4096), false},
+		{"empty base", nil, base[:100], false},
+		{"empty target", base, nil, true},
+		{"short", base, base[:minMatch-1], false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Encode(tt.base, tt.target)
+			if tt.small {
+				assert.Less(t, len(d), len(tt.target)/10+16)
+			}
+			actual, err := Apply(tt.base, d)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.target), len(actual))
+			assert.Equal(t, string(tt.target), string(actual))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		d := Encode(base, edited)
+		_, err := Apply(base[:2000], d)
+		assert.ErrorIs(t, err, ErrInvalidDelta)
+		_, err = Apply(base, d[:len(d)-1])
+		assert.ErrorIs(t, err, ErrInvalidDelta)
+		_, err = Apply(base, nil)
+		assert.ErrorIs(t, err, ErrInvalidDelta)
+		_, err = Apply(base, []byte{10, 1<<1 | 1, 0})
+		assert.ErrorIs(t, err, ErrInvalidDelta)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tempfiles
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/dustin/go-humanize"
+)
+
+// ErrScratchQuotaExceeded is returned when writing scratch data would take a ScratchSpace over its quota.
+var ErrScratchQuotaExceeded = errors.New("scratch space quota exceeded")
+
+// Quota is implemented by TempFileProviders which limit how many bytes their files may hold at once. Writers reserve
+// space before writing to a file, and release it once the file is deleted.
+type Quota interface {
+	// Reserve reserves |n| bytes, or returns an error wrapping ErrScratchQuotaExceeded if that would exceed the quota.
+	Reserve(n int64) error
+	// Release releases |n| bytes which were reserved by Reserve.
+	Release(n int64)
+}
+
+// ScratchSpace is a TempFileProvider for scratch data, such as the sorted runs of an external sort, which creates its
+// files in a given directory and limits how many bytes they hold at once.
+type ScratchSpace struct {
+	*TempFileProviderAt
+	quota int64
+	used  atomic.Int64
+}
+
+var _ TempFileProvider = (*ScratchSpace)(nil)
+var _ Quota = (*ScratchSpace)(nil)
+
+// NewScratchSpace returns a ScratchSpace which creates files in |dir|, which must already exist, and which holds at
+// most |quota| bytes at once. A |quota| of 0 is unlimited.
+func NewScratchSpace(dir string, quota int64) *ScratchSpace {
+	return &ScratchSpace{TempFileProviderAt: NewTempFileProviderAt(dir), quota: quota}
+}
+
+// Quota returns the most bytes the files of |s| may hold at once, or 0 if it is unlimited.
+func (s *ScratchSpace) Quota() int64 {
+	return s.quota
+}
+
+// Used returns the number of bytes currently reserved in |s|.
+func (s *ScratchSpace) Used() int64 {
+	return s.used.Load()
+}
+
+func (s *ScratchSpace) Reserve(n int64) error {
+	for {
+		used := s.used.Load()
+		if s.quota > 0 && used+n > s.quota {
+			return fmt.Errorf("%w: writing %s of scratch data to %s would exceed the quota of %s",
+				ErrScratchQuotaExceeded, humanize.Bytes(uint64(used+n)), s.GetTempDir(), humanize.Bytes(uint64(s.quota)))
+		}
+		if s.used.CompareAndSwap(used, used+n) {
+			return nil
+		}
+	}
+}
+
+func (s *ScratchSpace) Release(n int64) {
+	s.used.Add(-n)
+}
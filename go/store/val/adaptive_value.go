@@ -97,6 +97,15 @@ func (v AdaptiveValue) IsOutOfBand() bool {
 	return v[0] != 0
 }
 
+// OutOfBandAddress returns the address of the value if it's stored out-of-band.
+func (v AdaptiveValue) OutOfBandAddress() (hash.Hash, bool) {
+	if !v.IsOutOfBand() {
+		return hash.Hash{}, false
+	}
+	_, lengthBytes := uvarint.Uvarint(v)
+	return hash.New(v[lengthBytes:]), true
+}
+
 var maxVarIntLength ByteSize = 9
 var maxOutOfBandAdaptiveValueLength = maxVarIntLength + hash.ByteLen
 
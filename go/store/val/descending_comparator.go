@@ -0,0 +1,125 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package val
+
+import (
+	"context"
+)
+
+// DescendingTupleComparator is a comparator that orders some fields of a tuple in descending order. Fields are
+// compared by an inner comparator, and the result is inverted for fields that are descending. This is used by
+// indexes that declare descending columns, so that the index is stored in the order of its declared directions.
+//
+// Direction is a property of the comparator rather than of the encoding, the same way CollationTupleComparator orders
+// strings by their collation. Field values are encoded as they are for ascending fields, so everything that decodes
+// index tuples or builds them from SQL values, like range lookups, diffs, merges and statistics, is unchanged. An
+// order-inverting encoding would have needed a second codec for every type, and byte-wise tricks like complementing
+// the bytes of a field don't invert the order of variable-length or collated values. The cost is that only the order
+// of the tuples in the tree changes, so older clients that would compare them in ascending order are locked out of
+// roots with descending index columns by doltdb.DescendingIndexFeatureVersion.
+type DescendingTupleComparator struct {
+	innerCmp   TupleComparator
+	descending []bool
+}
+
+var _ TupleComparator = DescendingTupleComparator{}
+
+// NewDescendingTupleComparator returns a TupleComparator that wraps |inner|, inverting the order of each field i for
+// which |descending|[i] is true. If |inner| is nil, the DefaultTupleComparator is used.
+func NewDescendingTupleComparator(inner TupleComparator, descending []bool) TupleComparator {
+	if inner == nil {
+		inner = DefaultTupleComparator{}
+	}
+	return DescendingTupleComparator{innerCmp: inner, descending: descending}
+}
+
+// Compare implements the TupleComparator interface.
+func (c DescendingTupleComparator) Compare(ctx context.Context, left, right Tuple, desc TupleDesc) (cmp int) {
+	fast := desc.GetFixedAccess()
+	for i := range fast {
+		start, stop := fast[i][0], fast[i][1]
+		cmp = c.CompareValues(ctx, i, left[start:stop], right[start:stop], desc.Types[i])
+		if cmp != 0 {
+			return cmp
+		}
+	}
+
+	off := len(fast)
+	for i, typ := range desc.Types[off:] {
+		j := i + off
+		cmp = c.CompareValues(ctx, j, left.GetField(j), right.GetField(j), typ)
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return
+}
+
+// CompareValues implements the TupleComparator interface.
+func (c DescendingTupleComparator) CompareValues(ctx context.Context, index int, left, right []byte, typ Type) int {
+	cmp := c.innerCmp.CompareValues(ctx, index, left, right, typ)
+	if index < len(c.descending) && c.descending[index] {
+		return -cmp
+	}
+	return cmp
+}
+
+// Prefix implements the TupleComparator interface.
+func (c DescendingTupleComparator) Prefix(n int) TupleComparator {
+	if n > len(c.descending) {
+		n = len(c.descending)
+	}
+	return DescendingTupleComparator{c.innerCmp.Prefix(n), c.descending[:n]}
+}
+
+// Suffix implements the TupleComparator interface.
+func (c DescendingTupleComparator) Suffix(n int) TupleComparator {
+	descending := make([]bool, n)
+	if n <= len(c.descending) {
+		copy(descending, c.descending[len(c.descending)-n:])
+	}
+	return DescendingTupleComparator{c.innerCmp.Suffix(n), descending}
+}
+
+// Validated implements the TupleComparator interface.
+func (c DescendingTupleComparator) Validated(types []Type) TupleComparator {
+	if len(c.descending) > len(types) {
+		panic("too many sort directions compared to type encoding")
+	}
+	innerCmp := c.innerCmp.Validated(types)
+	if !anyDescending(c.descending) {
+		return innerCmp
+	}
+	descending := c.descending
+	if len(descending) < len(types) {
+		descending = make([]bool, len(types))
+		copy(descending, c.descending)
+	}
+	return DescendingTupleComparator{innerCmp, descending}
+}
+
+// IsDescending returns whether the ith field is ordered in descending order.
+func (c DescendingTupleComparator) IsDescending(i int) bool {
+	return i < len(c.descending) && c.descending[i]
+}
+
+func anyDescending(descending []bool) bool {
+	for _, d := range descending {
+		if d {
+			return true
+		}
+	}
+	return false
+}
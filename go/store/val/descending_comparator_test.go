@@ -0,0 +1,71 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package val
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescendingTupleComparator(t *testing.T) {
+	ctx := context.Background()
+	desc := NewTupleDescriptorWithArgs(TupleDescriptorArgs{
+		Comparator: NewDescendingTupleComparator(nil, []bool{true, false}),
+	}, Type{Enc: Int64Enc, Nullable: true}, Type{Enc: Int64Enc})
+
+	assert.True(t, desc.IsDescending(0))
+	assert.False(t, desc.IsDescending(1))
+
+	tb := NewTupleBuilder(desc, nil)
+	build := func(a *int64, b int64) Tuple {
+		if a != nil {
+			tb.PutInt64(0, *a)
+		}
+		tb.PutInt64(1, b)
+		tup, err := tb.Build(testPool)
+		require.NoError(t, err)
+		return tup
+	}
+	one, two := int64(1), int64(2)
+
+	// the first field is ordered descending, the second ascending
+	assert.Equal(t, 1, desc.Compare(ctx, build(&one, 1), build(&two, 1)))
+	assert.Equal(t, -1, desc.Compare(ctx, build(&two, 1), build(&one, 1)))
+	assert.Equal(t, -1, desc.Compare(ctx, build(&one, 1), build(&one, 2)))
+	assert.Equal(t, 0, desc.Compare(ctx, build(&one, 1), build(&one, 1)))
+
+	// NULLs sort first in ascending order, so they sort last in descending order
+	assert.Equal(t, 1, desc.Compare(ctx, build(nil, 1), build(&one, 1)))
+
+	prefix := desc.PrefixDesc(1)
+	assert.True(t, prefix.IsDescending(0))
+	assert.Equal(t, 1, prefix.Compare(ctx, build(&one, 2), build(&two, 1)))
+}
+
+func TestDescendingTupleComparatorValidated(t *testing.T) {
+	types := []Type{{Enc: Int64Enc}, {Enc: Int64Enc}, {Enc: Hash128Enc}}
+
+	// directions are padded with ascending fields
+	cmp := NewDescendingTupleComparator(nil, []bool{false, true}).Validated(types)
+	assert.Equal(t, DescendingTupleComparator{DefaultTupleComparator{}, []bool{false, true, false}}, cmp)
+	assert.Equal(t, DescendingTupleComparator{DefaultTupleComparator{}, []bool{true, false}}, cmp.Suffix(2))
+
+	// a comparator without descending fields is not wrapped
+	cmp = NewDescendingTupleComparator(nil, []bool{false, false}).Validated(types)
+	assert.Equal(t, DefaultTupleComparator{}, cmp)
+}
@@ -101,5 +101,13 @@ func (c ExtendedTupleComparator) Validated(types []Type) TupleComparator {
 	if !hasHandler {
 		return innerCmp
 	}
+	// Descending fields must also be inverted for extended types, so the descending comparator stays outermost
+	if descCmp, ok := innerCmp.(DescendingTupleComparator); ok {
+		descInner := descCmp.innerCmp
+		if extendedInner, ok := descInner.(ExtendedTupleComparator); ok {
+			descInner = extendedInner.innerCmp
+		}
+		return DescendingTupleComparator{ExtendedTupleComparator{descInner, c.handlers}, descCmp.descending}
+	}
 	return ExtendedTupleComparator{innerCmp, c.handlers}
 }
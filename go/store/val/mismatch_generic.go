@@ -0,0 +1,30 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !amd64 && !arm64
+// +build !amd64,!arm64
+
+package val
+
+// mismatch returns the offset of the first byte at which |left| and |right| differ, or the length of the shorter of
+// the two if they don't differ.
+func mismatch(left, right []byte) int {
+	n := min(len(left), len(right))
+	for i := 0; i < n; i++ {
+		if left[i] != right[i] {
+			return i
+		}
+	}
+	return n
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build amd64 || arm64
+// +build amd64 arm64
+
+package val
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// mismatch returns the offset of the first byte at which |left| and |right| differ, or the length of the shorter of
+// the two if they don't differ. It compares eight bytes at a time, which amd64 and arm64 load unaligned with a single
+// instruction, and finds the differing byte of a word from its trailing zeros.
+func mismatch(left, right []byte) int {
+	n := min(len(left), len(right))
+	i := 0
+	for ; i+8 <= n; i += 8 {
+		if x := binary.LittleEndian.Uint64(left[i:]) ^ binary.LittleEndian.Uint64(right[i:]); x != 0 {
+			return i + bits.TrailingZeros64(x)/8
+		}
+	}
+	for ; i < n; i++ {
+		if left[i] != right[i] {
+			return i
+		}
+	}
+	return n
+}
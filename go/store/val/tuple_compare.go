@@ -44,7 +44,7 @@ var _ TupleComparator = DefaultTupleComparator{}
 
 // Compare implements TupleComparator
 func (d DefaultTupleComparator) Compare(ctx context.Context, left, right Tuple, desc TupleDesc) (cmp int) {
-	for i := range desc.fast {
+	for i := skipEqualPrefix(left, right, desc); i < len(desc.fast); i++ {
 		start, stop := desc.fast[i][0], desc.fast[i][1]
 		cmp = compare(desc.Types[i], left[start:stop], right[start:stop])
 		if cmp != 0 {
@@ -83,6 +83,23 @@ func (d DefaultTupleComparator) Validated(types []Type) TupleComparator {
 	return d
 }
 
+// skipEqualPrefix returns the index of the first field of |desc| which may differ between |left| and |right|. Keys
+// with several leading fixed-width fields, like composite keys of integers, often share most of them, and finding the
+// first byte at which they differ skips comparing the shared fields one at a time.
+func skipEqualPrefix(left, right Tuple, desc TupleDesc) int {
+	if desc.eqPrefix < 2 {
+		return 0
+	}
+	end := desc.fast[desc.eqPrefix-1][1]
+	off := ByteSize(mismatch(left[:end], right[:end]))
+	for i := 0; i < desc.eqPrefix; i++ {
+		if off < desc.fast[i][1] {
+			return i
+		}
+	}
+	return desc.eqPrefix
+}
+
 func compare(typ Type, left, right []byte) int {
 	// order NULLs first
 	if left == nil || right == nil {
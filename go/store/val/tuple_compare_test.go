@@ -0,0 +1,143 @@
+// Copyright 2025 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package val
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMismatch(t *testing.T) {
+	for n := 0; n < 100; n++ {
+		left := make([]byte, n)
+		rand.Read(left)
+		right := make([]byte, n)
+		copy(right, left)
+		assert.Equal(t, n, mismatch(left, right))
+		assert.Equal(t, n, mismatch(left, append(right, 1)))
+		for i := 0; i < n; i++ {
+			right[i]++
+			assert.Equal(t, i, mismatch(left, right))
+			right[i]--
+		}
+	}
+}
+
+func TestCompareSkipsEqualPrefix(t *testing.T) {
+	ctx := context.Background()
+	desc := NewTupleDescriptor(
+		Type{Enc: Int32Enc},
+		Type{Enc: Int64Enc},
+		Type{Enc: Uint16Enc},
+		Type{Enc: Float64Enc},
+		Type{Enc: Int64Enc},
+	)
+	require.Equal(t, 3, desc.eqPrefix)
+	slow := desc.WithoutFixedAccess()
+
+	build := func(a int32, b int64, c uint16, d float64, e int64) Tuple {
+		tb := NewTupleBuilder(desc, nil)
+		tb.PutInt32(0, a)
+		tb.PutInt64(1, b)
+		tb.PutUint16(2, c)
+		tb.PutFloat64(3, d)
+		tb.PutInt64(4, e)
+		tup, err := tb.Build(testPool)
+		require.NoError(t, err)
+		return tup
+	}
+	tuples := []Tuple{
+		build(1, 1, 1, 1, 1),
+		build(1, 1, 1, 1, 2),
+		build(1, 1, 2, 1, 1),
+		build(1, -1, 1, 1, 1),
+		build(-1, 1, 1, 1, 1),
+		build(1, 256, 1, 1, 1),
+		build(1, 1, 1, math.NaN(), 1),
+		build(1, 1, 1, math.Copysign(0, -1), 1),
+		build(1, 1, 1, 0, 1),
+	}
+	for _, l := range tuples {
+		for _, r := range tuples {
+			assert.Equal(t, slow.Compare(ctx, l, r), desc.Compare(ctx, l, r))
+		}
+	}
+
+	// NaNs don't compare equal to themselves, so float fields aren't skipped
+	nan := build(1, 1, 1, math.NaN(), 1)
+	assert.NotEqual(t, 0, desc.Compare(ctx, nan, nan))
+	assert.Equal(t, 0, desc.Compare(ctx, build(1, 1, 1, 0, 1), build(1, 1, 1, math.Copysign(0, -1), 1)))
+}
+
+func BenchmarkCompare(b *testing.B) {
+	ctx := context.Background()
+	desc := NewTupleDescriptor(
+		Type{Enc: Int64Enc},
+		Type{Enc: Int64Enc},
+		Type{Enc: Int64Enc},
+		Type{Enc: Int64Enc},
+	)
+	tuples := make([]Tuple, 1024)
+	for i := range tuples {
+		tb := NewTupleBuilder(desc, nil)
+		// a composite key whose leading fields are shared by many rows
+		tb.PutInt64(0, 1)
+		tb.PutInt64(1, int64(i/256))
+		tb.PutInt64(2, int64(i/16))
+		tb.PutInt64(3, int64(i))
+		var err error
+		tuples[i], err = tb.Build(testPool)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	bench := func(b *testing.B, desc TupleDesc) {
+		for i := 0; i < b.N; i++ {
+			j := i % (len(tuples) - 1)
+			_ = desc.Compare(ctx, tuples[j], tuples[j+1])
+		}
+	}
+	b.Run("fixed access", func(b *testing.B) {
+		bench(b, desc)
+	})
+	b.Run("field by field", func(b *testing.B) {
+		fields := desc
+		fields.eqPrefix = 0
+		bench(b, fields)
+	})
+}
+
+func BenchmarkMismatch(b *testing.B) {
+	for _, n := range []int{16, 64, 256} {
+		left := make([]byte, n)
+		rand.Read(left)
+		right := make([]byte, n)
+		copy(right, left)
+		right[n-1]++
+		b.Run(fmt.Sprintf("%d bytes", n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				_ = mismatch(left, right)
+			}
+		})
+	}
+}
@@ -48,6 +48,8 @@ type TupleDesc struct {
 	Handlers []TupleTypeHandler
 	cmp      TupleComparator
 	fast     FixedAccess
+	// eqPrefix is the number of leading fields of |fast| whose values are equal if their bytes are equal.
+	eqPrefix int
 }
 
 // TupleTypeHandler is used to specifically handle types that use extended encoding. Such types are declared by GMS, and
@@ -96,6 +98,7 @@ func NewTupleDescriptorWithArgs(args TupleDescriptorArgs, types ...Type) (td Tup
 		cmp:      args.Comparator,
 		fast:     makeFixedAccess(types),
 	}
+	td.eqPrefix = byteEqualPrefix(types, td.fast)
 	return
 }
 
@@ -142,6 +145,18 @@ func makeFixedAccess(types []Type) (acc FixedAccess) {
 	return
 }
 
+// byteEqualPrefix returns the number of leading fields of |acc| which compare equal whenever their bytes are equal.
+// Floats end the prefix, since NaNs don't compare equal to themselves.
+func byteEqualPrefix(types []Type, acc FixedAccess) int {
+	for i := range acc {
+		switch types[i].Enc {
+		case Float32Enc, Float64Enc:
+			return i
+		}
+	}
+	return len(acc)
+}
+
 func (td TupleDesc) AddressFieldCount() (n int) {
 	IterAddressFields(td, func(int, Type) {
 		n++
@@ -188,6 +203,14 @@ func (td TupleDesc) Comparator() TupleComparator {
 	return td.cmp
 }
 
+// IsDescending returns true if the ith field of the TupleDesc is ordered in descending order.
+func (td TupleDesc) IsDescending(i int) bool {
+	if c, ok := td.cmp.(DescendingTupleComparator); ok {
+		return c.IsDescending(i)
+	}
+	return false
+}
+
 // Count returns the number of fields in the TupleDesc.
 func (td TupleDesc) Count() int {
 	return len(td.Types)
@@ -15,8 +15,11 @@
 package val
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
+	"fmt"
+	"io"
 
 	"github.com/dolthub/go-mysql-server/sql"
 	"github.com/dolthub/go-mysql-server/sql/types"
@@ -34,6 +37,16 @@ type ValueStore interface {
 	WriteBytes(ctx context.Context, val []byte) (hash.Hash, error)
 }
 
+// StreamingValueStore is a ValueStore that can read byte sequences incrementally, without loading
+// the entire sequence into memory.
+type StreamingValueStore interface {
+	ValueStore
+	// NewBytesReader returns a reader of the byte sequence at |h|.
+	NewBytesReader(ctx context.Context, h hash.Hash) (io.Reader, error)
+	// ReadBytesRange returns up to |length| bytes of the byte sequence at |h|, starting at |offset|.
+	ReadBytesRange(ctx context.Context, h hash.Hash, offset, length int64) ([]byte, error)
+}
+
 // ImmutableValue represents a content-addressed value stored in a ValueStore.
 // The contents are loaded lazily and stored in |Buf|
 type ImmutableValue struct {
@@ -61,6 +74,42 @@ func (t *ImmutableValue) GetBytes(ctx context.Context) ([]byte, error) {
 	return t.Buf, nil
 }
 
+// NewReader returns a reader of the value's contents. Unless the contents have already been loaded,
+// they're read incrementally from the ValueStore, if it supports it.
+func (t *ImmutableValue) NewReader(ctx context.Context) (io.Reader, error) {
+	if t.Buf == nil && !t.Addr.IsEmpty() {
+		if svs, ok := t.vs.(StreamingValueStore); ok {
+			return svs.NewBytesReader(ctx, t.Addr)
+		}
+	}
+	buf, err := t.GetBytes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(buf), nil
+}
+
+// GetBytesRange returns up to |length| bytes of the value's contents, starting at |offset|. Unless the
+// contents have already been loaded, only the part of the value up to the end of the range is read.
+func (t *ImmutableValue) GetBytesRange(ctx context.Context, offset, length int64) ([]byte, error) {
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("invalid byte range (%d, %d)", offset, length)
+	}
+	if t.Buf == nil && !t.Addr.IsEmpty() {
+		if svs, ok := t.vs.(StreamingValueStore); ok {
+			return svs.ReadBytesRange(ctx, t.Addr, offset, length)
+		}
+	}
+	buf, err := t.GetBytes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= int64(len(buf)) {
+		return []byte{}, nil
+	}
+	return buf[offset:min(int64(len(buf)), offset+length)], nil
+}
+
 type TextStorage struct {
 	ImmutableValue
 	maxByteLength int64
@@ -190,15 +239,11 @@ func (b *ByteArray) Unwrap(ctx context.Context) ([]byte, error) {
 }
 
 func (b *ByteArray) ToString(ctx context.Context) (string, error) {
-	buf, err := b.ToBytes(ctx)
+	buf, err := b.GetBytesRange(ctx, 0, BytePeekLength)
 	if err != nil {
 		return "", err
 	}
-	toShow := BytePeekLength
-	if len(buf) < toShow {
-		toShow = len(buf)
-	}
-	return string(buf[:toShow]), nil
+	return string(buf), nil
 }
 
 // Hash implements sql.AnyWrapper by returning the Dolt hash.
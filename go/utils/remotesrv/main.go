@@ -18,16 +18,20 @@ import (
 	"context"
 	"flag"
 	"fmt"
-	"log"
+	"net/http"
 	"os"
 	"os/signal"
 
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
 	"github.com/dolthub/dolt/go/store/datas"
 )
 
@@ -40,18 +44,30 @@ func main() {
 	grpcPortParam := flag.Int("grpc-port", -1, "the port the grpc server will listen on; default 50051")
 	httpPortParam := flag.Int("http-port", -1, "the port the http server will listen on; default 80; if http-port is equal to grpc-port, both services will serve over the same port")
 	httpHostParam := flag.String("http-host", "", "hostname to use in the host component of the URLs that the server generates; default ''; if '', server will echo the :authority header")
+	maxBandwidthParam := flag.String("max-bandwidth", "", "limit the table files uploaded to and downloaded from the server to this many bytes per second in total, such as 10MB; default unlimited")
+	drainTimeoutParam := flag.Duration("drain-timeout", 0, "on shutdown, wait at most this long for in-flight requests to finish, such as 30s; default wait for as long as they take")
+	maxConcurrentStreamsParam := flag.Uint("max-concurrent-streams", 0, "limit the number of concurrent requests of each HTTP/2 connection; default unlimited")
+	namespacesParam := flag.String("namespaces", "", "YAML file configuring the namespaces of a multi-tenant server, whose databases are addressed as <namespace>/<database>; default none")
+	logLevelParam := flag.String("log-level", "info", "the level of logging, one of trace, debug, info, warning or error; default info")
+	adminAddrParam := flag.String("admin-addr", "", "address to serve admin endpoints on, such as localhost:8081, which should not be reachable by clients; default none")
 	flag.Parse()
 
+	logLevel, err := logrus.ParseLevel(*logLevelParam)
+	if err != nil {
+		logrus.Fatalf("invalid 'log-level' parameter: %v", err)
+	}
+	logrus.SetLevel(logLevel)
+
 	if dirParam != nil && len(*dirParam) > 0 {
 		err := os.Chdir(*dirParam)
 
 		if err != nil {
-			log.Fatalln("failed to chdir to:", *dirParam, "error:", err.Error())
+			logrus.WithError(err).WithField("dir", *dirParam).Fatal("failed to chdir")
 		} else {
-			log.Println("cwd set to " + *dirParam)
+			logrus.WithField("dir", *dirParam).Info("cwd set")
 		}
 	} else {
-		log.Println("'dir' parameter not provided. Using the current working dir.")
+		logrus.Info("'dir' parameter not provided. Using the current working dir.")
 	}
 
 	if *httpPortParam != -1 {
@@ -59,17 +75,25 @@ func main() {
 	} else {
 		*httpPortParam = 80
 		*httpHostParam = ":80"
-		log.Println("'http-port' parameter not provided. Using default port 80")
+		logrus.Info("'http-port' parameter not provided. Using default port 80")
 	}
 
 	if *grpcPortParam == -1 {
 		*grpcPortParam = 50051
-		log.Println("'grpc-port' parameter not provided. Using default port 50051")
+		logrus.Info("'grpc-port' parameter not provided. Using default port 50051")
+	}
+
+	var maxBandwidth uint64
+	if *maxBandwidthParam != "" {
+		maxBandwidth, err = iohelp.ParseBandwidth(*maxBandwidthParam)
+		if err != nil {
+			logrus.Fatalf("invalid 'max-bandwidth' parameter: %v", err)
+		}
 	}
 
 	fs, err := filesys.LocalFilesysWithWorkingDir(".")
 	if err != nil {
-		log.Fatalln("could not get cwd path:", err.Error())
+		logrus.Fatalf("could not get cwd path: %v", err)
 	}
 
 	var dbCache remotesrv.DBCache
@@ -77,7 +101,7 @@ func main() {
 		ctx := context.Background()
 		dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, "remotesrv")
 		if !dEnv.Valid() {
-			log.Fatalln("repo-mode failed to load repository")
+			logrus.Fatal("repo-mode failed to load repository")
 		}
 		db := doltdb.HackDatasDatabaseFromDoltDB(dEnv.DoltDB(ctx))
 		cs := datas.ChunkStoreFromDatabase(db)
@@ -86,25 +110,54 @@ func main() {
 		dbCache = NewLocalCSCache(fs)
 	}
 
+	var options []grpc.ServerOption
+	if *namespacesParam != "" {
+		if *repoModeParam {
+			logrus.Fatal("'namespaces' parameter cannot be used with 'repo-mode'")
+		}
+		namespaces, err := remotesrv.LoadNamespaces(fs, *namespacesParam)
+		if err != nil {
+			logrus.Fatalf("failed to load namespaces: %v", err)
+		}
+		dbCache = remotesrv.NewNamespacedDBCache(dbCache, fs, namespaces)
+		ni := remotesrv.NamespaceInterceptor{
+			Lgr:        logrus.NewEntry(logrus.StandardLogger()),
+			Namespaces: namespaces,
+			DBCache:    dbCache,
+		}
+		options = ni.Options()
+	}
+
 	server, err := remotesrv.NewServer(remotesrv.ServerArgs{
-		HttpHost:           *httpHostParam,
-		HttpListenAddr:     fmt.Sprintf(":%d", *httpPortParam),
-		GrpcListenAddr:     fmt.Sprintf(":%d", *grpcPortParam),
-		FS:                 fs,
-		DBCache:            dbCache,
-		ReadOnly:           *readOnlyParam,
-		ConcurrencyControl: remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_IGNORE_WORKING_SET,
+		HttpHost:             *httpHostParam,
+		HttpListenAddr:       fmt.Sprintf(":%d", *httpPortParam),
+		GrpcListenAddr:       fmt.Sprintf(":%d", *grpcPortParam),
+		FS:                   fs,
+		DBCache:              dbCache,
+		ReadOnly:             *readOnlyParam,
+		ConcurrencyControl:   remotesapi.PushConcurrencyControl_PUSH_CONCURRENCY_CONTROL_IGNORE_WORKING_SET,
+		MaxBandwidth:         maxBandwidth,
+		Options:              options,
+		DrainTimeout:         *drainTimeoutParam,
+		MaxConcurrentStreams: uint32(*maxConcurrentStreamsParam),
 	})
 	if err != nil {
-		log.Fatalf("error creating remotesrv Server: %v\n", err)
+		logrus.Fatalf("error creating remotesrv Server: %v", err)
 	}
 	listeners, err := server.Listeners()
 	if err != nil {
-		log.Fatalf("error starting remotesrv Server listeners: %v\n", err)
+		logrus.Fatalf("error starting remotesrv Server listeners: %v", err)
 	}
 	go func() {
 		server.Serve(listeners)
 	}()
+	if *adminAddrParam != "" {
+		go func() {
+			logrus.WithField("addr", *adminAddrParam).Info("starting admin server")
+			err := http.ListenAndServe(*adminAddrParam, remotesrv.NewLogLevelHandler(logrus.StandardLogger()))
+			logrus.WithError(err).Error("admin server exited")
+		}()
+	}
 	waitForSignal()
 	server.GracefulStop()
 }
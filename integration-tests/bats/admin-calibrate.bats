@@ -0,0 +1,54 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "admin-calibrate: stores cost model coefficients in the global config" {
+    dolt sql -q "create table small (pk int primary key);"
+    dolt sql -q "create table t (pk int primary key, c varchar(20));"
+    dolt sql -q "insert into t with recursive r(n) as (select 1 union all select n + 1 from r where n < 1000) select n, concat('row', n) from r;"
+
+    run dolt admin calibrate --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "of table t:" ]] || false
+    [[ "$output" =~ "costmodel.rand_io" ]] || false
+    run dolt config --global --get costmodel.rand_io
+    [ "$status" -eq 1 ]
+
+    run dolt admin calibrate
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Stored the coefficients in the global config." ]] || false
+    run dolt config --global --get costmodel.seq_io
+    [ "$status" -eq 0 ]
+    [ "$output" = "1" ]
+    run dolt config --global --get costmodel.cpu
+    [ "$status" -eq 0 ]
+    [ -n "$output" ]
+
+    # queries are planned with the calibrated cost model
+    run dolt sql -q "select count(*) from t a join t b on a.pk = b.pk;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1000" ]] || false
+}
+
+@test "admin-calibrate: needs a table with rows" {
+    dolt sql -q "create table t (pk int primary key);"
+    run dolt admin calibrate
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "calibration needs a table with rows" ]] || false
+}
+
+@test "admin-calibrate: invalid coefficients fall back to the default cost model" {
+    dolt sql -q "create table t (pk int primary key); insert into t values (1), (2);"
+    dolt config --global --add costmodel.cpu fast
+    run dolt sql -q "select count(*) from t a join t b on a.pk = b.pk;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
@@ -0,0 +1,69 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+get_head_commit() {
+    dolt log -n 1 | grep -m 1 commit | cut -c 13-44
+}
+
+@test "admin-forget: discards history before a commit" {
+    dolt sql -q "create table t (pk int primary key, secret varchar(20)); insert into t values (1, 'forget me');"
+    dolt commit -Am "first"
+    old=$(get_head_commit)
+    dolt sql -q "update t set secret = 'redacted' where pk = 1;"
+    dolt commit -am "second"
+    dolt branch other
+    dolt tag v1
+    dolt sql -q "insert into t values (2, 'kept');"
+    dolt commit -am "third"
+
+    run dolt admin forget --dry-run HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/heads/other" ]] || false
+    [[ "$output" =~ "refs/tags/v1" ]] || false
+    run dolt log --oneline
+    [[ "$output" =~ "first" ]] || false
+
+    run dolt admin forget HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Removed the discarded history." ]] || false
+
+    run dolt log --oneline
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ ! "$output" =~ "first" ]] || false
+    run dolt log --oneline other
+    [ "${#lines[@]}" -eq 1 ]
+    run dolt log --oneline v1
+    [ "${#lines[@]}" -eq 1 ]
+
+    run dolt sql -q "select secret from t order by pk" -r csv
+    [[ "$output" =~ "redacted" ]] || false
+    [[ "$output" =~ "kept" ]] || false
+
+    run dolt show $old
+    [ "$status" -ne 0 ]
+}
+
+@test "admin-forget: every branch must contain the commit" {
+    dolt commit --allow-empty -m "first"
+    dolt branch old
+    dolt commit --allow-empty -m "second"
+
+    run dolt admin forget HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "refs/heads/old" ]] || false
+
+    run dolt admin forget notacommit
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "notacommit is not a valid commit" ]] || false
+}
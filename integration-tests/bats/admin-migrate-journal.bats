@@ -0,0 +1,117 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "create table t (pk int primary key, c varchar(20)); insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "first"
+    dolt sql -q "insert into t values (3, 'three');"
+}
+
+teardown() {
+    assert_feature_version
+    stop_sql_server
+    teardown_common
+}
+
+@test "admin-migrate-journal: copy of a prepared database verifies" {
+    run dolt admin migrate-journal prepare
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "to be moved" ]] || false
+    [ -f .dolt/copy_manifest.json ]
+
+    dest=$(mktemp -d)
+    cp -r .dolt "$dest/"
+    cd "$dest"
+
+    run dolt admin migrate-journal verify
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "match the copy manifest" ]] || false
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    run dolt status
+    [[ "$output" =~ "modified:" ]] || false
+}
+
+@test "admin-migrate-journal: archive of a prepared database verifies" {
+    archive="$BATS_TMPDIR/migrate-$$.tar.gz"
+    run dolt admin migrate-journal prepare --output "$archive"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Wrote $archive" ]] || false
+
+    dest=$(mktemp -d)
+    cd "$dest"
+    tar xzf "$archive"
+    rm "$archive"
+
+    run dolt admin migrate-journal verify --files-only
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "match the copy manifest" ]] || false
+    run dolt admin migrate-journal verify
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "select c from t where pk = 3" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "three" ]] || false
+}
+
+@test "admin-migrate-journal: writes after prepare fail verification" {
+    dolt admin migrate-journal prepare
+    dolt sql -q "insert into t values (4, 'four');"
+
+    dest=$(mktemp -d)
+    cp -r .dolt "$dest/"
+    cd "$dest"
+
+    run dolt admin migrate-journal verify --files-only
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "prepare and copy the database again" ]] || false
+}
+
+@test "admin-migrate-journal: damaged copy fails verification" {
+    dolt admin migrate-journal prepare
+
+    dest=$(mktemp -d)
+    cp -r .dolt "$dest/"
+    cd "$dest"
+    echo "garbage" >> .dolt/repo_state.json
+
+    run dolt admin migrate-journal verify --files-only
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "repo_state.json doesn't match the copy manifest" ]] || false
+}
+
+@test "admin-migrate-journal: verify needs a prepared database" {
+    run dolt admin migrate-journal verify
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "wasn't prepared to be copied" ]] || false
+}
+
+@test "admin-migrate-journal: bad arguments" {
+    run dolt admin migrate-journal
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "one of prepare or verify must be given" ]] || false
+
+    run dolt admin migrate-journal move
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unknown argument: move" ]] || false
+
+    run dolt admin migrate-journal verify --output out.tar.gz
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--output is only valid with prepare" ]] || false
+}
+
+@test "admin-migrate-journal: prepare fails while a sql-server is running" {
+    if [ "$SQL_ENGINE" = "remote-engine" ]; then
+        skip "this test starts its own sql-server"
+    fi
+    start_sql_server
+
+    run dolt admin migrate-journal prepare
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "in use by another dolt process" ]] || false
+    [ ! -f .dolt/copy_manifest.json ]
+}
@@ -0,0 +1,171 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    TMPDIRS=$(pwd)/tmpdirs
+    mkdir -p $TMPDIRS/repo1
+
+    cd $TMPDIRS/repo1
+    dolt init
+    dolt sql -q "create table t1 (a int primary key)"
+    dolt sql -q "insert into t1 values (1), (2)"
+    dolt commit -Am "cm1"
+    dolt tag -m "first release" v1
+    dolt sql -q "insert into t1 values (3)"
+    dolt commit -am "cm2"
+    cd $TMPDIRS
+}
+
+teardown() {
+    teardown_common
+    rm -rf $TMPDIRS
+    cd $BATS_TMPDIR
+}
+
+@test "bundle: create and verify a bundle" {
+    cd repo1
+    run dolt bundle create v1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Created bundle v1.bundle of tag v1" ]] || false
+    [[ "$output" =~ "sha256: " ]] || false
+    [ -f v1.bundle ]
+
+    run dolt bundle verify v1.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "bundle v1.bundle is valid" ]] || false
+    [[ "$output" =~ "tag:     v1" ]] || false
+    [[ "$output" =~ "branch:  main" ]] || false
+    [[ "$output" =~ "message: first release" ]] || false
+}
+
+@test "bundle: verify a bundle outside a repository" {
+    cd repo1
+    dolt bundle create v1 ../release.bundle
+    cd ..
+
+    run dolt bundle verify release.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "tag:     v1" ]] || false
+}
+
+@test "bundle: create a bundle of a missing tag" {
+    cd repo1
+    run dolt bundle create v2
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "tag 'v2' not found" ]] || false
+    [ ! -f v2.bundle ]
+}
+
+@test "bundle: clone a bundle" {
+    cd repo1
+    dolt bundle create -b release v1 ../v1.bundle
+    cd ..
+
+    dolt clone bundle://./v1.bundle cloned
+    cd cloned
+    run dolt sql -q "select count(*) from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "release" ]] || false
+
+    run dolt tag -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "first release" ]] || false
+}
+
+@test "bundle: fetch from a bundle" {
+    cd repo1
+    dolt bundle create v1 ../v1.bundle
+    cd ..
+
+    mkdir repo2 && cd repo2
+    dolt init
+    dolt remote add release bundle://../v1.bundle
+    dolt fetch release
+    run dolt sql -q "select count(*) from t1 as of 'v1'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt push release main
+    [ "$status" -ne 0 ]
+}
+
+@test "bundle: bundle every branch and tag" {
+    cd repo1
+    dolt branch feature HEAD~1
+    dolt tag v2
+    run dolt bundle create --refs ../all.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Created bundle ../all.bundle of 4 refs" ]] || false
+    cd ..
+
+    run dolt bundle verify all.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/heads/feature" ]] || false
+    [[ "$output" =~ "refs/tags/v1" ]] || false
+    [[ "$output" =~ "refs/tags/v2" ]] || false
+
+    dolt clone bundle://./all.bundle cloned
+    cd cloned
+    run dolt sql -q "select count(*) from t1" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/feature" ]] || false
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "v2" ]] || false
+}
+
+@test "bundle: bundle some branches and tags" {
+    cd repo1
+    dolt branch feature HEAD~1
+    dolt tag v2
+    run dolt bundle create --refs ../some.bundle feature refs/tags/v2
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "of 2 refs" ]] || false
+
+    run dolt bundle create --refs ../missing.bundle feature nosuchref
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no branch or tag named 'nosuchref'" ]] || false
+    [ ! -f ../missing.bundle ]
+    cd ..
+
+    run dolt bundle verify some.bundle
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/feature" ]] || false
+    [[ "$output" =~ "refs/tags/v2" ]] || false
+    [[ ! "$output" =~ "refs/heads/main" ]] || false
+    [[ ! "$output" =~ "refs/tags/v1" ]] || false
+
+    mkdir repo2 && cd repo2
+    dolt init
+    dolt remote add airgap bundle://../some.bundle
+    dolt fetch airgap
+    run dolt sql -q "select count(*) from t1 as of 'airgap/feature'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+}
+
+@test "bundle: corrupted bundles are invalid" {
+    cd repo1
+    dolt bundle create v1
+    cp v1.bundle corrupted.bundle
+    size=$(wc -c < corrupted.bundle)
+    printf 'XXXXXXXX' | dd of=corrupted.bundle bs=1 seek=$((size / 2)) conv=notrunc
+
+    run dolt bundle verify corrupted.bundle
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid" ]] || false
+
+    run dolt clone bundle://./corrupted.bundle cloned
+    [ "$status" -ne 0 ]
+}
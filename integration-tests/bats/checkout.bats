@@ -273,6 +273,40 @@ SQL
     [[ "$output" =~ "2" ]] || false
 }
 
+@test "checkout: dolt checkout table from another branch stages only that table" {
+    dolt sql -q "create table t (c1 int primary key, c2 int)"
+    dolt sql -q "create table z (c1 int primary key, c2 int)"
+    dolt commit -Am "create tables"
+    dolt checkout -b b1
+    dolt sql -q "insert into t values (1,1)"
+    dolt sql -q "create table y (c1 int primary key)"
+    dolt commit -Am "changes on b1"
+    dolt checkout main
+    dolt sql -q "insert into z values (2,2)"
+
+    run dolt checkout b1 -- t y
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "select table_name, staged, status from dolt_status order by table_name" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t,true,modified" ]] || false
+    [[ "$output" =~ "y,true,new table" ]] || false
+    [[ "$output" =~ "z,false,modified" ]] || false
+
+    run dolt checkout b1 -- nosuchtable
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table nosuchtable does not exist in b1" ]] || false
+
+    # -- makes z a table name rather than a branch
+    dolt branch z
+    run dolt checkout -- z
+    [ "$status" -eq 0 ]
+    run dolt branch --show-current
+    [[ "$output" =~ "main" ]] || false
+    run dolt sql -q "select count(*) from z" -r csv
+    [[ "$output" =~ "0" ]] || false
+}
+
 @test "checkout: with -f flag without conflict" {
     dolt sql -q 'create table test (id int primary key);'
     dolt sql -q 'insert into test (id) values (8);'
@@ -0,0 +1,85 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+load $BATS_TEST_DIRNAME/helper/query-server-common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "create table users (id int primary key, name varchar(100), balance decimal(10,2));"
+    dolt sql -q "insert into users values (1, 'alice', 10.50), (2, 'bob', null), (3, 'carol', 3.25);"
+    dolt sql -q "create table tags (user_id int, tag varchar(20), primary key (user_id, tag));"
+    dolt sql -q "insert into tags values (1, 'a'), (1, 'b'), (2, 'a'), (3, 'c');"
+    dolt commit -Am "create tables"
+
+    mkdir -p remote/legacy
+    cd remote/legacy
+    dolt init
+    dolt sql -q "create table users (id int primary key, name varchar(100), balance decimal(10,2));"
+    dolt sql -q "insert into users values (1, 'alice', 10.50), (2, 'bob', null), (3, 'carol', 3.25);"
+    dolt sql -q "create table tags (user_id int, tag varchar(20), primary key (user_id, tag));"
+    dolt sql -q "insert into tags values (1, 'a'), (1, 'b'), (2, 'a'), (3, 'c');"
+    cd ..
+    start_sql_server_with_args --host 0.0.0.0
+    cd ..
+}
+
+teardown() {
+    stop_sql_server 1
+    assert_feature_version
+    teardown_common
+}
+
+@test "compare-to-mysql: matching databases" {
+    run dolt compare-to-mysql --chunk-size 1 "root@tcp(127.0.0.1:$PORT)/legacy"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "tags: ok (4 rows)" ]] || false
+    [[ "$output" =~ "users: ok (3 rows)" ]] || false
+    [[ "$output" =~ "databases match" ]] || false
+}
+
+@test "compare-to-mysql: differing rows" {
+    dolt sql -q "update users set balance = 11 where id = 1; delete from users where id = 2; insert into users values (4, 'dave', 0);"
+    dolt sql -q "update tags set tag = 'z' where user_id = 3;"
+
+    run dolt compare-to-mysql --chunk-size 2 "root@tcp(127.0.0.1:$PORT)/legacy"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "users: data differs" ]] || false
+    [[ "$output" =~ "different: (1)" ]] || false
+    [[ "$output" =~ "missing from dolt: (2)" ]] || false
+    [[ "$output" =~ "missing from mysql: (4)" ]] || false
+    [[ "$output" =~ "missing from dolt: (3, c)" ]] || false
+    [[ "$output" =~ "missing from mysql: (3, z)" ]] || false
+    [[ "$output" =~ "databases differ" ]] || false
+
+    run dolt compare-to-mysql --max-diffs 1 "root@tcp(127.0.0.1:$PORT)/legacy" users
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "users: data differs" ]] || false
+    [[ ! "$output" =~ "tags" ]] || false
+    [ $(echo "$output" | grep -c "missing from\|different:") -eq 1 ]
+}
+
+@test "compare-to-mysql: differing schemas" {
+    dolt sql -q "alter table users add column email varchar(100);"
+    dolt sql -q "create table extra (id int primary key);"
+    dolt sql -q "drop table tags;"
+
+    run dolt compare-to-mysql "root@tcp(127.0.0.1:$PORT)/legacy"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "tags: missing from dolt" ]] || false
+    [[ "$output" =~ "extra: missing from mysql" ]] || false
+    [[ "$output" =~ "users: schema differs" ]] || false
+    [[ "$output" =~ "column email varchar(100) missing from mysql" ]] || false
+}
+
+@test "compare-to-mysql: bad arguments" {
+    run dolt compare-to-mysql
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "a MySQL dsn is required" ]] || false
+
+    run dolt compare-to-mysql "root@tcp(127.0.0.1:$PORT)/"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "must name a database" ]] || false
+
+    run dolt compare-to-mysql "root@tcp(127.0.0.1:1)/legacy"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unable to connect to MySQL server" ]] || false
+}
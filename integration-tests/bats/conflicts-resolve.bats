@@ -231,3 +231,56 @@ teardown() {
     [ $status -eq 0 ]
     [[ $output =~ "main" ]] || false
 }
+
+@test "conflicts-resolve: resolve with a script and fall back to theirs" {
+    dolt sql -q "create table t (i int primary key, t text)"
+    dolt add .
+    dolt commit -am "init commit"
+    dolt checkout -b other
+    dolt sql -q "insert into t values (1,'other'), (2,'other'), (3,'other')"
+    dolt commit -am "other commit"
+    dolt checkout main
+    dolt sql -q "insert into t values (1,'main'), (2,'main'), (3,'main')"
+    dolt commit -am "main commit"
+
+    run dolt merge other
+    [ $status -eq 1 ]
+    [[ $output =~ "Automatic merge failed" ]] || false
+
+    cat > resolve.sql <<SQL
+update dolt_conflicts_t set our_t = concat(our_t, '+', their_t) where our_i = 1;
+delete from dolt_conflicts_t where our_i = 1;
+SQL
+
+    run dolt conflicts resolve --script resolve.sql --theirs --commit
+    [ $status -eq 0 ]
+    [[ $output =~ "t: 1 row by script, 2 rows by theirs" ]] || false
+
+    run dolt sql -q "select * from t order by i" -r csv
+    [ $status -eq 0 ]
+    [[ $output =~ "1,main+other" ]] || false
+    [[ $output =~ "2,other" ]] || false
+    [[ $output =~ "3,other" ]] || false
+
+    run dolt log -n 1
+    [ $status -eq 0 ]
+    [[ $output =~ "Merge other into main" ]] || false
+    [[ $output =~ "t: 1 row by script, 2 rows by theirs" ]] || false
+}
+
+@test "conflicts-resolve: script which leaves conflicts cannot commit" {
+    basic_conflict
+
+    run dolt merge other
+    [ $status -eq 1 ]
+
+    echo "select 1;" > resolve.sql
+    run dolt conflicts resolve --script resolve.sql --commit
+    [ $status -eq 1 ]
+    [[ $output =~ "1 conflicts remain unresolved" ]] || false
+    [[ $output =~ "cannot commit the merge with unresolved conflicts" ]] || false
+
+    run dolt conflicts resolve --ours --commit t
+    [ $status -eq 1 ]
+    [[ $output =~ "--commit and --message can only be used with --script" ]] || false
+}
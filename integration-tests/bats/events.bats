@@ -297,3 +297,75 @@ SQL
     [ $status -eq 0 ]
     [[ $output =~ "| 1  " ]] || false
 }
+
+@test "events: events on branches named by dolt_event_branches run on those branches" {
+    dolt sql -q "SET @@GLOBAL.dolt_event_branches = 'feature';"
+    dolt sql << SQL
+call dolt_checkout('-b', 'feature');
+CREATE EVENT branch_event ON SCHEDULE EVERY 1 SECOND STARTS CURRENT_TIMESTAMP DO INSERT INTO totals (int_col) VALUES (7);
+call dolt_commit('-Am', 'Adding a recurring event on feature');
+SQL
+
+    # the event stays enabled on the feature branch
+    run dolt sql -q "call dolt_checkout('feature'); SELECT status FROM information_schema.events;"
+    [ $status -eq 0 ]
+    [[ $output =~ "ENABLED" ]] || false
+    [[ ! $output =~ "DISABLED" ]] || false
+
+    # and runs against the feature branch, not the default branch
+    sleep 3
+    run dolt sql -r csv -q "SELECT IF(COUNT(*) > 0, 'ran', 'idle') FROM \`repo1/feature\`.totals UNION ALL SELECT IF(COUNT(*) > 0, 'ran', 'idle') FROM \`repo1/main\`.totals;"
+    [ $status -eq 0 ]
+    [ "${lines[1]}" = "ran" ]
+    [ "${lines[2]}" = "idle" ]
+
+    run dolt sql -r csv -q "SELECT DISTINCT event, branch, error FROM dolt_events_history WHERE NOT catch_up;"
+    [ $status -eq 0 ]
+    [[ $output =~ "branch_event,feature," ]] || false
+}
+
+@test "events: missed executions are caught up once when dolt_event_catch_up is once" {
+    dolt sql -q "CREATE EVENT catch_up_event ON SCHEDULE EVERY 1 HOUR STARTS CURRENT_TIMESTAMP + INTERVAL 1 SECOND DO INSERT INTO totals (int_col) VALUES (5);"
+    sleep 3
+    run dolt sql -q "SELECT COUNT(*) FROM totals;"
+    [ $status -eq 0 ]
+    [[ $output =~ "| 1        |" ]] || false
+
+    run dolt sql -r csv -q "SELECT event, branch FROM dolt_events_history WHERE NOT catch_up;"
+    [ $status -eq 0 ]
+    [[ $output =~ "catch_up_event,main" ]] || false
+
+    # the last execution of each event is kept in the config directory of the server
+    stop_sql_server 1
+    [ -f .doltcfg/event_state.json ]
+    run cat .doltcfg/event_state.json
+    [[ $output =~ "repo1.catch_up_event" ]] || false
+
+    # pretend the server was down for longer than the interval of the event
+    echo '{"repo1.catch_up_event":"2020-01-01T00:00:00Z"}' > .doltcfg/event_state.json
+
+    # by default, missed executions are skipped
+    start_sql_server
+    sleep 2
+    run dolt sql -q "SELECT COUNT(*) FROM totals;"
+    [ $status -eq 0 ]
+    [[ $output =~ "| 1        |" ]] || false
+    stop_sql_server 1
+
+    echo '{"repo1.catch_up_event":"2020-01-01T00:00:00Z"}' > .doltcfg/event_state.json
+    cat > config.yml <<YAML
+system_variables:
+  dolt_event_catch_up: once
+YAML
+    start_sql_server_with_config "" config.yml
+    sleep 2
+    run dolt sql -q "SELECT COUNT(*) FROM totals;"
+    [ $status -eq 0 ]
+    [[ $output =~ "| 2        |" ]] || false
+
+    # the history is kept in memory, so it only holds the execution which caught up
+    run dolt sql -r csv -q "SELECT event, branch, catch_up, error FROM dolt_events_history WHERE catch_up;"
+    [ $status -eq 0 ]
+    [[ $output =~ "catch_up_event,main," ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+}
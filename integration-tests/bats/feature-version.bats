@@ -44,6 +44,25 @@ SQL
     [[ "$output" =~ "feature version: $NEW" ]] || false
 }
 
+@test "feature-version: only descending index columns lock out clients of the previous version" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c0 int); CREATE INDEX c0_asc ON test (c0);"
+    dolt commit -Am "ascending index"
+    run dolt version --feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature version: 7" ]] || false
+    run dolt --feature-version 7 sql -q "INSERT INTO test VALUES (1, 1);"
+    [ "$status" -eq 0 ]
+
+    dolt sql -q "CREATE INDEX c0_desc ON test (c0 DESC);"
+    dolt commit -Am "descending index"
+    run dolt version --feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature version: 8" ]] || false
+    run dolt --feature-version 7 sql -q "SELECT * FROM test;"
+    [ "$status" -ne 0 ]
+    [[ ! "$output" =~ "panic" ]] || false
+}
+
 setup_remote_tests() {
     # remote repo from top-level test directory
     rm -rf .dolt/
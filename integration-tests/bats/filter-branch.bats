@@ -48,6 +48,29 @@ teardown() {
     [[ "$output" =~ "updated commit" ]] || false
 }
 
+@test "filter-branch: --tables only rewrites the given tables" {
+    dolt sql -q "INSERT INTO to_drop VALUES (1),(2);"
+    dolt commit -Am "added rows to to_drop"
+    dolt sql -q "INSERT INTO test VALUES (7,7),(8,8),(9,9);"
+    dolt commit -Am "added more rows"
+
+    run dolt filter-branch --tables test --parallelism 2 -q "DELETE FROM test WHERE pk > 1; DELETE FROM to_drop;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rewrote "[0-9]+" of "[0-9]+" commits" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM to_drop" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT max(pk) FROM dolt_history_test;" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
 
 @test "filter-branch: filter multiple branches" {
     dolt branch other
@@ -609,6 +609,44 @@ SQL
     [[ ! "$output" =~ "Archive Metadata" ]] || false
 }
 
+@test "garbage_collection: dolt gc --dry-run does not collect anything" {
+    dolt sql <<SQL
+CREATE TABLE test (pk int PRIMARY KEY);
+INSERT INTO test VALUES (1),(2),(3),(4),(5);
+DELETE FROM test WHERE pk > 1;
+SQL
+    cp .dolt/noms/manifest ../manifest_before_dry_run
+
+    run dolt gc --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "dry run: no chunks were collected" ]] || false
+    [[ "$output" =~ "table files to rewrite:" ]] || false
+    [[ "$output" =~ "to collect" ]] || false
+    cmp .dolt/noms/manifest ../manifest_before_dry_run
+
+    run dolt sql -q "call dolt_gc('--dry-run', '--full');" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "dry run: no chunks were collected" ]] || false
+    cmp .dolt/noms/manifest ../manifest_before_dry_run
+
+    run dolt gc --dry-run --shallow
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--shallow is not compatible with --dry-run" ]] || false
+}
+
+@test "garbage_collection: dolt gc --verbose prints phase timings" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY); INSERT INTO test VALUES (1),(2),(3);"
+
+    run dolt gc --verbose
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "mark and copy reachable chunks:" ]] || false
+    [[ "$output" =~ "total:" ]] || false
+
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
 
 
 
@@ -463,3 +463,87 @@ SQL
     [ "$status" -eq 1 ]
     [[ "$output" =~ "fatal: --all-text is only supported for create operations" ]] || false
 }
+
+@test "import-replace-tables: replace table with several secondary indexes rebuilds the indexes" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  c2 BIGINT,
+  c3 VARCHAR(20),
+  PRIMARY KEY (pk),
+  INDEX idx_c1 (c1),
+  UNIQUE INDEX idx_c2 (c2),
+  INDEX idx_c3_c1 (c3, c1)
+);
+INSERT INTO test VALUES (100, 100, 100, 'old');
+SQL
+
+    cat <<DELIM > indexed.csv
+pk,c1,c2,c3
+1,10,1,a
+2,10,2,b
+3,30,3,a
+4,40,4,c
+DELIM
+
+    run dolt table import -r test indexed.csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Rows Processed: 4, Additions: 4, Modifications: 0, Had No Effect: 0" ]] || false
+    [[ "$output" =~ "Import completed successfully." ]] || false
+
+    run dolt index ls test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "idx_c1(c1)" ]] || false
+    [[ "$output" =~ "idx_c2(c2)" ]] || false
+    [[ "$output" =~ "idx_c3_c1(c3, c1)" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk FROM test WHERE c1 = 10 ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "2" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -r csv -q "SELECT pk FROM test WHERE c3 = 'a' ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "3" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -r csv -q "SELECT count(*) FROM test WHERE c2 = 100"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt sql -q "INSERT INTO test VALUES (5, 50, 4, 'd')"
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "duplicate unique key given: [4]" ]] || false
+}
+
+@test "import-replace-tables: replace table reports duplicate keys in a unique secondary index" {
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  c2 BIGINT,
+  PRIMARY KEY (pk),
+  INDEX idx_c1 (c1),
+  UNIQUE INDEX idx_c2 (c2)
+);
+INSERT INTO test VALUES (100, 100, 100);
+SQL
+
+    cat <<DELIM > dupes.csv
+pk,c1,c2
+1,10,1
+2,20,1
+DELIM
+
+    run dolt table import -r test dupes.csv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "duplicate unique key given: [1]" ]] || false
+
+    run dolt sql -r csv -q "SELECT pk FROM test"
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "100" ]
+    [ "${#lines[@]}" -eq 2 ]
+}
@@ -2722,3 +2722,34 @@ SQL
     # column should still exist
     [[ "$output" =~ '`v1` int' ]] || false
 }
+
+@test "index: descending columns are stored in descending order" {
+    dolt sql <<SQL
+INSERT INTO onepk VALUES (1, 10, 1), (2, 30, 2), (3, 20, 3);
+CALL dolt_commit('-Am', 'add rows');
+CREATE INDEX v1v2 ON onepk (v1 DESC, v2);
+SQL
+    run dolt index cat onepk v1v2 -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[0]}" =~ "v1,v2,pk1" ]] || false
+    [[ "${lines[1]}" =~ "30,2,2" ]] || false
+    [[ "${lines[2]}" =~ "20,3,3" ]] || false
+    [[ "${lines[3]}" =~ "10,1,1" ]] || false
+
+    run dolt index rebuild onepk v1v2
+    [ "$status" -eq "0" ]
+    run dolt index cat onepk v1v2 -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" =~ "30,2,2" ]] || false
+    [[ "${lines[3]}" =~ "10,1,1" ]] || false
+
+    run dolt sql -q "SELECT pk1 FROM onepk ORDER BY v1 DESC, v2" -r csv
+    [ "$status" -eq "0" ]
+    [[ "${lines[1]}" = "2" ]] || false
+    [[ "${lines[2]}" = "3" ]] || false
+    [[ "${lines[3]}" = "1" ]] || false
+
+    run dolt diff -r sql
+    [ "$status" -eq "0" ]
+    [[ "$output" =~ 'ALTER TABLE `onepk` ADD INDEX `v1v2`(`v1` DESC,`v2`);' ]] || false
+}
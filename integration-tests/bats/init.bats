@@ -320,6 +320,106 @@ teardown() {
     [ -d "$baseDir/repo_dir/.dolt" ]
 }
 
+make_template() {
+  mkdir -p "$1/queries" "$1/hooks"
+  echo '{"user.name": "Template User"}' > "$1/config.json"
+  cat > "$1/schema.sql" <<SQL
+create table t (pk int primary key, v varchar(20));
+create view v as select count(*) as c from t;
+SQL
+  printf 'tmp_*\n!tmp_keep\n' > "$1/dolt_ignore"
+  printf -- '-- Counts the rows of t\nselect count(*) from t;\n' > "$1/queries/count_t.sql"
+  echo "insert into t values (1, 'seeded');" > "$1/hooks/01_seed.sql"
+}
+
+@test "init: --template seeds the schema, ignore rules, saved queries and config of the new repository" {
+  set_dolt_user "baz", "baz@bash.com"
+  make_template "$BATS_TMPDIR/template-$$"
+
+  run dolt init --template "$BATS_TMPDIR/template-$$"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "Successfully initialized dolt data repository." ]] || false
+
+  run dolt config --local --get user.name
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "Template User" ]] || false
+
+  run dolt sql -r csv -q "select * from t"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "1,seeded" ]] || false
+
+  run dolt sql -r csv -q "select * from v"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "1" ]] || false
+
+  run dolt sql -r csv -q "select pattern, ignored from dolt_ignore order by pattern"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "tmp_*,1" ]] || false
+  [[ "$output" =~ "tmp_keep,0" ]] || false
+
+  run dolt sql -r csv -q "select name, query, description from dolt_query_catalog"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "count_t,select count(*) from t,Counts the rows of t" ]] || false
+
+  # the template is applied in a single commit on top of the initial commit
+  run dolt log --oneline
+  [ "$status" -eq 0 ]
+  [ "${#lines[@]}" -eq 2 ]
+  [[ "${lines[0]}" =~ "Apply template template-$$" ]] || false
+  [[ "${lines[1]}" =~ "Initialize data repository" ]] || false
+
+  run dolt status
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+  rm -rf "$BATS_TMPDIR/template-$$"
+}
+
+@test "init: init.template in the global config is used as the default template" {
+  set_dolt_user "baz", "baz@bash.com"
+  make_template "$BATS_TMPDIR/template-$$"
+  dolt config --global --add init.template "$BATS_TMPDIR/template-$$"
+
+  run dolt init
+  dolt config --global --unset init.template
+  [ "$status" -eq 0 ]
+
+  run dolt sql -r csv -q "select count(*) from t"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "1" ]] || false
+
+  rm -rf "$BATS_TMPDIR/template-$$"
+}
+
+@test "init: a template which fails to load or apply is reported" {
+  set_dolt_user "baz", "baz@bash.com"
+
+  run dolt init --template "$BATS_TMPDIR/missing-template-$$"
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "Failed to load template" ]] || false
+  [ ! -d .dolt ]
+
+  mkdir -p "$BATS_TMPDIR/bad-template-$$"
+  echo '{"not.an.option": "x"}' > "$BATS_TMPDIR/bad-template-$$/config.json"
+  run dolt init --template "$BATS_TMPDIR/bad-template-$$"
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "invalid config option not.an.option" ]] || false
+
+  echo '{}' > "$BATS_TMPDIR/bad-template-$$/config.json"
+  echo 'create table t (pk int primary key); create tabel oops;' > "$BATS_TMPDIR/bad-template-$$/schema.sql"
+  run dolt init --template "$BATS_TMPDIR/bad-template-$$"
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "Failed to apply template" ]] || false
+  [[ "$output" =~ "schema.sql" ]] || false
+
+  # the changes of the template are discarded
+  run dolt sql -q "show tables"
+  [ "$status" -eq 0 ]
+  [[ ! "$output" =~ "| t " ]] || false
+
+  rm -rf "$BATS_TMPDIR/bad-template-$$"
+}
+
 assert_valid_repository () {
   run dolt log
   [ "$status" -eq 0 ]
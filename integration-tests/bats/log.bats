@@ -1043,4 +1043,71 @@ export NO_COLOR=1
     [[  "${lines[18]}" =~ "|/" ]] || false                               # |/
     [[  "${lines[19]}" =~ "* commit" ]] || false                         # *  commit Initialize data repository
 
-}
\ No newline at end of file
+}
+@test "log: --show-committer shows who committed on behalf of the author" {
+    dolt sql -q "set @@dolt_commit_author = 'Jane Doe <jane@example.com>'; call dolt_commit('--allow-empty', '-m', 'for jane')"
+
+    run dolt log -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Author: Jane Doe <jane@example.com>" ]] || false
+    [[ ! "$output" =~ "Committer:" ]] || false
+
+    run dolt log -n 1 --show-committer
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Author: Jane Doe <jane@example.com>" ]] || false
+    [[ "$output" =~ "Committer: root <root@" ]] || false
+
+    dolt commit --allow-empty -m "by the author"
+    run dolt log -n 1 --show-committer
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Committer: Bats Tests <bats@email.fake>" ]] || false
+}
+
+@test "log: --format dot exports the commit graph" {
+    dolt sql -q "create table testtable (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "commit 1"
+    dolt sql -q "insert into testtable values (1)"
+    dolt commit -am "commit \"2\""
+    head=$(dolt sql -q "select hashof('HEAD')" -r csv | tail -n 1)
+    parent=$(dolt sql -q "select hashof('HEAD~1')" -r csv | tail -n 1)
+
+    run dolt log --format dot
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" = "digraph dolt_log {" ]] || false
+    [[ "$output" =~ "\"$head\" -> \"$parent\";" ]] || false
+    [[ "$output" =~ 'commit \"2\"\n(HEAD, main)"' ]] || false
+    [[ ! "$output" =~ "tables changed" ]] || false
+
+    run dolt log --format dot --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '(HEAD, main)\n1 tables changed"' ]] || false
+
+    run dolt log --format dot -n 1
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "->" ]] || false
+}
+
+@test "log: --format json exports the commit graph" {
+    dolt sql -q "create table testtable (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "commit 1"
+    head=$(dolt sql -q "select hashof('HEAD')" -r csv | tail -n 1)
+    parent=$(dolt sql -q "select hashof('HEAD~1')" -r csv | tail -n 1)
+
+    run dolt log --format json --stat
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "\"commit_hash\": \"$head\"" ]] || false
+    [[ "$output" =~ "\"tables_changed\": 1" ]] || false
+    [[ "$output" =~ "\"message\": \"Initialize data repository\"" ]] || false
+    [[ "$output" =~ "\"from\": \"$head\"," ]] || false
+    [[ "$output" =~ "\"to\": \"$parent\"," ]] || false
+
+    run dolt log --format xml
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid --format 'xml', valid options are dot and json" ]] || false
+
+    run dolt log --format json --oneline
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--format cannot be used with --graph or --oneline" ]] || false
+}
@@ -60,7 +60,7 @@ teardown() {
 @test "ls: --system shows system tables" {
     run dolt ls --system
     [ "$status" -eq 0 ]
-    [ "${#lines[@]}" -eq 24 ]
+    [ "${#lines[@]}" -eq 32 ]
     [[ "$output" =~ "System tables:" ]] || false
     [[ "$output" =~ "dolt_status" ]] || false
     [[ "$output" =~ "dolt_commits" ]] || false
@@ -73,6 +73,14 @@ teardown() {
     [[ "$output" =~ "dolt_backups" ]] || false
     [[ "$output" =~ "dolt_remote_branches" ]] || false
     [[ "$output" =~ "dolt_help" ]] || false
+    [[ "$output" =~ "dolt_operations" ]] || false
+    [[ "$output" =~ "dolt_cache_stats" ]] || false
+    [[ "$output" =~ "dolt_storage_usage" ]] || false
+    [[ "$output" =~ "dolt_table_sizes" ]] || false
+    [[ "$output" =~ "dolt_audit_log" ]] || false
+    [[ "$output" =~ "dolt_query_log" ]] || false
+    [[ "$output" =~ "dolt_query_summary" ]] || false
+    [[ "$output" =~ "dolt_events_history" ]] || false
     [[ "$output" =~ "dolt_constraint_violations_table_one" ]] || false
     [[ "$output" =~ "dolt_history_table_one" ]] || false
     [[ "$output" =~ "dolt_conflicts_table_one" ]] || false
@@ -181,6 +189,27 @@ teardown() {
     [[ "$output" =~ "table_three" ]] || false
 }
 
+@test "ls: --verbose with a commit shows row counts and sizes at the commit" {
+    dolt sql -q "insert into table_one values (1), (2), (3)"
+    dolt commit -am "add rows to table_one"
+    dolt sql -q "insert into table_one values (4), (5)"
+
+    run dolt ls --verbose HEAD~1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "table_one" ]] || false
+    [[ "$output" =~ "0 rows" ]] || false
+    ! [[ "$output" =~ "3 rows" ]] || false
+
+    run dolt ls --verbose HEAD
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3 rows" ]] || false
+    [[ "$output" =~ " B" ]] || false
+
+    run dolt ls --verbose
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "5 rows" ]] || false
+}
+
 @test "ls: no tables in working set" {
     dolt sql -q "drop table table_one"
     dolt sql -q "drop table table_two"
@@ -278,6 +278,48 @@ SQL
     [[ ! "$output" =~ "add pk 0 to test1" ]] || false
 }
 
+@test "merge: merge strategies resolve conflicting rows" {
+    dolt sql -q "INSERT INTO test1 VALUES (0,0,0),(1,1,1)"
+    dolt commit -am "add rows to test1"
+    dolt branch other
+
+    dolt sql -q "UPDATE test1 SET c1 = 10 WHERE pk = 0"
+    dolt commit -am "update test1 on main"
+
+    dolt checkout other
+    dolt sql -q "UPDATE test1 SET c1 = 20 WHERE pk = 0"
+    dolt sql -q "UPDATE test1 SET c2 = 21 WHERE pk = 1"
+    dolt commit -am "update test1 on other"
+    dolt checkout main
+
+    run dolt merge -s octopus other
+    log_status_eq 1
+    [[ "$output" =~ "unknown merge strategy 'octopus'" ]] || false
+
+    dolt branch pre-merge
+    run dolt merge -s ours other
+    log_status_eq 0
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+
+    run dolt sql -q "SELECT * FROM test1 ORDER BY pk" -r csv
+    log_status_eq 0
+    [[ "${lines[1]}" = "0,10,0" ]] || false
+    [[ "${lines[2]}" = "1,1,21" ]] || false
+
+    dolt reset --hard pre-merge
+    run dolt merge --strategy theirs --squash --no-commit other
+    log_status_eq 0
+    [[ "$output" =~ "Squash" ]] || false
+
+    run dolt sql -q "SELECT * FROM test1 ORDER BY pk" -r csv
+    log_status_eq 0
+    [[ "${lines[1]}" = "0,20,0" ]] || false
+    [[ "${lines[2]}" = "1,1,21" ]] || false
+
+    run dolt sql -q "SELECT is_merging FROM dolt_merge_status" -r csv
+    [[ "$output" =~ "false" ]] || false
+}
+
 @test "merge: can merge commit spec with ancestor spec" {
     dolt checkout -b merge_branch
     dolt SQL -q "INSERT INTO test1 values (0,1,2)"
@@ -0,0 +1,154 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    mkdir migrations
+    cat > migrations/V1__create_users.sql <<SQL
+CREATE TABLE users (id int primary key, name varchar(100));
+SQL
+    cat > migrations/U1__create_users.sql <<SQL
+DROP TABLE users;
+SQL
+    cat > migrations/V2__add_email.sql <<SQL
+ALTER TABLE users ADD COLUMN email varchar(100);
+INSERT INTO users VALUES (1, 'alice', 'alice@example.com');
+SQL
+    cat > migrations/U2__add_email.sql <<SQL
+DELETE FROM users;
+ALTER TABLE users DROP COLUMN email;
+SQL
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "migrate-up-down: up applies migrations in order in tagged commits" {
+    run dolt migrate up --dir migrations
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applying migration 1" ]] || false
+    [[ "$output" =~ "Applying migration 2" ]] || false
+
+    run dolt sql -q "SELECT * FROM users" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1,alice,alice@example.com" ]] || false
+
+    run dolt log --oneline -n 2
+    [[ "${lines[0]}" =~ "Apply migration 2: add email" ]] || false
+    [[ "${lines[1]}" =~ "Apply migration 1: create users" ]] || false
+
+    run dolt tag
+    [[ "$output" =~ "migration-1" ]] || false
+    [[ "$output" =~ "migration-2" ]] || false
+
+    run dolt sql -q "SELECT version FROM dolt_migrations WHERE applied_at IS NOT NULL ORDER BY version" -r csv
+    [ "${lines[1]}" = "1" ]
+    [ "${lines[2]}" = "2" ]
+
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+
+    run dolt migrate up --dir migrations
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "No migrations to apply" ]] || false
+}
+
+@test "migrate-up-down: up stops at --target" {
+    dolt migrate up --dir migrations --target 1
+
+    run dolt sql -q "SELECT version, applied_at IS NOT NULL FROM dolt_migrations ORDER BY version" -r csv
+    [ "${lines[1]}" = "1,true" ]
+    [ "${lines[2]}" = "2,false" ]
+
+    dolt migrate up
+    run dolt sql -q "SELECT count(*) FROM users" -r csv
+    [ "${lines[1]}" = "1" ]
+}
+
+@test "migrate-up-down: down reverts the latest migration" {
+    dolt migrate up --dir migrations
+
+    run dolt migrate down
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Reverting migration 2" ]] || false
+
+    run dolt sql -q "SHOW CREATE TABLE users"
+    [[ ! "$output" =~ "email" ]] || false
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "Revert migration 2: add email" ]] || false
+    run dolt tag
+    [[ ! "$output" =~ "migration-2" ]] || false
+    [[ "$output" =~ "migration-1" ]] || false
+
+    dolt migrate down --target 0
+    run dolt sql -q "SHOW TABLES"
+    [[ ! "$output" =~ "users" ]] || false
+}
+
+@test "migrate-up-down: failed migration is rolled back" {
+    cat > migrations/V3__broken.sql <<SQL
+CREATE TABLE extra (pk int primary key);
+INSERT INTO users VALUES (2, 'bob', 'bob@example.com');
+INSERT INTO no_such_table VALUES (1);
+SQL
+    dolt migrate up --dir migrations --target 2
+
+    run dolt migrate up --dir migrations
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "migration 3 failed" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM users" -r csv
+    [ "${lines[1]}" = "1" ]
+    run dolt sql -q "SHOW TABLES"
+    [[ ! "$output" =~ "extra" ]] || false
+    run dolt status
+    [[ "$output" =~ "nothing to commit, working tree clean" ]] || false
+}
+
+@test "migrate-up-down: up requires a clean working set" {
+    dolt sql -q "CREATE TABLE t (pk int primary key)"
+    run dolt migrate up --dir migrations
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "uncommitted changes" ]] || false
+}
+
+@test "migrate-up-down: applied migrations cannot be changed" {
+    dolt migrate up --dir migrations
+    echo "CREATE TABLE users (id int primary key);" > migrations/V1__create_users.sql
+
+    run dolt migrate up --dir migrations
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "migration 1 has changed since it was applied" ]] || false
+}
+
+@test "migrate-up-down: merged migrations older than the latest applied one require --out-of-order" {
+    dolt migrate up --dir migrations
+
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO dolt_migrations (version, description, up_script) VALUES (3, 'other', 'CREATE TABLE other (pk int primary key);')"
+    dolt commit -Am "add migration 3"
+
+    dolt checkout main
+    dolt sql -q "INSERT INTO dolt_migrations (version, description, up_script) VALUES (4, 'main', 'CREATE TABLE main_table (pk int primary key);')"
+    dolt commit -Am "add migration 4"
+    dolt migrate up
+
+    dolt merge other
+    run dolt migrate up
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "migration 3 is older than the latest applied migration 4" ]] || false
+
+    run dolt migrate up --out-of-order
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Applying migration 3" ]] || false
+    run dolt sql -q "SHOW TABLES"
+    [[ "$output" =~ "other" ]] || false
+}
+
+@test "migrate-up-down: unknown subcommand" {
+    run dolt migrate sideways
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown migrate command 'sideways'" ]] || false
+}
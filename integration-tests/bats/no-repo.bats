@@ -52,6 +52,7 @@ teardown() {
     [[ "$output" =~ "schema - Commands for showing and importing table schemas." ]] || false
     [[ "$output" =~ "table - Commands for copying, renaming, deleting, and exporting tables." ]] || false
     [[ "$output" =~ "tag - Create, list, delete tags." ]] || false
+    [[ "$output" =~ "bundle - Create and verify single file bundles of branches and tags." ]] || false
     [[ "$output" =~ "blame - Show what revision and author last modified each row of a table." ]] || false
     [[ "$output" =~ "constraints - Commands for handling constraints." ]] || false
     [[ "$output" =~ "migrate - Executes a database migration to use the latest Dolt data format." ]] || false
@@ -232,6 +232,33 @@ SQL
     [[ "$output" =~ "unknown remote: 'poop'" ]] || false
 }
 
+@test "remotes: rename, set-url and set options of a remote" {
+    dolt remote add --timeout 30s test-remote http://localhost:50051/test-org/test-repo
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"timeout": "30s"' ]] || false
+
+    run dolt remote set --concurrency 4 --timeout "" test-remote
+    [ "$status" -eq 0 ]
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"concurrency": "4"' ]] || false
+    [[ ! "$output" =~ "timeout" ]] || false
+
+    run dolt remote set-url test-remote http://localhost:50051/test-org/other-repo
+    [ "$status" -eq 0 ]
+    run dolt remote rename test-remote renamed-remote
+    [ "$status" -eq 0 ]
+    run dolt remote -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "renamed-remote http://localhost:50051/test-org/other-repo" ]] || false
+    [[ ! "$output" =~ "test-remote" ]] || false
+
+    run dolt remote set --concurrency none renamed-remote
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid concurrency 'none'" ]] || false
+}
+
 @test "remotes: clone a remote" {
     dolt remote add test-remote http://localhost:50051/test-org/test-repo
     dolt sql <<SQL
@@ -1830,6 +1857,29 @@ SQL
     [ ! -d "$repoDir/remote" ]
 }
 
+@test "remotes: dolt_clone is not listed in dolt_operations once finished" {
+    repoDir="$BATS_TMPDIR/dolt-repo-$$"
+
+    tempDir=$(mktemp -d)
+    cd $tempDir
+    mkdir remote
+    mkdir repo1
+    cd repo1
+    dolt init
+    dolt remote add origin file://../remote
+    dolt push origin main
+
+    cd $repoDir
+    run dolt sql -q "select count(*) from dolt_operations" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "0" ]
+
+    run dolt sql -q "call dolt_clone('file://$tempDir/remote'); select count(*) from dolt_operations;" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[-1]}" = "0" ]
+    [ -d "$repoDir/remote" ]
+}
+
 @test "remotes: dolt_clone procedure" {
     repoDir="$BATS_TMPDIR/dolt-repo-$$"
 
@@ -163,3 +163,82 @@ stop_remotesrv() {
     cd ../cloned
     dolt clone http://localhost:1234/test-org/test-repo repo1
 }
+
+@test "remotesrv: can push delta encoded chunks" {
+    mkdir remote
+    mkdir cloned
+    cd remote
+    dolt init
+    dolt sql -q 'create table wide (pk int primary key, c1 varchar(200), c2 varchar(200), c3 int);'
+    dolt sql -q "insert into wide with recursive n (i) as (select 1 union all select i+1 from n where i < 2000) select i, repeat(md5(i), 6), repeat(md5(i+1), 6), i from n;"
+    dolt add wide
+    dolt commit -m 'create wide table.'
+
+    remotesrv --http-port 1234 --repo-mode &
+    remotesrv_pid=$!
+
+    cd ../cloned
+    dolt clone http://localhost:50051/test-org/test-repo repo1
+    cd repo1
+    dolt sql -q 'update wide set c3 = c3 + 1 where pk % 100 = 0;'
+    dolt commit -am 'update a column of some rows'
+    DOLT_PUSH_DELTA_CHUNKS=1 dolt push origin main:main
+
+    dolt sql -q 'update wide set c1 = concat(c1, "x") where pk % 150 = 0;'
+    dolt commit -am 'update another column'
+    DOLT_PUSH_DELTA_CHUNKS=1 dolt push origin main:main
+
+    stop_remotesrv
+    cd ../../remote
+    dolt reset --hard
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "update another column" ]] || false
+    run dolt sql -q 'select sum(c3) from wide;' -r csv
+    [[ "$output" =~ "2001020" ]] || false
+    run dolt sql -q "select count(*) from wide where c1 like '%x';" -r csv
+    [[ "$output" =~ "13" ]] || false
+    dolt fsck
+}
+
+@test "remotesrv: clone, push and fetch with a bandwidth limit" {
+    mkdir remote
+    mkdir cloned
+    cd remote
+    dolt init
+    dolt sql -q 'create table wide (pk int primary key, c1 varchar(200), c2 varchar(200), c3 int);'
+    dolt sql -q "insert into wide with recursive n (i) as (select 1 union all select i+1 from n where i < 2000) select i, repeat(md5(i), 6), repeat(md5(i+1), 6), i from n;"
+    dolt add wide
+    dolt commit -m 'create wide table.'
+
+    remotesrv --http-port 1234 --repo-mode --max-bandwidth 1MB &
+    remotesrv_pid=$!
+
+    cd ../cloned
+    run dolt clone --max-bandwidth fast http://localhost:50051/test-org/test-repo repo1
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid bandwidth 'fast'" ]] || false
+
+    SECONDS=0
+    dolt clone --max-bandwidth 25KB http://localhost:50051/test-org/test-repo repo1
+    [ "$SECONDS" -ge 2 ]
+    cd repo1
+    run dolt remote -v
+    [[ ! "$output" =~ "max_bandwidth" ]] || false
+
+    dolt sql -q 'update wide set c3 = c3 + 1 where pk % 100 = 0;'
+    dolt commit -am 'update a column of some rows'
+    run dolt push --max-bandwidth 0 origin main:main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "must be greater than 0" ]] || false
+    dolt push --max-bandwidth 100KB origin main:main
+    dolt fetch --max-bandwidth 100KB origin
+    dolt pull --max-bandwidth 100KB origin main
+
+    stop_remotesrv
+    cd ../../remote
+    dolt reset --hard
+    run dolt log --oneline -n 1
+    [[ "$output" =~ "update a column of some rows" ]] || false
+    run dolt sql -q 'select sum(c3) from wide;' -r csv
+    [[ "$output" =~ "2001020" ]] || false
+}
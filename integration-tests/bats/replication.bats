@@ -712,6 +712,54 @@ SQL
     [[ "$output" =~ "tag2" ]] || false
 }
 
+@test "replication: tags are not pushed when dolt_replicate_tags is disabled" {
+    cd repo1
+    dolt config --local --add sqlserver.global.dolt_replicate_to_remote remote1
+    dolt config --local --add sqlserver.global.dolt_replicate_tags 0
+    dolt sql -q "call dolt_tag('tag1')"
+    dolt sql -q "call dolt_branch('b1')"
+
+    cd ../
+    dolt clone file://./rem1 repo2
+    cd repo2
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/b1" ]] || false
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "tag1" ]] || false
+}
+
+@test "replication: clone --no-tags and delete remote tags" {
+    cd repo1
+    dolt tag v1
+    dolt tag v2
+    dolt push remote1 v1
+    dolt push remote1 v2
+
+    cd ../
+    dolt clone --no-tags file://./rem1 repo2
+    cd repo2
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt tag -d --remote origin v1
+    [ "$status" -eq 0 ]
+
+    cd ../
+    dolt clone file://./rem1 repo3
+    cd repo3
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "v1" ]] || false
+    [[ "$output" =~ "v2" ]] || false
+
+    run dolt tag v3 --remote origin
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "--remote" ]] || false
+}
+
 @test "replication: pull creates remote tracking branches" {
     dolt clone file://./rem1 repo2
     cd repo2
@@ -0,0 +1,69 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "rewrite-manifest: recovers a database with a corrupt manifest" {
+    dolt sql -q "create table t (pk int primary key); insert into t values (1), (2);"
+    dolt commit -Am "added t"
+    dolt branch other
+
+    echo "garbage" > .dolt/noms/manifest
+    run dolt status
+    [ "$status" -ne 0 ]
+
+    run dolt admin rewrite-manifest --dry-run
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "(chunk journal)" ]] || false
+    [[ "$output" =~ "last root of the chunk journal" ]] || false
+    run cat .dolt/noms/manifest
+    [[ "$output" =~ "garbage" ]] || false
+
+    run dolt admin rewrite-manifest
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Wrote a new manifest." ]] || false
+    [[ "$output" =~ "refs/heads/main" ]] || false
+    [[ "$output" =~ "refs/heads/other" ]] || false
+    run cat .dolt/noms/manifest.corrupt.*
+    [[ "$output" =~ "garbage" ]] || false
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    run dolt log other --oneline
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added t" ]] || false
+}
+
+@test "rewrite-manifest: recovers a database with a missing manifest" {
+    dolt sql -q "create table t (pk int primary key); insert into t values (1);"
+    dolt commit -Am "added t"
+
+    rm .dolt/noms/manifest
+    run dolt admin rewrite-manifest
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "refs/heads/main" ]] || false
+
+    run dolt sql -q "select count(*) from t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "rewrite-manifest: root must be the last root of the chunk journal" {
+    dolt commit --allow-empty -m "empty"
+
+    run dolt admin rewrite-manifest --root 0123456789abcdefghijklmnopqrstuv
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "chunk journal sets its root" ]] || false
+
+    run dolt admin rewrite-manifest --root notahash
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid root hash" ]] || false
+}
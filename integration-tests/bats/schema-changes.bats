@@ -452,4 +452,42 @@ EOF
   # After all these schema changes, the table hash remains the same.
   run dolt sql -r csv -q "select DOLT_HASHOF_TABLE('t') = (select DOLT_HASHOF_TABLE('t') from t as of HEAD);"
   [[ "$output" =~ "true" ]] || false
-}
\ No newline at end of file
+}
+@test "schema-changes: dolt schema show includes constraints and can omit unchanged tables" {
+    dolt sql <<SQL
+create table parent (id int primary key);
+create table child (id int primary key, pid int, v int, key (v), constraint fk_pid foreign key (pid) references parent (id), constraint chk_v check (v > 0));
+SQL
+    dolt commit -Am "create tables"
+
+    run dolt schema show child
+    [ $status -eq 0 ]
+    [[ "$output" =~ "CONSTRAINT \`fk_pid\` FOREIGN KEY (\`pid\`) REFERENCES \`parent\` (\`id\`)" ]] || false
+    [[ "$output" =~ "CONSTRAINT \`chk_v\` CHECK" ]] || false
+
+    # nothing has changed since HEAD
+    run dolt schema show --ddl-only-changes
+    [ $status -eq 0 ]
+    [[ ! "$output" =~ "child @ working" ]] || false
+    [[ ! "$output" =~ "test @ working" ]] || false
+
+    dolt sql -q "alter table child add constraint chk_pid check (pid is not null)"
+    run dolt schema show --ddl-only-changes
+    [ $status -eq 0 ]
+    [[ "$output" =~ "child @ working" ]] || false
+    [[ "$output" =~ "chk_pid" ]] || false
+    [[ ! "$output" =~ "parent @ working" ]] || false
+    [[ ! "$output" =~ "test @ working" ]] || false
+
+    # commits are compared with their parent
+    dolt commit -am "add check"
+    run dolt schema show --ddl-only-changes HEAD
+    [ $status -eq 0 ]
+    [[ "$output" =~ "child @ HEAD" ]] || false
+    [[ ! "$output" =~ "parent @ HEAD" ]] || false
+
+    run dolt schema show --ddl-only-changes HEAD~1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "child @ HEAD~1" ]] || false
+    [[ "$output" =~ "parent @ HEAD~1" ]] || false
+}
@@ -341,4 +341,33 @@ EOF
     [[ "$output" =~ "SerialMessage" ]] || false
     [[ "$output" =~ "{ key: 73000000, e6000000 ref: #pdcuscnfqsusgil1642k5hup1cp5co6t }" ]] || false
     [[ "$output" =~ "{ key: f4090000, e8130000 ref: #hddhk8djkj275q1so9fs3ag48v7qsfsi }" ]] || false
-}
\ No newline at end of file
+}
+@test "show: table at a revision" {
+    dolt sql -q "create table test (pk int primary key, c1 varchar(20))"
+    dolt sql -q "insert into test values (1, 'one'), (2, 'two')"
+    dolt commit -Am "create test"
+    dolt sql -q "insert into test values (3, 'three')"
+    dolt commit -am "add three"
+
+    run dolt show HEAD~1:test
+    [ $status -eq 0 ]
+    [[ "$output" =~ "table test at" ]] || false
+    [[ "$output" =~ "CREATE TABLE \`test\`" ]] || false
+    [[ "$output" =~ "2 rows" ]] || false
+    [[ "$output" =~ "two" ]] || false
+    ! [[ "$output" =~ "three" ]] || false
+
+    run dolt show main:test --limit 1
+    [ $status -eq 0 ]
+    [[ "$output" =~ "3 rows" ]] || false
+    [[ "$output" =~ "showing the first 1 rows" ]] || false
+    [[ "$output" =~ "one" ]] || false
+    ! [[ "$output" =~ "two" ]] || false
+
+    run dolt show HEAD:missing
+    [ $status -ne 0 ]
+
+    run dolt show nobranch:test
+    [ $status -ne 0 ]
+    [[ "$output" =~ "revision not found: nobranch" ]] || false
+}
@@ -0,0 +1,79 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_no_dolt_init
+    mkdir repo
+    cd repo
+    dolt init
+    dolt sql -q "create table t (pk int primary key, v varchar(10));"
+    dolt sql -q "insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "first commit"
+    dolt tag v1
+    dolt sql -q "insert into t values (3, 'three');"
+    dolt commit -am "second commit"
+    dolt branch other HEAD~1
+    dolt remote add pushed 'file://../pushed'
+    dolt push pushed main
+    dolt push pushed other
+    dolt push pushed v1
+    dolt sql -q "insert into t values (4, 'four');"
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "sql-attach-remote: query a remote database without cloning it" {
+    run dolt sql -r csv -q "call dolt_attach_remote('file://../pushed', 'rdb'); select count(*) from rdb.t;"
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" = "3" ]] || false
+
+    # nothing was cloned
+    [ ! -d rdb ]
+    [ ! -d ../rdb ]
+}
+
+@test "sql-attach-remote: the database name defaults to the last element of the url" {
+    run dolt sql -r csv -q "call dolt_attach_remote('file://../pushed'); select count(*) from pushed.t;"
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" = "3" ]] || false
+}
+
+@test "sql-attach-remote: read branches, tags and past commits" {
+    run dolt sql -r csv -q "call dolt_attach_remote('file://../pushed', 'rdb'); select count(*) from \`rdb/other\`.t; select count(*) from \`rdb/v1\`.t; select count(*) from rdb.t as of 'HEAD~1';"
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" = "2" ]] || false
+    [[ "${lines[5]}" = "2" ]] || false
+    [[ "${lines[7]}" = "2" ]] || false
+}
+
+@test "sql-attach-remote: attached remotes are read-only" {
+    run dolt sql -q "call dolt_attach_remote('file://../pushed', 'rdb'); insert into rdb.t values (5, 'five');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "read only" ]] || [[ "$output" =~ "read-only" ]] || false
+
+    run dolt sql -q "call dolt_attach_remote('file://../pushed', 'rdb'); use rdb; create table t2 (pk int primary key);"
+    [ "$status" -ne 0 ]
+}
+
+@test "sql-attach-remote: drop database detaches the remote" {
+    run dolt sql -q "call dolt_attach_remote('file://../pushed', 'rdb'); drop database rdb; show databases;"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "rdb" ]] || false
+
+    # the remote itself is unchanged
+    run dolt sql -r csv -q "call dolt_attach_remote('file://../pushed', 'rdb'); select count(*) from rdb.t;"
+    [ "$status" -eq 0 ]
+    [[ "${lines[3]}" = "3" ]] || false
+}
+
+@test "sql-attach-remote: errors" {
+    run dolt sql -q "call dolt_attach_remote('file://../pushed', 'repo');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "database exists" ]] || false
+
+    run dolt sql -q "call dolt_attach_remote('file://../pushed', 'rdb/main');"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid database name" ]] || false
+}
@@ -109,3 +109,40 @@ teardown() {
     [[ "$output" =~ "invalid syntax" ]] || false
     [[ "$output" =~ "151" ]] || false
 }
+
+@test "sql-config: persisted sql_mode applies to statements run with cli engine" {
+    echo '{"sqlserver.global.sql_mode":"ANSI_QUOTES"}' > .dolt/config.json
+    run dolt sql -q 'CREATE TABLE "quoted" ("pk" int primary key, "v" varchar(10))'
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q 'SELECT "pk", "v" FROM "quoted"' -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" = "pk,v" ]] || false
+}
+
+@test "sql-config: session sql_mode applies to later statements with cli engine" {
+    run dolt sql <<'SQL'
+SET sql_mode = 'ANSI_QUOTES,PIPES_AS_CONCAT';
+CREATE TABLE "quoted" ("pk" int primary key, "v" varchar(10));
+INSERT INTO "quoted" VALUES (1, 'a' || 'b');
+SQL
+    [ "$status" -eq 0 ]
+
+    run dolt sql -q "SELECT v FROM quoted" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "ab" ]] || false
+}
+
+@test "sql-config: non-strict sql_mode truncates out of range values with cli engine" {
+    dolt sql -q "CREATE TABLE t (pk int primary key, v varchar(3))"
+    run dolt sql -q "INSERT INTO t VALUES (1, 'abcdef')"
+    [ "$status" -eq 1 ]
+
+    dolt sql <<'SQL'
+SET sql_mode = '';
+INSERT INTO t VALUES (1, 'abcdef');
+SQL
+    run dolt sql -q "SELECT v FROM t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "${lines[1]}" = "abc" ]] || false
+}
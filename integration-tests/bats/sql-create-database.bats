@@ -369,4 +369,44 @@ SQL
     run dolt sql -q "show create database tmpdb"
     [ "$status" -eq 0 ]
     [[ "$output" =~ "DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_spanish_ci" ]] || false
-}
\ No newline at end of file
+}
+@test "sql-create-database: dolt_database_template is applied to new databases" {
+    mkdir -p "$BATS_TMPDIR/template-$$/hooks"
+    echo '{"sqlserver.global.dolt_transaction_commit": "1"}' > "$BATS_TMPDIR/template-$$/config.json"
+    echo "create table t (pk int primary key);" > "$BATS_TMPDIR/template-$$/schema.sql"
+    echo "insert into t values (1), (2);" > "$BATS_TMPDIR/template-$$/hooks/seed.sql"
+
+    run dolt sql << SQL
+SET @@GLOBAL.dolt_database_template = '$BATS_TMPDIR/template-$$';
+CREATE DATABASE tmpldb;
+USE tmpldb;
+SELECT COUNT(*) AS c FROM t;
+SELECT message FROM dolt_log LIMIT 1;
+SQL
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "| 2 " ]] || false
+    [[ "$output" =~ "Apply template template-$$" ]] || false
+
+    cd tmpldb
+    run dolt config --local --get sqlserver.global.dolt_transaction_commit
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    cd ..
+
+    # databases created without the variable set aren't templated
+    run dolt sql -q "CREATE DATABASE plaindb; USE plaindb; SHOW TABLES;"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "| t " ]] || false
+
+    # a broken template fails CREATE DATABASE, but leaves the database behind
+    echo "insert into missing values (1);" > "$BATS_TMPDIR/template-$$/hooks/seed.sql"
+    run dolt sql << SQL
+SET @@GLOBAL.dolt_database_template = '$BATS_TMPDIR/template-$$';
+CREATE DATABASE brokendb;
+SQL
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "error applying template" ]] || false
+    [[ "$output" =~ "missing" ]] || false
+
+    rm -rf "$BATS_TMPDIR/template-$$"
+}
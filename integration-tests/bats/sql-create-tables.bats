@@ -791,3 +791,26 @@ SQL
     [[ "$output" =~ "2,2" ]] || false
 
 }
+
+@test "sql-create-tables: dolt_transactional_ddl rolls back schema changes" {
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY)"
+    run dolt sql <<SQL
+SET dolt_transactional_ddl = 1;
+START TRANSACTION;
+CREATE TABLE t2 (pk int PRIMARY KEY);
+ALTER TABLE t ADD COLUMN c int;
+DROP TABLE t2;
+CREATE TABLE t3 (pk int PRIMARY KEY);
+ROLLBACK;
+SQL
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "SHOW TABLES"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t" ]] || false
+    [[ ! "$output" =~ "t3" ]] || false
+
+    run dolt sql -r csv -q "SELECT COUNT(*) FROM information_schema.columns WHERE TABLE_NAME = 't'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
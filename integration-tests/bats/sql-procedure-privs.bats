@@ -67,6 +67,7 @@ mike_blocked_check() {
     # Execute privs on a DB does not grant admin procedure privs
     dolt sql -q "GRANT EXECUTE ON mydb.* TO mike@localhost"
 
+    mike_blocked_check "dolt_attach_remote('file:///myDatabasesDir/database/.dolt/noms')"
     mike_blocked_check "dolt_backup('sync','foo')"
     mike_blocked_check "dolt_clone('file:///myDatabasesDir/database/.dolt/noms')"
     mike_blocked_check "dolt_fetch('origin')"
@@ -154,5 +155,27 @@ SQL
   dolt -u neil -p pwd sql -q "call user_proc()"
 }
 
+@test "sql-procedure-privs: committing as another committer requires SUPER" {
+  dolt sql -q "GRANT ALL ON mydb.* TO mike@localhost"
+  cd mydb
+
+  # any user may name another author, and is recorded as the committer
+  dolt -u mike -p pwd sql -q "set @@dolt_commit_author = 'Jane Doe <jane@example.com>'; call dolt_commit('--allow-empty', '-m', 'for jane')"
+  run dolt sql -r csv -q "select committer, committed_by from dolt_log('--show-committer') limit 1"
+  [ $status -eq 0 ]
+  [[ $output =~ "Jane Doe,mike" ]] || false
+
+  run dolt -u mike -p pwd sql -q "set @@dolt_commit_committer = 'Jane Doe <jane@example.com>'; call dolt_commit('--allow-empty', '-m', 'as jane')"
+  [ $status -eq 1 ]
+  [[ $output =~ "command denied to user 'mike'" ]] || false
+  [[ $output =~ "dolt_commit_committer requires the SUPER privilege" ]] || false
+
+  dolt sql -q "GRANT SUPER ON *.* TO mike@localhost"
+  dolt -u mike -p pwd sql -q "set @@dolt_commit_committer = 'Jane Doe <jane@example.com>'; call dolt_commit('--allow-empty', '-m', 'as jane')"
+  run dolt sql -r csv -q "select committer, committed_by, committed_by_email from dolt_log('--show-committer') limit 1"
+  [ $status -eq 0 ]
+  [[ $output =~ "mike,Jane Doe,jane@example.com" ]] || false
+}
+
 # TODO - Alter Routine Grants can be created and revoked (tested in enginetests), but we can't
 # actually alter any routines in a meaningful way, so until we can there is nothing to test.
@@ -101,6 +101,45 @@ call dolt_commit('-m', 'add some vals');
     [[ "$status" != 0 ]] || false
 }
 
+@test "sql-server-remotesrv: remotesapi max_bandwidth limits transfers" {
+    mkdir -p db/remote
+    cd db/remote
+    dolt init
+    dolt sql -q 'create table vals (i int primary key, s varchar(200));'
+    dolt sql -q "insert into vals with recursive n (i) as (select 1 union all select i+1 from n where i < 2000) select i, repeat(md5(i), 6) from n;"
+    dolt commit -Am 'initial vals.'
+
+    cat > ../config.yaml <<EOF
+remotesapi:
+  port: 50051
+  max_bandwidth: 25KB
+EOF
+    dolt sql-server --config ../config.yaml &
+    srv_pid=$!
+
+    cd ../../
+    SECONDS=0
+    dolt clone http://localhost:50051/remote repo1
+    [ "$SECONDS" -ge 2 ]
+    cd repo1
+    run dolt sql -q 'select count(*) from vals'
+    [[ "$output" =~ "2000" ]] || false
+}
+
+@test "sql-server-remotesrv: invalid remotesapi max_bandwidth stops process" {
+    mkdir -p db/remote
+    cd db/remote
+    dolt init
+    cat > ../config.yaml <<EOF
+remotesapi:
+  port: 50051
+  max_bandwidth: fast
+EOF
+    run dolt sql-server --config ../config.yaml
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid bandwidth 'fast'" ]] || false
+}
+
 @test "sql-server-remotesrv: a read replica can replicate from a remotesapi running in sql-server" {
     # Set up our primary sql-server which accepts writes.
     mkdir -p primary/db
@@ -696,3 +735,35 @@ GRANT CLONE_ADMIN ON *.* TO clone_admin_user@'localhost';
     [[ "$output" =~ "main" ]] || false
 }
 
+
+@test "sql-server-remotesrv: dolt_remote_fetch_budget limits what queries fetch from attached remotes" {
+    mkdir -p db/remote
+    cd db/remote
+    dolt init
+    dolt sql -q 'create table vals (i int primary key, s varchar(100));'
+    dolt sql -q "insert into vals with recursive n(i) as (select 1 union all select i + 1 from n where i < 5000) select i, repeat('x', 100) from n;"
+    dolt commit -Am 'initial vals.'
+
+    dolt sql-server --remotesapi-port 50051 &
+    srv_pid=$!
+    cd ../..
+
+    # By cloning here, we have a near-at-hand way to wait for the server to be ready.
+    dolt clone http://localhost:50051/remote cloned_remote
+
+    mkdir attacher && cd attacher
+    start_sql_server
+
+    run dolt --port $PORT --host 0.0.0.0 --no-tls sql -q "
+call dolt_attach_remote('http://localhost:50051/remote', 'rdb');
+set dolt_remote_fetch_budget = 1024;
+select count(*) from rdb.vals;"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "fetched more than dolt_remote_fetch_budget = 1024 bytes from remote databases" ]] || false
+
+    run dolt --port $PORT --host 0.0.0.0 --no-tls sql -r csv -q "
+call dolt_attach_remote('http://localhost:50051/remote', 'rdb2');
+select count(*) from rdb2.vals;"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "5000" ]] || false
+}
@@ -2115,3 +2115,152 @@ EOF
     [[ "$output" =~ "br3  | true" ]] || false
     [[ "$output" =~ "main | false" ]] || false
 }
+
+@test "sql-server: audit log records connections and queries to a file" {
+    cd repo1
+    echo "
+audit_log:
+  target: file
+  path: audit.log
+  queries: dml" > server.yaml
+    start_sql_server_with_config repo1 server.yaml
+
+    dolt --use-db repo1 sql -q "CREATE TABLE audited (pk int primary key)"
+    dolt --use-db repo1 sql -q "INSERT INTO audited VALUES (1)"
+    dolt --use-db repo1 sql -q "SELECT * FROM audited"
+    stop_sql_server 1 && sleep 0.5
+
+    run cat audit.log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"event":"connect"' ]] || false
+    [[ "$output" =~ '"event":"disconnect"' ]] || false
+    [[ "$output" =~ '"database":"repo1","branch":"main","kind":"ddl","query":"CREATE TABLE audited (pk int primary key)","tables":["audited"]' ]] || false
+    [[ "$output" =~ '"kind":"dml","query":"INSERT INTO audited VALUES (1)","tables":["audited"]' ]] || false
+    [[ ! "$output" =~ "SELECT * FROM audited" ]] || false
+}
+
+@test "sql-server: audit log table target is queryable through dolt_audit_log" {
+    cd repo1
+    echo "
+audit_log:
+  target: table
+  queries: ddl" > server.yaml
+    start_sql_server_with_config repo1 server.yaml
+
+    dolt --use-db repo1 sql -q "CREATE TABLE audited (pk int primary key)"
+    dolt --use-db repo1 sql -q "INSERT INTO audited VALUES (1)"
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT branch, kind, query, tables FROM dolt_audit_log WHERE event = 'query'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "main,ddl,CREATE TABLE audited (pk int primary key),audited" ]] || false
+    [[ ! "$output" =~ "INSERT INTO audited" ]] || false
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT DISTINCT event FROM dolt_audit_log WHERE event = 'connect'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "connect" ]] || false
+}
+
+@test "sql-server: query limits from user session vars interrupt long and large queries" {
+    cd repo1
+    dolt sql -q "CREATE TABLE limited (pk int primary key); INSERT INTO limited VALUES (1), (2), (3);"
+    echo "
+privilege_file: privs.json
+user_session_vars:
+- name: fewrows
+  vars:
+    dolt_max_result_rows: 2
+- name: quick
+  vars:
+    max_execution_time: 100" > server.yaml
+
+    dolt --privilege-file=privs.json sql -q "CREATE USER dolt@'127.0.0.1'"
+    dolt --privilege-file=privs.json sql -q "CREATE USER fewrows@'127.0.0.1' IDENTIFIED BY 'pass'; GRANT ALL ON *.* TO fewrows@'127.0.0.1'"
+    dolt --privilege-file=privs.json sql -q "CREATE USER quick@'127.0.0.1' IDENTIFIED BY 'pass'; GRANT ALL ON *.* TO quick@'127.0.0.1'"
+
+    SQL_USER=dolt
+    start_sql_server_with_config "" server.yaml
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=fewrows --password=pass --use-db repo1 sql -q "SELECT * FROM limited"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "returned more than dolt_max_result_rows = 2 rows" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=fewrows --password=pass --use-db repo1 sql -r csv -q "SELECT * FROM limited WHERE pk < 3"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=quick --password=pass --use-db repo1 sql -q "SELECT sleep(5)"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "maximum statement execution time exceeded" ]] || false
+
+    # Writes aren't timed, and the connection survives an interrupted query
+    run dolt --host=127.0.0.1 --port=$PORT --no-tls --user=quick --password=pass --use-db repo1 sql -r csv -q "INSERT INTO limited VALUES (4); SELECT count(*) FROM limited"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+}
+
+@test "sql-server: query scheduler times out statements waiting behind a busy database" {
+    cd repo1
+    echo "
+query_scheduler:
+  max_concurrent_queries: 1
+  queue_timeout_millis: 500
+  databases:
+  - name: repo1
+    weight: 2" > server.yaml
+    start_sql_server_with_config repo1 server.yaml
+
+    dolt --use-db repo1 sql -q "SELECT sleep(5)" &
+    sleep 2
+
+    run dolt --use-db repo1 sql -q "SELECT 1"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "Too many concurrent queries" ]] || false
+
+    wait
+    run dolt --use-db repo1 sql -r csv -q "SELECT 1 AS ok"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "ok" ]] || false
+}
+
+@test "sql-server: profiles are captured to dolt_profile_dir while dolt_profile_interval is set" {
+    cd repo1
+    start_sql_server repo1
+    PROFILE_DIR="$(pwd)/profiles"
+
+    dolt --use-db repo1 sql -q "SET @@GLOBAL.dolt_profile_dir = '$PROFILE_DIR'; SET @@GLOBAL.dolt_profile_types = 'heap,goroutine'"
+    sleep 2
+    [ ! -d "$PROFILE_DIR" ]
+
+    dolt --use-db repo1 sql -q "SET @@GLOBAL.dolt_profile_interval = 3600"
+    sleep 3
+    run ls "$PROFILE_DIR"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ heap-.*\.pb\.gz ]] || false
+    [[ "$output" =~ goroutine-.*\.pb\.gz ]] || false
+    [[ ! "$output" =~ cpu- ]] || false
+}
+
+@test "sql-server: dolt_query_log records statements and dolt_query_summary aggregates them by digest" {
+    cd repo1
+    start_sql_server repo1
+
+    dolt --use-db repo1 sql -q "CREATE TABLE profiled (pk int primary key, c int)"
+    dolt --use-db repo1 sql -q "INSERT INTO profiled VALUES (1, 1), (2, 2), (3, 3)"
+
+    # nothing is recorded until the query log is enabled
+    dolt --use-db repo1 sql -q "SET @@GLOBAL.dolt_query_log = 1"
+    dolt --use-db repo1 sql -q "SELECT * FROM profiled WHERE c = 1"
+    dolt --use-db repo1 sql -q "select * from profiled where c = 2"
+    dolt --use-db repo1 sql -q "UPDATE profiled SET c = 4 WHERE pk = 3"
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT digest_text, count, rows_affected, rows_sent, rows_examined FROM dolt_query_summary WHERE digest_text LIKE '%profiled%' ORDER BY digest_text"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "select * from profiled where c = ?,2,0,2,6" ]] || false
+    [[ "$output" =~ "update profiled set c = ? where pk = ?,1,1,0," ]] || false
+    [[ ! "$output" =~ "insert" ]] || false
+
+    run dolt --use-db repo1 sql -r csv -q "SELECT query FROM dolt_query_log WHERE query LIKE '%profiled%'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "SELECT * FROM profiled WHERE c = 1" ]] || false
+    [[ "$output" =~ "select * from profiled where c = 2" ]] || false
+}
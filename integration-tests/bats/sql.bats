@@ -2759,6 +2759,27 @@ SQL
     [ "$status" -eq 1 ]
 }
 
+@test "sql: --file stops and rolls back on a failed dolt_assert" {
+    dolt sql -q "create table test (a int primary key, b int); insert into test values (1,1), (2,2);"
+
+    cat > script.sql <<SQL
+    start transaction;
+    insert into test values (3,3);
+    call dolt_assert_query_empty('select * from test where b > 2', 'b must stay small');
+    insert into test values (4,4);
+    commit;
+SQL
+
+    run dolt sql --file script.sql
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "dolt_assert_query_empty failed: b must stay small" ]] || false
+
+    run dolt sql -q "select count(*) from test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    ! [[ "$output" =~ "3" ]] || false
+}
+
 @test "sql: server with no dbs yet should be able to describe dolt stored procedures" {
     # make directories outside of the existing init'ed dolt repos
     tempDir=$(mktemp -d)
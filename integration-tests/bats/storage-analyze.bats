@@ -0,0 +1,46 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "storage-analyze: attributes storage to tables and indexes" {
+    dolt sql -q "create table t (pk int primary key, v varchar(20), key v_idx (v)); insert into t values (1, 'one'), (2, 'two');"
+    dolt sql -q "create table u (pk int primary key); insert into u values (1);"
+    dolt commit -Am "created tables"
+    dolt sql -q "drop table u;"
+
+    run dolt admin storage analyze
+    [ "$status" -eq 0 ]
+    [[ "${lines[0]}" =~ "Table" ]] || false
+    [[ "${lines[0]}" =~ "Reclaimable" ]] || false
+    [[ "$output" =~ "t      PRIMARY" ]] || false
+    [[ "$output" =~ "t      v_idx" ]] || false
+    [[ "$output" =~ "u      PRIMARY" ]] || false
+    [[ "$output" =~ "Total" ]] || false
+
+    run dolt sql -q "select table_name, index_name, bytes > 0 as in_use, history_bytes > 0 as in_history from dolt_table_sizes where table_name = 'u'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "u,PRIMARY,false,true" ]] || false
+
+    run dolt admin storage unknown
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unknown argument: unknown" ]] || false
+}
+
+@test "storage-analyze: versions only in the reflog are reclaimable" {
+    dolt sql -q "create table t (pk int primary key, v varchar(20)); insert into t values (1, 'one'), (2, 'two');"
+    dolt commit -Am "created t"
+    dolt sql -q "update t set v = 'uno' where pk = 1;"
+    dolt reset --hard
+
+    run dolt sql -q "select count(*) from dolt_table_sizes where table_name = 't' and reclaimable_bytes > 0" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "1" ]
+}
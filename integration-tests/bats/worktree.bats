@@ -0,0 +1,142 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+
+    dolt sql -q "CREATE TABLE test(pk BIGINT PRIMARY KEY, v varchar(10))"
+    dolt commit -Am "Created table"
+    dolt branch feature
+
+    WORKTREE_DIR="$BATS_TMPDIR/dolt-worktree-$$"
+    rm -rf "$WORKTREE_DIR"
+}
+
+teardown() {
+    assert_feature_version
+    rm -rf "$WORKTREE_DIR"
+    teardown_common
+}
+
+@test "worktree: add checks out branch in new directory" {
+    run dolt worktree add "$WORKTREE_DIR" feature
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "with branch 'feature' checked out" ]] || false
+
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "dolt-worktree-$$" ]] || false
+    [[ "$output" =~ "[feature]" ]] || false
+
+    cd "$WORKTREE_DIR"
+    run dolt branch --show-current
+    [ "$status" -eq 0 ]
+    [ "$output" = "feature" ]
+
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test" ]] || false
+}
+
+@test "worktree: working sets are independent and commits are shared" {
+    dolt worktree add "$WORKTREE_DIR" feature
+
+    cd "$WORKTREE_DIR"
+    dolt sql -q "INSERT INTO test VALUES (1, 'a')"
+
+    cd -
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "nothing to commit" ]] || false
+
+    cd "$WORKTREE_DIR"
+    dolt commit -am "added row on feature"
+
+    cd -
+    run dolt log feature -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added row on feature" ]] || false
+}
+
+@test "worktree: cannot check out a branch twice" {
+    run dolt worktree add "$WORKTREE_DIR" main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already checked out" ]] || false
+
+    dolt worktree add "$WORKTREE_DIR" feature
+    run dolt worktree add "$WORKTREE_DIR-2" feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already checked out" ]] || false
+}
+
+@test "worktree: add fails for missing branch" {
+    run dolt worktree add "$WORKTREE_DIR" doesnotexist
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "branch 'doesnotexist' not found" ]] || false
+}
+
+@test "worktree: remove keeps shared storage intact" {
+    dolt worktree add "$WORKTREE_DIR" feature
+
+    run dolt worktree remove "$WORKTREE_DIR"
+    [ "$status" -eq 0 ]
+    [ ! -d "$WORKTREE_DIR/.dolt" ]
+
+    run dolt worktree list
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+
+    run dolt worktree add "$WORKTREE_DIR" feature
+    [ "$status" -eq 0 ]
+}
+
+@test "worktree: cannot check out or delete a branch held by another worktree" {
+    dolt worktree add "$WORKTREE_DIR" feature
+
+    run dolt checkout feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already checked out" ]] || false
+
+    run dolt branch -D feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already checked out" ]] || false
+
+    cd "$WORKTREE_DIR"
+    run dolt checkout main
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "already checked out" ]] || false
+
+    cd -
+    dolt worktree remove "$WORKTREE_DIR"
+    dolt branch -D feature
+}
+
+@test "worktree: data directory pointer file is honored" {
+    dolt worktree add "$WORKTREE_DIR" feature
+
+    # replace the symlink with the pointer file used where symlinks are unavailable
+    datadir=$(cd "$WORKTREE_DIR/.dolt/noms" && pwd -P)
+    rm "$WORKTREE_DIR/.dolt/noms"
+    echo "$datadir" > "$WORKTREE_DIR/.dolt/noms.link"
+
+    cd "$WORKTREE_DIR"
+    dolt sql -q "INSERT INTO test VALUES (1, 'a')"
+    dolt commit -am "added row through pointer"
+
+    cd -
+    run dolt log feature -n 1
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added row through pointer" ]] || false
+
+    run dolt worktree remove "$WORKTREE_DIR"
+    [ "$status" -eq 0 ]
+    [ ! -d "$WORKTREE_DIR/.dolt" ]
+    [ -d .dolt/noms ]
+}